@@ -147,18 +147,18 @@ export const api = {
   /** List of registered strategy names (501 in arbitrage-only mode). */
   strategyList: () =>
     fetchJson<{ strategies: string[] }>('/api/strategy/list').then((r) => r.strategies ?? []),
-  /** Set active strategy (POST). Returns 501 if runtime not available. */
-  strategySetActive: async (name: string) => {
+  /** Set the active strategy, or several run concurrently (POST). Returns 501 if runtime not available. */
+  strategySetActive: async (name: string | string[]) => {
     const res = await fetch(`${API_BASE}/api/strategy/active`, {
       method: 'POST',
       headers: { 'Content-Type': 'application/json', Accept: 'application/json' },
-      body: JSON.stringify({ name }),
+      body: JSON.stringify(Array.isArray(name) ? { names: name } : { name }),
     })
     if (!res.ok) {
       const text = await res.text()
       throw new Error(res.status === 501 ? 'Strategy switching not available' : text || `HTTP ${res.status}`)
     }
-    return res.json() as Promise<{ active: string }>
+    return res.json() as Promise<{ active: string; strategies?: string[] }>
   },
   strategyCandidates: (limit = 20, source?: string) => {
     const params = new URLSearchParams()
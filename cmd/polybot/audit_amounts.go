@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/app"
+	"github.com/alanyoungcy/polymarketbot/internal/config"
+	"github.com/alanyoungcy/polymarketbot/internal/service"
+)
+
+// runAuditAmounts implements "polybot audit-amounts". It exits 0 when no
+// discrepancies are found, 2 when some are, and 1 on error.
+func runAuditAmounts(args []string) int {
+	fs := flag.NewFlagSet("audit-amounts", flag.ExitOnError)
+	configPath := fs.String("config", "config.toml", "path to configuration file")
+	fromStr := fs.String("from", "", "start of the range, RFC3339 (default: 24h before -to)")
+	toStr := fs.String("to", "", "end of the range, RFC3339 (default: now)")
+	tolerance := fs.Int64("tolerance", 0, "largest difference in base units (1e-6) not reported")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	_ = fs.Parse(args)
+
+	// Logs go to stderr so the report on stdout stays machine-readable.
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+	slog.SetDefault(logger)
+
+	to := time.Now().UTC()
+	if *toStr != "" {
+		t, err := time.Parse(time.RFC3339, *toStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "audit-amounts: invalid -to: %v\n", err)
+			return 1
+		}
+		to = t
+	}
+	from := to.Add(-24 * time.Hour)
+	if *fromStr != "" {
+		t, err := time.Parse(time.RFC3339, *fromStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "audit-amounts: invalid -from: %v\n", err)
+			return 1
+		}
+		from = t
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit-amounts: load config %s: %v\n", *configPath, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	application := app.New(cfg, logger)
+	defer application.Close()
+
+	rep, err := application.AuditAmounts(ctx, from, to, *tolerance)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit-amounts: %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			fmt.Fprintf(os.Stderr, "audit-amounts: encode report: %v\n", err)
+			return 1
+		}
+	} else {
+		printAmountAudit(rep)
+	}
+	if len(rep.Discrepancies) > 0 {
+		return 2
+	}
+	return 0
+}
+
+// printAmountAudit writes the report as a summary line and a table.
+func printAmountAudit(rep service.AmountAuditReport) {
+	fmt.Printf("amount audit %s .. %s: %d orders (%d compared with CLOB, %d fetch errors), %d positions, %d discrepancies\n",
+		rep.From.Format(time.RFC3339), rep.To.Format(time.RFC3339),
+		rep.Orders, rep.OrdersOnCLOB, rep.FetchErrors, rep.Positions, len(rep.Discrepancies))
+	if len(rep.Discrepancies) == 0 {
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tID\tFIELD\tSOURCE\tSTORED\tEXPECTED\tDIFF_UNITS")
+	for _, d := range rep.Discrepancies {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			d.Kind, d.ID, d.Field, d.Source, d.Stored, d.Expected, d.DiffUnits)
+	}
+	_ = tw.Flush()
+}
@@ -1,6 +1,15 @@
 // Command polybot is the backend entry point for the polymarket bot. It loads
 // configuration, validates it, wires dependencies, sets up signal handling, and
-// starts the application in the configured mode.
+// starts the application in the configured mode. SIGHUP reloads the
+// configuration file.
+//
+// One-off maintenance commands run as subcommands instead:
+//
+//	polybot audit-amounts -from 2025-01-01T00:00:00Z -to 2025-02-01T00:00:00Z
+//	polybot migrate status
+//	polybot migrate up
+//	polybot migrate down -steps 1
+//	polybot replay-signals -from 2025-01-01T00:00:00Z -strategy bond -risk
 package main
 
 import (
@@ -17,6 +26,16 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "audit-amounts" {
+		os.Exit(runAuditAmounts(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay-signals" {
+		os.Exit(runReplaySignals(os.Args[2:]))
+	}
+
 	configPath := flag.String("config", "config.toml", "path to configuration file")
 	flag.Parse()
 
@@ -67,7 +86,7 @@ func main() {
 	)
 
 	// Create the application.
-	application := app.New(cfg, logger)
+	application := app.New(cfg, logger).WithConfigPath(*configPath)
 	defer application.Close()
 
 	// Setup signal handling for graceful shutdown.
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/app"
+	"github.com/alanyoungcy/polymarketbot/internal/config"
+	"github.com/alanyoungcy/polymarketbot/internal/store/postgres"
+)
+
+// runMigrate implements "polybot migrate status|up|down". status exits 2
+// when migrations are pending or an applied one changed; every command exits
+// 1 on error.
+func runMigrate(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(os.Stderr, "usage: polybot migrate status|up|down [-config path] [-steps n]")
+		return 1
+	}
+	cmd := args[0]
+
+	fs := flag.NewFlagSet("migrate "+cmd, flag.ExitOnError)
+	configPath := fs.String("config", "config.toml", "path to configuration file")
+	steps := fs.Int("steps", 1, "number of migrations to roll back (down only)")
+	_ = fs.Parse(args[1:])
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+	slog.SetDefault(logger)
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: load config %s: %v\n", *configPath, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	application := app.New(cfg, logger)
+	defer application.Close()
+
+	switch cmd {
+	case "status":
+		status, err := application.MigrationStatus(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+			return 1
+		}
+		if printMigrationStatus(status) {
+			return 2
+		}
+		return 0
+	case "up":
+		if err := application.Migrate(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+			return 1
+		}
+		fmt.Println("migrations up to date")
+		return 0
+	case "down":
+		reverted, err := application.RollbackMigrations(ctx, *steps)
+		for _, name := range reverted {
+			fmt.Printf("rolled back %s\n", name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+			return 1
+		}
+		if len(reverted) == 0 {
+			fmt.Println("no applied migrations to roll back")
+		}
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "migrate: unknown command %q (want status, up or down)\n", cmd)
+		return 1
+	}
+}
+
+// printMigrationStatus writes the migrations as a table and a summary line,
+// and reports whether any needs attention (pending or modified).
+func printMigrationStatus(status []postgres.MigrationStatus) bool {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATE\tAPPLIED_AT\tROLLBACK")
+	pending, modified := 0, 0
+	for _, m := range status {
+		state, at := "pending", "-"
+		switch {
+		case m.Unknown:
+			state = "applied (not embedded)"
+		case m.Modified:
+			state = "applied (modified)"
+			modified++
+		case m.Applied:
+			state = "applied"
+		default:
+			pending++
+		}
+		if m.Applied {
+			at = m.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		rollback := "no"
+		if m.Reversible {
+			rollback = "yes"
+		}
+		fmt.Fprintf(tw, "%03d\t%s\t%s\t%s\t%s\n", m.Version, m.Name, state, at, rollback)
+	}
+	_ = tw.Flush()
+	fmt.Printf("%d migrations, %d pending, %d modified since applied\n", len(status), pending, modified)
+	return pending > 0 || modified > 0
+}
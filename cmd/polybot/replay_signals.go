@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/app"
+	"github.com/alanyoungcy/polymarketbot/internal/backtest"
+	"github.com/alanyoungcy/polymarketbot/internal/config"
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// runReplaySignals implements "polybot replay-signals". It exits 0 when
+// every comparable signal replays to its recorded outcome, 2 when some do
+// not, and 1 on error.
+func runReplaySignals(args []string) int {
+	fs := flag.NewFlagSet("replay-signals", flag.ExitOnError)
+	configPath := fs.String("config", "config.toml", "path to configuration file")
+	fromStr := fs.String("from", "", "start of the range, RFC3339 (default: 24h before -to)")
+	toStr := fs.String("to", "", "end of the range, RFC3339 (default: now)")
+	strategy := fs.String("strategy", "", "replay only this strategy's signals")
+	market := fs.String("market", "", "replay only this market's signals")
+	limit := fs.Int("limit", 0, "replay at most this many signals, the newest (default 100000)")
+	withRisk := fs.Bool("risk", false, "run the current risk checks instead of accepting every signal")
+	all := fs.Bool("all", false, "list every signal, not only changed ones")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	_ = fs.Parse(args)
+
+	// Logs go to stderr so the report on stdout stays machine-readable.
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+	slog.SetDefault(logger)
+
+	to := time.Now().UTC()
+	if *toStr != "" {
+		t, err := time.Parse(time.RFC3339, *toStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "replay-signals: invalid -to: %v\n", err)
+			return 1
+		}
+		to = t
+	}
+	from := to.Add(-24 * time.Hour)
+	if *fromStr != "" {
+		t, err := time.Parse(time.RFC3339, *fromStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "replay-signals: invalid -from: %v\n", err)
+			return 1
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		fmt.Fprintln(os.Stderr, "replay-signals: -from must be before -to")
+		return 1
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay-signals: load config %s: %v\n", *configPath, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	application := app.New(cfg, logger)
+	defer application.Close()
+
+	rep, err := application.ReplaySignals(ctx, domain.SignalFilter{
+		Strategy: *strategy,
+		MarketID: *market,
+		From:     from,
+		To:       to,
+		Limit:    *limit,
+	}, *withRisk)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay-signals: %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			fmt.Fprintf(os.Stderr, "replay-signals: encode report: %v\n", err)
+			return 1
+		}
+	} else {
+		printSignalReplay(rep, *all)
+	}
+	if rep.Changed > 0 {
+		return 2
+	}
+	return 0
+}
+
+// printSignalReplay writes the status counts and a table of the changed
+// signals, or of every signal with all.
+func printSignalReplay(rep backtest.SignalReplayReport, all bool) {
+	fmt.Printf("signal replay %s .. %s: %d signals, %d changed (%s)\n",
+		rep.From.Format(time.RFC3339), rep.To.Format(time.RFC3339), rep.Signals, rep.Changed, rep.Elapsed)
+	statuses := make([]string, 0, len(rep.Recorded))
+	for s := range rep.Recorded {
+		statuses = append(statuses, s)
+	}
+	for s := range rep.Replayed {
+		if _, ok := rep.Recorded[s]; !ok {
+			statuses = append(statuses, s)
+		}
+	}
+	sort.Strings(statuses)
+	for _, s := range statuses {
+		fmt.Printf("  %-9s recorded %6d  replayed %6d\n", s, rep.Recorded[s], rep.Replayed[s])
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := false
+	for _, r := range rep.Results {
+		if !all && !r.Changed {
+			continue
+		}
+		if !header {
+			fmt.Fprintln(tw, "SIGNAL\tSTRATEGY\tMARKET\tSIDE\tRECORDED\tREPLAYED\tPRICE\tSIZE\tREASON")
+			header = true
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%g\t%g\t%s\n",
+			r.SignalID, r.Strategy, r.MarketID, r.Side, r.Recorded, r.Replayed, r.Price, r.Size, r.Reason)
+	}
+	_ = tw.Flush()
+}
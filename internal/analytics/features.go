@@ -0,0 +1,324 @@
+// Package analytics computes order-flow features of each asset from the
+// market data feed, shared by every strategy through domain.FeatureProvider
+// instead of each strategy deriving its own from raw snapshots.
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// FeatureConfig configures a FeatureTracker.
+type FeatureConfig struct {
+	DepthLevels   int           // book levels per side summed by the depth imbalance
+	Window        time.Duration // lookback of trade flow and realized volatility
+	FlushInterval time.Duration // how often changed features are written to the cache
+}
+
+// samplePoint is a timestamped value in an asset's rolling window: a mid, or
+// a trade's signed taker size (positive for buys).
+type samplePoint struct {
+	at time.Time
+	v  float64
+}
+
+// assetFeatures is the rolling state of one asset.
+type assetFeatures struct {
+	mid        float64
+	microprice float64
+	depthImb   float64
+	mids       []samplePoint
+	flow       []samplePoint
+	trades     []time.Time
+	updatedAt  time.Time
+	dirty      bool // changed since the last flush
+}
+
+// FeatureTracker computes microprice, top-N depth imbalance, trade flow
+// imbalance and realized volatility per asset from book snapshots and trade
+// prints, and writes them to a shared cache every flush interval so other
+// processes see them too. It implements domain.FeatureProvider.
+type FeatureTracker struct {
+	cache  domain.FeatureCache // optional
+	cfg    FeatureConfig
+	logger *slog.Logger
+
+	mu     sync.RWMutex
+	assets map[string]*assetFeatures
+}
+
+// NewFeatureTracker creates a FeatureTracker. cache may be nil; then
+// features are only served in process.
+func NewFeatureTracker(cache domain.FeatureCache, cfg FeatureConfig, logger *slog.Logger) *FeatureTracker {
+	if cfg.DepthLevels <= 0 {
+		cfg.DepthLevels = 5
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 5 * time.Minute
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	return &FeatureTracker{
+		cache:  cache,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "features")),
+		assets: make(map[string]*assetFeatures),
+	}
+}
+
+// OnBook updates an asset's book features from a full snapshot.
+func (t *FeatureTracker) OnBook(snap domain.OrderbookSnapshot) {
+	bids := sortedLevels(snap.Bids, true)
+	asks := sortedLevels(snap.Asks, false)
+	bestBid, bestAsk := snap.BestBid, snap.BestAsk
+	var bidSize, askSize float64
+	if len(bids) > 0 {
+		bestBid, bidSize = bids[0].Price, bids[0].Size
+	}
+	if len(asks) > 0 {
+		bestAsk, askSize = asks[0].Price, asks[0].Size
+	}
+	if bestBid <= 0 || bestAsk <= 0 {
+		return
+	}
+	mid := (bestBid + bestAsk) / 2
+	micro := mid
+	if bidSize+askSize > 0 {
+		micro = (bestBid*askSize + bestAsk*bidSize) / (bidSize + askSize)
+	}
+	var bidDepth, askDepth float64
+	for i := 0; i < t.cfg.DepthLevels && i < len(bids); i++ {
+		bidDepth += bids[i].Size
+	}
+	for i := 0; i < t.cfg.DepthLevels && i < len(asks); i++ {
+		askDepth += asks[i].Size
+	}
+	depthImb := 0.0
+	if bidDepth+askDepth > 0 {
+		depthImb = (bidDepth - askDepth) / (bidDepth + askDepth)
+	}
+	ts := eventTime(snap.Timestamp)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a := t.asset(snap.AssetID)
+	if n := len(a.mids); n == 0 || a.mids[n-1].v != mid {
+		a.mids = append(a.mids, samplePoint{at: ts, v: mid})
+	}
+	a.mid, a.microprice, a.depthImb = mid, micro, depthImb
+	t.touch(a, ts)
+}
+
+// OnTrade counts a trade print toward the asset's trade flow. Prints without
+// a taker side count as trades but not toward the imbalance.
+func (t *FeatureTracker) OnTrade(trade domain.LastTradePrice) {
+	if trade.Size <= 0 {
+		return
+	}
+	signed := 0.0
+	switch trade.Side {
+	case domain.OrderSideBuy:
+		signed = trade.Size
+	case domain.OrderSideSell:
+		signed = -trade.Size
+	}
+	ts := eventTime(trade.Timestamp)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a := t.asset(trade.AssetID)
+	a.trades = append(a.trades, ts)
+	if signed != 0 {
+		a.flow = append(a.flow, samplePoint{at: ts, v: signed})
+	}
+	t.touch(a, ts)
+}
+
+// Features returns the asset's features, from memory when this process
+// tracks the asset and from the cache otherwise.
+func (t *FeatureTracker) Features(ctx context.Context, assetID string) (domain.MarketFeatures, error) {
+	t.mu.RLock()
+	a, ok := t.assets[assetID]
+	var f domain.MarketFeatures
+	if ok {
+		f = t.compute(assetID, a)
+	}
+	t.mu.RUnlock()
+	if ok {
+		return f, nil
+	}
+	if t.cache == nil {
+		return domain.MarketFeatures{}, domain.ErrNotFound
+	}
+	f, err := t.cache.Get(ctx, assetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.MarketFeatures{}, err
+		}
+		return domain.MarketFeatures{}, fmt.Errorf("analytics: features %s: %w", assetID, err)
+	}
+	return f, nil
+}
+
+// Run writes changed features to the cache every flush interval and drops
+// assets without updates for two windows, until ctx is cancelled. Call in a
+// goroutine.
+func (t *FeatureTracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	t.logger.InfoContext(ctx, "feature tracker started",
+		slog.Int("depth_levels", t.cfg.DepthLevels),
+		slog.Duration("window", t.cfg.Window),
+	)
+	defer t.logger.InfoContext(ctx, "feature tracker stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			t.flush(ctx, now)
+		}
+	}
+}
+
+// flush writes the features changed since the last flush and prunes stale
+// assets.
+func (t *FeatureTracker) flush(ctx context.Context, now time.Time) {
+	t.mu.Lock()
+	var changed []domain.MarketFeatures
+	for id, a := range t.assets {
+		if now.Sub(a.updatedAt) > 2*t.cfg.Window {
+			delete(t.assets, id)
+			continue
+		}
+		if a.dirty {
+			a.dirty = false
+			changed = append(changed, t.compute(id, a))
+		}
+	}
+	t.mu.Unlock()
+
+	if t.cache == nil || len(changed) == 0 {
+		return
+	}
+	if err := t.cache.SetBatch(ctx, changed); err != nil {
+		t.logger.WarnContext(ctx, "features: cache write failed",
+			slog.Int("assets", len(changed)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// asset returns the state of assetID, creating it. Called with t.mu held.
+func (t *FeatureTracker) asset(assetID string) *assetFeatures {
+	a, ok := t.assets[assetID]
+	if !ok {
+		a = &assetFeatures{}
+		t.assets[assetID] = a
+	}
+	return a
+}
+
+// touch trims a's windows to the one ending at ts and marks it changed.
+// Called with t.mu held.
+func (t *FeatureTracker) touch(a *assetFeatures, ts time.Time) {
+	if ts.After(a.updatedAt) {
+		a.updatedAt = ts
+	}
+	cutoff := a.updatedAt.Add(-t.cfg.Window)
+	// Keep the last mid before the window so its first return is counted.
+	i := 0
+	for i+1 < len(a.mids) && !a.mids[i+1].at.After(cutoff) {
+		i++
+	}
+	a.mids = a.mids[i:]
+	a.flow = trimPoints(a.flow, cutoff)
+	j := 0
+	for j < len(a.trades) && !a.trades[j].After(cutoff) {
+		j++
+	}
+	a.trades = a.trades[j:]
+	a.dirty = true
+}
+
+// compute derives the features of a. Called with t.mu held.
+func (t *FeatureTracker) compute(assetID string, a *assetFeatures) domain.MarketFeatures {
+	f := domain.MarketFeatures{
+		AssetID:        assetID,
+		Mid:            a.mid,
+		Microprice:     a.microprice,
+		DepthImbalance: a.depthImb,
+		Trades:         len(a.trades),
+		Window:         t.cfg.Window,
+		UpdatedAt:      a.updatedAt,
+	}
+	var buys, sells float64
+	for _, p := range a.flow {
+		if p.v > 0 {
+			buys += p.v
+		} else {
+			sells -= p.v
+		}
+	}
+	if buys+sells > 0 {
+		f.TradeFlowImbalance = (buys - sells) / (buys + sells)
+	}
+	var sumSq float64
+	for i := 1; i < len(a.mids); i++ {
+		if prev := a.mids[i-1].v; prev > 0 {
+			r := math.Log(a.mids[i].v / prev)
+			sumSq += r * r
+		}
+	}
+	f.RealizedVol = math.Sqrt(sumSq)
+	return f
+}
+
+// trimPoints drops points at or before cutoff.
+func trimPoints(pts []samplePoint, cutoff time.Time) []samplePoint {
+	i := 0
+	for i < len(pts) && !pts[i].at.After(cutoff) {
+		i++
+	}
+	return pts[i:]
+}
+
+// sortedLevels returns a copy of levels best first: highest price for bids,
+// lowest for asks. Empty levels are dropped.
+func sortedLevels(levels []domain.PriceLevel, bids bool) []domain.PriceLevel {
+	out := make([]domain.PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		if l.Size > 0 && l.Price > 0 {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if bids {
+			return out[i].Price > out[j].Price
+		}
+		return out[i].Price < out[j].Price
+	})
+	return out
+}
+
+// eventTime is ts, or now for events without a timestamp.
+func eventTime(ts time.Time) time.Time {
+	if ts.IsZero() {
+		return time.Now().UTC()
+	}
+	return ts
+}
+
+// Compile-time interface check.
+var _ domain.FeatureProvider = (*FeatureTracker)(nil)
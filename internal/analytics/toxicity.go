@@ -0,0 +1,251 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// ToxicityConfig configures a ToxicityTracker.
+type ToxicityConfig struct {
+	BucketVolume  float64       // shares per volume bucket
+	Buckets       int           // completed buckets averaged into VPIN
+	MinBuckets    int           // completed buckets before the score is warm
+	FlushInterval time.Duration // how often changed scores are written to the cache
+}
+
+// assetToxicity is the bucketed flow of one asset.
+type assetToxicity struct {
+	buy, sell  float64   // taker volume of the open bucket
+	imbalances []float64 // |buy - sell| / volume of completed buckets, oldest first
+	lastPrice  float64
+	lastSide   domain.OrderSide // tick-rule side of the last print
+	updatedAt  time.Time
+	dirty      bool // changed since the last flush
+}
+
+// ToxicityTracker scores order flow toxicity per asset, VPIN style: trade
+// prints fill equal-volume buckets, and the score is the mean absolute
+// buy/sell imbalance of the last completed buckets. Prints without a taker
+// side are classified by the tick rule, or split evenly when the price has
+// not moved since an unclassified print. Scores are written to a shared
+// cache every flush interval so other processes see them too. It
+// implements domain.ToxicityProvider.
+type ToxicityTracker struct {
+	cache  domain.ToxicityCache // optional
+	cfg    ToxicityConfig
+	logger *slog.Logger
+
+	mu     sync.RWMutex
+	assets map[string]*assetToxicity
+}
+
+// NewToxicityTracker creates a ToxicityTracker. cache may be nil; then
+// scores are only served in process. A tracker never fed trades serves the
+// cache only.
+func NewToxicityTracker(cache domain.ToxicityCache, cfg ToxicityConfig, logger *slog.Logger) *ToxicityTracker {
+	if cfg.BucketVolume <= 0 {
+		cfg.BucketVolume = 500
+	}
+	if cfg.Buckets <= 0 {
+		cfg.Buckets = 50
+	}
+	if cfg.MinBuckets <= 0 || cfg.MinBuckets > cfg.Buckets {
+		cfg.MinBuckets = min(10, cfg.Buckets)
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	return &ToxicityTracker{
+		cache:  cache,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "toxicity")),
+		assets: make(map[string]*assetToxicity),
+	}
+}
+
+// OnTrade adds a trade print to the asset's volume buckets.
+func (t *ToxicityTracker) OnTrade(trade domain.LastTradePrice) {
+	if trade.Size <= 0 {
+		return
+	}
+	ts := eventTime(trade.Timestamp)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a, ok := t.assets[trade.AssetID]
+	if !ok {
+		a = &assetToxicity{}
+		t.assets[trade.AssetID] = a
+	}
+	side := trade.Side
+	if side == "" && trade.Price > 0 && a.lastPrice > 0 {
+		switch {
+		case trade.Price > a.lastPrice:
+			side = domain.OrderSideBuy
+		case trade.Price < a.lastPrice:
+			side = domain.OrderSideSell
+		default:
+			side = a.lastSide
+		}
+	}
+	if trade.Price > 0 {
+		a.lastPrice = trade.Price
+	}
+	a.lastSide = side
+	t.fill(a, side, trade.Size)
+	if ts.After(a.updatedAt) {
+		a.updatedAt = ts
+	}
+	a.dirty = true
+}
+
+// fill adds size shares on side (split evenly when unknown) to a's open
+// bucket, closing buckets as they reach the bucket volume. Called with t.mu
+// held.
+func (t *ToxicityTracker) fill(a *assetToxicity, side domain.OrderSide, size float64) {
+	bv := t.cfg.BucketVolume
+	add := func(v float64) {
+		switch side {
+		case domain.OrderSideBuy:
+			a.buy += v
+		case domain.OrderSideSell:
+			a.sell += v
+		default:
+			a.buy += v / 2
+			a.sell += v / 2
+		}
+	}
+	closeBucket := func() {
+		a.imbalances = append(a.imbalances, math.Abs(a.buy-a.sell)/bv)
+		if over := len(a.imbalances) - t.cfg.Buckets; over > 0 {
+			a.imbalances = a.imbalances[over:]
+		}
+		a.buy, a.sell = 0, 0
+	}
+
+	// Top up the open bucket.
+	take := math.Min(size, bv-(a.buy+a.sell))
+	add(take)
+	size -= take
+	if a.buy+a.sell >= bv-1e-9 {
+		closeBucket()
+	}
+	// A print spanning whole buckets fills them with its own side only;
+	// more than Buckets of them would be trimmed anyway.
+	if whole := math.Floor(size / bv); whole > 0 {
+		for i := 0; i < int(math.Min(whole, float64(t.cfg.Buckets))); i++ {
+			add(bv)
+			closeBucket()
+		}
+		size -= whole * bv
+	}
+	if size > 0 {
+		add(size)
+	}
+}
+
+// Toxicity returns the asset's score, from memory when this process tracks
+// the asset and from the cache otherwise.
+func (t *ToxicityTracker) Toxicity(ctx context.Context, assetID string) (domain.AssetToxicity, error) {
+	t.mu.RLock()
+	a, ok := t.assets[assetID]
+	var s domain.AssetToxicity
+	if ok {
+		s = t.compute(assetID, a)
+	}
+	t.mu.RUnlock()
+	if ok {
+		return s, nil
+	}
+	if t.cache == nil {
+		return domain.AssetToxicity{}, domain.ErrNotFound
+	}
+	s, err := t.cache.Get(ctx, assetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.AssetToxicity{}, err
+		}
+		return domain.AssetToxicity{}, fmt.Errorf("analytics: toxicity %s: %w", assetID, err)
+	}
+	return s, nil
+}
+
+// Run writes changed scores to the cache every flush interval and drops
+// assets without trades for domain.ToxicityMaxAge, until ctx is cancelled.
+// Call in a goroutine.
+func (t *ToxicityTracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	t.logger.InfoContext(ctx, "toxicity tracker started",
+		slog.Float64("bucket_volume", t.cfg.BucketVolume),
+		slog.Int("buckets", t.cfg.Buckets),
+	)
+	defer t.logger.InfoContext(ctx, "toxicity tracker stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			t.flush(ctx, now)
+		}
+	}
+}
+
+// flush writes the scores changed since the last flush and prunes idle
+// assets.
+func (t *ToxicityTracker) flush(ctx context.Context, now time.Time) {
+	t.mu.Lock()
+	var changed []domain.AssetToxicity
+	for id, a := range t.assets {
+		if now.Sub(a.updatedAt) > domain.ToxicityMaxAge {
+			delete(t.assets, id)
+			continue
+		}
+		if a.dirty {
+			a.dirty = false
+			changed = append(changed, t.compute(id, a))
+		}
+	}
+	t.mu.Unlock()
+
+	if t.cache == nil || len(changed) == 0 {
+		return
+	}
+	if err := t.cache.SetBatch(ctx, changed); err != nil {
+		t.logger.WarnContext(ctx, "toxicity: cache write failed",
+			slog.Int("assets", len(changed)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// compute derives the score of a. Called with t.mu held.
+func (t *ToxicityTracker) compute(assetID string, a *assetToxicity) domain.AssetToxicity {
+	s := domain.AssetToxicity{
+		AssetID:      assetID,
+		Buckets:      len(a.imbalances),
+		BucketVolume: t.cfg.BucketVolume,
+		Warm:         len(a.imbalances) >= t.cfg.MinBuckets,
+		UpdatedAt:    a.updatedAt,
+	}
+	if n := len(a.imbalances); n > 0 {
+		var sum float64
+		for _, v := range a.imbalances {
+			sum += v
+		}
+		s.VPIN = sum / float64(n)
+	}
+	return s
+}
+
+// Compile-time interface check.
+var _ domain.ToxicityProvider = (*ToxicityTracker)(nil)
@@ -9,16 +9,114 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/alanyoungcy/polymarketbot/internal/config"
+	"github.com/alanyoungcy/polymarketbot/internal/executor"
+	"github.com/alanyoungcy/polymarketbot/internal/feed"
+	"github.com/alanyoungcy/polymarketbot/internal/pipeline"
+	"github.com/alanyoungcy/polymarketbot/internal/service"
+	"github.com/alanyoungcy/polymarketbot/internal/strategy"
 )
 
 // App is the root application object. It owns the configuration, logger, and a
 // list of cleanup functions that are called in reverse order on shutdown.
 type App struct {
+	// cfg is the configuration the app started with; it is never written
+	// after New. live holds the configuration as last reloaded (nil until a
+	// reload); read it through config.
 	cfg     *config.Config
+	live    atomic.Pointer[config.Config]
 	logger  *slog.Logger
 	closers []func()
+
+	// configPath is the file ReloadConfig re-reads; set by WithConfigPath.
+	configPath string
+	// reloadMu serializes configuration reloads and guards engine.
+	reloadMu sync.Mutex
+	// engine is set by the trade and full modes; reloads apply strategy
+	// parameter changes to it.
+	engine *strategy.Engine
+
+	// portfolioRisk is set by buildExecutor; nil until an executor exists.
+	portfolioRisk *service.PortfolioRiskManager
+	// marketWatcher is set by buildExecutor when risk.metadata_poll_interval
+	// is non-zero and Gamma and the market store are available.
+	marketWatcher *service.MarketWatcher
+	// resolutionWatcher is set by buildExecutor when
+	// risk.resolution_poll_interval is non-zero and Gamma is configured.
+	resolutionWatcher *service.ResolutionWatcher
+	// redemption is set by buildExecutor when redemption.enabled is set and
+	// Postgres, polygon.rpc_url and an EOA wallet are available.
+	redemption *service.RedemptionService
+	// orderJanitor is set by buildExecutor when risk.stale_order_interval is
+	// non-zero and the order store is available.
+	orderJanitor *service.OrderJanitor
+	// unhedgedMonitor is set by buildExecutor when
+	// arbitrage.unhedged_check_interval is non-zero and arb executions are
+	// recorded.
+	unhedgedMonitor *service.UnhedgedExposureMonitor
+	// userFeed is set by buildExecutor when orders go to the CLOB and
+	// polymarket.user_channel is enabled.
+	userFeed *feed.PolymarketUserFeed
+	// marketFeed is set by the trade and full modes when the Polymarket
+	// market WebSocket runs; risk checks listen to its connection state.
+	marketFeed *feed.PolymarketWSFeed
+	// candidates is set by the trade and full modes when
+	// strategy.auto_execute is false; it holds the signals for the API to
+	// rank and execute.
+	candidates *service.CandidateService
+	// deadLetters is set by startDataPipeline when the Goldsky scrape runs
+	// and Redis is wired; the API replays its batches.
+	deadLetters *pipeline.DeadLetters
+	// trades is set by startDataPipeline when the Goldsky scrape runs;
+	// /metrics exports its ingestion counts.
+	trades *service.TradeService
+	// signals is built on first use by signalRecorder when signals.record or
+	// hindsight.enabled is set and Postgres is wired; the engine records
+	// emitted signals to it and the executor their outcomes.
+	signals *service.SignalRecorder
+	// hindsight is set by startHindsight when hindsight.enabled is set and
+	// Postgres is wired.
+	hindsight *service.HindsightService
+	// marketMatcher is set by startMarketMatcher when crossmap.enabled is
+	// set and Kalshi credentials and Postgres are available.
+	marketMatcher *service.MarketMatcher
+	// heatmap is set by startHeatmap when Postgres and Redis are wired.
+	heatmap *service.HeatmapAggregator
+	// cancelRatio is set by startCancelRatio when risk.cancel_ratio_limit is
+	// set and Redis is wired.
+	cancelRatio *service.CancelRatioTracker
+	// rewardsTracker is set by startRewardsTracker when Gamma and Postgres
+	// are wired.
+	rewardsTracker *service.RewardsTracker
+	// fees is built on first use by feeModel when fees.enabled is set and
+	// Gamma is configured; shared by arbitrage, risk checks and the API.
+	fees *service.FeeModel
+	// increments is built on first use by orderIncrements when
+	// increments.enabled is set and the CLOB is configured; shared by every
+	// order service.
+	increments *service.OrderIncrementCache
+	// latency is set by buildExecutor when latency.enabled is set; the
+	// trading pipeline reports to it and the API serves it.
+	latency *service.LatencyTracker
+	// executor is set by buildExecutor; the API serves its queue depths.
+	executor *executor.Executor
+	// balances is built on first use by balanceGuard when polygon.rpc_url and
+	// wallet.private_key are set; shared by order placement and the API.
+	balances *service.BalanceGuard
+	// risk is built on first use by riskService and shared by the executor
+	// and order previews, so previews see the markets and feeds it has
+	// blocked.
+	risk *service.RiskService
+	// lists is built on first use by marketLists when Postgres is wired;
+	// shared by strategy market listings, watched assets, risk checks and
+	// the API.
+	lists *service.MarketListService
+	// capital is built on first use by capitalAllocator when capital.enabled
+	// is set and Postgres is wired; shared by risk checks and the API.
+	capital *service.CapitalAllocator
 }
 
 // New creates a new App from the given configuration and logger.
@@ -29,6 +127,23 @@ func New(cfg *config.Config, logger *slog.Logger) *App {
 	}
 }
 
+// config returns the current configuration: the last reloaded one, or the
+// startup configuration before any reload. The returned value is not
+// modified afterwards, so it is safe to read while a reload runs.
+func (a *App) config() *config.Config {
+	if cfg := a.live.Load(); cfg != nil {
+		return cfg
+	}
+	return a.cfg
+}
+
+// WithConfigPath records the file the configuration was loaded from, which
+// a reload (SIGHUP or POST /api/config/reload) reads again.
+func (a *App) WithConfigPath(path string) *App {
+	a.configPath = path
+	return a
+}
+
 // Run is the main entry point. It wires all dependencies, selects the
 // operating mode, starts the corresponding goroutines, and blocks until the
 // context is cancelled. On return it runs all registered cleanup functions.
@@ -43,6 +158,7 @@ func (a *App) Run(ctx context.Context) error {
 		return fmt.Errorf("app: wire dependencies: %w", err)
 	}
 	a.closers = append(a.closers, cleanup)
+	go a.reloadOnSignal(ctx, deps)
 
 	mode := strings.ToLower(a.cfg.Mode)
 	switch mode {
@@ -54,6 +170,10 @@ func (a *App) Run(ctx context.Context) error {
 		return a.MonitorMode(ctx, deps)
 	case "scrape":
 		return a.ScrapeMode(ctx, deps)
+	case "backtest":
+		return a.BacktestMode(ctx, deps)
+	case "backfill":
+		return a.BackfillMode(ctx, deps)
 	case "full":
 		return a.FullMode(ctx, deps)
 	default:
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/crypto"
+	"github.com/alanyoungcy/polymarketbot/internal/service"
+)
+
+// AuditAmounts recomputes maker/taker amounts and fill values for the orders
+// and positions in [from, to) and reports those that differ by more than
+// tolerance base units from what was stored or what the CLOB returns. It
+// wires only Postgres and Redis regardless of the configured mode. Without a
+// wallet key the CLOB comparison is skipped.
+func (a *App) AuditAmounts(ctx context.Context, from, to time.Time, tolerance int64) (service.AmountAuditReport, error) {
+	if !from.Before(to) {
+		return service.AmountAuditReport{}, fmt.Errorf("app: audit amounts: from %s must be before to %s",
+			from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	cfg := *a.cfg
+	cfg.Mode = "audit-amounts"
+	deps, cleanup, err := Wire(ctx, &cfg)
+	if err != nil {
+		return service.AmountAuditReport{}, fmt.Errorf("app: wire dependencies: %w", err)
+	}
+	a.closers = append(a.closers, cleanup)
+
+	auditor := service.NewAmountAuditor(deps.OrderStore, deps.PositionStore, a.logger).
+		WithTolerance(tolerance).
+		WithCollateralDecimals(a.collateralDecimals())
+	if inc := a.orderIncrements(deps); inc != nil {
+		auditor.WithIncrements(inc)
+	}
+
+	if a.cfg.Wallet.PrivateKey == "" {
+		a.logger.WarnContext(ctx, "audit amounts: no wallet key configured, skipping CLOB comparison")
+	} else {
+		signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID)
+		if err != nil {
+			return service.AmountAuditReport{}, fmt.Errorf("app: audit amounts: create signer: %w", err)
+		}
+		clobClient := a.newClobClient(deps, signer)
+		if err := clobClient.DeriveAPIKey(ctx); err != nil {
+			a.logger.WarnContext(ctx, "audit amounts: derive API key failed, skipping CLOB comparison",
+				slog.String("error", err.Error()),
+			)
+		} else {
+			auditor.WithVenue(clobClient)
+		}
+	}
+
+	return auditor.Run(ctx, from, to)
+}
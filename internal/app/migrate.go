@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/alanyoungcy/polymarketbot/internal/store/postgres"
+)
+
+// Migrate applies the pending embedded migrations to the configured
+// database, whatever supabase.run_migrations says.
+func (a *App) Migrate(ctx context.Context) error {
+	pg, err := a.migrationClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := pg.RunMigrations(ctx); err != nil {
+		return fmt.Errorf("app: migrate: %w", err)
+	}
+	a.logger.InfoContext(ctx, "migrations applied")
+	return nil
+}
+
+// RollbackMigrations reverts the last steps applied migrations and returns
+// the names of those reverted, newest first.
+func (a *App) RollbackMigrations(ctx context.Context, steps int) ([]string, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("app: rollback migrations: steps must be positive, got %d", steps)
+	}
+	pg, err := a.migrationClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reverted, err := pg.RollbackMigrations(ctx, steps)
+	for _, name := range reverted {
+		a.logger.InfoContext(ctx, "migration rolled back", slog.String("migration", name))
+	}
+	if err != nil {
+		return reverted, fmt.Errorf("app: rollback migrations: %w", err)
+	}
+	return reverted, nil
+}
+
+// MigrationStatus lists the embedded migrations and whether each is applied.
+func (a *App) MigrationStatus(ctx context.Context) ([]postgres.MigrationStatus, error) {
+	pg, err := a.migrationClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, err := pg.MigrationStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("app: migration status: %w", err)
+	}
+	return status, nil
+}
+
+// migrationClient connects to Postgres only, closed with the App.
+func (a *App) migrationClient(ctx context.Context) (*postgres.Client, error) {
+	pg, err := postgres.New(ctx, postgresConfig(a.cfg))
+	if err != nil {
+		return nil, fmt.Errorf("app: postgres: %w", err)
+	}
+	a.closers = append(a.closers, pg.Close)
+	return pg, nil
+}
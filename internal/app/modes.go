@@ -1,26 +1,40 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/alanyoungcy/polymarketbot/internal/analytics"
 	"github.com/alanyoungcy/polymarketbot/internal/arbitrage"
+	"github.com/alanyoungcy/polymarketbot/internal/backtest"
 	"github.com/alanyoungcy/polymarketbot/internal/config"
 	"github.com/alanyoungcy/polymarketbot/internal/crypto"
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 	"github.com/alanyoungcy/polymarketbot/internal/executor"
 	"github.com/alanyoungcy/polymarketbot/internal/feed"
 	"github.com/alanyoungcy/polymarketbot/internal/pipeline"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/chain"
 	"github.com/alanyoungcy/polymarketbot/internal/platform/goldsky"
 	"github.com/alanyoungcy/polymarketbot/internal/platform/kalshi"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/manifold"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polygon"
 	"github.com/alanyoungcy/polymarketbot/internal/platform/polymarket"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/predictit"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/pricefeed"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/ratelimit"
 	"github.com/alanyoungcy/polymarketbot/internal/server/handler"
 	"github.com/alanyoungcy/polymarketbot/internal/server/middleware"
 	"github.com/alanyoungcy/polymarketbot/internal/server/ws"
@@ -34,6 +48,30 @@ type strategyDeps struct {
 	rewardsTracker *service.RewardsTracker
 	gammaClient    *polymarket.GammaClient
 	kalshiClient   *kalshi.Client
+	// Read-only venues quoted by cross_platform_arb.
+	predictItClient *predictit.Client
+	manifoldClient  *manifold.Client
+	// instruments resolves venue links not present in the config maps.
+	instruments *service.InstrumentRegistry
+	// cancelRatio tracks per-market cancel ratios for liquidity_provider;
+	// started by startCancelRatio.
+	cancelRatio *service.CancelRatioTracker
+	// inventory reads the wallet's net shares per market for
+	// liquidity_provider quote skew.
+	inventory *service.PositionInventory
+	// features computes per-asset order-flow features from the market feed
+	// when features.enabled is set; started by startFeatures.
+	features *analytics.FeatureTracker
+	// toxicity scores per-asset order flow toxicity (VPIN) from the market
+	// feed's trades when toxicity.enabled is set; started by startToxicity.
+	toxicity *analytics.ToxicityTracker
+	// tradeAnalytics keeps per-market VWAP and volume from the ingested
+	// trades when trade_analytics.enabled is set; started by
+	// startTradeAnalytics.
+	tradeAnalytics *service.TradeAnalytics
+	// priceFeed polls exchange tickers for spot prices when
+	// pricefeed.enabled is set; started by startPriceFeed.
+	priceFeed *pricefeed.Feed
 }
 
 // TradeMode starts the strategy engine, price service, order execution, and
@@ -57,16 +95,29 @@ func (a *App) TradeMode(ctx context.Context, deps *Dependencies) error {
 	sd := a.buildStrategyDeps(deps)
 	reg := a.newStrategyRegistry(deps, sd)
 	engine := strategy.NewEngine(reg, signalCh, deps.PriceCache, a.logger)
+	a.setEngine(engine)
+	engine.SetEventBudget(time.Duration(a.cfg.Strategy.EventBudgetMs) * time.Millisecond)
+	engine.SetLegOrderType(domain.OrderType(a.cfg.Strategy.LegOrderType))
+	if err := engine.SetQueuePolicy(domain.StrategyQueuePolicy{
+		BufferSize:   a.cfg.Strategy.Queue.BufferSize,
+		Overflow:     a.cfg.Strategy.Queue.Overflow,
+		BlockTimeout: a.cfg.Strategy.Queue.BlockTimeout.Duration,
+	}); err != nil {
+		return fmt.Errorf("strategy queue: %w", err)
+	}
+	if deps.OpportunityRegistry != nil {
+		engine.SetOpportunityRegistry(deps.OpportunityRegistry, a.cfg.Arbitrage.OpportunityDedupWindow.Duration)
+	}
+	if deps.Keyspace != nil {
+		engine.SetStateCaches(deps.Keyspace.StateCache)
+	}
+	a.restoreStrategyParams(ctx, deps, engine)
 	if len(a.cfg.Strategy.Active) > 0 {
 		if err := engine.SetActiveNames(a.cfg.Strategy.Active); err != nil {
 			a.logger.WarnContext(ctx, "failed to set active strategies, engine will idle",
 				slog.Any("active", a.cfg.Strategy.Active),
 				slog.String("error", err.Error()),
 			)
-		} else {
-			g.Go(func() error {
-				return engine.RunAll(ctx)
-			})
 		}
 	} else {
 		if err := engine.SetActive(a.cfg.Strategy.Name); err != nil {
@@ -74,95 +125,196 @@ func (a *App) TradeMode(ctx context.Context, deps *Dependencies) error {
 				slog.String("strategy", a.cfg.Strategy.Name),
 				slog.String("error", err.Error()),
 			)
-		} else {
-			g.Go(func() error {
-				return engine.Run(ctx)
-			})
 		}
 	}
+	a.startMarketLists(ctx, g, deps)
+	// Watched assets: their books are seeded from REST before strategies
+	// start, then streamed by the WS feed below.
+	var assetIDs []string
+	if deps.MarketStore != nil && a.cfg.Polymarket.WsHost != "" {
+		assetIDs = a.watchAssetIDs(ctx, deps.MarketStore, 100)
+	}
+	a.warmCaches(ctx, deps, assetIDs)
+	a.startShadow(ctx, g, deps, engine)
+
+	// The engine always runs so strategies can be enabled later via /api/strategy/bulk.
+	g.Go(func() error {
+		return engine.RunAll(ctx)
+	})
 
 	// Engine feeder: subscribe to "prices" and feed engine (so strategies get events from Redis).
 	engineFeeder := feed.NewEngineFeeder(deps.SignalBus, deps.BookCache, engine, a.logger)
+	if sampler := a.eventSampler(deps); sampler != nil {
+		engineFeeder.WithSampler(sampler)
+	}
 	g.Go(func() error {
 		return engineFeeder.Run(ctx)
 	})
+	a.startBookRecorder(ctx, g, deps)
+	a.startBookSnapshots(ctx, g, deps, assetIDs)
+	a.startCandles(ctx, g, deps)
+	a.startFeatures(ctx, g, sd)
+	a.startToxicity(ctx, g, sd)
+	a.startTradeAnalytics(ctx, g, sd)
+	a.startPriceFeed(ctx, g, sd)
+	a.startSignals(ctx, g, deps, engine)
+	a.startHindsight(ctx, g, deps)
+	a.startPerformance(ctx, g, deps)
+	a.startCapital(ctx, g, deps)
+	a.startStrategyBreakers(ctx, g, deps, engine)
+	a.startHeatmap(ctx, g, deps, engine)
+	a.startCancelRatio(ctx, g, sd)
+	a.startRewardsTracker(ctx, g, sd)
+	a.startMarketMatcher(ctx, g, deps, sd)
+	a.startNotifier(ctx, g, deps)
+	a.startProfitSweep(ctx, g, deps)
+	if alertSvc := a.newAlertService(deps); alertSvc != nil {
+		g.Go(func() error {
+			return alertSvc.Run(ctx)
+		})
+	}
 
 	// Polymarket WS feed: push book/price into PriceService and engine (produces "prices" events).
-	if deps.MarketStore != nil && a.cfg.Polymarket.WsHost != "" {
-		assetIDs := a.watchAssetIDs(ctx, deps.MarketStore, 100)
-		if len(assetIDs) > 0 {
-			wsFeed := feed.NewPolymarketWSFeed(
-				a.cfg.Polymarket.WsHost,
-				assetIDs,
-				func(ctx context.Context, snap domain.OrderbookSnapshot) {
-					_ = priceSvc.HandleBookUpdate(ctx, snap)
-					_ = engine.HandleBookUpdate(ctx, snap)
-				},
-				func(ctx context.Context, change domain.PriceChange) {
-					_ = priceSvc.HandlePriceChange(ctx, change)
-					_ = engine.HandlePriceChange(ctx, change)
-				},
-				a.logger,
-			)
-			g.Go(func() error {
-				defer wsFeed.Close()
-				return wsFeed.Run(ctx)
+	if len(assetIDs) > 0 {
+		wsFeed := feed.NewPolymarketWSFeed(
+			a.cfg.Polymarket.WsHost,
+			assetIDs,
+			func(ctx context.Context, snap domain.OrderbookSnapshot) {
+				if sd.features != nil {
+					sd.features.OnBook(snap)
+				}
+				_ = priceSvc.HandleBookUpdate(ctx, snap)
+				_ = engine.HandleBookUpdate(ctx, snap)
+			},
+			func(ctx context.Context, change domain.PriceChange) {
+				_ = priceSvc.HandlePriceChange(ctx, change)
+				_ = engine.HandlePriceChange(ctx, change)
+			},
+			a.logger,
+		).WithBus(deps.SignalBus).WithAudit(deps.AuditStore).WithSilenceTimeout(a.cfg.Polymarket.WsSilenceTimeout.Duration)
+		wsFeed.WithWatchdog(a.cfg.Polymarket.WsReconnectAfter.Duration, a.cfg.Polymarket.WsAssetStaleTimeout.Duration).
+			WithRESTFallback(a.newClobClient(deps, nil), a.cfg.Polymarket.RestPollInterval.Duration)
+		if a.cfg.Candles.Enabled || sd.features != nil || sd.toxicity != nil {
+			wsFeed.WithLastTrade(func(ctx context.Context, trade domain.LastTradePrice) {
+				if sd.features != nil {
+					sd.features.OnTrade(trade)
+				}
+				if sd.toxicity != nil {
+					sd.toxicity.OnTrade(trade)
+				}
+				if a.cfg.Candles.Enabled {
+					_ = priceSvc.HandleLastTrade(ctx, trade)
+				}
 			})
 		}
+		wsFeed.AddListener(engine)
+		a.marketFeed = wsFeed
+		g.Go(func() error {
+			defer wsFeed.Close()
+			return wsFeed.Run(ctx)
+		})
 	}
 
 	// BondTracker: poll open bond positions and update on resolution.
 	if deps.BondPositionStore != nil && sd != nil && sd.gammaClient != nil {
 		bondTracker := service.NewBondTracker(deps.BondPositionStore, sd.gammaClient, deps.SignalBus, 2*time.Minute, a.logger)
+		if deps.OrderStore != nil {
+			bondTracker.WithFills(deps.OrderStore, deps.MarketStore)
+		}
 		g.Go(func() error {
 			return bondTracker.Run(ctx)
 		})
 	}
 
+	// Executor: reads signals and places orders through the full execution
+	// pipeline. With strategy.auto_execute false the engine's signals are
+	// held as ranked candidates instead, and only those executed from the
+	// API reach the executor.
+	execCh := signalCh
+	var candidates *service.CandidateService
 	if !a.cfg.Strategy.AutoExecute {
-		a.logger.InfoContext(ctx, "strategy.auto_execute is false; bot will scan and publish candidates only")
+		a.logger.InfoContext(ctx, "strategy.auto_execute is false; signals are held as candidates and executed on request")
+		execCh = make(chan domain.TradeSignal, 32)
+		candidates = a.newCandidateService(deps)
+	}
+	exec, execErr := a.buildExecutor(ctx, deps, execCh, sd)
+	if execErr != nil {
+		a.logger.WarnContext(ctx, "trade mode: executor build failed, falling back to log-only",
+			slog.String("error", execErr.Error()),
+		)
 		g.Go(func() error {
 			for {
 				select {
 				case <-ctx.Done():
 					return ctx.Err()
-				case _, ok := <-signalCh:
+				case sig, ok := <-execCh:
 					if !ok {
 						return nil
 					}
+					a.logger.InfoContext(ctx, "trade signal received (no executor)",
+						slog.String("signal_id", sig.ID),
+						slog.String("source", sig.Source),
+						slog.String("side", string(sig.Side)),
+					)
 				}
 			}
 		})
 	} else {
-		// Executor: reads signals and places orders through the full execution pipeline.
-		exec, execErr := a.buildExecutor(ctx, deps, signalCh, sd)
-		if execErr != nil {
-			a.logger.WarnContext(ctx, "trade mode: executor build failed, falling back to log-only",
-				slog.String("error", execErr.Error()),
-			)
+		g.Go(func() error {
+			return exec.Run(ctx)
+		})
+		if a.portfolioRisk != nil {
 			g.Go(func() error {
-				for {
-					select {
-					case <-ctx.Done():
-						return ctx.Err()
-					case sig, ok := <-signalCh:
-						if !ok {
-							return nil
-						}
-						a.logger.InfoContext(ctx, "trade signal received (no executor)",
-							slog.String("signal_id", sig.ID),
-							slog.String("source", sig.Source),
-							slog.String("side", string(sig.Side)),
-						)
-					}
-				}
+				return a.portfolioRisk.Run(ctx)
 			})
-		} else {
+		}
+		if a.latency != nil {
+			g.Go(func() error {
+				return a.latency.Run(ctx)
+			})
+		}
+		if a.marketWatcher != nil {
+			a.marketWatcher.AddListener(engine)
+			g.Go(func() error {
+				return a.marketWatcher.Run(ctx)
+			})
+		}
+		if a.resolutionWatcher != nil {
+			g.Go(func() error {
+				return a.resolutionWatcher.Run(ctx)
+			})
+		}
+		if a.redemption != nil {
+			g.Go(func() error {
+				return a.redemption.Run(ctx)
+			})
+		}
+		if a.orderJanitor != nil {
+			g.Go(func() error {
+				return a.orderJanitor.Run(ctx)
+			})
+		}
+		if a.unhedgedMonitor != nil {
+			a.unhedgedMonitor.WithSignals(execCh)
+			g.Go(func() error {
+				return a.unhedgedMonitor.Run(ctx)
+			})
+		}
+		if a.userFeed != nil {
 			g.Go(func() error {
-				return exec.Run(ctx)
+				return a.userFeed.Run(ctx)
 			})
 		}
 	}
+	if candidates != nil {
+		if execErr == nil {
+			candidates.WithRoute(execCh)
+		}
+		a.candidates = candidates
+		g.Go(func() error {
+			return candidates.Run(ctx, signalCh)
+		})
+	}
 
 	// Relation discovery (one-shot).
 	if sd != nil && sd.relationSvc != nil {
@@ -183,10 +335,11 @@ func (a *App) TradeMode(ctx context.Context, deps *Dependencies) error {
 	return g.Wait()
 }
 
-// ArbitrageMode starts the selected arbitrage strategy detector and the HTTP server.
+// ArbitrageMode starts the detector of the selected arbitrage strategies and
+// the HTTP server.
 func (a *App) ArbitrageMode(ctx context.Context, deps *Dependencies) error {
 	a.logger.InfoContext(ctx, "starting arbitrage mode",
-		slog.String("strategy", a.cfg.Arbitrage.Strategy),
+		slog.String("strategies", strings.Join(arbStrategyNames(a.cfg.Arbitrage), ",")),
 	)
 
 	g, ctx := errgroup.WithContext(ctx)
@@ -199,22 +352,27 @@ func (a *App) ArbitrageMode(ctx context.Context, deps *Dependencies) error {
 		MaxUnhedgedNotional: a.cfg.Arbitrage.MaxUnhedgedNotional,
 		KillSwitchLossUSD:   a.cfg.Arbitrage.KillSwitchLossUSD,
 		PerVenueFeeBps:      a.cfg.Arbitrage.PerVenueFeeBps,
+		OpportunityTTL:      a.cfg.Arbitrage.OpportunityTTL.Duration,
 	}
 	arbSvc := service.NewArbService(deps.ArbStore, deps.SignalBus, deps.AuditStore, arbCfg, a.logger)
 
-	arbStrategy, err := a.newArbStrategy(a.cfg.Arbitrage, a.logger)
+	arbStrategies, err := a.newArbStrategies(a.cfg.Arbitrage, a.logger)
 	if err != nil {
 		return fmt.Errorf("arbitrage mode: %w", err)
 	}
 	det := arbitrage.NewDetector(arbitrage.DetectorConfig{
-		Strategy:  arbStrategy,
-		ArbSvc:    arbSvc,
-		BookCache: deps.BookCache,
-		Logger:    a.logger,
+		Strategies:    arbStrategies,
+		ArbSvc:        arbSvc,
+		BookCache:     deps.BookCache,
+		Opportunities: deps.OpportunityRegistry,
+		DedupWindow:   a.cfg.Arbitrage.OpportunityDedupWindow.Duration,
+		Fees:          a.feeModel(deps),
+		Logger:        a.logger,
 	})
 	g.Go(func() error {
 		return det.Run(ctx, deps.SignalBus)
 	})
+	a.startNotifier(ctx, g, deps)
 
 	if a.cfg.Server.Enabled {
 		a.startHTTPServer(ctx, g, deps, nil, nil, nil)
@@ -248,6 +406,9 @@ func (a *App) MonitorMode(ctx context.Context, deps *Dependencies) error {
 		}
 	})
 
+	a.startHeatmap(ctx, g, deps, nil)
+	a.startNotifier(ctx, g, deps)
+
 	// HTTP server is always started in monitor mode.
 	a.startHTTPServer(ctx, g, deps, nil, nil, nil)
 
@@ -271,6 +432,172 @@ func (a *App) ScrapeMode(ctx context.Context, deps *Dependencies) error {
 	return g.Wait()
 }
 
+// BacktestMode replays recorded book events and historical trades through each
+// configured strategy with a simulated order placer, logs a PnL summary, and
+// writes the full report as JSON to backtest.report_path and/or S3.
+func (a *App) BacktestMode(ctx context.Context, deps *Dependencies) error {
+	bc := a.cfg.Backtest
+	a.logger.InfoContext(ctx, "starting backtest mode",
+		slog.String("from", bc.From),
+		slog.String("to", bc.To),
+		slog.String("source", bc.Source),
+		slog.String("book_source", bc.BookSource),
+	)
+
+	from, err := time.Parse(time.RFC3339, bc.From)
+	if err != nil {
+		return fmt.Errorf("backtest mode: parse from: %w", err)
+	}
+	to, err := time.Parse(time.RFC3339, bc.To)
+	if err != nil {
+		return fmt.Errorf("backtest mode: parse to: %w", err)
+	}
+
+	names := bc.Strategies
+	if len(names) == 0 {
+		names = a.cfg.Strategy.Active
+	}
+	if len(names) == 0 && a.cfg.Strategy.Name != "" {
+		names = []string{a.cfg.Strategy.Name}
+	}
+
+	var trades backtest.TradeLoader
+	switch bc.Source {
+	case "s3":
+		if deps.BlobReader == nil {
+			return fmt.Errorf("backtest mode: source s3 requires blob storage")
+		}
+		trades = backtest.NewArchiveTradeLoader(deps.BlobReader)
+	default:
+		if deps.TradeStore != nil {
+			trades = backtest.NewStoreTradeLoader(deps.TradeStore, bc.MaxEvents)
+		}
+	}
+
+	var books backtest.BookLoader
+	switch bc.BookSource {
+	case "s3":
+		if deps.BlobReader == nil {
+			return fmt.Errorf("backtest mode: book_source s3 requires blob storage")
+		}
+		books = backtest.NewArchiveBookLoader(deps.BlobReader)
+	default:
+		if deps.BookEventStore != nil {
+			books = backtest.NewStoreBookLoader(deps.BookEventStore)
+		}
+	}
+
+	sd := a.buildStrategyDeps(deps)
+	factory := func(books domain.OrderbookCache, prices domain.PriceCache) *strategy.Registry {
+		simDeps := *deps
+		simDeps.BookCache = books
+		simDeps.PriceCache = prices
+		return a.newStrategyRegistry(&simDeps, sd)
+	}
+
+	runner := backtest.NewRunner(backtest.Config{
+		From:      from,
+		To:        to,
+		FeeBps:    bc.FeeBps,
+		MaxEvents: bc.MaxEvents,
+	}, books, trades, factory, a.logger)
+
+	report, err := runner.Run(ctx, names)
+	if err != nil {
+		return fmt.Errorf("backtest mode: %w", err)
+	}
+
+	for _, sr := range report.Strategies {
+		a.logger.InfoContext(ctx, "backtest result",
+			slog.String("strategy", sr.Strategy),
+			slog.Int("signals", sr.Signals),
+			slog.Int("fills", sr.Fills),
+			slog.Int("rejected", sr.Rejected),
+			slog.Float64("volume_usd", sr.VolumeUSD),
+			slog.Float64("fees_usd", sr.FeesUSD),
+			slog.Float64("realized_pnl", sr.RealizedPnL),
+			slog.Float64("unrealized_pnl", sr.UnrealizedPnL),
+			slog.Float64("total_pnl", sr.TotalPnL),
+		)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("backtest mode: marshal report: %w", err)
+	}
+	if bc.ReportPath != "" {
+		if err := os.WriteFile(bc.ReportPath, data, 0o644); err != nil {
+			return fmt.Errorf("backtest mode: write report: %w", err)
+		}
+		a.logger.InfoContext(ctx, "backtest report written", slog.String("path", bc.ReportPath))
+	}
+	if deps.BlobWriter != nil {
+		key := fmt.Sprintf("backtest/reports/%s.json", report.StartedAt.Format("20060102T150405Z"))
+		if err := deps.BlobWriter.Put(ctx, key, bytes.NewReader(data), "application/json"); err != nil {
+			a.logger.WarnContext(ctx, "backtest report upload failed",
+				slog.String("path", key),
+				slog.String("error", err.Error()),
+			)
+		} else {
+			a.logger.InfoContext(ctx, "backtest report uploaded", slog.String("path", key))
+		}
+	}
+	return nil
+}
+
+// BackfillMode loads historical Goldsky order fills over backfill.from..to with
+// parallel paginated workers, stores raw pages in S3 and bulk-loads trades
+// into Postgres, then returns.
+func (a *App) BackfillMode(ctx context.Context, deps *Dependencies) error {
+	bc := a.cfg.Backfill
+	from, err := time.Parse(time.RFC3339, bc.From)
+	if err != nil {
+		return fmt.Errorf("backfill mode: parse from: %w", err)
+	}
+	to, err := time.Parse(time.RFC3339, bc.To)
+	if err != nil {
+		return fmt.Errorf("backfill mode: parse to: %w", err)
+	}
+	if deps.MarketStore == nil || deps.TradeStore == nil {
+		return fmt.Errorf("backfill mode: postgres not configured")
+	}
+	copier, ok := deps.TradeStore.(pipeline.TradeCopier)
+	if !ok {
+		return fmt.Errorf("backfill mode: trade store does not support bulk copy")
+	}
+	if deps.BlobWriter == nil {
+		a.logger.WarnContext(ctx, "backfill mode: s3 not configured, raw fill pages will not be kept")
+	}
+
+	marketSvc := service.NewMarketService(deps.MarketStore, deps.MarketCache, deps.SignalBus, a.logger)
+	if reg := a.instrumentRegistry(deps); reg != nil {
+		marketSvc.WithInstruments(reg)
+	}
+	tradeSvc := service.NewTradeService(deps.TradeStore, deps.SignalBus, deps.AuditStore, a.logger)
+	backfill := pipeline.NewGoldskyBackfill(
+		goldsky.NewClient(a.cfg.Pipeline.GoldskyURL, a.cfg.Pipeline.GoldskyAPIKey),
+		deps.BlobWriter,
+		pipeline.NewTradeProcessor(tradeSvc, marketSvc, a.logger),
+		copier,
+		pipeline.BackfillConfig{
+			From:              from,
+			To:                to,
+			Chunk:             bc.Chunk.Duration,
+			Workers:           bc.Workers,
+			PageSize:          bc.PageSize,
+			RequestsPerSecond: bc.RequestsPerSecond,
+		},
+		a.logger,
+	).WithRetry(deps.Retrier)
+	if a.cfg.S3.Format == "parquet" {
+		backfill.WithParquet()
+	}
+	if _, err := backfill.Run(ctx); err != nil {
+		return fmt.Errorf("backfill mode: %w", err)
+	}
+	return nil
+}
+
 // FullMode starts all subsystems: trading, arbitrage, scraping, monitoring,
 // and the HTTP server.
 func (a *App) FullMode(ctx context.Context, deps *Dependencies) error {
@@ -285,16 +612,29 @@ func (a *App) FullMode(ctx context.Context, deps *Dependencies) error {
 	sd := a.buildStrategyDeps(deps)
 	reg := a.newStrategyRegistry(deps, sd)
 	engine := strategy.NewEngine(reg, signalCh, deps.PriceCache, a.logger)
+	a.setEngine(engine)
+	engine.SetEventBudget(time.Duration(a.cfg.Strategy.EventBudgetMs) * time.Millisecond)
+	engine.SetLegOrderType(domain.OrderType(a.cfg.Strategy.LegOrderType))
+	if err := engine.SetQueuePolicy(domain.StrategyQueuePolicy{
+		BufferSize:   a.cfg.Strategy.Queue.BufferSize,
+		Overflow:     a.cfg.Strategy.Queue.Overflow,
+		BlockTimeout: a.cfg.Strategy.Queue.BlockTimeout.Duration,
+	}); err != nil {
+		return fmt.Errorf("strategy queue: %w", err)
+	}
+	if deps.OpportunityRegistry != nil {
+		engine.SetOpportunityRegistry(deps.OpportunityRegistry, a.cfg.Arbitrage.OpportunityDedupWindow.Duration)
+	}
+	if deps.Keyspace != nil {
+		engine.SetStateCaches(deps.Keyspace.StateCache)
+	}
+	a.restoreStrategyParams(ctx, deps, engine)
 	if len(a.cfg.Strategy.Active) > 0 {
 		if err := engine.SetActiveNames(a.cfg.Strategy.Active); err != nil {
 			a.logger.WarnContext(ctx, "failed to set active strategies, engine will idle",
 				slog.Any("active", a.cfg.Strategy.Active),
 				slog.String("error", err.Error()),
 			)
-		} else {
-			g.Go(func() error {
-				return engine.RunAll(ctx)
-			})
 		}
 	} else {
 		if err := engine.SetActive(a.cfg.Strategy.Name); err != nil {
@@ -302,93 +642,194 @@ func (a *App) FullMode(ctx context.Context, deps *Dependencies) error {
 				slog.String("strategy", a.cfg.Strategy.Name),
 				slog.String("error", err.Error()),
 			)
-		} else {
-			g.Go(func() error {
-				return engine.Run(ctx)
-			})
 		}
 	}
+	a.startMarketLists(ctx, g, deps)
+	// Watched assets: their books are seeded from REST before strategies
+	// start, then streamed by the WS feed below.
+	var assetIDs []string
+	if deps.MarketStore != nil && a.cfg.Polymarket.WsHost != "" {
+		assetIDs = a.watchAssetIDs(ctx, deps.MarketStore, 100)
+	}
+	a.warmCaches(ctx, deps, assetIDs)
+	a.startShadow(ctx, g, deps, engine)
+
+	// The engine always runs so strategies can be enabled later via /api/strategy/bulk.
+	g.Go(func() error {
+		return engine.RunAll(ctx)
+	})
 
 	// Engine feeder: subscribe to "prices" and feed engine.
 	engineFeeder := feed.NewEngineFeeder(deps.SignalBus, deps.BookCache, engine, a.logger)
+	if sampler := a.eventSampler(deps); sampler != nil {
+		engineFeeder.WithSampler(sampler)
+	}
 	g.Go(func() error {
 		return engineFeeder.Run(ctx)
 	})
+	a.startBookRecorder(ctx, g, deps)
+	a.startBookSnapshots(ctx, g, deps, assetIDs)
+	a.startCandles(ctx, g, deps)
+	a.startFeatures(ctx, g, sd)
+	a.startToxicity(ctx, g, sd)
+	a.startTradeAnalytics(ctx, g, sd)
+	a.startPriceFeed(ctx, g, sd)
+	a.startSignals(ctx, g, deps, engine)
+	a.startHindsight(ctx, g, deps)
+	a.startPerformance(ctx, g, deps)
+	a.startCapital(ctx, g, deps)
+	a.startStrategyBreakers(ctx, g, deps, engine)
+	a.startHeatmap(ctx, g, deps, engine)
+	a.startCancelRatio(ctx, g, sd)
+	a.startRewardsTracker(ctx, g, sd)
+	a.startMarketMatcher(ctx, g, deps, sd)
+	a.startNotifier(ctx, g, deps)
+	a.startProfitSweep(ctx, g, deps)
+	if alertSvc := a.newAlertService(deps); alertSvc != nil {
+		g.Go(func() error {
+			return alertSvc.Run(ctx)
+		})
+	}
 
 	// Polymarket WS feed: push book/price into PriceService and engine.
-	if deps.MarketStore != nil && a.cfg.Polymarket.WsHost != "" {
-		assetIDs := a.watchAssetIDs(ctx, deps.MarketStore, 100)
-		if len(assetIDs) > 0 {
-			wsFeed := feed.NewPolymarketWSFeed(
-				a.cfg.Polymarket.WsHost,
-				assetIDs,
-				func(ctx context.Context, snap domain.OrderbookSnapshot) {
-					_ = priceSvc.HandleBookUpdate(ctx, snap)
-					_ = engine.HandleBookUpdate(ctx, snap)
-				},
-				func(ctx context.Context, change domain.PriceChange) {
-					_ = priceSvc.HandlePriceChange(ctx, change)
-					_ = engine.HandlePriceChange(ctx, change)
-				},
-				a.logger,
-			)
-			g.Go(func() error {
-				defer wsFeed.Close()
-				return wsFeed.Run(ctx)
+	if len(assetIDs) > 0 {
+		wsFeed := feed.NewPolymarketWSFeed(
+			a.cfg.Polymarket.WsHost,
+			assetIDs,
+			func(ctx context.Context, snap domain.OrderbookSnapshot) {
+				if sd.features != nil {
+					sd.features.OnBook(snap)
+				}
+				_ = priceSvc.HandleBookUpdate(ctx, snap)
+				_ = engine.HandleBookUpdate(ctx, snap)
+			},
+			func(ctx context.Context, change domain.PriceChange) {
+				_ = priceSvc.HandlePriceChange(ctx, change)
+				_ = engine.HandlePriceChange(ctx, change)
+			},
+			a.logger,
+		).WithBus(deps.SignalBus).WithAudit(deps.AuditStore).WithSilenceTimeout(a.cfg.Polymarket.WsSilenceTimeout.Duration)
+		wsFeed.WithWatchdog(a.cfg.Polymarket.WsReconnectAfter.Duration, a.cfg.Polymarket.WsAssetStaleTimeout.Duration).
+			WithRESTFallback(a.newClobClient(deps, nil), a.cfg.Polymarket.RestPollInterval.Duration)
+		if a.cfg.Candles.Enabled || sd.features != nil || sd.toxicity != nil {
+			wsFeed.WithLastTrade(func(ctx context.Context, trade domain.LastTradePrice) {
+				if sd.features != nil {
+					sd.features.OnTrade(trade)
+				}
+				if sd.toxicity != nil {
+					sd.toxicity.OnTrade(trade)
+				}
+				if a.cfg.Candles.Enabled {
+					_ = priceSvc.HandleLastTrade(ctx, trade)
+				}
 			})
 		}
+		wsFeed.AddListener(engine)
+		a.marketFeed = wsFeed
+		g.Go(func() error {
+			defer wsFeed.Close()
+			return wsFeed.Run(ctx)
+		})
 	}
 
 	// BondTracker: poll open bond positions and update on resolution.
 	if deps.BondPositionStore != nil && sd != nil && sd.gammaClient != nil {
 		bondTracker := service.NewBondTracker(deps.BondPositionStore, sd.gammaClient, deps.SignalBus, 2*time.Minute, a.logger)
+		if deps.OrderStore != nil {
+			bondTracker.WithFills(deps.OrderStore, deps.MarketStore)
+		}
 		g.Go(func() error {
 			return bondTracker.Run(ctx)
 		})
 	}
 
+	// Executor: reads signals and places orders through the full execution
+	// pipeline. With strategy.auto_execute false the engine's signals are
+	// held as ranked candidates instead, and only those executed from the
+	// API reach the executor.
+	execCh := signalCh
+	var candidates *service.CandidateService
 	if !a.cfg.Strategy.AutoExecute {
-		a.logger.InfoContext(ctx, "strategy.auto_execute is false; bot will scan and publish candidates only")
+		a.logger.InfoContext(ctx, "strategy.auto_execute is false; signals are held as candidates and executed on request")
+		execCh = make(chan domain.TradeSignal, 32)
+		candidates = a.newCandidateService(deps)
+	}
+	exec, execErr := a.buildExecutor(ctx, deps, execCh, sd)
+	if execErr != nil {
+		a.logger.WarnContext(ctx, "full mode: executor build failed, falling back to log-only",
+			slog.String("error", execErr.Error()),
+		)
 		g.Go(func() error {
 			for {
 				select {
 				case <-ctx.Done():
 					return ctx.Err()
-				case _, ok := <-signalCh:
+				case sig, ok := <-execCh:
 					if !ok {
 						return nil
 					}
+					a.logger.InfoContext(ctx, "trade signal received (no executor)",
+						slog.String("signal_id", sig.ID),
+						slog.String("source", sig.Source),
+					)
 				}
 			}
 		})
 	} else {
-		// Executor: reads signals and places orders through the full execution pipeline.
-		exec, execErr := a.buildExecutor(ctx, deps, signalCh, sd)
-		if execErr != nil {
-			a.logger.WarnContext(ctx, "full mode: executor build failed, falling back to log-only",
-				slog.String("error", execErr.Error()),
-			)
+		g.Go(func() error {
+			return exec.Run(ctx)
+		})
+		if a.portfolioRisk != nil {
 			g.Go(func() error {
-				for {
-					select {
-					case <-ctx.Done():
-						return ctx.Err()
-					case sig, ok := <-signalCh:
-						if !ok {
-							return nil
-						}
-						a.logger.InfoContext(ctx, "trade signal received (no executor)",
-							slog.String("signal_id", sig.ID),
-							slog.String("source", sig.Source),
-						)
-					}
-				}
+				return a.portfolioRisk.Run(ctx)
 			})
-		} else {
+		}
+		if a.latency != nil {
+			g.Go(func() error {
+				return a.latency.Run(ctx)
+			})
+		}
+		if a.marketWatcher != nil {
+			a.marketWatcher.AddListener(engine)
+			g.Go(func() error {
+				return a.marketWatcher.Run(ctx)
+			})
+		}
+		if a.resolutionWatcher != nil {
+			g.Go(func() error {
+				return a.resolutionWatcher.Run(ctx)
+			})
+		}
+		if a.redemption != nil {
+			g.Go(func() error {
+				return a.redemption.Run(ctx)
+			})
+		}
+		if a.orderJanitor != nil {
 			g.Go(func() error {
-				return exec.Run(ctx)
+				return a.orderJanitor.Run(ctx)
 			})
 		}
+		if a.unhedgedMonitor != nil {
+			a.unhedgedMonitor.WithSignals(execCh)
+			g.Go(func() error {
+				return a.unhedgedMonitor.Run(ctx)
+			})
+		}
+		if a.userFeed != nil {
+			g.Go(func() error {
+				return a.userFeed.Run(ctx)
+			})
+		}
+	}
+	if candidates != nil {
+		if execErr == nil {
+			candidates.WithRoute(execCh)
+		}
+		a.candidates = candidates
+		g.Go(func() error {
+			return candidates.Run(ctx, signalCh)
+		})
 	}
 
 	// Relation discovery (one-shot).
@@ -402,7 +843,7 @@ func (a *App) FullMode(ctx context.Context, deps *Dependencies) error {
 		}()
 	}
 
-	// Arb detection if enabled: run selected arbitrage strategy detector.
+	// Arb detection if enabled: run the detector of the selected arbitrage strategies.
 	if a.cfg.Arbitrage.Enabled && deps.ArbStore != nil {
 		arbCfg := service.ArbConfig{
 			MinNetEdgeBps:       a.cfg.Arbitrage.MinNetEdgeBps,
@@ -412,19 +853,23 @@ func (a *App) FullMode(ctx context.Context, deps *Dependencies) error {
 			MaxUnhedgedNotional: a.cfg.Arbitrage.MaxUnhedgedNotional,
 			KillSwitchLossUSD:   a.cfg.Arbitrage.KillSwitchLossUSD,
 			PerVenueFeeBps:      a.cfg.Arbitrage.PerVenueFeeBps,
+			OpportunityTTL:      a.cfg.Arbitrage.OpportunityTTL.Duration,
 		}
 		arbSvc := service.NewArbService(deps.ArbStore, deps.SignalBus, deps.AuditStore, arbCfg, a.logger)
-		arbStrategy, err := a.newArbStrategy(a.cfg.Arbitrage, a.logger)
+		arbStrategies, err := a.newArbStrategies(a.cfg.Arbitrage, a.logger)
 		if err != nil {
 			a.logger.WarnContext(ctx, "full mode: arb strategy disabled",
 				slog.String("error", err.Error()),
 			)
 		} else {
 			det := arbitrage.NewDetector(arbitrage.DetectorConfig{
-				Strategy:  arbStrategy,
-				ArbSvc:    arbSvc,
-				BookCache: deps.BookCache,
-				Logger:    a.logger,
+				Strategies:    arbStrategies,
+				ArbSvc:        arbSvc,
+				BookCache:     deps.BookCache,
+				Opportunities: deps.OpportunityRegistry,
+				DedupWindow:   a.cfg.Arbitrage.OpportunityDedupWindow.Duration,
+				Fees:          a.feeModel(deps),
+				Logger:        a.logger,
 			})
 			g.Go(func() error {
 				return det.Run(ctx, deps.SignalBus)
@@ -432,18 +877,21 @@ func (a *App) FullMode(ctx context.Context, deps *Dependencies) error {
 		}
 	}
 
-	// Full mode always includes pipeline workers; trigger channel allows POST /api/pipeline/trigger to run one cycle.
+	// Full mode always includes pipeline workers; the run queue lets POST /api/pipeline/trigger run one cycle.
 	if !a.cfg.Pipeline.Enabled {
 		a.logger.WarnContext(ctx, "pipeline.enabled is false, but full mode runs the pipeline by design")
 	}
-	pipelineTriggerCh := make(chan struct{}, 1)
-	if err := a.startDataPipeline(ctx, g, deps, pipelineTriggerCh); err != nil {
+	var pipelineQueue *service.PipelineQueue
+	if deps.PipelineRunStore != nil {
+		pipelineQueue = service.NewPipelineQueue(deps.PipelineRunStore, a.logger)
+	}
+	if err := a.startDataPipeline(ctx, g, deps, pipelineQueue); err != nil {
 		return fmt.Errorf("full mode: %w", err)
 	}
 
 	// HTTP server.
 	if a.cfg.Server.Enabled {
-		a.startHTTPServer(ctx, g, deps, pipelineTriggerCh, engine, engine)
+		a.startHTTPServer(ctx, g, deps, pipelineQueue, engine, engine)
 	}
 
 	return g.Wait()
@@ -452,14 +900,16 @@ func (a *App) FullMode(ctx context.Context, deps *Dependencies) error {
 // startHTTPServer adds an HTTP server goroutine to the given errgroup. It
 // registers the WebSocket hub plus available REST handlers. The server is
 // shut down gracefully when the context is cancelled.
-// pipelineTriggerCh is optional; when non-nil, POST /api/pipeline/trigger will send on it to request one pipeline run.
-// strategyCtrl is optional; when non-nil (trade/full mode), GET/POST /api/strategy/active and GET /api/strategy/list are registered.
-// strategySignals is optional; when non-nil, GET /api/strategy/candidates returns ranked manual-bet candidates.
+// GET /api/config and POST /api/config/reload are always registered behind
+// middleware.Admin, as are the cache namespace endpoints when Redis is wired.
+// pipelineQueue is optional; when non-nil, POST /api/pipeline/trigger queues a run and GET /api/pipeline/runs reports run history.
+// strategyCtrl is optional; when non-nil (trade/full mode), GET/POST /api/strategy/active, GET /api/strategy/list, POST /api/strategy/bulk, PUT /api/strategy/{name}/params and the /api/strategy/{name}/versions endpoints are registered.
+// strategySignals is optional; when non-nil, GET /api/strategy/candidates returns ranked manual-bet candidates, held by a.candidates and executable via POST /api/strategy/candidates/{id}/execute when strategy.auto_execute is false.
 func (a *App) startHTTPServer(
 	ctx context.Context,
 	g *errgroup.Group,
 	deps *Dependencies,
-	pipelineTriggerCh chan<- struct{},
+	pipelineQueue *service.PipelineQueue,
 	strategyCtrl handler.StrategyRuntimeController,
 	strategySignals handler.StrategySignalProvider,
 ) {
@@ -467,20 +917,76 @@ func (a *App) startHTTPServer(
 
 	mux := http.NewServeMux()
 
-	// Health — always available.
-	health := handler.NewHealthHandler(a.logger)
+	// Admin endpoints take server.admin_token, or the admin role when
+	// server.auth is enabled (see apiRouteRoles).
+	admin := middleware.Admin(a.cfg.Server.AdminToken)
+	if a.cfg.Server.Auth.Enabled {
+		admin = func(h http.Handler) http.Handler { return h }
+	}
+
+	// Health — always available. Liveness watches the executor only;
+	// readiness also probes the backing services and the CLOB.
+	health := handler.NewHealthHandler(a.logger).WithProbes(deps.Probes...)
+	if a.cfg.Polymarket.ClobHost != "" {
+		health.WithProbes(domain.HealthProbe{Name: "clob", Check: a.newClobClient(deps, nil).Ping})
+	}
+	if a.marketFeed != nil {
+		health.WithFeeds(a.marketFeed)
+	}
+	if a.executor != nil {
+		health.WithExecutor(a.executor)
+	}
 	mux.HandleFunc("GET /api/health", health.HealthCheck)
+	mux.HandleFunc("GET /api/health/live", health.Live)
+	mux.HandleFunc("GET /api/health/ready", health.Ready)
 
 	// Status — mode and strategy for dashboard (REST fallback when WS status not yet received).
 	statusH := handler.NewStatusHandler(a.cfg.Mode, a.cfg.Strategy.Name)
 	mux.HandleFunc("GET /api/status", statusH.GetStatus)
 
+	// Config introspection and reload — admin-only.
+	ch := handler.NewConfigHandler(runtimeConfig{
+		app:           a,
+		deps:          deps,
+		pipelineQueue: pipelineQueue,
+		strategies:    strategyCtrl != nil,
+	}, a.logger)
+	if sp, ok := strategyCtrl.(handler.StrategyParamsReporter); ok {
+		ch.WithStrategies(sp)
+	}
+	mux.Handle("GET /api/config", admin(http.HandlerFunc(ch.GetConfig)))
+	rlh := handler.NewConfigReloadHandler(configReloader{app: a, deps: deps}, a.logger)
+	mux.Handle("POST /api/config/reload", admin(http.HandlerFunc(rlh.Reload)))
+
+	// Cache namespaces — admin-only; flushes one Redis namespace at a time.
+	if deps.Keyspace != nil {
+		cah := handler.NewCacheHandler(deps.Keyspace, a.logger)
+		mux.Handle("GET /api/admin/cache/namespaces", admin(http.HandlerFunc(cah.ListNamespaces)))
+		mux.Handle("DELETE /api/admin/cache/namespaces/{namespace}", admin(http.HandlerFunc(cah.FlushNamespace)))
+	}
+
 	// WebSocket hub — requires only Redis SignalBus.
 	hub := ws.NewHub(deps.SignalBus, a.logger, ws.Config{
-		Mode:         a.cfg.Mode,
-		StrategyName: a.cfg.Strategy.Name,
-		StartedAt:    time.Now().UTC(),
+		Mode:           a.cfg.Mode,
+		StrategyName:   a.cfg.Strategy.Name,
+		StartedAt:      time.Now().UTC(),
+		Token:          a.cfg.Server.WSToken,
+		ACL:            a.cfg.Server.WSACL,
+		AllowedOrigins: a.cfg.Server.CORSOrigins,
+		FlushInterval:  a.cfg.Server.WSFlushInterval.Duration,
+		MaxBatch:       a.cfg.Server.WSMaxBatch,
 	})
+	// Snapshot queries over /ws: recent signals and open positions.
+	if strategySignals != nil {
+		hub.WithSignals(strategySignals)
+	}
+	if deps.PositionStore != nil {
+		wallet := ""
+		if signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID); err == nil {
+			wallet = signer.Address().Hex()
+		}
+		hub.WithPositions(deps.PositionStore, wallet)
+	}
 	mux.HandleFunc("GET /ws", hub.HandleWS)
 
 	g.Go(func() error {
@@ -488,11 +994,177 @@ func (a *App) startHTTPServer(
 	})
 
 	if strategyCtrl != nil {
-		srh := handler.NewStrategyRuntimeHandler(strategyCtrl, hub, a.logger)
+		srh := handler.NewStrategyRuntimeHandler(strategyCtrl, hub, a.logger).WithAudit(deps.AuditStore)
 		mux.HandleFunc("GET /api/strategy/active", srh.GetActive)
 		mux.HandleFunc("GET /api/strategy/list", srh.List)
 		mux.HandleFunc("POST /api/strategy/active", srh.SetActive)
+		if bc, ok := strategyCtrl.(handler.StrategyBulkController); ok {
+			bh := handler.NewStrategyBulkHandler(bc, hub, a.logger).WithAudit(deps.AuditStore)
+			mux.HandleFunc("POST /api/strategy/bulk", bh.Bulk)
+		}
+		if rp, ok := strategyCtrl.(handler.StrategyResourceProvider); ok {
+			rh := handler.NewStrategyResourcesHandler(rp)
+			mux.HandleFunc("GET /api/strategy/resources", rh.Resources)
+		}
+		if rc, ok := strategyCtrl.(service.StrategyReconfigurer); ok {
+			paramSvc := service.NewStrategyParamService(deps.StratCfgStore, rc, a.logger).WithAudit(deps.AuditStore)
+			sph := handler.NewStrategyParamsHandler(paramSvc, a.logger)
+			if deps.StratCfgStore != nil {
+				sph.WithVersions(paramSvc)
+			}
+			mux.HandleFunc("PUT /api/strategy/{name}/params", sph.UpdateParams)
+			mux.HandleFunc("GET /api/strategy/{name}/versions", sph.ListVersions)
+			mux.HandleFunc("POST /api/strategy/{name}/versions/{version}/rollback", sph.Rollback)
+		}
+		if bc, ok := strategyCtrl.(handler.StrategyBreakerController); ok {
+			shh := handler.NewStrategyHealthHandler(bc, a.logger)
+			if qr, ok := strategyCtrl.(handler.StrategyQueueReporter); ok {
+				shh.WithQueues(qr)
+			}
+			mux.HandleFunc("GET /api/strategy/health", shh.Health)
+			mux.HandleFunc("POST /api/strategy/{name}/enable", shh.Enable)
+		}
+	}
+
+	// Strategy hindsight — 501 unless hindsight.enabled is set and Postgres is wired.
+	var hindsight handler.HindsightReporter
+	if a.hindsight != nil {
+		hindsight = a.hindsight
+	}
+	hh := handler.NewStrategyHindsightHandler(hindsight, a.logger)
+	mux.HandleFunc("GET /api/strategy/{name}/hindsight", hh.Hindsight)
+
+	// Shadow candidates — 501 without Postgres.
+	sch := handler.NewStrategyShadowHandler(a.logger)
+	if deps.ShadowCandidateStore != nil {
+		sch = sch.WithStore(deps.ShadowCandidateStore)
+	}
+	mux.HandleFunc("GET /api/strategy/{name}/shadow", sch.Candidates)
+
+	// Instrument registry — identifier resolution and venue links; 501 without Postgres.
+	instruments := a.instrumentRegistry(deps)
+	ih := handler.NewInstrumentHandler(a.logger)
+	if instruments != nil {
+		ih = ih.WithRegistry(instruments)
+	}
+	mux.HandleFunc("GET /api/instruments", ih.ListInstruments)
+	mux.HandleFunc("GET /api/instruments/resolve", ih.ResolveInstrument)
+	mux.HandleFunc("POST /api/instruments/lookup", ih.LookupInstruments)
+	mux.HandleFunc("GET /api/instruments/{id}", ih.GetInstrument)
+	mux.HandleFunc("PUT /api/instruments/{id}/identifiers/{kind}", ih.LinkIdentifier)
+
+	// Cross-venue market matches — review works without a running matcher;
+	// 501 without Postgres.
+	cmh := handler.NewCrossMapHandler(a.logger)
+	if a.marketMatcher != nil {
+		cmh = cmh.WithService(a.marketMatcher)
+	} else if mm := a.newMarketMatcher(deps, instruments); mm != nil {
+		cmh = cmh.WithService(mm)
+	}
+	mux.HandleFunc("GET /api/crossmap", cmh.ListMatches)
+	mux.HandleFunc("POST /api/crossmap", cmh.PostMatch)
+
+	// Market heat map — 501 unless the aggregator runs in this mode.
+	hmh := handler.NewHeatmapHandler(a.logger)
+	if a.heatmap != nil {
+		hmh = hmh.WithSource(a.heatmap)
+	}
+	mux.HandleFunc("GET /api/heatmap", hmh.Heatmap)
+
+	// Cancel ratios — 501 unless the tracker runs in this mode.
+	crh := handler.NewCancelRatioHandler(a.logger)
+	if a.cancelRatio != nil {
+		crh = crh.WithSource(a.cancelRatio)
+	}
+	mux.HandleFunc("GET /api/orders/cancel-ratio", crh.CancelRatio)
+
+	// Operator market blacklist/whitelist — 501 without Postgres.
+	mlh := handler.NewMarketListHandler(a.logger)
+	if lists := a.marketLists(deps); lists != nil {
+		mlh = mlh.WithSource(lists)
+	}
+	mux.HandleFunc("GET /api/markets/lists", mlh.Lists)
+	mux.HandleFunc("POST /api/markets/{id}/{list}", mlh.Add)
+	mux.HandleFunc("DELETE /api/markets/{id}/{list}", mlh.Remove)
+
+	// Recorded signals and their outcomes — 501 without Postgres.
+	sgh := handler.NewSignalsHandler(a.logger)
+	if deps.SignalStore != nil {
+		sgh = sgh.WithStore(deps.SignalStore)
+	}
+	mux.HandleFunc("GET /api/signals", sgh.List)
+
+	// Venue and Goldsky failures by error class — 501 when retry is off.
+	eh := handler.NewErrorsHandler(a.logger)
+	if deps.Retrier != nil {
+		eh = eh.WithSource(deps.Retrier)
+	}
+	mux.HandleFunc("GET /api/errors", eh.Errors)
+
+	// Signal-to-fill latency by stage — 501 unless the executor runs with
+	// latency.enabled. /metrics also carries the executor's queue depths and,
+	// when the pipeline runs here, trade ingestion counts.
+	lh := handler.NewLatencyHandler(a.logger)
+	mh := handler.NewMetricsHandler(a.logger)
+	if a.latency != nil {
+		lh = lh.WithSource(a.latency)
+		mh = mh.WithLatency(a.latency)
+	}
+	if a.executor != nil {
+		mh = mh.WithExecutorQueues(a.executor)
+	}
+	if a.trades != nil {
+		mh = mh.WithTradeIngest(a.trades)
+	}
+	mux.HandleFunc("GET /api/latency", lh.Latency)
+	mux.HandleFunc("GET /metrics", mh.Metrics)
+
+	// Builder-program rebates — 501 without [builder] credentials.
+	brh := handler.NewBuilderRewardsHandler(a.logger)
+	if a.builderAuth() != nil && a.cfg.Polymarket.ClobHost != "" {
+		brh = brh.WithSource(service.NewBuilderRewardsService(a.newClobClient(deps, nil), 0, a.logger))
+	}
+	mux.HandleFunc("GET /api/rewards/builder", brh.BuilderRewards)
+
+	// Liquidity reward uptime and accrual — 501 without Postgres and Gamma.
+	lrh := handler.NewLPRewardsHandler(a.logger)
+	if a.rewardsTracker != nil && deps.LPRewardStore != nil {
+		lrh = lrh.WithSource(a.rewardsTracker)
+	}
+	mux.HandleFunc("GET /api/rewards/lp", lrh.LPRewards)
+
+	// Per-market fee schedules — 501 when the fee model is off.
+	fh := handler.NewFeesHandler(a.logger)
+	if fees := a.feeModel(deps); fees != nil {
+		fh = fh.WithSource(fees)
+	}
+	mux.HandleFunc("GET /api/fees/{market}", fh.GetFees)
+
+	wh := handler.NewWalletHandler(a.logger)
+	if guard := a.balanceGuard(deps); guard != nil {
+		wh = wh.WithSource(guard)
+	}
+	mux.HandleFunc("GET /api/wallet/balances", wh.Balances)
+
+	// Per-strategy capital budgets — 501 when capital.enabled is off.
+	cph := handler.NewCapitalHandler(a.logger)
+	if capital := a.capitalAllocator(deps); capital != nil {
+		cph = cph.WithSource(capital)
+	}
+	mux.HandleFunc("GET /api/capital", cph.Capital)
+
+	// Activity timeline for incident review — 501 without Postgres.
+	tlh := handler.NewTimelineHandler(a.logger)
+	if deps.TimelineStore != nil {
+		tlh = tlh.WithService(service.NewTimelineService(deps.TimelineStore))
+	}
+	mux.HandleFunc("GET /api/timeline", tlh.Timeline)
+
+	ah := handler.NewAuditHandler(a.logger)
+	if deps.AuditStore != nil {
+		ah = ah.WithService(service.NewAuditQueryService(deps.AuditStore))
 	}
+	mux.HandleFunc("GET /api/audit", ah.List)
 
 	// Register store-backed handlers only when Postgres is wired.
 	var marketResolver handler.StrategyCandidateMarketResolver
@@ -500,8 +1172,30 @@ func (a *App) startHTTPServer(
 		marketSvc := service.NewMarketService(deps.MarketStore, deps.MarketCache, deps.SignalBus, a.logger)
 		marketResolver = marketSvc
 		mh := handler.NewMarketHandler(marketSvc, a.logger)
+		if instruments != nil {
+			mh.WithInstruments(instruments)
+		}
 		mux.HandleFunc("GET /api/markets", mh.ListMarkets)
 		mux.HandleFunc("GET /api/markets/{id}", mh.GetMarket)
+		if deps.BookEventStore != nil {
+			brh := handler.NewBookReplayHandler(marketSvc, deps.BookEventStore, a.logger)
+			mux.HandleFunc("GET /api/markets/{id}/book-replay", brh.Replay)
+		}
+		if deps.CandleStore != nil {
+			candleSvc := service.NewCandleService(deps.SignalBus, deps.CandleStore, service.CandleConfig{}, a.logger)
+			ch := handler.NewCandlesHandler(marketSvc, candleSvc, a.logger)
+			mux.HandleFunc("GET /api/markets/{id}/candles", ch.Candles)
+		}
+		tah := handler.NewMarketAnalyticsHandler(marketSvc, a.logger)
+		if a.cfg.TradeAnalytics.Enabled && deps.TradeAnalyticsCache != nil {
+			tah.WithSource(service.NewTradeAnalytics(deps.SignalBus, deps.TradeAnalyticsCache, service.TradeAnalyticsConfig{}, a.logger))
+		}
+		mux.HandleFunc("GET /api/markets/{id}/analytics", tah.Analytics)
+		txh := handler.NewMarketToxicityHandler(marketSvc, a.logger)
+		if a.cfg.Toxicity.Enabled && deps.ToxicityCache != nil {
+			txh.WithSource(analytics.NewToxicityTracker(deps.ToxicityCache, analytics.ToxicityConfig{}, a.logger))
+		}
+		mux.HandleFunc("GET /api/markets/{id}/toxicity", txh.Toxicity)
 	}
 
 	if strategySignals != nil {
@@ -511,8 +1205,12 @@ func (a *App) startHTTPServer(
 			marketResolver,
 			a.cfg.Strategy.AutoExecute,
 			a.logger,
-		)
+		).WithAudit(deps.AuditStore)
+		if a.candidates != nil {
+			sc.WithRanker(a.candidates)
+		}
 		mux.HandleFunc("GET /api/strategy/candidates", sc.ListCandidates)
+		mux.HandleFunc("POST /api/strategy/candidates/{id}/execute", sc.ExecuteCandidate)
 	}
 
 	if deps.OrderStore != nil && deps.PositionStore != nil {
@@ -522,7 +1220,7 @@ func (a *App) startHTTPServer(
 				slog.String("error", err.Error()),
 			)
 		} else {
-			clobClient := polymarket.NewClobClient(a.cfg.Polymarket.ClobHost, signer, nil)
+			clobClient := a.newClobClient(deps, signer)
 			if err := clobClient.DeriveAPIKey(ctx); err != nil {
 				a.logger.WarnContext(ctx, "HTTP server: derive API key failed; order submission may fail",
 					slog.String("error", err.Error()),
@@ -533,9 +1231,21 @@ func (a *App) startHTTPServer(
 				deps.OrderStore, deps.PositionStore, deps.BookCache,
 				deps.PriceCache, deps.RateLimiter, deps.SignalBus,
 				deps.AuditStore, signer, a.logger,
-			)
+			).WithOrderRate(a.cfg.RateLimit.OrdersPerSecond).WithCollateralDecimals(a.collateralDecimals())
+			if inc := a.orderIncrements(deps); inc != nil {
+				orderSvc.WithIncrements(inc)
+			}
 			if clobClient != nil {
 				orderSvc.WithClobClient(clobClient)
+				if guard := a.balanceGuard(deps); guard != nil && a.cfg.Polygon.BalanceCheck {
+					orderSvc.WithFundsCheck(guard)
+				}
+			}
+			// Dry runs (POST /api/orders?dry_run=true) preview the executor's
+			// risk checks and the market's fees.
+			orderSvc.WithRisk(a.riskService(deps))
+			if fees := a.feeModel(deps); fees != nil {
+				orderSvc.WithFees(fees)
 			}
 			oh := handler.NewOrderHandler(orderSvc, a.logger)
 			mux.HandleFunc("GET /api/orders", oh.ListOrders)
@@ -544,6 +1254,33 @@ func (a *App) startHTTPServer(
 		}
 	}
 
+	// Positions and PnL — when PositionStore is wired.
+	if deps.PositionStore != nil {
+		positionSvc := service.NewPositionService(
+			deps.PositionStore, deps.PriceCache, deps.SignalBus, deps.AuditStore, a.logger,
+		)
+		poh := handler.NewPositionHandler(positionSvc, a.logger)
+		mux.HandleFunc("GET /api/positions", poh.ListPositions)
+		mux.HandleFunc("GET /api/positions/{id}", poh.GetPosition)
+		mux.HandleFunc("GET /api/pnl/summary", poh.PnLSummary)
+	}
+
+	// Strategy performance attribution, persisted daily by startPerformance.
+	if deps.PerformanceStore != nil {
+		pfh := handler.NewPerformanceHandler(
+			service.NewPerformanceService(deps.PerformanceStore, a.performanceConfig(), a.logger), a.logger,
+		)
+		mux.HandleFunc("GET /api/performance/strategies", pfh.Strategies)
+		mux.HandleFunc("GET /api/performance/daily", pfh.Daily)
+	}
+
+	// Accounting exports — lot-level realized PnL from recorded fills.
+	if deps.OrderStore != nil {
+		lotSvc := service.NewLotService(deps.OrderStore, domain.LotMethod(a.cfg.Accounting.LotMethod), a.logger)
+		eh := handler.NewExportHandler(lotSvc, a.logger)
+		mux.HandleFunc("GET /api/export/lots", eh.ExportLots)
+	}
+
 	if deps.ArbStore != nil {
 		arbSvc := service.NewArbService(deps.ArbStore, deps.SignalBus, deps.AuditStore,
 			service.ArbConfig{
@@ -560,14 +1297,41 @@ func (a *App) startHTTPServer(
 		mux.HandleFunc("GET /api/arbitrage/profit", ah.Profit)
 		mux.HandleFunc("GET /api/arbitrage/executions", ah.ListExecutions)
 		mux.HandleFunc("GET /api/arbitrage/executions/{id}", ah.GetExecution)
+		mux.HandleFunc("GET /api/arbitrage/stats", ah.Stats)
 	}
 
-	// Pipeline trigger — when pipelineTriggerCh is set, trigger requests one run.
+	// Pipeline trigger and run history — when pipelineQueue is set; 501 otherwise.
+	// Dead-lettered fill batches — when the pipeline runs in this process.
 	ph := handler.NewPipelineHandler(a.logger)
-	if pipelineTriggerCh != nil {
-		ph = ph.WithTriggerChannel(pipelineTriggerCh)
+	if pipelineQueue != nil {
+		ph = ph.WithRuns(pipelineQueue)
+	}
+	if a.deadLetters != nil {
+		ph = ph.WithDeadLetters(a.deadLetters)
 	}
 	mux.HandleFunc("POST /api/pipeline/trigger", ph.TriggerPipeline)
+	mux.HandleFunc("GET /api/pipeline/runs", ph.ListRuns)
+	mux.HandleFunc("GET /api/pipeline/runs/{id}", ph.GetRun)
+	mux.HandleFunc("GET /api/pipeline/dlq", ph.ListDeadLetters)
+	mux.HandleFunc("POST /api/pipeline/dlq/retry", ph.RetryDeadLetters)
+
+	// Alerts CRUD — when AlertStore is wired. Evaluation runs in trade/full mode;
+	// changes made here are picked up live via the "alerts" channel.
+	if alertSvc := a.newAlertService(deps); alertSvc != nil {
+		alh := handler.NewAlertHandler(alertSvc, a.logger)
+		mux.HandleFunc("GET /api/alerts", alh.ListAlerts)
+		mux.HandleFunc("POST /api/alerts", alh.CreateAlert)
+		mux.HandleFunc("GET /api/alerts/{id}", alh.GetAlert)
+		mux.HandleFunc("PUT /api/alerts/{id}", alh.UpdateAlert)
+		mux.HandleFunc("DELETE /api/alerts/{id}", alh.DeleteAlert)
+	}
+
+	// Portfolio risk — when an executor is running (trade/full mode).
+	if a.portfolioRisk != nil {
+		rh := handler.NewRiskHandler(a.portfolioRisk, a.logger)
+		mux.HandleFunc("GET /api/risk", rh.GetRisk)
+		mux.HandleFunc("POST /api/risk/reset", rh.Reset)
+	}
 
 	// Bond handler — when BondPositionStore is wired.
 	if deps.BondPositionStore != nil {
@@ -577,8 +1341,16 @@ func (a *App) startHTTPServer(
 		mux.HandleFunc("GET /api/bonds/{id}", bh.GetBond)
 	}
 
-	// Middleware chain: CORS then logging.
+	// Middleware chain: auth, CORS, then logging.
 	var h http.Handler = mux
+	if a.cfg.Server.Auth.Enabled {
+		h = a.newAuthorizer(deps).Middleware(func(r *http.Request) string {
+			_, pattern := mux.Handler(r)
+			return pattern
+		})(h)
+	} else {
+		a.logger.WarnContext(ctx, "server.auth disabled: API routes, including orders and strategy changes, are unauthenticated")
+	}
 	if len(a.cfg.Server.CORSOrigins) > 0 {
 		h = middleware.CORS(a.cfg.Server.CORSOrigins)(h)
 	}
@@ -613,13 +1385,545 @@ func (a *App) startHTTPServer(
 	})
 }
 
-func (a *App) newStrategyRegistry(deps *Dependencies, sd *strategyDeps) *strategy.Registry {
-	baseParams := make(map[string]any)
-	if a.cfg.Strategy.Params != nil {
-		for k, v := range a.cfg.Strategy.Params {
-			baseParams[k] = v
-		}
+// startBookRecorder records "prices" events to the BookEventStore for book
+// replay when recorder.enabled is set and Postgres is wired.
+func (a *App) startBookRecorder(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	if !a.cfg.Recorder.Enabled || deps.BookEventStore == nil {
+		return
+	}
+	rec := feed.NewBookRecorder(
+		deps.SignalBus,
+		deps.BookCache,
+		deps.BookEventStore,
+		a.cfg.Recorder.FlushInterval.Duration,
+		a.cfg.Recorder.BatchSize,
+		a.cfg.Recorder.Retention.Duration,
+		a.logger,
+	)
+	g.Go(func() error {
+		return rec.Run(ctx)
+	})
+}
+
+// startBookSnapshots samples books of book_snapshots.assets, or of the
+// watched assetIDs, to S3 when book_snapshots.enabled is set.
+func (a *App) startBookSnapshots(ctx context.Context, g *errgroup.Group, deps *Dependencies, assetIDs []string) {
+	bc := a.cfg.BookSnapshots
+	if !bc.Enabled || deps.BlobWriter == nil {
+		return
+	}
+	assets := bc.Assets
+	if len(assets) == 0 {
+		assets = assetIDs
+	}
+	rec := feed.NewBookSnapshotRecorder(
+		deps.SignalBus,
+		deps.BookCache,
+		deps.BlobWriter,
+		feed.BookSnapshotConfig{
+			Assets:        assets,
+			Interval:      bc.Interval.Duration,
+			MinInterval:   bc.MinInterval.Duration,
+			FlushInterval: bc.FlushInterval.Duration,
+			BatchSize:     bc.BatchSize,
+		},
+		a.logger,
+	)
+	g.Go(func() error {
+		return rec.Run(ctx)
+	})
+}
+
+// startCandles records one-minute price candles from "prices" and "trades"
+// when candles.enabled is set and Postgres is wired.
+func (a *App) startCandles(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	if !a.cfg.Candles.Enabled || deps.CandleStore == nil {
+		return
+	}
+	candles := service.NewCandleService(deps.SignalBus, deps.CandleStore, service.CandleConfig{
+		FlushInterval: a.cfg.Candles.FlushInterval.Duration,
+		Retention:     a.cfg.Candles.Retention.Duration,
+	}, a.logger)
+	g.Go(func() error {
+		return candles.Run(ctx)
+	})
+}
+
+// startFeatures runs the feature tracker's cache flush when features.enabled
+// is set. The tracker itself is fed by the market feed callbacks.
+func (a *App) startFeatures(ctx context.Context, g *errgroup.Group, sd *strategyDeps) {
+	if sd == nil || sd.features == nil {
+		return
+	}
+	g.Go(func() error {
+		return sd.features.Run(ctx)
+	})
+}
+
+// startToxicity runs the toxicity tracker's cache flush when
+// toxicity.enabled is set. The tracker itself is fed by the market feed's
+// trade callback.
+func (a *App) startToxicity(ctx context.Context, g *errgroup.Group, sd *strategyDeps) {
+	if sd == nil || sd.toxicity == nil {
+		return
+	}
+	g.Go(func() error {
+		return sd.toxicity.Run(ctx)
+	})
+}
+
+// startTradeAnalytics consumes the ingested trades into per-market VWAP and
+// volume when trade_analytics.enabled is set.
+func (a *App) startTradeAnalytics(ctx context.Context, g *errgroup.Group, sd *strategyDeps) {
+	if sd == nil || sd.tradeAnalytics == nil {
+		return
+	}
+	g.Go(func() error {
+		return sd.tradeAnalytics.Run(ctx)
+	})
+}
+
+// startPriceFeed polls the spot price tickers when pricefeed.enabled is set.
+func (a *App) startPriceFeed(ctx context.Context, g *errgroup.Group, sd *strategyDeps) {
+	if sd == nil || sd.priceFeed == nil {
+		return
+	}
+	g.Go(func() error {
+		return sd.priceFeed.Run(ctx)
+	})
+}
+
+// eventSampler builds the engine feeder's sampler when strategy.sampling is enabled.
+func (a *App) eventSampler(deps *Dependencies) *feed.Sampler {
+	sc := a.cfg.Strategy.Sampling
+	if !sc.Enabled {
+		return nil
+	}
+	return feed.NewSampler(feed.SamplerConfig{
+		Mode:            feed.SamplingMode(sc.Mode),
+		EveryN:          sc.EveryN,
+		Interval:        sc.Interval.Duration,
+		MinLiquidityUSD: sc.MinLiquidityUSD,
+		DepthBand:       sc.DepthBand,
+		ScoreRefresh:    sc.ScoreRefresh.Duration,
+		Priority:        sc.Priority,
+		Sampled:         sc.Sampled,
+	}, deps.BookCache)
+}
+
+// apiRouteRoles are the routes whose role server.auth does not take from
+// the method: health probes and /ws (which checks its own tokens) are
+// public, and admin endpoints need the admin role.
+var apiRouteRoles = map[string]middleware.Role{
+	"GET /api/health":                                middleware.RolePublic,
+	"GET /api/health/live":                           middleware.RolePublic,
+	"GET /api/health/ready":                          middleware.RolePublic,
+	"GET /ws":                                        middleware.RolePublic,
+	"GET /api/config":                                middleware.RoleAdmin,
+	"POST /api/config/reload":                        middleware.RoleAdmin,
+	"GET /api/admin/cache/namespaces":                middleware.RoleAdmin,
+	"DELETE /api/admin/cache/namespaces/{namespace}": middleware.RoleAdmin,
+	"POST /api/risk/reset":                           middleware.RoleAdmin,
+	"GET /api/audit":                                 middleware.RoleAdmin,
+}
+
+// newAuthorizer builds the server.auth middleware: the configured keys,
+// server.admin_token as an admin key, and apiRouteRoles overridden by
+// server.auth.routes. Mutating calls are audited.
+func (a *App) newAuthorizer(deps *Dependencies) *middleware.Authorizer {
+	auth := a.cfg.Server.Auth
+	keys := make([]middleware.APIKey, 0, len(auth.Keys)+1)
+	for _, k := range auth.Keys {
+		role, _ := middleware.ParseRole(k.Role) // validated by config
+		keys = append(keys, middleware.APIKey{Name: k.Name, Key: k.Key, Role: role})
+	}
+	if a.cfg.Server.AdminToken != "" {
+		keys = append(keys, middleware.APIKey{Name: "admin_token", Key: a.cfg.Server.AdminToken, Role: middleware.RoleAdmin})
+	}
+	routes := make(map[string]middleware.Role, len(apiRouteRoles)+len(auth.Routes))
+	for pattern, role := range apiRouteRoles {
+		routes[pattern] = role
+	}
+	for pattern, name := range auth.Routes {
+		role, _ := middleware.ParseRole(name)
+		routes[pattern] = role
+	}
+	return middleware.NewAuthorizer(keys, auth.JWTSecret, a.logger).
+		WithRoutes(routes).
+		WithAudit(deps.AuditStore)
+}
+
+// newCandidateService builds the holder of manual-trading candidates,
+// persisted to the signal store when Postgres is wired and ranked against
+// the cached books.
+func (a *App) newCandidateService(deps *Dependencies) *service.CandidateService {
+	cc := a.cfg.Strategy.Candidates
+	return service.NewCandidateService(deps.SignalStore, deps.BookCache, service.CandidateConfig{
+		MaxCandidates:     cc.MaxCandidates,
+		MaxAge:            cc.MaxAge.Duration,
+		FreshnessHalfLife: cc.FreshnessHalfLife.Duration,
+		DefaultEdgeBps:    a.cfg.Risk.DefaultEdgeBps,
+	}, a.logger)
+}
+
+// signalRecorder returns the recorder of emitted signals and their
+// outcomes, built on first use, or nil unless signals.record or
+// hindsight.enabled is set and Postgres is wired. startSignals runs it.
+func (a *App) signalRecorder(deps *Dependencies) *service.SignalRecorder {
+	if a.signals != nil || deps.SignalStore == nil || (!a.cfg.Signals.Record && !a.cfg.Hindsight.Enabled) {
+		return a.signals
+	}
+	a.signals = service.NewSignalRecorder(deps.SignalStore,
+		a.cfg.Signals.FlushInterval.Duration, a.cfg.Signals.BatchSize, a.logger)
+	return a.signals
+}
+
+// startSignals records every signal the engine emits, and with the executor
+// what became of it, when the signal recorder is available.
+func (a *App) startSignals(ctx context.Context, g *errgroup.Group, deps *Dependencies, engine *strategy.Engine) {
+	rec := a.signalRecorder(deps)
+	if rec == nil {
+		return
+	}
+	engine.SetSignalRecorder(rec)
+	g.Go(func() error {
+		return rec.Run(ctx)
+	})
+}
+
+// startHindsight periodically scores the signals recorded by startSignals
+// against market outcomes when hindsight.enabled is set and Postgres is
+// wired.
+func (a *App) startHindsight(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	if !a.cfg.Hindsight.Enabled || deps.SignalStore == nil {
+		return
+	}
+	hs := service.NewHindsightService(
+		deps.SignalStore,
+		a.cfg.Hindsight.Horizon.Duration,
+		a.cfg.Hindsight.Window.Duration,
+		a.cfg.Hindsight.Interval.Duration,
+		a.cfg.Hindsight.MaxSignals,
+		a.logger,
+	).WithOrders(deps.OrderStore).WithBooks(deps.BookEventStore)
+	if deps.MarketStore != nil && a.cfg.Polymarket.GammaHost != "" {
+		hs.WithResolutions(deps.MarketStore, a.newGammaClient(deps))
+	}
+	a.hindsight = hs
+	g.Go(func() error {
+		return hs.Run(ctx)
+	})
+}
+
+// startShadow diverts the signals of strategy.shadow strategies from the
+// executor: each is published on ch:shadow and, with Postgres, stored as a
+// shadow candidate.
+func (a *App) startShadow(ctx context.Context, g *errgroup.Group, deps *Dependencies, engine *strategy.Engine) {
+	if len(a.cfg.Strategy.Shadow) == 0 {
+		return
+	}
+	rec := service.NewSignalRecorder(deps.ShadowCandidateStore, 5*time.Second, 200, a.logger)
+	if deps.SignalBus != nil {
+		rec.WithBus(deps.SignalBus, service.ShadowChannel, "shadow_signal")
+	}
+	engine.SetShadow(a.cfg.Strategy.Shadow, rec)
+	g.Go(func() error {
+		return rec.Run(ctx)
+	})
+	a.logger.InfoContext(ctx, "shadow strategies: signals recorded, not executed",
+		slog.Any("strategies", a.cfg.Strategy.Shadow))
+}
+
+// performanceConfig maps the performance settings for PerformanceService.
+func (a *App) performanceConfig() service.PerformanceConfig {
+	return service.PerformanceConfig{
+		Interval:     a.cfg.Performance.Interval.Duration,
+		BackfillDays: a.cfg.Performance.BackfillDays,
+		WindowDays:   a.cfg.Performance.WindowDays,
+		TakerFeeBps:  a.cfg.Arbitrage.PerVenueFeeBps["polymarket"],
+	}
+}
+
+// startPerformance persists per-strategy daily performance attribution when
+// performance.enabled is set and Postgres is wired.
+func (a *App) startPerformance(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	if !a.cfg.Performance.Enabled || deps.PerformanceStore == nil {
+		return
+	}
+	perf := service.NewPerformanceService(deps.PerformanceStore, a.performanceConfig(), a.logger)
+	g.Go(func() error {
+		return perf.Run(ctx)
+	})
+}
+
+// startCapital rebalances strategy budgets by recent performance when
+// capital.enabled is set and capital.rebalance_interval is non-zero.
+func (a *App) startCapital(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	capital := a.capitalAllocator(deps)
+	if capital == nil || a.cfg.Capital.RebalanceInterval.Duration <= 0 {
+		return
+	}
+	if !a.cfg.Performance.Enabled || deps.PerformanceStore == nil {
+		a.logger.WarnContext(ctx, "capital.rebalance_interval is set but performance attribution is off; budgets stay fixed")
+		return
+	}
+	g.Go(func() error {
+		return capital.Run(ctx)
+	})
+}
+
+// startMarketMatcher runs the Polymarket <-> Kalshi market matcher when
+// crossmap.enabled is set. Approved matches are linked in the instrument
+// registry, where cross_platform_arb resolves venue refs.
+func (a *App) startMarketMatcher(ctx context.Context, g *errgroup.Group, deps *Dependencies, sd *strategyDeps) {
+	if !a.cfg.CrossMap.Enabled {
+		return
+	}
+	if sd == nil || sd.kalshiClient == nil {
+		a.logger.WarnContext(ctx, "crossmap.enabled is set but Kalshi credentials are missing; market matcher not started")
+		return
+	}
+	mm := a.newMarketMatcher(deps, sd.instruments)
+	if mm == nil {
+		a.logger.WarnContext(ctx, "crossmap.enabled is set but Postgres is not wired; market matcher not started")
+		return
+	}
+	a.marketMatcher = mm.WithKalshi(sd.kalshiClient)
+	g.Go(func() error {
+		return a.marketMatcher.Run(ctx)
+	})
+}
+
+// newMarketMatcher returns a matcher over the stored matches, or nil when
+// Postgres is not wired. It cannot run matching until WithKalshi is called.
+func (a *App) newMarketMatcher(deps *Dependencies, instruments *service.InstrumentRegistry) *service.MarketMatcher {
+	if deps.CrossMatchStore == nil || deps.MarketStore == nil {
+		return nil
+	}
+	mm := service.NewMarketMatcher(deps.CrossMatchStore, deps.MarketStore, service.MarketMatcherConfig{
+		Interval:      a.cfg.CrossMap.Interval.Duration,
+		MinConfidence: a.cfg.CrossMap.MinConfidence,
+		AutoApprove:   a.cfg.CrossMap.AutoApprove,
+		MaxMarkets:    a.cfg.CrossMap.MaxMarkets,
+	}, a.logger)
+	if instruments != nil {
+		mm.WithInstruments(instruments)
+	}
+	return mm
+}
+
+// startHeatmap runs the market heat map aggregator behind GET /api/heatmap.
+// engine may be nil, in which case signal counts stay zero.
+func (a *App) startHeatmap(ctx context.Context, g *errgroup.Group, deps *Dependencies, engine *strategy.Engine) {
+	if deps.MarketStore == nil || deps.SignalBus == nil {
+		return
+	}
+	a.heatmap = service.NewHeatmapAggregator(deps.MarketStore, deps.SignalBus, a.logger)
+	if engine != nil {
+		engine.AddSignalObserver(a.heatmap)
+	}
+	g.Go(func() error {
+		return a.heatmap.Run(ctx)
+	})
+}
+
+// startMarketLists loads the market lists before the watched assets are
+// chosen and re-reads them in the background.
+func (a *App) startMarketLists(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	lists := a.marketLists(deps)
+	if lists == nil {
+		return
+	}
+	if err := lists.Load(ctx); err != nil {
+		a.logger.WarnContext(ctx, "market lists: initial load failed", slog.String("error", err.Error()))
 	}
+	g.Go(func() error {
+		return lists.Run(ctx)
+	})
+}
+
+// startCancelRatio runs the cancel ratio tracker built by buildStrategyDeps
+// and exposes it behind GET /api/orders/cancel-ratio.
+func (a *App) startCancelRatio(ctx context.Context, g *errgroup.Group, sd *strategyDeps) {
+	if sd == nil || sd.cancelRatio == nil {
+		return
+	}
+	a.cancelRatio = sd.cancelRatio
+	g.Go(func() error {
+		return a.cancelRatio.Run(ctx)
+	})
+}
+
+// startRewardsTracker samples liquidity_provider quotes for reward uptime
+// and accrual and exposes them behind GET /api/rewards/lp.
+func (a *App) startRewardsTracker(ctx context.Context, g *errgroup.Group, sd *strategyDeps) {
+	if sd == nil || sd.rewardsTracker == nil {
+		return
+	}
+	a.rewardsTracker = sd.rewardsTracker
+	g.Go(func() error {
+		return a.rewardsTracker.Run(ctx)
+	})
+}
+
+// startStrategyBreakers enables the engine's per-strategy circuit breakers
+// and feeds them order outcomes and realized PnL from the signal bus.
+func (a *App) startStrategyBreakers(ctx context.Context, g *errgroup.Group, deps *Dependencies, engine *strategy.Engine) {
+	bc := a.cfg.Strategy.Breaker
+	if !bc.Enabled {
+		return
+	}
+	if deps.SignalBus == nil {
+		a.logger.WarnContext(ctx, "strategy circuit breakers disabled: signal bus not configured")
+		return
+	}
+	var notifier strategy.BreakerNotifier
+	if deps.Dispatcher != nil {
+		notifier = deps.Dispatcher
+	} else if deps.Notifier != nil {
+		notifier = deps.Notifier
+	}
+	engine.SetCircuitBreakers(strategy.BreakerConfig{
+		MaxConsecutiveFailures: bc.MaxConsecutiveFailures,
+		MaxLossUSD:             bc.MaxLossUSD,
+		LossWindow:             bc.LossWindow.Duration,
+	}, deps.AuditStore, deps.SignalBus, notifier)
+	g.Go(func() error {
+		return engine.WatchOutcomes(ctx, deps.SignalBus)
+	})
+}
+
+// startNotifier forwards order, position, arb and bond events from the signal
+// bus to the configured Telegram/Discord senders.
+func (a *App) startNotifier(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	if deps.Dispatcher == nil {
+		return
+	}
+	g.Go(func() error {
+		return deps.Dispatcher.Run(ctx)
+	})
+}
+
+// startProfitSweep runs the cold-storage profit sweep when sweep.enabled is
+// set. Sweeps are sent from the EOA derived from wallet.private_key, so the
+// job is skipped for Safe-based wallets whose funds the EOA cannot move.
+func (a *App) startProfitSweep(ctx context.Context, g *errgroup.Group, deps *Dependencies) {
+	if !a.cfg.Sweep.Enabled {
+		return
+	}
+	if deps.SweepStore == nil || deps.PositionStore == nil || deps.OrderStore == nil {
+		a.logger.WarnContext(ctx, "profit sweep disabled: postgres not configured")
+		return
+	}
+	if a.cfg.Wallet.SafeAddress != "" {
+		a.logger.WarnContext(ctx, "profit sweep disabled: funds are held by wallet.safe_address, only EOA wallets can be swept")
+		return
+	}
+	txm, err := polygon.NewTxManager(a.cfg.Sweep.RPCURL, a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID)
+	if err != nil {
+		a.logger.WarnContext(ctx, "profit sweep disabled", slog.String("error", err.Error()))
+		return
+	}
+	var notifier service.SweepNotifier
+	if deps.Dispatcher != nil {
+		notifier = deps.Dispatcher
+	}
+	sweepSvc := service.NewSweepService(
+		deps.SweepStore,
+		deps.PositionStore,
+		deps.OrderStore,
+		txm,
+		deps.AuditStore,
+		deps.LockManager,
+		notifier,
+		service.SweepConfig{
+			Wallet:         txm.Address().Hex(),
+			ColdAddress:    a.cfg.Sweep.ColdAddress,
+			TokenAddress:   a.cfg.Sweep.USDCAddress,
+			PnLThreshold:   a.cfg.Sweep.PnLThreshold,
+			WorkingCapital: a.cfg.Sweep.WorkingCapital,
+			MinAmount:      a.cfg.Sweep.MinAmount,
+			Interval:       a.cfg.Sweep.CheckInterval.Duration,
+			Confirmations:  a.cfg.Sweep.Confirmations,
+			ConfirmTimeout: a.cfg.Sweep.ConfirmTimeout.Duration,
+		},
+		a.logger,
+	)
+	g.Go(func() error {
+		return sweepSvc.Run(ctx)
+	})
+}
+
+// newRedemptionService builds the on-chain redemption of resolved positions,
+// or returns nil when Postgres, polygon.rpc_url or an EOA wallet is missing.
+// Redemptions are sent from the EOA derived from wallet.private_key, which
+// holds no tokens when wallet.safe_address is set.
+func (a *App) newRedemptionService(ctx context.Context, deps *Dependencies, closer service.PositionCloser, wallet string) *service.RedemptionService {
+	if deps.RedemptionStore == nil || deps.PositionStore == nil || deps.MarketStore == nil {
+		a.logger.WarnContext(ctx, "redemption disabled: postgres not configured")
+		return nil
+	}
+	if a.cfg.Wallet.SafeAddress != "" {
+		a.logger.WarnContext(ctx, "redemption disabled: tokens are held by wallet.safe_address, only EOA wallets can redeem")
+		return nil
+	}
+	client, err := chain.NewClient(a.cfg.Polygon.RPCURL)
+	if err != nil {
+		a.logger.WarnContext(ctx, "redemption disabled", slog.String("error", err.Error()))
+		return nil
+	}
+	txm, err := polygon.NewTxManager(a.cfg.Polygon.RPCURL, a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID)
+	if err != nil {
+		a.logger.WarnContext(ctx, "redemption disabled", slog.String("error", err.Error()))
+		return nil
+	}
+	var notifier service.SweepNotifier
+	if deps.Dispatcher != nil {
+		notifier = deps.Dispatcher
+	}
+	return service.NewRedemptionService(
+		deps.RedemptionStore,
+		deps.PositionStore,
+		deps.MarketStore,
+		closer,
+		client,
+		txm,
+		deps.AuditStore,
+		deps.LockManager,
+		notifier,
+		service.RedemptionConfig{
+			Wallet:         wallet,
+			Lookback:       a.cfg.Redemption.Lookback.Duration,
+			MinProceeds:    a.cfg.Redemption.MinProceeds,
+			Interval:       a.cfg.Redemption.CheckInterval.Duration,
+			Confirmations:  a.cfg.Redemption.Confirmations,
+			ConfirmTimeout: a.cfg.Redemption.ConfirmTimeout.Duration,
+		},
+		a.logger,
+	)
+}
+
+// newAlertService builds an AlertService, or returns nil when alerts are not
+// persisted in this mode.
+func (a *App) newAlertService(deps *Dependencies) *service.AlertService {
+	if deps.AlertStore == nil {
+		return nil
+	}
+	var notifier service.AlertNotifier
+	switch {
+	case deps.Dispatcher != nil:
+		notifier = deps.Dispatcher
+	case deps.Notifier != nil:
+		notifier = deps.Notifier
+	}
+	return service.NewAlertService(deps.AlertStore, deps.MarketStore, deps.SignalBus, notifier, a.logger)
+}
+
+// newStrategyRegistry builds every registered strategy type under its own
+// name, then the instances declared in strategy.instances. A strategy whose
+// dependencies or enabling flag are missing is marked unavailable instead.
+func (a *App) newStrategyRegistry(deps *Dependencies, sd *strategyDeps) *strategy.Registry {
+	params := strategyParams(a.config())
 	baseCfg := strategy.Config{
 		Name:         a.cfg.Strategy.Name,
 		Coin:         a.cfg.Strategy.Coin,
@@ -629,133 +1933,269 @@ func (a *App) newStrategyRegistry(deps *Dependencies, sd *strategyDeps) *strateg
 		MaxPositions: a.cfg.Strategy.MaxPositions,
 		TakeProfit:   a.cfg.Strategy.TakeProfit,
 		StopLoss:     a.cfg.Strategy.StopLoss,
-		Params:       baseParams,
 	}
-	prices := deps.PriceCache
-	tracker := strategy.NewPriceTracker(prices, 5*time.Minute)
-	reg := strategy.NewRegistry()
+	d := a.strategyBuildDeps(deps, sd)
+	// Types off by config are not built under their own name; their
+	// declared instances still are.
+	disabled := map[string]string{}
+	if !a.cfg.Strategy.YesNoSpread.Enabled {
+		disabled["yes_no_spread"] = "strategy.yes_no_spread.enabled"
+	}
+	if !a.cfg.Strategy.CrossPlatformArb.Enabled {
+		disabled["cross_platform_arb"] = "strategy.cross_platform_arb.enabled"
+	}
+	if !a.cfg.Strategy.TemporalOverlap.Enabled {
+		disabled["temporal_overlap"] = "strategy.temporal_overlap.enabled"
+	}
+	if !a.cfg.Strategy.LatencyArb.Enabled {
+		disabled["latency_arb"] = "strategy.latency_arb.enabled"
+	}
 
-	reg.Register("flash_crash", strategy.NewFlashCrash(baseCfg, tracker, a.logger))
-	reg.Register("mean_reversion", strategy.NewMeanReversion(baseCfg, strategy.NewPriceTracker(prices, 5*time.Minute), a.logger))
-	reg.Register("arb", strategy.NewArbStrategy(baseCfg, a.logger))
-
-	if deps.MarketStore != nil && deps.BookCache != nil && a.cfg.Strategy.YesNoSpread.Enabled {
-		ynParams := mergeParams(baseParams, map[string]any{
-			"min_edge_bps":  a.cfg.Strategy.YesNoSpread.MinEdgeBps,
-			"size_per_leg":  a.cfg.Strategy.YesNoSpread.SizePerLeg,
-			"ttl_seconds":   a.cfg.Strategy.YesNoSpread.TTLSeconds,
-			"max_stale_sec": a.cfg.Strategy.YesNoSpread.MaxStaleSec,
-			"cooldown_sec":  a.cfg.Strategy.YesNoSpread.CooldownSec,
-		})
-		reg.Register("yes_no_spread", strategy.NewYesNoSpread(
-			strategy.Config{Name: baseCfg.Name, Params: ynParams},
-			strategy.NewPriceTracker(prices, 5*time.Minute),
-			deps.MarketStore,
-			deps.BookCache,
-			a.logger,
-		))
+	reg := strategy.NewRegistry()
+	// build registers the strategy typ named name. overrides, an instance's
+	// own params, are also applied through Reconfigure, which rejects keys
+	// the type does not know where the constructors ignore them.
+	build := func(name, typ string, p, overrides map[string]any, gate string) {
+		cfg := baseCfg
+		cfg.Params = p
+		s, err := strategy.Build(typ, name, cfg, d)
+		if err == nil && len(overrides) > 0 {
+			err = s.Reconfigure(overrides)
+		}
+		var missing *strategy.MissingDepsError
+		switch {
+		case errors.As(err, &missing):
+			if gate != "" {
+				missing.Missing = append(missing.Missing, gate)
+			}
+			reg.MarkUnavailable(name, missing.Missing)
+		case err != nil:
+			a.logger.Error("strategy not built", slog.String("strategy", name), slog.String("error", err.Error()))
+			reg.MarkUnavailable(name, []string{err.Error()})
+		case gate != "":
+			reg.MarkUnavailable(name, []string{gate})
+		default:
+			reg.Register(name, s)
+		}
+	}
+	for _, typ := range strategy.Types() {
+		build(typ, typ, params[typ], nil, disabled[typ])
 	}
 
-	if deps.ConditionGroupStore != nil && deps.MarketStore != nil {
-		raParams := mergeParams(baseParams, map[string]any{
-			"min_edge_bps":   a.cfg.Strategy.RebalancingArb.MinEdgeBps,
-			"max_group_size": a.cfg.Strategy.RebalancingArb.MaxGroupSize,
-			"size_per_leg":   a.cfg.Strategy.RebalancingArb.SizePerLeg,
-			"ttl_seconds":    a.cfg.Strategy.RebalancingArb.TTLSeconds,
-			"max_stale_sec":  a.cfg.Strategy.RebalancingArb.MaxStaleSec,
-		})
-		reg.Register("rebalancing_arb", strategy.NewRebalancingArb(
-			strategy.Config{Name: baseCfg.Name, Params: raParams},
-			strategy.NewPriceTracker(prices, 5*time.Minute),
-			deps.ConditionGroupStore, deps.MarketStore, prices, a.logger))
-	}
-	if deps.BondPositionStore != nil && deps.MarketStore != nil {
-		bParams := mergeParams(baseParams, map[string]any{
-			"min_yes_price":     a.cfg.Strategy.Bond.MinYesPrice,
-			"min_apr":           a.cfg.Strategy.Bond.MinAPR,
-			"min_volume":        a.cfg.Strategy.Bond.MinVolume,
-			"max_days_to_exp":   a.cfg.Strategy.Bond.MaxDaysToExp,
-			"min_days_to_exp":   a.cfg.Strategy.Bond.MinDaysToExp,
-			"max_positions":     a.cfg.Strategy.Bond.MaxPositions,
-			"size_per_position": a.cfg.Strategy.Bond.SizePerPosition,
-		})
-		reg.Register("bond", strategy.NewBondStrategy(
-			strategy.Config{Name: baseCfg.Name, Params: bParams},
-			strategy.NewPriceTracker(prices, 5*time.Minute),
-			deps.BondPositionStore, deps.MarketStore, a.logger))
+	names := make([]string, 0, len(a.cfg.Strategy.Instances))
+	for name := range a.cfg.Strategy.Instances {
+		names = append(names, name)
 	}
-	var rewards strategy.RewardsTracker
-	if sd != nil && sd.rewardsTracker != nil {
-		rewards = sd.rewardsTracker
+	sort.Strings(names)
+	for _, name := range names {
+		inst := a.cfg.Strategy.Instances[name]
+		build(name, inst.Type, mergeParams(params[inst.Type], inst.Params), inst.Params, "")
 	}
+	return reg
+}
+
+// strategyBuildDeps collects the dependencies strategy factories draw on.
+// Interface fields are only set from non-nil pointers so factories can test
+// them against nil.
+func (a *App) strategyBuildDeps(deps *Dependencies, sd *strategyDeps) strategy.Deps {
+	d := strategy.Deps{
+		Prices:          deps.PriceCache,
+		Books:           deps.BookCache,
+		ConditionGroups: deps.ConditionGroupStore,
+		MarketRelations: deps.MarketRelationStore,
+		BondPositions:   deps.BondPositionStore,
+		Categories:      make(map[string]domain.CategoryFilter, len(a.cfg.Strategy.Categories)),
+		BondEarlyExit:   a.cfg.Strategy.Bond.EarlyExit,
+		KalshiMarkets:   a.cfg.Strategy.CrossPlatformArb.MarketMap,
+		Logger:          a.logger,
+	}
+	// Strategies see the market store gated by the operator's market lists.
 	if deps.MarketStore != nil {
-		lpParams := mergeParams(baseParams, map[string]any{
-			"half_spread_bps":   a.cfg.Strategy.LiquidityProvider.HalfSpreadBps,
-			"requote_threshold": a.cfg.Strategy.LiquidityProvider.RequoteThreshold,
-			"size":              a.cfg.Strategy.LiquidityProvider.Size,
-			"max_markets":       a.cfg.Strategy.LiquidityProvider.MaxMarkets,
-		})
-		reg.Register("liquidity_provider", strategy.NewLiquidityProvider(
-			strategy.Config{Name: baseCfg.Name, Params: lpParams},
-			strategy.NewPriceTracker(prices, 5*time.Minute),
-			rewards, deps.MarketStore, a.logger))
+		d.Markets = deps.MarketStore
+		if lists := a.marketLists(deps); lists != nil {
+			d.Markets = strategy.GateMarkets(deps.MarketStore, lists)
+		}
 	}
-	var relSvc strategy.RelationComputer
-	if sd != nil && sd.relationSvc != nil {
-		relSvc = sd.relationSvc
+	for name, f := range a.cfg.Strategy.Categories {
+		d.Categories[name] = domain.CategoryFilter{Include: f.Include, Exclude: f.Exclude}
 	}
-	if deps.ConditionGroupStore != nil && deps.MarketRelationStore != nil && deps.MarketStore != nil {
-		caParams := mergeParams(baseParams, map[string]any{
-			"min_edge_bps":  a.cfg.Strategy.CombinatorialArb.MinEdgeBps,
-			"max_relations": a.cfg.Strategy.CombinatorialArb.MaxRelations,
-			"size_per_leg":  a.cfg.Strategy.CombinatorialArb.SizePerLeg,
-		})
-		reg.Register("combinatorial_arb", strategy.NewCombinatorialArb(
-			strategy.Config{Name: baseCfg.Name, Params: caParams},
-			strategy.NewPriceTracker(prices, 5*time.Minute),
-			deps.ConditionGroupStore, deps.MarketRelationStore, relSvc,
-			deps.MarketStore, prices, a.logger))
-	}
-
-	if deps.MarketStore != nil && deps.BookCache != nil && a.cfg.Strategy.CrossPlatformArb.Enabled && sd != nil && sd.kalshiClient != nil {
-		cpParams := mergeParams(baseParams, map[string]any{
-			"min_edge_bps":  a.cfg.Strategy.CrossPlatformArb.MinEdgeBps,
-			"size_per_leg":  a.cfg.Strategy.CrossPlatformArb.SizePerLeg,
-			"ttl_seconds":   a.cfg.Strategy.CrossPlatformArb.TTLSeconds,
-			"refresh_sec":   a.cfg.Strategy.CrossPlatformArb.RefreshSec,
-			"max_stale_sec": a.cfg.Strategy.CrossPlatformArb.MaxStaleSec,
-			"cooldown_sec":  a.cfg.Strategy.CrossPlatformArb.CooldownSec,
+	if sd == nil {
+		return d
+	}
+	if sd.relationSvc != nil {
+		d.Relations = sd.relationSvc
+	}
+	if sd.rewardsTracker != nil {
+		d.Rewards = sd.rewardsTracker
+	}
+	if sd.cancelRatio != nil {
+		d.CancelRatio = sd.cancelRatio
+	}
+	if sd.inventory != nil {
+		d.Inventory = sd.inventory
+	}
+	if sd.features != nil {
+		d.Features = sd.features
+	}
+	if sd.tradeAnalytics != nil {
+		d.TradeAnalytics = sd.tradeAnalytics
+	}
+	if sd.toxicity != nil {
+		d.Toxicity = sd.toxicity
+	}
+	if sd.priceFeed != nil {
+		d.ReferencePrices = sd.priceFeed
+	}
+	if sd.kalshiClient != nil {
+		d.Kalshi = sd.kalshiClient
+	}
+	// PredictIt publishes market data about once a minute; Manifold is
+	// polled a little more often since its AMM moves with every bet.
+	if sd.predictItClient != nil {
+		d.VenueFeeds = append(d.VenueFeeds, strategy.VenueFeed{
+			Venue:      domain.VenuePredictIt,
+			Quoter:     sd.predictItClient,
+			Markets:    a.cfg.Strategy.CrossPlatformArb.PredictItMap,
+			ReadOnly:   true,
+			MinRefresh: time.Minute,
 		})
-		reg.Register("cross_platform_arb", strategy.NewCrossPlatformArb(
-			strategy.Config{Name: baseCfg.Name, Params: cpParams},
-			strategy.NewPriceTracker(prices, 5*time.Minute),
-			deps.MarketStore,
-			deps.BookCache,
-			sd.kalshiClient,
-			a.cfg.Strategy.CrossPlatformArb.MarketMap,
-			a.logger,
-		))
-	}
-
-	if deps.MarketStore != nil && deps.BookCache != nil && a.cfg.Strategy.TemporalOverlap.Enabled {
-		toParams := mergeParams(baseParams, map[string]any{
-			"min_edge_bps":    a.cfg.Strategy.TemporalOverlap.MinEdgeBps,
-			"size_per_leg":    a.cfg.Strategy.TemporalOverlap.SizePerLeg,
-			"ttl_seconds":     a.cfg.Strategy.TemporalOverlap.TTLSeconds,
-			"max_stale_sec":   a.cfg.Strategy.TemporalOverlap.MaxStaleSec,
-			"cooldown_sec":    a.cfg.Strategy.TemporalOverlap.CooldownSec,
-			"refresh_minutes": a.cfg.Strategy.TemporalOverlap.RefreshMinutes,
-			"max_pairs":       a.cfg.Strategy.TemporalOverlap.MaxPairs,
+	}
+	if sd.manifoldClient != nil {
+		d.VenueFeeds = append(d.VenueFeeds, strategy.VenueFeed{
+			Venue:      domain.VenueManifold,
+			Quoter:     sd.manifoldClient,
+			Markets:    a.cfg.Strategy.CrossPlatformArb.ManifoldMap,
+			ReadOnly:   true,
+			MinRefresh: 15 * time.Second,
 		})
-		reg.Register("temporal_overlap", strategy.NewTemporalOverlap(
-			strategy.Config{Name: baseCfg.Name, Params: toParams},
-			strategy.NewPriceTracker(prices, 5*time.Minute),
-			deps.MarketStore,
-			deps.BookCache,
-			a.logger,
-		))
 	}
-	return reg
+	if sd.instruments != nil {
+		d.Instruments = sd.instruments
+	}
+	return d
+}
+
+// strategyParams returns the parameters each strategy is built with:
+// strategy.params overlaid with the strategy's own section. flash_crash,
+// mean_reversion and arb take strategy.params as is.
+func strategyParams(cfg *config.Config) map[string]map[string]any {
+	base := mergeParams(cfg.Strategy.Params, nil)
+	return map[string]map[string]any{
+		"flash_crash":    base,
+		"mean_reversion": base,
+		"arb":            base,
+		"yes_no_spread": mergeParams(base, map[string]any{
+			"min_edge_bps":     cfg.Strategy.YesNoSpread.MinEdgeBps,
+			"size_per_leg":     cfg.Strategy.YesNoSpread.SizePerLeg,
+			"min_size_per_leg": cfg.Strategy.YesNoSpread.MinSizePerLeg,
+			"ttl_seconds":      cfg.Strategy.YesNoSpread.TTLSeconds,
+			"max_stale_sec":    cfg.Strategy.YesNoSpread.MaxStaleSec,
+			"cooldown_sec":     cfg.Strategy.YesNoSpread.CooldownSec,
+			"toxicity_widen":   cfg.Strategy.YesNoSpread.ToxicityWiden,
+			"toxicity_pause":   cfg.Strategy.YesNoSpread.ToxicityPause,
+			"toxicity_edge":    cfg.Strategy.YesNoSpread.ToxicityEdge,
+		}),
+		"rebalancing_arb": mergeParams(base, map[string]any{
+			"min_edge_bps":     cfg.Strategy.RebalancingArb.MinEdgeBps,
+			"max_group_size":   cfg.Strategy.RebalancingArb.MaxGroupSize,
+			"size_per_leg":     cfg.Strategy.RebalancingArb.SizePerLeg,
+			"min_size_per_leg": cfg.Strategy.RebalancingArb.MinSizePerLeg,
+			"ttl_seconds":      cfg.Strategy.RebalancingArb.TTLSeconds,
+			"max_stale_sec":    cfg.Strategy.RebalancingArb.MaxStaleSec,
+		}),
+		"bond": mergeParams(base, map[string]any{
+			"min_yes_price":     cfg.Strategy.Bond.MinYesPrice,
+			"min_apr":           cfg.Strategy.Bond.MinAPR,
+			"min_volume":        cfg.Strategy.Bond.MinVolume,
+			"max_days_to_exp":   cfg.Strategy.Bond.MaxDaysToExp,
+			"min_days_to_exp":   cfg.Strategy.Bond.MinDaysToExp,
+			"max_positions":     cfg.Strategy.Bond.MaxPositions,
+			"size_per_position": cfg.Strategy.Bond.SizePerPosition,
+			"stop_price":        cfg.Strategy.Bond.StopPrice,
+			"min_volume_24h":    cfg.Strategy.Bond.MinVolume24h,
+		}),
+		"liquidity_provider": mergeParams(base, map[string]any{
+			"half_spread_bps":    cfg.Strategy.LiquidityProvider.HalfSpreadBps,
+			"requote_threshold":  cfg.Strategy.LiquidityProvider.RequoteThreshold,
+			"size":               cfg.Strategy.LiquidityProvider.Size,
+			"max_markets":        cfg.Strategy.LiquidityProvider.MaxMarkets,
+			"tick_size":          cfg.Strategy.LiquidityProvider.TickSize,
+			"max_inventory":      cfg.Strategy.LiquidityProvider.MaxInventory,
+			"inventory_skew_bps": cfg.Strategy.LiquidityProvider.InventorySkewBps,
+			"max_volatility":     cfg.Strategy.LiquidityProvider.MaxVolatility,
+			"min_volume_24h":     cfg.Strategy.LiquidityProvider.MinVolume24h,
+			"min_reward_usd":     cfg.Strategy.LiquidityProvider.MinRewardUSD,
+			"levels":             cfg.Strategy.LiquidityProvider.Levels,
+			"level_spacing_bps":  cfg.Strategy.LiquidityProvider.LevelSpacingBps,
+			"size_decay":         cfg.Strategy.LiquidityProvider.SizeDecay,
+			"toxicity_widen":     cfg.Strategy.LiquidityProvider.ToxicityWiden,
+			"toxicity_pause":     cfg.Strategy.LiquidityProvider.ToxicityPause,
+			"toxicity_spread":    cfg.Strategy.LiquidityProvider.ToxicitySpread,
+		}),
+		"combinatorial_arb": mergeParams(base, map[string]any{
+			"min_edge_bps":  cfg.Strategy.CombinatorialArb.MinEdgeBps,
+			"max_relations": cfg.Strategy.CombinatorialArb.MaxRelations,
+			"size_per_leg":  cfg.Strategy.CombinatorialArb.SizePerLeg,
+		}),
+		"cross_platform_arb": mergeParams(base, map[string]any{
+			"min_edge_bps":  cfg.Strategy.CrossPlatformArb.MinEdgeBps,
+			"size_per_leg":  cfg.Strategy.CrossPlatformArb.SizePerLeg,
+			"ttl_seconds":   cfg.Strategy.CrossPlatformArb.TTLSeconds,
+			"refresh_sec":   cfg.Strategy.CrossPlatformArb.RefreshSec,
+			"max_stale_sec": cfg.Strategy.CrossPlatformArb.MaxStaleSec,
+			"cooldown_sec":  cfg.Strategy.CrossPlatformArb.CooldownSec,
+		}),
+		"temporal_overlap": mergeParams(base, map[string]any{
+			"min_edge_bps":     cfg.Strategy.TemporalOverlap.MinEdgeBps,
+			"size_per_leg":     cfg.Strategy.TemporalOverlap.SizePerLeg,
+			"min_size_per_leg": cfg.Strategy.TemporalOverlap.MinSizePerLeg,
+			"ttl_seconds":      cfg.Strategy.TemporalOverlap.TTLSeconds,
+			"max_stale_sec":    cfg.Strategy.TemporalOverlap.MaxStaleSec,
+			"cooldown_sec":     cfg.Strategy.TemporalOverlap.CooldownSec,
+			"refresh_minutes":  cfg.Strategy.TemporalOverlap.RefreshMinutes,
+			"max_pairs":        cfg.Strategy.TemporalOverlap.MaxPairs,
+			"max_ref_move_bps": cfg.Strategy.TemporalOverlap.MaxRefMoveBps,
+		}),
+		"latency_arb": mergeParams(base, map[string]any{
+			"min_move_bps":    cfg.Strategy.LatencyArb.MinMoveBps,
+			"lookback_sec":    cfg.Strategy.LatencyArb.LookbackSec,
+			"max_lag_move":    cfg.Strategy.LatencyArb.MaxLagMove,
+			"max_price":       cfg.Strategy.LatencyArb.MaxPrice,
+			"size":            cfg.Strategy.LatencyArb.Size,
+			"ttl_seconds":     cfg.Strategy.LatencyArb.TTLSeconds,
+			"max_stale_sec":   cfg.Strategy.LatencyArb.MaxStaleSec,
+			"max_ref_age_sec": cfg.Strategy.LatencyArb.MaxRefAgeSec,
+			"cooldown_sec":    cfg.Strategy.LatencyArb.CooldownSec,
+			"refresh_minutes": cfg.Strategy.LatencyArb.RefreshMinutes,
+			"max_markets":     cfg.Strategy.LatencyArb.MaxMarkets,
+		}),
+	}
+}
+
+// restoreStrategyParams applies parameter overrides saved through
+// PUT /api/strategy/{name}/params on top of the TOML values or, with
+// strategy.prefer_persisted, the newest saved version of each strategy's
+// params, then saves a version of every strategy whose effective params
+// changed since its last one.
+func (a *App) restoreStrategyParams(ctx context.Context, deps *Dependencies, engine *strategy.Engine) {
+	if deps.StratCfgStore == nil {
+		return
+	}
+	svc := service.NewStrategyParamService(deps.StratCfgStore, engine, a.logger)
+	restore := svc.Restore
+	if a.cfg.Strategy.PreferPersisted {
+		restore = svc.RestoreLatest
+	}
+	if err := restore(ctx); err != nil {
+		a.logger.WarnContext(ctx, "failed to restore strategy params, using config values",
+			slog.String("error", err.Error()),
+		)
+	}
+	if err := svc.SaveVersions(ctx, domain.StrategyVersionStartup); err != nil {
+		a.logger.WarnContext(ctx, "failed to save strategy param versions",
+			slog.String("error", err.Error()),
+		)
+	}
 }
 
 func mergeParams(base map[string]any, overrides map[string]any) map[string]any {
@@ -769,9 +2209,9 @@ func mergeParams(base map[string]any, overrides map[string]any) map[string]any {
 	return out
 }
 
-// newArbStrategy builds the arbitrage strategy registry and returns the
-// strategy selected by config (e.g. "spread", "imbalance", "yes_no_spread").
-func (a *App) newArbStrategy(cfg config.ArbitrageConfig, logger *slog.Logger) (arbitrage.Strategy, error) {
+// newArbStrategies builds the arbitrage strategy registry and returns the
+// strategies selected by config (e.g. "spread", "imbalance", "yes_no_spread").
+func (a *App) newArbStrategies(cfg config.ArbitrageConfig, logger *slog.Logger) ([]arbitrage.Strategy, error) {
 	reg := arbitrage.NewRegistry()
 	polymarketFeeBps := 0.0
 	if v, ok := cfg.PerVenueFeeBps["polymarket"]; ok {
@@ -787,12 +2227,18 @@ func (a *App) newArbStrategy(cfg config.ArbitrageConfig, logger *slog.Logger) (a
 	}, logger))
 	reg.Register("imbalance", arbitrage.NewImbalance(arbitrage.ImbalanceConfig{
 		RatioThreshold:  cfg.ImbalanceRatioThreshold,
-		MinTotalVolume:  100.0,
+		MinTotalVolume:  cfg.Imbalance.MinTotalVolume,
 		EstFeeBps:       polymarketFeeBps,
 		EstSlippageBps:  cfg.MaxSlippageBps,
 		EstLatencyBps:   5.0,
 		MaxAmount:       cfg.MaxTradeAmount,
-		EdgeBpsPerRatio: 15.0,
+		EdgeBpsPerRatio: cfg.Imbalance.EdgeBpsPerRatio,
+		Levels:          cfg.Imbalance.Levels,
+		LevelDecay:      cfg.Imbalance.LevelDecay,
+		HalfLife:        cfg.Imbalance.HalfLife.Duration,
+		TradeFlow:       cfg.Imbalance.TradeFlow,
+		FlowHalfLife:    cfg.Imbalance.FlowHalfLife.Duration,
+		MinFlowUSD:      cfg.Imbalance.MinFlowUSD,
 	}, logger))
 	reg.Register("yes_no_spread", arbitrage.NewYesNoSpread(arbitrage.YesNoSpreadConfig{
 		MinEdgeBps:     cfg.MinNetEdgeBps,
@@ -802,14 +2248,59 @@ func (a *App) newArbStrategy(cfg config.ArbitrageConfig, logger *slog.Logger) (a
 		MaxAmount:      cfg.MaxTradeAmount,
 	}, logger))
 
+	var out []arbitrage.Strategy
+	for _, name := range arbStrategyNames(cfg) {
+		s, err := reg.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// arbStrategyNames returns the arbitrage strategies selected by config:
+// arbitrage.strategies without repeats when set, otherwise
+// arbitrage.strategy (default "spread").
+func arbStrategyNames(cfg config.ArbitrageConfig) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range cfg.Strategies {
+		name = strings.TrimSpace(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
 	name := strings.TrimSpace(cfg.Strategy)
 	if name == "" {
 		name = "spread"
 	}
-	return reg.Get(name)
+	return []string{name}
 }
 
-// watchAssetIDs returns token IDs from active markets for WS subscription (up to maxAssets).
+// warmCaches seeds the book and price caches with CLOB REST snapshots of
+// assetIDs when polymarket.warmup_books is set, so strategies start with
+// books instead of idling until the WS feed delivers them.
+func (a *App) warmCaches(ctx context.Context, deps *Dependencies, assetIDs []string) {
+	if !a.cfg.Polymarket.WarmupBooks || len(assetIDs) == 0 || deps.BookCache == nil || deps.PriceCache == nil {
+		return
+	}
+	warmer := service.NewCacheWarmer(
+		a.newClobClient(deps, nil),
+		deps.BookCache, deps.PriceCache,
+		a.cfg.Polymarket.WarmupConcurrency,
+		a.cfg.Polymarket.WarmupTimeout.Duration,
+		a.logger,
+	)
+	warmer.Warm(ctx, assetIDs)
+}
+
+// watchAssetIDs returns token IDs from active markets for WS subscription (up to maxAssets),
+// leaving out markets excluded by the market lists.
 func (a *App) watchAssetIDs(ctx context.Context, store domain.MarketStore, maxAssets int) []string {
 	markets, err := store.ListActive(ctx, domain.ListOpts{Limit: 200})
 	if err != nil {
@@ -819,6 +2310,9 @@ func (a *App) watchAssetIDs(ctx context.Context, store domain.MarketStore, maxAs
 	seen := make(map[string]bool)
 	var ids []string
 	for _, m := range markets {
+		if a.lists != nil && !a.lists.Allows(m) {
+			continue
+		}
 		for _, tid := range m.TokenIDs {
 			if tid == "" || seen[tid] {
 				continue
@@ -833,11 +2327,260 @@ func (a *App) watchAssetIDs(ctx context.Context, store domain.MarketStore, maxAs
 	return ids
 }
 
+// apiTransport returns the transport that throttles venue's REST calls with
+// the configured token buckets, or nil when rate limiting is disabled, Redis
+// is not wired or the venue has no limits.
+func (a *App) apiTransport(deps *Dependencies, venue string) http.RoundTripper {
+	rl := a.cfg.RateLimit
+	if !rl.Enabled || deps.TokenBucket == nil {
+		return nil
+	}
+	limits := ratelimit.VenueLimits{Venue: venue}
+	if r, ok := rl.Venues[venue]; ok {
+		limits.Limit = ratelimit.Limit{Rate: r.Rate, Burst: bucketBurst(r.Rate, r.Burst)}
+	}
+	for _, ep := range rl.Endpoints {
+		if ep.Venue != venue {
+			continue
+		}
+		limits.Endpoints = append(limits.Endpoints, ratelimit.EndpointLimit{
+			Name:   ep.Name,
+			Method: ep.Method,
+			Path:   ep.Path,
+			Limit:  ratelimit.Limit{Rate: ep.Rate, Burst: bucketBurst(ep.Rate, ep.Burst)},
+		})
+	}
+	if limits.Limit.Rate <= 0 && len(limits.Endpoints) == 0 {
+		return nil
+	}
+	return ratelimit.NewTransport(nil, deps.TokenBucket, limits, a.logger)
+}
+
+// bucketBurst defaults an unset burst to the rate rounded up.
+func bucketBurst(rate float64, burst int) int {
+	if burst > 0 {
+		return burst
+	}
+	return max(1, int(math.Ceil(rate)))
+}
+
+// newClobClient creates a CLOB client throttled by ratelimit config,
+// retrying per retry config and attributing orders to the builder program
+// when builder credentials are set.
+func (a *App) newClobClient(deps *Dependencies, signer *crypto.Signer) *polymarket.ClobClient {
+	c := polymarket.NewClobClient(a.cfg.Polymarket.ClobHost, signer, nil).WithRetry(deps.Retrier)
+	if auth := a.builderAuth(); auth != nil {
+		c.WithBuilder(auth)
+	}
+	if rt := a.apiTransport(deps, config.RateLimitVenueClob); rt != nil {
+		c.WithTransport(rt)
+	}
+	return c
+}
+
+// builderAuth returns the builder-program credentials, or nil when
+// [builder] is not configured.
+func (a *App) builderAuth() *crypto.HMACAuth {
+	b := a.cfg.Builder
+	if b.ApiKey == "" {
+		return nil
+	}
+	return &crypto.HMACAuth{Key: b.ApiKey, Secret: b.ApiSecret, Passphrase: b.ApiPassphrase}
+}
+
+// newGammaClient creates a Gamma client throttled by ratelimit config and
+// retrying per retry config.
+func (a *App) newGammaClient(deps *Dependencies) *polymarket.GammaClient {
+	c := polymarket.NewGammaClient(a.cfg.Polymarket.GammaHost).WithRetry(deps.Retrier)
+	if rt := a.apiTransport(deps, config.RateLimitVenueGamma); rt != nil {
+		c.WithTransport(rt)
+	}
+	return c
+}
+
+// feeModel returns the shared per-market fee model, built on first use, or
+// nil when fees.enabled is off or Gamma is not configured.
+func (a *App) feeModel(deps *Dependencies) *service.FeeModel {
+	if a.fees != nil || !a.cfg.Fees.Enabled || a.cfg.Polymarket.GammaHost == "" {
+		return a.fees
+	}
+	a.fees = service.NewFeeModel(a.newGammaClient(deps), service.FeeModelConfig{
+		RefreshInterval: a.cfg.Fees.RefreshInterval.Duration,
+		DefaultTakerBps: a.cfg.Arbitrage.PerVenueFeeBps["polymarket"],
+		RebateBps:       a.cfg.Fees.BuilderRebateBps,
+	}, a.logger)
+	if deps.MarketStore != nil {
+		a.fees.WithMarkets(deps.MarketStore)
+	}
+	return a.fees
+}
+
+// collateralDecimals returns the decimals order amounts are signed in, per
+// market.
+func (a *App) collateralDecimals() domain.CollateralDecimals {
+	return domain.CollateralDecimals{
+		Default:  a.cfg.Polymarket.CollateralDecimals,
+		ByMarket: a.cfg.Polymarket.MarketCollateralDecimals,
+	}
+}
+
+// orderIncrements returns the shared per-token tick size and minimum order
+// size cache, built on first use, or nil when increments are disabled or the
+// CLOB is not configured.
+func (a *App) orderIncrements(deps *Dependencies) *service.OrderIncrementCache {
+	if a.increments != nil || !a.cfg.Increments.Enabled || a.cfg.Polymarket.ClobHost == "" {
+		return a.increments
+	}
+	ic := a.cfg.Increments
+	a.increments = service.NewOrderIncrementCache(a.newClobClient(deps, nil), service.OrderIncrementsConfig{
+		RefreshInterval: ic.RefreshInterval.Duration,
+		SizeStep:        ic.SizeStep,
+		DefaultTickSize: ic.DefaultTickSize,
+		DefaultMinSize:  ic.DefaultMinSize,
+	}, a.logger)
+	return a.increments
+}
+
+// balanceGuard returns the shared on-chain balance and allowance checker,
+// built on first use, or nil when polygon.rpc_url or the wallet key is not
+// configured.
+func (a *App) balanceGuard(deps *Dependencies) *service.BalanceGuard {
+	if a.balances != nil || a.cfg.Polygon.RPCURL == "" {
+		return a.balances
+	}
+	signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID)
+	if err != nil {
+		return nil
+	}
+	client, err := chain.NewClient(a.cfg.Polygon.RPCURL)
+	if err != nil {
+		return nil
+	}
+	a.balances = service.NewBalanceGuard(client, service.BalanceGuardConfig{
+		Wallet: signer.Address().Hex(),
+		MaxAge: a.cfg.Polygon.BalanceMaxAge.Duration,
+	}, a.logger)
+	if deps.MarketStore != nil {
+		a.balances.WithMarkets(deps.MarketStore)
+	}
+	return a.balances
+}
+
+// riskService returns the pre-trade risk service shared by the executor and
+// order previews, built on first use. deps.PositionStore must be set.
+func (a *App) riskService(deps *Dependencies) *service.RiskService {
+	if a.risk != nil {
+		return a.risk
+	}
+	a.risk = service.NewRiskService(deps.PositionStore, deps.PriceCache, riskConfig(a.config()), a.logger)
+	if deps.MarketStore != nil {
+		a.risk.WithMarkets(deps.MarketStore)
+	}
+	if fees := a.feeModel(deps); fees != nil {
+		a.risk.WithFees(fees)
+	}
+	if capital := a.capitalAllocator(deps); capital != nil {
+		a.risk.WithCapital(capital)
+	}
+	if lists := a.marketLists(deps); lists != nil {
+		a.risk.WithMarketLists(lists)
+	}
+	if deps.BookCache != nil {
+		a.risk.WithBooks(deps.BookCache)
+	}
+	if deps.FeatureCache != nil {
+		a.risk.WithFeatures(deps.FeatureCache)
+	}
+	return a.risk
+}
+
+// marketLists returns the operator's market blacklist and whitelist, built
+// on first use, or nil when Postgres is not wired. startMarketLists loads
+// and refreshes them.
+func (a *App) marketLists(deps *Dependencies) *service.MarketListService {
+	if a.lists != nil || deps.MarketListStore == nil {
+		return a.lists
+	}
+	a.lists = service.NewMarketListService(deps.MarketListStore, deps.MarketListCache, service.MarketListConfig{
+		RefreshInterval: a.cfg.MarketLists.RefreshInterval.Duration,
+	}, a.logger)
+	if deps.MarketStore != nil {
+		a.lists.WithMarkets(deps.MarketStore)
+	}
+	if deps.AuditStore != nil {
+		a.lists.WithAudit(deps.AuditStore)
+	}
+	return a.lists
+}
+
+// riskConfig returns the pre-trade risk limits configured in cfg.
+func riskConfig(cfg *config.Config) service.RiskConfig {
+	rc := service.RiskConfig{
+		MaxPositions:     cfg.Strategy.MaxPositions,
+		MaxTradeAmount:   cfg.Arbitrage.MaxTradeAmount,
+		MaxSlippageBps:   cfg.Arbitrage.MaxSlippageBps,
+		CloseHorizon:     cfg.Risk.CloseHaircutHorizon.Duration,
+		CloseMinFactor:   cfg.Risk.CloseHaircutMinFactor,
+		CloseMultipliers: cfg.Risk.CloseHaircutMultipliers,
+		MinSizePolicy:    cfg.Risk.MinSizePolicy,
+		FeeBps:           cfg.Arbitrage.PerVenueFeeBps["polymarket"],
+		RedeemGasUSD:     cfg.Risk.RedeemGasUSD,
+		DefaultEdgeBps:   cfg.Risk.DefaultEdgeBps,
+
+		Slippage:           slippageSpec(cfg.Risk.Slippage.SlippageModelConfig),
+		SlippageByStrategy: make(map[string]service.SlippageSpec, len(cfg.Risk.Slippage.Strategies)),
+	}
+	for name, m := range cfg.Risk.Slippage.Strategies {
+		rc.SlippageByStrategy[name] = slippageSpec(m)
+	}
+	return rc
+}
+
+// slippageSpec converts a configured slippage model for the risk service.
+func slippageSpec(m config.SlippageModelConfig) service.SlippageSpec {
+	return service.SlippageSpec{Model: m.Model, MaxBps: m.MaxBps, Bps: m.Bps, VolMultiplier: m.VolMultiplier}
+}
+
+// capitalAllocator returns the per-strategy capital allocator, built on first
+// use, or nil when capital.enabled is off, Postgres is not wired or the
+// wallet key is not configured.
+func (a *App) capitalAllocator(deps *Dependencies) *service.CapitalAllocator {
+	if a.capital != nil || !a.cfg.Capital.Enabled || deps.OrderStore == nil || deps.PositionStore == nil {
+		return a.capital
+	}
+	signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID)
+	if err != nil {
+		return nil
+	}
+	a.capital = service.NewCapitalAllocator(deps.OrderStore, deps.PositionStore, service.CapitalAllocatorConfig{
+		Wallet:            signer.Address().Hex(),
+		BudgetsUSD:        a.cfg.Capital.BudgetsUSD,
+		DefaultBudgetUSD:  a.cfg.Capital.DefaultBudgetUSD,
+		RebalanceInterval: a.cfg.Capital.RebalanceInterval.Duration,
+		PerformanceDays:   a.cfg.Capital.PerformanceDays,
+		MaxShift:          a.cfg.Capital.MaxShift,
+	}, a.logger)
+	if a.cfg.Performance.Enabled && deps.PerformanceStore != nil {
+		a.capital.WithPerformance(service.NewPerformanceService(deps.PerformanceStore, a.performanceConfig(), a.logger))
+	}
+	return a.capital
+}
+
+// newKalshiClient creates a Kalshi client throttled by ratelimit config and
+// retrying per retry config.
+func (a *App) newKalshiClient(deps *Dependencies) *kalshi.Client {
+	c := kalshi.NewClient(a.cfg.Kalshi.BaseURL, a.cfg.Kalshi.ApiKey).WithRetry(deps.Retrier)
+	if rt := a.apiTransport(deps, config.RateLimitVenueKalshi); rt != nil {
+		c.WithTransport(rt)
+	}
+	return c
+}
+
 // buildStrategyDeps creates optional dependencies used by advanced strategies.
 func (a *App) buildStrategyDeps(deps *Dependencies) *strategyDeps {
 	sd := &strategyDeps{}
 	if a.cfg.Polymarket.GammaHost != "" {
-		sd.gammaClient = polymarket.NewGammaClient(a.cfg.Polymarket.GammaHost)
+		sd.gammaClient = a.newGammaClient(deps)
 	}
 
 	// Relation/rewards services for combinatorial_arb and liquidity_provider.
@@ -845,12 +2588,18 @@ func (a *App) buildStrategyDeps(deps *Dependencies) *strategyDeps {
 		sd.relationSvc = service.NewRelationService(deps.ConditionGroupStore, deps.MarketRelationStore, a.logger)
 		if sd.gammaClient != nil {
 			sd.rewardsTracker = service.NewRewardsTracker(sd.gammaClient, 50_000, a.logger)
+			if deps.LPRewardStore != nil && deps.OrderStore != nil && deps.BookCache != nil {
+				if signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID); err == nil {
+					sd.rewardsTracker.WithAccrual(deps.LPRewardStore, deps.OrderStore, deps.BookCache,
+						signer.Address().Hex(), a.cfg.Strategy.LiquidityProvider.RewardSampleInterval.Duration)
+				}
+			}
 		}
 	}
 
 	// Kalshi client for cross-platform strategy.
 	if a.cfg.Kalshi.BaseURL != "" && a.cfg.Kalshi.ApiKey != "" && a.cfg.Kalshi.RsaPrivateKeyPath != "" {
-		kc := kalshi.NewClient(a.cfg.Kalshi.BaseURL, a.cfg.Kalshi.ApiKey)
+		kc := a.newKalshiClient(deps)
 		keyBytes, err := os.ReadFile(a.cfg.Kalshi.RsaPrivateKeyPath)
 		if err != nil {
 			a.logger.Warn("build strategy deps: failed reading Kalshi RSA key",
@@ -867,9 +2616,119 @@ func (a *App) buildStrategyDeps(deps *Dependencies) *strategyDeps {
 		}
 	}
 
+	if a.cfg.PredictIt.Enabled {
+		sd.predictItClient = predictit.NewClient(a.cfg.PredictIt.BaseURL)
+	}
+	if a.cfg.Manifold.Enabled {
+		sd.manifoldClient = manifold.NewClient(a.cfg.Manifold.BaseURL)
+	}
+
+	sd.instruments = a.instrumentRegistry(deps)
+
+	if deps.SignalBus != nil && a.cfg.Risk.CancelRatioLimit > 0 {
+		var notifier service.AlertNotifier
+		if deps.Dispatcher != nil {
+			notifier = deps.Dispatcher
+		} else if deps.Notifier != nil {
+			notifier = deps.Notifier
+		}
+		sd.cancelRatio = service.NewCancelRatioTracker(deps.SignalBus, deps.AuditStore, notifier, service.CancelRatioConfig{
+			Limit:      a.cfg.Risk.CancelRatioLimit,
+			WarnAt:     a.cfg.Risk.CancelRatioWarnAt,
+			MinCancels: a.cfg.Risk.CancelRatioMinCancels,
+		}, a.logger)
+	}
+
+	if a.cfg.Features.Enabled {
+		sd.features = analytics.NewFeatureTracker(deps.FeatureCache, analytics.FeatureConfig{
+			DepthLevels:   a.cfg.Features.DepthLevels,
+			Window:        a.cfg.Features.Window.Duration,
+			FlushInterval: a.cfg.Features.FlushInterval.Duration,
+		}, a.logger)
+	}
+
+	if a.cfg.Toxicity.Enabled {
+		sd.toxicity = analytics.NewToxicityTracker(deps.ToxicityCache, analytics.ToxicityConfig{
+			BucketVolume:  a.cfg.Toxicity.BucketVolume,
+			Buckets:       a.cfg.Toxicity.Buckets,
+			MinBuckets:    a.cfg.Toxicity.MinBuckets,
+			FlushInterval: a.cfg.Toxicity.FlushInterval.Duration,
+		}, a.logger)
+	}
+
+	if a.cfg.TradeAnalytics.Enabled && deps.SignalBus != nil {
+		sd.tradeAnalytics = service.NewTradeAnalytics(deps.SignalBus, deps.TradeAnalyticsCache, service.TradeAnalyticsConfig{
+			VWAPWindow:    a.cfg.TradeAnalytics.VWAPWindow.Duration,
+			BucketWidth:   a.cfg.TradeAnalytics.BucketWidth,
+			FlushInterval: a.cfg.TradeAnalytics.FlushInterval.Duration,
+		}, a.logger)
+	}
+
+	if a.cfg.PriceFeed.Enabled {
+		var sources []pricefeed.Source
+		for _, name := range a.cfg.PriceFeed.Sources {
+			switch name {
+			case "binance":
+				sources = append(sources, pricefeed.NewBinanceSource(a.cfg.PriceFeed.BinanceURL))
+			case "coinbase":
+				sources = append(sources, pricefeed.NewCoinbaseSource(a.cfg.PriceFeed.CoinbaseURL))
+			}
+		}
+		sd.priceFeed = pricefeed.NewFeed(sources, deps.ReferencePriceCache, pricefeed.Config{
+			Assets:       a.cfg.PriceFeed.Assets,
+			PollInterval: a.cfg.PriceFeed.PollInterval.Duration,
+			History:      a.cfg.PriceFeed.History.Duration,
+		}, a.logger)
+	}
+
+	if deps.PositionStore != nil {
+		if signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID); err == nil {
+			sd.inventory = service.NewPositionInventory(deps.PositionStore, signer.Address().Hex(), 10*time.Second, a.logger)
+		}
+	}
+
 	return sd
 }
 
+// instrumentRegistry returns the instrument registry, or nil when Postgres is
+// not wired.
+func (a *App) instrumentRegistry(deps *Dependencies) *service.InstrumentRegistry {
+	if deps.InstrumentStore == nil || deps.InstrumentCache == nil {
+		return nil
+	}
+	return service.NewInstrumentRegistry(deps.InstrumentStore, deps.InstrumentCache, a.logger)
+}
+
+// seedInstrumentLinks copies the cross_platform_arb venue maps into the
+// instrument registry so links configured in TOML are visible through
+// /api/instruments. Links already set through the API are kept.
+func (a *App) seedInstrumentLinks(ctx context.Context, reg *service.InstrumentRegistry) {
+	cfg := a.cfg.Strategy.CrossPlatformArb
+	for venue, refs := range map[string]map[string]string{
+		domain.VenueKalshi:    cfg.MarketMap,
+		domain.VenuePredictIt: cfg.PredictItMap,
+		domain.VenueManifold:  cfg.ManifoldMap,
+	} {
+		if len(refs) == 0 {
+			continue
+		}
+		n, err := reg.SeedVenueLinks(ctx, venue, refs)
+		if err != nil {
+			a.logger.WarnContext(ctx, "seed instrument links failed",
+				slog.String("venue", venue),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if n > 0 {
+			a.logger.InfoContext(ctx, "seeded instrument links",
+				slog.String("venue", venue),
+				slog.Int("linked", n),
+			)
+		}
+	}
+}
+
 // buildExecutor creates the full execution pipeline: signer -> clobClient ->
 // orderService -> riskService -> executor. Returns the executor and any error.
 func (a *App) buildExecutor(ctx context.Context, deps *Dependencies, signalCh <-chan domain.TradeSignal, sd *strategyDeps) (*executor.Executor, error) {
@@ -878,7 +2737,7 @@ func (a *App) buildExecutor(ctx context.Context, deps *Dependencies, signalCh <-
 		return nil, fmt.Errorf("build executor: create signer: %w", err)
 	}
 
-	clobClient := polymarket.NewClobClient(a.cfg.Polymarket.ClobHost, signer, nil)
+	clobClient := a.newClobClient(deps, signer)
 	if err := clobClient.DeriveAPIKey(ctx); err != nil {
 		a.logger.WarnContext(ctx, "build executor: derive API key failed, CLOB submission disabled",
 			slog.String("error", err.Error()),
@@ -890,18 +2749,115 @@ func (a *App) buildExecutor(ctx context.Context, deps *Dependencies, signalCh <-
 		deps.OrderStore, deps.PositionStore, deps.BookCache,
 		deps.PriceCache, deps.RateLimiter, deps.SignalBus,
 		deps.AuditStore, signer, a.logger,
-	)
+	).WithOrderRate(a.cfg.RateLimit.OrdersPerSecond).WithCollateralDecimals(a.collateralDecimals())
+	// Prices and sizes are rounded onto the token's tick size and size step.
+	if inc := a.orderIncrements(deps); inc != nil {
+		orderSvc.WithIncrements(inc)
+	}
 	if clobClient != nil {
 		orderSvc.WithClobClient(clobClient)
+		// Orders the wallet cannot settle on chain are refused before posting.
+		if guard := a.balanceGuard(deps); guard != nil && a.cfg.Polygon.BalanceCheck {
+			orderSvc.WithFundsCheck(guard)
+		}
+	}
+	if len(a.cfg.Risk.OrderTTLSeconds) > 0 {
+		ttls := make(map[string]time.Duration, len(a.cfg.Risk.OrderTTLSeconds))
+		for name, sec := range a.cfg.Risk.OrderTTLSeconds {
+			ttls[name] = time.Duration(sec) * time.Second
+		}
+		orderSvc.WithOrderTTLs(ttls)
 	}
 
-	riskSvc := service.NewRiskService(deps.PositionStore, deps.PriceCache, service.RiskConfig{
-		MaxPositions:   a.cfg.Strategy.MaxPositions,
-		MaxTradeAmount: a.cfg.Arbitrage.MaxTradeAmount,
-		MaxSlippageBps: a.cfg.Arbitrage.MaxSlippageBps,
-	}, a.logger)
+	// Latency from signal emission to fill, reported by the executor, the
+	// order service and the fill tracker.
+	if a.cfg.Latency.Enabled {
+		a.latency = service.NewLatencyTracker(deps.LatencyStore, service.LatencyTrackerConfig{
+			Window:        a.cfg.Latency.Window,
+			FlushInterval: a.cfg.Latency.FlushInterval.Duration,
+		}, a.logger)
+		orderSvc.WithLatency(a.latency)
+	}
+
+	// Fill tracking: the CLOB user channel reports fills and cancellations of
+	// our orders, which update order status and positions as they happen.
+	positionSvc := service.NewPositionService(
+		deps.PositionStore, deps.PriceCache, deps.SignalBus, deps.AuditStore, a.logger,
+	)
+	if clobClient != nil && a.cfg.Polymarket.UserChannel && a.cfg.Polymarket.WsHost != "" {
+		if creds, ok := clobClient.Credentials(); ok {
+			tracker := service.NewFillTracker(deps.OrderStore, positionSvc, deps.SignalBus, deps.AuditStore, a.logger)
+			if a.latency != nil {
+				tracker.WithLatency(a.latency)
+			}
+			a.userFeed = feed.NewPolymarketUserFeed(a.cfg.Polymarket.WsHost, creds,
+				tracker.HandleFill, tracker.HandleOrderUpdate, a.logger)
+		}
+	}
+
+	riskSvc := a.riskService(deps)
 
 	exec := executor.NewExecutor(signalCh, orderSvc, riskSvc, signer.Address().Hex(), a.logger)
+	if deps.AuditStore != nil {
+		exec.SetAudit(deps.AuditStore)
+	}
+	if a.latency != nil {
+		exec.SetLatency(a.latency)
+	}
+	if rec := a.signalRecorder(deps); rec != nil {
+		exec.SetResolver(rec)
+	}
+	exec.SetWorkers(a.cfg.Executor.Workers, a.cfg.Executor.QueueDepth)
+	a.executor = exec
+
+	// Portfolio-level risk: aggregate exposure/PnL and the daily-loss kill switch.
+	var riskNotifier service.AlertNotifier
+	if deps.Dispatcher != nil {
+		riskNotifier = deps.Dispatcher
+	}
+	a.portfolioRisk = service.NewPortfolioRiskManager(
+		deps.PositionStore, deps.PriceCache, orderSvc, deps.SignalBus, riskNotifier, deps.KillSwitchStore,
+		service.PortfolioRiskConfig{
+			Wallet:            signer.Address().Hex(),
+			DailyLossLimitUSD: a.config().Risk.DailyLossLimitUSD,
+			CheckInterval:     a.cfg.Risk.PortfolioCheckInterval.Duration,
+		}, a.logger)
+	exec.SetKillSwitch(a.portfolioRisk)
+	if a.cfg.Risk.CancelAllOnShutdown {
+		exec.SetCancelOnShutdown(a.cfg.Risk.ShutdownCancelTimeout.Duration)
+	}
+
+	// Fast-poll tier for metadata of held markets; the engine is added as a
+	// listener by the caller.
+	if interval := a.cfg.Risk.MetadataPollInterval.Duration; interval > 0 && deps.MarketStore != nil && a.cfg.Polymarket.GammaHost != "" {
+		a.marketWatcher = service.NewMarketWatcher(
+			deps.PositionStore, deps.MarketStore, deps.MarketCache,
+			a.newGammaClient(deps), deps.SignalBus,
+			service.MarketWatcherConfig{Wallet: signer.Address().Hex(), Interval: interval},
+			a.logger)
+		a.marketWatcher.AddListener(riskSvc)
+	}
+	// Positions in resolved markets are closed at the payout.
+	if interval := a.cfg.Risk.ResolutionPollInterval.Duration; interval > 0 && deps.PositionStore != nil && a.cfg.Polymarket.GammaHost != "" {
+		a.resolutionWatcher = service.NewResolutionWatcher(
+			deps.PositionStore, positionSvc, a.newGammaClient(deps), deps.AuditStore,
+			service.ResolutionWatcherConfig{Wallet: signer.Address().Hex(), Interval: interval},
+			a.logger)
+	}
+	// Winning tokens in resolved markets are redeemed for USDC on-chain.
+	if a.cfg.Redemption.Enabled {
+		a.redemption = a.newRedemptionService(ctx, deps, positionSvc, signer.Address().Hex())
+	}
+	// Resting orders past their TTL are cancelled.
+	if interval := a.cfg.Risk.StaleOrderInterval.Duration; interval > 0 && deps.OrderStore != nil {
+		a.orderJanitor = service.NewOrderJanitor(
+			deps.OrderStore, orderSvc, deps.AuditStore,
+			service.OrderJanitorConfig{Wallet: signer.Address().Hex(), Interval: interval},
+			a.logger)
+	}
+	if a.marketFeed != nil {
+		a.marketFeed.AddListener(riskSvc)
+	}
 
 	// Enable arb execution recording if stores are available.
 	if sd != nil && deps.ArbStore != nil && deps.ArbExecutionStore != nil {
@@ -913,13 +2869,45 @@ func (a *App) buildExecutor(ctx context.Context, deps *Dependencies, signalCh <-
 		}
 		arbSvc := service.NewArbService(deps.ArbStore, deps.SignalBus, deps.AuditStore, arbCfg, a.logger)
 		exec.SetArbRecording(arbSvc, deps.ArbExecutionStore, a.cfg.Arbitrage.MaxLegGapMs)
+
+		// Partial executions: page (and optionally close) unhedged exposure.
+		if interval := a.cfg.Arbitrage.UnhedgedCheckInterval.Duration; interval > 0 && a.cfg.Arbitrage.MaxUnhedgedNotional > 0 && deps.PositionStore != nil {
+			a.unhedgedMonitor = service.NewUnhedgedExposureMonitor(
+				deps.ArbExecutionStore, deps.PositionStore, deps.BookCache, riskNotifier, deps.AuditStore,
+				service.UnhedgedMonitorConfig{
+					Wallet:      signer.Address().Hex(),
+					MaxNotional: a.cfg.Arbitrage.MaxUnhedgedNotional,
+					Interval:    interval,
+					Lookback:    a.cfg.Arbitrage.UnhedgedLookback.Duration,
+					Corrective:  a.cfg.Arbitrage.UnhedgedCorrective,
+				}, a.logger)
+		}
+	}
+	// Best-effort leg groups: unwind partial fills past the unhedged limit.
+	if sd != nil && a.cfg.Arbitrage.MaxUnhedgedNotional > 0 {
+		exec.SetHedging(deps.BookCache, a.cfg.Arbitrage.MaxUnhedgedNotional, a.cfg.Arbitrage.HedgeBudgetUSD)
+	}
+	// All-or-none leg groups: roll back legs placed before a failed one.
+	if sd != nil && a.cfg.Arbitrage.UnwindAllOrNone {
+		exec.SetUnwind(deps.BookCache, riskNotifier)
+	}
+	// Fully placed leg groups: re-quote legs left partially filled. Only
+	// with the user channel, since the leg orders' fills come from it.
+	if sd != nil && a.userFeed != nil && a.cfg.Arbitrage.LegTopUpAttempts > 0 {
+		exec.SetLegTopUp(deps.BookCache, a.cfg.Arbitrage.LegTopUpAfter.Duration,
+			a.cfg.Arbitrage.LegTopUpAttempts, a.cfg.Arbitrage.MaxSlippageBps)
+	}
+	// Immediate signals: sweep the book as FAK rather than rest at one price.
+	if a.cfg.Arbitrage.SweepImmediate && deps.BookCache != nil {
+		exec.SetImmediateSweep(deps.BookCache, a.cfg.Arbitrage.SweepEdgeFraction, a.cfg.Arbitrage.MaxSlippageBps)
 	}
 
 	return exec, nil
 }
 
-// pipelineTriggerCh is optional; when non-nil the pipeline loop also runs one cycle on receive.
-func (a *App) startDataPipeline(ctx context.Context, g *errgroup.Group, deps *Dependencies, pipelineTriggerCh <-chan struct{}) error {
+// pipelineQueue is optional; when non-nil its queued runs execute one market
+// scrape plus Goldsky cycle each, alongside the regular interval loops.
+func (a *App) startDataPipeline(ctx context.Context, g *errgroup.Group, deps *Dependencies, pipelineQueue *service.PipelineQueue) error {
 	if deps.MarketStore == nil || deps.TradeStore == nil || deps.AuditStore == nil {
 		return fmt.Errorf("pipeline requires postgres stores (markets, trades, audit)")
 	}
@@ -933,11 +2921,15 @@ func (a *App) startDataPipeline(ctx context.Context, g *errgroup.Group, deps *De
 	}
 
 	marketSvc := service.NewMarketService(deps.MarketStore, deps.MarketCache, deps.SignalBus, a.logger)
+	if reg := a.instrumentRegistry(deps); reg != nil {
+		marketSvc.WithInstruments(reg)
+		a.seedInstrumentLinks(ctx, reg)
+	}
 	marketScraper := pipeline.NewMarketScraper(
 		marketSvc,
-		polymarket.NewGammaClient(a.cfg.Polymarket.GammaHost),
+		a.newGammaClient(deps),
 		a.logger,
-	)
+	).WithSync(a.cfg.Pipeline.MarketPageSize, a.cfg.Pipeline.MarketFullSyncInterval.Duration)
 
 	g.Go(func() error {
 		err := marketScraper.RunLoop(ctx, interval)
@@ -949,7 +2941,7 @@ func (a *App) startDataPipeline(ctx context.Context, g *errgroup.Group, deps *De
 
 	// Event scraper: populate condition_groups and condition_group_markets.
 	if deps.ConditionGroupStore != nil {
-		gammaClient := polymarket.NewGammaClient(a.cfg.Polymarket.GammaHost)
+		gammaClient := a.newGammaClient(deps)
 		eventScraper := pipeline.NewEventScraper(deps.ConditionGroupStore, gammaClient, a.logger, deps.MarketStore)
 		g.Go(func() error {
 			err := eventScraper.RunLoop(ctx, interval)
@@ -962,48 +2954,84 @@ func (a *App) startDataPipeline(ctx context.Context, g *errgroup.Group, deps *De
 
 	// Goldsky scrape + trade processing: only run when pipeline.goldsky_url is set.
 	// If you don't have a Goldsky subgraph, leave it empty and the rest of the bot still runs.
+	// goldskyCycle is shared by the interval loop and queued runs; goldskyMu
+	// keeps them from scraping the same window twice.
+	var goldskyCycle func(ctx context.Context) (fills, ingested int, err error)
 	if a.cfg.Pipeline.GoldskyURL != "" {
 		tradeSvc := service.NewTradeService(deps.TradeStore, deps.SignalBus, deps.AuditStore, a.logger)
+		a.trades = tradeSvc
 		tradeProcessor := pipeline.NewTradeProcessor(tradeSvc, marketSvc, a.logger)
 		goldskyScraper := pipeline.NewGoldskyScraper(
 			goldsky.NewClient(a.cfg.Pipeline.GoldskyURL, a.cfg.Pipeline.GoldskyAPIKey),
 			deps.BlobWriter,
 			a.logger,
+		).WithRetry(deps.Retrier)
+		if a.cfg.S3.Format == "parquet" {
+			goldskyScraper.WithParquet()
+		}
+		// Failed batches are dead-lettered so the scrape moves past their
+		// window; they are replayed every dlq_retry_interval and on demand.
+		var deadLetters *pipeline.DeadLetters
+		if deps.DeadLetterQueue != nil {
+			deadLetters = pipeline.NewDeadLetters(deps.DeadLetterQueue, tradeProcessor, a.cfg.Pipeline.DLQMaxAttempts, a.logger)
+			a.deadLetters = deadLetters
+		}
+
+		var (
+			goldskyMu     sync.Mutex
+			lastTimestamp time.Time
 		)
+		goldskyCycle = func(ctx context.Context) (int, int, error) {
+			goldskyMu.Lock()
+			defer goldskyMu.Unlock()
 
-		g.Go(func() error {
-			lastTimestamp, err := tradeSvc.GetLastTimestamp(ctx)
-			if err != nil {
-				a.logger.WarnContext(ctx, "pipeline: failed to read last trade timestamp, defaulting to 24h lookback",
-					slog.String("error", err.Error()),
-				)
-			}
 			if lastTimestamp.IsZero() {
-				lastTimestamp = time.Now().UTC().Add(-24 * time.Hour)
+				ts, err := tradeSvc.GetLastTimestamp(ctx)
+				if err != nil {
+					a.logger.WarnContext(ctx, "pipeline: failed to read last trade timestamp, defaulting to 24h lookback",
+						slog.String("error", err.Error()),
+					)
+				}
+				if ts.IsZero() {
+					ts = time.Now().UTC().Add(-24 * time.Hour)
+				}
+				lastTimestamp = ts
 			}
 
-			runOnce := func() {
-				fills, scrapeErr := goldskyScraper.Run(ctx, lastTimestamp)
-				if scrapeErr != nil {
-					a.logger.ErrorContext(ctx, "pipeline: goldsky scrape failed", slog.String("error", scrapeErr.Error()))
-					return
+			fills, scrapeErr := goldskyScraper.Run(ctx, lastTimestamp)
+			if scrapeErr != nil {
+				return 0, 0, fmt.Errorf("goldsky scrape: %w", scrapeErr)
+			}
+			if len(fills) == 0 {
+				return 0, 0, nil
+			}
+
+			ingested, processErr := tradeProcessor.ProcessFills(ctx, fills)
+			if processErr != nil {
+				if deadLetters == nil {
+					return len(fills), 0, fmt.Errorf("trade processing: %w", processErr)
 				}
-				if len(fills) == 0 {
-					return
+				if err := deadLetters.Record(ctx, pipeline.StageTradeProcessing, fills, processErr); err != nil {
+					return len(fills), 0, fmt.Errorf("trade processing: %w; %v", processErr, err)
 				}
+				lastTimestamp = latestRawFillTimestamp(fills, lastTimestamp)
+				return len(fills), 0, fmt.Errorf("trade processing, %d fills dead-lettered: %w", len(fills), processErr)
+			}
 
-				ingested, processErr := tradeProcessor.ProcessFills(ctx, fills)
-				if processErr != nil {
-					a.logger.ErrorContext(ctx, "pipeline: trade processing failed", slog.String("error", processErr.Error()))
-					return
-				}
+			lastTimestamp = latestRawFillTimestamp(fills, lastTimestamp)
+			a.logger.InfoContext(ctx, "pipeline: processed goldsky fills",
+				slog.Int("fills", len(fills)),
+				slog.Int("trades_ingested", ingested),
+				slog.Time("last_timestamp", lastTimestamp),
+			)
+			return len(fills), ingested, nil
+		}
 
-				lastTimestamp = latestRawFillTimestamp(fills, lastTimestamp)
-				a.logger.InfoContext(ctx, "pipeline: processed goldsky fills",
-					slog.Int("fills", len(fills)),
-					slog.Int("trades_ingested", ingested),
-					slog.Time("last_timestamp", lastTimestamp),
-				)
+		g.Go(func() error {
+			runOnce := func() {
+				if _, _, err := goldskyCycle(ctx); err != nil {
+					a.logger.ErrorContext(ctx, "pipeline: goldsky cycle failed", slog.String("error", err.Error()))
+				}
 			}
 
 			runOnce()
@@ -1011,29 +3039,86 @@ func (a *App) startDataPipeline(ctx context.Context, g *errgroup.Group, deps *De
 			defer ticker.Stop()
 
 			for {
-				if pipelineTriggerCh != nil {
-					select {
-					case <-ctx.Done():
-						return nil
-					case <-ticker.C:
-						runOnce()
-					case <-pipelineTriggerCh:
-						runOnce()
-					}
-				} else {
-					select {
-					case <-ctx.Done():
-						return nil
-					case <-ticker.C:
-						runOnce()
-					}
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					runOnce()
 				}
 			}
 		})
+
+		if deadLetters != nil && a.cfg.Pipeline.DLQRetryInterval.Duration > 0 {
+			g.Go(func() error {
+				err := deadLetters.RunLoop(ctx, a.cfg.Pipeline.DLQRetryInterval.Duration, a.cfg.Pipeline.DLQRetryBatches)
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("dead letter retry loop: %w", err)
+			})
+		}
 	} else {
 		a.logger.InfoContext(ctx, "pipeline: goldsky_url not set, skipping Goldsky order-fill scrape (rest of bot runs normally)")
 	}
 
+	// CTF splits, merges and redemptions: only when pipeline.goldsky_activity_url is set.
+	var onchainScraper *pipeline.OnchainEventScraper
+	if a.cfg.Pipeline.GoldskyActivityURL != "" && deps.OnchainEventStore != nil {
+		wallets := a.cfg.Pipeline.OnchainWallets
+		if len(wallets) == 0 {
+			if signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID); err == nil {
+				wallets = []string{signer.Address().Hex()}
+			}
+		}
+		onchainScraper = pipeline.NewOnchainEventScraper(
+			goldsky.NewClient(a.cfg.Pipeline.GoldskyActivityURL, a.cfg.Pipeline.GoldskyAPIKey),
+			deps.OnchainEventStore,
+			pipeline.OnchainEventConfig{
+				Wallets:  wallets,
+				Lookback: a.cfg.Pipeline.OnchainLookback.Duration,
+			},
+			a.logger,
+		).WithRetry(deps.Retrier)
+		g.Go(func() error {
+			err := onchainScraper.RunLoop(ctx, interval)
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("onchain event scraper loop: %w", err)
+		})
+	}
+
+	// On-demand runs from POST /api/pipeline/trigger.
+	if pipelineQueue != nil {
+		job := func(ctx context.Context) (map[string]int64, error) {
+			stats := map[string]int64{}
+			if err := marketScraper.Run(ctx); err != nil {
+				return stats, fmt.Errorf("market scrape: %w", err)
+			}
+			if onchainScraper != nil {
+				inserted, err := onchainScraper.Run(ctx)
+				stats["onchain_events"] = inserted
+				if err != nil {
+					return stats, fmt.Errorf("onchain events: %w", err)
+				}
+			}
+			if goldskyCycle == nil {
+				return stats, nil
+			}
+			fills, ingested, err := goldskyCycle(ctx)
+			stats["fills"] = int64(fills)
+			stats["trades_ingested"] = int64(ingested)
+			return stats, err
+		}
+		g.Go(func() error {
+			err := pipelineQueue.Run(ctx, job, interval)
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("pipeline run queue: %w", err)
+		})
+	}
+
 	a.logger.InfoContext(ctx, "pipeline workers started",
 		slog.Duration("interval", interval),
 		slog.String("gamma_host", a.cfg.Polymarket.GammaHost),
@@ -0,0 +1,336 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/config"
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/service"
+	"github.com/alanyoungcy/polymarketbot/internal/strategy"
+)
+
+// reloadNotifyKeys are the notify settings a reload applies to the running
+// notifier and dispatcher.
+var reloadNotifyKeys = map[string]bool{
+	"notify.telegram_token":      true,
+	"notify.telegram_chat_id":    true,
+	"notify.discord_webhook_url": true,
+	"notify.events":              true,
+	"notify.max_retries":         true,
+	"notify.retry_backoff":       true,
+	"notify.rate_per_minute":     true,
+}
+
+// reloadSenderKeys are the notify settings that add or remove senders.
+var reloadSenderKeys = map[string]bool{
+	"notify.telegram_token":      true,
+	"notify.telegram_chat_id":    true,
+	"notify.discord_webhook_url": true,
+}
+
+// reloadRiskKeys are the risk settings a reload applies to the running risk
+// checks. A key also covers the entries of a table of that name.
+var reloadRiskKeys = []string{
+	"risk.daily_loss_limit_usd",
+	"risk.close_haircut_horizon",
+	"risk.close_haircut_min_factor",
+	"risk.close_haircut_multipliers",
+	"risk.min_size_policy",
+	"risk.redeem_gas_usd",
+	"risk.default_edge_bps",
+}
+
+// configReloader implements handler.ConfigReloader for POST
+// /api/config/reload; SIGHUP runs the same reload.
+type configReloader struct {
+	app  *App
+	deps *Dependencies
+}
+
+// ReloadConfig re-reads the configuration file and environment overrides,
+// applies the changes that are safe while running (strategy parameters,
+// notification settings and risk thresholds) and rejects the rest as
+// requiring a restart. A file that fails to load or validate changes
+// nothing and returns an error wrapping domain.ErrInvalidConfig.
+func (r configReloader) ReloadConfig(ctx context.Context) (domain.ConfigReloadReport, error) {
+	a := r.app
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+
+	if a.configPath == "" {
+		return domain.ConfigReloadReport{}, errors.New("config reload: configuration file path not set")
+	}
+	next, err := config.Load(a.configPath)
+	if err != nil {
+		return domain.ConfigReloadReport{}, fmt.Errorf("config reload: %w: %v", domain.ErrInvalidConfig, err)
+	}
+	if err := next.Validate(); err != nil {
+		return domain.ConfigReloadReport{}, fmt.Errorf("config reload: %w: %v", domain.ErrInvalidConfig, err)
+	}
+	cur := a.config()
+	changes, err := config.Diff(cur, next)
+	if err != nil {
+		return domain.ConfigReloadReport{}, fmt.Errorf("config reload: %w", err)
+	}
+
+	report := domain.ConfigReloadReport{ReloadedAt: time.Now().UTC()}
+	rejected := make(map[string]string)
+	var params, notifyChanges, riskChanges []config.Change
+	for _, c := range changes {
+		switch {
+		case strings.HasPrefix(c.Key, "strategy."):
+			if reason := r.classifyStrategyChange(next, c); reason != "" {
+				rejected[c.Key] = reason
+				continue
+			}
+			params = append(params, c)
+		case reloadNotifyKeys[c.Key]:
+			notifyChanges = append(notifyChanges, c)
+		case isRiskReloadKey(c.Key):
+			riskChanges = append(riskChanges, c)
+		default:
+			rejected[c.Key] = "requires a restart"
+		}
+	}
+
+	// Senders are only reached through the dispatcher when it was running
+	// from startup.
+	if d := r.deps.Dispatcher; d != nil && !d.Running() && len(notifySenders(next.Notify)) > 0 {
+		kept := notifyChanges[:0]
+		for _, c := range notifyChanges {
+			if reloadSenderKeys[c.Key] {
+				rejected[c.Key] = "notifications were disabled at startup; enabling them requires a restart"
+				continue
+			}
+			kept = append(kept, c)
+		}
+		notifyChanges = kept
+	}
+
+	for key, reason := range r.applyStrategyParams(ctx, next, params) {
+		rejected[key] = reason
+	}
+
+	var applied []config.Change
+	for _, c := range changes {
+		if _, ok := rejected[c.Key]; ok {
+			report.Rejected = append(report.Rejected, domain.ConfigChange{Key: c.Key, Old: c.Old, New: c.New, Reason: rejected[c.Key]})
+			continue
+		}
+		applied = append(applied, c)
+		report.Applied = append(report.Applied, domain.ConfigChange{Key: c.Key, Old: c.Old, New: c.New})
+	}
+	merged, err := config.Merge(cur, next, applied)
+	if err != nil {
+		return domain.ConfigReloadReport{}, fmt.Errorf("config reload: %w", err)
+	}
+
+	if len(notifyChanges) > 0 {
+		n := merged.Notify
+		r.deps.Notifier.Reconfigure(notifySenders(n), n.Events, n.MaxRetries, n.RetryBackoff.Duration)
+		if r.deps.Dispatcher != nil {
+			r.deps.Dispatcher.SetRate(n.RatePerMinute)
+		}
+	}
+	if len(riskChanges) > 0 {
+		if a.risk != nil {
+			a.risk.SetConfig(riskConfig(merged))
+		}
+		if a.portfolioRisk != nil {
+			a.portfolioRisk.SetDailyLossLimit(merged.Risk.DailyLossLimitUSD)
+		}
+	}
+	// Readers hold on to the previous configuration; it is replaced, never
+	// written in place.
+	a.live.Store(merged)
+
+	r.logReport(ctx, report)
+	return report, nil
+}
+
+// classifyStrategyChange returns why a strategy setting cannot be applied
+// live, or "" when it is a strategy parameter: a strategy.params entry or a
+// section setting that feeds a strategy's parameters.
+func (r configReloader) classifyStrategyChange(next *config.Config, c config.Change) string {
+	cur := r.app.config()
+	if strings.HasPrefix(c.Key, "strategy.params.") {
+		if c.New == nil {
+			return "removing a strategy parameter requires a restart"
+		}
+		return ""
+	}
+	candidate, err := config.Merge(cur, next, []config.Change{c})
+	if err != nil || reflect.DeepEqual(strategyParams(candidate), strategyParams(cur)) {
+		return "requires a restart"
+	}
+	return ""
+}
+
+// applyStrategyParams reconfigures every running strategy whose parameters
+// the changes alter. Parameters saved through PUT /api/strategy/{name}/params
+// keep precedence, as they do on restart. It returns the changes a strategy
+// refused, keyed by setting, with the reason.
+func (r configReloader) applyStrategyParams(ctx context.Context, next *config.Config, changes []config.Change) map[string]string {
+	engine := r.app.engine
+	if engine == nil || len(changes) == 0 {
+		return nil
+	}
+	cur := r.app.config()
+	candidate, err := config.Merge(cur, next, changes)
+	if err != nil {
+		return rejectAll(changes, err.Error())
+	}
+	var stored map[string]map[string]any
+	var params *service.StrategyParamService
+	if r.deps.StratCfgStore != nil {
+		params = service.NewStrategyParamService(r.deps.StratCfgStore, engine, r.app.logger)
+		stored, err = params.Stored(ctx)
+		if err != nil {
+			r.app.logger.WarnContext(ctx, "config reload: saved strategy params unavailable",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	curParams, nextParams := strategyParams(cur), strategyParams(candidate)
+	effective := engine.EffectiveParams()
+	names := make([]string, 0, len(nextParams))
+	for name := range nextParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(map[string]string)
+	for _, name := range names {
+		supported, ok := effective[name]
+		if !ok {
+			continue
+		}
+		update := make(map[string]any)
+		for k, v := range nextParams[name] {
+			if _, ok := supported[k]; !ok {
+				continue
+			}
+			if _, ok := stored[name][k]; ok {
+				continue
+			}
+			if !reflect.DeepEqual(curParams[name][k], v) {
+				update[k] = v
+			}
+		}
+		if len(update) == 0 {
+			continue
+		}
+		if err := engine.Reconfigure(name, update); err != nil {
+			for _, c := range changesFeeding(cur, next, changes, name) {
+				out[c.Key] = fmt.Sprintf("strategy %s: %v", name, err)
+			}
+			continue
+		}
+		if params != nil {
+			if _, _, err := params.SaveVersion(ctx, name, domain.StrategyVersionReload); err != nil {
+				r.app.logger.WarnContext(ctx, "config reload: save strategy param version failed",
+					slog.String("strategy", name),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+	return out
+}
+
+// changesFeeding returns the changes that alter the parameters of the named
+// strategy.
+func changesFeeding(cur, next *config.Config, changes []config.Change, name string) []config.Change {
+	before := strategyParams(cur)[name]
+	var out []config.Change
+	for _, c := range changes {
+		candidate, err := config.Merge(cur, next, []config.Change{c})
+		if err != nil || !reflect.DeepEqual(strategyParams(candidate)[name], before) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func rejectAll(changes []config.Change, reason string) map[string]string {
+	out := make(map[string]string, len(changes))
+	for _, c := range changes {
+		out[c.Key] = reason
+	}
+	return out
+}
+
+func isRiskReloadKey(key string) bool {
+	for _, k := range reloadRiskKeys {
+		if key == k || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// logReport logs the outcome of a reload and records it as a
+// "config_reloaded" audit entry.
+func (r configReloader) logReport(ctx context.Context, report domain.ConfigReloadReport) {
+	applied := make([]string, 0, len(report.Applied))
+	for _, c := range report.Applied {
+		applied = append(applied, c.Key)
+	}
+	rejected := make(map[string]string, len(report.Rejected))
+	for _, c := range report.Rejected {
+		rejected[c.Key] = c.Reason
+	}
+	log := r.app.logger.InfoContext
+	if len(rejected) > 0 {
+		log = r.app.logger.WarnContext
+	}
+	log(ctx, "configuration reloaded",
+		slog.Any("applied", applied),
+		slog.Any("rejected", rejected),
+	)
+	if r.deps.AuditStore == nil || len(applied)+len(rejected) == 0 {
+		return
+	}
+	if err := r.deps.AuditStore.Log(ctx, "config_reloaded", map[string]any{
+		"applied":  applied,
+		"rejected": rejected,
+	}); err != nil {
+		r.app.logger.WarnContext(ctx, "config reload: audit log failed", slog.String("error", err.Error()))
+	}
+}
+
+// setEngine records the strategy engine reloads apply parameters to.
+func (a *App) setEngine(engine *strategy.Engine) {
+	a.reloadMu.Lock()
+	a.engine = engine
+	a.reloadMu.Unlock()
+}
+
+// reloadOnSignal reloads the configuration on every SIGHUP until ctx is
+// cancelled.
+func (a *App) reloadOnSignal(ctx context.Context, deps *Dependencies) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if _, err := (configReloader{app: a, deps: deps}).ReloadConfig(ctx); err != nil {
+				a.logger.ErrorContext(ctx, "configuration reload failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
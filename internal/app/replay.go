@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alanyoungcy/polymarketbot/internal/backtest"
+	"github.com/alanyoungcy/polymarketbot/internal/crypto"
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// ReplaySignals re-feeds the recorded signals matching f through a dry-run
+// executor and compares what it makes of each with the recorded outcome.
+// With withRisk the current risk checks run too, for the configured wallet.
+// It wires only Postgres and Redis regardless of the configured mode.
+func (a *App) ReplaySignals(ctx context.Context, f domain.SignalFilter, withRisk bool) (backtest.SignalReplayReport, error) {
+	cfg := *a.cfg
+	cfg.Mode = "replay-signals"
+	deps, cleanup, err := Wire(ctx, &cfg)
+	if err != nil {
+		return backtest.SignalReplayReport{}, fmt.Errorf("app: wire dependencies: %w", err)
+	}
+	a.closers = append(a.closers, cleanup)
+	if deps.SignalStore == nil {
+		return backtest.SignalReplayReport{}, fmt.Errorf("app: replay signals: postgres not configured")
+	}
+
+	replayCfg := backtest.SignalReplayConfig{MaxLegGapMs: a.cfg.Arbitrage.MaxLegGapMs}
+	if a.cfg.Wallet.PrivateKey != "" {
+		signer, err := crypto.NewSigner(a.cfg.Wallet.PrivateKey, a.cfg.Polymarket.ChainID)
+		if err != nil {
+			return backtest.SignalReplayReport{}, fmt.Errorf("app: replay signals: create signer: %w", err)
+		}
+		replayCfg.Wallet = signer.Address().Hex()
+	}
+	replayer := backtest.NewSignalReplayer(deps.SignalStore, replayCfg, a.logger)
+	if withRisk {
+		replayer.WithRisk(a.riskService(deps))
+	}
+	return replayer.Run(ctx, f)
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/alanyoungcy/polymarketbot/internal/config"
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/service"
+)
+
+// runtimeConfig implements handler.RuntimeConfigProvider for GET /api/config.
+// Subsystem state is read per request because the executor and its
+// companions are built after the HTTP server starts.
+type runtimeConfig struct {
+	app           *App
+	deps          *Dependencies
+	pipelineQueue *service.PipelineQueue
+	strategies    bool // a strategy engine runs in this mode
+}
+
+func (rc runtimeConfig) RedactedConfig() (map[string]any, error) {
+	return config.RedactedMap(rc.app.config())
+}
+
+// Subsystems reports each optional subsystem and, when it is not running,
+// the missing store, key or setting that disabled it.
+func (rc runtimeConfig) Subsystems() []domain.SubsystemStatus {
+	cfg, deps := rc.app.config(), rc.deps
+	mode := cfg.Mode
+	var out []domain.SubsystemStatus
+	add := func(name, reason string) {
+		out = append(out, domain.SubsystemStatus{Name: name, Active: reason == "", Reason: reason})
+	}
+	notInMode := fmt.Sprintf("not used in %s mode", mode)
+	noPostgres := "missing store: postgres not configured"
+
+	add("redis", unless(deps.SignalBus != nil, "missing store: redis not configured"))
+	add("postgres", unless(deps.MarketStore != nil, notInMode))
+	add("s3", unless(deps.BlobWriter != nil, notInMode))
+
+	add("strategy_engine", unless(rc.strategies, notInMode))
+	execReason := notInMode
+	if rc.strategies {
+		execReason = "missing key: wallet.private_key"
+		if cfg.Wallet.PrivateKey != "" {
+			execReason = "signer unavailable"
+		}
+	}
+	add("executor", unless(rc.app.portfolioRisk != nil, execReason))
+	var userFeed, watcher string
+	switch {
+	case rc.app.portfolioRisk == nil:
+		userFeed, watcher = "executor not running", "executor not running"
+	default:
+		userFeed, watcher = "missing key: CLOB API credentials", noPostgres
+	}
+	if !cfg.Polymarket.UserChannel {
+		userFeed = "disabled: polymarket.user_channel is false"
+	}
+	resolution, janitor := watcher, watcher
+	if cfg.Risk.MetadataPollInterval.Duration <= 0 {
+		watcher = "disabled: risk.metadata_poll_interval is 0"
+	}
+	if cfg.Risk.ResolutionPollInterval.Duration <= 0 {
+		resolution = "disabled: risk.resolution_poll_interval is 0"
+	}
+	if cfg.Risk.StaleOrderInterval.Duration <= 0 {
+		janitor = "disabled: risk.stale_order_interval is 0"
+	}
+	add("user_fill_feed", unless(rc.app.userFeed != nil, userFeed))
+	add("market_watcher", unless(rc.app.marketWatcher != nil, watcher))
+	add("resolution_watcher", unless(rc.app.resolutionWatcher != nil, resolution))
+	redemption := "executor not running"
+	switch {
+	case !cfg.Redemption.Enabled:
+		redemption = "disabled: redemption.enabled is false"
+	case cfg.Wallet.SafeAddress != "":
+		redemption = "unsupported: wallet.safe_address is set"
+	case deps.RedemptionStore == nil:
+		redemption = noPostgres
+	}
+	add("redemption", unless(rc.app.redemption != nil, redemption))
+	add("order_janitor", unless(rc.app.orderJanitor != nil, janitor))
+	unhedged := "executor not running"
+	switch {
+	case cfg.Arbitrage.UnhedgedCheckInterval.Duration <= 0:
+		unhedged = "disabled: arbitrage.unhedged_check_interval is 0"
+	case cfg.Arbitrage.MaxUnhedgedNotional <= 0:
+		unhedged = "disabled: arbitrage.max_unhedged_notional is 0"
+	case rc.app.portfolioRisk != nil:
+		unhedged = noPostgres
+	}
+	add("unhedged_monitor", unless(rc.app.unhedgedMonitor != nil, unhedged))
+
+	add("kalshi", unless(cfg.Kalshi.BaseURL != "" && cfg.Kalshi.ApiKey != "" && cfg.Kalshi.RsaPrivateKeyPath != "",
+		"missing key: kalshi.api_key or kalshi.rsa_private_key_path"))
+	add("predictit", unless(cfg.PredictIt.Enabled, "disabled: predictit.enabled is false"))
+	add("manifold", unless(cfg.Manifold.Enabled, "disabled: manifold.enabled is false"))
+
+	add("notify", unless(cfg.Notify.TelegramToken != "" || cfg.Notify.DiscordWebhookURL != "",
+		"missing key: notify.telegram_token or notify.discord_webhook_url"))
+	add("alerts", unless(deps.AlertStore != nil, noPostgres))
+	add("timeline", unless(deps.TimelineStore != nil, noPostgres))
+	add("audit_log", unless(deps.AuditStore != nil, noPostgres))
+	recorder := noPostgres
+	if !cfg.Recorder.Enabled {
+		recorder = "disabled: recorder.enabled is false"
+	}
+	add("recorder", unless(cfg.Recorder.Enabled && deps.BookEventStore != nil, recorder))
+	bookSnapshots := notInMode
+	if rc.strategies {
+		bookSnapshots = "missing store: s3 not configured"
+		if !cfg.BookSnapshots.Enabled {
+			bookSnapshots = "disabled: book_snapshots.enabled is false"
+		}
+	}
+	add("book_snapshots", unless(rc.strategies && cfg.BookSnapshots.Enabled && deps.BlobWriter != nil, bookSnapshots))
+	candles := noPostgres
+	if !cfg.Candles.Enabled {
+		candles = "disabled: candles.enabled is false"
+	}
+	add("candles", unless(cfg.Candles.Enabled && deps.CandleStore != nil, candles))
+	features := notInMode
+	if rc.strategies {
+		features = "disabled: features.enabled is false"
+	}
+	add("features", unless(rc.strategies && cfg.Features.Enabled, features))
+	pricefeed := notInMode
+	if rc.strategies {
+		pricefeed = "disabled: pricefeed.enabled is false"
+	}
+	add("pricefeed", unless(rc.strategies && cfg.PriceFeed.Enabled, pricefeed))
+	sampling := notInMode
+	if rc.strategies {
+		sampling = "disabled: strategy.sampling.enabled is false"
+	}
+	add("event_sampling", unless(rc.strategies && cfg.Strategy.Sampling.Enabled, sampling))
+	breakers := notInMode
+	switch {
+	case rc.strategies && !cfg.Strategy.Breaker.Enabled:
+		breakers = "disabled: strategy.breaker.enabled is false"
+	case rc.strategies:
+		breakers = "missing store: redis not configured"
+	}
+	add("strategy_breakers", unless(rc.strategies && cfg.Strategy.Breaker.Enabled && deps.SignalBus != nil, breakers))
+	signals := noPostgres
+	switch {
+	case !rc.strategies:
+		signals = notInMode
+	case !cfg.Signals.Record && !cfg.Hindsight.Enabled:
+		signals = "disabled: signals.record is false"
+	}
+	add("signals", unless(rc.app.signals != nil, signals))
+	hindsight := noPostgres
+	switch {
+	case !rc.strategies:
+		hindsight = notInMode
+	case !cfg.Hindsight.Enabled:
+		hindsight = "disabled: hindsight.enabled is false"
+	}
+	add("hindsight", unless(rc.app.hindsight != nil, hindsight))
+	performance := noPostgres
+	switch {
+	case !rc.strategies:
+		performance = notInMode
+	case !cfg.Performance.Enabled:
+		performance = "disabled: performance.enabled is false"
+	}
+	add("performance", unless(rc.strategies && cfg.Performance.Enabled && deps.PerformanceStore != nil, performance))
+	matcher := noPostgres
+	switch {
+	case !rc.strategies:
+		matcher = notInMode
+	case !cfg.CrossMap.Enabled:
+		matcher = "disabled: crossmap.enabled is false"
+	case cfg.Kalshi.ApiKey == "" || cfg.Kalshi.RsaPrivateKeyPath == "":
+		matcher = "missing key: kalshi.api_key or kalshi.rsa_private_key_path"
+	}
+	add("market_matcher", unless(rc.app.marketMatcher != nil, matcher))
+	heatmap := noPostgres
+	if !rc.strategies && mode != "monitor" {
+		heatmap = notInMode
+	}
+	add("heatmap", unless(rc.app.heatmap != nil, heatmap))
+	cancelRatio := notInMode
+	switch {
+	case rc.strategies && cfg.Risk.CancelRatioLimit == 0:
+		cancelRatio = "disabled: risk.cancel_ratio_limit is 0"
+	case rc.strategies:
+		cancelRatio = "missing store: redis not configured"
+	}
+	add("cancel_ratio", unless(rc.app.cancelRatio != nil, cancelRatio))
+	fees := "missing key: polymarket.gamma_host"
+	if !cfg.Fees.Enabled {
+		fees = "disabled: fees.enabled is false"
+	}
+	add("fees", unless(rc.app.fees != nil, fees))
+	balance := "signer unavailable"
+	switch {
+	case cfg.Polygon.RPCURL == "":
+		balance = "missing key: polygon.rpc_url"
+	case cfg.Wallet.PrivateKey == "":
+		balance = "missing key: wallet.private_key"
+	case !cfg.Polygon.BalanceCheck:
+		balance = "disabled: polygon.balance_check is false"
+	}
+	add("balance_check", unless(rc.app.balances != nil && cfg.Polygon.BalanceCheck, balance))
+	capital := noPostgres
+	switch {
+	case !cfg.Capital.Enabled:
+		capital = "disabled: capital.enabled is false"
+	case cfg.Wallet.PrivateKey == "":
+		capital = "missing key: wallet.private_key"
+	}
+	add("capital_allocator", unless(rc.app.capital != nil, capital))
+	sweep := noPostgres
+	switch {
+	case !cfg.Sweep.Enabled:
+		sweep = "disabled: sweep.enabled is false"
+	case cfg.Wallet.SafeAddress != "":
+		sweep = "unsupported: wallet.safe_address is set"
+	}
+	add("profit_sweep", unless(cfg.Sweep.Enabled && deps.SweepStore != nil && cfg.Wallet.SafeAddress == "", sweep))
+	pipeline := notInMode
+	if mode == "full" {
+		pipeline = noPostgres
+	}
+	add("pipeline", unless(rc.pipelineQueue != nil, pipeline))
+	add("instruments", unless(deps.InstrumentStore != nil && deps.InstrumentCache != nil, noPostgres))
+	detector := noPostgres
+	switch {
+	case mode != "arbitrage" && mode != "full":
+		detector = notInMode
+	case mode == "full" && !cfg.Arbitrage.Enabled:
+		detector = "disabled: arbitrage.enabled is false"
+	}
+	add("arbitrage_detector", unless(deps.ArbStore != nil && (mode == "arbitrage" || cfg.Arbitrage.Enabled && mode == "full"), detector))
+	dedup := "missing store: redis not configured"
+	if cfg.Arbitrage.OpportunityDedupWindow.Duration <= 0 {
+		dedup = "disabled: arbitrage.opportunity_dedup_window is 0"
+	}
+	add("opportunity_dedup", unless(deps.OpportunityRegistry != nil && cfg.Arbitrage.OpportunityDedupWindow.Duration > 0, dedup))
+	add("ws_auth", unless(cfg.Server.WSToken != "" || len(cfg.Server.WSACL) > 0,
+		"disabled: server.ws_token and server.ws_acl are empty"))
+	return out
+}
+
+// unless returns "" when ok and reason otherwise.
+func unless(ok bool, reason string) string {
+	if ok {
+		return ""
+	}
+	return reason
+}
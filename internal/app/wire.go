@@ -11,6 +11,7 @@ import (
 	"github.com/alanyoungcy/polymarketbot/internal/config"
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 	"github.com/alanyoungcy/polymarketbot/internal/notify"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/retry"
 	"github.com/alanyoungcy/polymarketbot/internal/store/postgres"
 )
 
@@ -30,15 +31,42 @@ type Dependencies struct {
 	ConditionGroupStore  domain.ConditionGroupStore
 	BondPositionStore    domain.BondPositionStore
 	MarketRelationStore  domain.MarketRelationStore
+	BookEventStore       domain.BookEventStore
+	CandleStore          domain.CandleStore
+	PerformanceStore     domain.PerformanceStore
+	AlertStore           domain.AlertStore
+	SweepStore           domain.SweepStore
+	KillSwitchStore      domain.KillSwitchStore
+	RedemptionStore      domain.RedemptionStore
+	PipelineRunStore     domain.PipelineRunStore
+	InstrumentStore      domain.InstrumentStore
+	SignalStore          domain.SignalStore
+	ShadowCandidateStore domain.SignalStore // signals of shadow-mode strategies
+	CrossMatchStore      domain.CrossMatchStore
+	TimelineStore        domain.TimelineStore
+	OnchainEventStore    domain.OnchainEventStore
+	LPRewardStore        domain.LPRewardStore
+	LatencyStore         domain.LatencyStore
+	MarketListStore      domain.MarketListStore
 
 	// Caches
 	PriceCache           domain.PriceCache
 	BookCache            domain.OrderbookCache
+	FeatureCache         domain.FeatureCache
+	ToxicityCache        domain.ToxicityCache
+	TradeAnalyticsCache  domain.TradeAnalyticsCache
+	ReferencePriceCache  domain.ReferencePriceCache
 	MarketCache          domain.MarketCache
+	MarketListCache      domain.MarketListCache
 	ConditionGroupCache  domain.ConditionGroupCache
+	InstrumentCache      domain.InstrumentCache
 	RateLimiter          domain.RateLimiter
+	TokenBucket          domain.TokenBucket // outbound API throttling
 	LockManager          domain.LockManager
+	OpportunityRegistry  domain.OpportunityRegistry
 	SignalBus            domain.SignalBus
+	DeadLetterQueue      domain.DeadLetterQueue // failed pipeline batches
+	Keyspace             *redis.Keyspace // namespaces of the caches above
 
 	// Blob storage
 	BlobWriter  domain.BlobWriter
@@ -47,24 +75,51 @@ type Dependencies struct {
 	Archiver    domain.Archiver
 
 	// Notifications
-	Notifier *notify.Notifier
+	Notifier   *notify.Notifier
+	Dispatcher *notify.Dispatcher // nil when no signal bus is wired
+
+	// Retrier retries failed venue and Goldsky calls and counts failures by
+	// error class; nil when retry.enabled is off.
+	Retrier *retry.Retrier
+
+	// Probes check the reachability of the backing services wired above,
+	// for /api/health/ready.
+	Probes []domain.HealthProbe
 }
 
 // needsPostgres returns true for modes that require a database connection.
 func needsPostgres(mode string) bool {
 	switch mode {
-	case "trade", "arbitrage", "scrape", "backtest", "full":
+	case "trade", "arbitrage", "scrape", "backtest", "backfill", "full", "audit-amounts", "replay-signals":
 		return true
 	default:
 		return false
 	}
 }
 
-// needsS3 returns true for modes that require object storage.
-func needsS3(mode string) bool {
-	switch mode {
-	case "scrape", "backtest", "full":
+// postgresConfig returns the PostgreSQL client settings from cfg.Supabase.
+func postgresConfig(cfg *config.Config) postgres.ClientConfig {
+	return postgres.ClientConfig{
+		DSN:      cfg.Supabase.DSN,
+		Host:     cfg.Supabase.Host,
+		Port:     cfg.Supabase.Port,
+		Database: cfg.Supabase.Database,
+		User:     cfg.Supabase.User,
+		Password: cfg.Supabase.Password,
+		SSLMode:  cfg.Supabase.SSLMode,
+		MaxConns: cfg.Supabase.PoolMaxConns,
+		MinConns: cfg.Supabase.PoolMinConns,
+	}
+}
+
+// needsS3 returns true for modes that require object storage, and for trade
+// mode when the book snapshot recorder is enabled.
+func needsS3(cfg *config.Config) bool {
+	switch cfg.Mode {
+	case "scrape", "backtest", "backfill", "full":
 		return true
+	case "trade":
+		return cfg.BookSnapshots.Enabled
 	default:
 		return false
 	}
@@ -87,22 +142,13 @@ func Wire(ctx context.Context, cfg *config.Config) (*Dependencies, func(), error
 
 	// --- PostgreSQL (only for modes that need persistence) ---
 	if needsPostgres(cfg.Mode) {
-		pgClient, err := postgres.New(ctx, postgres.ClientConfig{
-			DSN:      cfg.Supabase.DSN,
-			Host:     cfg.Supabase.Host,
-			Port:     cfg.Supabase.Port,
-			Database: cfg.Supabase.Database,
-			User:     cfg.Supabase.User,
-			Password: cfg.Supabase.Password,
-			SSLMode:  cfg.Supabase.SSLMode,
-			MaxConns: cfg.Supabase.PoolMaxConns,
-			MinConns: cfg.Supabase.PoolMinConns,
-		})
+		pgClient, err := postgres.New(ctx, postgresConfig(cfg))
 		if err != nil {
 			cleanup()
 			return nil, nil, fmt.Errorf("wire: postgres: %w", err)
 		}
 		closers = append(closers, pgClient.Close)
+		deps.Probes = append(deps.Probes, domain.HealthProbe{Name: "postgres", Check: pgClient.Ping})
 
 		// Run migrations if enabled.
 		if cfg.Supabase.RunMigrations {
@@ -124,6 +170,23 @@ func Wire(ctx context.Context, cfg *config.Config) (*Dependencies, func(), error
 		deps.ConditionGroupStore = postgres.NewConditionGroupStore(pool)
 		deps.BondPositionStore = postgres.NewBondPositionStore(pool)
 		deps.MarketRelationStore = postgres.NewMarketRelationStore(pool)
+		deps.BookEventStore = postgres.NewBookEventStore(pool)
+		deps.CandleStore = postgres.NewCandleStore(pool)
+		deps.PerformanceStore = postgres.NewPerformanceStore(pool)
+		deps.LPRewardStore = postgres.NewLPRewardStore(pool)
+		deps.LatencyStore = postgres.NewLatencyStore(pool)
+		deps.MarketListStore = postgres.NewMarketListStore(pool)
+		deps.AlertStore = postgres.NewAlertStore(pool)
+		deps.SweepStore = postgres.NewSweepStore(pool)
+		deps.KillSwitchStore = postgres.NewKillSwitchStore(pool)
+		deps.RedemptionStore = postgres.NewRedemptionStore(pool)
+		deps.PipelineRunStore = postgres.NewPipelineRunStore(pool)
+		deps.InstrumentStore = postgres.NewInstrumentStore(pool)
+		deps.SignalStore = postgres.NewSignalStore(pool)
+		deps.ShadowCandidateStore = postgres.NewShadowCandidateStore(pool)
+		deps.CrossMatchStore = postgres.NewCrossMatchStore(pool)
+		deps.TimelineStore = postgres.NewTimelineStore(pool)
+		deps.OnchainEventStore = postgres.NewOnchainEventStore(pool)
 	}
 
 	// --- Redis ---
@@ -140,6 +203,7 @@ func Wire(ctx context.Context, cfg *config.Config) (*Dependencies, func(), error
 		return nil, nil, fmt.Errorf("wire: redis: %w", err)
 	}
 	closers = append(closers, func() { _ = redisClient.Close() })
+	deps.Probes = append(deps.Probes, domain.HealthProbe{Name: "redis", Check: redisClient.Ping})
 
 	redisTTL := time.Duration(0)
 	if cfg.Redis.CacheTTLMinutes > 0 {
@@ -150,16 +214,29 @@ func Wire(ctx context.Context, cfg *config.Config) (*Dependencies, func(), error
 		streamMaxLen = int64(cfg.Redis.StreamMaxLen)
 	}
 
-	deps.PriceCache = redis.NewPriceCache(redisClient, redisTTL)
-	deps.BookCache = redis.NewOrderbookCache(redisClient, redisTTL)
-	deps.MarketCache = redis.NewMarketCache(redisClient)
-	deps.ConditionGroupCache = redis.NewConditionGroupCache(redisClient)
-	deps.RateLimiter = redis.NewRateLimiter(redisClient)
-	deps.LockManager = redis.NewLockManager(redisClient)
+	// Each subsystem writes under its own namespace below the root prefix so
+	// keys cannot collide and one namespace can be flushed on its own.
+	keys := redis.NewKeyspace(redisClient.Namespace(cfg.Redis.KeyPrefix))
+	deps.Keyspace = keys
+	deps.PriceCache = redis.NewPriceCache(keys.MarketData(), redisTTL)
+	deps.BookCache = redis.NewOrderbookCache(keys.MarketData(), redisTTL)
+	deps.FeatureCache = redis.NewFeatureCache(keys.MarketData(), redisTTL)
+	deps.ToxicityCache = redis.NewToxicityCache(keys.MarketData(), domain.ToxicityMaxAge)
+	deps.TradeAnalyticsCache = redis.NewTradeAnalyticsCache(keys.MarketData(), domain.TradeAnalyticsVolumeWindow)
+	deps.ReferencePriceCache = redis.NewReferencePriceCache(keys.MarketData(), redisTTL)
+	deps.MarketCache = redis.NewMarketCache(keys.Catalog())
+	deps.MarketListCache = redis.NewMarketListCache(keys.Catalog())
+	deps.ConditionGroupCache = redis.NewConditionGroupCache(keys.Catalog())
+	deps.InstrumentCache = redis.NewInstrumentCache(keys.Catalog())
+	deps.RateLimiter = redis.NewRateLimiter(keys.Execution())
+	deps.TokenBucket = redis.NewTokenBucketLimiter(keys.Execution())
+	deps.LockManager = redis.NewLockManager(keys.Execution())
+	deps.OpportunityRegistry = redis.NewOpportunityRegistry(keys.Opportunity())
 	deps.SignalBus = redis.NewSignalBusWithMaxLen(redisClient, streamMaxLen)
+	deps.DeadLetterQueue = redis.NewDeadLetterQueue(keys.Pipeline())
 
 	// --- S3 blob storage (only for modes that need object storage) ---
-	if needsS3(cfg.Mode) {
+	if needsS3(cfg) {
 		s3Client, err := s3blob.New(ctx, s3blob.ClientConfig{
 			Endpoint:       cfg.S3.Endpoint,
 			Region:         cfg.S3.Region,
@@ -174,6 +251,7 @@ func Wire(ctx context.Context, cfg *config.Config) (*Dependencies, func(), error
 			return nil, nil, fmt.Errorf("wire: s3: %w", err)
 		}
 		closers = append(closers, func() { _ = s3Client.Close() })
+		deps.Probes = append(deps.Probes, domain.HealthProbe{Name: "s3", Check: s3Client.Health})
 
 		deps.BlobWriter = s3blob.NewWriter(s3Client)
 		reader := s3blob.NewReader(s3Client)
@@ -181,28 +259,56 @@ func Wire(ctx context.Context, cfg *config.Config) (*Dependencies, func(), error
 		deps.BlobDeleter = reader // same type implements BlobDeleter
 		// Archiver: only when we also have Postgres (stores with ListBefore + AuditStore)
 		if deps.TradeStore != nil && deps.OrderStore != nil && deps.ArbStore != nil && deps.AuditStore != nil {
-			deps.Archiver = s3blob.NewArchiver(
+			archiver := s3blob.NewArchiver(
 				deps.BlobWriter,
 				deps.TradeStore,
 				deps.OrderStore,
 				deps.ArbStore,
 				deps.AuditStore,
 			)
+			if cfg.S3.Format == "parquet" {
+				archiver.WithParquet(cfg.S3.PartitionByMarket)
+			}
+			deps.Archiver = archiver
 		}
 	}
 
-	// --- Notifications ---
-	var senders []notify.Sender
-	if cfg.Notify.TelegramToken != "" && cfg.Notify.TelegramChatID != "" {
-		senders = append(senders, notify.NewTelegramSender(
-			cfg.Notify.TelegramToken,
-			cfg.Notify.TelegramChatID,
-		))
+	// --- Retry ---
+	if cfg.Retry.Enabled {
+		deps.Retrier = retry.New(retry.Policy{
+			MaxAttempts:     cfg.Retry.MaxAttempts,
+			BaseDelay:       cfg.Retry.BaseDelay.Duration,
+			MaxDelay:        cfg.Retry.MaxDelay.Duration,
+			RateLimitFactor: cfg.Retry.RateLimitFactor,
+			Jitter:          cfg.Retry.Jitter,
+		}, logger)
 	}
-	if cfg.Notify.DiscordWebhookURL != "" {
-		senders = append(senders, notify.NewDiscordSender(cfg.Notify.DiscordWebhookURL))
+
+	// --- Notifications ---
+	deps.Notifier = notify.NewNotifier(notifySenders(cfg.Notify), cfg.Notify.Events, logger).
+		WithRetry(cfg.Notify.MaxRetries, cfg.Notify.RetryBackoff.Duration)
+	if deps.SignalBus != nil {
+		deps.Dispatcher = notify.NewDispatcher(
+			deps.Notifier,
+			deps.SignalBus,
+			cfg.Notify.Channels,
+			cfg.Notify.RatePerMinute,
+			logger,
+		)
 	}
-	deps.Notifier = notify.NewNotifier(senders, cfg.Notify.Events, logger)
 
 	return deps, cleanup, nil
 }
+
+// notifySenders returns a sender for every notification channel configured
+// in cfg.
+func notifySenders(cfg config.NotifyConfig) []notify.Sender {
+	var senders []notify.Sender
+	if cfg.TelegramToken != "" && cfg.TelegramChatID != "" {
+		senders = append(senders, notify.NewTelegramSender(cfg.TelegramToken, cfg.TelegramChatID))
+	}
+	if cfg.DiscordWebhookURL != "" {
+		senders = append(senders, notify.NewDiscordSender(cfg.DiscordWebhookURL))
+	}
+	return senders
+}
@@ -3,6 +3,7 @@ package arbitrage
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -12,30 +13,49 @@ import (
 	"github.com/alanyoungcy/polymarketbot/internal/service"
 )
 
-// Detector runs the selected arbitrage strategy on orderbook updates from the
-// "prices" channel and evaluates/records opportunities via ArbService.
+// Detector runs the selected arbitrage strategies on orderbook updates from
+// the "prices" channel and evaluates/records opportunities via ArbService.
+// Every strategy sees every update; when several find an opportunity on the
+// same market and direction from one update, only the one with the best net
+// edge is recorded. An opportunity still open (same dedup key) is refreshed
+// rather than recorded again, and open opportunities on the updated token
+// that no longer pass evaluation are expired. Opportunities already
+// recorded, or claimed by an executing strategy, within the dedup window are
+// skipped.
 type Detector struct {
-	strategy Strategy
-	arbSvc   *service.ArbService
-	bookCache domain.OrderbookCache
-	logger   *slog.Logger
+	strategies  []Strategy
+	arbSvc      *service.ArbService
+	bookCache   domain.OrderbookCache
+	opps        domain.OpportunityRegistry
+	dedupWindow time.Duration
+	fees        *service.FeeModel
+	logger      *slog.Logger
 }
 
-// DetectorConfig configures the detector.
+// DetectorConfig configures the detector. Opportunities and DedupWindow are
+// optional; dedup is off when either is unset. Fees is optional; when set,
+// single-venue opportunities are repriced with their market's taker fee
+// instead of the strategy's static estimate.
 type DetectorConfig struct {
-	Strategy Strategy
-	ArbSvc   *service.ArbService
-	BookCache domain.OrderbookCache
-	Logger   *slog.Logger
+	Strategies    []Strategy
+	ArbSvc        *service.ArbService
+	BookCache     domain.OrderbookCache
+	Opportunities domain.OpportunityRegistry
+	DedupWindow   time.Duration
+	Fees          *service.FeeModel
+	Logger        *slog.Logger
 }
 
-// NewDetector creates a detector that runs the given strategy.
+// NewDetector creates a detector that runs the given strategies.
 func NewDetector(cfg DetectorConfig) *Detector {
 	return &Detector{
-		strategy:  cfg.Strategy,
-		arbSvc:   cfg.ArbSvc,
-		bookCache: cfg.BookCache,
-		logger:    cfg.Logger.With(slog.String("component", "arb_detector")),
+		strategies:  cfg.Strategies,
+		arbSvc:      cfg.ArbSvc,
+		bookCache:   cfg.BookCache,
+		opps:        cfg.Opportunities,
+		dedupWindow: cfg.DedupWindow,
+		fees:        cfg.Fees,
+		logger:      cfg.Logger.With(slog.String("component", "arb_detector")),
 	}
 }
 
@@ -49,20 +69,34 @@ type priceEvent struct {
 	Timestamp string  `json:"timestamp"`
 }
 
-// Run subscribes to the "prices" channel and runs the strategy on each update.
-// It blocks until ctx is cancelled.
+// Run subscribes to the "prices" channel and runs the strategies on each
+// update, and to "trades" when a strategy is a TradeObserver. It blocks
+// until ctx is cancelled.
 func (d *Detector) Run(ctx context.Context, bus domain.SignalBus) error {
 	ch, err := bus.Subscribe(ctx, "prices")
 	if err != nil {
 		return fmt.Errorf("arb detector: subscribe prices: %w", err)
 	}
-	d.logger.Info("arb detector started", slog.String("strategy", d.strategy.Name()))
+	var trades <-chan []byte
+	if len(d.observers()) > 0 {
+		trades, err = bus.Subscribe(ctx, "trades")
+		if err != nil {
+			return fmt.Errorf("arb detector: subscribe trades: %w", err)
+		}
+	}
+	d.logger.Info("arb detector started", slog.String("strategies", strings.Join(d.names(), ",")))
 	defer d.logger.Info("arb detector stopped")
 
+	// Open opportunities on tokens without updates expire after their TTL.
+	sweep := time.NewTicker(time.Second)
+	defer sweep.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
+		case now := <-sweep.C:
+			d.arbSvc.ExpireStale(ctx, now)
 		case data, ok := <-ch:
 			if !ok {
 				return nil
@@ -73,10 +107,57 @@ func (d *Detector) Run(ctx context.Context, bus domain.SignalBus) error {
 					slog.String("payload", string(data)),
 				)
 			}
+		case data, ok := <-trades:
+			if !ok {
+				trades = nil
+				continue
+			}
+			d.handleTrade(data)
 		}
 	}
 }
 
+// tradeEvent is the JSON shape published by PriceService to "trades".
+type tradeEvent struct {
+	Event     string  `json:"event"`
+	AssetID   string  `json:"asset_id"`
+	Price     float64 `json:"price"`
+	Size      float64 `json:"size"`
+	Side      string  `json:"side"`
+	Timestamp string  `json:"timestamp"`
+}
+
+// handleTrade passes a trade print to the strategies that observe trades.
+func (d *Detector) handleTrade(data []byte) {
+	var ev tradeEvent
+	if err := json.Unmarshal(data, &ev); err != nil || ev.Event != "last_trade_price" || ev.AssetID == "" {
+		return
+	}
+	trade := domain.LastTradePrice{
+		AssetID: ev.AssetID,
+		Price:   ev.Price,
+		Size:    ev.Size,
+		Side:    domain.OrderSide(strings.ToLower(ev.Side)),
+	}
+	if t, err := time.Parse(time.RFC3339Nano, ev.Timestamp); err == nil {
+		trade.Timestamp = t
+	}
+	for _, o := range d.observers() {
+		o.ObserveTrade(trade)
+	}
+}
+
+// observers returns the strategies that observe trades.
+func (d *Detector) observers() []TradeObserver {
+	var out []TradeObserver
+	for _, s := range d.strategies {
+		if o, ok := s.(TradeObserver); ok {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
 func (d *Detector) handleMessage(ctx context.Context, data []byte) error {
 	var ev priceEvent
 	if err := json.Unmarshal(data, &ev); err != nil {
@@ -114,11 +195,23 @@ func (d *Detector) handleMessage(ctx context.Context, data []byte) error {
 		}
 	}
 
-	opps, err := d.strategy.Detect(ctx, snap)
-	if err != nil {
-		return fmt.Errorf("strategy detect: %w", err)
+	var errs []error
+	var found []detected
+	for _, s := range d.strategies {
+		opps, err := s.Detect(ctx, snap)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("strategy %s detect: %w", s.Name(), err))
+			continue
+		}
+		for _, opp := range opps {
+			if opp, ok := d.applyFees(ctx, opp); ok {
+				found = append(found, detected{opp: opp, strategy: s.Name()})
+			}
+		}
 	}
-	for _, opp := range opps {
+	live := make(map[string]bool)
+	for _, f := range bestPerMarket(found) {
+		opp := f.opp
 		ok, err := d.arbSvc.Evaluate(ctx, opp)
 		if err != nil {
 			d.logger.Warn("arb evaluate failed", slog.String("opp_id", opp.ID), slog.String("error", err.Error()))
@@ -127,9 +220,97 @@ func (d *Detector) handleMessage(ctx context.Context, data []byte) error {
 		if !ok {
 			continue
 		}
+		live[opp.Key()] = true
+		if d.arbSvc.Refresh(opp) {
+			continue
+		}
+		if d.seenRecently(ctx, opp, f.strategy) {
+			continue
+		}
 		if err := d.arbSvc.Record(ctx, opp); err != nil {
 			d.logger.Warn("arb record failed", slog.String("opp_id", opp.ID), slog.String("error", err.Error()))
 		}
 	}
-	return nil
+	// A strategy that failed may simply not have looked; keep its
+	// opportunities open until the next update or the TTL.
+	if len(errs) == 0 {
+		d.arbSvc.ExpireMissing(ctx, assetID, live)
+	}
+	return errors.Join(errs...)
+}
+
+// detected is an opportunity and the strategy that found it.
+type detected struct {
+	opp      domain.ArbOpportunity
+	strategy string
+}
+
+// bestPerMarket keeps, of the opportunities found on the same market in the
+// same direction, the one with the highest net edge, in order of first
+// detection.
+func bestPerMarket(found []detected) []detected {
+	if len(found) < 2 {
+		return found
+	}
+	index := make(map[string]int, len(found))
+	out := make([]detected, 0, len(found))
+	for _, f := range found {
+		key := f.opp.Key()
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(out)
+			out = append(out, f)
+			continue
+		}
+		if f.opp.NetEdgeBps > out[i].opp.NetEdgeBps {
+			out[i] = f
+		}
+	}
+	return out
+}
+
+// names returns the names of the detector's strategies.
+func (d *Detector) names() []string {
+	names := make([]string, len(d.strategies))
+	for i, s := range d.strategies {
+		names[i] = s.Name()
+	}
+	return names
+}
+
+// applyFees replaces a single-venue opportunity's fee estimate with the
+// effective taker fee of its market and recomputes net edge and expected PnL.
+// ok is false when no edge is left after fees. Cross-venue opportunities are
+// returned unchanged.
+func (d *Detector) applyFees(ctx context.Context, opp domain.ArbOpportunity) (_ domain.ArbOpportunity, ok bool) {
+	if d.fees == nil || opp.KalshiMarketID != "" {
+		return opp, true
+	}
+	fee := d.fees.TakerFeeBps(ctx, opp.PolyTokenID)
+	opp.NetEdgeBps += opp.EstFeeBps - fee
+	opp.EstFeeBps = fee
+	opp.ExpectedPnLUSD = opp.MaxAmount * (opp.NetEdgeBps / 10000)
+	return opp, opp.NetEdgeBps > 0
+}
+
+// seenRecently reports whether opp, found by strategy, was already recorded
+// by a detector or claimed by a strategy within the dedup window. Registry errors fail open.
+func (d *Detector) seenRecently(ctx context.Context, opp domain.ArbOpportunity, strategy string) bool {
+	if d.opps == nil || d.dedupWindow <= 0 {
+		return false
+	}
+	fp := opp.Fingerprint()
+	first, holder, err := d.opps.Observe(ctx, fp, "arb_detector:"+strategy, d.dedupWindow)
+	if err != nil {
+		d.logger.Warn("arb dedup check failed", slog.String("opp_id", opp.ID), slog.String("error", err.Error()))
+		return false
+	}
+	if !first {
+		d.logger.Debug("arb opportunity suppressed as duplicate",
+			slog.String("opp_id", opp.ID),
+			slog.String("fingerprint", fp),
+			slog.String("holder", holder),
+		)
+	}
+	return !first
 }
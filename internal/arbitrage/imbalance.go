@@ -3,6 +3,10 @@ package arbitrage
 import (
 	"context"
 	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -18,32 +22,62 @@ type ImbalanceConfig struct {
 	EstLatencyBps    float64
 	MaxAmount        float64
 	EdgeBpsPerRatio  float64 // gross edge in bps per unit ratio above 1.0 (e.g. 10 bps per 0.5 ratio)
+
+	// Levels is how many levels per side count, best first; 0 counts all.
+	Levels int
+	// LevelDecay weights each level LevelDecay times the level above it; 1
+	// (or 0) weights all levels equally.
+	LevelDecay float64
+	// HalfLife smooths the weighted volumes of an asset's successive updates
+	// exponentially, so the latest update counts half after HalfLife; 0
+	// uses each update alone.
+	HalfLife time.Duration
+	// TradeFlow requires the net taker notional of recent trade prints,
+	// decayed with FlowHalfLife, to lean the same way as the book by at
+	// least MinFlowUSD.
+	TradeFlow    bool
+	FlowHalfLife time.Duration
+	MinFlowUSD   float64
 }
 
 // Imbalance detects opportunities when orderbook volume is skewed (e.g. much
 // more bid volume than ask volume suggests buying pressure / mean reversion).
+// Volumes are weighted by depth and smoothed over time per asset; with
+// TradeFlow, trade prints observed through ObserveTrade must confirm the
+// direction.
 type Imbalance struct {
 	cfg    ImbalanceConfig
 	logger *slog.Logger
+
+	mu     sync.Mutex
+	assets map[string]*imbalanceState
+}
+
+// imbalanceState is the decayed book and trade-flow state of one asset.
+type imbalanceState struct {
+	bidVol, askVol float64 // smoothed depth-weighted notional
+	bookAt         time.Time
+	mid            float64
+	flow           float64 // decayed net taker notional; buys positive
+	flowAt         time.Time
 }
 
 // NewImbalance creates an imbalance arbitrage strategy.
 func NewImbalance(cfg ImbalanceConfig, logger *slog.Logger) *Imbalance {
-	return &Imbalance{cfg: cfg, logger: logger.With(slog.String("arb_strategy", "imbalance"))}
+	return &Imbalance{
+		cfg:    cfg,
+		logger: logger.With(slog.String("arb_strategy", "imbalance")),
+		assets: make(map[string]*imbalanceState),
+	}
 }
 
 // Name returns the strategy identifier.
 func (i *Imbalance) Name() string { return "imbalance" }
 
-// Detect returns opportunities when bid/ask volume ratio exceeds threshold.
+// Detect returns opportunities when the smoothed, depth-weighted bid/ask
+// volume ratio exceeds threshold and, with TradeFlow, trade flow agrees.
 func (i *Imbalance) Detect(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.ArbOpportunity, error) {
-	var bidVol, askVol float64
-	for _, l := range snap.Bids {
-		bidVol += l.Price * l.Size
-	}
-	for _, l := range snap.Asks {
-		askVol += l.Price * l.Size
-	}
+	bidVol, askVol, flow := i.observeBook(snap, time.Now())
 	total := bidVol + askVol
 	if total < i.cfg.MinTotalVolume {
 		return nil, nil
@@ -66,6 +100,17 @@ func (i *Imbalance) Detect(ctx context.Context, snap domain.OrderbookSnapshot) (
 	if grossEdgeBps <= 0 {
 		return nil, nil
 	}
+	if i.cfg.TradeFlow {
+		minFlow := math.Max(i.cfg.MinFlowUSD, math.SmallestNonzeroFloat64)
+		if (direction == "imbalance_buy" && flow < minFlow) || (direction == "imbalance_sell" && -flow < minFlow) {
+			i.logger.DebugContext(ctx, "imbalance not confirmed by trade flow",
+				slog.String("asset_id", snap.AssetID),
+				slog.String("direction", direction),
+				slog.Float64("flow", flow),
+			)
+			return nil, nil
+		}
+	}
 	netEdgeBps := grossEdgeBps - i.cfg.EstFeeBps - i.cfg.EstSlippageBps - i.cfg.EstLatencyBps
 	if netEdgeBps <= 0 {
 		return nil, nil
@@ -103,7 +148,105 @@ func (i *Imbalance) Detect(ctx context.Context, snap domain.OrderbookSnapshot) (
 		slog.String("asset_id", snap.AssetID),
 		slog.String("direction", direction),
 		slog.Float64("ratio", ratio),
+		slog.Float64("flow", flow),
 		slog.Float64("net_edge_bps", netEdgeBps),
 	)
 	return []domain.ArbOpportunity{opp}, nil
 }
+
+// ObserveTrade adds a trade print to its asset's trade flow. Prints without
+// a taker side are classified against the last mid: at or above it a buy,
+// below it a sell.
+func (i *Imbalance) ObserveTrade(trade domain.LastTradePrice) {
+	if !i.cfg.TradeFlow || trade.Price <= 0 || trade.Size <= 0 {
+		return
+	}
+	now := time.Now()
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	st := i.state(trade.AssetID)
+	sign := 0.0
+	switch {
+	case trade.Side == domain.OrderSideBuy:
+		sign = 1
+	case trade.Side == domain.OrderSideSell:
+		sign = -1
+	case st.mid > 0 && trade.Price >= st.mid:
+		sign = 1
+	case st.mid > 0:
+		sign = -1
+	}
+	st.flow = decay(st.flow, now.Sub(st.flowAt), i.cfg.FlowHalfLife) + sign*trade.Price*trade.Size
+	st.flowAt = now
+}
+
+// observeBook folds snap into its asset's smoothed depth-weighted volumes
+// and returns them with the trade flow decayed to now.
+func (i *Imbalance) observeBook(snap domain.OrderbookSnapshot, now time.Time) (bidVol, askVol, flow float64) {
+	bid := i.weightedVolume(snap.Bids, true)
+	ask := i.weightedVolume(snap.Asks, false)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	st := i.state(snap.AssetID)
+	if st.bookAt.IsZero() || i.cfg.HalfLife <= 0 {
+		st.bidVol, st.askVol = bid, ask
+	} else {
+		// Exponential moving average: the previous value decays toward the
+		// new one with the time since the last update.
+		keep := decay(1, now.Sub(st.bookAt), i.cfg.HalfLife)
+		st.bidVol = keep*st.bidVol + (1-keep)*bid
+		st.askVol = keep*st.askVol + (1-keep)*ask
+	}
+	st.bookAt = now
+	if snap.MidPrice > 0 {
+		st.mid = snap.MidPrice
+	} else if snap.BestBid > 0 && snap.BestAsk > 0 {
+		st.mid = (snap.BestBid + snap.BestAsk) / 2
+	}
+	return st.bidVol, st.askVol, decay(st.flow, now.Sub(st.flowAt), i.cfg.FlowHalfLife)
+}
+
+// weightedVolume returns the notional of the best Levels levels of a side,
+// each weighted LevelDecay times the level above it.
+func (i *Imbalance) weightedVolume(levels []domain.PriceLevel, bids bool) float64 {
+	sorted := append([]domain.PriceLevel(nil), levels...)
+	sort.Slice(sorted, func(a, b int) bool {
+		if bids {
+			return sorted[a].Price > sorted[b].Price
+		}
+		return sorted[a].Price < sorted[b].Price
+	})
+	if i.cfg.Levels > 0 && len(sorted) > i.cfg.Levels {
+		sorted = sorted[:i.cfg.Levels]
+	}
+	factor := i.cfg.LevelDecay
+	if factor <= 0 || factor > 1 {
+		factor = 1
+	}
+	var vol float64
+	w := 1.0
+	for _, l := range sorted {
+		vol += w * l.Price * l.Size
+		w *= factor
+	}
+	return vol
+}
+
+// state returns assetID's state, creating it. i.mu must be held.
+func (i *Imbalance) state(assetID string) *imbalanceState {
+	st, ok := i.assets[assetID]
+	if !ok {
+		st = &imbalanceState{}
+		i.assets[assetID] = st
+	}
+	return st
+}
+
+// decay returns v decayed over elapsed with halfLife; v itself when
+// halfLife is not positive.
+func decay(v float64, elapsed, halfLife time.Duration) float64 {
+	if halfLife <= 0 || elapsed <= 0 {
+		return v
+	}
+	return v * math.Exp2(-elapsed.Seconds()/halfLife.Seconds())
+}
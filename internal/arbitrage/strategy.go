@@ -1,5 +1,5 @@
 // Package arbitrage provides selectable single-venue (Polymarket) arbitrage
-// strategies and a detector that runs the chosen strategy on orderbook data.
+// strategies and a detector that runs the chosen strategies on orderbook data.
 package arbitrage
 
 import (
@@ -16,3 +16,9 @@ type Strategy interface {
 	// Single-venue: KalshiMarketID and KalshiPrice are left empty.
 	Detect(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.ArbOpportunity, error)
 }
+
+// TradeObserver is implemented by strategies that also use trade prints; the
+// detector passes them every print from the "trades" channel.
+type TradeObserver interface {
+	ObserveTrade(trade domain.LastTradePrice)
+}
@@ -0,0 +1,220 @@
+package backtest
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// StoreTradeLoader loads trades from a TradeStore (Postgres).
+type StoreTradeLoader struct {
+	store domain.TradeStore
+	limit int
+}
+
+// NewStoreTradeLoader creates a loader over store. limit <= 0 means no limit.
+func NewStoreTradeLoader(store domain.TradeStore, limit int) *StoreTradeLoader {
+	return &StoreTradeLoader{store: store, limit: limit}
+}
+
+// LoadTrades returns trades in [from, to], oldest first.
+func (l *StoreTradeLoader) LoadTrades(ctx context.Context, from, to time.Time) ([]domain.Trade, error) {
+	return l.store.ListRange(ctx, from, to, l.limit)
+}
+
+// ArchiveTradeLoader loads trades from the S3 JSONL archives written by the
+// archiver (archive/trades/YYYY-MM.jsonl). Each archive file holds every trade
+// before its cutoff month, so all files from the From month onwards are read
+// and filtered to the requested range. Parquet archives (s3.format =
+// "parquet") are skipped; they are for analytics tools.
+type ArchiveTradeLoader struct {
+	reader domain.BlobReader
+}
+
+// NewArchiveTradeLoader creates a loader over the given blob reader.
+func NewArchiveTradeLoader(reader domain.BlobReader) *ArchiveTradeLoader {
+	return &ArchiveTradeLoader{reader: reader}
+}
+
+// LoadTrades returns archived trades in [from, to], oldest first. Trades that
+// appear in more than one archive file are de-duplicated by ID.
+func (l *ArchiveTradeLoader) LoadTrades(ctx context.Context, from, to time.Time) ([]domain.Trade, error) {
+	infos, err := l.reader.List(ctx, "archive/trades/")
+	if err != nil {
+		return nil, fmt.Errorf("backtest: list trade archives: %w", err)
+	}
+	fromMonth := from.UTC().Format("2006-01")
+
+	seen := make(map[int64]struct{})
+	var out []domain.Trade
+	var jsonl, parquet int
+	for _, info := range infos {
+		if strings.HasSuffix(info.Path, ".parquet") {
+			parquet++
+			continue
+		}
+		jsonl++
+		month := strings.TrimSuffix(path.Base(info.Path), ".jsonl")
+		if month < fromMonth {
+			continue
+		}
+		trades, err := l.readFile(ctx, info.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range trades {
+			if t.Timestamp.Before(from) || t.Timestamp.After(to) {
+				continue
+			}
+			if t.ID != 0 {
+				if _, dup := seen[t.ID]; dup {
+					continue
+				}
+				seen[t.ID] = struct{}{}
+			}
+			out = append(out, t)
+		}
+	}
+	if jsonl == 0 && parquet > 0 {
+		return nil, fmt.Errorf("backtest: trade archives are Parquet (s3.format = \"parquet\"); only JSONL archives can be loaded, use source = \"postgres\"")
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp.Before(out[j].Timestamp) })
+	return out, nil
+}
+
+func (l *ArchiveTradeLoader) readFile(ctx context.Context, p string) ([]domain.Trade, error) {
+	rc, err := l.reader.Get(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("backtest: get %s: %w", p, err)
+	}
+	defer rc.Close()
+
+	var trades []domain.Trade
+	sc := bufio.NewScanner(rc)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var t domain.Trade
+		if err := json.Unmarshal(line, &t); err != nil {
+			return nil, fmt.Errorf("backtest: decode %s: %w", p, err)
+		}
+		trades = append(trades, t)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("backtest: read %s: %w", p, err)
+	}
+	return trades, nil
+}
+
+// StoreBookLoader loads book events from a BookEventStore (the recorder's
+// Postgres table).
+type StoreBookLoader struct {
+	store domain.BookEventStore
+}
+
+// NewStoreBookLoader creates a loader over store.
+func NewStoreBookLoader(store domain.BookEventStore) *StoreBookLoader {
+	return &StoreBookLoader{store: store}
+}
+
+// LoadBookEvents returns the recorded events of every asset in [from, to],
+// oldest first.
+func (l *StoreBookLoader) LoadBookEvents(ctx context.Context, from, to time.Time, limit int) ([]domain.BookEvent, error) {
+	return l.store.ListRange(ctx, nil, from, to, limit)
+}
+
+// ArchiveBookLoader loads book snapshots from the gzipped JSONL files written
+// by the book snapshot recorder (books/dt=YYYY-MM-DD/asset=ID/*.jsonl.gz).
+// Each snapshot is replayed as a snapshot book event.
+type ArchiveBookLoader struct {
+	reader domain.BlobReader
+}
+
+// NewArchiveBookLoader creates a loader over the given blob reader.
+func NewArchiveBookLoader(reader domain.BlobReader) *ArchiveBookLoader {
+	return &ArchiveBookLoader{reader: reader}
+}
+
+// LoadBookEvents returns the snapshots in [from, to] of every recorded asset,
+// oldest first. With limit > 0 only the oldest limit are returned.
+func (l *ArchiveBookLoader) LoadBookEvents(ctx context.Context, from, to time.Time, limit int) ([]domain.BookEvent, error) {
+	var out []domain.BookEvent
+	for day := from.UTC().Truncate(24 * time.Hour); !day.After(to); day = day.Add(24 * time.Hour) {
+		prefix := "books/dt=" + day.Format("2006-01-02") + "/"
+		infos, err := l.reader.List(ctx, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("backtest: list %s: %w", prefix, err)
+		}
+		for _, info := range infos {
+			if !strings.HasSuffix(info.Path, ".jsonl.gz") {
+				continue
+			}
+			snaps, err := l.readFile(ctx, info.Path)
+			if err != nil {
+				return nil, err
+			}
+			for _, snap := range snaps {
+				if snap.AssetID == "" || snap.Timestamp.Before(from) || snap.Timestamp.After(to) {
+					continue
+				}
+				out = append(out, domain.BookEvent{
+					AssetID:   snap.AssetID,
+					Kind:      domain.BookEventSnapshot,
+					Bids:      snap.Bids,
+					Asks:      snap.Asks,
+					BestBid:   snap.BestBid,
+					BestAsk:   snap.BestAsk,
+					Timestamp: snap.Timestamp,
+				})
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Timestamp.Before(out[j].Timestamp) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (l *ArchiveBookLoader) readFile(ctx context.Context, p string) ([]domain.OrderbookSnapshot, error) {
+	rc, err := l.reader.Get(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("backtest: get %s: %w", p, err)
+	}
+	defer rc.Close()
+	zr, err := gzip.NewReader(rc)
+	if err != nil {
+		return nil, fmt.Errorf("backtest: decompress %s: %w", p, err)
+	}
+	defer zr.Close()
+
+	var snaps []domain.OrderbookSnapshot
+	sc := bufio.NewScanner(zr)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var snap domain.OrderbookSnapshot
+		if err := json.Unmarshal(line, &snap); err != nil {
+			return nil, fmt.Errorf("backtest: decode %s: %w", p, err)
+		}
+		snaps = append(snaps, snap)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("backtest: read %s: %w", p, err)
+	}
+	return snaps, nil
+}
@@ -0,0 +1,142 @@
+package backtest
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// MemoryCache is an in-process PriceCache and OrderbookCache used during
+// replays so strategies read the simulated book instead of live Redis state.
+type MemoryCache struct {
+	mu     sync.RWMutex
+	books  map[string]domain.OrderbookSnapshot
+	prices map[string]pricePoint
+}
+
+type pricePoint struct {
+	price float64
+	ts    time.Time
+}
+
+// NewMemoryCache creates an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		books:  make(map[string]domain.OrderbookSnapshot),
+		prices: make(map[string]pricePoint),
+	}
+}
+
+// SetPrice records the latest price for an asset.
+func (c *MemoryCache) SetPrice(_ context.Context, assetID string, price float64, ts time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prices[assetID] = pricePoint{price: price, ts: ts}
+	return nil
+}
+
+// GetPrice returns the latest price for an asset or domain.ErrNotFound.
+func (c *MemoryCache) GetPrice(_ context.Context, assetID string) (float64, time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	p, ok := c.prices[assetID]
+	if !ok {
+		return 0, time.Time{}, domain.ErrNotFound
+	}
+	return p.price, p.ts, nil
+}
+
+// GetPrices returns the latest prices for the given assets; missing assets are omitted.
+func (c *MemoryCache) GetPrices(_ context.Context, assetIDs []string) (map[string]float64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make(map[string]float64, len(assetIDs))
+	for _, id := range assetIDs {
+		if p, ok := c.prices[id]; ok {
+			out[id] = p.price
+		}
+	}
+	return out, nil
+}
+
+// SetSnapshot replaces the book for an asset and increments its version.
+func (c *MemoryCache) SetSnapshot(_ context.Context, assetID string, snap domain.OrderbookSnapshot) error {
+	snap.Bids = append([]domain.PriceLevel(nil), snap.Bids...)
+	snap.Asks = append([]domain.PriceLevel(nil), snap.Asks...)
+	snap.Stale = false
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snap.Version = c.books[assetID].Version + 1
+	c.books[assetID] = snap
+	return nil
+}
+
+// GetSnapshot returns a copy of the book for an asset or domain.ErrNotFound.
+func (c *MemoryCache) GetSnapshot(_ context.Context, assetID string) (domain.OrderbookSnapshot, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snap, ok := c.books[assetID]
+	if !ok {
+		return domain.OrderbookSnapshot{}, domain.ErrNotFound
+	}
+	snap.Bids = append([]domain.PriceLevel(nil), snap.Bids...)
+	snap.Asks = append([]domain.PriceLevel(nil), snap.Asks...)
+	return snap, nil
+}
+
+// UpdateLevel sets (or removes when size is 0) one price level, refreshes the
+// BBO and increments the book's version.
+func (c *MemoryCache) UpdateLevel(_ context.Context, assetID string, side string, price, size float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snap := c.books[assetID]
+	snap.AssetID = assetID
+	if strings.EqualFold(side, "BUY") {
+		snap.Bids = setLevel(snap.Bids, price, size)
+		sort.Slice(snap.Bids, func(i, j int) bool { return snap.Bids[i].Price > snap.Bids[j].Price })
+	} else {
+		snap.Asks = setLevel(snap.Asks, price, size)
+		sort.Slice(snap.Asks, func(i, j int) bool { return snap.Asks[i].Price < snap.Asks[j].Price })
+	}
+	snap.BestBid, snap.BestAsk, snap.MidPrice = 0, 0, 0
+	if len(snap.Bids) > 0 {
+		snap.BestBid = snap.Bids[0].Price
+	}
+	if len(snap.Asks) > 0 {
+		snap.BestAsk = snap.Asks[0].Price
+	}
+	if snap.BestBid > 0 && snap.BestAsk > 0 {
+		snap.MidPrice = (snap.BestBid + snap.BestAsk) / 2
+	}
+	snap.Version++
+	c.books[assetID] = snap
+	return nil
+}
+
+// GetBBO returns the best bid and ask for an asset.
+func (c *MemoryCache) GetBBO(_ context.Context, assetID string) (float64, float64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snap := c.books[assetID]
+	return snap.BestBid, snap.BestAsk, nil
+}
+
+func setLevel(levels []domain.PriceLevel, price, size float64) []domain.PriceLevel {
+	for i := range levels {
+		if levels[i].Price == price {
+			if size <= 0 {
+				return append(levels[:i], levels[i+1:]...)
+			}
+			levels[i].Size = size
+			return levels
+		}
+	}
+	if size <= 0 {
+		return levels
+	}
+	return append(levels, domain.PriceLevel{Price: price, Size: size})
+}
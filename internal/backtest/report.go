@@ -0,0 +1,85 @@
+package backtest
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Report summarises a backtest run.
+type Report struct {
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	BookEvents int              `json:"book_events"`
+	Trades     int              `json:"trades"`
+	FeeBps     float64          `json:"fee_bps"`
+	Strategies []StrategyReport `json:"strategies"`
+	StartedAt  time.Time        `json:"started_at"`
+	Elapsed    string           `json:"elapsed"`
+}
+
+// StrategyReport is the simulated PnL for one strategy.
+type StrategyReport struct {
+	Strategy      string           `json:"strategy"`
+	Signals       int              `json:"signals"`
+	Fills         int              `json:"fills"`
+	Rejected      int              `json:"rejected"`
+	VolumeUSD     float64          `json:"volume_usd"`
+	FeesUSD       float64          `json:"fees_usd"`
+	RealizedPnL   float64          `json:"realized_pnl"`
+	UnrealizedPnL float64          `json:"unrealized_pnl"`
+	TotalPnL      float64          `json:"total_pnl"`
+	OpenPositions []PositionReport `json:"open_positions"`
+}
+
+// PositionReport is an open simulated position marked at the last replayed mid.
+type PositionReport struct {
+	TokenID   string  `json:"token_id"`
+	Quantity  float64 `json:"quantity"`
+	AvgCost   float64 `json:"avg_cost"`
+	MarkPrice float64 `json:"mark_price"`
+}
+
+// Results returns a per-strategy report. Open positions are marked at the
+// cached mid price (falling back to cost when no mid is known).
+func (s *SimulatedPlacer) Results(ctx context.Context) []StrategyReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]StrategyReport, 0, len(s.byStr))
+	for name, sb := range s.byStr {
+		r := StrategyReport{
+			Strategy:      name,
+			Signals:       sb.signals,
+			Fills:         sb.fills,
+			Rejected:      sb.rejected,
+			VolumeUSD:     sb.volumeUSD,
+			FeesUSD:       sb.feesUSD,
+			RealizedPnL:   sb.realized,
+			OpenPositions: []PositionReport{},
+		}
+		for tokenID, pos := range sb.positions {
+			if pos.qty == 0 {
+				continue
+			}
+			mark := pos.avgCost
+			if snap, err := s.books.GetSnapshot(ctx, tokenID); err == nil && snap.MidPrice > 0 {
+				mark = snap.MidPrice
+			}
+			r.UnrealizedPnL += (mark - pos.avgCost) * pos.qty
+			r.OpenPositions = append(r.OpenPositions, PositionReport{
+				TokenID:   tokenID,
+				Quantity:  pos.qty,
+				AvgCost:   pos.avgCost,
+				MarkPrice: mark,
+			})
+		}
+		sort.Slice(r.OpenPositions, func(i, j int) bool {
+			return r.OpenPositions[i].TokenID < r.OpenPositions[j].TokenID
+		})
+		r.TotalPnL = r.RealizedPnL + r.UnrealizedPnL
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Strategy < out[j].Strategy })
+	return out
+}
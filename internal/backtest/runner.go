@@ -0,0 +1,230 @@
+// Package backtest replays recorded orderbook events and historical trades
+// through the strategy engine with a simulated order placer, producing a
+// per-strategy PnL report.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/strategy"
+)
+
+// signalBuffer is the engine output buffer used during replay. It is drained
+// after every event, so it only needs to hold the signals of a single event.
+const signalBuffer = 4096
+
+// TradeLoader loads historical trades in [from, to], oldest first.
+type TradeLoader interface {
+	LoadTrades(ctx context.Context, from, to time.Time) ([]domain.Trade, error)
+}
+
+// BookLoader loads recorded book events in [from, to], oldest first, at most
+// limit of them (<= 0 means no limit).
+type BookLoader interface {
+	LoadBookEvents(ctx context.Context, from, to time.Time, limit int) ([]domain.BookEvent, error)
+}
+
+// RegistryFactory builds a fresh strategy registry whose strategies read
+// books and prices from the given caches. A new registry is built for every
+// strategy so in-memory state (cooldowns, quotes) never leaks between runs.
+type RegistryFactory func(books domain.OrderbookCache, prices domain.PriceCache) *strategy.Registry
+
+// Config controls a backtest run.
+type Config struct {
+	From      time.Time
+	To        time.Time
+	FeeBps    float64
+	MaxEvents int // cap on loaded book events; <= 0 means no cap
+}
+
+// Runner replays data through one strategy at a time.
+type Runner struct {
+	cfg      Config
+	books    BookLoader
+	trades   TradeLoader
+	registry RegistryFactory
+	logger   *slog.Logger
+}
+
+// NewRunner creates a Runner. books and trades may be nil; at least one must
+// be set for the replay to contain any events.
+func NewRunner(cfg Config, books BookLoader, trades TradeLoader, registry RegistryFactory, logger *slog.Logger) *Runner {
+	return &Runner{
+		cfg:      cfg,
+		books:    books,
+		trades:   trades,
+		registry: registry,
+		logger:   logger.With(slog.String("component", "backtest")),
+	}
+}
+
+// Run loads the configured time range once and replays it through each named
+// strategy. Event timestamps are rebased to wall-clock time as they are fed so
+// that strategy staleness checks behave as they would live; cooldowns that
+// rely on time.Now therefore see compressed time.
+func (r *Runner) Run(ctx context.Context, names []string) (Report, error) {
+	started := time.Now().UTC()
+	report := Report{From: r.cfg.From, To: r.cfg.To, FeeBps: r.cfg.FeeBps, StartedAt: started}
+
+	if len(names) == 0 {
+		return report, fmt.Errorf("backtest: no strategies to run")
+	}
+	if !r.cfg.From.Before(r.cfg.To) {
+		return report, fmt.Errorf("backtest: from (%s) must be before to (%s)", r.cfg.From, r.cfg.To)
+	}
+
+	var bookEvents []domain.BookEvent
+	if r.books != nil {
+		var err error
+		bookEvents, err = r.books.LoadBookEvents(ctx, r.cfg.From, r.cfg.To, r.cfg.MaxEvents)
+		if err != nil {
+			return report, fmt.Errorf("backtest: load book events: %w", err)
+		}
+	}
+	var trades []domain.Trade
+	if r.trades != nil {
+		var err error
+		trades, err = r.trades.LoadTrades(ctx, r.cfg.From, r.cfg.To)
+		if err != nil {
+			return report, fmt.Errorf("backtest: load trades: %w", err)
+		}
+	}
+	report.BookEvents = len(bookEvents)
+	report.Trades = len(trades)
+	r.logger.InfoContext(ctx, "backtest data loaded",
+		slog.Int("book_events", len(bookEvents)),
+		slog.Int("trades", len(trades)),
+		slog.Time("from", r.cfg.From),
+		slog.Time("to", r.cfg.To),
+	)
+
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		results, err := r.runOne(ctx, name, bookEvents, trades)
+		if err != nil {
+			r.logger.WarnContext(ctx, "backtest strategy failed",
+				slog.String("strategy", name),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if len(results) == 0 {
+			results = []StrategyReport{{Strategy: name, OpenPositions: []PositionReport{}}}
+		}
+		report.Strategies = append(report.Strategies, results...)
+	}
+
+	report.Elapsed = time.Since(started).Round(time.Millisecond).String()
+	return report, nil
+}
+
+func (r *Runner) runOne(ctx context.Context, name string, bookEvents []domain.BookEvent, trades []domain.Trade) ([]StrategyReport, error) {
+	cache := NewMemoryCache()
+	sim := NewSimulatedPlacer(cache, r.cfg.FeeBps)
+	reg := r.registry(cache, cache)
+
+	strat, err := reg.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	signalCh := make(chan domain.TradeSignal, signalBuffer)
+	engine := strategy.NewEngine(reg, signalCh, cache, r.logger)
+	if err := engine.SetActive(name); err != nil {
+		return nil, err
+	}
+	if err := strat.Init(ctx); err != nil {
+		r.logger.WarnContext(ctx, "backtest strategy init failed, continuing",
+			slog.String("strategy", name),
+			slog.String("error", err.Error()),
+		)
+	}
+	defer func() { _ = strat.Close() }()
+
+	bi, ti := 0, 0
+	for bi < len(bookEvents) || ti < len(trades) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC()
+		if ti >= len(trades) || (bi < len(bookEvents) && !bookEvents[bi].Timestamp.After(trades[ti].Timestamp)) {
+			r.feedBookEvent(ctx, engine, cache, bookEvents[bi], now)
+			bi++
+		} else {
+			tr := trades[ti]
+			tr.Timestamp = now
+			if err := engine.HandleTrade(ctx, tr); err != nil {
+				r.logger.DebugContext(ctx, "backtest trade event failed", slog.String("error", err.Error()))
+			}
+			ti++
+		}
+		r.drain(ctx, signalCh, sim)
+	}
+
+	return sim.Results(ctx), nil
+}
+
+func (r *Runner) feedBookEvent(ctx context.Context, engine *strategy.Engine, cache *MemoryCache, ev domain.BookEvent, now time.Time) {
+	var err error
+	switch ev.Kind {
+	case domain.BookEventDelta:
+		_ = cache.UpdateLevel(ctx, ev.AssetID, ev.Side, ev.Price, ev.Size)
+		bid, ask, _ := cache.GetBBO(ctx, ev.AssetID)
+		if bid > 0 && ask > 0 {
+			_ = cache.SetPrice(ctx, ev.AssetID, (bid+ask)/2, now)
+		}
+		err = engine.HandlePriceChange(ctx, domain.PriceChange{
+			AssetID:   ev.AssetID,
+			Side:      ev.Side,
+			Price:     ev.Price,
+			Size:      ev.Size,
+			Timestamp: now,
+		})
+	default:
+		snap := domain.OrderbookSnapshot{
+			AssetID:   ev.AssetID,
+			Bids:      ev.Bids,
+			Asks:      ev.Asks,
+			BestBid:   ev.BestBid,
+			BestAsk:   ev.BestAsk,
+			Timestamp: now,
+		}
+		if snap.BestBid > 0 && snap.BestAsk > 0 {
+			snap.MidPrice = (snap.BestBid + snap.BestAsk) / 2
+		}
+		_ = cache.SetSnapshot(ctx, ev.AssetID, snap)
+		if snap.MidPrice > 0 {
+			_ = cache.SetPrice(ctx, ev.AssetID, snap.MidPrice, now)
+		}
+		err = engine.HandleBookUpdate(ctx, snap)
+	}
+	if err != nil {
+		r.logger.DebugContext(ctx, "backtest book event failed",
+			slog.String("asset_id", ev.AssetID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// drain fills every signal emitted for the last event before the next event
+// is replayed, so fills always see the book the signal was generated from.
+func (r *Runner) drain(ctx context.Context, signalCh chan domain.TradeSignal, sim *SimulatedPlacer) {
+	for {
+		select {
+		case sig := <-signalCh:
+			if _, err := sim.PlaceOrder(ctx, sig); err != nil {
+				r.logger.DebugContext(ctx, "backtest simulated order failed",
+					slog.String("signal_id", sig.ID),
+					slog.String("error", err.Error()),
+				)
+			}
+		default:
+			return
+		}
+	}
+}
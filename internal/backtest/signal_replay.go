@@ -0,0 +1,264 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/executor"
+)
+
+// maxReplaySignals caps the signals loaded by one replay.
+const maxReplaySignals = 100_000
+
+// SignalReplayConfig controls a signal replay.
+type SignalReplayConfig struct {
+	// Wallet is the wallet risk checks are run for.
+	Wallet string
+	// MaxLegGapMs is how long an incomplete leg group waits for its missing
+	// legs, as arbitrage.max_leg_gap_ms. Defaults to 2000.
+	MaxLegGapMs int64
+}
+
+// ReplayedSignal compares what became of one recorded signal with what the
+// dry-run executor made of it.
+type ReplayedSignal struct {
+	SignalID string              `json:"signal_id"`
+	Strategy string              `json:"strategy"`
+	MarketID string              `json:"market_id"`
+	Side     string              `json:"side"`
+	Recorded domain.SignalStatus `json:"recorded"`
+	Replayed domain.SignalStatus `json:"replayed"`
+	Reason   string              `json:"reason,omitempty"` // of the replayed status
+	Price    float64             `json:"price,omitempty"`  // as placed in the replay
+	Size     float64             `json:"size,omitempty"`
+	Changed  bool                `json:"changed"`
+}
+
+// SignalReplayReport summarises a signal replay. Recorded and Replayed count
+// signals by status; Changed counts the signals whose replayed status
+// differs from a recorded placed, rejected, expired or skipped one.
+type SignalReplayReport struct {
+	From      time.Time        `json:"from"`
+	To        time.Time        `json:"to"`
+	Signals   int              `json:"signals"`
+	Recorded  map[string]int   `json:"recorded"`
+	Replayed  map[string]int   `json:"replayed"`
+	Changed   int              `json:"changed"`
+	Results   []ReplayedSignal `json:"results"` // oldest first
+	StartedAt time.Time        `json:"started_at"`
+	Elapsed   string           `json:"elapsed"`
+}
+
+// SignalReplayer re-feeds recorded signals through an executor that places
+// nothing, for regression testing what execution does with what strategies
+// emitted. Signals are replayed back to back, oldest first, with their
+// timestamps shifted to the moment they are fed so expiries keep their
+// original lead. Risk checks accept every signal unless WithRisk is set.
+type SignalReplayer struct {
+	store  domain.SignalStore
+	risk   executor.RiskChecker // optional
+	cfg    SignalReplayConfig
+	logger *slog.Logger
+}
+
+// NewSignalReplayer creates a SignalReplayer over the recorded signals in
+// store.
+func NewSignalReplayer(store domain.SignalStore, cfg SignalReplayConfig, logger *slog.Logger) *SignalReplayer {
+	if cfg.MaxLegGapMs <= 0 {
+		cfg.MaxLegGapMs = 2000
+	}
+	return &SignalReplayer{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "signal_replay")),
+	}
+}
+
+// WithRisk runs the replayed signals through risk's sizing and pre-trade
+// checks. Those read the current positions and limits, not the ones the
+// signals originally met.
+func (r *SignalReplayer) WithRisk(risk executor.RiskChecker) *SignalReplayer {
+	r.risk = risk
+	return r
+}
+
+// Run replays the signals matching f (at most 100000, the newest when more
+// match).
+func (r *SignalReplayer) Run(ctx context.Context, f domain.SignalFilter) (SignalReplayReport, error) {
+	started := time.Now().UTC()
+	report := SignalReplayReport{
+		From:      f.From,
+		To:        f.To,
+		Recorded:  make(map[string]int),
+		Replayed:  make(map[string]int),
+		Results:   []ReplayedSignal{},
+		StartedAt: started,
+	}
+	if f.Limit <= 0 || f.Limit > maxReplaySignals {
+		f.Limit = maxReplaySignals
+	}
+	records, err := r.store.List(ctx, f)
+	if err != nil {
+		return report, fmt.Errorf("backtest: load signals: %w", err)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Signal.CreatedAt.Before(records[j].Signal.CreatedAt)
+	})
+	report.Signals = len(records)
+	r.logger.InfoContext(ctx, "signal replay loaded", slog.Int("signals", len(records)))
+
+	placer := newDryRunPlacer()
+	risk := r.risk
+	if risk == nil {
+		risk = placer
+	}
+	signalCh := make(chan domain.TradeSignal, len(records))
+	exec := executor.NewExecutor(signalCh, placer, risk, r.cfg.Wallet, r.logger)
+	exec.SetResolver(placer)
+
+	legs := false
+	for _, rec := range records {
+		if rec.Signal.Metadata["leg_group_id"] != "" {
+			legs = true
+			break
+		}
+	}
+	if legs {
+		exec.SetArbRecording(nil, nil, r.cfg.MaxLegGapMs)
+	}
+
+	go func() {
+		defer close(signalCh)
+		for _, rec := range records {
+			sig := rebaseSignal(rec.Signal, time.Now().UTC())
+			select {
+			case signalCh <- sig:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	if err := exec.Run(ctx); err != nil {
+		return report, err
+	}
+	if legs {
+		// Incomplete best_effort groups are placed when their gap runs out.
+		select {
+		case <-time.After(time.Duration(r.cfg.MaxLegGapMs)*time.Millisecond + 100*time.Millisecond):
+		case <-ctx.Done():
+			return report, ctx.Err()
+		}
+	}
+
+	for _, rec := range records {
+		res := placer.result(rec.Signal.ID)
+		row := ReplayedSignal{
+			SignalID: rec.Signal.ID,
+			Strategy: rec.Signal.Source,
+			MarketID: rec.Signal.MarketID,
+			Side:     string(rec.Signal.Side),
+			Recorded: rec.Status,
+			Replayed: res.status,
+			Reason:   res.reason,
+			Price:    res.price,
+			Size:     res.size,
+		}
+		switch rec.Status {
+		case domain.SignalStatusPending, domain.SignalStatusFailed:
+			// Not reproducible without the venue, or never executed.
+		default:
+			row.Changed = row.Recorded != row.Replayed
+		}
+		report.Recorded[string(row.Recorded)]++
+		report.Replayed[string(row.Replayed)]++
+		if row.Changed {
+			report.Changed++
+		}
+		report.Results = append(report.Results, row)
+	}
+	report.Elapsed = time.Since(started).Round(time.Millisecond).String()
+	return report, nil
+}
+
+// rebaseSignal shifts sig's timestamps so that it was created at now.
+func rebaseSignal(sig domain.TradeSignal, now time.Time) domain.TradeSignal {
+	shift := now.Sub(sig.CreatedAt)
+	sig.CreatedAt = now
+	for _, t := range []*time.Time{&sig.ExpiresAt, &sig.OrderExpiration, &sig.EmittedAt} {
+		if !t.IsZero() {
+			*t = t.Add(shift)
+		}
+	}
+	return sig
+}
+
+type replayResult struct {
+	status      domain.SignalStatus
+	reason      string
+	price, size float64
+}
+
+// dryRunPlacer accepts every order without placing it, remembering the
+// terms, and collects the executor's resolution of every signal. It
+// implements executor.OrderPlacer, executor.QuoteCanceller,
+// executor.RiskChecker and executor.SignalResolver.
+type dryRunPlacer struct {
+	mu      sync.Mutex
+	placed  map[string]domain.TradeSignal // by order (signal) ID
+	results map[string]replayResult       // by signal ID
+}
+
+func newDryRunPlacer() *dryRunPlacer {
+	return &dryRunPlacer{
+		placed:  make(map[string]domain.TradeSignal),
+		results: make(map[string]replayResult),
+	}
+}
+
+// PlaceOrder accepts sig as a resting order.
+func (p *dryRunPlacer) PlaceOrder(_ context.Context, sig domain.TradeSignal) (domain.OrderResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.placed[sig.ID] = sig
+	return domain.OrderResult{Success: true, OrderID: sig.ID, Status: domain.OrderStatusOpen}, nil
+}
+
+// CancelOrder accepts every cancellation.
+func (p *dryRunPlacer) CancelOrder(context.Context, string) error {
+	return nil
+}
+
+// PreTradeCheck accepts every signal.
+func (p *dryRunPlacer) PreTradeCheck(context.Context, domain.TradeSignal, string) error {
+	return nil
+}
+
+// Resolve records the executor's resolution of a signal, with the terms of
+// the order it placed.
+func (p *dryRunPlacer) Resolve(res domain.SignalResolution) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r := replayResult{status: res.Status, reason: res.Reason}
+	for _, id := range res.OrderIDs {
+		if sig, ok := p.placed[id]; ok {
+			r.price, r.size = sig.Price(), sig.Size()
+		}
+	}
+	p.results[res.Signal.ID] = r
+}
+
+// result returns what became of signal id; pending when the executor never
+// resolved it.
+func (p *dryRunPlacer) result(id string) replayResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if r, ok := p.results[id]; ok {
+		return r
+	}
+	return replayResult{status: domain.SignalStatusPending}
+}
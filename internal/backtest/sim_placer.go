@@ -0,0 +1,173 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// position is a signed token position with average entry price. Positive
+// quantity is long; negative quantity is short.
+type position struct {
+	qty     float64
+	avgCost float64
+}
+
+// strategyBook accumulates simulated fills for one strategy.
+type strategyBook struct {
+	signals   int
+	fills     int
+	rejected  int
+	volumeUSD float64
+	feesUSD   float64
+	realized  float64
+	positions map[string]*position // token ID -> position
+}
+
+// SimulatedPlacer fills trade signals against the replayed book. A buy fills
+// at the best ask when the signal price is at or above it; a sell fills at the
+// best bid when the signal price is at or below it. Non-marketable signals are
+// rejected rather than rested, so results reflect taker-style execution only.
+// When no book has been seen for the token, the signal price is used.
+// It implements executor.OrderPlacer and executor.RiskChecker.
+type SimulatedPlacer struct {
+	books  domain.OrderbookCache
+	feeBps float64
+
+	mu    sync.Mutex
+	byStr map[string]*strategyBook
+}
+
+// NewSimulatedPlacer creates a SimulatedPlacer that reads prices from books
+// and charges feeBps on filled notional.
+func NewSimulatedPlacer(books domain.OrderbookCache, feeBps float64) *SimulatedPlacer {
+	return &SimulatedPlacer{
+		books:  books,
+		feeBps: feeBps,
+		byStr:  make(map[string]*strategyBook),
+	}
+}
+
+// PreTradeCheck accepts every signal; backtests measure strategy behaviour
+// without live risk limits.
+func (s *SimulatedPlacer) PreTradeCheck(_ context.Context, _ domain.TradeSignal, _ string) error {
+	return nil
+}
+
+// PlaceOrder simulates immediate execution of sig.
+func (s *SimulatedPlacer) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (domain.OrderResult, error) {
+	if sig.SizeUnits <= 0 || sig.PriceTicks <= 0 {
+		return domain.OrderResult{}, fmt.Errorf("backtest: %w: non-positive price or size", domain.ErrInvalidOrder)
+	}
+	bestBid, bestAsk, _ := s.books.GetBBO(ctx, sig.TokenID)
+
+	limit := sig.Price()
+	fillPrice := limit
+	switch sig.Side {
+	case domain.OrderSideBuy:
+		if bestAsk > 0 {
+			if limit < bestAsk {
+				return s.reject(sig, "limit below best ask"), nil
+			}
+			fillPrice = bestAsk
+		}
+	case domain.OrderSideSell:
+		if bestBid > 0 {
+			if limit > bestBid {
+				return s.reject(sig, "limit above best bid"), nil
+			}
+			fillPrice = bestBid
+		}
+	default:
+		return domain.OrderResult{}, fmt.Errorf("backtest: %w: unknown side %q", domain.ErrInvalidOrder, sig.Side)
+	}
+
+	size := sig.Size()
+	notional := fillPrice * size
+	fee := notional * s.feeBps / 10000
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sb := s.bookFor(sig.Source)
+	sb.signals++
+	sb.fills++
+	sb.volumeUSD += notional
+	sb.feesUSD += fee
+	sb.realized -= fee
+
+	qty := size
+	if sig.Side == domain.OrderSideSell {
+		qty = -size
+	}
+	pos, ok := sb.positions[sig.TokenID]
+	if !ok {
+		pos = &position{}
+		sb.positions[sig.TokenID] = pos
+	}
+	sb.realized += pos.apply(qty, fillPrice)
+
+	return domain.OrderResult{
+		Success:     true,
+		OrderID:     uuid.New().String(),
+		Status:      domain.OrderStatusMatched,
+		FilledPrice: fillPrice,
+		FeeUSD:      fee,
+	}, nil
+}
+
+func (s *SimulatedPlacer) reject(sig domain.TradeSignal, msg string) domain.OrderResult {
+	s.mu.Lock()
+	sb := s.bookFor(sig.Source)
+	sb.signals++
+	sb.rejected++
+	s.mu.Unlock()
+	return domain.OrderResult{Success: false, Status: domain.OrderStatusCancelled, Message: msg}
+}
+
+func (s *SimulatedPlacer) bookFor(strategy string) *strategyBook {
+	sb, ok := s.byStr[strategy]
+	if !ok {
+		sb = &strategyBook{positions: make(map[string]*position)}
+		s.byStr[strategy] = sb
+	}
+	return sb
+}
+
+// apply adds qty at price to the position and returns the realized PnL from
+// any quantity that reduced or flipped the existing position.
+func (p *position) apply(qty, price float64) float64 {
+	if p.qty == 0 || (p.qty > 0) == (qty > 0) {
+		total := p.qty + qty
+		p.avgCost = (p.avgCost*abs(p.qty) + price*abs(qty)) / abs(total)
+		p.qty = total
+		return 0
+	}
+
+	closing := min(abs(qty), abs(p.qty))
+	var realized float64
+	if p.qty > 0 {
+		realized = (price - p.avgCost) * closing
+	} else {
+		realized = (p.avgCost - price) * closing
+	}
+	p.qty += qty
+	switch {
+	case abs(p.qty) < 1e-9:
+		p.qty, p.avgCost = 0, 0
+	case (p.qty > 0) == (qty > 0):
+		// Flipped through zero: the remainder opens at the fill price.
+		p.avgCost = price
+	}
+	return realized
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
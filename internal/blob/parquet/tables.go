@@ -0,0 +1,209 @@
+package parquet
+
+import (
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Table maps a record type to its Parquet schema and its lake partition.
+type Table[T any] struct {
+	Schema Schema
+	Row    func(T) []any
+	// Time picks the record's partition date.
+	Time func(T) time.Time
+	// Market picks the record's market partition; nil means the table is
+	// partitioned by date only.
+	Market func(T) string
+}
+
+// Marshal encodes records as a Parquet file.
+func (t Table[T]) Marshal(records []T) ([]byte, error) {
+	rows := make([][]any, len(records))
+	for i, r := range records {
+		rows[i] = t.Row(r)
+	}
+	return t.Schema.Marshal(rows)
+}
+
+// Partition is the records of one Hive-style partition directory.
+type Partition[T any] struct {
+	// Dir is "dt=2025-01-02", or "dt=2025-01-02/market=<id>" when
+	// partitioned by market.
+	Dir     string
+	Records []T
+}
+
+// Partition groups records by UTC date and, with byMarket on a table that
+// has a market, by market, in directory order.
+func (t Table[T]) Partition(records []T, byMarket bool) []Partition[T] {
+	idx := make(map[string]int)
+	var parts []Partition[T]
+	for _, r := range records {
+		dir := "dt=" + t.Time(r).UTC().Format("2006-01-02")
+		if byMarket && t.Market != nil {
+			market := t.Market(r)
+			if market == "" {
+				market = "unknown"
+			}
+			dir += "/market=" + url.PathEscape(market)
+		}
+		i, ok := idx[dir]
+		if !ok {
+			i = len(parts)
+			idx[dir] = i
+			parts = append(parts, Partition[T]{Dir: dir})
+		}
+		parts[i].Records = append(parts[i].Records, r)
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Dir < parts[j].Dir })
+	return parts
+}
+
+// Fills is the raw Goldsky order-filled events table (raw/fills/).
+var Fills = Table[domain.RawFill]{
+	Schema: Schema{Name: "fills", Columns: []Column{
+		{Name: "id", Type: String},
+		{Name: "transaction_hash", Type: String},
+		{Name: "timestamp", Type: Timestamp},
+		{Name: "maker", Type: String},
+		{Name: "maker_asset_id", Type: String},
+		{Name: "maker_amount_filled", Type: Int64},
+		{Name: "taker", Type: String},
+		{Name: "taker_asset_id", Type: String},
+		{Name: "taker_amount_filled", Type: Int64},
+	}},
+	Row: func(f domain.RawFill) []any {
+		return []any{
+			f.ID, f.TransactionHash, time.Unix(f.Timestamp, 0),
+			f.Maker, f.MakerAssetID, f.MakerAmountFilled,
+			f.Taker, f.TakerAssetID, f.TakerAmountFilled,
+		}
+	},
+	Time: func(f domain.RawFill) time.Time { return time.Unix(f.Timestamp, 0) },
+}
+
+// Trades is the enriched trades table (archive/trades/).
+var Trades = Table[domain.Trade]{
+	Schema: Schema{Name: "trades", Columns: []Column{
+		{Name: "id", Type: Int64},
+		{Name: "source", Type: String},
+		{Name: "source_trade_id", Type: String},
+		{Name: "source_log_idx", Type: Int64, Optional: true},
+		{Name: "timestamp", Type: Timestamp},
+		{Name: "market_id", Type: String},
+		{Name: "maker", Type: String},
+		{Name: "taker", Type: String},
+		{Name: "token_side", Type: String},
+		{Name: "maker_direction", Type: String},
+		{Name: "taker_direction", Type: String},
+		{Name: "price", Type: Double},
+		{Name: "usd_amount", Type: Double},
+		{Name: "token_amount", Type: Double},
+		{Name: "tx_hash", Type: String},
+	}},
+	Row: func(t domain.Trade) []any {
+		var logIdx any
+		if t.SourceLogIdx != nil {
+			logIdx = *t.SourceLogIdx
+		}
+		return []any{
+			t.ID, t.Source, t.SourceTradeID, logIdx, t.Timestamp,
+			t.MarketID, t.Maker, t.Taker, t.TokenSide,
+			t.MakerDirection, t.TakerDirection,
+			t.Price, t.USDAmount, t.TokenAmount, t.TxHash,
+		}
+	},
+	Time:   func(t domain.Trade) time.Time { return t.Timestamp },
+	Market: func(t domain.Trade) string { return t.MarketID },
+}
+
+// Orders is the orders table (archive/orders/). Prices and sizes are
+// decimal, not fixed-point ticks.
+var Orders = Table[domain.Order]{
+	Schema: Schema{Name: "orders", Columns: []Column{
+		{Name: "id", Type: String},
+		{Name: "exchange_id", Type: String},
+		{Name: "market_id", Type: String},
+		{Name: "token_id", Type: String},
+		{Name: "wallet", Type: String},
+		{Name: "side", Type: String},
+		{Name: "type", Type: String},
+		{Name: "price", Type: Double},
+		{Name: "size", Type: Double},
+		{Name: "maker_amount", Type: String, Optional: true},
+		{Name: "taker_amount", Type: String, Optional: true},
+		{Name: "filled_size", Type: Double},
+		{Name: "status", Type: String},
+		{Name: "signature", Type: String},
+		{Name: "strategy", Type: String},
+		{Name: "created_at", Type: Timestamp},
+		{Name: "filled_at", Type: Timestamp, Optional: true},
+		{Name: "cancelled_at", Type: Timestamp, Optional: true},
+		{Name: "expires_at", Type: Timestamp, Optional: true},
+		{Name: "cancel_after", Type: Timestamp, Optional: true},
+	}},
+	Row: func(o domain.Order) []any {
+		var maker, taker any
+		if o.MakerAmount != nil {
+			maker = o.MakerAmount.String()
+		}
+		if o.TakerAmount != nil {
+			taker = o.TakerAmount.String()
+		}
+		return []any{
+			o.ID, o.ExchangeID, o.MarketID, o.TokenID, o.Wallet,
+			string(o.Side), string(o.Type), o.Price(), o.Size(),
+			maker, taker, o.FilledSize, string(o.Status),
+			o.Signature, o.Strategy, o.CreatedAt,
+			optionalTime(o.FilledAt), optionalTime(o.CancelledAt),
+			optionalTime(o.ExpiresAt), optionalTime(o.CancelAfter),
+		}
+	},
+	Time:   func(o domain.Order) time.Time { return o.CreatedAt },
+	Market: func(o domain.Order) string { return o.MarketID },
+}
+
+// ArbHistory is the arbitrage opportunities table (archive/arb_history/).
+var ArbHistory = Table[domain.ArbOpportunity]{
+	Schema: Schema{Name: "arb_history", Columns: []Column{
+		{Name: "id", Type: String},
+		{Name: "poly_market_id", Type: String},
+		{Name: "poly_token_id", Type: String},
+		{Name: "poly_price", Type: Double},
+		{Name: "kalshi_market_id", Type: String},
+		{Name: "kalshi_price", Type: Double},
+		{Name: "gross_edge_bps", Type: Double},
+		{Name: "direction", Type: String},
+		{Name: "max_amount", Type: Double},
+		{Name: "est_fee_bps", Type: Double},
+		{Name: "est_slippage_bps", Type: Double},
+		{Name: "est_latency_bps", Type: Double},
+		{Name: "net_edge_bps", Type: Double},
+		{Name: "expected_pnl_usd", Type: Double},
+		{Name: "detected_at", Type: Timestamp},
+		{Name: "duration_ms", Type: Int64},
+		{Name: "executed", Type: Bool},
+	}},
+	Row: func(a domain.ArbOpportunity) []any {
+		return []any{
+			a.ID, a.PolyMarketID, a.PolyTokenID, a.PolyPrice,
+			a.KalshiMarketID, a.KalshiPrice, a.GrossEdgeBps, a.Direction,
+			a.MaxAmount, a.EstFeeBps, a.EstSlippageBps, a.EstLatencyBps,
+			a.NetEdgeBps, a.ExpectedPnLUSD, a.DetectedAt,
+			a.Duration.Milliseconds(), a.Executed,
+		}
+	},
+	Time:   func(a domain.ArbOpportunity) time.Time { return a.DetectedAt },
+	Market: func(a domain.ArbOpportunity) string { return a.PolyMarketID },
+}
+
+// optionalTime returns *t, or nil for a nil t.
+func optionalTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
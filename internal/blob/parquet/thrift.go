@@ -0,0 +1,88 @@
+package parquet
+
+import "encoding/binary"
+
+// Thrift compact protocol type IDs used by the Parquet metadata.
+const (
+	tI32    byte = 5
+	tI64    byte = 6
+	tBinary byte = 8
+	tList   byte = 9
+	tStruct byte = 12
+)
+
+// compactWriter encodes the Thrift compact protocol, which Parquet uses for
+// page headers and the file footer. It covers the subset those need: i32,
+// i64, binary, lists and nested structs.
+type compactWriter struct {
+	buf  []byte
+	last []int16 // previous field ID of each open struct
+}
+
+// begin opens a struct, top-level or as a list element.
+func (w *compactWriter) begin() {
+	w.last = append(w.last, 0)
+}
+
+// end writes the stop field and closes the innermost struct.
+func (w *compactWriter) end() {
+	w.buf = append(w.buf, 0)
+	w.last = w.last[:len(w.last)-1]
+}
+
+// field writes a field header, delta-encoding the ID when it allows.
+func (w *compactWriter) field(id int16, typ byte) {
+	top := len(w.last) - 1
+	if delta := id - w.last[top]; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.varint(int64(id))
+	}
+	w.last[top] = id
+}
+
+func (w *compactWriter) i32(id int16, v int32) {
+	w.field(id, tI32)
+	w.varint(int64(v))
+}
+
+func (w *compactWriter) i64(id int16, v int64) {
+	w.field(id, tI64)
+	w.varint(v)
+}
+
+func (w *compactWriter) str(id int16, v string) {
+	w.field(id, tBinary)
+	w.binary(v)
+}
+
+// structField opens a nested struct field; close it with end.
+func (w *compactWriter) structField(id int16) {
+	w.field(id, tStruct)
+	w.begin()
+}
+
+// list writes a list field header for n elements of type elem; the
+// elements follow.
+func (w *compactWriter) list(id int16, elem byte, n int) {
+	w.field(id, tList)
+	if n < 15 {
+		w.buf = append(w.buf, byte(n)<<4|elem)
+		return
+	}
+	w.buf = append(w.buf, 0xf0|elem)
+	w.buf = binary.AppendUvarint(w.buf, uint64(n))
+}
+
+// varint writes v zigzag-encoded, as compact i16, i32 and i64 values are.
+func (w *compactWriter) varint(v int64) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(v<<1)^uint64(v>>63))
+}
+
+// binary writes a length-prefixed string without a field header, as list
+// elements are.
+func (w *compactWriter) binary(v string) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
@@ -0,0 +1,318 @@
+// Package parquet writes Apache Parquet files for the S3 data lake, so that
+// DuckDB, Athena and other analytics tools can query fills, trades and
+// archives without parsing JSONL. It covers what the lake needs: flat
+// schemas of required or optional primitive columns, PLAIN-encoded and
+// GZIP-compressed, one data page per column chunk.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ContentType is the media type of Parquet objects.
+const ContentType = "application/vnd.apache.parquet"
+
+// Ext is the file extension of Parquet objects.
+const Ext = ".parquet"
+
+// magic starts and ends every Parquet file.
+const magic = "PAR1"
+
+// rowGroupRows caps the rows of one row group, so readers can skip and
+// parallelise over large archives.
+const rowGroupRows = 100_000
+
+// Type is a column's value type.
+type Type int
+
+const (
+	Bool   Type = iota // BOOLEAN; values are bool
+	Int64              // INT64; values are int64
+	Double             // DOUBLE; values are float64
+	String             // BYTE_ARRAY annotated UTF8; values are string
+	// Timestamp is INT64 annotated TIMESTAMP_MICROS, in UTC; values are
+	// time.Time.
+	Timestamp
+)
+
+// Column is one field of a flat schema. Only Optional columns accept nil.
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+// Schema is a flat Parquet schema: a named root with primitive columns.
+type Schema struct {
+	Name    string
+	Columns []Column
+}
+
+// Parquet metadata enum values (parquet.thrift).
+const (
+	physBoolean   = 0
+	physInt64     = 2
+	physDouble    = 5
+	physByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMicros = 10
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecGzip = 2
+
+	pageData = 0
+)
+
+// columnChunk records where one column of a row group was written.
+type columnChunk struct {
+	offset       int64
+	values       int64
+	uncompressed int64
+	compressed   int64
+}
+
+// Marshal encodes rows as a Parquet file. Each row holds one value per
+// column, in column order.
+func (s Schema) Marshal(rows [][]any) ([]byte, error) {
+	for i, row := range rows {
+		if len(row) != len(s.Columns) {
+			return nil, fmt.Errorf("parquet: %s row %d: %d values for %d columns", s.Name, i, len(row), len(s.Columns))
+		}
+	}
+
+	out := []byte(magic)
+	var groups [][]columnChunk
+	for start := 0; start < len(rows); start += rowGroupRows {
+		group := rows[start:min(start+rowGroupRows, len(rows))]
+		chunks := make([]columnChunk, len(s.Columns))
+		for c, col := range s.Columns {
+			page, err := encodePage(col, c, group)
+			if err != nil {
+				return nil, fmt.Errorf("parquet: %s: %w", s.Name, err)
+			}
+			chunks[c] = columnChunk{
+				offset:       int64(len(out)),
+				values:       int64(len(group)),
+				uncompressed: page.uncompressed,
+				compressed:   int64(len(page.data)),
+			}
+			out = append(out, page.data...)
+		}
+		groups = append(groups, chunks)
+	}
+
+	footer := s.footer(rows, groups)
+	out = append(out, footer...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(footer)))
+	return append(out, magic...), nil
+}
+
+// page is an encoded data page: header plus compressed body.
+type page struct {
+	data         []byte
+	uncompressed int64 // header plus uncompressed body
+}
+
+// encodePage encodes column c of rows as one data page. Optional columns
+// carry RLE definition levels ahead of the values; nulls have no value.
+func encodePage(col Column, c int, rows [][]any) (page, error) {
+	var (
+		values []byte
+		bools  []bool
+		levels []byte
+	)
+	for i, row := range rows {
+		v := row[c]
+		if v == nil {
+			if !col.Optional {
+				return page{}, fmt.Errorf("column %s row %d: nil in a required column", col.Name, i)
+			}
+			levels = append(levels, 0)
+			continue
+		}
+		levels = append(levels, 1)
+		ok := true
+		switch col.Type {
+		case Bool:
+			var b bool
+			b, ok = v.(bool)
+			bools = append(bools, b)
+		case Int64:
+			var n int64
+			n, ok = v.(int64)
+			values = binary.LittleEndian.AppendUint64(values, uint64(n))
+		case Double:
+			var f float64
+			f, ok = v.(float64)
+			values = binary.LittleEndian.AppendUint64(values, math.Float64bits(f))
+		case String:
+			var str string
+			str, ok = v.(string)
+			values = binary.LittleEndian.AppendUint32(values, uint32(len(str)))
+			values = append(values, str...)
+		case Timestamp:
+			var t time.Time
+			t, ok = v.(time.Time)
+			values = binary.LittleEndian.AppendUint64(values, uint64(t.UnixMicro()))
+		}
+		if !ok {
+			return page{}, fmt.Errorf("column %s row %d: unexpected %T", col.Name, i, v)
+		}
+	}
+	if col.Type == Bool {
+		values = packBools(bools)
+	}
+
+	var body []byte
+	if col.Optional {
+		rle := rleLevels(levels)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(rle)))
+		body = append(body, rle...)
+	}
+	body = append(body, values...)
+
+	var zbuf bytes.Buffer
+	zw := gzip.NewWriter(&zbuf)
+	if _, err := zw.Write(body); err != nil {
+		return page{}, fmt.Errorf("column %s: compress: %w", col.Name, err)
+	}
+	if err := zw.Close(); err != nil {
+		return page{}, fmt.Errorf("column %s: compress: %w", col.Name, err)
+	}
+
+	var h compactWriter
+	h.begin()
+	h.i32(1, pageData)
+	h.i32(2, int32(len(body)))
+	h.i32(3, int32(zbuf.Len()))
+	h.structField(5) // DataPageHeader
+	h.i32(1, int32(len(rows)))
+	h.i32(2, encodingPlain)
+	h.i32(3, encodingRLE)
+	h.i32(4, encodingRLE)
+	h.end()
+	h.end()
+
+	return page{
+		data:         append(h.buf, zbuf.Bytes()...),
+		uncompressed: int64(len(h.buf) + len(body)),
+	}, nil
+}
+
+// footer encodes the FileMetaData.
+func (s Schema) footer(rows [][]any, groups [][]columnChunk) []byte {
+	var w compactWriter
+	w.begin()
+	w.i32(1, 1) // version
+
+	w.list(2, tStruct, len(s.Columns)+1)
+	w.begin()
+	w.str(4, s.Name)
+	w.i32(5, int32(len(s.Columns)))
+	w.end()
+	for _, col := range s.Columns {
+		w.begin()
+		w.i32(1, physicalType(col.Type))
+		rep := int32(repetitionRequired)
+		if col.Optional {
+			rep = repetitionOptional
+		}
+		w.i32(3, rep)
+		w.str(4, col.Name)
+		switch col.Type {
+		case String:
+			w.i32(6, convertedUTF8)
+		case Timestamp:
+			w.i32(6, convertedTimestampMicros)
+		}
+		w.end()
+	}
+
+	w.i64(3, int64(len(rows)))
+
+	w.list(4, tStruct, len(groups))
+	for g, chunks := range groups {
+		var total int64
+		w.begin()
+		w.list(1, tStruct, len(chunks))
+		for c, ch := range chunks {
+			col := s.Columns[c]
+			total += ch.uncompressed
+			w.begin()
+			w.i64(2, ch.offset)
+			w.structField(3) // ColumnMetaData
+			w.i32(1, physicalType(col.Type))
+			w.list(2, tI32, 2)
+			w.varint(encodingPlain)
+			w.varint(encodingRLE)
+			w.list(3, tBinary, 1)
+			w.binary(col.Name)
+			w.i32(4, codecGzip)
+			w.i64(5, ch.values)
+			w.i64(6, ch.uncompressed)
+			w.i64(7, ch.compressed)
+			w.i64(9, ch.offset)
+			w.end()
+			w.end()
+		}
+		w.i64(2, total)
+		w.i64(3, int64(min(rowGroupRows, len(rows)-g*rowGroupRows)))
+		w.end()
+	}
+
+	w.str(6, "polymarketbot")
+	w.end()
+	return w.buf
+}
+
+// physicalType maps a column type to its Parquet physical type.
+func physicalType(t Type) int32 {
+	switch t {
+	case Bool:
+		return physBoolean
+	case Double:
+		return physDouble
+	case String:
+		return physByteArray
+	default: // Int64, Timestamp
+		return physInt64
+	}
+}
+
+// packBools bit-packs booleans LSB first, as PLAIN BOOLEAN values are.
+func packBools(bools []bool) []byte {
+	out := make([]byte, (len(bools)+7)/8)
+	for i, b := range bools {
+		if b {
+			out[i/8] |= 1 << (i % 8)
+		}
+	}
+	return out
+}
+
+// rleLevels encodes definition levels of bit width 1 as RLE runs of the
+// RLE/bit-packing hybrid.
+func rleLevels(levels []byte) []byte {
+	var out []byte
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		out = binary.AppendUvarint(out, uint64(j-i)<<1)
+		out = append(out, levels[i])
+		i = j
+	}
+	return out
+}
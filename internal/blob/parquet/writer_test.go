@@ -0,0 +1,587 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The tests read the writer's output back with readFile, a reader written
+// from the Parquet and Thrift compact protocol specifications that shares no
+// code with the writer.
+
+var allTypes = Schema{
+	Name: "all_types",
+	Columns: []Column{
+		{Name: "flag", Type: Bool},
+		{Name: "n", Type: Int64},
+		{Name: "x", Type: Double},
+		{Name: "s", Type: String},
+		{Name: "ts", Type: Timestamp},
+		{Name: "opt_flag", Type: Bool, Optional: true},
+		{Name: "opt_n", Type: Int64, Optional: true},
+		{Name: "opt_x", Type: Double, Optional: true},
+		{Name: "opt_s", Type: String, Optional: true},
+		{Name: "opt_ts", Type: Timestamp, Optional: true},
+	},
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	ts := time.Date(2025, 3, 4, 5, 6, 7, 891_000, time.UTC)
+	var rows [][]any
+	for i := range 21 {
+		row := []any{
+			i%3 == 0,
+			int64(i*1_000_003) - 7,
+			float64(i) / 7,
+			strings.Repeat("é", i%4),
+			ts.Add(time.Duration(i) * time.Hour),
+			nil, nil, nil, nil, nil,
+		}
+		// Mix runs of nulls and values, so definition levels need several
+		// RLE runs.
+		if i%5 != 1 && i < 17 {
+			row[5] = i%2 == 0
+			row[6] = -int64(i)
+			row[7] = math.Inf(1)
+			row[8] = fmt.Sprintf("v%d", i)
+			row[9] = ts.Add(-time.Duration(i) * time.Microsecond)
+		}
+		rows = append(rows, row)
+	}
+
+	data, err := allTypes.Marshal(rows)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	f, err := readFile(data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	wantSchema := []schemaElement{
+		{name: "flag", typ: physBoolean, repetition: repetitionRequired, converted: -1},
+		{name: "n", typ: physInt64, repetition: repetitionRequired, converted: -1},
+		{name: "x", typ: physDouble, repetition: repetitionRequired, converted: -1},
+		{name: "s", typ: physByteArray, repetition: repetitionRequired, converted: convertedUTF8},
+		{name: "ts", typ: physInt64, repetition: repetitionRequired, converted: convertedTimestampMicros},
+		{name: "opt_flag", typ: physBoolean, repetition: repetitionOptional, converted: -1},
+		{name: "opt_n", typ: physInt64, repetition: repetitionOptional, converted: -1},
+		{name: "opt_x", typ: physDouble, repetition: repetitionOptional, converted: -1},
+		{name: "opt_s", typ: physByteArray, repetition: repetitionOptional, converted: convertedUTF8},
+		{name: "opt_ts", typ: physInt64, repetition: repetitionOptional, converted: convertedTimestampMicros},
+	}
+	if f.root != "all_types" {
+		t.Errorf("root = %q, want all_types", f.root)
+	}
+	if !reflect.DeepEqual(f.columns, wantSchema) {
+		t.Errorf("schema = %+v, want %+v", f.columns, wantSchema)
+	}
+	if f.numRows != int64(len(rows)) || len(f.groups) != 1 {
+		t.Fatalf("rows = %d in %d groups, want %d in 1", f.numRows, len(f.groups), len(rows))
+	}
+	if !reflect.DeepEqual(f.rows, rows) {
+		t.Errorf("rows differ\ngot:  %v\nwant: %v", f.rows, rows)
+	}
+}
+
+func TestMarshalAllNullsAndManyBools(t *testing.T) {
+	schema := Schema{Name: "b", Columns: []Column{
+		{Name: "b", Type: Bool},
+		{Name: "never", Type: String, Optional: true},
+	}}
+	var rows [][]any
+	for i := range 1000 {
+		rows = append(rows, []any{i%7 == 0 || i%11 == 0, nil})
+	}
+	data, err := schema.Marshal(rows)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	f, err := readFile(data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !reflect.DeepEqual(f.rows, rows) {
+		t.Errorf("rows differ")
+	}
+}
+
+func TestMarshalRowGroups(t *testing.T) {
+	schema := Schema{Name: "groups", Columns: []Column{
+		{Name: "n", Type: Int64},
+		{Name: "odd", Type: Bool, Optional: true},
+	}}
+	n := 2*rowGroupRows + 5
+	rows := make([][]any, n)
+	for i := range rows {
+		var odd any
+		if i%3 != 0 {
+			odd = i%2 == 1
+		}
+		rows[i] = []any{int64(i), odd}
+	}
+	data, err := schema.Marshal(rows)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	f, err := readFile(data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if want := []int64{rowGroupRows, rowGroupRows, 5}; !reflect.DeepEqual(f.groups, want) {
+		t.Errorf("row groups = %v, want %v", f.groups, want)
+	}
+	if f.numRows != int64(n) {
+		t.Errorf("num_rows = %d, want %d", f.numRows, n)
+	}
+	if !reflect.DeepEqual(f.rows, rows) {
+		t.Errorf("rows differ")
+	}
+}
+
+func TestMarshalEmpty(t *testing.T) {
+	data, err := allTypes.Marshal(nil)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	f, err := readFile(data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if f.numRows != 0 || len(f.groups) != 0 || len(f.columns) != len(allTypes.Columns) {
+		t.Errorf("got %d rows, %d groups, %d columns", f.numRows, len(f.groups), len(f.columns))
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	schema := Schema{Name: "e", Columns: []Column{
+		{Name: "n", Type: Int64},
+		{Name: "s", Type: String, Optional: true},
+	}}
+	tests := []struct {
+		name string
+		row  []any
+	}{
+		{"nil in required column", []any{nil, "a"}},
+		{"wrong type", []any{int64(1), 2}},
+		{"int instead of int64", []any{1, nil}},
+		{"short row", []any{int64(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := schema.Marshal([][]any{{int64(0), nil}, tt.row}); err == nil {
+				t.Fatal("Marshal succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestTablesMarshal(t *testing.T) {
+	// Every lake table must produce a readable file from a zero record.
+	check := func(name string, marshal func() ([]byte, error), columns int) {
+		t.Helper()
+		data, err := marshal()
+		if err != nil {
+			t.Fatalf("%s: Marshal: %v", name, err)
+		}
+		f, err := readFile(data)
+		if err != nil {
+			t.Fatalf("%s: read: %v", name, err)
+		}
+		if len(f.columns) != columns || len(f.rows) != 1 || len(f.rows[0]) != columns {
+			t.Errorf("%s: got %d columns and %d rows", name, len(f.columns), len(f.rows))
+		}
+	}
+	check("fills", func() ([]byte, error) { return Fills.Schema.Marshal([][]any{Fills.Row(zero(Fills))}) }, len(Fills.Schema.Columns))
+	check("trades", func() ([]byte, error) { return Trades.Schema.Marshal([][]any{Trades.Row(zero(Trades))}) }, len(Trades.Schema.Columns))
+	check("orders", func() ([]byte, error) { return Orders.Schema.Marshal([][]any{Orders.Row(zero(Orders))}) }, len(Orders.Schema.Columns))
+	check("arb_history", func() ([]byte, error) { return ArbHistory.Schema.Marshal([][]any{ArbHistory.Row(zero(ArbHistory))}) }, len(ArbHistory.Schema.Columns))
+}
+
+func zero[T any](Table[T]) T {
+	var v T
+	return v
+}
+
+// parquetFile is a decoded Parquet file.
+type parquetFile struct {
+	root    string
+	columns []schemaElement
+	numRows int64
+	groups  []int64 // rows per row group
+	rows    [][]any
+}
+
+type schemaElement struct {
+	name       string
+	typ        int64
+	repetition int64
+	converted  int64 // -1 when absent
+}
+
+// readFile decodes a Parquet file written with PLAIN values, RLE definition
+// levels and GZIP pages.
+func readFile(data []byte) (*parquetFile, error) {
+	if len(data) < 12 || string(data[:4]) != magic || string(data[len(data)-4:]) != magic {
+		return nil, fmt.Errorf("missing magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerLen
+	if footerStart < 4 {
+		return nil, fmt.Errorf("footer length %d out of range", footerLen)
+	}
+	r := &thriftReader{buf: data[footerStart : len(data)-8]}
+	meta, err := r.readStruct()
+	if err != nil {
+		return nil, fmt.Errorf("footer: %w", err)
+	}
+	if r.pos != len(r.buf) {
+		return nil, fmt.Errorf("footer: %d trailing bytes", len(r.buf)-r.pos)
+	}
+
+	f := &parquetFile{numRows: meta.int(3)}
+	schema := meta.list(2)
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("empty schema")
+	}
+	root := schema[0].(thriftStruct)
+	f.root = root.str(4)
+	if int(root.int(5)) != len(schema)-1 {
+		return nil, fmt.Errorf("root has %d children, schema %d columns", root.int(5), len(schema)-1)
+	}
+	for _, el := range schema[1:] {
+		s := el.(thriftStruct)
+		col := schemaElement{name: s.str(4), typ: s.int(1), repetition: s.int(3), converted: -1}
+		if _, ok := s[6]; ok {
+			col.converted = s.int(6)
+		}
+		f.columns = append(f.columns, col)
+	}
+
+	for g, el := range meta.list(4) {
+		group := el.(thriftStruct)
+		groupRows := group.int(3)
+		f.groups = append(f.groups, groupRows)
+		chunks := group.list(1)
+		if len(chunks) != len(f.columns) {
+			return nil, fmt.Errorf("row group %d: %d chunks for %d columns", g, len(chunks), len(f.columns))
+		}
+		start := len(f.rows)
+		for range groupRows {
+			f.rows = append(f.rows, make([]any, len(f.columns)))
+		}
+		var total int64
+		for c, el := range chunks {
+			col := f.columns[c]
+			cm := el.(thriftStruct).strct(3)
+			if cm.int(1) != col.typ || cm.int(4) != codecGzip || cm.int(5) != groupRows {
+				return nil, fmt.Errorf("row group %d column %s: bad metadata %v", g, col.name, cm)
+			}
+			if path := cm.list(3); len(path) != 1 || string(path[0].([]byte)) != col.name {
+				return nil, fmt.Errorf("row group %d column %s: path %v", g, col.name, path)
+			}
+			total += cm.int(6)
+			values, err := readChunk(data, cm, col, groupRows)
+			if err != nil {
+				return nil, fmt.Errorf("row group %d column %s: %w", g, col.name, err)
+			}
+			for i, v := range values {
+				f.rows[start+i][c] = v
+			}
+		}
+		if group.int(2) != total {
+			return nil, fmt.Errorf("row group %d: total_byte_size %d, chunks sum %d", g, group.int(2), total)
+		}
+	}
+	if int64(len(f.rows)) != f.numRows {
+		return nil, fmt.Errorf("num_rows %d, row groups hold %d", f.numRows, len(f.rows))
+	}
+	return f, nil
+}
+
+// readChunk decodes the single data page of a column chunk.
+func readChunk(data []byte, cm thriftStruct, col schemaElement, rows int64) ([]any, error) {
+	offset := cm.int(9)
+	if offset < 4 || offset >= int64(len(data)) {
+		return nil, fmt.Errorf("data page offset %d out of range", offset)
+	}
+	r := &thriftReader{buf: data[offset:]}
+	header, err := r.readStruct()
+	if err != nil {
+		return nil, fmt.Errorf("page header: %w", err)
+	}
+	dph := header.strct(5)
+	if header.int(1) != pageData || dph.int(1) != rows || dph.int(2) != encodingPlain || dph.int(3) != encodingRLE {
+		return nil, fmt.Errorf("bad page header %v", header)
+	}
+	compressed, uncompressed := header.int(3), header.int(2)
+	if int64(r.pos)+compressed != cm.int(7) || int64(r.pos)+uncompressed != cm.int(6) {
+		return nil, fmt.Errorf("chunk sizes %d/%d do not match page %d+%d/%d", cm.int(7), cm.int(6), r.pos, compressed, uncompressed)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(r.buf[r.pos : int64(r.pos)+compressed]))
+	if err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+	if int64(len(body)) != uncompressed {
+		return nil, fmt.Errorf("page body %d bytes, header says %d", len(body), uncompressed)
+	}
+
+	defined := make([]bool, rows)
+	for i := range defined {
+		defined[i] = true
+	}
+	if col.repetition == repetitionOptional {
+		if len(body) < 4 {
+			return nil, fmt.Errorf("short definition levels")
+		}
+		n := int(binary.LittleEndian.Uint32(body))
+		if 4+n > len(body) {
+			return nil, fmt.Errorf("definition levels %d bytes out of range", n)
+		}
+		levels, err := decodeHybrid(body[4:4+n], int(rows))
+		if err != nil {
+			return nil, fmt.Errorf("definition levels: %w", err)
+		}
+		for i, l := range levels {
+			defined[i] = l == 1
+		}
+		body = body[4+n:]
+	}
+
+	values := make([]any, rows)
+	var bit int
+	for i := range values {
+		if !defined[i] {
+			continue
+		}
+		switch col.typ {
+		case physBoolean:
+			if bit/8 >= len(body) {
+				return nil, fmt.Errorf("short boolean values")
+			}
+			values[i] = body[bit/8]&(1<<(bit%8)) != 0
+			bit++
+			continue
+		case physInt64, physDouble:
+			if len(body) < 8 {
+				return nil, fmt.Errorf("short values")
+			}
+			u := binary.LittleEndian.Uint64(body)
+			body = body[8:]
+			switch {
+			case col.typ == physDouble:
+				values[i] = math.Float64frombits(u)
+			case col.converted == convertedTimestampMicros:
+				values[i] = time.UnixMicro(int64(u)).UTC()
+			default:
+				values[i] = int64(u)
+			}
+		case physByteArray:
+			if len(body) < 4 || 4+int(binary.LittleEndian.Uint32(body)) > len(body) {
+				return nil, fmt.Errorf("short byte array")
+			}
+			n := int(binary.LittleEndian.Uint32(body))
+			values[i] = string(body[4 : 4+n])
+			body = body[4+n:]
+		default:
+			return nil, fmt.Errorf("unexpected physical type %d", col.typ)
+		}
+	}
+	if col.typ == physBoolean {
+		if want := (bit + 7) / 8; len(body) != want {
+			return nil, fmt.Errorf("%d boolean bytes, want %d", len(body), want)
+		}
+	} else if len(body) != 0 {
+		return nil, fmt.Errorf("%d trailing value bytes", len(body))
+	}
+	return values, nil
+}
+
+// decodeHybrid decodes n levels of bit width 1 from the RLE/bit-packing
+// hybrid encoding.
+func decodeHybrid(buf []byte, n int) ([]byte, error) {
+	var out []byte
+	for len(out) < n {
+		h, k := binary.Uvarint(buf)
+		if k <= 0 {
+			return nil, fmt.Errorf("bad run header")
+		}
+		buf = buf[k:]
+		if h&1 == 1 { // bit-packed groups of 8
+			groups := int(h >> 1)
+			if len(buf) < groups {
+				return nil, fmt.Errorf("short bit-packed run")
+			}
+			for _, b := range buf[:groups] {
+				for i := range 8 {
+					out = append(out, b>>i&1)
+				}
+			}
+			buf = buf[groups:]
+			continue
+		}
+		if len(buf) < 1 {
+			return nil, fmt.Errorf("short RLE run")
+		}
+		for range h >> 1 {
+			out = append(out, buf[0])
+		}
+		buf = buf[1:]
+	}
+	if len(buf) != 0 {
+		return nil, fmt.Errorf("%d trailing level bytes", len(buf))
+	}
+	return out[:n], nil
+}
+
+// thriftStruct is a decoded Thrift struct: field ID to value. Values are
+// int64, bool, float64, []byte, []any or thriftStruct.
+type thriftStruct map[int16]any
+
+func (s thriftStruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s thriftStruct) str(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s thriftStruct) list(id int16) []any {
+	v, _ := s[id].([]any)
+	return v
+}
+
+func (s thriftStruct) strct(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+// thriftReader decodes the Thrift compact protocol.
+type thriftReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("bad varint at %d", r.pos)
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) zigzag() (int64, error) {
+	u, err := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1), err
+}
+
+func (r *thriftReader) readStruct() (thriftStruct, error) {
+	s := thriftStruct{}
+	var last int16
+	for {
+		h, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if h == 0 {
+			return s, nil
+		}
+		typ := h & 0x0f
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			v, err := r.zigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		last = id
+		switch typ {
+		case 1, 2: // boolean true/false, held in the header
+			s[id] = typ == 1
+			continue
+		}
+		if s[id], err = r.value(typ); err != nil {
+			return nil, fmt.Errorf("field %d: %w", id, err)
+		}
+	}
+}
+
+func (r *thriftReader) value(typ byte) (any, error) {
+	switch typ {
+	case 1, 2: // list element booleans
+		b, err := r.byte()
+		return b == 1, err
+	case 3:
+		b, err := r.byte()
+		return int64(int8(b)), err
+	case 4, 5, 6:
+		return r.zigzag()
+	case 7:
+		if r.pos+8 > len(r.buf) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(r.buf[r.pos:]))
+		r.pos += 8
+		return v, nil
+	case 8:
+		n, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if r.pos+int(n) > len(r.buf) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		v := r.buf[r.pos : r.pos+int(n)]
+		r.pos += int(n)
+		return v, nil
+	case 9, 10:
+		h, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		n := int(h >> 4)
+		if n == 15 {
+			u, err := r.uvarint()
+			if err != nil {
+				return nil, err
+			}
+			n = int(u)
+		}
+		out := make([]any, n)
+		for i := range out {
+			if out[i], err = r.value(h & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case 12:
+		return r.readStruct()
+	default:
+		return nil, fmt.Errorf("unsupported thrift type %d", typ)
+	}
+}
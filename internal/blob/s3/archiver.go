@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/alanyoungcy/polymarketbot/internal/blob/parquet"
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
@@ -47,7 +48,8 @@ type ArbArchiveStore interface {
 // ---------------------------------------------------------------------------
 
 // ArchiveImpl implements domain.Archiver by querying the domain stores for
-// old records, serializing them to JSONL, and uploading the result to S3.
+// old records, serializing them to JSONL (or Parquet, see WithParquet), and
+// uploading the result to S3.
 //
 // Deletion of the archived records from the primary store is intentionally
 // NOT performed here -- that is a separate, explicit step to be executed
@@ -58,6 +60,9 @@ type ArchiveImpl struct {
 	orders OrderArchiveStore
 	arb    ArbArchiveStore
 	audit  domain.AuditStore
+
+	parquet  bool
+	byMarket bool
 }
 
 // NewArchiver creates a new ArchiveImpl.
@@ -77,10 +82,25 @@ func NewArchiver(
 	}
 }
 
+// WithParquet writes archives as Parquet instead of JSONL, one file per day
+// of records under a Hive-style partition, and with byMarket per market as
+// well:
+//
+//	archive/trades/dt=2025-01-02/2025-02.parquet
+//	archive/trades/dt=2025-01-02/market=0xabc/2025-02.parquet
+//
+// The file name is the cutoff month, so re-running a month's archive
+// replaces its files.
+func (a *ArchiveImpl) WithParquet(byMarket bool) *ArchiveImpl {
+	a.parquet = true
+	a.byMarket = byMarket
+	return a
+}
+
 // ArchiveTrades queries all trades before the cutoff, serializes them to
-// JSONL, and uploads the file to S3 at archive/trades/YYYY-MM.jsonl. The
-// archival event is recorded in the audit log and the count of archived
-// records is returned.
+// JSONL, and uploads the file to S3 at archive/trades/YYYY-MM.jsonl (Parquet
+// partitions under archive/trades/ with WithParquet). The archival event is
+// recorded in the audit log and the count of archived records is returned.
 func (a *ArchiveImpl) ArchiveTrades(ctx context.Context, before time.Time) (int64, error) {
 	trades, err := a.trades.ListBefore(ctx, before)
 	if err != nil {
@@ -90,14 +110,9 @@ func (a *ArchiveImpl) ArchiveTrades(ctx context.Context, before time.Time) (int6
 		return 0, nil
 	}
 
-	buf, err := marshalJSONL(trades)
+	path, err := archive(ctx, a, "trades", before, trades, parquet.Trades)
 	if err != nil {
-		return 0, fmt.Errorf("s3blob: archive trades marshal: %w", err)
-	}
-
-	path := archivePath("trades", before)
-	if err := a.writer.Put(ctx, path, bytes.NewReader(buf), "application/x-ndjson"); err != nil {
-		return 0, fmt.Errorf("s3blob: archive trades upload: %w", err)
+		return 0, fmt.Errorf("s3blob: archive trades: %w", err)
 	}
 
 	count := int64(len(trades))
@@ -114,9 +129,9 @@ func (a *ArchiveImpl) ArchiveTrades(ctx context.Context, before time.Time) (int6
 }
 
 // ArchiveOrders queries all orders before the cutoff, serializes them to
-// JSONL, and uploads the file to S3 at archive/orders/YYYY-MM.jsonl. The
-// archival event is recorded in the audit log and the count of archived
-// records is returned.
+// JSONL, and uploads the file to S3 at archive/orders/YYYY-MM.jsonl (Parquet
+// partitions under archive/orders/ with WithParquet). The archival event is
+// recorded in the audit log and the count of archived records is returned.
 func (a *ArchiveImpl) ArchiveOrders(ctx context.Context, before time.Time) (int64, error) {
 	orders, err := a.orders.ListBefore(ctx, before)
 	if err != nil {
@@ -126,14 +141,9 @@ func (a *ArchiveImpl) ArchiveOrders(ctx context.Context, before time.Time) (int6
 		return 0, nil
 	}
 
-	buf, err := marshalJSONL(orders)
+	path, err := archive(ctx, a, "orders", before, orders, parquet.Orders)
 	if err != nil {
-		return 0, fmt.Errorf("s3blob: archive orders marshal: %w", err)
-	}
-
-	path := archivePath("orders", before)
-	if err := a.writer.Put(ctx, path, bytes.NewReader(buf), "application/x-ndjson"); err != nil {
-		return 0, fmt.Errorf("s3blob: archive orders upload: %w", err)
+		return 0, fmt.Errorf("s3blob: archive orders: %w", err)
 	}
 
 	count := int64(len(orders))
@@ -151,7 +161,8 @@ func (a *ArchiveImpl) ArchiveOrders(ctx context.Context, before time.Time) (int6
 
 // ArchiveArbHistory queries all arbitrage opportunities before the cutoff,
 // serializes them to JSONL, and uploads the file to S3 at
-// archive/arb_history/YYYY-MM.jsonl. The archival event is recorded in the
+// archive/arb_history/YYYY-MM.jsonl (Parquet partitions under
+// archive/arb_history/ with WithParquet). The archival event is recorded in the
 // audit log and the count of archived records is returned.
 func (a *ArchiveImpl) ArchiveArbHistory(ctx context.Context, before time.Time) (int64, error) {
 	opps, err := a.arb.ListBefore(ctx, before)
@@ -162,14 +173,9 @@ func (a *ArchiveImpl) ArchiveArbHistory(ctx context.Context, before time.Time) (
 		return 0, nil
 	}
 
-	buf, err := marshalJSONL(opps)
+	path, err := archive(ctx, a, "arb_history", before, opps, parquet.ArbHistory)
 	if err != nil {
-		return 0, fmt.Errorf("s3blob: archive arb history marshal: %w", err)
-	}
-
-	path := archivePath("arb_history", before)
-	if err := a.writer.Put(ctx, path, bytes.NewReader(buf), "application/x-ndjson"); err != nil {
-		return 0, fmt.Errorf("s3blob: archive arb history upload: %w", err)
+		return 0, fmt.Errorf("s3blob: archive arb history: %w", err)
 	}
 
 	count := int64(len(opps))
@@ -189,6 +195,35 @@ func (a *ArchiveImpl) ArchiveArbHistory(ctx context.Context, before time.Time) (
 // helpers
 // ---------------------------------------------------------------------------
 
+// archive uploads records as the kind's archive and returns its path: the
+// JSONL file, or the kind's directory when written as Parquet partitions.
+func archive[T any](ctx context.Context, a *ArchiveImpl, kind string, before time.Time, records []T, table parquet.Table[T]) (string, error) {
+	if !a.parquet {
+		buf, err := marshalJSONL(records)
+		if err != nil {
+			return "", fmt.Errorf("marshal: %w", err)
+		}
+		path := archivePath(kind, before)
+		if err := a.writer.Put(ctx, path, bytes.NewReader(buf), "application/x-ndjson"); err != nil {
+			return "", fmt.Errorf("upload: %w", err)
+		}
+		return path, nil
+	}
+
+	dir := "archive/" + kind + "/"
+	for _, part := range table.Partition(records, a.byMarket) {
+		buf, err := table.Marshal(part.Records)
+		if err != nil {
+			return "", fmt.Errorf("marshal %s: %w", part.Dir, err)
+		}
+		path := dir + part.Dir + "/" + before.Format("2006-01") + parquet.Ext
+		if err := a.writer.Put(ctx, path, bytes.NewReader(buf), parquet.ContentType); err != nil {
+			return "", fmt.Errorf("upload %s: %w", path, err)
+		}
+	}
+	return dir, nil
+}
+
 // archivePath builds the S3 key for an archive file, partitioned by the
 // year-month of the cutoff time.
 //
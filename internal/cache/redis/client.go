@@ -19,9 +19,12 @@ type ClientConfig struct {
 	TLSEnabled bool
 }
 
-// Client wraps a go-redis Client and provides connectivity helpers.
+// Client wraps a go-redis Client and provides connectivity helpers. A Client
+// returned by Namespace shares the connection pool and prefixes every key
+// written by the caches built from it.
 type Client struct {
-	rdb *redis.Client
+	rdb    *redis.Client
+	prefix string // "" for the root keyspace, else "name:...:"
 }
 
 // New creates a new Redis Client, pings it to verify connectivity, and returns
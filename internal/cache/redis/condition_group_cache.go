@@ -22,11 +22,12 @@ const cgTTL = 5 * time.Minute
 //	cg:mkt:{marketID} - string value of the group ID
 type ConditionGroupCache struct {
 	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
 }
 
 // NewConditionGroupCache creates a ConditionGroupCache backed by the given Client.
 func NewConditionGroupCache(c *Client) *ConditionGroupCache {
-	return &ConditionGroupCache{rdb: c.Underlying()}
+	return &ConditionGroupCache{rdb: c.Underlying(), ns: c.prefix}
 }
 
 func cgKey(id string) string        { return "cg:" + id }
@@ -39,7 +40,7 @@ func (c *ConditionGroupCache) Set(ctx context.Context, group domain.ConditionGro
 		return fmt.Errorf("redis: marshal condition group %s: %w", group.ID, err)
 	}
 
-	key := cgKey(group.ID)
+	key := c.ns + cgKey(group.ID)
 
 	pipe := c.rdb.TxPipeline()
 	pipe.HSet(ctx, key, "data", data)
@@ -54,7 +55,7 @@ func (c *ConditionGroupCache) Set(ctx context.Context, group domain.ConditionGro
 // Get retrieves a ConditionGroup by its ID from the cache.
 // It returns domain.ErrNotFound when the key does not exist.
 func (c *ConditionGroupCache) Get(ctx context.Context, id string) (domain.ConditionGroup, error) {
-	data, err := c.rdb.HGet(ctx, cgKey(id), "data").Bytes()
+	data, err := c.rdb.HGet(ctx, c.ns+cgKey(id), "data").Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return domain.ConditionGroup{}, domain.ErrNotFound
@@ -72,7 +73,7 @@ func (c *ConditionGroupCache) Get(ctx context.Context, id string) (domain.Condit
 // GetByMarketID looks up a ConditionGroup by one of its linked market IDs.
 // It returns domain.ErrNotFound if the mapping or group does not exist.
 func (c *ConditionGroupCache) GetByMarketID(ctx context.Context, marketID string) (domain.ConditionGroup, error) {
-	groupID, err := c.rdb.Get(ctx, cgMarketKey(marketID)).Result()
+	groupID, err := c.rdb.Get(ctx, c.ns+cgMarketKey(marketID)).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return domain.ConditionGroup{}, domain.ErrNotFound
@@ -85,7 +86,7 @@ func (c *ConditionGroupCache) GetByMarketID(ctx context.Context, marketID string
 
 // Invalidate removes a ConditionGroup from the cache.
 func (c *ConditionGroupCache) Invalidate(ctx context.Context, id string) error {
-	if err := c.rdb.Del(ctx, cgKey(id)).Err(); err != nil {
+	if err := c.rdb.Del(ctx, c.ns+cgKey(id)).Err(); err != nil {
 		return fmt.Errorf("redis: invalidate condition group %s: %w", id, err)
 	}
 	return nil
@@ -0,0 +1,165 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// DeadLetterQueue implements domain.DeadLetterQueue with a Redis stream,
+// one JSON entry per batch. The stream is never trimmed: a dead-lettered
+// batch is only removed once it has been replayed or parked.
+//
+// Key schema:
+//
+//	dlq        - stream of domain.DeadLetterBatch
+//	dlq:parked - stream of parked batches; entries that did not decode keep
+//	             their raw payload and an "error" field
+type DeadLetterQueue struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+}
+
+// NewDeadLetterQueue creates a DeadLetterQueue backed by the given Client.
+func NewDeadLetterQueue(c *Client) *DeadLetterQueue {
+	return &DeadLetterQueue{rdb: c.Underlying(), ns: c.prefix}
+}
+
+const (
+	deadLetterKey       = "dlq"
+	deadLetterParkedKey = "dlq:parked"
+)
+
+// Push appends a batch and returns its stream entry ID.
+func (q *DeadLetterQueue) Push(ctx context.Context, batch domain.DeadLetterBatch) (string, error) {
+	id, err := q.add(ctx, deadLetterKey, batch)
+	if err != nil {
+		return "", fmt.Errorf("redis: push dead letter batch: %w", err)
+	}
+	return id, nil
+}
+
+// Park appends a batch to the parked stream and returns its entry ID.
+func (q *DeadLetterQueue) Park(ctx context.Context, batch domain.DeadLetterBatch) (string, error) {
+	id, err := q.add(ctx, deadLetterParkedKey, batch)
+	if err != nil {
+		return "", fmt.Errorf("redis: park dead letter batch: %w", err)
+	}
+	return id, nil
+}
+
+func (q *DeadLetterQueue) add(ctx context.Context, key string, batch domain.DeadLetterBatch) (string, error) {
+	batch.ID = ""
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return "", fmt.Errorf("marshal: %w", err)
+	}
+	return q.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.ns + key,
+		Values: map[string]interface{}{"payload": data},
+	}).Result()
+}
+
+// List returns up to limit batches, oldest first. Entries that do not
+// decode are moved to the parked stream with the decode error, so they
+// neither block the queue nor get lost.
+func (q *DeadLetterQueue) List(ctx context.Context, limit int) ([]domain.DeadLetterBatch, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	msgs, err := q.rdb.XRangeN(ctx, q.ns+deadLetterKey, "-", "+", int64(limit)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis: list dead letter batches: %w", err)
+	}
+	out := make([]domain.DeadLetterBatch, 0, len(msgs))
+	for _, msg := range msgs {
+		batch, err := decodeDeadLetter(msg)
+		if err != nil {
+			if err := q.parkRaw(ctx, msg, err); err != nil {
+				return out, err
+			}
+			continue
+		}
+		out = append(out, batch)
+	}
+	return out, nil
+}
+
+// parkRaw moves an undecodable entry to the parked stream as is.
+func (q *DeadLetterQueue) parkRaw(ctx context.Context, msg redis.XMessage, cause error) error {
+	values := make(map[string]interface{}, len(msg.Values)+1)
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+	values["error"] = cause.Error()
+	_, err := q.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{Stream: q.ns + deadLetterParkedKey, Values: values})
+		pipe.XDel(ctx, q.ns+deadLetterKey, msg.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("redis: park undecodable dead letter %s: %w", msg.ID, err)
+	}
+	return nil
+}
+
+// ListParked returns up to limit parked batches, oldest first. An entry
+// parked because it did not decode is returned with its decode error and
+// no fills.
+func (q *DeadLetterQueue) ListParked(ctx context.Context, limit int) ([]domain.DeadLetterBatch, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	msgs, err := q.rdb.XRangeN(ctx, q.ns+deadLetterParkedKey, "-", "+", int64(limit)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis: list parked dead letter batches: %w", err)
+	}
+	out := make([]domain.DeadLetterBatch, 0, len(msgs))
+	for _, msg := range msgs {
+		batch, err := decodeDeadLetter(msg)
+		if cause, ok := msg.Values["error"].(string); ok {
+			batch = domain.DeadLetterBatch{ID: msg.ID, Error: cause}
+		} else if err != nil {
+			batch = domain.DeadLetterBatch{ID: msg.ID, Error: err.Error()}
+		}
+		out = append(out, batch)
+	}
+	return out, nil
+}
+
+func decodeDeadLetter(msg redis.XMessage) (domain.DeadLetterBatch, error) {
+	var batch domain.DeadLetterBatch
+	payload, ok := msg.Values["payload"].(string)
+	if !ok {
+		return batch, errors.New("undecodable: no payload field")
+	}
+	if err := json.Unmarshal([]byte(payload), &batch); err != nil {
+		return batch, fmt.Errorf("undecodable: %w", err)
+	}
+	batch.ID = msg.ID
+	return batch, nil
+}
+
+// Delete removes a batch.
+func (q *DeadLetterQueue) Delete(ctx context.Context, id string) error {
+	if err := q.rdb.XDel(ctx, q.ns+deadLetterKey, id).Err(); err != nil {
+		return fmt.Errorf("redis: delete dead letter batch %s: %w", id, err)
+	}
+	return nil
+}
+
+// Len returns the number of batches waiting.
+func (q *DeadLetterQueue) Len(ctx context.Context) (int64, error) {
+	n, err := q.rdb.XLen(ctx, q.ns+deadLetterKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis: dead letter queue length: %w", err)
+	}
+	return n, nil
+}
+
+// Compile-time interface check.
+var _ domain.DeadLetterQueue = (*DeadLetterQueue)(nil)
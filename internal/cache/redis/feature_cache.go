@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// FeatureCache implements domain.FeatureCache with one JSON string per asset.
+// Entries expire after ttl so assets no longer tracked drop out.
+type FeatureCache struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+	ttl time.Duration
+}
+
+// NewFeatureCache creates a FeatureCache backed by the given Client. ttl
+// defaults to 5 minutes.
+func NewFeatureCache(c *Client, ttl time.Duration) *FeatureCache {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &FeatureCache{rdb: c.Underlying(), ns: c.prefix, ttl: ttl}
+}
+
+func featureKey(assetID string) string {
+	return "feature:" + assetID
+}
+
+// SetBatch stores the features of several assets in one pipeline.
+func (fc *FeatureCache) SetBatch(ctx context.Context, features []domain.MarketFeatures) error {
+	if len(features) == 0 {
+		return nil
+	}
+	pipe := fc.rdb.Pipeline()
+	for _, f := range features {
+		data, err := json.Marshal(f)
+		if err != nil {
+			return fmt.Errorf("redis: marshal features %s: %w", f.AssetID, err)
+		}
+		pipe.Set(ctx, fc.ns+featureKey(f.AssetID), data, fc.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis: set features: %w", err)
+	}
+	return nil
+}
+
+// Get returns the cached features of an asset, or domain.ErrNotFound.
+func (fc *FeatureCache) Get(ctx context.Context, assetID string) (domain.MarketFeatures, error) {
+	data, err := fc.rdb.Get(ctx, fc.ns+featureKey(assetID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return domain.MarketFeatures{}, domain.ErrNotFound
+		}
+		return domain.MarketFeatures{}, fmt.Errorf("redis: get features %s: %w", assetID, err)
+	}
+	var f domain.MarketFeatures
+	if err := json.Unmarshal(data, &f); err != nil {
+		return domain.MarketFeatures{}, fmt.Errorf("redis: unmarshal features %s: %w", assetID, err)
+	}
+	return f, nil
+}
+
+// Compile-time interface check.
+var _ domain.FeatureCache = (*FeatureCache)(nil)
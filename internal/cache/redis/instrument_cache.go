@@ -0,0 +1,181 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const instrumentTTL = 10 * time.Minute
+
+// InstrumentCache implements domain.InstrumentCache using Redis hashes with
+// JSON-serialized Instrument data and one reverse index entry per identifier.
+// Outcome tokens are additionally indexed under domain.IDKindToken so either
+// token resolves without knowing which side it is.
+//
+// Key schema:
+//
+//	instrument:{id}                 - hash with field "data" containing JSON
+//	instrument:ref:{kind}:{value}   - string value of the instrument ID
+type InstrumentCache struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+}
+
+// NewInstrumentCache creates an InstrumentCache backed by the given Client.
+func NewInstrumentCache(c *Client) *InstrumentCache {
+	return &InstrumentCache{rdb: c.Underlying(), ns: c.prefix}
+}
+
+func instrumentKey(id string) string { return "instrument:" + id }
+func instrumentRefKey(kind domain.IDKind, value string) string {
+	return "instrument:ref:" + string(kind) + ":" + value
+}
+
+// refKeys returns the reverse index keys for every identifier of inst,
+// prefixed with ns.
+func refKeys(ns string, inst domain.Instrument) []string {
+	keys := make([]string, 0, len(inst.Identifiers)+2)
+	for kind, value := range inst.Identifiers {
+		if value == "" {
+			continue
+		}
+		keys = append(keys, ns+instrumentRefKey(kind, value))
+		if kind == domain.IDKindYesToken || kind == domain.IDKindNoToken {
+			keys = append(keys, ns+instrumentRefKey(domain.IDKindToken, value))
+		}
+	}
+	return keys
+}
+
+// Set stores an Instrument and its identifier index with a 10-minute TTL.
+func (c *InstrumentCache) Set(ctx context.Context, inst domain.Instrument) error {
+	data, err := json.Marshal(inst)
+	if err != nil {
+		return fmt.Errorf("redis: marshal instrument %s: %w", inst.ID, err)
+	}
+
+	key := c.ns + instrumentKey(inst.ID)
+
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(ctx, key, "data", data)
+	pipe.Expire(ctx, key, instrumentTTL)
+	for _, ref := range refKeys(c.ns, inst) {
+		pipe.Set(ctx, ref, inst.ID, instrumentTTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis: set instrument %s: %w", inst.ID, err)
+	}
+	return nil
+}
+
+// Get retrieves an Instrument by its ID from the cache.
+// It returns domain.ErrNotFound when the key does not exist.
+func (c *InstrumentCache) Get(ctx context.Context, id string) (domain.Instrument, error) {
+	data, err := c.rdb.HGet(ctx, c.ns+instrumentKey(id), "data").Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return domain.Instrument{}, domain.ErrNotFound
+		}
+		return domain.Instrument{}, fmt.Errorf("redis: get instrument %s: %w", id, err)
+	}
+
+	var inst domain.Instrument
+	if err := json.Unmarshal(data, &inst); err != nil {
+		return domain.Instrument{}, fmt.Errorf("redis: unmarshal instrument %s: %w", id, err)
+	}
+	return inst, nil
+}
+
+// Resolve returns the instrument ID an identifier maps to.
+// It returns domain.ErrNotFound when the identifier is not cached.
+func (c *InstrumentCache) Resolve(ctx context.Context, kind domain.IDKind, value string) (string, error) {
+	id, err := c.rdb.Get(ctx, c.ns+instrumentRefKey(kind, value)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", domain.ErrNotFound
+		}
+		return "", fmt.Errorf("redis: resolve instrument %s=%s: %w", kind, value, err)
+	}
+	return id, nil
+}
+
+// ResolveBatch returns cached instruments for values, keyed by value, using
+// one MGET for the index and one pipeline for the records.
+func (c *InstrumentCache) ResolveBatch(ctx context.Context, kind domain.IDKind, values []string) (map[string]domain.Instrument, error) {
+	out := make(map[string]domain.Instrument, len(values))
+	if len(values) == 0 {
+		return out, nil
+	}
+
+	keys := make([]string, len(values))
+	for i, v := range values {
+		keys[i] = c.ns + instrumentRefKey(kind, v)
+	}
+	ids, err := c.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis: resolve instruments by %s: %w", kind, err)
+	}
+
+	pipe := c.rdb.Pipeline()
+	cmds := make(map[string]*redis.StringCmd, len(values))
+	for i, raw := range ids {
+		id, ok := raw.(string)
+		if !ok || id == "" {
+			continue
+		}
+		cmds[values[i]] = pipe.HGet(ctx, c.ns+instrumentKey(id), "data")
+	}
+	if len(cmds) == 0 {
+		return out, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("redis: resolve instruments by %s: %w", kind, err)
+	}
+
+	for value, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			// Index entry outlived the record; treat as a miss.
+			continue
+		}
+		var inst domain.Instrument
+		if err := json.Unmarshal(data, &inst); err != nil {
+			continue
+		}
+		out[value] = inst
+	}
+	return out, nil
+}
+
+// Invalidate removes an Instrument and its identifier index from the cache.
+func (c *InstrumentCache) Invalidate(ctx context.Context, id string) error {
+	inst, err := c.Get(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("redis: invalidate instrument %s: %w", id, err)
+	}
+
+	pipe := c.rdb.TxPipeline()
+	pipe.Del(ctx, c.ns+instrumentKey(id))
+
+	// Only delete index entries if we successfully read the instrument.
+	if err == nil {
+		for _, ref := range refKeys(c.ns, inst) {
+			pipe.Del(ctx, ref)
+		}
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis: invalidate instrument %s: %w", id, err)
+	}
+	return nil
+}
+
+// Compile-time interface check.
+var _ domain.InstrumentCache = (*InstrumentCache)(nil)
@@ -24,6 +24,7 @@ return 0
 // a Lua-based conditional unlock.
 type LockManager struct {
 	rdb      *redis.Client
+	ns       string // key prefix from Client.Namespace
 	unlockSc *redis.Script
 }
 
@@ -31,6 +32,7 @@ type LockManager struct {
 func NewLockManager(c *Client) *LockManager {
 	return &LockManager{
 		rdb:      c.Underlying(),
+		ns:       c.prefix,
 		unlockSc: redis.NewScript(unlockLua),
 	}
 }
@@ -46,7 +48,7 @@ func lockKey(key string) string {
 // It returns domain.ErrLockHeld if the lock is already held by another party.
 func (lm *LockManager) Acquire(ctx context.Context, key string, ttl time.Duration) (func(), error) {
 	token := uuid.New().String()
-	lk := lockKey(key)
+	lk := lm.ns + lockKey(key)
 
 	ok, err := lm.rdb.SetNX(ctx, lk, token, ttl).Result()
 	if err != nil {
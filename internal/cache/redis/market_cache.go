@@ -22,11 +22,12 @@ const marketTTL = 5 * time.Minute
 //	market:token:{tokenID} - string value of the market ID
 type MarketCache struct {
 	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
 }
 
 // NewMarketCache creates a MarketCache backed by the given Client.
 func NewMarketCache(c *Client) *MarketCache {
-	return &MarketCache{rdb: c.Underlying()}
+	return &MarketCache{rdb: c.Underlying(), ns: c.prefix}
 }
 
 func marketKey(id string) string       { return "market:" + id }
@@ -40,7 +41,7 @@ func (mc *MarketCache) Set(ctx context.Context, market domain.Market) error {
 		return fmt.Errorf("redis: marshal market %s: %w", market.ID, err)
 	}
 
-	key := marketKey(market.ID)
+	key := mc.ns + marketKey(market.ID)
 
 	pipe := mc.rdb.TxPipeline()
 	pipe.HSet(ctx, key, "data", data)
@@ -51,7 +52,7 @@ func (mc *MarketCache) Set(ctx context.Context, market domain.Market) error {
 		if tokenID == "" {
 			continue
 		}
-		tokKey := marketTokenKey(tokenID)
+		tokKey := mc.ns + marketTokenKey(tokenID)
 		pipe.Set(ctx, tokKey, market.ID, marketTTL)
 	}
 
@@ -64,7 +65,7 @@ func (mc *MarketCache) Set(ctx context.Context, market domain.Market) error {
 // Get retrieves a Market by its ID from the cache.
 // It returns domain.ErrNotFound when the key does not exist.
 func (mc *MarketCache) Get(ctx context.Context, id string) (domain.Market, error) {
-	data, err := mc.rdb.HGet(ctx, marketKey(id), "data").Bytes()
+	data, err := mc.rdb.HGet(ctx, mc.ns+marketKey(id), "data").Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return domain.Market{}, domain.ErrNotFound
@@ -82,7 +83,7 @@ func (mc *MarketCache) Get(ctx context.Context, id string) (domain.Market, error
 // GetByToken looks up a Market by one of its ERC-1155 token IDs.
 // It returns domain.ErrNotFound if the token mapping or market does not exist.
 func (mc *MarketCache) GetByToken(ctx context.Context, tokenID string) (domain.Market, error) {
-	marketID, err := mc.rdb.Get(ctx, marketTokenKey(tokenID)).Result()
+	marketID, err := mc.rdb.Get(ctx, mc.ns+marketTokenKey(tokenID)).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return domain.Market{}, domain.ErrNotFound
@@ -103,7 +104,7 @@ func (mc *MarketCache) Invalidate(ctx context.Context, id string) error {
 	}
 
 	pipe := mc.rdb.TxPipeline()
-	pipe.Del(ctx, marketKey(id))
+	pipe.Del(ctx, mc.ns+marketKey(id))
 
 	// Only delete token mappings if we successfully read the market.
 	if err == nil {
@@ -111,7 +112,7 @@ func (mc *MarketCache) Invalidate(ctx context.Context, id string) error {
 			if tokenID == "" {
 				continue
 			}
-			pipe.Del(ctx, marketTokenKey(tokenID))
+			pipe.Del(ctx, mc.ns+marketTokenKey(tokenID))
 		}
 	}
 
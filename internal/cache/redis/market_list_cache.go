@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// MarketListCache implements domain.MarketListCache with both lists stored
+// as one JSON string, so every reader sees the lists of a single write.
+//
+// Key schema:
+//
+//	market_lists - JSON array of domain.MarketListEntry
+type MarketListCache struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+}
+
+// NewMarketListCache creates a MarketListCache backed by the given Client.
+func NewMarketListCache(c *Client) *MarketListCache {
+	return &MarketListCache{rdb: c.Underlying(), ns: c.prefix}
+}
+
+const marketListsKey = "market_lists"
+
+// Set replaces the cached entries of both lists. The key does not expire;
+// Postgres stays the source of truth and rewrites it on every change.
+func (mc *MarketListCache) Set(ctx context.Context, entries []domain.MarketListEntry) error {
+	if entries == nil {
+		entries = []domain.MarketListEntry{}
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("redis: marshal market lists: %w", err)
+	}
+	if err := mc.rdb.Set(ctx, mc.ns+marketListsKey, data, 0).Err(); err != nil {
+		return fmt.Errorf("redis: set market lists: %w", err)
+	}
+	return nil
+}
+
+// Get returns the cached entries, or domain.ErrNotFound when nothing has
+// been cached.
+func (mc *MarketListCache) Get(ctx context.Context) ([]domain.MarketListEntry, error) {
+	data, err := mc.rdb.Get(ctx, mc.ns+marketListsKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("redis: get market lists: %w", err)
+	}
+	var entries []domain.MarketListEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("redis: unmarshal market lists: %w", err)
+	}
+	return entries, nil
+}
+
+// Compile-time interface check.
+var _ domain.MarketListCache = (*MarketListCache)(nil)
@@ -0,0 +1,230 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// Subsystem namespaces under the root key prefix.
+//
+// Key schema (root prefix "polybot"):
+//
+//	polybot:md:price:{asset}            - PriceCache, OrderbookCache
+//	polybot:md:feature:{asset}          - FeatureCache
+//	polybot:md:toxicity:{asset}         - ToxicityCache
+//	polybot:catalog:market:{id}         - MarketCache, ConditionGroupCache, InstrumentCache
+//	polybot:catalog:market_lists        - MarketListCache
+//	polybot:exec:lock:{key}             - LockManager, RateLimiter, TokenBucketLimiter
+//	polybot:opp:claim:{fingerprint}     - OpportunityRegistry
+//	polybot:pipeline:dlq                - DeadLetterQueue
+//	polybot:pipeline:dlq:parked         - DeadLetterQueue (parked batches)
+//	polybot:strategy:{name}:{key}       - per-strategy StateCache
+const (
+	NamespaceMarketData  = "md"
+	NamespaceCatalog     = "catalog"
+	NamespaceExecution   = "exec"
+	NamespaceOpportunity = "opp"
+	NamespacePipeline    = "pipeline"
+	NamespaceStrategy    = "strategy"
+)
+
+// flushBatch is the SCAN page size and UNLINK batch size used by Flush.
+const flushBatch = 500
+
+// Namespace returns a Client sharing c's connection whose caches prefix every
+// key with name. Namespaces nest: c.Namespace("a").Namespace("b") writes
+// "a:b:...". An empty name returns c.
+func (c *Client) Namespace(name string) *Client {
+	name = strings.Trim(name, ":")
+	if name == "" {
+		return c
+	}
+	return &Client{rdb: c.rdb, prefix: c.prefix + name + ":"}
+}
+
+// Prefix returns the key prefix of c, "" for the root keyspace.
+func (c *Client) Prefix() string {
+	return c.prefix
+}
+
+// Flush deletes every key under c's prefix with SCAN and UNLINK, so other
+// namespaces and the rest of the database are untouched. It refuses to run on
+// the root keyspace. It returns the number of keys deleted.
+func (c *Client) Flush(ctx context.Context) (int64, error) {
+	if c.prefix == "" {
+		return 0, errors.New("redis: refusing to flush the root keyspace")
+	}
+	match := escapeGlob(c.prefix) + "*"
+	var (
+		cursor  uint64
+		deleted int64
+	)
+	for {
+		keys, next, err := c.rdb.Scan(ctx, cursor, match, flushBatch).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("redis: flush %s: scan: %w", c.prefix, err)
+		}
+		if len(keys) > 0 {
+			n, err := c.rdb.Unlink(ctx, keys...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("redis: flush %s: unlink: %w", c.prefix, err)
+			}
+			deleted += n
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
+}
+
+// escapeGlob escapes the characters SCAN MATCH treats as patterns.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Keyspace hands out the subsystem and per-strategy namespaces under one root
+// and flushes them by name. Only namespaces holding rebuildable data can be
+// flushed: "exec" (locks, rate-limit windows) and "pipeline" (dead-lettered
+// batches) are excluded.
+type Keyspace struct {
+	root *Client
+
+	mu         sync.Mutex
+	strategies map[string]bool
+}
+
+// NewKeyspace creates a Keyspace rooted at root (typically
+// client.Namespace(cfg.Redis.KeyPrefix)).
+func NewKeyspace(root *Client) *Keyspace {
+	return &Keyspace{root: root, strategies: make(map[string]bool)}
+}
+
+// MarketData returns the namespace for prices and orderbooks.
+func (k *Keyspace) MarketData() *Client { return k.root.Namespace(NamespaceMarketData) }
+
+// Catalog returns the namespace for market, condition group and instrument
+// metadata.
+func (k *Keyspace) Catalog() *Client { return k.root.Namespace(NamespaceCatalog) }
+
+// Execution returns the namespace for locks and rate limits.
+func (k *Keyspace) Execution() *Client { return k.root.Namespace(NamespaceExecution) }
+
+// Opportunity returns the namespace for the cross-detector opportunity registry.
+func (k *Keyspace) Opportunity() *Client { return k.root.Namespace(NamespaceOpportunity) }
+
+// Pipeline returns the namespace for the data pipeline's dead-letter queue.
+func (k *Keyspace) Pipeline() *Client { return k.root.Namespace(NamespacePipeline) }
+
+// Strategy returns the private namespace of the named strategy and makes it
+// flushable as "strategy:{name}".
+func (k *Keyspace) Strategy(name string) *Client {
+	k.mu.Lock()
+	k.strategies[name] = true
+	k.mu.Unlock()
+	return k.root.Namespace(NamespaceStrategy).Namespace(name)
+}
+
+// StateCache returns a StateCache in the named strategy's namespace.
+func (k *Keyspace) StateCache(strategy string) domain.StateCache {
+	return NewStateCache(k.Strategy(strategy))
+}
+
+// Namespaces returns the flushable namespace names, sorted.
+func (k *Keyspace) Namespaces() []string {
+	out := []string{NamespaceMarketData, NamespaceCatalog, NamespaceOpportunity}
+	k.mu.Lock()
+	for name := range k.strategies {
+		out = append(out, NamespaceStrategy+":"+name)
+	}
+	k.mu.Unlock()
+	sort.Strings(out)
+	return out
+}
+
+// FlushNamespace deletes every key in the named namespace (one of
+// Namespaces) and returns how many were deleted. An unknown or protected
+// name returns domain.ErrNotFound.
+func (k *Keyspace) FlushNamespace(ctx context.Context, name string) (int64, error) {
+	var ns *Client
+	switch name {
+	case NamespaceMarketData:
+		ns = k.MarketData()
+	case NamespaceCatalog:
+		ns = k.Catalog()
+	case NamespaceOpportunity:
+		ns = k.Opportunity()
+	default:
+		strategy, ok := strings.CutPrefix(name, NamespaceStrategy+":")
+		k.mu.Lock()
+		known := ok && k.strategies[strategy]
+		k.mu.Unlock()
+		if !known {
+			return 0, fmt.Errorf("redis: namespace %q: %w", name, domain.ErrNotFound)
+		}
+		ns = k.root.Namespace(NamespaceStrategy).Namespace(strategy)
+	}
+	return ns.Flush(ctx)
+}
+
+// StateCache implements domain.StateCache with plain strings under a
+// namespace.
+type StateCache struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+}
+
+// NewStateCache creates a StateCache writing under c's prefix.
+func NewStateCache(c *Client) *StateCache {
+	return &StateCache{rdb: c.Underlying(), ns: c.prefix}
+}
+
+// Get returns the value stored under key, or domain.ErrNotFound.
+func (s *StateCache) Get(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.rdb.Get(ctx, s.ns+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis: get state %s%s: %w", s.ns, key, err)
+	}
+	return val, nil
+}
+
+// Set stores value under key; ttl <= 0 means no expiry.
+func (s *StateCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		ttl = 0
+	}
+	if err := s.rdb.Set(ctx, s.ns+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis: set state %s%s: %w", s.ns, key, err)
+	}
+	return nil
+}
+
+// Delete removes key.
+func (s *StateCache) Delete(ctx context.Context, key string) error {
+	if err := s.rdb.Del(ctx, s.ns+key).Err(); err != nil {
+		return fmt.Errorf("redis: delete state %s%s: %w", s.ns, key, err)
+	}
+	return nil
+}
+
+// Compile-time interface check.
+var _ domain.StateCache = (*StateCache)(nil)
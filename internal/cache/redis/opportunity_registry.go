@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed scripts/opportunity_claim.lua
+var opportunityClaimLua string
+
+//go:embed scripts/opportunity_observe.lua
+var opportunityObserveLua string
+
+// OpportunityRegistry implements domain.OpportunityRegistry with one Redis
+// string per fingerprint and role, expiring after the suppression window.
+//
+// Key schema:
+//
+//	opp:claim:{fingerprint}   - source of the executing strategy holding it
+//	opp:seen:{fingerprint}    - source of the detector that recorded it
+type OpportunityRegistry struct {
+	rdb     *redis.Client
+	ns      string // key prefix from Client.Namespace
+	claim   *redis.Script
+	observe *redis.Script
+}
+
+// NewOpportunityRegistry creates an OpportunityRegistry backed by the given Client.
+func NewOpportunityRegistry(c *Client) *OpportunityRegistry {
+	return &OpportunityRegistry{
+		rdb:     c.Underlying(),
+		ns:      c.prefix,
+		claim:   redis.NewScript(opportunityClaimLua),
+		observe: redis.NewScript(opportunityObserveLua),
+	}
+}
+
+func opportunityClaimKey(fp string) string { return "opp:claim:" + fp }
+func opportunitySeenKey(fp string) string  { return "opp:seen:" + fp }
+
+// Claim reserves fingerprint for source for window.
+func (r *OpportunityRegistry) Claim(ctx context.Context, fingerprint, source string, window time.Duration) (bool, string, error) {
+	holder, err := r.claim.Run(ctx, r.rdb,
+		[]string{r.ns + opportunityClaimKey(fingerprint)},
+		source, window.Milliseconds(),
+	).Text()
+	if err != nil {
+		return false, "", fmt.Errorf("redis: claim opportunity %s: %w", fingerprint, err)
+	}
+	return holder == "", holder, nil
+}
+
+// Observe records a detector sighting of fingerprint for window.
+func (r *OpportunityRegistry) Observe(ctx context.Context, fingerprint, source string, window time.Duration) (bool, string, error) {
+	holder, err := r.observe.Run(ctx, r.rdb,
+		[]string{r.ns + opportunityClaimKey(fingerprint), r.ns + opportunitySeenKey(fingerprint)},
+		source, window.Milliseconds(),
+	).Text()
+	if err != nil {
+		return false, "", fmt.Errorf("redis: observe opportunity %s: %w", fingerprint, err)
+	}
+	return holder == "", holder, nil
+}
+
+// Compile-time interface check.
+var _ domain.OpportunityRegistry = (*OpportunityRegistry)(nil)
@@ -18,9 +18,10 @@ var orderbookUpdateLua string
 // hashes for each asset's orderbook. When ttl > 0, all keys get that TTL so
 // Redis can evict old data (e.g. for 30MB limit).
 type OrderbookCache struct {
-	rdb              *redis.Client
-	orderbookUpdate  *redis.Script
-	ttl              time.Duration
+	rdb             *redis.Client
+	ns              string // key prefix from Client.Namespace
+	orderbookUpdate *redis.Script
+	ttl             time.Duration
 }
 
 // NewOrderbookCache creates an OrderbookCache backed by the given Client.
@@ -28,6 +29,7 @@ type OrderbookCache struct {
 func NewOrderbookCache(c *Client, ttl time.Duration) *OrderbookCache {
 	return &OrderbookCache{
 		rdb:             c.Underlying(),
+		ns:              c.prefix,
 		orderbookUpdate: redis.NewScript(orderbookUpdateLua),
 		ttl:             ttl,
 	}
@@ -40,21 +42,26 @@ func bookAskSizeKey(assetID string) string { return "book:" + assetID + ":ask:si
 func bookBBOKey(assetID string) string     { return "book:" + assetID + ":bbo" }
 func bookMetaKey(assetID string) string    { return "book:" + assetID + ":meta" }
 
+// snapshotReadAttempts is how often GetSnapshot reads a book whose version
+// changes mid-read before returning it marked stale.
+const snapshotReadAttempts = 3
+
 // SetSnapshot atomically replaces the entire orderbook snapshot for an asset.
 // It clears existing data and repopulates all sorted sets, size hashes, the BBO
-// hash, and the metadata hash.
+// hash, and the metadata hash, whose book version it increments.
 func (oc *OrderbookCache) SetSnapshot(ctx context.Context, assetID string, snap domain.OrderbookSnapshot) error {
-	bidsKey := bookBidsKey(assetID)
-	asksKey := bookAsksKey(assetID)
-	bidSizeKey := bookBidSizeKey(assetID)
-	askSizeKey := bookAskSizeKey(assetID)
-	bboKey := bookBBOKey(assetID)
-	metaKey := bookMetaKey(assetID)
+	bidsKey := oc.ns + bookBidsKey(assetID)
+	asksKey := oc.ns + bookAsksKey(assetID)
+	bidSizeKey := oc.ns + bookBidSizeKey(assetID)
+	askSizeKey := oc.ns + bookAskSizeKey(assetID)
+	bboKey := oc.ns + bookBBOKey(assetID)
+	metaKey := oc.ns + bookMetaKey(assetID)
 
 	pipe := oc.rdb.TxPipeline()
 
-	// Clear existing keys.
-	pipe.Del(ctx, bidsKey, asksKey, bidSizeKey, askSizeKey, bboKey, metaKey)
+	// Clear existing keys. The metadata hash is kept so the version carries
+	// on across snapshots.
+	pipe.Del(ctx, bidsKey, asksKey, bidSizeKey, askSizeKey, bboKey)
 
 	// Populate bids.
 	for _, lvl := range snap.Bids {
@@ -82,6 +89,7 @@ func (oc *OrderbookCache) SetSnapshot(ctx context.Context, assetID string, snap
 
 	// Set metadata.
 	pipe.HSet(ctx, metaKey, "ts", strconv.FormatInt(snap.Timestamp.UnixNano(), 10))
+	pipe.HIncrBy(ctx, metaKey, "ver", 1)
 
 	if oc.ttl > 0 {
 		for _, key := range []string{bidsKey, asksKey, bidSizeKey, askSizeKey, bboKey, metaKey} {
@@ -97,16 +105,39 @@ func (oc *OrderbookCache) SetSnapshot(ctx context.Context, assetID string, snap
 
 // GetSnapshot reconstructs a full OrderbookSnapshot from Redis.
 // It returns domain.ErrNotFound if no snapshot data exists for the asset.
+// The book version is read before and after the levels; when an update
+// landed in between, the read is retried, and after snapshotReadAttempts
+// the last read is returned with Stale set.
 func (oc *OrderbookCache) GetSnapshot(ctx context.Context, assetID string) (domain.OrderbookSnapshot, error) {
-	bidsKey := bookBidsKey(assetID)
-	asksKey := bookAsksKey(assetID)
-	bidSizeKey := bookBidSizeKey(assetID)
-	askSizeKey := bookAskSizeKey(assetID)
-	bboKey := bookBBOKey(assetID)
-	metaKey := bookMetaKey(assetID)
+	var snap domain.OrderbookSnapshot
+	for range snapshotReadAttempts {
+		var (
+			consistent bool
+			err        error
+		)
+		snap, consistent, err = oc.readSnapshot(ctx, assetID)
+		if err != nil || consistent {
+			return snap, err
+		}
+	}
+	snap.Stale = true
+	return snap, nil
+}
+
+// readSnapshot reads the book once, reporting whether its version was the
+// same before and after the levels were read.
+func (oc *OrderbookCache) readSnapshot(ctx context.Context, assetID string) (domain.OrderbookSnapshot, bool, error) {
+	bidsKey := oc.ns + bookBidsKey(assetID)
+	asksKey := oc.ns + bookAsksKey(assetID)
+	bidSizeKey := oc.ns + bookBidSizeKey(assetID)
+	askSizeKey := oc.ns + bookAskSizeKey(assetID)
+	bboKey := oc.ns + bookBBOKey(assetID)
+	metaKey := oc.ns + bookMetaKey(assetID)
 
 	pipe := oc.rdb.Pipeline()
 
+	// Version before the levels; the metadata read last carries it after.
+	verCmd := pipe.HGet(ctx, metaKey, "ver")
 	// Read bids sorted descending (highest first).
 	bidsCmd := pipe.ZRevRangeWithScores(ctx, bidsKey, 0, -1)
 	// Read asks sorted ascending (lowest first).
@@ -120,25 +151,28 @@ func (oc *OrderbookCache) GetSnapshot(ctx context.Context, assetID string) (doma
 	metaCmd := pipe.HGetAll(ctx, metaKey)
 
 	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
-		return domain.OrderbookSnapshot{}, fmt.Errorf("redis: get orderbook snapshot %s: %w", assetID, err)
+		return domain.OrderbookSnapshot{}, false, fmt.Errorf("redis: get orderbook snapshot %s: %w", assetID, err)
 	}
 
 	metaVals, _ := metaCmd.Result()
 	if len(metaVals) == 0 {
-		return domain.OrderbookSnapshot{}, domain.ErrNotFound
+		return domain.OrderbookSnapshot{}, false, domain.ErrNotFound
 	}
+	verBefore, _ := verCmd.Result()
+	consistent := verBefore == metaVals["ver"]
 
 	snap := domain.OrderbookSnapshot{
 		AssetID: assetID,
 	}
 
-	// Parse timestamp.
+	// Parse timestamp and version.
 	if tsStr, ok := metaVals["ts"]; ok {
 		tsNano, err := strconv.ParseInt(tsStr, 10, 64)
 		if err == nil {
 			snap.Timestamp = time.Unix(0, tsNano)
 		}
 	}
+	snap.Version, _ = strconv.ParseInt(metaVals["ver"], 10, 64)
 
 	// Build bid levels.
 	bidSizes, _ := bidSizeCmd.Result()
@@ -190,34 +224,36 @@ func (oc *OrderbookCache) GetSnapshot(ctx context.Context, assetID string) (doma
 		snap.MidPrice = (snap.BestBid + snap.BestAsk) / 2
 	}
 
-	return snap, nil
+	return snap, consistent, nil
 }
 
 // UpdateLevel applies an incremental orderbook level update using an atomic Lua
 // script. If size > 0 the level is added/updated; if size == 0 the level is
-// removed. The script recomputes the BBO after the update.
+// removed. The script recomputes the BBO after the update and increments the
+// book version when the asset has a snapshot.
 func (oc *OrderbookCache) UpdateLevel(ctx context.Context, assetID string, side string, price, size float64) error {
 	var zKey, hKey string
 	var sideArg string
 
 	switch side {
 	case "bids", "BUY":
-		zKey = bookBidsKey(assetID)
-		hKey = bookBidSizeKey(assetID)
+		zKey = oc.ns + bookBidsKey(assetID)
+		hKey = oc.ns + bookBidSizeKey(assetID)
 		sideArg = "bids"
 	case "asks", "SELL":
-		zKey = bookAsksKey(assetID)
-		hKey = bookAskSizeKey(assetID)
+		zKey = oc.ns + bookAsksKey(assetID)
+		hKey = oc.ns + bookAskSizeKey(assetID)
 		sideArg = "asks"
 	default:
 		return fmt.Errorf("redis: update level: unknown side %q", side)
 	}
 
-	bboKey := bookBBOKey(assetID)
+	bboKey := oc.ns + bookBBOKey(assetID)
+	metaKey := oc.ns + bookMetaKey(assetID)
 	priceStr := strconv.FormatFloat(price, 'f', -1, 64)
 	sizeStr := strconv.FormatFloat(size, 'f', -1, 64)
 
-	keys := []string{zKey, hKey, bboKey}
+	keys := []string{zKey, hKey, bboKey, metaKey}
 	args := []interface{}{priceStr, sizeStr, sideArg}
 
 	if err := oc.orderbookUpdate.Run(ctx, oc.rdb, keys, args...).Err(); err != nil {
@@ -229,7 +265,7 @@ func (oc *OrderbookCache) UpdateLevel(ctx context.Context, assetID string, side
 // GetBBO retrieves the current best bid and best ask from the BBO hash.
 // It returns domain.ErrNotFound if no BBO data exists.
 func (oc *OrderbookCache) GetBBO(ctx context.Context, assetID string) (bestBid, bestAsk float64, err error) {
-	bboKey := bookBBOKey(assetID)
+	bboKey := oc.ns + bookBBOKey(assetID)
 	vals, err := oc.rdb.HGetAll(ctx, bboKey).Result()
 	if err != nil {
 		return 0, 0, fmt.Errorf("redis: get bbo %s: %w", assetID, err)
@@ -15,13 +15,14 @@ import (
 // When ttl > 0, keys are set to expire so Redis can evict old data (e.g. 30MB limit).
 type PriceCache struct {
 	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
 	ttl time.Duration
 }
 
 // NewPriceCache creates a PriceCache backed by the given Client.
 // ttl is applied to cache keys when > 0 (e.g. 15*time.Minute).
 func NewPriceCache(c *Client, ttl time.Duration) *PriceCache {
-	return &PriceCache{rdb: c.Underlying(), ttl: ttl}
+	return &PriceCache{rdb: c.Underlying(), ns: c.prefix, ttl: ttl}
 }
 
 func priceKey(assetID string) string {
@@ -30,7 +31,7 @@ func priceKey(assetID string) string {
 
 // SetPrice stores the latest price and timestamp for an asset.
 func (pc *PriceCache) SetPrice(ctx context.Context, assetID string, price float64, ts time.Time) error {
-	key := priceKey(assetID)
+	key := pc.ns + priceKey(assetID)
 	fields := map[string]interface{}{
 		"price": strconv.FormatFloat(price, 'f', -1, 64),
 		"ts":    strconv.FormatInt(ts.UnixNano(), 10),
@@ -49,7 +50,7 @@ func (pc *PriceCache) SetPrice(ctx context.Context, assetID string, price float6
 // GetPrice retrieves the latest price and timestamp for an asset.
 // It returns domain.ErrNotFound when the key does not exist.
 func (pc *PriceCache) GetPrice(ctx context.Context, assetID string) (float64, time.Time, error) {
-	key := priceKey(assetID)
+	key := pc.ns + priceKey(assetID)
 	vals, err := pc.rdb.HGetAll(ctx, key).Result()
 	if err != nil {
 		return 0, time.Time{}, fmt.Errorf("redis: get price %s: %w", assetID, err)
@@ -89,7 +90,7 @@ func (pc *PriceCache) GetPrices(ctx context.Context, assetIDs []string) (map[str
 	pipe := pc.rdb.Pipeline()
 	cmds := make(map[string]*redis.MapStringStringCmd, len(assetIDs))
 	for _, id := range assetIDs {
-		cmds[id] = pipe.HGetAll(ctx, priceKey(id))
+		cmds[id] = pipe.HGetAll(ctx, pc.ns+priceKey(id))
 	}
 
 	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
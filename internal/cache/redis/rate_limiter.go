@@ -21,6 +21,7 @@ const waitPollInterval = 50 * time.Millisecond
 // backed by Redis sorted sets and an atomic Lua script.
 type RateLimiter struct {
 	rdb           *redis.Client
+	ns            string // key prefix from Client.Namespace
 	slidingWindow *redis.Script
 }
 
@@ -28,6 +29,7 @@ type RateLimiter struct {
 func NewRateLimiter(c *Client) *RateLimiter {
 	return &RateLimiter{
 		rdb:           c.Underlying(),
+		ns:            c.prefix,
 		slidingWindow: redis.NewScript(slidingWindowLua),
 	}
 }
@@ -46,7 +48,7 @@ func (rl *RateLimiter) Allow(ctx context.Context, key string, limit int, window
 	result, err := rl.slidingWindow.Run(
 		ctx,
 		rl.rdb,
-		[]string{rateLimitKey(key)},
+		[]string{rl.ns + rateLimitKey(key)},
 		now,
 		windowMicro,
 		limit,
@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// ReferencePriceCache implements domain.ReferencePriceCache with one JSON
+// string per asset. Entries expire after ttl so a stalled feed is not served
+// as current.
+type ReferencePriceCache struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+	ttl time.Duration
+}
+
+// NewReferencePriceCache creates a ReferencePriceCache backed by the given
+// Client. ttl defaults to 1 minute.
+func NewReferencePriceCache(c *Client, ttl time.Duration) *ReferencePriceCache {
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+	return &ReferencePriceCache{rdb: c.Underlying(), ns: c.prefix, ttl: ttl}
+}
+
+func referencePriceKey(asset string) string {
+	return "refprice:" + asset
+}
+
+// SetBatch stores the prices of several assets in one pipeline.
+func (rc *ReferencePriceCache) SetBatch(ctx context.Context, prices []domain.ReferencePrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	pipe := rc.rdb.Pipeline()
+	for _, p := range prices {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("redis: marshal reference price %s: %w", p.Asset, err)
+		}
+		pipe.Set(ctx, rc.ns+referencePriceKey(p.Asset), data, rc.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis: set reference prices: %w", err)
+	}
+	return nil
+}
+
+// Get returns the cached price of an asset, or domain.ErrNotFound.
+func (rc *ReferencePriceCache) Get(ctx context.Context, asset string) (domain.ReferencePrice, error) {
+	data, err := rc.rdb.Get(ctx, rc.ns+referencePriceKey(asset)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return domain.ReferencePrice{}, domain.ErrNotFound
+		}
+		return domain.ReferencePrice{}, fmt.Errorf("redis: get reference price %s: %w", asset, err)
+	}
+	var p domain.ReferencePrice
+	if err := json.Unmarshal(data, &p); err != nil {
+		return domain.ReferencePrice{}, fmt.Errorf("redis: unmarshal reference price %s: %w", asset, err)
+	}
+	return p, nil
+}
+
+// Compile-time interface check.
+var _ domain.ReferencePriceCache = (*ReferencePriceCache)(nil)
@@ -0,0 +1,11 @@
+-- Claim an opportunity fingerprint for an executing source
+-- KEYS[1] = claim key
+-- ARGV[1] = source
+-- ARGV[2] = window (milliseconds)
+-- Returns "" when claimed (or re-claimed by the same source), else the holder.
+local holder = redis.call('GET', KEYS[1])
+if holder and holder ~= ARGV[1] then
+    return holder
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return ''
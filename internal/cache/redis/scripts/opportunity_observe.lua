@@ -0,0 +1,16 @@
+-- Record that a non-executing detector saw an opportunity fingerprint
+-- KEYS[1] = claim key
+-- KEYS[2] = seen key
+-- ARGV[1] = source
+-- ARGV[2] = window (milliseconds)
+-- Returns "" when this is the first sighting in the window, else the holder.
+local holder = redis.call('GET', KEYS[1])
+if holder then
+    return holder
+end
+local seen = redis.call('GET', KEYS[2])
+if seen then
+    return seen
+end
+redis.call('SET', KEYS[2], ARGV[1], 'PX', ARGV[2])
+return ''
@@ -2,12 +2,14 @@
 -- KEYS[1] = sorted set key (book:{asset}:bids or asks)
 -- KEYS[2] = size hash key
 -- KEYS[3] = bbo hash key
+-- KEYS[4] = meta hash key (book version in field 'ver')
 -- ARGV[1] = price (string)
 -- ARGV[2] = size (string)
 -- ARGV[3] = side ("bids" or "asks")
 local zkey = KEYS[1]
 local hkey = KEYS[2]
 local bboKey = KEYS[3]
+local metaKey = KEYS[4]
 local price = ARGV[1]
 local size = tonumber(ARGV[2])
 local side = ARGV[3]
@@ -34,4 +36,9 @@ else
         redis.call('HDEL', bboKey, 'ask')
     end
 end
-return 1
+-- Bump the version of books that have a snapshot; returns the new version,
+-- or 0 when there is none.
+if redis.call('EXISTS', metaKey) == 1 then
+    return redis.call('HINCRBY', metaKey, 'ver', 1)
+end
+return 0
@@ -0,0 +1,22 @@
+-- Token bucket as a generic cell rate algorithm (GCRA): the key stores the
+-- theoretical arrival time (TAT) of the next request. Every call reserves a
+-- token and returns how long the caller must wait before using it.
+-- KEYS[1] = bucket key
+-- ARGV[1] = current timestamp (microseconds)
+-- ARGV[2] = emission interval, 1/rate (microseconds)
+-- ARGV[3] = burst size
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+local tat = tonumber(redis.call('GET', key) or now)
+if tat < now then
+    tat = now
+end
+local wait = tat - (burst - 1) * interval - now
+if wait < 0 then
+    wait = 0
+end
+local nextTat = tat + interval
+redis.call('SET', key, string.format('%d', nextTat), 'PX', math.ceil((nextTat - now) / 1000) + 1)
+return wait
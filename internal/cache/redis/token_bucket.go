@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed scripts/token_bucket.lua
+var tokenBucketLua string
+
+// TokenBucketLimiter implements domain.TokenBucket with one Redis key per
+// bucket updated by an atomic Lua script (GCRA), so every process sharing the
+// keyspace draws from the same buckets.
+type TokenBucketLimiter struct {
+	rdb    *redis.Client
+	ns     string // key prefix from Client.Namespace
+	script *redis.Script
+}
+
+// NewTokenBucketLimiter creates a TokenBucketLimiter backed by the given
+// Client.
+func NewTokenBucketLimiter(c *Client) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		rdb:    c.Underlying(),
+		ns:     c.prefix,
+		script: redis.NewScript(tokenBucketLua),
+	}
+}
+
+func tokenBucketKey(key string) string {
+	return "tokens:" + key
+}
+
+// Reserve takes one token from key's bucket and returns how long to wait
+// before using it. rate must be positive; burst below 1 is treated as 1.
+func (tb *TokenBucketLimiter) Reserve(ctx context.Context, key string, rate float64, burst int) (time.Duration, error) {
+	if rate <= 0 {
+		return 0, fmt.Errorf("redis: token bucket %s: rate must be positive", key)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	interval := int64(float64(time.Second/time.Microsecond) / rate)
+	if interval < 1 {
+		interval = 1
+	}
+
+	wait, err := tb.script.Run(
+		ctx,
+		tb.rdb,
+		[]string{tb.ns + tokenBucketKey(key)},
+		time.Now().UnixMicro(),
+		interval,
+		burst,
+	).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("redis: token bucket reserve %s: %w", key, err)
+	}
+	return time.Duration(wait) * time.Microsecond, nil
+}
+
+// Compile-time interface check.
+var _ domain.TokenBucket = (*TokenBucketLimiter)(nil)
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// ToxicityCache implements domain.ToxicityCache with one JSON string per
+// asset. Entries expire after ttl so assets no longer traded drop out.
+type ToxicityCache struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+	ttl time.Duration
+}
+
+// NewToxicityCache creates a ToxicityCache backed by the given Client. ttl
+// defaults to domain.ToxicityMaxAge.
+func NewToxicityCache(c *Client, ttl time.Duration) *ToxicityCache {
+	if ttl <= 0 {
+		ttl = domain.ToxicityMaxAge
+	}
+	return &ToxicityCache{rdb: c.Underlying(), ns: c.prefix, ttl: ttl}
+}
+
+func toxicityKey(assetID string) string {
+	return "toxicity:" + assetID
+}
+
+// SetBatch stores the toxicity of several assets in one pipeline.
+func (tc *ToxicityCache) SetBatch(ctx context.Context, scores []domain.AssetToxicity) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	pipe := tc.rdb.Pipeline()
+	for _, s := range scores {
+		data, err := json.Marshal(s)
+		if err != nil {
+			return fmt.Errorf("redis: marshal toxicity %s: %w", s.AssetID, err)
+		}
+		pipe.Set(ctx, tc.ns+toxicityKey(s.AssetID), data, tc.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis: set toxicity: %w", err)
+	}
+	return nil
+}
+
+// Get returns the cached toxicity of an asset, or domain.ErrNotFound.
+func (tc *ToxicityCache) Get(ctx context.Context, assetID string) (domain.AssetToxicity, error) {
+	data, err := tc.rdb.Get(ctx, tc.ns+toxicityKey(assetID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return domain.AssetToxicity{}, domain.ErrNotFound
+		}
+		return domain.AssetToxicity{}, fmt.Errorf("redis: get toxicity %s: %w", assetID, err)
+	}
+	var s domain.AssetToxicity
+	if err := json.Unmarshal(data, &s); err != nil {
+		return domain.AssetToxicity{}, fmt.Errorf("redis: unmarshal toxicity %s: %w", assetID, err)
+	}
+	return s, nil
+}
+
+// Compile-time interface check.
+var _ domain.ToxicityCache = (*ToxicityCache)(nil)
@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// TradeAnalyticsCache implements domain.TradeAnalyticsCache with one JSON
+// string per market. Entries expire after ttl so markets no longer traded
+// drop out.
+type TradeAnalyticsCache struct {
+	rdb *redis.Client
+	ns  string // key prefix from Client.Namespace
+	ttl time.Duration
+}
+
+// NewTradeAnalyticsCache creates a TradeAnalyticsCache backed by the given
+// Client. ttl defaults to the 24 hour volume window.
+func NewTradeAnalyticsCache(c *Client, ttl time.Duration) *TradeAnalyticsCache {
+	if ttl <= 0 {
+		ttl = domain.TradeAnalyticsVolumeWindow
+	}
+	return &TradeAnalyticsCache{rdb: c.Underlying(), ns: c.prefix, ttl: ttl}
+}
+
+func tradeAnalyticsKey(marketID string) string {
+	return "tradeanalytics:" + marketID
+}
+
+// SetBatch stores the analytics of several markets in one pipeline.
+func (tc *TradeAnalyticsCache) SetBatch(ctx context.Context, analytics []domain.MarketTradeAnalytics) error {
+	if len(analytics) == 0 {
+		return nil
+	}
+	pipe := tc.rdb.Pipeline()
+	for _, a := range analytics {
+		data, err := json.Marshal(a)
+		if err != nil {
+			return fmt.Errorf("redis: marshal trade analytics %s: %w", a.MarketID, err)
+		}
+		pipe.Set(ctx, tc.ns+tradeAnalyticsKey(a.MarketID), data, tc.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis: set trade analytics: %w", err)
+	}
+	return nil
+}
+
+// Get returns the cached analytics of a market, or domain.ErrNotFound.
+func (tc *TradeAnalyticsCache) Get(ctx context.Context, marketID string) (domain.MarketTradeAnalytics, error) {
+	data, err := tc.rdb.Get(ctx, tc.ns+tradeAnalyticsKey(marketID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return domain.MarketTradeAnalytics{}, domain.ErrNotFound
+		}
+		return domain.MarketTradeAnalytics{}, fmt.Errorf("redis: get trade analytics %s: %w", marketID, err)
+	}
+	var a domain.MarketTradeAnalytics
+	if err := json.Unmarshal(data, &a); err != nil {
+		return domain.MarketTradeAnalytics{}, fmt.Errorf("redis: unmarshal trade analytics %s: %w", marketID, err)
+	}
+	return a, nil
+}
+
+// Compile-time interface check.
+var _ domain.TradeAnalyticsCache = (*TradeAnalyticsCache)(nil)
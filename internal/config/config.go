@@ -4,27 +4,58 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
 // Config is the root configuration structure. Fields are populated from a TOML
 // file and then optionally overridden by POLYBOT_* environment variables.
 type Config struct {
-	Wallet     WalletConfig     `toml:"wallet"`
-	Polymarket PolymarketConfig `toml:"polymarket"`
-	Builder    BuilderConfig    `toml:"builder"`
-	Kalshi     KalshiConfig     `toml:"kalshi"`
-	Supabase   SupabaseConfig   `toml:"supabase"`
-	Redis      RedisConfig      `toml:"redis"`
-	S3         S3Config         `toml:"s3"`
-	Strategy   StrategyConfig   `toml:"strategy"`
-	Arbitrage  ArbitrageConfig  `toml:"arbitrage"`
-	Pipeline   PipelineConfig   `toml:"pipeline"`
-	Server     ServerConfig     `toml:"server"`
-	Notify     NotifyConfig     `toml:"notify"`
-	Mode       string           `toml:"mode"`
-	LogLevel   string           `toml:"log_level"`
+	Wallet         WalletConfig         `toml:"wallet"`
+	Polymarket     PolymarketConfig     `toml:"polymarket"`
+	Builder        BuilderConfig        `toml:"builder"`
+	Kalshi         KalshiConfig         `toml:"kalshi"`
+	PredictIt      PredictItConfig      `toml:"predictit"`
+	Manifold       ManifoldConfig       `toml:"manifold"`
+	Supabase       SupabaseConfig       `toml:"supabase"`
+	Redis          RedisConfig          `toml:"redis"`
+	S3             S3Config             `toml:"s3"`
+	Strategy       StrategyConfig       `toml:"strategy"`
+	Arbitrage      ArbitrageConfig      `toml:"arbitrage"`
+	Risk           RiskConfig           `toml:"risk"`
+	Executor       ExecutorConfig       `toml:"executor"`
+	RateLimit      RateLimitConfig      `toml:"ratelimit"`
+	Retry          RetryConfig          `toml:"retry"`
+	Sweep          SweepConfig          `toml:"sweep"`
+	Redemption     RedemptionConfig     `toml:"redemption"`
+	Accounting     AccountingConfig     `toml:"accounting"`
+	Pipeline       PipelineConfig       `toml:"pipeline"`
+	Server         ServerConfig         `toml:"server"`
+	Notify         NotifyConfig         `toml:"notify"`
+	Recorder       RecorderConfig       `toml:"recorder"`
+	BookSnapshots  BookSnapshotsConfig  `toml:"book_snapshots"`
+	Candles        CandlesConfig        `toml:"candles"`
+	Features       FeaturesConfig       `toml:"features"`
+	Toxicity       ToxicityConfig       `toml:"toxicity"`
+	TradeAnalytics TradeAnalyticsConfig `toml:"trade_analytics"`
+	PriceFeed      PriceFeedConfig      `toml:"pricefeed"`
+	Fees           FeesConfig           `toml:"fees"`
+	Increments     IncrementsConfig     `toml:"increments"`
+	Latency        LatencyConfig        `toml:"latency"`
+	Polygon        PolygonConfig        `toml:"polygon"`
+	Signals        SignalsConfig        `toml:"signals"`
+	Hindsight      HindsightConfig      `toml:"hindsight"`
+	Performance    PerformanceConfig    `toml:"performance"`
+	Capital        CapitalConfig        `toml:"capital"`
+	CrossMap       CrossMapConfig       `toml:"crossmap"`
+	MarketLists    MarketListsConfig    `toml:"market_lists"`
+	Backtest       BacktestConfig       `toml:"backtest"`
+	Backfill       BackfillConfig       `toml:"backfill"`
+	Mode           string               `toml:"mode"`
+	LogLevel       string               `toml:"log_level"`
 }
 
 // WalletConfig holds Ethereum wallet credentials.
@@ -36,12 +67,39 @@ type WalletConfig struct {
 }
 
 // PolymarketConfig holds Polymarket API endpoints and chain parameters.
+// UserChannel enables fill tracking over the authenticated user WebSocket.
+// WsSilenceTimeout is how long the market WebSocket may go without a message
+// before the feed is reported degraded and its books treated as stale; 0
+// disables the check. The watchdog escalates from there: WsReconnectAfter of
+// silence drops and redials the connection, an asset silent for
+// WsAssetStaleTimeout on a live connection is resubscribed, and while the
+// feed is not live books are polled over REST every RestPollInterval; 0
+// disables each. WarmupBooks seeds the book and price caches from CLOB
+// REST snapshots of the watched assets before strategies start in trade and
+// full mode, fetching WarmupConcurrency books at a time for at most
+// WarmupTimeout.
 type PolymarketConfig struct {
-	ClobHost      string `toml:"clob_host"`
-	GammaHost     string `toml:"gamma_host"`
-	WsHost        string `toml:"ws_host"`
-	ChainID       int    `toml:"chain_id"`
-	SignatureType int    `toml:"signature_type"`
+	ClobHost         string   `toml:"clob_host"`
+	GammaHost        string   `toml:"gamma_host"`
+	WsHost           string   `toml:"ws_host"`
+	ChainID          int      `toml:"chain_id"`
+	SignatureType    int      `toml:"signature_type"`
+	UserChannel      bool     `toml:"user_channel"`
+	WsSilenceTimeout duration `toml:"ws_silence_timeout"`
+
+	WsReconnectAfter    duration `toml:"ws_reconnect_after"`
+	WsAssetStaleTimeout duration `toml:"ws_asset_stale_timeout"`
+	RestPollInterval    duration `toml:"rest_poll_interval"`
+
+	WarmupBooks       bool     `toml:"warmup_books"`
+	WarmupConcurrency int      `toml:"warmup_concurrency"`
+	WarmupTimeout     duration `toml:"warmup_timeout"`
+
+	// CollateralDecimals is the decimals of the collateral token order
+	// amounts are signed in (6 for USDC.e); MarketCollateralDecimals
+	// overrides it per market condition ID.
+	CollateralDecimals       int            `toml:"collateral_decimals"`
+	MarketCollateralDecimals map[string]int `toml:"market_collateral_decimals"`
 }
 
 // BuilderConfig holds Polymarket builder-program API credentials.
@@ -58,6 +116,21 @@ type KalshiConfig struct {
 	BaseURL           string `toml:"base_url"`
 }
 
+// PredictItConfig enables the read-only PredictIt market data feed used by
+// cross_platform_arb. PredictIt cannot be traded through the bot, so gaps
+// against it are surfaced as manual candidates only.
+type PredictItConfig struct {
+	Enabled bool   `toml:"enabled"`
+	BaseURL string `toml:"base_url"`
+}
+
+// ManifoldConfig enables the read-only Manifold Markets feed used by
+// cross_platform_arb. Like PredictIt, its gaps are manual candidates only.
+type ManifoldConfig struct {
+	Enabled bool   `toml:"enabled"`
+	BaseURL string `toml:"base_url"`
+}
+
 // SupabaseConfig holds PostgreSQL / Supabase connection parameters.
 type SupabaseConfig struct {
 	DSN           string `toml:"dsn"`
@@ -77,6 +150,9 @@ type SupabaseConfig struct {
 // RedisConfig holds Redis connection parameters and limits for small instances
 // (e.g. Redis Cloud 30MB). StreamMaxLen caps Redis stream length; CacheTTLMinutes
 // sets TTL on cache keys (orderbook, price, etc.) so old data is evicted.
+// KeyPrefix is the root namespace for every cache key; subsystems and
+// strategies get their own namespace below it. Instances sharing caches must
+// use the same prefix.
 type RedisConfig struct {
 	Addr             string `toml:"addr"`
 	Password         string `toml:"password"`
@@ -86,17 +162,25 @@ type RedisConfig struct {
 	TLSEnabled       bool   `toml:"tls_enabled"`
 	StreamMaxLen     int    `toml:"stream_max_len"`     // max entries per stream (e.g. 500 for ~30MB)
 	CacheTTLMinutes  int    `toml:"cache_ttl_minutes"`  // TTL for cache keys (orderbook, price, market)
+	KeyPrefix        string `toml:"key_prefix"`         // root key namespace, e.g. "polybot"
 }
 
 // S3Config holds S3-compatible object storage parameters.
+// Format is how raw Goldsky fills and DB archives are written: "jsonl" (fills
+// as daily CSV and backfill JSONL pages, archives as monthly JSONL) or
+// "parquet" (Hive-partitioned Parquet: raw/fills/dt=YYYY-MM-DD/ and
+// archive/<table>/dt=YYYY-MM-DD/). PartitionByMarket adds a market=<id>
+// level under dt= to the Parquet trades, orders and arb_history archives.
 type S3Config struct {
-	Endpoint       string `toml:"endpoint"`
-	Region         string `toml:"region"`
-	Bucket         string `toml:"bucket"`
-	AccessKey      string `toml:"access_key"`
-	SecretKey      string `toml:"secret_key"`
-	UseSSL         bool   `toml:"use_ssl"`
-	ForcePathStyle bool   `toml:"force_path_style"`
+	Endpoint          string `toml:"endpoint"`
+	Region            string `toml:"region"`
+	Bucket            string `toml:"bucket"`
+	AccessKey         string `toml:"access_key"`
+	SecretKey         string `toml:"secret_key"`
+	UseSSL            bool   `toml:"use_ssl"`
+	ForcePathStyle    bool   `toml:"force_path_style"`
+	Format            string `toml:"format"`
+	PartitionByMarket bool   `toml:"partition_by_market"`
 }
 
 // StrategyConfig holds trading strategy parameters.
@@ -105,14 +189,46 @@ type StrategyConfig struct {
 	AutoExecute  bool           `toml:"auto_execute"`
 	Coin         string         `toml:"coin"`
 	Size         float64        `toml:"size"`
-	PriceScale   int            `toml:"price_scale"`
-	SizeScale    int            `toml:"size_scale"`
+	PriceScale   int            `toml:"price_scale"` // must be domain.PriceScale
+	SizeScale    int            `toml:"size_scale"`  // must be domain.SizeScale
 	MaxPositions int            `toml:"max_positions"`
 	TakeProfit   float64        `toml:"take_profit"`
 	StopLoss     float64        `toml:"stop_loss"`
 	Params       map[string]any `toml:"params"`
 	// Active is the list of strategy names to run concurrently (multi-strategy mode). If set, engine uses RunAll.
 	Active []string `toml:"active"`
+	// EventBudgetMs is the per-event wall-clock processing-time budget; slower strategy callbacks are counted and logged. 0 disables.
+	EventBudgetMs int `toml:"event_budget_ms"`
+	// LegOrderType is the order type ("GTC", "FOK" or "FAK") for legs of
+	// multi-leg arbitrage signals; empty keeps GTC. FOK makes each leg fill
+	// completely or not at all.
+	LegOrderType string `toml:"leg_order_type"`
+	// Shadow lists strategies run in shadow mode: their signals bypass the
+	// executor and are recorded as shadow candidates and streamed on
+	// ch:shadow, for comparison with the strategies that trade.
+	Shadow []string `toml:"shadow"`
+	// Sampling thins book events for illiquid markets before they reach strategies.
+	Sampling SamplingConfig `toml:"sampling"`
+	// Breaker disables a strategy whose orders keep failing or losing.
+	Breaker BreakerConfig `toml:"breaker"`
+	// Queue sizes the per-strategy event queues of multi-strategy mode.
+	Queue StrategyQueueConfig `toml:"queue"`
+	// Candidates holds and ranks the signals of manual trading
+	// (AutoExecute false) for GET /api/strategy/candidates.
+	Candidates CandidatesConfig `toml:"candidates"`
+	// Categories restricts strategies to markets by Gamma tag, keyed by
+	// strategy name (one of CategoryFilterStrategies) or the name of an
+	// instance of one.
+	Categories map[string]CategoryFilterConfig `toml:"categories"`
+	// Instances declares additional named instances of strategy types,
+	// keyed by instance name, each with its own params. An instance runs,
+	// and is selected in active and shadow, under its name.
+	Instances map[string]StrategyInstanceConfig `toml:"instances"`
+	// PreferPersisted starts each strategy from the newest saved version of
+	// its effective params (strategy_config_versions in Postgres) instead of
+	// the TOML values. Strategies without a saved version start from TOML
+	// with their persisted overrides, as when it is off.
+	PreferPersisted bool `toml:"prefer_persisted"`
 
 	RebalancingArb    RebalancingArbConfig    `toml:"rebalancing_arb"`
 	Bond              BondStrategyConfig      `toml:"bond"`
@@ -121,6 +237,80 @@ type StrategyConfig struct {
 	YesNoSpread       YesNoSpreadConfig       `toml:"yes_no_spread"`
 	CrossPlatformArb  CrossPlatformArbConfig  `toml:"cross_platform_arb"`
 	TemporalOverlap   TemporalOverlapConfig   `toml:"temporal_overlap"`
+	LatencyArb        LatencyArbConfig        `toml:"latency_arb"`
+}
+
+// CategoryFilterConfig limits a strategy to markets carrying at least one
+// Include tag (any market when empty) and none of the Exclude tags. Tags are
+// Gamma tag slugs such as "politics", "crypto" or "sports".
+type CategoryFilterConfig struct {
+	Include []string `toml:"include"`
+	Exclude []string `toml:"exclude"`
+}
+
+// StrategyInstanceConfig is one [strategy.instances.<name>] entry: a strategy
+// of Type built with the type's params overlaid with Params.
+type StrategyInstanceConfig struct {
+	Type   string         `toml:"type"`
+	Params map[string]any `toml:"params"`
+}
+
+// CategoryFilterStrategies lists the strategies that look markets up in the
+// market store and so can be filtered by category.
+var CategoryFilterStrategies = []string{
+	"bond", "combinatorial_arb", "cross_platform_arb", "latency_arb",
+	"liquidity_provider", "rebalancing_arb", "temporal_overlap", "yes_no_spread",
+}
+
+// SamplingConfig controls per-market event sampling in the engine feeder.
+// Assets whose liquidity score (USD resting within depth_band of the mid) is
+// at least min_liquidity_usd get every event; the rest get one in every_n
+// events (mode "every_n") or at most one per interval (mode "interval").
+// Priority and Sampled are token IDs forced into either class.
+type SamplingConfig struct {
+	Enabled         bool     `toml:"enabled"`
+	Mode            string   `toml:"mode"`
+	EveryN          int      `toml:"every_n"`
+	Interval        duration `toml:"interval"`
+	MinLiquidityUSD float64  `toml:"min_liquidity_usd"`
+	DepthBand       float64  `toml:"depth_band"`
+	ScoreRefresh    duration `toml:"score_refresh"`
+	Priority        []string `toml:"priority"`
+	Sampled         []string `toml:"sampled"`
+}
+
+// BreakerConfig holds the per-strategy circuit breaker settings. A strategy
+// is disabled after max_consecutive_failures orders in a row fail, or when
+// its realized losses within loss_window exceed max_loss_usd. Either limit is
+// off when 0. A tripped strategy stays disabled until re-enabled through
+// POST /api/strategy/{name}/enable.
+type BreakerConfig struct {
+	Enabled                bool     `toml:"enabled"`
+	MaxConsecutiveFailures int      `toml:"max_consecutive_failures"`
+	MaxLossUSD             float64  `toml:"max_loss_usd"`
+	LossWindow             duration `toml:"loss_window"`
+}
+
+// StrategyQueueConfig sizes each strategy's event queues in multi-strategy
+// mode (one per event kind: book, price, trade, market) and picks what
+// happens to an event when a queue is full: "drop_oldest" discards the oldest
+// queued event, "drop_newest" the incoming one, and "block" makes the feed
+// wait up to block_timeout for room before discarding the incoming event.
+type StrategyQueueConfig struct {
+	BufferSize   int      `toml:"buffer_size"`
+	Overflow     string   `toml:"overflow"`
+	BlockTimeout duration `toml:"block_timeout"`
+}
+
+// CandidatesConfig tunes the candidates of manual trading: at most
+// MaxCandidates pending signals are held, each for MaxAge at most (or until
+// it expires), ranked by expected edge (risk.default_edge_bps when a signal
+// has none) times a freshness halving every FreshnessHalfLife times a
+// liquidity factor from the cached book.
+type CandidatesConfig struct {
+	MaxCandidates     int      `toml:"max_candidates"`
+	MaxAge            duration `toml:"max_age"`
+	FreshnessHalfLife duration `toml:"freshness_half_life"`
 }
 
 // RebalancingArbConfig holds config for rebalancing_arb strategy.
@@ -129,8 +319,11 @@ type RebalancingArbConfig struct {
 	MinEdgeBps   int     `toml:"min_edge_bps"`
 	MaxGroupSize int     `toml:"max_group_size"`
 	SizePerLeg   float64 `toml:"size_per_leg"`
-	TTLSeconds   int     `toml:"ttl_seconds"`
-	MaxStaleSec  int     `toml:"max_stale_sec"`
+	// MinSizePerLeg is the smallest depth-sized leg worth emitting; legs
+	// are sized from book depth up to SizePerLeg.
+	MinSizePerLeg float64 `toml:"min_size_per_leg"`
+	TTLSeconds    int     `toml:"ttl_seconds"`
+	MaxStaleSec   int     `toml:"max_stale_sec"`
 }
 
 // BondStrategyConfig holds config for bond strategy.
@@ -143,6 +336,13 @@ type BondStrategyConfig struct {
 	MinDaysToExp    int     `toml:"min_days_to_exp"`
 	MaxPositions    int     `toml:"max_positions"`
 	SizePerPosition float64 `toml:"size_per_position"`
+	// EarlyExit sells a held bond before resolution when its remaining APR
+	// at the bid drops below MinAPR or the bid drops below StopPrice.
+	EarlyExit bool    `toml:"early_exit"`
+	StopPrice float64 `toml:"stop_price"` // 0 disables the stop
+	// MinVolume24h skips markets with less USD traded over the last 24
+	// hours, per trade_analytics. 0 disables.
+	MinVolume24h float64 `toml:"min_volume_24h"`
 }
 
 // LiquidityProviderConfig holds config for liquidity_provider strategy.
@@ -152,8 +352,37 @@ type LiquidityProviderConfig struct {
 	RequoteThreshold float64 `toml:"requote_threshold"`
 	Size             float64 `toml:"size"`
 	MaxMarkets       int     `toml:"max_markets"`
+	TickSize         float64 `toml:"tick_size"`
 	MinVolume        float64 `toml:"min_volume"`
 	RewardsOnly      bool    `toml:"rewards_only"`
+	// MaxInventory is the net shares per market at which the side adding to
+	// the inventory is pulled; it also scales the quote skew. 0 disables both.
+	MaxInventory     float64 `toml:"max_inventory"`
+	InventorySkewBps int     `toml:"inventory_skew_bps"` // mid shift at max_inventory
+	// MaxVolatility is the standard deviation of the mid over the last five
+	// minutes above which both quotes are pulled. 0 disables.
+	MaxVolatility float64 `toml:"max_volatility"`
+	// MinVolume24h is the USD traded over the last 24 hours, per
+	// trade_analytics, a market needs before it is first quoted. 0 disables.
+	MinVolume24h float64 `toml:"min_volume_24h"`
+	// MinRewardUSD is the estimated daily LP reward accrual, from our own
+	// quoting history, below which a market is not quoted. 0 disables.
+	MinRewardUSD float64 `toml:"min_reward_usd"`
+	// RewardSampleInterval is how often resting quotes are sampled for
+	// reward uptime and accrual.
+	RewardSampleInterval duration `toml:"reward_sample_interval"`
+	// Levels is the number of orders quoted per side, LevelSpacingBps apart
+	// outward from the inner quote; each level is SizeDecay times the size of
+	// the one inside it. Every requote replaces the whole ladder.
+	Levels          int     `toml:"levels"`
+	LevelSpacingBps int     `toml:"level_spacing_bps"`
+	SizeDecay       float64 `toml:"size_decay"`
+	// ToxicityWiden is the order flow toxicity (VPIN, per [toxicity]) at
+	// which the half spread is multiplied by ToxicitySpread; ToxicityPause
+	// the one at which both quotes are pulled. 0 disables each.
+	ToxicityWiden  float64 `toml:"toxicity_widen"`
+	ToxicityPause  float64 `toml:"toxicity_pause"`
+	ToxicitySpread float64 `toml:"toxicity_spread"`
 }
 
 // CombinatorialArbConfig holds config for combinatorial_arb strategy.
@@ -166,12 +395,19 @@ type CombinatorialArbConfig struct {
 
 // YesNoSpreadConfig holds config for yes_no_spread strategy.
 type YesNoSpreadConfig struct {
-	Enabled     bool    `toml:"enabled"`
-	MinEdgeBps  int     `toml:"min_edge_bps"`
-	SizePerLeg  float64 `toml:"size_per_leg"`
-	TTLSeconds  int     `toml:"ttl_seconds"`
-	MaxStaleSec int     `toml:"max_stale_sec"`
-	CooldownSec int     `toml:"cooldown_sec"`
+	Enabled       bool    `toml:"enabled"`
+	MinEdgeBps    int     `toml:"min_edge_bps"`
+	SizePerLeg    float64 `toml:"size_per_leg"`
+	MinSizePerLeg float64 `toml:"min_size_per_leg"`
+	TTLSeconds    int     `toml:"ttl_seconds"`
+	MaxStaleSec   int     `toml:"max_stale_sec"`
+	CooldownSec   int     `toml:"cooldown_sec"`
+	// ToxicityWiden is the order flow toxicity (VPIN, per [toxicity]) of
+	// either leg at which min_edge_bps is multiplied by ToxicityEdge;
+	// ToxicityPause the one at which the market is skipped. 0 disables each.
+	ToxicityWiden float64 `toml:"toxicity_widen"`
+	ToxicityPause float64 `toml:"toxicity_pause"`
+	ToxicityEdge  float64 `toml:"toxicity_edge"`
 }
 
 // CrossPlatformArbConfig holds config for cross_platform_arb strategy.
@@ -184,6 +420,10 @@ type CrossPlatformArbConfig struct {
 	MaxStaleSec int               `toml:"max_stale_sec"`
 	CooldownSec int               `toml:"cooldown_sec"`
 	MarketMap   map[string]string `toml:"market_map"`
+	// PredictItMap maps Polymarket market IDs or slugs to PredictIt
+	// "marketID:contractID" refs; ManifoldMap maps them to Manifold slugs.
+	PredictItMap map[string]string `toml:"predictit_map"`
+	ManifoldMap  map[string]string `toml:"manifold_map"`
 }
 
 // TemporalOverlapConfig holds config for temporal_overlap strategy.
@@ -191,17 +431,44 @@ type TemporalOverlapConfig struct {
 	Enabled        bool    `toml:"enabled"`
 	MinEdgeBps     int     `toml:"min_edge_bps"`
 	SizePerLeg     float64 `toml:"size_per_leg"`
+	MinSizePerLeg  float64 `toml:"min_size_per_leg"`
 	TTLSeconds     int     `toml:"ttl_seconds"`
 	MaxStaleSec    int     `toml:"max_stale_sec"`
 	CooldownSec    int     `toml:"cooldown_sec"`
 	RefreshMinutes int     `toml:"refresh_minutes"`
 	MaxPairs       int     `toml:"max_pairs"`
+	// MaxRefMoveBps holds off an asset's pairs while its spot price has moved
+	// more than this over the last minute (needs pricefeed; 0 = off).
+	MaxRefMoveBps int `toml:"max_ref_move_bps"`
+}
+
+// LatencyArbConfig holds config for the latency_arb strategy, which buys the
+// side of a crypto up/down market a spot move favours while the Polymarket
+// book lags behind it. Needs pricefeed.enabled.
+type LatencyArbConfig struct {
+	Enabled bool `toml:"enabled"`
+	// MinMoveBps is the spot move over LookbackSec that counts as a move;
+	// MaxLagMove is the most the market's up-probability may have followed
+	// it (in probability points) for the book to count as lagging.
+	MinMoveBps     int     `toml:"min_move_bps"`
+	LookbackSec    int     `toml:"lookback_sec"`
+	MaxLagMove     float64 `toml:"max_lag_move"`
+	MaxPrice       float64 `toml:"max_price"`
+	Size           float64 `toml:"size"`
+	TTLSeconds     int     `toml:"ttl_seconds"`
+	MaxStaleSec    int     `toml:"max_stale_sec"`
+	MaxRefAgeSec   int     `toml:"max_ref_age_sec"`
+	CooldownSec    int     `toml:"cooldown_sec"`
+	RefreshMinutes int     `toml:"refresh_minutes"`
+	MaxMarkets     int     `toml:"max_markets"`
 }
 
 // ArbitrageConfig holds arbitrage parameters and selectable strategy.
 type ArbitrageConfig struct {
 	// Strategy selects which arbitrage strategy to run: "spread", "imbalance", "yes_no_spread".
+	// Strategies, when set, runs several of them in one detector instead.
 	Strategy            string             `toml:"strategy"`
+	Strategies          []string           `toml:"strategies"`
 	Enabled             bool               `toml:"enabled"`
 	MinNetEdgeBps       float64            `toml:"min_net_edge_bps"`
 	MaxTradeAmount      float64            `toml:"max_trade_amount"`
@@ -209,6 +476,7 @@ type ArbitrageConfig struct {
 	MinDurationMs       int64              `toml:"min_duration_ms"`
 	MaxLegGapMs         int64              `toml:"max_leg_gap_ms"`
 	MaxUnhedgedNotional float64            `toml:"max_unhedged_notional"`
+	HedgeBudgetUSD      float64            `toml:"hedge_budget_usd"`
 	MaxSlippageBps      float64            `toml:"max_slippage_bps"`
 	KillSwitchLossUSD   float64            `toml:"kill_switch_loss_usd"`
 	PerVenueFeeBps      map[string]float64 `toml:"per_venue_fee_bps"`
@@ -216,11 +484,258 @@ type ArbitrageConfig struct {
 	MinSpreadBps float64 `toml:"min_spread_bps"`
 	// ImbalanceRatioThreshold: bid_vol/ask_vol or ask_vol/bid_vol must exceed this for imbalance strategy.
 	ImbalanceRatioThreshold float64 `toml:"imbalance_ratio_threshold"`
+	// OpportunityDedupWindow suppresses an opportunity already recorded by
+	// the detector or executed by a strategy for this long, across both
+	// paths (shared through Redis). 0 disables.
+	OpportunityDedupWindow duration `toml:"opportunity_dedup_window"`
+	// OpportunityTTL expires a detected opportunity that stays open (not
+	// executed, edge not seen gone) without being detected again for this
+	// long. 0 disables.
+	OpportunityTTL duration `toml:"opportunity_ttl"`
+	// LegTopUpAttempts is how many times a leg of a fully placed leg group
+	// that rests partially filled is cancelled and re-quoted at the top of
+	// book (within MaxSlippageBps), checking every LegTopUpAfter. 0 disables.
+	// Needs the CLOB user channel for fills.
+	LegTopUpAttempts int      `toml:"leg_topup_attempts"`
+	LegTopUpAfter    duration `toml:"leg_topup_after"`
+	// SweepImmediate sends immediate-urgency signals as FAK orders that take
+	// every level up to SweepEdgeFraction of the signal's expected edge (at
+	// most MaxSlippageBps) past its price, instead of resting a GTC limit.
+	SweepImmediate    bool    `toml:"sweep_immediate"`
+	SweepEdgeFraction float64 `toml:"sweep_edge_fraction"`
+	// UnhedgedCheckInterval is how often recorded arb executions that ended
+	// partial are netted per asset and checked against MaxUnhedgedNotional,
+	// paging through notify on a breach; 0 disables. Executions started
+	// more than UnhedgedLookback ago are ignored. UnhedgedCorrective also
+	// sends FAK signals closing the largest exposures.
+	UnhedgedCheckInterval duration `toml:"unhedged_check_interval"`
+	UnhedgedLookback      duration `toml:"unhedged_lookback"`
+	UnhedgedCorrective    bool     `toml:"unhedged_corrective"`
+	// UnwindAllOrNone rolls back all_or_none leg groups that stop at a
+	// failed leg: earlier legs are cancelled if resting and closed at the top
+	// of book if filled, and the execution is recorded as "unwound".
+	UnwindAllOrNone bool `toml:"unwind_all_or_none"`
+	// Imbalance tunes the imbalance strategy.
+	Imbalance ImbalanceArbConfig `toml:"imbalance"`
+}
+
+// ImbalanceArbConfig tunes the imbalance arbitrage strategy. Bid and ask
+// notional is summed over the best Levels levels of each side (0 = all), each
+// level weighted LevelDecay times the one above it, and smoothed per asset
+// with HalfLife (0 = each update alone). TradeFlow requires the net taker
+// notional of recent trade prints, decayed with FlowHalfLife, to lean the
+// book's way by at least MinFlowUSD; the prints come from the "trades"
+// channel, published by a trade or full mode process with candles or
+// features enabled. MinTotalVolume is the least weighted notional considered
+// and EdgeBpsPerRatio the gross edge per unit of ratio above 1.
+type ImbalanceArbConfig struct {
+	Levels          int      `toml:"levels"`
+	LevelDecay      float64  `toml:"level_decay"`
+	HalfLife        duration `toml:"half_life"`
+	TradeFlow       bool     `toml:"trade_flow"`
+	FlowHalfLife    duration `toml:"flow_half_life"`
+	MinFlowUSD      float64  `toml:"min_flow_usd"`
+	MinTotalVolume  float64  `toml:"min_total_volume"`
+	EdgeBpsPerRatio float64  `toml:"edge_bps_per_ratio"`
+}
+
+// RiskConfig holds global risk-layer settings applied by the executor to every
+// strategy's signals.
+// CloseHaircutHorizon: entry (BUY) sizes shrink linearly over this window
+// before a market's end date, down to CloseHaircutMinFactor at the end; 0
+// disables the haircut. CloseHaircutMultipliers scales the cut per strategy
+// (0 exempts, 1 default, 2 doubles).
+//
+// DailyLossLimitUSD trips the portfolio kill switch (cancel all orders, halt
+// the executor) when today's realized + unrealized PnL falls to -limit; 0
+// disables it. PortfolioCheckInterval is how often the portfolio is re-marked.
+// MetadataPollInterval is how often markets with open positions are re-fetched
+// from Gamma to catch end-date moves and pauses between catalog scrapes; 0
+// disables the fast poll. ResolutionPollInterval is how often they are
+// checked for resolution, closing positions in resolved markets at the
+// payout; 0 disables it.
+//
+// MinSizePolicy ("off", "reject", "floor") handles entries whose expected
+// edge cannot cover the Polymarket taker fee (arbitrage.per_venue_fee_bps)
+// plus RedeemGasUSD; DefaultEdgeBps is the edge assumed for signals without
+// edge_bps metadata.
+//
+// CancelAllOnShutdown makes the executor cancel every open order of the
+// wallet when the bot stops, waiting at most ShutdownCancelTimeout.
+//
+// StaleOrderInterval is how often resting orders are checked against their
+// TTL and cancelled once past it; 0 disables the order janitor. An order's
+// TTL is OrderTTLSeconds of its strategy when listed (0 exempts the
+// strategy), otherwise the expiry of the signal that placed it.
+//
+// CancelRatioLimit is the venue's cancels-per-fill limit over a rolling hour,
+// per market; 0 disables cancel-ratio tracking. Once a market's ratio reaches
+// CancelRatioWarnAt of the limit with at least CancelRatioMinCancels cancels,
+// an alert is raised and the liquidity provider requotes less eagerly.
+//
+// Slippage sets the slippage model PreTradeCheck and breakeven sizing use,
+// with per-strategy overrides; see RiskSlippageConfig.
+type RiskConfig struct {
+	CloseHaircutHorizon     duration           `toml:"close_haircut_horizon"`
+	CloseHaircutMinFactor   float64            `toml:"close_haircut_min_factor"`
+	CloseHaircutMultipliers map[string]float64 `toml:"close_haircut_multipliers"`
+	DailyLossLimitUSD       float64            `toml:"daily_loss_limit_usd"`
+	PortfolioCheckInterval  duration           `toml:"portfolio_check_interval"`
+	MetadataPollInterval    duration           `toml:"metadata_poll_interval"`
+	ResolutionPollInterval  duration           `toml:"resolution_poll_interval"`
+	MinSizePolicy           string             `toml:"min_size_policy"`
+	RedeemGasUSD            float64            `toml:"redeem_gas_usd"`
+	DefaultEdgeBps          float64            `toml:"default_edge_bps"`
+	CancelRatioLimit        float64            `toml:"cancel_ratio_limit"`
+	CancelRatioWarnAt       float64            `toml:"cancel_ratio_warn_at"`
+	CancelRatioMinCancels   int                `toml:"cancel_ratio_min_cancels"`
+	CancelAllOnShutdown     bool               `toml:"cancel_all_on_shutdown"`
+	ShutdownCancelTimeout   duration           `toml:"shutdown_cancel_timeout"`
+	StaleOrderInterval      duration           `toml:"stale_order_interval"`
+	OrderTTLSeconds         map[string]int     `toml:"order_ttl_seconds"`
+	Slippage                RiskSlippageConfig `toml:"slippage"`
+}
+
+// RiskSlippageConfig is the default slippage model and its per-strategy
+// overrides, keyed by strategy instance name or type. PreTradeCheck rejects
+// orders whose slippage, the larger of the quoted one (limit price against
+// the last price) and the model's estimate, plus fees exceeds MaxBps, and
+// breakeven sizing subtracts the estimate from the expected edge.
+//
+// Models: "static" estimates Bps for every fill; "depth" walks the order's
+// size through the cached book up to its limit price and measures the VWAP
+// against the best level; "volatility" estimates Bps plus VolMultiplier times
+// the asset's realized volatility (features.enabled) in bps. Depth and
+// volatility fall back to Bps without a book or features.
+//
+// MaxBps 0 uses arbitrage.max_slippage_bps. A strategy entry without a model
+// takes the default's model, bps and vol_multiplier; one without max_bps
+// takes the default's.
+type RiskSlippageConfig struct {
+	SlippageModelConfig
+	Strategies map[string]SlippageModelConfig `toml:"strategies"`
+}
+
+// SlippageModelConfig is one slippage model and its limit.
+type SlippageModelConfig struct {
+	Model         string  `toml:"model"`
+	MaxBps        float64 `toml:"max_bps"`
+	Bps           float64 `toml:"bps"`
+	VolMultiplier float64 `toml:"vol_multiplier"`
+}
+
+// SlippageModels lists the models risk.slippage accepts.
+var SlippageModels = []string{"static", "depth", "volatility"}
+
+// ExecutorConfig controls how many signals the executor processes at once.
+// Signals are spread over Workers by token, so signals on different tokens
+// are placed concurrently while those on the same token keep their order;
+// each worker queues up to QueueDepth signals. Workers 1 processes every
+// signal in turn. Queue depths are exported at GET /metrics.
+type ExecutorConfig struct {
+	Workers    int `toml:"workers"`
+	QueueDepth int `toml:"queue_depth"`
+}
+
+// RateLimitConfig throttles outbound REST calls with Redis token buckets
+// shared by every instance on the same redis.key_prefix. Venues holds one
+// bucket per API, keyed by RateLimitVenues; Endpoints add a tighter bucket for
+// matching requests on top of their venue's. OrdersPerSecond caps order
+// submissions per wallet in the order service.
+type RateLimitConfig struct {
+	Enabled         bool                      `toml:"enabled"`
+	Venues          map[string]RateLimitRule  `toml:"venues"`
+	Endpoints       []EndpointRateLimitConfig `toml:"endpoints"`
+	OrdersPerSecond int                       `toml:"orders_per_second"`
+}
+
+// RateLimitRule is a token bucket: Rate requests per second with bursts of up
+// to Burst. Burst 0 means Rate rounded up.
+type RateLimitRule struct {
+	Rate  float64 `toml:"rate"`
+	Burst int     `toml:"burst"`
+}
+
+// EndpointRateLimitConfig limits requests to one venue whose URL path starts
+// with Path (the full request path, including any API base path) and, when
+// Method is set, whose HTTP method matches.
+type EndpointRateLimitConfig struct {
+	Venue  string  `toml:"venue"`
+	Name   string  `toml:"name"`
+	Method string  `toml:"method"`
+	Path   string  `toml:"path"`
+	Rate   float64 `toml:"rate"`
+	Burst  int     `toml:"burst"`
+}
+
+// Venue keys of RateLimitConfig.Venues.
+const (
+	RateLimitVenueClob   = "polymarket.clob"
+	RateLimitVenueGamma  = "polymarket.gamma"
+	RateLimitVenueKalshi = "kalshi"
+)
+
+// RateLimitVenues lists the APIs that can be throttled.
+var RateLimitVenues = []string{RateLimitVenueClob, RateLimitVenueGamma, RateLimitVenueKalshi}
+
+// RetryConfig is the retry policy for venue and Goldsky calls that fail with
+// a transient or rate-limited error. Only reads and cancels are retried;
+// order placement never is. Each retry waits base_delay doubled per attempt,
+// times rate_limit_factor after a rate limit, capped at max_delay, less up to
+// jitter of itself at random.
+type RetryConfig struct {
+	Enabled         bool     `toml:"enabled"`
+	MaxAttempts     int      `toml:"max_attempts"` // including the first
+	BaseDelay       duration `toml:"base_delay"`
+	MaxDelay        duration `toml:"max_delay"` // 0 = uncapped
+	RateLimitFactor float64  `toml:"rate_limit_factor"`
+	Jitter          float64  `toml:"jitter"` // fraction in [0, 1]
+}
+
+// SweepConfig controls the optional profit sweep to cold storage. When
+// realized PnL since the last sweep reaches PnLThreshold, USDC above
+// WorkingCapital is transferred from the hot wallet to ColdAddress.
+type SweepConfig struct {
+	Enabled        bool     `toml:"enabled"`
+	RPCURL         string   `toml:"rpc_url"`
+	ColdAddress    string   `toml:"cold_address"`
+	USDCAddress    string   `toml:"usdc_address"`
+	PnLThreshold   float64  `toml:"pnl_threshold"`
+	WorkingCapital float64  `toml:"working_capital"`
+	MinAmount      float64  `toml:"min_amount"`
+	CheckInterval  duration `toml:"check_interval"`
+	Confirmations  int      `toml:"confirmations"`
+	ConfirmTimeout duration `toml:"confirm_timeout"`
+}
+
+// RedemptionConfig controls the optional on-chain redemption of resolved
+// positions. When enabled, every CheckInterval the wallet's outcome tokens in
+// markets whose condition has resolved are redeemed for USDC.e through the
+// CTF (or the NegRiskAdapter) when they pay at least MinProceeds, and the
+// positions still open there are closed at the payout. Markets of positions
+// opened within Lookback are checked. It uses polygon.rpc_url and signs with
+// wallet.private_key.
+type RedemptionConfig struct {
+	Enabled        bool     `toml:"enabled"`
+	CheckInterval  duration `toml:"check_interval"`
+	Lookback       duration `toml:"lookback"`
+	MinProceeds    float64  `toml:"min_proceeds"`
+	Confirmations  int      `toml:"confirmations"`
+	ConfirmTimeout duration `toml:"confirm_timeout"`
+}
+
+// AccountingConfig controls lot-level PnL accounting for exports.
+// LotMethod is "fifo" or "lifo".
+type AccountingConfig struct {
+	LotMethod string `toml:"lot_method"`
 }
 
 // PipelineConfig holds data-pipeline / scraping parameters.
 // ArchiveRetentionDays: keep only this many days in DB before archiving to S3 (then purged).
 // S3ArchiveRetentionMonths: delete S3 archive files older than this to cap storage (e.g. 10GB).
+// GoldskyActivityURL: the Goldsky activity subgraph, scraped every ScrapeInterval for CTF
+// splits, merges and redemptions of OnchainWallets (the wallet's address when empty) into
+// onchain_events; empty skips them. OnchainLookback: how far back the first scrape starts.
 type PipelineConfig struct {
 	Enabled                  bool     `toml:"enabled"`
 	GoldskyURL               string   `toml:"goldsky_url"`
@@ -229,6 +744,23 @@ type PipelineConfig struct {
 	ArchiveRetentionDays     int      `toml:"archive_retention_days"`
 	ArchiveCron              string   `toml:"archive_cron"`
 	S3ArchiveRetentionMonths int      `toml:"s3_archive_retention_months"`
+	GoldskyActivityURL       string   `toml:"goldsky_activity_url"`
+	OnchainWallets           []string `toml:"onchain_wallets"`
+	OnchainLookback          duration `toml:"onchain_lookback"`
+	// MarketPageSize is how many markets the market scraper requests per
+	// Gamma page. Runs page through every market once per
+	// MarketFullSyncInterval; runs in between only fetch markets updated
+	// since the previous run. 0 makes every run full.
+	MarketPageSize         int      `toml:"market_page_size"`
+	MarketFullSyncInterval duration `toml:"market_full_sync_interval"`
+	// Fill batches the trade processor fails on are dead-lettered to Redis
+	// and the scrape moves on. Every DLQRetryInterval up to DLQRetryBatches
+	// of them are replayed (also on POST /api/pipeline/dlq/retry); 0
+	// disables the automatic replay. A batch that has failed DLQMaxAttempts
+	// times is parked instead of requeued; 0 never parks.
+	DLQRetryInterval duration `toml:"dlq_retry_interval"`
+	DLQRetryBatches  int      `toml:"dlq_retry_batches"`
+	DLQMaxAttempts   int      `toml:"dlq_max_attempts"`
 }
 
 // duration is a wrapper around time.Duration that supports TOML string decoding
@@ -251,18 +783,306 @@ func (d duration) MarshalText() ([]byte, error) {
 }
 
 // ServerConfig holds HTTP server parameters.
+//
+// WSToken, when set, is required to open /ws and grants every channel.
+// WSACL maps additional /ws tokens to the channel patterns they may receive
+// (e.g. "ch:book:*"). CORSOrigins also restricts browser origins on /ws.
+// AdminToken guards admin-only endpoints such as GET /api/config; they are
+// refused while it is empty. Auth, when enabled, replaces it with per-role
+// API keys and JWTs on every route.
 type ServerConfig struct {
-	Enabled     bool     `toml:"enabled"`
-	Port        int      `toml:"port"`
-	CORSOrigins []string `toml:"cors_origins"`
+	Enabled     bool                `toml:"enabled"`
+	Port        int                 `toml:"port"`
+	CORSOrigins []string            `toml:"cors_origins"`
+	WSToken     string              `toml:"ws_token"`
+	WSACL       map[string][]string `toml:"ws_acl"`
+	AdminToken  string              `toml:"admin_token"`
+
+	// WSFlushInterval batches /ws output per client: messages within the
+	// interval go out as one frame, latest book update per asset only.
+	// 0 writes one frame per message.
+	WSFlushInterval duration `toml:"ws_flush_interval"`
+	WSMaxBatch      int      `toml:"ws_max_batch"` // messages per batch frame
+
+	Auth ServerAuthConfig `toml:"auth"`
+}
+
+// ServerAuthConfig enables role-based authentication of the HTTP API.
+//
+// Callers present an API key from Keys or an HS256 JWT signed with
+// JWTSecret (claims "sub", "role", "exp"), as a Bearer token or in
+// X-API-Key. Roles rank read_only < trader < admin: reads need read_only,
+// mutating calls trader, and admin endpoints admin. Routes overrides the
+// role of a route pattern (e.g. "POST /api/risk/reset" = "admin"), with
+// "public" for no authentication. AdminToken, when set, is accepted as an
+// admin key.
+type ServerAuthConfig struct {
+	Enabled   bool              `toml:"enabled"`
+	JWTSecret string            `toml:"jwt_secret"`
+	Keys      []APIKeyConfig    `toml:"keys"`
+	Routes    map[string]string `toml:"routes"`
+}
+
+// APIKeyConfig is one named API key and the role it grants.
+type APIKeyConfig struct {
+	Name string `toml:"name"`
+	Key  string `toml:"key"`
+	Role string `toml:"role"` // read_only, trader or admin
 }
 
-// NotifyConfig holds notification channel credentials.
+// NotifyConfig holds notification channel credentials and controls which bus
+// events are forwarded to them.
 type NotifyConfig struct {
 	TelegramToken     string   `toml:"telegram_token"`
 	TelegramChatID    string   `toml:"telegram_chat_id"`
 	DiscordWebhookURL string   `toml:"discord_webhook_url"`
 	Events            []string `toml:"events"`
+	Channels          []string `toml:"channels"`        // signal bus channels to watch
+	RatePerMinute     int      `toml:"rate_per_minute"` // max notifications per minute; 0 = unlimited
+	MaxRetries        int      `toml:"max_retries"`     // retries per sender on failure
+	RetryBackoff      duration `toml:"retry_backoff"`   // first retry delay; doubles per attempt
+}
+
+// RecorderConfig controls the orderbook event recorder used for book replay.
+// Events older than Retention are deleted hourly; 0 keeps them forever.
+type RecorderConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	FlushInterval duration `toml:"flush_interval"`
+	BatchSize     int      `toml:"batch_size"`
+	Retention     duration `toml:"retention"`
+}
+
+// BookSnapshotsConfig controls the orderbook snapshot recorder, which samples
+// full books into gzipped JSONL files on S3 under books/dt=YYYY-MM-DD/asset=ID/
+// as a research dataset and the book source of backtest.book_source = "s3".
+// Assets defaults to the watched assets. Books are sampled every Interval, or
+// with Interval 0 on every update, at most once per asset per MinInterval.
+// Files are uploaded every FlushInterval or once BatchSize snapshots are
+// buffered. It runs in trade and full mode.
+type BookSnapshotsConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	Assets        []string `toml:"assets"`
+	Interval      duration `toml:"interval"`
+	MinInterval   duration `toml:"min_interval"`
+	FlushInterval duration `toml:"flush_interval"`
+	BatchSize     int      `toml:"batch_size"`
+}
+
+// CandlesConfig controls recording of one-minute OHLCV price candles, served
+// at GET /api/markets/{id}/candles. Retention 0 keeps candles forever.
+type CandlesConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	FlushInterval duration `toml:"flush_interval"`
+	Retention     duration `toml:"retention"`
+}
+
+// FeaturesConfig controls the order-flow features (microprice, depth and
+// trade flow imbalance, realized volatility) computed per asset from the
+// market feed, cached in Redis and read by flash_crash and mean_reversion.
+// DepthLevels is the number of book levels per side in the depth imbalance;
+// Window is the lookback of trade flow and realized volatility.
+type FeaturesConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	DepthLevels   int      `toml:"depth_levels"`
+	Window        duration `toml:"window"`
+	FlushInterval duration `toml:"flush_interval"`
+}
+
+// ToxicityConfig controls the per-asset order flow toxicity (VPIN) computed
+// from the market feed's trade prints in buckets of BucketVolume shares,
+// averaged over the last Buckets and acted on once MinBuckets completed.
+// Cached in Redis, read by liquidity_provider and yes_no_spread
+// (toxicity_widen / toxicity_pause) and served at
+// GET /api/markets/{id}/toxicity.
+type ToxicityConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	BucketVolume  float64  `toml:"bucket_volume"`
+	Buckets       int      `toml:"buckets"`
+	MinBuckets    int      `toml:"min_buckets"`
+	FlushInterval duration `toml:"flush_interval"`
+}
+
+// TradeAnalyticsConfig controls the per-market trade analytics computed from
+// ingested trades: VWAP over VWAPWindow, and 24h volume and volume by price
+// in buckets of BucketWidth. Cached in Redis, read by bond and
+// liquidity_provider (min_volume_24h) and served at
+// GET /api/markets/{id}/analytics.
+type TradeAnalyticsConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	VWAPWindow    duration `toml:"vwap_window"`
+	BucketWidth   float64  `toml:"bucket_width"`
+	FlushInterval duration `toml:"flush_interval"`
+}
+
+// PriceFeedConfig controls the external spot price feed: public exchange
+// tickers for Assets, polled every PollInterval from Sources in order
+// ("binance", "coinbase"; a later source fills assets an earlier one could
+// not quote), cached in Redis and read by temporal_overlap and latency_arb.
+// History is how far back spot moves can be measured.
+type PriceFeedConfig struct {
+	Enabled      bool     `toml:"enabled"`
+	Sources      []string `toml:"sources"`
+	Assets       []string `toml:"assets"`
+	PollInterval duration `toml:"poll_interval"`
+	History      duration `toml:"history"`
+	BinanceURL   string   `toml:"binance_url"`
+	CoinbaseURL  string   `toml:"coinbase_url"`
+}
+
+// PriceFeedSources lists the ticker APIs pricefeed.sources accepts.
+var PriceFeedSources = []string{"binance", "coinbase"}
+
+// FeesConfig controls the per-market fee model: maker and taker fees fetched
+// from Gamma for each market, cached for RefreshInterval, net of the builder
+// program's BuilderRebateBps. Arbitrage edges and the risk slippage check use
+// the effective fees; markets whose schedule cannot be fetched fall back to
+// arbitrage.per_venue_fee_bps["polymarket"] as the taker fee.
+type FeesConfig struct {
+	Enabled          bool     `toml:"enabled"`
+	RefreshInterval  duration `toml:"refresh_interval"`
+	BuilderRebateBps float64  `toml:"builder_rebate_bps"`
+}
+
+// IncrementsConfig controls rounding orders onto each token's venue
+// increments before they are signed: prices to the tick size and sizes to
+// SizeStep shares, both read from the CLOB book and cached for
+// RefreshInterval, and orders below the minimum order size are refused.
+// DefaultTickSize and DefaultMinSize apply when the CLOB cannot be reached.
+type IncrementsConfig struct {
+	Enabled         bool     `toml:"enabled"`
+	RefreshInterval duration `toml:"refresh_interval"`
+	SizeStep        float64  `toml:"size_step"`
+	DefaultTickSize float64  `toml:"default_tick_size"`
+	DefaultMinSize  float64  `toml:"default_min_size"`
+}
+
+// LatencyConfig controls latency tracking from signal emission to fill:
+// queueing in the executor, risk checks, signing, the CLOB round trip and
+// taker fill confirmation. Quantiles are computed over the last Window
+// samples per stage and served at GET /api/latency and GET /metrics; with
+// Postgres, each order's breakdown is persisted every FlushInterval.
+type LatencyConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	Window        int      `toml:"window"`
+	FlushInterval duration `toml:"flush_interval"`
+}
+
+// PolygonConfig points at a Polygon PoS JSON-RPC endpoint for reading the
+// trading wallet's on-chain state. With RPCURL set and BalanceCheck on,
+// orders sent to the CLOB are refused when the wallet lacks the USDC.e, the
+// outcome tokens or the exchange allowances to settle them; readings are
+// reused for BalanceMaxAge. GET /api/wallet/balances serves the reading.
+type PolygonConfig struct {
+	RPCURL        string   `toml:"rpc_url"`
+	BalanceCheck  bool     `toml:"balance_check"`
+	BalanceMaxAge duration `toml:"balance_max_age"`
+}
+
+// SignalsConfig controls signal persistence: with Record set, every signal
+// the engine emits is stored with what the executor made of it (needs
+// Postgres). Signals are written every FlushInterval or once BatchSize are
+// buffered. Hindsight records signals regardless.
+type SignalsConfig struct {
+	Record        bool     `toml:"record"`
+	FlushInterval duration `toml:"flush_interval"`
+	BatchSize     int      `toml:"batch_size"`
+}
+
+// HindsightConfig controls strategy signal recording and the job that scores
+// recorded signals, executed or skipped, against what the market did next.
+// Horizon is how long after a signal its mark price is taken when the market
+// has not resolved; Window is how far back each evaluation looks.
+type HindsightConfig struct {
+	Enabled    bool     `toml:"enabled"`
+	Horizon    duration `toml:"horizon"`
+	Interval   duration `toml:"interval"`
+	Window     duration `toml:"window"`
+	MaxSignals int      `toml:"max_signals"` // per strategy per evaluation
+}
+
+// PerformanceConfig controls the job that attributes results to strategies
+// per UTC day (win rate, fees, expected vs. captured edge) and persists them.
+// Interval is how often today's and yesterday's rows are recomputed;
+// BackfillDays are recomputed at start. WindowDays are the windows
+// GET /api/performance/strategies reports by default.
+type PerformanceConfig struct {
+	Enabled      bool     `toml:"enabled"`
+	Interval     duration `toml:"interval"`
+	BackfillDays int      `toml:"backfill_days"`
+	WindowDays   []int    `toml:"window_days"`
+}
+
+// CapitalConfig splits trading capital between strategies. BudgetsUSD caps
+// the notional each strategy may have committed, in resting buy orders plus
+// open positions at entry price; strategies not listed get DefaultBudgetUSD,
+// where 0 leaves them uncapped. Entries over the remaining budget are
+// rejected by the pre-trade risk check.
+//
+// Every RebalanceInterval (0 disables rebalancing) budgets are reweighted by
+// each strategy's Sharpe over the last PerformanceDays, moving a budget at
+// most MaxShift (a fraction) from its configured value while keeping the
+// total. Rebalancing needs performance.enabled.
+type CapitalConfig struct {
+	Enabled           bool               `toml:"enabled"`
+	BudgetsUSD        map[string]float64 `toml:"budgets_usd"`
+	DefaultBudgetUSD  float64            `toml:"default_budget_usd"`
+	RebalanceInterval duration           `toml:"rebalance_interval"`
+	PerformanceDays   int                `toml:"performance_days"`
+	MaxShift          float64            `toml:"max_shift"`
+}
+
+// CrossMapConfig controls the Polymarket <-> Kalshi market matcher. Every
+// Interval it scores active Polymarket markets against open Kalshi markets
+// and stores proposals scoring at least MinConfidence for review at
+// /api/crossmap; proposals at or above AutoApprove (0 = never) are approved
+// without review. MaxMarkets caps the markets read from each venue per run.
+type CrossMapConfig struct {
+	Enabled       bool     `toml:"enabled"`
+	Interval      duration `toml:"interval"`
+	MinConfidence float64  `toml:"min_confidence"`
+	AutoApprove   float64  `toml:"auto_approve"`
+	MaxMarkets    int      `toml:"max_markets"`
+}
+
+// MarketListsConfig controls the operator's market blacklist and whitelist,
+// managed at /api/markets/{id}/blacklist and /api/markets/{id}/whitelist and
+// stored in Postgres. Each instance re-reads them from Redis every
+// RefreshInterval to pick up changes made through other instances.
+type MarketListsConfig struct {
+	RefreshInterval duration `toml:"refresh_interval"`
+}
+
+// BacktestConfig holds parameters for mode = "backtest". From and To are
+// RFC3339 timestamps. Source selects where historical trades are read from:
+// "postgres" (trades table) or "s3" (archive/trades JSONL). BookSource
+// selects where book events are read from: "postgres" (the recorder's
+// book_events table) or "s3" (the book snapshot recorder's files under
+// books/). Strategies defaults to strategy.active, or strategy.name when that
+// is empty.
+type BacktestConfig struct {
+	From       string   `toml:"from"`
+	To         string   `toml:"to"`
+	Source     string   `toml:"source"`
+	BookSource string   `toml:"book_source"`
+	Strategies []string `toml:"strategies"`
+	FeeBps     float64  `toml:"fee_bps"`
+	MaxEvents  int      `toml:"max_events"`
+	ReportPath string   `toml:"report_path"`
+}
+
+// BackfillConfig holds parameters for mode = "backfill", which loads
+// historical Goldsky order fills between From and To (RFC3339) and exits. The
+// range is split into Chunk-wide slices paged through by Workers in parallel,
+// with at most RequestsPerSecond GraphQL queries across all workers (0 = no
+// cap). Raw pages go to S3 under goldsky/backfill/ and trades are COPYed into
+// Postgres; re-running an overlapping range skips stored trades.
+type BackfillConfig struct {
+	From              string   `toml:"from"`
+	To                string   `toml:"to"`
+	Chunk             duration `toml:"chunk"`
+	Workers           int      `toml:"workers"`
+	PageSize          int      `toml:"page_size"`
+	RequestsPerSecond float64  `toml:"requests_per_second"`
 }
 
 // Defaults returns a Config populated with reasonable default values.
@@ -270,15 +1090,34 @@ type NotifyConfig struct {
 func Defaults() Config {
 	return Config{
 		Polymarket: PolymarketConfig{
-			ClobHost:      "https://clob.polymarket.com",
-			GammaHost:     "https://gamma-api.polymarket.com",
-			WsHost:        "wss://ws-subscriptions-clob.polymarket.com",
-			ChainID:       137,
-			SignatureType: 2,
+			ClobHost:         "https://clob.polymarket.com",
+			GammaHost:        "https://gamma-api.polymarket.com",
+			WsHost:           "wss://ws-subscriptions-clob.polymarket.com",
+			ChainID:          137,
+			SignatureType:    2,
+			UserChannel:      true,
+			WsSilenceTimeout: duration{30 * time.Second},
+
+			WsReconnectAfter:    duration{2 * time.Minute},
+			WsAssetStaleTimeout: duration{15 * time.Minute},
+			RestPollInterval:    duration{10 * time.Second},
+
+			WarmupBooks:       true,
+			WarmupConcurrency: 4,
+			WarmupTimeout:     duration{30 * time.Second},
+
+			CollateralDecimals:       6,
+			MarketCollateralDecimals: map[string]int{},
 		},
 		Kalshi: KalshiConfig{
 			BaseURL: "https://api.elections.kalshi.com/trade-api/v2",
 		},
+		PredictIt: PredictItConfig{
+			BaseURL: "https://www.predictit.org/api/marketdata",
+		},
+		Manifold: ManifoldConfig{
+			BaseURL: "https://api.manifold.markets/v0",
+		},
 		Supabase: SupabaseConfig{
 			DSN:           "",
 			Host:          "localhost",
@@ -298,6 +1137,7 @@ func Defaults() Config {
 			TLSEnabled:      false,
 			StreamMaxLen:     500,
 			CacheTTLMinutes: 15,
+			KeyPrefix:       "polybot",
 		},
 		S3: S3Config{
 			Endpoint:       "http://localhost:9000",
@@ -305,6 +1145,7 @@ func Defaults() Config {
 			Bucket:         "polybot-data",
 			UseSSL:         false,
 			ForcePathStyle: true,
+			Format:         "jsonl",
 		},
 		Strategy: StrategyConfig{
 			Name:         "flash_crash",
@@ -317,34 +1158,111 @@ func Defaults() Config {
 			TakeProfit:   0.10,
 			StopLoss:     0.05,
 			Params:       map[string]any{},
+			Sampling: SamplingConfig{
+				Enabled:         false,
+				Mode:            "every_n",
+				EveryN:          10,
+				Interval:        duration{5 * time.Second},
+				MinLiquidityUSD: 500,
+				DepthBand:       0.02,
+				ScoreRefresh:    duration{time.Minute},
+			},
+			Breaker: BreakerConfig{
+				Enabled:                true,
+				MaxConsecutiveFailures: 5,
+				MaxLossUSD:             0,
+				LossWindow:             duration{time.Hour},
+			},
+			Queue: StrategyQueueConfig{
+				BufferSize:   32,
+				Overflow:     "drop_oldest",
+				BlockTimeout: duration{50 * time.Millisecond},
+			},
+			Candidates: CandidatesConfig{
+				MaxCandidates:     500,
+				MaxAge:            duration{15 * time.Minute},
+				FreshnessHalfLife: duration{2 * time.Minute},
+			},
+			Categories: map[string]CategoryFilterConfig{},
+			Instances:  map[string]StrategyInstanceConfig{},
+			Bond: BondStrategyConfig{
+				MinYesPrice:     0.95,
+				MinAPR:          0.10,
+				MinVolume:       100_000,
+				MaxDaysToExp:    90,
+				MinDaysToExp:    7,
+				MaxPositions:    10,
+				SizePerPosition: 50.0,
+				EarlyExit:       true,
+				StopPrice:       0.85,
+			},
+			LiquidityProvider: LiquidityProviderConfig{
+				HalfSpreadBps:    50,
+				RequoteThreshold: 0.005,
+				Size:             10.0,
+				MaxMarkets:       5,
+				TickSize:         0.01,
+				MinVolume:        50_000,
+				RewardsOnly:      true,
+				MaxInventory:     100,
+				InventorySkewBps: 50,
+				MaxVolatility:    0.03,
+				Levels:           1,
+				LevelSpacingBps:  100,
+				SizeDecay:        1,
+				ToxicitySpread:   2,
+
+				RewardSampleInterval: duration{time.Minute},
+			},
 			YesNoSpread: YesNoSpreadConfig{
-				Enabled:     true,
-				MinEdgeBps:  40,
-				SizePerLeg:  5.0,
-				TTLSeconds:  30,
-				MaxStaleSec: 5,
-				CooldownSec: 2,
+				Enabled:       true,
+				MinEdgeBps:    40,
+				SizePerLeg:    5.0,
+				MinSizePerLeg: 1.0,
+				TTLSeconds:    30,
+				MaxStaleSec:   5,
+				CooldownSec:   2,
+				ToxicityEdge:  2,
 			},
 			CrossPlatformArb: CrossPlatformArbConfig{
-				Enabled:     false,
-				MinEdgeBps:  60,
-				SizePerLeg:  5.0,
-				TTLSeconds:  30,
-				RefreshSec:  5,
-				MaxStaleSec: 8,
-				CooldownSec: 3,
-				MarketMap:   map[string]string{},
+				Enabled:      false,
+				MinEdgeBps:   60,
+				SizePerLeg:   5.0,
+				TTLSeconds:   30,
+				RefreshSec:   5,
+				MaxStaleSec:  8,
+				CooldownSec:  3,
+				MarketMap:    map[string]string{},
+				PredictItMap: map[string]string{},
+				ManifoldMap:  map[string]string{},
 			},
 			TemporalOverlap: TemporalOverlapConfig{
 				Enabled:        false,
 				MinEdgeBps:     80,
 				SizePerLeg:     5.0,
+				MinSizePerLeg:  1.0,
 				TTLSeconds:     30,
 				MaxStaleSec:    6,
 				CooldownSec:    3,
 				RefreshMinutes: 10,
 				MaxPairs:       100,
+				MaxRefMoveBps:  50,
+			},
+			LatencyArb: LatencyArbConfig{
+				Enabled:        false,
+				MinMoveBps:     30,
+				LookbackSec:    30,
+				MaxLagMove:     0.02,
+				MaxPrice:       0.90,
+				Size:           5.0,
+				TTLSeconds:     10,
+				MaxStaleSec:    5,
+				MaxRefAgeSec:   10,
+				CooldownSec:    30,
+				RefreshMinutes: 10,
+				MaxMarkets:     200,
 			},
+			EventBudgetMs: 50,
 		},
 		Arbitrage: ArbitrageConfig{
 			Strategy:                "spread",
@@ -355,14 +1273,97 @@ func Defaults() Config {
 			MinDurationMs:           500,
 			MaxLegGapMs:             2000,
 			MaxUnhedgedNotional:     50.0,
+			HedgeBudgetUSD:          10.0,
 			MaxSlippageBps:          20.0,
 			KillSwitchLossUSD:       100.0,
 			MinSpreadBps:            30.0,
 			ImbalanceRatioThreshold: 1.5,
+			OpportunityDedupWindow:  duration{10 * time.Second},
+			OpportunityTTL:          duration{30 * time.Second},
+			LegTopUpAttempts:        2,
+			LegTopUpAfter:           duration{5 * time.Second},
+			SweepImmediate:          true,
+			SweepEdgeFraction:       0.5,
+			UnhedgedCheckInterval:   duration{30 * time.Second},
+			UnhedgedLookback:        duration{24 * time.Hour},
+			UnwindAllOrNone:         true,
 			PerVenueFeeBps: map[string]float64{
 				"polymarket": 0.0,
 				"kalshi":     7.0,
 			},
+			Imbalance: ImbalanceArbConfig{
+				Levels:          5,
+				LevelDecay:      0.75,
+				HalfLife:        duration{5 * time.Second},
+				FlowHalfLife:    duration{30 * time.Second},
+				MinTotalVolume:  100.0,
+				EdgeBpsPerRatio: 15.0,
+			},
+		},
+		Risk: RiskConfig{
+			CloseHaircutHorizon:     duration{48 * time.Hour},
+			CloseHaircutMinFactor:   0.25,
+			CloseHaircutMultipliers: map[string]float64{},
+			DailyLossLimitUSD:       0,
+			PortfolioCheckInterval:  duration{15 * time.Second},
+			MetadataPollInterval:    duration{30 * time.Second},
+			ResolutionPollInterval:  duration{5 * time.Minute},
+			MinSizePolicy:           "reject",
+			RedeemGasUSD:            0.01,
+			DefaultEdgeBps:          100,
+			CancelRatioLimit:        50,
+			CancelRatioWarnAt:       0.8,
+			CancelRatioMinCancels:   20,
+			CancelAllOnShutdown:     true,
+			StaleOrderInterval:      duration{5 * time.Second},
+			OrderTTLSeconds:         map[string]int{},
+			ShutdownCancelTimeout:   duration{10 * time.Second},
+			Slippage: RiskSlippageConfig{
+				SlippageModelConfig: SlippageModelConfig{Model: "static", VolMultiplier: 1},
+				Strategies:          map[string]SlippageModelConfig{},
+			},
+		},
+		Executor: ExecutorConfig{
+			Workers:    4,
+			QueueDepth: 64,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Venues: map[string]RateLimitRule{
+				RateLimitVenueClob:   {Rate: 10, Burst: 10},
+				RateLimitVenueGamma:  {Rate: 5, Burst: 5},
+				RateLimitVenueKalshi: {Rate: 5, Burst: 5},
+			},
+			OrdersPerSecond: 10,
+		},
+		Retry: RetryConfig{
+			Enabled:         true,
+			MaxAttempts:     3,
+			BaseDelay:       duration{500 * time.Millisecond},
+			MaxDelay:        duration{10 * time.Second},
+			RateLimitFactor: 4,
+			Jitter:          0.2,
+		},
+		Accounting: AccountingConfig{
+			LotMethod: "fifo",
+		},
+		Sweep: SweepConfig{
+			Enabled:        false,
+			USDCAddress:    "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174",
+			PnLThreshold:   500,
+			WorkingCapital: 1000,
+			MinAmount:      10,
+			CheckInterval:  duration{15 * time.Minute},
+			Confirmations:  20,
+			ConfirmTimeout: duration{10 * time.Minute},
+		},
+		Redemption: RedemptionConfig{
+			Enabled:        false,
+			CheckInterval:  duration{10 * time.Minute},
+			Lookback:       duration{30 * 24 * time.Hour},
+			MinProceeds:    1,
+			Confirmations:  20,
+			ConfirmTimeout: duration{10 * time.Minute},
 		},
 		Pipeline: PipelineConfig{
 			Enabled:                  false,
@@ -372,14 +1373,136 @@ func Defaults() Config {
 			ArchiveRetentionDays:     30,
 			ArchiveCron:              "0 3 1 * *",
 			S3ArchiveRetentionMonths: 6,
+			OnchainLookback:          duration{30 * 24 * time.Hour},
+			MarketPageSize:           500,
+			MarketFullSyncInterval:   duration{6 * time.Hour},
+			DLQRetryInterval:         duration{10 * time.Minute},
+			DLQRetryBatches:          20,
+			DLQMaxAttempts:           5,
 		},
 		Server: ServerConfig{
 			Enabled:     true,
 			Port:        8000,
 			CORSOrigins: []string{"http://localhost:3000", "http://localhost:5173"},
+			WSMaxBatch:  256,
 		},
 		Notify: NotifyConfig{
-			Events: []string{"arb_detected", "order_filled", "position_closed", "error"},
+			Events:        []string{"arb_detected", "order_filled", "position_closed", "error"},
+			Channels:      []string{"orders", "positions", "arb", "bond_resolved"},
+			RatePerMinute: 20,
+			MaxRetries:    3,
+			RetryBackoff:  duration{2 * time.Second},
+		},
+		Backtest: BacktestConfig{
+			Source:     "postgres",
+			BookSource: "postgres",
+			FeeBps:     0,
+			MaxEvents:  1_000_000,
+		},
+		Backfill: BackfillConfig{
+			Chunk:             duration{24 * time.Hour},
+			Workers:           4,
+			PageSize:          1000,
+			RequestsPerSecond: 5,
+		},
+		Recorder: RecorderConfig{
+			Enabled:       false,
+			FlushInterval: duration{time.Second},
+			BatchSize:     500,
+			Retention:     duration{7 * 24 * time.Hour},
+		},
+		BookSnapshots: BookSnapshotsConfig{
+			Enabled:       false,
+			Interval:      duration{10 * time.Second},
+			MinInterval:   duration{time.Second},
+			FlushInterval: duration{5 * time.Minute},
+			BatchSize:     50000,
+		},
+		Candles: CandlesConfig{
+			Enabled:       false,
+			FlushInterval: duration{5 * time.Second},
+			Retention:     duration{90 * 24 * time.Hour},
+		},
+		Features: FeaturesConfig{
+			Enabled:       false,
+			DepthLevels:   5,
+			Window:        duration{5 * time.Minute},
+			FlushInterval: duration{time.Second},
+		},
+		Toxicity: ToxicityConfig{
+			Enabled:       false,
+			BucketVolume:  500,
+			Buckets:       50,
+			MinBuckets:    10,
+			FlushInterval: duration{time.Second},
+		},
+		TradeAnalytics: TradeAnalyticsConfig{
+			Enabled:       false,
+			VWAPWindow:    duration{time.Hour},
+			BucketWidth:   0.01,
+			FlushInterval: duration{5 * time.Second},
+		},
+		PriceFeed: PriceFeedConfig{
+			Enabled:      false,
+			Sources:      []string{"binance", "coinbase"},
+			Assets:       []string{"btc", "eth", "sol", "doge"},
+			PollInterval: duration{2 * time.Second},
+			History:      duration{10 * time.Minute},
+			BinanceURL:   "https://api.binance.com",
+			CoinbaseURL:  "https://api.exchange.coinbase.com",
+		},
+		Fees: FeesConfig{
+			Enabled:         true,
+			RefreshInterval: duration{10 * time.Minute},
+		},
+		Latency: LatencyConfig{
+			Enabled:       true,
+			Window:        2048,
+			FlushInterval: duration{5 * time.Second},
+		},
+		Increments: IncrementsConfig{
+			Enabled:         true,
+			RefreshInterval: duration{time.Hour},
+			SizeStep:        0.01,
+			DefaultTickSize: 0.01,
+		},
+		Polygon: PolygonConfig{
+			BalanceCheck:  true,
+			BalanceMaxAge: duration{15 * time.Second},
+		},
+		Signals: SignalsConfig{
+			Record:        true,
+			FlushInterval: duration{5 * time.Second},
+			BatchSize:     200,
+		},
+		Hindsight: HindsightConfig{
+			Enabled:    false,
+			Horizon:    duration{time.Hour},
+			Interval:   duration{15 * time.Minute},
+			Window:     duration{7 * 24 * time.Hour},
+			MaxSignals: 1000,
+		},
+		Performance: PerformanceConfig{
+			Enabled:      false,
+			Interval:     duration{time.Hour},
+			BackfillDays: 30,
+			WindowDays:   []int{1, 7, 30},
+		},
+		Capital: CapitalConfig{
+			Enabled:           false,
+			RebalanceInterval: duration{6 * time.Hour},
+			PerformanceDays:   7,
+			MaxShift:          0.5,
+		},
+		CrossMap: CrossMapConfig{
+			Enabled:       false,
+			Interval:      duration{time.Hour},
+			MinConfidence: 0.55,
+			AutoApprove:   0,
+			MaxMarkets:    5000,
+		},
+		MarketLists: MarketListsConfig{
+			RefreshInterval: duration{15 * time.Second},
 		},
 		Mode:     "full",
 		LogLevel: "info",
@@ -393,6 +1516,7 @@ var validModes = map[string]bool{
 	"monitor":   true,
 	"scrape":    true,
 	"backtest":  true,
+	"backfill":  true,
 	"server":    true,
 	"full":      true,
 }
@@ -412,7 +1536,7 @@ func (c *Config) Validate() error {
 
 	// Mode
 	if !validModes[strings.ToLower(c.Mode)] {
-		errs = append(errs, fmt.Sprintf("unknown mode %q (valid: trade, arbitrage, monitor, scrape, backtest, server, full)", c.Mode))
+		errs = append(errs, fmt.Sprintf("unknown mode %q (valid: trade, arbitrage, monitor, scrape, backtest, backfill, server, full)", c.Mode))
 	}
 
 	// LogLevel
@@ -441,6 +1565,36 @@ func (c *Config) Validate() error {
 	if c.Polymarket.SignatureType != 1 && c.Polymarket.SignatureType != 2 {
 		errs = append(errs, fmt.Sprintf("polymarket: signature_type must be 1 (EOA) or 2 (Safe), got %d", c.Polymarket.SignatureType))
 	}
+	if c.Polymarket.WsSilenceTimeout.Duration < 0 {
+		errs = append(errs, "polymarket: ws_silence_timeout must not be negative")
+	}
+	if c.Polymarket.WsReconnectAfter.Duration < 0 {
+		errs = append(errs, "polymarket: ws_reconnect_after must not be negative")
+	} else if r, s := c.Polymarket.WsReconnectAfter.Duration, c.Polymarket.WsSilenceTimeout.Duration; r > 0 && s > 0 && r <= s {
+		errs = append(errs, "polymarket: ws_reconnect_after must exceed ws_silence_timeout")
+	}
+	if c.Polymarket.WsAssetStaleTimeout.Duration < 0 {
+		errs = append(errs, "polymarket: ws_asset_stale_timeout must not be negative")
+	}
+	if c.Polymarket.RestPollInterval.Duration < 0 {
+		errs = append(errs, "polymarket: rest_poll_interval must not be negative")
+	}
+	if c.Polymarket.WarmupBooks {
+		if c.Polymarket.WarmupConcurrency <= 0 {
+			errs = append(errs, "polymarket: warmup_concurrency must be > 0 when warmup_books is set")
+		}
+		if c.Polymarket.WarmupTimeout.Duration <= 0 {
+			errs = append(errs, "polymarket: warmup_timeout must be > 0 when warmup_books is set")
+		}
+	}
+	if d := c.Polymarket.CollateralDecimals; d <= 0 || d > 18 {
+		errs = append(errs, fmt.Sprintf("polymarket: collateral_decimals must be 1-18, got %d", d))
+	}
+	for market, d := range c.Polymarket.MarketCollateralDecimals {
+		if market == "" || d <= 0 || d > 18 {
+			errs = append(errs, fmt.Sprintf("polymarket: market_collateral_decimals %q must be 1-18, got %d", market, d))
+		}
+	}
 
 	// Builder — all three fields must be set together, or all empty.
 	bk := c.Builder.ApiKey != ""
@@ -462,6 +1616,14 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Read-only venues.
+	if c.PredictIt.Enabled && c.PredictIt.BaseURL == "" {
+		errs = append(errs, "predictit: base_url must not be empty when enabled")
+	}
+	if c.Manifold.Enabled && c.Manifold.BaseURL == "" {
+		errs = append(errs, "manifold: base_url must not be empty when enabled")
+	}
+
 	// Supabase
 	if strings.TrimSpace(c.Supabase.DSN) == "" {
 		if c.Supabase.Host == "" {
@@ -491,6 +1653,9 @@ func (c *Config) Validate() error {
 	if c.Redis.PoolSize < 1 {
 		errs = append(errs, "redis: pool_size must be >= 1")
 	}
+	if strings.ContainsAny(c.Redis.KeyPrefix, "*?[]\\ ") {
+		errs = append(errs, "redis: key_prefix must not contain spaces or glob characters")
+	}
 
 	// S3
 	if c.S3.Endpoint == "" {
@@ -499,20 +1664,149 @@ func (c *Config) Validate() error {
 	if c.S3.Bucket == "" {
 		errs = append(errs, "s3: bucket must not be empty")
 	}
+	if c.S3.Format != "jsonl" && c.S3.Format != "parquet" {
+		errs = append(errs, fmt.Sprintf("s3: format must be \"jsonl\" or \"parquet\", got %q", c.S3.Format))
+	}
 
 	// Strategy
 	if c.Strategy.Size <= 0 {
 		errs = append(errs, "strategy: size must be > 0")
 	}
-	if c.Strategy.PriceScale <= 0 {
-		errs = append(errs, "strategy: price_scale must be > 0")
+	if c.Strategy.PriceScale != domain.PriceScale {
+		errs = append(errs, fmt.Sprintf("strategy: price_scale must be %d, got %d", domain.PriceScale, c.Strategy.PriceScale))
 	}
-	if c.Strategy.SizeScale <= 0 {
-		errs = append(errs, "strategy: size_scale must be > 0")
+	if c.Strategy.SizeScale != domain.SizeScale {
+		errs = append(errs, fmt.Sprintf("strategy: size_scale must be %d, got %d", domain.SizeScale, c.Strategy.SizeScale))
 	}
 	if c.Strategy.MaxPositions < 1 {
 		errs = append(errs, "strategy: max_positions must be >= 1")
 	}
+	if c.Strategy.EventBudgetMs < 0 {
+		errs = append(errs, "strategy: event_budget_ms must be >= 0")
+	}
+	switch c.Strategy.LegOrderType {
+	case "", "GTC", "FOK", "FAK":
+	default:
+		errs = append(errs, fmt.Sprintf("strategy: leg_order_type must be GTC, FOK or FAK, got %q", c.Strategy.LegOrderType))
+	}
+	for _, name := range c.Strategy.Shadow {
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, "strategy: shadow names must not be empty")
+			break
+		}
+	}
+	if sc := c.Strategy.Sampling; sc.Enabled {
+		switch sc.Mode {
+		case "every_n":
+			if sc.EveryN < 1 {
+				errs = append(errs, "strategy.sampling: every_n must be >= 1")
+			}
+		case "interval":
+			if sc.Interval.Duration <= 0 {
+				errs = append(errs, "strategy.sampling: interval must be > 0")
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("strategy.sampling: mode must be every_n or interval, got %q", sc.Mode))
+		}
+		if sc.MinLiquidityUSD < 0 {
+			errs = append(errs, "strategy.sampling: min_liquidity_usd must be >= 0")
+		}
+		if sc.DepthBand <= 0 || sc.DepthBand >= 1 {
+			errs = append(errs, "strategy.sampling: depth_band must be in (0, 1)")
+		}
+		if sc.ScoreRefresh.Duration <= 0 {
+			errs = append(errs, "strategy.sampling: score_refresh must be > 0")
+		}
+	}
+	if bc := c.Strategy.Breaker; bc.Enabled {
+		if bc.MaxConsecutiveFailures < 0 {
+			errs = append(errs, "strategy.breaker: max_consecutive_failures must be >= 0")
+		}
+		if bc.MaxLossUSD < 0 {
+			errs = append(errs, "strategy.breaker: max_loss_usd must be >= 0")
+		}
+		if bc.MaxLossUSD > 0 && bc.LossWindow.Duration <= 0 {
+			errs = append(errs, "strategy.breaker: loss_window must be > 0 when max_loss_usd is set")
+		}
+	}
+	if c.Strategy.Queue.BufferSize <= 0 {
+		errs = append(errs, "strategy.queue: buffer_size must be > 0")
+	}
+	switch c.Strategy.Queue.Overflow {
+	case "drop_oldest", "drop_newest":
+	case "block":
+		if c.Strategy.Queue.BlockTimeout.Duration <= 0 {
+			errs = append(errs, "strategy.queue: block_timeout must be > 0 when overflow is block")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("strategy.queue: overflow must be drop_oldest, drop_newest or block, got %q", c.Strategy.Queue.Overflow))
+	}
+	if c.Strategy.Candidates.MaxCandidates <= 0 {
+		errs = append(errs, "strategy.candidates: max_candidates must be > 0")
+	}
+	if c.Strategy.Candidates.MaxAge.Duration <= 0 {
+		errs = append(errs, "strategy.candidates: max_age must be > 0")
+	}
+	if c.Strategy.Candidates.FreshnessHalfLife.Duration <= 0 {
+		errs = append(errs, "strategy.candidates: freshness_half_life must be > 0")
+	}
+	for name, inst := range c.Strategy.Instances {
+		switch {
+		case name == "" || strings.ContainsAny(name, ": \t"):
+			errs = append(errs, fmt.Sprintf("strategy.instances: name %q must be non-empty without spaces or colons", name))
+		case strings.TrimSpace(inst.Type) == "":
+			errs = append(errs, fmt.Sprintf("strategy.instances.%s: type is required", name))
+		case name == inst.Type:
+			errs = append(errs, fmt.Sprintf("strategy.instances.%s: name must differ from its type", name))
+		}
+	}
+	for name, f := range c.Strategy.Categories {
+		filterable := slices.Contains(CategoryFilterStrategies, name)
+		if inst, ok := c.Strategy.Instances[name]; ok {
+			filterable = slices.Contains(CategoryFilterStrategies, inst.Type)
+		}
+		if !filterable {
+			errs = append(errs, fmt.Sprintf("strategy.categories: %q cannot be filtered (valid: %s)", name, strings.Join(CategoryFilterStrategies, ", ")))
+			continue
+		}
+		for _, tag := range f.Include {
+			if strings.TrimSpace(tag) == "" {
+				errs = append(errs, fmt.Sprintf("strategy.categories.%s: include has an empty tag", name))
+			} else if slices.ContainsFunc(f.Exclude, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				errs = append(errs, fmt.Sprintf("strategy.categories.%s: tag %q is both included and excluded", name, tag))
+			}
+		}
+		if slices.ContainsFunc(f.Exclude, func(t string) bool { return strings.TrimSpace(t) == "" }) {
+			errs = append(errs, fmt.Sprintf("strategy.categories.%s: exclude has an empty tag", name))
+		}
+	}
+	if sp := c.Strategy.Bond.StopPrice; sp < 0 || sp >= 1 {
+		errs = append(errs, "strategy.bond: stop_price must be in [0, 1)")
+	}
+	if lc := c.Strategy.LiquidityProvider; lc.MaxInventory < 0 || lc.InventorySkewBps < 0 || lc.MaxVolatility < 0 {
+		errs = append(errs, "strategy.liquidity_provider: max_inventory, inventory_skew_bps and max_volatility must be >= 0")
+	}
+	if lc := c.Strategy.LiquidityProvider; lc.Levels < 1 || lc.Levels > 10 || lc.LevelSpacingBps < 1 || lc.SizeDecay <= 0 || lc.SizeDecay > 1 {
+		errs = append(errs, "strategy.liquidity_provider: levels must be in [1, 10], level_spacing_bps >= 1 and size_decay in (0, 1]")
+	}
+	if lc := c.Strategy.LiquidityProvider; lc.ToxicityWiden < 0 || lc.ToxicityWiden > 1 || lc.ToxicityPause < 0 || lc.ToxicityPause > 1 {
+		errs = append(errs, "strategy.liquidity_provider: toxicity_widen and toxicity_pause must be 0-1")
+	}
+	if ts := c.Strategy.LiquidityProvider.ToxicitySpread; ts < 1 || ts > 10 {
+		errs = append(errs, fmt.Sprintf("strategy.liquidity_provider: toxicity_spread must be 1-10, got %g", ts))
+	}
+	if yc := c.Strategy.YesNoSpread; yc.ToxicityWiden < 0 || yc.ToxicityWiden > 1 || yc.ToxicityPause < 0 || yc.ToxicityPause > 1 {
+		errs = append(errs, "strategy.yes_no_spread: toxicity_widen and toxicity_pause must be 0-1")
+	}
+	if te := c.Strategy.YesNoSpread.ToxicityEdge; te < 1 || te > 10 {
+		errs = append(errs, fmt.Sprintf("strategy.yes_no_spread: toxicity_edge must be 1-10, got %g", te))
+	}
+	if c.Strategy.Bond.MinVolume24h < 0 || c.Strategy.LiquidityProvider.MinVolume24h < 0 {
+		errs = append(errs, "strategy: bond and liquidity_provider min_volume_24h must be >= 0")
+	}
+	if lc := c.Strategy.LiquidityProvider; lc.MinRewardUSD < 0 || lc.RewardSampleInterval.Duration <= 0 {
+		errs = append(errs, "strategy.liquidity_provider: min_reward_usd must be >= 0 and reward_sample_interval > 0")
+	}
 
 	// Arbitrage
 	if c.Arbitrage.Enabled {
@@ -526,12 +1820,573 @@ func (c *Config) Validate() error {
 			errs = append(errs, "arbitrage: kill_switch_loss_usd must be > 0 when enabled")
 		}
 	}
+	if c.Arbitrage.OpportunityDedupWindow.Duration < 0 {
+		errs = append(errs, "arbitrage: opportunity_dedup_window must be >= 0")
+	}
+	if c.Arbitrage.OpportunityTTL.Duration < 0 {
+		errs = append(errs, "arbitrage: opportunity_ttl must be >= 0")
+	}
+	im := c.Arbitrage.Imbalance
+	if im.Levels < 0 {
+		errs = append(errs, "arbitrage.imbalance: levels must be >= 0")
+	}
+	if im.LevelDecay <= 0 || im.LevelDecay > 1 {
+		errs = append(errs, "arbitrage.imbalance: level_decay must be in (0, 1]")
+	}
+	if im.HalfLife.Duration < 0 {
+		errs = append(errs, "arbitrage.imbalance: half_life must be >= 0")
+	}
+	if im.TradeFlow && im.FlowHalfLife.Duration <= 0 {
+		errs = append(errs, "arbitrage.imbalance: flow_half_life must be > 0 when trade_flow is set")
+	}
+	if im.MinFlowUSD < 0 || im.MinTotalVolume < 0 {
+		errs = append(errs, "arbitrage.imbalance: min_flow_usd and min_total_volume must be >= 0")
+	}
+	if im.EdgeBpsPerRatio <= 0 {
+		errs = append(errs, "arbitrage.imbalance: edge_bps_per_ratio must be > 0")
+	}
+	for _, name := range c.Arbitrage.Strategies {
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, "arbitrage: strategies must not contain empty names")
+			break
+		}
+	}
+	if c.Arbitrage.MaxUnhedgedNotional < 0 {
+		errs = append(errs, "arbitrage: max_unhedged_notional must be >= 0")
+	}
+	if c.Arbitrage.HedgeBudgetUSD < 0 {
+		errs = append(errs, "arbitrage: hedge_budget_usd must be >= 0")
+	}
+	if c.Arbitrage.UnhedgedCheckInterval.Duration < 0 {
+		errs = append(errs, "arbitrage: unhedged_check_interval must be >= 0")
+	}
+	if c.Arbitrage.UnhedgedCheckInterval.Duration > 0 && c.Arbitrage.UnhedgedLookback.Duration <= 0 {
+		errs = append(errs, "arbitrage: unhedged_lookback must be > 0 when unhedged_check_interval is set")
+	}
+	if c.Arbitrage.LegTopUpAttempts < 0 {
+		errs = append(errs, "arbitrage: leg_topup_attempts must be >= 0")
+	}
+	if c.Arbitrage.LegTopUpAttempts > 0 && c.Arbitrage.LegTopUpAfter.Duration <= 0 {
+		errs = append(errs, "arbitrage: leg_topup_after must be > 0 when leg_topup_attempts is set")
+	}
+	if c.Arbitrage.SweepImmediate && (c.Arbitrage.SweepEdgeFraction < 0 || c.Arbitrage.SweepEdgeFraction > 1) {
+		errs = append(errs, "arbitrage: sweep_edge_fraction must be in [0, 1] when sweep_immediate is set")
+	}
+
+	// Accounting
+	if c.Accounting.LotMethod != "fifo" && c.Accounting.LotMethod != "lifo" {
+		errs = append(errs, fmt.Sprintf("accounting: lot_method must be fifo or lifo, got %q", c.Accounting.LotMethod))
+	}
+
+	// Sweep
+	if c.Sweep.Enabled {
+		if c.Sweep.RPCURL == "" {
+			errs = append(errs, "sweep: rpc_url is required when enabled")
+		}
+		if !isHexAddress(c.Sweep.ColdAddress) {
+			errs = append(errs, fmt.Sprintf("sweep: cold_address must be a 0x-prefixed 20-byte hex address, got %q", c.Sweep.ColdAddress))
+		}
+		if !isHexAddress(c.Sweep.USDCAddress) {
+			errs = append(errs, fmt.Sprintf("sweep: usdc_address must be a 0x-prefixed 20-byte hex address, got %q", c.Sweep.USDCAddress))
+		}
+		if c.Sweep.PnLThreshold <= 0 {
+			errs = append(errs, "sweep: pnl_threshold must be positive")
+		}
+		if c.Sweep.WorkingCapital < 0 {
+			errs = append(errs, "sweep: working_capital must be >= 0")
+		}
+		if c.Sweep.MinAmount < 0 {
+			errs = append(errs, "sweep: min_amount must be >= 0")
+		}
+		if c.Sweep.CheckInterval.Duration <= 0 {
+			errs = append(errs, "sweep: check_interval must be positive")
+		}
+		if c.Sweep.Confirmations <= 0 {
+			errs = append(errs, "sweep: confirmations must be positive")
+		}
+	}
+
+	// Redemption
+	if c.Redemption.Enabled {
+		if c.Polygon.RPCURL == "" {
+			errs = append(errs, "redemption: polygon.rpc_url is required when enabled")
+		}
+		if c.Redemption.CheckInterval.Duration <= 0 {
+			errs = append(errs, "redemption: check_interval must be positive")
+		}
+		if c.Redemption.Lookback.Duration <= 0 {
+			errs = append(errs, "redemption: lookback must be positive")
+		}
+		if c.Redemption.MinProceeds < 0 {
+			errs = append(errs, "redemption: min_proceeds must be >= 0")
+		}
+		if c.Redemption.Confirmations <= 0 {
+			errs = append(errs, "redemption: confirmations must be positive")
+		}
+	}
+
+	// Notify
+	if c.Notify.RatePerMinute < 0 {
+		errs = append(errs, "notify: rate_per_minute must be >= 0")
+	}
+	if c.Notify.MaxRetries < 0 {
+		errs = append(errs, "notify: max_retries must be >= 0")
+	}
+	if c.Notify.RetryBackoff.Duration < 0 {
+		errs = append(errs, "notify: retry_backoff must be >= 0")
+	}
+
+	// Risk
+	if c.Risk.CloseHaircutHorizon.Duration < 0 {
+		errs = append(errs, "risk: close_haircut_horizon must be >= 0")
+	}
+	if c.Risk.CloseHaircutMinFactor < 0 || c.Risk.CloseHaircutMinFactor > 1 {
+		errs = append(errs, "risk: close_haircut_min_factor must be in [0, 1]")
+	}
+	if c.Risk.DailyLossLimitUSD < 0 {
+		errs = append(errs, "risk: daily_loss_limit_usd must be >= 0")
+	}
+	if c.Risk.PortfolioCheckInterval.Duration < 0 {
+		errs = append(errs, "risk: portfolio_check_interval must be >= 0")
+	}
+	if c.Risk.MetadataPollInterval.Duration < 0 {
+		errs = append(errs, "risk: metadata_poll_interval must be >= 0")
+	}
+	if c.Risk.ResolutionPollInterval.Duration < 0 {
+		errs = append(errs, "risk: resolution_poll_interval must be >= 0")
+	}
+	switch c.Risk.MinSizePolicy {
+	case "off", "reject", "floor":
+	default:
+		errs = append(errs, fmt.Sprintf("risk: min_size_policy must be off, reject or floor, got %q", c.Risk.MinSizePolicy))
+	}
+	if c.Risk.RedeemGasUSD < 0 {
+		errs = append(errs, "risk: redeem_gas_usd must be >= 0")
+	}
+	if c.Risk.DefaultEdgeBps < 0 {
+		errs = append(errs, "risk: default_edge_bps must be >= 0")
+	}
+	if c.Risk.CancelRatioLimit < 0 {
+		errs = append(errs, "risk: cancel_ratio_limit must be >= 0")
+	}
+	if c.Risk.CancelRatioWarnAt <= 0 || c.Risk.CancelRatioWarnAt > 1 {
+		errs = append(errs, "risk: cancel_ratio_warn_at must be in (0, 1]")
+	}
+	if c.Risk.CancelRatioMinCancels < 0 {
+		errs = append(errs, "risk: cancel_ratio_min_cancels must be >= 0")
+	}
+	if c.Risk.CancelAllOnShutdown && c.Risk.ShutdownCancelTimeout.Duration <= 0 {
+		errs = append(errs, "risk: shutdown_cancel_timeout must be > 0 when cancel_all_on_shutdown is set")
+	}
+	if c.Risk.StaleOrderInterval.Duration < 0 {
+		errs = append(errs, "risk: stale_order_interval must be >= 0")
+	}
+	for name, ttl := range c.Risk.OrderTTLSeconds {
+		if ttl < 0 {
+			errs = append(errs, fmt.Sprintf("risk: order_ttl_seconds[%s] must be >= 0", name))
+		}
+	}
+	for name, m := range c.Risk.CloseHaircutMultipliers {
+		if m < 0 {
+			errs = append(errs, fmt.Sprintf("risk: close_haircut_multipliers[%s] must be >= 0", name))
+		}
+	}
+	if !slices.Contains(SlippageModels, c.Risk.Slippage.Model) {
+		errs = append(errs, fmt.Sprintf("risk: unknown slippage.model %q (valid: %s)", c.Risk.Slippage.Model, strings.Join(SlippageModels, ", ")))
+	}
+	slippages := map[string]SlippageModelConfig{"slippage": c.Risk.Slippage.SlippageModelConfig}
+	for name, m := range c.Risk.Slippage.Strategies {
+		if m.Model != "" && !slices.Contains(SlippageModels, m.Model) {
+			errs = append(errs, fmt.Sprintf("risk: unknown slippage.strategies[%s].model %q (valid: %s)", name, m.Model, strings.Join(SlippageModels, ", ")))
+		}
+		slippages[fmt.Sprintf("slippage.strategies[%s]", name)] = m
+	}
+	for key, m := range slippages {
+		if m.MaxBps < 0 || m.Bps < 0 || m.VolMultiplier < 0 {
+			errs = append(errs, fmt.Sprintf("risk: %s max_bps, bps and vol_multiplier must be >= 0", key))
+		}
+	}
+
+	// Executor
+	if c.Executor.Workers <= 0 || c.Executor.QueueDepth <= 0 {
+		errs = append(errs, "executor: workers and queue_depth must be > 0")
+	}
+
+	// RateLimit
+	knownVenue := func(v string) bool {
+		for _, k := range RateLimitVenues {
+			if v == k {
+				return true
+			}
+		}
+		return false
+	}
+	for venue, r := range c.RateLimit.Venues {
+		if !knownVenue(venue) {
+			errs = append(errs, fmt.Sprintf("ratelimit: unknown venue %q (valid: %s)", venue, strings.Join(RateLimitVenues, ", ")))
+		}
+		if r.Rate < 0 || r.Burst < 0 {
+			errs = append(errs, fmt.Sprintf("ratelimit: venues[%s] rate and burst must be >= 0", venue))
+		}
+	}
+	for i, ep := range c.RateLimit.Endpoints {
+		if !knownVenue(ep.Venue) {
+			errs = append(errs, fmt.Sprintf("ratelimit: endpoints[%d]: unknown venue %q", i, ep.Venue))
+		}
+		if ep.Name == "" {
+			errs = append(errs, fmt.Sprintf("ratelimit: endpoints[%d]: name is required", i))
+		}
+		if !strings.HasPrefix(ep.Path, "/") {
+			errs = append(errs, fmt.Sprintf("ratelimit: endpoints[%d]: path must start with /, got %q", i, ep.Path))
+		}
+		if ep.Rate <= 0 || ep.Burst < 0 {
+			errs = append(errs, fmt.Sprintf("ratelimit: endpoints[%d]: rate must be > 0 and burst >= 0", i))
+		}
+	}
+	if c.RateLimit.OrdersPerSecond <= 0 {
+		errs = append(errs, "ratelimit: orders_per_second must be > 0")
+	}
+
+	// Retry
+	if c.Retry.MaxAttempts < 1 {
+		errs = append(errs, "retry: max_attempts must be >= 1")
+	}
+	if c.Retry.BaseDelay.Duration < 0 || c.Retry.MaxDelay.Duration < 0 {
+		errs = append(errs, "retry: base_delay and max_delay must be >= 0")
+	}
+	if c.Retry.RateLimitFactor < 1 {
+		errs = append(errs, "retry: rate_limit_factor must be >= 1")
+	}
+	if c.Retry.Jitter < 0 || c.Retry.Jitter > 1 {
+		errs = append(errs, "retry: jitter must be between 0 and 1")
+	}
+
+	// Pipeline
+	if c.Pipeline.GoldskyActivityURL != "" && c.Pipeline.OnchainLookback.Duration <= 0 {
+		errs = append(errs, "pipeline: onchain_lookback must be > 0 when goldsky_activity_url is set")
+	}
+	if c.Pipeline.MarketPageSize < 1 || c.Pipeline.MarketPageSize > 500 {
+		errs = append(errs, "pipeline: market_page_size must be between 1 and 500")
+	}
+	if c.Pipeline.MarketFullSyncInterval.Duration < 0 {
+		errs = append(errs, "pipeline: market_full_sync_interval must be >= 0")
+	}
+	if c.Pipeline.DLQRetryInterval.Duration < 0 {
+		errs = append(errs, "pipeline: dlq_retry_interval must be >= 0")
+	}
+	if c.Pipeline.DLQRetryBatches < 1 {
+		errs = append(errs, "pipeline: dlq_retry_batches must be >= 1")
+	}
+	if c.Pipeline.DLQMaxAttempts < 0 {
+		errs = append(errs, "pipeline: dlq_max_attempts must be >= 0")
+	}
+
+	// Recorder
+	if c.Recorder.Enabled && c.Recorder.Retention.Duration < 0 {
+		errs = append(errs, "recorder: retention must be >= 0")
+	}
+
+	// Book snapshots
+	if c.BookSnapshots.Enabled {
+		if c.BookSnapshots.Interval.Duration < 0 {
+			errs = append(errs, "book_snapshots: interval must be >= 0")
+		}
+		if c.BookSnapshots.MinInterval.Duration < 0 {
+			errs = append(errs, "book_snapshots: min_interval must be >= 0")
+		}
+		if c.BookSnapshots.FlushInterval.Duration <= 0 {
+			errs = append(errs, "book_snapshots: flush_interval must be > 0")
+		}
+		if c.BookSnapshots.BatchSize < 1 {
+			errs = append(errs, "book_snapshots: batch_size must be >= 1")
+		}
+	}
+
+	// Candles
+	if c.Candles.Enabled {
+		if c.Candles.FlushInterval.Duration <= 0 {
+			errs = append(errs, "candles: flush_interval must be > 0")
+		}
+		if c.Candles.Retention.Duration < 0 {
+			errs = append(errs, "candles: retention must be >= 0")
+		}
+	}
+
+	// Features
+	if c.Features.Enabled {
+		if c.Features.DepthLevels <= 0 {
+			errs = append(errs, "features: depth_levels must be > 0")
+		}
+		if c.Features.Window.Duration <= 0 {
+			errs = append(errs, "features: window must be > 0")
+		}
+		if c.Features.FlushInterval.Duration <= 0 {
+			errs = append(errs, "features: flush_interval must be > 0")
+		}
+	}
+
+	// Toxicity
+	if c.Toxicity.Enabled {
+		if c.Toxicity.BucketVolume <= 0 {
+			errs = append(errs, "toxicity: bucket_volume must be > 0")
+		}
+		if c.Toxicity.Buckets <= 0 {
+			errs = append(errs, "toxicity: buckets must be > 0")
+		}
+		if c.Toxicity.MinBuckets <= 0 || c.Toxicity.MinBuckets > c.Toxicity.Buckets {
+			errs = append(errs, fmt.Sprintf("toxicity: min_buckets must be 1-buckets (%d), got %d", c.Toxicity.Buckets, c.Toxicity.MinBuckets))
+		}
+		if c.Toxicity.FlushInterval.Duration <= 0 {
+			errs = append(errs, "toxicity: flush_interval must be > 0")
+		}
+	}
+
+	// Trade analytics
+	if c.TradeAnalytics.Enabled {
+		if w := c.TradeAnalytics.VWAPWindow.Duration; w <= 0 || w > 24*time.Hour {
+			errs = append(errs, "trade_analytics: vwap_window must be > 0 and <= 24h")
+		}
+		if w := c.TradeAnalytics.BucketWidth; w <= 0 || w > 1 {
+			errs = append(errs, "trade_analytics: bucket_width must be > 0 and <= 1")
+		}
+		if c.TradeAnalytics.FlushInterval.Duration <= 0 {
+			errs = append(errs, "trade_analytics: flush_interval must be > 0")
+		}
+	}
+
+	// Price feed
+	if c.PriceFeed.Enabled {
+		if len(c.PriceFeed.Sources) == 0 {
+			errs = append(errs, "pricefeed: sources must not be empty when enabled")
+		}
+		for _, src := range c.PriceFeed.Sources {
+			if !slices.Contains(PriceFeedSources, src) {
+				errs = append(errs, fmt.Sprintf("pricefeed: unknown source %q (valid: %s)", src, strings.Join(PriceFeedSources, ", ")))
+			}
+		}
+		if slices.Contains(c.PriceFeed.Sources, "binance") && c.PriceFeed.BinanceURL == "" {
+			errs = append(errs, "pricefeed: binance_url must not be empty when binance is a source")
+		}
+		if slices.Contains(c.PriceFeed.Sources, "coinbase") && c.PriceFeed.CoinbaseURL == "" {
+			errs = append(errs, "pricefeed: coinbase_url must not be empty when coinbase is a source")
+		}
+		if len(c.PriceFeed.Assets) == 0 {
+			errs = append(errs, "pricefeed: assets must not be empty when enabled")
+		}
+		if c.PriceFeed.PollInterval.Duration <= 0 {
+			errs = append(errs, "pricefeed: poll_interval must be > 0")
+		}
+		if c.PriceFeed.History.Duration < c.PriceFeed.PollInterval.Duration {
+			errs = append(errs, "pricefeed: history must be at least poll_interval")
+		}
+	}
+
+	// Fees
+	if c.Fees.Enabled && c.Fees.RefreshInterval.Duration <= 0 {
+		errs = append(errs, "fees: refresh_interval must be > 0")
+	}
+	if c.Fees.BuilderRebateBps < 0 {
+		errs = append(errs, "fees: builder_rebate_bps must be >= 0")
+	}
+
+	// Latency
+	if lc := c.Latency; lc.Enabled && (lc.Window <= 0 || lc.FlushInterval.Duration <= 0) {
+		errs = append(errs, "latency: window and flush_interval must be > 0")
+	}
+
+	// Increments
+	if ic := c.Increments; ic.Enabled {
+		if ic.RefreshInterval.Duration <= 0 {
+			errs = append(errs, "increments: refresh_interval must be > 0")
+		}
+		if ic.SizeStep < 0 || ic.DefaultMinSize < 0 {
+			errs = append(errs, "increments: size_step and default_min_size must be >= 0")
+		}
+		if ic.DefaultTickSize < 0 || ic.DefaultTickSize >= 1 {
+			errs = append(errs, "increments: default_tick_size must be in [0, 1)")
+		}
+	}
+
+	// Polygon
+	if c.Polygon.RPCURL != "" && c.Polygon.BalanceMaxAge.Duration <= 0 {
+		errs = append(errs, "polygon: balance_max_age must be > 0")
+	}
+
+	// Signals
+	if c.Signals.FlushInterval.Duration <= 0 {
+		errs = append(errs, "signals: flush_interval must be > 0")
+	}
+	if c.Signals.BatchSize <= 0 {
+		errs = append(errs, "signals: batch_size must be > 0")
+	}
+
+	// Hindsight
+	if c.Hindsight.Enabled {
+		if c.Hindsight.Horizon.Duration <= 0 {
+			errs = append(errs, "hindsight: horizon must be > 0")
+		}
+		if c.Hindsight.Interval.Duration <= 0 {
+			errs = append(errs, "hindsight: interval must be > 0")
+		}
+		if c.Hindsight.Window.Duration <= 0 {
+			errs = append(errs, "hindsight: window must be > 0")
+		}
+		if c.Hindsight.MaxSignals <= 0 {
+			errs = append(errs, "hindsight: max_signals must be > 0")
+		}
+	}
+
+	// Performance
+	if c.Performance.Enabled {
+		if c.Performance.Interval.Duration <= 0 {
+			errs = append(errs, "performance: interval must be > 0")
+		}
+		if c.Performance.BackfillDays < 1 {
+			errs = append(errs, "performance: backfill_days must be >= 1")
+		}
+	}
+	for _, d := range c.Performance.WindowDays {
+		if d < 1 || d > 366 {
+			errs = append(errs, fmt.Sprintf("performance: window_days must be in [1, 366], got %d", d))
+		}
+	}
+
+	// Capital
+	if c.Capital.Enabled {
+		for name, b := range c.Capital.BudgetsUSD {
+			if b < 0 {
+				errs = append(errs, fmt.Sprintf("capital: budgets_usd.%s must be >= 0", name))
+			}
+		}
+		if c.Capital.DefaultBudgetUSD < 0 {
+			errs = append(errs, "capital: default_budget_usd must be >= 0")
+		}
+		if c.Capital.RebalanceInterval.Duration < 0 {
+			errs = append(errs, "capital: rebalance_interval must be >= 0")
+		}
+		if c.Capital.RebalanceInterval.Duration > 0 {
+			if c.Capital.PerformanceDays < 1 || c.Capital.PerformanceDays > 366 {
+				errs = append(errs, "capital: performance_days must be in [1, 366]")
+			}
+			if c.Capital.MaxShift < 0 || c.Capital.MaxShift >= 1 {
+				errs = append(errs, "capital: max_shift must be in [0, 1)")
+			}
+		}
+	}
+
+	// MarketLists
+	if c.MarketLists.RefreshInterval.Duration <= 0 {
+		errs = append(errs, "market_lists: refresh_interval must be > 0")
+	}
+
+	// CrossMap
+	if c.CrossMap.Enabled {
+		if c.CrossMap.Interval.Duration <= 0 {
+			errs = append(errs, "crossmap: interval must be > 0")
+		}
+		if c.CrossMap.MinConfidence < 0 || c.CrossMap.MinConfidence > 1 {
+			errs = append(errs, fmt.Sprintf("crossmap: min_confidence must be in [0,1], got %g", c.CrossMap.MinConfidence))
+		}
+		if c.CrossMap.AutoApprove != 0 && (c.CrossMap.AutoApprove < c.CrossMap.MinConfidence || c.CrossMap.AutoApprove > 1) {
+			errs = append(errs, fmt.Sprintf("crossmap: auto_approve must be 0 or in [min_confidence,1], got %g", c.CrossMap.AutoApprove))
+		}
+		if c.CrossMap.MaxMarkets <= 0 {
+			errs = append(errs, "crossmap: max_markets must be > 0")
+		}
+	}
+
+	// Backtest
+	if c.Mode == "backtest" {
+		from, ferr := time.Parse(time.RFC3339, c.Backtest.From)
+		if ferr != nil {
+			errs = append(errs, fmt.Sprintf("backtest: from must be RFC3339, got %q", c.Backtest.From))
+		}
+		to, terr := time.Parse(time.RFC3339, c.Backtest.To)
+		if terr != nil {
+			errs = append(errs, fmt.Sprintf("backtest: to must be RFC3339, got %q", c.Backtest.To))
+		}
+		if ferr == nil && terr == nil && !from.Before(to) {
+			errs = append(errs, "backtest: from must be before to")
+		}
+		if c.Backtest.Source != "postgres" && c.Backtest.Source != "s3" {
+			errs = append(errs, fmt.Sprintf("backtest: source must be postgres or s3, got %q", c.Backtest.Source))
+		}
+		if c.Backtest.BookSource != "postgres" && c.Backtest.BookSource != "s3" {
+			errs = append(errs, fmt.Sprintf("backtest: book_source must be postgres or s3, got %q", c.Backtest.BookSource))
+		}
+		if c.Backtest.FeeBps < 0 {
+			errs = append(errs, "backtest: fee_bps must be >= 0")
+		}
+	}
+
+	// Backfill
+	if c.Mode == "backfill" {
+		from, ferr := time.Parse(time.RFC3339, c.Backfill.From)
+		if ferr != nil {
+			errs = append(errs, fmt.Sprintf("backfill: from must be RFC3339, got %q", c.Backfill.From))
+		}
+		to, terr := time.Parse(time.RFC3339, c.Backfill.To)
+		if terr != nil {
+			errs = append(errs, fmt.Sprintf("backfill: to must be RFC3339, got %q", c.Backfill.To))
+		}
+		if ferr == nil && terr == nil && !from.Before(to) {
+			errs = append(errs, "backfill: from must be before to")
+		}
+		if c.Pipeline.GoldskyURL == "" {
+			errs = append(errs, "backfill: pipeline.goldsky_url must be set")
+		}
+		if c.Backfill.Chunk.Duration <= 0 {
+			errs = append(errs, "backfill: chunk must be > 0")
+		}
+		if c.Backfill.Workers <= 0 {
+			errs = append(errs, "backfill: workers must be > 0")
+		}
+		if c.Backfill.PageSize <= 0 || c.Backfill.PageSize > 1000 {
+			errs = append(errs, fmt.Sprintf("backfill: page_size must be 1-1000, got %d", c.Backfill.PageSize))
+		}
+		if c.Backfill.RequestsPerSecond < 0 {
+			errs = append(errs, "backfill: requests_per_second must be >= 0")
+		}
+	}
 
 	// Server
 	if c.Server.Enabled {
 		if c.Server.Port <= 0 || c.Server.Port > 65535 {
 			errs = append(errs, fmt.Sprintf("server: port must be 1-65535, got %d", c.Server.Port))
 		}
+		if c.Server.WSFlushInterval.Duration < 0 {
+			errs = append(errs, "server: ws_flush_interval must be >= 0")
+		}
+		if c.Server.WSMaxBatch <= 0 {
+			errs = append(errs, "server: ws_max_batch must be > 0")
+		}
+		if auth := c.Server.Auth; auth.Enabled {
+			if len(auth.Keys) == 0 && auth.JWTSecret == "" && c.Server.AdminToken == "" {
+				errs = append(errs, "server.auth: enabled requires keys, jwt_secret or server.admin_token")
+			}
+			names := make(map[string]bool, len(auth.Keys))
+			keys := make(map[string]bool, len(auth.Keys))
+			for i, k := range auth.Keys {
+				if k.Name == "" || k.Key == "" {
+					errs = append(errs, fmt.Sprintf("server.auth: keys[%d] needs a name and a key", i))
+				}
+				if !slices.Contains([]string{"read_only", "trader", "admin"}, k.Role) {
+					errs = append(errs, fmt.Sprintf("server.auth: keys[%d] role must be read_only, trader or admin, got %q", i, k.Role))
+				}
+				if names[k.Name] {
+					errs = append(errs, fmt.Sprintf("server.auth: duplicate key name %q", k.Name))
+				}
+				if k.Key != "" && keys[k.Key] {
+					errs = append(errs, fmt.Sprintf("server.auth: keys[%d] repeats another key", i))
+				}
+				names[k.Name], keys[k.Key] = true, true
+			}
+			for route, role := range auth.Routes {
+				if !slices.Contains([]string{"public", "read_only", "trader", "admin"}, role) {
+					errs = append(errs, fmt.Sprintf("server.auth: routes[%q] must be public, read_only, trader or admin, got %q", route, role))
+				}
+			}
+		}
 	}
 
 	if len(errs) > 0 {
@@ -539,3 +2394,17 @@ func (c *Config) Validate() error {
 	}
 	return nil
 }
+
+// isHexAddress reports whether s is a 0x-prefixed 20-byte hex address.
+func isHexAddress(s string) bool {
+	h, ok := strings.CutPrefix(s, "0x")
+	if !ok || len(h) != 40 {
+		return false
+	}
+	for _, r := range h {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
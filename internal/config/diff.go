@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Change is one setting that differs between two configurations, keyed by
+// its dotted TOML name (e.g. "risk.daily_loss_limit_usd" or
+// "strategy.params.size"). Old and New are redacted like RedactedMap; nil
+// means the setting is absent on that side.
+type Change struct {
+	Key string
+	Old any
+	New any
+
+	path []string
+}
+
+// Diff returns the settings of next that differ from cur, sorted by key.
+// Tables and maps are compared leaf by leaf; arrays compare as a whole.
+func Diff(cur, next *Config) ([]Change, error) {
+	curRaw, err := tomlMap(cur)
+	if err != nil {
+		return nil, fmt.Errorf("config: diff: %w", err)
+	}
+	nextRaw, err := tomlMap(next)
+	if err != nil {
+		return nil, fmt.Errorf("config: diff: %w", err)
+	}
+	curRed, err := RedactedMap(cur)
+	if err != nil {
+		return nil, err
+	}
+	nextRed, err := RedactedMap(next)
+	if err != nil {
+		return nil, err
+	}
+
+	a, b := flatten(curRaw), flatten(nextRaw)
+	ra, rb := flatten(curRed), flatten(nextRed)
+	keys := make(map[string][]string, len(a))
+	for k, l := range a {
+		keys[k] = l.path
+	}
+	for k, l := range b {
+		keys[k] = l.path
+	}
+	var changes []Change
+	for k, path := range keys {
+		if reflect.DeepEqual(a[k].value, b[k].value) {
+			continue
+		}
+		changes = append(changes, Change{Key: k, Old: redactedLeaf(ra, k), New: redactedLeaf(rb, k), path: path})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes, nil
+}
+
+// Merge returns a copy of cur with the given changes, taken from a Diff of
+// cur against next, applied from next. Settings not listed keep cur's value.
+func Merge(cur, next *Config, changes []Change) (*Config, error) {
+	out, err := tomlMap(cur)
+	if err != nil {
+		return nil, fmt.Errorf("config: merge: %w", err)
+	}
+	src, err := tomlMap(next)
+	if err != nil {
+		return nil, fmt.Errorf("config: merge: %w", err)
+	}
+	for _, c := range changes {
+		if len(c.path) == 0 {
+			continue
+		}
+		v, ok := lookupPath(src, c.path)
+		setPath(out, c.path, v, ok)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(out); err != nil {
+		return nil, fmt.Errorf("config: merge: encode: %w", err)
+	}
+	merged := &Config{}
+	if _, err := toml.Decode(buf.String(), merged); err != nil {
+		return nil, fmt.Errorf("config: merge: decode: %w", err)
+	}
+	return merged, nil
+}
+
+// opaqueKeys are tables compared as a whole because their keys are
+// secrets.
+var opaqueKeys = map[string]bool{"server.ws_acl": true}
+
+type leaf struct {
+	path  []string
+	value any
+}
+
+// flatten maps the dotted key of every non-table value in m to the value.
+// Empty tables have no values.
+func flatten(m map[string]any) map[string]leaf {
+	out := make(map[string]leaf)
+	var walk func(prefix []string, m map[string]any)
+	walk = func(prefix []string, m map[string]any) {
+		for k, v := range m {
+			path := append(append([]string(nil), prefix...), k)
+			key := strings.Join(path, ".")
+			if sub, ok := v.(map[string]any); ok && !opaqueKeys[key] {
+				walk(path, sub)
+				continue
+			}
+			out[key] = leaf{path: path, value: v}
+		}
+	}
+	walk(nil, m)
+	return out
+}
+
+func redactedLeaf(m map[string]leaf, key string) any {
+	if l, ok := m[key]; ok {
+		return l.value
+	}
+	return nil
+}
+
+func lookupPath(m map[string]any, path []string) (any, bool) {
+	for i, k := range path {
+		v, ok := m[k]
+		if !ok {
+			return nil, false
+		}
+		if i == len(path)-1 {
+			return v, true
+		}
+		if m, ok = v.(map[string]any); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// setPath sets path in m to v, creating tables on the way, or deletes it
+// when ok is false.
+func setPath(m map[string]any, path []string, v any, ok bool) {
+	for _, k := range path[:len(path)-1] {
+		sub, isMap := m[k].(map[string]any)
+		if !isMap {
+			if !ok {
+				return
+			}
+			sub = make(map[string]any)
+			m[k] = sub
+		}
+		m = sub
+	}
+	last := path[len(path)-1]
+	if ok {
+		m[last] = v
+	} else {
+		delete(m, last)
+	}
+}
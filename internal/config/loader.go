@@ -46,6 +46,15 @@ func applyEnvOverrides(cfg *Config) {
 	setStr(&cfg.Polymarket.WsHost, "POLYBOT_POLYMARKET_WS_HOST")
 	setInt(&cfg.Polymarket.ChainID, "POLYBOT_POLYMARKET_CHAIN_ID")
 	setInt(&cfg.Polymarket.SignatureType, "POLYBOT_POLYMARKET_SIGNATURE_TYPE")
+	setBool(&cfg.Polymarket.UserChannel, "POLYBOT_POLYMARKET_USER_CHANNEL")
+	setDuration(&cfg.Polymarket.WsSilenceTimeout, "POLYBOT_POLYMARKET_WS_SILENCE_TIMEOUT")
+	setDuration(&cfg.Polymarket.WsReconnectAfter, "POLYBOT_POLYMARKET_WS_RECONNECT_AFTER")
+	setDuration(&cfg.Polymarket.WsAssetStaleTimeout, "POLYBOT_POLYMARKET_WS_ASSET_STALE_TIMEOUT")
+	setDuration(&cfg.Polymarket.RestPollInterval, "POLYBOT_POLYMARKET_REST_POLL_INTERVAL")
+	setBool(&cfg.Polymarket.WarmupBooks, "POLYBOT_POLYMARKET_WARMUP_BOOKS")
+	setInt(&cfg.Polymarket.WarmupConcurrency, "POLYBOT_POLYMARKET_WARMUP_CONCURRENCY")
+	setInt(&cfg.Polymarket.CollateralDecimals, "POLYBOT_POLYMARKET_COLLATERAL_DECIMALS")
+	setDuration(&cfg.Polymarket.WarmupTimeout, "POLYBOT_POLYMARKET_WARMUP_TIMEOUT")
 
 	// ── Builder ──
 	setStr(&cfg.Builder.ApiKey, "POLYBOT_BUILDER_API_KEY")
@@ -57,6 +66,12 @@ func applyEnvOverrides(cfg *Config) {
 	setStr(&cfg.Kalshi.RsaPrivateKeyPath, "POLYBOT_KALSHI_RSA_PRIVATE_KEY_PATH")
 	setStr(&cfg.Kalshi.BaseURL, "POLYBOT_KALSHI_BASE_URL")
 
+	// ── Read-only venues ──
+	setBool(&cfg.PredictIt.Enabled, "POLYBOT_PREDICTIT_ENABLED")
+	setStr(&cfg.PredictIt.BaseURL, "POLYBOT_PREDICTIT_BASE_URL")
+	setBool(&cfg.Manifold.Enabled, "POLYBOT_MANIFOLD_ENABLED")
+	setStr(&cfg.Manifold.BaseURL, "POLYBOT_MANIFOLD_BASE_URL")
+
 	// ── Supabase ──
 	setStr(&cfg.Supabase.DSN, "POLYBOT_SUPABASE_DSN")
 	setStr(&cfg.Supabase.DSN, "POLYBOT_SUPABASE_URL") // compatibility alias
@@ -82,6 +97,7 @@ func applyEnvOverrides(cfg *Config) {
 	setBool(&cfg.Redis.TLSEnabled, "POLYBOT_REDIS_TLS_ENABLED")
 	setInt(&cfg.Redis.StreamMaxLen, "POLYBOT_REDIS_STREAM_MAX_LEN")
 	setInt(&cfg.Redis.CacheTTLMinutes, "POLYBOT_REDIS_CACHE_TTL_MINUTES")
+	setStr(&cfg.Redis.KeyPrefix, "POLYBOT_REDIS_KEY_PREFIX")
 
 	// ── S3 ──
 	setStr(&cfg.S3.Endpoint, "POLYBOT_S3_ENDPOINT")
@@ -91,6 +107,8 @@ func applyEnvOverrides(cfg *Config) {
 	setStr(&cfg.S3.SecretKey, "POLYBOT_S3_SECRET_KEY")
 	setBool(&cfg.S3.UseSSL, "POLYBOT_S3_USE_SSL")
 	setBool(&cfg.S3.ForcePathStyle, "POLYBOT_S3_FORCE_PATH_STYLE")
+	setStr(&cfg.S3.Format, "POLYBOT_S3_FORMAT")
+	setBool(&cfg.S3.PartitionByMarket, "POLYBOT_S3_PARTITION_BY_MARKET")
 
 	// ── Strategy ──
 	setStr(&cfg.Strategy.Name, "POLYBOT_STRATEGY_NAME")
@@ -102,12 +120,49 @@ func applyEnvOverrides(cfg *Config) {
 	setInt(&cfg.Strategy.MaxPositions, "POLYBOT_STRATEGY_MAX_POSITIONS")
 	setFloat64(&cfg.Strategy.TakeProfit, "POLYBOT_STRATEGY_TAKE_PROFIT")
 	setFloat64(&cfg.Strategy.StopLoss, "POLYBOT_STRATEGY_STOP_LOSS")
+	setInt(&cfg.Strategy.EventBudgetMs, "POLYBOT_STRATEGY_EVENT_BUDGET_MS")
+	setStr(&cfg.Strategy.LegOrderType, "POLYBOT_STRATEGY_LEG_ORDER_TYPE")
+	setStringSlice(&cfg.Strategy.Shadow, "POLYBOT_STRATEGY_SHADOW")
+	setBool(&cfg.Strategy.PreferPersisted, "POLYBOT_STRATEGY_PREFER_PERSISTED")
+	setBool(&cfg.Strategy.Sampling.Enabled, "POLYBOT_STRATEGY_SAMPLING_ENABLED")
+	setStr(&cfg.Strategy.Sampling.Mode, "POLYBOT_STRATEGY_SAMPLING_MODE")
+	setInt(&cfg.Strategy.Sampling.EveryN, "POLYBOT_STRATEGY_SAMPLING_EVERY_N")
+	setDuration(&cfg.Strategy.Sampling.Interval, "POLYBOT_STRATEGY_SAMPLING_INTERVAL")
+	setFloat64(&cfg.Strategy.Sampling.MinLiquidityUSD, "POLYBOT_STRATEGY_SAMPLING_MIN_LIQUIDITY_USD")
+	setStringSlice(&cfg.Strategy.Sampling.Priority, "POLYBOT_STRATEGY_SAMPLING_PRIORITY")
+	setStringSlice(&cfg.Strategy.Sampling.Sampled, "POLYBOT_STRATEGY_SAMPLING_SAMPLED")
+	setBool(&cfg.Strategy.Breaker.Enabled, "POLYBOT_STRATEGY_BREAKER_ENABLED")
+	setInt(&cfg.Strategy.Breaker.MaxConsecutiveFailures, "POLYBOT_STRATEGY_BREAKER_MAX_CONSECUTIVE_FAILURES")
+	setFloat64(&cfg.Strategy.Breaker.MaxLossUSD, "POLYBOT_STRATEGY_BREAKER_MAX_LOSS_USD")
+	setDuration(&cfg.Strategy.Breaker.LossWindow, "POLYBOT_STRATEGY_BREAKER_LOSS_WINDOW")
+	setInt(&cfg.Strategy.Queue.BufferSize, "POLYBOT_STRATEGY_QUEUE_BUFFER_SIZE")
+	setStr(&cfg.Strategy.Queue.Overflow, "POLYBOT_STRATEGY_QUEUE_OVERFLOW")
+	setDuration(&cfg.Strategy.Queue.BlockTimeout, "POLYBOT_STRATEGY_QUEUE_BLOCK_TIMEOUT")
+	setInt(&cfg.Strategy.Candidates.MaxCandidates, "POLYBOT_STRATEGY_CANDIDATES_MAX_CANDIDATES")
+	setDuration(&cfg.Strategy.Candidates.MaxAge, "POLYBOT_STRATEGY_CANDIDATES_MAX_AGE")
+	setDuration(&cfg.Strategy.Candidates.FreshnessHalfLife, "POLYBOT_STRATEGY_CANDIDATES_FRESHNESS_HALF_LIFE")
 	setBool(&cfg.Strategy.YesNoSpread.Enabled, "POLYBOT_STRATEGY_YES_NO_SPREAD_ENABLED")
+	setBool(&cfg.Strategy.Bond.EarlyExit, "POLYBOT_STRATEGY_BOND_EARLY_EXIT")
+	setFloat64(&cfg.Strategy.Bond.StopPrice, "POLYBOT_STRATEGY_BOND_STOP_PRICE")
+	setFloat64(&cfg.Strategy.Bond.MinVolume24h, "POLYBOT_STRATEGY_BOND_MIN_VOLUME_24H")
+	setFloat64(&cfg.Strategy.LiquidityProvider.MaxInventory, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_MAX_INVENTORY")
+	setInt(&cfg.Strategy.LiquidityProvider.InventorySkewBps, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_INVENTORY_SKEW_BPS")
+	setFloat64(&cfg.Strategy.LiquidityProvider.MaxVolatility, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_MAX_VOLATILITY")
+	setFloat64(&cfg.Strategy.LiquidityProvider.ToxicityWiden, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_TOXICITY_WIDEN")
+	setFloat64(&cfg.Strategy.LiquidityProvider.ToxicityPause, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_TOXICITY_PAUSE")
+	setFloat64(&cfg.Strategy.LiquidityProvider.MinVolume24h, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_MIN_VOLUME_24H")
+	setFloat64(&cfg.Strategy.LiquidityProvider.MinRewardUSD, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_MIN_REWARD_USD")
+	setDuration(&cfg.Strategy.LiquidityProvider.RewardSampleInterval, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_REWARD_SAMPLE_INTERVAL")
+	setInt(&cfg.Strategy.LiquidityProvider.Levels, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_LEVELS")
+	setInt(&cfg.Strategy.LiquidityProvider.LevelSpacingBps, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_LEVEL_SPACING_BPS")
+	setFloat64(&cfg.Strategy.LiquidityProvider.SizeDecay, "POLYBOT_STRATEGY_LIQUIDITY_PROVIDER_SIZE_DECAY")
 	setBool(&cfg.Strategy.CrossPlatformArb.Enabled, "POLYBOT_STRATEGY_CROSS_PLATFORM_ARB_ENABLED")
 	setBool(&cfg.Strategy.TemporalOverlap.Enabled, "POLYBOT_STRATEGY_TEMPORAL_OVERLAP_ENABLED")
+	setBool(&cfg.Strategy.LatencyArb.Enabled, "POLYBOT_STRATEGY_LATENCY_ARB_ENABLED")
 
 	// ── Arbitrage ──
 	setStr(&cfg.Arbitrage.Strategy, "POLYBOT_ARBITRAGE_STRATEGY")
+	setStringSlice(&cfg.Arbitrage.Strategies, "POLYBOT_ARBITRAGE_STRATEGIES")
 	setBool(&cfg.Arbitrage.Enabled, "POLYBOT_ARBITRAGE_ENABLED")
 	setFloat64(&cfg.Arbitrage.MinNetEdgeBps, "POLYBOT_ARBITRAGE_MIN_NET_EDGE_BPS")
 	setFloat64(&cfg.Arbitrage.MaxTradeAmount, "POLYBOT_ARBITRAGE_MAX_TRADE_AMOUNT")
@@ -117,8 +172,62 @@ func applyEnvOverrides(cfg *Config) {
 	setInt64(&cfg.Arbitrage.MinDurationMs, "POLYBOT_ARBITRAGE_MIN_DURATION_MS")
 	setInt64(&cfg.Arbitrage.MaxLegGapMs, "POLYBOT_ARBITRAGE_MAX_LEG_GAP_MS")
 	setFloat64(&cfg.Arbitrage.MaxUnhedgedNotional, "POLYBOT_ARBITRAGE_MAX_UNHEDGED_NOTIONAL")
+	setFloat64(&cfg.Arbitrage.HedgeBudgetUSD, "POLYBOT_ARBITRAGE_HEDGE_BUDGET_USD")
 	setFloat64(&cfg.Arbitrage.MaxSlippageBps, "POLYBOT_ARBITRAGE_MAX_SLIPPAGE_BPS")
 	setFloat64(&cfg.Arbitrage.KillSwitchLossUSD, "POLYBOT_ARBITRAGE_KILL_SWITCH_LOSS_USD")
+	setDuration(&cfg.Arbitrage.OpportunityDedupWindow, "POLYBOT_ARBITRAGE_OPPORTUNITY_DEDUP_WINDOW")
+	setDuration(&cfg.Arbitrage.OpportunityTTL, "POLYBOT_ARBITRAGE_OPPORTUNITY_TTL")
+	setInt(&cfg.Arbitrage.LegTopUpAttempts, "POLYBOT_ARBITRAGE_LEG_TOPUP_ATTEMPTS")
+	setDuration(&cfg.Arbitrage.LegTopUpAfter, "POLYBOT_ARBITRAGE_LEG_TOPUP_AFTER")
+	setBool(&cfg.Arbitrage.SweepImmediate, "POLYBOT_ARBITRAGE_SWEEP_IMMEDIATE")
+	setFloat64(&cfg.Arbitrage.SweepEdgeFraction, "POLYBOT_ARBITRAGE_SWEEP_EDGE_FRACTION")
+	setDuration(&cfg.Arbitrage.UnhedgedCheckInterval, "POLYBOT_ARBITRAGE_UNHEDGED_CHECK_INTERVAL")
+	setBool(&cfg.Arbitrage.UnwindAllOrNone, "POLYBOT_ARBITRAGE_UNWIND_ALL_OR_NONE")
+	setDuration(&cfg.Arbitrage.UnhedgedLookback, "POLYBOT_ARBITRAGE_UNHEDGED_LOOKBACK")
+	setBool(&cfg.Arbitrage.UnhedgedCorrective, "POLYBOT_ARBITRAGE_UNHEDGED_CORRECTIVE")
+	setInt(&cfg.Arbitrage.Imbalance.Levels, "POLYBOT_ARBITRAGE_IMBALANCE_LEVELS")
+	setFloat64(&cfg.Arbitrage.Imbalance.LevelDecay, "POLYBOT_ARBITRAGE_IMBALANCE_LEVEL_DECAY")
+	setDuration(&cfg.Arbitrage.Imbalance.HalfLife, "POLYBOT_ARBITRAGE_IMBALANCE_HALF_LIFE")
+	setBool(&cfg.Arbitrage.Imbalance.TradeFlow, "POLYBOT_ARBITRAGE_IMBALANCE_TRADE_FLOW")
+	setDuration(&cfg.Arbitrage.Imbalance.FlowHalfLife, "POLYBOT_ARBITRAGE_IMBALANCE_FLOW_HALF_LIFE")
+	setFloat64(&cfg.Arbitrage.Imbalance.MinFlowUSD, "POLYBOT_ARBITRAGE_IMBALANCE_MIN_FLOW_USD")
+	setFloat64(&cfg.Arbitrage.Imbalance.MinTotalVolume, "POLYBOT_ARBITRAGE_IMBALANCE_MIN_TOTAL_VOLUME")
+	setFloat64(&cfg.Arbitrage.Imbalance.EdgeBpsPerRatio, "POLYBOT_ARBITRAGE_IMBALANCE_EDGE_BPS_PER_RATIO")
+
+	// ── Risk ──
+	setDuration(&cfg.Risk.CloseHaircutHorizon, "POLYBOT_RISK_CLOSE_HAIRCUT_HORIZON")
+	setFloat64(&cfg.Risk.CloseHaircutMinFactor, "POLYBOT_RISK_CLOSE_HAIRCUT_MIN_FACTOR")
+	setFloat64(&cfg.Risk.DailyLossLimitUSD, "POLYBOT_RISK_DAILY_LOSS_LIMIT_USD")
+	setDuration(&cfg.Risk.PortfolioCheckInterval, "POLYBOT_RISK_PORTFOLIO_CHECK_INTERVAL")
+	setDuration(&cfg.Risk.MetadataPollInterval, "POLYBOT_RISK_METADATA_POLL_INTERVAL")
+	setDuration(&cfg.Risk.ResolutionPollInterval, "POLYBOT_RISK_RESOLUTION_POLL_INTERVAL")
+	setStr(&cfg.Risk.MinSizePolicy, "POLYBOT_RISK_MIN_SIZE_POLICY")
+	setFloat64(&cfg.Risk.RedeemGasUSD, "POLYBOT_RISK_REDEEM_GAS_USD")
+	setFloat64(&cfg.Risk.DefaultEdgeBps, "POLYBOT_RISK_DEFAULT_EDGE_BPS")
+	setFloat64(&cfg.Risk.CancelRatioLimit, "POLYBOT_RISK_CANCEL_RATIO_LIMIT")
+	setFloat64(&cfg.Risk.CancelRatioWarnAt, "POLYBOT_RISK_CANCEL_RATIO_WARN_AT")
+	setInt(&cfg.Risk.CancelRatioMinCancels, "POLYBOT_RISK_CANCEL_RATIO_MIN_CANCELS")
+	setBool(&cfg.Risk.CancelAllOnShutdown, "POLYBOT_RISK_CANCEL_ALL_ON_SHUTDOWN")
+	setDuration(&cfg.Risk.ShutdownCancelTimeout, "POLYBOT_RISK_SHUTDOWN_CANCEL_TIMEOUT")
+	setDuration(&cfg.Risk.StaleOrderInterval, "POLYBOT_RISK_STALE_ORDER_INTERVAL")
+	setStr(&cfg.Risk.Slippage.Model, "POLYBOT_RISK_SLIPPAGE_MODEL")
+	setFloat64(&cfg.Risk.Slippage.MaxBps, "POLYBOT_RISK_SLIPPAGE_MAX_BPS")
+
+	// ── Executor ──
+	setInt(&cfg.Executor.Workers, "POLYBOT_EXECUTOR_WORKERS")
+	setInt(&cfg.Executor.QueueDepth, "POLYBOT_EXECUTOR_QUEUE_DEPTH")
+
+	// ── RateLimit ──
+	setBool(&cfg.RateLimit.Enabled, "POLYBOT_RATELIMIT_ENABLED")
+	setInt(&cfg.RateLimit.OrdersPerSecond, "POLYBOT_RATELIMIT_ORDERS_PER_SECOND")
+
+	// ── Retry ──
+	setBool(&cfg.Retry.Enabled, "POLYBOT_RETRY_ENABLED")
+	setInt(&cfg.Retry.MaxAttempts, "POLYBOT_RETRY_MAX_ATTEMPTS")
+	setDuration(&cfg.Retry.BaseDelay, "POLYBOT_RETRY_BASE_DELAY")
+	setDuration(&cfg.Retry.MaxDelay, "POLYBOT_RETRY_MAX_DELAY")
+	setFloat64(&cfg.Retry.RateLimitFactor, "POLYBOT_RETRY_RATE_LIMIT_FACTOR")
+	setFloat64(&cfg.Retry.Jitter, "POLYBOT_RETRY_JITTER")
 
 	// ── Pipeline ──
 	setBool(&cfg.Pipeline.Enabled, "POLYBOT_PIPELINE_ENABLED")
@@ -128,17 +237,177 @@ func applyEnvOverrides(cfg *Config) {
 	setInt(&cfg.Pipeline.ArchiveRetentionDays, "POLYBOT_PIPELINE_ARCHIVE_RETENTION_DAYS")
 	setStr(&cfg.Pipeline.ArchiveCron, "POLYBOT_PIPELINE_ARCHIVE_CRON")
 	setInt(&cfg.Pipeline.S3ArchiveRetentionMonths, "POLYBOT_PIPELINE_S3_ARCHIVE_RETENTION_MONTHS")
+	setStr(&cfg.Pipeline.GoldskyActivityURL, "POLYBOT_PIPELINE_GOLDSKY_ACTIVITY_URL")
+	setStringSlice(&cfg.Pipeline.OnchainWallets, "POLYBOT_PIPELINE_ONCHAIN_WALLETS")
+	setDuration(&cfg.Pipeline.OnchainLookback, "POLYBOT_PIPELINE_ONCHAIN_LOOKBACK")
+	setInt(&cfg.Pipeline.MarketPageSize, "POLYBOT_PIPELINE_MARKET_PAGE_SIZE")
+	setDuration(&cfg.Pipeline.MarketFullSyncInterval, "POLYBOT_PIPELINE_MARKET_FULL_SYNC_INTERVAL")
+	setDuration(&cfg.Pipeline.DLQRetryInterval, "POLYBOT_PIPELINE_DLQ_RETRY_INTERVAL")
+	setInt(&cfg.Pipeline.DLQRetryBatches, "POLYBOT_PIPELINE_DLQ_RETRY_BATCHES")
+	setInt(&cfg.Pipeline.DLQMaxAttempts, "POLYBOT_PIPELINE_DLQ_MAX_ATTEMPTS")
 
 	// ── Server ──
 	setBool(&cfg.Server.Enabled, "POLYBOT_SERVER_ENABLED")
 	setInt(&cfg.Server.Port, "POLYBOT_SERVER_PORT")
 	setStringSlice(&cfg.Server.CORSOrigins, "POLYBOT_SERVER_CORS_ORIGINS")
+	setStr(&cfg.Server.WSToken, "POLYBOT_SERVER_WS_TOKEN")
+	setStr(&cfg.Server.AdminToken, "POLYBOT_SERVER_ADMIN_TOKEN")
+	setDuration(&cfg.Server.WSFlushInterval, "POLYBOT_SERVER_WS_FLUSH_INTERVAL")
+	setInt(&cfg.Server.WSMaxBatch, "POLYBOT_SERVER_WS_MAX_BATCH")
+	setBool(&cfg.Server.Auth.Enabled, "POLYBOT_SERVER_AUTH_ENABLED")
+	setStr(&cfg.Server.Auth.JWTSecret, "POLYBOT_SERVER_AUTH_JWT_SECRET")
+	setAPIKeys(&cfg.Server.Auth.Keys, "POLYBOT_SERVER_AUTH_KEYS")
+
+	// ── Accounting ──
+	setStr(&cfg.Accounting.LotMethod, "POLYBOT_ACCOUNTING_LOT_METHOD")
+
+	// ── Sweep ──
+	setBool(&cfg.Sweep.Enabled, "POLYBOT_SWEEP_ENABLED")
+	setStr(&cfg.Sweep.RPCURL, "POLYBOT_SWEEP_RPC_URL")
+	setStr(&cfg.Sweep.ColdAddress, "POLYBOT_SWEEP_COLD_ADDRESS")
+	setStr(&cfg.Sweep.USDCAddress, "POLYBOT_SWEEP_USDC_ADDRESS")
+	setFloat64(&cfg.Sweep.PnLThreshold, "POLYBOT_SWEEP_PNL_THRESHOLD")
+	setFloat64(&cfg.Sweep.WorkingCapital, "POLYBOT_SWEEP_WORKING_CAPITAL")
+	setFloat64(&cfg.Sweep.MinAmount, "POLYBOT_SWEEP_MIN_AMOUNT")
+	setDuration(&cfg.Sweep.CheckInterval, "POLYBOT_SWEEP_CHECK_INTERVAL")
+	setInt(&cfg.Sweep.Confirmations, "POLYBOT_SWEEP_CONFIRMATIONS")
+	setDuration(&cfg.Sweep.ConfirmTimeout, "POLYBOT_SWEEP_CONFIRM_TIMEOUT")
+
+	// ── Redemption ──
+	setBool(&cfg.Redemption.Enabled, "POLYBOT_REDEMPTION_ENABLED")
+	setDuration(&cfg.Redemption.CheckInterval, "POLYBOT_REDEMPTION_CHECK_INTERVAL")
+	setDuration(&cfg.Redemption.Lookback, "POLYBOT_REDEMPTION_LOOKBACK")
+	setFloat64(&cfg.Redemption.MinProceeds, "POLYBOT_REDEMPTION_MIN_PROCEEDS")
+	setInt(&cfg.Redemption.Confirmations, "POLYBOT_REDEMPTION_CONFIRMATIONS")
+	setDuration(&cfg.Redemption.ConfirmTimeout, "POLYBOT_REDEMPTION_CONFIRM_TIMEOUT")
 
 	// ── Notify ──
 	setStr(&cfg.Notify.TelegramToken, "POLYBOT_NOTIFY_TELEGRAM_TOKEN")
 	setStr(&cfg.Notify.TelegramChatID, "POLYBOT_NOTIFY_TELEGRAM_CHAT_ID")
 	setStr(&cfg.Notify.DiscordWebhookURL, "POLYBOT_NOTIFY_DISCORD_WEBHOOK_URL")
 	setStringSlice(&cfg.Notify.Events, "POLYBOT_NOTIFY_EVENTS")
+	setStringSlice(&cfg.Notify.Channels, "POLYBOT_NOTIFY_CHANNELS")
+	setInt(&cfg.Notify.RatePerMinute, "POLYBOT_NOTIFY_RATE_PER_MINUTE")
+	setInt(&cfg.Notify.MaxRetries, "POLYBOT_NOTIFY_MAX_RETRIES")
+	setDuration(&cfg.Notify.RetryBackoff, "POLYBOT_NOTIFY_RETRY_BACKOFF")
+
+	// ── Recorder ──
+	setBool(&cfg.Recorder.Enabled, "POLYBOT_RECORDER_ENABLED")
+	setDuration(&cfg.Recorder.FlushInterval, "POLYBOT_RECORDER_FLUSH_INTERVAL")
+	setInt(&cfg.Recorder.BatchSize, "POLYBOT_RECORDER_BATCH_SIZE")
+	setDuration(&cfg.Recorder.Retention, "POLYBOT_RECORDER_RETENTION")
+
+	// ── Book snapshots ──
+	setBool(&cfg.BookSnapshots.Enabled, "POLYBOT_BOOK_SNAPSHOTS_ENABLED")
+	setStringSlice(&cfg.BookSnapshots.Assets, "POLYBOT_BOOK_SNAPSHOTS_ASSETS")
+	setDuration(&cfg.BookSnapshots.Interval, "POLYBOT_BOOK_SNAPSHOTS_INTERVAL")
+	setDuration(&cfg.BookSnapshots.MinInterval, "POLYBOT_BOOK_SNAPSHOTS_MIN_INTERVAL")
+	setDuration(&cfg.BookSnapshots.FlushInterval, "POLYBOT_BOOK_SNAPSHOTS_FLUSH_INTERVAL")
+	setInt(&cfg.BookSnapshots.BatchSize, "POLYBOT_BOOK_SNAPSHOTS_BATCH_SIZE")
+
+	// ── Candles ──
+	setBool(&cfg.Candles.Enabled, "POLYBOT_CANDLES_ENABLED")
+	setDuration(&cfg.Candles.FlushInterval, "POLYBOT_CANDLES_FLUSH_INTERVAL")
+	setDuration(&cfg.Candles.Retention, "POLYBOT_CANDLES_RETENTION")
+
+	// ── Features ──
+	setBool(&cfg.Features.Enabled, "POLYBOT_FEATURES_ENABLED")
+	setInt(&cfg.Features.DepthLevels, "POLYBOT_FEATURES_DEPTH_LEVELS")
+	setDuration(&cfg.Features.Window, "POLYBOT_FEATURES_WINDOW")
+	setDuration(&cfg.Features.FlushInterval, "POLYBOT_FEATURES_FLUSH_INTERVAL")
+
+	// ── Toxicity ──
+	setBool(&cfg.Toxicity.Enabled, "POLYBOT_TOXICITY_ENABLED")
+	setFloat64(&cfg.Toxicity.BucketVolume, "POLYBOT_TOXICITY_BUCKET_VOLUME")
+	setInt(&cfg.Toxicity.Buckets, "POLYBOT_TOXICITY_BUCKETS")
+	setInt(&cfg.Toxicity.MinBuckets, "POLYBOT_TOXICITY_MIN_BUCKETS")
+	setDuration(&cfg.Toxicity.FlushInterval, "POLYBOT_TOXICITY_FLUSH_INTERVAL")
+
+	// ── Trade analytics ──
+	setBool(&cfg.TradeAnalytics.Enabled, "POLYBOT_TRADE_ANALYTICS_ENABLED")
+	setDuration(&cfg.TradeAnalytics.VWAPWindow, "POLYBOT_TRADE_ANALYTICS_VWAP_WINDOW")
+	setFloat64(&cfg.TradeAnalytics.BucketWidth, "POLYBOT_TRADE_ANALYTICS_BUCKET_WIDTH")
+	setDuration(&cfg.TradeAnalytics.FlushInterval, "POLYBOT_TRADE_ANALYTICS_FLUSH_INTERVAL")
+
+	// ── Price feed ──
+	setBool(&cfg.PriceFeed.Enabled, "POLYBOT_PRICEFEED_ENABLED")
+	setStringSlice(&cfg.PriceFeed.Sources, "POLYBOT_PRICEFEED_SOURCES")
+	setStringSlice(&cfg.PriceFeed.Assets, "POLYBOT_PRICEFEED_ASSETS")
+	setDuration(&cfg.PriceFeed.PollInterval, "POLYBOT_PRICEFEED_POLL_INTERVAL")
+	setDuration(&cfg.PriceFeed.History, "POLYBOT_PRICEFEED_HISTORY")
+	setStr(&cfg.PriceFeed.BinanceURL, "POLYBOT_PRICEFEED_BINANCE_URL")
+	setStr(&cfg.PriceFeed.CoinbaseURL, "POLYBOT_PRICEFEED_COINBASE_URL")
+
+	// ── Fees ──
+	setBool(&cfg.Fees.Enabled, "POLYBOT_FEES_ENABLED")
+	setDuration(&cfg.Fees.RefreshInterval, "POLYBOT_FEES_REFRESH_INTERVAL")
+	setFloat64(&cfg.Fees.BuilderRebateBps, "POLYBOT_FEES_BUILDER_REBATE_BPS")
+	setBool(&cfg.Latency.Enabled, "POLYBOT_LATENCY_ENABLED")
+	setInt(&cfg.Latency.Window, "POLYBOT_LATENCY_WINDOW")
+	setDuration(&cfg.Latency.FlushInterval, "POLYBOT_LATENCY_FLUSH_INTERVAL")
+	setBool(&cfg.Increments.Enabled, "POLYBOT_INCREMENTS_ENABLED")
+	setDuration(&cfg.Increments.RefreshInterval, "POLYBOT_INCREMENTS_REFRESH_INTERVAL")
+	setFloat64(&cfg.Increments.SizeStep, "POLYBOT_INCREMENTS_SIZE_STEP")
+	setFloat64(&cfg.Increments.DefaultTickSize, "POLYBOT_INCREMENTS_DEFAULT_TICK_SIZE")
+	setFloat64(&cfg.Increments.DefaultMinSize, "POLYBOT_INCREMENTS_DEFAULT_MIN_SIZE")
+
+	// ── Polygon ──
+	setStr(&cfg.Polygon.RPCURL, "POLYBOT_POLYGON_RPC_URL")
+	setBool(&cfg.Polygon.BalanceCheck, "POLYBOT_POLYGON_BALANCE_CHECK")
+	setDuration(&cfg.Polygon.BalanceMaxAge, "POLYBOT_POLYGON_BALANCE_MAX_AGE")
+
+	// ── Signals ──
+	setBool(&cfg.Signals.Record, "POLYBOT_SIGNALS_RECORD")
+	setDuration(&cfg.Signals.FlushInterval, "POLYBOT_SIGNALS_FLUSH_INTERVAL")
+	setInt(&cfg.Signals.BatchSize, "POLYBOT_SIGNALS_BATCH_SIZE")
+
+	// ── Hindsight ──
+	setBool(&cfg.Hindsight.Enabled, "POLYBOT_HINDSIGHT_ENABLED")
+	setDuration(&cfg.Hindsight.Horizon, "POLYBOT_HINDSIGHT_HORIZON")
+	setDuration(&cfg.Hindsight.Interval, "POLYBOT_HINDSIGHT_INTERVAL")
+	setDuration(&cfg.Hindsight.Window, "POLYBOT_HINDSIGHT_WINDOW")
+	setInt(&cfg.Hindsight.MaxSignals, "POLYBOT_HINDSIGHT_MAX_SIGNALS")
+
+	// ── Performance ──
+	setBool(&cfg.Performance.Enabled, "POLYBOT_PERFORMANCE_ENABLED")
+	setDuration(&cfg.Performance.Interval, "POLYBOT_PERFORMANCE_INTERVAL")
+	setInt(&cfg.Performance.BackfillDays, "POLYBOT_PERFORMANCE_BACKFILL_DAYS")
+	setIntSlice(&cfg.Performance.WindowDays, "POLYBOT_PERFORMANCE_WINDOW_DAYS")
+
+	// ── Capital ──
+	setBool(&cfg.Capital.Enabled, "POLYBOT_CAPITAL_ENABLED")
+	setFloat64(&cfg.Capital.DefaultBudgetUSD, "POLYBOT_CAPITAL_DEFAULT_BUDGET_USD")
+	setDuration(&cfg.Capital.RebalanceInterval, "POLYBOT_CAPITAL_REBALANCE_INTERVAL")
+	setInt(&cfg.Capital.PerformanceDays, "POLYBOT_CAPITAL_PERFORMANCE_DAYS")
+	setFloat64(&cfg.Capital.MaxShift, "POLYBOT_CAPITAL_MAX_SHIFT")
+
+	// ── CrossMap ──
+	setBool(&cfg.CrossMap.Enabled, "POLYBOT_CROSSMAP_ENABLED")
+	setDuration(&cfg.CrossMap.Interval, "POLYBOT_CROSSMAP_INTERVAL")
+	setFloat64(&cfg.CrossMap.MinConfidence, "POLYBOT_CROSSMAP_MIN_CONFIDENCE")
+	setFloat64(&cfg.CrossMap.AutoApprove, "POLYBOT_CROSSMAP_AUTO_APPROVE")
+	setInt(&cfg.CrossMap.MaxMarkets, "POLYBOT_CROSSMAP_MAX_MARKETS")
+
+	// ── MarketLists ──
+	setDuration(&cfg.MarketLists.RefreshInterval, "POLYBOT_MARKET_LISTS_REFRESH_INTERVAL")
+
+	// ── Backtest ──
+	setStr(&cfg.Backtest.From, "POLYBOT_BACKTEST_FROM")
+	setStr(&cfg.Backtest.To, "POLYBOT_BACKTEST_TO")
+	setStr(&cfg.Backtest.Source, "POLYBOT_BACKTEST_SOURCE")
+	setStr(&cfg.Backtest.BookSource, "POLYBOT_BACKTEST_BOOK_SOURCE")
+	setStringSlice(&cfg.Backtest.Strategies, "POLYBOT_BACKTEST_STRATEGIES")
+	setFloat64(&cfg.Backtest.FeeBps, "POLYBOT_BACKTEST_FEE_BPS")
+	setInt(&cfg.Backtest.MaxEvents, "POLYBOT_BACKTEST_MAX_EVENTS")
+	setStr(&cfg.Backtest.ReportPath, "POLYBOT_BACKTEST_REPORT_PATH")
+
+	// ── Backfill ──
+	setStr(&cfg.Backfill.From, "POLYBOT_BACKFILL_FROM")
+	setStr(&cfg.Backfill.To, "POLYBOT_BACKFILL_TO")
+	setDuration(&cfg.Backfill.Chunk, "POLYBOT_BACKFILL_CHUNK")
+	setInt(&cfg.Backfill.Workers, "POLYBOT_BACKFILL_WORKERS")
+	setInt(&cfg.Backfill.PageSize, "POLYBOT_BACKFILL_PAGE_SIZE")
+	setFloat64(&cfg.Backfill.RequestsPerSecond, "POLYBOT_BACKFILL_REQUESTS_PER_SECOND")
 
 	// ── Top-level ──
 	setStr(&cfg.Mode, "POLYBOT_MODE")
@@ -196,6 +465,20 @@ func setDuration(dst *duration, key string) {
 	}
 }
 
+func setIntSlice(dst *[]int, key string) {
+	if v := os.Getenv(key); v != "" {
+		var out []int
+		for _, p := range strings.Split(v, ",") {
+			if n, err := strconv.Atoi(strings.TrimSpace(p)); err == nil {
+				out = append(out, n)
+			}
+		}
+		if len(out) > 0 {
+			*dst = out
+		}
+	}
+}
+
 func setStringSlice(dst *[]string, key string) {
 	if v := os.Getenv(key); v != "" {
 		parts := strings.Split(v, ",")
@@ -211,3 +494,20 @@ func setStringSlice(dst *[]string, key string) {
 		}
 	}
 }
+
+// setAPIKeys reads comma-separated "name:role:key" entries; malformed
+// entries are skipped. The list replaces the configured keys.
+func setAPIKeys(dst *[]APIKeyConfig, key string) {
+	if v := os.Getenv(key); v != "" {
+		var out []APIKeyConfig
+		for _, p := range strings.Split(v, ",") {
+			parts := strings.SplitN(strings.TrimSpace(p), ":", 3)
+			if len(parts) == 3 && parts[0] != "" && parts[2] != "" {
+				out = append(out, APIKeyConfig{Name: parts[0], Role: parts[1], Key: parts[2]})
+			}
+		}
+		if len(out) > 0 {
+			*dst = out
+		}
+	}
+}
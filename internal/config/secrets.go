@@ -1,5 +1,12 @@
 package config
 
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
 // RedactedConfig returns a shallow copy of cfg with sensitive fields replaced
 // by the redaction placeholder "***". Use this when logging or printing the
 // active configuration so secrets are never accidentally exposed.
@@ -36,6 +43,22 @@ func RedactedConfig(cfg *Config) Config {
 	redact(&out.S3.AccessKey)
 	redact(&out.S3.SecretKey)
 
+	// Server: ACL keys are tokens too.
+	out.Server = cfg.Server
+	redact(&out.Server.WSToken)
+	redact(&out.Server.AdminToken)
+	if len(cfg.Server.WSACL) > 0 {
+		out.Server.WSACL = map[string][]string{redacted: {}}
+	}
+	redact(&out.Server.Auth.JWTSecret)
+	if cfg.Server.Auth.Keys != nil {
+		out.Server.Auth.Keys = make([]APIKeyConfig, len(cfg.Server.Auth.Keys))
+		for i, k := range cfg.Server.Auth.Keys {
+			redact(&k.Key)
+			out.Server.Auth.Keys[i] = k
+		}
+	}
+
 	// Notify
 	out.Notify = cfg.Notify
 	redact(&out.Notify.TelegramToken)
@@ -59,6 +82,12 @@ func RedactedConfig(cfg *Config) Config {
 			out.Strategy.Params[k] = v
 		}
 	}
+	if cfg.Server.Auth.Routes != nil {
+		out.Server.Auth.Routes = make(map[string]string, len(cfg.Server.Auth.Routes))
+		for k, v := range cfg.Server.Auth.Routes {
+			out.Server.Auth.Routes[k] = v
+		}
+	}
 	if cfg.Arbitrage.PerVenueFeeBps != nil {
 		out.Arbitrage.PerVenueFeeBps = make(map[string]float64, len(cfg.Arbitrage.PerVenueFeeBps))
 		for k, v := range cfg.Arbitrage.PerVenueFeeBps {
@@ -69,6 +98,31 @@ func RedactedConfig(cfg *Config) Config {
 	return out
 }
 
+// RedactedMap returns RedactedConfig(cfg) as a generic map keyed by the TOML
+// names used in config.toml, so JSON APIs expose the same keys operators
+// write.
+func RedactedMap(cfg *Config) (map[string]any, error) {
+	red := RedactedConfig(cfg)
+	out, err := tomlMap(red)
+	if err != nil {
+		return nil, fmt.Errorf("config: redacted: %w", err)
+	}
+	return out, nil
+}
+
+// tomlMap round-trips v through TOML into a generic map keyed by TOML names.
+func tomlMap(v any) (map[string]any, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	var out map[string]any
+	if _, err := toml.Decode(buf.String(), &out); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	return out, nil
+}
+
 const redacted = "***"
 
 // redact replaces a non-empty string with the redacted placeholder.
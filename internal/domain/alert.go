@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// AlertMetric is the book-derived value an alert watches.
+type AlertMetric string
+
+const (
+	AlertMetricMid    AlertMetric = "mid"
+	AlertMetricBid    AlertMetric = "bid"
+	AlertMetricAsk    AlertMetric = "ask"
+	AlertMetricSpread AlertMetric = "spread" // best ask - best bid
+)
+
+// AlertOperator compares the watched metric against the alert threshold.
+type AlertOperator string
+
+const (
+	AlertOpGT  AlertOperator = ">"
+	AlertOpGTE AlertOperator = ">="
+	AlertOpLT  AlertOperator = "<"
+	AlertOpLTE AlertOperator = "<="
+)
+
+// Alert is a user-defined price condition on a single token. One-shot alerts
+// disable themselves after firing; recurring alerts re-arm once the condition
+// stops holding and the cooldown has elapsed.
+type Alert struct {
+	ID              string
+	MarketID        string
+	TokenID         string
+	Metric          AlertMetric
+	Operator        AlertOperator
+	Threshold       float64
+	Recurring       bool
+	Cooldown        time.Duration
+	Enabled         bool
+	Note            string
+	TriggerCount    int
+	LastTriggeredAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Value extracts the alert's metric from a best bid/ask pair. ok is false when
+// the book side(s) the metric needs are empty.
+func (a Alert) Value(bestBid, bestAsk float64) (v float64, ok bool) {
+	switch a.Metric {
+	case AlertMetricBid:
+		return bestBid, bestBid > 0
+	case AlertMetricAsk:
+		return bestAsk, bestAsk > 0
+	case AlertMetricSpread:
+		return bestAsk - bestBid, bestBid > 0 && bestAsk > 0
+	default:
+		return (bestBid + bestAsk) / 2, bestBid > 0 && bestAsk > 0
+	}
+}
+
+// Matches reports whether v satisfies the alert condition.
+func (a Alert) Matches(v float64) bool {
+	switch a.Operator {
+	case AlertOpGT:
+		return v > a.Threshold
+	case AlertOpGTE:
+		return v >= a.Threshold
+	case AlertOpLT:
+		return v < a.Threshold
+	case AlertOpLTE:
+		return v <= a.Threshold
+	default:
+		return false
+	}
+}
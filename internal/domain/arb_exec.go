@@ -20,6 +20,9 @@ const (
 	ArbExecFilled    ArbExecStatus = "filled"
 	ArbExecCancelled ArbExecStatus = "cancelled"
 	ArbExecFailed    ArbExecStatus = "failed"
+	// ArbExecUnwound is an all_or_none execution whose filled legs were
+	// closed after a later leg failed.
+	ArbExecUnwound ArbExecStatus = "unwound"
 )
 
 // ArbExecution records one multi-leg arbitrage execution and its PnL.
@@ -51,3 +54,18 @@ type ArbLeg struct {
 	SlippageBps   float64
 	Status        OrderStatus
 }
+
+// Filled reports whether the leg's order traded at all.
+func (l ArbLeg) Filled() bool {
+	return l.Status == OrderStatusMatched || l.Status == OrderStatusPartiallyFilled
+}
+
+// UnhedgedExposure is the net filled size of one asset (CLOB token) across
+// arb executions whose other legs did not fill.
+type UnhedgedExposure struct {
+	TokenID    string
+	MarketID   string
+	NetSize    float64  // signed: positive long, negative short
+	Notional   float64  // |NetSize| at the legs' average fill price
+	Executions []string // IDs of the arb executions contributing
+}
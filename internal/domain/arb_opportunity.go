@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArbOpportunityStatus is the lifecycle state of a recorded arbitrage
+// opportunity: open while the detector keeps seeing it, then executed or
+// expired.
+type ArbOpportunityStatus string
+
+const (
+	ArbOppOpen     ArbOpportunityStatus = "open"
+	ArbOppExecuted ArbOpportunityStatus = "executed"
+	// ArbOppExpired is an opportunity whose edge disappeared, or that was
+	// not seen again within the opportunity TTL, before it was executed.
+	ArbOppExpired ArbOpportunityStatus = "expired"
+)
+
+// ArbOpportunityClose is how an open (or expired) opportunity ended.
+type ArbOpportunityClose struct {
+	Status     ArbOpportunityStatus // ArbOppExecuted or ArbOppExpired
+	At         time.Time
+	LastSeenAt time.Time // last detection; sets the opportunity's duration
+	// Set when executed: the arb execution and its realized edge and PnL.
+	ExecutionID     string
+	RealizedEdgeBps float64
+	RealizedPnLUSD  float64
+}
+
+// Key returns the dedup key identifying the opportunity across detections:
+// its market set (sorted, Kalshi markets prefixed by venue) and direction.
+// Opportunities with the same key are one opportunity seen again.
+func (o ArbOpportunity) Key() string {
+	markets := make([]string, 0, 2)
+	if o.PolyMarketID != "" {
+		markets = append(markets, o.PolyMarketID)
+	}
+	if o.KalshiMarketID != "" {
+		markets = append(markets, VenueKalshi+":"+o.KalshiMarketID)
+	}
+	sort.Strings(markets)
+	return strings.Join(markets, ",") + "|" + o.Direction
+}
+
+// RealizedEdgeBps is the execution's net PnL over the notional of its
+// filled legs, in bps, comparable to the opportunity's net edge. It is 0
+// when nothing filled.
+func (e ArbExecution) RealizedEdgeBps() float64 {
+	var notional float64
+	for _, leg := range e.Legs {
+		if leg.Filled() {
+			notional += leg.FilledPrice * leg.Size
+		}
+	}
+	if notional <= 0 {
+		return 0
+	}
+	return e.NetPnLUSD / notional * 10000
+}
@@ -0,0 +1,88 @@
+package domain
+
+import "time"
+
+// ArbStatsQuery selects the opportunities detected and executions started in
+// [Since, Until).
+type ArbStatsQuery struct {
+	Since time.Time
+	Until time.Time
+	// EdgeBucketBps is the width of the net edge histogram buckets.
+	EdgeBucketBps float64
+	// MaxMarkets caps the per-market breakdown, busiest markets first.
+	MaxMarkets int
+}
+
+// ArbStats aggregates arbitrage opportunities and executions over a time
+// range.
+type ArbStats struct {
+	Since time.Time
+	Until time.Time
+
+	Detected   int     // opportunities detected
+	Executed   int     // opportunities marked executed
+	Executions int     // executions started
+	Filled     int     // executions with every leg filled
+	NetPnLUSD  float64 // over all executions
+
+	EdgeHistogram []ArbEdgeBucket
+	Duration      ArbDurationStats
+	ByStrategy    []ArbStrategyStats
+	ByMarket      []ArbMarketStats
+}
+
+// HitRate returns Executed / Detected, or 0 when nothing was detected.
+func (s ArbStats) HitRate() float64 {
+	if s.Detected == 0 {
+		return 0
+	}
+	return float64(s.Executed) / float64(s.Detected)
+}
+
+// ArbEdgeBucket counts opportunities whose net edge falls in [LowBps, HighBps).
+type ArbEdgeBucket struct {
+	LowBps   float64
+	HighBps  float64
+	Detected int
+	Executed int
+}
+
+// ArbDurationStats summarizes how long opportunities stayed open, over
+// those with a recorded duration.
+type ArbDurationStats struct {
+	Count int
+	AvgMs float64
+	P50Ms float64
+	P90Ms float64
+	MaxMs float64
+}
+
+// ArbStrategyStats is the execution breakdown of one arb type and the
+// strategy that placed its first leg ("" when unknown).
+type ArbStrategyStats struct {
+	ArbType         ArbType
+	Strategy        string
+	Executions      int
+	Filled          int
+	NetPnLUSD       float64
+	FeesUSD         float64
+	AvgGrossEdgeBps float64
+}
+
+// ArbMarketStats is the opportunity breakdown of one Polymarket market.
+type ArbMarketStats struct {
+	MarketID       string
+	Detected       int
+	Executed       int
+	AvgNetEdgeBps  float64
+	ExpectedPnLUSD float64
+	NetPnLUSD      float64 // realized by executions of the market's opportunities
+}
+
+// HitRate returns Executed / Detected, or 0 when nothing was detected.
+func (m ArbMarketStats) HitRate() float64 {
+	if m.Detected == 0 {
+		return 0
+	}
+	return float64(m.Executed) / float64(m.Detected)
+}
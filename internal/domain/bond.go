@@ -9,9 +9,11 @@ const (
 	BondOpen         BondStatus = "open"
 	BondResolvedWin  BondStatus = "resolved_win"
 	BondResolvedLoss BondStatus = "resolved_loss"
+	BondExited       BondStatus = "exited" // sold before resolution
 )
 
-// BondPosition tracks a high-probability YES holding to resolution.
+// BondPosition tracks a high-probability YES holding to resolution, or to an
+// early exit when the bond strategy sells it first.
 type BondPosition struct {
 	ID             string
 	MarketID       string
@@ -22,6 +24,13 @@ type BondPosition struct {
 	Size           float64
 	Status         BondStatus
 	RealizedPnL    float64
+	ExitedSize     float64 // shares sold before resolution
+	ExitPrice      float64 // average price of the shares sold
 	CreatedAt      time.Time
-	ResolvedAt     *time.Time
+	ResolvedAt     *time.Time // resolution or exit time
+}
+
+// Remaining returns the shares still held.
+func (p BondPosition) Remaining() float64 {
+	return max(0, p.Size-p.ExitedSize)
 }
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// BuilderTrade is a trade the venue attributed to our builder-program
+// credentials. FeeUSDC is the builder fee credited for it.
+type BuilderTrade struct {
+	ID        string
+	MarketID  string
+	TokenID   string
+	Side      OrderSide
+	Price     float64
+	Size      float64 // shares
+	SizeUSDC  float64 // notional
+	FeeUSDC   float64
+	Status    string // MATCHED, MINED, CONFIRMED, RETRYING or FAILED
+	MatchedAt time.Time
+}
+
+// BuilderMarketRewards is the builder-attributed activity of one market.
+type BuilderMarketRewards struct {
+	MarketID   string
+	Trades     int
+	VolumeUSDC float64
+	FeesUSDC   float64
+}
+
+// BuilderRewards sums the builder-attributed trades matched since Since.
+// Failed trades are left out. Markets is sorted by fees, highest first.
+type BuilderRewards struct {
+	Since      time.Time
+	Trades     int
+	VolumeUSDC float64
+	FeesUSDC   float64
+	Markets    []BuilderMarketRewards
+	UpdatedAt  time.Time
+}
@@ -20,6 +20,36 @@ type OrderbookCache interface {
 	GetBBO(ctx context.Context, assetID string) (bestBid, bestAsk float64, err error)
 }
 
+// FeatureCache shares the latest market features across processes.
+type FeatureCache interface {
+	SetBatch(ctx context.Context, features []MarketFeatures) error
+	// Get returns ErrNotFound on a miss.
+	Get(ctx context.Context, assetID string) (MarketFeatures, error)
+}
+
+// ToxicityCache shares the latest order flow toxicity across processes.
+type ToxicityCache interface {
+	SetBatch(ctx context.Context, scores []AssetToxicity) error
+	// Get returns ErrNotFound on a miss.
+	Get(ctx context.Context, assetID string) (AssetToxicity, error)
+}
+
+// TradeAnalyticsCache shares the latest trade analytics of markets across
+// processes.
+type TradeAnalyticsCache interface {
+	SetBatch(ctx context.Context, analytics []MarketTradeAnalytics) error
+	// Get returns ErrNotFound on a miss.
+	Get(ctx context.Context, marketID string) (MarketTradeAnalytics, error)
+}
+
+// ReferencePriceCache shares the latest external spot prices across
+// processes.
+type ReferencePriceCache interface {
+	SetBatch(ctx context.Context, prices []ReferencePrice) error
+	// Get returns ErrNotFound on a miss.
+	Get(ctx context.Context, asset string) (ReferencePrice, error)
+}
+
 // MarketCache provides fast market metadata lookups.
 type MarketCache interface {
 	Set(ctx context.Context, market Market) error
@@ -28,6 +58,15 @@ type MarketCache interface {
 	Invalidate(ctx context.Context, id string) error
 }
 
+// MarketListCache shares the market blacklist and whitelist across
+// processes.
+type MarketListCache interface {
+	// Set replaces the cached entries of both lists.
+	Set(ctx context.Context, entries []MarketListEntry) error
+	// Get returns ErrNotFound when nothing has been cached.
+	Get(ctx context.Context) ([]MarketListEntry, error)
+}
+
 // ConditionGroupCache provides fast condition group lookups.
 type ConditionGroupCache interface {
 	Set(ctx context.Context, group ConditionGroup) error
@@ -42,11 +81,31 @@ type RateLimiter interface {
 	Wait(ctx context.Context, key string) error
 }
 
+// TokenBucket is a distributed token-bucket limiter for outbound API calls,
+// shared by every process using the same store.
+type TokenBucket interface {
+	// Reserve takes one token from key's bucket, which refills at rate tokens
+	// per second up to burst, and returns how long the caller must wait
+	// before using it (0 when a token was available).
+	Reserve(ctx context.Context, key string, rate float64, burst int) (time.Duration, error)
+}
+
 // LockManager provides distributed locking.
 type LockManager interface {
 	Acquire(ctx context.Context, key string, ttl time.Duration) (unlock func(), err error)
 }
 
+// StateCache is a key/value cache private to one owner (a strategy's Redis
+// namespace), so the owner's keys never collide with anyone else's and can be
+// flushed together.
+type StateCache interface {
+	// Get returns ErrNotFound on a miss.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value; ttl <= 0 keeps it until deleted or flushed.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
 // StreamMessage represents a single entry from a Redis stream.
 type StreamMessage struct {
 	ID      string
@@ -60,3 +119,21 @@ type SignalBus interface {
 	StreamAppend(ctx context.Context, stream string, payload []byte) error
 	StreamRead(ctx context.Context, stream string, lastID string, count int) ([]StreamMessage, error)
 }
+
+// DeadLetterQueue holds pipeline batches that failed to process until they
+// are replayed. Entries are kept until deleted; nothing is trimmed. Batches
+// that are not to be replayed again are parked for inspection.
+type DeadLetterQueue interface {
+	// Push appends a batch and returns the ID it was stored under.
+	Push(ctx context.Context, batch DeadLetterBatch) (string, error)
+	// List returns up to limit batches, oldest first. Entries that cannot
+	// be decoded are parked with the decode error instead of returned.
+	List(ctx context.Context, limit int) ([]DeadLetterBatch, error)
+	Delete(ctx context.Context, id string) error
+	Len(ctx context.Context) (int64, error)
+	// Park appends a batch to the parked entries, which are never replayed,
+	// and returns the ID it was stored under.
+	Park(ctx context.Context, batch DeadLetterBatch) (string, error)
+	// ListParked returns up to limit parked batches, oldest first.
+	ListParked(ctx context.Context, limit int) ([]DeadLetterBatch, error)
+}
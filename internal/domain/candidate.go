@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Candidate is a signal held for manual trading (strategy.auto_execute =
+// false), ranked for the dashboard. Score is EdgeBps discounted by
+// Freshness and Liquidity.
+type Candidate struct {
+	Signal TradeSignal
+	Status SignalStatus // pending until routed to the executor
+	Rank   int          // 1-based position among the pending candidates
+	Score  float64
+	// EdgeBps is the signal's expected edge: its edge_bps or net_edge_bps
+	// metadata, else the default edge.
+	EdgeBps float64
+	// Freshness decays from 1 at emission, halving every half-life.
+	Freshness float64
+	// Liquidity is the share of the signal's size the opposite side of the
+	// cached book fills at the signal price or better, 0 to 1; DepthUSD is
+	// that depth's notional.
+	Liquidity float64
+	DepthUSD  float64
+	RoutedAt  time.Time
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// CandleBase is the resolution price candles are recorded at; coarser
+// intervals are aggregated from base candles when read.
+const CandleBase = time.Minute
+
+// CandleIntervals maps the candle interval names accepted by the API to their
+// length. Every interval is a whole multiple of CandleBase.
+var CandleIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// Candle is one OHLCV bar of an asset's price. Open/High/Low/Close follow the
+// mid price, or the traded price for bars in which no mid was seen; Volume
+// (shares) and Trades count the last-trade prints within the bar.
+type Candle struct {
+	AssetID string
+	Start   time.Time // bar start, aligned to the interval
+	Open    float64
+	High    float64
+	Low     float64
+	Close   float64
+	Volume  float64
+	Trades  int
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// StrategyAllocation is one strategy's capital budget and how much of it is
+// committed. Amounts are USD notional.
+type StrategyAllocation struct {
+	Strategy      string
+	BaseBudgetUSD float64 // configured budget
+	BudgetUSD     float64 // budget after rebalancing, BaseBudgetUSD * Factor
+	Factor        float64 // performance weight from the last rebalance, 1 before any
+	Sharpe        float64 // Sharpe the factor was derived from
+	OpenOrdersUSD float64 // unfilled notional of resting buy orders
+	PositionsUSD  float64 // open positions at entry price
+	Uncapped      bool    // no budget applies; BudgetUSD and AvailableUSD are 0
+}
+
+// UsedUSD returns the committed notional.
+func (a StrategyAllocation) UsedUSD() float64 {
+	return a.OpenOrdersUSD + a.PositionsUSD
+}
+
+// AvailableUSD returns the budget left for new entries, never below 0.
+func (a StrategyAllocation) AvailableUSD() float64 {
+	if a.Uncapped {
+		return 0
+	}
+	return max(0, a.BudgetUSD-a.UsedUSD())
+}
+
+// CapitalReport is the allocation of every budgeted or active strategy.
+type CapitalReport struct {
+	TotalBudgetUSD float64
+	TotalUsedUSD   float64
+	Strategies     []StrategyAllocation // sorted by strategy name
+	RebalancedAt   *time.Time           // nil until the first rebalance
+}
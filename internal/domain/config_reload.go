@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// ConfigChange is one setting a configuration reload found changed, keyed
+// by its dotted TOML name. Secret values are redacted.
+type ConfigChange struct {
+	Key    string
+	Old    any
+	New    any
+	Reason string // why the change was not applied; empty when applied
+}
+
+// ConfigReloadReport is the outcome of a configuration reload: the changes
+// now in effect and those left for a restart.
+type ConfigReloadReport struct {
+	Applied    []ConfigChange
+	Rejected   []ConfigChange
+	ReloadedAt time.Time
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// CrossMatchStatus is the review state of a proposed cross-venue market match.
+type CrossMatchStatus string
+
+const (
+	CrossMatchPending  CrossMatchStatus = "pending"  // proposed by the matcher, awaiting review
+	CrossMatchApproved CrossMatchStatus = "approved" // linked on the market's instrument
+	CrossMatchRejected CrossMatchStatus = "rejected" // never proposed again
+)
+
+// Valid reports whether s is a known status.
+func (s CrossMatchStatus) Valid() bool {
+	switch s {
+	case CrossMatchPending, CrossMatchApproved, CrossMatchRejected:
+		return true
+	}
+	return false
+}
+
+// CrossMarketMatch pairs a Polymarket market with a Kalshi market that asks
+// the same question. Confidence is the matcher's score in [0, 1]; Scores
+// holds its components (tokens, dates, entities). Manual matches have
+// Confidence 1 and Source "manual".
+type CrossMarketMatch struct {
+	ID           string
+	MarketID     string
+	MarketSlug   string
+	Question     string
+	KalshiTicker string
+	KalshiTitle  string
+	Confidence   float64
+	Scores       map[string]float64
+	Status       CrossMatchStatus
+	Source       string // "auto" or "manual"
+	ReviewedAt   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -3,13 +3,25 @@ package domain
 import "errors"
 
 var (
-	ErrNotFound      = errors.New("not found")
-	ErrAlreadyExists = errors.New("already exists")
-	ErrRateLimited   = errors.New("rate limited")
-	ErrUnauthorized  = errors.New("unauthorized")
-	ErrInvalidOrder  = errors.New("invalid order parameters")
-	ErrSigningFailed = errors.New("signing failed")
-	ErrWSDisconnect  = errors.New("websocket disconnected")
-	ErrContextDone   = errors.New("context cancelled")
-	ErrLockHeld      = errors.New("lock already held")
+	ErrNotFound          = errors.New("not found")
+	ErrAlreadyExists     = errors.New("already exists")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrInvalidOrder      = errors.New("invalid order parameters")
+	ErrSigningFailed     = errors.New("signing failed")
+	ErrWSDisconnect      = errors.New("websocket disconnected")
+	ErrContextDone       = errors.New("context cancelled")
+	ErrLockHeld          = errors.New("lock already held")
+	ErrInvalidAlert      = errors.New("invalid alert")
+	ErrInvalidParams     = errors.New("invalid strategy params")
+	ErrInvalidInstrument = errors.New("invalid instrument")
+	ErrInvalidCrossMatch = errors.New("invalid cross-venue match")
+	ErrInvalidTimeline   = errors.New("invalid timeline query")
+	ErrInvalidAuditQuery = errors.New("invalid audit query")
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	ErrInvalidCandles    = errors.New("invalid candle query")
+	ErrInsufficientFunds = errors.New("insufficient on-chain balance or allowance")
+	ErrInvalidConfig     = errors.New("invalid configuration")
+	ErrInvalidMarketList = errors.New("invalid market list entry")
+	ErrCandidateClosed   = errors.New("candidate already routed or expired")
 )
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// ExecutorQueueStats is a snapshot of the executor's signal queues. Backlog
+// is the number of signals waiting in the engine's channel for dispatch;
+// Workers holds one entry per worker.
+type ExecutorQueueStats struct {
+	Backlog         int
+	DispatchBlocked int64 // dispatches that waited on a full worker queue
+	Workers         []ExecutorWorkerStats
+}
+
+// ExecutorWorkerStats describes one executor worker.
+type ExecutorWorkerStats struct {
+	Queued    int   // signals waiting in the worker's queue
+	Capacity  int   // the worker's queue depth
+	Busy      bool  // a signal is being processed
+	Processed int64 // signals processed since start
+}
+
+// ExecutorHeartbeat reports the liveness of the executor's dispatch loop,
+// which turns at least every Interval while it runs.
+type ExecutorHeartbeat struct {
+	Running  bool
+	LastBeat time.Time // zero before the loop first turned
+	Interval time.Duration
+}
+
+// Stalled reports whether a running loop has not turned for more than
+// three intervals at now.
+func (h ExecutorHeartbeat) Stalled(now time.Time) bool {
+	return h.Running && !h.LastBeat.IsZero() && now.Sub(h.LastBeat) > 3*h.Interval
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MarketFeatures are rolling order-flow features of one asset, computed from
+// its book snapshots and trade prints (analytics.FeatureTracker).
+type MarketFeatures struct {
+	AssetID    string
+	Mid        float64
+	Microprice float64 // top-of-book mid weighted by the opposite side's size
+	// DepthImbalance is (bid - ask) / (bid + ask) of the size resting in the
+	// top N levels of each side, in [-1, 1]; positive means more bids.
+	DepthImbalance float64
+	// TradeFlowImbalance is (buy - sell) / (buy + sell) of the taker volume
+	// printed over the window, in [-1, 1]; 0 without trades.
+	TradeFlowImbalance float64
+	// RealizedVol is the square root of the summed squared log returns of
+	// the mid over the window.
+	RealizedVol float64
+	Trades      int           // trade prints in the window
+	Window      time.Duration // lookback of the flow and volatility features
+	UpdatedAt   time.Time
+}
+
+// FeatureProvider serves the latest features of an asset to strategies.
+type FeatureProvider interface {
+	// Features returns ErrNotFound for an asset without features yet.
+	Features(ctx context.Context, assetID string) (MarketFeatures, error)
+}
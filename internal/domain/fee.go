@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Fee schedule sources.
+const (
+	FeeSourceVenue   = "venue"   // fetched from the venue's market metadata
+	FeeSourceDefault = "default" // configured fallback; the venue lookup failed
+)
+
+// FeeSchedule is the fee schedule of one market, in bps of notional.
+// RebateBps is credited back on every fill (e.g. the builder program), so the
+// effective maker fee may be negative.
+type FeeSchedule struct {
+	MarketID        string
+	MakerFeeBps     float64
+	TakerFeeBps     float64
+	RebateBps       float64
+	RewardsEligible bool // the market pays maker liquidity rewards
+	Source          string
+	FetchedAt       time.Time
+}
+
+// EffectiveMakerBps returns the maker fee net of rebates.
+func (f FeeSchedule) EffectiveMakerBps() float64 {
+	return f.MakerFeeBps - f.RebateBps
+}
+
+// EffectiveTakerBps returns the taker fee net of rebates, never below zero.
+func (f FeeSchedule) EffectiveTakerBps() float64 {
+	return max(0, f.TakerFeeBps-f.RebateBps)
+}
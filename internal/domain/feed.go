@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// FeedState is the connection state of a market data feed.
+type FeedState string
+
+const (
+	FeedStateConnected    FeedState = "connected"    // subscribed and receiving data
+	FeedStateDegraded     FeedState = "degraded"     // connected, but silent for longer than expected
+	FeedStateReconnecting FeedState = "reconnecting" // connection lost; retrying with backoff
+	FeedStateResubscribed FeedState = "resubscribed" // reconnected and subscriptions restored
+)
+
+// Live reports whether books from a feed in state s are current. Books are
+// stale while a feed is degraded or reconnecting.
+func (s FeedState) Live() bool {
+	return s == FeedStateConnected || s == FeedStateResubscribed
+}
+
+// FeedStatus reports a feed connection state change.
+type FeedStatus struct {
+	Feed     string
+	State    FeedState
+	Previous FeedState
+	At       time.Time
+	// GapStart is when data stopped arriving; set while the feed is not live.
+	GapStart *time.Time
+	// LastGap is the length of the most recent gap, set once the feed is
+	// live again.
+	LastGap    time.Duration
+	Reconnects int
+	Error      string
+}
+
+// FeedHealth is a point-in-time view of a feed for health reporting.
+type FeedHealth struct {
+	Feed  string
+	State FeedState
+	// LastMessage is when the feed last delivered a message; zero before the
+	// first.
+	LastMessage time.Time
+	Assets      int // subscribed assets
+	// StaleAssets counts subscribed assets silent for longer than the
+	// per-asset staleness threshold.
+	StaleAssets int
+	Reconnects  int
+	// ForcedReconnects counts connections dropped by the watchdog for
+	// carrying no data.
+	ForcedReconnects int
+	Resubscribes     int // stale assets resubscribed on a live connection
+	// Polling is set while books are refreshed from REST because the feed
+	// is not live; LastPoll is when the last round finished.
+	Polling  bool
+	LastPoll time.Time
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Fixed-point scales. Prices are carried as PriceTicks (PriceScale ticks per
+// 1.0 of collateral per share) and sizes as SizeUnits (SizeScale units per
+// share), independent of the collateral token's own decimals.
+const (
+	PriceScale = 1_000_000
+	SizeScale  = 1_000_000
+
+	// DefaultCollateralDecimals is the decimals of USDC.e, Polymarket's
+	// collateral. Outcome tokens have the decimals of their collateral.
+	DefaultCollateralDecimals = 6
+	// MaxCollateralDecimals bounds the decimals accepted for a collateral
+	// token (ERC-20 tokens use at most 18).
+	MaxCollateralDecimals = 18
+)
+
+// AmountScale is the number of base units per share and per USDC at
+// DefaultCollateralDecimals, the same scale as PriceTicks and SizeUnits.
+const AmountScale = 1_000_000
+
+// ToPriceTicks converts a price to fixed-point ticks, rounded to the nearest
+// tick. Truncating instead (int64(price * 1e6)) turns prices such as 0.57,
+// which float64 holds as 0.56999..., into one tick less.
+func ToPriceTicks(price float64) int64 {
+	return int64(math.Round(price * PriceScale))
+}
+
+// ToSizeUnits converts a size in shares to fixed-point units, rounded to the
+// nearest unit.
+func ToSizeUnits(size float64) int64 {
+	return int64(math.Round(size * SizeScale))
+}
+
+// TicksToPrice converts fixed-point ticks to a display price.
+func TicksToPrice(ticks int64) float64 {
+	return float64(ticks) / PriceScale
+}
+
+// UnitsToSize converts fixed-point units to a display size in shares.
+func UnitsToSize(units int64) float64 {
+	return float64(units) / SizeScale
+}
+
+// decimalScale returns 10^decimals.
+func decimalScale(decimals int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// ToBaseUnits converts an amount of a token with the given decimals to its
+// integer base units, rounded half away from zero. The amount is taken at
+// its shortest decimal form, so 1.1 at 18 decimals is exactly 1.1e18 rather
+// than the binary float's 1100000000000000088.8...
+func ToBaseUnits(amount float64, decimals int) *big.Int {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok { // NaN or Inf
+		return new(big.Int)
+	}
+	r.Mul(r, new(big.Rat).SetInt(decimalScale(decimals)))
+	num, den := r.Num(), r.Denom()
+	q, m := new(big.Int).QuoRem(num, den, new(big.Int))
+	// Round half away from zero: |2m| >= den.
+	if m.Abs(m).Lsh(m, 1).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// FromBaseUnits converts integer base units of a token with the given
+// decimals to an amount. Very large values (e.g. unlimited allowances) come
+// out as a very large number, which is fine for comparisons.
+func FromBaseUnits(units *big.Int, decimals int) float64 {
+	if units == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(units), new(big.Float).SetInt(decimalScale(decimals))).Float64()
+	return f
+}
+
+// CollateralDecimals resolves the decimals of the collateral each market
+// settles in: the ByMarket entry of its condition ID, else Default. The zero
+// value resolves every market to DefaultCollateralDecimals.
+type CollateralDecimals struct {
+	Default  int
+	ByMarket map[string]int
+}
+
+// For returns the collateral decimals of marketID.
+func (c CollateralDecimals) For(marketID string) int {
+	if d, ok := c.ByMarket[marketID]; ok {
+		return d
+	}
+	if c.Default > 0 {
+		return c.Default
+	}
+	return DefaultCollateralDecimals
+}
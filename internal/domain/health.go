@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// HealthProbe checks that a dependency is reachable; Check returns nil when
+// it is.
+type HealthProbe struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
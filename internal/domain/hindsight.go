@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// SignalOutcome is the hindsight evaluation of one recorded signal: what it
+// would have earned at its own price and size, whether or not it traded.
+type SignalOutcome struct {
+	Signal    TradeSignal
+	Executed  bool    // an order was placed for the signal and not rejected
+	ExitPrice float64 // settlement (1 or 0) when Resolved, else the mark at the horizon
+	Resolved  bool
+	Pending   bool    // no outcome price yet; excluded from the stats
+	PnL       float64 // counterfactual USD PnL
+}
+
+// HindsightStats aggregates evaluated outcomes.
+type HindsightStats struct {
+	Signals   int // all signals, including pending ones
+	Evaluated int
+	Hits      int // evaluated signals with positive PnL
+	PnL       float64
+}
+
+// HitRate returns Hits / Evaluated, or 0 when nothing was evaluated.
+func (s HindsightStats) HitRate() float64 {
+	if s.Evaluated == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Evaluated)
+}
+
+// Add folds o into the stats.
+func (s *HindsightStats) Add(o SignalOutcome) {
+	s.Signals++
+	if o.Pending {
+		return
+	}
+	s.Evaluated++
+	s.PnL += o.PnL
+	if o.PnL > 0 {
+		s.Hits++
+	}
+}
+
+// HindsightReport compares a strategy's executed and skipped signals against
+// what the market did afterwards.
+type HindsightReport struct {
+	Strategy    string
+	Since       time.Time
+	Horizon     time.Duration
+	Executed    HindsightStats
+	Skipped     HindsightStats
+	Outcomes    []SignalOutcome // newest first
+	EvaluatedAt time.Time
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IDKind names one kind of identifier an instrument can be referenced by.
+type IDKind string
+
+const (
+	IDKindMarket    IDKind = "market_id"    // Polymarket Gamma market ID
+	IDKindCondition IDKind = "condition_id" // CTF condition ID
+	IDKindSlug      IDKind = "slug"         // Polymarket URL slug
+	IDKindYesToken  IDKind = "yes_token"    // ERC-1155 token for outcome 1
+	IDKindNoToken   IDKind = "no_token"     // ERC-1155 token for outcome 2
+	IDKindKalshi    IDKind = "kalshi_ticker"
+	IDKindPredictIt IDKind = "predictit_ref" // "marketID:contractID"
+	IDKindManifold  IDKind = "manifold_ref"  // slug or "id:<marketID>"
+
+	// IDKindToken is a lookup-only kind matching either outcome token.
+	IDKindToken IDKind = "token_id"
+)
+
+// IDKinds lists the identifier kinds stored on an instrument.
+var IDKinds = []IDKind{
+	IDKindMarket, IDKindCondition, IDKindSlug, IDKindYesToken, IDKindNoToken,
+	IDKindKalshi, IDKindPredictIt, IDKindManifold,
+}
+
+// Valid reports whether k is a known kind, including lookup-only kinds.
+func (k IDKind) Valid() bool {
+	if k == IDKindToken {
+		return true
+	}
+	for _, v := range IDKinds {
+		if k == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand returns the stored kinds a lookup by k matches.
+func (k IDKind) Expand() []IDKind {
+	if k == IDKindToken {
+		return []IDKind{IDKindYesToken, IDKindNoToken}
+	}
+	return []IDKind{k}
+}
+
+// VenueIDKind returns the identifier kind holding a venue's market ref.
+func VenueIDKind(venue string) (IDKind, bool) {
+	switch venue {
+	case VenueKalshi:
+		return IDKindKalshi, true
+	case VenuePredictIt:
+		return IDKindPredictIt, true
+	case VenueManifold:
+		return IDKindManifold, true
+	}
+	return "", false
+}
+
+// Instrument is the canonical record for one tradable YES/NO question,
+// linking every identifier it is known by across venues.
+type Instrument struct {
+	ID          string
+	Question    string
+	Identifiers map[IDKind]string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Ref returns the instrument's identifier of kind k, or "".
+func (i Instrument) Ref(k IDKind) string {
+	return i.Identifiers[k]
+}
+
+// InstrumentFromMarket builds the identifier set for a Polymarket market.
+// The ID is left empty for the caller to assign.
+func InstrumentFromMarket(m Market) Instrument {
+	ids := map[IDKind]string{IDKindMarket: m.ID}
+	for k, v := range map[IDKind]string{
+		IDKindCondition: m.ConditionID,
+		IDKindSlug:      m.Slug,
+		IDKindYesToken:  m.YesTokenID(),
+		IDKindNoToken:   m.NoTokenID(),
+	} {
+		if v != "" {
+			ids[k] = v
+		}
+	}
+	return Instrument{Question: m.Question, Identifiers: ids}
+}
+
+// InstrumentStore persists instruments and their identifier links. An
+// identifier (kind, value) belongs to at most one instrument.
+type InstrumentStore interface {
+	// Upsert writes the instrument and replaces its identifier links.
+	// Returns ErrAlreadyExists if an identifier is linked to another instrument.
+	Upsert(ctx context.Context, inst Instrument) error
+	UpsertBatch(ctx context.Context, insts []Instrument) error
+	GetByID(ctx context.Context, id string) (Instrument, error)
+	Lookup(ctx context.Context, kind IDKind, value string) (Instrument, error)
+	// LookupBatch returns the instruments found for values, keyed by value.
+	LookupBatch(ctx context.Context, kind IDKind, values []string) (map[string]Instrument, error)
+	List(ctx context.Context, opts ListOpts) ([]Instrument, error)
+}
+
+// InstrumentCache provides fast instrument and identifier lookups.
+type InstrumentCache interface {
+	Set(ctx context.Context, inst Instrument) error
+	Get(ctx context.Context, id string) (Instrument, error)
+	// Resolve returns the instrument ID an identifier maps to.
+	Resolve(ctx context.Context, kind IDKind, value string) (string, error)
+	// ResolveBatch returns cached instruments for values, keyed by value.
+	// Values missing from the cache are omitted.
+	ResolveBatch(ctx context.Context, kind IDKind, values []string) (map[string]Instrument, error)
+	Invalidate(ctx context.Context, id string) error
+}
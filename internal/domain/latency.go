@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// LatencyStage names one measured step of a signal's way from a strategy to
+// a fill.
+type LatencyStage string
+
+const (
+	LatencyStageQueue LatencyStage = "queue" // emission to executor pickup
+	LatencyStageRisk  LatencyStage = "risk"  // risk sizing and pre-trade checks
+	LatencyStageSign  LatencyStage = "sign"  // EIP-712 order signing
+	LatencyStagePost  LatencyStage = "post"  // CLOB order POST round trip
+	LatencyStageAck   LatencyStage = "ack"   // emission to CLOB acknowledgement
+	LatencyStageFill  LatencyStage = "fill"  // acknowledgement to first taker fill report
+	LatencyStageTotal LatencyStage = "total" // emission to first taker fill report
+)
+
+// LatencyStages lists every stage in pipeline order.
+var LatencyStages = []LatencyStage{
+	LatencyStageQueue, LatencyStageRisk, LatencyStageSign, LatencyStagePost,
+	LatencyStageAck, LatencyStageFill, LatencyStageTotal,
+}
+
+// OrderLatency is the latency breakdown of one order, keyed by the ID of the
+// signal it was placed for. Stages holds the stages that were measured;
+// fills of resting (maker) orders are not, since they wait on the market.
+type OrderLatency struct {
+	OrderID   string
+	Strategy  string
+	MarketID  string
+	EmittedAt time.Time // zero for orders not emitted by the engine
+	AckedAt   time.Time
+	FilledAt  time.Time
+	Stages    map[LatencyStage]time.Duration
+	UpdatedAt time.Time
+}
+
+// LatencyStats summarizes one stage: quantiles over the most recent samples,
+// and the count and sum of every sample since start.
+type LatencyStats struct {
+	Stage         LatencyStage
+	Samples       int // in the quantile window
+	P50, P95, P99 time.Duration
+	Max           time.Duration
+	Count         int64
+	Sum           time.Duration
+}
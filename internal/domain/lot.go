@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// LotMethod selects which open lot a closing fill is matched against.
+type LotMethod string
+
+const (
+	LotFIFO LotMethod = "fifo" // oldest lot first
+	LotLIFO LotMethod = "lifo" // newest lot first
+)
+
+// ParseLotMethod parses "fifo" or "lifo"; an empty string means FIFO.
+func ParseLotMethod(s string) (LotMethod, error) {
+	switch LotMethod(s) {
+	case "", LotFIFO:
+		return LotFIFO, nil
+	case LotLIFO:
+		return LotLIFO, nil
+	default:
+		return "", fmt.Errorf("unknown lot method %q (valid: fifo, lifo)", s)
+	}
+}
+
+// Fill is one executed trade of the bot's wallet on a single token.
+type Fill struct {
+	OrderID  string
+	MarketID string
+	TokenID  string
+	Side     OrderSide
+	Price    float64
+	Size     float64
+	Time     time.Time
+}
+
+// FillFromOrder converts a matched order into a Fill. FilledSize is used when
+// recorded, otherwise the full order size.
+func FillFromOrder(o Order) Fill {
+	size := o.FilledSize
+	if size <= 0 {
+		size = o.Size()
+	}
+	at := o.CreatedAt
+	if o.FilledAt != nil {
+		at = *o.FilledAt
+	}
+	return Fill{
+		OrderID:  o.ID,
+		MarketID: o.MarketID,
+		TokenID:  o.TokenID,
+		Side:     o.Side,
+		Price:    o.Price(),
+		Size:     size,
+		Time:     at,
+	}
+}
+
+// Lot is a quantity of a token acquired by one fill. A closed lot records the
+// fill that disposed of it and the gain realized; an open lot has no close.
+// Direction is OrderSideBuy for long lots and OrderSideSell for short lots
+// opened by selling more than was held.
+type Lot struct {
+	TokenID      string
+	MarketID     string
+	Direction    OrderSide
+	Size         float64
+	OpenOrderID  string
+	OpenPrice    float64
+	OpenedAt     time.Time
+	CloseOrderID string
+	ClosePrice   float64
+	ClosedAt     *time.Time
+	RealizedPnL  float64
+}
+
+// HoldingPeriod returns how long the lot was held (0 for open lots).
+func (l Lot) HoldingPeriod() time.Duration {
+	if l.ClosedAt == nil {
+		return 0
+	}
+	return l.ClosedAt.Sub(l.OpenedAt)
+}
+
+// LotReport is the lot-level realized PnL of a wallet over a period.
+type LotReport struct {
+	Wallet      string
+	Method      LotMethod
+	From        *time.Time
+	To          time.Time
+	Closed      []Lot   // lots closed within [From, To]
+	Open        []Lot   // lots still open at To
+	RealizedPnL float64 // sum over Closed
+}
+
+// lotEpsilon is the size below which a lot remainder is treated as empty.
+const lotEpsilon = 1e-9
+
+// MatchLots replays fills in time order per token and matches each closing
+// fill against open lots using method, splitting lots on partial closes. It
+// returns the closed lot slices (one per open/close pair) and the lots still
+// open, both ordered by time.
+func MatchLots(fills []Fill, method LotMethod) (closed, open []Lot) {
+	sorted := make([]Fill, len(fills))
+	copy(sorted, fills)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	inventory := make(map[string][]Lot) // token -> open lots, oldest first
+	var tokens []string
+	for _, f := range sorted {
+		if f.Size <= 0 {
+			continue
+		}
+		lots, seen := inventory[f.TokenID]
+		if !seen {
+			tokens = append(tokens, f.TokenID)
+		}
+		remaining := f.Size
+		for remaining > lotEpsilon && len(lots) > 0 && lots[0].Direction != f.Side {
+			idx := 0
+			if method == LotLIFO {
+				idx = len(lots) - 1
+			}
+			lot := &lots[idx]
+			qty := min(remaining, lot.Size)
+			closedAt := f.Time
+			c := *lot
+			c.Size = qty
+			c.CloseOrderID = f.OrderID
+			c.ClosePrice = f.Price
+			c.ClosedAt = &closedAt
+			if c.Direction == OrderSideBuy {
+				c.RealizedPnL = (f.Price - c.OpenPrice) * qty
+			} else {
+				c.RealizedPnL = (c.OpenPrice - f.Price) * qty
+			}
+			closed = append(closed, c)
+
+			lot.Size -= qty
+			remaining -= qty
+			if lot.Size <= lotEpsilon {
+				lots = append(lots[:idx], lots[idx+1:]...)
+			}
+		}
+		if remaining > lotEpsilon {
+			lots = append(lots, Lot{
+				TokenID:     f.TokenID,
+				MarketID:    f.MarketID,
+				Direction:   f.Side,
+				Size:        remaining,
+				OpenOrderID: f.OrderID,
+				OpenPrice:   f.Price,
+				OpenedAt:    f.Time,
+			})
+		}
+		inventory[f.TokenID] = lots
+	}
+	for _, t := range tokens {
+		open = append(open, inventory[t]...)
+	}
+	sort.SliceStable(open, func(i, j int) bool { return open[i].OpenedAt.Before(open[j].OpenedAt) })
+	return closed, open
+}
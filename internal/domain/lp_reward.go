@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// LPRewardDay is one market's liquidity rewards on one UTC day: the reward
+// terms last seen that day, how long our liquidity_provider quotes were
+// tracked there and how long they qualified, and the estimated accrual.
+type LPRewardDay struct {
+	Day       time.Time
+	MarketID  string
+	Eligible  bool    // the market offered rewards when last checked
+	DailyRate float64 // USD paid to all qualifying makers per day
+	MaxSpread float64 // qualifying distance from the mid, in price (0.03 = 3c)
+	MinSize   float64 // smallest qualifying order, in shares
+	// QuotedSeconds is the time the market was tracked while we quoted it
+	// that day; QualifyingSeconds the part of it our quotes qualified.
+	QuotedSeconds     float64
+	QualifyingSeconds float64
+	// ShareSeconds sums our estimated share of the market's quote score over
+	// the qualifying time; divided by QualifyingSeconds it is the average.
+	ShareSeconds float64
+	EstimatedUSD float64
+	UpdatedAt    time.Time
+}
+
+// Uptime returns the fraction of the quoted time our quotes qualified.
+func (d LPRewardDay) Uptime() float64 {
+	if d.QuotedSeconds <= 0 {
+		return 0
+	}
+	return d.QualifyingSeconds / d.QuotedSeconds
+}
+
+// AvgShare returns our average share of the quote score while qualifying.
+func (d LPRewardDay) AvgShare() float64 {
+	if d.QualifyingSeconds <= 0 {
+		return 0
+	}
+	return d.ShareSeconds / d.QualifyingSeconds
+}
+
+// LPRewardEstimate is what a market's liquidity rewards are expected to pay
+// us per day of quoting.
+type LPRewardEstimate struct {
+	MarketID  string
+	DailyRate float64
+	MaxSpread float64
+	MinSize   float64
+	// EstimatedDailyUSD is the accrual of the recent days we quoted the
+	// market, projected to a full day of quoting. 0 when Days is 0.
+	EstimatedDailyUSD float64
+	Days              int // days of history behind the estimate
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // MarketStatus represents the lifecycle state of a market.
 type MarketStatus string
@@ -16,13 +19,180 @@ type Market struct {
 	ID          string
 	Question    string
 	Slug        string
-	Outcomes    [2]string    // e.g. ["Yes","No"] or ["Up","Down"]
-	TokenIDs    [2]string    // ERC-1155 token IDs (76-digit strings)
+	Outcomes    []string // e.g. ["Yes","No"], ["Up","Down"] or one name per categorical outcome
+	TokenIDs    []string // ERC-1155 token IDs (76-digit strings), parallel to Outcomes
 	ConditionID string
 	NegRisk     bool
 	Volume      float64
+	Tags        []string // lower-case Gamma tag slugs, e.g. "politics", "crypto"
 	Status      MarketStatus
 	ClosedAt    *time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
+
+// HasTag reports whether the market carries tag (case-insensitive).
+func (m Market) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryFilter restricts a strategy to markets by tag. A market passes when
+// it carries at least one Include tag (any market when Include is empty) and
+// none of the Exclude tags.
+type CategoryFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// IsZero reports whether the filter lets every market through.
+func (f CategoryFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Allows reports whether m passes the filter.
+func (f CategoryFilter) Allows(m Market) bool {
+	for _, t := range f.Exclude {
+		if m.HasTag(t) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, t := range f.Include {
+		if m.HasTag(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBinary reports whether the market has exactly two outcomes.
+func (m Market) IsBinary() bool {
+	return len(m.TokenIDs) == 2
+}
+
+// TokenID returns the token ID of outcome i, or "" when there is none.
+func (m Market) TokenID(i int) string {
+	if i < 0 || i >= len(m.TokenIDs) {
+		return ""
+	}
+	return m.TokenIDs[i]
+}
+
+// YesTokenID returns the token of the first outcome ("Yes" on binary markets).
+func (m Market) YesTokenID() string {
+	return m.TokenID(0)
+}
+
+// NoTokenID returns the token of the second outcome ("No" on binary markets).
+func (m Market) NoTokenID() string {
+	return m.TokenID(1)
+}
+
+// OutcomeIndex returns the position of tokenID among the market's tokens, or -1.
+func (m Market) OutcomeIndex(tokenID string) int {
+	if tokenID == "" {
+		return -1
+	}
+	for i, id := range m.TokenIDs {
+		if id == tokenID {
+			return i
+		}
+	}
+	return -1
+}
+
+// OutcomeName returns the outcome name of tokenID, or "" when it is not one
+// of the market's tokens.
+func (m Market) OutcomeName(tokenID string) string {
+	i := m.OutcomeIndex(tokenID)
+	if i < 0 || i >= len(m.Outcomes) {
+		return ""
+	}
+	return m.Outcomes[i]
+}
+
+// OutcomeTokens returns the tokens whose prices should sum to 1 across a
+// mutually exclusive group: the Yes token of a binary market (its No token
+// is the complement) or every token of a categorical market.
+func (m Market) OutcomeTokens() []string {
+	if m.IsBinary() {
+		if m.TokenIDs[0] == "" {
+			return nil
+		}
+		return m.TokenIDs[:1]
+	}
+	out := make([]string, 0, len(m.TokenIDs))
+	for _, id := range m.TokenIDs {
+		if id != "" {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// MarketField names a market metadata field tracked for changes.
+type MarketField string
+
+const (
+	MarketFieldStatus  MarketField = "status"
+	MarketFieldEndDate MarketField = "end_date"
+)
+
+// MarketUpdate reports a metadata change on a market, detected by comparing a
+// fresh Gamma fetch (Market) with the previously known state (Previous).
+type MarketUpdate struct {
+	Market     Market
+	Previous   Market
+	Changes    []MarketField
+	DetectedAt time.Time
+}
+
+// Changed reports whether f is among the update's changes.
+func (u MarketUpdate) Changed(f MarketField) bool {
+	for _, c := range u.Changes {
+		if c == f {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffMarket returns the tracked fields that differ between prev and next.
+func DiffMarket(prev, next Market) []MarketField {
+	var changes []MarketField
+	if prev.Status != next.Status {
+		changes = append(changes, MarketFieldStatus)
+	}
+	switch {
+	case prev.ClosedAt == nil && next.ClosedAt == nil:
+	case prev.ClosedAt == nil || next.ClosedAt == nil || !prev.ClosedAt.Equal(*next.ClosedAt):
+		changes = append(changes, MarketFieldEndDate)
+	}
+	return changes
+}
+
+// MarketHeat is one market's cell in the opportunity heat map: how far its
+// outcome prices are from summing to 1, how wide its books are, and how much
+// trading and strategy activity it has seen in the last hour.
+type MarketHeat struct {
+	MarketID string
+	Slug     string
+	Question string
+	// Priced is false until every outcome has a two-sided book; the price
+	// fields are zero until then.
+	Priced       bool
+	SumDeviation float64 // sum of outcome mid prices - 1
+	Spread       float64 // widest best ask - best bid across outcomes
+	Volume1h     float64 // USD traded in the last hour
+	Signals1h    int     // strategy signals in the last hour
+	// SignalsByStrategy breaks Signals1h down by signal source.
+	SignalsByStrategy map[string]int
+	PriceUpdatedAt    time.Time
+}
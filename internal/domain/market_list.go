@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// MarketList names an operator-managed list of markets.
+type MarketList string
+
+const (
+	// MarketListBlacklist excludes a market from every strategy.
+	MarketListBlacklist MarketList = "blacklist"
+	// MarketListWhitelist, once it holds any market, restricts strategies to
+	// the markets on it.
+	MarketListWhitelist MarketList = "whitelist"
+)
+
+// Valid reports whether l is a known list.
+func (l MarketList) Valid() bool {
+	return l == MarketListBlacklist || l == MarketListWhitelist
+}
+
+// MarketListEntry is one market on a blacklist or whitelist. TokenIDs are
+// the market's outcome tokens when it was listed, so signals and feeds that
+// carry only a token can be matched.
+type MarketListEntry struct {
+	List      MarketList
+	MarketID  string
+	TokenIDs  []string
+	Reason    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// OnchainEventKind is the kind of a Conditional Tokens Framework (CTF) event.
+type OnchainEventKind string
+
+const (
+	// OnchainEventSplit: collateral split into a full set of outcome tokens.
+	OnchainEventSplit OnchainEventKind = "split"
+	// OnchainEventMerge: a full set of outcome tokens merged back into
+	// collateral.
+	OnchainEventMerge OnchainEventKind = "merge"
+	// OnchainEventRedemption: outcome tokens of a resolved condition redeemed
+	// for their payout.
+	OnchainEventRedemption OnchainEventKind = "redemption"
+)
+
+// OnchainEventKinds lists every CTF event kind, in scrape order.
+var OnchainEventKinds = []OnchainEventKind{OnchainEventSplit, OnchainEventMerge, OnchainEventRedemption}
+
+// OnchainEvent is a CTF split, merge or redemption from the Goldsky activity
+// subgraph. These move outcome tokens without an order fill, so token
+// balances only reconcile with trades once they are accounted for.
+type OnchainEvent struct {
+	ID          string // subgraph entity ID (the transaction hash)
+	Kind        OnchainEventKind
+	Stakeholder string // wallet that split, merged or redeemed, lower-case
+	ConditionID string
+	// Amount is the collateral (USDC) moved: the amount split or merged, or
+	// the payout of a redemption. A split or merge moves as many of each
+	// outcome token.
+	Amount    float64
+	IndexSets []string // redemption only: the outcome index sets redeemed
+	Timestamp time.Time
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OpportunityLeg is one (action, instrument) pair of an opportunity, used to
+// fingerprint it. Action is a lowercase order side ("buy", "sell") for
+// strategy signals and the Direction for detector opportunities; Ref is a
+// Polymarket token ID or a venue ref.
+type OpportunityLeg struct {
+	Action string
+	Ref    string
+}
+
+// OpportunityFingerprint returns a stable identifier for the opportunity made
+// of legs, independent of leg order. Two detectors that see the same trade on
+// the same instruments produce the same fingerprint.
+func OpportunityFingerprint(legs ...OpportunityLeg) string {
+	parts := make([]string, 0, len(legs))
+	for _, l := range legs {
+		if l.Ref == "" {
+			continue
+		}
+		parts = append(parts, strings.ToLower(strings.TrimSpace(l.Action))+"|"+l.Ref)
+	}
+	sort.Strings(parts)
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Fingerprint returns the opportunity's fingerprint over its Polymarket token
+// and, for cross-venue opportunities, its Kalshi market.
+func (o ArbOpportunity) Fingerprint() string {
+	ref := o.PolyTokenID
+	if ref == "" {
+		ref = o.PolyMarketID
+	}
+	legs := []OpportunityLeg{{Action: o.Direction, Ref: ref}}
+	if o.KalshiMarketID != "" {
+		legs = append(legs, OpportunityLeg{Action: o.Direction, Ref: VenueKalshi + ":" + o.KalshiMarketID})
+	}
+	return OpportunityFingerprint(legs...)
+}
+
+// SignalGroupFingerprint returns the fingerprint of the opportunity a set of
+// signals (usually one leg group) trades. A signal hedged on another venue
+// also contributes its "venue"/"venue_ref" metadata as a leg.
+func SignalGroupFingerprint(legs []TradeSignal) string {
+	fl := make([]OpportunityLeg, 0, len(legs))
+	for _, s := range legs {
+		fl = append(fl, OpportunityLeg{Action: string(s.Side), Ref: s.TokenID})
+		if v := s.Metadata["venue_ref"]; v != "" {
+			fl = append(fl, OpportunityLeg{Action: string(s.Side), Ref: s.Metadata["venue"] + ":" + v})
+		}
+	}
+	return OpportunityFingerprint(fl...)
+}
+
+// OpportunityRegistry is a shared, time-windowed record of opportunities
+// already acted on, so the arbitrage detector and strategies do not record
+// or execute the same opportunity twice.
+type OpportunityRegistry interface {
+	// Claim reserves fingerprint for source, an executing strategy, for
+	// window. It succeeds when the fingerprint is free or already held by
+	// source (refreshing the window); otherwise it returns false and the
+	// holding source.
+	Claim(ctx context.Context, fingerprint, source string, window time.Duration) (bool, string, error)
+	// Observe records that source, a non-executing detector, saw
+	// fingerprint. It returns false and the holder when the fingerprint was
+	// claimed or observed by anyone within window, including source itself.
+	Observe(ctx context.Context, fingerprint, source string, window time.Duration) (bool, string, error)
+}
@@ -23,27 +23,92 @@ const (
 	OrderTypeFAK OrderType = "FAK" // Fill-And-Kill
 )
 
-// OrderStatus tracks the order lifecycle.
+// Valid reports whether t is one of the supported order types.
+func (t OrderType) Valid() bool {
+	switch t {
+	case OrderTypeGTC, OrderTypeGTD, OrderTypeFOK, OrderTypeFAK:
+		return true
+	}
+	return false
+}
+
+// OrderStatus tracks the order lifecycle:
+//
+//	pending -> open -> partially_filled -> matched (filled)
+//	                                    \-> cancelled | expired
+//
+// A pending order the venue refuses becomes failed. Matched, cancelled,
+// expired and failed are terminal.
 type OrderStatus string
 
 const (
-	OrderStatusPending   OrderStatus = "pending"
-	OrderStatusOpen      OrderStatus = "open"
-	OrderStatusMatched   OrderStatus = "matched"
-	OrderStatusCancelled OrderStatus = "cancelled"
-	OrderStatusFailed    OrderStatus = "failed"
+	OrderStatusPending         OrderStatus = "pending"
+	OrderStatusOpen            OrderStatus = "open"
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled"
+	OrderStatusMatched         OrderStatus = "matched" // completely filled
+	OrderStatusCancelled       OrderStatus = "cancelled"
+	OrderStatusExpired         OrderStatus = "expired" // GTD order reached its expiration
+	OrderStatusFailed          OrderStatus = "failed"
 )
 
+// orderTransitions lists the statuses each live status may move to.
+// partially_filled may repeat as further fills arrive.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending: {
+		OrderStatusOpen, OrderStatusPartiallyFilled, OrderStatusMatched,
+		OrderStatusCancelled, OrderStatusExpired, OrderStatusFailed,
+	},
+	OrderStatusOpen: {
+		OrderStatusPartiallyFilled, OrderStatusMatched, OrderStatusCancelled, OrderStatusExpired,
+	},
+	OrderStatusPartiallyFilled: {
+		OrderStatusPartiallyFilled, OrderStatusMatched, OrderStatusCancelled, OrderStatusExpired,
+	},
+}
+
+// Live reports whether an order in status s can still fill.
+func (s OrderStatus) Live() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// CanTransition reports whether an order in status s may move to next.
+func (s OrderStatus) CanTransition(next OrderStatus) bool {
+	for _, to := range orderTransitions[s] {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
+// fillEpsilon absorbs float rounding when comparing filled and order sizes.
+const fillEpsilon = 1e-9
+
+// FillStatus returns the status of a live order of size shares once filled
+// shares have matched: open, partially_filled or matched.
+func FillStatus(filled, size float64) OrderStatus {
+	switch {
+	case filled >= size-fillEpsilon:
+		return OrderStatusMatched
+	case filled > fillEpsilon:
+		return OrderStatusPartiallyFilled
+	default:
+		return OrderStatusOpen
+	}
+}
+
 // Order represents a signed trading order.
 type Order struct {
 	ID          string
+	ExchangeID  string // order hash assigned by the CLOB; empty until accepted
 	MarketID    string
 	TokenID     string
 	Wallet      string
 	Side        OrderSide
 	Type        OrderType
-	PriceTicks  int64    // fixed-point: price * 1e6
-	SizeUnits   int64    // fixed-point: size  * 1e6
+	PriceTicks  int64    // fixed-point: price * PriceScale
+	SizeUnits   int64    // fixed-point: size  * SizeScale
 	MakerAmount *big.Int // integer notional used in signed payload
 	TakerAmount *big.Int // integer quantity used in signed payload
 	FilledSize  float64
@@ -53,16 +118,46 @@ type Order struct {
 	CreatedAt   time.Time
 	FilledAt    *time.Time
 	CancelledAt *time.Time
+	ExpiresAt   *time.Time // GTD orders only
+	// CancelAfter is when the order, if still resting, is cancelled by the
+	// order janitor; nil rests until filled or cancelled.
+	CancelAfter *time.Time
+}
+
+// Remaining returns the unfilled size.
+func (o Order) Remaining() float64 {
+	return max(0, o.Size()-o.FilledSize)
+}
+
+// FillPercent returns the filled share of the order size, 0 to 100.
+func (o Order) FillPercent() float64 {
+	if o.SizeUnits <= 0 {
+		return 0
+	}
+	return min(100, o.FilledSize/o.Size()*100)
 }
 
 // Price returns the float64 display price from fixed-point ticks.
 func (o Order) Price() float64 {
-	return float64(o.PriceTicks) / 1e6
+	return TicksToPrice(o.PriceTicks)
 }
 
 // Size returns the float64 display size from fixed-point units.
 func (o Order) Size() float64 {
-	return float64(o.SizeUnits) / 1e6
+	return UnitsToSize(o.SizeUnits)
+}
+
+// VenueOrderAmounts is an order's price and amounts exactly as the venue
+// reported them, as decimal strings, so audits can compare them without
+// rounding through float64.
+type VenueOrderAmounts struct {
+	ExchangeID   string
+	Side         OrderSide
+	Price        string // per share, e.g. "0.57"
+	OriginalSize string // shares
+	SizeMatched  string // shares
+	MakerAmount  string // base units; may be empty
+	TakerAmount  string // base units; may be empty
 }
 
 // OrderResult wraps the API response after order submission.
@@ -73,5 +168,45 @@ type OrderResult struct {
 	Message     string
 	ShouldRetry bool
 	FilledPrice float64 // filled price when matched
+	FilledSize  float64 // shares matched on submission, when reported
 	FeeUSD      float64 // fee for this order
 }
+
+// OrderFillEvent is a fill of one of the wallet's orders reported by the
+// exchange user channel. Size and Price are this fill only; an order filled in
+// several matches produces several events.
+type OrderFillEvent struct {
+	TradeID    string
+	ExchangeID string // the wallet's order that was filled
+	TokenID    string
+	Price      float64
+	Size       float64
+	Maker      bool   // the order was resting on the book
+	Status     string // exchange trade status: MATCHED, MINED, CONFIRMED, RETRYING, FAILED
+	Time       time.Time
+}
+
+// OrderUpdateEvent is an order lifecycle change reported by the exchange user
+// channel. Type is "placement", "update" (partial or full match) or
+// "cancellation"; SizeMatched is cumulative.
+type OrderUpdateEvent struct {
+	ExchangeID   string
+	TokenID      string
+	Type         string
+	OriginalSize float64
+	SizeMatched  float64
+	Time         time.Time
+}
+
+// MarketCancelRatio is the wallet's order activity on one market over the
+// last hour. Ratio is cancels per fill (the cancel count when nothing
+// filled); NearLimit is set once it reaches the configured warning level of
+// the venue's cancel-ratio limit.
+type MarketCancelRatio struct {
+	MarketID  string
+	Placed    int
+	Cancelled int
+	Filled    int
+	Ratio     float64
+	NearLimit bool
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Order increment sources.
+const (
+	IncrementSourceVenue   = "venue"   // read from the token's CLOB book
+	IncrementSourceDefault = "default" // configured fallback; the venue lookup failed
+)
+
+// incrementSlack is how far, in fixed-point units (1e-6), a price or size
+// may sit below an increment and still count as on it. It absorbs float
+// truncation in prices and sizes not built with ToPriceTicks/ToSizeUnits.
+const incrementSlack = 10
+
+// OrderIncrements are the steps the venue requires of one token's orders:
+// prices on a multiple of TickSize, sizes on a multiple of SizeStep shares
+// and at least MinOrderSize shares. A zero field is not enforced.
+type OrderIncrements struct {
+	TokenID      string
+	TickSize     float64
+	SizeStep     float64
+	MinOrderSize float64
+	Source       string
+	FetchedAt    time.Time
+}
+
+// Normalize rounds a fixed-point price and size (PriceTicks and SizeUnits)
+// onto the increments. A buy's price rounds down and a sell's up, so rounding
+// never makes the limit more aggressive; the size rounds down, so it never
+// exceeds what was sized. It returns an error wrapping ErrInvalidOrder
+// when the rounded price is not strictly between 0 and 1 or the rounded size
+// is below MinOrderSize.
+func (in OrderIncrements) Normalize(side OrderSide, priceTicks, sizeUnits int64) (int64, int64, error) {
+	if tick := ToPriceTicks(in.TickSize); tick > 0 {
+		if side == OrderSideSell {
+			priceTicks = (priceTicks - incrementSlack + tick - 1) / tick * tick
+		} else {
+			priceTicks = (priceTicks + incrementSlack) / tick * tick
+		}
+	}
+	if step := ToSizeUnits(in.SizeStep); step > 0 {
+		sizeUnits = (sizeUnits + incrementSlack) / step * step
+	}
+	if priceTicks <= 0 || priceTicks >= PriceScale {
+		return 0, 0, fmt.Errorf("%w: price %.6f outside (0, 1) at tick size %g",
+			ErrInvalidOrder, TicksToPrice(priceTicks), in.TickSize)
+	}
+	if sizeUnits <= 0 || UnitsToSize(sizeUnits) < in.MinOrderSize {
+		return 0, 0, fmt.Errorf("%w: size %.6f below minimum order size %g",
+			ErrInvalidOrder, UnitsToSize(sizeUnits), in.MinOrderSize)
+	}
+	return priceTicks, sizeUnits, nil
+}
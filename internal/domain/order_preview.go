@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// OrderPreview is what placing a signal would do right now, computed without
+// signing or posting it: the order after risk sizing, the checks it would
+// fail, and its expected fill against the current book.
+type OrderPreview struct {
+	SignalID  string
+	MarketID  string
+	TokenID   string
+	Side      OrderSide
+	Type      OrderType
+	ExpiresAt *time.Time // GTD expiration
+
+	Price float64 // limit price
+	Size  float64 // shares after risk sizing
+	// RequestedSize is the size of the signal before risk sizing (close
+	// haircut, minimum profitable size).
+	RequestedSize float64
+	Notional      float64 // Price * Size
+
+	// Accepted is true when no check would refuse the order; Rejections
+	// lists the reasons otherwise.
+	Accepted   bool
+	Rejections []string
+
+	// Book at preview time. BookAt is zero when no book was cached.
+	BestBid float64
+	BestAsk float64
+	BookAt  time.Time
+
+	// MatchedSize is the part of Size the book fills immediately at or
+	// better than Price, at the volume-weighted MatchPrice. SlippageBps is
+	// MatchPrice against the touch (best ask for buys, best bid for sells).
+	// RestingSize is what would rest on the book: the unmatched part of a
+	// GTC or GTD order; FOK and FAK remainders are cancelled.
+	MatchedSize float64
+	MatchPrice  float64
+	SlippageBps float64
+	RestingSize float64
+
+	// Fees: the taker fee on the matched part and the maker fee on the
+	// resting part, in bps of notional and in USDC (negative for a rebate).
+	TakerFeeBps float64
+	MakerFeeBps float64
+	FeeUSD      float64
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// OrderRounding is the precision the CLOB expects of an order's signed
+// amounts, as the official clients derive it from the token's tick size:
+// prices to the tick's decimals, sizes to two decimals, and the collateral
+// leg (price times size) to AmountDecimals.
+type OrderRounding struct {
+	PriceDecimals  int
+	SizeDecimals   int
+	AmountDecimals int
+}
+
+// orderRoundings are the official clients' rounding configs by tick size.
+var orderRoundings = map[int64]OrderRounding{ // by tick in PriceTicks
+	100_000: {PriceDecimals: 1, SizeDecimals: 2, AmountDecimals: 3}, // 0.1
+	10_000:  {PriceDecimals: 2, SizeDecimals: 2, AmountDecimals: 4}, // 0.01
+	1_000:   {PriceDecimals: 3, SizeDecimals: 2, AmountDecimals: 5}, // 0.001
+	100:     {PriceDecimals: 4, SizeDecimals: 2, AmountDecimals: 6}, // 0.0001
+}
+
+// RoundingForTick returns the rounding of a token with the given tick size.
+// An unknown or zero tick size (increments not looked up) gets the finest
+// config, 0.0001, so the price is changed as little as possible.
+func RoundingForTick(tickSize float64) OrderRounding {
+	if r, ok := orderRoundings[ToPriceTicks(tickSize)]; ok {
+		return r
+	}
+	return orderRoundings[100]
+}
+
+// unitStep returns the fixed-point step of a value with the given decimals
+// at a scale of 10^6: 10^(6-decimals), at least 1.
+func unitStep(decimals int) int64 {
+	step := int64(1)
+	for i := decimals; i < 6; i++ {
+		step *= 10
+	}
+	return step
+}
+
+// Round rounds a fixed-point price to the nearest PriceDecimals and a size
+// down to SizeDecimals, as the official clients do before deriving the
+// amounts. It returns an error wrapping ErrInvalidOrder when the price
+// leaves (0, 1) or the size rounds to zero.
+func (r OrderRounding) Round(priceTicks, sizeUnits int64) (int64, int64, error) {
+	if step := unitStep(r.PriceDecimals); step > 1 {
+		priceTicks = (priceTicks + step/2) / step * step
+	}
+	if step := unitStep(r.SizeDecimals); step > 1 {
+		sizeUnits = sizeUnits / step * step
+	}
+	if priceTicks <= 0 || priceTicks >= PriceScale {
+		return 0, 0, fmt.Errorf("%w: price %.6f outside (0, 1) at %d decimals",
+			ErrInvalidOrder, TicksToPrice(priceTicks), r.PriceDecimals)
+	}
+	if sizeUnits <= 0 {
+		return 0, 0, fmt.Errorf("%w: size rounds to zero at %d decimals", ErrInvalidOrder, r.SizeDecimals)
+	}
+	return priceTicks, sizeUnits, nil
+}
+
+// Amounts rounds the price and size (see Round) and returns the maker and
+// taker amounts, in base units of a collateral with the given decimals, to
+// sign into an order on side. A buy's maker amount is the collateral paid,
+// price times size, and its taker amount the shares received; a sell's maker
+// amount is the shares given and its taker amount the collateral received.
+// The collateral leg is rounded down to AmountDecimals, the share leg is the
+// rounded size. The rounded price and size are returned with the amounts.
+func (r OrderRounding) Amounts(side OrderSide, priceTicks, sizeUnits int64, decimals int) (maker, taker *big.Int, price, size int64, err error) {
+	price, size, err = r.Round(priceTicks, sizeUnits)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	scale := decimalScale(decimals)
+	shares := new(big.Int).Mul(big.NewInt(size), scale)
+	shares.Quo(shares, big.NewInt(SizeScale))
+
+	// price * size at a scale of 10^12, truncated to AmountDecimals, then
+	// rescaled to the collateral's base units.
+	collateral := new(big.Int).Mul(big.NewInt(price), big.NewInt(size))
+	collateral.Quo(collateral, decimalScale(12-r.AmountDecimals))
+	collateral.Mul(collateral, scale)
+	collateral.Quo(collateral, decimalScale(r.AmountDecimals))
+
+	if side == OrderSideSell {
+		return shares, collateral, price, size, nil
+	}
+	return collateral, shares, price, size, nil
+}
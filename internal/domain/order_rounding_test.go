@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRoundingForTick(t *testing.T) {
+	tests := []struct {
+		tick float64
+		want OrderRounding
+	}{
+		{0.1, OrderRounding{PriceDecimals: 1, SizeDecimals: 2, AmountDecimals: 3}},
+		{0.01, OrderRounding{PriceDecimals: 2, SizeDecimals: 2, AmountDecimals: 4}},
+		{0.001, OrderRounding{PriceDecimals: 3, SizeDecimals: 2, AmountDecimals: 5}},
+		{0.0001, OrderRounding{PriceDecimals: 4, SizeDecimals: 2, AmountDecimals: 6}},
+		{0, OrderRounding{PriceDecimals: 4, SizeDecimals: 2, AmountDecimals: 6}},
+		{0.05, OrderRounding{PriceDecimals: 4, SizeDecimals: 2, AmountDecimals: 6}},
+	}
+	for _, tt := range tests {
+		if got := RoundingForTick(tt.tick); got != tt.want {
+			t.Errorf("RoundingForTick(%v) = %+v, want %+v", tt.tick, got, tt.want)
+		}
+	}
+}
+
+func TestOrderRoundingRound(t *testing.T) {
+	tests := []struct {
+		name      string
+		tick      float64
+		price     float64
+		size      float64
+		wantPrice int64
+		wantSize  int64
+	}{
+		{"0.1 rounds up", 0.1, 0.56, 10.129, 600_000, 10_120_000},
+		{"0.1 rounds down", 0.1, 0.44, 5.555, 400_000, 5_550_000},
+		{"0.01 half rounds up", 0.01, 0.505, 1, 510_000, 1_000_000},
+		{"0.01 rounds down", 0.01, 0.504, 1.999, 500_000, 1_990_000},
+		{"0.001", 0.001, 0.1234, 7.777, 123_000, 7_770_000},
+		{"0.0001", 0.0001, 0.12345, 3.333, 123_500, 3_330_000},
+		{"0.0001 exact", 0.0001, 0.9999, 0.01, 999_900, 10_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, size, err := RoundingForTick(tt.tick).Round(ToPriceTicks(tt.price), ToSizeUnits(tt.size))
+			if err != nil {
+				t.Fatalf("Round: %v", err)
+			}
+			if price != tt.wantPrice || size != tt.wantSize {
+				t.Errorf("Round = (%d, %d), want (%d, %d)", price, size, tt.wantPrice, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestOrderRoundingRoundErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		tick  float64
+		price float64
+		size  float64
+	}{
+		{"size truncates to zero", 0.01, 0.5, 0.009},
+		{"zero size", 0.01, 0.5, 0},
+		{"price rounds to one", 0.01, 0.999, 1},
+		{"price rounds to zero", 0.01, 0.004, 1},
+		{"price rounds to one at 0.1", 0.1, 0.96, 1},
+		{"negative price", 0.0001, -0.5, 1},
+		{"price above one", 0.0001, 1.5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := RoundingForTick(tt.tick).Round(ToPriceTicks(tt.price), ToSizeUnits(tt.size))
+			if !errors.Is(err, ErrInvalidOrder) {
+				t.Fatalf("Round error = %v, want ErrInvalidOrder", err)
+			}
+			_, _, _, _, err = RoundingForTick(tt.tick).Amounts(OrderSideBuy, ToPriceTicks(tt.price), ToSizeUnits(tt.size), 6)
+			if !errors.Is(err, ErrInvalidOrder) {
+				t.Fatalf("Amounts error = %v, want ErrInvalidOrder", err)
+			}
+		})
+	}
+}
+
+func TestOrderRoundingAmounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		tick      float64
+		side      OrderSide
+		price     float64
+		size      float64
+		decimals  int
+		wantMaker string
+		wantTaker string
+	}{
+		// Vectors of the official CLOB clients.
+		{"reference buy", 0.01, OrderSideBuy, 0.50, 21.04, 6, "10520000", "21040000"},
+		{"reference sell", 0.01, OrderSideSell, 0.56, 21.04, 6, "21040000", "11782400"},
+
+		{"0.1 buy", 0.1, OrderSideBuy, 0.56, 10.129, 6, "6072000", "10120000"},
+		{"0.1 sell", 0.1, OrderSideSell, 0.44, 5.555, 6, "5550000", "2220000"},
+		{"0.01 buy", 0.01, OrderSideBuy, 0.505, 3.337, 6, "1698300", "3330000"},
+		{"0.01 sell", 0.01, OrderSideSell, 0.57, 3.33, 6, "3330000", "1898100"},
+		{"0.001 buy", 0.001, OrderSideBuy, 0.1234, 7.777, 6, "955710", "7770000"},
+		{"0.001 sell", 0.001, OrderSideSell, 0.1234, 7.777, 6, "7770000", "955710"},
+		{"0.0001 buy", 0.0001, OrderSideBuy, 0.12345, 3.333, 6, "411255", "3330000"},
+		{"0.0001 sell", 0.0001, OrderSideSell, 0.12345, 3.333, 6, "3330000", "411255"},
+
+		{"18 decimals buy", 0.01, OrderSideBuy, 0.50, 21.04, 18, "10520000000000000000", "21040000000000000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maker, taker, _, _, err := RoundingForTick(tt.tick).Amounts(tt.side, ToPriceTicks(tt.price), ToSizeUnits(tt.size), tt.decimals)
+			if err != nil {
+				t.Fatalf("Amounts: %v", err)
+			}
+			if maker.String() != tt.wantMaker || taker.String() != tt.wantTaker {
+				t.Errorf("Amounts = (%s, %s), want (%s, %s)", maker, taker, tt.wantMaker, tt.wantTaker)
+			}
+		})
+	}
+}
@@ -17,6 +17,12 @@ type OrderbookSnapshot struct {
 	BestAsk   float64
 	MidPrice  float64
 	Timestamp time.Time
+
+	// Version counts the changes applied to the cached book: each snapshot
+	// and level update increments it. Stale is set when the book kept
+	// changing while it was read, so its levels may mix two versions.
+	Version int64
+	Stale   bool
 }
 
 // PriceChange is an incremental orderbook level update.
@@ -33,6 +39,7 @@ type LastTradePrice struct {
 	AssetID   string
 	Price     float64
 	Size      float64
+	Side      OrderSide // taker side; empty when the venue did not say
 	Timestamp time.Time
 }
 
@@ -45,3 +52,29 @@ type PriceSnapshot struct {
 	Spread   float64
 	Time     time.Time
 }
+
+// BookEventKind distinguishes full snapshots from incremental deltas in the
+// recorded book history.
+type BookEventKind string
+
+const (
+	BookEventSnapshot BookEventKind = "snapshot"
+	BookEventDelta    BookEventKind = "delta"
+)
+
+// BookEvent is one recorded orderbook event for an asset. Snapshot events
+// carry the full Bids/Asks; delta events carry a single Side/Price/Size level
+// change. BestBid/BestAsk reflect the book after the event was applied.
+type BookEvent struct {
+	ID        int64
+	AssetID   string
+	Kind      BookEventKind
+	Bids      []PriceLevel
+	Asks      []PriceLevel
+	Side      string
+	Price     float64
+	Size      float64
+	BestBid   float64
+	BestAsk   float64
+	Timestamp time.Time
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// StrategyDay is one strategy's trading activity on one UTC day: positions
+// closed, orders created and arb executions started that day. It is the unit
+// strategy performance is persisted and aggregated in.
+type StrategyDay struct {
+	Day         time.Time // midnight UTC
+	Strategy    string
+	Closed      int     // positions closed
+	Wins        int     // closed positions with positive realized PnL
+	RealizedPnL float64 // of the closed positions
+	// ClosedNotional is the entry notional of the closed positions.
+	ClosedNotional float64
+	Orders         int     // orders created
+	FilledOrders   int     // orders created that day with any fill
+	FilledNotional float64 // filled size * limit price of those orders
+	// TakerNotional is the filled notional of FOK/FAK orders outside arb
+	// executions; the venue taker fee is charged on it.
+	TakerNotional float64
+	// ExpectedEdgeUSD is the edge the strategy's signals claimed (edge_bps
+	// metadata) times the notional filled on them; EdgeNotional is that
+	// filled notional. Signals are only known when signal recording is on.
+	ExpectedEdgeUSD float64
+	EdgeNotional    float64
+	ArbExecutions   int
+	ArbFeesUSD      float64 // fees recorded on arb execution legs
+	ArbSlippageUSD  float64
+	FeesUSD         float64 // ArbFeesUSD plus the taker fee on TakerNotional
+	ComputedAt      time.Time
+}
+
+// StrategyPerformance attributes a strategy's results over a window of whole
+// UTC days.
+type StrategyPerformance struct {
+	Strategy       string
+	Closed         int
+	Wins           int
+	RealizedPnL    float64
+	Orders         int
+	FilledOrders   int
+	FilledNotional float64
+	FeesUSD        float64
+	ArbExecutions  int
+	// ExpectedEdgeBps is the notional-weighted edge the strategy's filled
+	// signals claimed; CapturedEdgeBps is the realized PnL per entry notional
+	// of the positions it closed.
+	ExpectedEdgeBps float64
+	CapturedEdgeBps float64
+	// Sharpe is the mean over the standard deviation of daily realized PnL,
+	// annualized by sqrt(365); days without closes count as 0.
+	Sharpe      float64
+	MaxDrawdown float64 // largest fall of cumulative daily realized PnL from a peak
+}
+
+// WinRate returns Wins / Closed, or 0 when nothing closed.
+func (p StrategyPerformance) WinRate() float64 {
+	if p.Closed == 0 {
+		return 0
+	}
+	return float64(p.Wins) / float64(p.Closed)
+}
+
+// PerformanceWindow is the performance of every strategy over the Days whole
+// UTC days [Since, Until).
+type PerformanceWindow struct {
+	Days       int
+	Since      time.Time
+	Until      time.Time
+	Strategies []StrategyPerformance // sorted by strategy name
+}
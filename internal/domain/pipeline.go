@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// PipelineRunStatus is the lifecycle state of a requested pipeline run.
+type PipelineRunStatus string
+
+const (
+	PipelineRunQueued  PipelineRunStatus = "queued"
+	PipelineRunRunning PipelineRunStatus = "running"
+	PipelineRunDone    PipelineRunStatus = "done"
+	PipelineRunFailed  PipelineRunStatus = "failed"
+)
+
+// PipelineRun is one on-demand execution of the data pipeline (market scrape
+// plus Goldsky fill ingestion). Stats holds per-stage counters such as
+// "fills" and "trades_ingested".
+type PipelineRun struct {
+	ID          string
+	Trigger     string // who requested the run, e.g. "api"
+	Status      PipelineRunStatus
+	Stats       map[string]int64
+	Error       string
+	RequestedAt time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// DeadLetterBatch is a batch of raw fills the pipeline failed to process,
+// kept with the failure so it can be replayed instead of waiting for the
+// next scrape of its window.
+type DeadLetterBatch struct {
+	ID       string // assigned by the queue
+	Stage    string // pipeline stage that failed, e.g. "trade_processing"
+	Fills    []RawFill
+	Error    string
+	Attempts int // processing attempts so far, the original one included
+	FailedAt time.Time
+}
+
+// DeadLetterRetry summarizes one replay of dead-lettered batches.
+type DeadLetterRetry struct {
+	Batches        int // batches read from the queue
+	Replayed       int // batches processed and removed
+	Failed         int // batches that failed again and were requeued
+	Parked         int // batches that reached the attempt limit and were parked
+	Fills          int // fills replayed, those of partly failed batches included
+	Duplicates     int // fills skipped as already replayed, by fill ID
+	TradesIngested int
+}
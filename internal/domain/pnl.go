@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// PositionFilter narrows position queries. Empty fields match everything.
+type PositionFilter struct {
+	Wallet   string
+	Strategy string
+	Status   PositionStatus
+}
+
+// PnLBucket is the realized PnL of the positions one strategy closed on one
+// UTC day.
+type PnLBucket struct {
+	Day         time.Time // midnight UTC
+	Strategy    string
+	RealizedPnL float64
+	Closed      int
+}
+
+// StrategyPnL is a strategy's realized PnL over a period and the unrealized
+// PnL of its open positions at the current price.
+type StrategyPnL struct {
+	Strategy      string
+	RealizedPnL   float64
+	UnrealizedPnL float64
+	Closed        int
+	Open          int
+}
+
+// DailyPnL is the realized PnL booked on one UTC day across strategies.
+type DailyPnL struct {
+	Day         time.Time
+	RealizedPnL float64
+	Closed      int
+}
+
+// PnLSummary aggregates realized PnL of positions closed in [Since, Until)
+// and the unrealized PnL of positions open now.
+type PnLSummary struct {
+	Since         time.Time
+	Until         time.Time
+	RealizedPnL   float64
+	UnrealizedPnL float64
+	ByStrategy    []StrategyPnL // sorted by strategy name
+	ByDay         []DailyPnL    // oldest first
+	Unpriced      int           // open positions without a current price
+}
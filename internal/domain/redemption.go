@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// RedemptionStatus is the lifecycle state of an on-chain redemption.
+type RedemptionStatus string
+
+const (
+	RedemptionPending   RedemptionStatus = "pending"   // recorded, not yet broadcast
+	RedemptionSubmitted RedemptionStatus = "submitted" // broadcast, awaiting confirmations
+	RedemptionConfirmed RedemptionStatus = "confirmed"
+	RedemptionFailed    RedemptionStatus = "failed"
+)
+
+// Redemption is a redeemPositions transaction that turns the wallet's tokens
+// in a resolved market into USDC. Shares and Payouts are per outcome, parallel
+// to the market's token IDs; Proceeds is the USDC they pay. PositionIDs are
+// the positions the proceeds were booked against once confirmed.
+type Redemption struct {
+	ID          string
+	Wallet      string
+	MarketID    string
+	ConditionID string
+	NegRisk     bool
+	TokenIDs    []string
+	Shares      []float64
+	Payouts     []float64 // USDC per share
+	Proceeds    float64
+	PositionIDs []string
+	TxHash      string
+	BlockNumber int64
+	GasUsed     int64
+	Status      RedemptionStatus
+	Error       string
+	CreatedAt   time.Time
+	ConfirmedAt *time.Time
+}
+
+// Active reports whether the redemption may still be mined.
+func (r Redemption) Active() bool {
+	return r.Status == RedemptionPending || r.Status == RedemptionSubmitted
+}
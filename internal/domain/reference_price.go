@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReferencePrice is an external spot price of a crypto asset, e.g. BTC/USD
+// from an exchange's public ticker.
+type ReferencePrice struct {
+	Asset  string // lower-case symbol: "btc", "eth", "sol", "doge"
+	Price  float64
+	Source string // exchange the price came from, e.g. "binance"
+	At     time.Time
+}
+
+// ReferencePriceProvider serves external spot prices to strategies pricing
+// crypto up/down markets.
+type ReferencePriceProvider interface {
+	// ReferencePrice returns the latest spot price of asset, or ErrNotFound
+	// when none has been seen.
+	ReferencePrice(ctx context.Context, asset string) (ReferencePrice, error)
+	// ReferenceMove returns the fractional change of asset's spot price over
+	// the last window (0.01 = +1%), or ErrNotFound when the history does not
+	// reach back that far.
+	ReferenceMove(ctx context.Context, asset string, window time.Duration) (float64, error)
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// PortfolioRiskState is a point-in-time view of aggregate portfolio risk and
+// the global kill switch.
+type PortfolioRiskState struct {
+	OpenPositions  int
+	Exposure       float64 // sum of |size * mark price| over open positions, USD
+	UnrealizedPnL  float64
+	RealizedPnL    float64 // realized today (UTC)
+	DailyPnL       float64 // RealizedPnL + UnrealizedPnL - the day's opening mark, re-marked at a reset
+	DailyLossLimit float64 // 0 = kill switch disabled
+	Tripped        bool
+	TripReason     string
+	TrippedAt      *time.Time
+	ResetAt        *time.Time
+	UpdatedAt      time.Time
+}
+
+// KillSwitchState is the persisted part of a wallet's portfolio kill switch:
+// the trip, and the mark daily PnL is measured from, so a restart neither
+// re-arms trading nor forgets the day's losses.
+type KillSwitchState struct {
+	Wallet     string
+	Tripped    bool
+	TripReason string
+	TrippedAt  *time.Time
+	ResetAt    *time.Time
+	Baseline   float64   // PnL DailyPnL is measured from
+	Day        time.Time // UTC day Baseline applies to; zero before the first check
+	UpdatedAt  time.Time
+}
@@ -19,23 +19,33 @@ type TradeSignal struct {
 	MarketID   string
 	TokenID    string
 	Side       OrderSide
-	PriceTicks int64             // fixed-point price, 1e6 ticks
-	SizeUnits  int64             // fixed-point size, 1e6 units
+	PriceTicks int64             // fixed-point price, PriceScale ticks
+	SizeUnits  int64             // fixed-point size, SizeScale units
 	Urgency    SignalUrgency
 	Reason     string
 	Metadata   map[string]string
 	CreatedAt  time.Time
 	ExpiresAt  time.Time
+
+	// OrderType is the time in force of the order placed for this signal;
+	// empty means GTC. OrderExpiration is when a GTD order lapses and must
+	// be zero for every other type.
+	OrderType       OrderType
+	OrderExpiration time.Time
+
+	// EmittedAt is when the strategy engine handed the signal to the
+	// executor; latency tracking measures queueing from it.
+	EmittedAt time.Time
 }
 
 // Price returns the display price from fixed-point ticks.
 func (s TradeSignal) Price() float64 {
-	return float64(s.PriceTicks) / 1e6
+	return TicksToPrice(s.PriceTicks)
 }
 
 // Size returns the display size from fixed-point units.
 func (s TradeSignal) Size() float64 {
-	return float64(s.SizeUnits) / 1e6
+	return UnitsToSize(s.SizeUnits)
 }
 
 // ArbOpportunity represents a detected cross-platform arbitrage.
@@ -57,6 +67,16 @@ type ArbOpportunity struct {
 	DetectedAt      time.Time
 	Duration        time.Duration
 	Executed        bool
+
+	// Lifecycle, see ArbOpportunityStatus. Realized values are set once
+	// executed.
+	Status          ArbOpportunityStatus
+	DedupKey        string
+	LastSeenAt      time.Time
+	ClosedAt        *time.Time
+	ExecutionID     string
+	RealizedEdgeBps float64
+	RealizedPnLUSD  float64
 }
 
 // BotStatus is a summary of the bot's current operational state.
@@ -68,3 +88,69 @@ type BotStatus struct {
 	OpenOrders    int32
 	StrategyName  string
 }
+
+// StrategyResourceUsage summarises how much engine time and in-memory state a
+// strategy consumes. WallTime and the per-event AvgWall, MaxWall and LastWall are
+// wall-clock time spent inside the strategy's event callbacks, not CPU time:
+// they include time the callback waited on locks or I/O and time its
+// goroutine was not scheduled.
+type StrategyResourceUsage struct {
+	Strategy    string
+	Events      int64
+	Signals     int64
+	WallTime    time.Duration
+	AvgWall     time.Duration // WallTime per event
+	MaxWall     time.Duration // slowest single event
+	LastWall    time.Duration
+	OverBudget  int64
+	HeldEntries map[string]int   // e.g. "pairs", "quotes", "last_emit"
+	Evicted     map[string]int64 // entries expired or evicted by size caps, same keys
+	LastEventAt time.Time
+
+	// Queue pressure in multi-strategy mode, keyed by queue ("book", "price",
+	// "trade", "market"). Dropped events were lost; coalesced book and price
+	// updates were replaced in the queue by a newer one for the strategy.
+	Dropped     map[string]int64
+	Coalesced   map[string]int64
+	MaxBookLag  time.Duration // age of the oldest book snapshot processed
+	LastBookLag time.Duration
+}
+
+// Overflow policies for a strategy's event queues when they are full.
+const (
+	QueueOverflowDropOldest = "drop_oldest" // discard the oldest queued event
+	QueueOverflowDropNewest = "drop_newest" // discard the incoming event
+	QueueOverflowBlock      = "block"       // wait up to BlockTimeout, then discard the incoming event
+)
+
+// StrategyQueuePolicy sizes the per-strategy event queues of multi-strategy
+// mode and decides what happens to an event when one is full.
+type StrategyQueuePolicy struct {
+	BufferSize   int // events per queue
+	Overflow     string
+	BlockTimeout time.Duration
+}
+
+// StrategyQueueStats is the backpressure state of one strategy's event
+// queues, keyed by queue ("book", "price", "trade", "market").
+type StrategyQueueStats struct {
+	Strategy    string
+	Depth       map[string]int   // events waiting now
+	Dropped     map[string]int64 // events lost to a full queue
+	Coalesced   map[string]int64 // book and price updates replaced by a newer one
+	Blocked     map[string]int64 // sends that waited for room (overflow "block")
+	BlockedTime time.Duration    // total time senders waited
+}
+
+// StrategyBreakerState is a strategy's circuit breaker: whether it is tripped
+// (the strategy's signals are discarded) and the counters that trip it.
+type StrategyBreakerState struct {
+	Strategy            string
+	Tripped             bool
+	Reason              string // why it tripped; empty while closed
+	TrippedAt           *time.Time
+	ConsecutiveFailures int
+	WindowLossUSD       float64 // realized losses within the loss window, >= 0
+	Orders              int64   // order outcomes seen since start
+	Failures            int64
+}
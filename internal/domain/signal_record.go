@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+// SignalStatus is what became of a recorded signal.
+type SignalStatus string
+
+const (
+	// SignalStatusPending: the executor has not handled the signal (yet).
+	SignalStatusPending SignalStatus = "pending"
+	// SignalStatusPlaced: an order was accepted for the signal.
+	SignalStatusPlaced SignalStatus = "placed"
+	// SignalStatusRejected: a risk check or the venue refused the order.
+	SignalStatusRejected SignalStatus = "rejected"
+	// SignalStatusFailed: placing the order failed.
+	SignalStatusFailed SignalStatus = "failed"
+	// SignalStatusExpired: the signal expired before an order was placed.
+	SignalStatusExpired SignalStatus = "expired"
+	// SignalStatusSkipped: the executor dropped the signal without trying to
+	// place it (duplicate, kill switch, manual-only, quote pull).
+	SignalStatusSkipped SignalStatus = "skipped"
+	// SignalStatusRouted: a manual-trading candidate was sent to the
+	// executor on request; the executor's resolution replaces it.
+	SignalStatusRouted SignalStatus = "routed"
+)
+
+// Valid reports whether s is a known status.
+func (s SignalStatus) Valid() bool {
+	switch s {
+	case SignalStatusPending, SignalStatusPlaced, SignalStatusRejected,
+		SignalStatusFailed, SignalStatusExpired, SignalStatusSkipped,
+		SignalStatusRouted:
+		return true
+	}
+	return false
+}
+
+// SignalResolution is the executor's verdict on one signal. OrderIDs are the
+// orders placed for it: the signal's own ID, plus a retry's.
+type SignalResolution struct {
+	Signal   TradeSignal
+	Status   SignalStatus
+	Reason   string
+	OrderIDs []string
+	At       time.Time
+}
+
+// SignalRecord is a recorded signal with its outcome. FilledSize and
+// LastFillAt are summed over the signal's orders.
+type SignalRecord struct {
+	Signal       TradeSignal
+	Status       SignalStatus
+	StatusReason string
+	OrderIDs     []string
+	ResolvedAt   time.Time // zero while pending
+	FilledSize   float64
+	LastFillAt   time.Time
+}
+
+// SignalFilter selects recorded signals. Empty fields match everything;
+// From and To bound created_at to [From, To).
+type SignalFilter struct {
+	Strategy string
+	MarketID string
+	TokenID  string
+	Status   SignalStatus
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
@@ -28,9 +28,20 @@ type MarketStore interface {
 type OrderStore interface {
 	Create(ctx context.Context, order Order) error
 	UpdateStatus(ctx context.Context, id string, status OrderStatus) error
+	// SetExchangeID records the CLOB order hash assigned to a local order.
+	SetExchangeID(ctx context.Context, id, exchangeID string) error
+	// RecordFill sets the cumulative filled size and status of an order.
+	RecordFill(ctx context.Context, id string, filledSize float64, status OrderStatus) error
 	GetByID(ctx context.Context, id string) (Order, error)
+	GetByExchangeID(ctx context.Context, exchangeID string) (Order, error)
+	// ListOpen returns the wallet's live orders: pending, open or partially filled.
 	ListOpen(ctx context.Context, wallet string) ([]Order, error)
 	ListByMarket(ctx context.Context, marketID string, opts ListOpts) ([]Order, error)
+	// ListFilled returns the wallet's orders with fills (matched, or any status
+	// with a filled size) filled at or before until, oldest first.
+	ListFilled(ctx context.Context, wallet string, until time.Time) ([]Order, error)
+	// ListRange returns orders created in [from, to), oldest first (for audits).
+	ListRange(ctx context.Context, from, to time.Time) ([]Order, error)
 	// ListBefore returns all orders created strictly before the given time (for archiving).
 	ListBefore(ctx context.Context, before time.Time) ([]Order, error)
 	// DeleteBefore deletes orders created before the given time (for retention purge). Returns count deleted.
@@ -45,14 +56,27 @@ type PositionStore interface {
 	GetOpen(ctx context.Context, wallet string) ([]Position, error)
 	GetByID(ctx context.Context, id string) (Position, error)
 	ListHistory(ctx context.Context, wallet string, opts ListOpts) ([]Position, error)
+	// RealizedPnLSince sums the realized PnL of positions closed after since.
+	RealizedPnLSince(ctx context.Context, wallet string, since time.Time) (float64, error)
+	// List returns positions matching filter, newest first. opts.Since and
+	// opts.Until bound opened_at.
+	List(ctx context.Context, filter PositionFilter, opts ListOpts) ([]Position, error)
+	// RealizedPnLByDay sums the realized PnL of positions matching filter that
+	// closed in [since, until), per strategy and UTC day.
+	RealizedPnLByDay(ctx context.Context, filter PositionFilter, since, until time.Time) ([]PnLBucket, error)
 }
 
 // TradeStore persists enriched trade fills.
 type TradeStore interface {
-	InsertBatch(ctx context.Context, trades []Trade) error
+	// InsertBatch stores trades, skipping those already stored (see Trade),
+	// and returns the number inserted. Inserted trades get their ID set;
+	// skipped ones keep ID 0.
+	InsertBatch(ctx context.Context, trades []Trade) (int64, error)
 	GetLastTimestamp(ctx context.Context) (time.Time, error)
 	ListByMarket(ctx context.Context, marketID string, opts ListOpts) ([]Trade, error)
 	ListByWallet(ctx context.Context, wallet string, opts ListOpts) ([]Trade, error)
+	// ListRange returns trades with timestamp in [from, to] ordered oldest first (for backtests). A limit <= 0 means no limit.
+	ListRange(ctx context.Context, from, to time.Time, limit int) ([]Trade, error)
 	// ListBefore returns all trades with timestamp strictly before the given time (for archiving).
 	ListBefore(ctx context.Context, before time.Time) ([]Trade, error)
 	// DeleteBefore deletes trades with timestamp before the given time (for retention purge). Returns count deleted.
@@ -63,6 +87,10 @@ type TradeStore interface {
 type ArbStore interface {
 	Insert(ctx context.Context, opp ArbOpportunity) error
 	MarkExecuted(ctx context.Context, id string) error
+	// Close moves an opportunity out of open: to executed from open or
+	// expired, to expired from open only. It returns ErrNotFound when no
+	// opportunity id can make that transition.
+	Close(ctx context.Context, id string, c ArbOpportunityClose) error
 	ListRecent(ctx context.Context, limit int) ([]ArbOpportunity, error)
 	// ListBefore returns all arb opportunities detected strictly before the given time (for archiving).
 	ListBefore(ctx context.Context, before time.Time) ([]ArbOpportunity, error)
@@ -78,10 +106,34 @@ type AuditEntry struct {
 	CreatedAt time.Time
 }
 
+// AuditFilter selects audit entries. Zero fields do not filter.
+type AuditFilter struct {
+	Events     []string  // any of these event names
+	From       time.Time // created at or after
+	To         time.Time // created before
+	OrderID    string    // detail.order_id
+	PositionID string    // detail.position_id
+}
+
+// AuditQuery pages the audit entries matching a filter, newest first.
+type AuditQuery struct {
+	AuditFilter
+	Before *AuditPosition // resume after this entry
+	Limit  int
+}
+
+// AuditPosition is the position of an entry in the newest-first audit log;
+// a query resumes strictly after (older than) it.
+type AuditPosition struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
 // AuditStore persists an append-only audit log.
 type AuditStore interface {
 	Log(ctx context.Context, event string, detail map[string]any) error
 	List(ctx context.Context, opts ListOpts) ([]AuditEntry, error)
+	Query(ctx context.Context, q AuditQuery) ([]AuditEntry, error)
 }
 
 // StrategyConfig is a named strategy configuration blob.
@@ -92,11 +144,43 @@ type StrategyConfig struct {
 	UpdatedAt time.Time
 }
 
-// StrategyConfigStore persists strategy configurations.
+// Sources of a StrategyConfigVersion.
+const (
+	StrategyVersionStartup  = "startup"  // effective parameters after startup
+	StrategyVersionAPI      = "api"      // PUT /api/strategy/{name}/params
+	StrategyVersionReload   = "reload"   // POST /api/config/reload
+	StrategyVersionRollback = "rollback" // rollback to an earlier version
+)
+
+// StrategyConfigVersion is a saved version of a strategy's effective
+// parameters, runtime overrides included. Versions count from 1 per
+// strategy.
+type StrategyConfigVersion struct {
+	Name       string
+	Version    int
+	Params     map[string]any // effective parameters
+	Overrides  map[string]any // runtime overrides persisted at the time
+	Source     string         // one of the StrategyVersion* constants
+	RollbackOf int            // version rolled back to when Source is "rollback"
+	CreatedAt  time.Time
+}
+
+// StrategyConfigStore persists strategy configurations and the version
+// history of their effective parameters.
 type StrategyConfigStore interface {
 	Get(ctx context.Context, name string) (StrategyConfig, error)
 	Upsert(ctx context.Context, cfg StrategyConfig) error
 	List(ctx context.Context) ([]StrategyConfig, error)
+	// SaveVersion stores v as the next version of v.Name and returns it with
+	// Version and CreatedAt set.
+	SaveVersion(ctx context.Context, v StrategyConfigVersion) (StrategyConfigVersion, error)
+	// ListVersions returns up to limit versions of name, newest first.
+	ListVersions(ctx context.Context, name string, limit int) ([]StrategyConfigVersion, error)
+	// GetVersion returns ErrNotFound when name has no such version.
+	GetVersion(ctx context.Context, name string, version int) (StrategyConfigVersion, error)
+	// LatestVersions returns the newest version of every strategy that has
+	// one.
+	LatestVersions(ctx context.Context) ([]StrategyConfigVersion, error)
 }
 
 // ConditionGroupStore persists condition groups and their market links.
@@ -113,6 +197,8 @@ type BondPositionStore interface {
 	Create(ctx context.Context, pos BondPosition) error
 	Update(ctx context.Context, pos BondPosition) error
 	GetOpen(ctx context.Context) ([]BondPosition, error)
+	// GetClosed returns resolved and exited positions, most recent first.
+	GetClosed(ctx context.Context) ([]BondPosition, error)
 	GetByID(ctx context.Context, id string) (BondPosition, error)
 }
 
@@ -132,4 +218,193 @@ type ArbExecutionStore interface {
 	ListRecent(ctx context.Context, limit int) ([]ArbExecution, error)
 	SumPnL(ctx context.Context, since time.Time) (float64, error)
 	SumPnLByType(ctx context.Context, arbType ArbType, since time.Time) (float64, error)
+	// Stats aggregates the opportunities and executions in the query's range.
+	Stats(ctx context.Context, q ArbStatsQuery) (ArbStats, error)
+}
+
+// OnchainEventStore persists CTF splits, merges and redemptions.
+type OnchainEventStore interface {
+	// InsertBatch stores events, skipping those already stored (same kind
+	// and ID), and returns the number inserted.
+	InsertBatch(ctx context.Context, events []OnchainEvent) (int64, error)
+	// LastTimestamp returns the time of the newest stored event of kind, or
+	// the zero time when there is none.
+	LastTimestamp(ctx context.Context, kind OnchainEventKind) (time.Time, error)
+	// ListByWallet returns wallet's events, newest first. opts.Since and
+	// opts.Until bound the event time.
+	ListByWallet(ctx context.Context, wallet string, opts ListOpts) ([]OnchainEvent, error)
+}
+
+// PerformanceStore computes and persists per-strategy daily performance.
+type PerformanceStore interface {
+	// ComputeDays derives the StrategyDay rows of the UTC days in [from, to)
+	// from orders, positions, arb executions and recorded signals. FeesUSD
+	// and ComputedAt are left for the caller.
+	ComputeDays(ctx context.Context, from, to time.Time) ([]StrategyDay, error)
+	// UpsertDays stores rows, replacing those of the same day and strategy.
+	UpsertDays(ctx context.Context, days []StrategyDay) error
+	// ListDays returns stored rows of days in [from, to) for strategy (every
+	// strategy when empty), oldest first.
+	ListDays(ctx context.Context, strategy string, from, to time.Time) ([]StrategyDay, error)
+}
+
+// LPRewardStore persists per-market liquidity reward days.
+type LPRewardStore interface {
+	// UpsertDays stores rows, replacing those of the same day and market.
+	UpsertDays(ctx context.Context, days []LPRewardDay) error
+	// ListDays returns stored rows of days in [from, to) for marketID (every
+	// market when empty), oldest first.
+	ListDays(ctx context.Context, marketID string, from, to time.Time) ([]LPRewardDay, error)
+}
+
+// LatencyStore persists per-order latency breakdowns.
+type LatencyStore interface {
+	// UpsertLatencies stores breakdowns, replacing those of the same order.
+	UpsertLatencies(ctx context.Context, latencies []OrderLatency) error
+	// ListSlowest returns up to limit breakdowns acknowledged since since,
+	// of strategy (every strategy when empty), slowest acknowledgement first.
+	ListSlowest(ctx context.Context, strategy string, since time.Time, limit int) ([]OrderLatency, error)
+}
+
+// MarketListStore persists the market blacklist and whitelist.
+type MarketListStore interface {
+	// Upsert adds entry to its list, replacing the reason and tokens when the
+	// market is already on it.
+	Upsert(ctx context.Context, entry MarketListEntry) error
+	// Delete removes marketID from list; ErrNotFound when it is not on it.
+	Delete(ctx context.Context, list MarketList, marketID string) error
+	// List returns every entry of both lists, oldest first.
+	List(ctx context.Context) ([]MarketListEntry, error)
+}
+
+// BookEventStore persists recorded orderbook snapshots and deltas for replay.
+type BookEventStore interface {
+	InsertBatch(ctx context.Context, events []BookEvent) error
+	// ListRange returns events for the given assets (all assets when assetIDs is
+	// empty) with timestamps in [from, to], ordered by timestamp ascending. A
+	// limit <= 0 means no limit.
+	ListRange(ctx context.Context, assetIDs []string, from, to time.Time, limit int) ([]BookEvent, error)
+	// DeleteBefore deletes events recorded before the given time (for retention purge). Returns count deleted.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// CandleStore persists base (1-minute) price candles and aggregates them into
+// coarser intervals.
+type CandleStore interface {
+	// UpsertBatch merges candles into the stored bar of the same asset and
+	// start: the stored open is kept, high/low widen, close is replaced and
+	// volume/trades add up, so a bar may be written in several parts.
+	UpsertBatch(ctx context.Context, candles []Candle) error
+	// ListRange returns assetID's candles of the given interval (a multiple of
+	// CandleBase) starting in [from, to), oldest first. A limit <= 0 means no
+	// limit; otherwise the most recent limit candles are returned.
+	ListRange(ctx context.Context, assetID string, interval time.Duration, from, to time.Time, limit int) ([]Candle, error)
+	// DeleteBefore deletes candles starting before the given time (for retention purge). Returns count deleted.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AlertStore persists user-defined price alerts.
+type AlertStore interface {
+	Create(ctx context.Context, a Alert) error
+	Update(ctx context.Context, a Alert) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (Alert, error)
+	List(ctx context.Context) ([]Alert, error)
+	ListEnabled(ctx context.Context) ([]Alert, error)
+	// MarkTriggered bumps the trigger count and last-triggered time; when
+	// disable is true the alert is also switched off (one-shot alerts).
+	MarkTriggered(ctx context.Context, id string, at time.Time, disable bool) error
+}
+
+// PipelineRunStore persists on-demand pipeline runs. At most one run may be
+// queued at a time.
+type PipelineRunStore interface {
+	// Enqueue inserts a queued run, or returns ErrAlreadyExists if another
+	// run is already queued.
+	Enqueue(ctx context.Context, run PipelineRun) error
+	// Queued returns the currently queued run, or ErrNotFound.
+	Queued(ctx context.Context) (PipelineRun, error)
+	// ClaimNext marks the oldest queued run as running and returns it, or
+	// returns ErrNotFound when the queue is empty.
+	ClaimNext(ctx context.Context) (PipelineRun, error)
+	// Finish records the final status, stats and error of a run.
+	Finish(ctx context.Context, run PipelineRun) error
+	// FailRunning marks runs left running by a previous process as failed.
+	FailRunning(ctx context.Context, reason string) (int64, error)
+	GetByID(ctx context.Context, id string) (PipelineRun, error)
+	List(ctx context.Context, limit int) ([]PipelineRun, error)
+}
+
+// SweepStore persists profit sweeps to cold storage.
+type SweepStore interface {
+	Create(ctx context.Context, s Sweep) error
+	Update(ctx context.Context, s Sweep) error
+	// LastConfirmed returns the most recent confirmed sweep, or ErrNotFound.
+	LastConfirmed(ctx context.Context) (Sweep, error)
+	// ListActive returns the pending and submitted sweeps sent from
+	// fromAddress, oldest first.
+	ListActive(ctx context.Context, fromAddress string) ([]Sweep, error)
+	List(ctx context.Context, limit int) ([]Sweep, error)
+}
+
+// KillSwitchStore persists the portfolio kill switch of each wallet.
+type KillSwitchStore interface {
+	// Get returns ErrNotFound when wallet has no saved state.
+	Get(ctx context.Context, wallet string) (KillSwitchState, error)
+	Save(ctx context.Context, s KillSwitchState) error
+}
+
+// RedemptionStore persists on-chain redemptions of resolved positions.
+type RedemptionStore interface {
+	Create(ctx context.Context, r Redemption) error
+	Update(ctx context.Context, r Redemption) error
+	// ListActive returns wallet's pending and submitted redemptions, oldest
+	// first.
+	ListActive(ctx context.Context, wallet string) ([]Redemption, error)
+	// List returns the most recent redemptions, newest first.
+	List(ctx context.Context, limit int) ([]Redemption, error)
+}
+
+// SignalStore persists the signals strategies emit, executed or not, with
+// what the executor made of them.
+type SignalStore interface {
+	InsertBatch(ctx context.Context, signals []TradeSignal) error
+	// Resolve records the outcomes of signals, inserting signals not
+	// recorded yet. Order IDs accumulate across resolutions of a signal.
+	Resolve(ctx context.Context, resolutions []SignalResolution) error
+	// List returns the signals matching f with their outcomes, newest first.
+	// A pending signal past its expiry is reported as expired.
+	List(ctx context.Context, f SignalFilter) ([]SignalRecord, error)
+	// ListByStrategy returns signals from source created in [opts.Since,
+	// opts.Until], newest first.
+	ListByStrategy(ctx context.Context, source string, opts ListOpts) ([]TradeSignal, error)
+	// Sources returns the distinct strategies with signals created at or after since.
+	Sources(ctx context.Context, since time.Time) ([]string, error)
+	// DeleteBefore deletes signals created before the given time (for retention purge). Returns count deleted.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// CrossMatchStore persists proposed and reviewed cross-venue market matches.
+type CrossMatchStore interface {
+	// Propose inserts a match or refreshes the score of an existing pending
+	// one for the same (MarketID, KalshiTicker). Reviewed matches keep their
+	// status and score.
+	Propose(ctx context.Context, m CrossMarketMatch) error
+	// Create inserts a match or overwrites the existing one for the same
+	// pair, status included.
+	Create(ctx context.Context, m CrossMarketMatch) (CrossMarketMatch, error)
+	GetByID(ctx context.Context, id string) (CrossMarketMatch, error)
+	// List returns matches with the given status (all when empty), highest
+	// confidence first.
+	List(ctx context.Context, status CrossMatchStatus, opts ListOpts) ([]CrossMarketMatch, error)
+	// Approved returns the market IDs and Kalshi tickers that already have an
+	// approved match, so the matcher does not propose them again.
+	Approved(ctx context.Context) (markets, tickers map[string]struct{}, err error)
+	SetStatus(ctx context.Context, id string, status CrossMatchStatus) (CrossMarketMatch, error)
+}
+
+// TimelineStore reads signals, orders and audit entries as one
+// chronologically ordered feed.
+type TimelineStore interface {
+	List(ctx context.Context, q TimelineQuery) ([]TimelineEvent, error)
 }
@@ -0,0 +1,9 @@
+package domain
+
+// SubsystemStatus reports whether an optional part of the bot is running in
+// this process and, when it is not, why (e.g. a missing store or key).
+type SubsystemStatus struct {
+	Name   string
+	Active bool
+	Reason string // empty when Active
+}
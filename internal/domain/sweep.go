@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// SweepStatus is the lifecycle state of a profit sweep transfer.
+type SweepStatus string
+
+const (
+	SweepPending   SweepStatus = "pending"   // recorded, not yet broadcast
+	SweepSubmitted SweepStatus = "submitted" // broadcast, awaiting confirmations
+	SweepConfirmed SweepStatus = "confirmed"
+	SweepFailed    SweepStatus = "failed"
+)
+
+// Sweep is a transfer of USDC profits from the hot trading wallet to cold
+// storage. RealizedPnL is the profit accumulated since the previous confirmed
+// sweep that triggered this one.
+type Sweep struct {
+	ID          string
+	FromAddress string
+	ToAddress   string
+	Amount      float64 // USDC
+	RealizedPnL float64
+	TxHash      string
+	BlockNumber int64
+	Status      SweepStatus
+	Error       string
+	CreatedAt   time.Time
+	ConfirmedAt *time.Time
+}
+
+// Active reports whether the sweep may still be mined.
+func (s Sweep) Active() bool {
+	return s.Status == SweepPending || s.Status == SweepSubmitted
+}
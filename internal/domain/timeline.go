@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// Timeline event kinds.
+const (
+	TimelineSignal = "signal" // a strategy emitted a signal
+	TimelineOrder  = "order"  // an order was created or cancelled
+	TimelineFill   = "fill"   // an order (partially) filled or a fill failed to apply
+	TimelineFeed   = "feed"   // a market data feed changed connection state
+	TimelineRisk   = "risk"   // a risk check rejected a signal or a risk control tripped
+	TimelineConfig = "config" // strategy configuration or the active set changed
+	TimelineOther  = "audit"  // any other audit log entry
+)
+
+// TimelineKinds lists every timeline kind.
+var TimelineKinds = []string{
+	TimelineSignal, TimelineOrder, TimelineFill, TimelineFeed, TimelineRisk, TimelineConfig, TimelineOther,
+}
+
+// TimelineAuditKinds maps audit log events to timeline kinds. Audit events
+// not listed are TimelineOther; "order_placed" is left out of the timeline
+// because the order row itself is listed.
+var TimelineAuditKinds = map[string]string{
+	"order_cancelled":          TimelineOrder,
+	"shutdown_cancel_all":      TimelineOrder,
+	"order_filled":             TimelineFill,
+	"fill_failed":              TimelineFill,
+	"feed_state_changed":       TimelineFeed,
+	"risk_rejected":            TimelineRisk,
+	"cancel_ratio_warning":     TimelineRisk,
+	"strategy_breaker_tripped": TimelineRisk,
+	"strategy_breaker_reset":   TimelineRisk,
+	"strategy_params_updated":  TimelineConfig,
+	"strategy_active_changed":  TimelineConfig,
+	"strategy_config_updated":  TimelineConfig,
+}
+
+// Timeline sources: the table a timeline event was read from.
+const (
+	TimelineSourceSignal = "signal"
+	TimelineSourceOrder  = "order"
+	TimelineSourceAudit  = "audit"
+)
+
+// TimelineEvent is one entry of the bot activity timeline. (At, Source, ID)
+// is unique and orders the timeline.
+type TimelineEvent struct {
+	At       time.Time
+	Kind     string
+	Event    string // audit event name, "signal_emitted" or "order_created"
+	Source   string
+	ID       string // row ID within Source
+	MarketID string
+	Detail   map[string]any
+}
+
+// TimelinePosition is the position of an event in the timeline; listing
+// resumes strictly after it.
+type TimelinePosition struct {
+	At     time.Time
+	Source string
+	ID     string
+}
+
+// TimelineQuery selects timeline events in [From, To), oldest first.
+type TimelineQuery struct {
+	From  time.Time
+	To    time.Time
+	After *TimelinePosition // resume after this event
+	Kinds []string          // empty means every kind
+	Limit int
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ToxicityMaxAge is how long an asset's toxicity is kept without new trades.
+const ToxicityMaxAge = 24 * time.Hour
+
+// AssetToxicity is the order flow toxicity of one asset: a VPIN-style score
+// computed from its trade prints in equal-volume buckets
+// (analytics.ToxicityTracker).
+type AssetToxicity struct {
+	AssetID string
+	// VPIN is the mean of |buy - sell| / volume over the last Buckets
+	// completed volume buckets, in [0, 1]; high values mean one-sided,
+	// likely informed, taker flow. 0 until a bucket completes.
+	VPIN         float64
+	Buckets      int     // completed buckets in VPIN
+	BucketVolume float64 // shares per bucket
+	// Warm reports that Buckets reached the configured minimum, so VPIN is
+	// meaningful enough to act on.
+	Warm      bool
+	UpdatedAt time.Time
+}
+
+// ToxicityProvider serves the latest toxicity of an asset to strategies.
+type ToxicityProvider interface {
+	// Toxicity returns ErrNotFound for an asset without trades yet.
+	Toxicity(ctx context.Context, assetID string) (AssetToxicity, error)
+}
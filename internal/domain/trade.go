@@ -2,7 +2,9 @@ package domain
 
 import "time"
 
-// Trade represents an enriched, processed trade fill.
+// Trade represents an enriched, processed trade fill. A trade is stored once
+// per source and SourceTradeID (with SourceLogIdx), and once per on-chain
+// TxHash and SourceLogIdx when both are known.
 type Trade struct {
 	ID             int64
 	Source         string // "polymarket", "kalshi", "goldsky"
@@ -12,7 +14,7 @@ type Trade struct {
 	MarketID       string
 	Maker          string
 	Taker          string
-	TokenSide      string // "token1" or "token2"
+	TokenSide      string // "token1", "token2", ... by outcome position
 	MakerDirection string // "buy" or "sell"
 	TakerDirection string // "buy" or "sell"
 	Price          float64
@@ -23,6 +25,7 @@ type Trade struct {
 
 // RawFill represents a raw on-chain order-filled event from Goldsky.
 type RawFill struct {
+	ID                string // subgraph entity ID, unique per fill
 	Timestamp         int64
 	Maker             string
 	MakerAssetID      string
@@ -31,4 +34,14 @@ type RawFill struct {
 	TakerAssetID      string
 	TakerAmountFilled int64
 	TransactionHash   string
+	// LogIndex is the position of the fill's OrderFilled log in its
+	// transaction, when the subgraph's entity ID carries it; nil otherwise.
+	LogIndex *int64
+}
+
+// TradeIngestStats counts trades offered for ingestion since startup: those
+// stored and those skipped as already stored.
+type TradeIngestStats struct {
+	Inserted   int64
+	Duplicates int64
 }
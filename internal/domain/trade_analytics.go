@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TradeAnalyticsVolumeWindow is the lookback of the volume statistics of
+// MarketTradeAnalytics.
+const TradeAnalyticsVolumeWindow = 24 * time.Hour
+
+// MarketTradeAnalytics are rolling statistics of the trades ingested for one
+// market (service.TradeAnalytics). Prices are those of the market's first
+// outcome: trades of the second are counted at one minus their price.
+type MarketTradeAnalytics struct {
+	MarketID   string
+	VWAP       float64       // volume-weighted average price over VWAPWindow; 0 without trades
+	VWAPWindow time.Duration // lookback of the VWAP
+	LastPrice  float64
+	Volume24h  float64 // USD traded over the last 24 hours
+	Trades24h  int     // trades over the last 24 hours
+	// Profile is the USD volume of the last 24 hours by price bucket of
+	// width BucketWidth, lowest price first; empty buckets are left out.
+	Profile     []VolumeBucket
+	BucketWidth float64
+	UpdatedAt   time.Time // time of the latest trade
+}
+
+// VolumeBucket is the volume traded at prices in [Price, Price+width).
+type VolumeBucket struct {
+	Price     float64
+	VolumeUSD float64
+	Trades    int
+}
+
+// TradeAnalyticsProvider serves the trade analytics of a market to
+// strategies and the API.
+type TradeAnalyticsProvider interface {
+	// TradeAnalytics returns ErrNotFound for a market without trades in the
+	// last 24 hours.
+	TradeAnalytics(ctx context.Context, marketID string) (MarketTradeAnalytics, error)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// External prediction venues quoted against Polymarket.
+const (
+	VenueKalshi    = "kalshi"
+	VenuePredictIt = "predictit"
+	VenueManifold  = "manifold"
+)
+
+// VenueQuote is the top of book for a binary market on another venue, with
+// prices as probabilities in [0, 1]. A zero price means that side has no
+// quote. Ref is the venue's own market identifier.
+type VenueQuote struct {
+	Venue  string
+	Ref    string
+	YesBid float64
+	YesAsk float64
+	NoBid  float64
+	NoAsk  float64
+	At     time.Time
+}
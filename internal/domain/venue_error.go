@@ -0,0 +1,276 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ErrorClass categorises a failure from an external venue, data provider,
+// cache or store so callers can decide whether to retry, back off, or alert.
+type ErrorClass string
+
+const (
+	// ErrorClassRateLimited: the venue throttled the request; retry after
+	// backing off.
+	ErrorClassRateLimited ErrorClass = "rate_limited"
+	// ErrorClassTransient: network failure, timeout or 5xx; retry as-is.
+	ErrorClassTransient ErrorClass = "transient"
+	// ErrorClassAuth: credentials were missing, expired or refused. Retrying
+	// will not help; an operator has to act.
+	ErrorClassAuth ErrorClass = "auth"
+	// ErrorClassValidation: the request itself was malformed (4xx). Retrying
+	// the same request will fail again.
+	ErrorClassValidation ErrorClass = "validation"
+	// ErrorClassVenueRejected: the request was well-formed but the venue
+	// refused it (insufficient balance, market closed, order would cross).
+	ErrorClassVenueRejected ErrorClass = "venue_rejected"
+	// ErrorClassFatal: the deployment itself is broken (schema mismatch,
+	// unusable signing key, wrong Redis key type). Nothing will succeed
+	// until it is fixed.
+	ErrorClassFatal ErrorClass = "fatal"
+	// ErrorClassUnknown: anything not classified above.
+	ErrorClassUnknown ErrorClass = "unknown"
+)
+
+// Class sentinels, matched by errors.Is against a *VenueError of that class.
+// ErrRateLimited and ErrUnauthorized double as the rate-limited and auth
+// sentinels.
+var (
+	ErrTransient     = errors.New("transient venue error")
+	ErrValidation    = errors.New("request rejected as invalid")
+	ErrVenueRejected = errors.New("rejected by venue")
+	ErrFatal         = errors.New("fatal error")
+)
+
+// ErrorClasses lists every class, in the order reports show them.
+var ErrorClasses = []ErrorClass{
+	ErrorClassRateLimited,
+	ErrorClassTransient,
+	ErrorClassAuth,
+	ErrorClassValidation,
+	ErrorClassVenueRejected,
+	ErrorClassFatal,
+	ErrorClassUnknown,
+}
+
+// Retryable reports whether requests failing with this class may succeed if
+// sent again.
+func (c ErrorClass) Retryable() bool {
+	return c == ErrorClassRateLimited || c == ErrorClassTransient
+}
+
+func (c ErrorClass) sentinel() error {
+	switch c {
+	case ErrorClassRateLimited:
+		return ErrRateLimited
+	case ErrorClassTransient:
+		return ErrTransient
+	case ErrorClassAuth:
+		return ErrUnauthorized
+	case ErrorClassValidation:
+		return ErrValidation
+	case ErrorClassVenueRejected:
+		return ErrVenueRejected
+	case ErrorClassFatal:
+		return ErrFatal
+	}
+	return nil
+}
+
+// VenueError is the error returned by platform clients (CLOB, Gamma, Kalshi,
+// Goldsky, ...) for failed calls. errors.Is matches the class sentinel and
+// anything Err wraps, e.g. ErrNotFound for a 404.
+type VenueError struct {
+	Venue   string // "polymarket", "kalshi", "goldsky", ...
+	Class   ErrorClass
+	Status  int    // HTTP status; 0 for transport failures
+	Message string // venue-provided detail
+	Err     error  // underlying cause; may be nil
+}
+
+// Error implements error.
+func (e *VenueError) Error() string {
+	msg := e.Message
+	if msg == "" && e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if e.Status != 0 {
+		return fmt.Sprintf("%s: %s (HTTP %d): %s", e.Venue, e.Class, e.Status, msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Venue, e.Class, msg)
+}
+
+// Unwrap returns the underlying cause.
+func (e *VenueError) Unwrap() error { return e.Err }
+
+// Is matches the sentinel for the error's class.
+func (e *VenueError) Is(target error) bool {
+	s := e.Class.sentinel()
+	return s != nil && target == s
+}
+
+// NewHTTPError classifies a non-2xx response from venue. 404 also wraps
+// ErrNotFound.
+func NewHTTPError(venue string, status int, message string) *VenueError {
+	e := &VenueError{Venue: venue, Class: HTTPStatusClass(status), Status: status, Message: message}
+	if status == http.StatusNotFound {
+		e.Err = ErrNotFound
+	}
+	return e
+}
+
+// NewTransportError wraps a failure to reach venue at all (dial, TLS, reset,
+// timeout). A cancelled context stays unclassified: retrying it is pointless.
+func NewTransportError(venue string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%s: http request: %w", venue, err)
+	}
+	return &VenueError{Venue: venue, Class: ErrorClassTransient, Err: err}
+}
+
+// NewRejectedError reports a venue refusing a well-formed request.
+func NewRejectedError(venue, message string) *VenueError {
+	return &VenueError{Venue: venue, Class: ErrorClassVenueRejected, Message: message}
+}
+
+// HTTPStatusClass maps an HTTP status code to an ErrorClass.
+func HTTPStatusClass(status int) ErrorClass {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return ErrorClassAuth
+	case status == http.StatusRequestTimeout, status >= 500:
+		return ErrorClassTransient
+	case status == http.StatusConflict, status == http.StatusUnprocessableEntity:
+		return ErrorClassVenueRejected
+	case status >= 400:
+		return ErrorClassValidation
+	}
+	return ErrorClassUnknown
+}
+
+// ClassifyError returns the class of err: the class of a wrapped VenueError,
+// else the class implied by a wrapped sentinel, Postgres or Redis error, or
+// network error.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassUnknown
+	}
+	var ve *VenueError
+	if errors.As(err, &ve) {
+		return ve.Class
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrorClassUnknown
+	}
+	var pgErr sqlStateError
+	if errors.As(err, &pgErr) {
+		return sqlStateClass(pgErr.SQLState())
+	}
+	var rdErr redisError
+	if errors.As(err, &rdErr) {
+		if class := redisErrorClass(rdErr.Error()); class != ErrorClassUnknown {
+			return class
+		}
+	}
+	var netErr net.Error
+	var retryable safeToRetryError
+	switch {
+	case errors.Is(err, ErrRateLimited):
+		return ErrorClassRateLimited
+	case errors.Is(err, ErrUnauthorized):
+		return ErrorClassAuth
+	case errors.Is(err, ErrTransient), errors.Is(err, ErrWSDisconnect),
+		errors.Is(err, io.ErrUnexpectedEOF), errors.As(err, &netErr),
+		errors.As(err, &retryable) && retryable.SafeToRetry():
+		return ErrorClassTransient
+	case errors.Is(err, ErrValidation), errors.Is(err, ErrInvalidOrder):
+		return ErrorClassValidation
+	case errors.Is(err, ErrVenueRejected):
+		return ErrorClassVenueRejected
+	case errors.Is(err, ErrFatal), errors.Is(err, ErrSigningFailed):
+		return ErrorClassFatal
+	}
+	return ErrorClassUnknown
+}
+
+// sqlStateError is implemented by Postgres server errors (*pgconn.PgError).
+type sqlStateError interface {
+	error
+	SQLState() string
+}
+
+// safeToRetryError is implemented by Postgres client errors raised before
+// the server saw the statement (dial failures, timeouts).
+type safeToRetryError interface {
+	error
+	SafeToRetry() bool
+}
+
+// redisError is implemented by errors replied by a Redis server.
+type redisError interface {
+	error
+	RedisError()
+}
+
+// sqlStateClass maps a Postgres SQLSTATE code to an ErrorClass by its class
+// (first two characters), with the retryable transaction failures singled
+// out.
+func sqlStateClass(code string) ErrorClass {
+	switch code {
+	case "40001", "40P01": // serialization failure, deadlock
+		return ErrorClassTransient
+	case "57014", "57P01", "57P02", "57P03": // query cancelled, server shutting down or starting
+		return ErrorClassTransient
+	}
+	if len(code) < 2 {
+		return ErrorClassUnknown
+	}
+	switch code[:2] {
+	case "08", "53": // connection exception, insufficient resources
+		return ErrorClassTransient
+	case "28": // invalid authorization
+		return ErrorClassAuth
+	case "22", "23": // data exception, integrity constraint violation
+		return ErrorClassValidation
+	case "42", "58", "XX": // undefined table or column, system error, internal error
+		return ErrorClassFatal
+	}
+	return ErrorClassUnknown
+}
+
+// redisErrorClass maps a Redis error reply to an ErrorClass by its prefix.
+func redisErrorClass(msg string) ErrorClass {
+	prefix, _, _ := strings.Cut(msg, " ")
+	switch prefix {
+	case "LOADING", "BUSY", "TRYAGAIN", "CLUSTERDOWN", "MASTERDOWN", "READONLY", "OOM":
+		return ErrorClassTransient
+	case "NOAUTH", "WRONGPASS", "NOPERM":
+		return ErrorClassAuth
+	case "WRONGTYPE":
+		return ErrorClassFatal
+	}
+	return ErrorClassUnknown
+}
+
+// IsRetryable reports whether the call that failed with err may succeed if
+// retried (rate limited or transient).
+func IsRetryable(err error) bool {
+	return ClassifyError(err).Retryable()
+}
+
+// ErrorStats counts the failures of one operation (a venue API, the Goldsky
+// subgraph, ...) in one error class since the process started.
+type ErrorStats struct {
+	Op        string
+	Class     ErrorClass
+	Failures  int64 // failed attempts
+	Retries   int64 // failed attempts that were retried
+	Exhausted int64 // calls given up on with a retryable error when attempts ran out
+}
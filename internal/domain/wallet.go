@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// ExchangeApproval is what one Polymarket exchange contract may move from the
+// wallet: its USDC allowance (needed to buy) and whether it is an operator of
+// the wallet's CTF outcome tokens (needed to sell).
+type ExchangeApproval struct {
+	Name          string // "ctf_exchange", "neg_risk_ctf_exchange" or "neg_risk_adapter"
+	Address       string
+	USDCAllowance float64
+	CTFApproved   bool
+}
+
+// WalletBalances is an on-chain reading of the trading wallet's collateral
+// and exchange approvals.
+type WalletBalances struct {
+	Wallet    string
+	USDC      float64 // USDC.e balance
+	Exchanges []ExchangeApproval
+	Block     uint64 // block the reading was taken at
+	CheckedAt time.Time
+}
+
+// Exchange returns the approval of the exchange named name.
+func (b WalletBalances) Exchange(name string) (ExchangeApproval, bool) {
+	for _, e := range b.Exchanges {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return ExchangeApproval{}, false
+}
@@ -2,9 +2,12 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,17 +23,58 @@ type OrderPlacer interface {
 }
 
 // ReplaceOrderer is optional. When implemented, the executor uses ReplaceOrder
-// for liquidity_provider requotes (cancel existing order and place new one).
+// for liquidity_provider requotes (cancel the last order of the previous quote
+// set and place the first level of the new one).
 type ReplaceOrderer interface {
 	ReplaceOrder(ctx context.Context, cancelID string, newSig domain.TradeSignal) (domain.OrderResult, error)
 }
 
+// QuoteCanceller cancels a single order (service.OrderService). The executor
+// uses it to pull liquidity_provider quotes and to close out what a sweep
+// order left unfilled.
+type QuoteCanceller interface {
+	CancelOrder(ctx context.Context, orderID string) error
+}
+
 // RiskChecker validates whether a trade signal passes pre-trade risk controls
 // (e.g., position limits, drawdown checks, margin requirements).
 type RiskChecker interface {
 	PreTradeCheck(ctx context.Context, signal domain.TradeSignal, wallet string) error
 }
 
+// SignalSizer is optionally implemented by the RiskChecker to resize a signal
+// before the risk check (e.g. the close haircut in service.RiskService).
+type SignalSizer interface {
+	AdjustSize(ctx context.Context, signal domain.TradeSignal) (domain.TradeSignal, error)
+}
+
+// OrderCanceller cancels every open order of a wallet (service.OrderService).
+type OrderCanceller interface {
+	CancelAll(ctx context.Context, wallet string) error
+}
+
+// LatencyRecorder receives the executor's share of a signal's latency
+// breakdown (service.LatencyTracker).
+type LatencyRecorder interface {
+	Received(sig domain.TradeSignal, at time.Time)
+	Record(id string, stage domain.LatencyStage, d time.Duration)
+}
+
+// SignalResolver receives what became of each signal (service.SignalRecorder).
+// Resolve must not block.
+type SignalResolver interface {
+	Resolve(res domain.SignalResolution)
+}
+
+// KillSwitch halts all trading while tripped (service.PortfolioRiskManager).
+// Every path that opens exposure checks it right before placing: signals,
+// retries, leg groups (timed-out best_effort ones included) and top-up
+// re-quotes. Only unwinds of filled legs (LegUnwinder, HedgeGuard) still go
+// out, on purpose, since they close exposure.
+type KillSwitch interface {
+	Halted() bool
+}
+
 // Executor reads trade signals from a channel, applies deduplication, expiry,
 // and risk checks, then places orders through the OrderPlacer interface.
 // When signals have leg_group_id in metadata they are buffered and executed
@@ -39,6 +83,10 @@ type Executor struct {
 	signalCh <-chan domain.TradeSignal
 	orderSvc OrderPlacer
 	riskSvc  RiskChecker
+	killSw   KillSwitch // optional
+	audit    domain.AuditStore // optional; records risk rejections
+	latency  LatencyRecorder   // optional
+	resolver SignalResolver    // optional
 	dedup    *Dedup
 	wallet   string
 	logger   *slog.Logger
@@ -47,12 +95,26 @@ type Executor struct {
 	arbSvc     *service.ArbService
 	arbExecStore domain.ArbExecutionStore
 	maxLegGapMs  int64
+	hedge        *HedgeGuard // optional; unwinds partial best-effort groups
+	topUp        *LegTopUp   // optional; completes partially filled legs
+	sweep        *Sweeper    // optional; sends immediate signals as FAK sweeps
+	unwind       *LegUnwinder // optional; rolls back failed all_or_none groups
+
+	shutdownCancel  OrderCanceller // optional; cancels resting orders on exit
+	shutdownTimeout time.Duration
 
 	cleanupInterval time.Duration
 
-	// lastLPOrderID tracks the last order ID per (tokenID, side) for liquidity_provider requotes.
-	lastLPOrderID   map[string]string
-	lastLPOrderIDMu sync.Mutex
+	pool      *workerPool // optional; see SetWorkers
+	busy      atomic.Bool // serial processing only
+	processed atomic.Int64
+
+	running atomic.Bool
+	beat    atomic.Int64 // unix nanos of the dispatch loop's last turn
+
+	// lpQuotes tracks the resting liquidity_provider quote set per token.
+	lpQuotes   map[string]*lpQuoteSet
+	lpQuotesMu sync.Mutex
 }
 
 // NewExecutor creates an Executor that reads signals from signalCh, validates
@@ -74,7 +136,7 @@ func NewExecutor(
 		logger:          logger.With(slog.String("component", "executor")),
 		cleanupInterval: 30 * time.Second,
 		maxLegGapMs:     2000,
-		lastLPOrderID:   make(map[string]string),
+		lpQuotes:        make(map[string]*lpQuoteSet),
 	}
 }
 
@@ -89,21 +151,218 @@ func (e *Executor) SetArbRecording(arbSvc *service.ArbService, arbExecStore doma
 	e.legAccum = NewLegGroupAccumulator(e.maxLegGapMs, e.placeLegGroup, e.logger)
 }
 
-// placeLegGroup is the onComplete callback: place each leg, then record execution.
+// SetHedging enables the best-effort leg policy's hedging: legs of a
+// best_effort group that were placed while other legs failed or never arrived
+// have their resting remainder cancelled and their fills count as unhedged
+// notional, and once the total exceeds maxUnhedged the oldest fills are
+// unwound at the top of books, spending at most budgetUSD in realized losses. It also turns on leg-group accumulation if
+// SetArbRecording has not.
+func (e *Executor) SetHedging(books domain.OrderbookCache, maxUnhedged, budgetUSD float64) {
+	e.hedge = NewHedgeGuard(e.orderSvc, books, maxUnhedged, budgetUSD, e.logger)
+	if e.legAccum == nil {
+		e.legAccum = NewLegGroupAccumulator(e.maxLegGapMs, e.placeLegGroup, e.logger)
+	}
+}
+
+// SetUnwind makes the executor roll back all_or_none leg groups that stop at
+// a failed leg: legs placed before it are cancelled if resting and closed at
+// the top of books if filled, the execution is recorded as "unwound" once
+// nothing is left open, and notifier (optional) is told either way. It also
+// turns on leg-group accumulation if SetArbRecording has not.
+func (e *Executor) SetUnwind(books domain.OrderbookCache, notifier service.AlertNotifier) {
+	e.unwind = NewLegUnwinder(e.orderSvc, books, notifier, e.logger)
+	if e.legAccum == nil {
+		e.legAccum = NewLegGroupAccumulator(e.maxLegGapMs, e.placeLegGroup, e.logger)
+	}
+}
+
+// SetLegTopUp makes the executor complete leg groups whose legs were all
+// placed but rest partially filled: after each wait of after, the unfilled
+// remainder of a leg is cancelled and re-quoted at the top of book, at most
+// attempts times and within maxSlippageBps of the leg's price. It needs fill
+// tracking to be running and an order placer that can read and cancel orders
+// (LegOrders); otherwise it does nothing. It also turns on leg-group
+// accumulation if SetArbRecording has not.
+func (e *Executor) SetLegTopUp(books domain.OrderbookCache, after time.Duration, attempts int, maxSlippageBps float64) {
+	orders, ok := e.orderSvc.(LegOrders)
+	if !ok || attempts <= 0 {
+		return
+	}
+	e.topUp = NewLegTopUp(e.orderSvc, orders, books, after, attempts, maxSlippageBps, e.logger)
+	e.topUp.halted = e.halted
+	if e.legAccum == nil {
+		e.legAccum = NewLegGroupAccumulator(e.maxLegGapMs, e.placeLegGroup, e.logger)
+	}
+}
+
+// SetImmediateSweep sends signals of immediate urgency as FAK orders that
+// sweep the book up to a slippage budget of edgeFraction of the signal's
+// expected edge, at most maxSlippageBps, instead of resting a GTC limit at
+// the signal price. The part of a sweep left unfilled is cancelled.
+func (e *Executor) SetImmediateSweep(books domain.OrderbookCache, edgeFraction, maxSlippageBps float64) {
+	e.sweep = NewSweeper(books, edgeFraction, maxSlippageBps)
+}
+
+// SetCancelOnShutdown makes Run cancel every open order of the wallet once
+// ctx is cancelled and pending signals are drained, so no quotes are left
+// resting unattended after the bot stops. The cancellation is bounded by
+// timeout and audited as "shutdown_cancel_all". It needs an order placer
+// that can cancel (OrderCanceller); otherwise it does nothing.
+func (e *Executor) SetCancelOnShutdown(timeout time.Duration) {
+	canceller, ok := e.orderSvc.(OrderCanceller)
+	if !ok {
+		return
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	e.shutdownCancel = canceller
+	e.shutdownTimeout = timeout
+}
+
+// SetKillSwitch makes the executor place nothing that opens exposure while
+// ks is tripped; see KillSwitch.
+func (e *Executor) SetKillSwitch(ks KillSwitch) {
+	e.killSw = ks
+}
+
+// halted reports whether the kill switch is tripped.
+func (e *Executor) halted() bool {
+	return e.killSw != nil && e.killSw.Halted()
+}
+
+// SetAudit records each signal rejected by risk sizing or the pre-trade
+// check as a "risk_rejected" audit entry, so rejections show up in the
+// activity timeline.
+func (e *Executor) SetAudit(audit domain.AuditStore) {
+	e.audit = audit
+}
+
+// SetLatency reports when each signal is picked up and how long its risk
+// checks take to l.
+func (e *Executor) SetLatency(l LatencyRecorder) {
+	e.latency = l
+}
+
+// SetResolver reports the outcome of every signal to r: placed with its
+// order IDs, rejected, failed, expired or skipped.
+func (e *Executor) SetResolver(r SignalResolver) {
+	e.resolver = r
+}
+
+// resolve reports sig's outcome to the resolver, if any.
+func (e *Executor) resolve(sig domain.TradeSignal, status domain.SignalStatus, reason string, orderIDs ...string) {
+	if e.resolver == nil {
+		return
+	}
+	var ids []string
+	for _, id := range orderIDs {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	e.resolver.Resolve(domain.SignalResolution{
+		Signal:   sig,
+		Status:   status,
+		Reason:   reason,
+		OrderIDs: ids,
+		At:       time.Now().UTC(),
+	})
+}
+
+// recordRisk reports the risk check duration of sig, started at start.
+func (e *Executor) recordRisk(sig domain.TradeSignal, start time.Time) {
+	if e.latency != nil {
+		e.latency.Record(sig.ID, domain.LatencyStageRisk, time.Since(start))
+	}
+}
+
+// auditRejection records a risk rejection. stage is "sizing" or "pre_trade".
+func (e *Executor) auditRejection(ctx context.Context, sig domain.TradeSignal, stage string, cause error, log *slog.Logger) {
+	if e.audit == nil {
+		return
+	}
+	if err := e.audit.Log(ctx, "risk_rejected", map[string]any{
+		"signal_id": sig.ID,
+		"strategy":  sig.Source,
+		"market":    sig.MarketID,
+		"token_id":  sig.TokenID,
+		"side":      string(sig.Side),
+		"price":     sig.Price(),
+		"size":      sig.Size(),
+		"stage":     stage,
+		"reason":    cause.Error(),
+	}); err != nil {
+		log.Warn("audit risk rejection failed", slog.String("error", err.Error()))
+	}
+}
+
+// placeLegGroup is the onComplete callback: run the legs through the risk
+// checks (checkLegs), place each leg, then record execution.
+// all_or_none places legs in order and stops at the first failure;
+// best_effort places them concurrently so a slow leg does not hold up the
+// others, and hands legs left without their counterparts to the HedgeGuard.
+// An all_or_none group that stopped early is rolled back by the LegUnwinder.
+// legs may be short of leg_count when a best_effort group timed out.
 func (e *Executor) placeLegGroup(ctx context.Context, legs []domain.TradeSignal, policy domain.LegPolicy) error {
-	results := make([]domain.OrderResult, 0, len(legs))
-	for _, sig := range legs {
-		res, err := e.orderSvc.PlaceOrder(ctx, sig)
-		if err != nil {
-			e.logger.Error("leg group place order failed", slog.String("signal_id", sig.ID), slog.String("error", err.Error()))
-			res = domain.OrderResult{Success: false, OrderID: "", Status: domain.OrderStatusFailed}
+	// The switch may have tripped while the group was accumulating.
+	if e.halted() {
+		e.logger.Warn("kill switch tripped, dropping leg group", slog.Int("legs", len(legs)))
+		for _, sig := range legs {
+			e.resolve(sig, domain.SignalStatusSkipped, "kill switch tripped")
+		}
+		return nil
+	}
+	legs, swept := e.checkLegs(ctx, legs, policy)
+	if len(legs) == 0 {
+		return nil
+	}
+	var results []domain.OrderResult
+	if policy == domain.LegPolicyBestEffort {
+		results = e.placeLegsConcurrently(ctx, legs, swept)
+	} else {
+		results = make([]domain.OrderResult, 0, len(legs))
+		for i, sig := range legs {
+			res := e.placeLeg(ctx, sig, swept[i])
+			results = append(results, res)
+			if policy == domain.LegPolicyAllOrNone && !res.Success {
+				e.logger.Warn("all_or_none: leg failed, stopping", slog.String("signal_id", sig.ID))
+				break
+			}
 		}
-		results = append(results, res)
-		if policy == domain.LegPolicyAllOrNone && !res.Success {
-			e.logger.Warn("all_or_none: leg failed, stopping", slog.String("signal_id", sig.ID))
-			break
+	}
+
+	expected := len(legs)
+	if len(legs) > 0 {
+		if n, err := strconv.Atoi(legs[0].Metadata["leg_count"]); err == nil && n > expected {
+			expected = n
+		}
+	}
+	var placed []domain.TradeSignal
+	for i, res := range results {
+		if res.Success {
+			placed = append(placed, legs[i])
 		}
 	}
+	e.resolveLegs(legs, results)
+	partial := len(placed) < expected
+	if partial && len(placed) > 0 && policy == domain.LegPolicyBestEffort && e.hedge != nil {
+		e.hedge.Track(ctx, legs[0].Metadata["leg_group_id"], legs, results)
+	}
+	var unwound *UnwindResult
+	if partial && len(placed) > 0 && policy == domain.LegPolicyAllOrNone && e.unwind != nil {
+		r := e.unwind.Unwind(ctx, legs[0].Metadata["leg_group_id"], legs, results)
+		unwound = &r
+	}
+	// Every leg is on the book: top up legs that did not match in full.
+	if !partial && e.topUp != nil {
+		for i, res := range results {
+			if res.Status != domain.OrderStatusMatched || res.FilledSize < legs[i].Size() {
+				e.topUp.Watch(ctx, legs[i].Metadata["leg_group_id"], legs[i])
+			}
+		}
+	}
+
 	if e.arbSvc == nil || e.arbExecStore == nil {
 		return nil
 	}
@@ -132,6 +391,12 @@ func (e *Executor) placeLegGroup(ctx context.Context, legs []domain.TradeSignal,
 	}
 	now := time.Now().UTC()
 	exec.CompletedAt = &now
+	if partial {
+		exec.Status = domain.ArbExecPartial
+		if len(placed) == 0 {
+			exec.Status = domain.ArbExecFailed
+		}
+	}
 	for i, sig := range legs {
 		res := domain.OrderResult{}
 		if i < len(results) {
@@ -153,16 +418,210 @@ func (e *Executor) placeLegGroup(ctx context.Context, legs []domain.TradeSignal,
 		}
 		exec.Legs = append(exec.Legs, leg)
 	}
+	// Offsetting orders count as legs so the PnL covers the round trip.
+	if unwound != nil {
+		exec.Legs = append(exec.Legs, unwound.Offsets...)
+		if unwound.Complete {
+			exec.Status = domain.ArbExecUnwound
+		}
+	}
 	e.arbSvc.ComputeRealizedPnL(&exec)
 	if err := e.arbExecStore.Create(ctx, exec); err != nil {
 		e.logger.Warn("arb execution record failed", slog.String("error", err.Error()))
 	}
+	// Close the detected opportunity the legs traded, if any, with the
+	// realized edge.
+	if exec.OpportunityID != "" && exec.Status != domain.ArbExecFailed {
+		if err := e.arbSvc.Executed(ctx, exec); err != nil {
+			e.logger.Warn("arb opportunity close failed",
+				slog.String("opp_id", exec.OpportunityID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
 	return nil
 }
 
+// checkLegs runs risk sizing and the pre-trade check on every leg of a group
+// before any leg is placed, and returns the legs to place with whether each
+// was converted to a sweep. Legs are swept first, so the checks see the
+// price actually sent. Sizing applies to
+// the group as a whole: every leg is scaled by the smallest factor sizing
+// gave any leg (e.g. the close haircut), so the legs stay balanced; legs are
+// never enlarged. A rejected leg rejects the whole group, except under
+// best_effort, which places the legs that passed. Dropped legs are resolved.
+func (e *Executor) checkLegs(ctx context.Context, legs []domain.TradeSignal, policy domain.LegPolicy) ([]domain.TradeSignal, []bool) {
+	swept := make([]bool, len(legs))
+	legs = append([]domain.TradeSignal(nil), legs...)
+	for i, sig := range legs {
+		legs[i], swept[i] = e.sweepSignal(ctx, sig, e.logger.With(slog.String("signal_id", sig.ID)))
+	}
+
+	riskStart := time.Now()
+	errs := make([]error, len(legs))
+	stages := make([]string, len(legs))
+	factor := 1.0
+	for i, sig := range legs {
+		adjusted, err := e.adjustSize(ctx, sig)
+		if err != nil {
+			errs[i], stages[i] = err, "sizing"
+			continue
+		}
+		if sig.SizeUnits > 0 {
+			factor = min(factor, float64(adjusted.SizeUnits)/float64(sig.SizeUnits))
+		}
+	}
+	sized := make([]domain.TradeSignal, len(legs))
+	rejected := false
+	for i, sig := range legs {
+		if factor < 1 {
+			sig.SizeUnits = int64(float64(sig.SizeUnits) * factor)
+		}
+		sized[i] = sig
+		if errs[i] == nil {
+			if err := e.riskSvc.PreTradeCheck(ctx, sig, e.wallet); err != nil {
+				errs[i], stages[i] = err, "pre_trade"
+			}
+		}
+		e.recordRisk(sig, riskStart)
+		if errs[i] != nil {
+			rejected = true
+			log := e.logger.With(slog.String("signal_id", sig.ID), slog.String("leg_group_id", sig.Metadata["leg_group_id"]))
+			log.Warn("risk check rejected leg", slog.String("stage", stages[i]), slog.String("error", errs[i].Error()))
+			e.auditRejection(ctx, sig, stages[i], errs[i], log)
+			e.resolve(sig, domain.SignalStatusRejected, errs[i].Error())
+		}
+	}
+	if factor < 1 {
+		e.logger.Info("leg group resized by risk sizing",
+			slog.String("leg_group_id", legs[0].Metadata["leg_group_id"]),
+			slog.Float64("factor", factor),
+		)
+	}
+	if !rejected {
+		return sized, swept
+	}
+
+	var out []domain.TradeSignal
+	var outSwept []bool
+	for i, sig := range sized {
+		switch {
+		case errs[i] != nil:
+		case policy == domain.LegPolicyBestEffort:
+			out = append(out, sig)
+			outSwept = append(outSwept, swept[i])
+		default:
+			e.resolve(sig, domain.SignalStatusSkipped, "another leg of the group was rejected by risk checks")
+		}
+	}
+	return out, outSwept
+}
+
+// adjustSize applies risk sizing to sig when the risk checker sizes signals
+// (SignalSizer).
+func (e *Executor) adjustSize(ctx context.Context, sig domain.TradeSignal) (domain.TradeSignal, error) {
+	sizer, ok := e.riskSvc.(SignalSizer)
+	if !ok {
+		return sig, nil
+	}
+	return sizer.AdjustSize(ctx, sig)
+}
+
+// resolveLegs reports the outcome of each leg of a group; legs after results
+// were not attempted.
+func (e *Executor) resolveLegs(legs []domain.TradeSignal, results []domain.OrderResult) {
+	for i, sig := range legs {
+		switch {
+		case i >= len(results):
+			e.resolve(sig, domain.SignalStatusSkipped, "leg group stopped after a failed leg")
+		case results[i].Success:
+			e.resolve(sig, domain.SignalStatusPlaced, "", results[i].OrderID)
+		case results[i].Status == domain.OrderStatusFailed:
+			e.resolve(sig, domain.SignalStatusFailed, results[i].Message, results[i].OrderID)
+		default:
+			e.resolve(sig, domain.SignalStatusRejected, results[i].Message, results[i].OrderID)
+		}
+	}
+}
+
+// placeLeg places one leg, turning an error into a failed result, and
+// cancels the local remainder of a swept leg. A leg is refused once the kill
+// switch trips mid-group.
+func (e *Executor) placeLeg(ctx context.Context, sig domain.TradeSignal, swept bool) domain.OrderResult {
+	if e.halted() {
+		return domain.OrderResult{Success: false, Message: "kill switch tripped"}
+	}
+	res, err := e.orderSvc.PlaceOrder(ctx, sig)
+	if err != nil {
+		e.logger.Error("leg group place order failed", slog.String("signal_id", sig.ID), slog.String("error", err.Error()))
+		return domain.OrderResult{Success: false, OrderID: "", Status: domain.OrderStatusFailed, Message: err.Error()}
+	}
+	if swept {
+		e.cancelUnfilled(ctx, sig, res, e.logger.With(slog.String("signal_id", sig.ID)))
+	}
+	return res
+}
+
+// sweepSignal converts sig into a FAK sweep when immediate sweeping is set
+// and sig qualifies, reporting whether it did.
+func (e *Executor) sweepSignal(ctx context.Context, sig domain.TradeSignal, log *slog.Logger) (domain.TradeSignal, bool) {
+	if e.sweep == nil || !e.sweep.Applies(sig) {
+		return sig, false
+	}
+	out, err := e.sweep.Convert(ctx, sig)
+	if err != nil {
+		log.Warn("sweep: book unavailable, sending FAK at signal price", slog.String("error", err.Error()))
+	}
+	log.Debug("immediate signal sent as FAK sweep",
+		slog.Float64("signal_price", sig.Price()),
+		slog.Float64("sweep_price", out.Price()),
+		slog.Float64("budget_bps", e.sweep.BudgetBps(sig)),
+	)
+	return out, true
+}
+
+// cancelUnfilled cancels the local order of a sweep that did not fill
+// completely. The venue kills a FAK order's remainder, but the order would
+// otherwise stay live locally with nothing resting behind it.
+func (e *Executor) cancelUnfilled(ctx context.Context, sig domain.TradeSignal, res domain.OrderResult, log *slog.Logger) {
+	if !res.Success || res.Status == domain.OrderStatusMatched || res.FilledSize >= sig.Size() {
+		return
+	}
+	canceller, ok := e.orderSvc.(QuoteCanceller)
+	if !ok {
+		return
+	}
+	if err := canceller.CancelOrder(ctx, sig.ID); err != nil {
+		if !errors.Is(err, domain.ErrInvalidTransition) {
+			log.Warn("sweep: cancel unfilled remainder failed", slog.String("error", err.Error()))
+		}
+		return
+	}
+	log.Info("sweep left unfilled, remainder cancelled",
+		slog.Float64("filled", res.FilledSize),
+		slog.Float64("size", sig.Size()),
+	)
+}
+
+// placeLegsConcurrently places every leg at once and returns the results in
+// leg order.
+func (e *Executor) placeLegsConcurrently(ctx context.Context, legs []domain.TradeSignal, swept []bool) []domain.OrderResult {
+	results := make([]domain.OrderResult, len(legs))
+	var wg sync.WaitGroup
+	for i, sig := range legs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.placeLeg(ctx, sig, swept[i])
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
 // Run starts the executor's main loop. It processes signals until the context
-// is cancelled, at which point it drains any remaining signals in the channel
-// and returns.
+// is cancelled or the signal channel is closed, at which point it drains any
+// remaining signals in the channel and returns.
 func (e *Executor) Run(ctx context.Context) error {
 	e.logger.Info("executor started")
 	defer e.logger.Info("executor stopped")
@@ -170,18 +629,51 @@ func (e *Executor) Run(ctx context.Context) error {
 	cleanupTicker := time.NewTicker(e.cleanupInterval)
 	defer cleanupTicker.Stop()
 
+	e.running.Store(true)
+	defer e.running.Store(false)
+
+	if e.pool != nil {
+		e.logger.Info("executor workers started", slog.Int("workers", len(e.pool.workers)))
+		e.startWorkers(ctx)
+	}
+
 	for {
+		e.beat.Store(time.Now().UnixNano())
 		select {
 		case <-ctx.Done():
+			if e.pool != nil {
+				e.stopWorkers()
+			}
 			e.drain()
+			e.cancelOnShutdown()
 			return ctx.Err()
 
 		case sig, ok := <-e.signalCh:
 			if !ok {
-				// Channel closed; shut down.
+				// Channel closed; shut down as on cancellation.
+				if e.pool != nil {
+					e.stopWorkers()
+				}
+				e.drain()
+				e.cancelOnShutdown()
 				return nil
 			}
-			e.process(ctx, sig)
+			if e.pool == nil {
+				e.busy.Store(true)
+				e.process(ctx, sig)
+				e.processed.Add(1)
+				e.busy.Store(false)
+				continue
+			}
+			if !e.pool.dispatch(ctx, sig) {
+				// Cancelled while waiting on a full queue: the signal goes
+				// after everything already queued.
+				e.stopWorkers()
+				e.drainSignal(sig)
+				e.drain()
+				e.cancelOnShutdown()
+				return ctx.Err()
+			}
 
 		case <-cleanupTicker.C:
 			e.dedup.Cleanup()
@@ -192,6 +684,7 @@ func (e *Executor) Run(ctx context.Context) error {
 // process handles a single trade signal through the full validation and
 // execution pipeline.
 func (e *Executor) process(ctx context.Context, sig domain.TradeSignal) {
+	pickedUp := time.Now()
 	log := e.logger.With(
 		slog.String("signal_id", sig.ID),
 		slog.String("source", sig.Source),
@@ -199,6 +692,33 @@ func (e *Executor) process(ctx context.Context, sig domain.TradeSignal) {
 		slog.String("side", string(sig.Side)),
 	)
 
+	// liquidity_provider quote pulls only cancel a resting order, so they
+	// bypass the kill switch and risk checks.
+	if sig.Source == "liquidity_provider" && sig.Metadata["lp_pull"] != "" {
+		e.pullLPQuote(ctx, sig, log)
+		e.resolve(sig, domain.SignalStatusSkipped, "quote pull")
+		return
+	}
+
+	// Global kill switch: nothing is placed while trading is halted.
+	if e.halted() {
+		log.Warn("kill switch tripped, dropping signal")
+		e.resolve(sig, domain.SignalStatusSkipped, "kill switch tripped")
+		return
+	}
+
+	// Signals priced against read-only venues are candidates for manual
+	// execution only.
+	if sig.Metadata != nil && sig.Metadata["execution"] == "manual" {
+		log.Debug("manual-only signal, skipping")
+		e.resolve(sig, domain.SignalStatusSkipped, "manual execution only")
+		return
+	}
+
+	if e.latency != nil {
+		e.latency.Received(sig, pickedUp)
+	}
+
 	// 0. Multi-leg: buffer and run group when complete.
 	if e.legAccum != nil && sig.Metadata != nil && sig.Metadata["leg_group_id"] != "" {
 		if e.legAccum.Add(ctx, sig) {
@@ -217,46 +737,48 @@ func (e *Executor) process(ctx context.Context, sig domain.TradeSignal) {
 		log.Warn("signal expired, skipping",
 			slog.Time("expires_at", sig.ExpiresAt),
 		)
+		e.resolve(sig, domain.SignalStatusExpired, "")
 		return
 	}
 
-	// 3. Pre-trade risk check.
-	if err := e.riskSvc.PreTradeCheck(ctx, sig, e.wallet); err != nil {
+	// Immediate signals sweep the book instead of resting at the signal
+	// price; convert first so the risk checks see the price actually sent.
+	sig, swept := e.sweepSignal(ctx, sig, log)
+
+	// 3. Risk sizing (e.g. haircut near market close), then pre-trade risk check.
+	riskStart := time.Now()
+	adjusted, err := e.adjustSize(ctx, sig)
+	if err != nil {
+		e.recordRisk(sig, riskStart)
+		log.Warn("risk sizing rejected signal, skipping",
+			slog.String("error", err.Error()),
+		)
+		e.auditRejection(ctx, sig, "sizing", err, log)
+		e.resolve(sig, domain.SignalStatusRejected, err.Error())
+		return
+	}
+	sig = adjusted
+	err = e.riskSvc.PreTradeCheck(ctx, sig, e.wallet)
+	e.recordRisk(sig, riskStart)
+	if err != nil {
 		log.Warn("risk check failed, skipping",
 			slog.String("error", err.Error()),
 		)
+		e.auditRejection(ctx, sig, "pre_trade", err, log)
+		e.resolve(sig, domain.SignalStatusRejected, err.Error())
 		return
 	}
 
-	// 4. Place or replace order (LP requote: replace when we have a previous order for same token+side).
+	// 4. Place the order (LP quotes replace the token's previous quote set).
 	var result domain.OrderResult
-	var err error
-	didReplace := false
 	if sig.Source == "liquidity_provider" {
-		e.lastLPOrderIDMu.Lock()
-		key := "lp:" + sig.TokenID + ":" + string(sig.Side)
-		prevID := e.lastLPOrderID[key]
-		e.lastLPOrderIDMu.Unlock()
-		if prevID != "" {
-			if repl, ok := e.orderSvc.(ReplaceOrderer); ok {
-				result, err = repl.ReplaceOrder(ctx, prevID, sig)
-				didReplace = true
-			}
-		}
-	}
-	if !didReplace || err != nil {
+		result, err = e.placeLPQuote(ctx, sig, log)
+	} else {
 		result, err = e.orderSvc.PlaceOrder(ctx, sig)
 	}
-	if err == nil && result.Success && sig.Source == "liquidity_provider" {
-		e.lastLPOrderIDMu.Lock()
-		e.lastLPOrderID["lp:"+sig.TokenID+":"+string(sig.Side)] = result.OrderID
-		e.lastLPOrderIDMu.Unlock()
-	}
 
 	if err != nil {
-		log.Error("order placement failed",
-			slog.String("error", err.Error()),
-		)
+		e.handlePlaceError(ctx, sig, err, log)
 		return
 	}
 
@@ -268,8 +790,10 @@ func (e *Executor) process(ctx context.Context, sig domain.TradeSignal) {
 			slog.Bool("should_retry", result.ShouldRetry),
 		)
 		if result.ShouldRetry {
-			e.retryOrder(ctx, sig, log)
+			e.retryOrder(ctx, sig, domain.ErrorClassUnknown, log)
+			return
 		}
+		e.resolve(sig, domain.SignalStatusRejected, result.Message, result.OrderID)
 		return
 	}
 
@@ -277,29 +801,82 @@ func (e *Executor) process(ctx context.Context, sig domain.TradeSignal) {
 		slog.String("order_id", result.OrderID),
 		slog.String("status", string(result.Status)),
 	)
+	e.resolve(sig, domain.SignalStatusPlaced, "", result.OrderID)
+	if swept {
+		e.cancelUnfilled(ctx, sig, result, log)
+	}
 }
 
-// retryOrder makes a single retry attempt for a failed order. A production
-// system would use exponential back-off and a bounded retry count; this
-// implementation performs one retry after a short pause.
-func (e *Executor) retryOrder(ctx context.Context, sig domain.TradeSignal, log *slog.Logger) {
+// handlePlaceError branches on the class of a failed placement: rate-limited
+// and transient failures are retried, auth failures are logged as errors
+// because every further order will fail the same way until an operator
+// fixes the credentials, and validation errors and venue rejections are
+// dropped since resending the same order cannot succeed.
+func (e *Executor) handlePlaceError(ctx context.Context, sig domain.TradeSignal, err error, log *slog.Logger) {
+	class := domain.ClassifyError(err)
+	attrs := []any{
+		slog.String("error", err.Error()),
+		slog.String("error_class", string(class)),
+	}
+	switch {
+	case class.Retryable():
+		log.Warn("order placement failed, retrying", attrs...)
+		e.retryOrder(ctx, sig, class, log)
+	case class == domain.ErrorClassAuth:
+		log.Error("order placement failed: venue refused credentials", attrs...)
+		e.resolve(sig, domain.SignalStatusFailed, err.Error())
+	case class == domain.ErrorClassValidation, class == domain.ErrorClassVenueRejected:
+		log.Warn("order refused by venue, not retrying", attrs...)
+		e.resolve(sig, domain.SignalStatusRejected, err.Error())
+	default:
+		log.Error("order placement failed", attrs...)
+		e.resolve(sig, domain.SignalStatusFailed, err.Error())
+	}
+}
+
+// retryDelay is the pause before retrying a placement that failed with class.
+// Rate limits get a longer pause so the retry lands in a fresh window.
+func retryDelay(class domain.ErrorClass) time.Duration {
+	if class == domain.ErrorClassRateLimited {
+		return 2 * time.Second
+	}
+	return 500 * time.Millisecond
+}
+
+// retryOrder makes a single retry attempt for a failed order after a pause
+// chosen by the failure's class. The retry gets a fresh ID because the failed
+// attempt is already persisted under the signal's ID.
+func (e *Executor) retryOrder(ctx context.Context, sig domain.TradeSignal, class domain.ErrorClass, log *slog.Logger) {
 	// Respect expiry even for retries.
 	if !sig.ExpiresAt.IsZero() && time.Now().UTC().After(sig.ExpiresAt) {
 		log.Warn("signal expired during retry, giving up")
+		e.resolve(sig, domain.SignalStatusExpired, "expired before retry")
 		return
 	}
 
 	select {
 	case <-ctx.Done():
+		e.resolve(sig, domain.SignalStatusFailed, "shut down before retry")
 		return
-	case <-time.After(500 * time.Millisecond):
+	case <-time.After(retryDelay(class)):
 	}
+	if e.halted() {
+		log.Warn("kill switch tripped during retry backoff, giving up")
+		e.resolve(sig, domain.SignalStatusSkipped, "kill switch tripped before retry")
+		return
+	}
+
+	retry := sig
+	retry.ID = uuid.New().String()
+	log = log.With(slog.String("retry_id", retry.ID))
 
-	result, err := e.orderSvc.PlaceOrder(ctx, sig)
+	result, err := e.orderSvc.PlaceOrder(ctx, retry)
 	if err != nil {
 		log.Error("retry order placement failed",
 			slog.String("error", err.Error()),
+			slog.String("error_class", string(domain.ClassifyError(err))),
 		)
+		e.resolve(sig, domain.SignalStatusFailed, err.Error())
 		return
 	}
 
@@ -307,10 +884,12 @@ func (e *Executor) retryOrder(ctx context.Context, sig domain.TradeSignal, log *
 		log.Info("retry order placed successfully",
 			slog.String("order_id", result.OrderID),
 		)
+		e.resolve(sig, domain.SignalStatusPlaced, "", result.OrderID)
 	} else {
 		log.Warn("retry order also rejected",
 			slog.String("message", result.Message),
 		)
+		e.resolve(sig, domain.SignalStatusRejected, result.Message, result.OrderID)
 	}
 }
 
@@ -323,20 +902,63 @@ func (e *Executor) drain() {
 			if !ok {
 				return
 			}
-			e.logger.Warn("draining signal after shutdown",
-				slog.String("signal_id", sig.ID),
-			)
-			// We use a short-lived context for draining so we don't hang
-			// indefinitely on external calls during shutdown.
-			drainCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			e.process(drainCtx, sig)
-			cancel()
+			e.drainSignal(sig)
 		default:
 			return
 		}
 	}
 }
 
+// drainSignal processes sig after shutdown.
+func (e *Executor) drainSignal(sig domain.TradeSignal) {
+	e.logger.Warn("draining signal after shutdown",
+		slog.String("signal_id", sig.ID),
+	)
+	// We use a short-lived context for draining so we don't hang
+	// indefinitely on external calls during shutdown.
+	drainCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	e.process(drainCtx, sig)
+	cancel()
+}
+
+// cancelOnShutdown cancels the wallet's open orders when SetCancelOnShutdown
+// is set. It runs after ctx is cancelled, so it uses its own bounded context.
+func (e *Executor) cancelOnShutdown() {
+	if e.shutdownCancel == nil {
+		return
+	}
+	e.logger.Info("cancelling open orders before exit", slog.Duration("timeout", e.shutdownTimeout))
+	ctx, cancel := context.WithTimeout(context.Background(), e.shutdownTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := e.shutdownCancel.CancelAll(ctx, e.wallet)
+	detail := map[string]any{
+		"wallet":     e.wallet,
+		"elapsed_ms": time.Since(start).Milliseconds(),
+		"timeout_ms": e.shutdownTimeout.Milliseconds(),
+	}
+	if err != nil {
+		detail["error"] = err.Error()
+		e.logger.Error("shutdown cancel-all failed; orders may still be resting",
+			slog.String("wallet", e.wallet),
+			slog.String("error", err.Error()),
+		)
+	} else {
+		e.logger.Info("cancelled open orders before exit", slog.String("wallet", e.wallet))
+	}
+
+	if e.audit == nil {
+		return
+	}
+	// The cancel may have used up ctx; the audit entry gets its own deadline.
+	auditCtx, auditCancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer auditCancel()
+	if err := e.audit.Log(auditCtx, "shutdown_cancel_all", detail); err != nil {
+		e.logger.Warn("audit shutdown cancel-all failed", slog.String("error", err.Error()))
+	}
+}
+
 // SetDedupTTL replaces the dedup instance with a new one using the given TTL.
 // This is useful for testing or runtime reconfiguration.
 func (e *Executor) SetDedupTTL(ttl time.Duration) {
@@ -349,6 +971,21 @@ func (e *Executor) SetCleanupInterval(d time.Duration) {
 	e.cleanupInterval = d
 }
 
+// Heartbeat reports whether Run is running and when its dispatch loop last
+// turned. The loop turns at least every cleanup interval while it is free;
+// it stops turning while a serial placement or a dispatch to a full worker
+// queue blocks it.
+func (e *Executor) Heartbeat() domain.ExecutorHeartbeat {
+	hb := domain.ExecutorHeartbeat{
+		Running:  e.running.Load(),
+		Interval: e.cleanupInterval,
+	}
+	if ns := e.beat.Load(); ns != 0 {
+		hb.LastBeat = time.Unix(0, ns)
+	}
+	return hb
+}
+
 // Wallet returns the wallet address this executor is configured with.
 func (e *Executor) Wallet() string {
 	return e.wallet
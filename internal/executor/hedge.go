@@ -0,0 +1,200 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// unwindSource is the TradeSignal.Source of orders placed by the HedgeGuard.
+const unwindSource = "hedge_unwinder"
+
+// unhedgedLeg is the filled part of a placed leg of a best-effort group whose
+// other legs failed or never arrived.
+type unhedgedLeg struct {
+	GroupID  string
+	Signal   domain.TradeSignal
+	Units    int64 // filled shares still open
+	Notional float64
+	Since    time.Time
+}
+
+// HedgeGuard tracks the notional left unhedged by best-effort leg groups.
+// Only what the legs filled counts: their resting remainders are cancelled
+// when tracked. When the total exceeds maxUnhedged it unwinds the oldest legs
+// through a LegUnwinder, taking the opposite side at the top of book (FAK),
+// as long as the realized loss stays within the hedging budget. Once the
+// budget is spent, exposure is only logged and must be closed by hand.
+type HedgeGuard struct {
+	unwinder    *LegUnwinder
+	maxUnhedged float64
+	budget      float64
+	logger      *slog.Logger
+
+	// mu is held for the whole of an unwind so unwinds never overlap.
+	mu    sync.Mutex
+	open  []unhedgedLeg // oldest first
+	total float64
+	spent float64
+}
+
+// NewHedgeGuard creates a HedgeGuard. maxUnhedged is the USD notional that
+// may stay unhedged; budgetUSD caps the total loss the unwinder may realize
+// (0 disables unwinding).
+func NewHedgeGuard(placer OrderPlacer, books domain.OrderbookCache, maxUnhedged, budgetUSD float64, logger *slog.Logger) *HedgeGuard {
+	return &HedgeGuard{
+		unwinder:    NewLegUnwinder(placer, books, nil, logger),
+		maxUnhedged: maxUnhedged,
+		budget:      budgetUSD,
+		logger:      logger.With(slog.String("component", "hedge_guard")),
+	}
+}
+
+// Track records the legs of groupID that were placed while the rest of the
+// group was not; results[i] is the outcome of legs[i]. Each placed leg's
+// resting remainder is cancelled and only its fills are tracked, then legs
+// are unwound if the unhedged total is over the limit.
+func (h *HedgeGuard) Track(ctx context.Context, groupID string, legs []domain.TradeSignal, results []domain.OrderResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now().UTC()
+	added, tracked := 0.0, 0
+	for i, res := range results {
+		if !res.Success || i >= len(legs) {
+			continue
+		}
+		sig := legs[i]
+		log := h.logger.With(
+			slog.String("leg_group_id", groupID),
+			slog.String("signal_id", sig.ID),
+		)
+		filled, err := h.unwinder.settle(ctx, sig, res, log)
+		if err != nil {
+			log.Error("cancel resting remainder failed, it may still fill unhedged", slog.String("error", err.Error()))
+		}
+		if filled <= 0 {
+			continue
+		}
+		n := sig.Price() * filled
+		h.open = append(h.open, unhedgedLeg{GroupID: groupID, Signal: sig, Units: domain.ToSizeUnits(filled), Notional: n, Since: now})
+		added += n
+		tracked++
+	}
+	if tracked == 0 {
+		return
+	}
+	h.total += added
+	h.logger.Warn("leg group partially executed, exposure unhedged",
+		slog.String("leg_group_id", groupID),
+		slog.Int("legs", tracked),
+		slog.Float64("notional", added),
+		slog.Float64("unhedged_total", h.total),
+		slog.Float64("max_unhedged", h.maxUnhedged),
+	)
+
+	if h.total > h.maxUnhedged {
+		h.unwindLocked(ctx)
+	}
+}
+
+// State returns the unhedged notional and the hedging budget spent so far.
+func (h *HedgeGuard) State() (unhedged, spent float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.total, h.spent
+}
+
+// unwindLocked closes the oldest unhedged legs until the total is back within
+// the limit, stopping at the first leg it cannot close in full.
+func (h *HedgeGuard) unwindLocked(ctx context.Context) {
+	for h.total > h.maxUnhedged && len(h.open) > 0 {
+		leg := h.open[0]
+		log := h.logger.With(
+			slog.String("leg_group_id", leg.GroupID),
+			slog.String("signal_id", leg.Signal.ID),
+			slog.String("token", leg.Signal.TokenID),
+		)
+
+		withinBudget := func(cost float64) error {
+			if h.budget <= 0 || h.spent+max(cost, 0) > h.budget {
+				return fmt.Errorf("executor: hedging budget exhausted: unwind cost %.2f, spent %.2f of %.2f", cost, h.spent, h.budget)
+			}
+			return nil
+		}
+		closed, cost, err := h.unwinder.closeFilled(ctx, leg.GroupID, leg.Signal, leg.Units, unwindSource,
+			fmt.Sprintf("unwind unhedged leg %s of group %s", leg.Signal.ID, leg.GroupID), withinBudget)
+		if err != nil {
+			log.Error("unwind failed, leg left unhedged",
+				slog.String("error", err.Error()),
+				slog.Float64("unhedged_total", h.total),
+			)
+			return
+		}
+
+		frac := min(closed.Size/domain.UnitsToSize(leg.Units), 1)
+		h.total -= leg.Notional * frac
+		h.spent += max(cost, 0) * frac
+		log.Warn("unhedged leg unwound",
+			slog.String("order_id", closed.OrderID),
+			slog.Float64("size", closed.Size),
+			slog.Float64("exit_price", closed.ExpectedPrice),
+			slog.Float64("unwind_cost", cost),
+			slog.Float64("budget_spent", h.spent),
+			slog.Float64("unhedged_total", h.total),
+		)
+		if frac < 1 {
+			// The FAK closed part of the leg; the rest waits for the next try.
+			h.open[0].Units -= domain.ToSizeUnits(closed.Size)
+			h.open[0].Notional -= leg.Notional * frac
+			return
+		}
+		h.open = h.open[1:]
+	}
+}
+
+// offsetSignal builds the FAK order from source that closes sizeUnits of
+// sig at the current top of book, and its cost: the loss versus sig's price
+// (negative when the exit is better).
+func offsetSignal(ctx context.Context, books domain.OrderbookCache, sig domain.TradeSignal, sizeUnits int64, source, reason string) (domain.TradeSignal, float64, error) {
+	bid, ask, err := books.GetBBO(ctx, sig.TokenID)
+	if err != nil {
+		return domain.TradeSignal{}, 0, fmt.Errorf("executor: unwind book %s: %w", sig.TokenID, err)
+	}
+
+	entry, size := sig.Price(), domain.UnitsToSize(sizeUnits)
+	side, exit, cost := domain.OrderSideSell, bid, 0.0
+	if sig.Side == domain.OrderSideSell {
+		side, exit = domain.OrderSideBuy, ask
+	}
+	if exit <= 0 {
+		return domain.TradeSignal{}, 0, fmt.Errorf("executor: unwind %s: no %s liquidity", sig.TokenID, side)
+	}
+	if side == domain.OrderSideSell {
+		cost = (entry - exit) * size
+	} else {
+		cost = (exit - entry) * size
+	}
+
+	now := time.Now().UTC()
+	return domain.TradeSignal{
+		ID:         uuid.New().String(),
+		Source:     source,
+		MarketID:   sig.MarketID,
+		TokenID:    sig.TokenID,
+		Side:       side,
+		PriceTicks: domain.ToPriceTicks(exit),
+		SizeUnits:  sizeUnits,
+		Urgency:    domain.SignalUrgencyImmediate,
+		Reason:     reason,
+		Metadata:   map[string]string{"unwind_of": sig.ID},
+		CreatedAt:  now,
+		OrderType:  domain.OrderTypeFAK,
+	}, cost, nil
+}
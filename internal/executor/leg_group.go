@@ -31,7 +31,8 @@ type LegGroupAccumulator struct {
 }
 
 // NewLegGroupAccumulator creates an accumulator. maxGapMs is the maximum time
-// allowed between first and last leg; when exceeded the group is discarded.
+// allowed between first and last leg; when exceeded the group is discarded,
+// except that a best_effort group executes the legs it received.
 func NewLegGroupAccumulator(
 	maxGapMs int64,
 	onComplete func(ctx context.Context, legs []domain.TradeSignal, policy domain.LegPolicy) error,
@@ -77,16 +78,7 @@ func (a *LegGroupAccumulator) Add(ctx context.Context, sig domain.TradeSignal) (
 			FirstSeen:  time.Now().UTC(),
 		}
 		g.timer = time.AfterFunc(time.Duration(a.maxGapMs)*time.Millisecond, func() {
-			a.mu.Lock()
-			defer a.mu.Unlock()
-			if _, ok := a.groups[legGroupID]; ok {
-				delete(a.groups, legGroupID)
-				a.logger.Warn("leg group timed out",
-					slog.String("leg_group_id", legGroupID),
-					slog.Int("received", len(g.Legs)),
-					slog.Int("expected", expected),
-				)
-			}
+			a.timeout(ctx, g)
 		})
 		a.groups[legGroupID] = g
 	}
@@ -111,3 +103,34 @@ func (a *LegGroupAccumulator) Add(ctx context.Context, sig domain.TradeSignal) (
 	}
 	return true
 }
+
+// timeout drops a group whose legs did not all arrive within maxGapMs. A
+// best_effort group still executes the legs it has, so a leg on a slow venue
+// does not hold up the rest; the missing legs count as unhedged.
+func (a *LegGroupAccumulator) timeout(ctx context.Context, g *PendingLegGroup) {
+	a.mu.Lock()
+	if _, ok := a.groups[g.LegGroupID]; !ok {
+		a.mu.Unlock()
+		return
+	}
+	delete(a.groups, g.LegGroupID)
+	legs := make([]domain.TradeSignal, len(g.Legs))
+	copy(legs, g.Legs)
+	a.mu.Unlock()
+
+	a.logger.Warn("leg group timed out",
+		slog.String("leg_group_id", g.LegGroupID),
+		slog.Int("received", len(legs)),
+		slog.Int("expected", g.Expected),
+		slog.String("policy", string(g.Policy)),
+	)
+	if g.Policy != domain.LegPolicyBestEffort || len(legs) == 0 || ctx.Err() != nil {
+		return
+	}
+	if err := a.onComplete(ctx, legs, g.Policy); err != nil {
+		a.logger.Error("leg group onComplete failed",
+			slog.String("leg_group_id", g.LegGroupID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,183 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// topUpOf is the TradeSignal.Metadata key naming the leg a top-up order
+// completes.
+const topUpOf = "top_up_of"
+
+// minTopUpShares is the smallest remainder worth re-quoting.
+const minTopUpShares = 0.01
+
+// LegOrders reads and cancels placed orders (service.OrderService).
+type LegOrders interface {
+	GetOrder(ctx context.Context, id string) (domain.Order, error)
+	CancelOrder(ctx context.Context, orderID string) error
+}
+
+// LegTopUp completes the legs of a fully placed leg group whose orders rest
+// without filling completely, so the group does not stay half executed. A
+// while after placement it checks each leg's order; while part of the leg is
+// unfilled it cancels the resting remainder and re-quotes it at the top of
+// book as a FAK order, as long as that price is within maxSlippageBps of the
+// leg's signal price. It gives up after the configured number of attempts.
+type LegTopUp struct {
+	placer         OrderPlacer
+	orders         LegOrders
+	books          domain.OrderbookCache
+	after          time.Duration
+	attempts       int
+	maxSlippageBps float64
+	halted         func() bool // optional; no re-quotes while it reports true
+	logger         *slog.Logger
+}
+
+// NewLegTopUp creates a LegTopUp that checks legs after each wait of after,
+// re-quoting at most attempts times per leg.
+func NewLegTopUp(placer OrderPlacer, orders LegOrders, books domain.OrderbookCache, after time.Duration, attempts int, maxSlippageBps float64, logger *slog.Logger) *LegTopUp {
+	return &LegTopUp{
+		placer:         placer,
+		orders:         orders,
+		books:          books,
+		after:          after,
+		attempts:       attempts,
+		maxSlippageBps: maxSlippageBps,
+		logger:         logger.With(slog.String("component", "leg_topup")),
+	}
+}
+
+// Watch follows the leg placed for sig (local order sig.ID) of groupID in the
+// background until it is filled, topped up or given up on.
+func (t *LegTopUp) Watch(ctx context.Context, groupID string, sig domain.TradeSignal) {
+	go t.follow(ctx, groupID, sig)
+}
+
+// follow is the per-leg loop behind Watch. filled counts the shares matched
+// by the leg's earlier orders.
+func (t *LegTopUp) follow(ctx context.Context, groupID string, sig domain.TradeSignal) {
+	log := t.logger.With(
+		slog.String("leg_group_id", groupID),
+		slog.String("signal_id", sig.ID),
+		slog.String("token", sig.TokenID),
+	)
+	orderID, filled := sig.ID, 0.0
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(t.after):
+		}
+
+		order, err := t.orders.GetOrder(ctx, orderID)
+		if err != nil {
+			log.Warn("top-up: read leg order failed", slog.String("order_id", orderID), slog.String("error", err.Error()))
+			return
+		}
+		// A matched order whose trades the fill tracker has not recorded yet
+		// is taken as complete rather than bought twice.
+		got := order.FilledSize
+		if order.Status == domain.OrderStatusMatched && got <= 0 {
+			got = order.Size()
+		}
+		remaining := sig.Size() - filled - got
+		if remaining < minTopUpShares {
+			if attempt > 1 {
+				log.Info("top-up: leg filled", slog.Int("orders", attempt))
+			}
+			return
+		}
+		if attempt > t.attempts {
+			log.Warn("top-up: leg left partially filled",
+				slog.Float64("remaining", remaining),
+				slog.Float64("fill_pct", (sig.Size()-remaining)/sig.Size()*100),
+			)
+			return
+		}
+
+		if order.Status.Live() {
+			if err := t.orders.CancelOrder(ctx, order.ID); err != nil {
+				if errors.Is(err, domain.ErrInvalidTransition) {
+					// Filled or cancelled meanwhile; look again next round.
+					attempt--
+					continue
+				}
+				log.Warn("top-up: cancel resting remainder failed", slog.String("order_id", order.ID), slog.String("error", err.Error()))
+				return
+			}
+		}
+		filled += got
+
+		next, err := t.requote(ctx, groupID, sig, remaining)
+		if err != nil {
+			log.Warn("top-up: re-quote skipped", slog.Float64("remaining", remaining), slog.String("error", err.Error()))
+			return
+		}
+		// The resting remainder stays cancelled when the switch has tripped.
+		if t.halted != nil && t.halted() {
+			log.Warn("top-up: kill switch tripped, leg left partially filled", slog.Float64("remaining", remaining))
+			return
+		}
+		res, err := t.placer.PlaceOrder(ctx, next)
+		if err != nil || !res.Success {
+			msg := res.Message
+			if err != nil {
+				msg = err.Error()
+			}
+			log.Warn("top-up: re-quote order failed", slog.String("error", msg))
+			return
+		}
+		log.Info("top-up: re-quoted leg remainder",
+			slog.String("order_id", res.OrderID),
+			slog.Int("attempt", attempt),
+			slog.Float64("price", next.Price()),
+			slog.Float64("remaining", remaining),
+		)
+		orderID = next.ID
+	}
+}
+
+// requote builds the FAK order for remaining shares of sig at the current
+// top of book, refusing prices more than maxSlippageBps worse than the leg's.
+func (t *LegTopUp) requote(ctx context.Context, groupID string, sig domain.TradeSignal, remaining float64) (domain.TradeSignal, error) {
+	bid, ask, err := t.books.GetBBO(ctx, sig.TokenID)
+	if err != nil {
+		return domain.TradeSignal{}, fmt.Errorf("executor: top-up book %s: %w", sig.TokenID, err)
+	}
+	limit := sig.Price()
+	price, worse := ask, ask-limit
+	if sig.Side == domain.OrderSideSell {
+		price, worse = bid, limit-bid
+	}
+	if price <= 0 {
+		return domain.TradeSignal{}, fmt.Errorf("executor: top-up %s: no liquidity", sig.TokenID)
+	}
+	if limit > 0 && worse/limit*10000 > t.maxSlippageBps {
+		return domain.TradeSignal{}, fmt.Errorf("executor: top-up %s: price %.4f is %.0f bps past %.4f",
+			sig.TokenID, price, worse/limit*10000, limit)
+	}
+
+	return domain.TradeSignal{
+		ID:         uuid.New().String(),
+		Source:     sig.Source,
+		MarketID:   sig.MarketID,
+		TokenID:    sig.TokenID,
+		Side:       sig.Side,
+		PriceTicks: domain.ToPriceTicks(price),
+		SizeUnits:  domain.ToSizeUnits(remaining),
+		Urgency:    domain.SignalUrgencyImmediate,
+		Reason:     fmt.Sprintf("top up leg %s of group %s", sig.ID, groupID),
+		Metadata:   map[string]string{topUpOf: sig.ID, "leg_group_id": groupID},
+		CreatedAt:  time.Now().UTC(),
+		OrderType:  domain.OrderTypeFAK,
+	}, nil
+}
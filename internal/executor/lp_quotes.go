@@ -0,0 +1,131 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// lpQuoteSet is the ladder of liquidity_provider orders resting on one token:
+// every level of both sides placed from one requote.
+type lpQuoteSet struct {
+	id     string            // the signals' "lp_quote_set"
+	orders map[string]string // order ID by lpLevelKey
+}
+
+// lpLevelKey identifies a signal's side and ladder level within its set.
+func lpLevelKey(sig domain.TradeSignal) string {
+	level := sig.Metadata["lp_level"]
+	if level == "" {
+		level = "0"
+	}
+	return string(sig.Side) + ":" + level
+}
+
+// placeLPQuote places one level of a liquidity_provider quote set. The first
+// level of a new set to reach placement replaces the token's previous ladder
+// as a whole: every order of the old set is cancelled, the last one through
+// ReplaceOrder together with placing the new level, so old and new levels
+// never rest side by side. Later levels of the set are placed normally.
+// Signals without "lp_quote_set" form a set of their own.
+func (e *Executor) placeLPQuote(ctx context.Context, sig domain.TradeSignal, log *slog.Logger) (domain.OrderResult, error) {
+	setID := sig.Metadata["lp_quote_set"]
+	if setID == "" {
+		setID = sig.ID
+	}
+	e.lpQuotesMu.Lock()
+	cur := e.lpQuotes[sig.TokenID]
+	var stale []string
+	if cur == nil || cur.id != setID {
+		if cur != nil {
+			for _, id := range cur.orders {
+				stale = append(stale, id)
+			}
+		}
+		cur = &lpQuoteSet{id: setID, orders: make(map[string]string)}
+		e.lpQuotes[sig.TokenID] = cur
+	}
+	e.lpQuotesMu.Unlock()
+
+	var result domain.OrderResult
+	var err error
+	didReplace := false
+	if len(stale) > 0 {
+		last := ""
+		repl, canReplace := e.orderSvc.(ReplaceOrderer)
+		if canReplace {
+			last, stale = stale[len(stale)-1], stale[:len(stale)-1]
+		}
+		e.cancelLPOrders(ctx, stale, "requote", log)
+		if last != "" {
+			result, err = repl.ReplaceOrder(ctx, last, sig)
+			didReplace = true
+		}
+	}
+	if !didReplace || err != nil {
+		result, err = e.orderSvc.PlaceOrder(ctx, sig)
+	}
+	if err == nil && result.Success {
+		e.lpQuotesMu.Lock()
+		if e.lpQuotes[sig.TokenID] == cur {
+			cur.orders[lpLevelKey(sig)] = result.OrderID
+		}
+		e.lpQuotesMu.Unlock()
+	}
+	return result, err
+}
+
+// pullLPQuote cancels every level of the resting liquidity_provider ladder on
+// the signal's token and side, so the next quote on that side is placed
+// fresh.
+func (e *Executor) pullLPQuote(ctx context.Context, sig domain.TradeSignal, log *slog.Logger) {
+	prefix := string(sig.Side) + ":"
+	var orderIDs []string
+	e.lpQuotesMu.Lock()
+	if cur := e.lpQuotes[sig.TokenID]; cur != nil {
+		for key, id := range cur.orders {
+			if strings.HasPrefix(key, prefix) {
+				orderIDs = append(orderIDs, id)
+				delete(cur.orders, key)
+			}
+		}
+	}
+	e.lpQuotesMu.Unlock()
+	if len(orderIDs) == 0 {
+		return
+	}
+	e.cancelLPOrders(ctx, orderIDs, sig.Metadata["lp_pull"], log)
+	log.Info("liquidity_provider quote pulled",
+		slog.Int("orders", len(orderIDs)),
+		slog.String("cause", sig.Metadata["lp_pull"]),
+	)
+}
+
+// cancelLPOrders cancels resting liquidity_provider orders. Orders that
+// already filled or went away need no cancel.
+func (e *Executor) cancelLPOrders(ctx context.Context, orderIDs []string, cause string, log *slog.Logger) {
+	if len(orderIDs) == 0 {
+		return
+	}
+	canceller, ok := e.orderSvc.(QuoteCanceller)
+	if !ok {
+		log.Warn("quote cancel ignored: order placer cannot cancel orders")
+		return
+	}
+	for _, orderID := range orderIDs {
+		if err := canceller.CancelOrder(ctx, orderID); err != nil && !errors.Is(err, domain.ErrInvalidTransition) {
+			log.Warn("liquidity_provider quote cancel failed",
+				slog.String("order_id", orderID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		log.Debug("liquidity_provider quote cancelled",
+			slog.String("order_id", orderID),
+			slog.String("cause", cause),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Sweeper turns immediate-urgency signals into marketable FAK orders. A GTC
+// limit at the signal price rests whenever the top level moved or is too
+// thin, so instead the order is priced to take every level up to a slippage
+// budget: edgeFraction of the signal's expected edge ("edge_bps" metadata),
+// at most maxSlippageBps. Whatever the sweep does not fill is killed by the
+// venue rather than left resting.
+type Sweeper struct {
+	books          domain.OrderbookCache
+	edgeFraction   float64
+	maxSlippageBps float64
+}
+
+// NewSweeper creates a Sweeper that gives up at most edgeFraction of a
+// signal's edge, capped at maxSlippageBps, to fill it.
+func NewSweeper(books domain.OrderbookCache, edgeFraction, maxSlippageBps float64) *Sweeper {
+	return &Sweeper{
+		books:          books,
+		edgeFraction:   edgeFraction,
+		maxSlippageBps: maxSlippageBps,
+	}
+}
+
+// Applies reports whether sig is swept: immediate urgency and no time in
+// force other than GTC requested (leg order types set by the engine are kept).
+func (s *Sweeper) Applies(sig domain.TradeSignal) bool {
+	return sig.Urgency == domain.SignalUrgencyImmediate &&
+		(sig.OrderType == "" || sig.OrderType == domain.OrderTypeGTC)
+}
+
+// BudgetBps returns the slippage sig may take, in bps of its price. A signal
+// without an expected edge gets none and sweeps only up to its own price.
+func (s *Sweeper) BudgetBps(sig domain.TradeSignal) float64 {
+	edge, err := strconv.ParseFloat(sig.Metadata["edge_bps"], 64)
+	if err != nil || edge <= 0 {
+		return 0
+	}
+	return math.Min(edge*s.edgeFraction, s.maxSlippageBps)
+}
+
+// Convert returns sig as a FAK order priced at the deepest level of the
+// opposite side needed to fill it, no further than the slippage budget from
+// the signal price, and never better than the signal price itself (the
+// venue fills at the resting levels' prices anyway). Without a consistent
+// book it is a FAK order at the signal price, returned with the error.
+func (s *Sweeper) Convert(ctx context.Context, sig domain.TradeSignal) (domain.TradeSignal, error) {
+	out := sig
+	out.OrderType = domain.OrderTypeFAK
+	snap, err := s.books.GetSnapshot(ctx, sig.TokenID)
+	if err != nil {
+		return out, fmt.Errorf("executor: sweep book %s: %w", sig.TokenID, err)
+	}
+	if snap.Stale {
+		return out, fmt.Errorf("executor: sweep book %s changed while read (version %d)", sig.TokenID, snap.Version)
+	}
+	price := sig.Price()
+	budget := s.BudgetBps(sig) / 10_000
+
+	levels := snap.Asks
+	limit := price * (1 + budget)
+	within := func(p float64) bool { return p <= limit+1e-9 }
+	if sig.Side == domain.OrderSideSell {
+		levels = snap.Bids
+		limit = price * (1 - budget)
+		within = func(p float64) bool { return p >= limit-1e-9 }
+	}
+	levels = append([]domain.PriceLevel(nil), levels...)
+	sort.Slice(levels, func(i, j int) bool {
+		if sig.Side == domain.OrderSideSell {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+
+	sweep, need := price, sig.Size()
+	for _, lvl := range levels {
+		if need <= 0 || !within(lvl.Price) {
+			break
+		}
+		if sig.Side == domain.OrderSideSell {
+			sweep = math.Min(sweep, lvl.Price)
+		} else {
+			sweep = math.Max(sweep, lvl.Price)
+		}
+		need -= lvl.Size
+	}
+
+	out.PriceTicks = domain.ToPriceTicks(sweep)
+	return out, nil
+}
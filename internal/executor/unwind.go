@@ -0,0 +1,210 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/service"
+)
+
+// legUnwindSource is the TradeSignal.Source of orders placed by the
+// LegUnwinder.
+const legUnwindSource = "leg_unwinder"
+
+// LegUnwinder rolls back all_or_none leg groups that stopped at a failed leg.
+// Legs placed before the failure would otherwise stay open as naked
+// exposure: the unfilled remainder of each is cancelled and what it already
+// filled is closed by taking the opposite side at the top of book (FAK).
+type LegUnwinder struct {
+	placer    OrderPlacer
+	canceller QuoteCanceller // nil when the placer cannot cancel
+	orders    LegOrders      // nil when the placer cannot read orders back
+	books     domain.OrderbookCache
+	notifier  service.AlertNotifier // optional
+	logger    *slog.Logger
+}
+
+// UnwindResult is the outcome of unwinding one leg group.
+type UnwindResult struct {
+	// Offsets are the orders placed to close filled legs, as legs of the
+	// group's execution.
+	Offsets []domain.ArbLeg
+	// Complete is true when every resting leg was cancelled and every
+	// filled leg closed in full.
+	Complete bool
+}
+
+// NewLegUnwinder creates a LegUnwinder. Resting legs are only cancelled when
+// placer can cancel orders (QuoteCanceller), and their fills are only read
+// back after the cancel when it can read orders (LegOrders); otherwise the
+// fills reported at placement are offset.
+func NewLegUnwinder(placer OrderPlacer, books domain.OrderbookCache, notifier service.AlertNotifier, logger *slog.Logger) *LegUnwinder {
+	canceller, _ := placer.(QuoteCanceller)
+	orders, _ := placer.(LegOrders)
+	return &LegUnwinder{
+		placer:    placer,
+		canceller: canceller,
+		orders:    orders,
+		books:     books,
+		notifier:  notifier,
+		logger:    logger.With(slog.String("component", "leg_unwinder")),
+	}
+}
+
+// Unwind cancels and offsets the legs of groupID that were placed; results
+// holds the outcome of legs[i] for each leg that was attempted. Each offset
+// is sized from the leg's fills as read after its cancel.
+func (u *LegUnwinder) Unwind(ctx context.Context, groupID string, legs []domain.TradeSignal, results []domain.OrderResult) UnwindResult {
+	out := UnwindResult{Complete: true}
+	var failures []string
+	for i, res := range results {
+		if !res.Success || i >= len(legs) {
+			continue
+		}
+		sig := legs[i]
+		log := u.logger.With(
+			slog.String("leg_group_id", groupID),
+			slog.String("signal_id", sig.ID),
+			slog.String("token", sig.TokenID),
+		)
+
+		filled, err := u.settle(ctx, sig, res, log)
+		if err != nil {
+			log.Error("unwind: cancel resting leg failed", slog.String("error", err.Error()))
+			failures = append(failures, fmt.Sprintf("cancel %s: %v", sig.ID, err))
+			out.Complete = false
+		}
+		if filled <= 0 {
+			continue
+		}
+
+		units := domain.ToSizeUnits(filled)
+		leg, cost, err := u.closeFilled(ctx, groupID, sig, units, legUnwindSource,
+			fmt.Sprintf("unwind leg %s of failed all_or_none group %s", sig.ID, groupID), nil)
+		if err != nil {
+			log.Error("unwind order failed", slog.String("error", err.Error()))
+			failures = append(failures, fmt.Sprintf("offset %s: %v", sig.ID, err))
+			out.Complete = false
+			continue
+		}
+		if size := domain.UnitsToSize(units); leg.Size < size {
+			failures = append(failures, fmt.Sprintf("offset %s: closed %.2f of %.2f", sig.ID, leg.Size, size))
+			out.Complete = false
+		}
+		out.Offsets = append(out.Offsets, leg)
+		log.Warn("filled leg unwound",
+			slog.String("order_id", leg.OrderID),
+			slog.Float64("size", leg.Size),
+			slog.Float64("exit_price", leg.ExpectedPrice),
+			slog.Float64("unwind_cost", cost),
+		)
+	}
+
+	u.notify(ctx, groupID, out, failures)
+	return out
+}
+
+// closeFilled places the FAK order from source that closes units shares of
+// the leg placed for sig at the top of book. It returns the order as a leg
+// of groupID's execution, sized by the shares it closed, and its cost: the
+// loss versus sig's price. allow, when set, may refuse the order by its cost
+// before it is placed.
+func (u *LegUnwinder) closeFilled(ctx context.Context, groupID string, sig domain.TradeSignal, units int64, source, reason string, allow func(cost float64) error) (domain.ArbLeg, float64, error) {
+	offset, cost, err := offsetSignal(ctx, u.books, sig, units, source, reason)
+	if err != nil {
+		return domain.ArbLeg{}, 0, err
+	}
+	if allow != nil {
+		if err := allow(cost); err != nil {
+			return domain.ArbLeg{}, cost, err
+		}
+	}
+	offset.Metadata["leg_group_id"] = groupID
+	placed, err := u.placer.PlaceOrder(ctx, offset)
+	if err != nil {
+		return domain.ArbLeg{}, cost, err
+	}
+	if !placed.Success {
+		return domain.ArbLeg{}, cost, fmt.Errorf("executor: unwind order %s rejected: %s", offset.ID, placed.Message)
+	}
+	closed := placed.FilledSize
+	if placed.Status == domain.OrderStatusMatched && closed <= 0 {
+		closed = offset.Size()
+	}
+	leg := domain.ArbLeg{
+		OrderID:       placed.OrderID,
+		MarketID:      offset.MarketID,
+		TokenID:       offset.TokenID,
+		Side:          offset.Side,
+		ExpectedPrice: offset.Price(),
+		FilledPrice:   placed.FilledPrice,
+		Size:          closed,
+		FeeUSD:        placed.FeeUSD,
+		Status:        placed.Status,
+	}
+	if leg.FilledPrice <= 0 {
+		leg.FilledPrice = leg.ExpectedPrice
+	}
+	return leg, cost, nil
+}
+
+// settle cancels the resting remainder of the leg placed for sig and returns
+// how much of it filled. The fills are read back after the cancel, so shares
+// matched between placement and cancel are counted too; without an order
+// reader, or when the read fails, the fills reported at placement are used.
+// The error is that of the cancel; the fills are returned either way.
+func (u *LegUnwinder) settle(ctx context.Context, sig domain.TradeSignal, res domain.OrderResult, log *slog.Logger) (float64, error) {
+	filled := res.FilledSize
+	if res.Status == domain.OrderStatusMatched && filled <= 0 {
+		filled = sig.Size()
+	}
+	if res.Status == domain.OrderStatusMatched || filled >= sig.Size() {
+		return filled, nil
+	}
+	cancelErr := u.cancel(ctx, sig.ID)
+	if u.orders == nil {
+		return filled, cancelErr
+	}
+	order, err := u.orders.GetOrder(ctx, sig.ID)
+	if err != nil {
+		log.Warn("unwind: read leg fills failed, using fills at placement", slog.String("error", err.Error()))
+		return filled, cancelErr
+	}
+	got := order.FilledSize
+	if order.Status == domain.OrderStatusMatched && got <= 0 {
+		got = order.Size()
+	}
+	return max(filled, got), cancelErr
+}
+
+// cancel cancels a resting leg. A leg that is no longer open (filled or
+// already cancelled meanwhile) is not an error.
+func (u *LegUnwinder) cancel(ctx context.Context, orderID string) error {
+	if u.canceller == nil {
+		return errors.New("order placer cannot cancel orders")
+	}
+	if err := u.canceller.CancelOrder(ctx, orderID); err != nil && !errors.Is(err, domain.ErrInvalidTransition) {
+		return err
+	}
+	return nil
+}
+
+// notify reports the unwind of groupID to the alert channels.
+func (u *LegUnwinder) notify(ctx context.Context, groupID string, out UnwindResult, failures []string) {
+	if u.notifier == nil {
+		return
+	}
+	title := "Leg group unwound"
+	msg := fmt.Sprintf("all_or_none group %s failed; %d filled leg(s) closed", groupID, len(out.Offsets))
+	if !out.Complete {
+		title = "Leg group unwind incomplete"
+		msg += "; exposure left open: " + strings.Join(failures, "; ")
+	}
+	if err := u.notifier.Notify(ctx, "leg_group_unwound", title, msg); err != nil {
+		u.logger.Warn("unwind notification failed", slog.String("error", err.Error()))
+	}
+}
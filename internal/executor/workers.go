@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// signalWorker is one lane of the worker pool: a queue of signals processed
+// in arrival order.
+type signalWorker struct {
+	queue     chan domain.TradeSignal
+	busy      atomic.Bool
+	processed atomic.Int64
+}
+
+// workerPool spreads signals over workers by token, so signals on different
+// tokens are processed concurrently while those on the same token keep the
+// order the engine emitted them in.
+type workerPool struct {
+	workers []*signalWorker
+	blocked atomic.Int64
+	wg      sync.WaitGroup
+}
+
+func newWorkerPool(n, queueDepth int) *workerPool {
+	p := &workerPool{workers: make([]*signalWorker, n)}
+	for i := range p.workers {
+		p.workers[i] = &signalWorker{queue: make(chan domain.TradeSignal, queueDepth)}
+	}
+	return p
+}
+
+// workerFor returns the worker of sig's token, or of its market when the
+// signal names no token.
+func (p *workerPool) workerFor(sig domain.TradeSignal) *signalWorker {
+	key := sig.TokenID
+	if key == "" {
+		key = sig.MarketID
+	}
+	if key == "" {
+		key = sig.ID
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return p.workers[h.Sum32()%uint32(len(p.workers))]
+}
+
+// dispatch queues sig on its worker. When that queue is full it waits, which
+// holds back every other signal too but keeps per-token order; it gives up
+// when ctx is cancelled, reporting false.
+func (p *workerPool) dispatch(ctx context.Context, sig domain.TradeSignal) bool {
+	w := p.workerFor(sig)
+	select {
+	case w.queue <- sig:
+		return true
+	default:
+	}
+	p.blocked.Add(1)
+	select {
+	case w.queue <- sig:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// SetWorkers makes Run process signals on n workers instead of one at a
+// time. Signals are assigned to a worker by token ID (market ID when empty),
+// so signals on the same token are still placed in the order they were
+// emitted; each worker buffers up to queueDepth signals. n <= 1 keeps
+// processing serial. Must be called before Run.
+func (e *Executor) SetWorkers(n, queueDepth int) {
+	if n <= 1 {
+		e.pool = nil
+		return
+	}
+	if queueDepth <= 0 {
+		queueDepth = 64
+	}
+	e.pool = newWorkerPool(n, queueDepth)
+}
+
+// QueueStats reports the signal backlog and, with SetWorkers, the depth of
+// each worker's queue.
+func (e *Executor) QueueStats() domain.ExecutorQueueStats {
+	stats := domain.ExecutorQueueStats{Backlog: len(e.signalCh)}
+	if e.pool == nil {
+		stats.Workers = []domain.ExecutorWorkerStats{{
+			Busy:      e.busy.Load(),
+			Processed: e.processed.Load(),
+		}}
+		return stats
+	}
+	stats.DispatchBlocked = e.pool.blocked.Load()
+	stats.Workers = make([]domain.ExecutorWorkerStats, len(e.pool.workers))
+	for i, w := range e.pool.workers {
+		stats.Workers[i] = domain.ExecutorWorkerStats{
+			Queued:    len(w.queue),
+			Capacity:  cap(w.queue),
+			Busy:      w.busy.Load(),
+			Processed: w.processed.Load(),
+		}
+	}
+	return stats
+}
+
+// startWorkers runs one goroutine per worker until its queue is closed.
+// Signals still queued after ctx is cancelled are processed with a short
+// deadline, as drain does.
+func (e *Executor) startWorkers(ctx context.Context) {
+	for _, w := range e.pool.workers {
+		e.pool.wg.Add(1)
+		go func() {
+			defer e.pool.wg.Done()
+			for sig := range w.queue {
+				w.busy.Store(true)
+				if ctx.Err() == nil {
+					e.process(ctx, sig)
+				} else {
+					e.drainSignal(sig)
+				}
+				w.processed.Add(1)
+				w.busy.Store(false)
+			}
+		}()
+	}
+}
+
+// stopWorkers closes every worker queue and waits for the queued signals to
+// be processed.
+func (e *Executor) stopWorkers() {
+	for _, w := range e.pool.workers {
+		close(w.queue)
+	}
+	e.pool.wg.Wait()
+}
@@ -0,0 +1,170 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// bookEventPruneInterval is how often book events past the retention are
+// deleted.
+const bookEventPruneInterval = time.Hour
+
+// BookRecorder subscribes to the "prices" Redis channel and persists every
+// book snapshot and level delta to a BookEventStore so the book can later be
+// replayed around a specific trade or arbitrage. Events older than the
+// retention are deleted every hour.
+type BookRecorder struct {
+	bus       domain.SignalBus
+	bookCache domain.OrderbookCache
+	store     domain.BookEventStore
+	flushDur  time.Duration
+	batchSize int
+	retention time.Duration // 0 keeps events forever
+	logger    *slog.Logger
+
+	buf []domain.BookEvent
+}
+
+// NewBookRecorder creates a BookRecorder. Events are written when batchSize
+// events are buffered or every flushInterval, whichever comes first, and
+// kept for retention (0 keeps them forever).
+func NewBookRecorder(
+	bus domain.SignalBus,
+	bookCache domain.OrderbookCache,
+	store domain.BookEventStore,
+	flushInterval time.Duration,
+	batchSize int,
+	retention time.Duration,
+	logger *slog.Logger,
+) *BookRecorder {
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &BookRecorder{
+		bus:       bus,
+		bookCache: bookCache,
+		store:     store,
+		flushDur:  flushInterval,
+		batchSize: batchSize,
+		retention: retention,
+		logger:    logger.With(slog.String("component", "book_recorder")),
+	}
+}
+
+// Run subscribes to "prices" and records events until ctx is cancelled.
+// Buffered events are flushed on shutdown.
+func (r *BookRecorder) Run(ctx context.Context) error {
+	ch, err := r.bus.Subscribe(ctx, "prices")
+	if err != nil {
+		return err
+	}
+	r.logger.Info("book recorder started")
+	defer r.logger.Info("book recorder stopped")
+
+	ticker := time.NewTicker(r.flushDur)
+	defer ticker.Stop()
+	prune := time.NewTicker(bookEventPruneInterval)
+	defer prune.Stop()
+	r.prune(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			r.flush(flushCtx)
+			cancel()
+			return ctx.Err()
+		case <-ticker.C:
+			r.flush(ctx)
+		case <-prune.C:
+			r.prune(ctx)
+		case data, ok := <-ch:
+			if !ok {
+				r.flush(ctx)
+				return nil
+			}
+			ev, ok := r.parse(ctx, data)
+			if !ok {
+				continue
+			}
+			r.buf = append(r.buf, ev)
+			if len(r.buf) >= r.batchSize {
+				r.flush(ctx)
+			}
+		}
+	}
+}
+
+// prune deletes book events older than the retention.
+func (r *BookRecorder) prune(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+	deleted, err := r.store.DeleteBefore(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		r.logger.Warn("book recorder: prune failed", slog.String("error", err.Error()))
+		return
+	}
+	if deleted > 0 {
+		r.logger.Info("book recorder: pruned old book events", slog.Int64("deleted", deleted))
+	}
+}
+
+func (r *BookRecorder) parse(ctx context.Context, data []byte) (domain.BookEvent, bool) {
+	var ev priceEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return domain.BookEvent{}, false
+	}
+	assetID := strings.TrimSpace(ev.AssetID)
+	if assetID == "" {
+		return domain.BookEvent{}, false
+	}
+	ts := time.Now()
+	if ev.Timestamp != "" {
+		if t, err := time.Parse(time.RFC3339Nano, ev.Timestamp); err == nil {
+			ts = t
+		}
+	}
+
+	out := domain.BookEvent{
+		AssetID:   assetID,
+		BestBid:   ev.BestBid,
+		BestAsk:   ev.BestAsk,
+		Timestamp: ts,
+	}
+	if ev.Event == "price_change" {
+		out.Kind = domain.BookEventDelta
+		out.Side = ev.Side
+		out.Price = ev.Price
+		out.Size = ev.Size
+		return out, true
+	}
+
+	out.Kind = domain.BookEventSnapshot
+	if snap, err := r.bookCache.GetSnapshot(ctx, assetID); err == nil && snap.AssetID != "" {
+		out.Bids = snap.Bids
+		out.Asks = snap.Asks
+	}
+	return out, true
+}
+
+func (r *BookRecorder) flush(ctx context.Context) {
+	if len(r.buf) == 0 {
+		return
+	}
+	if err := r.store.InsertBatch(ctx, r.buf); err != nil {
+		r.logger.WarnContext(ctx, "book recorder flush failed",
+			slog.Int("events", len(r.buf)),
+			slog.String("error", err.Error()),
+		)
+	}
+	r.buf = r.buf[:0]
+}
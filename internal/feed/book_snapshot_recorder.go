@@ -0,0 +1,253 @@
+package feed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// BookSnapshotConfig controls a BookSnapshotRecorder.
+type BookSnapshotConfig struct {
+	// Assets are the assets recorded; empty records every asset seen on
+	// "prices".
+	Assets []string
+	// Interval samples every recorded asset's book at this cadence. 0
+	// samples on every update instead, at most once per asset per
+	// MinInterval.
+	Interval    time.Duration
+	MinInterval time.Duration
+	// Snapshots are uploaded when BatchSize are buffered or every
+	// FlushInterval, whichever comes first.
+	FlushInterval time.Duration
+	BatchSize     int
+}
+
+// BookSnapshotRecorder samples full orderbook snapshots from the book cache
+// and uploads them to object storage as gzipped JSONL, one
+// domain.OrderbookSnapshot per line, under
+// books/dt=YYYY-MM-DD/asset=ID/{first snapshot time}.jsonl.gz. The files are
+// a research dataset and the book source of backtests with
+// book_source = "s3". Samples of a book that has not changed since the
+// previous one are skipped.
+type BookSnapshotRecorder struct {
+	bus       domain.SignalBus
+	bookCache domain.OrderbookCache
+	writer    domain.BlobWriter
+	cfg       BookSnapshotConfig
+	logger    *slog.Logger
+
+	watched map[string]bool // nil records every asset
+	seen    []string        // assets sampled on the interval, in first-seen order
+	last    map[string]bookSample
+	buf     []domain.OrderbookSnapshot
+}
+
+// bookSample is the last snapshot recorded for an asset.
+type bookSample struct {
+	at      time.Time
+	version int64
+}
+
+// NewBookSnapshotRecorder creates a BookSnapshotRecorder.
+func NewBookSnapshotRecorder(
+	bus domain.SignalBus,
+	bookCache domain.OrderbookCache,
+	writer domain.BlobWriter,
+	cfg BookSnapshotConfig,
+	logger *slog.Logger,
+) *BookSnapshotRecorder {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50000
+	}
+	r := &BookSnapshotRecorder{
+		bus:       bus,
+		bookCache: bookCache,
+		writer:    writer,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "book_snapshots")),
+		last:      make(map[string]bookSample),
+	}
+	if len(cfg.Assets) > 0 {
+		r.watched = make(map[string]bool, len(cfg.Assets))
+		for _, id := range cfg.Assets {
+			if id = strings.TrimSpace(id); id != "" && !r.watched[id] {
+				r.watched[id] = true
+				r.seen = append(r.seen, id)
+			}
+		}
+	}
+	return r
+}
+
+// Run subscribes to "prices" and records snapshots until ctx is cancelled.
+// Buffered snapshots are uploaded on shutdown.
+func (r *BookSnapshotRecorder) Run(ctx context.Context) error {
+	ch, err := r.bus.Subscribe(ctx, "prices")
+	if err != nil {
+		return err
+	}
+	r.logger.Info("book snapshot recorder started",
+		slog.Int("assets", len(r.watched)),
+		slog.Duration("interval", r.cfg.Interval),
+	)
+	defer r.logger.Info("book snapshot recorder stopped")
+
+	flush := time.NewTicker(r.cfg.FlushInterval)
+	defer flush.Stop()
+	var sampleC <-chan time.Time
+	if r.cfg.Interval > 0 {
+		sample := time.NewTicker(r.cfg.Interval)
+		defer sample.Stop()
+		sampleC = sample.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			r.flush(flushCtx)
+			cancel()
+			return ctx.Err()
+		case <-flush.C:
+			r.flush(ctx)
+		case <-sampleC:
+			for _, id := range r.seen {
+				r.record(ctx, id)
+			}
+		case data, ok := <-ch:
+			if !ok {
+				r.flush(ctx)
+				return nil
+			}
+			var ev priceEvent
+			if err := json.Unmarshal(data, &ev); err != nil {
+				continue
+			}
+			r.observe(ctx, strings.TrimSpace(ev.AssetID))
+		}
+	}
+}
+
+// observe handles an update of assetID's book: on the interval it adds the
+// asset to the sampled set, otherwise it records the book unless the asset
+// was recorded less than MinInterval ago.
+func (r *BookSnapshotRecorder) observe(ctx context.Context, assetID string) {
+	if assetID == "" || (r.watched != nil && !r.watched[assetID]) {
+		return
+	}
+	if r.cfg.Interval > 0 {
+		if r.watched == nil {
+			if _, ok := r.last[assetID]; !ok {
+				r.last[assetID] = bookSample{}
+				r.seen = append(r.seen, assetID)
+			}
+		}
+		return
+	}
+	if s, ok := r.last[assetID]; ok && time.Since(s.at) < r.cfg.MinInterval {
+		return
+	}
+	r.record(ctx, assetID)
+}
+
+// record buffers assetID's cached book unless it is unchanged since the last
+// one recorded.
+func (r *BookSnapshotRecorder) record(ctx context.Context, assetID string) {
+	snap, err := r.bookCache.GetSnapshot(ctx, assetID)
+	if err != nil || snap.AssetID == "" {
+		return
+	}
+	now := time.Now().UTC()
+	prev := r.last[assetID]
+	if snap.Version != 0 && snap.Version == prev.version {
+		return
+	}
+	r.last[assetID] = bookSample{at: now, version: snap.Version}
+	if snap.Timestamp.IsZero() {
+		snap.Timestamp = now
+	}
+	r.buf = append(r.buf, snap)
+	if len(r.buf) >= r.cfg.BatchSize {
+		r.flush(ctx)
+	}
+}
+
+// flush uploads the buffered snapshots, one file per asset and day. Files
+// that fail to upload are dropped and logged.
+func (r *BookSnapshotRecorder) flush(ctx context.Context) {
+	if len(r.buf) == 0 {
+		return
+	}
+	type fileKey struct{ day, asset string }
+	files := make(map[fileKey][]domain.OrderbookSnapshot)
+	for _, snap := range r.buf {
+		k := fileKey{day: snap.Timestamp.UTC().Format("2006-01-02"), asset: snap.AssetID}
+		files[k] = append(files[k], snap)
+	}
+	r.buf = r.buf[:0]
+
+	keys := make([]fileKey, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].day != keys[j].day {
+			return keys[i].day < keys[j].day
+		}
+		return keys[i].asset < keys[j].asset
+	})
+
+	var uploaded, failed int
+	for _, k := range keys {
+		snaps := files[k]
+		path := bookSnapshotPath(k.asset, snaps[0].Timestamp)
+		if err := r.upload(ctx, path, snaps); err != nil {
+			failed++
+			r.logger.WarnContext(ctx, "book snapshot upload failed",
+				slog.String("path", path),
+				slog.Int("snapshots", len(snaps)),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		uploaded++
+	}
+	r.logger.DebugContext(ctx, "book snapshots flushed",
+		slog.Int("files", uploaded),
+		slog.Int("failed", failed),
+	)
+}
+
+func (r *BookSnapshotRecorder) upload(ctx context.Context, path string, snaps []domain.OrderbookSnapshot) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, snap := range snaps {
+		if err := enc.Encode(snap); err != nil {
+			return fmt.Errorf("encode snapshot: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compress snapshots: %w", err)
+	}
+	return r.writer.Put(ctx, path, &buf, "application/gzip")
+}
+
+// bookSnapshotPath returns the object path of a snapshot file of assetID
+// starting at first.
+func bookSnapshotPath(assetID string, first time.Time) string {
+	first = first.UTC()
+	return fmt.Sprintf("books/dt=%s/asset=%s/%s.jsonl.gz",
+		first.Format("2006-01-02"), assetID, first.Format("20060102T150405.000000000Z"))
+}
@@ -30,6 +30,7 @@ type EngineFeeder struct {
 	bus       domain.SignalBus
 	bookCache domain.OrderbookCache
 	engine    *strategy.Engine
+	sampler   *Sampler // optional; nil forwards every event
 	logger    *slog.Logger
 }
 
@@ -43,14 +44,30 @@ func NewEngineFeeder(bus domain.SignalBus, bookCache domain.OrderbookCache, engi
 	}
 }
 
+// WithSampler thins events for low-priority assets before they reach the engine.
+func (f *EngineFeeder) WithSampler(s *Sampler) *EngineFeeder {
+	f.sampler = s
+	return f
+}
+
 // Run subscribes to "prices" and calls engine.HandleBookUpdate or HandlePriceChange for each message.
 func (f *EngineFeeder) Run(ctx context.Context) error {
 	ch, err := f.bus.Subscribe(ctx, "prices")
 	if err != nil {
 		return err
 	}
-	f.logger.Info("engine feeder started")
-	defer f.logger.Info("engine feeder stopped")
+	f.logger.Info("engine feeder started", slog.Bool("sampling", f.sampler != nil))
+	defer func() {
+		if f.sampler != nil {
+			forwarded, skipped := f.sampler.Stats()
+			f.logger.Info("engine feeder stopped",
+				slog.Int64("forwarded", forwarded),
+				slog.Int64("sampled_out", skipped),
+			)
+			return
+		}
+		f.logger.Info("engine feeder stopped")
+	}()
 
 	for {
 		select {
@@ -79,6 +96,9 @@ func (f *EngineFeeder) handleMessage(ctx context.Context, data []byte) error {
 	if assetID == "" {
 		return nil
 	}
+	if f.sampler != nil && !f.sampler.Allow(ctx, assetID, time.Now()) {
+		return nil
+	}
 	ts := time.Now()
 	if ev.Timestamp != "" {
 		if t, err := time.Parse(time.RFC3339Nano, ev.Timestamp); err == nil {
@@ -0,0 +1,98 @@
+package feed
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/crypto"
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polymarket"
+)
+
+// FillHandler is called for each fill of the wallet's orders (FillTracker).
+type FillHandler func(ctx context.Context, ev domain.OrderFillEvent) error
+
+// OrderUpdateHandler is called for each order lifecycle event (FillTracker).
+type OrderUpdateHandler func(ctx context.Context, ev domain.OrderUpdateEvent) error
+
+// PolymarketUserFeed keeps a connection to the authenticated CLOB user
+// channel open and passes the wallet's fills and order events to the given
+// handlers. It reconnects with exponential backoff on disconnect.
+type PolymarketUserFeed struct {
+	wsURL   string
+	auth    crypto.HMACAuth
+	onFill  FillHandler
+	onOrder OrderUpdateHandler
+	logger  *slog.Logger
+}
+
+// NewPolymarketUserFeed creates a user channel feed. wsHost is the configured
+// Polymarket WebSocket host; auth holds the derived L2 API credentials.
+func NewPolymarketUserFeed(wsHost string, auth crypto.HMACAuth, onFill FillHandler, onOrder OrderUpdateHandler, logger *slog.Logger) *PolymarketUserFeed {
+	return &PolymarketUserFeed{
+		wsURL:   polymarket.UserChannelURL(wsHost),
+		auth:    auth,
+		onFill:  onFill,
+		onOrder: onOrder,
+		logger:  logger.With(slog.String("component", "polymarket_user_feed")),
+	}
+}
+
+// Run serves the user channel until ctx is cancelled.
+func (f *PolymarketUserFeed) Run(ctx context.Context) error {
+	const (
+		minBackoff = 2 * time.Second
+		maxBackoff = 60 * time.Second
+		// A connection that stayed up this long resets the backoff.
+		stableAfter = time.Minute
+	)
+	backoff := minBackoff
+	for {
+		client := polymarket.NewUserWSClient(f.wsURL, f.auth)
+		client.OnFill(func(ev domain.OrderFillEvent) {
+			if f.onFill == nil {
+				return
+			}
+			if err := f.onFill(ctx, ev); err != nil {
+				f.logger.Warn("fill handler failed",
+					slog.String("exchange_id", ev.ExchangeID),
+					slog.String("trade_id", ev.TradeID),
+					slog.String("error", err.Error()),
+				)
+			}
+		})
+		client.OnOrderUpdate(func(ev domain.OrderUpdateEvent) {
+			if f.onOrder == nil {
+				return
+			}
+			if err := f.onOrder(ctx, ev); err != nil {
+				f.logger.Warn("order update handler failed",
+					slog.String("exchange_id", ev.ExchangeID),
+					slog.String("type", ev.Type),
+					slog.String("error", err.Error()),
+				)
+			}
+		})
+
+		f.logger.Info("polymarket user channel connecting", slog.String("url", f.wsURL))
+		started := time.Now()
+		err := client.Run(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if time.Since(started) > stableAfter {
+			backoff = minBackoff
+		}
+		f.logger.Warn("polymarket user channel disconnected, reconnecting",
+			slog.String("error", err.Error()),
+			slog.Duration("backoff", backoff),
+		)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
@@ -2,6 +2,7 @@ package feed
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"sync"
 	"time"
@@ -10,23 +11,70 @@ import (
 	"github.com/alanyoungcy/polymarketbot/internal/platform/polymarket"
 )
 
+// feedChannel is the signal bus channel for feed connection state changes.
+const feedChannel = "feed"
+
+// polymarketFeedName identifies the Polymarket market feed in FeedStatus.
+const polymarketFeedName = "polymarket"
+
 // BookUpdateHandler is called for each orderbook snapshot (PriceService + Engine).
 type BookUpdateHandler func(ctx context.Context, snap domain.OrderbookSnapshot)
 
 // PriceChangeHandler is called for each price change (PriceService + Engine).
 type PriceChangeHandler func(ctx context.Context, change domain.PriceChange)
 
+// LastTradeHandler is called for each last trade price print (PriceService).
+type LastTradeHandler func(ctx context.Context, trade domain.LastTradePrice)
+
+// FeedStatusListener receives feed connection state changes (RiskService,
+// strategy.Engine).
+type FeedStatusListener interface {
+	HandleFeedStatus(ctx context.Context, status domain.FeedStatus) error
+}
+
 // PolymarketWSFeed connects to the Polymarket CLOB WebSocket, subscribes to
-// book and price_change for the given asset IDs, and invokes the provided
+// book and price_change (and last_trade_price when a handler is set) for the
+// given asset IDs, and invokes the provided
 // handlers on each message. It reconnects on disconnect.
+//
+// Connection state changes (connected, degraded, reconnecting, resubscribed)
+// are published on the "feed" channel and passed to registered listeners so
+// books are not trusted while data is not arriving. The watchdog (see
+// WithWatchdog and WithRESTFallback) escalates a silent feed from degraded to
+// a forced reconnect and keeps books refreshed over REST meanwhile.
 type PolymarketWSFeed struct {
 	wsURL     string
 	assetIDs  []string
 	onBook    BookUpdateHandler
 	onPrice   PriceChangeHandler
+	onTrade   LastTradeHandler  // optional
+	bus       domain.SignalBus  // optional
+	audit     domain.AuditStore // optional
+	silence   time.Duration     // 0 disables the degraded check
 	logger    *slog.Logger
 	closeOnce sync.Once
 	done      chan struct{}
+
+	// Watchdog escalation; 0 disables each step.
+	reconnectAfter time.Duration // feed-wide silence before a forced reconnect
+	assetStale     time.Duration // per-asset silence before a resubscribe
+	books          BookFetcher   // optional REST fallback
+	pollEvery      time.Duration
+
+	mu        sync.Mutex
+	status    domain.FeedStatus
+	lastMsg   time.Time
+	listeners []FeedStatusListener
+
+	assetMsg     map[string]time.Time    // last message per asset
+	client       *polymarket.WSClient    // current connection; nil between connections
+	drop         context.CancelCauseFunc // ends the current connection
+	subscribed   time.Time               // when the current connection subscribed
+	resubbed     map[string]time.Time    // last watchdog resubscribe per asset
+	forced       int
+	resubscribes int
+	polling      bool
+	lastPoll     time.Time
 }
 
 // NewPolymarketWSFeed creates a feed that will subscribe to the given asset IDs.
@@ -38,9 +86,53 @@ func NewPolymarketWSFeed(wsURL string, assetIDs []string, onBook BookUpdateHandl
 		onPrice:  onPrice,
 		logger:   logger.With(slog.String("component", "polymarket_ws_feed")),
 		done:     make(chan struct{}),
+		status:   domain.FeedStatus{Feed: polymarketFeedName},
+		assetMsg: make(map[string]time.Time),
+		resubbed: make(map[string]time.Time),
 	}
 }
 
+// WithBus publishes connection state changes on the "feed" channel.
+func (f *PolymarketWSFeed) WithBus(bus domain.SignalBus) *PolymarketWSFeed {
+	f.bus = bus
+	return f
+}
+
+// WithLastTrade also subscribes to last_trade_price and passes each print
+// to handler.
+func (f *PolymarketWSFeed) WithLastTrade(handler LastTradeHandler) *PolymarketWSFeed {
+	f.onTrade = handler
+	return f
+}
+
+// WithAudit records connection state changes as "feed_state_changed"
+// audit entries for the activity timeline.
+func (f *PolymarketWSFeed) WithAudit(audit domain.AuditStore) *PolymarketWSFeed {
+	f.audit = audit
+	return f
+}
+
+// WithSilenceTimeout reports the feed degraded when no message has arrived
+// for d while connected. 0 disables the check.
+func (f *PolymarketWSFeed) WithSilenceTimeout(d time.Duration) *PolymarketWSFeed {
+	f.silence = d
+	return f
+}
+
+// AddListener registers a listener for connection state changes.
+func (f *PolymarketWSFeed) AddListener(l FeedStatusListener) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listeners = append(f.listeners, l)
+}
+
+// Status returns the current connection state.
+func (f *PolymarketWSFeed) Status() domain.FeedStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status
+}
+
 // Run connects, subscribes to book and price_change for the configured assets,
 // and runs until ctx is cancelled. Reconnects with backoff on disconnect.
 func (f *PolymarketWSFeed) Run(ctx context.Context) error {
@@ -48,6 +140,12 @@ func (f *PolymarketWSFeed) Run(ctx context.Context) error {
 		f.logger.Info("no asset IDs to subscribe, exiting")
 		return nil
 	}
+	if f.silence > 0 || f.reconnectAfter > 0 || f.assetStale > 0 {
+		go f.watch(ctx)
+	}
+	if f.books != nil && f.pollEvery > 0 {
+		go f.pollLoop(ctx)
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -56,9 +154,7 @@ func (f *PolymarketWSFeed) Run(ctx context.Context) error {
 			return nil
 		default:
 		}
-		connCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
-		err := f.runConnection(connCtx)
-		cancel()
+		err := f.runConnection(ctx)
 		if err == nil {
 			return nil
 		}
@@ -66,6 +162,7 @@ func (f *PolymarketWSFeed) Run(ctx context.Context) error {
 			return ctx.Err()
 		}
 		f.logger.Warn("polymarket ws disconnected, reconnecting", slog.String("error", err.Error()))
+		f.setState(ctx, domain.FeedStateReconnecting, err)
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -79,27 +176,189 @@ func (f *PolymarketWSFeed) runConnection(ctx context.Context) error {
 	defer client.Close()
 
 	client.OnBookUpdate(func(snap domain.OrderbookSnapshot) {
+		f.touch(ctx, snap.AssetID)
 		if f.onBook != nil {
 			f.onBook(context.Background(), snap)
 		}
 	})
 	client.OnPriceChange(func(change domain.PriceChange) {
+		f.touch(ctx, change.AssetID)
 		if f.onPrice != nil {
 			f.onPrice(context.Background(), change)
 		}
 	})
+	client.OnLastTradePrice(func(trade domain.LastTradePrice) {
+		f.touch(ctx, trade.AssetID)
+		if f.onTrade != nil {
+			f.onTrade(context.Background(), trade)
+		}
+	})
+	client.OnConnState(func(state polymarket.ConnState, err error) {
+		switch state {
+		case polymarket.ConnLost:
+			f.setState(ctx, domain.FeedStateReconnecting, err)
+		case polymarket.ConnRestored:
+			f.setState(ctx, domain.FeedStateResubscribed, nil)
+		}
+	})
 
-	if err := client.Connect(ctx); err != nil {
+	// The timeout bounds the handshake and subscribe only; the connection
+	// itself lives until ctx is cancelled.
+	connCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	if err := client.Connect(connCtx); err != nil {
 		return err
 	}
-	channels := []string{"book", "price_change"}
-	if err := client.Subscribe(ctx, channels, f.assetIDs); err != nil {
+	if err := client.Subscribe(connCtx, f.channels(), f.assetIDs); err != nil {
 		return err
 	}
 	f.logger.Info("polymarket ws subscribed", slog.Int("assets", len(f.assetIDs)))
 
-	<-ctx.Done()
-	return ctx.Err()
+	// The watchdog ends a connection that stopped carrying data through
+	// linkCtx; the cause is what Run logs before reconnecting.
+	linkCtx, drop := context.WithCancelCause(ctx)
+	defer drop(nil)
+	f.mu.Lock()
+	f.client, f.drop, f.subscribed = client, drop, time.Now()
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.client, f.drop = nil, nil
+		f.mu.Unlock()
+	}()
+
+	if f.Status().State == "" {
+		f.setState(ctx, domain.FeedStateConnected, nil)
+	} else {
+		f.setState(ctx, domain.FeedStateResubscribed, nil)
+	}
+
+	select {
+	case <-linkCtx.Done():
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return context.Cause(linkCtx)
+	case <-f.done:
+		return nil
+	}
+}
+
+// channels returns the market channels the feed subscribes to.
+func (f *PolymarketWSFeed) channels() []string {
+	channels := []string{"book", "price_change"}
+	if f.onTrade != nil {
+		channels = append(channels, "last_trade_price")
+	}
+	return channels
+}
+
+// touch records a message arrival for assetID and ends a silence-induced
+// degraded state.
+func (f *PolymarketWSFeed) touch(ctx context.Context, assetID string) {
+	f.mu.Lock()
+	f.lastMsg = time.Now()
+	if assetID != "" {
+		f.assetMsg[assetID] = f.lastMsg
+	}
+	degraded := f.status.State == domain.FeedStateDegraded
+	f.mu.Unlock()
+	if degraded {
+		f.setState(ctx, domain.FeedStateConnected, nil)
+	}
+}
+
+// setState records a state change, then publishes it and notifies listeners.
+// A repeated state is ignored.
+func (f *PolymarketWSFeed) setState(ctx context.Context, state domain.FeedState, cause error) {
+	now := time.Now()
+	f.mu.Lock()
+	prev := f.status
+	if prev.State == state {
+		f.mu.Unlock()
+		return
+	}
+	st := domain.FeedStatus{
+		Feed:       prev.Feed,
+		State:      state,
+		Previous:   prev.State,
+		At:         now,
+		GapStart:   prev.GapStart,
+		LastGap:    prev.LastGap,
+		Reconnects: prev.Reconnects,
+	}
+	if cause != nil {
+		st.Error = cause.Error()
+	}
+	switch {
+	case !state.Live() && st.GapStart == nil:
+		// Data stopped with the last message received, not when the loss
+		// was noticed.
+		start := now
+		if !f.lastMsg.IsZero() {
+			start = f.lastMsg
+		}
+		st.GapStart = &start
+	case state.Live() && st.GapStart != nil:
+		st.LastGap = now.Sub(*st.GapStart)
+		st.GapStart = nil
+	}
+	if state == domain.FeedStateResubscribed {
+		st.Reconnects++
+	}
+	f.status = st
+	listeners := append([]FeedStatusListener(nil), f.listeners...)
+	f.mu.Unlock()
+
+	attrs := []any{
+		slog.String("state", string(st.State)),
+		slog.String("previous", string(st.Previous)),
+	}
+	if st.LastGap > 0 && state.Live() {
+		attrs = append(attrs, slog.Duration("gap", st.LastGap))
+	}
+	if st.Error != "" {
+		attrs = append(attrs, slog.String("error", st.Error))
+	}
+	if state.Live() {
+		f.logger.Info("polymarket ws feed state changed", attrs...)
+	} else {
+		f.logger.Warn("polymarket ws feed state changed", attrs...)
+	}
+
+	if f.bus != nil {
+		payload, _ := json.Marshal(map[string]any{
+			"event":       "feed_state",
+			"feed":        st.Feed,
+			"state":       st.State,
+			"previous":    st.Previous,
+			"at":          st.At,
+			"gap_start":   st.GapStart,
+			"last_gap_ms": st.LastGap.Milliseconds(),
+			"reconnects":  st.Reconnects,
+			"error":       st.Error,
+		})
+		if err := f.bus.Publish(ctx, feedChannel, payload); err != nil {
+			f.logger.WarnContext(ctx, "publish feed state failed", slog.String("error", err.Error()))
+		}
+	}
+	if f.audit != nil {
+		if err := f.audit.Log(ctx, "feed_state_changed", map[string]any{
+			"feed":        st.Feed,
+			"state":       string(st.State),
+			"previous":    string(st.Previous),
+			"last_gap_ms": st.LastGap.Milliseconds(),
+			"reconnects":  st.Reconnects,
+			"error":       st.Error,
+		}); err != nil {
+			f.logger.WarnContext(ctx, "audit feed state failed", slog.String("error", err.Error()))
+		}
+	}
+	for _, l := range listeners {
+		if err := l.HandleFeedStatus(ctx, st); err != nil {
+			f.logger.WarnContext(ctx, "feed status listener failed", slog.String("error", err.Error()))
+		}
+	}
 }
 
 // Close stops the feed.
@@ -0,0 +1,168 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// SamplingMode selects how events for low-priority assets are thinned.
+type SamplingMode string
+
+const (
+	// SampleEveryN forwards one in every N events per asset.
+	SampleEveryN SamplingMode = "every_n"
+	// SampleInterval forwards at most one event per asset per interval.
+	SampleInterval SamplingMode = "interval"
+)
+
+// SamplerConfig configures a Sampler. An asset is high priority, and gets
+// every event, when its liquidity score (USD resting within DepthBand of the
+// mid on both sides of the book) is at least MinLiquidityUSD. Priority and
+// Sampled force assets into either class regardless of score.
+type SamplerConfig struct {
+	Mode            SamplingMode
+	EveryN          int
+	Interval        time.Duration
+	MinLiquidityUSD float64
+	DepthBand       float64
+	ScoreRefresh    time.Duration
+	Priority        []string
+	Sampled         []string
+}
+
+// assetSample is the sampling state of one asset.
+type assetSample struct {
+	score    float64
+	scored   bool
+	scoredAt time.Time
+	seen     int
+	lastSent time.Time
+}
+
+// Sampler decides which book events reach the strategy engine, passing
+// every event for liquid (priority) assets and a sample for the long tail.
+// Scores are recomputed from the cached book every ScoreRefresh. A Sampler
+// is not safe for concurrent use.
+type Sampler struct {
+	cfg      SamplerConfig
+	books    domain.OrderbookCache
+	priority map[string]bool
+	sampled  map[string]bool
+	assets   map[string]*assetSample
+
+	forwarded, skipped int64
+}
+
+// NewSampler creates a Sampler that scores assets from books.
+func NewSampler(cfg SamplerConfig, books domain.OrderbookCache) *Sampler {
+	if cfg.Mode == "" {
+		cfg.Mode = SampleEveryN
+	}
+	if cfg.EveryN <= 0 {
+		cfg.EveryN = 10
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	if cfg.DepthBand <= 0 {
+		cfg.DepthBand = 0.02
+	}
+	if cfg.ScoreRefresh <= 0 {
+		cfg.ScoreRefresh = time.Minute
+	}
+	s := &Sampler{
+		cfg:      cfg,
+		books:    books,
+		priority: make(map[string]bool, len(cfg.Priority)),
+		sampled:  make(map[string]bool, len(cfg.Sampled)),
+		assets:   make(map[string]*assetSample),
+	}
+	for _, id := range cfg.Priority {
+		s.priority[id] = true
+	}
+	for _, id := range cfg.Sampled {
+		s.sampled[id] = true
+	}
+	return s
+}
+
+// Allow reports whether an event for assetID at now should be forwarded.
+func (s *Sampler) Allow(ctx context.Context, assetID string, now time.Time) bool {
+	st := s.assets[assetID]
+	if st == nil {
+		st = &assetSample{}
+		s.assets[assetID] = st
+	}
+
+	if !s.isPriority(ctx, assetID, st, now) && !s.take(st, now) {
+		s.skipped++
+		return false
+	}
+	s.forwarded++
+	return true
+}
+
+// Stats returns the number of events forwarded and skipped so far.
+func (s *Sampler) Stats() (forwarded, skipped int64) {
+	return s.forwarded, s.skipped
+}
+
+func (s *Sampler) isPriority(ctx context.Context, assetID string, st *assetSample, now time.Time) bool {
+	switch {
+	case s.priority[assetID]:
+		return true
+	case s.sampled[assetID]:
+		return false
+	}
+	if now.Sub(st.scoredAt) >= s.cfg.ScoreRefresh {
+		st.scoredAt = now
+		if snap, err := s.books.GetSnapshot(ctx, assetID); err == nil && snap.AssetID != "" {
+			st.score, st.scored = liquidityScore(snap, s.cfg.DepthBand), true
+		}
+	}
+	// Assets without a book yet are not known to be illiquid.
+	return !st.scored || st.score >= s.cfg.MinLiquidityUSD
+}
+
+// take applies the sampling mode to a low-priority asset's event.
+func (s *Sampler) take(st *assetSample, now time.Time) bool {
+	if s.cfg.Mode == SampleInterval {
+		if now.Sub(st.lastSent) < s.cfg.Interval {
+			return false
+		}
+		st.lastSent = now
+		return true
+	}
+	st.seen++
+	if st.seen < s.cfg.EveryN {
+		return false
+	}
+	st.seen = 0
+	return true
+}
+
+// liquidityScore is the USD notional resting within band of the mid on both
+// sides of the book.
+func liquidityScore(snap domain.OrderbookSnapshot, band float64) float64 {
+	mid := snap.MidPrice
+	if mid <= 0 && snap.BestBid > 0 && snap.BestAsk > 0 {
+		mid = (snap.BestBid + snap.BestAsk) / 2
+	}
+	if mid <= 0 {
+		return 0
+	}
+	var usd float64
+	for _, l := range snap.Bids {
+		if l.Price >= mid-band {
+			usd += l.Price * l.Size
+		}
+	}
+	for _, l := range snap.Asks {
+		if l.Price <= mid+band {
+			usd += l.Price * l.Size
+		}
+	}
+	return usd
+}
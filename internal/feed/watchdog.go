@@ -0,0 +1,275 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polymarket"
+)
+
+// errFeedStale ends a connection the watchdog found carrying no data.
+var errFeedStale = errors.New("feed: no messages within the reconnect threshold")
+
+// pollConcurrency bounds the books fetched at once by the REST fallback.
+const pollConcurrency = 4
+
+// BookFetcher reads a token's orderbook over REST (polymarket.ClobClient).
+type BookFetcher interface {
+	GetOrderbook(ctx context.Context, tokenID string) (domain.OrderbookSnapshot, error)
+}
+
+// WithWatchdog escalates a silent feed past the degraded state: a connection
+// that has carried no message for reconnectAfter is dropped and dialled
+// afresh, and an asset that has had no message for assetStale while the
+// connection is live is unsubscribed and subscribed again, which makes the
+// venue send its book anew. 0 disables either step.
+func (f *PolymarketWSFeed) WithWatchdog(reconnectAfter, assetStale time.Duration) *PolymarketWSFeed {
+	f.reconnectAfter = reconnectAfter
+	f.assetStale = assetStale
+	return f
+}
+
+// WithRESTFallback polls books every interval from books while the feed is
+// degraded or reconnecting, passing them to the book handler so caches and
+// marks stay current during the gap. The feed stays not live meanwhile, so
+// listeners keep holding back signals and entries.
+func (f *PolymarketWSFeed) WithRESTFallback(books BookFetcher, every time.Duration) *PolymarketWSFeed {
+	f.books = books
+	f.pollEvery = every
+	return f
+}
+
+// Health returns the feed's current state and watchdog counters.
+func (f *PolymarketWSFeed) Health() domain.FeedHealth {
+	now := time.Now()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h := domain.FeedHealth{
+		Feed:             f.status.Feed,
+		State:            f.status.State,
+		LastMessage:      f.lastMsg,
+		Assets:           len(f.assetIDs),
+		Reconnects:       f.status.Reconnects,
+		ForcedReconnects: f.forced,
+		Resubscribes:     f.resubscribes,
+		Polling:          f.polling,
+		LastPoll:         f.lastPoll,
+	}
+	if f.assetStale > 0 && f.status.State.Live() {
+		for _, id := range f.assetIDs {
+			if now.Sub(f.assetSinceLocked(id, false)) > f.assetStale {
+				h.StaleAssets++
+			}
+		}
+	}
+	return h
+}
+
+// watch runs the watchdog checks until the feed stops.
+func (f *PolymarketWSFeed) watch(ctx context.Context) {
+	interval := time.Duration(0)
+	for _, d := range []time.Duration{f.silence, f.reconnectAfter, f.assetStale} {
+		if d > 0 && (interval == 0 || d < interval) {
+			interval = d
+		}
+	}
+	ticker := time.NewTicker(max(interval/4, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.done:
+			return
+		case now := <-ticker.C:
+			f.checkSilence(ctx, now)
+			f.checkAssets(ctx, now)
+		}
+	}
+}
+
+// checkSilence reports the feed degraded when a live connection has carried
+// no message for the silence timeout, and drops the connection once that
+// lasts reconnectAfter.
+func (f *PolymarketWSFeed) checkSilence(ctx context.Context, now time.Time) {
+	f.mu.Lock()
+	// Measure from the last message, or from when the connection came up
+	// when nothing has been received on it yet.
+	since := f.lastMsg
+	if f.subscribed.After(since) {
+		since = f.subscribed
+	}
+	if f.status.State.Live() && f.status.At.After(since) {
+		since = f.status.At
+	}
+	silent := now.Sub(since)
+	degrade := f.silence > 0 && f.status.State.Live() && silent > f.silence
+	var drop context.CancelCauseFunc
+	if f.reconnectAfter > 0 && f.drop != nil && silent > f.reconnectAfter {
+		drop, f.drop = f.drop, nil
+		f.forced++
+	}
+	f.mu.Unlock()
+
+	if degrade {
+		f.setState(ctx, domain.FeedStateDegraded, nil)
+	}
+	if drop != nil {
+		f.logger.Warn("polymarket ws silent, forcing reconnect", slog.Duration("silent", silent))
+		drop(errFeedStale)
+	}
+}
+
+// checkAssets resubscribes the assets that have gone quiet for assetStale on
+// a live connection. An asset is resubscribed at most once per assetStale; a
+// failed resubscribe drops the connection.
+func (f *PolymarketWSFeed) checkAssets(ctx context.Context, now time.Time) {
+	if f.assetStale <= 0 {
+		return
+	}
+	f.mu.Lock()
+	if !f.status.State.Live() || f.client == nil {
+		f.mu.Unlock()
+		return
+	}
+	var stale []string
+	for _, id := range f.assetIDs {
+		if now.Sub(f.assetSinceLocked(id, true)) > f.assetStale {
+			stale = append(stale, id)
+			f.resubbed[id] = now
+		}
+	}
+	client, drop := f.client, f.drop
+	f.resubscribes += len(stale)
+	f.mu.Unlock()
+	if len(stale) == 0 {
+		return
+	}
+
+	f.logger.Info("polymarket ws resubscribing stale assets", slog.Int("assets", len(stale)))
+	if err := f.resubscribe(ctx, client, stale); err != nil {
+		f.logger.Warn("polymarket ws resubscribe failed", slog.String("error", err.Error()))
+		if drop != nil {
+			drop(fmt.Errorf("feed: resubscribe stale assets: %w", err))
+		}
+	}
+}
+
+// assetSinceLocked returns when assetID last showed signs of life: its last
+// message, the current connection's subscribe or, with resubs, its last
+// watchdog resubscribe. f.mu must be held.
+func (f *PolymarketWSFeed) assetSinceLocked(assetID string, resubs bool) time.Time {
+	since := f.assetMsg[assetID]
+	if f.subscribed.After(since) {
+		since = f.subscribed
+	}
+	if t := f.resubbed[assetID]; resubs && t.After(since) {
+		since = t
+	}
+	return since
+}
+
+// resubscribe unsubscribes and subscribes assetIDs again on client.
+func (f *PolymarketWSFeed) resubscribe(ctx context.Context, client *polymarket.WSClient, assetIDs []string) error {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+	channels := f.channels()
+	if err := client.Unsubscribe(ctx, channels, assetIDs); err != nil {
+		return err
+	}
+	return client.Subscribe(ctx, channels, assetIDs)
+}
+
+// pollLoop refreshes books over REST every pollEvery while the feed is not
+// live.
+func (f *PolymarketWSFeed) pollLoop(ctx context.Context) {
+	ticker := time.NewTicker(f.pollEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.done:
+			return
+		case <-ticker.C:
+		}
+		state := f.Status().State
+		live := state == "" || state.Live()
+		f.mu.Lock()
+		changed := f.polling == live
+		f.polling = !live
+		f.mu.Unlock()
+		if changed {
+			if live {
+				f.logger.Info("polymarket ws live again, rest fallback stopped")
+			} else {
+				f.logger.Warn("polymarket ws not live, polling books over rest",
+					slog.String("state", string(state)),
+					slog.Duration("interval", f.pollEvery),
+				)
+			}
+		}
+		if !live {
+			f.pollBooks(ctx)
+		}
+	}
+}
+
+// pollBooks fetches every subscribed asset's book and passes it to the book
+// handler, stopping early once the feed is live again. A round is bounded
+// by the poll interval.
+func (f *PolymarketWSFeed) pollBooks(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, f.pollEvery)
+	defer cancel()
+
+	var (
+		wg     sync.WaitGroup
+		failMu sync.Mutex
+		failed int
+	)
+	ids := make(chan string)
+	for range min(pollConcurrency, len(f.assetIDs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				snap, err := f.books.GetOrderbook(ctx, id)
+				if err != nil {
+					failMu.Lock()
+					failed++
+					failMu.Unlock()
+					continue
+				}
+				// A live feed's books are newer than this snapshot.
+				if f.Status().State.Live() {
+					continue
+				}
+				if f.onBook != nil {
+					f.onBook(context.Background(), snap)
+				}
+			}
+		}()
+	}
+feed:
+	for _, id := range f.assetIDs {
+		select {
+		case ids <- id:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(ids)
+	wg.Wait()
+
+	f.mu.Lock()
+	f.lastPoll = time.Now()
+	f.mu.Unlock()
+	if failed > 0 {
+		f.logger.Debug("polymarket rest fallback: books not fetched", slog.Int("failed", failed))
+	}
+}
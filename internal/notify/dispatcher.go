@@ -0,0 +1,221 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// DefaultChannels are the signal bus channels the Dispatcher watches when none
+// are configured.
+var DefaultChannels = []string{"orders", "positions", "arb", "bond_resolved"}
+
+// dispatchQueueSize bounds the number of notifications waiting for the rate
+// limiter. Messages beyond this are dropped rather than blocking publishers.
+const dispatchQueueSize = 100
+
+type message struct {
+	event string
+	title string
+	body  string
+}
+
+// Dispatcher turns signal bus events into notifications. It subscribes to the
+// configured channels, formats each event it recognises, and delivers it via
+// the Notifier no faster than the configured rate. Delivery happens on a
+// single goroutine so a slow or failing sender never blocks the bus.
+type Dispatcher struct {
+	notifier *Notifier
+	bus      domain.SignalBus
+	channels []string
+	interval atomic.Int64 // minimum gap between deliveries; 0 = unlimited
+	running  atomic.Bool
+	queue    chan message
+	logger   *slog.Logger
+}
+
+// NewDispatcher creates a Dispatcher. ratePerMinute <= 0 disables rate
+// limiting; an empty channels list falls back to DefaultChannels.
+func NewDispatcher(notifier *Notifier, bus domain.SignalBus, channels []string, ratePerMinute int, logger *slog.Logger) *Dispatcher {
+	if len(channels) == 0 {
+		channels = DefaultChannels
+	}
+	d := &Dispatcher{
+		notifier: notifier,
+		bus:      bus,
+		channels: channels,
+		queue:    make(chan message, dispatchQueueSize),
+		logger:   logger.With(slog.String("component", "notify_dispatcher")),
+	}
+	d.SetRate(ratePerMinute)
+	return d
+}
+
+// SetRate changes the delivery rate limit; ratePerMinute <= 0 disables it.
+func (d *Dispatcher) SetRate(ratePerMinute int) {
+	var interval time.Duration
+	if ratePerMinute > 0 {
+		interval = time.Minute / time.Duration(ratePerMinute)
+	}
+	d.interval.Store(int64(interval))
+}
+
+// Running reports whether Run is delivering notifications. It stays false
+// when Run found no sender configured.
+func (d *Dispatcher) Running() bool {
+	return d.running.Load()
+}
+
+// Notify queues a notification for asynchronous, rate-limited delivery. It
+// applies the same event filter as Notifier.Notify and never blocks.
+func (d *Dispatcher) Notify(ctx context.Context, event, title, message string) error {
+	if !d.notifier.Enabled() || !d.notifier.Allowed(event) {
+		return nil
+	}
+	d.enqueue(ctx, event, title, message)
+	return nil
+}
+
+// Run subscribes to the configured bus channels and delivers notifications
+// until ctx is cancelled. It returns immediately when no sender is
+// configured. Call in a goroutine.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	if !d.notifier.Enabled() {
+		d.logger.InfoContext(ctx, "no notification senders configured; dispatcher disabled")
+		return nil
+	}
+	for _, ch := range d.channels {
+		sub, err := d.bus.Subscribe(ctx, ch)
+		if err != nil {
+			return fmt.Errorf("notify: subscribe %s: %w", ch, err)
+		}
+		go d.consume(ctx, ch, sub)
+	}
+
+	d.running.Store(true)
+	defer d.running.Store(false)
+	d.logger.InfoContext(ctx, "notify dispatcher started", slog.Any("channels", d.channels))
+	defer d.logger.InfoContext(ctx, "notify dispatcher stopped")
+
+	var last time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case m := <-d.queue:
+			interval := time.Duration(d.interval.Load())
+			if wait := interval - time.Since(last); interval > 0 && wait > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+			last = time.Now()
+			d.notifier.dispatch(ctx, m.title, m.body)
+		}
+	}
+}
+
+// consume formats events from one bus channel and queues those that pass the
+// notifier's event filter.
+func (d *Dispatcher) consume(ctx context.Context, channel string, sub <-chan []byte) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data, ok := <-sub:
+			if !ok {
+				return
+			}
+			event, title, body, ok := formatEvent(data)
+			if !ok || !d.notifier.Allowed(event) {
+				continue
+			}
+			d.enqueue(ctx, event, title, body)
+		}
+	}
+}
+
+func (d *Dispatcher) enqueue(ctx context.Context, event, title, body string) {
+	select {
+	case d.queue <- message{event: event, title: title, body: body}:
+	default:
+		d.logger.WarnContext(ctx, "notification queue full; dropping",
+			slog.String("event", event),
+			slog.String("title", title),
+		)
+	}
+}
+
+// formatEvent maps a bus payload to a notification event type, title and
+// body. ok is false for events that are not notified.
+func formatEvent(data []byte) (event, title, body string, ok bool) {
+	var ev map[string]any
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return "", "", "", false
+	}
+	str := func(k string) string { s, _ := ev[k].(string); return s }
+	num := func(k string) float64 { f, _ := ev[k].(float64); return f }
+
+	switch event = str("event"); event {
+	case "order_placed":
+		// Orders that match immediately on the CLOB are reported as fills.
+		if strings.EqualFold(str("status"), string(domain.OrderStatusMatched)) {
+			event = "order_filled"
+			title = "Order filled"
+		} else {
+			title = "Order placed"
+		}
+		body = fmt.Sprintf("%s %s\nOrder: %s", strings.ToUpper(str("side")), str("market"), str("order_id"))
+		if s := str("status"); s != "" {
+			body += "\nStatus: " + s
+		}
+	case "order_filled":
+		title = "Order filled"
+		body = fmt.Sprintf("%s %s\nPrice: %.4f  Size: %.2f  (%s)\nOrder: %s",
+			strings.ToUpper(str("side")), str("market"), num("price"), num("size"), str("status"), str("order_id"))
+	case "order_failed":
+		// Auth failures need an operator, not a retry: report them as errors.
+		if str("error_class") == string(domain.ErrorClassAuth) {
+			event = "error"
+			title = "Venue authentication failed"
+		} else {
+			title = "Order failed"
+		}
+		body = fmt.Sprintf("%s %s\nClass: %s\n%s\nOrder: %s",
+			strings.ToUpper(str("side")), str("market"), str("error_class"), str("error"), str("order_id"))
+	case "order_cancelled":
+		title = "Order cancelled"
+		body = "Order: " + str("order_id")
+	case "position_opened":
+		title = "Position opened"
+		body = fmt.Sprintf("%s %s\nEntry: %.4f  Size: %.2f\nPosition: %s",
+			strings.ToUpper(str("direction")), str("market"), num("entry_price"), num("size"), str("position_id"))
+	case "position_closed":
+		title = "Position closed"
+		body = fmt.Sprintf("%s\nExit: %.4f  PnL: %+.2f USD\nPosition: %s",
+			str("market"), num("exit_price"), num("realized_pnl"), str("position_id"))
+	case "arb_detected":
+		title = "Arbitrage detected"
+		body = fmt.Sprintf("Polymarket %s / Kalshi %s (%s)\nNet edge: %.1f bps  Expected PnL: %.2f USD",
+			str("poly_market"), str("kalshi_market"), str("direction"), num("net_edge_bps"), num("expected_pnl"))
+	case "bond_resolved":
+		title = "Bond resolved"
+		body = fmt.Sprintf("%s\nStatus: %s  PnL: %+.2f USD\nPosition: %s",
+			str("market_id"), str("status"), num("realized_pnl"), str("position_id"))
+	case "market_updated":
+		title = "Market metadata changed"
+		body = fmt.Sprintf("%s\nStatus: %s -> %s\nEnd date: %s -> %s",
+			str("question"), str("prev_status"), str("status"), str("prev_end_date"), str("end_date"))
+	default:
+		return "", "", "", false
+	}
+	return event, title, body, true
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Sender is the interface that each notification channel must implement.
@@ -22,31 +24,74 @@ type Sender interface {
 // of allowed event types; Notify only forwards messages whose event type is in
 // the allowed set, while NotifyAll bypasses the filter.
 type Notifier struct {
+	logger *slog.Logger
+
+	mu      sync.RWMutex
 	senders []Sender
 	events  map[string]bool // allowed event types
-	logger  *slog.Logger
+	retries int             // extra attempts per sender after a failure
+	backoff time.Duration   // delay before the first retry; doubles each attempt
 }
 
 // NewNotifier creates a Notifier that will deliver to the given senders. Only
 // events whose type appears in the events slice will be forwarded by Notify.
 // If events is empty, all event types are allowed.
 func NewNotifier(senders []Sender, events []string, logger *slog.Logger) *Notifier {
-	allowed := make(map[string]bool, len(events))
-	for _, e := range events {
-		allowed[strings.TrimSpace(e)] = true
-	}
 	return &Notifier{
 		senders: senders,
-		events:  allowed,
+		events:  allowedEvents(events),
 		logger:  logger.With(slog.String("component", "notifier")),
 	}
 }
 
+func allowedEvents(events []string) map[string]bool {
+	allowed := make(map[string]bool, len(events))
+	for _, e := range events {
+		allowed[strings.TrimSpace(e)] = true
+	}
+	return allowed
+}
+
+// WithRetry makes each sender retry a failed send up to retries more times,
+// waiting backoff before the first retry and doubling it after each attempt.
+func (n *Notifier) WithRetry(retries int, backoff time.Duration) *Notifier {
+	n.retries = max(0, retries)
+	n.backoff = backoff
+	return n
+}
+
+// Reconfigure replaces the senders, the event filter and the retry policy
+// of a running Notifier. Deliveries already in flight finish with the
+// previous senders.
+func (n *Notifier) Reconfigure(senders []Sender, events []string, retries int, backoff time.Duration) {
+	allowed := allowedEvents(events)
+	n.mu.Lock()
+	n.senders = senders
+	n.events = allowed
+	n.retries = max(0, retries)
+	n.backoff = backoff
+	n.mu.Unlock()
+}
+
+// Enabled reports whether any sender is configured.
+func (n *Notifier) Enabled() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.senders) > 0
+}
+
+// Allowed reports whether Notify would forward the given event type.
+func (n *Notifier) Allowed(event string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.events) == 0 || n.events[event]
+}
+
 // Notify sends a notification to all senders only if the event type is in the
 // allowed list. If no events were configured (empty list), all events pass.
 func (n *Notifier) Notify(ctx context.Context, event, title, message string) error {
 	// If specific events were configured, filter.
-	if len(n.events) > 0 && !n.events[event] {
+	if !n.Allowed(event) {
 		n.logger.DebugContext(ctx, "event filtered out",
 			slog.String("event", event),
 		)
@@ -65,13 +110,16 @@ func (n *Notifier) NotifyAll(ctx context.Context, title, message string) error {
 // individual senders are collected and returned as a combined error; a single
 // sender failure does not prevent delivery to the remaining senders.
 func (n *Notifier) dispatch(ctx context.Context, title, message string) error {
-	if len(n.senders) == 0 {
+	n.mu.RLock()
+	senders, retries, backoff := n.senders, n.retries, n.backoff
+	n.mu.RUnlock()
+	if len(senders) == 0 {
 		return nil
 	}
 
 	var errs []string
-	for _, s := range n.senders {
-		if err := s.Send(ctx, title, message); err != nil {
+	for _, s := range senders {
+		if err := n.send(ctx, s, retries, backoff, title, message); err != nil {
 			n.logger.ErrorContext(ctx, "sender failed",
 				slog.String("sender", s.Name()),
 				slog.String("error", err.Error()),
@@ -90,3 +138,29 @@ func (n *Notifier) dispatch(ctx context.Context, title, message string) error {
 	}
 	return nil
 }
+
+// send delivers to one sender, retrying up to retries times with
+// exponential backoff.
+func (n *Notifier) send(ctx context.Context, s Sender, retries int, backoff time.Duration, title, message string) error {
+	wait := backoff
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			n.logger.DebugContext(ctx, "retrying notification",
+				slog.String("sender", s.Name()),
+				slog.Int("attempt", attempt),
+				slog.String("error", err.Error()),
+			)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+		if err = s.Send(ctx, title, message); err == nil {
+			return nil
+		}
+	}
+	return err
+}
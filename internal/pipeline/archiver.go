@@ -153,13 +153,20 @@ func (a *Archiver) purgeOldS3Archives(ctx context.Context) error {
 	return nil
 }
 
-// parseArchiveKeyYearMonth extracts YYYY-MM from a key like "archive/trades/2025-01.jsonl". Returns year*100+month and true, or 0, false.
+// parseArchiveKeyYearMonth extracts YYYY-MM from a key like "archive/trades/2025-01.jsonl"
+// or, for Parquet archives, "archive/trades/dt=2024-12-30/2025-01.parquet" (the cutoff month
+// either way). Returns year*100+month and true, or 0, false.
 func parseArchiveKeyYearMonth(path string) (int, bool) {
 	// path: archive/trades/2025-01.jsonl
-	if !strings.HasSuffix(path, ".jsonl") {
+	var base string
+	switch {
+	case strings.HasSuffix(path, ".jsonl"):
+		base = strings.TrimSuffix(path, ".jsonl")
+	case strings.HasSuffix(path, ".parquet"):
+		base = strings.TrimSuffix(path, ".parquet")
+	default:
 		return 0, false
 	}
-	base := strings.TrimSuffix(path, ".jsonl")
 	parts := strings.Split(base, "/")
 	if len(parts) < 2 {
 		return 0, false
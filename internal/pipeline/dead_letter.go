@@ -0,0 +1,206 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// StageTradeProcessing is the dead-letter stage of fill batches whose
+// enrichment or ingestion failed.
+const StageTradeProcessing = "trade_processing"
+
+// DeadLetters dead-letters fill batches the trade processor fails on, so the
+// scrape can move past their window without losing them, and replays them.
+// Replays are idempotent: fills are deduplicated by fill ID across the
+// replayed batches, and the trade store skips trades it already holds.
+// A batch that has failed maxAttempts times is parked instead of requeued.
+type DeadLetters struct {
+	queue       domain.DeadLetterQueue
+	processor   *TradeProcessor
+	maxAttempts int // 0 never parks
+	logger      *slog.Logger
+
+	mu sync.Mutex // one replay at a time
+}
+
+// NewDeadLetters creates DeadLetters writing to queue and replaying through
+// processor, parking batches after maxAttempts failures (0 never parks).
+func NewDeadLetters(queue domain.DeadLetterQueue, processor *TradeProcessor, maxAttempts int, logger *slog.Logger) *DeadLetters {
+	return &DeadLetters{
+		queue:       queue,
+		processor:   processor,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}
+}
+
+// Record dead-letters fills that failed at stage with cause.
+func (d *DeadLetters) Record(ctx context.Context, stage string, fills []domain.RawFill, cause error) error {
+	batch := domain.DeadLetterBatch{
+		Stage:    stage,
+		Fills:    fills,
+		Attempts: 1,
+		FailedAt: time.Now().UTC(),
+	}
+	if cause != nil {
+		batch.Error = cause.Error()
+	}
+	id, err := d.queue.Push(ctx, batch)
+	if err != nil {
+		return fmt.Errorf("dead letters: record %d fills: %w", len(fills), err)
+	}
+	d.logger.Warn("fill batch dead-lettered",
+		slog.String("id", id),
+		slog.String("stage", stage),
+		slog.Int("fills", len(fills)),
+		slog.String("error", batch.Error),
+	)
+	return nil
+}
+
+// List returns up to limit dead-lettered batches, oldest first.
+func (d *DeadLetters) List(ctx context.Context, limit int) ([]domain.DeadLetterBatch, error) {
+	return d.queue.List(ctx, limit)
+}
+
+// ListParked returns up to limit parked batches, oldest first.
+func (d *DeadLetters) ListParked(ctx context.Context, limit int) ([]domain.DeadLetterBatch, error) {
+	return d.queue.ListParked(ctx, limit)
+}
+
+// Retry replays up to limit batches, oldest first. A batch that fails as a
+// whole is replayed fill by fill, so one bad fill does not hold back the
+// rest. A batch that processes is removed; the fills that fail again are
+// requeued at the back with the batch's attempts and error updated, so they
+// do not block the batches behind them, or parked once the batch has
+// reached the attempt limit.
+func (d *DeadLetters) Retry(ctx context.Context, limit int) (domain.DeadLetterRetry, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var res domain.DeadLetterRetry
+	batches, err := d.queue.List(ctx, limit)
+	if err != nil {
+		return res, fmt.Errorf("dead letters: list: %w", err)
+	}
+	res.Batches = len(batches)
+
+	seen := make(map[string]bool)
+	for _, batch := range batches {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		fills := make([]domain.RawFill, 0, len(batch.Fills))
+		for _, f := range batch.Fills {
+			key := f.ID
+			if key == "" {
+				key = f.TransactionHash
+			}
+			if key != "" && seen[key] {
+				res.Duplicates++
+				continue
+			}
+			seen[key] = true
+			fills = append(fills, f)
+		}
+
+		var failed []domain.RawFill
+		ingested, procErr := d.processor.ProcessFills(ctx, fills)
+		if procErr != nil && len(fills) > 1 {
+			ingested, failed, procErr = d.replayEach(ctx, fills)
+		} else if procErr != nil {
+			failed = fills
+		}
+		res.Fills += len(fills) - len(failed)
+		res.TradesIngested += ingested
+		if len(failed) == 0 {
+			res.Replayed++
+		} else {
+			batch.Fills = failed
+			batch.Attempts++
+			batch.Error = procErr.Error()
+			batch.FailedAt = time.Now().UTC()
+			if d.maxAttempts > 0 && batch.Attempts >= d.maxAttempts {
+				res.Parked++
+				if _, err := d.queue.Park(ctx, batch); err != nil {
+					return res, fmt.Errorf("dead letters: park %s: %w", batch.ID, err)
+				}
+				d.logger.Error("dead-lettered batch parked after repeated failures",
+					slog.String("id", batch.ID),
+					slog.Int("attempts", batch.Attempts),
+					slog.Int("fills", len(failed)),
+					slog.String("error", batch.Error),
+				)
+			} else {
+				res.Failed++
+				if _, err := d.queue.Push(ctx, batch); err != nil {
+					return res, fmt.Errorf("dead letters: requeue %s: %w", batch.ID, err)
+				}
+				d.logger.Warn("dead-lettered batch failed again",
+					slog.String("id", batch.ID),
+					slog.Int("attempts", batch.Attempts),
+					slog.Int("fills", len(failed)),
+					slog.String("error", batch.Error),
+				)
+			}
+		}
+		if err := d.queue.Delete(ctx, batch.ID); err != nil {
+			return res, fmt.Errorf("dead letters: %w", err)
+		}
+	}
+
+	if res.Batches > 0 {
+		d.logger.Info("dead-lettered batches retried",
+			slog.Int("batches", res.Batches),
+			slog.Int("replayed", res.Replayed),
+			slog.Int("failed", res.Failed),
+			slog.Int("parked", res.Parked),
+			slog.Int("trades_ingested", res.TradesIngested),
+		)
+	}
+	return res, nil
+}
+
+// replayEach processes fills one at a time and returns the trades stored,
+// the fills that failed and the last failure.
+func (d *DeadLetters) replayEach(ctx context.Context, fills []domain.RawFill) (int, []domain.RawFill, error) {
+	var (
+		ingested int
+		failed   []domain.RawFill
+		lastErr  error
+	)
+	for _, f := range fills {
+		n, err := d.processor.ProcessFills(ctx, []domain.RawFill{f})
+		if err != nil {
+			failed = append(failed, f)
+			lastErr = err
+			continue
+		}
+		ingested += n
+	}
+	return ingested, failed, lastErr
+}
+
+// RunLoop retries up to limit batches every interval until ctx is
+// cancelled.
+func (d *DeadLetters) RunLoop(ctx context.Context, interval time.Duration, limit int) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("dead letter retry loop stopped")
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := d.Retry(ctx, limit); err != nil {
+				d.logger.Error("dead letter retry failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
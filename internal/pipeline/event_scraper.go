@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
@@ -19,7 +20,9 @@ type EventFetcher interface {
 // EventScraper scrapes event data from the Gamma API and syncs condition
 // groups and their market links to the store. If marketStore is set, it
 // upserts each market before linking so condition_group_markets.market_id
-// satisfies the foreign key to markets(id).
+// satisfies the foreign key to markets(id); the market is stored with the
+// event's tags merged into its own, which the per-strategy category filters
+// match on.
 type EventScraper struct {
 	groups      domain.ConditionGroupStore
 	marketStore domain.MarketStore
@@ -72,12 +75,15 @@ func (s *EventScraper) Run(ctx context.Context) error {
 				continue
 			}
 
+			eventTags := polymarket.TagSlugs(events[i].Tags)
 			for _, mkt := range events[i].Markets {
 				if mkt.ID == "" {
 					continue
 				}
 				if s.marketStore != nil {
-					if err := s.marketStore.Upsert(ctx, mkt.ToDomainMarket()); err != nil {
+					dm := mkt.ToDomainMarket()
+					dm.Tags = mergeTags(eventTags, dm.Tags)
+					if err := s.marketStore.Upsert(ctx, dm); err != nil {
 						if !errors.Is(err, context.Canceled) {
 							s.logger.Error("event scraper: upsert market failed",
 								slog.String("market_id", mkt.ID),
@@ -117,6 +123,17 @@ func (s *EventScraper) Run(ctx context.Context) error {
 	return nil
 }
 
+// mergeTags returns the tags of a and then those of b not already in a.
+func mergeTags(a, b []string) []string {
+	out := append([]string(nil), a...)
+	for _, t := range b {
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
 // RunLoop runs the event scraper on a repeating interval until the context is
 // cancelled.
 func (s *EventScraper) RunLoop(ctx context.Context, interval time.Duration) error {
@@ -0,0 +1,355 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/alanyoungcy/polymarketbot/internal/blob/parquet"
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/retry"
+)
+
+// FillPager pages through historical order fills by entity ID
+// (goldsky.Client).
+type FillPager interface {
+	FetchOrderFillsPage(ctx context.Context, from, to time.Time, afterID string, first int) ([]domain.RawFill, error)
+}
+
+// TradeCopier bulk-loads trades, skipping duplicates (postgres.TradeStore).
+type TradeCopier interface {
+	CopyTrades(ctx context.Context, trades []domain.Trade) (int64, error)
+}
+
+// BackfillConfig describes one historical backfill run.
+type BackfillConfig struct {
+	From time.Time
+	To   time.Time
+	// Chunk is the width of the time slice one worker pages through at a
+	// time. Smaller chunks spread a busy period over more workers.
+	Chunk   time.Duration
+	Workers int
+	// PageSize is the number of fills per GraphQL query (subgraph max 1000).
+	PageSize int
+	// RequestsPerSecond caps queries across all workers; 0 disables the cap.
+	RequestsPerSecond float64
+}
+
+// BackfillResult summarises a backfill run.
+type BackfillResult struct {
+	Chunks   int64
+	Pages    int64
+	Fills    int64
+	Trades   int64 // fills resolved to a known market
+	Inserted int64 // trades not already stored
+}
+
+// GoldskyBackfill loads historical order fills over a fixed time range. The
+// range is split into chunks that parallel workers page through; each page is
+// written to object storage as raw JSONL (or Parquet, see WithParquet) and
+// COPYed into the trades table.
+// Re-running an overlapping range is safe: stored trades are skipped.
+type GoldskyBackfill struct {
+	pager     FillPager
+	writer    domain.BlobWriter
+	processor *TradeProcessor
+	copier    TradeCopier
+	cfg       BackfillConfig
+	retrier   *retry.Retrier
+	parquet   bool
+	logger    *slog.Logger
+}
+
+// NewGoldskyBackfill creates a GoldskyBackfill. writer may be nil, in which
+// case raw pages are not kept.
+func NewGoldskyBackfill(pager FillPager, writer domain.BlobWriter, processor *TradeProcessor, copier TradeCopier, cfg BackfillConfig, logger *slog.Logger) *GoldskyBackfill {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.PageSize <= 0 || cfg.PageSize > 1000 {
+		cfg.PageSize = 1000
+	}
+	if cfg.Chunk <= 0 {
+		cfg.Chunk = 24 * time.Hour
+	}
+	return &GoldskyBackfill{
+		pager:     pager,
+		writer:    writer,
+		processor: processor,
+		copier:    copier,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "goldsky_backfill")),
+	}
+}
+
+// WithRetry retries failed page fetches per r instead of the default
+// Goldsky policy.
+func (b *GoldskyBackfill) WithRetry(r *retry.Retrier) *GoldskyBackfill {
+	b.retrier = r
+	return b
+}
+
+// WithParquet writes raw pages as Parquet to the data lake's fills table,
+// split by fill date (raw/fills/dt=2024-03-01/20240301T000000Z-0003.parquet),
+// instead of JSONL.
+func (b *GoldskyBackfill) WithParquet() *GoldskyBackfill {
+	b.parquet = true
+	return b
+}
+
+// backfillChunk is one [from, to) slice of the backfill range.
+type backfillChunk struct {
+	from, to time.Time
+}
+
+// Run backfills the configured range and returns what was loaded. The first
+// chunk that fails stops the run; chunks already completed stay stored.
+func (b *GoldskyBackfill) Run(ctx context.Context) (BackfillResult, error) {
+	var res BackfillResult
+	if !b.cfg.From.Before(b.cfg.To) {
+		return res, fmt.Errorf("backfill: from %s must be before to %s", b.cfg.From, b.cfg.To)
+	}
+
+	var chunks []backfillChunk
+	for from := b.cfg.From; from.Before(b.cfg.To); from = from.Add(b.cfg.Chunk) {
+		to := from.Add(b.cfg.Chunk)
+		if to.After(b.cfg.To) {
+			to = b.cfg.To
+		}
+		chunks = append(chunks, backfillChunk{from: from, to: to})
+	}
+
+	b.logger.Info("goldsky backfill started",
+		slog.Time("from", b.cfg.From),
+		slog.Time("to", b.cfg.To),
+		slog.Int("chunks", len(chunks)),
+		slog.Int("workers", b.cfg.Workers),
+	)
+	started := time.Now()
+
+	pacer := newRequestPacer(b.cfg.RequestsPerSecond)
+	queue := make(chan backfillChunk)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		defer close(queue)
+		for _, c := range chunks {
+			select {
+			case <-gctx.Done():
+				return nil
+			case queue <- c:
+			}
+		}
+		return nil
+	})
+	for i := 0; i < b.cfg.Workers; i++ {
+		g.Go(func() error {
+			for c := range queue {
+				if err := b.runChunk(gctx, c, pacer, &res); err != nil {
+					return err
+				}
+				atomic.AddInt64(&res.Chunks, 1)
+			}
+			return nil
+		})
+	}
+	err := g.Wait()
+
+	b.logger.Info("goldsky backfill finished",
+		slog.Int64("chunks_done", res.Chunks),
+		slog.Int64("pages", res.Pages),
+		slog.Int64("fills", res.Fills),
+		slog.Int64("trades", res.Trades),
+		slog.Int64("inserted", res.Inserted),
+		slog.Duration("elapsed", time.Since(started)),
+	)
+	return res, err
+}
+
+// runChunk pages through one chunk until a short page.
+func (b *GoldskyBackfill) runChunk(ctx context.Context, c backfillChunk, pacer *requestPacer, res *BackfillResult) error {
+	var (
+		after string
+		page  int
+		fills int64
+	)
+	for {
+		if err := pacer.wait(ctx); err != nil {
+			return err
+		}
+		batch, err := fetchWithRetry(ctx, b.retrier, b.logger, func() ([]domain.RawFill, error) {
+			return b.pager.FetchOrderFillsPage(ctx, c.from, c.to, after, b.cfg.PageSize)
+		})
+		if err != nil {
+			return fmt.Errorf("backfill chunk %s: page %d: %w", c.from.Format(time.RFC3339), page, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+		if err := b.storePage(ctx, c, page, batch, res); err != nil {
+			return err
+		}
+		atomic.AddInt64(&res.Pages, 1)
+		fills += int64(len(batch))
+		page++
+		if len(batch) < b.cfg.PageSize {
+			break
+		}
+		after = batch[len(batch)-1].ID
+	}
+	b.logger.Info("backfill chunk done",
+		slog.Time("from", c.from),
+		slog.Time("to", c.to),
+		slog.Int("pages", page),
+		slog.Int64("fills", fills),
+	)
+	return nil
+}
+
+// storePage uploads one page as raw JSONL or Parquet and bulk-loads its
+// trades.
+func (b *GoldskyBackfill) storePage(ctx context.Context, c backfillChunk, page int, fills []domain.RawFill, res *BackfillResult) error {
+	atomic.AddInt64(&res.Fills, int64(len(fills)))
+
+	if b.writer != nil && b.parquet {
+		name := fmt.Sprintf("%s-%04d", c.from.UTC().Format("20060102T150405Z"), page)
+		if err := putFillsParquet(ctx, b.writer, fills, name); err != nil {
+			return fmt.Errorf("backfill: %w", err)
+		}
+	} else if b.writer != nil {
+		data, err := fillsToJSONL(fills)
+		if err != nil {
+			return fmt.Errorf("backfill: encode page: %w", err)
+		}
+		path := backfillPath(c.from, page)
+		if err := b.writer.Put(ctx, path, bytes.NewReader(data), "application/x-ndjson"); err != nil {
+			return fmt.Errorf("backfill: upload %s: %w", path, err)
+		}
+	}
+
+	trades, err := b.processor.Enrich(ctx, fills)
+	if err != nil {
+		return err
+	}
+	if len(trades) == 0 {
+		return nil
+	}
+	inserted, err := b.copier.CopyTrades(ctx, trades)
+	if err != nil {
+		return fmt.Errorf("backfill: copy %d trades: %w", len(trades), err)
+	}
+	atomic.AddInt64(&res.Trades, int64(len(trades)))
+	atomic.AddInt64(&res.Inserted, inserted)
+	return nil
+}
+
+// backfillPath returns the object path of a raw backfill page, partitioned by
+// the chunk start:
+//
+//	goldsky/backfill/2024-03-01/20240301T000000Z-0003.jsonl
+func backfillPath(chunk time.Time, page int) string {
+	chunk = chunk.UTC()
+	return fmt.Sprintf("goldsky/backfill/%s/%s-%04d.jsonl",
+		chunk.Format("2006-01-02"), chunk.Format("20060102T150405Z"), page)
+}
+
+// putFillsParquet uploads fills to the lake's fills table as one Parquet
+// file per fill date, raw/fills/dt=YYYY-MM-DD/<name>.parquet.
+func putFillsParquet(ctx context.Context, writer domain.BlobWriter, fills []domain.RawFill, name string) error {
+	for _, part := range parquet.Fills.Partition(fills, false) {
+		data, err := parquet.Fills.Marshal(part.Records)
+		if err != nil {
+			return fmt.Errorf("encode fills: %w", err)
+		}
+		path := "raw/fills/" + part.Dir + "/" + name + parquet.Ext
+		if err := writer.Put(ctx, path, bytes.NewReader(data), parquet.ContentType); err != nil {
+			return fmt.Errorf("upload %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// rawFillRecord is the JSONL form of a RawFill, using the subgraph's field
+// names.
+type rawFillRecord struct {
+	ID                string `json:"id"`
+	TransactionHash   string `json:"transactionHash"`
+	Timestamp         int64  `json:"timestamp"`
+	Maker             string `json:"maker"`
+	MakerAssetID      string `json:"makerAssetId"`
+	MakerAmountFilled int64  `json:"makerAmountFilled"`
+	Taker             string `json:"taker"`
+	TakerAssetID      string `json:"takerAssetId"`
+	TakerAmountFilled int64  `json:"takerAmountFilled"`
+}
+
+// fillsToJSONL encodes fills one JSON object per line.
+func fillsToJSONL(fills []domain.RawFill) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i, f := range fills {
+		if err := enc.Encode(rawFillRecord{
+			ID:                f.ID,
+			TransactionHash:   f.TransactionHash,
+			Timestamp:         f.Timestamp,
+			Maker:             f.Maker,
+			MakerAssetID:      f.MakerAssetID,
+			MakerAmountFilled: f.MakerAmountFilled,
+			Taker:             f.Taker,
+			TakerAssetID:      f.TakerAssetID,
+			TakerAmountFilled: f.TakerAmountFilled,
+		}); err != nil {
+			return nil, fmt.Errorf("jsonl encode fill %d: %w", i, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// requestPacer spaces requests shared by several goroutines at least interval
+// apart. A zero interval never waits.
+type requestPacer struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRequestPacer(perSecond float64) *requestPacer {
+	p := &requestPacer{}
+	if perSecond > 0 {
+		p.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return p
+}
+
+// wait blocks until the caller's turn or ctx is done.
+func (p *requestPacer) wait(ctx context.Context) error {
+	if p.interval <= 0 {
+		return ctx.Err()
+	}
+	p.mu.Lock()
+	now := time.Now()
+	if p.next.Before(now) {
+		p.next = now
+	}
+	at := p.next
+	p.next = p.next.Add(p.interval)
+	p.mu.Unlock()
+
+	if d := time.Until(at); d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+	return nil
+}
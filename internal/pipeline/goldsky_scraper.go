@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/retry"
 )
 
 // FillFetcher retrieves raw on-chain order-filled events.
@@ -22,6 +23,8 @@ type FillFetcher interface {
 type GoldskyScraper struct {
 	fetcher FillFetcher
 	writer  domain.BlobWriter
+	retrier *retry.Retrier
+	parquet bool
 	logger  *slog.Logger
 }
 
@@ -34,13 +37,28 @@ func NewGoldskyScraper(fetcher FillFetcher, writer domain.BlobWriter, logger *sl
 	}
 }
 
+// WithRetry retries failed fetches per r instead of the default Goldsky
+// policy, counting the failures in r's error stats.
+func (s *GoldskyScraper) WithRetry(r *retry.Retrier) *GoldskyScraper {
+	s.retrier = r
+	return s
+}
+
+// WithParquet uploads each run's fills as Parquet to the data lake's fills
+// table, split by fill date (raw/fills/dt=2025-01-02/20250102T150405Z.parquet),
+// instead of the daily CSV.
+func (s *GoldskyScraper) WithParquet() *GoldskyScraper {
+	s.parquet = true
+	return s
+}
+
 // Run executes a single scrape run. It fetches fills since the given timestamp,
 // converts them to CSV, uploads the CSV to S3, and returns the fills for further
 // processing.
 func (s *GoldskyScraper) Run(ctx context.Context, since time.Time) ([]domain.RawFill, error) {
 	const fetchLimit = 1000
 
-	fills, err := s.fetcher.FetchOrderFills(ctx, since, fetchLimit)
+	fills, err := s.fetch(ctx, since, fetchLimit)
 	if err != nil {
 		return nil, fmt.Errorf("fetching order fills since %v: %w", since, err)
 	}
@@ -50,6 +68,17 @@ func (s *GoldskyScraper) Run(ctx context.Context, since time.Time) ([]domain.Raw
 		return fills, nil
 	}
 
+	if s.parquet {
+		if err := putFillsParquet(ctx, s.writer, fills, time.Now().UTC().Format("20060102T150405Z")); err != nil {
+			return nil, fmt.Errorf("uploading fills: %w", err)
+		}
+		s.logger.Info("goldsky scrape complete",
+			slog.Int("fills_count", len(fills)),
+			slog.String("s3_path", "raw/fills/"),
+		)
+		return fills, nil
+	}
+
 	csvData, err := fillsToCSV(fills)
 	if err != nil {
 		return nil, fmt.Errorf("converting fills to CSV: %w", err)
@@ -68,6 +97,27 @@ func (s *GoldskyScraper) Run(ctx context.Context, since time.Time) ([]domain.Raw
 	return fills, nil
 }
 
+// fetch calls FetchOrderFills with fetchWithRetry.
+func (s *GoldskyScraper) fetch(ctx context.Context, since time.Time, first int) ([]domain.RawFill, error) {
+	return fetchWithRetry(ctx, s.retrier, s.logger, func() ([]domain.RawFill, error) {
+		return s.fetcher.FetchOrderFills(ctx, since, first)
+	})
+}
+
+// goldskyRetryPolicy applies to Goldsky fetches of a stage built without
+// WithRetry: 4 attempts, 1s doubling backoff, 5x after a rate limit.
+var goldskyRetryPolicy = retry.Policy{MaxAttempts: 4, BaseDelay: time.Second, RateLimitFactor: 5}
+
+// fetchWithRetry calls fetch through r, or goldskyRetryPolicy when r is nil:
+// rate-limited and transient failures are retried with backoff, others (bad
+// API key, malformed query) are returned immediately.
+func fetchWithRetry[T any](ctx context.Context, r *retry.Retrier, logger *slog.Logger, fetch func() ([]T, error)) ([]T, error) {
+	if r == nil {
+		r = retry.New(goldskyRetryPolicy, logger)
+	}
+	return retry.Value(ctx, r, "goldsky", fetch)
+}
+
 // RunLoop runs the Goldsky scraper on a repeating interval until the context is
 // cancelled. It tracks the last processed timestamp so each iteration only
 // fetches new fills.
@@ -2,82 +2,212 @@ package pipeline
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polymarket"
 )
 
+// incrementalOverlap is how far before the previous run's newest update an
+// incremental run keeps paging, to catch markets updated while it ran.
+const incrementalOverlap = 2 * time.Minute
+
 // MarketSyncer persists a batch of markets to the store.
 type MarketSyncer interface {
 	SyncMarkets(ctx context.Context, markets []domain.Market) error
 }
 
-// MarketFetcher retrieves markets from an external API.
+// MarketFetcher retrieves pages of markets from the Gamma API
+// (polymarket.GammaClient).
 type MarketFetcher interface {
-	GetMarkets(ctx context.Context, limit, offset int) ([]domain.Market, error)
+	GetMarketsPage(ctx context.Context, q polymarket.MarketsQuery) (polymarket.MarketsPage, error)
+}
+
+// pageValidator holds the cache validators of a fetched page and how many
+// markets it held, for answering a later 304.
+type pageValidator struct {
+	etag         string
+	lastModified string
+	count        int
 }
 
 // MarketScraper scrapes market data from external APIs and syncs to the store.
+// A full run pages through every market; incremental runs in between page
+// through markets by last update, newest first, and stop at the previous
+// run's newest. Pages are requested conditionally with the validators of
+// their previous response, and only markets that changed since they were
+// last synced are upserted.
 type MarketScraper struct {
 	marketSvc MarketSyncer
 	fetcher   MarketFetcher
 	logger    *slog.Logger
+
+	pageSize         int
+	fullSyncInterval time.Duration
+
+	// mu serializes runs: the interval loop and on-demand pipeline runs.
+	mu           sync.Mutex
+	watermark    time.Time                // newest UpdatedAt seen by the last run
+	lastFull     time.Time                // when the last full run completed
+	fingerprints map[string]uint64        // market ID -> fingerprint of the version last synced
+	validators   map[string]pageValidator // order:offset -> validators of the page
 }
 
-// NewMarketScraper creates a new MarketScraper.
+// NewMarketScraper creates a new MarketScraper. Every run is full, 100
+// markets a page, until WithSync is called.
 func NewMarketScraper(syncer MarketSyncer, fetcher MarketFetcher, logger *slog.Logger) *MarketScraper {
 	return &MarketScraper{
-		marketSvc: syncer,
-		fetcher:   fetcher,
-		logger:    logger,
+		marketSvc:    syncer,
+		fetcher:      fetcher,
+		logger:       logger,
+		pageSize:     100,
+		fingerprints: make(map[string]uint64),
+		validators:   make(map[string]pageValidator),
+	}
+}
+
+// WithSync sets the page size and how often a run pages through every
+// market; runs in between are incremental. fullSyncInterval 0 makes every
+// run full.
+func (s *MarketScraper) WithSync(pageSize int, fullSyncInterval time.Duration) *MarketScraper {
+	if pageSize > 0 {
+		s.pageSize = pageSize
 	}
+	s.fullSyncInterval = fullSyncInterval
+	return s
 }
 
-// Run executes a single scrape run that paginates through all markets and syncs
-// each batch to the store.
+// Run executes a single scrape run, full or incremental, and syncs each
+// page's changed markets to the store.
 func (s *MarketScraper) Run(ctx context.Context) error {
-	const pageSize = 100
-	offset := 0
-	totalSynced := 0
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	for {
+	full := s.watermark.IsZero() || s.fullSyncInterval <= 0 || time.Since(s.lastFull) >= s.fullSyncInterval
+	order := ""
+	if !full {
+		order = polymarket.GammaOrderUpdatedAt
+	}
+	since := s.watermark.Add(-incrementalOverlap)
+	newest := s.watermark
+	var fetched, totalSynced, notModified int
+
+	for offset := 0; ; offset += s.pageSize {
 		if err := ctx.Err(); err != nil {
 			return fmt.Errorf("market scraper context cancelled: %w", err)
 		}
 
-		markets, err := s.fetcher.GetMarkets(ctx, pageSize, offset)
+		key := fmt.Sprintf("%s:%d", order, offset)
+		prev := s.validators[key]
+		page, err := s.fetcher.GetMarketsPage(ctx, polymarket.MarketsQuery{
+			Limit:        s.pageSize,
+			Offset:       offset,
+			Order:        order,
+			ETag:         prev.etag,
+			LastModified: prev.lastModified,
+		})
 		if err != nil {
 			return fmt.Errorf("fetching markets at offset %d: %w", offset, err)
 		}
 
+		// Unchanged since the previous run: an incremental run has
+		// nothing newer past this point.
+		if page.NotModified {
+			notModified++
+			if !full || prev.count < s.pageSize {
+				break
+			}
+			continue
+		}
+		markets := page.Markets
+		if page.ETag != "" || page.LastModified != "" {
+			s.validators[key] = pageValidator{etag: page.ETag, lastModified: page.LastModified, count: len(markets)}
+		}
+
 		if len(markets) == 0 {
 			break
 		}
+		fetched += len(markets)
 
-		if err := s.marketSvc.SyncMarkets(ctx, markets); err != nil {
-			return fmt.Errorf("syncing %d markets at offset %d: %w", len(markets), offset, err)
+		changed, prints := s.changedMarkets(markets)
+		if len(changed) > 0 {
+			if err := s.marketSvc.SyncMarkets(ctx, changed); err != nil {
+				return fmt.Errorf("syncing %d markets at offset %d: %w", len(changed), offset, err)
+			}
+			for id, fp := range prints {
+				s.fingerprints[id] = fp
+			}
+			totalSynced += len(changed)
+			s.logger.Info("synced market batch",
+				slog.Int("batch_size", len(changed)),
+				slog.Int("fetched", len(markets)),
+				slog.Int("total_synced", totalSynced),
+				slog.Int("offset", offset),
+			)
+		}
+		for _, m := range markets {
+			if m.UpdatedAt.After(newest) {
+				newest = m.UpdatedAt
+			}
 		}
 
-		totalSynced += len(markets)
-		s.logger.Info("synced market batch",
-			slog.Int("batch_size", len(markets)),
-			slog.Int("total_synced", totalSynced),
-			slog.Int("offset", offset),
-		)
-
-		if len(markets) < pageSize {
+		if len(markets) < s.pageSize {
+			break
+		}
+		// Sorted newest first: the rest was synced by an earlier run.
+		if last := markets[len(markets)-1].UpdatedAt; !full && !last.IsZero() && last.Before(since) {
 			break
 		}
-
-		offset += pageSize
 	}
 
-	s.logger.Info("market scrape complete", slog.Int("total_synced", totalSynced))
+	s.watermark = newest
+	if full {
+		s.lastFull = time.Now()
+	}
+	s.logger.Info("market scrape complete",
+		slog.Bool("full", full),
+		slog.Int("fetched", fetched),
+		slog.Int("total_synced", totalSynced),
+		slog.Int("pages_not_modified", notModified),
+	)
 	return nil
 }
 
+// changedMarkets returns the markets whose content differs from the version
+// last synced, and their new fingerprints.
+func (s *MarketScraper) changedMarkets(markets []domain.Market) ([]domain.Market, map[string]uint64) {
+	changed := make([]domain.Market, 0, len(markets))
+	prints := make(map[string]uint64, len(markets))
+	for _, m := range markets {
+		fp, ok := marketFingerprint(m)
+		if ok && s.fingerprints[m.ID] == fp {
+			continue
+		}
+		changed = append(changed, m)
+		if ok {
+			prints[m.ID] = fp
+		}
+	}
+	return changed, prints
+}
+
+// marketFingerprint hashes every field of m; ok is false when m cannot be
+// encoded, in which case it is always synced.
+func marketFingerprint(m domain.Market) (uint64, bool) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return 0, false
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return h.Sum64(), true
+}
+
 // RunLoop runs the market scraper on a repeating interval until the context is
 // cancelled.
 func (s *MarketScraper) RunLoop(ctx context.Context, interval time.Duration) error {
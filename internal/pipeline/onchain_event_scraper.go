@@ -0,0 +1,203 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/retry"
+)
+
+// CTFEventPager pages through CTF splits, merges and redemptions by entity
+// ID (goldsky.Client).
+type CTFEventPager interface {
+	FetchCTFEventsPage(ctx context.Context, kind domain.OnchainEventKind, wallets []string, since time.Time, afterID string, first int) ([]domain.OnchainEvent, error)
+}
+
+// OnchainEventConfig configures an OnchainEventScraper.
+type OnchainEventConfig struct {
+	// Wallets restricts the scrape to these stakeholders; empty scrapes
+	// every wallet's events.
+	Wallets []string
+	// Lookback is how far back the first scrape of a kind with no stored
+	// events starts. Defaults to 30 days.
+	Lookback time.Duration
+	// PageSize is the number of events per GraphQL query (subgraph max 1000).
+	PageSize int
+	// MaxPages caps the pages read per kind and run, so a long backlog is
+	// caught up over several runs. Defaults to 50.
+	MaxPages int
+}
+
+// OnchainEventScraper loads CTF splits, merges and redemptions from the
+// Goldsky activity subgraph into the onchain_events table. Each run resumes
+// every kind from its newest stored event and pages through by entity ID,
+// retrying rate-limited and transient failures; events seen again at the
+// boundary timestamp are skipped by the store.
+type OnchainEventScraper struct {
+	pager   CTFEventPager
+	store   domain.OnchainEventStore
+	cfg     OnchainEventConfig
+	retrier *retry.Retrier
+	logger  *slog.Logger
+
+	// mu keeps the interval loop and queued runs from scraping at once.
+	mu      sync.Mutex
+	cursors map[domain.OnchainEventKind]*ctfCursor
+}
+
+// ctfCursor is where the next scrape of one event kind resumes: events at or
+// after since with an ID greater than after. latest is the newest event seen
+// in the current range, which becomes since once the range is exhausted (ID
+// order does not follow time, so the range must be read to the end first).
+type ctfCursor struct {
+	since  time.Time
+	after  string
+	latest time.Time
+}
+
+// NewOnchainEventScraper creates an OnchainEventScraper.
+func NewOnchainEventScraper(pager CTFEventPager, store domain.OnchainEventStore, cfg OnchainEventConfig, logger *slog.Logger) *OnchainEventScraper {
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 30 * 24 * time.Hour
+	}
+	if cfg.PageSize <= 0 || cfg.PageSize > 1000 {
+		cfg.PageSize = 1000
+	}
+	if cfg.MaxPages <= 0 {
+		cfg.MaxPages = 50
+	}
+	return &OnchainEventScraper{
+		pager:   pager,
+		store:   store,
+		cfg:     cfg,
+		logger:  logger.With(slog.String("component", "onchain_events")),
+		cursors: make(map[domain.OnchainEventKind]*ctfCursor),
+	}
+}
+
+// WithRetry retries failed page fetches per r instead of the default
+// Goldsky policy.
+func (s *OnchainEventScraper) WithRetry(r *retry.Retrier) *OnchainEventScraper {
+	s.retrier = r
+	return s
+}
+
+// Run scrapes every event kind once and returns the number of events
+// inserted. A kind that fails does not stop the others; the first error is
+// returned.
+func (s *OnchainEventScraper) Run(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		inserted int64
+		firstErr error
+	)
+	for _, kind := range domain.OnchainEventKinds {
+		n, err := s.scrapeKind(ctx, kind)
+		inserted += n
+		if err != nil {
+			s.logger.ErrorContext(ctx, "onchain event scrape failed",
+				slog.String("kind", string(kind)),
+				slog.String("error", err.Error()),
+			)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return inserted, firstErr
+}
+
+// scrapeKind pages through kind's events from its cursor, storing each page,
+// and moves the cursor on.
+func (s *OnchainEventScraper) scrapeKind(ctx context.Context, kind domain.OnchainEventKind) (int64, error) {
+	cur, err := s.cursor(ctx, kind)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		inserted  int64
+		fetched   int
+		exhausted bool
+	)
+	for page := 0; page < s.cfg.MaxPages; page++ {
+		events, err := fetchWithRetry(ctx, s.retrier, s.logger, func() ([]domain.OnchainEvent, error) {
+			return s.pager.FetchCTFEventsPage(ctx, kind, s.cfg.Wallets, cur.since, cur.after, s.cfg.PageSize)
+		})
+		if err != nil {
+			return inserted, fmt.Errorf("fetch %s events after %q: %w", kind, cur.after, err)
+		}
+
+		n, err := s.store.InsertBatch(ctx, events)
+		if err != nil {
+			return inserted, fmt.Errorf("store %s events: %w", kind, err)
+		}
+		inserted += n
+		fetched += len(events)
+		for _, e := range events {
+			if e.Timestamp.After(cur.latest) {
+				cur.latest = e.Timestamp
+			}
+		}
+		if len(events) > 0 {
+			cur.after = events[len(events)-1].ID
+		}
+		if len(events) < s.cfg.PageSize {
+			exhausted = true
+			break
+		}
+	}
+	if exhausted {
+		cur.since, cur.after = cur.latest, ""
+	}
+
+	if fetched > 0 {
+		s.logger.InfoContext(ctx, "onchain events scraped",
+			slog.String("kind", string(kind)),
+			slog.Int("fetched", fetched),
+			slog.Int64("inserted", inserted),
+			slog.Bool("caught_up", exhausted),
+		)
+	}
+	return inserted, nil
+}
+
+// cursor returns kind's cursor, starting at the newest stored event, or the
+// lookback when none is stored.
+func (s *OnchainEventScraper) cursor(ctx context.Context, kind domain.OnchainEventKind) (*ctfCursor, error) {
+	if cur, ok := s.cursors[kind]; ok {
+		return cur, nil
+	}
+	ts, err := s.store.LastTimestamp(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("last %s event: %w", kind, err)
+	}
+	if ts.IsZero() {
+		ts = time.Now().UTC().Add(-s.cfg.Lookback)
+	}
+	cur := &ctfCursor{since: ts, latest: ts}
+	s.cursors[kind] = cur
+	return cur, nil
+}
+
+// RunLoop runs the scraper immediately and then on every interval until ctx
+// is cancelled.
+func (s *OnchainEventScraper) RunLoop(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = s.Run(ctx)
+		select {
+		case <-ctx.Done():
+			s.logger.Info("onchain event scraper loop stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -15,8 +15,9 @@ import (
 const usdcAssetID = "0"
 
 // TradeIngester persists enriched trades and provides timestamp tracking.
+// IngestTrades skips trades already stored and returns how many it stored.
 type TradeIngester interface {
-	IngestTrades(ctx context.Context, trades []domain.Trade) error
+	IngestTrades(ctx context.Context, trades []domain.Trade) (int, error)
 	GetLastTimestamp(ctx context.Context) (time.Time, error)
 }
 
@@ -45,17 +46,45 @@ func NewTradeProcessor(tradeSvc TradeIngester, marketSvc MarketLookup, logger *s
 // them. For each fill it looks up the associated market by token ID and enriches
 // the trade with market metadata and direction information.
 //
-// It returns the number of trades successfully processed.
+// It returns the number of trades stored; trades already stored, from an
+// overlapping scrape window or a replay, are skipped.
 func (p *TradeProcessor) ProcessFills(ctx context.Context, fills []domain.RawFill) (int, error) {
 	if len(fills) == 0 {
 		return 0, nil
 	}
 
+	trades, err := p.Enrich(ctx, fills)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(trades) == 0 {
+		p.logger.Info("no trades to ingest after processing fills")
+		return 0, nil
+	}
+
+	ingested, err := p.tradeSvc.IngestTrades(ctx, trades)
+	if err != nil {
+		return 0, fmt.Errorf("ingesting %d trades: %w", len(trades), err)
+	}
+
+	p.logger.Info("trades processed and ingested",
+		slog.Int("fills_input", len(fills)),
+		slog.Int("trades_ingested", ingested),
+		slog.Int("duplicates_skipped", len(trades)-ingested),
+	)
+
+	return ingested, nil
+}
+
+// Enrich converts raw fills into trades without storing them. Fills whose
+// market cannot be resolved are logged and skipped.
+func (p *TradeProcessor) Enrich(ctx context.Context, fills []domain.RawFill) ([]domain.Trade, error) {
 	trades := make([]domain.Trade, 0, len(fills))
 
 	for i, fill := range fills {
 		if err := ctx.Err(); err != nil {
-			return 0, fmt.Errorf("trade processor context cancelled at fill %d: %w", i, err)
+			return nil, fmt.Errorf("trade processor context cancelled at fill %d: %w", i, err)
 		}
 
 		// Determine which asset ID is the token (non-USDC) to look up the market.
@@ -74,10 +103,10 @@ func (p *TradeProcessor) ProcessFills(ctx context.Context, fills []domain.RawFil
 			continue
 		}
 
-		// Determine token side (token1 or token2).
+		// Determine token side (token1, token2, ... by outcome position).
 		tokenSide := "token1"
-		if tokenID == market.TokenIDs[1] {
-			tokenSide = "token2"
+		if i := market.OutcomeIndex(tokenID); i > 0 {
+			tokenSide = fmt.Sprintf("token%d", i+1)
 		}
 
 		// Determine maker/taker directions based on which side holds USDC.
@@ -106,9 +135,17 @@ func (p *TradeProcessor) ProcessFills(ctx context.Context, fills []domain.RawFil
 			price = usdAmount / tokenAmount
 		}
 
+		// Each fill is keyed by its entity ID; several fills can share a
+		// transaction.
+		tradeID := fill.ID
+		if tradeID == "" {
+			tradeID = fill.TransactionHash
+		}
+
 		trade := domain.Trade{
 			Source:         "goldsky",
-			SourceTradeID:  fill.TransactionHash,
+			SourceTradeID:  tradeID,
+			SourceLogIdx:   fill.LogIndex,
 			Timestamp:      time.Unix(fill.Timestamp, 0),
 			MarketID:       market.ID,
 			Maker:          fill.Maker,
@@ -125,19 +162,5 @@ func (p *TradeProcessor) ProcessFills(ctx context.Context, fills []domain.RawFil
 		trades = append(trades, trade)
 	}
 
-	if len(trades) == 0 {
-		p.logger.Info("no trades to ingest after processing fills")
-		return 0, nil
-	}
-
-	if err := p.tradeSvc.IngestTrades(ctx, trades); err != nil {
-		return 0, fmt.Errorf("ingesting %d trades: %w", len(trades), err)
-	}
-
-	p.logger.Info("trades processed and ingested",
-		slog.Int("fills_input", len(fills)),
-		slog.Int("trades_ingested", len(trades)),
-	)
-
-	return len(trades), nil
+	return trades, nil
 }
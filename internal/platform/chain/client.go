@@ -0,0 +1,177 @@
+// Package chain reads the trading wallet's on-chain state on Polygon PoS: its
+// USDC.e collateral, its CTF outcome-token balances and the allowances the
+// Polymarket exchange contracts hold over both. Orders the wallet cannot
+// settle are rejected on-chain at match time, so these are checked before
+// placing. It also reads the payouts of resolved conditions and builds the
+// redeemPositions calls that turn winning tokens back into USDC.e. It is
+// read-only and needs no key; transfers and redemptions are signed and sent
+// by polygon.TxManager. It talks plain JSON-RPC through polygon.RPCClient,
+// so any Polygon RPC endpoint can be used.
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polygon"
+)
+
+// Polymarket contracts on Polygon PoS.
+const (
+	USDCe              = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174" // collateral
+	ConditionalTokens  = "0x4D97DCd97eC945f40cF65F87097ACe5EA0476045" // CTF, ERC-1155 outcome tokens
+	CTFExchange        = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+	NegRiskCTFExchange = "0xC5d563A36AE78145C45a50134d48A1215220f80a"
+	NegRiskAdapter     = "0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"
+)
+
+// Names of the exchange contracts in domain.ExchangeApproval.
+const (
+	ExchangeCTF        = "ctf_exchange"
+	ExchangeNegRisk    = "neg_risk_ctf_exchange"
+	ExchangeNegAdapter = "neg_risk_adapter"
+)
+
+// exchanges lists the contracts whose approvals WalletBalances reads.
+var exchanges = []struct{ name, address string }{
+	{ExchangeCTF, CTFExchange},
+	{ExchangeNegRisk, NegRiskCTFExchange},
+	{ExchangeNegAdapter, NegRiskAdapter},
+}
+
+// Function selectors.
+var (
+	selectorBalanceOf        = []byte{0x70, 0xa0, 0x82, 0x31} // ERC-20 balanceOf(address)
+	selectorAllowance        = []byte{0xdd, 0x62, 0xed, 0x3e} // ERC-20 allowance(address,address)
+	selectorBalanceOf1155    = []byte{0x00, 0xfd, 0xd5, 0x8e} // ERC-1155 balanceOf(address,uint256)
+	selectorIsApprovedForAll = []byte{0xe9, 0x85, 0xe9, 0xc5} // ERC-1155 isApprovedForAll(address,address)
+)
+
+// usdcDecimals is the decimals of USDC.e and of its outcome token shares.
+const usdcDecimals = domain.DefaultCollateralDecimals
+
+// Client reads balances and allowances over JSON-RPC.
+type Client struct {
+	rpc *polygon.RPCClient
+}
+
+// NewClient creates a Client for the Polygon RPC endpoint rpcURL.
+func NewClient(rpcURL string) (*Client, error) {
+	if rpcURL == "" {
+		return nil, errors.New("chain: rpc url is required")
+	}
+	return &Client{rpc: polygon.NewRPCClient(rpcURL, 10*time.Second)}, nil
+}
+
+// WalletBalances reads owner's USDC.e balance and, for each Polymarket
+// exchange contract, its USDC.e allowance and CTF operator approval, all at
+// the same block.
+func (c *Client) WalletBalances(ctx context.Context, owner string) (domain.WalletBalances, error) {
+	if !common.IsHexAddress(owner) {
+		return domain.WalletBalances{}, fmt.Errorf("chain: invalid wallet address %q", owner)
+	}
+	block, err := c.blockNumber(ctx)
+	if err != nil {
+		return domain.WalletBalances{}, err
+	}
+	at := fmt.Sprintf("0x%x", block)
+
+	bal, err := c.callUint(ctx, USDCe, at, selectorBalanceOf, addressWord(owner))
+	if err != nil {
+		return domain.WalletBalances{}, fmt.Errorf("chain: usdc balanceOf: %w", err)
+	}
+	out := domain.WalletBalances{
+		Wallet:    common.HexToAddress(owner).Hex(),
+		USDC:      toUnits(bal),
+		Exchanges: make([]domain.ExchangeApproval, 0, len(exchanges)),
+		Block:     block,
+		CheckedAt: time.Now().UTC(),
+	}
+	for _, ex := range exchanges {
+		allowance, err := c.callUint(ctx, USDCe, at, selectorAllowance, addressWord(owner), addressWord(ex.address))
+		if err != nil {
+			return domain.WalletBalances{}, fmt.Errorf("chain: usdc allowance %s: %w", ex.name, err)
+		}
+		approved, err := c.callUint(ctx, ConditionalTokens, at, selectorIsApprovedForAll, addressWord(owner), addressWord(ex.address))
+		if err != nil {
+			return domain.WalletBalances{}, fmt.Errorf("chain: ctf isApprovedForAll %s: %w", ex.name, err)
+		}
+		out.Exchanges = append(out.Exchanges, domain.ExchangeApproval{
+			Name:          ex.name,
+			Address:       ex.address,
+			USDCAllowance: toUnits(allowance),
+			CTFApproved:   approved.Sign() != 0,
+		})
+	}
+	return out, nil
+}
+
+// TokenBalance returns owner's balance, in shares, of the CTF outcome token
+// tokenID (the CLOB asset ID, a decimal uint256).
+func (c *Client) TokenBalance(ctx context.Context, owner, tokenID string) (float64, error) {
+	if !common.IsHexAddress(owner) {
+		return 0, fmt.Errorf("chain: invalid wallet address %q", owner)
+	}
+	id, ok := new(big.Int).SetString(tokenID, 10)
+	if !ok || id.Sign() < 0 {
+		return 0, fmt.Errorf("chain: invalid token id %q", tokenID)
+	}
+	bal, err := c.callUint(ctx, ConditionalTokens, "latest", selectorBalanceOf1155, addressWord(owner), common.LeftPadBytes(id.Bytes(), 32))
+	if err != nil {
+		return 0, fmt.Errorf("chain: ctf balanceOf %s: %w", tokenID, err)
+	}
+	return toUnits(bal), nil
+}
+
+// --------------------------------------------------------------------------
+// Internal helpers
+// --------------------------------------------------------------------------
+
+// callUint runs eth_call of selector(args...) on contract at block and
+// decodes the single uint256 (or bool) it returns.
+func (c *Client) callUint(ctx context.Context, contract, block string, selector []byte, args ...[]byte) (*big.Int, error) {
+	data := append([]byte{}, selector...)
+	for _, a := range args {
+		data = append(data, a...)
+	}
+	var out string
+	err := c.rpc.Call(ctx, "eth_call", []any{
+		map[string]string{"to": contract, "data": "0x" + hex.EncodeToString(data)},
+		block,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return polygon.ParseBig(out)
+}
+
+func (c *Client) blockNumber(ctx context.Context) (uint64, error) {
+	var out string
+	if err := c.rpc.Call(ctx, "eth_blockNumber", nil, &out); err != nil {
+		return 0, fmt.Errorf("chain: block number: %w", err)
+	}
+	v, err := polygon.ParseUint(out)
+	if err != nil {
+		return 0, fmt.Errorf("chain: block number: %w", err)
+	}
+	return v, nil
+}
+
+// addressWord ABI-encodes an address as a 32-byte word.
+func addressWord(addr string) []byte {
+	return common.LeftPadBytes(common.HexToAddress(addr).Bytes(), 32)
+}
+
+// toUnits converts a USDC.e or outcome token base-unit amount to a float. Unlimited
+// allowances (2^256-1) come out as a very large number, which is fine for
+// comparisons.
+func toUnits(v *big.Int) float64 {
+	return domain.FromBaseUnits(v, usdcDecimals)
+}
@@ -0,0 +1,161 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Redemption function selectors.
+var (
+	selectorPayoutDenominator = []byte{0xdd, 0x34, 0xde, 0x67} // CTF payoutDenominator(bytes32)
+	selectorPayoutNumerators  = []byte{0x05, 0x04, 0xc8, 0x14} // CTF payoutNumerators(bytes32,uint256)
+	selectorRedeemPositions   = []byte{0x01, 0xb7, 0x03, 0x7c} // CTF redeemPositions(address,bytes32,bytes32,uint256[])
+	selectorNegRiskRedeem     = []byte{0xdb, 0xec, 0xcb, 0x23} // NegRiskAdapter redeemPositions(bytes32,uint256[])
+)
+
+// Redeemable is what a wallet holds in a condition and what it pays out.
+// Balances and Payouts are parallel to the token IDs it was read for (the
+// market's outcomes, in outcome-slot order).
+type Redeemable struct {
+	ConditionID string
+	Resolved    bool       // the oracle has reported payouts
+	Balances    []*big.Int // base units (6 decimals)
+	Payouts     []float64  // USDC per share; zero until resolved
+	Proceeds    float64    // USDC redeeming the balances would pay
+}
+
+// Shares returns the balance of outcome i in shares.
+func (r Redeemable) Shares(i int) float64 {
+	return toUnits(r.Balances[i])
+}
+
+// HasBalance reports whether the wallet holds any of the condition's tokens.
+func (r Redeemable) HasBalance() bool {
+	for _, b := range r.Balances {
+		if b.Sign() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Redeemable reads owner's balances of tokenIDs, the outcome tokens of
+// conditionID in outcome-slot order, and the payouts the CTF reports for
+// them. A condition the oracle has not resolved yet comes back with Resolved
+// unset and zero payouts.
+func (c *Client) Redeemable(ctx context.Context, owner, conditionID string, tokenIDs []string) (Redeemable, error) {
+	if !common.IsHexAddress(owner) {
+		return Redeemable{}, fmt.Errorf("chain: invalid wallet address %q", owner)
+	}
+	cond, err := conditionWord(conditionID)
+	if err != nil {
+		return Redeemable{}, err
+	}
+	block, err := c.blockNumber(ctx)
+	if err != nil {
+		return Redeemable{}, err
+	}
+	at := fmt.Sprintf("0x%x", block)
+
+	out := Redeemable{
+		ConditionID: conditionID,
+		Balances:    make([]*big.Int, len(tokenIDs)),
+		Payouts:     make([]float64, len(tokenIDs)),
+	}
+	for i, tokenID := range tokenIDs {
+		id, ok := new(big.Int).SetString(tokenID, 10)
+		if !ok || id.Sign() < 0 {
+			return Redeemable{}, fmt.Errorf("chain: invalid token id %q", tokenID)
+		}
+		bal, err := c.callUint(ctx, ConditionalTokens, at, selectorBalanceOf1155, addressWord(owner), common.LeftPadBytes(id.Bytes(), 32))
+		if err != nil {
+			return Redeemable{}, fmt.Errorf("chain: ctf balanceOf %s: %w", tokenID, err)
+		}
+		out.Balances[i] = bal
+	}
+
+	den, err := c.callUint(ctx, ConditionalTokens, at, selectorPayoutDenominator, cond)
+	if err != nil {
+		return Redeemable{}, fmt.Errorf("chain: ctf payoutDenominator %s: %w", conditionID, err)
+	}
+	if den.Sign() == 0 {
+		return out, nil
+	}
+	out.Resolved = true
+	denF := new(big.Float).SetInt(den)
+	for i := range tokenIDs {
+		num, err := c.callUint(ctx, ConditionalTokens, at, selectorPayoutNumerators, cond, common.LeftPadBytes(big.NewInt(int64(i)).Bytes(), 32))
+		if err != nil {
+			return Redeemable{}, fmt.Errorf("chain: ctf payoutNumerators %s[%d]: %w", conditionID, i, err)
+		}
+		out.Payouts[i], _ = new(big.Float).Quo(new(big.Float).SetInt(num), denF).Float64()
+		out.Proceeds += out.Payouts[i] * out.Shares(i)
+	}
+	return out, nil
+}
+
+// RedeemCall returns the contract and calldata that redeem balances (base
+// units, in outcome-slot order) of conditionID for USDC.e. Standard markets
+// redeem every outcome's index set on the CTF; neg-risk markets go through
+// the NegRiskAdapter, which takes the amount of each outcome to redeem and
+// therefore needs its CTF operator approval.
+func RedeemCall(conditionID string, negRisk bool, balances []*big.Int) (string, []byte, error) {
+	cond, err := conditionWord(conditionID)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(balances) == 0 {
+		return "", nil, errors.New("chain: redeem needs at least one outcome")
+	}
+	if negRisk {
+		data := append([]byte{}, selectorNegRiskRedeem...)
+		data = append(data, cond...)
+		data = append(data, uintWord(big.NewInt(64))...) // offset of amounts
+		data = append(data, uintArray(balances)...)
+		return NegRiskAdapter, data, nil
+	}
+	indexSets := make([]*big.Int, len(balances))
+	for i := range balances {
+		indexSets[i] = new(big.Int).Lsh(big.NewInt(1), uint(i))
+	}
+	data := append([]byte{}, selectorRedeemPositions...)
+	data = append(data, addressWord(USDCe)...)
+	data = append(data, make([]byte, 32)...) // parentCollectionId: none
+	data = append(data, cond...)
+	data = append(data, uintWord(big.NewInt(128))...) // offset of indexSets
+	data = append(data, uintArray(indexSets)...)
+	return ConditionalTokens, data, nil
+}
+
+// conditionWord decodes a 0x-prefixed 32-byte condition ID.
+func conditionWord(conditionID string) ([]byte, error) {
+	s := strings.TrimPrefix(conditionID, "0x")
+	if len(s) != 64 {
+		return nil, fmt.Errorf("chain: invalid condition id %q", conditionID)
+	}
+	b := common.FromHex(s)
+	if len(b) != 32 {
+		return nil, fmt.Errorf("chain: invalid condition id %q", conditionID)
+	}
+	return b, nil
+}
+
+// uintWord ABI-encodes a uint256.
+func uintWord(v *big.Int) []byte {
+	return common.LeftPadBytes(v.Bytes(), 32)
+}
+
+// uintArray ABI-encodes the tail of a dynamic uint256[]: its length, then
+// its elements.
+func uintArray(vs []*big.Int) []byte {
+	out := uintWord(big.NewInt(int64(len(vs))))
+	for _, v := range vs {
+		out = append(out, uintWord(v)...)
+	}
+	return out
+}
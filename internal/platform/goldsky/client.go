@@ -7,14 +7,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
+// venueName identifies Goldsky in *domain.VenueError.
+const venueName = "goldsky"
+
 // Client is a GraphQL client for the Goldsky subgraph indexer, used to
-// query on-chain order fill events from the Polymarket CTF Exchange contract.
+// query on-chain order fill events from the Polymarket CTF Exchange contract
+// and, pointed at the activity subgraph, CTF splits, merges and redemptions.
 type Client struct {
 	graphqlURL string
 	apiKey     string
@@ -63,6 +68,7 @@ func (c *Client) FetchOrderFills(ctx context.Context, since time.Time, first int
 				orderDirection: asc
 				where: { timestamp_gte: $since }
 			) {
+				id
 				transactionHash
 				timestamp
 				maker
@@ -84,9 +90,55 @@ func (c *Client) FetchOrderFills(ctx context.Context, since time.Time, first int
 	if err != nil {
 		return nil, fmt.Errorf("goldsky: fetch order fills: %w", err)
 	}
+	return decodeOrderFills(respData)
+}
 
+// FetchOrderFillsPage returns up to first fills with timestamp in [from, to)
+// and an entity ID greater than afterID, ordered by ID. Pass the ID of the
+// last fill of a page as afterID to get the next one; an empty afterID starts
+// at the beginning of the range. Unlike timestamp paging, ID paging never
+// skips or repeats fills sharing a timestamp across a page boundary.
+func (c *Client) FetchOrderFillsPage(ctx context.Context, from, to time.Time, afterID string, first int) ([]domain.RawFill, error) {
+	query := `
+		query OrderFillsPage($from: BigInt!, $to: BigInt!, $after: String!, $first: Int!) {
+			orderFilledEvents(
+				first: $first
+				orderBy: id
+				orderDirection: asc
+				where: { timestamp_gte: $from, timestamp_lt: $to, id_gt: $after }
+			) {
+				id
+				transactionHash
+				timestamp
+				maker
+				makerAssetId
+				makerAmountFilled
+				taker
+				takerAssetId
+				takerAmountFilled
+			}
+		}
+	`
+
+	variables := map[string]any{
+		"from":  fmt.Sprintf("%d", from.Unix()),
+		"to":    fmt.Sprintf("%d", to.Unix()),
+		"after": afterID,
+		"first": first,
+	}
+
+	respData, err := c.doQuery(ctx, query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("goldsky: fetch order fills page: %w", err)
+	}
+	return decodeOrderFills(respData)
+}
+
+// decodeOrderFills converts an orderFilledEvents query result to RawFills.
+func decodeOrderFills(respData json.RawMessage) ([]domain.RawFill, error) {
 	var result struct {
 		OrderFilledEvents []struct {
+			ID                string `json:"id"`
 			TransactionHash   string `json:"transactionHash"`
 			Timestamp         string `json:"timestamp"`
 			Maker             string `json:"maker"`
@@ -112,6 +164,7 @@ func (c *Client) FetchOrderFills(ctx context.Context, since time.Time, first int
 		fmt.Sscanf(e.TakerAmountFilled, "%d", &takerAmt)
 
 		fills = append(fills, domain.RawFill{
+			ID:                e.ID,
 			TransactionHash:   e.TransactionHash,
 			Timestamp:         ts,
 			Maker:             e.Maker,
@@ -120,12 +173,133 @@ func (c *Client) FetchOrderFills(ctx context.Context, since time.Time, first int
 			Taker:             e.Taker,
 			TakerAssetID:      e.TakerAssetID,
 			TakerAmountFilled: takerAmt,
+			LogIndex:          fillLogIndex(e.ID, e.TransactionHash),
 		})
 	}
 
 	return fills, nil
 }
 
+// fillLogIndex returns the log index carried by a fill entity ID of the form
+// "{transactionHash}_{logIndex}" (or "-"), or nil for other ID schemes such
+// as "{transactionHash}_{orderHash}".
+func fillLogIndex(id, txHash string) *int64 {
+	if txHash == "" || len(id) <= len(txHash)+1 || !strings.EqualFold(id[:len(txHash)], txHash) {
+		return nil
+	}
+	if sep := id[len(txHash)]; sep != '_' && sep != '-' {
+		return nil
+	}
+	n, err := strconv.ParseInt(id[len(txHash)+1:], 10, 64)
+	if err != nil || n < 0 {
+		return nil
+	}
+	return &n
+}
+
+// ctfEntity describes how one CTF event kind is queried from the activity
+// subgraph: its entity, the field holding the wallet and the field holding
+// the collateral amount.
+type ctfEntity struct {
+	entity, wallet, amount, extra string
+}
+
+var ctfEntities = map[domain.OnchainEventKind]ctfEntity{
+	domain.OnchainEventSplit:      {entity: "splits", wallet: "stakeholder", amount: "amount"},
+	domain.OnchainEventMerge:      {entity: "merges", wallet: "stakeholder", amount: "amount"},
+	domain.OnchainEventRedemption: {entity: "redemptions", wallet: "redeemer", amount: "payout", extra: "indexSets"},
+}
+
+// FetchCTFEventsPage returns up to first CTF events of kind (splits, merges
+// or redemptions) at or after since with an entity ID greater than afterID,
+// ordered by ID, from the Polymarket activity subgraph. Pass the ID of the
+// last event of a page as afterID to get the next one. When wallets is
+// non-empty only their events are returned.
+func (c *Client) FetchCTFEventsPage(ctx context.Context, kind domain.OnchainEventKind, wallets []string, since time.Time, afterID string, first int) ([]domain.OnchainEvent, error) {
+	ent, ok := ctfEntities[kind]
+	if !ok {
+		return nil, fmt.Errorf("goldsky: unknown ctf event kind %q", kind)
+	}
+
+	params := "$since: BigInt!, $after: String!, $first: Int!"
+	where := "timestamp_gte: $since, id_gt: $after"
+	variables := map[string]any{
+		"since": fmt.Sprintf("%d", since.Unix()),
+		"after": afterID,
+		"first": first,
+	}
+	if len(wallets) > 0 {
+		lower := make([]string, len(wallets))
+		for i, w := range wallets {
+			lower[i] = strings.ToLower(w)
+		}
+		params += ", $wallets: [String!]!"
+		where += ", " + ent.wallet + "_in: $wallets"
+		variables["wallets"] = lower
+	}
+	query := fmt.Sprintf(`
+		query CTFEvents(%s) {
+			events: %s(
+				first: $first
+				orderBy: id
+				orderDirection: asc
+				where: { %s }
+			) {
+				id
+				timestamp
+				wallet: %s
+				condition
+				amount: %s
+				%s
+			}
+		}
+	`, params, ent.entity, where, ent.wallet, ent.amount, ent.extra)
+
+	respData, err := c.doQuery(ctx, query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("goldsky: fetch %s events: %w", kind, err)
+	}
+	return decodeCTFEvents(kind, respData)
+}
+
+// decodeCTFEvents converts an aliased CTF events query result to
+// OnchainEvents. Amounts are in USDC base units (6 decimals).
+func decodeCTFEvents(kind domain.OnchainEventKind, respData json.RawMessage) ([]domain.OnchainEvent, error) {
+	var result struct {
+		Events []struct {
+			ID        string   `json:"id"`
+			Timestamp string   `json:"timestamp"`
+			Wallet    string   `json:"wallet"`
+			Condition string   `json:"condition"`
+			Amount    string   `json:"amount"`
+			IndexSets []string `json:"indexSets"`
+		} `json:"events"`
+	}
+
+	if err := json.Unmarshal(respData, &result); err != nil {
+		return nil, fmt.Errorf("goldsky: decode %s events: %w", kind, err)
+	}
+
+	events := make([]domain.OnchainEvent, 0, len(result.Events))
+	for _, e := range result.Events {
+		var ts int64
+		fmt.Sscanf(e.Timestamp, "%d", &ts)
+		amount, _ := strconv.ParseFloat(e.Amount, 64)
+
+		events = append(events, domain.OnchainEvent{
+			ID:          e.ID,
+			Kind:        kind,
+			Stakeholder: strings.ToLower(e.Wallet),
+			ConditionID: e.Condition,
+			Amount:      amount / domain.AmountScale,
+			IndexSets:   e.IndexSets,
+			Timestamp:   time.Unix(ts, 0).UTC(),
+		})
+	}
+
+	return events, nil
+}
+
 // FetchLatestBlock returns the latest block number indexed by the Goldsky
 // subgraph. This is useful for monitoring indexing lag.
 func (c *Client) FetchLatestBlock(ctx context.Context) (int64, error) {
@@ -188,7 +362,7 @@ func (c *Client) doQuery(ctx context.Context, query string, variables map[string
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return nil, domain.NewTransportError(venueName, err)
 	}
 	defer resp.Body.Close()
 
@@ -198,7 +372,7 @@ func (c *Client) doQuery(ctx context.Context, query string, variables map[string
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, domain.NewHTTPError(venueName, resp.StatusCode, string(body))
 	}
 
 	var gqlResp graphqlResponse
@@ -17,14 +17,21 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/retry"
 )
 
+// venueName identifies Kalshi in *domain.VenueError.
+const venueName = "kalshi"
+
 // Client is the REST client for the Kalshi exchange API.
 type Client struct {
 	baseURL    string
 	apiKeyID   string
 	privateKey *rsa.PrivateKey
 	httpClient *http.Client
+	retrier    *retry.Retrier
 }
 
 // NewClient creates a new Kalshi REST client.
@@ -41,6 +48,20 @@ func NewClient(baseURL, apiKeyID string) *Client {
 	}
 }
 
+// WithTransport sends every request through rt, e.g. a rate-limiting
+// ratelimit.Transport.
+func (c *Client) WithTransport(rt http.RoundTripper) *Client {
+	c.httpClient.Transport = rt
+	return c
+}
+
+// WithRetry retries idempotent requests (queries and cancels) that fail
+// with a transient or rate-limited error. Order placement is never retried.
+func (c *Client) WithRetry(r *retry.Retrier) *Client {
+	c.retrier = r
+	return c
+}
+
 // SetRSAPrivateKey loads an RSA private key from PEM-encoded bytes and
 // configures the client for RSA-signed authentication.
 func (c *Client) SetRSAPrivateKey(pemBytes []byte) error {
@@ -99,6 +120,37 @@ func (c *Client) GetMarkets(ctx context.Context, limit, cursor string) ([]Kalshi
 	return resp.Markets, nil
 }
 
+// ListMarkets returns one page of markets with the given status ("open",
+// "closed", "settled"; empty for all) and the cursor of the next page, which
+// is empty on the last page.
+func (c *Client) ListMarkets(ctx context.Context, status, cursor string, limit int) ([]KalshiMarket, string, error) {
+	params := url.Values{}
+	if status != "" {
+		params.Set("status", status)
+	}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	body, err := c.doSignedRequest(ctx, http.MethodGet, "/markets?"+params.Encode(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("kalshi: list markets: %w", err)
+	}
+
+	var resp struct {
+		Markets []KalshiMarket `json:"markets"`
+		Cursor  string         `json:"cursor"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, "", fmt.Errorf("kalshi: decode markets: %w", err)
+	}
+
+	return resp.Markets, resp.Cursor, nil
+}
+
 // GetMarket returns a single market by its ticker.
 func (c *Client) GetMarket(ctx context.Context, ticker string) (KalshiMarket, error) {
 	path := fmt.Sprintf("/markets/%s", url.PathEscape(ticker))
@@ -153,7 +205,7 @@ func (c *Client) PlaceOrder(ctx context.Context, order KalshiOrder) error {
 	}
 
 	if resp.Order.Status == "canceled" {
-		return fmt.Errorf("kalshi: order was immediately cancelled")
+		return fmt.Errorf("kalshi: place order: %w", domain.NewRejectedError(venueName, "order was immediately cancelled"))
 	}
 
 	return nil
@@ -176,8 +228,18 @@ func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
 // --------------------------------------------------------------------------
 
 // doSignedRequest builds, signs (RSA), sends, and reads an HTTP request
-// against the Kalshi API.
+// against the Kalshi API. Idempotent requests go through the client's
+// retrier, signed afresh on every attempt.
 func (c *Client) doSignedRequest(ctx context.Context, method, path string, reqBody any) ([]byte, error) {
+	if !retry.Idempotent(method) {
+		return c.doSignedRequestOnce(ctx, method, path, reqBody)
+	}
+	return retry.Value(ctx, c.retrier, venueName, func() ([]byte, error) {
+		return c.doSignedRequestOnce(ctx, method, path, reqBody)
+	})
+}
+
+func (c *Client) doSignedRequestOnce(ctx context.Context, method, path string, reqBody any) ([]byte, error) {
 	var bodyReader io.Reader
 	if reqBody != nil {
 		jsonBody, err := json.Marshal(reqBody)
@@ -206,7 +268,7 @@ func (c *Client) doSignedRequest(ctx context.Context, method, path string, reqBo
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return nil, domain.NewTransportError(venueName, err)
 	}
 	defer resp.Body.Close()
 
@@ -253,7 +315,8 @@ func (c *Client) signRequest(req *http.Request, method, path string) error {
 	return nil
 }
 
-// checkStatus maps non-2xx HTTP status codes to appropriate errors.
+// checkStatus maps non-2xx HTTP status codes to a classified
+// *domain.VenueError carrying Kalshi's error message and code.
 func (c *Client) checkStatus(statusCode int, body []byte) error {
 	if statusCode >= 200 && statusCode < 300 {
 		return nil
@@ -262,18 +325,9 @@ func (c *Client) checkStatus(statusCode int, body []byte) error {
 	var apiErr KalshiErrorResponse
 	_ = json.Unmarshal(body, &apiErr)
 
-	switch statusCode {
-	case http.StatusNotFound:
-		return fmt.Errorf("kalshi: not found: %s (%s)", apiErr.Message, apiErr.Code)
-	case http.StatusUnauthorized, http.StatusForbidden:
-		return fmt.Errorf("kalshi: unauthorized: %s (%s)", apiErr.Message, apiErr.Code)
-	case http.StatusTooManyRequests:
-		return fmt.Errorf("kalshi: rate limited: %s (%s)", apiErr.Message, apiErr.Code)
-	case http.StatusBadRequest:
-		return fmt.Errorf("kalshi: bad request: %s (%s)", apiErr.Message, apiErr.Code)
-	case http.StatusConflict:
-		return fmt.Errorf("kalshi: conflict: %s (%s)", apiErr.Message, apiErr.Code)
-	default:
-		return fmt.Errorf("kalshi: HTTP %d: %s (%s)", statusCode, apiErr.Message, apiErr.Code)
+	msg := string(body)
+	if apiErr.Message != "" || apiErr.Code != "" {
+		msg = fmt.Sprintf("%s (%s)", apiErr.Message, apiErr.Code)
 	}
+	return domain.NewHTTPError(venueName, statusCode, msg)
 }
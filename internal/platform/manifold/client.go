@@ -0,0 +1,122 @@
+// Package manifold is a read-only client for the Manifold Markets API.
+// Manifold trades play money against an AMM, so its probability is used as
+// a reference price only.
+package manifold
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// venueName identifies Manifold in *domain.VenueError.
+const venueName = "manifold"
+
+// Client is the REST client for the Manifold API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Manifold client.
+//
+// baseURL is the API root, e.g. "https://api.manifold.markets/v0".
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// GetMarketBySlug returns a market by its URL slug.
+func (c *Client) GetMarketBySlug(ctx context.Context, slug string) (Market, error) {
+	return c.getMarket(ctx, "/slug/"+url.PathEscape(slug))
+}
+
+// GetMarket returns a market by its ID.
+func (c *Client) GetMarket(ctx context.Context, id string) (Market, error) {
+	return c.getMarket(ctx, "/market/"+url.PathEscape(id))
+}
+
+// Quote returns a YES/NO quote for ref, a market slug or "id:<marketID>".
+// The AMM has no spread, so bid and ask are both the current probability.
+func (c *Client) Quote(ctx context.Context, ref string) (domain.VenueQuote, error) {
+	var (
+		m   Market
+		err error
+	)
+	if id, ok := strings.CutPrefix(ref, "id:"); ok {
+		m, err = c.GetMarket(ctx, id)
+	} else {
+		m, err = c.GetMarketBySlug(ctx, ref)
+	}
+	if err != nil {
+		return domain.VenueQuote{}, err
+	}
+	if m.OutcomeType != "BINARY" {
+		return domain.VenueQuote{}, fmt.Errorf("manifold: market %s is %s, not BINARY", ref, m.OutcomeType)
+	}
+	if m.IsResolved {
+		return domain.VenueQuote{}, fmt.Errorf("manifold: market %s is resolved", ref)
+	}
+	if m.Probability <= 0 || m.Probability >= 1 {
+		return domain.VenueQuote{}, fmt.Errorf("manifold: market %s has no usable probability", ref)
+	}
+
+	p := m.Probability
+	return domain.VenueQuote{
+		Venue:  domain.VenueManifold,
+		Ref:    ref,
+		YesBid: p,
+		YesAsk: p,
+		NoBid:  1 - p,
+		NoAsk:  1 - p,
+		At:     time.Now().UTC(),
+	}, nil
+}
+
+func (c *Client) getMarket(ctx context.Context, path string) (Market, error) {
+	body, err := c.doGet(ctx, path)
+	if err != nil {
+		return Market{}, fmt.Errorf("manifold: get market %s: %w", path, err)
+	}
+	var m Market
+	if err := json.Unmarshal(body, &m); err != nil {
+		return Market{}, fmt.Errorf("manifold: decode market: %w", err)
+	}
+	return m, nil
+}
+
+// doGet sends an unauthenticated GET request to the Manifold API.
+func (c *Client) doGet(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, domain.NewTransportError(venueName, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, domain.NewHTTPError(venueName, resp.StatusCode, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,19 @@
+package manifold
+
+// --------------------------------------------------------------------------
+// Manifold API DTOs
+// --------------------------------------------------------------------------
+
+// Market is a Manifold market as returned by /v0/slug/{slug} and
+// /v0/market/{id}. Only the fields needed for binary quotes are decoded.
+type Market struct {
+	ID          string  `json:"id"`
+	Slug        string  `json:"slug"`
+	Question    string  `json:"question"`
+	URL         string  `json:"url"`
+	OutcomeType string  `json:"outcomeType"` // "BINARY", "MULTIPLE_CHOICE", ...
+	Mechanism   string  `json:"mechanism"`   // "cpmm-1" for binary AMM markets
+	Probability float64 `json:"probability"`
+	IsResolved  bool    `json:"isResolved"`
+	CloseTime   int64   `json:"closeTime"` // unix ms
+}
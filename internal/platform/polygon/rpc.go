@@ -0,0 +1,120 @@
+package polygon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// venueName identifies the Polygon RPC in *domain.VenueError.
+const venueName = "polygon"
+
+// RPCClient performs JSON-RPC calls against a Polygon RPC endpoint. It is
+// shared by TxManager and chain.Client. Transport failures and non-200
+// responses are returned as *domain.VenueError.
+type RPCClient struct {
+	url        string
+	httpClient *http.Client
+	reqID      atomic.Int64
+}
+
+// NewRPCClient creates an RPCClient for url whose requests time out after
+// timeout.
+func NewRPCClient(url string, timeout time.Duration) *RPCClient {
+	return &RPCClient{
+		url:        url,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int64  `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Call performs a JSON-RPC request and decodes the result into out.
+func (c *RPCClient) Call(ctx context.Context, method string, params []any, out any) error {
+	if params == nil {
+		params = []any{}
+	}
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: c.reqID.Add(1), Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("marshal %s request: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return domain.NewTransportError(venueName, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return domain.NewHTTPError(venueName, resp.StatusCode, string(respBody))
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return fmt.Errorf("decode %s response: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("rpc error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// ParseBig parses a JSON-RPC hex quantity ("0x1a"); an empty quantity is 0.
+func ParseBig(s string) (*big.Int, error) {
+	s = strings.TrimPrefix(s, "0x")
+	if s == "" {
+		return new(big.Int), nil
+	}
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity %q", s)
+	}
+	return v, nil
+}
+
+// ParseUint parses a JSON-RPC hex quantity that must fit in a uint64.
+func ParseUint(s string) (uint64, error) {
+	v, err := ParseBig(s)
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() {
+		return 0, fmt.Errorf("quantity %q overflows uint64", s)
+	}
+	return v.Uint64(), nil
+}
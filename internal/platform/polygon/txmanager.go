@@ -0,0 +1,268 @@
+// Package polygon provides a minimal on-chain transaction manager for the
+// Polygon PoS chain: it reads ERC-20 balances, signs and submits EIP-155
+// transactions from the bot's hot wallet, and waits for confirmations. It
+// talks plain JSON-RPC so any Polygon RPC endpoint can be used.
+package polygon
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// USDCe is the bridged USDC contract on Polygon used as Polymarket collateral.
+const USDCe = "0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"
+
+// ERC-20 function selectors.
+var (
+	selectorTransfer  = []byte{0xa9, 0x05, 0x9c, 0xbb} // transfer(address,uint256)
+	selectorBalanceOf = []byte{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+)
+
+// ErrTxReverted is returned by WaitForReceipt when the transaction was mined
+// but failed.
+var ErrTxReverted = errors.New("polygon: transaction reverted")
+
+// Receipt is the subset of a transaction receipt the bot cares about.
+type Receipt struct {
+	TxHash        string
+	BlockNumber   uint64
+	GasUsed       uint64
+	Confirmations uint64
+}
+
+// TxManager signs and submits transactions from a single hot wallet. Nonces
+// are assigned under a mutex so concurrent callers never reuse one.
+type TxManager struct {
+	rpc     *RPCClient
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+	address common.Address
+
+	// gasPriceBumpPct is added on top of eth_gasPrice so transactions are
+	// not stuck behind a fee spike.
+	gasPriceBumpPct int64
+
+	mu sync.Mutex // serialises nonce assignment in send
+}
+
+// NewTxManager creates a TxManager for the wallet identified by a
+// hex-encoded private key (with or without 0x prefix).
+func NewTxManager(rpcURL, privateKeyHex string, chainID int) (*TxManager, error) {
+	if rpcURL == "" {
+		return nil, errors.New("polygon: rpc url is required")
+	}
+	key, err := ethcrypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("polygon: invalid private key: %w", err)
+	}
+	return &TxManager{
+		rpc:             NewRPCClient(rpcURL, 20*time.Second),
+		chainID:         big.NewInt(int64(chainID)),
+		key:             key,
+		address:         ethcrypto.PubkeyToAddress(key.PublicKey),
+		gasPriceBumpPct: 20,
+	}, nil
+}
+
+// Address returns the hot wallet address.
+func (m *TxManager) Address() common.Address {
+	return m.address
+}
+
+// ERC20Balance returns the raw token balance (in the token's smallest unit)
+// of owner.
+func (m *TxManager) ERC20Balance(ctx context.Context, token, owner string) (*big.Int, error) {
+	if !common.IsHexAddress(token) || !common.IsHexAddress(owner) {
+		return nil, fmt.Errorf("polygon: invalid address in balanceOf(%s, %s)", token, owner)
+	}
+	data := append(append([]byte{}, selectorBalanceOf...), common.LeftPadBytes(common.HexToAddress(owner).Bytes(), 32)...)
+	var out string
+	err := m.rpc.Call(ctx, "eth_call", []any{
+		map[string]string{"to": token, "data": "0x" + hex.EncodeToString(data)},
+		"latest",
+	}, &out)
+	if err != nil {
+		return nil, fmt.Errorf("polygon: balanceOf: %w", err)
+	}
+	return ParseBig(out)
+}
+
+// TransferERC20 signs and broadcasts transfer(to, amount) on the token
+// contract and returns the transaction hash. It does not wait for the
+// transaction to be mined; see WaitForReceipt.
+func (m *TxManager) TransferERC20(ctx context.Context, token, to string, amount *big.Int) (string, error) {
+	if !common.IsHexAddress(token) || !common.IsHexAddress(to) {
+		return "", fmt.Errorf("polygon: invalid address in transfer(%s, %s)", token, to)
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return "", errors.New("polygon: transfer amount must be positive")
+	}
+	data := make([]byte, 0, 4+64)
+	data = append(data, selectorTransfer...)
+	data = append(data, common.LeftPadBytes(common.HexToAddress(to).Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+	return m.send(ctx, common.HexToAddress(token), big.NewInt(0), data)
+}
+
+// SendCall signs and broadcasts a call of data on contract, such as the
+// redemptions built by chain.RedeemCall, and returns the transaction hash.
+// Like TransferERC20 it does not wait for the transaction to be mined.
+func (m *TxManager) SendCall(ctx context.Context, contract string, data []byte) (string, error) {
+	if !common.IsHexAddress(contract) {
+		return "", fmt.Errorf("polygon: invalid contract address %q", contract)
+	}
+	if len(data) < 4 {
+		return "", errors.New("polygon: call data must start with a function selector")
+	}
+	return m.send(ctx, common.HexToAddress(contract), big.NewInt(0), data)
+}
+
+// WaitForReceipt polls until txHash is mined and has at least confirmations
+// blocks on top of (and including) its own block. It returns ErrTxReverted if
+// the transaction failed on-chain.
+func (m *TxManager) WaitForReceipt(ctx context.Context, txHash string, confirmations uint64, poll time.Duration) (Receipt, error) {
+	if poll <= 0 {
+		poll = 2 * time.Second
+	}
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		var raw *struct {
+			Status      string `json:"status"`
+			BlockNumber string `json:"blockNumber"`
+			GasUsed     string `json:"gasUsed"`
+		}
+		if err := m.rpc.Call(ctx, "eth_getTransactionReceipt", []any{txHash}, &raw); err != nil {
+			return Receipt{}, fmt.Errorf("polygon: get receipt %s: %w", txHash, err)
+		}
+		if raw != nil && raw.BlockNumber != "" {
+			block, err := ParseUint(raw.BlockNumber)
+			if err != nil {
+				return Receipt{}, fmt.Errorf("polygon: receipt %s block: %w", txHash, err)
+			}
+			if raw.Status == "0x0" {
+				return Receipt{TxHash: txHash, BlockNumber: block}, ErrTxReverted
+			}
+			head, err := m.blockNumber(ctx)
+			if err != nil {
+				return Receipt{}, err
+			}
+			if head >= block && head-block+1 >= confirmations {
+				gasUsed, _ := ParseUint(raw.GasUsed)
+				return Receipt{
+					TxHash:        txHash,
+					BlockNumber:   block,
+					GasUsed:       gasUsed,
+					Confirmations: head - block + 1,
+				}, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return Receipt{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// --------------------------------------------------------------------------
+// Internal helpers
+// --------------------------------------------------------------------------
+
+// send builds, signs (EIP-155 legacy transaction) and broadcasts a call.
+func (m *TxManager) send(ctx context.Context, to common.Address, value *big.Int, data []byte) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var nonceHex string
+	if err := m.rpc.Call(ctx, "eth_getTransactionCount", []any{m.address.Hex(), "pending"}, &nonceHex); err != nil {
+		return "", fmt.Errorf("polygon: get nonce: %w", err)
+	}
+	nonce, err := ParseUint(nonceHex)
+	if err != nil {
+		return "", fmt.Errorf("polygon: parse nonce: %w", err)
+	}
+
+	var gasPriceHex string
+	if err := m.rpc.Call(ctx, "eth_gasPrice", nil, &gasPriceHex); err != nil {
+		return "", fmt.Errorf("polygon: get gas price: %w", err)
+	}
+	gasPrice, err := ParseBig(gasPriceHex)
+	if err != nil {
+		return "", fmt.Errorf("polygon: parse gas price: %w", err)
+	}
+	gasPrice.Mul(gasPrice, big.NewInt(100+m.gasPriceBumpPct))
+	gasPrice.Div(gasPrice, big.NewInt(100))
+
+	var gasHex string
+	err = m.rpc.Call(ctx, "eth_estimateGas", []any{map[string]string{
+		"from":  m.address.Hex(),
+		"to":    to.Hex(),
+		"value": "0x" + value.Text(16),
+		"data":  "0x" + hex.EncodeToString(data),
+	}}, &gasHex)
+	if err != nil {
+		return "", fmt.Errorf("polygon: estimate gas: %w", err)
+	}
+	gas, err := ParseUint(gasHex)
+	if err != nil {
+		return "", fmt.Errorf("polygon: parse gas estimate: %w", err)
+	}
+	gas += gas / 5 // 20% headroom
+
+	raw, err := m.signLegacy(nonce, gasPrice, gas, to, value, data)
+	if err != nil {
+		return "", err
+	}
+	var txHash string
+	if err := m.rpc.Call(ctx, "eth_sendRawTransaction", []any{"0x" + hex.EncodeToString(raw)}, &txHash); err != nil {
+		return "", fmt.Errorf("polygon: send transaction: %w", err)
+	}
+	return txHash, nil
+}
+
+// signLegacy returns the RLP-encoded signed transaction.
+func (m *TxManager) signLegacy(nonce uint64, gasPrice *big.Int, gas uint64, to common.Address, value *big.Int, data []byte) ([]byte, error) {
+	unsigned, err := rlp.EncodeToBytes([]any{
+		nonce, gasPrice, gas, to, value, data, m.chainID, uint(0), uint(0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("polygon: encode transaction: %w", err)
+	}
+	sig, err := ethcrypto.Sign(ethcrypto.Keccak256(unsigned), m.key)
+	if err != nil {
+		return nil, fmt.Errorf("polygon: sign transaction: %w", err)
+	}
+	v := new(big.Int).Mul(m.chainID, big.NewInt(2))
+	v.Add(v, big.NewInt(35+int64(sig[64])))
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+
+	signed, err := rlp.EncodeToBytes([]any{
+		nonce, gasPrice, gas, to, value, data, v, r, s,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("polygon: encode signed transaction: %w", err)
+	}
+	return signed, nil
+}
+
+func (m *TxManager) blockNumber(ctx context.Context) (uint64, error) {
+	var out string
+	if err := m.rpc.Call(ctx, "eth_blockNumber", nil, &out); err != nil {
+		return 0, fmt.Errorf("polygon: block number: %w", err)
+	}
+	return ParseUint(out)
+}
@@ -7,12 +7,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/crypto"
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/retry"
 )
 
+// venueName identifies Polymarket in *domain.VenueError.
+const venueName = "polymarket"
+
 // ClobClient is the REST client for the Polymarket CLOB (Central Limit
 // Order Book) API. It handles order placement, cancellation, and queries.
 type ClobClient struct {
@@ -20,6 +27,8 @@ type ClobClient struct {
 	httpClient *http.Client
 	signer     *crypto.Signer
 	hmacAuth   *crypto.HMACAuth
+	builder    *crypto.HMACAuth // builder-program credentials; nil = no attribution
+	retrier    *retry.Retrier
 }
 
 // NewClobClient creates a new CLOB REST client.
@@ -38,9 +47,36 @@ func NewClobClient(baseURL string, signer *crypto.Signer, hmac *crypto.HMACAuth)
 	}
 }
 
+// WithTransport sends every request through rt, e.g. a rate-limiting
+// ratelimit.Transport.
+func (c *ClobClient) WithTransport(rt http.RoundTripper) *ClobClient {
+	c.httpClient.Transport = rt
+	return c
+}
+
+// WithRetry retries idempotent requests (queries and cancels) that fail
+// with a transient or rate-limited error. Order placement is never retried.
+func (c *ClobClient) WithRetry(r *retry.Retrier) *ClobClient {
+	c.retrier = r
+	return c
+}
+
+// WithBuilder attributes order submissions to the Polymarket builder
+// program: POST /order carries the builder's POLY_BUILDER_* headers
+// alongside the L2 headers. It also enables GetBuilderTrades.
+func (c *ClobClient) WithBuilder(auth *crypto.HMACAuth) *ClobClient {
+	c.builder = auth
+	return c
+}
+
 // PostOrder submits a signed order to the CLOB API and returns the result.
 func (c *ClobClient) PostOrder(ctx context.Context, order domain.Order) (domain.OrderResult, error) {
-	// Build the CLOB order payload.
+	// Build the CLOB order payload. The expiration must match the one signed
+	// into the order: Unix seconds for GTD, "0" otherwise.
+	expiration := "0"
+	if order.ExpiresAt != nil {
+		expiration = strconv.FormatInt(order.ExpiresAt.Unix(), 10)
+	}
 	body := map[string]any{
 		"order": map[string]any{
 			"tokenID":       order.TokenID,
@@ -49,7 +85,7 @@ func (c *ClobClient) PostOrder(ctx context.Context, order domain.Order) (domain.
 			"side":          string(order.Side),
 			"feeRateBps":    "0",
 			"nonce":         "0",
-			"expiration":    "0",
+			"expiration":    expiration,
 			"signatureType": 0,
 			"signature":     order.Signature,
 			"maker":         order.Wallet,
@@ -71,8 +107,9 @@ func (c *ClobClient) PostOrder(ctx context.Context, order domain.Order) (domain.
 	}
 
 	result := apiResult.ToDomainOrderResult()
+	result.FilledSize = apiResult.matchedShares(order.Side)
 	if !result.Success {
-		return result, fmt.Errorf("polymarket/clob: order rejected: %s", result.Message)
+		return result, fmt.Errorf("polymarket/clob: post order: %w", domain.NewRejectedError(venueName, result.Message))
 	}
 
 	return result, nil
@@ -97,7 +134,7 @@ func (c *ClobClient) CancelOrder(ctx context.Context, orderID string) error {
 		return fmt.Errorf("polymarket/clob: decode cancel response: %w", err)
 	}
 	if !result.Success {
-		return fmt.Errorf("polymarket/clob: cancel failed: %s", result.ErrorMsg)
+		return fmt.Errorf("polymarket/clob: cancel order %s: %w", orderID, domain.NewRejectedError(venueName, result.ErrorMsg))
 	}
 
 	return nil
@@ -118,7 +155,7 @@ func (c *ClobClient) CancelAll(ctx context.Context) error {
 		return fmt.Errorf("polymarket/clob: decode cancel-all response: %w", err)
 	}
 	if !result.Success {
-		return fmt.Errorf("polymarket/clob: cancel-all failed: %s", result.ErrorMsg)
+		return fmt.Errorf("polymarket/clob: cancel all: %w", domain.NewRejectedError(venueName, result.ErrorMsg))
 	}
 
 	return nil
@@ -141,6 +178,36 @@ func (c *ClobClient) GetOrder(ctx context.Context, orderID string) (domain.Order
 	return apiOrder.ToDomainOrder(), nil
 }
 
+// GetOrderAmounts retrieves a single order's price, sizes and amounts as the
+// CLOB reports them, without converting through float64.
+func (c *ClobClient) GetOrderAmounts(ctx context.Context, orderID string) (domain.VenueOrderAmounts, error) {
+	path := fmt.Sprintf("/order/%s", orderID)
+
+	respBody, err := c.doAuthenticatedRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return domain.VenueOrderAmounts{}, fmt.Errorf("polymarket/clob: get order %s: %w", orderID, err)
+	}
+
+	var apiOrder APIOrder
+	if err := json.Unmarshal(respBody, &apiOrder); err != nil {
+		return domain.VenueOrderAmounts{}, fmt.Errorf("polymarket/clob: decode order: %w", err)
+	}
+
+	side := domain.OrderSideBuy
+	if strings.EqualFold(apiOrder.Side, "SELL") {
+		side = domain.OrderSideSell
+	}
+	return domain.VenueOrderAmounts{
+		ExchangeID:   apiOrder.ID,
+		Side:         side,
+		Price:        apiOrder.Price,
+		OriginalSize: apiOrder.OriginalSize,
+		SizeMatched:  apiOrder.SizeMatched,
+		MakerAmount:  apiOrder.MakerAmount,
+		TakerAmount:  apiOrder.TakerAmount,
+	}, nil
+}
+
 // GetOpenOrders returns all open orders for the authenticated wallet.
 func (c *ClobClient) GetOpenOrders(ctx context.Context) ([]domain.Order, error) {
 	respBody, err := c.doAuthenticatedRequest(ctx, http.MethodGet, "/orders", nil)
@@ -161,6 +228,193 @@ func (c *ClobClient) GetOpenOrders(ctx context.Context) ([]domain.Order, error)
 	return orders, nil
 }
 
+// GetOrderbook returns the current orderbook of a token from the public
+// GET /book endpoint. The market data endpoints need no API key, so a
+// client built without a signer can call them.
+func (c *ClobClient) GetOrderbook(ctx context.Context, tokenID string) (domain.OrderbookSnapshot, error) {
+	var book BookMessage
+	if err := c.getMarketData(ctx, "/book?token_id="+url.QueryEscape(tokenID), &book); err != nil {
+		return domain.OrderbookSnapshot{}, fmt.Errorf("polymarket/clob: get book %s: %w", tokenID, err)
+	}
+	if book.AssetID == "" {
+		book.AssetID = tokenID
+	}
+
+	return BookToDomainSnapshot(&book), nil
+}
+
+// Ping checks that the CLOB API answers GET /.
+func (c *ClobClient) Ping(ctx context.Context) error {
+	if _, err := c.doAuthenticatedRequest(ctx, http.MethodGet, "/", nil); err != nil {
+		return fmt.Errorf("polymarket/clob: ping: %w", err)
+	}
+	return nil
+}
+
+// GetOrderIncrements returns the tick size and minimum order size of a
+// token, from the public GET /book endpoint. Fields the venue leaves out are
+// zero; SizeStep is not published and is left zero.
+func (c *ClobClient) GetOrderIncrements(ctx context.Context, tokenID string) (domain.OrderIncrements, error) {
+	var book BookMessage
+	if err := c.getMarketData(ctx, "/book?token_id="+url.QueryEscape(tokenID), &book); err != nil {
+		return domain.OrderIncrements{}, fmt.Errorf("polymarket/clob: get increments %s: %w", tokenID, err)
+	}
+	inc := domain.OrderIncrements{
+		TokenID:   tokenID,
+		Source:    domain.IncrementSourceVenue,
+		FetchedAt: time.Now().UTC(),
+	}
+	if book.TickSize != "" {
+		tick, err := parseMarketDataPrice(book.TickSize)
+		if err != nil {
+			return domain.OrderIncrements{}, fmt.Errorf("polymarket/clob: decode tick size %s: %w", tokenID, err)
+		}
+		inc.TickSize = tick
+	}
+	if book.MinOrderSize != "" {
+		minSize, err := parseMarketDataPrice(book.MinOrderSize)
+		if err != nil {
+			return domain.OrderIncrements{}, fmt.Errorf("polymarket/clob: decode min order size %s: %w", tokenID, err)
+		}
+		inc.MinOrderSize = minSize
+	}
+	return inc, nil
+}
+
+// GetMidpoint returns the midpoint of a token's best bid and ask from the
+// public GET /midpoint endpoint.
+func (c *ClobClient) GetMidpoint(ctx context.Context, tokenID string) (float64, error) {
+	var resp APIMidpoint
+	if err := c.getMarketData(ctx, "/midpoint?token_id="+url.QueryEscape(tokenID), &resp); err != nil {
+		return 0, fmt.Errorf("polymarket/clob: get midpoint %s: %w", tokenID, err)
+	}
+	mid, err := parseMarketDataPrice(resp.Mid)
+	if err != nil {
+		return 0, fmt.Errorf("polymarket/clob: decode midpoint %s: %w", tokenID, err)
+	}
+	return mid, nil
+}
+
+// GetPrice returns the best price resting on side of a token's book (the
+// best bid for a buy, the best ask for a sell) from the public GET /price
+// endpoint.
+func (c *ClobClient) GetPrice(ctx context.Context, tokenID string, side domain.OrderSide) (float64, error) {
+	path := "/price?token_id=" + url.QueryEscape(tokenID) + "&side=" + strings.ToUpper(string(side))
+	var resp APIPrice
+	if err := c.getMarketData(ctx, path, &resp); err != nil {
+		return 0, fmt.Errorf("polymarket/clob: get price %s %s: %w", tokenID, side, err)
+	}
+	price, err := parseMarketDataPrice(resp.Price)
+	if err != nil {
+		return 0, fmt.Errorf("polymarket/clob: decode price %s: %w", tokenID, err)
+	}
+	return price, nil
+}
+
+// GetSpread returns the difference between a token's best ask and best bid
+// from the public GET /spread endpoint.
+func (c *ClobClient) GetSpread(ctx context.Context, tokenID string) (float64, error) {
+	var resp APISpread
+	if err := c.getMarketData(ctx, "/spread?token_id="+url.QueryEscape(tokenID), &resp); err != nil {
+		return 0, fmt.Errorf("polymarket/clob: get spread %s: %w", tokenID, err)
+	}
+	spread, err := parseMarketDataPrice(resp.Spread)
+	if err != nil {
+		return 0, fmt.Errorf("polymarket/clob: decode spread %s: %w", tokenID, err)
+	}
+	return spread, nil
+}
+
+// getMarketData GETs a public market data endpoint and decodes the JSON
+// response into dst.
+func (c *ClobClient) getMarketData(ctx context.Context, path string, dst any) error {
+	respBody, err := c.doAuthenticatedRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(respBody, dst); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// builderTradesEnd is the next_cursor of the last page of a paginated CLOB
+// endpoint.
+const builderTradesEnd = "LTE="
+
+// GetBuilderTrades returns a page of the trades attributed to the builder
+// credentials set by WithBuilder, matched after after (zero = all), and the
+// cursor of the next page; the cursor is empty after the last page.
+func (c *ClobClient) GetBuilderTrades(ctx context.Context, after time.Time, cursor string) ([]domain.BuilderTrade, string, error) {
+	if c.builder == nil {
+		return nil, "", fmt.Errorf("polymarket/clob: builder trades: builder credentials not set")
+	}
+	const path = "/builder/trades"
+	q := url.Values{}
+	if !after.IsZero() {
+		q.Set("after", strconv.FormatInt(after.Unix(), 10))
+	}
+	if cursor != "" {
+		q.Set("next_cursor", cursor)
+	}
+	rawURL := c.baseURL + path
+	if len(q) > 0 {
+		rawURL += "?" + q.Encode()
+	}
+
+	respBody, err := retry.Value(ctx, c.retrier, "polymarket.clob", func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		for k, v := range c.builder.BuilderHeaders(http.MethodGet, path, "") {
+			req.Header.Set(k, v)
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, domain.NewTransportError(venueName, err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read response: %w", err)
+		}
+		if err := checkHTTPStatus(resp.StatusCode, body); err != nil {
+			return nil, err
+		}
+		return body, nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("polymarket/clob: builder trades: %w", err)
+	}
+
+	var page APIBuilderTradesPage
+	if err := json.Unmarshal(respBody, &page); err != nil {
+		return nil, "", fmt.Errorf("polymarket/clob: decode builder trades: %w", err)
+	}
+	trades := make([]domain.BuilderTrade, 0, len(page.Data))
+	for i := range page.Data {
+		trades = append(trades, page.Data[i].ToDomainBuilderTrade())
+	}
+	next := page.NextCursor
+	if next == builderTradesEnd || next == cursor {
+		next = ""
+	}
+	return trades, next, nil
+}
+
+// parseMarketDataPrice parses a decimal string from a market data response.
+func parseMarketDataPrice(s string) (float64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty value")
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
 // DeriveAPIKey performs the CLOB auth flow to obtain an HMAC API key. It
 // signs a ClobAuth EIP-712 message and sends it with L1 headers to the
 // derive-api-key endpoint. Per Polymarket docs, L1 requires POLY_ADDRESS,
@@ -187,7 +441,7 @@ func (c *ClobClient) DeriveAPIKey(ctx context.Context) error {
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("polymarket/clob: auth request: %w", err)
+		return fmt.Errorf("polymarket/clob: auth request: %w", domain.NewTransportError(venueName, err))
 	}
 	defer resp.Body.Close()
 
@@ -197,7 +451,7 @@ func (c *ClobClient) DeriveAPIKey(ctx context.Context) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("polymarket/clob: auth failed (HTTP %d): %s", resp.StatusCode, string(respBody))
+		return fmt.Errorf("polymarket/clob: auth failed: %w", domain.NewHTTPError(venueName, resp.StatusCode, string(respBody)))
 	}
 
 	var authResp struct {
@@ -218,13 +472,32 @@ func (c *ClobClient) DeriveAPIKey(ctx context.Context) error {
 	return nil
 }
 
+// Credentials returns the L2 API credentials obtained by DeriveAPIKey, or
+// false if the client is not authenticated.
+func (c *ClobClient) Credentials() (crypto.HMACAuth, bool) {
+	if c.hmacAuth == nil {
+		return crypto.HMACAuth{}, false
+	}
+	return *c.hmacAuth, true
+}
+
 // --------------------------------------------------------------------------
 // Internal helpers
 // --------------------------------------------------------------------------
 
 // doAuthenticatedRequest builds, signs (HMAC), sends, and reads an HTTP
-// request against the CLOB API. It returns the raw response body.
+// request against the CLOB API. It returns the raw response body. Idempotent
+// requests go through the client's retrier, signed afresh on every attempt.
 func (c *ClobClient) doAuthenticatedRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
+	if !retry.Idempotent(method) {
+		return c.doAuthenticatedRequestOnce(ctx, method, path, body)
+	}
+	return retry.Value(ctx, c.retrier, "polymarket.clob", func() ([]byte, error) {
+		return c.doAuthenticatedRequestOnce(ctx, method, path, body)
+	})
+}
+
+func (c *ClobClient) doAuthenticatedRequestOnce(ctx context.Context, method, path string, body any) ([]byte, error) {
 	var bodyReader io.Reader
 	var bodyStr string
 
@@ -255,9 +528,16 @@ func (c *ClobClient) doAuthenticatedRequest(ctx context.Context, method, path st
 		}
 	}
 
+	// Attribute order submissions to the builder program.
+	if c.builder != nil && method == http.MethodPost && path == "/order" {
+		for k, v := range c.builder.BuilderHeaders(method, path, bodyStr) {
+			req.Header.Set(k, v)
+		}
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return nil, domain.NewTransportError(venueName, err)
 	}
 	defer resp.Body.Close()
 
@@ -273,21 +553,11 @@ func (c *ClobClient) doAuthenticatedRequest(ctx context.Context, method, path st
 	return respBody, nil
 }
 
-// checkHTTPStatus maps non-2xx status codes to appropriate domain errors.
+// checkHTTPStatus maps non-2xx status codes to a classified
+// *domain.VenueError (see domain.HTTPStatusClass).
 func checkHTTPStatus(statusCode int, body []byte) error {
 	if statusCode >= 200 && statusCode < 300 {
 		return nil
 	}
-
-	bodyStr := string(body)
-	switch statusCode {
-	case http.StatusNotFound:
-		return fmt.Errorf("%w: %s", domain.ErrNotFound, bodyStr)
-	case http.StatusUnauthorized, http.StatusForbidden:
-		return fmt.Errorf("%w: %s", domain.ErrUnauthorized, bodyStr)
-	case http.StatusTooManyRequests:
-		return fmt.Errorf("%w: %s", domain.ErrRateLimited, bodyStr)
-	default:
-		return fmt.Errorf("HTTP %d: %s", statusCode, bodyStr)
-	}
+	return domain.NewHTTPError(venueName, statusCode, string(body))
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/retry"
 )
 
 // GammaClient is the REST client for the Polymarket Gamma API, which
@@ -18,6 +19,7 @@ import (
 type GammaClient struct {
 	baseURL    string
 	httpClient *http.Client
+	retrier    *retry.Retrier
 }
 
 // NewGammaClient creates a new Gamma API client.
@@ -32,30 +34,92 @@ func NewGammaClient(baseURL string) *GammaClient {
 	}
 }
 
+// WithTransport sends every request through rt, e.g. a rate-limiting
+// ratelimit.Transport.
+func (g *GammaClient) WithTransport(rt http.RoundTripper) *GammaClient {
+	g.httpClient.Transport = rt
+	return g
+}
+
+// WithRetry retries requests that fail with a transient or rate-limited
+// error.
+func (g *GammaClient) WithRetry(r *retry.Retrier) *GammaClient {
+	g.retrier = r
+	return g
+}
+
+// GammaOrderUpdatedAt sorts GET /markets by last update.
+const GammaOrderUpdatedAt = "updatedAt"
+
+// MarketsQuery selects a page of GET /markets.
+type MarketsQuery struct {
+	Limit  int
+	Offset int
+	// Order is the field to sort by (e.g. GammaOrderUpdatedAt); empty keeps
+	// the API's default order.
+	Order     string
+	Ascending bool
+
+	// ETag and LastModified are the validators of a previous response to
+	// the same query, sent as If-None-Match and If-Modified-Since.
+	ETag         string
+	LastModified string
+}
+
+// MarketsPage is one page of GET /markets.
+type MarketsPage struct {
+	Markets []domain.Market
+	// ETag and LastModified validate the page for a later conditional
+	// request; empty when the API sent none.
+	ETag         string
+	LastModified string
+	// NotModified is set when the API answered 304 to a conditional
+	// request: Markets is empty and the previous page still holds.
+	NotModified bool
+}
+
 // GetMarkets returns a paginated list of markets.
 func (g *GammaClient) GetMarkets(ctx context.Context, limit, offset int) ([]domain.Market, error) {
+	page, err := g.GetMarketsPage(ctx, MarketsQuery{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return page.Markets, nil
+}
+
+// GetMarketsPage returns one page of markets, conditionally when q carries
+// the validators of a previous response.
+func (g *GammaClient) GetMarketsPage(ctx context.Context, q MarketsQuery) (MarketsPage, error) {
 	params := url.Values{}
-	params.Set("limit", strconv.Itoa(limit))
-	params.Set("offset", strconv.Itoa(offset))
+	params.Set("limit", strconv.Itoa(q.Limit))
+	params.Set("offset", strconv.Itoa(q.Offset))
+	if q.Order != "" {
+		params.Set("order", q.Order)
+		params.Set("ascending", strconv.FormatBool(q.Ascending))
+	}
 
 	path := "/markets?" + params.Encode()
 
-	body, err := g.doGet(ctx, path)
+	resp, err := g.doGetConditional(ctx, path, q.ETag, q.LastModified)
 	if err != nil {
-		return nil, fmt.Errorf("polymarket/gamma: get markets: %w", err)
+		return MarketsPage{}, fmt.Errorf("polymarket/gamma: get markets: %w", err)
+	}
+	page := MarketsPage{ETag: resp.etag, LastModified: resp.lastModified, NotModified: resp.notModified}
+	if resp.notModified {
+		return page, nil
 	}
 
 	var apiMarkets []APIMarket
-	if err := json.Unmarshal(body, &apiMarkets); err != nil {
-		return nil, fmt.Errorf("polymarket/gamma: decode markets: %w", err)
+	if err := json.Unmarshal(resp.body, &apiMarkets); err != nil {
+		return MarketsPage{}, fmt.Errorf("polymarket/gamma: decode markets: %w", err)
 	}
 
-	markets := make([]domain.Market, 0, len(apiMarkets))
+	page.Markets = make([]domain.Market, 0, len(apiMarkets))
 	for i := range apiMarkets {
-		markets = append(markets, apiMarkets[i].ToDomainMarket())
+		page.Markets = append(page.Markets, apiMarkets[i].ToDomainMarket())
 	}
 
-	return markets, nil
+	return page, nil
 }
 
 // GetMarket returns a single market by its ID.
@@ -77,12 +141,13 @@ func (g *GammaClient) GetMarket(ctx context.Context, id string) (domain.Market,
 
 // MarketResolution holds resolution state for a market (for bond tracking).
 type MarketResolution struct {
-	Closed   bool // market is closed/settled
-	YesWon   bool // the Yes outcome won (only meaningful when Closed)
+	Closed        bool   // market is closed/settled
+	YesWon        bool   // the Yes outcome won (only meaningful when Closed)
+	WinnerTokenID string // token of the winning outcome, binary or categorical ("" if unknown)
 }
 
 // GetMarketResolution fetches market by ID and returns whether it is closed and whether Yes won.
-// Used by BondTracker to update bond positions on resolution.
+// Used by BondTracker and ResolutionWatcher to settle positions on resolution.
 func (g *GammaClient) GetMarketResolution(ctx context.Context, marketID string) (MarketResolution, error) {
 	path := fmt.Sprintf("/markets/%s", url.PathEscape(marketID))
 	body, err := g.doGet(ctx, path)
@@ -95,14 +160,38 @@ func (g *GammaClient) GetMarketResolution(ctx context.Context, marketID string)
 	}
 	res := MarketResolution{Closed: apiMarket.Closed}
 	for _, t := range apiMarket.Tokens {
-		if t.Outcome == "Yes" && t.Winner {
-			res.YesWon = true
-			break
+		if !t.Winner {
+			continue
 		}
+		res.WinnerTokenID = t.TokenID
+		res.YesWon = t.Outcome == "Yes"
+		break
 	}
 	return res, nil
 }
 
+// GetFeeSchedule returns the maker and taker base fees of a market and
+// whether it pays maker rewards. Rebates are not part of the venue response.
+func (g *GammaClient) GetFeeSchedule(ctx context.Context, marketID string) (domain.FeeSchedule, error) {
+	path := fmt.Sprintf("/markets/%s", url.PathEscape(marketID))
+	body, err := g.doGet(ctx, path)
+	if err != nil {
+		return domain.FeeSchedule{}, fmt.Errorf("polymarket/gamma: get market %s: %w", marketID, err)
+	}
+	var apiMarket APIMarket
+	if err := json.Unmarshal(body, &apiMarket); err != nil {
+		return domain.FeeSchedule{}, fmt.Errorf("polymarket/gamma: decode market: %w", err)
+	}
+	return domain.FeeSchedule{
+		MarketID:        marketID,
+		MakerFeeBps:     apiMarket.MakerBaseFee,
+		TakerFeeBps:     apiMarket.TakerBaseFee,
+		RewardsEligible: apiMarket.RewardsMinSize > 0,
+		Source:          domain.FeeSourceVenue,
+		FetchedAt:       time.Now().UTC(),
+	}, nil
+}
+
 // GetMarketBySlug returns a single market looked up by its URL slug.
 func (g *GammaClient) GetMarketBySlug(ctx context.Context, slug string) (domain.Market, error) {
 	params := url.Values{}
@@ -154,10 +243,13 @@ func (g *GammaClient) SearchMarkets(ctx context.Context, query string) ([]domain
 }
 
 // RewardEligibleMarket holds market ID and reward-related fields for LP strategy.
+// RewardsMaxSpread is in cents; DailyRate sums the market's reward programs
+// in USD per day.
 type RewardEligibleMarket struct {
 	MarketID       string
 	RewardsMinSize float64
 	RewardsMaxSpread float64
+	DailyRate      float64
 	Volume         float64
 }
 
@@ -193,12 +285,22 @@ func (g *GammaClient) ListRewardEligibleMarkets(ctx context.Context, minVolume f
 			MarketID:         m.ID,
 			RewardsMinSize:   m.RewardsMinSize,
 			RewardsMaxSpread: m.RewardsMaxSpread,
+			DailyRate:        dailyRate(m.ClobRewards),
 			Volume:           vol,
 		})
 	}
 	return out, nil
 }
 
+// dailyRate sums the daily rates of a market's reward programs.
+func dailyRate(rewards []APIClobReward) float64 {
+	var total float64
+	for _, r := range rewards {
+		total += r.RewardsDailyRate
+	}
+	return total
+}
+
 // GetEvents returns a paginated list of events from the Gamma API.
 func (g *GammaClient) GetEvents(ctx context.Context, limit, offset int) ([]APIEvent, error) {
 	params := url.Values{}
@@ -243,26 +345,72 @@ func (g *GammaClient) GetEvent(ctx context.Context, id string) (APIEvent, error)
 
 // doGet sends an unauthenticated GET request to the Gamma API.
 func (g *GammaClient) doGet(ctx context.Context, path string) ([]byte, error) {
+	resp, err := g.doGetConditional(ctx, path, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.body, nil
+}
+
+// gammaResponse is the body of a GET and its cache validators.
+type gammaResponse struct {
+	body         []byte
+	etag         string
+	lastModified string
+	notModified  bool
+}
+
+// doGetConditional sends a GET with If-None-Match / If-Modified-Since when
+// etag / lastModified are set. A 304 is returned as notModified, not as an
+// error. Failed requests are retried per the client's retrier.
+func (g *GammaClient) doGetConditional(ctx context.Context, path, etag, lastModified string) (gammaResponse, error) {
+	return retry.Value(ctx, g.retrier, "polymarket.gamma", func() (gammaResponse, error) {
+		return g.doGetConditionalOnce(ctx, path, etag, lastModified)
+	})
+}
+
+func (g *GammaClient) doGetConditionalOnce(ctx context.Context, path, etag, lastModified string) (gammaResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return gammaResponse{}, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Accept", "application/json")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
 
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http request: %w", err)
+		return gammaResponse{}, domain.NewTransportError(venueName, err)
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	out := gammaResponse{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		out.notModified = true
+		if out.etag == "" {
+			out.etag = etag
+		}
+		if out.lastModified == "" {
+			out.lastModified = lastModified
+		}
+		return out, nil
+	}
+
+	out.body, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return gammaResponse{}, fmt.Errorf("read response: %w", err)
 	}
 
-	if err := checkHTTPStatus(resp.StatusCode, body); err != nil {
-		return nil, err
+	if err := checkHTTPStatus(resp.StatusCode, out.body); err != nil {
+		return gammaResponse{}, err
 	}
 
-	return body, nil
+	return out, nil
 }
@@ -2,6 +2,7 @@ package polymarket
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
@@ -65,6 +66,46 @@ type APIOrderResult struct {
 	Status      string `json:"status,omitempty"`
 	TransactID  string `json:"transactID,omitempty"`
 	ShouldRetry bool   `json:"shouldRetry,omitempty"`
+	// MakingAmount and TakingAmount are what the order gave and received
+	// matching on submission: shares and USDC, or USDC and shares for a buy.
+	MakingAmount string `json:"makingAmount,omitempty"`
+	TakingAmount string `json:"takingAmount,omitempty"`
+}
+
+// APIMidpoint is the response of GET /midpoint.
+type APIMidpoint struct {
+	Mid string `json:"mid"`
+}
+
+// APIPrice is the response of GET /price.
+type APIPrice struct {
+	Price string `json:"price"`
+}
+
+// APISpread is the response of GET /spread.
+type APISpread struct {
+	Spread string `json:"spread"`
+}
+
+// APIBuilderTrade is a trade attributed to a builder, as returned by GET
+// /builder/trades.
+type APIBuilderTrade struct {
+	ID        string `json:"id"`
+	Market    string `json:"market"`
+	AssetID   string `json:"assetId"`
+	Side      string `json:"side"`
+	Price     string `json:"price"`
+	Size      string `json:"size"`
+	SizeUSDC  string `json:"sizeUsdc"`
+	FeeUSDC   string `json:"feeUsdc"`
+	Status    string `json:"status"`
+	MatchTime string `json:"matchTime"`
+}
+
+// APIBuilderTradesPage is the response of GET /builder/trades.
+type APIBuilderTradesPage struct {
+	Data       []APIBuilderTrade `json:"data"`
+	NextCursor string            `json:"next_cursor"`
 }
 
 // --------------------------------------------------------------------------
@@ -81,10 +122,38 @@ type APIEvent struct {
 	Active      flexBool    `json:"active"`
 	Closed      bool        `json:"closed"`
 	Markets     []APIMarket `json:"markets"`
+	Tags        []APITag    `json:"tags"`
 	CreatedAt   string      `json:"created_at"`
 	UpdatedAt   string      `json:"updated_at"`
 }
 
+// APITag is a Gamma category tag on an event or market.
+type APITag struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Slug  string `json:"slug"`
+}
+
+// TagSlugs returns the lower-case slugs of tags (the label when a tag has no
+// slug), without duplicates.
+func TagSlugs(tags []APITag) []string {
+	var out []string
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		slug := t.Slug
+		if slug == "" {
+			slug = strings.ReplaceAll(strings.TrimSpace(t.Label), " ", "-")
+		}
+		slug = strings.ToLower(slug)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		out = append(out, slug)
+	}
+	return out
+}
+
 // ToDomainConditionGroup converts an APIEvent to a domain.ConditionGroup.
 func (e *APIEvent) ToDomainConditionGroup() domain.ConditionGroup {
 	cg := domain.ConditionGroup{
@@ -131,10 +200,22 @@ type APIMarket struct {
 	ClobTokenIDs           string  `json:"clob_token_ids"` // JSON-encoded: e.g. "[\"123\",\"456\"]"
 	RewardsMinSize         float64 `json:"rewards_min_size"`
 	RewardsMaxSpread       float64 `json:"rewards_max_spread"`
+	ClobRewards            []APIClobReward `json:"clobRewards"`
 	SpreadBenefitBasisPts  float64 `json:"spread"`
+	MakerBaseFee           float64 `json:"makerBaseFee"` // bps of notional
+	TakerBaseFee           float64 `json:"takerBaseFee"` // bps of notional
+	Tags                   []APITag `json:"tags"`
 	Active                 bool    `json:"is_active"`
 }
 
+// APIClobReward is one liquidity reward program of a Gamma market.
+type APIClobReward struct {
+	ID               string  `json:"id"`
+	RewardsDailyRate float64 `json:"rewardsDailyRate"` // USD per day
+	StartDate        string  `json:"startDate"`
+	EndDate          string  `json:"endDate"`
+}
+
 // Token represents a token entry inside the Gamma API market response.
 type Token struct {
 	TokenID  string `json:"token_id"`
@@ -171,6 +252,9 @@ type BookMessage struct {
 	Asks      []WSPriceLevel  `json:"asks"`
 	Timestamp string          `json:"timestamp"`
 	Hash      string          `json:"hash"`
+	// TickSize and MinOrderSize are only set on REST GET /book responses.
+	TickSize     string `json:"tick_size"`
+	MinOrderSize string `json:"min_order_size"`
 }
 
 // WSPriceLevel is a single bid/ask level in the WebSocket orderbook data.
@@ -195,9 +279,49 @@ type PriceMessage struct {
 	Market    string `json:"market"`
 	Price     string `json:"price"`
 	Size      string `json:"size"`
+	Side      string `json:"side"` // taker side, "BUY" or "SELL"
 	Timestamp string `json:"timestamp"`
 }
 
+// UserTradeMessage is a "trade" event on the authenticated user channel. It
+// is sent once per match and again on each settlement status change.
+type UserTradeMessage struct {
+	ID           string           `json:"id"`
+	TakerOrderID string           `json:"taker_order_id"`
+	Market       string           `json:"market"`
+	AssetID      string           `json:"asset_id"`
+	Side         string           `json:"side"`
+	Size         string           `json:"size"`
+	Price        string           `json:"price"`
+	Status       string           `json:"status"` // MATCHED, MINED, CONFIRMED, RETRYING, FAILED
+	Owner        string           `json:"owner"`  // API key of the taker
+	MakerOrders  []UserMakerOrder `json:"maker_orders"`
+	Timestamp    string           `json:"timestamp"`
+}
+
+// UserMakerOrder is one resting order filled by a trade.
+type UserMakerOrder struct {
+	OrderID       string `json:"order_id"`
+	AssetID       string `json:"asset_id"`
+	MatchedAmount string `json:"matched_amount"`
+	Price         string `json:"price"`
+	Owner         string `json:"owner"` // API key of the maker
+}
+
+// UserOrderMessage is an "order" event on the authenticated user channel.
+type UserOrderMessage struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	Market       string `json:"market"`
+	AssetID      string `json:"asset_id"`
+	Side         string `json:"side"`
+	OriginalSize string `json:"original_size"`
+	SizeMatched  string `json:"size_matched"`
+	Price        string `json:"price"`
+	Type         string `json:"type"` // PLACEMENT, UPDATE, CANCELLATION
+	Timestamp    string `json:"timestamp"`
+}
+
 // --------------------------------------------------------------------------
 // WebSocket subscription commands
 // --------------------------------------------------------------------------
@@ -210,6 +334,21 @@ type WSCommand struct {
 	Markets  []string `json:"markets,omitempty"`
 }
 
+// UserSubscribeCommand authenticates and subscribes to the user channel. An
+// empty Markets list subscribes to all markets.
+type UserSubscribeCommand struct {
+	Auth    UserAuth `json:"auth"`
+	Type    string   `json:"type"` // "user"
+	Markets []string `json:"markets,omitempty"`
+}
+
+// UserAuth carries the L2 API credentials for the user channel.
+type UserAuth struct {
+	APIKey     string `json:"apiKey"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
 // --------------------------------------------------------------------------
 // Conversion helpers: API types -> domain types
 // --------------------------------------------------------------------------
@@ -256,18 +395,21 @@ func (a *APIOrder) ToDomainOrder() domain.Order {
 		o.Status = domain.OrderStatusPending
 	}
 
-	// Price -> PriceTicks (fixed-point * 1e6)
+	// Price -> PriceTicks (fixed-point)
 	if price, err := strconv.ParseFloat(a.Price, 64); err == nil {
-		o.PriceTicks = int64(price * 1e6)
+		o.PriceTicks = domain.ToPriceTicks(price)
 	}
 
 	// Sizes
 	if orig, err := strconv.ParseFloat(a.OriginalSize, 64); err == nil {
-		o.SizeUnits = int64(orig * 1e6)
+		o.SizeUnits = domain.ToSizeUnits(orig)
 	}
 	if matched, err := strconv.ParseFloat(a.SizeMatched, 64); err == nil {
 		o.FilledSize = matched
 	}
+	if o.Status == domain.OrderStatusOpen && o.FilledSize > 0 {
+		o.Status = domain.OrderStatusPartiallyFilled
+	}
 
 	// MakerAmount/TakerAmount as big.Int
 	if ma, ok := new(big.Int).SetString(a.MakerAmount, 10); ok {
@@ -291,6 +433,11 @@ func (a *APIOrder) ToDomainOrder() domain.Order {
 			o.CancelledAt = &t
 		}
 	}
+	// Expiration is Unix seconds; "0" means the order never expires.
+	if exp, err := strconv.ParseInt(a.Expiration, 10, 64); err == nil && exp > 0 {
+		t := time.Unix(exp, 0).UTC()
+		o.ExpiresAt = &t
+	}
 
 	return o
 }
@@ -322,9 +469,25 @@ func (r *APIOrderResult) ToDomainOrderResult() domain.OrderResult {
 	return result
 }
 
-// ToDomainMarket converts a Gamma APIMarket to a domain.Market. Safe for event-scraper
-// upserts: defaults Question to "Unknown" and Outcomes to "Yes"/"No" when missing so
-// markets(id) exists before linking in condition_group_markets.
+// matchedShares returns the shares an order on side matched on submission:
+// what a buy received or a sell gave. It is 0 when nothing matched.
+func (r *APIOrderResult) matchedShares(side domain.OrderSide) float64 {
+	amount := r.TakingAmount
+	if side == domain.OrderSideSell {
+		amount = r.MakingAmount
+	}
+	shares, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return shares
+}
+
+// ToDomainMarket converts a Gamma APIMarket to a domain.Market with one
+// outcome per token, so categorical markets keep all of theirs. Safe for
+// event-scraper upserts: defaults Question to "Unknown" and binary Outcomes to
+// "Yes"/"No" when missing so markets(id) exists before linking in
+// condition_group_markets.
 func (m *APIMarket) ToDomainMarket() domain.Market {
 	dm := domain.Market{
 		ID:          m.ID,
@@ -332,7 +495,7 @@ func (m *APIMarket) ToDomainMarket() domain.Market {
 		Slug:        m.Slug,
 		ConditionID: m.ConditionID,
 		NegRisk:     m.NegRisk,
-		Outcomes:    [2]string{"Yes", "No"},
+		Tags:        TagSlugs(m.Tags),
 	}
 	if dm.Question == "" {
 		dm.Question = "Unknown"
@@ -352,14 +515,29 @@ func (m *APIMarket) ToDomainMarket() domain.Market {
 		dm.Status = domain.MarketStatusSettled
 	}
 
-	// Tokens: extract up to 2 token IDs and outcomes
-	for i, tok := range m.Tokens {
-		if i >= 2 {
-			break
+	// Tokens: prefer the tokens array, falling back to the JSON-encoded
+	// clob_token_ids/outcomes pair that Gamma returns on list endpoints.
+	var names []string
+	if len(m.Tokens) > 0 {
+		for _, tok := range m.Tokens {
+			dm.TokenIDs = append(dm.TokenIDs, tok.TokenID)
+			names = append(names, tok.Outcome)
 		}
-		dm.TokenIDs[i] = tok.TokenID
-		if tok.Outcome != "" {
-			dm.Outcomes[i] = tok.Outcome
+	} else {
+		_ = json.Unmarshal([]byte(m.ClobTokenIDs), &dm.TokenIDs)
+		_ = json.Unmarshal([]byte(m.Outcomes), &names)
+	}
+	if len(dm.TokenIDs) <= 2 {
+		dm.TokenIDs = append(dm.TokenIDs, make([]string, 2-len(dm.TokenIDs))...)
+		dm.Outcomes = []string{"Yes", "No"}
+	} else {
+		dm.Outcomes = make([]string, len(dm.TokenIDs))
+	}
+	for i := range dm.Outcomes {
+		if i < len(names) && names[i] != "" {
+			dm.Outcomes[i] = names[i]
+		} else if dm.Outcomes[i] == "" {
+			dm.Outcomes[i] = fmt.Sprintf("Outcome %d", i+1)
 		}
 	}
 
@@ -406,8 +584,8 @@ func BookToDomainSnapshot(b *BookMessage) domain.OrderbookSnapshot {
 		snap.MidPrice = (snap.BestBid + snap.BestAsk) / 2
 	}
 
-	if ts, err := strconv.ParseInt(b.Timestamp, 10, 64); err == nil {
-		snap.Timestamp = time.Unix(ts, 0)
+	if _, err := strconv.ParseInt(b.Timestamp, 10, 64); err == nil {
+		snap.Timestamp = parseUserTimestamp(b.Timestamp)
 	} else if t, err := time.Parse(time.RFC3339, b.Timestamp); err == nil {
 		snap.Timestamp = t
 	} else {
@@ -426,11 +604,7 @@ func PriceChangeToDomain(p *PriceChangeMessage) domain.PriceChange {
 	pc.Price, _ = strconv.ParseFloat(p.Price, 64)
 	pc.Size, _ = strconv.ParseFloat(p.Size, 64)
 
-	if ts, err := strconv.ParseInt(p.Timestamp, 10, 64); err == nil {
-		pc.Timestamp = time.Unix(ts, 0)
-	} else {
-		pc.Timestamp = time.Now()
-	}
+	pc.Timestamp = parseUserTimestamp(p.Timestamp)
 
 	return pc
 }
@@ -442,12 +616,103 @@ func PriceToDomainLastTrade(p *PriceMessage) domain.LastTradePrice {
 	}
 	ltp.Price, _ = strconv.ParseFloat(p.Price, 64)
 	ltp.Size, _ = strconv.ParseFloat(p.Size, 64)
+	switch p.Side {
+	case "BUY":
+		ltp.Side = domain.OrderSideBuy
+	case "SELL":
+		ltp.Side = domain.OrderSideSell
+	}
+
+	ltp.Timestamp = parseUserTimestamp(p.Timestamp)
+
+	return ltp
+}
 
-	if ts, err := strconv.ParseInt(p.Timestamp, 10, 64); err == nil {
-		ltp.Timestamp = time.Unix(ts, 0)
+// UserTradeToFills extracts the fills of the wallet's own orders from a user
+// channel trade. apiKey identifies our orders among the taker and makers; when
+// empty every order in the trade is returned.
+func UserTradeToFills(t *UserTradeMessage, apiKey string) []domain.OrderFillEvent {
+	ts := parseUserTimestamp(t.Timestamp)
+	status := strings.ToUpper(t.Status)
+	var fills []domain.OrderFillEvent
+	if t.TakerOrderID != "" && (apiKey == "" || t.Owner == apiKey) {
+		f := domain.OrderFillEvent{
+			TradeID:    t.ID,
+			ExchangeID: t.TakerOrderID,
+			TokenID:    t.AssetID,
+			Status:     status,
+			Time:       ts,
+		}
+		f.Price, _ = strconv.ParseFloat(t.Price, 64)
+		f.Size, _ = strconv.ParseFloat(t.Size, 64)
+		fills = append(fills, f)
+	}
+	for _, mo := range t.MakerOrders {
+		if apiKey != "" && mo.Owner != apiKey {
+			continue
+		}
+		f := domain.OrderFillEvent{
+			TradeID:    t.ID,
+			ExchangeID: mo.OrderID,
+			TokenID:    mo.AssetID,
+			Maker:      true,
+			Status:     status,
+			Time:       ts,
+		}
+		f.Price, _ = strconv.ParseFloat(mo.Price, 64)
+		f.Size, _ = strconv.ParseFloat(mo.MatchedAmount, 64)
+		fills = append(fills, f)
+	}
+	return fills
+}
+
+// UserOrderToDomain converts a user channel order event.
+func UserOrderToDomain(o *UserOrderMessage) domain.OrderUpdateEvent {
+	ev := domain.OrderUpdateEvent{
+		ExchangeID: o.ID,
+		TokenID:    o.AssetID,
+		Type:       strings.ToLower(o.Type),
+		Time:       parseUserTimestamp(o.Timestamp),
+	}
+	ev.OriginalSize, _ = strconv.ParseFloat(o.OriginalSize, 64)
+	ev.SizeMatched, _ = strconv.ParseFloat(o.SizeMatched, 64)
+	return ev
+}
+
+// ToDomainBuilderTrade converts an APIBuilderTrade to a domain.BuilderTrade.
+func (t *APIBuilderTrade) ToDomainBuilderTrade() domain.BuilderTrade {
+	bt := domain.BuilderTrade{
+		ID:       t.ID,
+		MarketID: t.Market,
+		TokenID:  t.AssetID,
+		Status:   strings.ToUpper(t.Status),
+	}
+	switch strings.ToUpper(t.Side) {
+	case "BUY":
+		bt.Side = domain.OrderSideBuy
+	case "SELL":
+		bt.Side = domain.OrderSideSell
+	}
+	bt.Price, _ = strconv.ParseFloat(t.Price, 64)
+	bt.Size, _ = strconv.ParseFloat(t.Size, 64)
+	bt.SizeUSDC, _ = strconv.ParseFloat(t.SizeUSDC, 64)
+	bt.FeeUSDC, _ = strconv.ParseFloat(t.FeeUSDC, 64)
+	if ts, err := time.Parse(time.RFC3339, t.MatchTime); err == nil {
+		bt.MatchedAt = ts
 	} else {
-		ltp.Timestamp = time.Now()
+		bt.MatchedAt = parseUserTimestamp(t.MatchTime)
 	}
+	return bt
+}
 
-	return ltp
+// parseUserTimestamp parses a unix timestamp in seconds or milliseconds.
+func parseUserTimestamp(s string) time.Time {
+	ts, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Now()
+	}
+	if ts > 1e12 {
+		return time.UnixMilli(ts)
+	}
+	return time.Unix(ts, 0)
 }
@@ -0,0 +1,194 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/crypto"
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/gorilla/websocket"
+)
+
+// FillHandler is called for each fill of one of the wallet's orders.
+type FillHandler func(domain.OrderFillEvent)
+
+// OrderUpdateHandler is called for each order lifecycle event.
+type OrderUpdateHandler func(domain.OrderUpdateEvent)
+
+// UserChannelURL derives the user channel endpoint from the configured
+// WebSocket host, e.g. "wss://ws-subscriptions-clob.polymarket.com" or
+// ".../ws/market" both become ".../ws/user".
+func UserChannelURL(wsHost string) string {
+	u := strings.TrimRight(wsHost, "/")
+	switch {
+	case strings.HasSuffix(u, "/ws/user"):
+		return u
+	case strings.HasSuffix(u, "/ws/market"):
+		return strings.TrimSuffix(u, "market") + "user"
+	case strings.HasSuffix(u, "/ws"):
+		return u + "/user"
+	default:
+		return u + "/ws/user"
+	}
+}
+
+// UserWSClient is a client for the authenticated CLOB user channel, which
+// streams order and trade events for the API key's wallet. Unlike WSClient it
+// does not reconnect on its own: Run serves one connection and returns when
+// it drops, leaving retry policy to the caller.
+type UserWSClient struct {
+	wsURL string
+	auth  crypto.HMACAuth
+
+	handlerMu     sync.RWMutex
+	fillHandlers  []FillHandler
+	orderHandlers []OrderUpdateHandler
+}
+
+// NewUserWSClient creates a user channel client. auth holds the L2 API
+// credentials obtained from ClobClient.DeriveAPIKey.
+func NewUserWSClient(wsURL string, auth crypto.HMACAuth) *UserWSClient {
+	return &UserWSClient{wsURL: wsURL, auth: auth}
+}
+
+// OnFill registers a handler for fills of the wallet's orders.
+func (u *UserWSClient) OnFill(handler FillHandler) {
+	u.handlerMu.Lock()
+	defer u.handlerMu.Unlock()
+	u.fillHandlers = append(u.fillHandlers, handler)
+}
+
+// OnOrderUpdate registers a handler for order placement, update and
+// cancellation events.
+func (u *UserWSClient) OnOrderUpdate(handler OrderUpdateHandler) {
+	u.handlerMu.Lock()
+	defer u.handlerMu.Unlock()
+	u.orderHandlers = append(u.orderHandlers, handler)
+}
+
+// Run connects, authenticates and dispatches events until ctx is cancelled or
+// the connection fails. It always returns a non-nil error.
+func (u *UserWSClient) Run(ctx context.Context) error {
+	dialer := websocket.Dialer{HandshakeTimeout: 15 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, u.wsURL, nil)
+	if err != nil {
+		return fmt.Errorf("polymarket/user_ws: connect: %w", err)
+	}
+	defer conn.Close()
+
+	sub := UserSubscribeCommand{
+		Auth: UserAuth{APIKey: u.auth.Key, Secret: u.auth.Secret, Passphrase: u.auth.Passphrase},
+		Type: "user",
+	}
+	conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := conn.WriteJSON(sub); err != nil {
+		return fmt.Errorf("polymarket/user_ws: subscribe: %w", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	// Close the connection on cancellation to unblock ReadMessage, and keep
+	// it alive with pings meanwhile. Writes happen only on this goroutine
+	// after the subscribe above.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(pingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(writeWait))
+				_ = conn.Close()
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+					_ = conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("polymarket/user_ws: %w: %v", domain.ErrWSDisconnect, err)
+		}
+		u.handleMessage(raw)
+	}
+}
+
+// handleMessage routes a frame, which may be a single event or an array of
+// events, to the registered handlers.
+func (u *UserWSClient) handleMessage(raw []byte) {
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "[") {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(raw, &batch); err != nil {
+			return
+		}
+		for _, m := range batch {
+			u.handleEvent(m)
+		}
+		return
+	}
+	u.handleEvent(raw)
+}
+
+func (u *UserWSClient) handleEvent(raw []byte) {
+	var envelope struct {
+		Event string `json:"event_type"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return // Silently drop unparseable messages.
+	}
+
+	switch envelope.Event {
+	case "trade":
+		var msg UserTradeMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return
+		}
+		fills := UserTradeToFills(&msg, u.auth.Key)
+
+		u.handlerMu.RLock()
+		handlers := u.fillHandlers
+		u.handlerMu.RUnlock()
+
+		for _, f := range fills {
+			for _, h := range handlers {
+				h(f)
+			}
+		}
+
+	case "order":
+		var msg UserOrderMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return
+		}
+		ev := UserOrderToDomain(&msg)
+
+		u.handlerMu.RLock()
+		handlers := u.orderHandlers
+		u.handlerMu.RUnlock()
+
+		for _, h := range handlers {
+			h(ev)
+		}
+	}
+}
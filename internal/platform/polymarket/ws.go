@@ -37,6 +37,20 @@ type PriceChangeHandler func(domain.PriceChange)
 // LastTradePriceHandler is called when a last trade price message is received.
 type LastTradePriceHandler func(domain.LastTradePrice)
 
+// ConnState is a WSClient connection lifecycle event.
+type ConnState int
+
+const (
+	// ConnLost means the connection failed and the client is reconnecting.
+	ConnLost ConnState = iota
+	// ConnRestored means the client reconnected and restored its subscriptions.
+	ConnRestored
+)
+
+// ConnStateHandler is called on connection lifecycle events. err is the
+// read error for ConnLost and nil otherwise.
+type ConnStateHandler func(state ConnState, err error)
+
 // WSClient is a WebSocket client for the Polymarket CLOB real-time data feed.
 // It manages the connection lifecycle, subscriptions, and dispatches messages
 // to registered handlers.
@@ -54,6 +68,7 @@ type WSClient struct {
 	bookHandlers      []BookUpdateHandler
 	priceHandlers     []PriceChangeHandler
 	lastTradeHandlers []LastTradePriceHandler
+	connHandlers      []ConnStateHandler
 	handlerMu         sync.RWMutex
 
 	// done is closed when the client is shut down.
@@ -241,6 +256,14 @@ func (w *WSClient) OnLastTradePrice(handler LastTradePriceHandler) {
 	w.lastTradeHandlers = append(w.lastTradeHandlers, handler)
 }
 
+// OnConnState registers a handler that is called when the connection is lost
+// and when it has been re-established by the automatic reconnect.
+func (w *WSClient) OnConnState(handler ConnStateHandler) {
+	w.handlerMu.Lock()
+	defer w.handlerMu.Unlock()
+	w.connHandlers = append(w.connHandlers, handler)
+}
+
 // --------------------------------------------------------------------------
 // Internal methods
 // --------------------------------------------------------------------------
@@ -261,14 +284,15 @@ func (w *WSClient) sendCommand(cmd WSCommand) error {
 // them to the appropriate handlers. It runs in its own goroutine.
 // On disconnect, it attempts to reconnect with exponential backoff.
 func (w *WSClient) readLoop() {
-	defer func() {
-		w.mu.RLock()
-		conn := w.conn
-		w.mu.RUnlock()
-		if conn != nil {
-			conn.Close()
-		}
-	}()
+	// Each loop owns the connection it was started for; after a reconnect
+	// w.conn is the replacement, which must stay open.
+	w.mu.RLock()
+	conn := w.conn
+	w.mu.RUnlock()
+	if conn == nil {
+		return
+	}
+	defer conn.Close()
 
 	for {
 		select {
@@ -277,14 +301,6 @@ func (w *WSClient) readLoop() {
 		default:
 		}
 
-		w.mu.RLock()
-		conn := w.conn
-		w.mu.RUnlock()
-
-		if conn == nil {
-			return
-		}
-
 		_, message, err := conn.ReadMessage()
 		if err != nil {
 			// Check if we've been shut down.
@@ -295,6 +311,7 @@ func (w *WSClient) readLoop() {
 			}
 
 			// Attempt reconnection.
+			w.notifyConnState(ConnLost, err)
 			w.reconnect()
 			return // readLoop will be restarted by reconnect -> Connect
 		}
@@ -415,6 +432,7 @@ func (w *WSClient) reconnect() {
 		cancel()
 
 		if err == nil {
+			w.notifyConnState(ConnRestored, nil)
 			return
 		}
 
@@ -425,3 +443,14 @@ func (w *WSClient) reconnect() {
 		}
 	}
 }
+
+// notifyConnState calls the registered connection state handlers.
+func (w *WSClient) notifyConnState(state ConnState, err error) {
+	w.handlerMu.RLock()
+	handlers := w.connHandlers
+	w.handlerMu.RUnlock()
+
+	for _, h := range handlers {
+		h(state, err)
+	}
+}
@@ -0,0 +1,137 @@
+// Package predictit is a read-only client for the PredictIt public market
+// data API. PredictIt has no trading API, so quotes from it can only back
+// manually executed trades.
+package predictit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// venueName identifies PredictIt in *domain.VenueError.
+const venueName = "predictit"
+
+// Client is the REST client for the PredictIt market data API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new PredictIt client.
+//
+// baseURL is the API root, e.g. "https://www.predictit.org/api/marketdata".
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// GetMarket returns a single market with its contracts.
+func (c *Client) GetMarket(ctx context.Context, id int64) (Market, error) {
+	path := "/markets/" + url.PathEscape(strconv.FormatInt(id, 10))
+
+	body, err := c.doGet(ctx, path)
+	if err != nil {
+		return Market{}, fmt.Errorf("predictit: get market %d: %w", id, err)
+	}
+
+	var m Market
+	if err := json.Unmarshal(body, &m); err != nil {
+		return Market{}, fmt.Errorf("predictit: decode market: %w", err)
+	}
+	return m, nil
+}
+
+// Quote returns the YES/NO quote for ref, which is "marketID:contractID", or
+// just "marketID" for single-contract markets.
+func (c *Client) Quote(ctx context.Context, ref string) (domain.VenueQuote, error) {
+	marketPart, contractPart, hasContract := strings.Cut(ref, ":")
+	marketID, err := strconv.ParseInt(strings.TrimSpace(marketPart), 10, 64)
+	if err != nil {
+		return domain.VenueQuote{}, fmt.Errorf("predictit: invalid market ref %q", ref)
+	}
+
+	m, err := c.GetMarket(ctx, marketID)
+	if err != nil {
+		return domain.VenueQuote{}, err
+	}
+
+	var contract *Contract
+	switch {
+	case hasContract:
+		contractID, err := strconv.ParseInt(strings.TrimSpace(contractPart), 10, 64)
+		if err != nil {
+			return domain.VenueQuote{}, fmt.Errorf("predictit: invalid contract in ref %q", ref)
+		}
+		for i := range m.Contracts {
+			if m.Contracts[i].ID == contractID {
+				contract = &m.Contracts[i]
+				break
+			}
+		}
+	case len(m.Contracts) == 1:
+		contract = &m.Contracts[0]
+	default:
+		return domain.VenueQuote{}, fmt.Errorf("predictit: market %d has %d contracts; ref must name one as marketID:contractID", marketID, len(m.Contracts))
+	}
+	if contract == nil {
+		return domain.VenueQuote{}, fmt.Errorf("predictit: contract not found for ref %q: %w", ref, domain.ErrNotFound)
+	}
+	if !strings.EqualFold(contract.Status, "Open") {
+		return domain.VenueQuote{}, fmt.Errorf("predictit: contract %d is %s", contract.ID, contract.Status)
+	}
+
+	return domain.VenueQuote{
+		Venue:  domain.VenuePredictIt,
+		Ref:    ref,
+		YesAsk: deref(contract.BestBuyYesCost),
+		YesBid: deref(contract.BestSellYesCost),
+		NoAsk:  deref(contract.BestBuyNoCost),
+		NoBid:  deref(contract.BestSellNoCost),
+		At:     time.Now().UTC(),
+	}, nil
+}
+
+func deref(p *float64) float64 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// doGet sends an unauthenticated GET request to the market data API.
+func (c *Client) doGet(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, domain.NewTransportError(venueName, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, domain.NewHTTPError(venueName, resp.StatusCode, string(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,33 @@
+package predictit
+
+// --------------------------------------------------------------------------
+// PredictIt market data API DTOs
+// --------------------------------------------------------------------------
+
+// Market is a PredictIt market as returned by /marketdata/markets/{id}. A
+// market holds one or more contracts, each of which trades as its own
+// YES/NO pair.
+type Market struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	ShortName string     `json:"shortName"`
+	URL       string     `json:"url"`
+	Status    string     `json:"status"` // "Open", "Closed"
+	TimeStamp string     `json:"timeStamp"`
+	Contracts []Contract `json:"contracts"`
+}
+
+// Contract is one YES/NO contract within a market. Prices are in dollars
+// (0.01-0.99) and null when that side of the book is empty. "Buy" is the
+// price to take the offer, "Sell" the price received hitting the bid.
+type Contract struct {
+	ID              int64    `json:"id"`
+	Name            string   `json:"name"`
+	ShortName       string   `json:"shortName"`
+	Status          string   `json:"status"`
+	LastTradePrice  *float64 `json:"lastTradePrice"`
+	BestBuyYesCost  *float64 `json:"bestBuyYesCost"`
+	BestBuyNoCost   *float64 `json:"bestBuyNoCost"`
+	BestSellYesCost *float64 `json:"bestSellYesCost"`
+	BestSellNoCost  *float64 `json:"bestSellNoCost"`
+}
@@ -0,0 +1,113 @@
+// Package pricefeed polls public exchange tickers for the spot prices of the
+// crypto assets Polymarket's up/down markets settle on, and serves them to
+// strategies as domain.ReferencePriceProvider.
+package pricefeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Source is a public ticker API quoting spot prices in USD.
+type Source interface {
+	Name() string
+	// Prices returns the price of each asset it could quote, keyed by
+	// lower-case symbol. Assets it does not list are left out.
+	Prices(ctx context.Context, assets []string) (map[string]float64, error)
+}
+
+// BinanceSource reads Binance's public ticker, quoting assets against USDT.
+type BinanceSource struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewBinanceSource creates a BinanceSource.
+//
+// baseURL is the API root, e.g. "https://api.binance.com".
+func NewBinanceSource(baseURL string) *BinanceSource {
+	return &BinanceSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Name returns "binance".
+func (b *BinanceSource) Name() string { return "binance" }
+
+type binanceTicker struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+// Prices fetches every asset's <ASSET>USDT ticker in one request.
+func (b *BinanceSource) Prices(ctx context.Context, assets []string) (map[string]float64, error) {
+	symbols := make([]string, 0, len(assets))
+	bySymbol := make(map[string]string, len(assets))
+	for _, a := range assets {
+		s := strings.ToUpper(a) + "USDT"
+		symbols = append(symbols, s)
+		bySymbol[s] = a
+	}
+	list, err := json.Marshal(symbols)
+	if err != nil {
+		return nil, fmt.Errorf("binance: encode symbols: %w", err)
+	}
+	body, err := doGet(ctx, b.httpClient, b.Name(), b.baseURL+"/api/v3/ticker/price?symbols="+url.QueryEscape(string(list)))
+	if err != nil {
+		return nil, fmt.Errorf("binance: ticker: %w", err)
+	}
+	var tickers []binanceTicker
+	if err := json.Unmarshal(body, &tickers); err != nil {
+		return nil, fmt.Errorf("binance: decode ticker: %w", err)
+	}
+	out := make(map[string]float64, len(tickers))
+	for _, t := range tickers {
+		asset, ok := bySymbol[t.Symbol]
+		if !ok {
+			continue
+		}
+		p, err := strconv.ParseFloat(t.Price, 64)
+		if err != nil || p <= 0 {
+			continue
+		}
+		out[asset] = p
+	}
+	return out, nil
+}
+
+// doGet sends an unauthenticated GET request and returns the body, mapping
+// failures to *domain.VenueError for venue.
+func doGet(ctx context.Context, client *http.Client, venue, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, domain.NewTransportError(venue, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, domain.NewHTTPError(venue, resp.StatusCode, string(body))
+	}
+	return body, nil
+}
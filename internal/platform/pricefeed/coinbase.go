@@ -0,0 +1,68 @@
+package pricefeed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CoinbaseSource reads Coinbase Exchange's public product tickers, quoting
+// assets against USD. It makes one request per asset.
+type CoinbaseSource struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewCoinbaseSource creates a CoinbaseSource.
+//
+// baseURL is the API root, e.g. "https://api.exchange.coinbase.com".
+func NewCoinbaseSource(baseURL string) *CoinbaseSource {
+	return &CoinbaseSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Name returns "coinbase".
+func (c *CoinbaseSource) Name() string { return "coinbase" }
+
+type coinbaseTicker struct {
+	Price string `json:"price"`
+}
+
+// Prices fetches the <ASSET>-USD ticker of each asset. Assets that fail are
+// left out; the error is returned only when none could be quoted.
+func (c *CoinbaseSource) Prices(ctx context.Context, assets []string) (map[string]float64, error) {
+	out := make(map[string]float64, len(assets))
+	var errs []error
+	for _, a := range assets {
+		product := strings.ToUpper(a) + "-USD"
+		body, err := doGet(ctx, c.httpClient, c.Name(), c.baseURL+"/products/"+url.PathEscape(product)+"/ticker")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", product, err))
+			continue
+		}
+		var t coinbaseTicker
+		if err := json.Unmarshal(body, &t); err != nil {
+			errs = append(errs, fmt.Errorf("%s: decode ticker: %w", product, err))
+			continue
+		}
+		p, err := strconv.ParseFloat(t.Price, 64)
+		if err != nil || p <= 0 {
+			continue
+		}
+		out[a] = p
+	}
+	if len(out) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("coinbase: ticker: %w", errors.Join(errs...))
+	}
+	return out, nil
+}
@@ -0,0 +1,188 @@
+package pricefeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Config configures a Feed.
+type Config struct {
+	Assets       []string      // lower-case symbols, e.g. "btc"
+	PollInterval time.Duration // time between ticker reads
+	History      time.Duration // how far back moves can be measured
+}
+
+// point is one observed spot price.
+type point struct {
+	at    time.Time
+	price float64
+}
+
+// Feed polls its sources for spot prices every poll interval, keeps a short
+// history per asset and writes the latest prices to a shared cache so other
+// processes see them too. Sources are tried in order: an asset the first
+// source cannot quote is read from the next. It implements
+// domain.ReferencePriceProvider.
+type Feed struct {
+	sources []Source
+	cache   domain.ReferencePriceCache // optional
+	cfg     Config
+	logger  *slog.Logger
+
+	mu      sync.RWMutex
+	latest  map[string]domain.ReferencePrice
+	history map[string][]point
+}
+
+// NewFeed creates a Feed. cache may be nil; then prices are only served in
+// process.
+func NewFeed(sources []Source, cache domain.ReferencePriceCache, cfg Config, logger *slog.Logger) *Feed {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	if cfg.History <= 0 {
+		cfg.History = 10 * time.Minute
+	}
+	return &Feed{
+		sources: sources,
+		cache:   cache,
+		cfg:     cfg,
+		logger:  logger.With(slog.String("component", "pricefeed")),
+		latest:  make(map[string]domain.ReferencePrice),
+		history: make(map[string][]point),
+	}
+}
+
+// Run polls the sources every poll interval until ctx is cancelled. Call in
+// a goroutine.
+func (f *Feed) Run(ctx context.Context) error {
+	ticker := time.NewTicker(f.cfg.PollInterval)
+	defer ticker.Stop()
+
+	f.logger.InfoContext(ctx, "price feed started",
+		slog.Any("assets", f.cfg.Assets),
+		slog.Duration("poll_interval", f.cfg.PollInterval),
+	)
+	defer f.logger.InfoContext(ctx, "price feed stopped")
+
+	f.poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			f.poll(ctx)
+		}
+	}
+}
+
+// poll reads one price per asset, records it and writes the batch to the
+// cache. Source failures are logged and the next source is tried.
+func (f *Feed) poll(ctx context.Context) {
+	missing := f.cfg.Assets
+	var batch []domain.ReferencePrice
+	for _, src := range f.sources {
+		if len(missing) == 0 {
+			break
+		}
+		prices, err := src.Prices(ctx, missing)
+		if err != nil {
+			if ctx.Err() == nil {
+				f.logger.WarnContext(ctx, "pricefeed: read tickers failed",
+					slog.String("source", src.Name()),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+		now := time.Now().UTC()
+		var rest []string
+		for _, a := range missing {
+			p, ok := prices[a]
+			if !ok {
+				rest = append(rest, a)
+				continue
+			}
+			batch = append(batch, domain.ReferencePrice{Asset: a, Price: p, Source: src.Name(), At: now})
+		}
+		missing = rest
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	f.record(batch)
+	if f.cache != nil {
+		if err := f.cache.SetBatch(ctx, batch); err != nil && ctx.Err() == nil {
+			f.logger.WarnContext(ctx, "pricefeed: cache prices failed", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// record appends the prices to each asset's history and drops points older
+// than the history window.
+func (f *Feed) record(batch []domain.ReferencePrice) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, p := range batch {
+		f.latest[p.Asset] = p
+		h := append(f.history[p.Asset], point{at: p.At, price: p.Price})
+		cutoff := p.At.Add(-f.cfg.History)
+		i := 0
+		for i < len(h)-1 && h[i].at.Before(cutoff) {
+			i++
+		}
+		f.history[p.Asset] = h[i:]
+	}
+}
+
+// ReferencePrice returns the latest spot price of asset, from memory when
+// this process runs the feed and from the cache otherwise.
+func (f *Feed) ReferencePrice(ctx context.Context, asset string) (domain.ReferencePrice, error) {
+	f.mu.RLock()
+	p, ok := f.latest[asset]
+	f.mu.RUnlock()
+	if ok {
+		return p, nil
+	}
+	if f.cache == nil {
+		return domain.ReferencePrice{}, domain.ErrNotFound
+	}
+	p, err := f.cache.Get(ctx, asset)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ReferencePrice{}, err
+		}
+		return domain.ReferencePrice{}, fmt.Errorf("pricefeed: reference price %s: %w", asset, err)
+	}
+	return p, nil
+}
+
+// ReferenceMove returns the fractional change from the last price observed
+// at least window before the latest one to the latest one. Only prices this
+// process polled count, so window must fit in the history.
+func (f *Feed) ReferenceMove(_ context.Context, asset string, window time.Duration) (float64, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	h := f.history[asset]
+	if len(h) < 2 {
+		return 0, domain.ErrNotFound
+	}
+	last := h[len(h)-1]
+	cutoff := last.at.Add(-window)
+	for i := len(h) - 2; i >= 0; i-- {
+		if !h[i].at.After(cutoff) {
+			return last.price/h[i].price - 1, nil
+		}
+	}
+	return 0, domain.ErrNotFound
+}
+
+// Compile-time interface check.
+var _ domain.ReferencePriceProvider = (*Feed)(nil)
@@ -0,0 +1,140 @@
+// Package ratelimit throttles outbound venue API calls at the HTTP transport,
+// so every request a platform client makes draws from the same per-venue and
+// per-endpoint token buckets regardless of which service issued it.
+package ratelimit
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// warnInterval rate-limits "bucket unavailable" warnings per transport.
+const warnInterval = 30 * time.Second
+
+// Limit is a token bucket: Rate tokens per second, up to Burst at once. A
+// zero Rate disables the bucket.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// EndpointLimit is an additional bucket for requests whose URL path starts
+// with Path and, when Method is set, whose method matches.
+type EndpointLimit struct {
+	Name   string // bucket key suffix, e.g. "post_order"
+	Method string
+	Path   string
+	Limit  Limit
+}
+
+// VenueLimits are the buckets of one API. Every request takes a token from
+// the venue bucket and from the most specific matching endpoint bucket.
+type VenueLimits struct {
+	Venue     string // bucket key prefix, e.g. "polymarket.clob"
+	Limit     Limit
+	Endpoints []EndpointLimit
+}
+
+// Transport is an http.RoundTripper that waits for a token from its venue's
+// buckets before sending each request. When the bucket store fails the
+// request is sent unthrottled and a warning is logged, so a Redis outage
+// degrades throttling instead of halting trading.
+type Transport struct {
+	base    http.RoundTripper
+	buckets domain.TokenBucket
+	limits  VenueLimits
+	logger  *slog.Logger
+
+	mu     sync.Mutex
+	warnAt time.Time
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) with the token
+// buckets in limits.
+func NewTransport(base http.RoundTripper, buckets domain.TokenBucket, limits VenueLimits, logger *slog.Logger) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:    base,
+		buckets: buckets,
+		limits:  limits,
+		logger:  logger.With(slog.String("component", "ratelimit"), slog.String("venue", limits.Venue)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	var wait time.Duration
+	reserve := func(key string, l Limit) {
+		if l.Rate <= 0 {
+			return
+		}
+		d, err := t.buckets.Reserve(ctx, key, l.Rate, l.Burst)
+		if err != nil {
+			t.warn(key, err)
+			return
+		}
+		wait = max(wait, d)
+	}
+	reserve(t.limits.Venue, t.limits.Limit)
+	if ep, ok := t.match(req); ok {
+		reserve(t.limits.Venue+":"+ep.Name, ep.Limit)
+	}
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("ratelimit: %s: %w", t.limits.Venue, ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// match returns the endpoint bucket with the longest path prefix matching
+// req; a method-specific bucket wins over one for any method at equal length.
+func (t *Transport) match(req *http.Request) (EndpointLimit, bool) {
+	var (
+		best  EndpointLimit
+		found bool
+	)
+	for _, ep := range t.limits.Endpoints {
+		if ep.Method != "" && !strings.EqualFold(ep.Method, req.Method) {
+			continue
+		}
+		if !strings.HasPrefix(req.URL.Path, ep.Path) {
+			continue
+		}
+		if found && (len(ep.Path) < len(best.Path) || (len(ep.Path) == len(best.Path) && ep.Method == "")) {
+			continue
+		}
+		best, found = ep, true
+	}
+	return best, found
+}
+
+func (t *Transport) warn(key string, err error) {
+	t.mu.Lock()
+	now := time.Now()
+	if now.Sub(t.warnAt) < warnInterval {
+		t.mu.Unlock()
+		return
+	}
+	t.warnAt = now
+	t.mu.Unlock()
+	t.logger.Warn("rate limit bucket unavailable, sending unthrottled",
+		slog.String("bucket", key),
+		slog.String("error", err.Error()),
+	)
+}
@@ -0,0 +1,192 @@
+// Package retry re-runs failed venue and data provider calls according to
+// the class of their error (domain.ClassifyError): rate-limited and
+// transient failures are retried with exponential backoff and jitter, every
+// other class returns at once. Each failure is counted by operation and
+// class.
+package retry
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Policy decides how often and how long to wait between attempts.
+type Policy struct {
+	MaxAttempts     int           // attempts including the first; 1 never retries
+	BaseDelay       time.Duration // wait before the first retry; doubles per retry
+	MaxDelay        time.Duration // cap on a single wait; 0 = uncapped
+	RateLimitFactor float64       // multiplies the wait after a rate-limited failure
+	Jitter          float64       // fraction of each wait drawn at random, in [0, 1]
+}
+
+// DefaultPolicy makes 3 attempts, waiting 500ms then 1s (4x as long after a
+// rate limit), at most 10s, with 20% jitter.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:     3,
+		BaseDelay:       500 * time.Millisecond,
+		MaxDelay:        10 * time.Second,
+		RateLimitFactor: 4,
+		Jitter:          0.2,
+	}
+}
+
+// maxWait bounds a wait before RateLimitFactor so doubling cannot overflow.
+const maxWait = time.Hour
+
+// Delay returns the wait after the given failed attempt (1-based) of class.
+// With jitter the wait is drawn uniformly from [d*(1-Jitter), d].
+func (p Policy) Delay(attempt int, class domain.ErrorClass) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < maxWait; i++ {
+		d *= 2
+	}
+	d = min(d, maxWait)
+	if class == domain.ErrorClassRateLimited && p.RateLimitFactor > 1 {
+		d = time.Duration(float64(d) * p.RateLimitFactor)
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if j := min(1, p.Jitter); j > 0 && d > 0 {
+		d -= time.Duration(rand.Float64() * j * float64(d))
+	}
+	return d
+}
+
+type statsKey struct {
+	op    string
+	class domain.ErrorClass
+}
+
+// Retrier runs calls under a Policy and keeps failure counts per operation
+// and class. It is safe for concurrent use; a nil Retrier calls fn once.
+type Retrier struct {
+	policy Policy
+	logger *slog.Logger
+
+	mu    sync.Mutex
+	stats map[statsKey]*domain.ErrorStats
+}
+
+// New creates a Retrier. MaxAttempts below 1 is treated as 1.
+func New(policy Policy, logger *slog.Logger) *Retrier {
+	policy.MaxAttempts = max(1, policy.MaxAttempts)
+	return &Retrier{
+		policy: policy,
+		logger: logger.With(slog.String("component", "retry")),
+		stats:  make(map[statsKey]*domain.ErrorStats),
+	}
+}
+
+// Policy returns the retry policy.
+func (r *Retrier) Policy() Policy {
+	return r.policy
+}
+
+// Do calls fn until it succeeds, fails with a class that is not retryable,
+// the attempts run out or ctx is done, and returns fn's last error. op names
+// the call in the stats and logs, e.g. "polymarket.clob".
+func (r *Retrier) Do(ctx context.Context, op string, fn func() error) error {
+	if r == nil {
+		return fn()
+	}
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		class := domain.ClassifyError(err)
+		retry := class.Retryable() && attempt < r.policy.MaxAttempts && ctx.Err() == nil
+		r.record(op, class, retry, class.Retryable() && !retry)
+		if !retry {
+			return err
+		}
+		wait := r.policy.Delay(attempt, class)
+		r.logger.WarnContext(ctx, "call failed, retrying",
+			slog.String("op", op),
+			slog.String("error_class", string(class)),
+			slog.String("error", err.Error()),
+			slog.Int("attempt", attempt),
+			slog.Duration("wait", wait),
+		)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// Value is Do for calls that return a value.
+func Value[T any](ctx context.Context, r *Retrier, op string, fn func() (T, error)) (T, error) {
+	var out T
+	err := r.Do(ctx, op, func() error {
+		v, err := fn()
+		if err == nil {
+			out = v
+		}
+		return err
+	})
+	return out, err
+}
+
+func (r *Retrier) record(op string, class domain.ErrorClass, retried, exhausted bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := statsKey{op: op, class: class}
+	s, ok := r.stats[k]
+	if !ok {
+		s = &domain.ErrorStats{Op: op, Class: class}
+		r.stats[k] = s
+	}
+	s.Failures++
+	if retried {
+		s.Retries++
+	}
+	if exhausted {
+		s.Exhausted++
+	}
+}
+
+// ErrorStats returns the failure counts since the Retrier was created,
+// sorted by operation and then class in domain.ErrorClasses order.
+func (r *Retrier) ErrorStats() []domain.ErrorStats {
+	rank := make(map[domain.ErrorClass]int, len(domain.ErrorClasses))
+	for i, c := range domain.ErrorClasses {
+		rank[c] = i
+	}
+	r.mu.Lock()
+	out := make([]domain.ErrorStats, 0, len(r.stats))
+	for _, s := range r.stats {
+		out = append(out, *s)
+	}
+	r.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Op != out[j].Op {
+			return out[i].Op < out[j].Op
+		}
+		return rank[out[i].Class] < rank[out[j].Class]
+	})
+	return out
+}
+
+// Idempotent reports whether a venue request with the given HTTP method can
+// be sent again after a failure: reads and cancels. POST and PATCH may place
+// or amend an order twice and are never retried.
+func Idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// AlertService defines the methods that the alert handler requires.
+type AlertService interface {
+	List(ctx context.Context) ([]domain.Alert, error)
+	Get(ctx context.Context, id string) (domain.Alert, error)
+	Create(ctx context.Context, a domain.Alert) (domain.Alert, error)
+	Update(ctx context.Context, a domain.Alert) (domain.Alert, error)
+	Delete(ctx context.Context, id string) error
+	ResolveToken(ctx context.Context, marketID, outcome string) (string, error)
+}
+
+// AlertHandler serves CRUD endpoints for price alerts.
+type AlertHandler struct {
+	alerts AlertService
+	logger *slog.Logger
+}
+
+// NewAlertHandler creates an AlertHandler with the given service and logger.
+func NewAlertHandler(alerts AlertService, logger *slog.Logger) *AlertHandler {
+	return &AlertHandler{alerts: alerts, logger: logger}
+}
+
+// alertResponse is the JSON shape of a single alert.
+type alertResponse struct {
+	ID              string     `json:"id"`
+	MarketID        string     `json:"market_id"`
+	TokenID         string     `json:"token_id"`
+	Metric          string     `json:"metric"`
+	Operator        string     `json:"operator"`
+	Threshold       float64    `json:"threshold"`
+	Recurring       bool       `json:"recurring"`
+	CooldownSeconds int        `json:"cooldown_seconds"`
+	Enabled         bool       `json:"enabled"`
+	Note            string     `json:"note"`
+	TriggerCount    int        `json:"trigger_count"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func toAlertResponse(a domain.Alert) alertResponse {
+	return alertResponse{
+		ID:              a.ID,
+		MarketID:        a.MarketID,
+		TokenID:         a.TokenID,
+		Metric:          string(a.Metric),
+		Operator:        string(a.Operator),
+		Threshold:       a.Threshold,
+		Recurring:       a.Recurring,
+		CooldownSeconds: int(a.Cooldown / time.Second),
+		Enabled:         a.Enabled,
+		Note:            a.Note,
+		TriggerCount:    a.TriggerCount,
+		LastTriggeredAt: a.LastTriggeredAt,
+		CreatedAt:       a.CreatedAt,
+		UpdatedAt:       a.UpdatedAt,
+	}
+}
+
+// alertRequest is the JSON body for POST and PUT /api/alerts. Omitted fields
+// keep their current value on update. The condition can be given either as
+// metric/operator/threshold or as a single expression such as "mid>0.8".
+type alertRequest struct {
+	MarketID        *string  `json:"market_id"`
+	TokenID         *string  `json:"token_id"`
+	Outcome         string   `json:"outcome"`
+	Condition       string   `json:"condition"`
+	Metric          *string  `json:"metric"`
+	Operator        *string  `json:"operator"`
+	Threshold       *float64 `json:"threshold"`
+	Recurring       *bool    `json:"recurring"`
+	CooldownSeconds *int     `json:"cooldown_seconds"`
+	Enabled         *bool    `json:"enabled"`
+	Note            *string  `json:"note"`
+}
+
+// apply copies the fields present in req onto a.
+func (req alertRequest) apply(a *domain.Alert) error {
+	if req.MarketID != nil {
+		a.MarketID = *req.MarketID
+		if req.TokenID == nil {
+			a.TokenID = "" // re-resolved from the new market
+		}
+	}
+	if req.TokenID != nil {
+		a.TokenID = *req.TokenID
+	}
+	if req.Condition != "" {
+		metric, op, threshold, err := parseAlertCondition(req.Condition)
+		if err != nil {
+			return err
+		}
+		a.Metric, a.Operator, a.Threshold = metric, op, threshold
+	}
+	if req.Metric != nil {
+		a.Metric = domain.AlertMetric(strings.ToLower(strings.TrimSpace(*req.Metric)))
+	}
+	if req.Operator != nil {
+		a.Operator = domain.AlertOperator(strings.TrimSpace(*req.Operator))
+	}
+	if req.Threshold != nil {
+		a.Threshold = *req.Threshold
+	}
+	if req.Recurring != nil {
+		a.Recurring = *req.Recurring
+	}
+	if req.CooldownSeconds != nil {
+		a.Cooldown = time.Duration(*req.CooldownSeconds) * time.Second
+	}
+	if req.Enabled != nil {
+		a.Enabled = *req.Enabled
+	}
+	if req.Note != nil {
+		a.Note = *req.Note
+	}
+	return nil
+}
+
+// parseAlertCondition parses "<metric><op><threshold>", e.g. "mid>0.8" or "spread >= 0.05".
+func parseAlertCondition(s string) (domain.AlertMetric, domain.AlertOperator, float64, error) {
+	s = strings.ReplaceAll(s, " ", "")
+	for _, op := range []domain.AlertOperator{domain.AlertOpGTE, domain.AlertOpLTE, domain.AlertOpGT, domain.AlertOpLT} {
+		i := strings.Index(s, string(op))
+		if i <= 0 {
+			continue
+		}
+		threshold, err := strconv.ParseFloat(s[i+len(op):], 64)
+		if err != nil {
+			return "", "", 0, errors.New("invalid threshold in condition")
+		}
+		return domain.AlertMetric(strings.ToLower(s[:i])), op, threshold, nil
+	}
+	return "", "", 0, errors.New("condition must look like mid>0.8")
+}
+
+// ListAlerts returns all alerts.
+// GET /api/alerts
+func (h *AlertHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	list, err := h.alerts.List(r.Context())
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list alerts failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to list alerts")
+		return
+	}
+	out := make([]alertResponse, 0, len(list))
+	for _, a := range list {
+		out = append(out, toAlertResponse(a))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"alerts": out,
+		"count":  len(out),
+	})
+}
+
+// GetAlert returns a single alert by ID.
+// GET /api/alerts/{id}
+func (h *AlertHandler) GetAlert(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	a, err := h.alerts.Get(r.Context(), id)
+	if err != nil {
+		h.writeAlertError(w, r, "get", id, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAlertResponse(a))
+}
+
+// CreateAlert creates a new alert. Alerts are enabled and one-shot unless
+// specified otherwise.
+// POST /api/alerts
+func (h *AlertHandler) CreateAlert(w http.ResponseWriter, r *http.Request) {
+	var req alertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	a := domain.Alert{Enabled: true}
+	if err := req.apply(&a); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if a.TokenID == "" && a.MarketID != "" && req.Outcome != "" {
+		tokenID, err := h.alerts.ResolveToken(r.Context(), a.MarketID, req.Outcome)
+		if err != nil {
+			h.writeAlertError(w, r, "resolve", a.MarketID, err)
+			return
+		}
+		a.TokenID = tokenID
+	}
+	created, err := h.alerts.Create(r.Context(), a)
+	if err != nil {
+		h.writeAlertError(w, r, "create", "", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAlertResponse(created))
+}
+
+// UpdateAlert modifies an existing alert.
+// PUT /api/alerts/{id}
+func (h *AlertHandler) UpdateAlert(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req alertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	a, err := h.alerts.Get(r.Context(), id)
+	if err != nil {
+		h.writeAlertError(w, r, "get", id, err)
+		return
+	}
+	if err := req.apply(&a); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Outcome != "" && req.TokenID == nil && a.MarketID != "" {
+		tokenID, err := h.alerts.ResolveToken(r.Context(), a.MarketID, req.Outcome)
+		if err != nil {
+			h.writeAlertError(w, r, "resolve", a.MarketID, err)
+			return
+		}
+		a.TokenID = tokenID
+	}
+	updated, err := h.alerts.Update(r.Context(), a)
+	if err != nil {
+		h.writeAlertError(w, r, "update", id, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAlertResponse(updated))
+}
+
+// DeleteAlert removes an alert.
+// DELETE /api/alerts/{id}
+func (h *AlertHandler) DeleteAlert(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.alerts.Delete(r.Context(), id); err != nil {
+		h.writeAlertError(w, r, "delete", id, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"deleted": id})
+}
+
+func (h *AlertHandler) writeAlertError(w http.ResponseWriter, r *http.Request, op, id string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, "alert or market not found")
+	case errors.Is(err, domain.ErrInvalidAlert):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		h.logger.ErrorContext(r.Context(), "handler: "+op+" alert failed",
+			slog.String("alert_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to "+op+" alert")
+	}
+}
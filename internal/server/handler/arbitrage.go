@@ -22,8 +22,16 @@ type ArbExecutionStore interface {
 	SumPnLByType(ctx context.Context, arbType domain.ArbType, since time.Time) (float64, error)
 	ListRecent(ctx context.Context, limit int) ([]domain.ArbExecution, error)
 	GetByID(ctx context.Context, id string) (domain.ArbExecution, error)
+	Stats(ctx context.Context, q domain.ArbStatsQuery) (domain.ArbStats, error)
 }
 
+const (
+	defaultArbStatsWindow  = 7 * 24 * time.Hour
+	defaultArbEdgeBucket   = 10.0
+	defaultArbStatsMarkets = 20
+	maxArbStatsMarkets     = 200
+)
+
 // ArbHandler serves arbitrage-related HTTP endpoints.
 type ArbHandler struct {
 	arb     ArbService
@@ -157,3 +165,168 @@ func (h *ArbHandler) GetExecution(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, http.StatusOK, exec)
 }
+
+type arbEdgeBucketResponse struct {
+	LowBps   float64 `json:"low_bps"`
+	HighBps  float64 `json:"high_bps"`
+	Detected int     `json:"detected"`
+	Executed int     `json:"executed"`
+}
+
+type arbDurationResponse struct {
+	Count int     `json:"count"`
+	AvgMs float64 `json:"avg_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P90Ms float64 `json:"p90_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+type arbStrategyStatsResponse struct {
+	ArbType         string  `json:"arb_type"`
+	Strategy        string  `json:"strategy"`
+	Executions      int     `json:"executions"`
+	Filled          int     `json:"filled"`
+	NetPnLUSD       float64 `json:"net_pnl_usd"`
+	FeesUSD         float64 `json:"fees_usd"`
+	AvgGrossEdgeBps float64 `json:"avg_gross_edge_bps"`
+}
+
+type arbMarketStatsResponse struct {
+	MarketID       string  `json:"market_id"`
+	Detected       int     `json:"detected"`
+	Executed       int     `json:"executed"`
+	HitRate        float64 `json:"hit_rate"`
+	AvgNetEdgeBps  float64 `json:"avg_net_edge_bps"`
+	ExpectedPnLUSD float64 `json:"expected_pnl_usd"`
+	NetPnLUSD      float64 `json:"net_pnl_usd"`
+}
+
+type arbStatsResponse struct {
+	From          time.Time                  `json:"from"`
+	To            time.Time                  `json:"to"`
+	Detected      int                        `json:"detected"`
+	Executed      int                        `json:"executed"`
+	HitRate       float64                    `json:"hit_rate"`
+	Executions    int                        `json:"executions"`
+	Filled        int                        `json:"filled"`
+	NetPnLUSD     float64                    `json:"net_pnl_usd"`
+	EdgeBucketBps float64                    `json:"edge_bucket_bps"`
+	EdgeHistogram []arbEdgeBucketResponse    `json:"edge_histogram"`
+	Duration      arbDurationResponse        `json:"duration"`
+	ByStrategy    []arbStrategyStatsResponse `json:"by_strategy"`
+	ByMarket      []arbMarketStatsResponse   `json:"by_market"`
+}
+
+// Stats aggregates arbitrage opportunities and executions over a time range:
+// a histogram of net edge, how long opportunities lasted, the hit rate of
+// detected vs. executed opportunities, executions per arb type and strategy,
+// and opportunities per market. from and to are RFC 3339 times or dates and
+// default to the last 7 days.
+// GET /api/arbitrage/stats?from=&to=&bucket_bps=10&markets=20
+func (h *ArbHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	if h.arbExec == nil {
+		writeError(w, http.StatusNotImplemented, "arbitrage execution tracking not configured")
+		return
+	}
+	q := r.URL.Query()
+	to := time.Now().UTC()
+	if v := q.Get("to"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "to must be an RFC 3339 time or YYYY-MM-DD")
+			return
+		}
+		to = t
+	}
+	from := to.Add(-defaultArbStatsWindow)
+	if v := q.Get("from"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "from must be an RFC 3339 time or YYYY-MM-DD")
+			return
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		writeError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+	bucket := defaultArbEdgeBucket
+	if v := q.Get("bucket_bps"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 {
+			writeError(w, http.StatusBadRequest, "bucket_bps must be a positive number")
+			return
+		}
+		bucket = f
+	}
+	markets := defaultArbStatsMarkets
+	if v := q.Get("markets"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "markets must be a positive integer")
+			return
+		}
+		markets = min(n, maxArbStatsMarkets)
+	}
+
+	stats, err := h.arbExec.Stats(r.Context(), domain.ArbStatsQuery{
+		Since:         from,
+		Until:         to,
+		EdgeBucketBps: bucket,
+		MaxMarkets:    markets,
+	})
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: arb stats failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to compute arbitrage stats")
+		return
+	}
+
+	resp := arbStatsResponse{
+		From:          stats.Since,
+		To:            stats.Until,
+		Detected:      stats.Detected,
+		Executed:      stats.Executed,
+		HitRate:       stats.HitRate(),
+		Executions:    stats.Executions,
+		Filled:        stats.Filled,
+		NetPnLUSD:     stats.NetPnLUSD,
+		EdgeBucketBps: bucket,
+		EdgeHistogram: make([]arbEdgeBucketResponse, 0, len(stats.EdgeHistogram)),
+		Duration: arbDurationResponse{
+			Count: stats.Duration.Count,
+			AvgMs: stats.Duration.AvgMs,
+			P50Ms: stats.Duration.P50Ms,
+			P90Ms: stats.Duration.P90Ms,
+			MaxMs: stats.Duration.MaxMs,
+		},
+		ByStrategy: make([]arbStrategyStatsResponse, 0, len(stats.ByStrategy)),
+		ByMarket:   make([]arbMarketStatsResponse, 0, len(stats.ByMarket)),
+	}
+	for _, b := range stats.EdgeHistogram {
+		resp.EdgeHistogram = append(resp.EdgeHistogram, arbEdgeBucketResponse(b))
+	}
+	for _, st := range stats.ByStrategy {
+		resp.ByStrategy = append(resp.ByStrategy, arbStrategyStatsResponse{
+			ArbType:         string(st.ArbType),
+			Strategy:        st.Strategy,
+			Executions:      st.Executions,
+			Filled:          st.Filled,
+			NetPnLUSD:       st.NetPnLUSD,
+			FeesUSD:         st.FeesUSD,
+			AvgGrossEdgeBps: st.AvgGrossEdgeBps,
+		})
+	}
+	for _, m := range stats.ByMarket {
+		resp.ByMarket = append(resp.ByMarket, arbMarketStatsResponse{
+			MarketID:       m.MarketID,
+			Detected:       m.Detected,
+			Executed:       m.Executed,
+			HitRate:        m.HitRate(),
+			AvgNetEdgeBps:  m.AvgNetEdgeBps,
+			ExpectedPnLUSD: m.ExpectedPnLUSD,
+			NetPnLUSD:      m.NetPnLUSD,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	maxAuditExport  = 100_000 // entries written by one JSONL export
+	auditExportPage = 1000    // entries read per page while exporting
+)
+
+// AuditService pages the audit log (service.AuditQueryService).
+type AuditService interface {
+	List(ctx context.Context, f domain.AuditFilter, cursor string, limit int) ([]domain.AuditEntry, string, error)
+}
+
+// AuditHandler serves GET /api/audit.
+type AuditHandler struct {
+	audit  AuditService
+	logger *slog.Logger
+}
+
+// NewAuditHandler creates an AuditHandler. Until WithService is called the
+// endpoint responds 501.
+func NewAuditHandler(logger *slog.Logger) *AuditHandler {
+	return &AuditHandler{logger: logger}
+}
+
+// WithService sets the service backing the endpoint.
+func (h *AuditHandler) WithService(audit AuditService) *AuditHandler {
+	h.audit = audit
+	return h
+}
+
+type auditEntryResponse struct {
+	ID        int64          `json:"id"`
+	Event     string         `json:"event"`
+	Detail    map[string]any `json:"detail,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func toAuditEntryResponse(e domain.AuditEntry) auditEntryResponse {
+	return auditEntryResponse{ID: e.ID, Event: e.Event, Detail: e.Detail, CreatedAt: e.CreatedAt}
+}
+
+// List returns audit log entries, newest first. events is a comma-separated
+// list of event names; from and to (RFC 3339) bound created_at to
+// [from, to); order_id and position_id match the entry detail. Pass
+// next_cursor back as cursor to read the following page. With format=jsonl
+// every matching entry (up to 100000) is streamed as one JSON object per line
+// instead, ignoring limit.
+// GET /api/audit?events=&from=&to=&order_id=&position_id=&limit=100&cursor=&format=json
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h.audit == nil {
+		writeError(w, http.StatusNotImplemented, "audit log not available: postgres not configured")
+		return
+	}
+	q := r.URL.Query()
+
+	f := domain.AuditFilter{
+		OrderID:    q.Get("order_id"),
+		PositionID: q.Get("position_id"),
+	}
+	for _, e := range strings.Split(q.Get("events"), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			f.Events = append(f.Events, e)
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &f.From}, {"to", &f.To}} {
+		if v := q.Get(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, p.name+" must be an RFC 3339 time")
+				return
+			}
+			*p.dst = t
+		}
+	}
+	var limit int
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+
+	switch q.Get("format") {
+	case "", "json":
+	case "jsonl":
+		h.export(w, r, f, q.Get("cursor"))
+		return
+	default:
+		writeError(w, http.StatusBadRequest, "format must be json or jsonl")
+		return
+	}
+
+	list, next, err := h.audit.List(r.Context(), f, q.Get("cursor"), limit)
+	if errors.Is(err, domain.ErrInvalidAuditQuery) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list audit log failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to list audit log")
+		return
+	}
+
+	entries := make([]auditEntryResponse, 0, len(list))
+	for _, e := range list {
+		entries = append(entries, toAuditEntryResponse(e))
+	}
+	resp := map[string]any{"entries": entries}
+	if next != "" {
+		resp["next_cursor"] = next
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// export streams every entry matching f from cursor on as JSONL, reading the
+// log a page at a time.
+func (h *AuditHandler) export(w http.ResponseWriter, r *http.Request, f domain.AuditFilter, cursor string) {
+	// The first page is read before the header is written so a bad query
+	// still gets a JSON error.
+	page, next, err := h.audit.List(r.Context(), f, cursor, auditExportPage)
+	if errors.Is(err, domain.ErrInvalidAuditQuery) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: export audit log failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to export audit log")
+		return
+	}
+
+	// Large exports can outlive the server's WriteTimeout.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="audit_%s.jsonl"`, time.Now().UTC().Format("20060102T150405")))
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	written := 0
+	for {
+		for _, e := range page {
+			if written == maxAuditExport {
+				h.logger.WarnContext(r.Context(), "handler: audit export truncated",
+					slog.Int("entries", written))
+				return
+			}
+			if err := enc.Encode(toAuditEntryResponse(e)); err != nil {
+				return
+			}
+			written++
+		}
+		_ = rc.Flush()
+		if next == "" || r.Context().Err() != nil {
+			return
+		}
+		page, next, err = h.audit.List(r.Context(), f, next, auditExportPage)
+		if err != nil {
+			h.logger.ErrorContext(r.Context(), "handler: export audit log failed",
+				slog.Int("entries", written),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 // BondService defines the methods that the bond handler requires.
 type BondService interface {
 	GetOpen(ctx context.Context) ([]domain.BondPosition, error)
+	GetClosed(ctx context.Context) ([]domain.BondPosition, error)
 	GetByID(ctx context.Context, id string) (domain.BondPosition, error)
 }
 
@@ -74,7 +75,8 @@ func (h *BondHandler) GetBond(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, bond)
 }
 
-// Summary returns a portfolio summary of open bond positions.
+// Summary returns a portfolio summary of open bond positions and the yield
+// realized by resolved and early-exited ones.
 // GET /api/bonds/summary
 func (h *BondHandler) Summary(w http.ResponseWriter, r *http.Request) {
 	positions, err := h.bonds.GetOpen(r.Context())
@@ -85,16 +87,30 @@ func (h *BondHandler) Summary(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusInternalServerError, "failed to compute bond summary")
 		return
 	}
+	closed, err := h.bonds.GetClosed(r.Context())
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: bond summary failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to compute bond summary")
+		return
+	}
 
-	var totalInvested, totalExpectedYield, weightedAPRNum float64
+	// realizedBasis is the entry cost of the shares whose yield is realized.
+	var totalInvested, totalExpectedYield, weightedAPRNum, realizedPnL, realizedBasis float64
 	for _, p := range positions {
-		invested := p.EntryPrice * p.Size
+		held := p.Remaining()
+		invested := p.EntryPrice * held
 		totalInvested += invested
 
-		expectedYield := (1.0 - p.EntryPrice) * p.Size
+		expectedYield := (1.0 - p.EntryPrice) * held
 		totalExpectedYield += expectedYield
 
 		weightedAPRNum += p.ExpectedAPR * invested
+
+		// Shares already sold by an early exit.
+		realizedPnL += p.RealizedPnL
+		realizedBasis += p.EntryPrice * p.ExitedSize
 	}
 
 	weightedAPR := 0.0
@@ -102,10 +118,30 @@ func (h *BondHandler) Summary(w http.ResponseWriter, r *http.Request) {
 		weightedAPR = weightedAPRNum / totalInvested
 	}
 
+	var exitedPnL float64
+	exitedCount := 0
+	for _, p := range closed {
+		realizedBasis += p.EntryPrice * p.Size
+		realizedPnL += p.RealizedPnL
+		if p.Status == domain.BondExited {
+			exitedCount++
+			exitedPnL += p.RealizedPnL
+		}
+	}
+	realizedYield := 0.0
+	if realizedBasis > 0 {
+		realizedYield = realizedPnL / realizedBasis
+	}
+
 	writeJSON(w, http.StatusOK, map[string]any{
-		"open_count":     len(positions),
-		"total_invested": totalInvested,
-		"expected_yield": totalExpectedYield,
-		"weighted_apr":   weightedAPR,
+		"open_count":       len(positions),
+		"total_invested":   totalInvested,
+		"expected_yield":   totalExpectedYield,
+		"weighted_apr":     weightedAPR,
+		"closed_count":     len(closed),
+		"early_exit_count": exitedCount,
+		"early_exit_pnl":   exitedPnL,
+		"realized_pnl":     realizedPnL,
+		"realized_yield":   realizedYield,
 	})
 }
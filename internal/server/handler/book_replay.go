@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	defaultReplayWindow = 5 * time.Minute
+	maxReplayWindow     = 24 * time.Hour
+	defaultReplayLimit  = 5000
+	maxReplayLimit      = 50000
+	maxReplayGap        = 5 * time.Second
+)
+
+// BookReplayHandler streams recorded orderbook events for a market so the
+// dashboard can replay how the book evolved around a trade or arbitrage.
+type BookReplayHandler struct {
+	markets MarketService
+	events  domain.BookEventStore
+	logger  *slog.Logger
+}
+
+// NewBookReplayHandler creates a BookReplayHandler.
+func NewBookReplayHandler(markets MarketService, events domain.BookEventStore, logger *slog.Logger) *BookReplayHandler {
+	return &BookReplayHandler{
+		markets: markets,
+		events:  events,
+		logger:  logger,
+	}
+}
+
+// bookReplayEvent is one NDJSON line of the replay stream.
+type bookReplayEvent struct {
+	AssetID   string              `json:"asset_id"`
+	Outcome   string              `json:"outcome"`
+	Kind      string              `json:"kind"`
+	Bids      []domain.PriceLevel `json:"bids,omitempty"`
+	Asks      []domain.PriceLevel `json:"asks,omitempty"`
+	Side      string              `json:"side,omitempty"`
+	Price     float64             `json:"price,omitempty"`
+	Size      float64             `json:"size,omitempty"`
+	BestBid   float64             `json:"best_bid"`
+	BestAsk   float64             `json:"best_ask"`
+	Timestamp time.Time           `json:"timestamp"`
+}
+
+// Replay streams recorded book snapshots and deltas for both outcome tokens of
+// a market in time order as newline-delimited JSON. When speed > 0 the stream
+// is paced by the recorded inter-event gaps divided by speed (gaps are capped
+// at 5s of wall time); otherwise events are written as fast as possible.
+// GET /api/markets/{id}/book-replay?from=RFC3339&to=RFC3339&speed=1&limit=5000
+func (h *BookReplayHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	id := pathParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing market id")
+		return
+	}
+
+	q := r.URL.Query()
+	to := time.Now().UTC()
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to (expected RFC3339)")
+			return
+		}
+		to = t
+	}
+	from := to.Add(-defaultReplayWindow)
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from (expected RFC3339)")
+			return
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		writeError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+	if to.Sub(from) > maxReplayWindow {
+		writeError(w, http.StatusBadRequest, "replay window must not exceed 24h")
+		return
+	}
+
+	var speed float64
+	if v := q.Get("speed"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			writeError(w, http.StatusBadRequest, "invalid speed")
+			return
+		}
+		speed = f
+	}
+
+	limit := defaultReplayLimit
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	if limit > maxReplayLimit {
+		limit = maxReplayLimit
+	}
+
+	market, err := h.markets.GetMarket(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "market not found")
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "handler: book replay get market failed",
+			slog.String("market_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to get market")
+		return
+	}
+
+	outcomes := make(map[string]string, len(market.TokenIDs))
+	var assetIDs []string
+	for _, tok := range market.TokenIDs {
+		if tok == "" {
+			continue
+		}
+		assetIDs = append(assetIDs, tok)
+		outcomes[tok] = market.OutcomeName(tok)
+	}
+	if len(assetIDs) == 0 {
+		writeError(w, http.StatusNotFound, "market has no token ids")
+		return
+	}
+
+	events, err := h.events.ListRange(r.Context(), assetIDs, from, to, limit)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: book replay list events failed",
+			slog.String("market_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to load book events")
+		return
+	}
+
+	// Paced replays can outlive the server's WriteTimeout.
+	rc := http.NewResponseController(w)
+	if speed > 0 {
+		_ = rc.SetWriteDeadline(time.Time{})
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	var prev time.Time
+	for i, e := range events {
+		if speed > 0 && i > 0 {
+			gap := time.Duration(float64(e.Timestamp.Sub(prev)) / speed)
+			if gap > maxReplayGap {
+				gap = maxReplayGap
+			}
+			if gap > 0 {
+				timer := time.NewTimer(gap)
+				select {
+				case <-r.Context().Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+		}
+		prev = e.Timestamp
+
+		if err := enc.Encode(bookReplayEvent{
+			AssetID:   e.AssetID,
+			Outcome:   outcomes[e.AssetID],
+			Kind:      string(e.Kind),
+			Bids:      e.Bids,
+			Asks:      e.Asks,
+			Side:      e.Side,
+			Price:     e.Price,
+			Size:      e.Size,
+			BestBid:   e.BestBid,
+			BestAsk:   e.BestAsk,
+			Timestamp: e.Timestamp,
+		}); err != nil {
+			return
+		}
+		if speed > 0 {
+			_ = rc.Flush()
+		}
+	}
+	_ = rc.Flush()
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// BuilderRewardsSource sums builder-attributed trades
+// (service.BuilderRewardsService).
+type BuilderRewardsSource interface {
+	BuilderRewards(ctx context.Context, since time.Time) (domain.BuilderRewards, error)
+}
+
+// BuilderRewardsHandler serves GET /api/rewards/builder.
+type BuilderRewardsHandler struct {
+	source BuilderRewardsSource
+	logger *slog.Logger
+}
+
+// NewBuilderRewardsHandler creates a BuilderRewardsHandler. Until WithSource
+// is called the endpoint responds 501.
+func NewBuilderRewardsHandler(logger *slog.Logger) *BuilderRewardsHandler {
+	return &BuilderRewardsHandler{logger: logger}
+}
+
+// WithSource sets the service backing the endpoint.
+func (h *BuilderRewardsHandler) WithSource(source BuilderRewardsSource) *BuilderRewardsHandler {
+	h.source = source
+	return h
+}
+
+type builderMarketRewardsRow struct {
+	MarketID   string  `json:"market_id"`
+	Trades     int     `json:"trades"`
+	VolumeUSDC float64 `json:"volume_usdc"`
+	FeesUSDC   float64 `json:"fees_usdc"`
+}
+
+type builderRewardsResponse struct {
+	Since      time.Time                 `json:"since"`
+	Trades     int                       `json:"trades"`
+	VolumeUSDC float64                   `json:"volume_usdc"`
+	FeesUSDC   float64                   `json:"fees_usdc"`
+	Markets    []builderMarketRewardsRow `json:"markets"`
+	UpdatedAt  time.Time                 `json:"updated_at"`
+}
+
+// BuilderRewards returns the builder-program fees credited for our
+// attributed orders since ?since= (RFC 3339 or YYYY-MM-DD; default 30 days
+// ago), with trade count and volume, in total and per market.
+// GET /api/rewards/builder
+func (h *BuilderRewardsHandler) BuilderRewards(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "builder credentials not configured")
+		return
+	}
+	since := time.Now().UTC().AddDate(0, 0, -30).Truncate(24 * time.Hour)
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid since: want RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		since = t
+	}
+	rw, err := h.source.BuilderRewards(r.Context(), since)
+	if err != nil {
+		logHandler(h.logger, "builder_rewards").ErrorContext(r.Context(), "builder rewards failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusBadGateway, "failed to fetch builder trades")
+		return
+	}
+	resp := builderRewardsResponse{
+		Since:      rw.Since,
+		Trades:     rw.Trades,
+		VolumeUSDC: rw.VolumeUSDC,
+		FeesUSDC:   rw.FeesUSDC,
+		Markets:    make([]builderMarketRewardsRow, 0, len(rw.Markets)),
+		UpdatedAt:  rw.UpdatedAt,
+	}
+	for _, m := range rw.Markets {
+		resp.Markets = append(resp.Markets, builderMarketRewardsRow(m))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// CacheNamespaces lists and flushes Redis key namespaces (redis.Keyspace).
+type CacheNamespaces interface {
+	Namespaces() []string
+	FlushNamespace(ctx context.Context, name string) (int64, error)
+}
+
+// CacheHandler serves the cache namespace admin endpoints. Mount it behind
+// middleware.Admin.
+type CacheHandler struct {
+	keys   CacheNamespaces
+	logger *slog.Logger
+}
+
+// NewCacheHandler creates a CacheHandler.
+func NewCacheHandler(keys CacheNamespaces, logger *slog.Logger) *CacheHandler {
+	return &CacheHandler{keys: keys, logger: logger}
+}
+
+// ListNamespaces returns the namespaces that can be flushed.
+// GET /api/admin/cache/namespaces
+func (h *CacheHandler) ListNamespaces(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"namespaces": h.keys.Namespaces()})
+}
+
+// FlushNamespace deletes every key in one namespace, e.g. "md" or
+// "strategy:flash_crash". Other namespaces are untouched.
+// DELETE /api/admin/cache/namespaces/{namespace}
+func (h *CacheHandler) FlushNamespace(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("namespace")
+	deleted, err := h.keys.FlushNamespace(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "unknown or protected namespace: "+name)
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "handler: flush cache namespace failed",
+			slog.String("namespace", name),
+			slog.Int64("deleted", deleted),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to flush namespace")
+		return
+	}
+	h.logger.InfoContext(r.Context(), "handler: cache namespace flushed",
+		slog.String("namespace", name),
+		slog.Int64("deleted", deleted),
+	)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"namespace": name,
+		"deleted":   deleted,
+	})
+}
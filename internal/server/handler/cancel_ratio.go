@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// CancelRatioSource provides rolling per-market cancel ratios
+// (service.CancelRatioTracker).
+type CancelRatioSource interface {
+	Snapshot() []domain.MarketCancelRatio
+	Limit() float64
+}
+
+// CancelRatioHandler serves GET /api/orders/cancel-ratio.
+type CancelRatioHandler struct {
+	source CancelRatioSource
+	logger *slog.Logger
+}
+
+// NewCancelRatioHandler creates a CancelRatioHandler. Until WithSource is
+// called the endpoint responds 501.
+func NewCancelRatioHandler(logger *slog.Logger) *CancelRatioHandler {
+	return &CancelRatioHandler{logger: logger}
+}
+
+// WithSource sets the tracker backing the endpoint.
+func (h *CancelRatioHandler) WithSource(source CancelRatioSource) *CancelRatioHandler {
+	h.source = source
+	return h
+}
+
+type cancelRatioRow struct {
+	MarketID  string  `json:"market_id"`
+	Placed    int     `json:"placed_1h"`
+	Cancelled int     `json:"cancelled_1h"`
+	Filled    int     `json:"filled_1h"`
+	Ratio     float64 `json:"ratio"`
+	NearLimit bool    `json:"near_limit"`
+}
+
+// CancelRatio returns each market with order activity in the last hour and
+// its cancels-per-fill ratio against the configured venue limit, highest
+// ratio first.
+// GET /api/orders/cancel-ratio
+func (h *CancelRatioHandler) CancelRatio(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "cancel ratio tracking not available in this mode")
+		return
+	}
+	markets := h.source.Snapshot()
+	out := make([]cancelRatioRow, 0, len(markets))
+	nearLimit := 0
+	for _, m := range markets {
+		if m.NearLimit {
+			nearLimit++
+		}
+		out = append(out, cancelRatioRow{
+			MarketID:  m.MarketID,
+			Placed:    m.Placed,
+			Cancelled: m.Cancelled,
+			Filled:    m.Filled,
+			Ratio:     m.Ratio,
+			NearLimit: m.NearLimit,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"limit":        h.source.Limit(),
+		"near_limit":   nearLimit,
+		"markets":      out,
+		"generated_at": time.Now().UTC(),
+	})
+}
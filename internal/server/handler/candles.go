@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	defaultCandleInterval = "1m"
+	defaultCandleLimit    = 500
+	maxCandleLimit        = 5000
+)
+
+// CandleService reads aggregated price candles (service.CandleService).
+type CandleService interface {
+	Candles(ctx context.Context, assetID, interval string, from, to time.Time, limit int) ([]domain.Candle, error)
+}
+
+// CandlesHandler serves OHLCV price history for a market's outcome tokens.
+type CandlesHandler struct {
+	markets MarketService
+	candles CandleService
+	logger  *slog.Logger
+}
+
+// NewCandlesHandler creates a CandlesHandler.
+func NewCandlesHandler(markets MarketService, candles CandleService, logger *slog.Logger) *CandlesHandler {
+	return &CandlesHandler{
+		markets: markets,
+		candles: candles,
+		logger:  logger,
+	}
+}
+
+type candleResponse struct {
+	Time   time.Time `json:"t"`
+	Open   float64   `json:"o"`
+	High   float64   `json:"h"`
+	Low    float64   `json:"l"`
+	Close  float64   `json:"c"`
+	Volume float64   `json:"v"`
+	Trades int       `json:"n"`
+}
+
+type candleSeriesResponse struct {
+	AssetID string           `json:"asset_id"`
+	Outcome string           `json:"outcome"`
+	Candles []candleResponse `json:"candles"`
+}
+
+// Candles returns OHLCV candles for each outcome token of a market, oldest
+// first. interval is one of 1m, 5m, 15m, 1h, 4h or 1d (default 1m); outcome
+// (name, case-insensitive) or token narrows the response to one token. from
+// and to are RFC 3339; to defaults to now and from to limit intervals before
+// to. At most limit (default 500, max 5000) of the latest candles are
+// returned per token.
+// GET /api/markets/{id}/candles?interval=1m&outcome=&token=&from=&to=&limit=500
+func (h *CandlesHandler) Candles(w http.ResponseWriter, r *http.Request) {
+	id := pathParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing market id")
+		return
+	}
+
+	q := r.URL.Query()
+	interval := q.Get("interval")
+	if interval == "" {
+		interval = defaultCandleInterval
+	}
+	step, ok := domain.CandleIntervals[interval]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "interval must be one of 1m, 5m, 15m, 1h, 4h, 1d")
+		return
+	}
+	limit := defaultCandleLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = min(n, maxCandleLimit)
+	}
+	to := time.Now().UTC()
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "to must be an RFC 3339 time")
+			return
+		}
+		to = t
+	}
+	from := to.Add(-time.Duration(limit) * step)
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "from must be an RFC 3339 time")
+			return
+		}
+		from = t
+	}
+
+	market, err := h.markets.GetMarket(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "market not found")
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "handler: candles get market failed",
+			slog.String("market_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to get market")
+		return
+	}
+
+	token, outcome := q.Get("token"), q.Get("outcome")
+	var assetIDs []string
+	for _, tok := range market.TokenIDs {
+		switch {
+		case tok == "":
+			continue
+		case token != "" && tok != token:
+			continue
+		case outcome != "" && !strings.EqualFold(market.OutcomeName(tok), outcome):
+			continue
+		}
+		assetIDs = append(assetIDs, tok)
+	}
+	if len(assetIDs) == 0 {
+		writeError(w, http.StatusNotFound, "no matching outcome token")
+		return
+	}
+
+	series := make([]candleSeriesResponse, 0, len(assetIDs))
+	for _, assetID := range assetIDs {
+		list, err := h.candles.Candles(r.Context(), assetID, interval, from, to, limit)
+		if errors.Is(err, domain.ErrInvalidCandles) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			h.logger.ErrorContext(r.Context(), "handler: list candles failed",
+				slog.String("market_id", id),
+				slog.String("asset_id", assetID),
+				slog.String("error", err.Error()),
+			)
+			writeError(w, http.StatusInternalServerError, "failed to list candles")
+			return
+		}
+		candles := make([]candleResponse, 0, len(list))
+		for _, c := range list {
+			candles = append(candles, candleResponse{
+				Time:   c.Start,
+				Open:   c.Open,
+				High:   c.High,
+				Low:    c.Low,
+				Close:  c.Close,
+				Volume: c.Volume,
+				Trades: c.Trades,
+			})
+		}
+		series = append(series, candleSeriesResponse{
+			AssetID: assetID,
+			Outcome: market.OutcomeName(assetID),
+			Candles: candles,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"market_id": market.ID,
+		"interval":  interval,
+		"from":      from,
+		"to":        to,
+		"series":    series,
+	})
+}
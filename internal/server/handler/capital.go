@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// CapitalSource reports per-strategy capital budgets and usage
+// (service.CapitalAllocator).
+type CapitalSource interface {
+	Report(ctx context.Context) (domain.CapitalReport, error)
+}
+
+// CapitalHandler serves GET /api/capital.
+type CapitalHandler struct {
+	source CapitalSource
+	logger *slog.Logger
+}
+
+// NewCapitalHandler creates a CapitalHandler. Until WithSource is called the
+// endpoint responds 501.
+func NewCapitalHandler(logger *slog.Logger) *CapitalHandler {
+	return &CapitalHandler{logger: logger}
+}
+
+// WithSource sets the allocator backing the endpoint.
+func (h *CapitalHandler) WithSource(source CapitalSource) *CapitalHandler {
+	h.source = source
+	return h
+}
+
+type strategyAllocationResponse struct {
+	Strategy      string  `json:"strategy"`
+	Uncapped      bool    `json:"uncapped"`
+	BaseBudgetUSD float64 `json:"base_budget_usd"`
+	BudgetUSD     float64 `json:"budget_usd"`
+	Factor        float64 `json:"factor"`
+	Sharpe        float64 `json:"sharpe"`
+	OpenOrdersUSD float64 `json:"open_orders_usd"`
+	PositionsUSD  float64 `json:"positions_usd"`
+	UsedUSD       float64 `json:"used_usd"`
+	AvailableUSD  float64 `json:"available_usd"`
+}
+
+type capitalResponse struct {
+	TotalBudgetUSD float64                      `json:"total_budget_usd"`
+	TotalUsedUSD   float64                      `json:"total_used_usd"`
+	RebalancedAt   *time.Time                   `json:"rebalanced_at,omitempty"`
+	Strategies     []strategyAllocationResponse `json:"strategies"`
+}
+
+// Capital returns each strategy's budget, the capital its resting buy orders
+// and open positions commit, and what is left for new entries.
+// GET /api/capital
+func (h *CapitalHandler) Capital(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "capital allocation not available: capital.enabled is false or postgres not configured")
+		return
+	}
+	report, err := h.source.Report(r.Context())
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "capital report failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to compute capital allocation")
+		return
+	}
+	resp := capitalResponse{
+		TotalBudgetUSD: report.TotalBudgetUSD,
+		TotalUsedUSD:   report.TotalUsedUSD,
+		RebalancedAt:   report.RebalancedAt,
+		Strategies:     make([]strategyAllocationResponse, 0, len(report.Strategies)),
+	}
+	for _, a := range report.Strategies {
+		resp.Strategies = append(resp.Strategies, strategyAllocationResponse{
+			Strategy:      a.Strategy,
+			Uncapped:      a.Uncapped,
+			BaseBudgetUSD: a.BaseBudgetUSD,
+			BudgetUSD:     a.BudgetUSD,
+			Factor:        a.Factor,
+			Sharpe:        a.Sharpe,
+			OpenOrdersUSD: a.OpenOrdersUSD,
+			PositionsUSD:  a.PositionsUSD,
+			UsedUSD:       a.UsedUSD(),
+			AvailableUSD:  a.AvailableUSD(),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
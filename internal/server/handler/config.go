@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// RuntimeConfigProvider exposes the resolved, secret-redacted configuration
+// and which optional subsystems are running.
+type RuntimeConfigProvider interface {
+	// RedactedConfig returns the configuration keyed by its TOML names.
+	RedactedConfig() (map[string]any, error)
+	Subsystems() []domain.SubsystemStatus
+}
+
+// StrategyParamsReporter exposes the effective strategy parameters (e.g.
+// strategy.Engine in trade/full mode).
+type StrategyParamsReporter interface {
+	EffectiveParams() map[string]map[string]any
+	ActiveNames() []string
+	Unavailable() map[string][]string
+}
+
+// ConfigHandler serves GET /api/config. Mount it behind middleware.Admin.
+type ConfigHandler struct {
+	cfg        RuntimeConfigProvider
+	strategies StrategyParamsReporter // optional
+	logger     *slog.Logger
+}
+
+// NewConfigHandler creates a ConfigHandler.
+func NewConfigHandler(cfg RuntimeConfigProvider, logger *slog.Logger) *ConfigHandler {
+	return &ConfigHandler{cfg: cfg, logger: logger}
+}
+
+// WithStrategies sets the strategy parameter source. Without it the response
+// has no strategies section.
+func (h *ConfigHandler) WithStrategies(s StrategyParamsReporter) *ConfigHandler {
+	h.strategies = s
+	return h
+}
+
+type subsystemRow struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type strategyConfigRow struct {
+	Name    string         `json:"name"`
+	Active  bool           `json:"active"`
+	Params  map[string]any `json:"params,omitempty"`
+	Missing []string       `json:"missing,omitempty"` // deps that kept an unavailable strategy from registering
+}
+
+type configResponse struct {
+	Config     map[string]any      `json:"config"`
+	Subsystems []subsystemRow      `json:"subsystems"`
+	Strategies []strategyConfigRow `json:"strategies,omitempty"`
+}
+
+// GetConfig returns the resolved runtime config with secrets redacted, the
+// state of each subsystem, and the effective parameters of every strategy.
+// GET /api/config
+func (h *ConfigHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	log := logHandler(h.logger, "config")
+
+	cfg, err := h.cfg.RedactedConfig()
+	if err != nil {
+		log.ErrorContext(r.Context(), "redact config failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to render config")
+		return
+	}
+
+	resp := configResponse{Config: cfg}
+	for _, s := range h.cfg.Subsystems() {
+		resp.Subsystems = append(resp.Subsystems, subsystemRow{Name: s.Name, Active: s.Active, Reason: s.Reason})
+	}
+	if h.strategies != nil {
+		resp.Strategies = strategyRows(h.strategies)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// strategyRows lists registered strategies with their parameters, then the
+// unavailable ones with their missing dependencies, each sorted by name.
+func strategyRows(s StrategyParamsReporter) []strategyConfigRow {
+	active := make(map[string]bool)
+	for _, name := range s.ActiveNames() {
+		active[name] = true
+	}
+
+	params := s.EffectiveParams()
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rows := make([]strategyConfigRow, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, strategyConfigRow{Name: name, Active: active[name], Params: params[name]})
+	}
+
+	unavailable := s.Unavailable()
+	names = names[:0]
+	for name := range unavailable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rows = append(rows, strategyConfigRow{Name: name, Missing: unavailable[name]})
+	}
+	return rows
+}
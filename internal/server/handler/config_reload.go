@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// ConfigReloader re-reads the configuration file and applies the changes
+// that are safe while running.
+type ConfigReloader interface {
+	ReloadConfig(ctx context.Context) (domain.ConfigReloadReport, error)
+}
+
+// ConfigReloadHandler serves POST /api/config/reload. Mount it behind
+// middleware.Admin.
+type ConfigReloadHandler struct {
+	reloader ConfigReloader
+	logger   *slog.Logger
+}
+
+// NewConfigReloadHandler creates a ConfigReloadHandler.
+func NewConfigReloadHandler(reloader ConfigReloader, logger *slog.Logger) *ConfigReloadHandler {
+	return &ConfigReloadHandler{reloader: reloader, logger: logger}
+}
+
+type configChangeJSON struct {
+	Key    string `json:"key"`
+	Old    any    `json:"old"`
+	New    any    `json:"new"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type configReloadResponse struct {
+	ReloadedAt time.Time          `json:"reloaded_at"`
+	Applied    []configChangeJSON `json:"applied"`
+	Rejected   []configChangeJSON `json:"rejected"`
+}
+
+// Reload re-reads the configuration file and environment overrides, applies
+// what can change live (strategy parameters, notify settings, risk
+// thresholds) and reports the rest as rejected with the reason. Secret
+// values are redacted. An invalid file changes nothing and responds 400.
+// POST /api/config/reload
+func (h *ConfigReloadHandler) Reload(w http.ResponseWriter, r *http.Request) {
+	report, err := h.reloader.ReloadConfig(r.Context())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidConfig) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logHandler(h.logger, "config_reload").ErrorContext(r.Context(), "config reload failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to reload config")
+		return
+	}
+	resp := configReloadResponse{
+		ReloadedAt: report.ReloadedAt,
+		Applied:    make([]configChangeJSON, 0, len(report.Applied)),
+		Rejected:   make([]configChangeJSON, 0, len(report.Rejected)),
+	}
+	for _, c := range report.Applied {
+		resp.Applied = append(resp.Applied, configChangeJSON{Key: c.Key, Old: c.Old, New: c.New})
+	}
+	for _, c := range report.Rejected {
+		resp.Rejected = append(resp.Rejected, configChangeJSON{Key: c.Key, Old: c.Old, New: c.New, Reason: c.Reason})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
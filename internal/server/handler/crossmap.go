@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// CrossMapService lists and reviews Polymarket <-> Kalshi market matches
+// (service.MarketMatcher).
+type CrossMapService interface {
+	List(ctx context.Context, status domain.CrossMatchStatus, opts domain.ListOpts) ([]domain.CrossMarketMatch, error)
+	Review(ctx context.Context, id string, status domain.CrossMatchStatus) (domain.CrossMarketMatch, error)
+	Create(ctx context.Context, market, ticker string) (domain.CrossMarketMatch, error)
+}
+
+// CrossMapHandler serves the cross-venue market match review endpoints.
+type CrossMapHandler struct {
+	matches CrossMapService
+	logger  *slog.Logger
+}
+
+// NewCrossMapHandler creates a CrossMapHandler. Until WithService is called
+// every endpoint responds 501.
+func NewCrossMapHandler(logger *slog.Logger) *CrossMapHandler {
+	return &CrossMapHandler{logger: logger}
+}
+
+// WithService sets the matcher backing the endpoints.
+func (h *CrossMapHandler) WithService(matches CrossMapService) *CrossMapHandler {
+	h.matches = matches
+	return h
+}
+
+type crossMatchResponse struct {
+	ID           string             `json:"id"`
+	MarketID     string             `json:"market_id"`
+	MarketSlug   string             `json:"market_slug"`
+	Question     string             `json:"question"`
+	KalshiTicker string             `json:"kalshi_ticker"`
+	KalshiTitle  string             `json:"kalshi_title"`
+	Confidence   float64            `json:"confidence"`
+	Scores       map[string]float64 `json:"scores,omitempty"`
+	Status       string             `json:"status"`
+	Source       string             `json:"source"`
+	ReviewedAt   *time.Time         `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+func toCrossMatchResponse(m domain.CrossMarketMatch) crossMatchResponse {
+	return crossMatchResponse{
+		ID:           m.ID,
+		MarketID:     m.MarketID,
+		MarketSlug:   m.MarketSlug,
+		Question:     m.Question,
+		KalshiTicker: m.KalshiTicker,
+		KalshiTitle:  m.KalshiTitle,
+		Confidence:   m.Confidence,
+		Scores:       m.Scores,
+		Status:       string(m.Status),
+		Source:       m.Source,
+		ReviewedAt:   m.ReviewedAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func (h *CrossMapHandler) available(w http.ResponseWriter) bool {
+	if h.matches == nil {
+		writeError(w, http.StatusNotImplemented, "market matching not available in this mode")
+		return false
+	}
+	return true
+}
+
+// ListMatches returns stored matches, highest confidence first. status is
+// pending (default), approved, rejected or all.
+// GET /api/crossmap?status=pending&limit=50&offset=0
+func (h *CrossMapHandler) ListMatches(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	status := domain.CrossMatchStatus(strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status"))))
+	switch {
+	case status == "":
+		status = domain.CrossMatchPending
+	case status == "all":
+		status = ""
+	case !status.Valid():
+		writeError(w, http.StatusBadRequest, "status must be pending, approved, rejected or all")
+		return
+	}
+	opts := parseListOpts(r)
+	list, err := h.matches.List(r.Context(), status, opts)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list cross matches failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to list matches")
+		return
+	}
+	out := make([]crossMatchResponse, 0, len(list))
+	for _, m := range list {
+		out = append(out, toCrossMatchResponse(m))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"matches": out,
+		"limit":   opts.Limit,
+		"offset":  opts.Offset,
+	})
+}
+
+// crossMapRequest is the body of POST /api/crossmap: either a review of a
+// stored match (id and status) or a manual match (market_id and
+// kalshi_ticker), which is approved immediately.
+type crossMapRequest struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	MarketID     string `json:"market_id"`
+	KalshiTicker string `json:"kalshi_ticker"`
+}
+
+// PostMatch approves or rejects a proposed match, or records a manual one.
+// POST /api/crossmap {"id":"...","status":"approved"}
+// POST /api/crossmap {"market_id":"will-the-fed-cut-in-december","kalshi_ticker":"KXFEDDECISION-25DEC-C25"}
+func (h *CrossMapHandler) PostMatch(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	var req crossMapRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	var (
+		match  domain.CrossMarketMatch
+		err    error
+		status = http.StatusOK
+	)
+	switch {
+	case req.ID != "":
+		match, err = h.matches.Review(r.Context(), req.ID, domain.CrossMatchStatus(strings.ToLower(strings.TrimSpace(req.Status))))
+	case req.MarketID != "" || req.KalshiTicker != "":
+		match, err = h.matches.Create(r.Context(), strings.TrimSpace(req.MarketID), strings.TrimSpace(req.KalshiTicker))
+		status = http.StatusCreated
+	default:
+		writeError(w, http.StatusBadRequest, "id and status, or market_id and kalshi_ticker, are required")
+		return
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "match or market not found")
+		case errors.Is(err, domain.ErrInvalidCrossMatch):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.ErrorContext(r.Context(), "handler: save cross match failed",
+				slog.String("error", err.Error()),
+			)
+			writeError(w, http.StatusInternalServerError, "failed to save match")
+		}
+		return
+	}
+	writeJSON(w, status, toCrossMatchResponse(match))
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// ErrorStatsSource provides failure counts by operation and error class
+// (retry.Retrier).
+type ErrorStatsSource interface {
+	ErrorStats() []domain.ErrorStats
+}
+
+// ErrorsHandler serves GET /api/errors.
+type ErrorsHandler struct {
+	source ErrorStatsSource
+	logger *slog.Logger
+}
+
+// NewErrorsHandler creates an ErrorsHandler. Until WithSource is called the
+// endpoint responds 501.
+func NewErrorsHandler(logger *slog.Logger) *ErrorsHandler {
+	return &ErrorsHandler{logger: logger}
+}
+
+// WithSource sets the retrier backing the endpoint.
+func (h *ErrorsHandler) WithSource(source ErrorStatsSource) *ErrorsHandler {
+	h.source = source
+	return h
+}
+
+type errorStatsRow struct {
+	Op        string `json:"op"`
+	Class     string `json:"class"`
+	Failures  int64  `json:"failures"`
+	Retries   int64  `json:"retries"`
+	Exhausted int64  `json:"exhausted"`
+}
+
+// Errors returns the failed venue and Goldsky calls since startup, counted
+// by operation and error class (transient, rate_limited, auth, validation,
+// fatal, ...), with how many were retried and how many gave up with
+// attempts exhausted. totals sums failures per class.
+// GET /api/errors
+func (h *ErrorsHandler) Errors(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "retry is disabled")
+		return
+	}
+	stats := h.source.ErrorStats()
+	out := make([]errorStatsRow, 0, len(stats))
+	totals := make(map[string]int64)
+	for _, s := range stats {
+		out = append(out, errorStatsRow{
+			Op:        s.Op,
+			Class:     string(s.Class),
+			Failures:  s.Failures,
+			Retries:   s.Retries,
+			Exhausted: s.Exhausted,
+		})
+		totals[string(s.Class)] += s.Failures
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"errors":       out,
+		"totals":       totals,
+		"generated_at": time.Now().UTC(),
+	})
+}
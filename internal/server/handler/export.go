@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LotReporter produces tax-lot reports (service.LotService).
+type LotReporter interface {
+	Report(ctx context.Context, wallet string, method domain.LotMethod, from *time.Time, to time.Time) (domain.LotReport, error)
+}
+
+// ExportHandler serves accounting exports.
+type ExportHandler struct {
+	lots   LotReporter
+	logger *slog.Logger
+}
+
+// NewExportHandler creates an ExportHandler.
+func NewExportHandler(lots LotReporter, logger *slog.Logger) *ExportHandler {
+	return &ExportHandler{lots: lots, logger: logger}
+}
+
+// lotResponse is the JSON shape of a single lot.
+type lotResponse struct {
+	TokenID        string     `json:"token_id"`
+	MarketID       string     `json:"market_id"`
+	Direction      string     `json:"direction"`
+	Size           float64    `json:"size"`
+	OpenOrderID    string     `json:"open_order_id"`
+	OpenPrice      float64    `json:"open_price"`
+	OpenedAt       time.Time  `json:"opened_at"`
+	CloseOrderID   string     `json:"close_order_id,omitempty"`
+	ClosePrice     float64    `json:"close_price,omitempty"`
+	ClosedAt       *time.Time `json:"closed_at,omitempty"`
+	RealizedPnL    float64    `json:"realized_pnl"`
+	HoldingSeconds int64      `json:"holding_seconds,omitempty"`
+}
+
+func toLotResponse(l domain.Lot) lotResponse {
+	return lotResponse{
+		TokenID:        l.TokenID,
+		MarketID:       l.MarketID,
+		Direction:      string(l.Direction),
+		Size:           l.Size,
+		OpenOrderID:    l.OpenOrderID,
+		OpenPrice:      l.OpenPrice,
+		OpenedAt:       l.OpenedAt,
+		CloseOrderID:   l.CloseOrderID,
+		ClosePrice:     l.ClosePrice,
+		ClosedAt:       l.ClosedAt,
+		RealizedPnL:    l.RealizedPnL,
+		HoldingSeconds: int64(l.HoldingPeriod() / time.Second),
+	}
+}
+
+// ExportLots returns per-lot realized gains for a wallet, matched FIFO or
+// LIFO across fills on the same token. Closed lots are those disposed of
+// within [from, to]; open lots are still held at to. format=csv returns the
+// closed lots as a CSV download.
+// GET /api/export/lots?wallet=0x...&method=fifo|lifo&from=RFC3339&to=RFC3339&format=json|csv
+func (h *ExportHandler) ExportLots(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	wallet := q.Get("wallet")
+	if wallet == "" {
+		writeError(w, http.StatusBadRequest, "wallet query parameter required")
+		return
+	}
+	var method domain.LotMethod // empty: service default
+	if v := q.Get("method"); v != "" {
+		m, err := domain.ParseLotMethod(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		method = m
+	}
+	var from *time.Time
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from (expected RFC3339)")
+			return
+		}
+		from = &t
+	}
+	var to time.Time
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to (expected RFC3339)")
+			return
+		}
+		to = t
+	}
+	if from != nil && !to.IsZero() && !from.Before(to) {
+		writeError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	report, err := h.lots.Report(r.Context(), wallet, method, from, to)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: lot export failed",
+			slog.String("wallet", wallet),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to build lot report")
+		return
+	}
+
+	switch q.Get("format") {
+	case "", "json":
+		closed := make([]lotResponse, 0, len(report.Closed))
+		for _, l := range report.Closed {
+			closed = append(closed, toLotResponse(l))
+		}
+		open := make([]lotResponse, 0, len(report.Open))
+		for _, l := range report.Open {
+			open = append(open, toLotResponse(l))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"wallet":       report.Wallet,
+			"method":       report.Method,
+			"from":         report.From,
+			"to":           report.To,
+			"realized_pnl": report.RealizedPnL,
+			"closed_lots":  closed,
+			"open_lots":    open,
+		})
+	case "csv":
+		h.writeLotsCSV(w, r, report)
+	default:
+		writeError(w, http.StatusBadRequest, "format must be json or csv")
+	}
+}
+
+func (h *ExportHandler) writeLotsCSV(w http.ResponseWriter, r *http.Request, report domain.LotReport) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="lots_%s_%s.csv"`, report.Method, report.To.Format("20060102")))
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{
+		"token_id", "market_id", "direction", "size",
+		"opened_at", "open_order_id", "open_price",
+		"closed_at", "close_order_id", "close_price",
+		"realized_pnl", "holding_seconds",
+	})
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 6, 64) }
+	for _, l := range report.Closed {
+		_ = cw.Write([]string{
+			l.TokenID, l.MarketID, string(l.Direction), f(l.Size),
+			l.OpenedAt.Format(time.RFC3339), l.OpenOrderID, f(l.OpenPrice),
+			l.ClosedAt.Format(time.RFC3339), l.CloseOrderID, f(l.ClosePrice),
+			f(l.RealizedPnL), strconv.FormatInt(int64(l.HoldingPeriod()/time.Second), 10),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		h.logger.WarnContext(r.Context(), "handler: write lots csv failed", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// FeeSource provides per-market fee schedules (service.FeeModel).
+type FeeSource interface {
+	Schedule(ctx context.Context, id string) domain.FeeSchedule
+}
+
+// FeesHandler serves GET /api/fees/{market}.
+type FeesHandler struct {
+	source FeeSource
+	logger *slog.Logger
+}
+
+// NewFeesHandler creates a FeesHandler. Until WithSource is called the
+// endpoint responds 501.
+func NewFeesHandler(logger *slog.Logger) *FeesHandler {
+	return &FeesHandler{logger: logger}
+}
+
+// WithSource sets the fee model backing the endpoint.
+func (h *FeesHandler) WithSource(source FeeSource) *FeesHandler {
+	h.source = source
+	return h
+}
+
+type feeScheduleResponse struct {
+	MarketID          string  `json:"market_id"`
+	MakerFeeBps       float64 `json:"maker_fee_bps"`
+	TakerFeeBps       float64 `json:"taker_fee_bps"`
+	RebateBps         float64 `json:"rebate_bps"`
+	EffectiveMakerBps float64 `json:"effective_maker_bps"`
+	EffectiveTakerBps float64 `json:"effective_taker_bps"`
+	RewardsEligible   bool    `json:"rewards_eligible"`
+	Source            string  `json:"source"`
+	FetchedAt         string  `json:"fetched_at"`
+}
+
+// GetFees returns the fee schedule of a market, by market or token ID, with
+// the fees net of builder rebates. Source is "default" when the venue
+// schedule could not be fetched.
+// GET /api/fees/{market}
+func (h *FeesHandler) GetFees(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "fee model not available in this mode")
+		return
+	}
+	market := r.PathValue("market")
+	if market == "" {
+		writeError(w, http.StatusBadRequest, "missing market")
+		return
+	}
+	f := h.source.Schedule(r.Context(), market)
+	writeJSON(w, http.StatusOK, feeScheduleResponse{
+		MarketID:          f.MarketID,
+		MakerFeeBps:       f.MakerFeeBps,
+		TakerFeeBps:       f.TakerFeeBps,
+		RebateBps:         f.RebateBps,
+		EffectiveMakerBps: f.EffectiveMakerBps(),
+		EffectiveTakerBps: f.EffectiveTakerBps(),
+		RewardsEligible:   f.RewardsEligible,
+		Source:            f.Source,
+		FetchedAt:         f.FetchedAt.Format(time.RFC3339),
+	})
+}
@@ -1,14 +1,36 @@
 package handler
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
-// HealthHandler serves the health-check endpoint.
+// FeedHealthReporter reports a market data feed's health
+// (feed.PolymarketWSFeed).
+type FeedHealthReporter interface {
+	Health() domain.FeedHealth
+}
+
+// ExecutorHeartbeatSource reports the executor's dispatch loop liveness
+// (executor.Executor).
+type ExecutorHeartbeatSource interface {
+	Heartbeat() domain.ExecutorHeartbeat
+}
+
+// probeTimeout bounds each dependency probe of a readiness check.
+const probeTimeout = 2 * time.Second
+
+// HealthHandler serves the health-check endpoints.
 type HealthHandler struct {
-	logger *slog.Logger
+	logger   *slog.Logger
+	feeds    []FeedHealthReporter    // optional
+	probes   []domain.HealthProbe    // optional
+	executor ExecutorHeartbeatSource // optional
 }
 
 // NewHealthHandler creates a HealthHandler with the provided logger.
@@ -16,11 +38,186 @@ func NewHealthHandler(logger *slog.Logger) *HealthHandler {
 	return &HealthHandler{logger: logger}
 }
 
+// WithFeeds reports the given market data feeds, and the server degraded
+// while any of them is not live.
+func (h *HealthHandler) WithFeeds(feeds ...FeedHealthReporter) *HealthHandler {
+	h.feeds = append(h.feeds, feeds...)
+	return h
+}
+
+// WithProbes checks the given dependencies on every readiness check.
+func (h *HealthHandler) WithProbes(probes ...domain.HealthProbe) *HealthHandler {
+	h.probes = append(h.probes, probes...)
+	return h
+}
+
+// WithExecutor reports the executor's dispatch loop: the process is not
+// live while the loop is stalled.
+func (h *HealthHandler) WithExecutor(source ExecutorHeartbeatSource) *HealthHandler {
+	h.executor = source
+	return h
+}
+
 // HealthCheck responds with a simple JSON status indicating the server is alive.
+// Status is "degraded" while a reported feed is degraded, reconnecting or
+// not yet connected; the response stays 200 since the process itself is up.
 // GET /api/health
 func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	writeJSON(w, http.StatusOK, map[string]any{
+	resp := map[string]any{
 		"status":    "ok",
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	}
+	if len(h.feeds) > 0 {
+		now := time.Now()
+		feeds := make([]map[string]any, 0, len(h.feeds))
+		for _, f := range h.feeds {
+			fh := f.Health()
+			if !fh.State.Live() {
+				resp["status"] = "degraded"
+			}
+			entry := map[string]any{
+				"feed":              fh.Feed,
+				"state":             string(fh.State),
+				"assets":            fh.Assets,
+				"stale_assets":      fh.StaleAssets,
+				"reconnects":        fh.Reconnects,
+				"forced_reconnects": fh.ForcedReconnects,
+				"resubscribes":      fh.Resubscribes,
+				"polling":           fh.Polling,
+			}
+			if !fh.LastMessage.IsZero() {
+				entry["last_message"] = fh.LastMessage.UTC().Format(time.RFC3339)
+				entry["last_message_age_ms"] = now.Sub(fh.LastMessage).Milliseconds()
+			}
+			if !fh.LastPoll.IsZero() {
+				entry["last_poll"] = fh.LastPoll.UTC().Format(time.RFC3339)
+			}
+			feeds = append(feeds, entry)
+		}
+		resp["feeds"] = feeds
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Live reports whether the process is working: 200 unless the executor's
+// dispatch loop has stalled, 503 then. It checks no external dependency, so
+// an outage elsewhere does not get the process restarted.
+// GET /api/health/live
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	resp := map[string]any{
+		"status":    "ok",
+		"timestamp": now.UTC().Format(time.RFC3339),
+	}
+	code := http.StatusOK
+	if h.executor != nil {
+		check := executorCheck(h.executor.Heartbeat(), now)
+		if check["status"] != "ok" {
+			resp["status"] = "stalled"
+			code = http.StatusServiceUnavailable
+		}
+		resp["checks"] = []map[string]any{check}
+	}
+	writeJSON(w, code, resp)
+}
+
+// Ready reports whether the process can do its work, with the status and
+// latency of each dependency: every probe (Postgres, Redis, S3, CLOB) is run
+// concurrently, bounded by 2s each, and the market feeds and executor are
+// checked. Status is "ready"; "degraded" (still 200) while a feed is not
+// live, since the feed watchdog recovers it and pulling the process out of
+// rotation would not; "unavailable" (503) when a probe fails or the
+// executor has stalled.
+// GET /api/health/ready
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	checks := make([]map[string]any, len(h.probes))
+	var wg sync.WaitGroup
+	for i, p := range h.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks[i] = runProbe(r.Context(), p)
+		}()
+	}
+	wg.Wait()
+
+	status := "ready"
+	for _, c := range checks {
+		if c["status"] != "ok" {
+			status = "unavailable"
+		}
+	}
+	now := time.Now()
+	for _, f := range h.feeds {
+		fh := f.Health()
+		check := map[string]any{
+			"name":         "feed:" + fh.Feed,
+			"status":       "ok",
+			"state":        string(fh.State),
+			"stale_assets": fh.StaleAssets,
+			"polling":      fh.Polling,
+		}
+		if !fh.LastMessage.IsZero() {
+			check["last_message_age_ms"] = now.Sub(fh.LastMessage).Milliseconds()
+		}
+		if !fh.State.Live() {
+			check["status"] = "degraded"
+			if status == "ready" {
+				status = "degraded"
+			}
+		}
+		checks = append(checks, check)
+	}
+	if h.executor != nil {
+		check := executorCheck(h.executor.Heartbeat(), now)
+		if check["status"] != "ok" {
+			status = "unavailable"
+		}
+		checks = append(checks, check)
+	}
+
+	code := http.StatusOK
+	if status == "unavailable" {
+		code = http.StatusServiceUnavailable
+		h.logger.WarnContext(r.Context(), "readiness check failed", slog.Any("checks", checks))
+	}
+	writeJSON(w, code, map[string]any{
+		"status":    status,
+		"timestamp": now.UTC().Format(time.RFC3339),
+		"checks":    checks,
 	})
 }
+
+// runProbe runs one dependency probe and reports its outcome and latency.
+func runProbe(ctx context.Context, p domain.HealthProbe) map[string]any {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	start := time.Now()
+	err := p.Check(ctx)
+	check := map[string]any{
+		"name":       p.Name,
+		"status":     "ok",
+		"latency_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		check["status"] = "error"
+		check["error"] = err.Error()
+	}
+	return check
+}
+
+// executorCheck reports the executor's dispatch loop liveness at now.
+func executorCheck(hb domain.ExecutorHeartbeat, now time.Time) map[string]any {
+	check := map[string]any{
+		"name":    "executor",
+		"status":  "ok",
+		"running": hb.Running,
+	}
+	if !hb.LastBeat.IsZero() {
+		check["last_beat_age_ms"] = now.Sub(hb.LastBeat).Milliseconds()
+	}
+	if hb.Stalled(now) {
+		check["status"] = "stalled"
+	}
+	return check
+}
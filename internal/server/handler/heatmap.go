@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// HeatmapSource provides the running per-market heat map
+// (service.HeatmapAggregator).
+type HeatmapSource interface {
+	Snapshot() []domain.MarketHeat
+}
+
+// HeatmapHandler serves GET /api/heatmap.
+type HeatmapHandler struct {
+	source HeatmapSource
+	logger *slog.Logger
+}
+
+// NewHeatmapHandler creates a HeatmapHandler. Until WithSource is called the
+// endpoint responds 501.
+func NewHeatmapHandler(logger *slog.Logger) *HeatmapHandler {
+	return &HeatmapHandler{logger: logger}
+}
+
+// WithSource sets the aggregator backing the endpoint.
+func (h *HeatmapHandler) WithSource(source HeatmapSource) *HeatmapHandler {
+	h.source = source
+	return h
+}
+
+type heatmapCell struct {
+	MarketID          string         `json:"market_id"`
+	Slug              string         `json:"slug"`
+	Question          string         `json:"question"`
+	Priced            bool           `json:"priced"`
+	SumDeviation      *float64       `json:"sum_deviation"`
+	Spread            *float64       `json:"spread"`
+	Volume1h          float64        `json:"volume_1h"`
+	Signals1h         int            `json:"signals_1h"`
+	SignalsByStrategy map[string]int `json:"signals_by_strategy,omitempty"`
+	PriceUpdatedAt    *time.Time     `json:"price_updated_at,omitempty"`
+}
+
+// Heatmap returns every watched market with its YES/NO sum deviation (sum of
+// outcome mids - 1), widest spread, last-hour volume and signal counts,
+// largest deviation first. sum_deviation and spread are null until every
+// outcome has a two-sided book. limit caps the number of markets returned.
+// GET /api/heatmap?limit=100
+func (h *HeatmapHandler) Heatmap(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "heat map not available in this mode")
+		return
+	}
+	cells := h.source.Snapshot()
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		if n < len(cells) {
+			cells = cells[:n]
+		}
+	}
+
+	out := make([]heatmapCell, 0, len(cells))
+	for _, c := range cells {
+		cell := heatmapCell{
+			MarketID:          c.MarketID,
+			Slug:              c.Slug,
+			Question:          c.Question,
+			Priced:            c.Priced,
+			Volume1h:          c.Volume1h,
+			Signals1h:         c.Signals1h,
+			SignalsByStrategy: c.SignalsByStrategy,
+		}
+		if c.Priced {
+			dev, spread := c.SumDeviation, c.Spread
+			cell.SumDeviation = &dev
+			cell.Spread = &spread
+		}
+		if !c.PriceUpdatedAt.IsZero() {
+			at := c.PriceUpdatedAt
+			cell.PriceUpdatedAt = &at
+		}
+		out = append(out, cell)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"markets":      out,
+		"generated_at": time.Now().UTC(),
+	})
+}
@@ -3,10 +3,12 @@ package handler
 import (
 	"encoding/json"
 	"log/slog"
+	"maps"
 	"net/http"
 	"strconv"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/server/middleware"
 )
 
 // writeJSON marshals v as JSON and writes it to the response with the given
@@ -61,6 +63,28 @@ func pathParam(r *http.Request, name string) string {
 	return r.PathValue(name)
 }
 
+// auditAction records an operator action in the audit log when audit is
+// set, naming the authenticated principal when server.auth is enabled.
+// Failures are logged; the action itself has already been applied.
+func auditAction(r *http.Request, audit domain.AuditStore, logger *slog.Logger, event string, detail map[string]any) {
+	if audit == nil {
+		return
+	}
+	if p, ok := middleware.PrincipalFrom(r.Context()); ok {
+		detail = maps.Clone(detail)
+		if detail == nil {
+			detail = make(map[string]any, 1)
+		}
+		detail["principal"] = p.Name
+	}
+	if err := audit.Log(r.Context(), event, detail); err != nil {
+		logger.WarnContext(r.Context(), "handler: audit log failed",
+			slog.String("event", event),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
 // logHandler is a convenience to attach slog fields in handler code.
 func logHandler(logger *slog.Logger, handler string) *slog.Logger {
 	return logger.With(slog.String("handler", handler))
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// InstrumentRegistry resolves and maintains canonical instrument records
+// (service.InstrumentRegistry).
+type InstrumentRegistry interface {
+	Get(ctx context.Context, id string) (domain.Instrument, error)
+	Resolve(ctx context.Context, kind domain.IDKind, value string) (domain.Instrument, error)
+	ResolveBatch(ctx context.Context, kind domain.IDKind, values []string) (map[string]domain.Instrument, error)
+	List(ctx context.Context, opts domain.ListOpts) ([]domain.Instrument, error)
+	Link(ctx context.Context, id string, kind domain.IDKind, value string) (domain.Instrument, error)
+}
+
+// maxInstrumentLookup caps the values accepted by one bulk lookup.
+const maxInstrumentLookup = 500
+
+// InstrumentHandler serves the instrument registry endpoints.
+type InstrumentHandler struct {
+	registry InstrumentRegistry
+	logger   *slog.Logger
+}
+
+// NewInstrumentHandler creates an InstrumentHandler. Until WithRegistry is
+// called every endpoint responds 501.
+func NewInstrumentHandler(logger *slog.Logger) *InstrumentHandler {
+	return &InstrumentHandler{logger: logger}
+}
+
+// WithRegistry sets the instrument registry backing the endpoints.
+func (h *InstrumentHandler) WithRegistry(registry InstrumentRegistry) *InstrumentHandler {
+	h.registry = registry
+	return h
+}
+
+type instrumentResponse struct {
+	ID          string            `json:"id"`
+	Question    string            `json:"question"`
+	Identifiers map[string]string `json:"identifiers"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+func toInstrumentResponse(inst domain.Instrument) instrumentResponse {
+	ids := make(map[string]string, len(inst.Identifiers))
+	for k, v := range inst.Identifiers {
+		ids[string(k)] = v
+	}
+	return instrumentResponse{
+		ID:          inst.ID,
+		Question:    inst.Question,
+		Identifiers: ids,
+		CreatedAt:   inst.CreatedAt,
+		UpdatedAt:   inst.UpdatedAt,
+	}
+}
+
+func (h *InstrumentHandler) available(w http.ResponseWriter) bool {
+	if h.registry == nil {
+		writeError(w, http.StatusNotImplemented, "instrument registry not available in this mode")
+		return false
+	}
+	return true
+}
+
+// ListInstruments returns instruments ordered by ID.
+// GET /api/instruments?limit=50&offset=0
+func (h *InstrumentHandler) ListInstruments(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	opts := parseListOpts(r)
+	list, err := h.registry.List(r.Context(), opts)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list instruments failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to list instruments")
+		return
+	}
+	out := make([]instrumentResponse, 0, len(list))
+	for _, inst := range list {
+		out = append(out, toInstrumentResponse(inst))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"instruments": out,
+		"limit":       opts.Limit,
+		"offset":      opts.Offset,
+	})
+}
+
+// GetInstrument returns one instrument by its canonical ID.
+// GET /api/instruments/{id}
+func (h *InstrumentHandler) GetInstrument(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	id := pathParam(r, "id")
+	inst, err := h.registry.Get(r.Context(), id)
+	if err != nil {
+		h.writeRegistryError(w, r, "get instrument", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstrumentResponse(inst))
+}
+
+// ResolveInstrument finds the instrument an identifier belongs to. kind is
+// one of market_id, condition_id, slug, yes_token, no_token, token_id (either
+// outcome token), kalshi_ticker, predictit_ref or manifold_ref.
+// GET /api/instruments/resolve?kind=token_id&value=...
+func (h *InstrumentHandler) ResolveInstrument(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	kind := domain.IDKind(strings.TrimSpace(r.URL.Query().Get("kind")))
+	value := strings.TrimSpace(r.URL.Query().Get("value"))
+	if kind == "" || value == "" {
+		writeError(w, http.StatusBadRequest, "kind and value are required")
+		return
+	}
+	inst, err := h.registry.Resolve(r.Context(), kind, value)
+	if err != nil {
+		h.writeRegistryError(w, r, "resolve instrument", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstrumentResponse(inst))
+}
+
+type lookupInstrumentsRequest struct {
+	Kind   string   `json:"kind"`
+	Values []string `json:"values"`
+}
+
+type lookupInstrumentsResponse struct {
+	Kind        string                        `json:"kind"`
+	Instruments map[string]instrumentResponse `json:"instruments"`
+	Missing     []string                      `json:"missing"`
+}
+
+// LookupInstruments resolves many identifiers of one kind in a single call.
+// The response maps each found value to its instrument and lists the rest
+// under missing.
+// POST /api/instruments/lookup {"kind":"token_id","values":["...","..."]}
+func (h *InstrumentHandler) LookupInstruments(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	var req lookupInstrumentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if req.Kind == "" || len(req.Values) == 0 {
+		writeError(w, http.StatusBadRequest, "kind and values are required")
+		return
+	}
+	if len(req.Values) > maxInstrumentLookup {
+		writeError(w, http.StatusBadRequest, "too many values (max 500)")
+		return
+	}
+
+	found, err := h.registry.ResolveBatch(r.Context(), domain.IDKind(req.Kind), req.Values)
+	if err != nil {
+		h.writeRegistryError(w, r, "lookup instruments", err)
+		return
+	}
+	resp := lookupInstrumentsResponse{
+		Kind:        req.Kind,
+		Instruments: make(map[string]instrumentResponse, len(found)),
+		Missing:     []string{},
+	}
+	for _, v := range req.Values {
+		if inst, ok := found[v]; ok {
+			resp.Instruments[v] = toInstrumentResponse(inst)
+		} else {
+			resp.Missing = append(resp.Missing, v)
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// LinkIdentifier sets or clears one identifier on an instrument, e.g. to map
+// it to a Kalshi ticker. An empty value removes the identifier.
+// PUT /api/instruments/{id}/identifiers/{kind} {"value":"KXFED-25DEC-T4.00"}
+func (h *InstrumentHandler) LinkIdentifier(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	id := pathParam(r, "id")
+	kind := domain.IDKind(pathParam(r, "kind"))
+	var body struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	inst, err := h.registry.Link(r.Context(), id, kind, strings.TrimSpace(body.Value))
+	if err != nil {
+		h.writeRegistryError(w, r, "link instrument identifier", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstrumentResponse(inst))
+}
+
+func (h *InstrumentHandler) writeRegistryError(w http.ResponseWriter, r *http.Request, op string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, "instrument not found")
+	case errors.Is(err, domain.ErrInvalidInstrument):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrAlreadyExists):
+		writeError(w, http.StatusConflict, "identifier already linked to another instrument")
+	default:
+		h.logger.ErrorContext(r.Context(), "handler: "+op+" failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to "+op)
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LatencySource provides per-stage latency quantiles and the slowest
+// persisted order breakdowns (service.LatencyTracker).
+type LatencySource interface {
+	Stats(strategy string) []domain.LatencyStats
+	Slowest(ctx context.Context, strategy string, since time.Time, limit int) ([]domain.OrderLatency, error)
+}
+
+// LatencyHandler serves GET /api/latency.
+type LatencyHandler struct {
+	source LatencySource
+	logger *slog.Logger
+}
+
+// NewLatencyHandler creates a LatencyHandler. Until WithSource is called the
+// endpoint responds 501.
+func NewLatencyHandler(logger *slog.Logger) *LatencyHandler {
+	return &LatencyHandler{logger: logger}
+}
+
+// WithSource sets the tracker backing the endpoint.
+func (h *LatencyHandler) WithSource(source LatencySource) *LatencyHandler {
+	h.source = source
+	return h
+}
+
+type latencyStageRow struct {
+	Stage   string  `json:"stage"`
+	Samples int     `json:"samples"`
+	P50Ms   float64 `json:"p50_ms"`
+	P95Ms   float64 `json:"p95_ms"`
+	P99Ms   float64 `json:"p99_ms"`
+	MaxMs   float64 `json:"max_ms"`
+	Count   int64   `json:"count"`
+	MeanMs  float64 `json:"mean_ms"`
+}
+
+type orderLatencyRow struct {
+	OrderID   string             `json:"order_id"`
+	Strategy  string             `json:"strategy"`
+	MarketID  string             `json:"market_id"`
+	EmittedAt *time.Time         `json:"emitted_at,omitempty"`
+	AckedAt   time.Time          `json:"acked_at"`
+	FilledAt  *time.Time         `json:"filled_at,omitempty"`
+	StagesMs  map[string]float64 `json:"stages_ms"`
+}
+
+// Latency returns p50/p95/p99 and max of every stage from signal emission
+// to fill (queue, risk, sign, post, ack, fill, total) over the recent
+// samples, of ?strategy= only when set, and the ?limit= (default 20, max
+// 200) slowest order breakdowns acknowledged since ?since= (RFC 3339 or
+// YYYY-MM-DD; default the last hour).
+// GET /api/latency
+func (h *LatencyHandler) Latency(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "latency tracking is disabled")
+		return
+	}
+	q := r.URL.Query()
+	strategy := q.Get("strategy")
+	since := time.Now().UTC().Add(-time.Hour)
+	if v := q.Get("since"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid since: want RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		since = t
+	}
+	limit := 20
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = min(n, 200)
+	}
+
+	stats := h.source.Stats(strategy)
+	stages := make([]latencyStageRow, 0, len(stats))
+	for _, s := range stats {
+		row := latencyStageRow{
+			Stage:   string(s.Stage),
+			Samples: s.Samples,
+			P50Ms:   durationMs(s.P50),
+			P95Ms:   durationMs(s.P95),
+			P99Ms:   durationMs(s.P99),
+			MaxMs:   durationMs(s.Max),
+			Count:   s.Count,
+		}
+		if s.Count > 0 {
+			row.MeanMs = durationMs(s.Sum) / float64(s.Count)
+		}
+		stages = append(stages, row)
+	}
+
+	slowest := []orderLatencyRow{}
+	if limit > 0 {
+		orders, err := h.source.Slowest(r.Context(), strategy, since, limit)
+		if err != nil {
+			logHandler(h.logger, "latency").ErrorContext(r.Context(), "list slowest orders failed",
+				slog.String("error", err.Error()),
+			)
+			writeError(w, http.StatusInternalServerError, "failed to list order latencies")
+			return
+		}
+		for _, o := range orders {
+			row := orderLatencyRow{
+				OrderID:  o.OrderID,
+				Strategy: o.Strategy,
+				MarketID: o.MarketID,
+				AckedAt:  o.AckedAt,
+				StagesMs: make(map[string]float64, len(o.Stages)),
+			}
+			if !o.EmittedAt.IsZero() {
+				row.EmittedAt = &o.EmittedAt
+			}
+			if !o.FilledAt.IsZero() {
+				row.FilledAt = &o.FilledAt
+			}
+			for stage, d := range o.Stages {
+				row.StagesMs[string(stage)] = durationMs(d)
+			}
+			slowest = append(slowest, row)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"strategy":     strategy,
+		"stages":       stages,
+		"slowest":      slowest,
+		"since":        since,
+		"generated_at": time.Now().UTC(),
+	})
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LPRewardsSource serves the recorded liquidity reward days and current
+// estimates (service.RewardsTracker).
+type LPRewardsSource interface {
+	RewardDays(ctx context.Context, marketID string, from, to time.Time) ([]domain.LPRewardDay, error)
+	RewardEstimates(ctx context.Context) (map[string]domain.LPRewardEstimate, error)
+}
+
+// LPRewardsHandler serves GET /api/rewards/lp.
+type LPRewardsHandler struct {
+	source LPRewardsSource
+	logger *slog.Logger
+}
+
+// NewLPRewardsHandler creates an LPRewardsHandler. Until WithSource is
+// called the endpoint responds 501.
+func NewLPRewardsHandler(logger *slog.Logger) *LPRewardsHandler {
+	return &LPRewardsHandler{logger: logger}
+}
+
+// WithSource sets the service backing the endpoint.
+func (h *LPRewardsHandler) WithSource(source LPRewardsSource) *LPRewardsHandler {
+	h.source = source
+	return h
+}
+
+type lpRewardDayRow struct {
+	Day               string  `json:"day"`
+	MarketID          string  `json:"market_id"`
+	Eligible          bool    `json:"eligible"`
+	DailyRate         float64 `json:"daily_rate"`
+	MaxSpread         float64 `json:"max_spread"`
+	MinSize           float64 `json:"min_size"`
+	QuotedSeconds     float64 `json:"quoted_seconds"`
+	QualifyingSeconds float64 `json:"qualifying_seconds"`
+	Uptime            float64 `json:"uptime"`
+	AvgShare          float64 `json:"avg_share"`
+	EstimatedUSD      float64 `json:"estimated_usd"`
+}
+
+type lpRewardMarketRow struct {
+	MarketID          string  `json:"market_id"`
+	Days              int     `json:"days"`
+	QuotedSeconds     float64 `json:"quoted_seconds"`
+	EstimatedUSD      float64 `json:"estimated_usd"`
+	EstimatedDailyUSD float64 `json:"estimated_daily_usd"`
+	DailyRate         float64 `json:"daily_rate"`
+	Eligible          bool    `json:"eligible"`
+}
+
+type lpRewardsResponse struct {
+	From         time.Time           `json:"from"`
+	To           time.Time           `json:"to"`
+	EstimatedUSD float64             `json:"estimated_usd"`
+	Markets      []lpRewardMarketRow `json:"markets"`
+	Days         []lpRewardDayRow    `json:"days"`
+}
+
+// LPRewards returns the liquidity rewards our liquidity_provider quotes are
+// estimated to have accrued per market and UTC day in [?from=, ?to=)
+// (RFC 3339 or YYYY-MM-DD; default the last 30 days), with quoting uptime
+// within the max spread and average score share. ?market= filters to one
+// market. Markets carry totals and the current estimated daily accrual.
+// GET /api/rewards/lp
+func (h *LPRewardsHandler) LPRewards(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "lp reward tracking not configured")
+		return
+	}
+	q := r.URL.Query()
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	from := to.AddDate(0, 0, -30)
+	if v := q.Get("from"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid from: want RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid to: want RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		to = t
+	}
+	if !from.Before(to) {
+		writeError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+	marketID := q.Get("market")
+
+	days, err := h.source.RewardDays(r.Context(), marketID, from, to)
+	if err != nil {
+		logHandler(h.logger, "lp_rewards").ErrorContext(r.Context(), "list lp reward days failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to list lp reward days")
+		return
+	}
+	estimates, err := h.source.RewardEstimates(r.Context())
+	if err != nil {
+		logHandler(h.logger, "lp_rewards").WarnContext(r.Context(), "lp reward estimates failed",
+			slog.String("error", err.Error()),
+		)
+	}
+
+	resp := lpRewardsResponse{
+		From: from,
+		To:   to,
+		Days: make([]lpRewardDayRow, 0, len(days)),
+	}
+	markets := make(map[string]*lpRewardMarketRow)
+	for _, d := range days {
+		resp.Days = append(resp.Days, lpRewardDayRow{
+			Day:               d.Day.Format("2006-01-02"),
+			MarketID:          d.MarketID,
+			Eligible:          d.Eligible,
+			DailyRate:         d.DailyRate,
+			MaxSpread:         d.MaxSpread,
+			MinSize:           d.MinSize,
+			QuotedSeconds:     d.QuotedSeconds,
+			QualifyingSeconds: d.QualifyingSeconds,
+			Uptime:            d.Uptime(),
+			AvgShare:          d.AvgShare(),
+			EstimatedUSD:      d.EstimatedUSD,
+		})
+		if d.QuotedSeconds <= 0 {
+			continue
+		}
+		m, ok := markets[d.MarketID]
+		if !ok {
+			m = &lpRewardMarketRow{MarketID: d.MarketID}
+			markets[d.MarketID] = m
+		}
+		m.Days++
+		m.QuotedSeconds += d.QuotedSeconds
+		m.EstimatedUSD += d.EstimatedUSD
+		resp.EstimatedUSD += d.EstimatedUSD
+	}
+	resp.Markets = make([]lpRewardMarketRow, 0, len(markets))
+	for id, m := range markets {
+		if est, ok := estimates[id]; ok {
+			m.EstimatedDailyUSD = est.EstimatedDailyUSD
+			m.DailyRate = est.DailyRate
+			m.Eligible = true
+		}
+		resp.Markets = append(resp.Markets, *m)
+	}
+	sort.Slice(resp.Markets, func(i, j int) bool {
+		if resp.Markets[i].EstimatedUSD != resp.Markets[j].EstimatedUSD {
+			return resp.Markets[i].EstimatedUSD > resp.Markets[j].EstimatedUSD
+		}
+		return resp.Markets[i].MarketID < resp.Markets[j].MarketID
+	})
+	writeJSON(w, http.StatusOK, resp)
+}
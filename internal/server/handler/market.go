@@ -18,10 +18,16 @@ type MarketService interface {
 	Count(ctx context.Context) (int64, error)
 }
 
+// MarketIdentifierResolver maps any market identifier to its instrument.
+type MarketIdentifierResolver interface {
+	Resolve(ctx context.Context, kind domain.IDKind, value string) (domain.Instrument, error)
+}
+
 // MarketHandler serves market-related HTTP endpoints.
 type MarketHandler struct {
-	markets MarketService
-	logger  *slog.Logger
+	markets     MarketService
+	instruments MarketIdentifierResolver
+	logger      *slog.Logger
 }
 
 // NewMarketHandler creates a MarketHandler with the given service and logger.
@@ -32,6 +38,13 @@ func NewMarketHandler(markets MarketService, logger *slog.Logger) *MarketHandler
 	}
 }
 
+// WithInstruments lets GET /api/markets/{id} accept a slug, token ID or
+// condition ID in place of the market ID.
+func (h *MarketHandler) WithInstruments(instruments MarketIdentifierResolver) *MarketHandler {
+	h.instruments = instruments
+	return h
+}
+
 // listMarketsResponse wraps the list endpoint output with metadata.
 type listMarketsResponse struct {
 	Markets []domain.Market `json:"markets"`
@@ -71,7 +84,8 @@ func (h *MarketHandler) ListMarkets(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// GetMarket returns a single market by its ID.
+// GetMarket returns a single market by its ID, or by slug, token ID or
+// condition ID when an instrument registry is configured.
 // GET /api/markets/{id}
 func (h *MarketHandler) GetMarket(w http.ResponseWriter, r *http.Request) {
 	id := pathParam(r, "id")
@@ -81,6 +95,11 @@ func (h *MarketHandler) GetMarket(w http.ResponseWriter, r *http.Request) {
 	}
 
 	market, err := h.markets.GetMarket(r.Context(), id)
+	if errors.Is(err, domain.ErrNotFound) && h.instruments != nil {
+		if marketID := h.resolveMarketID(r.Context(), id); marketID != "" {
+			market, err = h.markets.GetMarket(r.Context(), marketID)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			writeError(w, http.StatusNotFound, "market not found")
@@ -96,3 +115,14 @@ func (h *MarketHandler) GetMarket(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, market)
 }
+
+// resolveMarketID returns the market ID for a slug, token ID or condition ID,
+// or "" if none match.
+func (h *MarketHandler) resolveMarketID(ctx context.Context, ref string) string {
+	for _, kind := range []domain.IDKind{domain.IDKindSlug, domain.IDKindToken, domain.IDKindCondition} {
+		if inst, err := h.instruments.Resolve(ctx, kind, ref); err == nil {
+			return inst.Ref(domain.IDKindMarket)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// MarketAnalyticsHandler serves GET /api/markets/{id}/analytics.
+type MarketAnalyticsHandler struct {
+	markets MarketService
+	source  domain.TradeAnalyticsProvider
+	logger  *slog.Logger
+}
+
+// NewMarketAnalyticsHandler creates a MarketAnalyticsHandler. Until
+// WithSource is called the endpoint responds 501.
+func NewMarketAnalyticsHandler(markets MarketService, logger *slog.Logger) *MarketAnalyticsHandler {
+	return &MarketAnalyticsHandler{markets: markets, logger: logger}
+}
+
+// WithSource sets the trade analytics backing the endpoint
+// (service.TradeAnalytics).
+func (h *MarketAnalyticsHandler) WithSource(source domain.TradeAnalyticsProvider) *MarketAnalyticsHandler {
+	h.source = source
+	return h
+}
+
+type volumeBucketJSON struct {
+	Price     float64 `json:"price"`
+	VolumeUSD float64 `json:"volume_usd"`
+	Trades    int     `json:"trades"`
+}
+
+type marketAnalyticsResponse struct {
+	MarketID    string             `json:"market_id"`
+	VWAP        float64            `json:"vwap"`
+	VWAPWindow  string             `json:"vwap_window"`
+	LastPrice   float64            `json:"last_price"`
+	Volume24h   float64            `json:"volume_24h"`
+	Trades24h   int                `json:"trades_24h"`
+	BucketWidth float64            `json:"bucket_width"`
+	Profile     []volumeBucketJSON `json:"profile"`
+	UpdatedAt   *time.Time         `json:"updated_at,omitempty"`
+}
+
+// Analytics returns the rolling trade analytics of a market: VWAP, 24h
+// volume and trade count, and the 24h volume profile by price bucket,
+// lowest first. Prices are those of the first outcome. A market without
+// trades in the last 24 hours reports zeros.
+// GET /api/markets/{id}/analytics
+func (h *MarketAnalyticsHandler) Analytics(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "market analytics not available: trade_analytics not enabled")
+		return
+	}
+	id := pathParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing market id")
+		return
+	}
+	market, err := h.markets.GetMarket(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "market not found")
+			return
+		}
+		logHandler(h.logger, "market_analytics").ErrorContext(r.Context(), "get market failed",
+			slog.String("market_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to get market")
+		return
+	}
+
+	a, err := h.source.TradeAnalytics(r.Context(), market.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		logHandler(h.logger, "market_analytics").ErrorContext(r.Context(), "get trade analytics failed",
+			slog.String("market_id", market.ID),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to get market analytics")
+		return
+	}
+	resp := marketAnalyticsResponse{
+		MarketID:    market.ID,
+		VWAP:        a.VWAP,
+		VWAPWindow:  a.VWAPWindow.String(),
+		LastPrice:   a.LastPrice,
+		Volume24h:   a.Volume24h,
+		Trades24h:   a.Trades24h,
+		BucketWidth: a.BucketWidth,
+		Profile:     make([]volumeBucketJSON, 0, len(a.Profile)),
+	}
+	if !a.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &a.UpdatedAt
+	}
+	for _, b := range a.Profile {
+		resp.Profile = append(resp.Profile, volumeBucketJSON{
+			Price:     b.Price,
+			VolumeUSD: b.VolumeUSD,
+			Trades:    b.Trades,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
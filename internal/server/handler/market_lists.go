@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// MarketListSource manages the market blacklist and whitelist
+// (service.MarketListService).
+type MarketListSource interface {
+	Add(ctx context.Context, list domain.MarketList, marketID, reason string) (domain.MarketListEntry, error)
+	Remove(ctx context.Context, list domain.MarketList, marketID string) error
+	Entries(ctx context.Context) ([]domain.MarketListEntry, error)
+}
+
+// MarketListHandler serves the market list endpoints.
+type MarketListHandler struct {
+	source MarketListSource
+	logger *slog.Logger
+}
+
+// NewMarketListHandler creates a MarketListHandler. Until WithSource is
+// called the endpoints respond 501.
+func NewMarketListHandler(logger *slog.Logger) *MarketListHandler {
+	return &MarketListHandler{logger: logger}
+}
+
+// WithSource sets the service backing the endpoints.
+func (h *MarketListHandler) WithSource(source MarketListSource) *MarketListHandler {
+	h.source = source
+	return h
+}
+
+type marketListEntryResponse struct {
+	MarketID  string    `json:"market_id"`
+	TokenIDs  []string  `json:"token_ids"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type marketListsResponse struct {
+	Blacklist []marketListEntryResponse `json:"blacklist"`
+	Whitelist []marketListEntryResponse `json:"whitelist"`
+}
+
+func toMarketListEntryResponse(e domain.MarketListEntry) marketListEntryResponse {
+	tokens := e.TokenIDs
+	if tokens == nil {
+		tokens = []string{}
+	}
+	return marketListEntryResponse{
+		MarketID:  e.MarketID,
+		TokenIDs:  tokens,
+		Reason:    e.Reason,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// Lists returns both lists, oldest entry first. The whitelist only applies
+// while it is not empty.
+// GET /api/markets/lists
+func (h *MarketListHandler) Lists(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "market lists not configured")
+		return
+	}
+	entries, err := h.source.Entries(r.Context())
+	if err != nil {
+		h.writeListError(w, r, "list", err)
+		return
+	}
+	resp := marketListsResponse{
+		Blacklist: []marketListEntryResponse{},
+		Whitelist: []marketListEntryResponse{},
+	}
+	for _, e := range entries {
+		if e.List == domain.MarketListWhitelist {
+			resp.Whitelist = append(resp.Whitelist, toMarketListEntryResponse(e))
+		} else {
+			resp.Blacklist = append(resp.Blacklist, toMarketListEntryResponse(e))
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type marketListRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Add puts the market on the blacklist or whitelist, with an optional
+// {"reason": "..."} body. Blacklisted markets are excluded from every
+// strategy; once the whitelist holds a market, only whitelisted markets are
+// traded.
+// POST /api/markets/{id}/blacklist, POST /api/markets/{id}/whitelist
+func (h *MarketListHandler) Add(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "market lists not configured")
+		return
+	}
+	list := domain.MarketList(r.PathValue("list"))
+	if !list.Valid() {
+		writeError(w, http.StatusNotFound, "unknown market list: want blacklist or whitelist")
+		return
+	}
+	var req marketListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	entry, err := h.source.Add(r.Context(), list, r.PathValue("id"), req.Reason)
+	if err != nil {
+		h.writeListError(w, r, "add", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toMarketListEntryResponse(entry))
+}
+
+// Remove takes the market off the blacklist or whitelist.
+// DELETE /api/markets/{id}/blacklist, DELETE /api/markets/{id}/whitelist
+func (h *MarketListHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "market lists not configured")
+		return
+	}
+	list := domain.MarketList(r.PathValue("list"))
+	if !list.Valid() {
+		writeError(w, http.StatusNotFound, "unknown market list: want blacklist or whitelist")
+		return
+	}
+	id := r.PathValue("id")
+	if err := h.source.Remove(r.Context(), list, id); err != nil {
+		h.writeListError(w, r, "remove", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"removed": id, "list": string(list)})
+}
+
+func (h *MarketListHandler) writeListError(w http.ResponseWriter, r *http.Request, op string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, "market not found or not on the list")
+	case errors.Is(err, domain.ErrInvalidMarketList):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		logHandler(h.logger, "market_lists").ErrorContext(r.Context(), op+" market list failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to "+op+" market list")
+	}
+}
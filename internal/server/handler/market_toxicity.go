@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// MarketToxicityHandler serves GET /api/markets/{id}/toxicity.
+type MarketToxicityHandler struct {
+	markets MarketService
+	source  domain.ToxicityProvider
+	logger  *slog.Logger
+}
+
+// NewMarketToxicityHandler creates a MarketToxicityHandler. Until WithSource
+// is called the endpoint responds 501.
+func NewMarketToxicityHandler(markets MarketService, logger *slog.Logger) *MarketToxicityHandler {
+	return &MarketToxicityHandler{markets: markets, logger: logger}
+}
+
+// WithSource sets the toxicity scores backing the endpoint
+// (analytics.ToxicityTracker).
+func (h *MarketToxicityHandler) WithSource(source domain.ToxicityProvider) *MarketToxicityHandler {
+	h.source = source
+	return h
+}
+
+type tokenToxicityJSON struct {
+	TokenID      string     `json:"token_id"`
+	Outcome      string     `json:"outcome"`
+	VPIN         float64    `json:"vpin"`
+	Buckets      int        `json:"buckets"`
+	BucketVolume float64    `json:"bucket_volume"`
+	Warm         bool       `json:"warm"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+type marketToxicityResponse struct {
+	MarketID string              `json:"market_id"`
+	Tokens   []tokenToxicityJSON `json:"tokens"`
+}
+
+// Toxicity returns the order flow toxicity (VPIN) of each of a market's
+// tokens. A token without recent trades reports zeros; scores are acted on
+// by strategies only once warm.
+// GET /api/markets/{id}/toxicity
+func (h *MarketToxicityHandler) Toxicity(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "market toxicity not available: toxicity not enabled")
+		return
+	}
+	id := pathParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing market id")
+		return
+	}
+	market, err := h.markets.GetMarket(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "market not found")
+			return
+		}
+		logHandler(h.logger, "market_toxicity").ErrorContext(r.Context(), "get market failed",
+			slog.String("market_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to get market")
+		return
+	}
+
+	resp := marketToxicityResponse{
+		MarketID: market.ID,
+		Tokens:   make([]tokenToxicityJSON, 0, len(market.TokenIDs)),
+	}
+	for _, tokenID := range market.TokenIDs {
+		if tokenID == "" {
+			continue
+		}
+		t, err := h.source.Toxicity(r.Context(), tokenID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			logHandler(h.logger, "market_toxicity").ErrorContext(r.Context(), "get toxicity failed",
+				slog.String("market_id", market.ID),
+				slog.String("token_id", tokenID),
+				slog.String("error", err.Error()),
+			)
+			writeError(w, http.StatusInternalServerError, "failed to get market toxicity")
+			return
+		}
+		entry := tokenToxicityJSON{
+			TokenID:      tokenID,
+			Outcome:      market.OutcomeName(tokenID),
+			VPIN:         t.VPIN,
+			Buckets:      t.Buckets,
+			BucketVolume: t.BucketVolume,
+			Warm:         t.Warm,
+		}
+		if !t.UpdatedAt.IsZero() {
+			entry.UpdatedAt = &t.UpdatedAt
+		}
+		resp.Tokens = append(resp.Tokens, entry)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
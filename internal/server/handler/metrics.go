@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LatencyStatsSource provides per-stage latency quantiles
+// (service.LatencyTracker).
+type LatencyStatsSource interface {
+	Stats(strategy string) []domain.LatencyStats
+}
+
+// ExecutorQueueSource reports the executor's signal queues
+// (executor.Executor).
+type ExecutorQueueSource interface {
+	QueueStats() domain.ExecutorQueueStats
+}
+
+// TradeIngestSource reports trade ingestion counts (service.TradeService).
+type TradeIngestSource interface {
+	IngestStats() domain.TradeIngestStats
+}
+
+// MetricsHandler serves GET /metrics in the Prometheus text exposition
+// format.
+type MetricsHandler struct {
+	latency LatencyStatsSource
+	queues  ExecutorQueueSource
+	trades  TradeIngestSource
+	logger  *slog.Logger
+}
+
+// NewMetricsHandler creates a MetricsHandler. Until a source is set the
+// endpoint responds 501.
+func NewMetricsHandler(logger *slog.Logger) *MetricsHandler {
+	return &MetricsHandler{logger: logger}
+}
+
+// WithLatency exports the order latency stages as a summary.
+func (h *MetricsHandler) WithLatency(source LatencyStatsSource) *MetricsHandler {
+	h.latency = source
+	return h
+}
+
+// WithExecutorQueues exports the executor's backlog and per-worker queue
+// depths as gauges.
+func (h *MetricsHandler) WithExecutorQueues(source ExecutorQueueSource) *MetricsHandler {
+	h.queues = source
+	return h
+}
+
+// WithTradeIngest exports the trades stored and skipped as duplicates by the
+// pipeline as counters.
+func (h *MetricsHandler) WithTradeIngest(source TradeIngestSource) *MetricsHandler {
+	h.trades = source
+	return h
+}
+
+// Metrics writes polybot_order_latency_seconds, a summary per stage with
+// the 0.5, 0.95 and 0.99 quantiles over the recent samples and the count
+// and sum since startup, and the executor queue metrics
+// polybot_executor_backlog, polybot_executor_queue_depth,
+// polybot_executor_queue_capacity and polybot_executor_busy per worker,
+// polybot_executor_processed_total and polybot_executor_dispatch_blocked_total,
+// and the trade ingestion counters polybot_trades_ingested_total and
+// polybot_trades_duplicates_total.
+// GET /metrics
+func (h *MetricsHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	if h.latency == nil && h.queues == nil && h.trades == nil {
+		writeError(w, http.StatusNotImplemented, "no metrics enabled")
+		return
+	}
+	var buf bytes.Buffer
+	if h.latency != nil {
+		buf.WriteString("# HELP polybot_order_latency_seconds Latency of each stage from signal emission to fill.\n")
+		buf.WriteString("# TYPE polybot_order_latency_seconds summary\n")
+		for _, s := range h.latency.Stats("") {
+			for _, q := range []struct {
+				label string
+				v     float64
+			}{{"0.5", s.P50.Seconds()}, {"0.95", s.P95.Seconds()}, {"0.99", s.P99.Seconds()}} {
+				fmt.Fprintf(&buf, "polybot_order_latency_seconds{stage=%q,quantile=%q} %g\n", s.Stage, q.label, q.v)
+			}
+			fmt.Fprintf(&buf, "polybot_order_latency_seconds_sum{stage=%q} %g\n", s.Stage, s.Sum.Seconds())
+			fmt.Fprintf(&buf, "polybot_order_latency_seconds_count{stage=%q} %d\n", s.Stage, s.Count)
+		}
+	}
+	if h.queues != nil {
+		writeExecutorQueues(&buf, h.queues.QueueStats())
+	}
+	if h.trades != nil {
+		st := h.trades.IngestStats()
+		buf.WriteString("# HELP polybot_trades_ingested_total Trades stored by the pipeline.\n")
+		buf.WriteString("# TYPE polybot_trades_ingested_total counter\n")
+		fmt.Fprintf(&buf, "polybot_trades_ingested_total %d\n", st.Inserted)
+		buf.WriteString("# HELP polybot_trades_duplicates_total Trades skipped as already stored.\n")
+		buf.WriteString("# TYPE polybot_trades_duplicates_total counter\n")
+		fmt.Fprintf(&buf, "polybot_trades_duplicates_total %d\n", st.Duplicates)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logHandler(h.logger, "metrics").WarnContext(r.Context(), "write metrics failed",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func writeExecutorQueues(buf *bytes.Buffer, st domain.ExecutorQueueStats) {
+	buf.WriteString("# HELP polybot_executor_backlog Signals waiting for dispatch to an executor worker.\n")
+	buf.WriteString("# TYPE polybot_executor_backlog gauge\n")
+	fmt.Fprintf(buf, "polybot_executor_backlog %d\n", st.Backlog)
+	buf.WriteString("# HELP polybot_executor_dispatch_blocked_total Dispatches that waited on a full worker queue.\n")
+	buf.WriteString("# TYPE polybot_executor_dispatch_blocked_total counter\n")
+	fmt.Fprintf(buf, "polybot_executor_dispatch_blocked_total %d\n", st.DispatchBlocked)
+
+	perWorker := []struct {
+		name, help, typ string
+		value           func(domain.ExecutorWorkerStats) int64
+	}{
+		{"polybot_executor_queue_depth", "Signals queued on an executor worker.", "gauge",
+			func(ws domain.ExecutorWorkerStats) int64 { return int64(ws.Queued) }},
+		{"polybot_executor_queue_capacity", "Queue capacity of an executor worker.", "gauge",
+			func(ws domain.ExecutorWorkerStats) int64 { return int64(ws.Capacity) }},
+		{"polybot_executor_busy", "Whether an executor worker is processing a signal.", "gauge",
+			func(ws domain.ExecutorWorkerStats) int64 {
+				if ws.Busy {
+					return 1
+				}
+				return 0
+			}},
+		{"polybot_executor_processed_total", "Signals processed by an executor worker.", "counter",
+			func(ws domain.ExecutorWorkerStats) int64 { return ws.Processed }},
+	}
+	for _, g := range perWorker {
+		fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", g.name, g.help, g.name, g.typ)
+		for i, ws := range st.Workers {
+			fmt.Fprintf(buf, "%s{worker=\"%d\"} %d\n", g.name, i, g.value(ws))
+		}
+	}
+}
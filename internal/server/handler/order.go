@@ -6,6 +6,8 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
@@ -14,6 +16,7 @@ import (
 // service layer.
 type OrderService interface {
 	PlaceOrder(ctx context.Context, sig domain.TradeSignal) (domain.OrderResult, error)
+	PreviewOrder(ctx context.Context, sig domain.TradeSignal) (domain.OrderPreview, error)
 	CancelOrder(ctx context.Context, orderID string) error
 	ListOpen(ctx context.Context, wallet string) ([]domain.Order, error)
 	ListByMarket(ctx context.Context, marketID string, opts domain.ListOpts) ([]domain.Order, error)
@@ -33,12 +36,20 @@ func NewOrderHandler(orders OrderService, logger *slog.Logger) *OrderHandler {
 	}
 }
 
+// orderRow is an order as listed by the API, with its filled percentage.
+type orderRow struct {
+	domain.Order
+	Remaining   float64 `json:"remaining"`
+	FillPercent float64 `json:"fill_pct"`
+}
+
 // listOrdersResponse wraps the list orders response.
 type listOrdersResponse struct {
-	Orders []domain.Order `json:"orders"`
+	Orders []orderRow `json:"orders"`
 }
 
-// ListOrders returns open orders for a wallet, or orders for a specific market.
+// ListOrders returns live orders for a wallet, or orders for a specific
+// market, each with its remaining size and fill percentage.
 // GET /api/orders?wallet=0x...&market_id=...&limit=50&offset=0
 func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
@@ -68,15 +79,45 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if orders == nil {
-		orders = []domain.Order{}
+	rows := make([]orderRow, 0, len(orders))
+	for _, o := range orders {
+		rows = append(rows, orderRow{Order: o, Remaining: o.Remaining(), FillPercent: o.FillPercent()})
 	}
 
-	writeJSON(w, http.StatusOK, listOrdersResponse{Orders: orders})
+	writeJSON(w, http.StatusOK, listOrdersResponse{Orders: rows})
+}
+
+// orderPreviewResponse is the body of a dry-run order placement.
+type orderPreviewResponse struct {
+	DryRun        bool     `json:"dry_run"`
+	SignalID      string   `json:"signal_id,omitempty"`
+	MarketID      string   `json:"market_id"`
+	TokenID       string   `json:"token_id"`
+	Side          string   `json:"side"`
+	Type          string   `json:"type"`
+	ExpiresAt     string   `json:"expires_at,omitempty"`
+	Price         float64  `json:"price"`
+	Size          float64  `json:"size"`
+	RequestedSize float64  `json:"requested_size"`
+	Notional      float64  `json:"notional"`
+	Accepted      bool     `json:"accepted"`
+	Rejections    []string `json:"rejections"`
+	BestBid       float64  `json:"best_bid"`
+	BestAsk       float64  `json:"best_ask"`
+	BookAt        string   `json:"book_at,omitempty"`
+	MatchedSize   float64  `json:"matched_size"`
+	MatchPrice    float64  `json:"match_price"`
+	SlippageBps   float64  `json:"slippage_bps"`
+	RestingSize   float64  `json:"resting_size"`
+	TakerFeeBps   float64  `json:"taker_fee_bps"`
+	MakerFeeBps   float64  `json:"maker_fee_bps"`
+	FeeUSD        float64  `json:"fee_usd"`
 }
 
-// PlaceOrder creates a new order from a trade signal JSON body.
-// POST /api/orders
+// PlaceOrder creates a new order from a trade signal JSON body. With
+// dry_run=true it places nothing and returns what the order would do: the
+// risk, funds and book checks it would fail, its expected fill and fees.
+// POST /api/orders?dry_run=true
 func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 	var sig domain.TradeSignal
 	if err := json.NewDecoder(r.Body).Decode(&sig); err != nil {
@@ -89,6 +130,18 @@ func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+		if dryRun {
+			h.previewOrder(w, r, sig)
+			return
+		}
+	}
+
 	result, err := h.orders.PlaceOrder(r.Context(), sig)
 	if err != nil {
 		if errors.Is(err, domain.ErrRateLimited) {
@@ -99,6 +152,14 @@ func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusBadRequest, result.Message)
 			return
 		}
+		switch domain.ClassifyError(err) {
+		case domain.ErrorClassValidation, domain.ErrorClassVenueRejected:
+			writeError(w, http.StatusUnprocessableEntity, "order rejected by venue: "+result.Message)
+			return
+		case domain.ErrorClassTransient:
+			writeError(w, http.StatusBadGateway, "venue unavailable, retry later")
+			return
+		}
 		h.logger.ErrorContext(r.Context(), "handler: place order failed",
 			slog.String("error", err.Error()),
 		)
@@ -109,6 +170,55 @@ func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, result)
 }
 
+func (h *OrderHandler) previewOrder(w http.ResponseWriter, r *http.Request, sig domain.TradeSignal) {
+	p, err := h.orders.PreviewOrder(r.Context(), sig)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOrder) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "handler: preview order failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to preview order")
+		return
+	}
+
+	resp := orderPreviewResponse{
+		DryRun:        true,
+		SignalID:      p.SignalID,
+		MarketID:      p.MarketID,
+		TokenID:       p.TokenID,
+		Side:          string(p.Side),
+		Type:          string(p.Type),
+		Price:         p.Price,
+		Size:          p.Size,
+		RequestedSize: p.RequestedSize,
+		Notional:      p.Notional,
+		Accepted:      p.Accepted,
+		Rejections:    p.Rejections,
+		BestBid:       p.BestBid,
+		BestAsk:       p.BestAsk,
+		MatchedSize:   p.MatchedSize,
+		MatchPrice:    p.MatchPrice,
+		SlippageBps:   p.SlippageBps,
+		RestingSize:   p.RestingSize,
+		TakerFeeBps:   p.TakerFeeBps,
+		MakerFeeBps:   p.MakerFeeBps,
+		FeeUSD:        p.FeeUSD,
+	}
+	if resp.Rejections == nil {
+		resp.Rejections = []string{}
+	}
+	if p.ExpiresAt != nil {
+		resp.ExpiresAt = p.ExpiresAt.Format(time.RFC3339)
+	}
+	if !p.BookAt.IsZero() {
+		resp.BookAt = p.BookAt.Format(time.RFC3339Nano)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
 // CancelOrder cancels an existing order by its ID.
 // DELETE /api/orders/{id}
 func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
@@ -123,6 +233,10 @@ func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusNotFound, "order not found")
 			return
 		}
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			writeError(w, http.StatusConflict, "order is no longer live")
+			return
+		}
 		h.logger.ErrorContext(r.Context(), "handler: cancel order failed",
 			slog.String("order_id", id),
 			slog.String("error", err.Error()),
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	defaultPerformanceDaily = 30 * 24 * time.Hour
+	maxPerformanceDays      = 366
+)
+
+// PerformanceService reports per-strategy performance attribution
+// (service.PerformanceService).
+type PerformanceService interface {
+	Windows() []int
+	Strategies(ctx context.Context, days int, strategy string) (domain.PerformanceWindow, error)
+	Daily(ctx context.Context, strategy string, from, to time.Time) ([]domain.StrategyDay, error)
+}
+
+// PerformanceHandler serves /api/performance endpoints.
+type PerformanceHandler struct {
+	perf   PerformanceService
+	logger *slog.Logger
+}
+
+// NewPerformanceHandler creates a PerformanceHandler.
+func NewPerformanceHandler(perf PerformanceService, logger *slog.Logger) *PerformanceHandler {
+	return &PerformanceHandler{perf: perf, logger: logger}
+}
+
+type strategyPerformanceResponse struct {
+	Strategy        string  `json:"strategy"`
+	Closed          int     `json:"closed"`
+	Wins            int     `json:"wins"`
+	WinRate         float64 `json:"win_rate"`
+	RealizedPnL     float64 `json:"realized_pnl"`
+	Orders          int     `json:"orders"`
+	FilledOrders    int     `json:"filled_orders"`
+	FilledNotional  float64 `json:"filled_notional"`
+	FeesUSD         float64 `json:"fees_usd"`
+	ArbExecutions   int     `json:"arb_executions"`
+	ExpectedEdgeBps float64 `json:"expected_edge_bps"`
+	CapturedEdgeBps float64 `json:"captured_edge_bps"`
+	Sharpe          float64 `json:"sharpe"`
+	MaxDrawdown     float64 `json:"max_drawdown"`
+}
+
+type performanceWindowResponse struct {
+	Days       int                           `json:"days"`
+	Since      time.Time                     `json:"since"`
+	Until      time.Time                     `json:"until"`
+	Strategies []strategyPerformanceResponse `json:"strategies"`
+}
+
+type strategyDayResponse struct {
+	Day             string    `json:"day"`
+	Strategy        string    `json:"strategy"`
+	Closed          int       `json:"closed"`
+	Wins            int       `json:"wins"`
+	RealizedPnL     float64   `json:"realized_pnl"`
+	ClosedNotional  float64   `json:"closed_notional"`
+	Orders          int       `json:"orders"`
+	FilledOrders    int       `json:"filled_orders"`
+	FilledNotional  float64   `json:"filled_notional"`
+	ExpectedEdgeUSD float64   `json:"expected_edge_usd"`
+	EdgeNotional    float64   `json:"edge_notional"`
+	ArbExecutions   int       `json:"arb_executions"`
+	ArbSlippageUSD  float64   `json:"arb_slippage_usd"`
+	FeesUSD         float64   `json:"fees_usd"`
+	ComputedAt      time.Time `json:"computed_at"`
+}
+
+// Strategies returns each strategy's win rate, realized PnL, fees, expected
+// vs. captured edge, Sharpe ratio and drawdown over windows of whole UTC days
+// ending today: the configured windows, or only days when given.
+// GET /api/performance/strategies?days=7&strategy=
+func (h *PerformanceHandler) Strategies(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	windows := h.perf.Windows()
+	if v := q.Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxPerformanceDays {
+			writeError(w, http.StatusBadRequest, "days must be an integer from 1 to 366")
+			return
+		}
+		windows = []int{n}
+	}
+
+	resp := make([]performanceWindowResponse, 0, len(windows))
+	for _, days := range windows {
+		pw, err := h.perf.Strategies(r.Context(), days, q.Get("strategy"))
+		if err != nil {
+			h.logger.ErrorContext(r.Context(), "handler: strategy performance failed",
+				slog.Int("days", days),
+				slog.String("error", err.Error()),
+			)
+			writeError(w, http.StatusInternalServerError, "failed to compute strategy performance")
+			return
+		}
+		win := performanceWindowResponse{
+			Days:       pw.Days,
+			Since:      pw.Since,
+			Until:      pw.Until,
+			Strategies: make([]strategyPerformanceResponse, 0, len(pw.Strategies)),
+		}
+		for _, p := range pw.Strategies {
+			win.Strategies = append(win.Strategies, strategyPerformanceResponse{
+				Strategy:        p.Strategy,
+				Closed:          p.Closed,
+				Wins:            p.Wins,
+				WinRate:         p.WinRate(),
+				RealizedPnL:     p.RealizedPnL,
+				Orders:          p.Orders,
+				FilledOrders:    p.FilledOrders,
+				FilledNotional:  p.FilledNotional,
+				FeesUSD:         p.FeesUSD,
+				ArbExecutions:   p.ArbExecutions,
+				ExpectedEdgeBps: p.ExpectedEdgeBps,
+				CapturedEdgeBps: p.CapturedEdgeBps,
+				Sharpe:          p.Sharpe,
+				MaxDrawdown:     p.MaxDrawdown,
+			})
+		}
+		resp = append(resp, win)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"windows": resp})
+}
+
+// Daily returns the persisted per-strategy rows of the UTC days in [from, to).
+// from and to take RFC3339 or YYYY-MM-DD; to defaults to now and from to 30
+// days before to.
+// GET /api/performance/daily?strategy=&from=2025-01-01&to=2025-02-01
+func (h *PerformanceHandler) Daily(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	to := time.Now().UTC()
+	if v := q.Get("to"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid to (expected RFC3339 or YYYY-MM-DD)")
+			return
+		}
+		to = t
+	}
+	from := to.Add(-defaultPerformanceDaily)
+	if v := q.Get("from"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid from (expected RFC3339 or YYYY-MM-DD)")
+			return
+		}
+		from = t
+	}
+	if !from.Before(to) {
+		writeError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	list, err := h.perf.Daily(r.Context(), q.Get("strategy"), from, to)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: daily performance failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to list daily performance")
+		return
+	}
+	days := make([]strategyDayResponse, 0, len(list))
+	for _, d := range list {
+		days = append(days, strategyDayResponse{
+			Day:             d.Day.Format("2006-01-02"),
+			Strategy:        d.Strategy,
+			Closed:          d.Closed,
+			Wins:            d.Wins,
+			RealizedPnL:     d.RealizedPnL,
+			ClosedNotional:  d.ClosedNotional,
+			Orders:          d.Orders,
+			FilledOrders:    d.FilledOrders,
+			FilledNotional:  d.FilledNotional,
+			ExpectedEdgeUSD: d.ExpectedEdgeUSD,
+			EdgeNotional:    d.EdgeNotional,
+			ArbExecutions:   d.ArbExecutions,
+			ArbSlippageUSD:  d.ArbSlippageUSD,
+			FeesUSD:         d.FeesUSD,
+			ComputedAt:      d.ComputedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"from": from,
+		"to":   to,
+		"days": days,
+	})
+}
@@ -1,15 +1,36 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
-// PipelineHandler serves pipeline trigger endpoints.
+// PipelineRuns queues pipeline runs and reports their history.
+type PipelineRuns interface {
+	Trigger(ctx context.Context, trigger string) (domain.PipelineRun, bool, error)
+	Get(ctx context.Context, id string) (domain.PipelineRun, error)
+	List(ctx context.Context, limit int) ([]domain.PipelineRun, error)
+}
+
+// PipelineDeadLetters lists and replays fill batches the pipeline failed to
+// process.
+type PipelineDeadLetters interface {
+	List(ctx context.Context, limit int) ([]domain.DeadLetterBatch, error)
+	ListParked(ctx context.Context, limit int) ([]domain.DeadLetterBatch, error)
+	Retry(ctx context.Context, limit int) (domain.DeadLetterRetry, error)
+}
+
+// PipelineHandler serves pipeline trigger and run history endpoints.
 type PipelineHandler struct {
-	logger    *slog.Logger
-	triggerCh chan<- struct{} // when non-nil, sending triggers one pipeline run
+	logger      *slog.Logger
+	runs        PipelineRuns        // optional; when nil, all endpoints return 501
+	deadLetters PipelineDeadLetters // optional; when nil, the DLQ endpoints return 501
 }
 
 // NewPipelineHandler creates a PipelineHandler with the given logger.
@@ -17,28 +38,243 @@ func NewPipelineHandler(logger *slog.Logger) *PipelineHandler {
 	return &PipelineHandler{logger: logger}
 }
 
-// WithTriggerChannel sets the channel to send on when a trigger is requested.
-// The pipeline loop must receive from this channel to run one cycle.
-func (h *PipelineHandler) WithTriggerChannel(ch chan<- struct{}) *PipelineHandler {
-	h.triggerCh = ch
+// WithRuns sets the run queue that triggers are recorded in.
+func (h *PipelineHandler) WithRuns(runs PipelineRuns) *PipelineHandler {
+	h.runs = runs
+	return h
+}
+
+// WithDeadLetters sets the dead-letter queue of failed fill batches.
+func (h *PipelineHandler) WithDeadLetters(dl PipelineDeadLetters) *PipelineHandler {
+	h.deadLetters = dl
 	return h
 }
 
-// TriggerPipeline enqueues one pipeline run. If a trigger channel is configured,
-// a non-blocking send is performed so the pipeline loop runs one cycle.
+// pipelineRunResponse is the JSON form of a pipeline run.
+type pipelineRunResponse struct {
+	ID          string           `json:"id"`
+	Trigger     string           `json:"trigger"`
+	Status      string           `json:"status"`
+	Stats       map[string]int64 `json:"stats"`
+	Error       string           `json:"error,omitempty"`
+	RequestedAt time.Time        `json:"requested_at"`
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	FinishedAt  *time.Time       `json:"finished_at,omitempty"`
+}
+
+func toPipelineRunResponse(run domain.PipelineRun) pipelineRunResponse {
+	stats := run.Stats
+	if stats == nil {
+		stats = map[string]int64{}
+	}
+	return pipelineRunResponse{
+		ID:          run.ID,
+		Trigger:     run.Trigger,
+		Status:      string(run.Status),
+		Stats:       stats,
+		Error:       run.Error,
+		RequestedAt: run.RequestedAt,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+	}
+}
+
+// triggerPipelineResponse wraps the queued run. Deduplicated is true when a
+// run was already queued and the trigger was folded into it.
+type triggerPipelineResponse struct {
+	Run          pipelineRunResponse `json:"run"`
+	Deduplicated bool                `json:"deduplicated"`
+}
+
+// TriggerPipeline queues one pipeline run and returns it for status polling.
 // POST /api/pipeline/trigger
 func (h *PipelineHandler) TriggerPipeline(w http.ResponseWriter, r *http.Request) {
-	h.logger.InfoContext(r.Context(), "handler: pipeline trigger requested")
-	if h.triggerCh != nil {
-		select {
-		case h.triggerCh <- struct{}{}:
-		default:
-			// already triggered and not yet consumed
+	if h.runs == nil {
+		writeError(w, http.StatusNotImplemented, "pipeline run queue not configured")
+		return
+	}
+	run, dedup, err := h.runs.Trigger(r.Context(), "api")
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: pipeline trigger failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to queue pipeline run")
+		return
+	}
+	h.logger.InfoContext(r.Context(), "handler: pipeline trigger requested",
+		slog.String("run_id", run.ID),
+		slog.Bool("deduplicated", dedup),
+	)
+	writeJSON(w, http.StatusAccepted, triggerPipelineResponse{
+		Run:          toPipelineRunResponse(run),
+		Deduplicated: dedup,
+	})
+}
+
+// listPipelineRunsResponse wraps the run history response.
+type listPipelineRunsResponse struct {
+	Runs []pipelineRunResponse `json:"runs"`
+}
+
+// ListRuns returns recent pipeline runs, newest first.
+// GET /api/pipeline/runs?limit=20
+func (h *PipelineHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	if h.runs == nil {
+		writeError(w, http.StatusNotImplemented, "pipeline run queue not configured")
+		return
+	}
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	runs, err := h.runs.List(r.Context(), limit)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list pipeline runs failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to list pipeline runs")
+		return
+	}
+	resp := listPipelineRunsResponse{Runs: make([]pipelineRunResponse, 0, len(runs))}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, toPipelineRunResponse(run))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetRun returns a single pipeline run.
+// GET /api/pipeline/runs/{id}
+func (h *PipelineHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	if h.runs == nil {
+		writeError(w, http.StatusNotImplemented, "pipeline run queue not configured")
+		return
+	}
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing run id")
+		return
+	}
+	run, err := h.runs.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "pipeline run not found")
+			return
 		}
+		h.logger.ErrorContext(r.Context(), "handler: get pipeline run failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to get pipeline run")
+		return
+	}
+	writeJSON(w, http.StatusOK, toPipelineRunResponse(run))
+}
+
+// deadLetterBatchResponse is the JSON form of a dead-lettered batch. Fills
+// are summarized by their transaction hashes.
+type deadLetterBatchResponse struct {
+	ID       string    `json:"id"`
+	Stage    string    `json:"stage"`
+	Fills    int       `json:"fills"`
+	TxHashes []string  `json:"tx_hashes"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// listDeadLettersResponse wraps the dead-lettered batches.
+type listDeadLettersResponse struct {
+	Batches []deadLetterBatchResponse `json:"batches"`
+}
+
+// ListDeadLetters returns dead-lettered fill batches, oldest first; with
+// parked=true, the batches parked after repeated failures or because they
+// could not be decoded.
+// GET /api/pipeline/dlq?limit=50&parked=true
+func (h *PipelineHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if h.deadLetters == nil {
+		writeError(w, http.StatusNotImplemented, "pipeline dead-letter queue not configured")
+		return
+	}
+	limit := deadLetterLimit(r, 50)
+	list := h.deadLetters.List
+	if parked, _ := strconv.ParseBool(r.URL.Query().Get("parked")); parked {
+		list = h.deadLetters.ListParked
 	}
-	writeJSON(w, http.StatusAccepted, map[string]any{
-		"status":       "accepted",
-		"message":      "pipeline trigger enqueued",
-		"requested_at": time.Now().UTC().Format(time.RFC3339),
+	batches, err := list(r.Context(), limit)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list dead letters failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to list dead-lettered batches")
+		return
+	}
+	resp := listDeadLettersResponse{Batches: make([]deadLetterBatchResponse, 0, len(batches))}
+	for _, b := range batches {
+		hashes := make([]string, 0, len(b.Fills))
+		for _, f := range b.Fills {
+			hashes = append(hashes, f.TransactionHash)
+		}
+		resp.Batches = append(resp.Batches, deadLetterBatchResponse{
+			ID:       b.ID,
+			Stage:    b.Stage,
+			Fills:    len(b.Fills),
+			TxHashes: hashes,
+			Error:    b.Error,
+			Attempts: b.Attempts,
+			FailedAt: b.FailedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// retryDeadLettersResponse is the JSON form of a dead-letter replay.
+type retryDeadLettersResponse struct {
+	Batches        int `json:"batches"`
+	Replayed       int `json:"replayed"`
+	Failed         int `json:"failed"`
+	Parked         int `json:"parked"`
+	Fills          int `json:"fills"`
+	Duplicates     int `json:"duplicates"`
+	TradesIngested int `json:"trades_ingested"`
+}
+
+// RetryDeadLetters replays up to limit dead-lettered batches, oldest first.
+// Replays are idempotent: fills are deduplicated by transaction hash and
+// trades already stored are skipped. Fills that fail again are requeued, or
+// parked once their batch reaches pipeline.dlq_max_attempts.
+// POST /api/pipeline/dlq/retry?limit=20
+func (h *PipelineHandler) RetryDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if h.deadLetters == nil {
+		writeError(w, http.StatusNotImplemented, "pipeline dead-letter queue not configured")
+		return
+	}
+	res, err := h.deadLetters.Retry(r.Context(), deadLetterLimit(r, 20))
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: retry dead letters failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to retry dead-lettered batches")
+		return
+	}
+	h.logger.InfoContext(r.Context(), "handler: dead letters retried",
+		slog.Int("batches", res.Batches),
+		slog.Int("replayed", res.Replayed),
+		slog.Int("failed", res.Failed),
+		slog.Int("parked", res.Parked),
+	)
+	writeJSON(w, http.StatusOK, retryDeadLettersResponse{
+		Batches:        res.Batches,
+		Replayed:       res.Replayed,
+		Failed:         res.Failed,
+		Parked:         res.Parked,
+		Fills:          res.Fills,
+		Duplicates:     res.Duplicates,
+		TradesIngested: res.TradesIngested,
 	})
 }
+
+// deadLetterLimit reads the limit query parameter, capped at 200.
+func deadLetterLimit(r *http.Request, def int) int {
+	limit := def
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	return min(limit, 200)
+}
@@ -2,15 +2,19 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
 // PositionService defines the methods that the position handler requires.
 type PositionService interface {
-	GetOpen(ctx context.Context, wallet string) ([]domain.Position, error)
+	List(ctx context.Context, filter domain.PositionFilter, opts domain.ListOpts) ([]domain.Position, error)
+	GetByID(ctx context.Context, id string) (domain.Position, error)
+	PnLSummary(ctx context.Context, filter domain.PositionFilter, since, until time.Time) (domain.PnLSummary, error)
 }
 
 // PositionHandler serves position-related HTTP endpoints.
@@ -32,19 +36,41 @@ type listPositionsResponse struct {
 	Positions []domain.Position `json:"positions"`
 }
 
-// ListPositions returns all open positions for a given wallet.
-// GET /api/positions?wallet=0x...
+// defaultPnLWindow is the period GET /api/pnl/summary covers without ?since.
+const defaultPnLWindow = 30 * 24 * time.Hour
+
+// ListPositions returns positions, newest first, open ones marked to the
+// current price. status is open (default), closed or all; since/until bound
+// the open time.
+// GET /api/positions?wallet=0x...&strategy=bond&status=open|closed|all&since=RFC3339&until=RFC3339&limit=50&offset=0
 func (h *PositionHandler) ListPositions(w http.ResponseWriter, r *http.Request) {
-	wallet := r.URL.Query().Get("wallet")
-	if wallet == "" {
-		writeError(w, http.StatusBadRequest, "wallet query parameter required")
+	filter, ok := positionFilter(w, r)
+	if !ok {
 		return
 	}
+	opts := parseListOpts(r)
+	q := r.URL.Query()
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid since (expected RFC3339)")
+			return
+		}
+		opts.Since = &t
+	}
+	if v := q.Get("until"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid until (expected RFC3339)")
+			return
+		}
+		opts.Until = &t
+	}
 
-	positions, err := h.positions.GetOpen(r.Context(), wallet)
+	positions, err := h.positions.List(r.Context(), filter, opts)
 	if err != nil {
 		h.logger.ErrorContext(r.Context(), "handler: list positions failed",
-			slog.String("wallet", wallet),
+			slog.String("wallet", filter.Wallet),
 			slog.String("error", err.Error()),
 		)
 		writeError(w, http.StatusInternalServerError, "failed to list positions")
@@ -57,3 +83,110 @@ func (h *PositionHandler) ListPositions(w http.ResponseWriter, r *http.Request)
 
 	writeJSON(w, http.StatusOK, listPositionsResponse{Positions: positions})
 }
+
+// GetPosition returns a single position by ID.
+// GET /api/positions/{id}
+func (h *PositionHandler) GetPosition(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing position id")
+		return
+	}
+
+	pos, err := h.positions.GetByID(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "position not found")
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "handler: get position failed",
+			slog.String("position_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to get position")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pos)
+}
+
+// PnLSummary returns realized PnL of positions closed in [since, until) by
+// strategy and by UTC day, plus the unrealized PnL of positions open now.
+// since and until take RFC3339 or YYYY-MM-DD; since defaults to 30 days ago
+// and until to now.
+// GET /api/pnl/summary?wallet=0x...&strategy=bond&since=2025-01-01&until=2025-02-01
+func (h *PositionHandler) PnLSummary(w http.ResponseWriter, r *http.Request) {
+	filter, ok := positionFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.Status = ""
+	q := r.URL.Query()
+	until := time.Now().UTC()
+	if v := q.Get("until"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid until (expected RFC3339 or YYYY-MM-DD)")
+			return
+		}
+		until = t
+	}
+	since := until.Add(-defaultPnLWindow)
+	if v := q.Get("since"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid since (expected RFC3339 or YYYY-MM-DD)")
+			return
+		}
+		since = t
+	}
+	if !since.Before(until) {
+		writeError(w, http.StatusBadRequest, "since must be before until")
+		return
+	}
+
+	sum, err := h.positions.PnLSummary(r.Context(), filter, since, until)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: pnl summary failed",
+			slog.String("wallet", filter.Wallet),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to compute pnl summary")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, sum)
+}
+
+// positionFilter reads wallet, strategy and status from the query string,
+// writing a 400 and returning false for an unknown status.
+func positionFilter(w http.ResponseWriter, r *http.Request) (domain.PositionFilter, bool) {
+	q := r.URL.Query()
+	filter := domain.PositionFilter{
+		Wallet:   q.Get("wallet"),
+		Strategy: q.Get("strategy"),
+		Status:   domain.PositionStatusOpen,
+	}
+	switch v := q.Get("status"); v {
+	case "", "open":
+	case "closed":
+		filter.Status = domain.PositionStatusClosed
+	case "all":
+		filter.Status = ""
+	default:
+		writeError(w, http.StatusBadRequest, "status must be open, closed or all")
+		return domain.PositionFilter{}, false
+	}
+	return filter, true
+}
+
+// parseDayOrTime parses an RFC3339 timestamp or a YYYY-MM-DD date (midnight UTC).
+func parseDayOrTime(v string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", v, time.UTC); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PortfolioRisk exposes portfolio-level risk state and the kill switch
+// (service.PortfolioRiskManager).
+type PortfolioRisk interface {
+	State() domain.PortfolioRiskState
+	Reset(ctx context.Context) domain.PortfolioRiskState
+}
+
+// RiskHandler serves the portfolio risk endpoints.
+type RiskHandler struct {
+	risk   PortfolioRisk
+	logger *slog.Logger
+}
+
+// NewRiskHandler creates a RiskHandler.
+func NewRiskHandler(risk PortfolioRisk, logger *slog.Logger) *RiskHandler {
+	return &RiskHandler{risk: risk, logger: logger}
+}
+
+// riskStateResponse is the JSON shape of domain.PortfolioRiskState.
+type riskStateResponse struct {
+	OpenPositions     int        `json:"open_positions"`
+	ExposureUSD       float64    `json:"exposure_usd"`
+	UnrealizedPnL     float64    `json:"unrealized_pnl"`
+	RealizedPnLToday  float64    `json:"realized_pnl_today"`
+	DailyPnL          float64    `json:"daily_pnl"`
+	DailyLossLimitUSD float64    `json:"daily_loss_limit_usd"`
+	KillSwitchEnabled bool       `json:"kill_switch_enabled"`
+	Tripped           bool       `json:"tripped"`
+	TripReason        string     `json:"trip_reason,omitempty"`
+	TrippedAt         *time.Time `json:"tripped_at,omitempty"`
+	ResetAt           *time.Time `json:"reset_at,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func toRiskStateResponse(s domain.PortfolioRiskState) riskStateResponse {
+	return riskStateResponse{
+		OpenPositions:     s.OpenPositions,
+		ExposureUSD:       s.Exposure,
+		UnrealizedPnL:     s.UnrealizedPnL,
+		RealizedPnLToday:  s.RealizedPnL,
+		DailyPnL:          s.DailyPnL,
+		DailyLossLimitUSD: s.DailyLossLimit,
+		KillSwitchEnabled: s.DailyLossLimit > 0,
+		Tripped:           s.Tripped,
+		TripReason:        s.TripReason,
+		TrippedAt:         s.TrippedAt,
+		ResetAt:           s.ResetAt,
+		UpdatedAt:         s.UpdatedAt,
+	}
+}
+
+// GetRisk returns aggregate exposure, PnL and kill switch state.
+// GET /api/risk
+func (h *RiskHandler) GetRisk(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, toRiskStateResponse(h.risk.State()))
+}
+
+// Reset re-arms a tripped kill switch so the executor resumes trading.
+// POST /api/risk/reset
+func (h *RiskHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	prev := h.risk.State()
+	state := h.risk.Reset(r.Context())
+	h.logger.WarnContext(r.Context(), "kill switch reset via API",
+		slog.Bool("was_tripped", prev.Tripped),
+		slog.String("reason", prev.TripReason),
+		slog.String("remote_addr", r.RemoteAddr),
+	)
+	writeJSON(w, http.StatusOK, toRiskStateResponse(state))
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// SignalLister lists recorded signals with their outcomes
+// (postgres.SignalStore).
+type SignalLister interface {
+	List(ctx context.Context, f domain.SignalFilter) ([]domain.SignalRecord, error)
+}
+
+// SignalsHandler serves GET /api/signals.
+type SignalsHandler struct {
+	store  SignalLister
+	logger *slog.Logger
+}
+
+// NewSignalsHandler creates a SignalsHandler. Until WithStore is called the
+// endpoint responds 501.
+func NewSignalsHandler(logger *slog.Logger) *SignalsHandler {
+	return &SignalsHandler{logger: logger}
+}
+
+// WithStore sets the signal store backing the endpoint.
+func (h *SignalsHandler) WithStore(store SignalLister) *SignalsHandler {
+	h.store = store
+	return h
+}
+
+type signalRecordJSON struct {
+	SignalID     string            `json:"signal_id"`
+	Strategy     string            `json:"strategy"`
+	MarketID     string            `json:"market_id"`
+	TokenID      string            `json:"token_id"`
+	Side         string            `json:"side"`
+	Price        float64           `json:"price"`
+	Size         float64           `json:"size"`
+	Urgency      int               `json:"urgency"`
+	OrderType    string            `json:"order_type,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	ExpiresAt    *time.Time        `json:"expires_at,omitempty"`
+	Status       string            `json:"status"`
+	StatusReason string            `json:"status_reason,omitempty"`
+	OrderIDs     []string          `json:"order_ids"`
+	ResolvedAt   *time.Time        `json:"resolved_at,omitempty"`
+	FilledSize   float64           `json:"filled_size"`
+	LastFillAt   *time.Time        `json:"last_fill_at,omitempty"`
+}
+
+// List returns recorded signals, newest first, with what the executor made
+// of them and the size filled by their orders. strategy, market, token and
+// status (pending, placed, rejected, failed, expired, skipped) filter;
+// from/to (RFC 3339 or YYYY-MM-DD) bound creation time to [from, to); limit
+// defaults to 100, max 1000. Pass the created_at of the last signal as to
+// to read the next page.
+// GET /api/signals
+func (h *SignalsHandler) List(w http.ResponseWriter, r *http.Request) {
+	if h.store == nil {
+		writeError(w, http.StatusNotImplemented, "signals not available: postgres not configured")
+		return
+	}
+	q := r.URL.Query()
+	f := domain.SignalFilter{
+		Strategy: q.Get("strategy"),
+		MarketID: q.Get("market"),
+		TokenID:  q.Get("token"),
+		Status:   domain.SignalStatus(q.Get("status")),
+		Limit:    100,
+	}
+	if f.Status != "" && !f.Status.Valid() {
+		writeError(w, http.StatusBadRequest, "invalid status: want pending, placed, rejected, failed, expired or skipped")
+		return
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &f.From}, {"to", &f.To}} {
+		if v := q.Get(p.name); v != "" {
+			t, ok := parseDayOrTime(v)
+			if !ok {
+				writeError(w, http.StatusBadRequest, "invalid "+p.name+": want RFC 3339 or YYYY-MM-DD")
+				return
+			}
+			*p.dst = t
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		f.Limit = min(n, 1000)
+	}
+
+	records, err := h.store.List(r.Context(), f)
+	if err != nil {
+		logHandler(h.logger, "signals").ErrorContext(r.Context(), "list signals failed",
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to list signals")
+		return
+	}
+	out := make([]signalRecordJSON, 0, len(records))
+	for _, rec := range records {
+		sig := rec.Signal
+		row := signalRecordJSON{
+			SignalID:     sig.ID,
+			Strategy:     sig.Source,
+			MarketID:     sig.MarketID,
+			TokenID:      sig.TokenID,
+			Side:         string(sig.Side),
+			Price:        sig.Price(),
+			Size:         sig.Size(),
+			Urgency:      int(sig.Urgency),
+			OrderType:    string(sig.OrderType),
+			Reason:       sig.Reason,
+			Metadata:     sig.Metadata,
+			CreatedAt:    sig.CreatedAt,
+			ExpiresAt:    optionalTime(sig.ExpiresAt),
+			Status:       string(rec.Status),
+			StatusReason: rec.StatusReason,
+			OrderIDs:     rec.OrderIDs,
+			ResolvedAt:   optionalTime(rec.ResolvedAt),
+			FilledSize:   rec.FilledSize,
+			LastFillAt:   optionalTime(rec.LastFillAt),
+		}
+		if row.OrderIDs == nil {
+			row.OrderIDs = []string{}
+		}
+		out = append(out, row)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"signals": out})
+}
+
+// optionalTime returns nil for the zero time so it is omitted from JSON.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// StrategyBulkController switches the full set of running strategies at
+// runtime (strategy.Engine).
+type StrategyBulkController interface {
+	ListNames() []string
+	ActiveNames() []string
+	ApplyActive(names []string) error
+	Mode() string
+	// Unavailable maps known-but-unregistered strategies to their missing dependencies.
+	Unavailable() map[string][]string
+}
+
+// StrategyBulkHandler serves POST /api/strategy/bulk.
+type StrategyBulkHandler struct {
+	ctrl   StrategyBulkController
+	hub    HubStrategyUpdater // optional
+	audit  domain.AuditStore  // optional
+	logger *slog.Logger
+}
+
+// NewStrategyBulkHandler creates a StrategyBulkHandler. hub may be nil.
+func NewStrategyBulkHandler(ctrl StrategyBulkController, hub HubStrategyUpdater, logger *slog.Logger) *StrategyBulkHandler {
+	return &StrategyBulkHandler{ctrl: ctrl, hub: hub, logger: logger}
+}
+
+// WithAudit records applied changes as "strategy_active_changed" audit
+// entries.
+func (h *StrategyBulkHandler) WithAudit(audit domain.AuditStore) *StrategyBulkHandler {
+	h.audit = audit
+	return h
+}
+
+// StrategyBulkRequest is the JSON body for POST /api/strategy/bulk. Either
+// set Active to replace the running set, or list names to Enable and Disable
+// relative to the current set.
+type StrategyBulkRequest struct {
+	Active  []string `json:"active"`
+	Enable  []string `json:"enable"`
+	Disable []string `json:"disable"`
+}
+
+// strategyBulkProblem explains why a strategy cannot be enabled.
+type strategyBulkProblem struct {
+	Strategy string   `json:"strategy"`
+	Reason   string   `json:"reason"`
+	Missing  []string `json:"missing,omitempty"`
+}
+
+// Bulk enables and disables strategies in one step. Every strategy to be
+// enabled is checked first; if any is unknown or missing dependencies the
+// request fails with 422 and nothing changes. With zero strategies left the
+// engine idles, with one it runs in single-strategy mode, and with more it
+// runs them concurrently.
+// POST /api/strategy/bulk
+func (h *StrategyBulkHandler) Bulk(w http.ResponseWriter, r *http.Request) {
+	var req StrategyBulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if req.Active != nil && (len(req.Enable) > 0 || len(req.Disable) > 0) {
+		writeError(w, http.StatusBadRequest, "use either active or enable/disable, not both")
+		return
+	}
+
+	var next []string
+	if req.Active != nil {
+		next = cleanNames(req.Active)
+	} else {
+		disable := cleanNames(req.Disable)
+		for _, name := range h.ctrl.ActiveNames() {
+			if !slices.Contains(disable, name) {
+				next = append(next, name)
+			}
+		}
+		for _, name := range cleanNames(req.Enable) {
+			if !slices.Contains(next, name) && !slices.Contains(disable, name) {
+				next = append(next, name)
+			}
+		}
+	}
+
+	registered := h.ctrl.ListNames()
+	unavailable := h.ctrl.Unavailable()
+	var problems []strategyBulkProblem
+	for _, name := range next {
+		if slices.Contains(registered, name) {
+			continue
+		}
+		if missing, ok := unavailable[name]; ok {
+			problems = append(problems, strategyBulkProblem{Strategy: name, Reason: "missing dependencies", Missing: missing})
+		} else {
+			problems = append(problems, strategyBulkProblem{Strategy: name, Reason: "unknown strategy"})
+		}
+	}
+	if len(problems) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{
+			"error":    "some strategies cannot be enabled; no changes applied",
+			"problems": problems,
+		})
+		return
+	}
+
+	previous := h.ctrl.ActiveNames()
+	if err := h.ctrl.ApplyActive(next); err != nil {
+		h.logger.WarnContext(r.Context(), "bulk strategy update failed",
+			slog.Any("strategies", next),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.logger.InfoContext(r.Context(), "bulk strategy update applied",
+		slog.Any("previous", previous),
+		slog.Any("active", next),
+	)
+	if h.hub != nil {
+		h.hub.SetStrategyName(strings.Join(next, ","))
+	}
+	if next == nil {
+		next = []string{}
+	}
+	auditAction(r, h.audit, h.logger, "strategy_active_changed", map[string]any{
+		"previous": previous,
+		"active":   next,
+		"source":   "bulk",
+	})
+	writeJSON(w, http.StatusOK, map[string]any{
+		"active": next,
+		"mode":   h.ctrl.Mode(),
+	})
+}
+
+// cleanNames trims names and drops empties and duplicates, preserving order.
+func cleanNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n != "" && !slices.Contains(out, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
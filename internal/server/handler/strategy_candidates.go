@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"sort"
@@ -27,6 +28,14 @@ type StrategyCandidateMarketResolver interface {
 	GetMarket(ctx context.Context, id string) (domain.Market, error)
 }
 
+// StrategyCandidateRanker holds and ranks the signals of manual trading, and
+// routes one to the executor on request (service.CandidateService).
+type StrategyCandidateRanker interface {
+	Ranked(ctx context.Context, sources []string, limit int) []domain.Candidate
+	Execute(ctx context.Context, id string) (domain.Candidate, error)
+	CanExecute() bool
+}
+
 // StrategyCandidate is a UI-facing, ranked signal candidate.
 type StrategyCandidate struct {
 	SignalID       string               `json:"signal_id"`
@@ -42,6 +51,28 @@ type StrategyCandidate struct {
 	CreatedAt      time.Time            `json:"created_at"`
 	ExpiresAt      time.Time            `json:"expires_at"`
 	Score          float64              `json:"score"`
+	// Venue is the external venue a cross-platform signal was priced against.
+	Venue string `json:"venue,omitempty"`
+	// ManualOnly marks signals the executor will not place, e.g. arbs
+	// against read-only venues like PredictIt.
+	ManualOnly bool `json:"manual_only,omitempty"`
+	// Shadow marks signals of shadow-mode strategies, never executed.
+	Shadow bool `json:"shadow,omitempty"`
+	// Rank, Status and Ranking are set for the held candidates of manual
+	// trading.
+	Rank    int               `json:"rank,omitempty"`
+	Status  string            `json:"status,omitempty"`
+	Ranking *candidateRanking `json:"ranking,omitempty"`
+}
+
+// candidateRanking is what a manual-trading candidate's score is made of:
+// score = edge_bps x freshness x (0.5 + 0.5 x liquidity).
+type candidateRanking struct {
+	EdgeBps   float64 `json:"edge_bps"`
+	Freshness float64 `json:"freshness"`
+	Liquidity float64 `json:"liquidity"`
+	DepthUSD  float64 `json:"depth_usd"`
+	AgeMs     int64   `json:"age_ms"`
 }
 
 type strategyCandidatesResponse struct {
@@ -49,6 +80,8 @@ type strategyCandidatesResponse struct {
 	AutoExecute    bool                `json:"auto_execute"`
 	Candidates     []StrategyCandidate `json:"candidates"`
 	Best           *StrategyCandidate  `json:"best,omitempty"`
+	// Executable is set when candidates can be sent to the executor.
+	Executable bool `json:"executable,omitempty"`
 }
 
 // StrategyCandidatesHandler serves candidate signal discovery for manual bets.
@@ -58,6 +91,8 @@ type StrategyCandidatesHandler struct {
 	markets     StrategyCandidateMarketResolver
 	autoExecute bool
 	logger      *slog.Logger
+	ranker      StrategyCandidateRanker // optional
+	audit       domain.AuditStore       // optional
 }
 
 // NewStrategyCandidatesHandler creates a new candidate handler.
@@ -77,10 +112,26 @@ func NewStrategyCandidatesHandler(
 	}
 }
 
-// ListCandidates returns ranked strategy candidates.
+// WithRanker serves the candidates held by ranker, the signals of manual
+// trading, instead of the engine's recent signals, and enables executing
+// them.
+func (h *StrategyCandidatesHandler) WithRanker(ranker StrategyCandidateRanker) *StrategyCandidatesHandler {
+	h.ranker = ranker
+	return h
+}
+
+// WithAudit records manual executions as "candidate_executed" audit events.
+func (h *StrategyCandidatesHandler) WithAudit(audit domain.AuditStore) *StrategyCandidatesHandler {
+	h.audit = audit
+	return h
+}
+
+// ListCandidates returns ranked strategy candidates. In manual trading the
+// held candidates are listed best first, with their rank and ranking
+// metadata.
 // GET /api/strategy/candidates?limit=20&source=rebalancing_arb
 func (h *StrategyCandidatesHandler) ListCandidates(w http.ResponseWriter, r *http.Request) {
-	if h.signals == nil {
+	if h.signals == nil && h.ranker == nil {
 		writeError(w, http.StatusNotImplemented, "strategy candidates not available in this mode")
 		return
 	}
@@ -105,6 +156,12 @@ func (h *StrategyCandidatesHandler) ListCandidates(w http.ResponseWriter, r *htt
 		filteredSources = parseSources(active)
 	}
 
+	questions := map[string]string{}
+	if h.ranker != nil {
+		h.listRanked(w, r, active, filteredSources, limit, questions)
+		return
+	}
+
 	signalLimit := limit * 10
 	if signalLimit < 50 {
 		signalLimit = 50
@@ -113,7 +170,6 @@ func (h *StrategyCandidatesHandler) ListCandidates(w http.ResponseWriter, r *htt
 
 	now := time.Now().UTC()
 	candidates := make([]StrategyCandidate, 0, len(signals))
-	questions := map[string]string{}
 	for _, sig := range signals {
 		if len(filteredSources) > 0 {
 			if _, ok := filteredSources[sig.Source]; !ok {
@@ -123,36 +179,8 @@ func (h *StrategyCandidatesHandler) ListCandidates(w http.ResponseWriter, r *htt
 		if !sig.ExpiresAt.IsZero() && now.After(sig.ExpiresAt) {
 			continue
 		}
-		c := StrategyCandidate{
-			SignalID:  sig.ID,
-			Strategy:  sig.Source,
-			MarketID:  sig.MarketID,
-			TokenID:   sig.TokenID,
-			Side:      sig.Side,
-			Price:     sig.Price(),
-			Size:      sig.Size(),
-			Urgency:   sig.Urgency,
-			Reason:    sig.Reason,
-			CreatedAt: sig.CreatedAt,
-			ExpiresAt: sig.ExpiresAt,
-			Score:     scoreCandidate(sig, now),
-		}
-		if c.MarketID != "" && h.markets != nil {
-			if q, ok := questions[c.MarketID]; ok {
-				c.MarketQuestion = q
-			} else {
-				mkt, err := h.markets.GetMarket(r.Context(), c.MarketID)
-				if err == nil {
-					c.MarketQuestion = mkt.Question
-					questions[c.MarketID] = mkt.Question
-				} else {
-					h.logger.DebugContext(r.Context(), "strategy candidates: market lookup failed",
-						slog.String("market_id", c.MarketID),
-						slog.String("error", err.Error()),
-					)
-				}
-			}
-		}
+		c := h.candidate(r.Context(), sig, questions)
+		c.Score = scoreCandidate(sig, now)
 		candidates = append(candidates, c)
 	}
 
@@ -180,6 +208,132 @@ func (h *StrategyCandidatesHandler) ListCandidates(w http.ResponseWriter, r *htt
 	})
 }
 
+// listRanked writes the held candidates of manual trading, best first.
+func (h *StrategyCandidatesHandler) listRanked(w http.ResponseWriter, r *http.Request, active string, sources map[string]struct{}, limit int, questions map[string]string) {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	now := time.Now()
+	ranked := h.ranker.Ranked(r.Context(), names, limit)
+	candidates := make([]StrategyCandidate, 0, len(ranked))
+	for _, rc := range ranked {
+		candidates = append(candidates, h.rankedCandidate(r.Context(), rc, now, questions))
+	}
+	var best *StrategyCandidate
+	if len(candidates) > 0 {
+		v := candidates[0]
+		best = &v
+	}
+	writeJSON(w, http.StatusOK, strategyCandidatesResponse{
+		ActiveStrategy: active,
+		AutoExecute:    h.autoExecute,
+		Candidates:     candidates,
+		Best:           best,
+		Executable:     h.ranker.CanExecute(),
+	})
+}
+
+// ExecuteCandidate sends a held candidate of manual trading to the executor.
+// 202 with the routed candidate; 404 for an unknown candidate, 409 for one
+// already routed or expired, 501 outside manual trading and 503 without an
+// executor.
+// POST /api/strategy/candidates/{id}/execute
+func (h *StrategyCandidatesHandler) ExecuteCandidate(w http.ResponseWriter, r *http.Request) {
+	if h.ranker == nil {
+		writeError(w, http.StatusNotImplemented, "candidate execution needs strategy.auto_execute = false")
+		return
+	}
+	if !h.ranker.CanExecute() {
+		writeError(w, http.StatusServiceUnavailable, "executor not available")
+		return
+	}
+	id := r.PathValue("id")
+	rc, err := h.ranker.Execute(r.Context(), id)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, "candidate not found")
+		return
+	case errors.Is(err, domain.ErrCandidateClosed):
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		logHandler(h.logger, "strategy_candidates").ErrorContext(r.Context(), "execute candidate failed",
+			slog.String("signal_id", id),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to execute candidate")
+		return
+	}
+	auditAction(r, h.audit, h.logger, "candidate_executed", map[string]any{
+		"signal_id": id,
+		"strategy":  rc.Signal.Source,
+		"market_id": rc.Signal.MarketID,
+		"token_id":  rc.Signal.TokenID,
+		"side":      string(rc.Signal.Side),
+		"price":     rc.Signal.Price(),
+		"size":      rc.Signal.Size(),
+		"score":     rc.Score,
+	})
+	writeJSON(w, http.StatusAccepted, h.rankedCandidate(r.Context(), rc, rc.RoutedAt, map[string]string{}))
+}
+
+// rankedCandidate converts a held candidate, scored at now.
+func (h *StrategyCandidatesHandler) rankedCandidate(ctx context.Context, rc domain.Candidate, now time.Time, questions map[string]string) StrategyCandidate {
+	c := h.candidate(ctx, rc.Signal, questions)
+	c.Score = rc.Score
+	c.Rank = rc.Rank
+	c.Status = string(rc.Status)
+	c.Ranking = &candidateRanking{
+		EdgeBps:   rc.EdgeBps,
+		Freshness: rc.Freshness,
+		Liquidity: rc.Liquidity,
+		DepthUSD:  rc.DepthUSD,
+		AgeMs:     max(now.Sub(rc.Signal.CreatedAt), 0).Milliseconds(),
+	}
+	return c
+}
+
+// candidate converts sig, with its market's question looked up once per
+// market in questions.
+func (h *StrategyCandidatesHandler) candidate(ctx context.Context, sig domain.TradeSignal, questions map[string]string) StrategyCandidate {
+	c := StrategyCandidate{
+		SignalID:  sig.ID,
+		Strategy:  sig.Source,
+		MarketID:  sig.MarketID,
+		TokenID:   sig.TokenID,
+		Side:      sig.Side,
+		Price:     sig.Price(),
+		Size:      sig.Size(),
+		Urgency:   sig.Urgency,
+		Reason:    sig.Reason,
+		CreatedAt: sig.CreatedAt,
+		ExpiresAt: sig.ExpiresAt,
+	}
+	if sig.Metadata != nil {
+		c.Venue = sig.Metadata["venue"]
+		c.ManualOnly = sig.Metadata["execution"] == "manual"
+		c.Shadow = sig.Metadata["shadow"] == "true"
+	}
+	if c.MarketID != "" && h.markets != nil {
+		if q, ok := questions[c.MarketID]; ok {
+			c.MarketQuestion = q
+		} else {
+			mkt, err := h.markets.GetMarket(ctx, c.MarketID)
+			if err == nil {
+				c.MarketQuestion = mkt.Question
+				questions[c.MarketID] = mkt.Question
+			} else {
+				h.logger.DebugContext(ctx, "strategy candidates: market lookup failed",
+					slog.String("market_id", c.MarketID),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+	return c
+}
+
 func parseSources(v string) map[string]struct{} {
 	out := map[string]struct{}{}
 	if strings.TrimSpace(v) == "" {
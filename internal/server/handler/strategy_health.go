@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// StrategyBreakerController exposes per-strategy circuit breakers (e.g.
+// strategy.Engine in trade/full mode).
+type StrategyBreakerController interface {
+	BreakerStates() []domain.StrategyBreakerState
+	ResetBreaker(ctx context.Context, strategy string) error
+}
+
+// StrategyQueueReporter exposes the backpressure state of the per-strategy
+// event queues (e.g. strategy.Engine).
+type StrategyQueueReporter interface {
+	QueueStats() []domain.StrategyQueueStats
+	QueuePolicy() domain.StrategyQueuePolicy
+}
+
+// StrategyHealthHandler serves the strategy circuit breaker endpoints.
+type StrategyHealthHandler struct {
+	breakers StrategyBreakerController
+	queues   StrategyQueueReporter
+	logger   *slog.Logger
+}
+
+// NewStrategyHealthHandler creates a StrategyHealthHandler.
+func NewStrategyHealthHandler(breakers StrategyBreakerController, logger *slog.Logger) *StrategyHealthHandler {
+	return &StrategyHealthHandler{breakers: breakers, logger: logger}
+}
+
+// WithQueues adds event queue backpressure metrics to the health response.
+func (h *StrategyHealthHandler) WithQueues(queues StrategyQueueReporter) *StrategyHealthHandler {
+	h.queues = queues
+	return h
+}
+
+type strategyBreakerRow struct {
+	Strategy            string     `json:"strategy"`
+	Status              string     `json:"status"` // "ok" or "tripped"
+	Reason              string     `json:"reason,omitempty"`
+	TrippedAt           *time.Time `json:"tripped_at,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	WindowLossUSD       float64    `json:"window_loss_usd"`
+	Orders              int64      `json:"orders"`
+	Failures            int64      `json:"failures"`
+	Queue               *queueRow  `json:"queue,omitempty"`
+}
+
+type queueRow struct {
+	Depth         map[string]int   `json:"depth"`
+	Dropped       map[string]int64 `json:"dropped"`
+	Coalesced     map[string]int64 `json:"coalesced"`
+	Blocked       map[string]int64 `json:"blocked"`
+	BlockedTimeMs float64          `json:"blocked_time_ms"`
+}
+
+type queuePolicyRow struct {
+	BufferSize     int     `json:"buffer_size"`
+	Overflow       string  `json:"overflow"`
+	BlockTimeoutMs float64 `json:"block_timeout_ms"`
+}
+
+// Health returns the circuit breaker state of every registered strategy and,
+// with WithQueues, the depth, drop, coalesce and block counters of its event
+// queues plus the queue policy, for tuning throughput.
+// GET /api/strategy/health
+func (h *StrategyHealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	states := h.breakers.BreakerStates()
+	queues := make(map[string]*queueRow)
+	var dropped int64
+	if h.queues != nil {
+		for _, q := range h.queues.QueueStats() {
+			queues[q.Strategy] = &queueRow{
+				Depth:         q.Depth,
+				Dropped:       q.Dropped,
+				Coalesced:     q.Coalesced,
+				Blocked:       q.Blocked,
+				BlockedTimeMs: durationMs(q.BlockedTime),
+			}
+			for _, n := range q.Dropped {
+				dropped += n
+			}
+		}
+	}
+	rows := make([]strategyBreakerRow, 0, len(states))
+	tripped := 0
+	for _, s := range states {
+		status := "ok"
+		if s.Tripped {
+			status = "tripped"
+			tripped++
+		}
+		rows = append(rows, strategyBreakerRow{
+			Strategy:            s.Strategy,
+			Status:              status,
+			Reason:              s.Reason,
+			TrippedAt:           s.TrippedAt,
+			ConsecutiveFailures: s.ConsecutiveFailures,
+			WindowLossUSD:       s.WindowLossUSD,
+			Orders:              s.Orders,
+			Failures:            s.Failures,
+			Queue:               queues[s.Strategy],
+		})
+	}
+	resp := map[string]any{
+		"strategies": rows,
+		"tripped":    tripped,
+	}
+	if h.queues != nil {
+		p := h.queues.QueuePolicy()
+		resp["queue_policy"] = queuePolicyRow{
+			BufferSize:     p.BufferSize,
+			Overflow:       p.Overflow,
+			BlockTimeoutMs: durationMs(p.BlockTimeout),
+		}
+		resp["dropped"] = dropped
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Enable closes a tripped strategy's circuit breaker so its signals are
+// executed again. Enabling a strategy whose breaker is closed is a no-op.
+// POST /api/strategy/{name}/enable
+func (h *StrategyHealthHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if err := h.breakers.ResetBreaker(r.Context(), name); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "unknown strategy: "+name)
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "handler: reset strategy breaker failed",
+			slog.String("strategy", name),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to enable strategy")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"strategy": name,
+		"status":   "ok",
+	})
+}
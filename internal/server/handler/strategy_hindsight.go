@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// HindsightReporter scores a strategy's recorded signals against realized
+// market outcomes (service.HindsightService).
+type HindsightReporter interface {
+	Report(ctx context.Context, strategy string) (domain.HindsightReport, error)
+}
+
+// StrategyHindsightHandler serves GET /api/strategy/{name}/hindsight. When
+// reporter is nil (no Postgres or hindsight.enabled unset), requests return 501.
+type StrategyHindsightHandler struct {
+	reporter HindsightReporter
+	logger   *slog.Logger
+}
+
+// NewStrategyHindsightHandler creates a StrategyHindsightHandler. reporter may be nil.
+func NewStrategyHindsightHandler(reporter HindsightReporter, logger *slog.Logger) *StrategyHindsightHandler {
+	return &StrategyHindsightHandler{reporter: reporter, logger: logger}
+}
+
+type hindsightStatsJSON struct {
+	Signals   int     `json:"signals"`
+	Evaluated int     `json:"evaluated"`
+	Pending   int     `json:"pending"`
+	Hits      int     `json:"hits"`
+	HitRate   float64 `json:"hit_rate"`
+	PnL       float64 `json:"pnl"`
+}
+
+type hindsightOutcomeJSON struct {
+	SignalID  string    `json:"signal_id"`
+	MarketID  string    `json:"market_id"`
+	TokenID   string    `json:"token_id"`
+	Side      string    `json:"side"`
+	Price     float64   `json:"price"`
+	Size      float64   `json:"size"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Executed  bool      `json:"executed"`
+	Resolved  bool      `json:"resolved"`
+	Pending   bool      `json:"pending"`
+	ExitPrice *float64  `json:"exit_price,omitempty"`
+	PnL       *float64  `json:"pnl,omitempty"`
+}
+
+type hindsightResponse struct {
+	Strategy       string                 `json:"strategy"`
+	Since          time.Time              `json:"since"`
+	HorizonSeconds float64                `json:"horizon_seconds"`
+	EvaluatedAt    time.Time              `json:"evaluated_at"`
+	Executed       hindsightStatsJSON     `json:"executed"`
+	Skipped        hindsightStatsJSON     `json:"skipped"`
+	Outcomes       []hindsightOutcomeJSON `json:"outcomes"`
+	TotalOutcomes  int                    `json:"total_outcomes"`
+}
+
+// Hindsight returns the strategy's hit rate and counterfactual PnL for
+// executed and skipped signals, plus a page of per-signal outcomes (newest
+// first; limit/offset as for other list endpoints).
+// GET /api/strategy/{name}/hindsight
+func (h *StrategyHindsightHandler) Hindsight(w http.ResponseWriter, r *http.Request) {
+	if h.reporter == nil {
+		writeError(w, http.StatusNotImplemented, "hindsight evaluation not available (requires Postgres and hindsight.enabled)")
+		return
+	}
+	name := pathParam(r, "name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "missing strategy name")
+		return
+	}
+
+	rep, err := h.reporter.Report(r.Context(), name)
+	if err != nil {
+		logHandler(h.logger, "strategy_hindsight").ErrorContext(r.Context(), "hindsight report failed",
+			slog.String("strategy", name),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to evaluate strategy signals")
+		return
+	}
+
+	opts := parseListOpts(r)
+	page := rep.Outcomes[min(opts.Offset, len(rep.Outcomes)):]
+	page = page[:min(opts.Limit, len(page))]
+	outcomes := make([]hindsightOutcomeJSON, 0, len(page))
+	for _, o := range page {
+		row := hindsightOutcomeJSON{
+			SignalID:  o.Signal.ID,
+			MarketID:  o.Signal.MarketID,
+			TokenID:   o.Signal.TokenID,
+			Side:      string(o.Signal.Side),
+			Price:     o.Signal.Price(),
+			Size:      o.Signal.Size(),
+			Reason:    o.Signal.Reason,
+			CreatedAt: o.Signal.CreatedAt,
+			Executed:  o.Executed,
+			Resolved:  o.Resolved,
+			Pending:   o.Pending,
+		}
+		if !o.Pending {
+			exit, pnl := o.ExitPrice, o.PnL
+			row.ExitPrice, row.PnL = &exit, &pnl
+		}
+		outcomes = append(outcomes, row)
+	}
+
+	writeJSON(w, http.StatusOK, hindsightResponse{
+		Strategy:       rep.Strategy,
+		Since:          rep.Since,
+		HorizonSeconds: rep.Horizon.Seconds(),
+		EvaluatedAt:    rep.EvaluatedAt,
+		Executed:       hindsightStats(rep.Executed),
+		Skipped:        hindsightStats(rep.Skipped),
+		Outcomes:       outcomes,
+		TotalOutcomes:  len(rep.Outcomes),
+	})
+}
+
+func hindsightStats(s domain.HindsightStats) hindsightStatsJSON {
+	return hindsightStatsJSON{
+		Signals:   s.Signals,
+		Evaluated: s.Evaluated,
+		Pending:   s.Signals - s.Evaluated,
+		Hits:      s.Hits,
+		HitRate:   s.HitRate(),
+		PnL:       s.PnL,
+	}
+}
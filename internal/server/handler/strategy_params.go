@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// StrategyParamUpdater validates, applies and persists strategy parameters
+// at runtime (service.StrategyParamService).
+type StrategyParamUpdater interface {
+	Update(ctx context.Context, name string, params map[string]any) (map[string]any, error)
+}
+
+// StrategyParamVersions lists and rolls back saved versions of strategy
+// parameters (service.StrategyParamService).
+type StrategyParamVersions interface {
+	Versions(ctx context.Context, name string, limit int) ([]domain.StrategyConfigVersion, error)
+	Rollback(ctx context.Context, name string, version int) (domain.StrategyConfigVersion, error)
+}
+
+// StrategyParamsHandler serves PUT /api/strategy/{name}/params and the
+// version history endpoints.
+type StrategyParamsHandler struct {
+	params   StrategyParamUpdater
+	versions StrategyParamVersions // optional; version endpoints respond 501 without it
+	logger   *slog.Logger
+}
+
+// NewStrategyParamsHandler creates a StrategyParamsHandler.
+func NewStrategyParamsHandler(params StrategyParamUpdater, logger *slog.Logger) *StrategyParamsHandler {
+	return &StrategyParamsHandler{params: params, logger: logger}
+}
+
+// WithVersions enables listing and rolling back parameter versions.
+func (h *StrategyParamsHandler) WithVersions(versions StrategyParamVersions) *StrategyParamsHandler {
+	h.versions = versions
+	return h
+}
+
+// strategyParamsResponse reports the persisted overrides after an update.
+type strategyParamsResponse struct {
+	Strategy string         `json:"strategy"`
+	Params   map[string]any `json:"params"`
+}
+
+// UpdateParams changes a strategy's parameters without a restart. The body is
+// a JSON object of parameter names to values, e.g. {"min_edge_bps": 50,
+// "size_per_leg": 10}; parameters not listed keep their value. Unknown
+// parameters or out-of-range values fail with 400 and nothing changes.
+// PUT /api/strategy/{name}/params
+func (h *StrategyParamsHandler) UpdateParams(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "missing strategy name")
+		return
+	}
+	var params map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if len(params) == 0 {
+		writeError(w, http.StatusBadRequest, "no parameters given")
+		return
+	}
+
+	persisted, err := h.params.Update(r.Context(), name, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "strategy not registered")
+		case errors.Is(err, domain.ErrInvalidParams):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.ErrorContext(r.Context(), "handler: update strategy params failed",
+				slog.String("strategy", name),
+				slog.String("error", err.Error()),
+			)
+			writeError(w, http.StatusInternalServerError, "failed to update strategy params")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, strategyParamsResponse{Strategy: name, Params: persisted})
+}
+
+// strategyVersionResponse is one saved version of a strategy's parameters.
+type strategyVersionResponse struct {
+	Version    int            `json:"version"`
+	Params     map[string]any `json:"params"`
+	Overrides  map[string]any `json:"overrides"`
+	Source     string         `json:"source"`
+	RollbackOf int            `json:"rollback_of,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+func newStrategyVersionResponse(v domain.StrategyConfigVersion) strategyVersionResponse {
+	return strategyVersionResponse{
+		Version:    v.Version,
+		Params:     v.Params,
+		Overrides:  v.Overrides,
+		Source:     v.Source,
+		RollbackOf: v.RollbackOf,
+		CreatedAt:  v.CreatedAt,
+	}
+}
+
+// listStrategyVersionsResponse wraps a strategy's saved versions.
+type listStrategyVersionsResponse struct {
+	Strategy string                    `json:"strategy"`
+	Versions []strategyVersionResponse `json:"versions"`
+}
+
+// ListVersions returns the saved versions of a strategy's effective
+// parameters, newest first; limit defaults to 50 and is capped at 500.
+// Responds 501 when versions are not persisted.
+// GET /api/strategy/{name}/versions?limit=
+func (h *StrategyParamsHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	if h.versions == nil {
+		writeError(w, http.StatusNotImplemented, "strategy versions not available")
+		return
+	}
+	name := r.PathValue("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "missing strategy name")
+		return
+	}
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = min(n, 500)
+	}
+
+	versions, err := h.versions.Versions(r.Context(), name, limit)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list strategy versions failed",
+			slog.String("strategy", name),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to list strategy versions")
+		return
+	}
+	resp := listStrategyVersionsResponse{Strategy: name, Versions: make([]strategyVersionResponse, 0, len(versions))}
+	for _, v := range versions {
+		resp.Versions = append(resp.Versions, newStrategyVersionResponse(v))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Rollback applies a saved version's parameters to the running strategy and
+// restores its overrides, and returns the new version recording the
+// rollback. Responds 404 for an unknown strategy or version and 400 when the
+// saved parameters no longer validate.
+// POST /api/strategy/{name}/versions/{version}/rollback
+func (h *StrategyParamsHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	if h.versions == nil {
+		writeError(w, http.StatusNotImplemented, "strategy versions not available")
+		return
+	}
+	name := r.PathValue("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "missing strategy name")
+		return
+	}
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil || version <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid version")
+		return
+	}
+
+	v, err := h.versions.Rollback(r.Context(), name, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "strategy or version not found")
+		case errors.Is(err, domain.ErrInvalidParams):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			h.logger.ErrorContext(r.Context(), "handler: rollback strategy params failed",
+				slog.String("strategy", name),
+				slog.Int("version", version),
+				slog.String("error", err.Error()),
+			)
+			writeError(w, http.StatusInternalServerError, "failed to roll back strategy params")
+		}
+		return
+	}
+	h.logger.InfoContext(r.Context(), "handler: strategy params rolled back",
+		slog.String("strategy", name),
+		slog.Int("to_version", version),
+		slog.Int("version", v.Version),
+	)
+	writeJSON(w, http.StatusOK, newStrategyVersionResponse(v))
+}
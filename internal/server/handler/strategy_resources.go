@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// StrategyResourceProvider exposes per-strategy resource metering (e.g.
+// strategy.Engine in trade/full mode).
+type StrategyResourceProvider interface {
+	ResourceUsage() []domain.StrategyResourceUsage
+	EventBudget() time.Duration
+}
+
+// StrategyResourcesHandler serves GET /api/strategy/resources.
+type StrategyResourcesHandler struct {
+	provider StrategyResourceProvider
+}
+
+// NewStrategyResourcesHandler creates a StrategyResourcesHandler.
+func NewStrategyResourcesHandler(provider StrategyResourceProvider) *StrategyResourcesHandler {
+	return &StrategyResourcesHandler{provider: provider}
+}
+
+// strategyResourceRow is one leaderboard entry. Durations are in milliseconds;
+// the *wall_ms fields are wall-clock time inside the strategy's callbacks,
+// not CPU time.
+type strategyResourceRow struct {
+	Rank          int              `json:"rank"`
+	Strategy      string           `json:"strategy"`
+	Events        int64            `json:"events"`
+	Signals       int64            `json:"signals"`
+	WallMs        float64          `json:"wall_ms"`
+	AvgWallMs     float64          `json:"avg_wall_ms"`
+	MaxWallMs     float64          `json:"max_wall_ms"`
+	LastWallMs    float64          `json:"last_wall_ms"`
+	OverBudget    int64            `json:"over_budget"`
+	HeldEntries   map[string]int   `json:"held_entries"`
+	Evicted       map[string]int64 `json:"evicted"`
+	Dropped       map[string]int64 `json:"dropped"`
+	Coalesced     map[string]int64 `json:"coalesced"`
+	MaxBookLagMs  float64          `json:"max_book_lag_ms"`
+	LastBookLagMs float64          `json:"last_book_lag_ms"`
+	LastEventAt   *time.Time       `json:"last_event_at,omitempty"`
+}
+
+// Resources returns the per-strategy resource leaderboard, heaviest first.
+// GET /api/strategy/resources
+func (h *StrategyResourcesHandler) Resources(w http.ResponseWriter, r *http.Request) {
+	usage := h.provider.ResourceUsage()
+	rows := make([]strategyResourceRow, 0, len(usage))
+	for i, u := range usage {
+		row := strategyResourceRow{
+			Rank:          i + 1,
+			Strategy:      u.Strategy,
+			Events:        u.Events,
+			Signals:       u.Signals,
+			WallMs:        durationMs(u.WallTime),
+			AvgWallMs:     durationMs(u.AvgWall),
+			MaxWallMs:     durationMs(u.MaxWall),
+			LastWallMs:    durationMs(u.LastWall),
+			OverBudget:    u.OverBudget,
+			HeldEntries:   u.HeldEntries,
+			Evicted:       u.Evicted,
+			Dropped:       u.Dropped,
+			Coalesced:     u.Coalesced,
+			MaxBookLagMs:  durationMs(u.MaxBookLag),
+			LastBookLagMs: durationMs(u.LastBookLag),
+		}
+		if row.HeldEntries == nil {
+			row.HeldEntries = map[string]int{}
+		}
+		if row.Evicted == nil {
+			row.Evicted = map[string]int64{}
+		}
+		if row.Dropped == nil {
+			row.Dropped = map[string]int64{}
+		}
+		if row.Coalesced == nil {
+			row.Coalesced = map[string]int64{}
+		}
+		if !u.LastEventAt.IsZero() {
+			t := u.LastEventAt.UTC()
+			row.LastEventAt = &t
+		}
+		rows = append(rows, row)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"budget_ms":  durationMs(h.provider.EventBudget()),
+		"strategies": rows,
+	})
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
 // StrategyRuntimeController is the interface for getting/setting the active
@@ -15,6 +17,12 @@ type StrategyRuntimeController interface {
 	SetActive(name string) error
 }
 
+// StrategyActiveApplier switches the running set of strategies at runtime,
+// starting and stopping individual strategies (strategy.Engine).
+type StrategyActiveApplier interface {
+	ApplyActive(names []string) error
+}
+
 // HubStrategyUpdater is called when the active strategy is changed so the
 // WebSocket hub can report the new name in bot_status.
 type HubStrategyUpdater interface {
@@ -26,6 +34,7 @@ type HubStrategyUpdater interface {
 type StrategyRuntimeHandler struct {
 	ctrl  StrategyRuntimeController
 	hub   HubStrategyUpdater // optional; when set, updated on POST
+	audit domain.AuditStore  // optional
 	logger *slog.Logger
 }
 
@@ -34,6 +43,13 @@ func NewStrategyRuntimeHandler(ctrl StrategyRuntimeController, hub HubStrategyUp
 	return &StrategyRuntimeHandler{ctrl: ctrl, hub: hub, logger: logger}
 }
 
+// WithAudit records strategy switches as "strategy_active_changed" audit
+// entries.
+func (h *StrategyRuntimeHandler) WithAudit(audit domain.AuditStore) *StrategyRuntimeHandler {
+	h.audit = audit
+	return h
+}
+
 // GetActive returns the current active strategy name.
 // GET /api/strategy/active
 func (h *StrategyRuntimeHandler) GetActive(w http.ResponseWriter, r *http.Request) {
@@ -59,12 +75,17 @@ func (h *StrategyRuntimeHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]any{"strategies": names})
 }
 
-// SetActiveRequest is the JSON body for POST /api/strategy/active.
+// SetActiveRequest is the JSON body for POST /api/strategy/active: Name for
+// one strategy, or Names for a set run concurrently.
 type SetActiveRequest struct {
-	Name string `json:"name"`
+	Name  string   `json:"name"`
+	Names []string `json:"names"`
 }
 
-// SetActive sets the active strategy and returns 200 or 400.
+// SetActive sets the active strategy or strategies and returns 200 or 400.
+// When the controller implements StrategyActiveApplier, strategies that stay
+// active keep running, added ones start and removed ones stop after their
+// queued events.
 // POST /api/strategy/active
 func (h *StrategyRuntimeHandler) SetActive(w http.ResponseWriter, r *http.Request) {
 	if h.ctrl == nil {
@@ -81,20 +102,47 @@ func (h *StrategyRuntimeHandler) SetActive(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	name := strings.TrimSpace(req.Name)
-	if name == "" {
-		writeError(w, http.StatusBadRequest, "name is required")
+	names := cleanNames(req.Names)
+	if name != "" && len(names) > 0 {
+		writeError(w, http.StatusBadRequest, "use either name or names, not both")
+		return
+	}
+	if name != "" {
+		names = []string{name}
+	}
+	if len(names) == 0 {
+		writeError(w, http.StatusBadRequest, "name or names is required")
+		return
+	}
+	applier, ok := h.ctrl.(StrategyActiveApplier)
+	if !ok && len(names) > 1 {
+		writeError(w, http.StatusNotImplemented, "multiple active strategies not supported")
 		return
 	}
-	if err := h.ctrl.SetActive(name); err != nil {
+
+	previous := h.ctrl.ActiveName()
+	var err error
+	if ok {
+		err = applier.ApplyActive(names)
+	} else {
+		err = h.ctrl.SetActive(names[0])
+	}
+	if err != nil {
 		h.logger.WarnContext(r.Context(), "set active strategy failed",
-			slog.String("name", name),
+			slog.Any("names", names),
 			slog.String("error", err.Error()),
 		)
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	active := strings.Join(names, ",")
 	if h.hub != nil {
-		h.hub.SetStrategyName(name)
+		h.hub.SetStrategyName(active)
 	}
-	writeJSON(w, http.StatusOK, map[string]string{"active": name})
+	auditAction(r, h.audit, h.logger, "strategy_active_changed", map[string]any{
+		"previous": previous,
+		"active":   names,
+		"source":   "active",
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"active": active, "strategies": names})
 }
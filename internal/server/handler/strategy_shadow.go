@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// ShadowCandidateLister lists the recorded signals of a shadow-mode strategy
+// (postgres.SignalStore over shadow_candidates).
+type ShadowCandidateLister interface {
+	ListByStrategy(ctx context.Context, source string, opts domain.ListOpts) ([]domain.TradeSignal, error)
+}
+
+// StrategyShadowHandler serves GET /api/strategy/{name}/shadow.
+type StrategyShadowHandler struct {
+	store  ShadowCandidateLister
+	logger *slog.Logger
+}
+
+// NewStrategyShadowHandler creates a StrategyShadowHandler. Until WithStore
+// is called the endpoint responds 501.
+func NewStrategyShadowHandler(logger *slog.Logger) *StrategyShadowHandler {
+	return &StrategyShadowHandler{logger: logger}
+}
+
+// WithStore sets the shadow candidates store backing the endpoint.
+func (h *StrategyShadowHandler) WithStore(store ShadowCandidateLister) *StrategyShadowHandler {
+	h.store = store
+	return h
+}
+
+type shadowCandidateJSON struct {
+	SignalID  string            `json:"signal_id"`
+	MarketID  string            `json:"market_id"`
+	TokenID   string            `json:"token_id"`
+	Side      string            `json:"side"`
+	Price     float64           `json:"price"`
+	Size      float64           `json:"size"`
+	Urgency   int               `json:"urgency"`
+	Reason    string            `json:"reason,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type shadowCandidatesResponse struct {
+	Strategy   string                `json:"strategy"`
+	Candidates []shadowCandidateJSON `json:"candidates"`
+}
+
+// Candidates returns the signals a shadow-mode strategy emitted instead of
+// trading, newest first. from/to (RFC 3339 or YYYY-MM-DD) bound creation
+// time; limit/offset as for other list endpoints.
+// GET /api/strategy/{name}/shadow
+func (h *StrategyShadowHandler) Candidates(w http.ResponseWriter, r *http.Request) {
+	if h.store == nil {
+		writeError(w, http.StatusNotImplemented, "shadow candidates not available: postgres not configured")
+		return
+	}
+	name := pathParam(r, "name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "missing strategy name")
+		return
+	}
+	opts := parseListOpts(r)
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid from: want RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		opts.Since = &t
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, ok := parseDayOrTime(v)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid to: want RFC 3339 or YYYY-MM-DD")
+			return
+		}
+		opts.Until = &t
+	}
+
+	signals, err := h.store.ListByStrategy(r.Context(), name, opts)
+	if err != nil {
+		logHandler(h.logger, "strategy_shadow").ErrorContext(r.Context(), "list shadow candidates failed",
+			slog.String("strategy", name),
+			slog.String("error", err.Error()),
+		)
+		writeError(w, http.StatusInternalServerError, "failed to list shadow candidates")
+		return
+	}
+	resp := shadowCandidatesResponse{
+		Strategy:   name,
+		Candidates: make([]shadowCandidateJSON, 0, len(signals)),
+	}
+	for _, sig := range signals {
+		resp.Candidates = append(resp.Candidates, shadowCandidateJSON{
+			SignalID:  sig.ID,
+			MarketID:  sig.MarketID,
+			TokenID:   sig.TokenID,
+			Side:      string(sig.Side),
+			Price:     sig.Price(),
+			Size:      sig.Size(),
+			Urgency:   int(sig.Urgency),
+			Reason:    sig.Reason,
+			Metadata:  sig.Metadata,
+			CreatedAt: sig.CreatedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
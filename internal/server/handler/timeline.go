@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// defaultTimelineWindow is the range served when from is omitted.
+const defaultTimelineWindow = time.Hour
+
+// TimelineService pages the bot activity timeline (service.TimelineService).
+type TimelineService interface {
+	List(ctx context.Context, from, to time.Time, cursor string, kinds []string, limit int) ([]domain.TimelineEvent, string, error)
+}
+
+// TimelineHandler serves GET /api/timeline.
+type TimelineHandler struct {
+	timeline TimelineService
+	logger   *slog.Logger
+}
+
+// NewTimelineHandler creates a TimelineHandler. Until WithService is called
+// the endpoint responds 501.
+func NewTimelineHandler(logger *slog.Logger) *TimelineHandler {
+	return &TimelineHandler{logger: logger}
+}
+
+// WithService sets the service backing the endpoint.
+func (h *TimelineHandler) WithService(timeline TimelineService) *TimelineHandler {
+	h.timeline = timeline
+	return h
+}
+
+type timelineEventResponse struct {
+	At       time.Time      `json:"at"`
+	Kind     string         `json:"kind"`
+	Event    string         `json:"event"`
+	Source   string         `json:"source"`
+	ID       string         `json:"id"`
+	MarketID string         `json:"market_id,omitempty"`
+	Detail   map[string]any `json:"detail,omitempty"`
+}
+
+// Timeline returns signals, orders, fills, feed connectivity changes, risk
+// rejections and configuration changes in [from, to), oldest first. from and
+// to are RFC 3339; to defaults to now and from to an hour before to. kinds
+// is a comma-separated subset of signal, order, fill, feed, risk, config and
+// audit. Pass next_cursor back as cursor to read the following page.
+// GET /api/timeline?from=&to=&kinds=&limit=200&cursor=
+func (h *TimelineHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	if h.timeline == nil {
+		writeError(w, http.StatusNotImplemented, "timeline not available: postgres not configured")
+		return
+	}
+	q := r.URL.Query()
+
+	to := time.Now().UTC()
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "to must be an RFC 3339 time")
+			return
+		}
+		to = t
+	}
+	from := to.Add(-defaultTimelineWindow)
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "from must be an RFC 3339 time")
+			return
+		}
+		from = t
+	}
+	var limit int
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+	var kinds []string
+	for _, k := range strings.Split(q.Get("kinds"), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			kinds = append(kinds, k)
+		}
+	}
+
+	list, next, err := h.timeline.List(r.Context(), from, to, q.Get("cursor"), kinds, limit)
+	if errors.Is(err, domain.ErrInvalidTimeline) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "handler: list timeline failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusInternalServerError, "failed to list timeline")
+		return
+	}
+
+	events := make([]timelineEventResponse, 0, len(list))
+	for _, e := range list {
+		events = append(events, timelineEventResponse{
+			At:       e.At,
+			Kind:     e.Kind,
+			Event:    e.Event,
+			Source:   e.Source,
+			ID:       e.ID,
+			MarketID: e.MarketID,
+			Detail:   e.Detail,
+		})
+	}
+	resp := map[string]any{
+		"from":   from,
+		"to":     to,
+		"events": events,
+	}
+	if next != "" {
+		resp["next_cursor"] = next
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// WalletBalanceSource reads the trading wallet's on-chain balances and
+// exchange allowances (service.BalanceGuard).
+type WalletBalanceSource interface {
+	Balances(ctx context.Context) (domain.WalletBalances, error)
+}
+
+// WalletHandler serves GET /api/wallet/balances.
+type WalletHandler struct {
+	source WalletBalanceSource
+	logger *slog.Logger
+}
+
+// NewWalletHandler creates a WalletHandler. Until WithSource is called the
+// endpoint responds 501.
+func NewWalletHandler(logger *slog.Logger) *WalletHandler {
+	return &WalletHandler{logger: logger}
+}
+
+// WithSource sets the balance reader backing the endpoint.
+func (h *WalletHandler) WithSource(source WalletBalanceSource) *WalletHandler {
+	h.source = source
+	return h
+}
+
+type exchangeApprovalResponse struct {
+	Name          string  `json:"name"`
+	Address       string  `json:"address"`
+	USDCAllowance float64 `json:"usdc_allowance"`
+	CTFApproved   bool    `json:"ctf_approved"`
+}
+
+type walletBalancesResponse struct {
+	Wallet    string                     `json:"wallet"`
+	USDC      float64                    `json:"usdc"`
+	Exchanges []exchangeApprovalResponse `json:"exchanges"`
+	Block     uint64                     `json:"block"`
+	CheckedAt string                     `json:"checked_at"`
+}
+
+// Balances returns the wallet's USDC.e balance and, per Polymarket exchange
+// contract, its USDC.e allowance and whether it may move the wallet's
+// outcome tokens, read from chain on every call.
+// GET /api/wallet/balances
+func (h *WalletHandler) Balances(w http.ResponseWriter, r *http.Request) {
+	if h.source == nil {
+		writeError(w, http.StatusNotImplemented, "wallet balances not available: polygon.rpc_url or wallet.private_key not set")
+		return
+	}
+	b, err := h.source.Balances(r.Context())
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "wallet balances failed", slog.String("error", err.Error()))
+		writeError(w, http.StatusBadGateway, "failed to read wallet balances")
+		return
+	}
+	resp := walletBalancesResponse{
+		Wallet:    b.Wallet,
+		USDC:      b.USDC,
+		Exchanges: make([]exchangeApprovalResponse, 0, len(b.Exchanges)),
+		Block:     b.Block,
+		CheckedAt: b.CheckedAt.Format(time.RFC3339),
+	}
+	for _, e := range b.Exchanges {
+		resp.Exchanges = append(resp.Exchanges, exchangeApprovalResponse{
+			Name:          e.Name,
+			Address:       e.Address,
+			USDCAllowance: e.USDCAllowance,
+			CTFApproved:   e.CTFApproved,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
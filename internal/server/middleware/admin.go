@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// Admin returns middleware for admin-only endpoints. Unlike Auth it fails
+// closed: when adminToken is empty every request is refused with 403, so
+// admin endpoints are never exposed by accident. Tokens are read the same way
+// as Auth (Bearer or X-API-Key).
+func Admin(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if adminToken == "" {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"error":"admin endpoints disabled (server.admin_token not set)"}`))
+				return
+			}
+
+			token := extractToken(r)
+			if token == "" {
+				writeUnauthorized(w, "missing authentication token")
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+				writeUnauthorized(w, "invalid authentication token")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Role is the permission level of an API caller or the one a route requires.
+// Roles are ordered: each grants everything below it.
+type Role int
+
+const (
+	RolePublic   Role = iota // no authentication required
+	RoleReadOnly             // reads
+	RoleTrader               // orders, strategies and other mutating calls
+	RoleAdmin                // config, cache and risk administration
+)
+
+// ParseRole parses "public", "read_only", "trader" or "admin".
+func ParseRole(s string) (Role, bool) {
+	switch s {
+	case "public":
+		return RolePublic, true
+	case "read_only":
+		return RoleReadOnly, true
+	case "trader":
+		return RoleTrader, true
+	case "admin":
+		return RoleAdmin, true
+	}
+	return 0, false
+}
+
+func (r Role) String() string {
+	switch r {
+	case RolePublic:
+		return "public"
+	case RoleReadOnly:
+		return "read_only"
+	case RoleTrader:
+		return "trader"
+	case RoleAdmin:
+		return "admin"
+	}
+	return "unknown"
+}
+
+// Principal is an authenticated API caller.
+type Principal struct {
+	Name string
+	Role Role
+	Via  string // "api_key" or "jwt"
+}
+
+type principalKey struct{}
+
+// PrincipalFrom returns the caller authenticated by Authorizer, if any.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// APIKey is a named static key and the role it grants.
+type APIKey struct {
+	Name string
+	Key  string
+	Role Role
+}
+
+// Authorizer authenticates API callers by API key or HS256 JWT and enforces
+// the role each route requires: the route's entry in its route table, else
+// read-only for GET and HEAD and trader for every other method. Every
+// mutating call, allowed or refused, is written to the audit log with the
+// principal that made it.
+type Authorizer struct {
+	keys      []APIKey
+	jwtSecret []byte
+	routes    map[string]Role // by mux pattern, e.g. "POST /api/risk/reset"
+	audit     domain.AuditStore
+	logger    *slog.Logger
+}
+
+// NewAuthorizer creates an Authorizer accepting keys and, when jwtSecret is
+// set, JWTs signed with it.
+func NewAuthorizer(keys []APIKey, jwtSecret string, logger *slog.Logger) *Authorizer {
+	return &Authorizer{
+		keys:      keys,
+		jwtSecret: []byte(jwtSecret),
+		routes:    make(map[string]Role),
+		logger:    logger.With(slog.String("component", "auth")),
+	}
+}
+
+// WithRoutes sets the role required by each route pattern, replacing any
+// earlier entry for the same pattern.
+func (a *Authorizer) WithRoutes(routes map[string]Role) *Authorizer {
+	for pattern, role := range routes {
+		a.routes[pattern] = role
+	}
+	return a
+}
+
+// WithAudit enables audit entries for mutating calls.
+func (a *Authorizer) WithAudit(audit domain.AuditStore) *Authorizer {
+	a.audit = audit
+	return a
+}
+
+// Middleware returns the middleware enforcing route roles. routeOf resolves
+// a request to its route pattern (e.g. via http.ServeMux.Handler); requests
+// matching no route need the method's default role.
+func (a *Authorizer) Middleware(routeOf func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := routeOf(r)
+			required := a.required(r.Method, route)
+			if required == RolePublic {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			p, err := a.authenticate(r)
+			if err != nil {
+				writeUnauthorized(w, err.Error())
+				a.record(r, route, p, http.StatusUnauthorized)
+				return
+			}
+			if p.Role < required {
+				writeForbidden(w, required.String()+" role required")
+				a.record(r, route, p, http.StatusForbidden)
+				return
+			}
+
+			r = r.WithContext(context.WithValue(r.Context(), principalKey{}, p))
+			if isSafeMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rw, r)
+			a.record(r, route, p, rw.statusCode)
+		})
+	}
+}
+
+// required returns the role a request to route needs.
+func (a *Authorizer) required(method, route string) Role {
+	if role, ok := a.routes[route]; ok {
+		return role
+	}
+	switch {
+	case method == http.MethodOptions: // CORS preflights carry no credentials
+		return RolePublic
+	case isSafeMethod(method):
+		return RoleReadOnly
+	default:
+		return RoleTrader
+	}
+}
+
+// authenticate resolves the request's token to a principal.
+func (a *Authorizer) authenticate(r *http.Request) (Principal, error) {
+	token := extractToken(r)
+	if token == "" {
+		return Principal{}, errors.New("missing authentication token")
+	}
+	// Compare against every key so the timing does not reveal which matched.
+	var match *APIKey
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.keys[i].Key)) == 1 {
+			match = &a.keys[i]
+		}
+	}
+	if match != nil {
+		return Principal{Name: match.Name, Role: match.Role, Via: "api_key"}, nil
+	}
+	if len(a.jwtSecret) > 0 && strings.Count(token, ".") == 2 {
+		return a.parseJWT(token, time.Now())
+	}
+	return Principal{}, errors.New("invalid authentication token")
+}
+
+// jwtClaims are the claims Authorizer reads from a JWT.
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+}
+
+// parseJWT verifies an HS256 JWT and returns its principal. Tokens must
+// carry a subject, a role other than public and an expiry.
+func (a *Authorizer) parseJWT(token string, now time.Time) (Principal, error) {
+	invalid := errors.New("invalid authentication token")
+	parts := strings.Split(token, ".")
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Principal{}, invalid
+	}
+	var h struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(header, &h); err != nil || h.Alg != "HS256" {
+		return Principal{}, invalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Principal{}, invalid
+	}
+	mac := hmac.New(sha256.New, a.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return Principal{}, invalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Principal{}, invalid
+	}
+	var c jwtClaims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return Principal{}, invalid
+	}
+	role, ok := ParseRole(c.Role)
+	if c.Subject == "" || !ok || role == RolePublic || c.ExpiresAt == 0 {
+		return Principal{}, invalid
+	}
+	if now.Unix() >= c.ExpiresAt {
+		return Principal{}, errors.New("authentication token expired")
+	}
+	if c.NotBefore != 0 && now.Unix() < c.NotBefore {
+		return Principal{}, invalid
+	}
+	return Principal{Name: c.Subject, Role: role, Via: "jwt"}, nil
+}
+
+// record writes the audit entry of a mutating call. p is the zero Principal
+// for unauthenticated calls.
+func (a *Authorizer) record(r *http.Request, route string, p Principal, status int) {
+	if a.audit == nil || isSafeMethod(r.Method) {
+		return
+	}
+	detail := map[string]any{
+		"principal": p.Name,
+		"method":    r.Method,
+		"path":      r.URL.Path,
+		"route":     route,
+		"status":    status,
+	}
+	if p.Name != "" {
+		detail["role"] = p.Role.String()
+		detail["auth"] = p.Via
+	}
+	if err := a.audit.Log(r.Context(), "api_call", detail); err != nil {
+		a.logger.WarnContext(r.Context(), "audit log failed",
+			slog.String("path", r.URL.Path),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// isSafeMethod reports whether method only reads.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// writeForbidden sends a 403 response with a JSON error body.
+func writeForbidden(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusForbidden)
+	w.Write([]byte(`{"error":"` + msg + `"}`))
+}
@@ -29,6 +29,7 @@ type Handlers struct {
 	Arb      *handler.ArbHandler
 	Strategy *handler.StrategyHandler
 	Pipeline *handler.PipelineHandler
+	Instruments *handler.InstrumentHandler
 }
 
 // Server is the headless HTTP + WebSocket API server for the Polymarket bot.
@@ -47,6 +48,8 @@ func NewServer(cfg Config, handlers Handlers, wsHub *ws.Hub, logger *slog.Logger
 
 	// Health check (no auth required).
 	mux.HandleFunc("GET /api/health", handlers.Health.HealthCheck)
+	mux.HandleFunc("GET /api/health/live", handlers.Health.Live)
+	mux.HandleFunc("GET /api/health/ready", handlers.Health.Ready)
 
 	// Market endpoints.
 	mux.HandleFunc("GET /api/markets", handlers.Markets.ListMarkets)
@@ -67,8 +70,19 @@ func NewServer(cfg Config, handlers Handlers, wsHub *ws.Hub, logger *slog.Logger
 	mux.HandleFunc("GET /api/strategy/config", handlers.Strategy.GetConfig)
 	mux.HandleFunc("PUT /api/strategy/config", handlers.Strategy.UpdateConfig)
 
-	// Pipeline trigger endpoint.
+	// Pipeline trigger and run history endpoints.
 	mux.HandleFunc("POST /api/pipeline/trigger", handlers.Pipeline.TriggerPipeline)
+	mux.HandleFunc("GET /api/pipeline/runs", handlers.Pipeline.ListRuns)
+	mux.HandleFunc("GET /api/pipeline/runs/{id}", handlers.Pipeline.GetRun)
+	mux.HandleFunc("GET /api/pipeline/dlq", handlers.Pipeline.ListDeadLetters)
+	mux.HandleFunc("POST /api/pipeline/dlq/retry", handlers.Pipeline.RetryDeadLetters)
+
+	// Instrument registry endpoints.
+	mux.HandleFunc("GET /api/instruments", handlers.Instruments.ListInstruments)
+	mux.HandleFunc("GET /api/instruments/resolve", handlers.Instruments.ResolveInstrument)
+	mux.HandleFunc("POST /api/instruments/lookup", handlers.Instruments.LookupInstruments)
+	mux.HandleFunc("GET /api/instruments/{id}", handlers.Instruments.GetInstrument)
+	mux.HandleFunc("PUT /api/instruments/{id}/identifiers/{kind}", handlers.Instruments.LinkIdentifier)
 
 	// WebSocket endpoint.
 	if wsHub != nil {
@@ -0,0 +1,90 @@
+package ws
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// authenticate returns the channel ACL for the request's token. A nil ACL
+// grants every channel. ok is false when auth is enabled and the token is
+// missing or unknown.
+func (h *Hub) authenticate(r *http.Request) (acl []string, ok bool) {
+	if h.token == "" && len(h.acl) == 0 {
+		return nil, true
+	}
+	token := requestToken(r)
+	if token == "" {
+		return nil, false
+	}
+	if h.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1 {
+		return nil, true
+	}
+	for t, patterns := range h.acl {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			// A configured but empty ACL grants nothing rather than everything.
+			return append([]string{}, patterns...), true
+		}
+	}
+	return nil, false
+}
+
+// requestToken reads the token from the "token" query parameter (browsers
+// cannot set headers on a WebSocket handshake) or an Authorization: Bearer
+// header.
+func requestToken(r *http.Request) string {
+	if t := strings.TrimSpace(r.URL.Query().Get("token")); t != "" {
+		return t
+	}
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		parts := strings.SplitN(auth, " ", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], "Bearer") {
+			return strings.TrimSpace(parts[1])
+		}
+	}
+	return ""
+}
+
+// checkOrigin allows requests without an Origin header (non-browser
+// clients) and browser requests from one of the allowed origins. An empty
+// list or "*" allows every origin.
+func (h *Hub) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || len(h.origins) == 0 {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	for _, o := range h.origins {
+		if o == "*" || strings.EqualFold(strings.TrimRight(o, "/"), u.Scheme+"://"+u.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern reports whether channel matches pattern, where a trailing "*"
+// matches any suffix ("ch:book:*" matches "ch:book:12345").
+func matchPattern(pattern, channel string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(channel, prefix)
+	}
+	return pattern == channel
+}
+
+// allowed reports whether the client's ACL permits channel, which may itself
+// be a wildcard pattern.
+func (c *client) allowed(channel string) bool {
+	if c.acl == nil {
+		return true
+	}
+	for _, p := range c.acl {
+		if matchPattern(p, channel) {
+			return true
+		}
+	}
+	return false
+}
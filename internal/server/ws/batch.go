@@ -0,0 +1,111 @@
+package ws
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// outMsg is a message queued for one client. Messages with the same
+// non-empty key supersede each other while they wait in a batch.
+type outMsg struct {
+	env envelope
+	key string
+}
+
+// coalesceKey returns the key under which a message on channel replaces an
+// earlier, not yet written one: per-asset book channels, and "prices"
+// events per asset_id (each carries the asset's full top of book, so only
+// the latest matters to a dashboard). Other messages are never coalesced.
+func coalesceKey(channel string, data []byte) string {
+	if strings.HasPrefix(channel, "ch:book:") {
+		return channel
+	}
+	if channel != "prices" {
+		return ""
+	}
+	var ev struct {
+		AssetID string `json:"asset_id"`
+	}
+	if json.Unmarshal(data, &ev) != nil || ev.AssetID == "" {
+		return ""
+	}
+	return "prices:" + ev.AssetID
+}
+
+// batchPump is writePump with batching: messages are collected for the hub's
+// flush interval, or until maxBatch are pending, and written as a single
+// "batch" envelope whose payload (messages, in protobuf) holds them. A lone
+// message is written as is.
+func (c *client) batchPump() {
+	ping := time.NewTicker(pingPeriod)
+	flush := time.NewTicker(c.hub.flushInterval)
+	defer func() {
+		ping.Stop()
+		flush.Stop()
+		c.conn.Close()
+	}()
+
+	var pending []outMsg
+	index := make(map[string]int) // key -> position in pending
+
+	write := func() bool {
+		if len(pending) == 0 {
+			return true
+		}
+		ok := c.writeBatch(pending)
+		pending = pending[:0]
+		clear(index)
+		return ok
+	}
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			if !ok {
+				// The hub closed the channel; deliver what is pending first.
+				write()
+				c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if i, dup := index[msg.key]; dup && msg.key != "" {
+				pending[i] = msg
+				continue
+			}
+			if msg.key != "" {
+				index[msg.key] = len(pending)
+			}
+			pending = append(pending, msg)
+			if len(pending) >= c.hub.maxBatch && !write() {
+				return
+			}
+
+		case <-flush.C:
+			if !write() {
+				return
+			}
+
+		case <-ping.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeBatch writes msgs as one frame.
+func (c *client) writeBatch(msgs []outMsg) bool {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if len(msgs) == 1 {
+		return c.writeEnvelope(msgs[0].env)
+	}
+	envs := make([]envelope, len(msgs))
+	for i, m := range msgs {
+		envs[i] = m.env
+	}
+	return c.writeEnvelope(batchEnvelope(envs))
+}
@@ -0,0 +1,181 @@
+package ws
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket subprotocols a client may request in Sec-WebSocket-Protocol.
+// Clients that request neither get JSON.
+const (
+	// SubprotocolJSON sends every envelope as a JSON text frame.
+	SubprotocolJSON = "polybot.json.v1"
+	// SubprotocolProto sends every envelope as a binary frame holding a
+	// polybot.v1.WsEnvelope (proto/polybot/v1/ws.proto).
+	SubprotocolProto = "polybot.proto.v1"
+)
+
+// channelTypes names the events of bus channels whose messages carry no
+// "event" field of their own.
+var channelTypes = map[string]string{
+	"ch:signal":     "signal",
+	"ch:arb":        "arb",
+	"ch:order":      "order",
+	"ch:status":     "bot_status",
+	"ch:shadow":     "shadow_signal",
+	"prices":        "price",
+	"orders":        "order",
+	"positions":     "position",
+	"arb":           "arb",
+	"trades":        "trade",
+	"price_updates": "price",
+	"arb_prices":    "arb_price",
+	"bond_resolved": "bond_resolved",
+	"alerts":        "alert",
+	"risk":          "risk",
+	"markets":       "market",
+	"feed":          "feed_state",
+}
+
+// envelope is the one message shape the hub sends, for bus events and its
+// own replies alike.
+type envelope struct {
+	Type    string
+	Channel string
+	TS      time.Time
+	ID      string
+	Payload json.RawMessage // JSON; nil for batches
+	Batch   []envelope      // set when Type is "batch"
+}
+
+// busEnvelope wraps a payload received on a bus channel. The type is the
+// payload's "event" field when it has one, otherwise the channel's. Payloads
+// that are not JSON are carried as a base64 string.
+func busEnvelope(channel string, data []byte, ts time.Time) envelope {
+	env := envelope{Type: channelType(channel), Channel: channel, TS: ts, Payload: data}
+	if !json.Valid(data) {
+		env.Payload, _ = json.Marshal(data)
+		return env
+	}
+	var ev struct {
+		Event string `json:"event"`
+	}
+	if json.Unmarshal(data, &ev) == nil && ev.Event != "" {
+		env.Type = ev.Event
+	}
+	return env
+}
+
+// channelType returns the event type of a channel: "book" for per-asset book
+// channels, the channel's entry in channelTypes, or the channel name.
+func channelType(channel string) string {
+	if strings.HasPrefix(channel, "ch:book:") {
+		return "book"
+	}
+	if t, ok := channelTypes[channel]; ok {
+		return t
+	}
+	return channel
+}
+
+// hubEnvelope builds a message produced by the hub itself.
+func hubEnvelope(typ, channel, id string, payload any) (envelope, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return envelope{}, err
+	}
+	return envelope{Type: typ, Channel: channel, TS: time.Now().UTC(), ID: id, Payload: data}, nil
+}
+
+// batchEnvelope wraps messages written together in one frame.
+func batchEnvelope(msgs []envelope) envelope {
+	return envelope{Type: "batch", TS: time.Now().UTC(), Batch: msgs}
+}
+
+type jsonEnvelope struct {
+	Type    string          `json:"type"`
+	Channel string          `json:"channel,omitempty"`
+	TS      time.Time       `json:"ts"`
+	ID      string          `json:"id,omitempty"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// MarshalJSON encodes the envelope; a batch's payload is the array of its
+// envelopes.
+func (e envelope) MarshalJSON() ([]byte, error) {
+	payload := e.Payload
+	if e.Type == "batch" {
+		var err error
+		if payload, err = json.Marshal(e.Batch); err != nil {
+			return nil, err
+		}
+	}
+	if payload == nil {
+		payload = json.RawMessage("null")
+	}
+	return json.Marshal(jsonEnvelope{Type: e.Type, Channel: e.Channel, TS: e.TS, ID: e.ID, Payload: payload})
+}
+
+// encode returns the frame type and bytes of e for a client speaking proto
+// or JSON.
+func (e envelope) encode(proto bool) (int, []byte, error) {
+	if proto {
+		return websocket.BinaryMessage, e.appendProto(nil), nil
+	}
+	data, err := json.Marshal(e)
+	return websocket.TextMessage, data, err
+}
+
+// Protobuf wire types.
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// appendProto appends e encoded as polybot.v1.WsEnvelope. Fields at their
+// zero value are left out, as proto3 does.
+func (e envelope) appendProto(b []byte) []byte {
+	b = appendProtoString(b, 1, e.Type)
+	b = appendProtoString(b, 2, e.Channel)
+	if !e.TS.IsZero() {
+		var ts []byte
+		if s := e.TS.Unix(); s != 0 {
+			ts = appendProtoTag(ts, 1, wireVarint)
+			ts = binary.AppendUvarint(ts, uint64(s))
+		}
+		if n := e.TS.Nanosecond(); n != 0 {
+			ts = appendProtoTag(ts, 2, wireVarint)
+			ts = binary.AppendUvarint(ts, uint64(n))
+		}
+		b = appendProtoBytes(b, 3, ts)
+	}
+	b = appendProtoString(b, 4, e.ID)
+	if len(e.Payload) > 0 {
+		b = appendProtoBytes(b, 5, e.Payload)
+	}
+	for _, m := range e.Batch {
+		b = appendProtoBytes(b, 6, m.appendProto(nil))
+	}
+	return b
+}
+
+func appendProtoTag(b []byte, field int, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wire))
+}
+
+func appendProtoBytes(b []byte, field int, v []byte) []byte {
+	b = appendProtoTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendProtoString(b []byte, field int, v string) []byte {
+	if v == "" {
+		return b
+	}
+	return appendProtoBytes(b, field, []byte(v))
+}
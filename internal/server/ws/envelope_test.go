@@ -0,0 +1,148 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb" // registers google/protobuf/timestamp.proto
+)
+
+// wsEnvelopeDescriptor builds polybot.v1.WsEnvelope as declared in
+// proto/polybot/v1/ws.proto.
+func wsEnvelopeDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	)
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("polybot/v1/ws.proto"),
+		Package:    proto.String("polybot.v1"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("WsEnvelope"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("type", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				field("channel", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				field("ts", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, ".google.protobuf.Timestamp"),
+				field("id", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				field("payload", 5, descriptorpb.FieldDescriptorProto_TYPE_BYTES, optional, ""),
+				field("messages", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated, ".polybot.v1.WsEnvelope"),
+			},
+		}},
+	}
+	file, err := protodesc.NewFile(fd, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("build ws.proto descriptor: %v", err)
+	}
+	return file.Messages().ByName("WsEnvelope")
+}
+
+func TestEnvelopeAppendProto(t *testing.T) {
+	md := wsEnvelopeDescriptor(t)
+	ts := time.Date(2025, 6, 1, 12, 30, 45, 123456789, time.UTC)
+
+	tests := []struct {
+		name string
+		env  envelope
+	}{
+		{"bus event", busEnvelope("orders", []byte(`{"event":"order_placed","id":"o1"}`), ts)},
+		{"non-JSON payload", busEnvelope("ch:book:123", []byte{0xff, 0x00}, ts)},
+		{"query result", envelope{Type: "query_result", Channel: "ch:signal", TS: ts, ID: "42", Payload: json.RawMessage(`[]`)}},
+		{"type only", envelope{Type: "error"}},
+		{"whole second", envelope{Type: "price", TS: time.Unix(1700000000, 0).UTC()}},
+		{"epoch second", envelope{Type: "price", TS: time.Unix(0, 5).UTC()}},
+		{"before epoch", envelope{Type: "price", TS: time.Date(1969, 7, 20, 20, 17, 40, 500, time.UTC)}},
+		{"batch", envelope{Type: "batch", TS: ts, Batch: []envelope{
+			busEnvelope("prices", []byte(`{"asset_id":"a","price":0.5}`), ts),
+			{Type: "batch", Batch: []envelope{{Type: "alert", Payload: json.RawMessage(`{}`)}}},
+			{},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.env.appendProto(nil)
+			msg := dynamicpb.NewMessage(md)
+			if err := proto.Unmarshal(data, msg); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			checkEnvelope(t, tt.env, msg)
+
+			// The runtime encodes fields in number order and leaves zero
+			// values out, as appendProto does.
+			want, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if !bytes.Equal(data, want) {
+				t.Errorf("appendProto = %x, runtime encodes %x", data, want)
+			}
+		})
+	}
+}
+
+// checkEnvelope compares a decoded WsEnvelope with the envelope it was
+// encoded from.
+func checkEnvelope(t *testing.T, env envelope, msg protoreflect.Message) {
+	t.Helper()
+	if len(msg.GetUnknown()) > 0 {
+		t.Errorf("unknown fields %x", msg.GetUnknown())
+	}
+	fields := msg.Descriptor().Fields()
+	get := func(name protoreflect.Name) protoreflect.Value {
+		return msg.Get(fields.ByName(name))
+	}
+	if got := get("type").String(); got != env.Type {
+		t.Errorf("type = %q, want %q", got, env.Type)
+	}
+	if got := get("channel").String(); got != env.Channel {
+		t.Errorf("channel = %q, want %q", got, env.Channel)
+	}
+	if got := get("id").String(); got != env.ID {
+		t.Errorf("id = %q, want %q", got, env.ID)
+	}
+	if got := get("payload").Bytes(); !bytes.Equal(got, env.Payload) {
+		t.Errorf("payload = %q, want %q", got, env.Payload)
+	}
+
+	if has := msg.Has(fields.ByName("ts")); has == env.TS.IsZero() {
+		t.Errorf("ts set = %v for %v", has, env.TS)
+	} else if has {
+		tsMsg := get("ts").Message()
+		tsFields := tsMsg.Descriptor().Fields()
+		got := time.Unix(tsMsg.Get(tsFields.ByName("seconds")).Int(), tsMsg.Get(tsFields.ByName("nanos")).Int())
+		if !got.Equal(env.TS) {
+			t.Errorf("ts = %v, want %v", got.UTC(), env.TS)
+		}
+	}
+
+	messages := get("messages").List()
+	if messages.Len() != len(env.Batch) {
+		t.Fatalf("messages = %d, want %d", messages.Len(), len(env.Batch))
+	}
+	for i, m := range env.Batch {
+		checkEnvelope(t, m, messages.Get(i).Message())
+	}
+}
@@ -37,6 +37,7 @@ var defaultChannels = []string{
 	"ch:arb",
 	"ch:order",
 	"ch:status",
+	"ch:shadow",
 	// Backward-compatible channels used by current services.
 	"prices",
 	"orders",
@@ -46,39 +47,45 @@ var defaultChannels = []string{
 	"price_updates",
 	"arb_prices",
 	"bond_resolved",
-}
-
-// upgrader configures the WebSocket upgrade parameters.
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow all origins. In production, restrict this to known origins.
-		return true
-	},
+	"alerts",
+	"risk",
+	"markets",
+	"feed",
 }
 
 // client represents a single WebSocket connection.
 type client struct {
 	hub  *Hub
 	conn *websocket.Conn
-	send chan []byte
+	send chan outMsg
 	subs map[string]bool // subscribed channels
-	mu   sync.RWMutex
+	acl  []string        // permitted channel patterns; nil permits all
+	// proto is set when the client negotiated SubprotocolProto; envelopes
+	// are then written as protobuf binary frames instead of JSON text.
+	proto bool
+	mu    sync.RWMutex
 }
 
-// subscribeMsg is the JSON message a client sends to subscribe to channels.
+// subscribeMsg is the JSON message a client sends to subscribe to channels
+// or to request a snapshot.
 type subscribeMsg struct {
-	Action   string   `json:"action"`   // "subscribe" or "unsubscribe"
+	Action   string   `json:"action"`   // "subscribe", "unsubscribe" or "query"
 	Channels []string `json:"channels"` // channel names
 	// Compatibility with prior client format:
 	// {"subscribe":["ch:book:*","ch:signal"]}
 	Subscribe   []string `json:"subscribe"`
 	Unsubscribe []string `json:"unsubscribe"`
+
+	// Snapshot query: {"action":"query","id":"1","query":"recent_signals","limit":20}
+	ID     string `json:"id"`
+	Query  string `json:"query"`
+	Limit  int    `json:"limit"`
+	Wallet string `json:"wallet"`
 }
 
 // Hub manages a set of connected WebSocket clients and broadcasts messages
-// from the Redis signal bus to all subscribed clients.
+// from the Redis signal bus to all subscribed clients. Every message is sent
+// as an envelope (see envelope.go) in the format the client negotiated.
 type Hub struct {
 	clients    map[*client]bool
 	broadcast  chan broadcastMsg
@@ -90,6 +97,19 @@ type Hub struct {
 	mode       string
 	strategy   string
 	startedAt  time.Time
+	upgrader   websocket.Upgrader
+
+	token   string
+	acl     map[string][]string
+	origins []string
+
+	signals   SignalSource
+	positions PositionSource
+	wallet    string
+
+	// Outbound batching (see batch.go); flushInterval 0 writes one frame per message.
+	flushInterval time.Duration
+	maxBatch      int
 }
 
 // broadcastMsg carries a message along with its source channel so the hub
@@ -97,14 +117,29 @@ type Hub struct {
 type broadcastMsg struct {
 	channel string
 	data    []byte
+	at      time.Time
 }
 
 // Config captures runtime metadata used in hub status snapshots sent to
 // WebSocket clients on connect.
+//
+// Token, when set, must be presented by every client (?token= or
+// Authorization: Bearer) and grants all channels. ACL maps further tokens to
+// the channel patterns they may receive; any ACL entry also turns auth on.
+// AllowedOrigins restricts browser origins; empty allows all.
+//
+// FlushInterval, when positive, batches each client's outbound messages:
+// messages queued within the interval are written as one "batch" frame
+// (at most MaxBatch messages), keeping only the latest book update per asset.
 type Config struct {
-	Mode         string
-	StrategyName string
-	StartedAt    time.Time
+	Mode           string
+	StrategyName   string
+	StartedAt      time.Time
+	Token          string
+	ACL            map[string][]string
+	AllowedOrigins []string
+	FlushInterval  time.Duration
+	MaxBatch       int
 }
 
 // NewHub creates a new WebSocket hub that bridges a Redis SignalBus to
@@ -123,7 +158,7 @@ func NewHub(bus domain.SignalBus, logger *slog.Logger, cfg Config) *Hub {
 		startedAt = time.Now().UTC()
 	}
 
-	return &Hub{
+	h := &Hub{
 		clients:    make(map[*client]bool),
 		broadcast:  make(chan broadcastMsg, 256),
 		register:   make(chan *client),
@@ -133,7 +168,23 @@ func NewHub(bus domain.SignalBus, logger *slog.Logger, cfg Config) *Hub {
 		mode:       mode,
 		strategy:   strategy,
 		startedAt:  startedAt,
+		token:      cfg.Token,
+		acl:        cfg.ACL,
+		origins:    cfg.AllowedOrigins,
+
+		flushInterval: cfg.FlushInterval,
+		maxBatch:      cfg.MaxBatch,
+	}
+	if h.maxBatch <= 0 {
+		h.maxBatch = sendBufferSize
 	}
+	h.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     h.checkOrigin,
+		Subprotocols:    []string{SubprotocolJSON, SubprotocolProto},
+	}
+	return h
 }
 
 // SetStrategyName updates the strategy name reported in bot_status (e.g. after
@@ -188,11 +239,15 @@ func (h *Hub) Run(ctx context.Context) error {
 			)
 
 		case msg := <-h.broadcast:
+			out := outMsg{env: busEnvelope(msg.channel, msg.data, msg.at)}
+			if h.flushInterval > 0 {
+				out.key = coalesceKey(msg.channel, msg.data)
+			}
 			h.mu.RLock()
 			for c := range h.clients {
-				if c.isSubscribed(msg.channel) {
+				if c.isSubscribed(msg.channel) && c.allowed(msg.channel) {
 					select {
-					case c.send <- msg.data:
+					case c.send <- out:
 					default:
 						// Client's send buffer is full; drop the message.
 						h.logger.Warn("ws: dropping message for slow client")
@@ -232,31 +287,49 @@ func (h *Hub) subscribeToChannel(ctx context.Context, channel string) {
 			h.broadcast <- broadcastMsg{
 				channel: channel,
 				data:    data,
+				at:      time.Now().UTC(),
 			}
 		}
 	}
 }
 
-// HandleWS upgrades an HTTP request to a WebSocket connection and registers
-// the client with the hub.
-// GET /ws
+// HandleWS authenticates the request, upgrades it to a WebSocket connection
+// and registers the client with the hub. The client starts subscribed to
+// ?channels=a,b when given, otherwise to every default channel, in both
+// cases limited to what its token permits. Clients requesting the
+// SubprotocolProto subprotocol receive protobuf frames, others JSON.
+// GET /ws?token=...&channels=ch:signal,positions
 func (h *Hub) HandleWS(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	acl, ok := h.authenticate(r)
+	if !ok {
+		http.Error(w, `{"error":"invalid or missing ws token"}`, http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		h.logger.Error("ws: upgrade failed", slog.String("error", err.Error()))
 		return
 	}
 
 	c := &client{
-		hub:  h,
-		conn: conn,
-		send: make(chan []byte, sendBufferSize),
-		subs: make(map[string]bool),
+		hub:   h,
+		conn:  conn,
+		send:  make(chan outMsg, sendBufferSize),
+		subs:  make(map[string]bool),
+		acl:   acl,
+		proto: conn.Subprotocol() == SubprotocolProto,
 	}
 
-	// Subscribe to all default channels initially.
-	for _, ch := range defaultChannels {
-		c.subs[ch] = true
+	initial := defaultChannels
+	if v := strings.TrimSpace(r.URL.Query().Get("channels")); v != "" {
+		initial = strings.Split(v, ",")
+	}
+	for _, ch := range initial {
+		ch = strings.TrimSpace(ch)
+		if ch != "" && c.allowed(ch) {
+			c.subs[ch] = true
+		}
 	}
 
 	h.register <- c
@@ -300,41 +373,58 @@ func (c *client) readPump() {
 			return
 		}
 
-		// Try to parse as a subscription management message.
+		// Try to parse as a subscription management or query message.
 		var sub subscribeMsg
-		if jsonErr := json.Unmarshal(message, &sub); jsonErr == nil &&
-			(sub.Action != "" || len(sub.Channels) > 0 || len(sub.Subscribe) > 0 || len(sub.Unsubscribe) > 0) {
-			c.handleSubscription(sub)
+		if jsonErr := json.Unmarshal(message, &sub); jsonErr == nil {
+			switch {
+			case sub.Action == "query":
+				c.handleQuery(sub)
+			case sub.Action != "" || len(sub.Channels) > 0 || len(sub.Subscribe) > 0 || len(sub.Unsubscribe) > 0:
+				c.handleSubscription(sub)
+			}
 		}
 	}
 }
 
 // handleSubscription processes subscribe/unsubscribe requests from the client.
+// Channels outside the client's ACL are refused and reported back in an
+// error envelope.
 func (c *client) handleSubscription(msg subscribeMsg) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if len(msg.Subscribe) > 0 {
-		for _, ch := range msg.Subscribe {
-			c.subs[ch] = true
+	var denied []string
+	subscribe := func(ch string) {
+		if !c.allowed(ch) {
+			denied = append(denied, ch)
+			return
 		}
+		c.subs[ch] = true
 	}
-	if len(msg.Unsubscribe) > 0 {
-		for _, ch := range msg.Unsubscribe {
-			delete(c.subs, ch)
-		}
+
+	c.mu.Lock()
+	for _, ch := range msg.Subscribe {
+		subscribe(ch)
+	}
+	for _, ch := range msg.Unsubscribe {
+		delete(c.subs, ch)
 	}
 
 	switch msg.Action {
 	case "subscribe":
 		for _, ch := range msg.Channels {
-			c.subs[ch] = true
+			subscribe(ch)
 		}
 	case "unsubscribe":
 		for _, ch := range msg.Channels {
 			delete(c.subs, ch)
 		}
 	}
+	c.mu.Unlock()
+
+	if len(denied) > 0 {
+		c.sendHub("error", "", "", map[string]any{
+			"message":  "channels not permitted",
+			"channels": denied,
+		})
+	}
 }
 
 // sendInitialStatus pushes a small JSON envelope so clients can immediately
@@ -345,25 +435,14 @@ func (c *client) sendInitialStatus() {
 		uptime = 0
 	}
 
-	msg, err := json.Marshal(map[string]any{
-		"type": "bot_status",
-		"payload": map[string]any{
-			"mode":           c.hub.mode,
-			"ws_connected":   true,
-			"uptime_seconds": uptime,
-			"open_positions": 0,
-			"open_orders":    0,
-			"strategy_name":  c.hub.strategy,
-		},
+	c.sendHub("bot_status", "ch:status", "", map[string]any{
+		"mode":           c.hub.mode,
+		"ws_connected":   true,
+		"uptime_seconds": uptime,
+		"open_positions": 0,
+		"open_orders":    0,
+		"strategy_name":  c.hub.strategy,
 	})
-	if err != nil {
-		return
-	}
-
-	select {
-	case c.send <- msg:
-	default:
-	}
 }
 
 // isSubscribed checks whether the client is subscribed to the given channel.
@@ -378,11 +457,8 @@ func (c *client) isSubscribed(channel string) bool {
 
 	// Wildcard match: "ch:book:*" should match "ch:book:12345".
 	for sub := range c.subs {
-		if len(sub) > 0 && sub[len(sub)-1] == '*' {
-			prefix := sub[:len(sub)-1]
-			if len(channel) >= len(prefix) && channel[:len(prefix)] == prefix {
-				return true
-			}
+		if strings.HasSuffix(sub, "*") && matchPattern(sub, channel) {
+			return true
 		}
 	}
 
@@ -390,9 +466,14 @@ func (c *client) isSubscribed(channel string) bool {
 }
 
 // writePump pumps messages from the hub to the WebSocket connection.
-// It sends protobuf binary frames for data messages and periodic ping
-// frames for keepalive.
+// It writes one frame per envelope, JSON text or protobuf binary as the
+// client negotiated, and periodic ping frames for keepalive. With a flush
+// interval set, messages are batched instead (see batchPump).
 func (c *client) writePump() {
+	if c.hub.flushInterval > 0 {
+		c.batchPump()
+		return
+	}
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		ticker.Stop()
@@ -409,8 +490,7 @@ func (c *client) writePump() {
 				return
 			}
 
-			// Send protobuf data as binary frames.
-			if err := c.conn.WriteMessage(websocket.BinaryMessage, message); err != nil {
+			if !c.writeEnvelope(message.env) {
 				return
 			}
 
@@ -422,3 +502,17 @@ func (c *client) writePump() {
 		}
 	}
 }
+
+// writeEnvelope encodes env for the client and writes it as one frame. The
+// write deadline must already be set.
+func (c *client) writeEnvelope(env envelope) bool {
+	typ, data, err := env.encode(c.proto)
+	if err != nil {
+		c.hub.logger.Warn("ws: encode envelope failed",
+			slog.String("type", env.Type),
+			slog.String("error", err.Error()),
+		)
+		return true
+	}
+	return c.conn.WriteMessage(typ, data) == nil
+}
@@ -0,0 +1,164 @@
+package ws
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// queryTimeout bounds the store lookups behind one snapshot query.
+const queryTimeout = 5 * time.Second
+
+// SignalSource provides recently emitted strategy signals (strategy.Engine).
+type SignalSource interface {
+	RecentSignals(limit int) []domain.TradeSignal
+}
+
+// PositionSource provides a wallet's open positions (domain.PositionStore).
+type PositionSource interface {
+	GetOpen(ctx context.Context, wallet string) ([]domain.Position, error)
+}
+
+// Snapshot queries a client can send as {"action":"query","query":...}. Each
+// is gated by the channel whose live stream carries the same data.
+const (
+	queryRecentSignals = "recent_signals"
+	queryOpenPositions = "open_positions"
+)
+
+var queryChannels = map[string]string{
+	queryRecentSignals: "ch:signal",
+	queryOpenPositions: "positions",
+}
+
+// signalView is the JSON shape of a signal in a recent_signals snapshot.
+type signalView struct {
+	ID        string               `json:"id"`
+	Source    string               `json:"source"`
+	MarketID  string               `json:"market_id"`
+	TokenID   string               `json:"token_id"`
+	Side      domain.OrderSide     `json:"side"`
+	Price     float64              `json:"price"`
+	Size      float64              `json:"size"`
+	Urgency   domain.SignalUrgency `json:"urgency"`
+	Reason    string               `json:"reason"`
+	Metadata  map[string]string    `json:"metadata,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	ExpiresAt time.Time            `json:"expires_at"`
+}
+
+// WithSignals enables the recent_signals snapshot query.
+func (h *Hub) WithSignals(src SignalSource) *Hub {
+	h.signals = src
+	return h
+}
+
+// WithPositions enables the open_positions snapshot query. wallet is used
+// when the query does not name one.
+func (h *Hub) WithPositions(src PositionSource, wallet string) *Hub {
+	h.positions = src
+	h.wallet = wallet
+	return h
+}
+
+// handleQuery answers a snapshot query with a query_result envelope whose
+// payload is the snapshot, or a query_error envelope whose payload is
+// {"query","error"}, echoing the request id.
+func (c *client) handleQuery(msg subscribeMsg) {
+	payload, err := c.runQuery(msg)
+	channel := queryChannels[msg.Query]
+	if err != "" {
+		c.sendHub("query_error", channel, msg.ID, map[string]string{
+			"query": msg.Query,
+			"error": err,
+		})
+		return
+	}
+	c.sendHub("query_result", channel, msg.ID, payload)
+}
+
+func (c *client) runQuery(msg subscribeMsg) (any, string) {
+	channel, known := queryChannels[msg.Query]
+	if !known {
+		return nil, "unknown query"
+	}
+	if !c.allowed(channel) {
+		return nil, "query not permitted"
+	}
+
+	limit := msg.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	switch msg.Query {
+	case queryRecentSignals:
+		if c.hub.signals == nil {
+			return nil, "recent_signals not available in this mode"
+		}
+		sigs := c.hub.signals.RecentSignals(limit)
+		out := make([]signalView, 0, len(sigs))
+		for _, s := range sigs {
+			out = append(out, signalView{
+				ID:        s.ID,
+				Source:    s.Source,
+				MarketID:  s.MarketID,
+				TokenID:   s.TokenID,
+				Side:      s.Side,
+				Price:     s.Price(),
+				Size:      s.Size(),
+				Urgency:   s.Urgency,
+				Reason:    s.Reason,
+				Metadata:  s.Metadata,
+				CreatedAt: s.CreatedAt,
+				ExpiresAt: s.ExpiresAt,
+			})
+		}
+		return out, ""
+
+	case queryOpenPositions:
+		if c.hub.positions == nil {
+			return nil, "open_positions not available in this mode"
+		}
+		wallet := msg.Wallet
+		if wallet == "" {
+			wallet = c.hub.wallet
+		}
+		if wallet == "" {
+			return nil, "wallet is required"
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+		defer cancel()
+		positions, err := c.hub.positions.GetOpen(ctx, wallet)
+		if err != nil {
+			c.hub.logger.Warn("ws: open_positions query failed",
+				slog.String("wallet", wallet),
+				slog.String("error", err.Error()),
+			)
+			return nil, "failed to load positions"
+		}
+		if len(positions) > limit {
+			positions = positions[:limit]
+		}
+		return positions, ""
+	}
+	return nil, "unknown query"
+}
+
+// sendHub queues a message produced by the hub for the client, dropping it
+// if the send buffer is full.
+func (c *client) sendHub(typ, channel, id string, payload any) {
+	env, err := hubEnvelope(typ, channel, id, payload)
+	if err != nil {
+		return
+	}
+	select {
+	case c.send <- outMsg{env: env}:
+	default:
+	}
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// alertsChannel carries alert lifecycle and trigger events. The WebSocket hub
+// forwards it to clients, and every AlertService reloads its rules when it
+// sees a create/update/delete so CRUD from any process takes effect live.
+const alertsChannel = "alerts"
+
+// alertReloadInterval is the fallback refresh period for enabled alerts.
+const alertReloadInterval = time.Minute
+
+// AlertNotifier delivers triggered alerts to external channels (notify.Notifier).
+type AlertNotifier interface {
+	Notify(ctx context.Context, event, title, message string) error
+}
+
+// AlertService manages user-defined price alerts and evaluates them against
+// the "prices" stream.
+type AlertService struct {
+	alerts   domain.AlertStore
+	markets  domain.MarketStore
+	bus      domain.SignalBus
+	notifier AlertNotifier
+	logger   *slog.Logger
+
+	mu       sync.Mutex
+	byToken  map[string][]domain.Alert
+	disarmed map[string]bool // recurring alerts wait for the condition to clear before re-firing
+}
+
+// NewAlertService creates an AlertService. markets and notifier may be nil.
+func NewAlertService(
+	alerts domain.AlertStore,
+	markets domain.MarketStore,
+	bus domain.SignalBus,
+	notifier AlertNotifier,
+	logger *slog.Logger,
+) *AlertService {
+	return &AlertService{
+		alerts:   alerts,
+		markets:  markets,
+		bus:      bus,
+		notifier: notifier,
+		logger:   logger.With(slog.String("component", "alert_service")),
+		byToken:  make(map[string][]domain.Alert),
+		disarmed: make(map[string]bool),
+	}
+}
+
+// List returns all alerts.
+func (s *AlertService) List(ctx context.Context) ([]domain.Alert, error) {
+	list, err := s.alerts.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("alert_service: list: %w", err)
+	}
+	return list, nil
+}
+
+// Get returns one alert by ID.
+func (s *AlertService) Get(ctx context.Context, id string) (domain.Alert, error) {
+	a, err := s.alerts.GetByID(ctx, id)
+	if err != nil {
+		return domain.Alert{}, fmt.Errorf("alert_service: get %s: %w", id, err)
+	}
+	return a, nil
+}
+
+// Create validates and stores a new alert. When TokenID is empty the first
+// outcome token of MarketID is used; when MarketID is empty it is looked up
+// from TokenID.
+func (s *AlertService) Create(ctx context.Context, a domain.Alert) (domain.Alert, error) {
+	a.ID = uuid.New().String()
+	a.CreatedAt = time.Now().UTC()
+	a.UpdatedAt = a.CreatedAt
+	a.TriggerCount = 0
+	a.LastTriggeredAt = nil
+	if err := s.normalize(ctx, &a); err != nil {
+		return domain.Alert{}, err
+	}
+	if err := s.alerts.Create(ctx, a); err != nil {
+		return domain.Alert{}, fmt.Errorf("alert_service: create: %w", err)
+	}
+	s.publishChange(ctx, "alert_created", a)
+	return a, nil
+}
+
+// Update validates and replaces the editable fields of an existing alert.
+func (s *AlertService) Update(ctx context.Context, a domain.Alert) (domain.Alert, error) {
+	if err := s.normalize(ctx, &a); err != nil {
+		return domain.Alert{}, err
+	}
+	if err := s.alerts.Update(ctx, a); err != nil {
+		return domain.Alert{}, fmt.Errorf("alert_service: update %s: %w", a.ID, err)
+	}
+	updated, err := s.alerts.GetByID(ctx, a.ID)
+	if err != nil {
+		return domain.Alert{}, fmt.Errorf("alert_service: reload %s: %w", a.ID, err)
+	}
+	s.publishChange(ctx, "alert_updated", updated)
+	return updated, nil
+}
+
+// Delete removes an alert.
+func (s *AlertService) Delete(ctx context.Context, id string) error {
+	if err := s.alerts.Delete(ctx, id); err != nil {
+		return fmt.Errorf("alert_service: delete %s: %w", id, err)
+	}
+	s.publishChange(ctx, "alert_deleted", domain.Alert{ID: id})
+	return nil
+}
+
+func (s *AlertService) normalize(ctx context.Context, a *domain.Alert) error {
+	a.MarketID = strings.TrimSpace(a.MarketID)
+	a.TokenID = strings.TrimSpace(a.TokenID)
+	if a.Metric == "" {
+		a.Metric = domain.AlertMetricMid
+	}
+	switch a.Metric {
+	case domain.AlertMetricMid, domain.AlertMetricBid, domain.AlertMetricAsk, domain.AlertMetricSpread:
+	default:
+		return fmt.Errorf("alert_service: %w: unknown metric %q", domain.ErrInvalidAlert, a.Metric)
+	}
+	switch a.Operator {
+	case domain.AlertOpGT, domain.AlertOpGTE, domain.AlertOpLT, domain.AlertOpLTE:
+	default:
+		return fmt.Errorf("alert_service: %w: unknown operator %q", domain.ErrInvalidAlert, a.Operator)
+	}
+	if a.Threshold < 0 || a.Threshold > 1 {
+		return fmt.Errorf("alert_service: %w: threshold must be in [0, 1]", domain.ErrInvalidAlert)
+	}
+	if a.Cooldown < 0 {
+		return fmt.Errorf("alert_service: %w: cooldown must be >= 0", domain.ErrInvalidAlert)
+	}
+
+	switch {
+	case a.TokenID == "" && a.MarketID == "":
+		return fmt.Errorf("alert_service: %w: market_id or token_id is required", domain.ErrInvalidAlert)
+	case a.TokenID == "":
+		if s.markets == nil {
+			return fmt.Errorf("alert_service: %w: token_id is required", domain.ErrInvalidAlert)
+		}
+		m, err := s.markets.GetByID(ctx, a.MarketID)
+		if err != nil {
+			return fmt.Errorf("alert_service: resolve market %s: %w", a.MarketID, err)
+		}
+		a.TokenID = m.YesTokenID()
+	case a.MarketID == "" && s.markets != nil:
+		if m, err := s.markets.GetByTokenID(ctx, a.TokenID); err == nil {
+			a.MarketID = m.ID
+		}
+	}
+	return nil
+}
+
+// ResolveToken returns the token ID of the named outcome of a market
+// (case-insensitive). An empty outcome selects the first token.
+func (s *AlertService) ResolveToken(ctx context.Context, marketID, outcome string) (string, error) {
+	if s.markets == nil {
+		return "", fmt.Errorf("alert_service: %w: market lookup unavailable", domain.ErrInvalidAlert)
+	}
+	m, err := s.markets.GetByID(ctx, marketID)
+	if err != nil {
+		return "", fmt.Errorf("alert_service: resolve market %s: %w", marketID, err)
+	}
+	if outcome == "" {
+		return m.YesTokenID(), nil
+	}
+	for i, name := range m.Outcomes {
+		if strings.EqualFold(name, outcome) && m.TokenID(i) != "" {
+			return m.TokenID(i), nil
+		}
+	}
+	return "", fmt.Errorf("alert_service: %w: market %s has no outcome %q", domain.ErrInvalidAlert, marketID, outcome)
+}
+
+// Run loads enabled alerts and evaluates them on every "prices" event until
+// ctx is cancelled. Call in a goroutine.
+func (s *AlertService) Run(ctx context.Context) error {
+	prices, err := s.bus.Subscribe(ctx, "prices")
+	if err != nil {
+		return fmt.Errorf("alert_service: subscribe prices: %w", err)
+	}
+	changes, err := s.bus.Subscribe(ctx, alertsChannel)
+	if err != nil {
+		return fmt.Errorf("alert_service: subscribe %s: %w", alertsChannel, err)
+	}
+	s.reload(ctx)
+
+	ticker := time.NewTicker(alertReloadInterval)
+	defer ticker.Stop()
+
+	s.logger.InfoContext(ctx, "alert service started")
+	defer s.logger.InfoContext(ctx, "alert service stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			s.reload(ctx)
+		case data, ok := <-changes:
+			if !ok {
+				return nil
+			}
+			var ev struct {
+				Event string `json:"event"`
+			}
+			if json.Unmarshal(data, &ev) == nil && ev.Event != "alert_triggered" {
+				s.reload(ctx)
+			}
+		case data, ok := <-prices:
+			if !ok {
+				return nil
+			}
+			var ev struct {
+				AssetID string  `json:"asset_id"`
+				BestBid float64 `json:"best_bid"`
+				BestAsk float64 `json:"best_ask"`
+			}
+			if err := json.Unmarshal(data, &ev); err != nil || ev.AssetID == "" {
+				continue
+			}
+			s.evaluate(ctx, ev.AssetID, ev.BestBid, ev.BestAsk)
+		}
+	}
+}
+
+func (s *AlertService) reload(ctx context.Context) {
+	list, err := s.alerts.ListEnabled(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "alert service reload failed", slog.String("error", err.Error()))
+		return
+	}
+	byToken := make(map[string][]domain.Alert, len(list))
+	for _, a := range list {
+		byToken[a.TokenID] = append(byToken[a.TokenID], a)
+	}
+	s.mu.Lock()
+	s.byToken = byToken
+	s.mu.Unlock()
+}
+
+func (s *AlertService) evaluate(ctx context.Context, tokenID string, bestBid, bestAsk float64) {
+	now := time.Now().UTC()
+	var fired []domain.Alert
+	var values []float64
+
+	s.mu.Lock()
+	alerts := s.byToken[tokenID]
+	kept := alerts[:0]
+	for _, a := range alerts {
+		v, ok := a.Value(bestBid, bestAsk)
+		if !ok {
+			kept = append(kept, a)
+			continue
+		}
+		if !a.Matches(v) {
+			delete(s.disarmed, a.ID)
+			kept = append(kept, a)
+			continue
+		}
+		if s.disarmed[a.ID] || (a.LastTriggeredAt != nil && now.Sub(*a.LastTriggeredAt) < a.Cooldown) {
+			kept = append(kept, a)
+			continue
+		}
+		a.TriggerCount++
+		a.LastTriggeredAt = &now
+		fired = append(fired, a)
+		values = append(values, v)
+		if a.Recurring {
+			s.disarmed[a.ID] = true
+			kept = append(kept, a)
+		}
+	}
+	s.byToken[tokenID] = kept
+	s.mu.Unlock()
+
+	for i, a := range fired {
+		s.fire(ctx, a, values[i], now)
+	}
+}
+
+func (s *AlertService) fire(ctx context.Context, a domain.Alert, value float64, at time.Time) {
+	if err := s.alerts.MarkTriggered(ctx, a.ID, at, !a.Recurring); err != nil {
+		s.logger.WarnContext(ctx, "alert mark triggered failed",
+			slog.String("alert_id", a.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+	s.logger.InfoContext(ctx, "alert triggered",
+		slog.String("alert_id", a.ID),
+		slog.String("token_id", a.TokenID),
+		slog.String("metric", string(a.Metric)),
+		slog.String("operator", string(a.Operator)),
+		slog.Float64("threshold", a.Threshold),
+		slog.Float64("value", value),
+	)
+
+	payload, _ := json.Marshal(map[string]any{
+		"event":         "alert_triggered",
+		"alert_id":      a.ID,
+		"market_id":     a.MarketID,
+		"token_id":      a.TokenID,
+		"metric":        string(a.Metric),
+		"operator":      string(a.Operator),
+		"threshold":     a.Threshold,
+		"value":         value,
+		"recurring":     a.Recurring,
+		"trigger_count": a.TriggerCount,
+		"note":          a.Note,
+		"timestamp":     at.Format(time.RFC3339Nano),
+	})
+	if err := s.bus.Publish(ctx, alertsChannel, payload); err != nil {
+		s.logger.WarnContext(ctx, "alert publish failed",
+			slog.String("alert_id", a.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if s.notifier != nil {
+		title := fmt.Sprintf("Alert: %s %s %.4f", a.Metric, a.Operator, a.Threshold)
+		msg := fmt.Sprintf("%s is %.4f (market %s, token %s)", a.Metric, value, a.MarketID, a.TokenID)
+		if a.Note != "" {
+			msg = a.Note + "\n" + msg
+		}
+		if err := s.notifier.Notify(ctx, "alert_triggered", title, msg); err != nil {
+			s.logger.WarnContext(ctx, "alert notify failed",
+				slog.String("alert_id", a.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+func (s *AlertService) publishChange(ctx context.Context, event string, a domain.Alert) {
+	if s.bus == nil {
+		return
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"event":     event,
+		"alert_id":  a.ID,
+		"market_id": a.MarketID,
+		"token_id":  a.TokenID,
+	})
+	if err := s.bus.Publish(ctx, alertsChannel, payload); err != nil {
+		s.logger.WarnContext(ctx, "alert change publish failed",
+			slog.String("event", event),
+			slog.String("error", err.Error()),
+		)
+	}
+}
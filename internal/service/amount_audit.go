@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// OrderAmountFetcher returns an order's amounts exactly as the venue reports
+// them (polymarket.ClobClient).
+type OrderAmountFetcher interface {
+	GetOrderAmounts(ctx context.Context, exchangeID string) (domain.VenueOrderAmounts, error)
+}
+
+// Discrepancy sources: which side of the comparison was recomputed.
+const (
+	AuditSourceStored = "stored" // stored fields recomputed from stored price and size
+	AuditSourceCLOB   = "clob"   // stored fields compared with the raw CLOB order
+)
+
+// AmountDiscrepancy is one value that does not match its recomputation.
+// Values are decimal strings; DiffUnits is the absolute difference in base
+// units (1e-6 USDC or shares).
+type AmountDiscrepancy struct {
+	Kind      string `json:"kind"` // "order" or "position"
+	ID        string `json:"id"`
+	Field     string `json:"field"`
+	Source    string `json:"source"`
+	Stored    string `json:"stored"`
+	Expected  string `json:"expected"`
+	DiffUnits int64  `json:"diff_units"`
+}
+
+// AmountAuditReport is the result of one amount audit.
+type AmountAuditReport struct {
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	Orders        int                 `json:"orders"`
+	OrdersOnCLOB  int                 `json:"orders_on_clob"` // orders compared with their CLOB copy
+	FetchErrors   int                 `json:"fetch_errors"`
+	Positions     int                 `json:"positions"`
+	Discrepancies []AmountDiscrepancy `json:"discrepancies"`
+}
+
+// AmountAuditor recomputes maker/taker amounts and fill values for stored
+// orders and positions with exact integer arithmetic, and compares them with
+// what was stored and with the raw CLOB orders, reporting every difference
+// beyond the tolerance.
+type AmountAuditor struct {
+	orders    domain.OrderStore
+	positions domain.PositionStore
+	venue     OrderAmountFetcher
+	incs      IncrementSource
+	tolerance int64
+	decimals  domain.CollateralDecimals
+	logger    *slog.Logger
+}
+
+// NewAmountAuditor creates an AmountAuditor. positions may be nil.
+func NewAmountAuditor(orders domain.OrderStore, positions domain.PositionStore, logger *slog.Logger) *AmountAuditor {
+	return &AmountAuditor{
+		orders:    orders,
+		positions: positions,
+		logger:    logger.With(slog.String("component", "amount_audit")),
+	}
+}
+
+// WithVenue enables comparing each accepted order with the CLOB's copy.
+func (a *AmountAuditor) WithVenue(venue OrderAmountFetcher) *AmountAuditor {
+	a.venue = venue
+	return a
+}
+
+// WithIncrements sets the per-token tick sizes that select each order's
+// rounding. Without it every token uses the finest rounding, as OrderService
+// does when increments are not looked up.
+func (a *AmountAuditor) WithIncrements(incs IncrementSource) *AmountAuditor {
+	a.incs = incs
+	return a
+}
+
+// WithTolerance sets the largest difference, in base units, that is not
+// reported. The default 0 reports every difference.
+func (a *AmountAuditor) WithTolerance(units int64) *AmountAuditor {
+	a.tolerance = units
+	return a
+}
+
+// WithCollateralDecimals sets the decimals of each market's collateral that
+// its orders' maker and taker amounts were signed at. Without it every
+// market uses domain.DefaultCollateralDecimals.
+func (a *AmountAuditor) WithCollateralDecimals(d domain.CollateralDecimals) *AmountAuditor {
+	a.decimals = d
+	return a
+}
+
+// Run audits orders created and positions opened in [from, to).
+func (a *AmountAuditor) Run(ctx context.Context, from, to time.Time) (AmountAuditReport, error) {
+	rep := AmountAuditReport{From: from, To: to, Discrepancies: []AmountDiscrepancy{}}
+
+	orders, err := a.orders.ListRange(ctx, from, to)
+	if err != nil {
+		return rep, fmt.Errorf("amount_audit: list orders: %w", err)
+	}
+	for _, o := range orders {
+		if err := ctx.Err(); err != nil {
+			return rep, err
+		}
+		rep.Orders++
+		a.auditStoredOrder(ctx, &rep, o)
+		if a.venue == nil || o.ExchangeID == "" {
+			continue
+		}
+		v, err := a.venue.GetOrderAmounts(ctx, o.ExchangeID)
+		if err != nil {
+			rep.FetchErrors++
+			a.logger.WarnContext(ctx, "fetch clob order failed",
+				slog.String("order_id", o.ID),
+				slog.String("exchange_id", o.ExchangeID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		rep.OrdersOnCLOB++
+		a.auditVenueOrder(ctx, &rep, o, v)
+	}
+
+	if a.positions != nil {
+		list, err := a.positions.List(ctx, domain.PositionFilter{}, domain.ListOpts{Since: &from, Until: &to})
+		if err != nil {
+			return rep, fmt.Errorf("amount_audit: list positions: %w", err)
+		}
+		for _, p := range list {
+			rep.Positions++
+			a.auditPosition(&rep, p)
+		}
+	}
+	return rep, nil
+}
+
+// amounts recomputes the maker and taker amounts of order o at priceTicks and
+// sizeUnits with the function OrderService signs with: the rounding of the
+// token's tick size at the market's collateral decimals. A price or size
+// that cannot be signed is reported as a discrepancy and ok is false.
+func (a *AmountAuditor) amounts(ctx context.Context, rep *AmountAuditReport, o domain.Order, field, source string, side domain.OrderSide, priceTicks, sizeUnits int64) (maker, taker *big.Int, ok bool) {
+	var tick float64
+	if a.incs != nil {
+		tick = a.incs.Increments(ctx, o.TokenID).TickSize
+	}
+	maker, taker, _, _, err := domain.RoundingForTick(tick).Amounts(side, priceTicks, sizeUnits, a.decimals.For(o.MarketID))
+	if err != nil {
+		a.add(rep, "order", o.ID, field, source, formatUnits(priceTicks)+" x "+formatUnits(sizeUnits), err.Error(), 0, true)
+		return nil, nil, false
+	}
+	return maker, taker, true
+}
+
+// auditStoredOrder checks the stored maker/taker amounts against the amounts
+// implied by the stored price and size.
+func (a *AmountAuditor) auditStoredOrder(ctx context.Context, rep *AmountAuditReport, o domain.Order) {
+	maker, taker, ok := a.amounts(ctx, rep, o, "amounts", AuditSourceStored, o.Side, o.PriceTicks, o.SizeUnits)
+	if !ok {
+		return
+	}
+	a.compareInt(rep, "order", o.ID, "maker_amount", AuditSourceStored, o.MakerAmount, maker)
+	a.compareInt(rep, "order", o.ID, "taker_amount", AuditSourceStored, o.TakerAmount, taker)
+}
+
+// auditVenueOrder checks the stored order against the CLOB's copy, and the
+// CLOB's amounts against those implied by its own price and size.
+func (a *AmountAuditor) auditVenueOrder(ctx context.Context, rep *AmountAuditReport, o domain.Order, v domain.VenueOrderAmounts) {
+	priceTicks, priceExact, priceOK := decimalUnits(v.Price)
+	sizeUnits, sizeExact, sizeOK := decimalUnits(v.OriginalSize)
+	matchedUnits, _, matchedOK := decimalUnits(v.SizeMatched)
+
+	if priceOK {
+		if !priceExact {
+			a.add(rep, "order", o.ID, "price", AuditSourceCLOB, v.Price, formatUnits(priceTicks), 0, true)
+		}
+		a.compareUnits(rep, "order", o.ID, "price", AuditSourceCLOB, o.PriceTicks, priceTicks)
+	}
+	if sizeOK {
+		if !sizeExact {
+			a.add(rep, "order", o.ID, "size", AuditSourceCLOB, v.OriginalSize, formatUnits(sizeUnits), 0, true)
+		}
+		a.compareUnits(rep, "order", o.ID, "size", AuditSourceCLOB, o.SizeUnits, sizeUnits)
+	}
+	if matchedOK {
+		a.compareUnits(rep, "order", o.ID, "filled_size", AuditSourceCLOB, floatUnits(o.FilledSize), matchedUnits)
+	}
+	if priceOK && sizeOK {
+		if maker, taker, ok := a.amounts(ctx, rep, o, "clob_amounts", AuditSourceCLOB, v.Side, priceTicks, sizeUnits); ok {
+			a.compareInt(rep, "order", o.ID, "clob_maker_amount", AuditSourceCLOB, parseBig(v.MakerAmount), maker)
+			a.compareInt(rep, "order", o.ID, "clob_taker_amount", AuditSourceCLOB, parseBig(v.TakerAmount), taker)
+		}
+	}
+	if priceOK && matchedOK {
+		// The stored fill value is what PnL uses: float size times float price.
+		exact := mulUnits(matchedUnits, priceTicks)
+		a.compareUnits(rep, "order", o.ID, "fill_value", AuditSourceCLOB, floatUnits(o.FilledSize*o.Price()), exact)
+	}
+}
+
+// auditPosition checks that a position's size is a whole number of base
+// units and that its float cost basis matches the exact product.
+func (a *AmountAuditor) auditPosition(rep *AmountAuditReport, p domain.Position) {
+	sizeUnits := floatUnits(p.Size)
+	if d := math.Abs(p.Size*domain.AmountScale - float64(sizeUnits)); d > 1e-3 {
+		a.add(rep, "position", p.ID, "size", AuditSourceStored,
+			strconv.FormatFloat(p.Size, 'f', -1, 64), formatUnits(sizeUnits), 0, true)
+	}
+	exact := mulUnits(sizeUnits, floatUnits(p.EntryPrice))
+	a.compareUnits(rep, "position", p.ID, "cost_basis", AuditSourceStored, floatUnits(p.Size*p.EntryPrice), exact)
+}
+
+func (a *AmountAuditor) compareInt(rep *AmountAuditReport, kind, id, field, source string, stored, expected *big.Int) {
+	if stored == nil {
+		a.add(rep, kind, id, field, source, "", expected.String(), expected.Int64(), true)
+		return
+	}
+	diff := new(big.Int).Sub(stored, expected)
+	diff.Abs(diff)
+	if !diff.IsInt64() {
+		a.add(rep, kind, id, field, source, stored.String(), expected.String(), math.MaxInt64, true)
+		return
+	}
+	a.add(rep, kind, id, field, source, stored.String(), expected.String(), diff.Int64(), false)
+}
+
+func (a *AmountAuditor) compareUnits(rep *AmountAuditReport, kind, id, field, source string, stored, expected int64) {
+	diff := stored - expected
+	if diff < 0 {
+		diff = -diff
+	}
+	a.add(rep, kind, id, field, source, formatUnits(stored), formatUnits(expected), diff, false)
+}
+
+// add records a discrepancy when force is set or diff exceeds the tolerance.
+func (a *AmountAuditor) add(rep *AmountAuditReport, kind, id, field, source, stored, expected string, diff int64, force bool) {
+	if !force && diff <= a.tolerance {
+		return
+	}
+	rep.Discrepancies = append(rep.Discrepancies, AmountDiscrepancy{
+		Kind:      kind,
+		ID:        id,
+		Field:     field,
+		Source:    source,
+		Stored:    stored,
+		Expected:  expected,
+		DiffUnits: diff,
+	})
+}
+
+// decimalUnits parses a decimal string into base units (x AmountScale). exact is
+// false when s has more than 6 decimals, in which case units is rounded down.
+func decimalUnits(s string) (units int64, exact, ok bool) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, false, false
+	}
+	r.Mul(r, new(big.Rat).SetInt64(domain.AmountScale))
+	q := new(big.Int).Quo(r.Num(), r.Denom())
+	if !q.IsInt64() {
+		return 0, false, false
+	}
+	return q.Int64(), r.IsInt(), true
+}
+
+// floatUnits converts a float amount to the nearest base unit.
+func floatUnits(f float64) int64 {
+	return int64(math.Round(f * domain.AmountScale))
+}
+
+// mulUnits multiplies two base-unit amounts, rounding down to a base unit.
+func mulUnits(a, b int64) int64 {
+	p := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	return p.Quo(p, big.NewInt(domain.AmountScale)).Int64()
+}
+
+// formatUnits renders base units as a 6-decimal string.
+func formatUnits(u int64) string {
+	return new(big.Rat).SetFrac64(u, domain.AmountScale).FloatString(6)
+}
+
+func parseBig(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil
+	}
+	return v
+}
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
@@ -18,17 +21,25 @@ type ArbConfig struct {
 	MaxUnhedgedNotional float64
 	KillSwitchLossUSD   float64
 	PerVenueFeeBps      map[string]float64
+	// OpportunityTTL expires an open opportunity not detected again for
+	// this long; 0 leaves it open until an update shows its edge is gone.
+	OpportunityTTL time.Duration
 }
 
 // ArbService evaluates and records arbitrage opportunities using the
 // net-edge model. All execution gates must pass before an opportunity
-// is considered actionable.
+// is considered actionable. Recorded opportunities are tracked by dedup key
+// (domain.ArbOpportunity.Key) while open, and each lifecycle transition
+// (open, executed, expired) is stored and published on "arb".
 type ArbService struct {
 	arb    domain.ArbStore
 	bus    domain.SignalBus
 	audit  domain.AuditStore
 	cfg    ArbConfig
 	logger *slog.Logger
+
+	mu   sync.Mutex
+	open map[string]domain.ArbOpportunity // dedup key -> open opportunity
 }
 
 // NewArbService creates an ArbService with all required dependencies.
@@ -45,6 +56,7 @@ func NewArbService(
 		audit:  audit,
 		cfg:    cfg,
 		logger: logger,
+		open:   make(map[string]domain.ArbOpportunity),
 	}
 }
 
@@ -116,27 +128,20 @@ func (s *ArbService) Evaluate(ctx context.Context, opp domain.ArbOpportunity) (b
 // Record persists an arbitrage opportunity to the store and publishes it
 // to the signal bus for downstream consumers.
 func (s *ArbService) Record(ctx context.Context, opp domain.ArbOpportunity) error {
+	opp.Status = domain.ArbOppOpen
+	opp.DedupKey = opp.Key()
+	if opp.DetectedAt.IsZero() {
+		opp.DetectedAt = time.Now().UTC()
+	}
+	opp.LastSeenAt = opp.DetectedAt
 	if err := s.arb.Insert(ctx, opp); err != nil {
 		return fmt.Errorf("arb_service: insert opportunity: %w", err)
 	}
+	s.mu.Lock()
+	s.open[opp.DedupKey] = opp
+	s.mu.Unlock()
 
-	// Publish to bus.
-	evt, _ := json.Marshal(map[string]any{
-		"event":          "arb_detected",
-		"opp_id":         opp.ID,
-		"poly_market":    opp.PolyMarketID,
-		"kalshi_market":  opp.KalshiMarketID,
-		"direction":      opp.Direction,
-		"net_edge_bps":   opp.NetEdgeBps,
-		"expected_pnl":   opp.ExpectedPnLUSD,
-		"gross_edge_bps": opp.GrossEdgeBps,
-	})
-	if pubErr := s.bus.Publish(ctx, "arb", evt); pubErr != nil {
-		s.logger.WarnContext(ctx, "arb_service: publish event failed",
-			slog.String("opp_id", opp.ID),
-			slog.String("error", pubErr.Error()),
-		)
-	}
+	s.publish(ctx, "arb_detected", opp, "")
 
 	// Audit log.
 	if auditErr := s.audit.Log(ctx, "arb_recorded", map[string]any{
@@ -161,6 +166,185 @@ func (s *ArbService) Record(ctx context.Context, opp domain.ArbOpportunity) erro
 	return nil
 }
 
+// Refresh reports whether opp is an opportunity already open, by dedup key,
+// and if so marks it seen again now. The detector records only
+// opportunities that are not.
+func (s *ArbService) Refresh(opp domain.ArbOpportunity) bool {
+	key := opp.Key()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	open, ok := s.open[key]
+	if !ok {
+		return false
+	}
+	open.LastSeenAt = time.Now().UTC()
+	s.open[key] = open
+	return true
+}
+
+// ExpireMissing expires the open opportunities on tokenID whose dedup keys
+// are not in live, the keys that passed evaluation on the latest update of
+// the token: their edge has disappeared.
+func (s *ArbService) ExpireMissing(ctx context.Context, tokenID string, live map[string]bool) {
+	var gone []domain.ArbOpportunity
+	s.mu.Lock()
+	for key, opp := range s.open {
+		if opp.PolyTokenID == tokenID && !live[key] {
+			gone = append(gone, opp)
+			delete(s.open, key)
+		}
+	}
+	s.mu.Unlock()
+	for _, opp := range gone {
+		s.expire(ctx, opp, "edge_gone")
+	}
+}
+
+// ExpireStale expires the open opportunities not detected again within
+// OpportunityTTL of now. It does nothing when the TTL is 0.
+func (s *ArbService) ExpireStale(ctx context.Context, now time.Time) {
+	if s.cfg.OpportunityTTL <= 0 {
+		return
+	}
+	var gone []domain.ArbOpportunity
+	s.mu.Lock()
+	for key, opp := range s.open {
+		if now.Sub(opp.LastSeenAt) > s.cfg.OpportunityTTL {
+			gone = append(gone, opp)
+			delete(s.open, key)
+		}
+	}
+	s.mu.Unlock()
+	for _, opp := range gone {
+		s.expire(ctx, opp, "ttl")
+	}
+}
+
+// expire stores and publishes an open opportunity's move to expired. An
+// opportunity already closed elsewhere (executed through another service)
+// is dropped silently.
+func (s *ArbService) expire(ctx context.Context, opp domain.ArbOpportunity, reason string) {
+	now := time.Now().UTC()
+	err := s.arb.Close(ctx, opp.ID, domain.ArbOpportunityClose{
+		Status:     domain.ArbOppExpired,
+		At:         now,
+		LastSeenAt: opp.LastSeenAt,
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		s.logger.WarnContext(ctx, "arb_service: expire opportunity failed",
+			slog.String("opp_id", opp.ID),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+	opp.Status = domain.ArbOppExpired
+	opp.ClosedAt = &now
+	opp.Duration = opp.LastSeenAt.Sub(opp.DetectedAt)
+	s.publish(ctx, "arb_expired", opp, reason)
+	s.logger.DebugContext(ctx, "arb_service: opportunity expired",
+		slog.String("opp_id", opp.ID),
+		slog.String("reason", reason),
+		slog.Duration("duration", opp.Duration),
+	)
+}
+
+// Executed closes the opportunity exec traded (exec.OpportunityID) as
+// executed, with the execution's realized edge and PnL next to the edge
+// expected at detection. An opportunity that expired while the execution
+// was in flight is still moved to executed. It returns an error wrapping
+// domain.ErrNotFound when the opportunity is unknown or already executed.
+func (s *ArbService) Executed(ctx context.Context, exec domain.ArbExecution) error {
+	if exec.OpportunityID == "" {
+		return fmt.Errorf("arb_service: execution %s has no opportunity: %w", exec.ID, domain.ErrNotFound)
+	}
+	now := time.Now().UTC()
+	var opp domain.ArbOpportunity
+	var wasOpen bool
+	s.mu.Lock()
+	for key, o := range s.open {
+		if o.ID == exec.OpportunityID {
+			opp, wasOpen = o, true
+			delete(s.open, key)
+			break
+		}
+	}
+	s.mu.Unlock()
+	lastSeen := now
+	if wasOpen {
+		lastSeen = opp.LastSeenAt
+	}
+	c := domain.ArbOpportunityClose{
+		Status:          domain.ArbOppExecuted,
+		At:              now,
+		LastSeenAt:      lastSeen,
+		ExecutionID:     exec.ID,
+		RealizedEdgeBps: exec.RealizedEdgeBps(),
+		RealizedPnLUSD:  exec.NetPnLUSD,
+	}
+	if err := s.arb.Close(ctx, exec.OpportunityID, c); err != nil {
+		return fmt.Errorf("arb_service: close opportunity %q: %w", exec.OpportunityID, err)
+	}
+	if wasOpen {
+		opp.Duration = opp.LastSeenAt.Sub(opp.DetectedAt)
+	} else {
+		opp = domain.ArbOpportunity{ID: exec.OpportunityID}
+	}
+	opp.Status = domain.ArbOppExecuted
+	opp.Executed = true
+	opp.ClosedAt = &now
+	opp.ExecutionID = exec.ID
+	opp.RealizedEdgeBps = c.RealizedEdgeBps
+	opp.RealizedPnLUSD = c.RealizedPnLUSD
+	s.publish(ctx, "arb_executed", opp, "")
+	s.logger.InfoContext(ctx, "arb_service: opportunity executed",
+		slog.String("opp_id", opp.ID),
+		slog.String("execution_id", exec.ID),
+		slog.Float64("net_edge_bps", opp.NetEdgeBps),
+		slog.Float64("realized_edge_bps", c.RealizedEdgeBps),
+	)
+	return nil
+}
+
+// publish sends a lifecycle transition of opp to "arb". Fields of an
+// opportunity not held in memory (executed through another service) are
+// left zero.
+func (s *ArbService) publish(ctx context.Context, event string, opp domain.ArbOpportunity, reason string) {
+	payload := map[string]any{
+		"event":          event,
+		"opp_id":         opp.ID,
+		"status":         opp.Status,
+		"dedup_key":      opp.DedupKey,
+		"poly_market":    opp.PolyMarketID,
+		"kalshi_market":  opp.KalshiMarketID,
+		"direction":      opp.Direction,
+		"net_edge_bps":   opp.NetEdgeBps,
+		"expected_pnl":   opp.ExpectedPnLUSD,
+		"gross_edge_bps": opp.GrossEdgeBps,
+	}
+	if opp.Status != domain.ArbOppOpen {
+		payload["duration_ms"] = opp.Duration.Milliseconds()
+	}
+	if opp.Status == domain.ArbOppExecuted {
+		payload["execution_id"] = opp.ExecutionID
+		payload["realized_edge_bps"] = opp.RealizedEdgeBps
+		payload["realized_pnl"] = opp.RealizedPnLUSD
+	}
+	if reason != "" {
+		payload["reason"] = reason
+	}
+	evt, _ := json.Marshal(payload)
+	if err := s.bus.Publish(ctx, "arb", evt); err != nil {
+		s.logger.WarnContext(ctx, "arb_service: publish event failed",
+			slog.String("opp_id", opp.ID),
+			slog.String("event", event),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
 // MarkExecuted updates an arbitrage opportunity as executed.
 func (s *ArbService) MarkExecuted(ctx context.Context, id string) error {
 	if err := s.arb.MarkExecuted(ctx, id); err != nil {
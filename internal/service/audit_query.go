@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// AuditQueryService reads the audit log for operators, newest first, paged
+// with opaque cursors.
+type AuditQueryService struct {
+	store domain.AuditStore
+}
+
+// NewAuditQueryService creates an AuditQueryService.
+func NewAuditQueryService(store domain.AuditStore) *AuditQueryService {
+	return &AuditQueryService{store: store}
+}
+
+// List returns up to limit entries matching f, newest first, resuming after
+// cursor when it is set, and the cursor of the next page (empty on the last
+// page). Invalid arguments wrap domain.ErrInvalidAuditQuery.
+func (s *AuditQueryService) List(ctx context.Context, f domain.AuditFilter, cursor string, limit int) ([]domain.AuditEntry, string, error) {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return nil, "", fmt.Errorf("%w: from must be before to", domain.ErrInvalidAuditQuery)
+	}
+	switch {
+	case limit <= 0:
+		limit = defaultAuditLimit
+	case limit > maxAuditLimit:
+		limit = maxAuditLimit
+	}
+	q := domain.AuditQuery{AuditFilter: f, Limit: limit}
+	if cursor != "" {
+		pos, err := decodeAuditCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		q.Before = &pos
+	}
+
+	entries, err := s.store.Query(ctx, q)
+	if err != nil {
+		return nil, "", fmt.Errorf("audit: %w", err)
+	}
+	var next string
+	if len(entries) == limit {
+		last := entries[len(entries)-1]
+		next = encodeAuditCursor(domain.AuditPosition{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return entries, next, nil
+}
+
+// encodeAuditCursor renders a position as "unixnano|id" in URL-safe base64.
+func encodeAuditCursor(p domain.AuditPosition) string {
+	raw := strconv.FormatInt(p.CreatedAt.UnixNano(), 10) + "|" + strconv.FormatInt(p.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeAuditCursor(cursor string) (domain.AuditPosition, error) {
+	malformed := fmt.Errorf("%w: malformed cursor", domain.ErrInvalidAuditQuery)
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return domain.AuditPosition{}, malformed
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return domain.AuditPosition{}, malformed
+	}
+	ns, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return domain.AuditPosition{}, malformed
+	}
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return domain.AuditPosition{}, malformed
+	}
+	return domain.AuditPosition{CreatedAt: time.Unix(0, ns).UTC(), ID: n}, nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// WalletReader reads the wallet's collateral, outcome-token balances and
+// exchange approvals on chain (chain.Client).
+type WalletReader interface {
+	WalletBalances(ctx context.Context, owner string) (domain.WalletBalances, error)
+	TokenBalance(ctx context.Context, owner, tokenID string) (float64, error)
+}
+
+// Exchange names in domain.WalletBalances, as reported by chain.Client.
+const (
+	exchangeCTF     = "ctf_exchange"
+	exchangeNegRisk = "neg_risk_ctf_exchange"
+)
+
+// BalanceGuardConfig configures a BalanceGuard.
+type BalanceGuardConfig struct {
+	Wallet string
+	// MaxAge is how long a wallet reading is reused before it is read again.
+	// Defaults to 15s.
+	MaxAge time.Duration
+}
+
+// BalanceGuard refuses orders the wallet cannot settle on chain: a buy
+// needs enough USDC.e and a USDC.e allowance for the market's exchange, a
+// sell needs enough outcome tokens and the exchange approved as their
+// operator. The CLOB accepts such orders and they only fail at match time.
+// The wallet reading is cached for MaxAge and USDC committed to buys since is
+// deducted from it, so a burst of buys cannot spend the same balance twice.
+// A failed RPC read lets the order through with a warning rather than
+// halting trading on a flaky endpoint.
+type BalanceGuard struct {
+	reader  WalletReader
+	markets domain.MarketStore // optional; picks the neg-risk exchange
+	cfg     BalanceGuardConfig
+	logger  *slog.Logger
+
+	mu        sync.Mutex
+	last      domain.WalletBalances
+	committed float64 // USDC of buys passed since last was read
+}
+
+// NewBalanceGuard creates a BalanceGuard.
+func NewBalanceGuard(reader WalletReader, cfg BalanceGuardConfig, logger *slog.Logger) *BalanceGuard {
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 15 * time.Second
+	}
+	return &BalanceGuard{
+		reader: reader,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "balance_guard")),
+	}
+}
+
+// WithMarkets sets the market store used to tell neg-risk markets, whose
+// orders settle through the neg-risk exchange. Without it every order is
+// checked against the standard CTF exchange.
+func (g *BalanceGuard) WithMarkets(markets domain.MarketStore) *BalanceGuard {
+	g.markets = markets
+	return g
+}
+
+// Balances returns a fresh on-chain reading of the wallet and caches it.
+func (g *BalanceGuard) Balances(ctx context.Context) (domain.WalletBalances, error) {
+	b, err := g.reader.WalletBalances(ctx, g.cfg.Wallet)
+	if err != nil {
+		return domain.WalletBalances{}, err
+	}
+	g.mu.Lock()
+	g.last, g.committed = b, 0
+	g.mu.Unlock()
+	return b, nil
+}
+
+// CheckFunds returns an error wrapping domain.ErrInsufficientFunds when the
+// wallet cannot settle sig. A buy that passes is committed against the
+// cached balance.
+func (g *BalanceGuard) CheckFunds(ctx context.Context, sig domain.TradeSignal) error {
+	return g.check(ctx, sig, true)
+}
+
+// HasFunds is CheckFunds for an order that will not be placed (a preview):
+// a buy that passes commits nothing.
+func (g *BalanceGuard) HasFunds(ctx context.Context, sig domain.TradeSignal) error {
+	return g.check(ctx, sig, false)
+}
+
+func (g *BalanceGuard) check(ctx context.Context, sig domain.TradeSignal, commit bool) error {
+	b, err := g.cached(ctx)
+	if err != nil {
+		g.logger.WarnContext(ctx, "balance guard: wallet read failed, order not checked",
+			slog.String("signal_id", sig.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+	name := g.exchange(ctx, sig)
+	ex, _ := b.Exchange(name)
+
+	if sig.Side == domain.OrderSideSell {
+		if !ex.CTFApproved {
+			return fmt.Errorf("%w: %s not approved to move outcome tokens", domain.ErrInsufficientFunds, name)
+		}
+		held, err := g.reader.TokenBalance(ctx, g.cfg.Wallet, sig.TokenID)
+		if err != nil {
+			g.logger.WarnContext(ctx, "balance guard: token balance read failed, order not checked",
+				slog.String("signal_id", sig.ID),
+				slog.String("token_id", sig.TokenID),
+				slog.String("error", err.Error()),
+			)
+			return nil
+		}
+		if held < sig.Size() {
+			return fmt.Errorf("%w: selling %.2f shares of %s, holding %.2f",
+				domain.ErrInsufficientFunds, sig.Size(), sig.TokenID, held)
+		}
+		return nil
+	}
+
+	need := sig.Price() * sig.Size()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if avail := b.USDC - g.committed; avail < need {
+		return fmt.Errorf("%w: buy needs %.2f USDC, wallet has %.2f", domain.ErrInsufficientFunds, need, avail)
+	}
+	if avail := ex.USDCAllowance - g.committed; avail < need {
+		return fmt.Errorf("%w: buy needs %.2f USDC, %s allowance is %.2f", domain.ErrInsufficientFunds, need, name, avail)
+	}
+	if commit {
+		g.committed += need
+	}
+	return nil
+}
+
+// cached returns the last wallet reading, reading again once it is older
+// than MaxAge.
+func (g *BalanceGuard) cached(ctx context.Context) (domain.WalletBalances, error) {
+	g.mu.Lock()
+	b := g.last
+	g.mu.Unlock()
+	if !b.CheckedAt.IsZero() && time.Since(b.CheckedAt) < g.cfg.MaxAge {
+		return b, nil
+	}
+	return g.Balances(ctx)
+}
+
+// exchange returns the name of the exchange sig's market settles through.
+func (g *BalanceGuard) exchange(ctx context.Context, sig domain.TradeSignal) string {
+	if g.markets == nil {
+		return exchangeCTF
+	}
+	m, err := g.markets.GetByTokenID(ctx, sig.TokenID)
+	if err != nil || !m.NegRisk {
+		return exchangeCTF
+	}
+	return exchangeNegRisk
+}
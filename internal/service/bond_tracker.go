@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -12,10 +13,14 @@ import (
 
 // BondTracker tracks high-probability bond positions from entry to resolution:
 // polls Gamma for market resolution, updates BondPosition status and PnL, publishes events.
+// With WithFills it also books the bond strategy's fills: buys open or add to
+// a bond position, sells (early exits) reduce it and credit realized yield.
 type BondTracker struct {
 	bonds   domain.BondPositionStore
 	gamma   *polymarket.GammaClient
 	bus     domain.SignalBus
+	orders  domain.OrderStore  // optional, for fills
+	markets domain.MarketStore // optional, for fills
 	pollDur time.Duration
 	logger  *slog.Logger
 }
@@ -40,8 +45,26 @@ func NewBondTracker(
 	}
 }
 
+// WithFills makes the tracker book fills of orders placed by the bond
+// strategy, read from the "orders" channel. markets supplies the expected
+// expiry of new positions.
+func (b *BondTracker) WithFills(orders domain.OrderStore, markets domain.MarketStore) *BondTracker {
+	b.orders = orders
+	b.markets = markets
+	return b
+}
+
 // Run polls open bond positions and updates status on resolution. Call in a goroutine.
 func (b *BondTracker) Run(ctx context.Context) error {
+	var fills <-chan []byte
+	if b.orders != nil && b.bus != nil {
+		ch, err := b.bus.Subscribe(ctx, "orders")
+		if err != nil {
+			return fmt.Errorf("bond_tracker: subscribe orders: %w", err)
+		}
+		fills = ch
+	}
+
 	ticker := time.NewTicker(b.pollDur)
 	defer ticker.Stop()
 	for {
@@ -52,8 +75,140 @@ func (b *BondTracker) Run(ctx context.Context) error {
 			if err := b.checkResolutions(ctx); err != nil {
 				b.logger.ErrorContext(ctx, "bond tracker check resolutions failed", slog.String("error", err.Error()))
 			}
+		case data, ok := <-fills:
+			if !ok {
+				fills = nil
+				continue
+			}
+			if err := b.handleOrderEvent(ctx, data); err != nil {
+				b.logger.ErrorContext(ctx, "bond tracker book fill failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// bondDustShares is the size below which an exited position counts as closed.
+const bondDustShares = 0.01
+
+// handleOrderEvent books an order_filled event of a bond strategy order.
+func (b *BondTracker) handleOrderEvent(ctx context.Context, data []byte) error {
+	var ev struct {
+		Event   string  `json:"event"`
+		OrderID string  `json:"order_id"`
+		Price   float64 `json:"price"`
+		Size    float64 `json:"size"`
+	}
+	if err := json.Unmarshal(data, &ev); err != nil || ev.Event != "order_filled" || ev.Size <= 0 {
+		return nil
+	}
+	order, err := b.orders.GetByID(ctx, ev.OrderID)
+	if err != nil {
+		return fmt.Errorf("bond_tracker: get order %s: %w", ev.OrderID, err)
+	}
+	if order.Strategy != "bond" {
+		return nil
+	}
+	if order.Side == domain.OrderSideBuy {
+		return b.bookEntry(ctx, order, ev.Price, ev.Size)
+	}
+	return b.bookExit(ctx, order, ev.Price, ev.Size)
+}
+
+// bookEntry opens the bond position of a buy order, keyed by the order ID,
+// or adds a further fill of the same order to it.
+func (b *BondTracker) bookEntry(ctx context.Context, order domain.Order, price, size float64) error {
+	pos, err := b.bonds.GetByID(ctx, order.ID)
+	if err == nil {
+		total := pos.Size + size
+		pos.EntryPrice = (pos.EntryPrice*pos.Size + price*size) / total
+		pos.Size = total
+		return b.bonds.Update(ctx, pos)
+	}
+
+	now := time.Now().UTC()
+	pos = domain.BondPosition{
+		ID:         order.ID,
+		MarketID:   order.MarketID,
+		TokenID:    order.TokenID,
+		EntryPrice: price,
+		Size:       size,
+		Status:     domain.BondOpen,
+		CreatedAt:  now,
+	}
+	if b.markets != nil {
+		if mkt, err := b.markets.GetByID(ctx, order.MarketID); err == nil && mkt.ClosedAt != nil {
+			pos.ExpectedExpiry = *mkt.ClosedAt
+		}
+	}
+	if pos.ExpectedExpiry.IsZero() {
+		pos.ExpectedExpiry = now
+	}
+	if days := pos.ExpectedExpiry.Sub(now).Hours() / 24; days > 0 && price > 0 {
+		pos.ExpectedAPR = (1 - price) / price * (365 / days)
+	}
+	if err := b.bonds.Create(ctx, pos); err != nil {
+		return err
+	}
+	b.logger.InfoContext(ctx, "bond position opened",
+		slog.String("id", pos.ID),
+		slog.String("market_id", pos.MarketID),
+		slog.Float64("entry_price", pos.EntryPrice),
+		slog.Float64("size", pos.Size),
+		slog.Float64("expected_apr", pos.ExpectedAPR),
+	)
+	return nil
+}
+
+// bookExit applies a sell fill to the open bond positions in the order's
+// token, oldest first, crediting (price - entry) per share sold as realized
+// yield. A position sold down to dust is closed as exited.
+func (b *BondTracker) bookExit(ctx context.Context, order domain.Order, price, size float64) error {
+	open, err := b.bonds.GetOpen(ctx)
+	if err != nil {
+		return err
+	}
+	for _, pos := range open {
+		if size <= 0 {
+			break
+		}
+		if pos.TokenID != order.TokenID || pos.Status != domain.BondOpen {
+			continue
+		}
+		sold := min(size, pos.Remaining())
+		size -= sold
+		pos.ExitPrice = (pos.ExitPrice*pos.ExitedSize + price*sold) / (pos.ExitedSize + sold)
+		pos.ExitedSize += sold
+		pos.RealizedPnL += (price - pos.EntryPrice) * sold
+		if pos.Remaining() < bondDustShares {
+			now := time.Now().UTC()
+			pos.Status = domain.BondExited
+			pos.ResolvedAt = &now
+		}
+		if err := b.bonds.Update(ctx, pos); err != nil {
+			return err
+		}
+		if pos.Status != domain.BondExited {
+			continue
+		}
+		b.logger.InfoContext(ctx, "bond position exited early",
+			slog.String("id", pos.ID),
+			slog.String("market_id", pos.MarketID),
+			slog.Float64("exit_price", pos.ExitPrice),
+			slog.Float64("realized_pnl", pos.RealizedPnL),
+		)
+		if b.bus != nil {
+			payload, _ := json.Marshal(map[string]any{
+				"event":        "bond_resolved",
+				"position_id":  pos.ID,
+				"market_id":    pos.MarketID,
+				"status":       string(pos.Status),
+				"exit_price":   pos.ExitPrice,
+				"realized_pnl": pos.RealizedPnL,
+			})
+			_ = b.bus.Publish(ctx, "bond_resolved", payload)
 		}
 	}
+	return nil
 }
 
 func (b *BondTracker) checkResolutions(ctx context.Context) error {
@@ -75,12 +230,13 @@ func (b *BondTracker) checkResolutions(ctx context.Context) error {
 		}
 		now := time.Now().UTC()
 		pos.ResolvedAt = &now
+		// Shares sold by an early exit already booked their yield.
 		if res.YesWon {
 			pos.Status = domain.BondResolvedWin
-			pos.RealizedPnL = pos.Size*(1.0-pos.EntryPrice) - pos.EntryPrice*0 // payout 1 per share, cost entry
+			pos.RealizedPnL += pos.Remaining() * (1.0 - pos.EntryPrice) // payout 1 per share, cost entry
 		} else {
 			pos.Status = domain.BondResolvedLoss
-			pos.RealizedPnL = -pos.Size * pos.EntryPrice
+			pos.RealizedPnL -= pos.Remaining() * pos.EntryPrice
 		}
 		if err := b.bonds.Update(ctx, pos); err != nil {
 			b.logger.ErrorContext(ctx, "bond position update failed", slog.String("id", pos.ID), slog.String("error", err.Error()))
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// BuilderTradeSource pages through the trades attributed to our builder
+// credentials (polymarket.ClobClient).
+type BuilderTradeSource interface {
+	GetBuilderTrades(ctx context.Context, after time.Time, cursor string) ([]domain.BuilderTrade, string, error)
+}
+
+// builderRewardsMaxPages bounds the pages read per summary.
+const builderRewardsMaxPages = 200
+
+// BuilderRewardsService sums the builder-program fees credited for our
+// attributed orders. Summaries are cached per start time for CacheTTL, so
+// polling the endpoint does not page through the venue every time.
+type BuilderRewardsService struct {
+	source   BuilderTradeSource
+	cacheTTL time.Duration
+	logger   *slog.Logger
+
+	mu    sync.Mutex
+	cache map[time.Time]domain.BuilderRewards
+}
+
+// NewBuilderRewardsService creates a BuilderRewardsService. cacheTTL
+// defaults to 1m.
+func NewBuilderRewardsService(source BuilderTradeSource, cacheTTL time.Duration, logger *slog.Logger) *BuilderRewardsService {
+	if cacheTTL <= 0 {
+		cacheTTL = time.Minute
+	}
+	return &BuilderRewardsService{
+		source:   source,
+		cacheTTL: cacheTTL,
+		logger:   logger.With(slog.String("component", "builder_rewards")),
+		cache:    make(map[time.Time]domain.BuilderRewards),
+	}
+}
+
+// BuilderRewards returns the trades, volume and fees attributed to the
+// builder since the given time, in total and per market.
+func (s *BuilderRewardsService) BuilderRewards(ctx context.Context, since time.Time) (domain.BuilderRewards, error) {
+	since = since.UTC()
+	s.mu.Lock()
+	cached, ok := s.cache[since]
+	s.mu.Unlock()
+	if ok && time.Since(cached.UpdatedAt) < s.cacheTTL {
+		return cached, nil
+	}
+
+	out := domain.BuilderRewards{Since: since}
+	markets := make(map[string]*domain.BuilderMarketRewards)
+	seen := make(map[string]bool)
+	cursor := ""
+	for page := 0; ; page++ {
+		if page == builderRewardsMaxPages {
+			s.logger.WarnContext(ctx, "builder trades truncated",
+				slog.Int("pages", page),
+				slog.Time("since", since),
+			)
+			break
+		}
+		trades, next, err := s.source.GetBuilderTrades(ctx, since, cursor)
+		if err != nil {
+			return domain.BuilderRewards{}, fmt.Errorf("builder rewards: %w", err)
+		}
+		for _, t := range trades {
+			if seen[t.ID] || t.Status == "FAILED" || t.MatchedAt.Before(since) {
+				continue
+			}
+			seen[t.ID] = true
+			m, ok := markets[t.MarketID]
+			if !ok {
+				m = &domain.BuilderMarketRewards{MarketID: t.MarketID}
+				markets[t.MarketID] = m
+			}
+			m.Trades++
+			m.VolumeUSDC += t.SizeUSDC
+			m.FeesUSDC += t.FeeUSDC
+			out.Trades++
+			out.VolumeUSDC += t.SizeUSDC
+			out.FeesUSDC += t.FeeUSDC
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	out.Markets = make([]domain.BuilderMarketRewards, 0, len(markets))
+	for _, m := range markets {
+		out.Markets = append(out.Markets, *m)
+	}
+	sort.Slice(out.Markets, func(i, j int) bool {
+		if out.Markets[i].FeesUSDC != out.Markets[j].FeesUSDC {
+			return out.Markets[i].FeesUSDC > out.Markets[j].FeesUSDC
+		}
+		return out.Markets[i].MarketID < out.Markets[j].MarketID
+	})
+	out.UpdatedAt = time.Now().UTC()
+
+	s.mu.Lock()
+	for k, v := range s.cache {
+		if time.Since(v.UpdatedAt) >= s.cacheTTL {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[since] = out
+	s.mu.Unlock()
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// BookFetcher reads a token's orderbook over REST (polymarket.ClobClient).
+type BookFetcher interface {
+	GetOrderbook(ctx context.Context, tokenID string) (domain.OrderbookSnapshot, error)
+}
+
+// CacheWarmer seeds the orderbook and price caches from REST snapshots at
+// startup, so strategies have books to work with before the WebSocket feed
+// delivers its first snapshot of each asset.
+type CacheWarmer struct {
+	books       BookFetcher
+	bookCache   domain.OrderbookCache
+	priceCache  domain.PriceCache
+	concurrency int
+	timeout     time.Duration
+	logger      *slog.Logger
+}
+
+// NewCacheWarmer creates a CacheWarmer fetching up to concurrency books at a
+// time (default 4) and giving up after timeout (default 30s).
+func NewCacheWarmer(books BookFetcher, bookCache domain.OrderbookCache, priceCache domain.PriceCache, concurrency int, timeout time.Duration, logger *slog.Logger) *CacheWarmer {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &CacheWarmer{
+		books:       books,
+		bookCache:   bookCache,
+		priceCache:  priceCache,
+		concurrency: concurrency,
+		timeout:     timeout,
+		logger:      logger.With(slog.String("component", "cache_warmer")),
+	}
+}
+
+// Warm fetches the book of every asset and writes it and its mid to the
+// caches, returning how many assets were seeded. Assets whose book cannot be
+// fetched or stored are logged and skipped; they fill in from the WebSocket
+// feed. Warm returns when every asset is done, the timeout passes or ctx is
+// cancelled.
+func (w *CacheWarmer) Warm(ctx context.Context, assetIDs []string) int {
+	if len(assetIDs) == 0 {
+		return 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	start := time.Now()
+
+	var (
+		seeded, failed atomic.Int64
+		wg             sync.WaitGroup
+	)
+	ids := make(chan string)
+	for range min(w.concurrency, len(assetIDs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				if err := w.seed(ctx, id); err != nil {
+					failed.Add(1)
+					if ctx.Err() == nil {
+						w.logger.DebugContext(ctx, "cache warm-up: asset skipped",
+							slog.String("asset_id", id),
+							slog.String("error", err.Error()),
+						)
+					}
+					continue
+				}
+				seeded.Add(1)
+			}
+		}()
+	}
+feed:
+	for _, id := range assetIDs {
+		select {
+		case ids <- id:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(ids)
+	wg.Wait()
+
+	attrs := []any{
+		slog.Int("assets", len(assetIDs)),
+		slog.Int64("seeded", seeded.Load()),
+		slog.Int64("failed", failed.Load()),
+		slog.Duration("elapsed", time.Since(start)),
+	}
+	if n := int64(len(assetIDs)); seeded.Load() < n {
+		w.logger.WarnContext(ctx, "cache warm-up incomplete", attrs...)
+	} else {
+		w.logger.InfoContext(ctx, "cache warm-up done", attrs...)
+	}
+	return int(seeded.Load())
+}
+
+func (w *CacheWarmer) seed(ctx context.Context, assetID string) error {
+	snap, err := w.books.GetOrderbook(ctx, assetID)
+	if err != nil {
+		return err
+	}
+	if err := w.bookCache.SetSnapshot(ctx, assetID, snap); err != nil {
+		return fmt.Errorf("cache_warmer: set snapshot for %q: %w", assetID, err)
+	}
+	// A one-sided book has no mid; leave the price unset rather than 0.
+	if snap.MidPrice > 0 {
+		if err := w.priceCache.SetPrice(ctx, assetID, snap.MidPrice, snap.Timestamp); err != nil {
+			return fmt.Errorf("cache_warmer: set price for %q: %w", assetID, err)
+		}
+	}
+	return nil
+}
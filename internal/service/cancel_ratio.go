@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	// cancelRatioAlertInterval is the minimum time between alerts for one market.
+	cancelRatioAlertInterval = 15 * time.Minute
+	// cancelRatioPruneInterval is how often markets without activity in the
+	// last hour are dropped.
+	cancelRatioPruneInterval = 5 * time.Minute
+)
+
+// CancelRatioConfig sets the venue limit the tracker warns against.
+type CancelRatioConfig struct {
+	Limit      float64 // cancels per fill over a rolling hour; 0 disables alerts
+	WarnAt     float64 // fraction of Limit at which a market is near the limit
+	MinCancels int     // cancels a market needs before it can be near the limit
+}
+
+// marketCancels is the rolling order activity of one market.
+type marketCancels struct {
+	placed    minuteWindow
+	cancelled minuteWindow
+	filled    minuteWindow
+	alertedAt time.Time
+}
+
+// CancelRatioTracker counts our placements, cancellations and fills per market
+// from the "orders" stream and keeps each market's rolling one-hour cancel
+// ratio (cancels per fill), so requote logic can back off and an operator is
+// alerted before the venue's cancel limits are reached.
+type CancelRatioTracker struct {
+	bus      domain.SignalBus
+	audit    domain.AuditStore // optional
+	notifier AlertNotifier     // optional
+	cfg      CancelRatioConfig
+	logger   *slog.Logger
+
+	mu      sync.Mutex
+	markets map[string]*marketCancels
+}
+
+// NewCancelRatioTracker creates a CancelRatioTracker. audit and notifier may
+// be nil.
+func NewCancelRatioTracker(bus domain.SignalBus, audit domain.AuditStore, notifier AlertNotifier, cfg CancelRatioConfig, logger *slog.Logger) *CancelRatioTracker {
+	return &CancelRatioTracker{
+		bus:      bus,
+		audit:    audit,
+		notifier: notifier,
+		cfg:      cfg,
+		logger:   logger.With(slog.String("component", "cancel_ratio")),
+		markets:  make(map[string]*marketCancels),
+	}
+}
+
+// Run consumes order events until ctx is cancelled. Call in a goroutine.
+func (t *CancelRatioTracker) Run(ctx context.Context) error {
+	orders, err := t.bus.Subscribe(ctx, "orders")
+	if err != nil {
+		return fmt.Errorf("cancel_ratio: subscribe orders: %w", err)
+	}
+	ticker := time.NewTicker(cancelRatioPruneInterval)
+	defer ticker.Stop()
+
+	t.logger.InfoContext(ctx, "cancel ratio tracker started", slog.Float64("limit", t.cfg.Limit))
+	defer t.logger.InfoContext(ctx, "cancel ratio tracker stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			t.prune(time.Now())
+		case data, ok := <-orders:
+			if !ok {
+				return nil
+			}
+			var ev struct {
+				Event  string `json:"event"`
+				Market string `json:"market"`
+			}
+			if err := json.Unmarshal(data, &ev); err != nil || ev.Market == "" {
+				continue
+			}
+			t.record(ctx, ev.Event, ev.Market, time.Now())
+		}
+	}
+}
+
+// record counts one order event against its market and alerts when the
+// market's cancel ratio has just reached the warning level.
+func (t *CancelRatioTracker) record(ctx context.Context, event, marketID string, now time.Time) {
+	switch event {
+	case "order_placed", "order_cancelled", "order_filled":
+	default:
+		return
+	}
+	t.mu.Lock()
+	m, ok := t.markets[marketID]
+	if !ok {
+		m = &marketCancels{}
+		t.markets[marketID] = m
+	}
+	switch event {
+	case "order_placed":
+		m.placed.add(now, 1)
+	case "order_filled":
+		m.filled.add(now, 1)
+	case "order_cancelled":
+		m.cancelled.add(now, 1)
+	}
+	r := t.ratioLocked(marketID, m, now)
+	alert := event == "order_cancelled" && r.NearLimit && now.Sub(m.alertedAt) >= cancelRatioAlertInterval
+	if alert {
+		m.alertedAt = now
+	}
+	t.mu.Unlock()
+
+	if alert {
+		t.alert(ctx, r)
+	}
+}
+
+// ratioLocked computes a market's figures for the hour ending at now.
+// Called with t.mu held.
+func (t *CancelRatioTracker) ratioLocked(marketID string, m *marketCancels, now time.Time) domain.MarketCancelRatio {
+	r := domain.MarketCancelRatio{
+		MarketID:  marketID,
+		Placed:    int(m.placed.total(now)),
+		Cancelled: int(m.cancelled.total(now)),
+		Filled:    int(m.filled.total(now)),
+	}
+	r.Ratio = float64(r.Cancelled) / float64(max(r.Filled, 1))
+	r.NearLimit = t.cfg.Limit > 0 &&
+		r.Cancelled >= t.cfg.MinCancels &&
+		r.Ratio >= t.cfg.Limit*t.cfg.WarnAt
+	return r
+}
+
+// NearCancelLimit reports whether marketID's cancel ratio is at the warning
+// level. It implements strategy.CancelRatioReader.
+func (t *CancelRatioTracker) NearCancelLimit(marketID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.markets[marketID]
+	if !ok {
+		return false
+	}
+	return t.ratioLocked(marketID, m, time.Now()).NearLimit
+}
+
+// Limit returns the configured cancels-per-fill limit.
+func (t *CancelRatioTracker) Limit() float64 {
+	return t.cfg.Limit
+}
+
+// Snapshot returns every market with order activity in the last hour,
+// highest cancel ratio first.
+func (t *CancelRatioTracker) Snapshot() []domain.MarketCancelRatio {
+	now := time.Now()
+	t.mu.Lock()
+	out := make([]domain.MarketCancelRatio, 0, len(t.markets))
+	for id, m := range t.markets {
+		r := t.ratioLocked(id, m, now)
+		if r.Placed == 0 && r.Cancelled == 0 && r.Filled == 0 {
+			continue
+		}
+		out = append(out, r)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Ratio != out[j].Ratio {
+			return out[i].Ratio > out[j].Ratio
+		}
+		return out[i].MarketID < out[j].MarketID
+	})
+	return out
+}
+
+// prune drops markets with no activity in the hour ending at now.
+func (t *CancelRatioTracker) prune(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, m := range t.markets {
+		if m.placed.total(now) == 0 && m.cancelled.total(now) == 0 && m.filled.total(now) == 0 &&
+			now.Sub(m.alertedAt) >= cancelRatioAlertInterval {
+			delete(t.markets, id)
+		}
+	}
+}
+
+func (t *CancelRatioTracker) alert(ctx context.Context, r domain.MarketCancelRatio) {
+	t.logger.WarnContext(ctx, "cancel ratio approaching venue limit",
+		slog.String("market", r.MarketID),
+		slog.Float64("ratio", r.Ratio),
+		slog.Float64("limit", t.cfg.Limit),
+		slog.Int("cancelled", r.Cancelled),
+		slog.Int("filled", r.Filled),
+	)
+	detail := map[string]any{
+		"market":    r.MarketID,
+		"ratio":     r.Ratio,
+		"limit":     t.cfg.Limit,
+		"placed":    r.Placed,
+		"cancelled": r.Cancelled,
+		"filled":    r.Filled,
+	}
+	if t.audit != nil {
+		if err := t.audit.Log(ctx, "cancel_ratio_warning", detail); err != nil {
+			t.logger.WarnContext(ctx, "cancel_ratio: audit log failed", slog.String("error", err.Error()))
+		}
+	}
+	detail["event"] = "cancel_ratio_warning"
+	payload, _ := json.Marshal(detail)
+	if err := t.bus.Publish(ctx, riskChannel, payload); err != nil {
+		t.logger.WarnContext(ctx, "cancel_ratio: publish event failed", slog.String("error", err.Error()))
+	}
+	if t.notifier != nil {
+		title := "Cancel ratio near limit: " + r.MarketID
+		msg := fmt.Sprintf("%d cancels / %d fills in the last hour (ratio %.1f, limit %.0f)",
+			r.Cancelled, r.Filled, r.Ratio, t.cfg.Limit)
+		if err := t.notifier.Notify(ctx, "cancel_ratio_warning", title, msg); err != nil {
+			t.logger.WarnContext(ctx, "cancel_ratio: notify failed", slog.String("error", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// CandidateConfig tunes the CandidateService.
+type CandidateConfig struct {
+	// MaxCandidates caps the pending candidates held; the oldest are
+	// dropped beyond it.
+	MaxCandidates int
+	// MaxAge drops candidates this old, as well as any past their expiry.
+	MaxAge time.Duration
+	// FreshnessHalfLife is the age at which a candidate's freshness halves.
+	FreshnessHalfLife time.Duration
+	// DefaultEdgeBps is the edge of signals without edge metadata.
+	DefaultEdgeBps float64
+	// FlushInterval is how often new candidates are written to the store.
+	FlushInterval time.Duration
+}
+
+// CandidateService holds the signals of manual-trading mode
+// (strategy.auto_execute = false) instead of dropping them: it persists them
+// to the signal store, ranks them by expected edge, freshness and liquidity
+// when listed, and routes one to the executor on request.
+type CandidateService struct {
+	store  domain.SignalStore    // optional
+	books  domain.OrderbookCache // optional
+	route  chan<- domain.TradeSignal
+	cfg    CandidateConfig
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	cands   map[string]*domain.Candidate // by signal ID
+	unsaved []domain.TradeSignal
+}
+
+// NewCandidateService creates a CandidateService. store and books may be
+// nil: candidates are then kept in memory only, and ranked without
+// liquidity.
+func NewCandidateService(store domain.SignalStore, books domain.OrderbookCache, cfg CandidateConfig, logger *slog.Logger) *CandidateService {
+	if cfg.MaxCandidates <= 0 {
+		cfg.MaxCandidates = 500
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 15 * time.Minute
+	}
+	if cfg.FreshnessHalfLife <= 0 {
+		cfg.FreshnessHalfLife = 2 * time.Minute
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	return &CandidateService{
+		store:  store,
+		books:  books,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "candidates")),
+		cands:  make(map[string]*domain.Candidate),
+	}
+}
+
+// WithRoute sends executed candidates to ch, the executor's signal input.
+// Without a route candidates are listed but cannot be executed.
+func (s *CandidateService) WithRoute(ch chan<- domain.TradeSignal) *CandidateService {
+	s.route = ch
+	return s
+}
+
+// CanExecute reports whether candidates can be routed to an executor.
+func (s *CandidateService) CanExecute() bool {
+	return s.route != nil
+}
+
+// Run loads the pending candidates left in the store, then holds every
+// signal received on signals until ctx is cancelled or signals is closed.
+func (s *CandidateService) Run(ctx context.Context, signals <-chan domain.TradeSignal) error {
+	s.load(ctx)
+	s.logger.Info("candidate service started", slog.Bool("executable", s.CanExecute()))
+	defer s.logger.Info("candidate service stopped")
+
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			s.flush(flushCtx)
+			cancel()
+			return ctx.Err()
+		case sig, ok := <-signals:
+			if !ok {
+				s.flush(ctx)
+				return nil
+			}
+			s.add(sig, true)
+		case <-ticker.C:
+			s.prune(time.Now())
+			s.flush(ctx)
+		}
+	}
+}
+
+// load restores the pending, unexpired signals recorded within MaxAge.
+func (s *CandidateService) load(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+	now := time.Now()
+	records, err := s.store.List(ctx, domain.SignalFilter{
+		Status: domain.SignalStatusPending,
+		From:   now.Add(-s.cfg.MaxAge),
+		Limit:  s.cfg.MaxCandidates,
+	})
+	if err != nil {
+		s.logger.Warn("load pending candidates failed", slog.String("error", err.Error()))
+		return
+	}
+	for _, rec := range records {
+		s.add(rec.Signal, false)
+	}
+	s.prune(now)
+	if len(records) > 0 {
+		s.logger.Info("pending candidates restored", slog.Int("candidates", len(records)))
+	}
+}
+
+// add holds sig as a pending candidate, queueing it for the store when
+// save is set.
+func (s *CandidateService) add(sig domain.TradeSignal, save bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cands[sig.ID]; ok {
+		return
+	}
+	s.cands[sig.ID] = &domain.Candidate{Signal: sig, Status: domain.SignalStatusPending}
+	if save && s.store != nil {
+		s.unsaved = append(s.unsaved, sig)
+	}
+}
+
+// prune drops expired and aged-out candidates, then the oldest pending ones
+// beyond MaxCandidates.
+func (s *CandidateService) prune(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []*domain.Candidate
+	for id, c := range s.cands {
+		sig := c.Signal
+		if now.Sub(sig.CreatedAt) > s.cfg.MaxAge || (!sig.ExpiresAt.IsZero() && now.After(sig.ExpiresAt)) {
+			delete(s.cands, id)
+			continue
+		}
+		if c.Status == domain.SignalStatusPending {
+			pending = append(pending, c)
+		}
+	}
+	if over := len(pending) - s.cfg.MaxCandidates; over > 0 {
+		sort.Slice(pending, func(i, j int) bool { return pending[i].Signal.CreatedAt.Before(pending[j].Signal.CreatedAt) })
+		for _, c := range pending[:over] {
+			delete(s.cands, c.Signal.ID)
+		}
+	}
+}
+
+// flush writes the candidates received since the last flush. A failed write
+// is logged; the candidates stay held in memory.
+func (s *CandidateService) flush(ctx context.Context) {
+	s.mu.Lock()
+	batch := s.unsaved
+	s.unsaved = nil
+	s.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+	if err := s.store.InsertBatch(ctx, batch); err != nil {
+		s.logger.Warn("persist candidates failed",
+			slog.Int("candidates", len(batch)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// Ranked returns up to limit pending candidates of the given strategies (all
+// when empty), best first, scored as of now. limit <= 0 returns all.
+func (s *CandidateService) Ranked(ctx context.Context, sources []string, limit int) []domain.Candidate {
+	now := time.Now()
+	s.mu.Lock()
+	var out []domain.Candidate
+	for _, c := range s.cands {
+		sig := c.Signal
+		if c.Status != domain.SignalStatusPending {
+			continue
+		}
+		if !sig.ExpiresAt.IsZero() && now.After(sig.ExpiresAt) {
+			continue
+		}
+		if len(sources) > 0 && !slices.Contains(sources, sig.Source) {
+			continue
+		}
+		out = append(out, *c)
+	}
+	s.mu.Unlock()
+
+	for i := range out {
+		s.score(ctx, &out[i], now)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score == out[j].Score {
+			return out[i].Signal.CreatedAt.After(out[j].Signal.CreatedAt)
+		}
+		return out[i].Score > out[j].Score
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	for i := range out {
+		out[i].Rank = i + 1
+	}
+	return out
+}
+
+// score ranks c at now: its expected edge, scaled by freshness and by a
+// liquidity factor from 0.5 (nothing fillable at the signal price, or no
+// cached book) to 1 (the full size fillable).
+func (s *CandidateService) score(ctx context.Context, c *domain.Candidate, now time.Time) {
+	sig := c.Signal
+	c.EdgeBps = s.cfg.DefaultEdgeBps
+	for _, key := range []string{"edge_bps", "net_edge_bps"} {
+		if v, err := strconv.ParseFloat(sig.Metadata[key], 64); err == nil {
+			c.EdgeBps = v
+			break
+		}
+	}
+	age := max(now.Sub(sig.CreatedAt), 0)
+	c.Freshness = math.Pow(0.5, age.Seconds()/s.cfg.FreshnessHalfLife.Seconds())
+	c.Liquidity, c.DepthUSD = s.liquidity(ctx, sig)
+	c.Score = c.EdgeBps * c.Freshness * (0.5 + 0.5*c.Liquidity)
+}
+
+// liquidity returns the share of sig's size the opposite book side fills at
+// sig's price or better, and that depth's notional.
+func (s *CandidateService) liquidity(ctx context.Context, sig domain.TradeSignal) (float64, float64) {
+	if s.books == nil || sig.TokenID == "" || sig.SizeUnits <= 0 {
+		return 0, 0
+	}
+	book, err := s.books.GetSnapshot(ctx, sig.TokenID)
+	if err != nil {
+		return 0, 0
+	}
+	price := sig.Price()
+	levels, fills := book.Asks, func(p float64) bool { return p <= price }
+	if sig.Side == domain.OrderSideSell {
+		levels, fills = book.Bids, func(p float64) bool { return p >= price }
+	}
+	var size, notional float64
+	for _, l := range levels {
+		if fills(l.Price) {
+			size += l.Size
+			notional += l.Price * l.Size
+		}
+	}
+	return min(1, size/sig.Size()), notional
+}
+
+// Execute routes the pending candidate id to the executor. It is marked
+// routed in the store first, so it is not restored as pending after a
+// restart; the executor's resolution then replaces that status. Returns
+// domain.ErrNotFound for an unknown candidate and domain.ErrCandidateClosed
+// for one already routed or expired.
+func (s *CandidateService) Execute(ctx context.Context, id string) (domain.Candidate, error) {
+	if s.route == nil {
+		return domain.Candidate{}, fmt.Errorf("candidates: no executor")
+	}
+	now := time.Now()
+	s.mu.Lock()
+	c, ok := s.cands[id]
+	if !ok {
+		s.mu.Unlock()
+		return domain.Candidate{}, domain.ErrNotFound
+	}
+	if c.Status != domain.SignalStatusPending || (!c.Signal.ExpiresAt.IsZero() && now.After(c.Signal.ExpiresAt)) {
+		s.mu.Unlock()
+		return domain.Candidate{}, domain.ErrCandidateClosed
+	}
+	c.Status = domain.SignalStatusRouted
+	c.RoutedAt = now
+	routed := *c
+	// Queueing latency is measured from the routing, not the emission.
+	routed.Signal.EmittedAt = now
+	s.mu.Unlock()
+
+	if s.store != nil {
+		err := s.store.Resolve(ctx, []domain.SignalResolution{{
+			Signal: routed.Signal,
+			Status: domain.SignalStatusRouted,
+			Reason: "manual execute",
+			At:     now,
+		}})
+		if err != nil {
+			s.reopen(id)
+			return domain.Candidate{}, fmt.Errorf("candidates: mark %s routed: %w", id, err)
+		}
+	}
+
+	select {
+	case s.route <- routed.Signal:
+	case <-ctx.Done():
+		s.reopen(id)
+		return domain.Candidate{}, ctx.Err()
+	}
+	s.logger.Info("candidate routed to executor",
+		slog.String("signal_id", id),
+		slog.String("source", routed.Signal.Source),
+		slog.String("market_id", routed.Signal.MarketID),
+	)
+	s.score(ctx, &routed, now)
+	return routed, nil
+}
+
+// reopen returns a candidate whose routing failed to pending. A status
+// already written to the store is left; the candidate is still held here.
+func (s *CandidateService) reopen(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.cands[id]; ok {
+		c.Status = domain.SignalStatusPending
+		c.RoutedAt = time.Time{}
+	}
+}
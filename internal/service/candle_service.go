@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// candlePruneInterval is how often candles past the retention are deleted.
+const candlePruneInterval = time.Hour
+
+// CandleConfig configures a CandleService.
+type CandleConfig struct {
+	FlushInterval time.Duration // how often in-progress bars are written
+	Retention     time.Duration // candles older than this are deleted; 0 keeps them
+}
+
+// candleBar is the part of an asset's current minute not yet written.
+type candleBar struct {
+	domain.Candle
+	hasMid bool // OHLC follows mids once one is seen in the bar
+}
+
+// CandleService records one-minute OHLCV candles per asset from the mid
+// prices on "prices" and the last-trade prints on "trades", and serves them
+// aggregated to coarser intervals. The PriceTracker keeps only a few minutes
+// of prices in memory; candles give the dashboard and backtests history.
+//
+// Bars are written in parts every flush interval and merged by the store, so
+// a restart loses at most one flush interval of data.
+type CandleService struct {
+	bus    domain.SignalBus
+	store  domain.CandleStore
+	cfg    CandleConfig
+	logger *slog.Logger
+
+	bars map[string]*candleBar
+}
+
+// NewCandleService creates a CandleService.
+func NewCandleService(bus domain.SignalBus, store domain.CandleStore, cfg CandleConfig, logger *slog.Logger) *CandleService {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	return &CandleService{
+		bus:    bus,
+		store:  store,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "candles")),
+		bars:   make(map[string]*candleBar),
+	}
+}
+
+// candleEvent is the part of a "prices" or "trades" event a candle needs.
+type candleEvent struct {
+	Event     string  `json:"event"`
+	AssetID   string  `json:"asset_id"`
+	MidPrice  float64 `json:"mid_price"`
+	Price     float64 `json:"price"`
+	Size      float64 `json:"size"`
+	Timestamp string  `json:"timestamp"`
+}
+
+// Run records candles until ctx is cancelled. Bars in progress are flushed
+// on shutdown. Call in a goroutine.
+func (s *CandleService) Run(ctx context.Context) error {
+	prices, err := s.bus.Subscribe(ctx, "prices")
+	if err != nil {
+		return fmt.Errorf("candles: subscribe prices: %w", err)
+	}
+	trades, err := s.bus.Subscribe(ctx, "trades")
+	if err != nil {
+		return fmt.Errorf("candles: subscribe trades: %w", err)
+	}
+	flush := time.NewTicker(s.cfg.FlushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(candlePruneInterval)
+	defer prune.Stop()
+
+	s.logger.InfoContext(ctx, "candle recorder started", slog.Duration("flush_interval", s.cfg.FlushInterval))
+	defer s.logger.InfoContext(ctx, "candle recorder stopped")
+	s.prune(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			s.flush(flushCtx)
+			cancel()
+			return ctx.Err()
+		case <-flush.C:
+			s.flush(ctx)
+		case <-prune.C:
+			s.prune(ctx)
+		case data, ok := <-prices:
+			if !ok {
+				s.flush(ctx)
+				return nil
+			}
+			if ev, ok := parseCandleEvent(data); ok && ev.MidPrice > 0 {
+				s.bar(ctx, ev).addMid(ev.MidPrice)
+			}
+		case data, ok := <-trades:
+			if !ok {
+				s.flush(ctx)
+				return nil
+			}
+			if ev, ok := parseCandleEvent(data); ok && ev.Event == "last_trade_price" && ev.Price > 0 {
+				s.bar(ctx, ev).addTrade(ev.Price, ev.Size)
+			}
+		}
+	}
+}
+
+func parseCandleEvent(data []byte) (candleEvent, bool) {
+	var ev candleEvent
+	if err := json.Unmarshal(data, &ev); err != nil || ev.AssetID == "" {
+		return candleEvent{}, false
+	}
+	return ev, true
+}
+
+// bar returns the bar ev falls into, first writing out the asset's bar for
+// another minute.
+func (s *CandleService) bar(ctx context.Context, ev candleEvent) *candleBar {
+	ts := time.Now()
+	if t, err := time.Parse(time.RFC3339Nano, ev.Timestamp); err == nil {
+		ts = t
+	}
+	start := ts.UTC().Truncate(domain.CandleBase)
+
+	b, ok := s.bars[ev.AssetID]
+	if ok && b.Start.Equal(start) {
+		return b
+	}
+	if ok && b.Open > 0 {
+		// A late event for an earlier minute also starts a new part; the
+		// store merges it into that minute's bar.
+		s.write(ctx, []domain.Candle{b.Candle})
+	}
+	b = &candleBar{Candle: domain.Candle{AssetID: ev.AssetID, Start: start}}
+	s.bars[ev.AssetID] = b
+	return b
+}
+
+// addMid moves the bar's OHLC to a mid price. The first mid of a bar replaces
+// prices taken from trades.
+func (b *candleBar) addMid(mid float64) {
+	if !b.hasMid {
+		b.hasMid = true
+		b.Open, b.High, b.Low = mid, mid, mid
+	}
+	b.High = max(b.High, mid)
+	b.Low = min(b.Low, mid)
+	b.Close = mid
+}
+
+// addTrade counts a trade print, and prices the bar from it while no mid has
+// been seen.
+func (b *candleBar) addTrade(price, size float64) {
+	b.Volume += size
+	b.Trades++
+	if b.hasMid {
+		return
+	}
+	if b.Open == 0 {
+		b.Open, b.High, b.Low = price, price, price
+	}
+	b.High = max(b.High, price)
+	b.Low = min(b.Low, price)
+	b.Close = price
+}
+
+// flush writes every bar in progress and starts their next parts empty.
+func (s *CandleService) flush(ctx context.Context) {
+	if len(s.bars) == 0 {
+		return
+	}
+	candles := make([]domain.Candle, 0, len(s.bars))
+	for _, b := range s.bars {
+		if b.Open > 0 {
+			candles = append(candles, b.Candle)
+		}
+	}
+	clear(s.bars)
+	s.write(ctx, candles)
+}
+
+func (s *CandleService) write(ctx context.Context, candles []domain.Candle) {
+	if err := s.store.UpsertBatch(ctx, candles); err != nil {
+		s.logger.WarnContext(ctx, "candles: flush failed",
+			slog.Int("candles", len(candles)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// prune deletes candles older than the retention.
+func (s *CandleService) prune(ctx context.Context) {
+	if s.cfg.Retention <= 0 {
+		return
+	}
+	deleted, err := s.store.DeleteBefore(ctx, time.Now().Add(-s.cfg.Retention))
+	if err != nil {
+		s.logger.WarnContext(ctx, "candles: prune failed", slog.String("error", err.Error()))
+		return
+	}
+	if deleted > 0 {
+		s.logger.InfoContext(ctx, "candles: pruned old candles", slog.Int64("deleted", deleted))
+	}
+}
+
+// Candles returns assetID's candles of the named interval (see
+// domain.CandleIntervals) starting in [from, to), oldest first, at most
+// limit of the most recent. from is aligned down to the interval so the first
+// bar is complete.
+func (s *CandleService) Candles(ctx context.Context, assetID, interval string, from, to time.Time, limit int) ([]domain.Candle, error) {
+	d, ok := domain.CandleIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("candles: unknown interval %q: %w", interval, domain.ErrInvalidCandles)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("candles: from must be before to: %w", domain.ErrInvalidCandles)
+	}
+	return s.store.ListRange(ctx, assetID, d, from.UTC().Truncate(d), to, limit)
+}
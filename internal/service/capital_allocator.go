@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// StrategyPerformanceSource reports per-strategy results over recent days
+// (PerformanceService).
+type StrategyPerformanceSource interface {
+	Strategies(ctx context.Context, days int, strategy string) (domain.PerformanceWindow, error)
+}
+
+// CapitalAllocatorConfig configures a CapitalAllocator.
+type CapitalAllocatorConfig struct {
+	Wallet string
+	// BudgetsUSD is the configured budget per strategy. Strategies not listed
+	// get DefaultBudgetUSD; 0 leaves them uncapped.
+	BudgetsUSD       map[string]float64
+	DefaultBudgetUSD float64
+
+	// RebalanceInterval is how often budgets are reweighted by performance;
+	// 0 keeps the configured budgets.
+	RebalanceInterval time.Duration
+	// PerformanceDays is the window the Sharpe used for reweighting covers.
+	PerformanceDays int
+	// MaxShift caps how far, as a fraction, a rebalanced budget moves from
+	// its configured value.
+	MaxShift float64
+}
+
+// CapitalAllocator splits capital between strategies. A strategy's usage is
+// the unfilled notional of its resting buy orders plus its open positions at
+// entry price; entries that would take it over its budget are refused.
+// Budgets can be reweighted periodically towards strategies with the better
+// recent Sharpe, keeping the total.
+type CapitalAllocator struct {
+	orders    domain.OrderStore
+	positions domain.PositionStore
+	perf      StrategyPerformanceSource // optional; required for rebalancing
+	cfg       CapitalAllocatorConfig
+	logger    *slog.Logger
+
+	mu           sync.RWMutex
+	factors      map[string]float64 // strategy -> budget factor from the last rebalance
+	sharpes      map[string]float64 // strategy -> Sharpe the factor came from
+	rebalancedAt *time.Time
+}
+
+// NewCapitalAllocator creates a CapitalAllocator for cfg.Wallet.
+func NewCapitalAllocator(orders domain.OrderStore, positions domain.PositionStore, cfg CapitalAllocatorConfig, logger *slog.Logger) *CapitalAllocator {
+	if cfg.PerformanceDays <= 0 {
+		cfg.PerformanceDays = 7
+	}
+	return &CapitalAllocator{
+		orders:    orders,
+		positions: positions,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "capital_allocator")),
+		factors:   make(map[string]float64),
+		sharpes:   make(map[string]float64),
+	}
+}
+
+// WithPerformance sets the performance source budgets are reweighted by.
+// Without it, Run keeps the configured budgets.
+func (c *CapitalAllocator) WithPerformance(perf StrategyPerformanceSource) *CapitalAllocator {
+	c.perf = perf
+	return c
+}
+
+// Run rebalances budgets every RebalanceInterval until ctx is cancelled.
+// It returns at once when rebalancing is off. Call in a goroutine.
+func (c *CapitalAllocator) Run(ctx context.Context) error {
+	if c.cfg.RebalanceInterval <= 0 || c.perf == nil {
+		return nil
+	}
+	ticker := time.NewTicker(c.cfg.RebalanceInterval)
+	defer ticker.Stop()
+
+	c.logger.InfoContext(ctx, "capital allocator started",
+		slog.Duration("rebalance_interval", c.cfg.RebalanceInterval),
+		slog.Int("performance_days", c.cfg.PerformanceDays),
+	)
+	defer c.logger.InfoContext(ctx, "capital allocator stopped")
+
+	c.rebalanceLogged(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			c.rebalanceLogged(ctx)
+		}
+	}
+}
+
+func (c *CapitalAllocator) rebalanceLogged(ctx context.Context) {
+	if err := c.Rebalance(ctx); err != nil && ctx.Err() == nil {
+		c.logger.WarnContext(ctx, "capital allocator: rebalance failed", slog.String("error", err.Error()))
+	}
+}
+
+// Rebalance reweights the configured budgets by each strategy's Sharpe over
+// the last PerformanceDays. A strategy's weight is its budget scaled by
+// 1 + MaxShift*tanh(Sharpe); weights are then normalized to the configured
+// total and the resulting factor clamped to [1-MaxShift, 1+MaxShift].
+// Strategies without results count as Sharpe 0.
+func (c *CapitalAllocator) Rebalance(ctx context.Context) error {
+	if c.perf == nil {
+		return nil
+	}
+	window, err := c.perf.Strategies(ctx, c.cfg.PerformanceDays, "")
+	if err != nil {
+		return fmt.Errorf("capital_allocator: strategy performance: %w", err)
+	}
+	sharpes := make(map[string]float64, len(window.Strategies))
+	for _, p := range window.Strategies {
+		sharpes[p.Strategy] = p.Sharpe
+	}
+
+	var total, weighted float64
+	tilts := make(map[string]float64, len(c.cfg.BudgetsUSD))
+	for name, base := range c.cfg.BudgetsUSD {
+		if base <= 0 {
+			continue
+		}
+		tilt := 1 + c.cfg.MaxShift*math.Tanh(sharpes[name])
+		tilts[name] = tilt
+		total += base
+		weighted += base * tilt
+	}
+	if weighted <= 0 {
+		return nil
+	}
+	scale := total / weighted
+
+	factors := make(map[string]float64, len(tilts))
+	for name, tilt := range tilts {
+		factors[name] = max(1-c.cfg.MaxShift, min(1+c.cfg.MaxShift, tilt*scale))
+	}
+	now := time.Now().UTC()
+	c.mu.Lock()
+	c.factors = factors
+	c.sharpes = sharpes
+	c.rebalancedAt = &now
+	c.mu.Unlock()
+
+	for name, f := range factors {
+		c.logger.InfoContext(ctx, "capital allocator: budget rebalanced",
+			slog.String("strategy", name),
+			slog.Float64("sharpe", sharpes[name]),
+			slog.Float64("factor", f),
+			slog.Float64("budget_usd", c.cfg.BudgetsUSD[name]*f),
+		)
+	}
+	return nil
+}
+
+// budget returns a strategy's current budget; ok is false when it is
+// uncapped. A strategy listed with budget 0 may not enter at all.
+func (c *CapitalAllocator) budget(strategy string) (alloc domain.StrategyAllocation, ok bool) {
+	alloc = domain.StrategyAllocation{Strategy: strategy, Factor: 1}
+	base, listed := c.cfg.BudgetsUSD[strategy]
+	if !listed {
+		if c.cfg.DefaultBudgetUSD <= 0 {
+			alloc.Uncapped = true
+			return alloc, false
+		}
+		base = c.cfg.DefaultBudgetUSD
+	}
+	c.mu.RLock()
+	if f, found := c.factors[strategy]; found {
+		alloc.Factor = f
+	}
+	alloc.Sharpe = c.sharpes[strategy]
+	c.mu.RUnlock()
+	alloc.BaseBudgetUSD = base
+	alloc.BudgetUSD = base * alloc.Factor
+	return alloc, true
+}
+
+// usage returns the committed notional per strategy: resting buy orders'
+// unfilled notional and open positions at entry price.
+func (c *CapitalAllocator) usage(ctx context.Context) (orders, positions map[string]float64, err error) {
+	open, err := c.orders.ListOpen(ctx, c.cfg.Wallet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("capital_allocator: list open orders: %w", err)
+	}
+	held, err := c.positions.GetOpen(ctx, c.cfg.Wallet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("capital_allocator: get open positions: %w", err)
+	}
+	orders = make(map[string]float64)
+	for _, o := range open {
+		if o.Side == domain.OrderSideBuy {
+			orders[o.Strategy] += o.Remaining() * o.Price()
+		}
+	}
+	positions = make(map[string]float64)
+	for _, p := range held {
+		positions[p.Strategy] += p.Size * p.EntryPrice
+	}
+	return orders, positions, nil
+}
+
+// Allocation returns a strategy's budget and usage.
+func (c *CapitalAllocator) Allocation(ctx context.Context, strategy string) (domain.StrategyAllocation, error) {
+	orders, positions, err := c.usage(ctx)
+	if err != nil {
+		return domain.StrategyAllocation{}, err
+	}
+	alloc, _ := c.budget(strategy)
+	alloc.OpenOrdersUSD = orders[strategy]
+	alloc.PositionsUSD = positions[strategy]
+	return alloc, nil
+}
+
+// Check returns an error when an entry of notional USD would take strategy
+// over its budget. Uncapped strategies always pass.
+func (c *CapitalAllocator) Check(ctx context.Context, strategy string, notional float64) error {
+	if _, ok := c.budget(strategy); !ok {
+		return nil
+	}
+	alloc, err := c.Allocation(ctx, strategy)
+	if err != nil {
+		return err
+	}
+	if alloc.UsedUSD()+notional > alloc.BudgetUSD {
+		return fmt.Errorf("capital_allocator: %s budget exhausted: %.2f used + %.2f > %.2f",
+			strategy, alloc.UsedUSD(), notional, alloc.BudgetUSD)
+	}
+	return nil
+}
+
+// Report returns the allocation of every strategy with a budget or
+// committed capital.
+func (c *CapitalAllocator) Report(ctx context.Context) (domain.CapitalReport, error) {
+	orders, positions, err := c.usage(ctx)
+	if err != nil {
+		return domain.CapitalReport{}, err
+	}
+	names := make(map[string]struct{}, len(c.cfg.BudgetsUSD)+len(orders)+len(positions))
+	for name := range c.cfg.BudgetsUSD {
+		names[name] = struct{}{}
+	}
+	for name := range orders {
+		names[name] = struct{}{}
+	}
+	for name := range positions {
+		names[name] = struct{}{}
+	}
+
+	var report domain.CapitalReport
+	for name := range names {
+		alloc, _ := c.budget(name)
+		alloc.OpenOrdersUSD = orders[name]
+		alloc.PositionsUSD = positions[name]
+		report.TotalBudgetUSD += alloc.BudgetUSD
+		report.TotalUsedUSD += alloc.UsedUSD()
+		report.Strategies = append(report.Strategies, alloc)
+	}
+	sort.Slice(report.Strategies, func(i, j int) bool {
+		return report.Strategies[i].Strategy < report.Strategies[j].Strategy
+	})
+	c.mu.RLock()
+	report.RebalancedAt = c.rebalancedAt
+	c.mu.RUnlock()
+	return report, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// FeeScheduleFetcher fetches a market's venue fee schedule
+// (polymarket.GammaClient).
+type FeeScheduleFetcher interface {
+	GetFeeSchedule(ctx context.Context, marketID string) (domain.FeeSchedule, error)
+}
+
+// FeeModelConfig holds the fee model settings.
+type FeeModelConfig struct {
+	// RefreshInterval is how long a fetched schedule is cached; a failed
+	// fetch serves the default schedule for as long. Defaults to 10m.
+	RefreshInterval time.Duration
+	// DefaultMakerBps and DefaultTakerBps are used when the venue schedule
+	// cannot be fetched.
+	DefaultMakerBps float64
+	DefaultTakerBps float64
+	// RebateBps is the builder-program rebate credited on every fill.
+	RebateBps float64
+}
+
+type cachedFeeSchedule struct {
+	schedule domain.FeeSchedule
+	fetched  time.Time
+}
+
+// FeeModel serves per-market maker and taker fees, net of builder rebates,
+// to arbitrage edge estimates and pre-trade risk checks. Schedules are
+// fetched from the venue and cached per market; lookups never fail and fall
+// back to the configured defaults.
+type FeeModel struct {
+	fetcher FeeScheduleFetcher
+	markets domain.MarketStore // optional; resolves token IDs to markets
+	cfg     FeeModelConfig
+	logger  *slog.Logger
+
+	mu        sync.Mutex
+	schedules map[string]cachedFeeSchedule // market or token ID -> schedule
+}
+
+// NewFeeModel creates a FeeModel. fetcher may be nil, in which case every
+// market gets the default schedule.
+func NewFeeModel(fetcher FeeScheduleFetcher, cfg FeeModelConfig, logger *slog.Logger) *FeeModel {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = 10 * time.Minute
+	}
+	return &FeeModel{
+		fetcher:   fetcher,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "fee_model")),
+		schedules: make(map[string]cachedFeeSchedule),
+	}
+}
+
+// WithMarkets sets the market store used to resolve token IDs, so a lookup by
+// token ID finds its market's schedule.
+func (f *FeeModel) WithMarkets(markets domain.MarketStore) *FeeModel {
+	f.markets = markets
+	return f
+}
+
+// Schedule returns the fee schedule of the market identified by id, a market
+// or token ID, with the builder rebate applied.
+func (f *FeeModel) Schedule(ctx context.Context, id string) domain.FeeSchedule {
+	f.mu.Lock()
+	c, ok := f.schedules[id]
+	f.mu.Unlock()
+	if ok && time.Since(c.fetched) < f.cfg.RefreshInterval {
+		return c.schedule
+	}
+
+	sched := f.fetch(ctx, id)
+	f.mu.Lock()
+	f.schedules[id] = cachedFeeSchedule{schedule: sched, fetched: time.Now()}
+	f.mu.Unlock()
+	return sched
+}
+
+// MakerFeeBps returns the effective maker fee of id's market, in bps.
+func (f *FeeModel) MakerFeeBps(ctx context.Context, id string) float64 {
+	return f.Schedule(ctx, id).EffectiveMakerBps()
+}
+
+// TakerFeeBps returns the effective taker fee of id's market, in bps.
+func (f *FeeModel) TakerFeeBps(ctx context.Context, id string) float64 {
+	return f.Schedule(ctx, id).EffectiveTakerBps()
+}
+
+func (f *FeeModel) fetch(ctx context.Context, id string) domain.FeeSchedule {
+	sched := domain.FeeSchedule{
+		MarketID:    id,
+		MakerFeeBps: f.cfg.DefaultMakerBps,
+		TakerFeeBps: f.cfg.DefaultTakerBps,
+		Source:      domain.FeeSourceDefault,
+		FetchedAt:   time.Now().UTC(),
+	}
+	if f.fetcher != nil {
+		marketID := id
+		if f.markets != nil {
+			if m, err := f.markets.GetByTokenID(ctx, id); err == nil && m.ID != "" {
+				marketID = m.ID
+			}
+		}
+		venue, err := f.fetcher.GetFeeSchedule(ctx, marketID)
+		if err != nil {
+			f.logger.WarnContext(ctx, "fee model: fetch failed, using default schedule",
+				slog.String("market_id", marketID),
+				slog.String("error", err.Error()),
+			)
+		} else {
+			sched = venue
+		}
+	}
+	sched.RebateBps = f.cfg.RebateBps
+	return sched
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// fillSeenTTL is how long applied fills are remembered for de-duplication.
+// The user channel repeats a trade on each settlement status change and
+// replays recent events after a reconnect.
+const fillSeenTTL = time.Hour
+
+// FillApplier applies an order fill to the wallet's positions
+// (PositionService).
+type FillApplier interface {
+	ApplyFill(ctx context.Context, order domain.Order, price, size float64) error
+}
+
+// FillTracker applies exchange-reported fills and order lifecycle events to
+// the order and position stores, so the bot learns about fills as they happen
+// instead of by polling.
+type FillTracker struct {
+	orders    domain.OrderStore
+	positions FillApplier
+	bus       domain.SignalBus
+	audit     domain.AuditStore
+	latency   FillLatencyRecorder // optional
+	logger    *slog.Logger
+
+	mu   sync.Mutex
+	seen map[string]time.Time // trade ID + order ID -> applied at
+}
+
+// NewFillTracker creates a FillTracker.
+func NewFillTracker(
+	orders domain.OrderStore,
+	positions FillApplier,
+	bus domain.SignalBus,
+	audit domain.AuditStore,
+	logger *slog.Logger,
+) *FillTracker {
+	return &FillTracker{
+		orders:    orders,
+		positions: positions,
+		bus:       bus,
+		audit:     audit,
+		logger:    logger.With(slog.String("component", "fill_tracker")),
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// FillLatencyRecorder receives when an order's taker fill was reported
+// (LatencyTracker).
+type FillLatencyRecorder interface {
+	Filled(id string, at time.Time)
+}
+
+// WithLatency reports the first taker fill of each order to l. Maker fills
+// are not reported: a resting order's wait measures the market, not us.
+func (t *FillTracker) WithLatency(l FillLatencyRecorder) *FillTracker {
+	t.latency = l
+	return t
+}
+
+// HandleFill applies one fill: the order's filled size and status are
+// updated and the position in its token is opened, grown or reduced. Only
+// the initial MATCHED report is applied; later settlement statuses are
+// ignored except FAILED, which is logged and audited for manual review.
+func (t *FillTracker) HandleFill(ctx context.Context, ev domain.OrderFillEvent) error {
+	reported := time.Now()
+	switch strings.ToUpper(ev.Status) {
+	case "", "MATCHED":
+	case "FAILED":
+		t.logger.ErrorContext(ctx, "fill_tracker: trade failed on-chain",
+			slog.String("trade_id", ev.TradeID),
+			slog.String("exchange_id", ev.ExchangeID),
+		)
+		t.auditLog(ctx, "fill_failed", map[string]any{
+			"trade_id":    ev.TradeID,
+			"exchange_id": ev.ExchangeID,
+			"token_id":    ev.TokenID,
+			"price":       ev.Price,
+			"size":        ev.Size,
+		})
+		return nil
+	default:
+		return nil
+	}
+	if ev.Size <= 0 {
+		return nil
+	}
+
+	key := ev.TradeID + ":" + ev.ExchangeID
+	if !t.markSeen(key) {
+		return nil
+	}
+
+	order, err := t.lookup(ctx, ev.ExchangeID)
+	if errors.Is(err, domain.ErrNotFound) {
+		t.logger.DebugContext(ctx, "fill_tracker: fill for unknown order",
+			slog.String("exchange_id", ev.ExchangeID),
+			slog.String("trade_id", ev.TradeID),
+		)
+		return nil
+	}
+	if err != nil {
+		t.forget(key)
+		return err
+	}
+
+	// A fill reported after the order was cancelled or expired locally (the
+	// cancel raced the match) still counts, but does not revive the order.
+	filled := min(order.FilledSize+ev.Size, order.Size())
+	status := order.Status
+	if next := domain.FillStatus(filled, order.Size()); order.Status.CanTransition(next) {
+		status = next
+	}
+	if err := t.orders.RecordFill(ctx, order.ID, filled, status); err != nil {
+		t.forget(key)
+		return fmt.Errorf("fill_tracker: record fill %s: %w", order.ID, err)
+	}
+	if t.latency != nil && !ev.Maker {
+		t.latency.Filled(order.ID, reported)
+	}
+	if err := t.positions.ApplyFill(ctx, order, ev.Price, ev.Size); err != nil {
+		// The order row already reflects the fill; a retry would double-count it.
+		t.logger.ErrorContext(ctx, "fill_tracker: apply fill to position failed",
+			slog.String("order_id", order.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	evt, _ := json.Marshal(map[string]any{
+		"event":       "order_filled",
+		"order_id":    order.ID,
+		"market":      order.MarketID,
+		"side":        string(order.Side),
+		"price":       ev.Price,
+		"size":        ev.Size,
+		"filled_size": filled,
+		"fill_pct":    min(100, filled/order.Size()*100),
+		"status":      string(status),
+		"maker":       ev.Maker,
+	})
+	if pubErr := t.bus.Publish(ctx, "orders", evt); pubErr != nil {
+		t.logger.WarnContext(ctx, "fill_tracker: publish event failed",
+			slog.String("order_id", order.ID),
+			slog.String("error", pubErr.Error()),
+		)
+	}
+	t.auditLog(ctx, "order_filled", map[string]any{
+		"order_id":    order.ID,
+		"exchange_id": ev.ExchangeID,
+		"trade_id":    ev.TradeID,
+		"price":       ev.Price,
+		"size":        ev.Size,
+		"filled_size": filled,
+		"maker":       ev.Maker,
+		"strategy":    order.Strategy,
+	})
+	t.logger.InfoContext(ctx, "fill_tracker: order filled",
+		slog.String("order_id", order.ID),
+		slog.String("side", string(order.Side)),
+		slog.Float64("price", ev.Price),
+		slog.Float64("size", ev.Size),
+		slog.Float64("filled_size", filled),
+		slog.String("status", string(status)),
+	)
+	return nil
+}
+
+// HandleOrderUpdate applies placement and cancellation events to local order
+// status. Match updates are ignored here because fills arrive as trades. A
+// cancellation at or after a GTD order's expiration marks it expired.
+func (t *FillTracker) HandleOrderUpdate(ctx context.Context, ev domain.OrderUpdateEvent) error {
+	var want domain.OrderStatus
+	switch ev.Type {
+	case "placement":
+		want = domain.OrderStatusOpen
+	case "cancellation":
+		want = domain.OrderStatusCancelled
+	default:
+		return nil
+	}
+
+	order, err := t.lookup(ctx, ev.ExchangeID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if want == domain.OrderStatusCancelled && order.ExpiresAt != nil && !ev.Time.Before(*order.ExpiresAt) {
+		want = domain.OrderStatusExpired
+	}
+	if order.Status == want || !order.Status.CanTransition(want) {
+		return nil
+	}
+	if err := t.orders.UpdateStatus(ctx, order.ID, want); err != nil {
+		return fmt.Errorf("fill_tracker: update order %s: %w", order.ID, err)
+	}
+	if want != domain.OrderStatusOpen {
+		evt, _ := json.Marshal(map[string]any{
+			"event":       "order_" + string(want),
+			"order_id":    order.ID,
+			"market":      order.MarketID,
+			"filled_size": order.FilledSize,
+		})
+		if pubErr := t.bus.Publish(ctx, "orders", evt); pubErr != nil {
+			t.logger.WarnContext(ctx, "fill_tracker: publish event failed",
+				slog.String("order_id", order.ID),
+				slog.String("error", pubErr.Error()),
+			)
+		}
+	}
+	t.logger.InfoContext(ctx, "fill_tracker: order status updated",
+		slog.String("order_id", order.ID),
+		slog.String("status", string(want)),
+		slog.Float64("size_matched", ev.SizeMatched),
+	)
+	return nil
+}
+
+// lookup finds the local order for an exchange order ID. Orders whose hash
+// was not recorded are tried by local ID as well.
+func (t *FillTracker) lookup(ctx context.Context, exchangeID string) (domain.Order, error) {
+	order, err := t.orders.GetByExchangeID(ctx, exchangeID)
+	if errors.Is(err, domain.ErrNotFound) {
+		order, err = t.orders.GetByID(ctx, exchangeID)
+	}
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return domain.Order{}, fmt.Errorf("fill_tracker: lookup order %s: %w", exchangeID, err)
+	}
+	return order, err
+}
+
+// markSeen records key and reports whether it was new.
+func (t *FillTracker) markSeen(key string) bool {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.seen[key]; ok {
+		return false
+	}
+	for k, at := range t.seen {
+		if now.Sub(at) > fillSeenTTL {
+			delete(t.seen, k)
+		}
+	}
+	t.seen[key] = now
+	return true
+}
+
+func (t *FillTracker) forget(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.seen, key)
+}
+
+func (t *FillTracker) auditLog(ctx context.Context, event string, details map[string]any) {
+	if err := t.audit.Log(ctx, event, details); err != nil {
+		t.logger.WarnContext(ctx, "fill_tracker: audit log failed",
+			slog.String("event", event),
+			slog.String("error", err.Error()),
+		)
+	}
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	// heatmapReloadInterval is how often the watched market list is refreshed.
+	heatmapReloadInterval = 5 * time.Minute
+	// heatmapMaxMarkets matches the markets the Polymarket WS feed watches.
+	heatmapMaxMarkets = 200
+)
+
+// minuteWindow sums values over the last hour in one-minute buckets, so adds
+// and reads are O(1) and old activity ages out without a scan.
+type minuteWindow struct {
+	sums    [60]float64
+	minutes [60]int64 // unix minute each bucket currently holds
+}
+
+// add counts v at t. Values older than the minute a bucket already holds are
+// dropped, so a late event can never clear newer activity.
+func (w *minuteWindow) add(t time.Time, v float64) {
+	m := t.Unix() / 60
+	i := m % 60
+	if w.minutes[i] > m {
+		return
+	}
+	if w.minutes[i] != m {
+		w.minutes[i] = m
+		w.sums[i] = 0
+	}
+	w.sums[i] += v
+}
+
+// total returns the sum of buckets within the hour ending at now.
+func (w *minuteWindow) total(now time.Time) float64 {
+	cur := now.Unix() / 60
+	var sum float64
+	for i, m := range w.minutes {
+		if cur-m < 60 {
+			sum += w.sums[i]
+		}
+	}
+	return sum
+}
+
+type heatQuote struct {
+	bid, ask float64
+}
+
+// marketHeat is the running state of one watched market.
+type marketHeat struct {
+	market   domain.Market
+	quotes   map[string]heatQuote // by token ID
+	priceAt  time.Time
+	volume   minuteWindow
+	signals  minuteWindow
+	byOrigin map[string]*minuteWindow
+}
+
+// HeatmapAggregator maintains per-market edge and activity figures for the
+// watched markets from the "prices" and "trades" streams and from the
+// signals the strategy engine emits (Record). Snapshot reads the running
+// state, so serving the heat map never scans stores.
+type HeatmapAggregator struct {
+	markets domain.MarketStore
+	bus     domain.SignalBus
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	byID    map[string]*marketHeat
+	byToken map[string]*marketHeat
+}
+
+// NewHeatmapAggregator creates a HeatmapAggregator.
+func NewHeatmapAggregator(markets domain.MarketStore, bus domain.SignalBus, logger *slog.Logger) *HeatmapAggregator {
+	return &HeatmapAggregator{
+		markets: markets,
+		bus:     bus,
+		logger:  logger.With(slog.String("component", "heatmap")),
+		byID:    make(map[string]*marketHeat),
+		byToken: make(map[string]*marketHeat),
+	}
+}
+
+// Run consumes price and trade events until ctx is cancelled, refreshing the
+// watched markets every few minutes. Call in a goroutine.
+func (h *HeatmapAggregator) Run(ctx context.Context) error {
+	prices, err := h.bus.Subscribe(ctx, "prices")
+	if err != nil {
+		return fmt.Errorf("heatmap: subscribe prices: %w", err)
+	}
+	trades, err := h.bus.Subscribe(ctx, "trades")
+	if err != nil {
+		return fmt.Errorf("heatmap: subscribe trades: %w", err)
+	}
+	h.reload(ctx)
+
+	ticker := time.NewTicker(heatmapReloadInterval)
+	defer ticker.Stop()
+
+	h.logger.InfoContext(ctx, "heatmap aggregator started")
+	defer h.logger.InfoContext(ctx, "heatmap aggregator stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			h.reload(ctx)
+		case data, ok := <-prices:
+			if !ok {
+				return nil
+			}
+			var ev struct {
+				AssetID string  `json:"asset_id"`
+				BestBid float64 `json:"best_bid"`
+				BestAsk float64 `json:"best_ask"`
+			}
+			if err := json.Unmarshal(data, &ev); err != nil || ev.AssetID == "" {
+				continue
+			}
+			h.onQuote(ev.AssetID, ev.BestBid, ev.BestAsk, time.Now().UTC())
+		case data, ok := <-trades:
+			if !ok {
+				return nil
+			}
+			var ev struct {
+				Event     string  `json:"event"`
+				Market    string  `json:"market"`
+				Amount    float64 `json:"amount"`
+				Timestamp string  `json:"timestamp"`
+			}
+			if err := json.Unmarshal(data, &ev); err != nil || ev.Event != "trade_ingested" {
+				continue
+			}
+			at, err := time.Parse(time.RFC3339, ev.Timestamp)
+			if err != nil {
+				at = time.Now()
+			}
+			h.onTrade(ev.Market, ev.Amount, at)
+		}
+	}
+}
+
+// reload replaces the watched market set, keeping the state of markets that
+// are still watched.
+func (h *HeatmapAggregator) reload(ctx context.Context) {
+	list, err := h.markets.ListActive(ctx, domain.ListOpts{Limit: heatmapMaxMarkets})
+	if err != nil {
+		h.logger.WarnContext(ctx, "heatmap reload failed", slog.String("error", err.Error()))
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	byID := make(map[string]*marketHeat, len(list))
+	byToken := make(map[string]*marketHeat, 2*len(list))
+	for _, m := range list {
+		mh, ok := h.byID[m.ID]
+		if !ok {
+			mh = &marketHeat{
+				quotes:   make(map[string]heatQuote),
+				byOrigin: make(map[string]*minuteWindow),
+			}
+		}
+		mh.market = m
+		byID[m.ID] = mh
+		for _, tid := range m.TokenIDs {
+			if tid != "" {
+				byToken[tid] = mh
+			}
+		}
+	}
+	h.byID = byID
+	h.byToken = byToken
+}
+
+func (h *HeatmapAggregator) onQuote(tokenID string, bid, ask float64, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	mh, ok := h.byToken[tokenID]
+	if !ok {
+		return
+	}
+	mh.quotes[tokenID] = heatQuote{bid: bid, ask: ask}
+	mh.priceAt = at
+}
+
+func (h *HeatmapAggregator) onTrade(marketID string, usd float64, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if mh, ok := h.byID[marketID]; ok {
+		mh.volume.add(at, usd)
+	}
+}
+
+// Record counts a signal emitted by the strategy engine against its market.
+// It implements strategy.SignalRecorder and never blocks.
+func (h *HeatmapAggregator) Record(sig domain.TradeSignal) {
+	at := sig.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	mh, ok := h.byID[sig.MarketID]
+	if !ok {
+		if mh, ok = h.byToken[sig.TokenID]; !ok {
+			return
+		}
+	}
+	mh.signals.add(at, 1)
+	w, ok := mh.byOrigin[sig.Source]
+	if !ok {
+		w = &minuteWindow{}
+		mh.byOrigin[sig.Source] = w
+	}
+	w.add(at, 1)
+}
+
+// Snapshot returns every watched market, those furthest from pricing to 1
+// first and unpriced markets last.
+func (h *HeatmapAggregator) Snapshot() []domain.MarketHeat {
+	now := time.Now()
+	h.mu.Lock()
+	out := make([]domain.MarketHeat, 0, len(h.byID))
+	for _, mh := range h.byID {
+		cell := domain.MarketHeat{
+			MarketID:       mh.market.ID,
+			Slug:           mh.market.Slug,
+			Question:       mh.market.Question,
+			Volume1h:       mh.volume.total(now),
+			Signals1h:      int(mh.signals.total(now)),
+			PriceUpdatedAt: mh.priceAt,
+		}
+		for origin, w := range mh.byOrigin {
+			if n := int(w.total(now)); n > 0 {
+				if cell.SignalsByStrategy == nil {
+					cell.SignalsByStrategy = make(map[string]int)
+				}
+				cell.SignalsByStrategy[origin] = n
+			}
+		}
+		cell.Priced, cell.SumDeviation, cell.Spread = mh.priceFigures()
+		out = append(out, cell)
+	}
+	h.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Priced != out[j].Priced {
+			return out[i].Priced
+		}
+		di, dj := math.Abs(out[i].SumDeviation), math.Abs(out[j].SumDeviation)
+		if di != dj {
+			return di > dj
+		}
+		return out[i].MarketID < out[j].MarketID
+	})
+	return out
+}
+
+// priceFigures returns the sum deviation and widest spread once every
+// outcome has a two-sided quote.
+func (mh *marketHeat) priceFigures() (priced bool, sumDeviation, spread float64) {
+	if len(mh.market.TokenIDs) == 0 {
+		return false, 0, 0
+	}
+	var sum float64
+	for _, tid := range mh.market.TokenIDs {
+		q, ok := mh.quotes[tid]
+		if !ok || q.bid <= 0 || q.ask <= 0 {
+			return false, 0, 0
+		}
+		sum += (q.bid + q.ask) / 2
+		spread = math.Max(spread, q.ask-q.bid)
+	}
+	return true, sum - 1, spread
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polymarket"
+)
+
+// hindsightMarkLookback is how far before the horizon a recorded book event
+// may be and still serve as the mark price.
+const hindsightMarkLookback = 5 * time.Minute
+
+// MarketResolver reports whether a market has resolved and which way
+// (polymarket.GammaClient).
+type MarketResolver interface {
+	GetMarketResolution(ctx context.Context, marketID string) (polymarket.MarketResolution, error)
+}
+
+// HindsightService scores recorded strategy signals against what the market
+// did afterwards. A signal is valued at its own price and size against the
+// settlement price once its market has resolved, or otherwise against the
+// recorded book mid Horizon after it was emitted. Signals without either are
+// pending. Executed and skipped signals are reported separately, so the
+// skipped side quantifies missed opportunities.
+type HindsightService struct {
+	signals    domain.SignalStore
+	orders     domain.OrderStore     // optional; without it every signal counts as skipped
+	markets    domain.MarketStore    // optional; needed to settle resolved markets
+	resolver   MarketResolver        // optional
+	books      domain.BookEventStore // optional; needed for mark prices
+	horizon    time.Duration
+	window     time.Duration
+	interval   time.Duration
+	maxSignals int
+	logger     *slog.Logger
+
+	mu       sync.Mutex
+	reports  map[string]domain.HindsightReport
+	resolved map[string]polymarket.MarketResolution // closed markets only; final
+}
+
+// NewHindsightService creates a HindsightService. Each evaluation covers the
+// last window of signals, at most maxSignals per strategy; Run re-evaluates
+// every interval.
+func NewHindsightService(
+	signals domain.SignalStore,
+	horizon, window, interval time.Duration,
+	maxSignals int,
+	logger *slog.Logger,
+) *HindsightService {
+	if horizon <= 0 {
+		horizon = time.Hour
+	}
+	if window <= 0 {
+		window = 7 * 24 * time.Hour
+	}
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	if maxSignals <= 0 {
+		maxSignals = 1000
+	}
+	return &HindsightService{
+		signals:    signals,
+		horizon:    horizon,
+		window:     window,
+		interval:   interval,
+		maxSignals: maxSignals,
+		logger:     logger.With(slog.String("component", "hindsight")),
+		reports:    make(map[string]domain.HindsightReport),
+		resolved:   make(map[string]polymarket.MarketResolution),
+	}
+}
+
+// WithOrders sets the order store used to tell executed signals from skipped ones.
+func (s *HindsightService) WithOrders(orders domain.OrderStore) *HindsightService {
+	s.orders = orders
+	return s
+}
+
+// WithResolutions settles signals in resolved markets at 1 or 0.
+func (s *HindsightService) WithResolutions(markets domain.MarketStore, resolver MarketResolver) *HindsightService {
+	s.markets = markets
+	s.resolver = resolver
+	return s
+}
+
+// WithBooks marks signals in unresolved markets at the recorded book mid.
+func (s *HindsightService) WithBooks(books domain.BookEventStore) *HindsightService {
+	s.books = books
+	return s
+}
+
+// Run evaluates every strategy with recent signals now and then every
+// interval until ctx is cancelled.
+func (s *HindsightService) Run(ctx context.Context) error {
+	s.logger.Info("hindsight evaluation started",
+		slog.Duration("horizon", s.horizon),
+		slog.Duration("window", s.window),
+	)
+	defer s.logger.Info("hindsight evaluation stopped")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.evaluateAll(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *HindsightService) evaluateAll(ctx context.Context) {
+	sources, err := s.signals.Sources(ctx, time.Now().UTC().Add(-s.window))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "hindsight: list strategies failed", slog.String("error", err.Error()))
+		return
+	}
+	for _, name := range sources {
+		if ctx.Err() != nil {
+			return
+		}
+		if _, err := s.Evaluate(ctx, name); err != nil {
+			s.logger.ErrorContext(ctx, "hindsight: evaluation failed",
+				slog.String("strategy", name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// Report returns the latest report for strategy, evaluating it when there is
+// none younger than the evaluation interval.
+func (s *HindsightService) Report(ctx context.Context, strategy string) (domain.HindsightReport, error) {
+	s.mu.Lock()
+	rep, ok := s.reports[strategy]
+	s.mu.Unlock()
+	if ok && time.Since(rep.EvaluatedAt) < s.interval {
+		return rep, nil
+	}
+	return s.Evaluate(ctx, strategy)
+}
+
+// Evaluate scores strategy's signals from the last window and caches the report.
+func (s *HindsightService) Evaluate(ctx context.Context, strategy string) (domain.HindsightReport, error) {
+	now := time.Now().UTC()
+	since := now.Add(-s.window)
+	sigs, err := s.signals.ListByStrategy(ctx, strategy, domain.ListOpts{Since: &since, Limit: s.maxSignals})
+	if err != nil {
+		return domain.HindsightReport{}, fmt.Errorf("hindsight: list signals for %s: %w", strategy, err)
+	}
+
+	rep := domain.HindsightReport{
+		Strategy:    strategy,
+		Since:       since,
+		Horizon:     s.horizon,
+		Outcomes:    make([]domain.SignalOutcome, 0, len(sigs)),
+		EvaluatedAt: now,
+	}
+	markets := make(map[string]domain.Market)
+	for _, sig := range sigs {
+		if err := ctx.Err(); err != nil {
+			return domain.HindsightReport{}, err
+		}
+		o := s.outcome(ctx, sig, now, markets)
+		if o.Executed {
+			rep.Executed.Add(o)
+		} else {
+			rep.Skipped.Add(o)
+		}
+		rep.Outcomes = append(rep.Outcomes, o)
+	}
+
+	s.mu.Lock()
+	s.reports[strategy] = rep
+	s.mu.Unlock()
+	return rep, nil
+}
+
+// outcome prices a single signal. markets caches market lookups for one evaluation.
+func (s *HindsightService) outcome(ctx context.Context, sig domain.TradeSignal, now time.Time, markets map[string]domain.Market) domain.SignalOutcome {
+	o := domain.SignalOutcome{Signal: sig, Executed: s.executed(ctx, sig)}
+
+	if exit, ok := s.settlement(ctx, sig, markets); ok {
+		o.ExitPrice, o.Resolved = exit, true
+	} else if exit, ok := s.mark(ctx, sig, now); ok {
+		o.ExitPrice = exit
+	} else {
+		o.Pending = true
+		return o
+	}
+
+	if sig.Side == domain.OrderSideSell {
+		o.PnL = (sig.Price() - o.ExitPrice) * sig.Size()
+	} else {
+		o.PnL = (o.ExitPrice - sig.Price()) * sig.Size()
+	}
+	return o
+}
+
+// executed reports whether an order was placed for sig (orders reuse the
+// signal ID) and either filled or was not rejected, cancelled or expired.
+func (s *HindsightService) executed(ctx context.Context, sig domain.TradeSignal) bool {
+	if s.orders == nil {
+		return false
+	}
+	ord, err := s.orders.GetByID(ctx, sig.ID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.logger.DebugContext(ctx, "hindsight: order lookup failed",
+				slog.String("signal_id", sig.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+		return false
+	}
+	if ord.FilledSize > 0 {
+		return true
+	}
+	return ord.Status != domain.OrderStatusFailed && ord.Status != domain.OrderStatusCancelled &&
+		ord.Status != domain.OrderStatusExpired
+}
+
+// settlement returns 1 or 0 for a signal whose market has resolved.
+func (s *HindsightService) settlement(ctx context.Context, sig domain.TradeSignal, markets map[string]domain.Market) (float64, bool) {
+	if s.resolver == nil || s.markets == nil || sig.MarketID == "" {
+		return 0, false
+	}
+	mkt, ok := markets[sig.MarketID]
+	if !ok {
+		var err error
+		if mkt, err = s.markets.GetByID(ctx, sig.MarketID); err != nil {
+			s.logger.DebugContext(ctx, "hindsight: market lookup failed",
+				slog.String("market_id", sig.MarketID),
+				slog.String("error", err.Error()),
+			)
+		}
+		markets[sig.MarketID] = mkt
+	}
+	idx := mkt.OutcomeIndex(sig.TokenID)
+	if idx < 0 {
+		return 0, false
+	}
+
+	res, ok := s.resolution(ctx, sig.MarketID)
+	if !ok || !res.Closed {
+		return 0, false
+	}
+	var won bool
+	switch {
+	case res.WinnerTokenID != "":
+		won = res.WinnerTokenID == sig.TokenID
+	case mkt.IsBinary():
+		won = (idx == 0) == res.YesWon
+	default:
+		// Categorical market without a reported winner.
+		return 0, false
+	}
+	if won {
+		return 1, true
+	}
+	return 0, true
+}
+
+// resolution returns the market's resolution, remembering closed markets.
+func (s *HindsightService) resolution(ctx context.Context, marketID string) (polymarket.MarketResolution, bool) {
+	s.mu.Lock()
+	res, ok := s.resolved[marketID]
+	s.mu.Unlock()
+	if ok {
+		return res, true
+	}
+	res, err := s.resolver.GetMarketResolution(ctx, marketID)
+	if err != nil {
+		s.logger.DebugContext(ctx, "hindsight: resolution fetch failed",
+			slog.String("market_id", marketID),
+			slog.String("error", err.Error()),
+		)
+		return polymarket.MarketResolution{}, false
+	}
+	if res.Closed {
+		s.mu.Lock()
+		s.resolved[marketID] = res
+		s.mu.Unlock()
+	}
+	return res, true
+}
+
+// mark returns the recorded book mid of the signal's token at CreatedAt+horizon.
+func (s *HindsightService) mark(ctx context.Context, sig domain.TradeSignal, now time.Time) (float64, bool) {
+	at := sig.CreatedAt.Add(s.horizon)
+	if s.books == nil || at.After(now) {
+		return 0, false
+	}
+	events, err := s.books.ListRange(ctx, []string{sig.TokenID}, at.Add(-hindsightMarkLookback), at, 0)
+	if err != nil {
+		s.logger.DebugContext(ctx, "hindsight: book lookup failed",
+			slog.String("token_id", sig.TokenID),
+			slog.String("error", err.Error()),
+		)
+		return 0, false
+	}
+	for i := len(events) - 1; i >= 0; i-- {
+		if e := events[i]; e.BestBid > 0 && e.BestAsk > 0 {
+			return (e.BestBid + e.BestAsk) / 2, true
+		}
+	}
+	return 0, false
+}
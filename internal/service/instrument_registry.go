@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// InstrumentRegistry maintains canonical instrument records that link every
+// identifier a market is known by: Polymarket market, condition and token
+// IDs, slugs, and refs on other venues. Lookups go through the Redis cache
+// and fall back to the persistent store.
+type InstrumentRegistry struct {
+	store  domain.InstrumentStore
+	cache  domain.InstrumentCache
+	logger *slog.Logger
+}
+
+// NewInstrumentRegistry creates an InstrumentRegistry.
+func NewInstrumentRegistry(store domain.InstrumentStore, cache domain.InstrumentCache, logger *slog.Logger) *InstrumentRegistry {
+	return &InstrumentRegistry{
+		store:  store,
+		cache:  cache,
+		logger: logger.With(slog.String("component", "instrument_registry")),
+	}
+}
+
+// Get returns an instrument by its canonical ID.
+func (r *InstrumentRegistry) Get(ctx context.Context, id string) (domain.Instrument, error) {
+	if inst, err := r.cache.Get(ctx, id); err == nil {
+		return inst, nil
+	}
+	inst, err := r.store.GetByID(ctx, id)
+	if err != nil {
+		return domain.Instrument{}, fmt.Errorf("instrument_registry: get %q: %w", id, err)
+	}
+	r.backfill(ctx, inst)
+	return inst, nil
+}
+
+// Resolve returns the instrument identified by (kind, value).
+func (r *InstrumentRegistry) Resolve(ctx context.Context, kind domain.IDKind, value string) (domain.Instrument, error) {
+	if !kind.Valid() {
+		return domain.Instrument{}, fmt.Errorf("instrument_registry: %w: unknown id kind %q", domain.ErrInvalidInstrument, kind)
+	}
+	if id, err := r.cache.Resolve(ctx, kind, value); err == nil {
+		if inst, err := r.cache.Get(ctx, id); err == nil {
+			return inst, nil
+		}
+	}
+	inst, err := r.store.Lookup(ctx, kind, value)
+	if err != nil {
+		return domain.Instrument{}, fmt.Errorf("instrument_registry: resolve %s=%q: %w", kind, value, err)
+	}
+	r.backfill(ctx, inst)
+	return inst, nil
+}
+
+// ResolveBatch returns the instruments for values of one kind, keyed by
+// value. Unknown values are omitted.
+func (r *InstrumentRegistry) ResolveBatch(ctx context.Context, kind domain.IDKind, values []string) (map[string]domain.Instrument, error) {
+	if !kind.Valid() {
+		return nil, fmt.Errorf("instrument_registry: %w: unknown id kind %q", domain.ErrInvalidInstrument, kind)
+	}
+	out, err := r.cache.ResolveBatch(ctx, kind, values)
+	if err != nil {
+		r.logger.WarnContext(ctx, "instrument_registry: cache batch lookup failed",
+			slog.String("error", err.Error()),
+		)
+		out = make(map[string]domain.Instrument, len(values))
+	}
+
+	var misses []string
+	for _, v := range values {
+		if _, ok := out[v]; !ok {
+			misses = append(misses, v)
+		}
+	}
+	if len(misses) == 0 {
+		return out, nil
+	}
+
+	found, err := r.store.LookupBatch(ctx, kind, misses)
+	if err != nil {
+		return nil, fmt.Errorf("instrument_registry: resolve batch by %s: %w", kind, err)
+	}
+	for v, inst := range found {
+		out[v] = inst
+		r.backfill(ctx, inst)
+	}
+	return out, nil
+}
+
+// List returns instruments ordered by ID.
+func (r *InstrumentRegistry) List(ctx context.Context, opts domain.ListOpts) ([]domain.Instrument, error) {
+	list, err := r.store.List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("instrument_registry: list: %w", err)
+	}
+	return list, nil
+}
+
+// Link sets the identifier of kind on an instrument, replacing any previous
+// value of that kind. An empty value removes the identifier.
+func (r *InstrumentRegistry) Link(ctx context.Context, id string, kind domain.IDKind, value string) (domain.Instrument, error) {
+	if !kind.Valid() || kind == domain.IDKindToken {
+		return domain.Instrument{}, fmt.Errorf("instrument_registry: %w: cannot link id kind %q", domain.ErrInvalidInstrument, kind)
+	}
+	if kind == domain.IDKindMarket && value == "" {
+		return domain.Instrument{}, fmt.Errorf("instrument_registry: %w: market_id cannot be removed", domain.ErrInvalidInstrument)
+	}
+
+	inst, err := r.store.GetByID(ctx, id)
+	if err != nil {
+		return domain.Instrument{}, fmt.Errorf("instrument_registry: link %q: %w", id, err)
+	}
+	// Drop cached index entries for the old value before it changes.
+	r.invalidate(ctx, id)
+
+	if value == "" {
+		delete(inst.Identifiers, kind)
+	} else {
+		inst.Identifiers[kind] = value
+	}
+	if err := r.store.Upsert(ctx, inst); err != nil {
+		return domain.Instrument{}, fmt.Errorf("instrument_registry: link %q %s=%q: %w", id, kind, value, err)
+	}
+	r.logger.InfoContext(ctx, "instrument_registry: identifier linked",
+		slog.String("instrument_id", id),
+		slog.String("kind", string(kind)),
+		slog.String("value", value),
+	)
+	return inst, nil
+}
+
+// SyncMarkets creates or refreshes the instruments for Polymarket markets.
+// Existing instruments keep their ID and any venue links; their Polymarket
+// identifiers are replaced with the market's current ones.
+func (r *InstrumentRegistry) SyncMarkets(ctx context.Context, markets []domain.Market) error {
+	if len(markets) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(markets))
+	for _, m := range markets {
+		ids = append(ids, m.ID)
+	}
+	existing, err := r.store.LookupBatch(ctx, domain.IDKindMarket, ids)
+	if err != nil {
+		return fmt.Errorf("instrument_registry: sync markets: %w", err)
+	}
+
+	insts := make([]domain.Instrument, 0, len(markets))
+	for _, m := range markets {
+		inst := domain.InstrumentFromMarket(m)
+		if prev, ok := existing[m.ID]; ok {
+			inst.ID = prev.ID
+			for kind, value := range prev.Identifiers {
+				if _, isVenue := venueKinds[kind]; isVenue {
+					inst.Identifiers[kind] = value
+				}
+			}
+		} else {
+			inst.ID = uuid.NewString()
+		}
+		insts = append(insts, inst)
+	}
+
+	err = r.store.UpsertBatch(ctx, insts)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		// An identifier moved between markets (e.g. a reused slug). Fall back
+		// to one-by-one so a single conflict does not block the batch.
+		err = nil
+		for _, inst := range insts {
+			if uerr := r.store.Upsert(ctx, inst); uerr != nil {
+				if !errors.Is(uerr, domain.ErrAlreadyExists) {
+					return fmt.Errorf("instrument_registry: sync markets: %w", uerr)
+				}
+				r.logger.WarnContext(ctx, "instrument_registry: identifier conflict, instrument skipped",
+					slog.String("market_id", inst.Ref(domain.IDKindMarket)),
+					slog.String("error", uerr.Error()),
+				)
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("instrument_registry: sync markets: %w", err)
+	}
+
+	for _, inst := range insts {
+		r.invalidate(ctx, inst.ID)
+	}
+	return nil
+}
+
+// SeedVenueLinks links venue refs from a config map keyed by Polymarket
+// market ID or slug. Instruments that already carry a ref for the venue are
+// left alone, so links edited through the API win over config.
+func (r *InstrumentRegistry) SeedVenueLinks(ctx context.Context, venue string, refs map[string]string) (int, error) {
+	kind, ok := domain.VenueIDKind(venue)
+	if !ok {
+		return 0, fmt.Errorf("instrument_registry: %w: unknown venue %q", domain.ErrInvalidInstrument, venue)
+	}
+
+	linked := 0
+	for key, ref := range refs {
+		if ref == "" {
+			continue
+		}
+		inst, err := r.store.Lookup(ctx, domain.IDKindMarket, key)
+		if errors.Is(err, domain.ErrNotFound) {
+			inst, err = r.store.Lookup(ctx, domain.IDKindSlug, key)
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			r.logger.DebugContext(ctx, "instrument_registry: venue map key not found",
+				slog.String("venue", venue),
+				slog.String("key", key),
+			)
+			continue
+		}
+		if err != nil {
+			return linked, fmt.Errorf("instrument_registry: seed %s links: %w", venue, err)
+		}
+		if inst.Ref(kind) != "" {
+			continue
+		}
+		if _, err := r.Link(ctx, inst.ID, kind, ref); err != nil {
+			r.logger.WarnContext(ctx, "instrument_registry: seed venue link failed",
+				slog.String("venue", venue),
+				slog.String("key", key),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		linked++
+	}
+	return linked, nil
+}
+
+// venueKinds are identifier kinds maintained by hand or config rather than
+// by market sync.
+var venueKinds = map[domain.IDKind]struct{}{
+	domain.IDKindKalshi:    {},
+	domain.IDKindPredictIt: {},
+	domain.IDKindManifold:  {},
+}
+
+func (r *InstrumentRegistry) backfill(ctx context.Context, inst domain.Instrument) {
+	if err := r.cache.Set(ctx, inst); err != nil {
+		r.logger.WarnContext(ctx, "instrument_registry: cache set failed",
+			slog.String("instrument_id", inst.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (r *InstrumentRegistry) invalidate(ctx context.Context, id string) {
+	if err := r.cache.Invalidate(ctx, id); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			r.logger.WarnContext(ctx, "instrument_registry: cache invalidate failed",
+				slog.String("instrument_id", id),
+				slog.String("error", err.Error()),
+			)
+		}
+		// Non-fatal: the cache will eventually expire on its own.
+	}
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LatencyTrackerConfig holds the latency tracker settings.
+type LatencyTrackerConfig struct {
+	// Window is how many recent samples per stage the quantiles are computed
+	// over. Defaults to 2048.
+	Window int
+	// FlushInterval is how often acknowledged breakdowns are persisted.
+	// Defaults to 5s.
+	FlushInterval time.Duration
+}
+
+// latencyMaxOpen is how long a breakdown waits for its acknowledgement or
+// taker fill before it is persisted as is and forgotten.
+const latencyMaxOpen = 10 * time.Minute
+
+type latencySample struct {
+	strategy string
+	d        time.Duration
+}
+
+// latencyWindow keeps the most recent samples of one stage, and the count
+// and sum of all of them.
+type latencyWindow struct {
+	samples []latencySample
+	next    int
+	count   int64
+	sum     time.Duration
+}
+
+func (w *latencyWindow) add(s latencySample, size int) {
+	w.count++
+	w.sum += s.d
+	if len(w.samples) < size {
+		w.samples = append(w.samples, s)
+		return
+	}
+	w.samples[w.next] = s
+	w.next = (w.next + 1) % size
+}
+
+type openLatency struct {
+	latency domain.OrderLatency
+	started time.Time
+	dirty   bool
+}
+
+// LatencyTracker measures how long signals take from emission to fill. The
+// executor reports when it picks a signal up and how long its risk checks
+// take, the order service how long signing and the CLOB round trip take, and
+// the fill tracker when a taker order's first fill is reported. Each stage
+// keeps a window of recent samples for quantiles; with a store, the
+// breakdown of every acknowledged order is persisted.
+type LatencyTracker struct {
+	store  domain.LatencyStore // optional
+	cfg    LatencyTrackerConfig
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	open    map[string]*openLatency // by order (signal) ID
+	windows map[domain.LatencyStage]*latencyWindow
+}
+
+// NewLatencyTracker creates a LatencyTracker. store may be nil; breakdowns
+// are then only counted in the quantile windows.
+func NewLatencyTracker(store domain.LatencyStore, cfg LatencyTrackerConfig, logger *slog.Logger) *LatencyTracker {
+	if cfg.Window <= 0 {
+		cfg.Window = 2048
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	windows := make(map[domain.LatencyStage]*latencyWindow, len(domain.LatencyStages))
+	for _, stage := range domain.LatencyStages {
+		windows[stage] = &latencyWindow{}
+	}
+	return &LatencyTracker{
+		store:   store,
+		cfg:     cfg,
+		logger:  logger.With(slog.String("component", "latency_tracker")),
+		open:    make(map[string]*openLatency),
+		windows: windows,
+	}
+}
+
+// Received starts the breakdown of sig when the executor picks it up at at,
+// recording its queueing time when the signal carries its emission time.
+func (t *LatencyTracker) Received(sig domain.TradeSignal, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o := t.openLocked(sig.ID, at)
+	o.latency.Strategy = sig.Source
+	o.latency.MarketID = sig.MarketID
+	o.latency.EmittedAt = sig.EmittedAt
+	if !sig.EmittedAt.IsZero() {
+		t.recordLocked(o, domain.LatencyStageQueue, at.Sub(sig.EmittedAt))
+	}
+}
+
+// Record adds the duration of one stage of order id.
+func (t *LatencyTracker) Record(id string, stage domain.LatencyStage, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.recordLocked(t.openLocked(id, time.Now()), stage, d)
+}
+
+// Acked marks order id acknowledged by the venue at at, recording the time
+// since emission, and schedules its breakdown to be persisted.
+func (t *LatencyTracker) Acked(id string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o := t.openLocked(id, at)
+	o.latency.AckedAt = at
+	o.dirty = true
+	if !o.latency.EmittedAt.IsZero() {
+		t.recordLocked(o, domain.LatencyStageAck, at.Sub(o.latency.EmittedAt))
+	}
+}
+
+// Filled records the first taker fill of order id, reported at at. Later
+// fills and orders not acknowledged since start are ignored.
+func (t *LatencyTracker) Filled(id string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.open[id]
+	if !ok || o.latency.AckedAt.IsZero() || !o.latency.FilledAt.IsZero() {
+		return
+	}
+	o.latency.FilledAt = at
+	o.dirty = true
+	t.recordLocked(o, domain.LatencyStageFill, at.Sub(o.latency.AckedAt))
+	if !o.latency.EmittedAt.IsZero() {
+		t.recordLocked(o, domain.LatencyStageTotal, at.Sub(o.latency.EmittedAt))
+	}
+}
+
+func (t *LatencyTracker) openLocked(id string, now time.Time) *openLatency {
+	o, ok := t.open[id]
+	if !ok {
+		o = &openLatency{
+			latency: domain.OrderLatency{OrderID: id, Stages: make(map[domain.LatencyStage]time.Duration)},
+			started: now,
+		}
+		t.open[id] = o
+	}
+	return o
+}
+
+func (t *LatencyTracker) recordLocked(o *openLatency, stage domain.LatencyStage, d time.Duration) {
+	d = max(0, d)
+	o.latency.Stages[stage] = d
+	if !o.latency.AckedAt.IsZero() {
+		o.dirty = true
+	}
+	t.windows[stage].add(latencySample{strategy: o.latency.Strategy, d: d}, t.cfg.Window)
+}
+
+// Stats returns the quantiles of every stage over the recent window, of
+// strategy's samples only when strategy is set; Count and Sum always cover
+// every sample since start.
+func (t *LatencyTracker) Stats(strategy string) []domain.LatencyStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]domain.LatencyStats, 0, len(domain.LatencyStages))
+	for _, stage := range domain.LatencyStages {
+		w := t.windows[stage]
+		ds := make([]time.Duration, 0, len(w.samples))
+		for _, s := range w.samples {
+			if strategy == "" || s.strategy == strategy {
+				ds = append(ds, s.d)
+			}
+		}
+		sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+		st := domain.LatencyStats{Stage: stage, Samples: len(ds), Count: w.count, Sum: w.sum}
+		if len(ds) > 0 {
+			st.P50 = latencyQuantile(ds, 0.50)
+			st.P95 = latencyQuantile(ds, 0.95)
+			st.P99 = latencyQuantile(ds, 0.99)
+			st.Max = ds[len(ds)-1]
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+// latencyQuantile returns the nearest-rank q-quantile of sorted ds.
+func latencyQuantile(ds []time.Duration, q float64) time.Duration {
+	i := int(q*float64(len(ds))+0.999999) - 1
+	return ds[min(max(i, 0), len(ds)-1)]
+}
+
+// Slowest returns up to limit persisted breakdowns acknowledged since since,
+// of strategy when set, slowest acknowledgement first.
+func (t *LatencyTracker) Slowest(ctx context.Context, strategy string, since time.Time, limit int) ([]domain.OrderLatency, error) {
+	if t.store == nil {
+		return nil, nil
+	}
+	return t.store.ListSlowest(ctx, strategy, since, limit)
+}
+
+// Run persists acknowledged breakdowns every flush interval until ctx is
+// cancelled. Call in a goroutine.
+func (t *LatencyTracker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			t.flush(flushCtx, time.Now())
+			cancel()
+			return ctx.Err()
+		case now := <-ticker.C:
+			t.flush(ctx, now)
+		}
+	}
+}
+
+// flush persists the changed breakdowns of acknowledged orders and forgets
+// those that are complete or have waited longer than latencyMaxOpen.
+// Breakdowns of signals that never became orders are dropped.
+func (t *LatencyTracker) flush(ctx context.Context, now time.Time) {
+	t.mu.Lock()
+	var batch []domain.OrderLatency
+	for id, o := range t.open {
+		acked := !o.latency.AckedAt.IsZero()
+		if acked && o.dirty {
+			o.latency.UpdatedAt = now.UTC()
+			l := o.latency
+			l.Stages = make(map[domain.LatencyStage]time.Duration, len(o.latency.Stages))
+			for k, v := range o.latency.Stages {
+				l.Stages[k] = v
+			}
+			batch = append(batch, l)
+			o.dirty = false
+		}
+		if !o.latency.FilledAt.IsZero() || now.Sub(o.started) > latencyMaxOpen {
+			delete(t.open, id)
+		}
+	}
+	t.mu.Unlock()
+
+	if t.store == nil || len(batch) == 0 {
+		return
+	}
+	if err := t.store.UpsertLatencies(ctx, batch); err != nil {
+		t.logger.WarnContext(ctx, "latency tracker: persist breakdowns failed",
+			slog.Int("count", len(batch)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
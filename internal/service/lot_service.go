@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LotService computes tax-lot accounting from the wallet's fills. Fills are
+// replayed from the first recorded order so lots opened before the report
+// period are matched correctly; orders removed by the retention purge are
+// therefore missing from the history.
+type LotService struct {
+	orders        domain.OrderStore
+	defaultMethod domain.LotMethod
+	logger        *slog.Logger
+}
+
+// NewLotService creates a LotService. method is used when a report does not
+// specify one.
+func NewLotService(orders domain.OrderStore, method domain.LotMethod, logger *slog.Logger) *LotService {
+	if method == "" {
+		method = domain.LotFIFO
+	}
+	return &LotService{
+		orders:        orders,
+		defaultMethod: method,
+		logger:        logger.With(slog.String("component", "lot_service")),
+	}
+}
+
+// Report matches the wallet's fills into lots using method (the service
+// default when empty) and returns lots closed in [from, to] plus those open
+// at to. A nil from means since the first fill; a zero to means now.
+func (s *LotService) Report(ctx context.Context, wallet string, method domain.LotMethod, from *time.Time, to time.Time) (domain.LotReport, error) {
+	if method == "" {
+		method = s.defaultMethod
+	}
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	orders, err := s.orders.ListFilled(ctx, wallet, to)
+	if err != nil {
+		return domain.LotReport{}, fmt.Errorf("lot_service: list fills: %w", err)
+	}
+	fills := make([]domain.Fill, 0, len(orders))
+	for _, o := range orders {
+		fills = append(fills, domain.FillFromOrder(o))
+	}
+	closed, open := domain.MatchLots(fills, method)
+
+	report := domain.LotReport{Wallet: wallet, Method: method, From: from, To: to, Open: open}
+	for _, l := range closed {
+		if from != nil && l.ClosedAt.Before(*from) {
+			continue
+		}
+		report.Closed = append(report.Closed, l)
+		report.RealizedPnL += l.RealizedPnL
+	}
+	return report, nil
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// MarketListConfig holds the market list settings.
+type MarketListConfig struct {
+	// RefreshInterval is how often the lists are re-read, picking up changes
+	// made through other instances. Defaults to 15s.
+	RefreshInterval time.Duration
+}
+
+// marketLists is an immutable view of both lists, indexed by market and
+// token ID.
+type marketLists struct {
+	entries     []domain.MarketListEntry
+	black       map[string]bool // market and token IDs
+	white       map[string]bool // market and token IDs
+	whiteMarket int             // markets on the whitelist
+}
+
+func newMarketLists(entries []domain.MarketListEntry) *marketLists {
+	l := &marketLists{entries: entries, black: make(map[string]bool), white: make(map[string]bool)}
+	for _, e := range entries {
+		ids := l.black
+		if e.List == domain.MarketListWhitelist {
+			ids = l.white
+			l.whiteMarket++
+		}
+		ids[e.MarketID] = true
+		for _, tid := range e.TokenIDs {
+			if tid != "" {
+				ids[tid] = true
+			}
+		}
+	}
+	return l
+}
+
+// MarketListService keeps the operator's market blacklist and whitelist.
+// Lists live in Postgres and are shared through Redis; every instance
+// serves them from memory and re-reads the cache every refresh interval. A
+// blacklisted market is excluded from strategies' market listings, from the
+// watched assets and from new entries; once the whitelist holds any market,
+// only whitelisted markets pass.
+type MarketListService struct {
+	store   domain.MarketListStore
+	cache   domain.MarketListCache // optional
+	markets domain.MarketStore     // optional; resolves listed markets' tokens
+	audit   domain.AuditStore      // optional
+	cfg     MarketListConfig
+	logger  *slog.Logger
+
+	mu    sync.RWMutex
+	lists *marketLists
+}
+
+// NewMarketListService creates a MarketListService. cache may be nil, in
+// which case every refresh reads Postgres. Until Load succeeds every market
+// passes.
+func NewMarketListService(store domain.MarketListStore, cache domain.MarketListCache, cfg MarketListConfig, logger *slog.Logger) *MarketListService {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = 15 * time.Second
+	}
+	return &MarketListService{
+		store:  store,
+		cache:  cache,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "market_lists")),
+		lists:  newMarketLists(nil),
+	}
+}
+
+// WithMarkets checks that listed markets exist and records their tokens, so
+// signals and feeds carrying only a token ID are matched.
+func (s *MarketListService) WithMarkets(markets domain.MarketStore) *MarketListService {
+	s.markets = markets
+	return s
+}
+
+// WithAudit records list changes as "market_listed" and "market_unlisted"
+// audit entries.
+func (s *MarketListService) WithAudit(audit domain.AuditStore) *MarketListService {
+	s.audit = audit
+	return s
+}
+
+// Load reads the lists from the cache, or from Postgres when the cache is
+// empty or unreachable, refilling the cache.
+func (s *MarketListService) Load(ctx context.Context) error {
+	if s.cache != nil {
+		entries, err := s.cache.Get(ctx)
+		if err == nil {
+			s.set(entries)
+			return nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.logger.WarnContext(ctx, "market lists: cache read failed, reading Postgres",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return s.publish(ctx)
+}
+
+// Run re-reads the lists every refresh interval until ctx is cancelled.
+// Call in a goroutine.
+func (s *MarketListService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := s.Load(ctx); err != nil && ctx.Err() == nil {
+				s.logger.WarnContext(ctx, "market lists: refresh failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// publish reads the lists from Postgres, serves them and writes them to the
+// cache for other instances.
+func (s *MarketListService) publish(ctx context.Context) error {
+	entries, err := s.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("market_lists: list: %w", err)
+	}
+	s.set(entries)
+	if s.cache != nil {
+		if err := s.cache.Set(ctx, entries); err != nil {
+			s.logger.WarnContext(ctx, "market lists: cache write failed", slog.String("error", err.Error()))
+		}
+	}
+	return nil
+}
+
+func (s *MarketListService) set(entries []domain.MarketListEntry) {
+	lists := newMarketLists(entries)
+	s.mu.Lock()
+	s.lists = lists
+	s.mu.Unlock()
+}
+
+func (s *MarketListService) current() *marketLists {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lists
+}
+
+// Add puts marketID on list with reason, replacing the reason when it is
+// already there. With a market store the market must exist; unknown markets
+// return an error wrapping domain.ErrNotFound.
+func (s *MarketListService) Add(ctx context.Context, list domain.MarketList, marketID, reason string) (domain.MarketListEntry, error) {
+	marketID = strings.TrimSpace(marketID)
+	if !list.Valid() {
+		return domain.MarketListEntry{}, fmt.Errorf("%w: unknown list %q", domain.ErrInvalidMarketList, list)
+	}
+	if marketID == "" {
+		return domain.MarketListEntry{}, fmt.Errorf("%w: market id is required", domain.ErrInvalidMarketList)
+	}
+	entry := domain.MarketListEntry{
+		List:      list,
+		MarketID:  marketID,
+		Reason:    strings.TrimSpace(reason),
+		CreatedAt: time.Now().UTC(),
+	}
+	if s.markets != nil {
+		m, err := s.markets.GetByID(ctx, marketID)
+		if err != nil {
+			return domain.MarketListEntry{}, fmt.Errorf("market_lists: get market %s: %w", marketID, err)
+		}
+		entry.TokenIDs = m.TokenIDs
+	}
+	if err := s.store.Upsert(ctx, entry); err != nil {
+		return domain.MarketListEntry{}, fmt.Errorf("market_lists: add: %w", err)
+	}
+	if err := s.publish(ctx); err != nil {
+		return domain.MarketListEntry{}, err
+	}
+	s.logger.InfoContext(ctx, "market listed",
+		slog.String("list", string(list)),
+		slog.String("market_id", marketID),
+		slog.String("reason", entry.Reason),
+	)
+	s.auditChange(ctx, "market_listed", entry)
+	for _, e := range s.current().entries {
+		if e.List == list && e.MarketID == marketID {
+			return e, nil
+		}
+	}
+	return entry, nil
+}
+
+// Remove takes marketID off list; the error wraps domain.ErrNotFound when it
+// is not on it.
+func (s *MarketListService) Remove(ctx context.Context, list domain.MarketList, marketID string) error {
+	if !list.Valid() {
+		return fmt.Errorf("%w: unknown list %q", domain.ErrInvalidMarketList, list)
+	}
+	if err := s.store.Delete(ctx, list, marketID); err != nil {
+		return fmt.Errorf("market_lists: remove: %w", err)
+	}
+	if err := s.publish(ctx); err != nil {
+		return err
+	}
+	s.logger.InfoContext(ctx, "market unlisted",
+		slog.String("list", string(list)),
+		slog.String("market_id", marketID),
+	)
+	s.auditChange(ctx, "market_unlisted", domain.MarketListEntry{List: list, MarketID: marketID})
+	return nil
+}
+
+func (s *MarketListService) auditChange(ctx context.Context, event string, e domain.MarketListEntry) {
+	if s.audit == nil {
+		return
+	}
+	detail := map[string]any{
+		"list":      string(e.List),
+		"market_id": e.MarketID,
+	}
+	if e.Reason != "" {
+		detail["reason"] = e.Reason
+	}
+	if err := s.audit.Log(ctx, event, detail); err != nil {
+		s.logger.WarnContext(ctx, "market lists: audit failed", slog.String("error", err.Error()))
+	}
+}
+
+// Entries re-reads the lists and returns every entry of both lists, oldest
+// first.
+func (s *MarketListService) Entries(ctx context.Context) ([]domain.MarketListEntry, error) {
+	if err := s.Load(ctx); err != nil {
+		return nil, err
+	}
+	return append([]domain.MarketListEntry(nil), s.current().entries...), nil
+}
+
+// Allows reports whether strategies may trade m.
+func (s *MarketListService) Allows(m domain.Market) bool {
+	_, excluded := s.Excluded(m.ID, "")
+	return !excluded
+}
+
+// Excluded reports whether the market or token is excluded, and by which
+// list: blacklist when it is on the blacklist, whitelist when the whitelist
+// is in use and it is not on it. Either ID may be empty.
+func (s *MarketListService) Excluded(marketID, tokenID string) (domain.MarketList, bool) {
+	l := s.current()
+	if (marketID != "" && l.black[marketID]) || (tokenID != "" && l.black[tokenID]) {
+		return domain.MarketListBlacklist, true
+	}
+	if l.whiteMarket == 0 {
+		return "", false
+	}
+	if (marketID != "" && l.white[marketID]) || (tokenID != "" && l.white[tokenID]) {
+		return "", false
+	}
+	return domain.MarketListWhitelist, true
+}
@@ -0,0 +1,550 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/kalshi"
+)
+
+// Weights of the match score components; they sum to 1.
+const (
+	matchWeightTokens   = 0.5
+	matchWeightDates    = 0.2
+	matchWeightEntities = 0.3
+)
+
+const (
+	// matchCandidates is how many Kalshi markets sharing the most tokens with
+	// a Polymarket question are scored in full.
+	matchCandidates = 20
+	// matchCommonToken drops tokens found in more Kalshi titles than this
+	// from the candidate index; they say nothing about which market is meant.
+	matchCommonToken = 500
+	// kalshiPageSize is the page size used when listing open Kalshi markets.
+	kalshiPageSize = 1000
+)
+
+// KalshiMarketLister pages through Kalshi markets (kalshi.Client).
+type KalshiMarketLister interface {
+	ListMarkets(ctx context.Context, status, cursor string, limit int) ([]kalshi.KalshiMarket, string, error)
+}
+
+// VenueLinker sets venue refs on instruments (InstrumentRegistry).
+type VenueLinker interface {
+	Resolve(ctx context.Context, kind domain.IDKind, value string) (domain.Instrument, error)
+	Link(ctx context.Context, id string, kind domain.IDKind, value string) (domain.Instrument, error)
+}
+
+// MarketMatcherConfig holds the matching settings. Proposals scoring below
+// MinConfidence are not stored; those at or above AutoApprove are approved
+// without review (0 disables auto-approval). MaxMarkets caps the markets
+// read from each venue per run.
+type MarketMatcherConfig struct {
+	Interval      time.Duration
+	MinConfidence float64
+	AutoApprove   float64
+	MaxMarkets    int
+}
+
+// MarketMatcher proposes Polymarket <-> Kalshi market pairs by fuzzy
+// matching Polymarket questions and slugs against Kalshi titles: token
+// overlap, closeness of the end dates, and shared entities (names and
+// numbers). Proposals are stored with their confidence for review through
+// /api/crossmap; an approved match is linked as the kalshi_ticker of the
+// market's instrument, where cross_platform_arb picks it up.
+type MarketMatcher struct {
+	store       domain.CrossMatchStore
+	markets     domain.MarketStore
+	kalshi      KalshiMarketLister // optional; without it only review and manual matches work
+	instruments VenueLinker        // optional; without it approvals are recorded but not linked
+	cfg         MarketMatcherConfig
+	logger      *slog.Logger
+}
+
+// NewMarketMatcher creates a MarketMatcher.
+func NewMarketMatcher(store domain.CrossMatchStore, markets domain.MarketStore, cfg MarketMatcherConfig, logger *slog.Logger) *MarketMatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.MaxMarkets <= 0 {
+		cfg.MaxMarkets = 5000
+	}
+	return &MarketMatcher{
+		store:   store,
+		markets: markets,
+		cfg:     cfg,
+		logger:  logger.With(slog.String("component", "market_matcher")),
+	}
+}
+
+// WithKalshi sets the client used to list open Kalshi markets.
+func (m *MarketMatcher) WithKalshi(k KalshiMarketLister) *MarketMatcher {
+	m.kalshi = k
+	return m
+}
+
+// WithInstruments links approved matches on the market's instrument.
+func (m *MarketMatcher) WithInstruments(l VenueLinker) *MarketMatcher {
+	m.instruments = l
+	return m
+}
+
+// Run matches now and then every interval until ctx is cancelled.
+func (m *MarketMatcher) Run(ctx context.Context) error {
+	if m.kalshi == nil {
+		return fmt.Errorf("market_matcher: no kalshi client")
+	}
+	m.logger.Info("market matcher started",
+		slog.Duration("interval", m.cfg.Interval),
+		slog.Float64("min_confidence", m.cfg.MinConfidence),
+	)
+	defer m.logger.Info("market matcher stopped")
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.Match(ctx); err != nil && ctx.Err() == nil {
+			m.logger.ErrorContext(ctx, "market_matcher: match failed", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Match scores active Polymarket markets against open Kalshi markets and
+// stores the best proposal for each market that clears MinConfidence. It
+// returns the number of proposals stored.
+func (m *MarketMatcher) Match(ctx context.Context) (int, error) {
+	if m.kalshi == nil {
+		return 0, fmt.Errorf("market_matcher: no kalshi client")
+	}
+	polys, err := m.listPolymarket(ctx)
+	if err != nil {
+		return 0, err
+	}
+	kms, err := m.listKalshi(ctx)
+	if err != nil {
+		return 0, err
+	}
+	doneMarkets, doneTickers, err := m.store.Approved(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("market_matcher: %w", err)
+	}
+
+	docs := make([]matchDoc, 0, len(kms))
+	for i, km := range kms {
+		if _, ok := doneTickers[km.Ticker]; ok {
+			continue
+		}
+		d := kalshiDoc(km)
+		d.ref = i
+		docs = append(docs, d)
+	}
+	index := newMatchIndex(docs)
+
+	stored, approved := 0, 0
+	for _, mkt := range polys {
+		if ctx.Err() != nil {
+			return stored, ctx.Err()
+		}
+		if _, ok := doneMarkets[mkt.ID]; ok || !mkt.IsBinary() {
+			continue
+		}
+		pd := polymarketDoc(mkt)
+		best, scores, ok := index.best(pd)
+		if !ok || scores["confidence"] < m.cfg.MinConfidence {
+			continue
+		}
+		km := kms[best.ref]
+		match := domain.CrossMarketMatch{
+			ID:           uuid.NewString(),
+			MarketID:     mkt.ID,
+			MarketSlug:   mkt.Slug,
+			Question:     mkt.Question,
+			KalshiTicker: km.Ticker,
+			KalshiTitle:  kalshiTitle(km),
+			Confidence:   scores["confidence"],
+			Scores:       scores,
+			Status:       domain.CrossMatchPending,
+			Source:       "auto",
+			CreatedAt:    time.Now().UTC(),
+		}
+		if m.cfg.AutoApprove > 0 && match.Confidence >= m.cfg.AutoApprove {
+			now := match.CreatedAt
+			match.Status = domain.CrossMatchApproved
+			match.ReviewedAt = &now
+			saved, err := m.store.Create(ctx, match)
+			if err != nil {
+				return stored, fmt.Errorf("market_matcher: %w", err)
+			}
+			m.link(ctx, saved)
+			approved++
+		} else if err := m.store.Propose(ctx, match); err != nil {
+			return stored, fmt.Errorf("market_matcher: %w", err)
+		}
+		stored++
+	}
+	m.logger.InfoContext(ctx, "market matcher run complete",
+		slog.Int("polymarket", len(polys)),
+		slog.Int("kalshi", len(kms)),
+		slog.Int("proposed", stored),
+		slog.Int("auto_approved", approved),
+	)
+	return stored, nil
+}
+
+// List returns stored matches with status (all when empty).
+func (m *MarketMatcher) List(ctx context.Context, status domain.CrossMatchStatus, opts domain.ListOpts) ([]domain.CrossMarketMatch, error) {
+	list, err := m.store.List(ctx, status, opts)
+	if err != nil {
+		return nil, fmt.Errorf("market_matcher: list: %w", err)
+	}
+	return list, nil
+}
+
+// Review approves or rejects a stored match. Approving links the ticker on
+// the market's instrument; rejecting an approved match removes that link.
+func (m *MarketMatcher) Review(ctx context.Context, id string, status domain.CrossMatchStatus) (domain.CrossMarketMatch, error) {
+	if status != domain.CrossMatchApproved && status != domain.CrossMatchRejected {
+		return domain.CrossMarketMatch{}, fmt.Errorf("market_matcher: %w: status must be approved or rejected", domain.ErrInvalidCrossMatch)
+	}
+	prev, err := m.store.GetByID(ctx, id)
+	if err != nil {
+		return domain.CrossMarketMatch{}, fmt.Errorf("market_matcher: review %q: %w", id, err)
+	}
+	match, err := m.store.SetStatus(ctx, id, status)
+	if err != nil {
+		return domain.CrossMarketMatch{}, fmt.Errorf("market_matcher: review %q: %w", id, err)
+	}
+	switch {
+	case status == domain.CrossMatchApproved:
+		m.link(ctx, match)
+	case prev.Status == domain.CrossMatchApproved:
+		m.unlink(ctx, match)
+	}
+	m.logger.InfoContext(ctx, "market_matcher: match reviewed",
+		slog.String("id", id),
+		slog.String("market_id", match.MarketID),
+		slog.String("kalshi_ticker", match.KalshiTicker),
+		slog.String("status", string(status)),
+	)
+	return match, nil
+}
+
+// Create records a manual, approved match. market may be a Polymarket
+// market ID or slug.
+func (m *MarketMatcher) Create(ctx context.Context, market, ticker string) (domain.CrossMarketMatch, error) {
+	if market == "" || ticker == "" {
+		return domain.CrossMarketMatch{}, fmt.Errorf("market_matcher: %w: market_id and kalshi_ticker are required", domain.ErrInvalidCrossMatch)
+	}
+	mkt, err := m.markets.GetByID(ctx, market)
+	if errors.Is(err, domain.ErrNotFound) {
+		mkt, err = m.markets.GetBySlug(ctx, market)
+	}
+	if err != nil {
+		return domain.CrossMarketMatch{}, fmt.Errorf("market_matcher: market %q: %w", market, err)
+	}
+	now := time.Now().UTC()
+	match, err := m.store.Create(ctx, domain.CrossMarketMatch{
+		ID:           uuid.NewString(),
+		MarketID:     mkt.ID,
+		MarketSlug:   mkt.Slug,
+		Question:     mkt.Question,
+		KalshiTicker: ticker,
+		Confidence:   1,
+		Status:       domain.CrossMatchApproved,
+		Source:       "manual",
+		ReviewedAt:   &now,
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return domain.CrossMarketMatch{}, fmt.Errorf("market_matcher: %w", err)
+	}
+	m.link(ctx, match)
+	return match, nil
+}
+
+func (m *MarketMatcher) link(ctx context.Context, match domain.CrossMarketMatch) {
+	if m.instruments == nil {
+		return
+	}
+	inst, err := m.instruments.Resolve(ctx, domain.IDKindMarket, match.MarketID)
+	if err == nil {
+		_, err = m.instruments.Link(ctx, inst.ID, domain.IDKindKalshi, match.KalshiTicker)
+	}
+	if err != nil {
+		m.logger.WarnContext(ctx, "market_matcher: link kalshi ticker failed",
+			slog.String("market_id", match.MarketID),
+			slog.String("kalshi_ticker", match.KalshiTicker),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (m *MarketMatcher) unlink(ctx context.Context, match domain.CrossMarketMatch) {
+	if m.instruments == nil {
+		return
+	}
+	inst, err := m.instruments.Resolve(ctx, domain.IDKindMarket, match.MarketID)
+	if err != nil || inst.Ref(domain.IDKindKalshi) != match.KalshiTicker {
+		return
+	}
+	if _, err := m.instruments.Link(ctx, inst.ID, domain.IDKindKalshi, ""); err != nil {
+		m.logger.WarnContext(ctx, "market_matcher: unlink kalshi ticker failed",
+			slog.String("market_id", match.MarketID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (m *MarketMatcher) listPolymarket(ctx context.Context) ([]domain.Market, error) {
+	const pageSize = 500
+	var out []domain.Market
+	for len(out) < m.cfg.MaxMarkets {
+		page, err := m.markets.ListActive(ctx, domain.ListOpts{Limit: pageSize, Offset: len(out)})
+		if err != nil {
+			return nil, fmt.Errorf("market_matcher: list polymarket markets: %w", err)
+		}
+		out = append(out, page...)
+		if len(page) < pageSize {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (m *MarketMatcher) listKalshi(ctx context.Context) ([]kalshi.KalshiMarket, error) {
+	var out []kalshi.KalshiMarket
+	cursor := ""
+	for len(out) < m.cfg.MaxMarkets {
+		page, next, err := m.kalshi.ListMarkets(ctx, "open", cursor, kalshiPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("market_matcher: list kalshi markets: %w", err)
+		}
+		out = append(out, page...)
+		if next == "" || len(page) == 0 {
+			break
+		}
+		cursor = next
+	}
+	return out, nil
+}
+
+// --------------------------------------------------------------------------
+// Scoring
+// --------------------------------------------------------------------------
+
+// matchDoc is the normalized text of one market. ref indexes the Kalshi
+// market list for Kalshi docs.
+type matchDoc struct {
+	ref      int
+	tokens   map[string]struct{}
+	entities map[string]struct{}
+	end      *time.Time
+}
+
+func polymarketDoc(mkt domain.Market) matchDoc {
+	text := mkt.Question + " " + strings.ReplaceAll(mkt.Slug, "-", " ")
+	return matchDoc{
+		tokens:   matchTokens(text),
+		entities: matchEntities(mkt.Question),
+		end:      mkt.ClosedAt,
+	}
+}
+
+func kalshiDoc(km kalshi.KalshiMarket) matchDoc {
+	d := matchDoc{
+		tokens:   matchTokens(kalshiTitle(km)),
+		entities: matchEntities(kalshiTitle(km)),
+	}
+	for _, s := range []string{km.CloseTime, km.ExpirationTime} {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			d.end = &t
+			break
+		}
+	}
+	return d
+}
+
+// kalshiTitle is the market title plus its subtitle, which on multi-strike
+// events carries the strike ("Above 4.00%").
+func kalshiTitle(km kalshi.KalshiMarket) string {
+	if km.Subtitle == "" {
+		return km.Title
+	}
+	return km.Title + " " + km.Subtitle
+}
+
+// matchIndex is an inverted token index over Kalshi docs.
+type matchIndex struct {
+	docs     []matchDoc
+	postings map[string][]int
+}
+
+func newMatchIndex(docs []matchDoc) *matchIndex {
+	idx := &matchIndex{docs: docs, postings: make(map[string][]int)}
+	for i, d := range docs {
+		for t := range d.tokens {
+			idx.postings[t] = append(idx.postings[t], i)
+		}
+	}
+	for t, p := range idx.postings {
+		if len(p) > matchCommonToken {
+			delete(idx.postings, t)
+		}
+	}
+	return idx
+}
+
+// best returns the highest-scoring doc for q and its score components.
+func (idx *matchIndex) best(q matchDoc) (matchDoc, map[string]float64, bool) {
+	shared := make(map[int]int)
+	for t := range q.tokens {
+		for _, i := range idx.postings[t] {
+			shared[i]++
+		}
+	}
+	if len(shared) == 0 {
+		return matchDoc{}, nil, false
+	}
+	cands := make([]int, 0, len(shared))
+	for i := range shared {
+		cands = append(cands, i)
+	}
+	sort.Slice(cands, func(a, b int) bool {
+		if shared[cands[a]] != shared[cands[b]] {
+			return shared[cands[a]] > shared[cands[b]]
+		}
+		return cands[a] < cands[b]
+	})
+	if len(cands) > matchCandidates {
+		cands = cands[:matchCandidates]
+	}
+
+	var best matchDoc
+	var bestScores map[string]float64
+	for _, i := range cands {
+		d := idx.docs[i]
+		scores := scoreMatch(q, d)
+		if bestScores == nil || scores["confidence"] > bestScores["confidence"] {
+			best, bestScores = d, scores
+		}
+	}
+	return best, bestScores, true
+}
+
+// scoreMatch returns the component scores of a pair and their weighted sum
+// under "confidence".
+func scoreMatch(a, b matchDoc) map[string]float64 {
+	tokens := jaccard(a.tokens, b.tokens)
+	entities := 0.5 // neither side names anything: no evidence either way
+	if len(a.entities) > 0 || len(b.entities) > 0 {
+		entities = jaccard(a.entities, b.entities)
+	}
+	dates := dateScore(a.end, b.end)
+	conf := matchWeightTokens*tokens + matchWeightDates*dates + matchWeightEntities*entities
+	round := func(v float64) float64 { return math.Round(v*10_000) / 10_000 }
+	return map[string]float64{
+		"tokens":     round(tokens),
+		"dates":      round(dates),
+		"entities":   round(entities),
+		"confidence": round(conf),
+	}
+}
+
+// dateScore rates how close two end dates are. Venues close the same event
+// hours to a day apart, so anything within 36h counts as the same date.
+func dateScore(a, b *time.Time) float64 {
+	if a == nil || b == nil {
+		return 0.5
+	}
+	d := a.Sub(*b)
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d <= 36*time.Hour:
+		return 1
+	case d <= 7*24*time.Hour:
+		return 0.6
+	case d <= 31*24*time.Hour:
+		return 0.25
+	default:
+		return 0
+	}
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+var (
+	matchWordRe = regexp.MustCompile(`[\p{L}\p{N}]+(?:\.\d+)?`)
+	matchNumRe  = regexp.MustCompile(`^\d+(?:\.\d+)?$`)
+)
+
+var matchStopwords = map[string]struct{}{
+	"a": {}, "an": {}, "the": {}, "will": {}, "be": {}, "is": {}, "are": {}, "was": {},
+	"of": {}, "in": {}, "on": {}, "at": {}, "to": {}, "for": {}, "by": {}, "from": {},
+	"and": {}, "or": {}, "than": {}, "this": {}, "that": {}, "with": {}, "as": {},
+	"who": {}, "what": {}, "which": {}, "when": {}, "does": {}, "do": {}, "did": {},
+	"yes": {}, "no": {}, "market": {}, "before": {}, "after": {}, "end": {},
+}
+
+// matchTokens returns the lowercased content words of s.
+func matchTokens(s string) map[string]struct{} {
+	out := make(map[string]struct{})
+	for _, w := range matchWordRe.FindAllString(strings.ToLower(s), -1) {
+		if _, stop := matchStopwords[w]; stop || (len(w) < 2 && !matchNumRe.MatchString(w)) {
+			continue
+		}
+		out[w] = struct{}{}
+	}
+	return out
+}
+
+// matchEntities returns the numbers and capitalized words of s, excluding
+// its first word, which is capitalized anyway.
+func matchEntities(s string) map[string]struct{} {
+	out := make(map[string]struct{})
+	for i, w := range matchWordRe.FindAllString(s, -1) {
+		lw := strings.ToLower(w)
+		if _, stop := matchStopwords[lw]; stop {
+			continue
+		}
+		switch {
+		case matchNumRe.MatchString(w):
+			if strings.Contains(w, ".") {
+				w = strings.TrimSuffix(strings.TrimRight(w, "0"), ".") // "4.00" and "4" are one strike
+			}
+			out[w] = struct{}{}
+		case i > 0 && unicode.IsUpper([]rune(w)[0]):
+			out[lw] = struct{}{}
+		}
+	}
+	return out
+}
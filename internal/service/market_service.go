@@ -9,12 +9,18 @@ import (
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
+// InstrumentSyncer keeps instrument records in step with synced markets.
+type InstrumentSyncer interface {
+	SyncMarkets(ctx context.Context, markets []domain.Market) error
+}
+
 // MarketService handles market discovery and metadata sync.
 type MarketService struct {
-	markets domain.MarketStore
-	cache   domain.MarketCache
-	bus     domain.SignalBus
-	logger  *slog.Logger
+	markets     domain.MarketStore
+	cache       domain.MarketCache
+	bus         domain.SignalBus
+	instruments InstrumentSyncer
+	logger      *slog.Logger
 }
 
 // NewMarketService creates a MarketService with all required dependencies.
@@ -32,6 +38,12 @@ func NewMarketService(
 	}
 }
 
+// WithInstruments registers markets with the instrument registry on every sync.
+func (s *MarketService) WithInstruments(instruments InstrumentSyncer) *MarketService {
+	s.instruments = instruments
+	return s
+}
+
 // SyncMarkets upserts a batch of markets into the persistent store and
 // invalidates cached entries so subsequent reads pick up fresh data.
 func (s *MarketService) SyncMarkets(ctx context.Context, markets []domain.Market) error {
@@ -56,6 +68,15 @@ func (s *MarketService) SyncMarkets(ctx context.Context, markets []domain.Market
 		}
 	}
 
+	if s.instruments != nil {
+		if err := s.instruments.SyncMarkets(ctx, markets); err != nil {
+			// Non-fatal: instruments catch up on the next sync.
+			s.logger.WarnContext(ctx, "market_service: instrument sync failed",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
 	s.logger.InfoContext(ctx, "market_service: synced markets",
 		slog.Int("count", len(markets)),
 	)
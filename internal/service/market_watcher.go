@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// marketsChannel is the signal bus channel for market metadata changes.
+const marketsChannel = "markets"
+
+// MarketFetcher fetches current market metadata from the venue
+// (polymarket.GammaClient).
+type MarketFetcher interface {
+	GetMarket(ctx context.Context, id string) (domain.Market, error)
+}
+
+// MarketUpdateListener receives metadata changes detected by MarketWatcher
+// (RiskService, strategy.Engine).
+type MarketUpdateListener interface {
+	HandleMarketUpdate(ctx context.Context, update domain.MarketUpdate) error
+}
+
+// MarketWatcherConfig holds the fast-poll settings.
+type MarketWatcherConfig struct {
+	Wallet   string
+	Interval time.Duration
+}
+
+// MarketWatcher is the fast-poll tier for market metadata. The catalog scrape
+// refreshes every market every few minutes; MarketWatcher re-fetches only the
+// markets the wallet holds positions in, on a much shorter interval, so an
+// end date moved forward or a market paused reaches risk checks and
+// strategies before the next scrape. Changes are written to the market store,
+// published on the "markets" channel and passed to registered listeners.
+type MarketWatcher struct {
+	positions domain.PositionStore
+	markets   domain.MarketStore
+	cache     domain.MarketCache // optional
+	fetcher   MarketFetcher
+	bus       domain.SignalBus // optional
+	cfg       MarketWatcherConfig
+	logger    *slog.Logger
+
+	mu        sync.Mutex
+	listeners []MarketUpdateListener
+	known     map[string]domain.Market // market ID -> last seen metadata
+}
+
+// NewMarketWatcher creates a MarketWatcher. cache and bus may be nil.
+func NewMarketWatcher(
+	positions domain.PositionStore,
+	markets domain.MarketStore,
+	cache domain.MarketCache,
+	fetcher MarketFetcher,
+	bus domain.SignalBus,
+	cfg MarketWatcherConfig,
+	logger *slog.Logger,
+) *MarketWatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 30 * time.Second
+	}
+	return &MarketWatcher{
+		positions: positions,
+		markets:   markets,
+		cache:     cache,
+		fetcher:   fetcher,
+		bus:       bus,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "market_watcher")),
+		known:     make(map[string]domain.Market),
+	}
+}
+
+// AddListener registers l to receive every detected change. Listeners are
+// called synchronously from the poll loop and should not block.
+func (w *MarketWatcher) AddListener(l MarketUpdateListener) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, l)
+}
+
+// Run polls held markets every Interval until ctx is cancelled. Call in a
+// goroutine.
+func (w *MarketWatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	w.logger.InfoContext(ctx, "market watcher started", slog.Duration("interval", w.cfg.Interval))
+	w.Poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.Poll(ctx)
+		}
+	}
+}
+
+// Poll re-fetches every market the wallet holds an open position in and
+// emits an update for each one whose status or end date changed. It returns
+// the updates emitted.
+func (w *MarketWatcher) Poll(ctx context.Context) []domain.MarketUpdate {
+	ids, err := w.heldMarketIDs(ctx)
+	if err != nil {
+		w.logger.WarnContext(ctx, "market_watcher: list held markets failed", slog.String("error", err.Error()))
+		return nil
+	}
+
+	var updates []domain.MarketUpdate
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return updates
+		}
+		update, ok, err := w.check(ctx, id)
+		if err != nil {
+			w.logger.WarnContext(ctx, "market_watcher: check market failed",
+				slog.String("market_id", id),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if ok {
+			w.emit(ctx, update)
+			updates = append(updates, update)
+		}
+	}
+
+	// Forget markets no longer held so the map does not grow unbounded.
+	held := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		held[id] = struct{}{}
+	}
+	w.mu.Lock()
+	for id := range w.known {
+		if _, ok := held[id]; !ok {
+			delete(w.known, id)
+		}
+	}
+	w.mu.Unlock()
+	return updates
+}
+
+// heldMarketIDs returns the distinct market IDs of the wallet's open positions.
+func (w *MarketWatcher) heldMarketIDs(ctx context.Context) ([]string, error) {
+	open, err := w.positions.GetOpen(ctx, w.cfg.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(open))
+	ids := make([]string, 0, len(open))
+	for _, p := range open {
+		id := p.MarketID
+		if id == "" && p.TokenID != "" {
+			m, err := w.markets.GetByTokenID(ctx, p.TokenID)
+			if err != nil {
+				continue
+			}
+			id = m.ID
+		}
+		if id == "" {
+			continue
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// check fetches one market and diffs it against the last known state. The
+// first time a market is seen the stored row is the baseline.
+func (w *MarketWatcher) check(ctx context.Context, id string) (domain.MarketUpdate, bool, error) {
+	w.mu.Lock()
+	prev, ok := w.known[id]
+	w.mu.Unlock()
+	if !ok {
+		stored, err := w.markets.GetByID(ctx, id)
+		if err != nil {
+			return domain.MarketUpdate{}, false, fmt.Errorf("market_watcher: load market: %w", err)
+		}
+		prev = stored
+	}
+
+	fresh, err := w.fetcher.GetMarket(ctx, id)
+	if err != nil {
+		return domain.MarketUpdate{}, false, fmt.Errorf("market_watcher: fetch market: %w", err)
+	}
+	// Only the tracked fields are taken from the fetch; the rest of the row
+	// (tokens, outcomes) is owned by the catalog scrape.
+	next := prev
+	next.Status = fresh.Status
+	next.ClosedAt = fresh.ClosedAt
+
+	w.mu.Lock()
+	w.known[id] = next
+	w.mu.Unlock()
+
+	changes := domain.DiffMarket(prev, next)
+	if len(changes) == 0 {
+		return domain.MarketUpdate{}, false, nil
+	}
+	next.UpdatedAt = time.Now().UTC()
+	if err := w.markets.Upsert(ctx, next); err != nil {
+		return domain.MarketUpdate{}, false, fmt.Errorf("market_watcher: upsert market: %w", err)
+	}
+	if w.cache != nil {
+		if err := w.cache.Invalidate(ctx, id); err != nil {
+			w.logger.DebugContext(ctx, "market_watcher: cache invalidate failed",
+				slog.String("market_id", id),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return domain.MarketUpdate{
+		Market:     next,
+		Previous:   prev,
+		Changes:    changes,
+		DetectedAt: next.UpdatedAt,
+	}, true, nil
+}
+
+// emit logs, publishes and fans out one update.
+func (w *MarketWatcher) emit(ctx context.Context, update domain.MarketUpdate) {
+	w.logger.WarnContext(ctx, "market metadata changed",
+		slog.String("market_id", update.Market.ID),
+		slog.Any("changes", update.Changes),
+		slog.String("status", string(update.Market.Status)),
+		slog.String("prev_status", string(update.Previous.Status)),
+	)
+
+	if w.bus != nil {
+		payload, _ := json.Marshal(map[string]any{
+			"event":         "market_updated",
+			"market_id":     update.Market.ID,
+			"question":      update.Market.Question,
+			"changes":       update.Changes,
+			"status":        update.Market.Status,
+			"prev_status":   update.Previous.Status,
+			"end_date":      update.Market.ClosedAt,
+			"prev_end_date": update.Previous.ClosedAt,
+			"detected_at":   update.DetectedAt,
+		})
+		if err := w.bus.Publish(ctx, marketsChannel, payload); err != nil {
+			w.logger.WarnContext(ctx, "market_watcher: publish update failed", slog.String("error", err.Error()))
+		}
+	}
+
+	w.mu.Lock()
+	listeners := append([]MarketUpdateListener(nil), w.listeners...)
+	w.mu.Unlock()
+	for _, l := range listeners {
+		if err := l.HandleMarketUpdate(ctx, update); err != nil {
+			w.logger.WarnContext(ctx, "market_watcher: listener failed",
+				slog.String("market_id", update.Market.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// OrderIncrementsFetcher fetches a token's venue tick size and minimum order
+// size (polymarket.ClobClient).
+type OrderIncrementsFetcher interface {
+	GetOrderIncrements(ctx context.Context, tokenID string) (domain.OrderIncrements, error)
+}
+
+// OrderIncrementsConfig holds the order increment settings.
+type OrderIncrementsConfig struct {
+	// RefreshInterval is how long fetched increments are cached. Defaults
+	// to 1h.
+	RefreshInterval time.Duration
+	// SizeStep is the share increment sizes round down to; the venue does
+	// not publish one per token.
+	SizeStep float64
+	// DefaultTickSize and DefaultMinSize are used when the venue omits a
+	// value or cannot be reached.
+	DefaultTickSize float64
+	DefaultMinSize  float64
+}
+
+// incrementsRetryAfter is how long the defaults of a failed fetch are served
+// before the venue is asked again.
+const incrementsRetryAfter = time.Minute
+
+type cachedIncrements struct {
+	increments domain.OrderIncrements
+	expires    time.Time
+}
+
+// OrderIncrementCache serves per-token price and size increments to order
+// placement. Increments are fetched from the venue and cached per token;
+// lookups never fail and fall back to the configured defaults.
+type OrderIncrementCache struct {
+	fetcher OrderIncrementsFetcher
+	cfg     OrderIncrementsConfig
+	logger  *slog.Logger
+
+	mu    sync.Mutex
+	cache map[string]cachedIncrements // token ID -> increments
+}
+
+// NewOrderIncrementCache creates an OrderIncrementCache. fetcher may be nil,
+// in which case every token gets the defaults.
+func NewOrderIncrementCache(fetcher OrderIncrementsFetcher, cfg OrderIncrementsConfig, logger *slog.Logger) *OrderIncrementCache {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = time.Hour
+	}
+	return &OrderIncrementCache{
+		fetcher: fetcher,
+		cfg:     cfg,
+		logger:  logger.With(slog.String("component", "order_increments")),
+		cache:   make(map[string]cachedIncrements),
+	}
+}
+
+// Increments returns the tick size, size step and minimum order size of
+// tokenID.
+func (c *OrderIncrementCache) Increments(ctx context.Context, tokenID string) domain.OrderIncrements {
+	c.mu.Lock()
+	cached, ok := c.cache[tokenID]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.increments
+	}
+
+	inc, ttl := c.fetch(ctx, tokenID)
+	c.mu.Lock()
+	c.cache[tokenID] = cachedIncrements{increments: inc, expires: time.Now().Add(ttl)}
+	c.mu.Unlock()
+	return inc
+}
+
+func (c *OrderIncrementCache) fetch(ctx context.Context, tokenID string) (domain.OrderIncrements, time.Duration) {
+	inc := domain.OrderIncrements{
+		TokenID:   tokenID,
+		Source:    domain.IncrementSourceDefault,
+		FetchedAt: time.Now().UTC(),
+	}
+	ttl := min(c.cfg.RefreshInterval, incrementsRetryAfter)
+	if c.fetcher != nil {
+		venue, err := c.fetcher.GetOrderIncrements(ctx, tokenID)
+		if err != nil {
+			c.logger.WarnContext(ctx, "order increments: fetch failed, using defaults",
+				slog.String("token_id", tokenID),
+				slog.String("error", err.Error()),
+			)
+		} else {
+			inc = venue
+			ttl = c.cfg.RefreshInterval
+		}
+	}
+	if inc.TickSize <= 0 {
+		inc.TickSize = c.cfg.DefaultTickSize
+	}
+	if inc.MinOrderSize <= 0 {
+		inc.MinOrderSize = c.cfg.DefaultMinSize
+	}
+	if inc.SizeStep <= 0 {
+		inc.SizeStep = c.cfg.SizeStep
+	}
+	return inc, ttl
+}
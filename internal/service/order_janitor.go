@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// SingleOrderCanceller cancels one order on the exchange and locally,
+// publishing order_cancelled (OrderService).
+type SingleOrderCanceller interface {
+	CancelOrder(ctx context.Context, orderID string) error
+}
+
+// OrderJanitorConfig configures an OrderJanitor.
+type OrderJanitorConfig struct {
+	Wallet   string
+	Interval time.Duration // how often live orders are checked
+}
+
+// OrderJanitor cancels the wallet's resting orders once they outlive their
+// TTL (domain.Order.CancelAfter, set at placement from the signal's expiry or
+// the strategy's order TTL), so quotes left behind by a signal that has
+// expired are not picked off after the market moves. Cancellation goes
+// through OrderService, which cancels on the CLOB and marks the order
+// cancelled. GTD orders already past their venue expiration are left to the
+// venue.
+type OrderJanitor struct {
+	orders    domain.OrderStore
+	canceller SingleOrderCanceller
+	audit     domain.AuditStore // optional
+	cfg       OrderJanitorConfig
+	logger    *slog.Logger
+}
+
+// NewOrderJanitor creates an OrderJanitor. audit may be nil.
+func NewOrderJanitor(
+	orders domain.OrderStore,
+	canceller SingleOrderCanceller,
+	audit domain.AuditStore,
+	cfg OrderJanitorConfig,
+	logger *slog.Logger,
+) *OrderJanitor {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	return &OrderJanitor{
+		orders:    orders,
+		canceller: canceller,
+		audit:     audit,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "order_janitor")),
+	}
+}
+
+// Run cancels stale orders every interval until ctx is cancelled. Call in a
+// goroutine.
+func (j *OrderJanitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	j.logger.InfoContext(ctx, "order janitor started", slog.Duration("interval", j.cfg.Interval))
+	defer j.logger.InfoContext(ctx, "order janitor stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			j.Sweep(ctx, time.Now().UTC())
+		}
+	}
+}
+
+// Sweep runs one pass at now and returns the number of orders cancelled.
+func (j *OrderJanitor) Sweep(ctx context.Context, now time.Time) int {
+	open, err := j.orders.ListOpen(ctx, j.cfg.Wallet)
+	if err != nil {
+		j.logger.WarnContext(ctx, "order janitor: list open orders failed",
+			slog.String("error", err.Error()))
+		return 0
+	}
+
+	cancelled := 0
+	for _, o := range open {
+		if ctx.Err() != nil {
+			break
+		}
+		if o.CancelAfter == nil || now.Before(*o.CancelAfter) {
+			continue
+		}
+		if o.ExpiresAt != nil && !now.Before(*o.ExpiresAt) {
+			continue
+		}
+		if j.cancel(ctx, o, now) {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// cancel cancels one stale order and reports whether it was cancelled. An
+// order that filled or was cancelled meanwhile is skipped quietly.
+func (j *OrderJanitor) cancel(ctx context.Context, o domain.Order, now time.Time) bool {
+	age := now.Sub(o.CreatedAt)
+	overdue := now.Sub(*o.CancelAfter)
+	if err := j.canceller.CancelOrder(ctx, o.ID); err != nil {
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			return false
+		}
+		j.logger.WarnContext(ctx, "order janitor: cancel stale order failed",
+			slog.String("order_id", o.ID),
+			slog.String("strategy", o.Strategy),
+			slog.String("error", err.Error()),
+		)
+		return false
+	}
+
+	j.logger.InfoContext(ctx, "order janitor: stale order cancelled",
+		slog.String("order_id", o.ID),
+		slog.String("market", o.MarketID),
+		slog.String("strategy", o.Strategy),
+		slog.Duration("age", age),
+		slog.Duration("overdue", overdue),
+	)
+	if j.audit != nil {
+		if err := j.audit.Log(ctx, "stale_order_cancelled", map[string]any{
+			"order_id":     o.ID,
+			"market":       o.MarketID,
+			"strategy":     o.Strategy,
+			"cancel_after": o.CancelAfter.Format(time.RFC3339),
+			"filled_size":  o.FilledSize,
+			"age_seconds":  age.Seconds(),
+		}); err != nil {
+			j.logger.WarnContext(ctx, "order janitor: audit log failed",
+				slog.String("order_id", o.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PreviewOrder runs sig through what placing it would involve — order terms,
+// the executor's risk sizing and pre-trade checks, rounding to the token's
+// increments, the on-chain funds check —
+// and estimates its fill and fees against the cached book, without signing,
+// storing or posting anything and without using the order rate limit. Checks
+// that would refuse the order are listed in the preview; the error is
+// non-nil only when sig is not a valid order (wrapping
+// domain.ErrInvalidOrder).
+func (s *OrderService) PreviewOrder(ctx context.Context, sig domain.TradeSignal) (domain.OrderPreview, error) {
+	orderType, expiresAt, err := orderTerms(sig, time.Now().UTC())
+	if err != nil {
+		return domain.OrderPreview{}, fmt.Errorf("order_service: %w", err)
+	}
+	wallet := s.signer.Address().Hex()
+
+	p := domain.OrderPreview{
+		SignalID:      sig.ID,
+		MarketID:      sig.MarketID,
+		TokenID:       sig.TokenID,
+		Side:          sig.Side,
+		Type:          orderType,
+		ExpiresAt:     expiresAt,
+		RequestedSize: sig.Size(),
+	}
+	reject := func(err error) {
+		p.Rejections = append(p.Rejections, err.Error())
+	}
+
+	if s.risk != nil {
+		adjusted, err := s.risk.AdjustSize(ctx, sig)
+		if err != nil {
+			reject(err)
+		} else {
+			sig = adjusted
+		}
+		if err := s.risk.PreTradeCheck(ctx, sig, wallet); err != nil {
+			reject(err)
+		}
+	}
+	if normalized, _, err := s.normalize(ctx, sig); err != nil {
+		reject(err)
+	} else {
+		sig = normalized
+	}
+	if s.funds != nil {
+		if err := s.funds.HasFunds(ctx, sig); err != nil {
+			reject(err)
+		}
+	}
+
+	p.Price, p.Size = sig.Price(), sig.Size()
+	p.Notional = p.Price * p.Size
+
+	haveBook := false
+	if s.book != nil {
+		if snap, err := s.book.GetSnapshot(ctx, sig.TokenID); err == nil {
+			p.BestBid, p.BestAsk, p.BookAt = snap.BestBid, snap.BestAsk, snap.Timestamp
+			previewFill(&p, snap, reject)
+			haveBook = true
+		}
+	}
+	if !haveBook && (orderType == domain.OrderTypeGTC || orderType == domain.OrderTypeGTD) {
+		// Without a book the whole order is assumed to rest.
+		p.RestingSize = p.Size
+	}
+
+	if s.fees != nil {
+		p.TakerFeeBps = s.fees.TakerFeeBps(ctx, sig.TokenID)
+		p.MakerFeeBps = s.fees.MakerFeeBps(ctx, sig.TokenID)
+		p.FeeUSD = (p.MatchedSize*p.MatchPrice*p.TakerFeeBps + p.RestingSize*p.Price*p.MakerFeeBps) / 10_000
+	}
+
+	p.Accepted = len(p.Rejections) == 0
+	return p, nil
+}
+
+// previewFill matches p against snap: the levels on the other side at or
+// better than the limit price fill first, best first, and what is left rests
+// or, for FOK and FAK, is cancelled. A FOK order the book cannot fill
+// completely is rejected.
+func previewFill(p *domain.OrderPreview, snap domain.OrderbookSnapshot, reject func(error)) {
+	levels, touch := snap.Asks, snap.BestAsk
+	crosses := func(price float64) bool { return price <= p.Price }
+	if p.Side == domain.OrderSideSell {
+		levels, touch = snap.Bids, snap.BestBid
+		crosses = func(price float64) bool { return price >= p.Price }
+	}
+	levels = append([]domain.PriceLevel(nil), levels...)
+	sort.Slice(levels, func(i, j int) bool {
+		if p.Side == domain.OrderSideSell {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+
+	var matched, cost float64
+	for _, l := range levels {
+		if l.Size <= 0 || l.Price <= 0 {
+			continue
+		}
+		if !crosses(l.Price) || matched >= p.Size {
+			break
+		}
+		if touch <= 0 {
+			touch = l.Price
+		}
+		take := min(l.Size, p.Size-matched)
+		matched += take
+		cost += take * l.Price
+	}
+
+	if p.Type == domain.OrderTypeFOK && matched < p.Size {
+		reject(fmt.Errorf("FOK order would be killed: book holds %.2f of %.2f shares at %.4f or better", matched, p.Size, p.Price))
+		return
+	}
+	if matched > 0 {
+		p.MatchedSize = matched
+		p.MatchPrice = cost / matched
+		if touch > 0 {
+			p.SlippageBps = (p.MatchPrice - touch) / touch * 10_000
+			if p.Side == domain.OrderSideSell {
+				p.SlippageBps = -p.SlippageBps
+			}
+		}
+	}
+	if p.Type == domain.OrderTypeGTC || p.Type == domain.OrderTypeGTD {
+		p.RestingSize = p.Size - matched
+	}
+}
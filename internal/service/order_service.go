@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/crypto"
@@ -24,7 +26,49 @@ type ClobPoster interface {
 	PostOrder(ctx context.Context, order domain.Order) (domain.OrderResult, error)
 }
 
+// ClobCanceller is optionally implemented by the ClobPoster; CancelOrder then
+// cancels the order on the exchange before marking it cancelled locally.
+type ClobCanceller interface {
+	CancelOrder(ctx context.Context, orderID string) error
+}
+
+// FundsChecker refuses orders the wallet cannot settle on chain
+// (BalanceGuard). HasFunds runs the same check without reserving funds, for
+// previews.
+type FundsChecker interface {
+	CheckFunds(ctx context.Context, sig domain.TradeSignal) error
+	HasFunds(ctx context.Context, sig domain.TradeSignal) error
+}
+
+// OrderRiskChecker is the executor's sizing and pre-trade risk check
+// (RiskService), run by PreviewOrder.
+type OrderRiskChecker interface {
+	AdjustSize(ctx context.Context, signal domain.TradeSignal) (domain.TradeSignal, error)
+	PreTradeCheck(ctx context.Context, signal domain.TradeSignal, wallet string) error
+}
+
+// FeeEstimator returns a market's effective fees in bps of notional
+// (FeeModel).
+type FeeEstimator interface {
+	MakerFeeBps(ctx context.Context, id string) float64
+	TakerFeeBps(ctx context.Context, id string) float64
+}
+
+// IncrementSource returns the price and size increments a token's orders
+// must align to (OrderIncrementCache).
+type IncrementSource interface {
+	Increments(ctx context.Context, tokenID string) domain.OrderIncrements
+}
+
+// OrderLatencyRecorder receives the signing and CLOB round trip durations of
+// each order and when the venue acknowledged it (LatencyTracker).
+type OrderLatencyRecorder interface {
+	Record(id string, stage domain.LatencyStage, d time.Duration)
+	Acked(id string, at time.Time)
+}
+
 // OrderService handles the order lifecycle from signal to confirmed order.
+// Status changes follow the transitions allowed by domain.OrderStatus.
 type OrderService struct {
 	orders     domain.OrderStore
 	positions  domain.PositionStore
@@ -35,9 +79,21 @@ type OrderService struct {
 	audit      domain.AuditStore
 	signer     Signer
 	clobClient ClobPoster
+	orderRate  int // orders per second per wallet
+	orderTTLs  map[string]time.Duration // strategy -> resting order TTL; 0 exempts
+	funds      FundsChecker             // optional
+	risk       OrderRiskChecker         // optional; previews only
+	fees       FeeEstimator             // optional; previews only
+	increments IncrementSource          // optional
+	latency    OrderLatencyRecorder     // optional
+	decimals   domain.CollateralDecimals
 	logger     *slog.Logger
 }
 
+// defaultOrderRate is the per-wallet order rate when WithOrderRate is not
+// called.
+const defaultOrderRate = 10
+
 // NewOrderService creates an OrderService with all required dependencies.
 func NewOrderService(
 	orders domain.OrderStore,
@@ -59,10 +115,73 @@ func NewOrderService(
 		bus:       bus,
 		audit:     audit,
 		signer:    signer,
+		orderRate: defaultOrderRate,
 		logger:    logger,
 	}
 }
 
+// WithOrderRate sets how many orders per second one wallet may submit;
+// PlaceOrder rejects faster submissions with domain.ErrRateLimited.
+func (s *OrderService) WithOrderRate(perSecond int) *OrderService {
+	if perSecond > 0 {
+		s.orderRate = perSecond
+	}
+	return s
+}
+
+// WithOrderTTLs sets how long orders of each strategy may rest before the
+// order janitor cancels them, overriding the signal's ExpiresAt; a zero TTL
+// exempts the strategy's orders. Strategies not listed use ExpiresAt.
+func (s *OrderService) WithOrderTTLs(ttls map[string]time.Duration) *OrderService {
+	s.orderTTLs = ttls
+	return s
+}
+
+// WithFundsCheck sets the on-chain balance and allowance check; PlaceOrder
+// then refuses orders that fail it with an error wrapping
+// domain.ErrInsufficientFunds.
+func (s *OrderService) WithFundsCheck(funds FundsChecker) *OrderService {
+	s.funds = funds
+	return s
+}
+
+// WithRisk sets the risk checks PreviewOrder runs. PlaceOrder does not run
+// them; signals reach it through the executor, which does.
+func (s *OrderService) WithRisk(risk OrderRiskChecker) *OrderService {
+	s.risk = risk
+	return s
+}
+
+// WithFees sets the fee model PreviewOrder estimates fees with. Without it
+// previews report no fees.
+func (s *OrderService) WithFees(fees FeeEstimator) *OrderService {
+	s.fees = fees
+	return s
+}
+
+// WithIncrements makes PlaceOrder and PreviewOrder round each order's price
+// and size onto its token's increments and refuse orders below the minimum
+// size (see domain.OrderIncrements.Normalize).
+func (s *OrderService) WithIncrements(increments IncrementSource) *OrderService {
+	s.increments = increments
+	return s
+}
+
+// WithLatency reports how long signing and the CLOB round trip of each
+// order take, and when the CLOB acknowledged it, to l.
+func (s *OrderService) WithLatency(l OrderLatencyRecorder) *OrderService {
+	s.latency = l
+	return s
+}
+
+// WithCollateralDecimals sets the decimals of each market's collateral, which
+// scale the maker and taker amounts signed into its orders. Without it every
+// market uses domain.DefaultCollateralDecimals (USDC.e).
+func (s *OrderService) WithCollateralDecimals(d domain.CollateralDecimals) *OrderService {
+	s.decimals = d
+	return s
+}
+
 // WithClobClient attaches a CLOB poster so PlaceOrder submits orders to the
 // exchange after persisting locally. Without a CLOB client, PlaceOrder works
 // in local-only mode (useful for testing/paper trading).
@@ -74,8 +193,46 @@ func (s *OrderService) WithClobClient(poster ClobPoster) *OrderService {
 // PlaceOrder converts a TradeSignal into a signed order, persists it, publishes
 // an event on the signal bus, and writes an audit log entry.
 func (s *OrderService) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (domain.OrderResult, error) {
+	orderType, expiresAt, err := orderTerms(sig, time.Now().UTC())
+	if err != nil {
+		return domain.OrderResult{
+			Success: false,
+			Message: err.Error(),
+		}, fmt.Errorf("order_service: %w", err)
+	}
+
+	// Tick size and size increments: the CLOB rejects misaligned orders.
+	sig, rounding, err := s.normalize(ctx, sig)
+	if err != nil {
+		s.logger.WarnContext(ctx, "order_service: order rejected by increments",
+			slog.String("signal_id", sig.ID),
+			slog.String("token_id", sig.TokenID),
+			slog.String("error", err.Error()),
+		)
+		return domain.OrderResult{
+			Success: false,
+			Message: err.Error(),
+		}, fmt.Errorf("order_service: %w", err)
+	}
+
+	// On-chain funds check: the CLOB accepts orders it cannot settle.
+	if s.funds != nil {
+		if err := s.funds.CheckFunds(ctx, sig); err != nil {
+			s.logger.WarnContext(ctx, "order_service: order blocked by funds check",
+				slog.String("signal_id", sig.ID),
+				slog.String("token_id", sig.TokenID),
+				slog.String("side", string(sig.Side)),
+				slog.String("error", err.Error()),
+			)
+			return domain.OrderResult{
+				Success: false,
+				Message: err.Error(),
+			}, fmt.Errorf("order_service: %w", err)
+		}
+	}
+
 	// Rate limit check.
-	allowed, err := s.limiter.Allow(ctx, "orders:"+s.signer.Address().Hex(), 10, time.Second)
+	allowed, err := s.limiter.Allow(ctx, "orders:"+s.signer.Address().Hex(), s.orderRate, time.Second)
 	if err != nil {
 		return domain.OrderResult{}, fmt.Errorf("order_service: rate limiter: %w", err)
 	}
@@ -96,12 +253,24 @@ func (s *OrderService) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (
 		TokenID:  sig.TokenID,
 		Wallet:   wallet,
 		Side:     sig.Side,
-		Type:     domain.OrderTypeGTC,
+		Type:     orderType,
 		PriceTicks: sig.PriceTicks,
 		SizeUnits:  sig.SizeUnits,
 		Status:     domain.OrderStatusPending,
 		Strategy:   sig.Source,
 		CreatedAt:  time.Now().UTC(),
+		ExpiresAt:  expiresAt,
+	}
+	order.CancelAfter = s.cancelAfter(sig, orderType, order.CreatedAt)
+	// Maker and taker amounts as the CLOB derives them: a buy pays
+	// price * size collateral for size shares, a sell the reverse.
+	order.MakerAmount, order.TakerAmount, _, _, err = rounding.Amounts(
+		sig.Side, sig.PriceTicks, sig.SizeUnits, s.decimals.For(sig.MarketID))
+	if err != nil {
+		return domain.OrderResult{
+			Success: false,
+			Message: err.Error(),
+		}, fmt.Errorf("order_service: %w", err)
 	}
 
 	// Build the signing payload.
@@ -116,16 +285,20 @@ func (s *OrderService) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (
 		Signer:        wallet,
 		Taker:         "0x0000000000000000000000000000000000000000",
 		TokenID:       sig.TokenID,
-		MakerAmount:   fmt.Sprintf("%d", sig.PriceTicks),
-		TakerAmount:   fmt.Sprintf("%d", sig.SizeUnits),
-		Expiration:    "0",
+		MakerAmount:   order.MakerAmount.String(),
+		TakerAmount:   order.TakerAmount.String(),
+		Expiration:    orderExpiration(expiresAt),
 		Nonce:         "0",
 		FeeRateBps:    "0",
 		Side:          sideInt,
 		SignatureType: 0,
 	}
 
+	signStart := time.Now()
 	signature, err := s.signer.SignOrder(payload)
+	if s.latency != nil {
+		s.latency.Record(order.ID, domain.LatencyStageSign, time.Since(signStart))
+	}
 	if err != nil {
 		return domain.OrderResult{
 			Success: false,
@@ -144,21 +317,44 @@ func (s *OrderService) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (
 
 	// Submit to CLOB if a poster is configured.
 	if s.clobClient != nil {
+		postStart := time.Now()
 		clobResult, clobErr := s.clobClient.PostOrder(ctx, order)
+		if s.latency != nil {
+			s.latency.Record(order.ID, domain.LatencyStagePost, time.Since(postStart))
+			if clobErr == nil {
+				s.latency.Acked(order.ID, time.Now())
+			}
+		}
 		if clobErr != nil {
 			_ = s.orders.UpdateStatus(ctx, order.ID, domain.OrderStatusFailed)
+			class := domain.ClassifyError(clobErr)
+			s.publishFailure(ctx, order, class, clobErr)
 			return domain.OrderResult{
-				Success: false,
-				OrderID: order.ID,
-				Message: clobErr.Error(),
+				Success:     false,
+				OrderID:     order.ID,
+				Status:      domain.OrderStatusFailed,
+				Message:     clobErr.Error(),
+				ShouldRetry: class.Retryable(),
 			}, fmt.Errorf("order_service: clob post order: %w", clobErr)
 		}
-		// Update local order status based on CLOB response.
+		// Update local order status based on CLOB response. A resting order
+		// that matched part of its size on submission is partially filled;
+		// the fill itself is recorded when the user channel reports the trade.
+		if clobResult.Status == domain.OrderStatusOpen && clobResult.FilledSize > 0 {
+			clobResult.Status = domain.FillStatus(clobResult.FilledSize, order.Size())
+		}
 		if clobResult.Status != "" {
 			_ = s.orders.UpdateStatus(ctx, order.ID, clobResult.Status)
 		}
 		if clobResult.OrderID == "" {
 			clobResult.OrderID = order.ID
+		} else if err := s.orders.SetExchangeID(ctx, order.ID, clobResult.OrderID); err != nil {
+			// Without the hash, user-channel fills for this order cannot be matched.
+			s.logger.WarnContext(ctx, "order_service: record exchange id failed",
+				slog.String("order_id", order.ID),
+				slog.String("exchange_id", clobResult.OrderID),
+				slog.String("error", err.Error()),
+			)
 		}
 
 		// Publish order placed event.
@@ -168,6 +364,7 @@ func (s *OrderService) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (
 			"market":   order.MarketID,
 			"side":     string(order.Side),
 			"status":   string(clobResult.Status),
+			"strategy": order.Strategy,
 		})
 		if pubErr := s.bus.Publish(ctx, "orders", evt); pubErr != nil {
 			s.logger.WarnContext(ctx, "order_service: publish event failed",
@@ -208,6 +405,7 @@ func (s *OrderService) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (
 		"order_id": order.ID,
 		"market":   order.MarketID,
 		"side":     string(order.Side),
+		"strategy": order.Strategy,
 	})
 	if pubErr := s.bus.Publish(ctx, "orders", evt); pubErr != nil {
 		s.logger.WarnContext(ctx, "order_service: publish event failed",
@@ -245,17 +443,158 @@ func (s *OrderService) PlaceOrder(ctx context.Context, sig domain.TradeSignal) (
 	}, nil
 }
 
-// CancelOrder cancels a single order by updating its status and publishing
-// a cancellation event.
+// normalize rounds sig's price and size onto its token's increments, then to
+// the precision the CLOB signs them at (domain.OrderRounding), and returns
+// that rounding for the order amounts. Without WithIncrements the tick size
+// is unknown and only the finest rounding applies. sig is returned unchanged
+// when it fails either.
+func (s *OrderService) normalize(ctx context.Context, sig domain.TradeSignal) (domain.TradeSignal, domain.OrderRounding, error) {
+	var inc domain.OrderIncrements
+	priceTicks, sizeUnits := sig.PriceTicks, sig.SizeUnits
+	if s.increments != nil {
+		inc = s.increments.Increments(ctx, sig.TokenID)
+		var err error
+		if priceTicks, sizeUnits, err = inc.Normalize(sig.Side, priceTicks, sizeUnits); err != nil {
+			return sig, domain.OrderRounding{}, err
+		}
+	}
+	rounding := domain.RoundingForTick(inc.TickSize)
+	priceTicks, sizeUnits, err := rounding.Round(priceTicks, sizeUnits)
+	if err != nil {
+		return sig, domain.OrderRounding{}, err
+	}
+	if priceTicks != sig.PriceTicks || sizeUnits != sig.SizeUnits {
+		s.logger.DebugContext(ctx, "order_service: order rounded to increments",
+			slog.String("signal_id", sig.ID),
+			slog.Int64("price_ticks", sig.PriceTicks),
+			slog.Int64("rounded_price_ticks", priceTicks),
+			slog.Int64("size_units", sig.SizeUnits),
+			slog.Int64("rounded_size_units", sizeUnits),
+			slog.Float64("tick_size", inc.TickSize),
+		)
+	}
+	sig.PriceTicks, sig.SizeUnits = priceTicks, sizeUnits
+	return sig, rounding, nil
+}
+
+// gtdMinLead is the shortest lead a GTD expiration may have; the CLOB
+// rejects orders that would expire within its one-minute security threshold.
+const gtdMinLead = time.Minute
+
+// orderTerms resolves the time in force of sig (GTC when unset) and, for GTD,
+// its expiration. It returns an error wrapping domain.ErrInvalidOrder for an
+// unknown type, a GTD order without a usable expiration, or an expiration on
+// any other type.
+func orderTerms(sig domain.TradeSignal, now time.Time) (domain.OrderType, *time.Time, error) {
+	t := sig.OrderType
+	if t == "" {
+		t = domain.OrderTypeGTC
+	}
+	if !t.Valid() {
+		return "", nil, fmt.Errorf("%w: unknown order type %q", domain.ErrInvalidOrder, sig.OrderType)
+	}
+	if t != domain.OrderTypeGTD {
+		if !sig.OrderExpiration.IsZero() {
+			return "", nil, fmt.Errorf("%w: expiration is only allowed on GTD orders", domain.ErrInvalidOrder)
+		}
+		return t, nil, nil
+	}
+	if sig.OrderExpiration.IsZero() {
+		return "", nil, fmt.Errorf("%w: GTD order requires an expiration", domain.ErrInvalidOrder)
+	}
+	if sig.OrderExpiration.Before(now.Add(gtdMinLead)) {
+		return "", nil, fmt.Errorf("%w: GTD expiration must be at least %s ahead", domain.ErrInvalidOrder, gtdMinLead)
+	}
+	exp := sig.OrderExpiration.UTC().Truncate(time.Second)
+	return t, &exp, nil
+}
+
+// cancelAfter returns when a resting order placed from sig is due for
+// cancellation by the order janitor: the strategy's order TTL from now when
+// one is configured, otherwise the signal's ExpiresAt. FOK and FAK orders
+// never rest and get none.
+func (s *OrderService) cancelAfter(sig domain.TradeSignal, t domain.OrderType, now time.Time) *time.Time {
+	if t == domain.OrderTypeFOK || t == domain.OrderTypeFAK {
+		return nil
+	}
+	if ttl, ok := s.orderTTLs[sig.Source]; ok {
+		if ttl <= 0 {
+			return nil
+		}
+		at := now.Add(ttl)
+		return &at
+	}
+	if sig.ExpiresAt.IsZero() {
+		return nil
+	}
+	at := sig.ExpiresAt.UTC()
+	return &at
+}
+
+// orderExpiration formats expiresAt as the Unix-seconds string used in the
+// signed order; "0" means no expiration.
+func orderExpiration(expiresAt *time.Time) string {
+	if expiresAt == nil {
+		return "0"
+	}
+	return strconv.FormatInt(expiresAt.Unix(), 10)
+}
+
+// publishFailure reports an order the venue did not accept on the "orders"
+// channel, tagged with the error class so subscribers (notify.Dispatcher) can
+// tell an expired API key from a rejected price.
+func (s *OrderService) publishFailure(ctx context.Context, order domain.Order, class domain.ErrorClass, cause error) {
+	evt, _ := json.Marshal(map[string]string{
+		"event":       "order_failed",
+		"order_id":    order.ID,
+		"market":      order.MarketID,
+		"side":        string(order.Side),
+		"strategy":    order.Strategy,
+		"error_class": string(class),
+		"error":       cause.Error(),
+	})
+	if pubErr := s.bus.Publish(ctx, "orders", evt); pubErr != nil {
+		s.logger.WarnContext(ctx, "order_service: publish event failed",
+			slog.String("order_id", order.ID),
+			slog.String("error", pubErr.Error()),
+		)
+	}
+	s.logger.WarnContext(ctx, "order_service: clob rejected order",
+		slog.String("order_id", order.ID),
+		slog.String("error_class", string(class)),
+		slog.String("error", cause.Error()),
+	)
+}
+
+// CancelOrder cancels a live order: on the exchange first when the CLOB
+// client supports it, then locally, and publishes a cancellation event.
+// orderID may be the local or the exchange ID. An order that already
+// filled, expired or was cancelled returns an error wrapping
+// domain.ErrInvalidTransition.
 func (s *OrderService) CancelOrder(ctx context.Context, orderID string) error {
-	if err := s.orders.UpdateStatus(ctx, orderID, domain.OrderStatusCancelled); err != nil {
+	order, err := s.lookup(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("order_service: cancel order %q: %w", orderID, err)
+	}
+	if !order.Status.CanTransition(domain.OrderStatusCancelled) {
+		return fmt.Errorf("order_service: cancel order %q: %w: order is %s",
+			orderID, domain.ErrInvalidTransition, order.Status)
+	}
+	if canceller, ok := s.clobClient.(ClobCanceller); ok && order.ExchangeID != "" {
+		if err := canceller.CancelOrder(ctx, order.ExchangeID); err != nil {
+			return fmt.Errorf("order_service: cancel order %q on clob: %w", orderID, err)
+		}
+	}
+	if err := s.orders.UpdateStatus(ctx, order.ID, domain.OrderStatusCancelled); err != nil {
 		return fmt.Errorf("order_service: cancel order %q: %w", orderID, err)
 	}
 
-	// Publish cancellation event.
+	// Publish cancellation event. The market lets listeners such as the
+	// cancel ratio tracker attribute the cancel.
 	evt, _ := json.Marshal(map[string]string{
 		"event":    "order_cancelled",
 		"order_id": orderID,
+		"market":   order.MarketID,
 	})
 	if pubErr := s.bus.Publish(ctx, "orders", evt); pubErr != nil {
 		s.logger.WarnContext(ctx, "order_service: publish cancel event failed",
@@ -266,7 +605,8 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID string) error {
 
 	// Audit log.
 	if auditErr := s.audit.Log(ctx, "order_cancelled", map[string]any{
-		"order_id": orderID,
+		"order_id":    orderID,
+		"filled_size": order.FilledSize,
 	}); auditErr != nil {
 		s.logger.WarnContext(ctx, "order_service: audit log failed",
 			slog.String("order_id", orderID),
@@ -276,15 +616,27 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderID string) error {
 
 	s.logger.InfoContext(ctx, "order_service: order cancelled",
 		slog.String("order_id", orderID),
+		slog.String("previous_status", string(order.Status)),
 	)
 
 	return nil
 }
 
+// lookup finds an order by local ID, then by exchange ID; strategies only
+// see the ID PlaceOrder returned, which is the exchange's when it assigned one.
+func (s *OrderService) lookup(ctx context.Context, id string) (domain.Order, error) {
+	order, err := s.orders.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		order, err = s.orders.GetByExchangeID(ctx, id)
+	}
+	return order, err
+}
+
 // ReplaceOrder atomically cancels the existing order and places a new one.
-// Used by liquidity_provider strategy for requoting.
+// Used by liquidity_provider strategy for requoting. An order that already
+// filled or went away needs no cancel, so the new one is placed regardless.
 func (s *OrderService) ReplaceOrder(ctx context.Context, cancelID string, newSig domain.TradeSignal) (domain.OrderResult, error) {
-	if err := s.CancelOrder(ctx, cancelID); err != nil {
+	if err := s.CancelOrder(ctx, cancelID); err != nil && !errors.Is(err, domain.ErrInvalidTransition) {
 		return domain.OrderResult{}, fmt.Errorf("order_service: replace order cancel leg failed: %w", err)
 	}
 	return s.PlaceOrder(ctx, newSig)
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PerformanceConfig configures a PerformanceService.
+type PerformanceConfig struct {
+	Interval     time.Duration // how often today's and yesterday's rows are recomputed
+	BackfillDays int           // days recomputed when Run starts
+	WindowDays   []int         // report windows, in days
+	TakerFeeBps  float64       // venue taker fee charged on FOK/FAK fills
+}
+
+// PerformanceService attributes trading results to strategies. Run derives
+// one row per strategy and UTC day from orders, positions, arb executions and
+// recorded signals and persists it; reports over multi-day windows are
+// aggregated from the persisted rows.
+type PerformanceService struct {
+	store  domain.PerformanceStore
+	cfg    PerformanceConfig
+	logger *slog.Logger
+}
+
+// NewPerformanceService creates a PerformanceService.
+func NewPerformanceService(store domain.PerformanceStore, cfg PerformanceConfig, logger *slog.Logger) *PerformanceService {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Hour
+	}
+	if cfg.BackfillDays <= 0 {
+		cfg.BackfillDays = 30
+	}
+	if len(cfg.WindowDays) == 0 {
+		cfg.WindowDays = []int{1, 7, 30}
+	}
+	return &PerformanceService{
+		store:  store,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "performance")),
+	}
+}
+
+// Run recomputes the last BackfillDays days, then today and yesterday every
+// interval, until ctx is cancelled. Yesterday is repeated so fills and
+// closes recorded after midnight are not lost. Call in a goroutine.
+func (s *PerformanceService) Run(ctx context.Context) error {
+	s.logger.InfoContext(ctx, "performance attribution started",
+		slog.Duration("interval", s.cfg.Interval),
+		slog.Int("backfill_days", s.cfg.BackfillDays),
+	)
+	defer s.logger.InfoContext(ctx, "performance attribution stopped")
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	days := s.cfg.BackfillDays
+	for {
+		if n, err := s.Refresh(ctx, days); err != nil {
+			s.logger.ErrorContext(ctx, "performance: refresh failed", slog.String("error", err.Error()))
+		} else {
+			s.logger.DebugContext(ctx, "performance: refreshed", slog.Int("days", days), slog.Int("rows", n))
+		}
+		days = 2
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh recomputes and stores the rows of the last days UTC days, today
+// included, and returns how many rows were written.
+func (s *PerformanceService) Refresh(ctx context.Context, days int) (int, error) {
+	until := startOfDay(time.Now()).AddDate(0, 0, 1)
+	rows, err := s.store.ComputeDays(ctx, until.AddDate(0, 0, -days), until)
+	if err != nil {
+		return 0, fmt.Errorf("performance: compute days: %w", err)
+	}
+	now := time.Now().UTC()
+	for i := range rows {
+		rows[i].FeesUSD = rows[i].ArbFeesUSD + rows[i].TakerNotional*s.cfg.TakerFeeBps/10000
+		rows[i].ComputedAt = now
+	}
+	if err := s.store.UpsertDays(ctx, rows); err != nil {
+		return 0, fmt.Errorf("performance: store days: %w", err)
+	}
+	return len(rows), nil
+}
+
+// Windows returns the configured report windows in days.
+func (s *PerformanceService) Windows() []int {
+	return s.cfg.WindowDays
+}
+
+// Daily returns the persisted rows of the days in [from, to) for strategy
+// (every strategy when empty), oldest first.
+func (s *PerformanceService) Daily(ctx context.Context, strategy string, from, to time.Time) ([]domain.StrategyDay, error) {
+	days, err := s.store.ListDays(ctx, strategy, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("performance: list days: %w", err)
+	}
+	return days, nil
+}
+
+// Strategies aggregates each strategy's persisted rows over the last days UTC
+// days, today included. strategy narrows the report to one strategy when set.
+func (s *PerformanceService) Strategies(ctx context.Context, days int, strategy string) (domain.PerformanceWindow, error) {
+	until := startOfDay(time.Now()).AddDate(0, 0, 1)
+	since := until.AddDate(0, 0, -days)
+	rows, err := s.Daily(ctx, strategy, since, until)
+	if err != nil {
+		return domain.PerformanceWindow{}, err
+	}
+
+	type acc struct {
+		perf                                          domain.StrategyPerformance
+		closedNotional, expectedEdgeUSD, edgeNotional float64
+		daily                                         []float64 // realized PnL per day of the window
+	}
+	byStrategy := make(map[string]*acc)
+	for _, r := range rows {
+		a, ok := byStrategy[r.Strategy]
+		if !ok {
+			a = &acc{
+				perf:  domain.StrategyPerformance{Strategy: r.Strategy},
+				daily: make([]float64, days),
+			}
+			byStrategy[r.Strategy] = a
+		}
+		p := &a.perf
+		p.Closed += r.Closed
+		p.Wins += r.Wins
+		p.RealizedPnL += r.RealizedPnL
+		p.Orders += r.Orders
+		p.FilledOrders += r.FilledOrders
+		p.FilledNotional += r.FilledNotional
+		p.FeesUSD += r.FeesUSD
+		p.ArbExecutions += r.ArbExecutions
+		a.closedNotional += r.ClosedNotional
+		a.expectedEdgeUSD += r.ExpectedEdgeUSD
+		a.edgeNotional += r.EdgeNotional
+		if i := int(r.Day.Sub(since) / (24 * time.Hour)); i >= 0 && i < days {
+			a.daily[i] += r.RealizedPnL
+		}
+	}
+
+	out := make([]domain.StrategyPerformance, 0, len(byStrategy))
+	for _, a := range byStrategy {
+		p := a.perf
+		if a.edgeNotional > 0 {
+			p.ExpectedEdgeBps = a.expectedEdgeUSD / a.edgeNotional * 10000
+		}
+		if a.closedNotional > 0 {
+			p.CapturedEdgeBps = p.RealizedPnL / a.closedNotional * 10000
+		}
+		p.Sharpe, p.MaxDrawdown = dailyRiskStats(a.daily)
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Strategy < out[j].Strategy })
+	return domain.PerformanceWindow{Days: days, Since: since, Until: until, Strategies: out}, nil
+}
+
+// dailyRiskStats returns the annualized Sharpe ratio (sample standard
+// deviation, zero risk-free rate) and the maximum drawdown of a daily PnL
+// series. The Sharpe ratio is 0 for fewer than two days or a flat series.
+func dailyRiskStats(pnl []float64) (sharpe, maxDrawdown float64) {
+	var cum, peak float64
+	for _, v := range pnl {
+		cum += v
+		peak = max(peak, cum)
+		maxDrawdown = max(maxDrawdown, peak-cum)
+	}
+	n := float64(len(pnl))
+	if n < 2 {
+		return 0, maxDrawdown
+	}
+	mean := cum / n
+	var ss float64
+	for _, v := range pnl {
+		ss += (v - mean) * (v - mean)
+	}
+	sd := math.Sqrt(ss / (n - 1))
+	if sd == 0 {
+		return 0, maxDrawdown
+	}
+	return mean / sd * math.Sqrt(365), maxDrawdown
+}
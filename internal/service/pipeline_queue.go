@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PipelineJob runs one pipeline cycle and returns per-stage counters.
+type PipelineJob func(ctx context.Context) (map[string]int64, error)
+
+// PipelineQueue turns pipeline triggers into persisted run records and
+// executes them one at a time. Triggers made while a run is already queued
+// collapse into that run, so a burst of requests costs one cycle.
+type PipelineQueue struct {
+	runs   domain.PipelineRunStore
+	logger *slog.Logger
+	wake   chan struct{}
+}
+
+// NewPipelineQueue creates a PipelineQueue.
+func NewPipelineQueue(runs domain.PipelineRunStore, logger *slog.Logger) *PipelineQueue {
+	return &PipelineQueue{
+		runs:   runs,
+		logger: logger.With(slog.String("component", "pipeline_queue")),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Trigger queues a run requested by trigger (e.g. "api"). If a run is
+// already queued it is returned instead and deduplicated is true.
+func (q *PipelineQueue) Trigger(ctx context.Context, trigger string) (run domain.PipelineRun, deduplicated bool, err error) {
+	run = domain.PipelineRun{
+		ID:          uuid.NewString(),
+		Trigger:     trigger,
+		Status:      domain.PipelineRunQueued,
+		Stats:       map[string]int64{},
+		RequestedAt: time.Now().UTC(),
+	}
+	err = q.runs.Enqueue(ctx, run)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		queued, qerr := q.runs.Queued(ctx)
+		if qerr == nil {
+			return queued, true, nil
+		}
+		if !errors.Is(qerr, domain.ErrNotFound) {
+			return domain.PipelineRun{}, false, fmt.Errorf("pipeline_queue: get queued run: %w", qerr)
+		}
+		// The queued run was claimed in between; queue a fresh one.
+		err = q.runs.Enqueue(ctx, run)
+	}
+	if err != nil {
+		return domain.PipelineRun{}, false, fmt.Errorf("pipeline_queue: enqueue: %w", err)
+	}
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return run, false, nil
+}
+
+// Get returns a run by ID.
+func (q *PipelineQueue) Get(ctx context.Context, id string) (domain.PipelineRun, error) {
+	return q.runs.GetByID(ctx, id)
+}
+
+// List returns the most recent runs, newest first.
+func (q *PipelineQueue) List(ctx context.Context, limit int) ([]domain.PipelineRun, error) {
+	return q.runs.List(ctx, limit)
+}
+
+// Run executes queued runs with job until ctx is cancelled. It wakes on each
+// trigger and also polls every poll interval so runs queued by another
+// process, or left queued across a restart, are picked up. Runs still marked
+// running from a previous process are failed on start.
+func (q *PipelineQueue) Run(ctx context.Context, job PipelineJob, poll time.Duration) error {
+	if poll <= 0 {
+		poll = 30 * time.Second
+	}
+	if n, err := q.runs.FailRunning(ctx, "interrupted by restart"); err != nil {
+		q.logger.WarnContext(ctx, "pipeline_queue: fail stale runs failed", slog.String("error", err.Error()))
+	} else if n > 0 {
+		q.logger.WarnContext(ctx, "pipeline_queue: marked interrupted runs as failed", slog.Int64("count", n))
+	}
+
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		q.drain(ctx, job)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain runs queued runs until the queue is empty.
+func (q *PipelineQueue) drain(ctx context.Context, job PipelineJob) {
+	for ctx.Err() == nil {
+		run, err := q.runs.ClaimNext(ctx)
+		if errors.Is(err, domain.ErrNotFound) {
+			return
+		}
+		if err != nil {
+			q.logger.WarnContext(ctx, "pipeline_queue: claim run failed", slog.String("error", err.Error()))
+			return
+		}
+
+		q.logger.InfoContext(ctx, "pipeline run started",
+			slog.String("run_id", run.ID),
+			slog.String("trigger", run.Trigger),
+		)
+		stats, jobErr := job(ctx)
+		finished := time.Now().UTC()
+		run.FinishedAt = &finished
+		run.Stats = stats
+		if run.Stats == nil {
+			run.Stats = map[string]int64{}
+		}
+		if jobErr != nil {
+			run.Status = domain.PipelineRunFailed
+			run.Error = jobErr.Error()
+		} else {
+			run.Status = domain.PipelineRunDone
+		}
+		// Record the outcome even if shutdown cancelled ctx mid-run.
+		finishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		if err := q.runs.Finish(finishCtx, run); err != nil {
+			q.logger.WarnContext(ctx, "pipeline_queue: record run outcome failed",
+				slog.String("run_id", run.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+		cancel()
+
+		attrs := []any{
+			slog.String("run_id", run.ID),
+			slog.String("status", string(run.Status)),
+			slog.Any("stats", run.Stats),
+		}
+		if jobErr != nil {
+			q.logger.ErrorContext(ctx, "pipeline run failed", append(attrs, slog.String("error", jobErr.Error()))...)
+		} else {
+			q.logger.InfoContext(ctx, "pipeline run finished", attrs...)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// riskChannel is the signal bus channel for kill switch events.
+const riskChannel = "risk"
+
+// OrderCanceller cancels all open orders for a wallet (OrderService).
+type OrderCanceller interface {
+	CancelAll(ctx context.Context, wallet string) error
+}
+
+// PortfolioRiskConfig holds the portfolio-level limits.
+type PortfolioRiskConfig struct {
+	Wallet string
+	// DailyLossLimitUSD trips the kill switch when today's PnL (realized
+	// today plus the change in unrealized since the first check of the UTC
+	// day) falls to -DailyLossLimitUSD. 0 disables the kill switch;
+	// exposure and PnL are still tracked.
+	DailyLossLimitUSD float64
+	CheckInterval     time.Duration
+}
+
+// PortfolioRiskManager tracks aggregate exposure and PnL across all open
+// positions of the trading wallet. When the daily loss limit is breached it
+// trips a global kill switch: all open orders are cancelled and the executor
+// rejects every new signal until the switch is reset manually. With a store,
+// the trip and the day's opening mark survive restarts.
+type PortfolioRiskManager struct {
+	positions domain.PositionStore
+	prices    domain.PriceCache
+	orders    OrderCanceller         // optional
+	bus       domain.SignalBus       // optional
+	notifier  AlertNotifier          // optional
+	store     domain.KillSwitchStore // optional
+	cfg       PortfolioRiskConfig
+	logger    *slog.Logger
+
+	mu    sync.RWMutex
+	state domain.PortfolioRiskState
+	// baseline is the PnL DailyPnL is measured from: realized since the
+	// start of the UTC day plus unrealized since entry, as marked at the
+	// first check of the day and again at each reset.
+	baseline float64
+	day      time.Time // UTC day the baseline applies to
+}
+
+// NewPortfolioRiskManager creates a PortfolioRiskManager. orders, bus,
+// notifier and store may be nil. The kill switch saved in store is restored,
+// so a switch tripped before a restart stays tripped until Reset.
+func NewPortfolioRiskManager(
+	positions domain.PositionStore,
+	prices domain.PriceCache,
+	orders OrderCanceller,
+	bus domain.SignalBus,
+	notifier AlertNotifier,
+	store domain.KillSwitchStore,
+	cfg PortfolioRiskConfig,
+	logger *slog.Logger,
+) *PortfolioRiskManager {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = 15 * time.Second
+	}
+	m := &PortfolioRiskManager{
+		positions: positions,
+		prices:    prices,
+		orders:    orders,
+		bus:       bus,
+		notifier:  notifier,
+		store:     store,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "portfolio_risk")),
+		state:     domain.PortfolioRiskState{DailyLossLimit: cfg.DailyLossLimitUSD},
+	}
+	if store != nil {
+		m.restore()
+	}
+	return m
+}
+
+// restore loads the saved kill switch. If it cannot be read the switch starts
+// tripped, so an unreadable store never re-arms trading; Reset re-arms it.
+func (m *PortfolioRiskManager) restore() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	saved, err := m.store.Get(ctx, m.cfg.Wallet)
+	if errors.Is(err, domain.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		now := time.Now().UTC()
+		m.state.Tripped = true
+		m.state.TripReason = "kill switch state unavailable at startup: " + err.Error()
+		m.state.TrippedAt = &now
+		m.logger.Error("portfolio_risk: load kill switch failed, trading halted until reset", slog.String("error", err.Error()))
+		return
+	}
+	m.state.Tripped = saved.Tripped
+	m.state.TripReason = saved.TripReason
+	m.state.TrippedAt = saved.TrippedAt
+	m.state.ResetAt = saved.ResetAt
+	m.baseline = saved.Baseline
+	m.day = saved.Day
+	if saved.Tripped {
+		m.logger.Warn("portfolio kill switch restored tripped", slog.String("reason", saved.TripReason))
+	}
+}
+
+// save persists the kill switch, if a store is set.
+func (m *PortfolioRiskManager) save(ctx context.Context, saved domain.KillSwitchState) {
+	if m.store == nil {
+		return
+	}
+	if err := m.store.Save(ctx, saved); err != nil {
+		m.logger.ErrorContext(ctx, "portfolio_risk: save kill switch failed", slog.String("error", err.Error()))
+	}
+}
+
+// killSwitchLocked returns the kill switch state to persist. m.mu must be
+// held.
+func (m *PortfolioRiskManager) killSwitchLocked() domain.KillSwitchState {
+	return domain.KillSwitchState{
+		Wallet:     m.cfg.Wallet,
+		Tripped:    m.state.Tripped,
+		TripReason: m.state.TripReason,
+		TrippedAt:  m.state.TrippedAt,
+		ResetAt:    m.state.ResetAt,
+		Baseline:   m.baseline,
+		Day:        m.day,
+	}
+}
+
+// Run re-evaluates the portfolio every CheckInterval and whenever a position
+// opens or closes, until ctx is cancelled. Call in a goroutine.
+func (m *PortfolioRiskManager) Run(ctx context.Context) error {
+	var positionEvents <-chan []byte
+	if m.bus != nil {
+		ch, err := m.bus.Subscribe(ctx, "positions")
+		if err != nil {
+			return fmt.Errorf("portfolio_risk: subscribe positions: %w", err)
+		}
+		positionEvents = ch
+	}
+
+	ticker := time.NewTicker(m.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	m.logger.InfoContext(ctx, "portfolio risk manager started",
+		slog.Float64("daily_loss_limit_usd", m.cfg.DailyLossLimitUSD),
+	)
+	m.check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.check(ctx)
+		case _, ok := <-positionEvents:
+			if !ok {
+				positionEvents = nil
+				continue
+			}
+			m.check(ctx)
+		}
+	}
+}
+
+// State returns the latest portfolio snapshot.
+func (m *PortfolioRiskManager) State() domain.PortfolioRiskState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// Halted reports whether the kill switch is tripped. The executor checks it
+// before every signal.
+func (m *PortfolioRiskManager) Halted() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state.Tripped
+}
+
+// Reset re-arms the kill switch. Today's current PnL becomes the new
+// baseline, so trading resumes with a full daily loss allowance. A reset
+// after the last check of an earlier day leaves the baseline to the next
+// check, which marks it afresh for the new day.
+func (m *PortfolioRiskManager) Reset(ctx context.Context) domain.PortfolioRiskState {
+	now := time.Now().UTC()
+	m.mu.Lock()
+	wasTripped := m.state.Tripped
+	if m.day.Equal(startOfDay(now)) {
+		m.baseline += m.state.DailyPnL
+	}
+	m.state.DailyPnL = 0
+	m.state.Tripped = false
+	m.state.TripReason = ""
+	m.state.TrippedAt = nil
+	m.state.ResetAt = &now
+	state := m.state
+	saved := m.killSwitchLocked()
+	m.mu.Unlock()
+
+	m.save(ctx, saved)
+	m.logger.WarnContext(ctx, "portfolio kill switch reset", slog.Bool("was_tripped", wasTripped))
+	m.publish(ctx, "kill_switch_reset", state)
+	m.notify(ctx, "Kill switch reset", "Trading resumed; daily loss allowance restored.")
+	return state
+}
+
+// SetDailyLossLimit changes the daily loss limit; 0 disables the kill
+// switch. It takes effect at the next check and does not re-arm a tripped
+// switch.
+func (m *PortfolioRiskManager) SetDailyLossLimit(usd float64) {
+	m.mu.Lock()
+	m.cfg.DailyLossLimitUSD = usd
+	m.state.DailyLossLimit = usd
+	m.mu.Unlock()
+}
+
+// check recomputes exposure and PnL and trips the kill switch if needed.
+func (m *PortfolioRiskManager) check(ctx context.Context) {
+	open, err := m.positions.GetOpen(ctx, m.cfg.Wallet)
+	if err != nil {
+		m.logger.WarnContext(ctx, "portfolio_risk: get open positions failed", slog.String("error", err.Error()))
+		return
+	}
+	now := time.Now().UTC()
+	realized, err := m.positions.RealizedPnLSince(ctx, m.cfg.Wallet, startOfDay(now))
+	if err != nil {
+		m.logger.WarnContext(ctx, "portfolio_risk: realized pnl failed", slog.String("error", err.Error()))
+		return
+	}
+
+	tokenIDs := make([]string, 0, len(open))
+	for _, p := range open {
+		tokenIDs = append(tokenIDs, p.TokenID)
+	}
+	marks := map[string]float64{}
+	if len(tokenIDs) > 0 && m.prices != nil {
+		if got, err := m.prices.GetPrices(ctx, tokenIDs); err == nil {
+			marks = got
+		}
+	}
+
+	var exposure, unrealized float64
+	for _, p := range open {
+		mark := marks[p.TokenID]
+		if mark <= 0 {
+			mark = p.EntryPrice // no live price: assume flat
+		}
+		exposure += math.Abs(p.Size * mark)
+		switch p.Direction {
+		case domain.OrderSideBuy:
+			unrealized += (mark - p.EntryPrice) * p.Size
+		case domain.OrderSideSell:
+			unrealized += (p.EntryPrice - mark) * p.Size
+		}
+	}
+
+	m.mu.Lock()
+	marked := !m.day.Equal(startOfDay(now))
+	if marked {
+		// New day: mark the open positions, so losses they built up on
+		// earlier days do not count against today's limit. Realized PnL is
+		// already measured from the start of the day.
+		m.baseline = unrealized
+		m.day = startOfDay(now)
+	}
+	m.state.OpenPositions = len(open)
+	m.state.Exposure = exposure
+	m.state.UnrealizedPnL = unrealized
+	m.state.RealizedPnL = realized
+	m.state.DailyPnL = realized + unrealized - m.baseline
+	m.state.UpdatedAt = now
+	trip := m.cfg.DailyLossLimitUSD > 0 && !m.state.Tripped && m.state.DailyPnL <= -m.cfg.DailyLossLimitUSD
+	if trip {
+		m.state.Tripped = true
+		m.state.TripReason = fmt.Sprintf("daily loss %.2f USD exceeds limit %.2f USD", -m.state.DailyPnL, m.cfg.DailyLossLimitUSD)
+		m.state.TrippedAt = &now
+	}
+	state := m.state
+	saved := m.killSwitchLocked()
+	m.mu.Unlock()
+
+	if marked || trip {
+		m.save(ctx, saved)
+	}
+	if trip {
+		m.trip(ctx, state)
+	}
+}
+
+// trip cancels all open orders and announces the halt.
+func (m *PortfolioRiskManager) trip(ctx context.Context, state domain.PortfolioRiskState) {
+	m.logger.ErrorContext(ctx, "portfolio kill switch tripped",
+		slog.String("reason", state.TripReason),
+		slog.Float64("daily_pnl", state.DailyPnL),
+		slog.Float64("exposure", state.Exposure),
+	)
+	if m.orders != nil {
+		if err := m.orders.CancelAll(ctx, m.cfg.Wallet); err != nil {
+			m.logger.ErrorContext(ctx, "portfolio_risk: cancel-all failed", slog.String("error", err.Error()))
+		}
+	}
+	m.publish(ctx, "kill_switch_tripped", state)
+	m.notify(ctx, "Kill switch tripped",
+		fmt.Sprintf("%s\nOpen orders cancelled; executor halted until reset.\nExposure: %.2f USD", state.TripReason, state.Exposure))
+}
+
+func (m *PortfolioRiskManager) publish(ctx context.Context, event string, state domain.PortfolioRiskState) {
+	if m.bus == nil {
+		return
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"event":     event,
+		"reason":    state.TripReason,
+		"daily_pnl": state.DailyPnL,
+		"exposure":  state.Exposure,
+	})
+	if err := m.bus.Publish(ctx, riskChannel, payload); err != nil {
+		m.logger.WarnContext(ctx, "portfolio_risk: publish event failed",
+			slog.String("event", event),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (m *PortfolioRiskManager) notify(ctx context.Context, title, message string) {
+	if m.notifier == nil {
+		return
+	}
+	if err := m.notifier.Notify(ctx, "kill_switch", title, message); err != nil {
+		m.logger.WarnContext(ctx, "portfolio_risk: notify failed", slog.String("error", err.Error()))
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, mo, d := t.UTC().Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+}
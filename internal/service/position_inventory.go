@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PositionInventory reports the wallet's net share inventory per market from
+// its open positions, for liquidity_provider quote skew. Open positions are
+// read at most once per refresh interval, so it can be asked on every book
+// update.
+type PositionInventory struct {
+	positions domain.PositionStore
+	wallet    string
+	refresh   time.Duration
+	logger    *slog.Logger
+
+	mu       sync.Mutex
+	loadedAt time.Time
+	byMarket map[string][]domain.Position
+}
+
+// NewPositionInventory creates a PositionInventory for wallet. refresh
+// defaults to 10s.
+func NewPositionInventory(positions domain.PositionStore, wallet string, refresh time.Duration, logger *slog.Logger) *PositionInventory {
+	if refresh <= 0 {
+		refresh = 10 * time.Second
+	}
+	return &PositionInventory{
+		positions: positions,
+		wallet:    wallet,
+		refresh:   refresh,
+		logger:    logger.With(slog.String("component", "position_inventory")),
+	}
+}
+
+// Inventory returns the net shares of tokenID held in marketID: shares
+// bought minus shares sold short, less the net shares of the market's other
+// outcome tokens, which are exposure the other way. When open positions
+// cannot be read the last loaded inventory is used; the error is returned
+// only if none was ever loaded. It implements strategy.InventoryReader.
+func (p *PositionInventory) Inventory(ctx context.Context, marketID, tokenID string) (float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Since(p.loadedAt) >= p.refresh {
+		open, err := p.positions.GetOpen(ctx, p.wallet)
+		if err != nil {
+			if p.byMarket == nil {
+				return 0, fmt.Errorf("position_inventory: open positions: %w", err)
+			}
+			p.logger.WarnContext(ctx, "position inventory: refresh failed, using last inventory",
+				slog.String("error", err.Error()))
+		} else {
+			p.byMarket = make(map[string][]domain.Position)
+			for _, pos := range open {
+				p.byMarket[pos.MarketID] = append(p.byMarket[pos.MarketID], pos)
+			}
+		}
+		// A failed read is retried after the next interval, not on every call.
+		p.loadedAt = time.Now()
+	}
+
+	net := 0.0
+	for _, pos := range p.byMarket[marketID] {
+		shares := pos.Size
+		if pos.Direction == domain.OrderSideSell {
+			shares = -shares
+		}
+		if pos.TokenID != tokenID {
+			shares = -shares
+		}
+		net += shares
+	}
+	return net, nil
+}
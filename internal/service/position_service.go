@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
@@ -143,6 +144,7 @@ func (s *PositionService) ClosePosition(ctx context.Context, posID string, exitP
 	case domain.OrderSideSell:
 		realizedPnL = (pos.EntryPrice - exitPrice) * pos.Size
 	}
+	realizedPnL += pos.RealizedPnL // booked by earlier partial reductions
 
 	if err := s.positions.Close(ctx, posID, exitPrice); err != nil {
 		return fmt.Errorf("position_service: close position %q: %w", posID, err)
@@ -155,6 +157,7 @@ func (s *PositionService) ClosePosition(ctx context.Context, posID string, exitP
 		"market":       pos.MarketID,
 		"exit_price":   exitPrice,
 		"realized_pnl": realizedPnL,
+		"strategy":     pos.Strategy,
 	})
 	if pubErr := s.bus.Publish(ctx, "positions", evt); pubErr != nil {
 		s.logger.WarnContext(ctx, "position_service: publish close event failed",
@@ -187,6 +190,86 @@ func (s *PositionService) ClosePosition(ctx context.Context, posID string, exitP
 	return nil
 }
 
+// positionEpsilon is the size below which a position is treated as flat.
+const positionEpsilon = 1e-9
+
+// ApplyFill updates the wallet's position in the order's token for a fill of
+// size at price. A fill in the position's direction (or with no position)
+// opens or adds to it at the size-weighted entry price; an opposite fill
+// reduces it, booking realized PnL, and closes it when fully offset. Sells
+// with no open position are ignored since only tracked inventory is modelled.
+func (s *PositionService) ApplyFill(ctx context.Context, order domain.Order, price, size float64) error {
+	if size <= 0 {
+		return nil
+	}
+	open, err := s.positions.GetOpen(ctx, order.Wallet)
+	if err != nil {
+		return fmt.Errorf("position_service: get open for fill: %w", err)
+	}
+	var pos *domain.Position
+	for i := range open {
+		if open[i].TokenID == order.TokenID {
+			pos = &open[i]
+			break
+		}
+	}
+
+	switch {
+	case pos == nil && order.Side == domain.OrderSideSell:
+		s.logger.DebugContext(ctx, "position_service: sell fill without open position ignored",
+			slog.String("order_id", order.ID),
+			slog.String("token_id", order.TokenID),
+		)
+		return nil
+	case pos == nil:
+		filled := order
+		filled.SizeUnits = domain.ToSizeUnits(size)
+		_, err := s.OpenPosition(ctx, filled, price)
+		return err
+	case pos.Direction == order.Side:
+		total := pos.Size + size
+		pos.EntryPrice = (pos.EntryPrice*pos.Size + price*size) / total
+		pos.Size = total
+	case size >= pos.Size-positionEpsilon:
+		return s.ClosePosition(ctx, pos.ID, price)
+	default:
+		switch pos.Direction {
+		case domain.OrderSideBuy:
+			pos.RealizedPnL += (price - pos.EntryPrice) * size
+		case domain.OrderSideSell:
+			pos.RealizedPnL += (pos.EntryPrice - price) * size
+		}
+		pos.Size -= size
+	}
+
+	if err := s.positions.Update(ctx, *pos); err != nil {
+		return fmt.Errorf("position_service: update position %q: %w", pos.ID, err)
+	}
+	evt, _ := json.Marshal(map[string]any{
+		"event":        "position_updated",
+		"position_id":  pos.ID,
+		"market":       pos.MarketID,
+		"order_id":     order.ID,
+		"entry_price":  pos.EntryPrice,
+		"size":         pos.Size,
+		"realized_pnl": pos.RealizedPnL,
+	})
+	if pubErr := s.bus.Publish(ctx, "positions", evt); pubErr != nil {
+		s.logger.WarnContext(ctx, "position_service: publish update event failed",
+			slog.String("position_id", pos.ID),
+			slog.String("error", pubErr.Error()),
+		)
+	}
+	s.logger.InfoContext(ctx, "position_service: position updated from fill",
+		slog.String("position_id", pos.ID),
+		slog.String("order_id", order.ID),
+		slog.Float64("fill_price", price),
+		slog.Float64("fill_size", size),
+		slog.Float64("size", pos.Size),
+	)
+	return nil
+}
+
 // GetOpen returns all open positions for the given wallet.
 func (s *PositionService) GetOpen(ctx context.Context, wallet string) ([]domain.Position, error) {
 	positions, err := s.positions.GetOpen(ctx, wallet)
@@ -285,3 +368,122 @@ func (s *PositionService) CheckTakeProfit(ctx context.Context, wallet string) ([
 
 	return triggered, nil
 }
+
+// List returns positions matching filter, newest first. Open positions are
+// marked to the cached price.
+func (s *PositionService) List(ctx context.Context, filter domain.PositionFilter, opts domain.ListOpts) ([]domain.Position, error) {
+	positions, err := s.positions.List(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("position_service: list positions: %w", err)
+	}
+	s.markToMarket(ctx, positions)
+	return positions, nil
+}
+
+// GetByID returns a position, marked to the cached price when open.
+func (s *PositionService) GetByID(ctx context.Context, id string) (domain.Position, error) {
+	pos, err := s.positions.GetByID(ctx, id)
+	if err != nil {
+		return domain.Position{}, fmt.Errorf("position_service: get position %q: %w", id, err)
+	}
+	one := []domain.Position{pos}
+	s.markToMarket(ctx, one)
+	return one[0], nil
+}
+
+// PnLSummary aggregates the realized PnL of positions matching filter that
+// closed in [since, until) by strategy and UTC day, and adds the unrealized
+// PnL of the matching positions open now at the cached price.
+func (s *PositionService) PnLSummary(ctx context.Context, filter domain.PositionFilter, since, until time.Time) (domain.PnLSummary, error) {
+	buckets, err := s.positions.RealizedPnLByDay(ctx, filter, since, until)
+	if err != nil {
+		return domain.PnLSummary{}, fmt.Errorf("position_service: realized pnl: %w", err)
+	}
+	openFilter := filter
+	openFilter.Status = domain.PositionStatusOpen
+	open, err := s.positions.List(ctx, openFilter, domain.ListOpts{})
+	if err != nil {
+		return domain.PnLSummary{}, fmt.Errorf("position_service: list open positions: %w", err)
+	}
+	unpriced := s.markToMarket(ctx, open)
+
+	sum := domain.PnLSummary{Since: since, Until: until, Unpriced: unpriced}
+	byStrategy := make(map[string]*domain.StrategyPnL)
+	strategy := func(name string) *domain.StrategyPnL {
+		sp, ok := byStrategy[name]
+		if !ok {
+			sp = &domain.StrategyPnL{Strategy: name}
+			byStrategy[name] = sp
+		}
+		return sp
+	}
+	for _, b := range buckets {
+		sum.RealizedPnL += b.RealizedPnL
+		sp := strategy(b.Strategy)
+		sp.RealizedPnL += b.RealizedPnL
+		sp.Closed += b.Closed
+		if n := len(sum.ByDay); n > 0 && sum.ByDay[n-1].Day.Equal(b.Day) {
+			sum.ByDay[n-1].RealizedPnL += b.RealizedPnL
+			sum.ByDay[n-1].Closed += b.Closed
+		} else {
+			sum.ByDay = append(sum.ByDay, domain.DailyPnL{Day: b.Day, RealizedPnL: b.RealizedPnL, Closed: b.Closed})
+		}
+	}
+	for _, pos := range open {
+		sum.UnrealizedPnL += pos.UnrealizedPnL
+		sp := strategy(pos.Strategy)
+		sp.UnrealizedPnL += pos.UnrealizedPnL
+		sp.Open++
+	}
+
+	sum.ByStrategy = make([]domain.StrategyPnL, 0, len(byStrategy))
+	for _, sp := range byStrategy {
+		sum.ByStrategy = append(sum.ByStrategy, *sp)
+	}
+	sort.Slice(sum.ByStrategy, func(i, j int) bool {
+		return sum.ByStrategy[i].Strategy < sum.ByStrategy[j].Strategy
+	})
+	return sum, nil
+}
+
+// markToMarket sets CurrentPrice and UnrealizedPnL on the open positions from
+// the price cache and returns how many had no cached price.
+func (s *PositionService) markToMarket(ctx context.Context, positions []domain.Position) int {
+	var tokens []string
+	for _, pos := range positions {
+		if pos.Status == domain.PositionStatusOpen {
+			tokens = append(tokens, pos.TokenID)
+		}
+	}
+	if len(tokens) == 0 {
+		return 0
+	}
+	prices, err := s.prices.GetPrices(ctx, tokens)
+	if err != nil {
+		s.logger.WarnContext(ctx, "position_service: price fetch failed for mark to market",
+			slog.String("error", err.Error()),
+		)
+		prices = nil
+	}
+
+	unpriced := 0
+	for i := range positions {
+		pos := &positions[i]
+		if pos.Status != domain.PositionStatusOpen {
+			continue
+		}
+		price, ok := prices[pos.TokenID]
+		if !ok || price <= 0 {
+			unpriced++
+			continue
+		}
+		pos.CurrentPrice = price
+		switch pos.Direction {
+		case domain.OrderSideBuy:
+			pos.UnrealizedPnL = (price - pos.EntryPrice) * pos.Size
+		case domain.OrderSideSell:
+			pos.UnrealizedPnL = (pos.EntryPrice - price) * pos.Size
+		}
+	}
+	return unpriced
+}
@@ -114,6 +114,28 @@ func (s *PriceService) HandlePriceChange(ctx context.Context, change domain.Pric
 	return nil
 }
 
+// HandleLastTrade publishes a last trade print, with its taker side when
+// known, on the "trades" channel for the candle recorder, dashboard and
+// arbitrage detector. Prints are kept off "prices", whose
+// consumers treat every event as a book change.
+func (s *PriceService) HandleLastTrade(ctx context.Context, trade domain.LastTradePrice) error {
+	payload := map[string]any{
+		"event":     "last_trade_price",
+		"asset_id":  trade.AssetID,
+		"price":     trade.Price,
+		"size":      trade.Size,
+		"timestamp": trade.Timestamp.Format(time.RFC3339Nano),
+	}
+	if trade.Side != "" {
+		payload["side"] = string(trade.Side)
+	}
+	evt, _ := json.Marshal(payload)
+	if err := s.bus.Publish(ctx, "trades", evt); err != nil {
+		return fmt.Errorf("price_service: publish last trade for %q: %w", trade.AssetID, err)
+	}
+	return nil
+}
+
 // GetPrice returns the latest cached price and its timestamp for a single asset.
 func (s *PriceService) GetPrice(ctx context.Context, assetID string) (float64, time.Time, error) {
 	price, ts, err := s.priceCache.GetPrice(ctx, assetID)
@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/chain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polygon"
+)
+
+const redemptionLockKey = "lock:redemption"
+
+// RedemptionChain reads the wallet's balances in a condition and the payouts
+// the CTF reports for it (chain.Client).
+type RedemptionChain interface {
+	Redeemable(ctx context.Context, owner, conditionID string, tokenIDs []string) (chain.Redeemable, error)
+}
+
+// RedemptionTxManager submits redemptions from the hot wallet (polygon.TxManager).
+type RedemptionTxManager interface {
+	SendCall(ctx context.Context, contract string, data []byte) (string, error)
+	WaitForReceipt(ctx context.Context, txHash string, confirmations uint64, poll time.Duration) (polygon.Receipt, error)
+}
+
+// RedemptionConfig controls which positions are redeemed and how long
+// confirmations are awaited.
+type RedemptionConfig struct {
+	Wallet         string        // hot wallet address (positions are recorded against it)
+	Lookback       time.Duration // closed positions opened this long ago are still checked
+	MinProceeds    float64       // smallest redemption worth paying gas for, USDC
+	Interval       time.Duration // how often to check
+	Confirmations  int           // blocks required before a redemption counts as confirmed
+	ConfirmTimeout time.Duration // stop waiting for confirmations after this long
+}
+
+// RedemptionService redeems the wallet's outcome tokens in resolved markets
+// for USDC. Each pass it checks the markets the wallet holds or recently held
+// positions in, and for each whose condition the oracle has resolved and in
+// which the wallet still holds tokens worth at least MinProceeds, it submits
+// redeemPositions (through the NegRiskAdapter for neg-risk markets) and waits
+// for confirmations. Once confirmed, the wallet's positions in the market
+// that are still open are closed at the on-chain payout of their token, which
+// books the proceeds as their realized PnL; positions ResolutionWatcher
+// already settled booked the same payout then. Every redemption is persisted,
+// audited and notified, and redemptions still unconfirmed are tracked again
+// on the next pass.
+type RedemptionService struct {
+	redemptions domain.RedemptionStore
+	positions   domain.PositionStore
+	markets     domain.MarketStore
+	closer      PositionCloser
+	chain       RedemptionChain
+	tx          RedemptionTxManager
+	audit       domain.AuditStore  // optional
+	locks       domain.LockManager // optional; prevents two instances redeeming at once
+	notifier    SweepNotifier      // optional
+	cfg         RedemptionConfig
+	logger      *slog.Logger
+}
+
+// NewRedemptionService creates a RedemptionService. audit, locks and notifier
+// may be nil.
+func NewRedemptionService(
+	redemptions domain.RedemptionStore,
+	positions domain.PositionStore,
+	markets domain.MarketStore,
+	closer PositionCloser,
+	chainClient RedemptionChain,
+	tx RedemptionTxManager,
+	audit domain.AuditStore,
+	locks domain.LockManager,
+	notifier SweepNotifier,
+	cfg RedemptionConfig,
+	logger *slog.Logger,
+) *RedemptionService {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Minute
+	}
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 30 * 24 * time.Hour
+	}
+	if cfg.Confirmations <= 0 {
+		cfg.Confirmations = 1
+	}
+	if cfg.ConfirmTimeout <= 0 {
+		cfg.ConfirmTimeout = 10 * time.Minute
+	}
+	return &RedemptionService{
+		redemptions: redemptions,
+		positions:   positions,
+		markets:     markets,
+		closer:      closer,
+		chain:       chainClient,
+		tx:          tx,
+		audit:       audit,
+		locks:       locks,
+		notifier:    notifier,
+		cfg:         cfg,
+		logger:      logger.With(slog.String("component", "redemption_service")),
+	}
+}
+
+// Run checks for redeemable positions every interval until ctx is cancelled.
+// Call in a goroutine.
+func (s *RedemptionService) Run(ctx context.Context) error {
+	s.logger.InfoContext(ctx, "redemption service started",
+		slog.Duration("interval", s.cfg.Interval),
+		slog.Float64("min_proceeds", s.cfg.MinProceeds),
+	)
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Check(ctx); err != nil && ctx.Err() == nil {
+			s.logger.ErrorContext(ctx, "redemption check failed", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check tracks unconfirmed redemptions, then redeems every resolved market
+// the wallet still holds tokens in. It returns the redemptions confirmed in
+// this pass.
+func (s *RedemptionService) Check(ctx context.Context) ([]domain.Redemption, error) {
+	if s.locks != nil {
+		unlock, err := s.locks.Acquire(ctx, redemptionLockKey, s.cfg.ConfirmTimeout+time.Minute)
+		if err != nil {
+			if errors.Is(err, domain.ErrLockHeld) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("redemption_service: acquire lock: %w", err)
+		}
+		defer unlock()
+	}
+
+	var confirmed []domain.Redemption
+	active, err := s.redemptions.ListActive(ctx, s.cfg.Wallet)
+	if err != nil {
+		return nil, fmt.Errorf("redemption_service: active redemptions: %w", err)
+	}
+	inFlight := make(map[string]bool, len(active))
+	for i := range active {
+		r := &active[i]
+		if r.TxHash == "" {
+			// Recorded but never broadcast (the process stopped in between).
+			s.fail(ctx, r, errors.New("not broadcast"))
+			continue
+		}
+		if s.track(ctx, r) {
+			confirmed = append(confirmed, *r)
+		}
+		if r.Active() {
+			inFlight[r.ConditionID] = true
+		}
+	}
+
+	markets, err := s.heldMarkets(ctx)
+	if err != nil {
+		return confirmed, err
+	}
+	for _, marketID := range markets {
+		if ctx.Err() != nil {
+			return confirmed, ctx.Err()
+		}
+		market, err := s.markets.GetByID(ctx, marketID)
+		if err != nil {
+			s.logger.DebugContext(ctx, "redemption_service: market lookup failed",
+				slog.String("market_id", marketID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if market.ConditionID == "" || len(market.TokenIDs) == 0 || inFlight[market.ConditionID] {
+			continue
+		}
+		rd, err := s.chain.Redeemable(ctx, s.cfg.Wallet, market.ConditionID, market.TokenIDs)
+		if err != nil {
+			s.logger.WarnContext(ctx, "redemption_service: read payouts failed",
+				slog.String("market_id", marketID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if !rd.Resolved || !rd.HasBalance() || rd.Proceeds < s.cfg.MinProceeds {
+			continue
+		}
+		r, err := s.redeem(ctx, market, rd)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "redemption failed",
+				slog.String("market_id", marketID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		inFlight[market.ConditionID] = true
+		if r.Status == domain.RedemptionConfirmed {
+			confirmed = append(confirmed, r)
+		}
+	}
+	return confirmed, nil
+}
+
+// heldMarkets returns the markets of the wallet's open positions and of the
+// positions it opened within the lookback.
+func (s *RedemptionService) heldMarkets(ctx context.Context) ([]string, error) {
+	open, err := s.positions.GetOpen(ctx, s.cfg.Wallet)
+	if err != nil {
+		return nil, fmt.Errorf("redemption_service: open positions: %w", err)
+	}
+	since := time.Now().UTC().Add(-s.cfg.Lookback)
+	recent, err := s.positions.ListHistory(ctx, s.cfg.Wallet, domain.ListOpts{Since: &since})
+	if err != nil {
+		return nil, fmt.Errorf("redemption_service: recent positions: %w", err)
+	}
+	var markets []string
+	seen := make(map[string]bool)
+	for _, pos := range append(open, recent...) {
+		if !seen[pos.MarketID] {
+			seen[pos.MarketID] = true
+			markets = append(markets, pos.MarketID)
+		}
+	}
+	return markets, nil
+}
+
+// redeem records, submits and tracks the redemption of rd in market.
+func (s *RedemptionService) redeem(ctx context.Context, market domain.Market, rd chain.Redeemable) (domain.Redemption, error) {
+	r := domain.Redemption{
+		ID:          uuid.NewString(),
+		Wallet:      s.cfg.Wallet,
+		MarketID:    market.ID,
+		ConditionID: market.ConditionID,
+		NegRisk:     market.NegRisk,
+		TokenIDs:    market.TokenIDs,
+		Shares:      make([]float64, len(rd.Balances)),
+		Payouts:     rd.Payouts,
+		Proceeds:    rd.Proceeds,
+		Status:      domain.RedemptionPending,
+		CreatedAt:   time.Now().UTC(),
+	}
+	for i := range rd.Balances {
+		r.Shares[i] = rd.Shares(i)
+	}
+	contract, data, err := chain.RedeemCall(market.ConditionID, market.NegRisk, rd.Balances)
+	if err != nil {
+		return r, fmt.Errorf("redemption_service: %w", err)
+	}
+	if err := s.redemptions.Create(ctx, r); err != nil {
+		return r, fmt.Errorf("redemption_service: record redemption: %w", err)
+	}
+
+	txHash, err := s.tx.SendCall(ctx, contract, data)
+	if err != nil {
+		s.fail(ctx, &r, err)
+		return r, fmt.Errorf("redemption_service: submit: %w", err)
+	}
+	r.TxHash = txHash
+	r.Status = domain.RedemptionSubmitted
+	s.update(ctx, r)
+	s.auditLog(ctx, "redemption_submitted", r)
+	s.logger.InfoContext(ctx, "redemption submitted",
+		slog.String("redemption_id", r.ID),
+		slog.String("market_id", r.MarketID),
+		slog.String("tx_hash", txHash),
+		slog.Float64("proceeds", r.Proceeds),
+	)
+	s.track(ctx, &r)
+	return r, nil
+}
+
+// track waits for r's confirmations and, once confirmed, books its proceeds.
+// It reports whether r was confirmed. A redemption still unconfirmed after
+// ConfirmTimeout stays submitted and is tracked again next pass.
+func (s *RedemptionService) track(ctx context.Context, r *domain.Redemption) bool {
+	waitCtx, cancel := context.WithTimeout(ctx, s.cfg.ConfirmTimeout)
+	defer cancel()
+	receipt, err := s.tx.WaitForReceipt(waitCtx, r.TxHash, uint64(s.cfg.Confirmations), 0)
+	if err != nil {
+		if errors.Is(err, polygon.ErrTxReverted) {
+			s.fail(ctx, r, err)
+			return false
+		}
+		s.logger.WarnContext(ctx, "redemption unconfirmed",
+			slog.String("redemption_id", r.ID),
+			slog.String("tx_hash", r.TxHash),
+			slog.String("error", err.Error()),
+		)
+		return false
+	}
+
+	now := time.Now().UTC()
+	r.Status = domain.RedemptionConfirmed
+	r.BlockNumber = int64(receipt.BlockNumber)
+	r.GasUsed = int64(receipt.GasUsed)
+	r.ConfirmedAt = &now
+	r.PositionIDs = s.book(ctx, *r)
+	s.update(ctx, *r)
+	s.auditLog(ctx, "redemption_confirmed", *r)
+	s.logger.InfoContext(ctx, "redemption confirmed",
+		slog.String("redemption_id", r.ID),
+		slog.String("market_id", r.MarketID),
+		slog.String("tx_hash", r.TxHash),
+		slog.Int64("block", r.BlockNumber),
+		slog.Float64("proceeds", r.Proceeds),
+		slog.Int("positions", len(r.PositionIDs)),
+	)
+	s.notify(ctx, "redemption", "Resolved position redeemed",
+		fmt.Sprintf("%.2f USDC from market %s\nTx: %s", r.Proceeds, r.MarketID, r.TxHash))
+	return true
+}
+
+// book closes the wallet's open positions in r's market at the payout of
+// their token and returns their IDs.
+func (s *RedemptionService) book(ctx context.Context, r domain.Redemption) []string {
+	open, err := s.positions.GetOpen(ctx, r.Wallet)
+	if err != nil {
+		s.logger.WarnContext(ctx, "redemption_service: open positions failed",
+			slog.String("redemption_id", r.ID),
+			slog.String("error", err.Error()),
+		)
+		return []string{}
+	}
+	ids := []string{}
+	for _, pos := range open {
+		if pos.MarketID != r.MarketID {
+			continue
+		}
+		payout, ok := redemptionPayout(r, pos.TokenID)
+		if !ok {
+			continue
+		}
+		if err := s.closer.ClosePosition(ctx, pos.ID, payout); err != nil {
+			if !errors.Is(err, domain.ErrNotFound) {
+				s.logger.ErrorContext(ctx, "redemption_service: close position failed",
+					slog.String("position_id", pos.ID),
+					slog.String("error", err.Error()),
+				)
+			}
+			continue
+		}
+		ids = append(ids, pos.ID)
+	}
+	return ids
+}
+
+// redemptionPayout returns the USDC per share r paid for tokenID.
+func redemptionPayout(r domain.Redemption, tokenID string) (float64, bool) {
+	for i, id := range r.TokenIDs {
+		if id == tokenID && i < len(r.Payouts) {
+			return r.Payouts[i], true
+		}
+	}
+	return 0, false
+}
+
+func (s *RedemptionService) fail(ctx context.Context, r *domain.Redemption, cause error) {
+	r.Status = domain.RedemptionFailed
+	r.Error = cause.Error()
+	s.update(ctx, *r)
+	s.auditLog(ctx, "redemption_failed", *r)
+	s.notify(ctx, "error", "Redemption failed",
+		fmt.Sprintf("%.2f USDC from market %s\n%s", r.Proceeds, r.MarketID, cause.Error()))
+}
+
+func (s *RedemptionService) update(ctx context.Context, r domain.Redemption) {
+	if err := s.redemptions.Update(ctx, r); err != nil {
+		s.logger.WarnContext(ctx, "redemption_service: update redemption failed",
+			slog.String("redemption_id", r.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *RedemptionService) auditLog(ctx context.Context, event string, r domain.Redemption) {
+	if s.audit == nil {
+		return
+	}
+	err := s.audit.Log(ctx, event, map[string]any{
+		"redemption_id": r.ID,
+		"market":        r.MarketID,
+		"condition_id":  r.ConditionID,
+		"neg_risk":      r.NegRisk,
+		"shares":        r.Shares,
+		"payouts":       r.Payouts,
+		"proceeds":      r.Proceeds,
+		"positions":     r.PositionIDs,
+		"tx_hash":       r.TxHash,
+		"block":         r.BlockNumber,
+		"gas_used":      r.GasUsed,
+		"error":         r.Error,
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "redemption_service: audit log failed",
+			slog.String("redemption_id", r.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *RedemptionService) notify(ctx context.Context, event, title, message string) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.Notify(ctx, event, title, message); err != nil {
+		s.logger.WarnContext(ctx, "redemption_service: notify failed", slog.String("error", err.Error()))
+	}
+}
@@ -35,8 +35,10 @@ func NewRelationService(
 
 // ComputeImpliedPrices returns implied YES prices for each market in the target
 // group, given the source group's market prices and the relation between the two.
-// sourcePrices is keyed by source group market ID (YES price 0..1).
-// Returns a map of target market ID -> implied price.
+// sourcePrices is keyed by source group market ID (YES price 0..1), or by
+// token ID for the outcomes of a categorical market; outcome_map keys and
+// values use the same scheme.
+// Returns a map of target market ID (or categorical outcome token ID) -> implied price.
 func (s *RelationService) ComputeImpliedPrices(
 	ctx context.Context,
 	sourceGroupID string,
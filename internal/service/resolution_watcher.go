@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PositionCloser closes a position at an exit price, booking its realized
+// PnL and publishing position_closed (PositionService).
+type PositionCloser interface {
+	ClosePosition(ctx context.Context, posID string, exitPrice float64) error
+}
+
+// ResolutionWatcherConfig configures a ResolutionWatcher.
+type ResolutionWatcherConfig struct {
+	Wallet   string
+	Interval time.Duration // how often markets with open positions are checked
+}
+
+// ResolutionWatcher closes the wallet's open positions in markets that have
+// resolved, which would otherwise stay open and marked at a stale price. It
+// polls Gamma for each market with an open position and, once the market is
+// closed with a winning outcome, closes every position in it at the payout:
+// 1 per share of the winning token, 0 for the others. Closed markets without
+// a winner yet (awaiting the oracle) are checked again next poll.
+type ResolutionWatcher struct {
+	positions domain.PositionStore
+	closer    PositionCloser
+	resolver  MarketResolver
+	audit     domain.AuditStore // optional
+	cfg       ResolutionWatcherConfig
+	logger    *slog.Logger
+}
+
+// NewResolutionWatcher creates a ResolutionWatcher. audit may be nil.
+func NewResolutionWatcher(
+	positions domain.PositionStore,
+	closer PositionCloser,
+	resolver MarketResolver,
+	audit domain.AuditStore,
+	cfg ResolutionWatcherConfig,
+	logger *slog.Logger,
+) *ResolutionWatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Minute
+	}
+	return &ResolutionWatcher{
+		positions: positions,
+		closer:    closer,
+		resolver:  resolver,
+		audit:     audit,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "resolution_watcher")),
+	}
+}
+
+// Run checks for resolved markets every interval until ctx is cancelled.
+// Call in a goroutine.
+func (w *ResolutionWatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	w.logger.InfoContext(ctx, "resolution watcher started", slog.Duration("interval", w.cfg.Interval))
+	defer w.logger.InfoContext(ctx, "resolution watcher stopped")
+
+	for {
+		w.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs one pass and returns the number of positions closed.
+func (w *ResolutionWatcher) Check(ctx context.Context) int {
+	open, err := w.positions.GetOpen(ctx, w.cfg.Wallet)
+	if err != nil {
+		w.logger.WarnContext(ctx, "resolution watcher: list open positions failed",
+			slog.String("error", err.Error()))
+		return 0
+	}
+
+	byMarket := make(map[string][]domain.Position)
+	var order []string
+	for _, pos := range open {
+		if _, ok := byMarket[pos.MarketID]; !ok {
+			order = append(order, pos.MarketID)
+		}
+		byMarket[pos.MarketID] = append(byMarket[pos.MarketID], pos)
+	}
+
+	closed := 0
+	for _, marketID := range order {
+		if ctx.Err() != nil {
+			break
+		}
+		res, err := w.resolver.GetMarketResolution(ctx, marketID)
+		if err != nil {
+			w.logger.DebugContext(ctx, "resolution watcher: resolution fetch failed",
+				slog.String("market_id", marketID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if !res.Closed || res.WinnerTokenID == "" {
+			continue
+		}
+		for _, pos := range byMarket[marketID] {
+			if w.settle(ctx, pos, res.WinnerTokenID) {
+				closed++
+			}
+		}
+	}
+	return closed
+}
+
+// settle closes pos at the payout of its token and reports whether it did.
+func (w *ResolutionWatcher) settle(ctx context.Context, pos domain.Position, winnerTokenID string) bool {
+	payout := 0.0
+	if pos.TokenID == winnerTokenID {
+		payout = 1.0
+	}
+	if err := w.closer.ClosePosition(ctx, pos.ID, payout); err != nil {
+		w.logger.ErrorContext(ctx, "resolution watcher: close position failed",
+			slog.String("position_id", pos.ID),
+			slog.String("market_id", pos.MarketID),
+			slog.String("error", err.Error()),
+		)
+		return false
+	}
+
+	w.logger.InfoContext(ctx, "resolution watcher: position settled",
+		slog.String("position_id", pos.ID),
+		slog.String("market_id", pos.MarketID),
+		slog.Float64("payout", payout),
+		slog.Float64("size", pos.Size),
+	)
+	if w.audit != nil {
+		if err := w.audit.Log(ctx, "position_resolved", map[string]any{
+			"position_id":  pos.ID,
+			"market":       pos.MarketID,
+			"token_id":     pos.TokenID,
+			"winner_token": winnerTokenID,
+			"payout":       payout,
+			"size":         pos.Size,
+			"entry_price":  pos.EntryPrice,
+			"strategy":     pos.Strategy,
+		}); err != nil {
+			w.logger.WarnContext(ctx, "resolution watcher: audit log failed",
+				slog.String("position_id", pos.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return true
+}
@@ -2,23 +2,55 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
 	"github.com/alanyoungcy/polymarketbot/internal/platform/polymarket"
 )
 
+const (
+	// lpStrategy is the strategy whose resting orders earn liquidity rewards.
+	lpStrategy = "liquidity_provider"
+	// rewardEstimateDays is how many recent days feed a reward estimate.
+	rewardEstimateDays = 7
+	// rewardSingleSidedDivisor scales the score of one-sided liquidity while
+	// the mid is within [0.10, 0.90], per the venue's reward formula; outside
+	// that range only two-sided liquidity scores.
+	rewardSingleSidedDivisor = 3.0
+)
+
 // RewardsTracker queries the Gamma API for LP/reward-eligible markets and
-// caches the list for the liquidity_provider strategy.
+// caches the list for the liquidity_provider strategy. With WithAccrual it
+// also samples our resting liquidity_provider quotes, estimates the rewards
+// they accrue and persists terms, uptime and accrual per market and UTC day.
 type RewardsTracker struct {
 	gamma       *polymarket.GammaClient
 	minVolume   float64
 	cacheTTL    time.Duration
 	lastRefresh  time.Time
-	cached      []string // eligible market IDs
+	cached      []string                                   // eligible market IDs
+	terms       map[string]polymarket.RewardEligibleMarket // by market ID
 	mu          sync.RWMutex
 	logger      *slog.Logger
+
+	// Accrual tracking; see WithAccrual.
+	store          domain.LPRewardStore
+	orders         domain.OrderStore
+	books          domain.OrderbookCache
+	wallet         string
+	sampleInterval time.Duration
+
+	dayMu       sync.Mutex
+	day         time.Time                      // UTC day of today's rows
+	today       map[string]*domain.LPRewardDay // by market ID
+	lastSample  time.Time
+	estimates   map[string]domain.LPRewardEstimate
+	estimatedAt time.Time
 }
 
 // NewRewardsTracker creates a RewardsTracker. minVolume is minimum daily volume (USD) for a market to be eligible.
@@ -62,7 +94,12 @@ func (r *RewardsTracker) EligibleMarketIDs(ctx context.Context) ([]string, error
 	for _, m := range markets {
 		ids = append(ids, m.MarketID)
 	}
+	terms := make(map[string]polymarket.RewardEligibleMarket, len(markets))
+	for _, m := range markets {
+		terms[m.MarketID] = m
+	}
 	r.cached = ids
+	r.terms = terms
 	r.lastRefresh = time.Now()
 	r.logger.DebugContext(ctx, "rewards eligible markets refreshed", slog.Int("count", len(ids)))
 	return ids, nil
@@ -77,15 +114,366 @@ func (r *RewardsTracker) SetMinVolume(v float64) {
 	r.lastRefresh = time.Time{}
 }
 
-// RewardEstimate holds estimated reward for a market (placeholder for future API).
-type RewardEstimate struct {
-	MarketID string
-	Estimate float64 // USD or points
+// WithAccrual makes Run sample wallet's resting liquidity_provider orders
+// every sampleInterval (default 1m) against the cached books, estimate the
+// rewards they accrue and persist them to store per market and UTC day.
+func (r *RewardsTracker) WithAccrual(store domain.LPRewardStore, orders domain.OrderStore, books domain.OrderbookCache, wallet string, sampleInterval time.Duration) *RewardsTracker {
+	if sampleInterval <= 0 {
+		sampleInterval = time.Minute
+	}
+	r.store = store
+	r.orders = orders
+	r.books = books
+	r.wallet = wallet
+	r.sampleInterval = sampleInterval
+	return r
+}
+
+// Run samples our quotes every sample interval and persists today's rows,
+// until ctx is cancelled. Without WithAccrual it returns at once. Call in a
+// goroutine.
+func (r *RewardsTracker) Run(ctx context.Context) error {
+	if r.store == nil || r.orders == nil || r.books == nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	if err := r.loadToday(ctx, now); err != nil {
+		r.logger.WarnContext(ctx, "rewards tracker: load today's rows failed", slog.String("error", err.Error()))
+	}
+	ticker := time.NewTicker(r.sampleInterval)
+	defer ticker.Stop()
+	r.logger.InfoContext(ctx, "rewards tracker started",
+		slog.Duration("sample_interval", r.sampleInterval),
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			r.flush(flushCtx, time.Now().UTC())
+			cancel()
+			return ctx.Err()
+		case t := <-ticker.C:
+			if _, err := r.EligibleMarketIDs(ctx); err != nil {
+				r.logger.WarnContext(ctx, "rewards tracker: refresh eligible markets failed", slog.String("error", err.Error()))
+			}
+			r.sample(ctx, t.UTC())
+			r.flush(ctx, t.UTC())
+		}
+	}
 }
 
-// EstimatesPerMarket returns reward estimates per market; currently returns empty slice.
-// Reserved for when Polymarket exposes reward accrual API.
-func (r *RewardsTracker) EstimatesPerMarket(ctx context.Context) ([]RewardEstimate, error) {
-	_ = ctx
-	return nil, nil
+// RewardEstimates returns the reward terms and estimated daily accrual of
+// every eligible market, by market ID. Estimates are cached like the
+// eligible list.
+func (r *RewardsTracker) RewardEstimates(ctx context.Context) (map[string]domain.LPRewardEstimate, error) {
+	if _, err := r.EligibleMarketIDs(ctx); err != nil {
+		return nil, err
+	}
+	r.dayMu.Lock()
+	if r.estimates != nil && time.Since(r.estimatedAt) < r.cacheTTL {
+		out := r.estimates
+		r.dayMu.Unlock()
+		return out, nil
+	}
+	r.dayMu.Unlock()
+
+	now := time.Now().UTC()
+	var history []domain.LPRewardDay
+	if r.store != nil {
+		var err error
+		history, err = r.store.ListDays(ctx, "", startOfDay(now).AddDate(0, 0, -rewardEstimateDays), startOfDay(now))
+		if err != nil {
+			return nil, fmt.Errorf("rewards tracker: estimates: %w", err)
+		}
+	}
+
+	r.mu.RLock()
+	out := make(map[string]domain.LPRewardEstimate, len(r.terms))
+	for id, t := range r.terms {
+		out[id] = domain.LPRewardEstimate{
+			MarketID:  id,
+			DailyRate: t.DailyRate,
+			MaxSpread: t.RewardsMaxSpread / 100,
+			MinSize:   t.RewardsMinSize,
+		}
+	}
+	r.mu.RUnlock()
+
+	r.dayMu.Lock()
+	for _, d := range r.today {
+		history = append(history, *d)
+	}
+	type acc struct{ usd, secs float64 }
+	sums := make(map[string]*acc)
+	for _, d := range history {
+		if d.QuotedSeconds <= 0 {
+			continue
+		}
+		e, ok := out[d.MarketID]
+		if !ok {
+			continue
+		}
+		a, ok := sums[d.MarketID]
+		if !ok {
+			a = &acc{}
+			sums[d.MarketID] = a
+		}
+		a.usd += d.EstimatedUSD
+		a.secs += d.QuotedSeconds
+		e.Days++
+		out[d.MarketID] = e
+	}
+	for id, a := range sums {
+		e := out[id]
+		e.EstimatedDailyUSD = a.usd / a.secs * 86400
+		out[id] = e
+	}
+	r.estimates = out
+	r.estimatedAt = now
+	r.dayMu.Unlock()
+	return out, nil
+}
+
+// RewardDays returns the rows of days in [from, to) for marketID (every
+// market when empty), oldest first, with today's rows as currently sampled.
+func (r *RewardsTracker) RewardDays(ctx context.Context, marketID string, from, to time.Time) ([]domain.LPRewardDay, error) {
+	if r.store == nil {
+		return nil, fmt.Errorf("rewards tracker: no reward store")
+	}
+	stored, err := r.store.ListDays(ctx, marketID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	r.dayMu.Lock()
+	day := r.day
+	var live []domain.LPRewardDay
+	if !day.IsZero() && !day.Before(startOfDay(from)) && day.Before(to) {
+		for _, d := range r.today {
+			if marketID == "" || d.MarketID == marketID {
+				live = append(live, *d)
+			}
+		}
+	}
+	r.dayMu.Unlock()
+	if len(live) == 0 {
+		return stored, nil
+	}
+
+	out := stored[:0:0]
+	for _, d := range stored {
+		if !d.Day.Equal(day) {
+			out = append(out, d)
+		}
+	}
+	out = append(out, live...)
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Day.Equal(out[j].Day) {
+			return out[i].Day.Before(out[j].Day)
+		}
+		return out[i].MarketID < out[j].MarketID
+	})
+	return out, nil
+}
+
+// loadToday resumes today's rows from the store after a restart.
+func (r *RewardsTracker) loadToday(ctx context.Context, now time.Time) error {
+	day := startOfDay(now)
+	rows, err := r.store.ListDays(ctx, "", day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	r.dayMu.Lock()
+	defer r.dayMu.Unlock()
+	r.rollover(now)
+	for i := range rows {
+		d := rows[i]
+		r.today[d.MarketID] = &d
+	}
+	return nil
+}
+
+// rollover starts a new set of rows when the UTC day changed. The caller
+// holds dayMu and has flushed the previous day.
+func (r *RewardsTracker) rollover(now time.Time) {
+	day := startOfDay(now)
+	if r.today != nil && r.day.Equal(day) {
+		return
+	}
+	r.day = day
+	r.today = make(map[string]*domain.LPRewardDay)
+	r.lastSample = time.Time{}
+}
+
+// row returns today's row of marketID, creating it. The caller holds dayMu.
+func (r *RewardsTracker) row(marketID string) *domain.LPRewardDay {
+	d, ok := r.today[marketID]
+	if !ok {
+		d = &domain.LPRewardDay{Day: r.day, MarketID: marketID}
+		r.today[marketID] = d
+	}
+	return d
+}
+
+// recordTerms updates today's rows with the latest eligibility and terms.
+// Markets no longer eligible keep their row, marked ineligible. The caller
+// holds dayMu.
+func (r *RewardsTracker) recordTerms(terms map[string]polymarket.RewardEligibleMarket) {
+	for id, d := range r.today {
+		if _, ok := terms[id]; !ok {
+			d.Eligible = false
+		}
+	}
+	for id, t := range terms {
+		d := r.row(id)
+		d.Eligible = true
+		d.DailyRate = t.DailyRate
+		d.MaxSpread = t.RewardsMaxSpread / 100
+		d.MinSize = t.RewardsMinSize
+	}
+}
+
+// sample records the current eligibility and terms in today's rows and adds
+// the time since the previous sample to every market we quote:
+// as qualifying when our resting quotes score, with our share of the score
+// and the reward it accrues.
+func (r *RewardsTracker) sample(ctx context.Context, now time.Time) {
+	open, err := r.orders.ListOpen(ctx, r.wallet)
+	if err != nil {
+		r.logger.WarnContext(ctx, "rewards tracker: list open orders failed", slog.String("error", err.Error()))
+		return
+	}
+	byMarket := make(map[string][]domain.Order)
+	for _, o := range open {
+		if o.Strategy == lpStrategy {
+			byMarket[o.MarketID] = append(byMarket[o.MarketID], o)
+		}
+	}
+
+	r.mu.RLock()
+	terms := r.terms
+	r.mu.RUnlock()
+
+	r.dayMu.Lock()
+	if r.today != nil && !r.day.Equal(startOfDay(now)) {
+		r.dayMu.Unlock()
+		r.flush(ctx, now)
+		r.dayMu.Lock()
+	}
+	r.rollover(now)
+	r.recordTerms(terms)
+	elapsed := r.sampleInterval
+	if !r.lastSample.IsZero() {
+		elapsed = min(now.Sub(r.lastSample), 2*r.sampleInterval)
+	}
+	r.lastSample = now
+	quoted := make(map[string]bool, len(byMarket))
+	for id := range byMarket {
+		quoted[id] = true
+	}
+	for id, d := range r.today {
+		if d.QuotedSeconds > 0 {
+			quoted[id] = true
+		}
+	}
+	r.dayMu.Unlock()
+
+	for id := range quoted {
+		d := func() domain.LPRewardDay {
+			r.dayMu.Lock()
+			defer r.dayMu.Unlock()
+			return *r.row(id)
+		}()
+		if !d.Eligible || d.MaxSpread <= 0 {
+			continue
+		}
+		share := r.scoreShare(ctx, d, byMarket[id])
+		secs := elapsed.Seconds()
+		r.dayMu.Lock()
+		row := r.row(id)
+		row.QuotedSeconds += secs
+		if share > 0 {
+			row.QualifyingSeconds += secs
+			row.ShareSeconds += share * secs
+			row.EstimatedUSD += row.DailyRate * share * secs / 86400
+		}
+		r.dayMu.Unlock()
+	}
+}
+
+// scoreShare estimates our share of the market's reward score from our
+// resting orders and the cached book of each token we quote. Orders and book
+// levels score ((v-s)/v)^2 x size, for v the max spread and s the distance
+// from the mid, when within v and at least the min size.
+func (r *RewardsTracker) scoreShare(ctx context.Context, terms domain.LPRewardDay, orders []domain.Order) float64 {
+	byToken := make(map[string][]domain.Order)
+	for _, o := range orders {
+		byToken[o.TokenID] = append(byToken[o.TokenID], o)
+	}
+	var ours, total float64
+	for tokenID, tokOrders := range byToken {
+		snap, err := r.books.GetSnapshot(ctx, tokenID)
+		if err != nil {
+			continue
+		}
+		mid := snap.MidPrice
+		if mid <= 0 && snap.BestBid > 0 && snap.BestAsk > 0 {
+			mid = (snap.BestBid + snap.BestAsk) / 2
+		}
+		if mid <= 0 {
+			continue
+		}
+		v := terms.MaxSpread
+		score := func(price, size float64) float64 {
+			s := math.Abs(price - mid)
+			if s > v || size < terms.MinSize {
+				return 0
+			}
+			return (v - s) / v * (v - s) / v * size
+		}
+		var ourBid, ourAsk, bookBid, bookAsk float64
+		for _, o := range tokOrders {
+			q := score(o.Price(), o.Remaining())
+			if o.Side == domain.OrderSideBuy {
+				ourBid += q
+			} else {
+				ourAsk += q
+			}
+		}
+		for _, l := range snap.Bids {
+			bookBid += score(l.Price, l.Size)
+		}
+		for _, l := range snap.Asks {
+			bookAsk += score(l.Price, l.Size)
+		}
+		ours += rewardScore(ourBid, ourAsk, mid)
+		total += rewardScore(max(bookBid, ourBid), max(bookAsk, ourAsk), mid)
+	}
+	if ours <= 0 || total <= 0 {
+		return 0
+	}
+	return min(1, ours/total)
+}
+
+// rewardScore combines the bid and ask scores of one token: two-sided
+// liquidity scores the smaller side, one-sided liquidity a third of it
+// while the mid is within [0.10, 0.90].
+func rewardScore(bid, ask, mid float64) float64 {
+	if mid >= 0.10 && mid <= 0.90 {
+		return max(min(bid, ask), max(bid, ask)/rewardSingleSidedDivisor)
+	}
+	return min(bid, ask)
+}
+
+// flush persists today's rows.
+func (r *RewardsTracker) flush(ctx context.Context, now time.Time) {
+	r.dayMu.Lock()
+	rows := make([]domain.LPRewardDay, 0, len(r.today))
+	for _, d := range r.today {
+		d.UpdatedAt = now
+		rows = append(rows, *d)
+	}
+	r.dayMu.Unlock()
+	if err := r.store.UpsertDays(ctx, rows); err != nil {
+		r.logger.WarnContext(ctx, "rewards tracker: persist reward days failed", slog.String("error", err.Error()))
+	}
 }
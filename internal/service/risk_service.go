@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
@@ -13,6 +17,49 @@ type RiskConfig struct {
 	MaxPositions   int
 	MaxTradeAmount float64
 	MaxSlippageBps float64
+
+	// CloseHorizon is how long before a market's end date entry sizes start
+	// shrinking; 0 disables the close haircut. The factor falls linearly from
+	// 1 at the horizon to CloseMinFactor at the end date.
+	CloseHorizon   time.Duration
+	CloseMinFactor float64
+	// CloseMultipliers scales the haircut per strategy (signal Source): 0
+	// exempts a strategy, 2 cuts twice as hard. Missing strategies use 1.
+	CloseMultipliers map[string]float64
+
+	// MinSizePolicy handles entries too small for their expected edge to
+	// cover fees and redemption gas: "reject" drops them, "floor" raises
+	// them to the breakeven size, "off" (or "") disables the check.
+	MinSizePolicy string
+	// FeeBps is the taker fee in bps of notional.
+	FeeBps float64
+	// RedeemGasUSD is the fixed cost of redeeming a position on resolution.
+	RedeemGasUSD float64
+	// DefaultEdgeBps is the expected edge, in bps of notional, assumed for
+	// signals that carry no "edge_bps" metadata.
+	DefaultEdgeBps float64
+
+	// Slippage is the default slippage model and limit; SlippageByStrategy
+	// overrides it per strategy (signal Source or strategy type), so
+	// conservative strategies are not held to arbitrage limits. See
+	// SlippageSpec.
+	Slippage           SlippageSpec
+	SlippageByStrategy map[string]SlippageSpec
+}
+
+// Minimum profitable size policies.
+const (
+	MinSizePolicyOff    = "off"
+	MinSizePolicyReject = "reject"
+	MinSizePolicyFloor  = "floor"
+)
+
+// endDateTTL is how long a market's end date is cached by the close haircut.
+const endDateTTL = 10 * time.Minute
+
+type cachedEndDate struct {
+	end     *time.Time
+	fetched time.Time
 }
 
 // RiskService provides pre-trade risk checks to ensure orders stay within
@@ -20,8 +67,23 @@ type RiskConfig struct {
 type RiskService struct {
 	positions domain.PositionStore
 	prices    domain.PriceCache
-	cfg       RiskConfig
+	markets   domain.MarketStore    // optional; required for the close haircut
+	fees      *FeeModel             // optional; per-market fees instead of FeeBps
+	capital   *CapitalAllocator     // optional; per-strategy budgets
+	lists     *MarketListService    // optional; operator blacklist/whitelist
+	books     domain.OrderbookCache // optional; depth slippage model
+	features  domain.FeatureCache   // optional; volatility slippage model
 	logger    *slog.Logger
+
+	cfgMu sync.RWMutex
+	cfg   RiskConfig
+
+	endMu    sync.Mutex
+	endDates map[string]cachedEndDate       // market or token ID -> end date
+	inactive map[string]domain.MarketStatus // market or token ID -> non-active status from MarketWatcher
+
+	feedMu     sync.Mutex
+	staleFeeds map[string]domain.FeedStatus // feed name -> status while degraded or reconnecting
 }
 
 // NewRiskService creates a RiskService with all required dependencies.
@@ -32,11 +94,331 @@ func NewRiskService(
 	logger *slog.Logger,
 ) *RiskService {
 	return &RiskService{
-		positions: positions,
-		prices:    prices,
-		cfg:       cfg,
-		logger:    logger,
+		positions:  positions,
+		prices:     prices,
+		cfg:        cfg,
+		logger:     logger,
+		endDates:   make(map[string]cachedEndDate),
+		inactive:   make(map[string]domain.MarketStatus),
+		staleFeeds: make(map[string]domain.FeedStatus),
+	}
+}
+
+// SetConfig replaces the risk limits; checks already running finish with
+// the previous ones.
+func (s *RiskService) SetConfig(cfg RiskConfig) {
+	s.cfgMu.Lock()
+	s.cfg = cfg
+	s.cfgMu.Unlock()
+}
+
+func (s *RiskService) config() RiskConfig {
+	s.cfgMu.RLock()
+	defer s.cfgMu.RUnlock()
+	return s.cfg
+}
+
+// WithMarkets sets the market store used to look up end dates for the close
+// haircut. Without it, AdjustSize leaves signals untouched.
+func (s *RiskService) WithMarkets(markets domain.MarketStore) *RiskService {
+	s.markets = markets
+	return s
+}
+
+// WithFees sets the per-market fee model. Breakeven sizing then uses the
+// market's effective taker fee instead of FeeBps, and the slippage check adds
+// the fee the order will pay to its slippage.
+func (s *RiskService) WithFees(fees *FeeModel) *RiskService {
+	s.fees = fees
+	return s
+}
+
+// WithCapital sets the capital allocator whose per-strategy budgets entries
+// must fit in.
+func (s *RiskService) WithCapital(capital *CapitalAllocator) *RiskService {
+	s.capital = capital
+	return s
+}
+
+// WithMarketLists blocks entries into markets excluded by the operator's
+// blacklist or whitelist. Exits stay allowed so positions can be closed.
+func (s *RiskService) WithMarketLists(lists *MarketListService) *RiskService {
+	s.lists = lists
+	return s
+}
+
+// WithBooks sets the orderbook cache the depth slippage model walks.
+func (s *RiskService) WithBooks(books domain.OrderbookCache) *RiskService {
+	s.books = books
+	return s
+}
+
+// WithFeatures sets the feature cache the volatility slippage model reads
+// realized volatility from.
+func (s *RiskService) WithFeatures(features domain.FeatureCache) *RiskService {
+	s.features = features
+	return s
+}
+
+// CloseHaircut returns the entry size factor in [0, 1] for a signal from the
+// given strategy on a market ending at end. It is 1 outside the horizon.
+func (s *RiskService) CloseHaircut(strategy string, end, now time.Time) float64 {
+	cfg := s.config()
+	if cfg.CloseHorizon <= 0 {
+		return 1
+	}
+	remaining := end.Sub(now)
+	if remaining >= cfg.CloseHorizon {
+		return 1
+	}
+	base := cfg.CloseMinFactor
+	if remaining > 0 {
+		frac := float64(remaining) / float64(cfg.CloseHorizon)
+		base = cfg.CloseMinFactor + (1-cfg.CloseMinFactor)*frac
+	}
+	mult := 1.0
+	if m, ok := cfg.CloseMultipliers[strategy]; ok {
+		mult = m
+	}
+	factor := 1 - mult*(1-base)
+	return max(0, min(1, factor))
+}
+
+// AdjustSize resizes entry (BUY) signals before the risk check. It applies
+// the close haircut, shrinking SizeUnits for markets within CloseHorizon of
+// their end date, and then the minimum profitable size (see BreakevenSize).
+// Exits are never changed. It returns an error when nothing economic is
+// left to trade.
+func (s *RiskService) AdjustSize(ctx context.Context, signal domain.TradeSignal) (domain.TradeSignal, error) {
+	if signal.Side != domain.OrderSideBuy {
+		return signal, nil
+	}
+	signal, haircut, err := s.applyCloseHaircut(ctx, signal)
+	if err != nil {
+		return signal, err
+	}
+	return s.applyMinSize(ctx, signal, haircut)
+}
+
+// applyCloseHaircut shrinks an entry near its market's end date. applied
+// reports whether the size was reduced.
+func (s *RiskService) applyCloseHaircut(ctx context.Context, signal domain.TradeSignal) (_ domain.TradeSignal, applied bool, _ error) {
+	if s.config().CloseHorizon <= 0 || s.markets == nil {
+		return signal, false, nil
+	}
+	end := s.endDate(ctx, signal)
+	if end == nil {
+		return signal, false, nil
+	}
+	factor := s.CloseHaircut(signal.Source, *end, time.Now().UTC())
+	if factor >= 1 {
+		return signal, false, nil
+	}
+	adjusted := int64(float64(signal.SizeUnits) * factor)
+	if adjusted <= 0 {
+		return signal, false, fmt.Errorf("risk_service: entry blocked, market ends %s (close haircut)", end.Format(time.RFC3339))
+	}
+	s.logger.InfoContext(ctx, "risk_service: close haircut applied",
+		slog.String("signal_id", signal.ID),
+		slog.String("source", signal.Source),
+		slog.String("token_id", signal.TokenID),
+		slog.Time("end_date", *end),
+		slog.Float64("factor", factor),
+		slog.Int64("size_units", signal.SizeUnits),
+		slog.Int64("adjusted_units", adjusted),
+	)
+	signal.SizeUnits = adjusted
+	return signal, true, nil
+}
+
+// BreakevenSize returns the smallest entry size, in shares, at which the
+// signal's expected edge covers the taker fee and the estimated slippage
+// plus the fixed redemption gas:
+//
+//	size * price * (edge_bps - fee_bps - slippage_bps) / 10000 >= redeem_gas_usd
+//
+// The edge comes from the signal's "edge_bps" metadata, falling back to
+// DefaultEdgeBps, and the slippage from the strategy's slippage model (see
+// EstimateSlippageBps). ok is false when the edge does not cover the fee
+// and slippage, so no size is profitable.
+func (s *RiskService) BreakevenSize(ctx context.Context, signal domain.TradeSignal) (size float64, ok bool) {
+	price := signal.Price()
+	if price <= 0 {
+		return 0, false
+	}
+	slippage := math.Max(s.EstimateSlippageBps(ctx, signal), 0)
+	netPerShare := price * (s.edgeBps(signal) - s.takerFeeBps(ctx, signal) - slippage) / 10_000
+	if netPerShare <= 0 {
+		return 0, false
+	}
+	return s.config().RedeemGasUSD / netPerShare, true
+}
+
+// takerFeeBps returns the taker fee of the signal's market: the fee model's
+// effective fee when set, otherwise FeeBps.
+func (s *RiskService) takerFeeBps(ctx context.Context, signal domain.TradeSignal) float64 {
+	if s.fees == nil {
+		return s.config().FeeBps
+	}
+	return s.fees.TakerFeeBps(ctx, signal.TokenID)
+}
+
+func (s *RiskService) edgeBps(signal domain.TradeSignal) float64 {
+	if v, err := strconv.ParseFloat(signal.Metadata["edge_bps"], 64); err == nil && v > 0 {
+		return v
+	}
+	return s.config().DefaultEdgeBps
+}
+
+// applyMinSize enforces MinSizePolicy on an entry. Flooring never undoes a
+// close haircut or pushes the notional past MaxTradeAmount; such signals are
+// rejected instead.
+func (s *RiskService) applyMinSize(ctx context.Context, signal domain.TradeSignal, haircut bool) (domain.TradeSignal, error) {
+	policy := s.config().MinSizePolicy
+	if policy == "" || policy == MinSizePolicyOff {
+		return signal, nil
+	}
+
+	edge := s.edgeBps(signal)
+	fee := s.takerFeeBps(ctx, signal)
+	breakeven, ok := s.BreakevenSize(ctx, signal)
+	if !ok {
+		return signal, fmt.Errorf("risk_service: sub-economic entry: edge %.1f bps does not cover fee %.1f bps and slippage %.1f bps",
+			edge, fee, math.Max(s.EstimateSlippageBps(ctx, signal), 0))
+	}
+	size := signal.Size()
+	if size >= breakeven {
+		return signal, nil
+	}
+
+	reason := fmt.Sprintf("size %.2f below breakeven %.2f (edge %.1f bps, fee %.1f bps, gas $%.4f)",
+		size, breakeven, edge, fee, s.config().RedeemGasUSD)
+	if policy != MinSizePolicyFloor || haircut {
+		return signal, fmt.Errorf("risk_service: sub-economic entry: %s", reason)
+	}
+	if s.config().MaxTradeAmount > 0 && breakeven*signal.Price() > s.config().MaxTradeAmount {
+		return signal, fmt.Errorf("risk_service: sub-economic entry: %s; breakeven notional exceeds max %.2f", reason, s.config().MaxTradeAmount)
+	}
+
+	floored := int64(math.Ceil(breakeven * domain.SizeScale))
+	s.logger.InfoContext(ctx, "risk_service: entry floored to breakeven size",
+		slog.String("signal_id", signal.ID),
+		slog.String("source", signal.Source),
+		slog.String("token_id", signal.TokenID),
+		slog.Float64("edge_bps", edge),
+		slog.Int64("size_units", signal.SizeUnits),
+		slog.Int64("adjusted_units", floored),
+	)
+	signal.SizeUnits = floored
+	return signal, nil
+}
+
+// endDate returns the cached end date of the signal's market, or nil when unknown.
+func (s *RiskService) endDate(ctx context.Context, signal domain.TradeSignal) *time.Time {
+	key := signal.MarketID
+	if key == "" {
+		key = signal.TokenID
 	}
+	s.endMu.Lock()
+	c, ok := s.endDates[key]
+	s.endMu.Unlock()
+	if ok && time.Since(c.fetched) < endDateTTL {
+		return c.end
+	}
+
+	var m domain.Market
+	var err error
+	if signal.MarketID != "" {
+		m, err = s.markets.GetByID(ctx, signal.MarketID)
+	} else {
+		m, err = s.markets.GetByTokenID(ctx, signal.TokenID)
+	}
+	if err != nil {
+		s.logger.DebugContext(ctx, "risk_service: market lookup for close haircut failed",
+			slog.String("key", key),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+	// Closed markets take no entries, so their end date is not kept.
+	if m.Status == domain.MarketStatusActive {
+		s.endMu.Lock()
+		s.endDates[key] = cachedEndDate{end: m.ClosedAt, fetched: time.Now()}
+		s.endMu.Unlock()
+	}
+	return m.ClosedAt
+}
+
+// HandleMarketUpdate applies a metadata change from MarketWatcher: the cached
+// end date is replaced immediately instead of waiting for the TTL, and entries
+// into a market that is no longer active are rejected until it reopens. The
+// end dates of markets that are no longer active are evicted, since nothing
+// is sized against them any more.
+func (s *RiskService) HandleMarketUpdate(ctx context.Context, update domain.MarketUpdate) error {
+	m := update.Market
+	keys := []string{m.ID}
+	for _, t := range m.TokenIDs {
+		if t != "" {
+			keys = append(keys, t)
+		}
+	}
+	now := time.Now()
+	s.endMu.Lock()
+	for _, k := range keys {
+		if m.Status == domain.MarketStatusActive {
+			s.endDates[k] = cachedEndDate{end: m.ClosedAt, fetched: now}
+			delete(s.inactive, k)
+		} else {
+			delete(s.endDates, k)
+			s.inactive[k] = m.Status
+		}
+	}
+	s.endMu.Unlock()
+	if update.Changed(domain.MarketFieldStatus) {
+		s.logger.InfoContext(ctx, "risk_service: market status changed",
+			slog.String("market_id", m.ID),
+			slog.String("status", string(m.Status)),
+		)
+	}
+	return nil
+}
+
+// HandleFeedStatus applies a connection state change from a market data feed:
+// entries are rejected while the feed is degraded or reconnecting, since the
+// prices they were sized against may be from before the gap.
+func (s *RiskService) HandleFeedStatus(ctx context.Context, status domain.FeedStatus) error {
+	s.feedMu.Lock()
+	if status.State.Live() {
+		delete(s.staleFeeds, status.Feed)
+	} else {
+		s.staleFeeds[status.Feed] = status
+	}
+	s.feedMu.Unlock()
+	return nil
+}
+
+// staleFeed returns a feed currently in a gap, if any.
+func (s *RiskService) staleFeed() (domain.FeedStatus, bool) {
+	s.feedMu.Lock()
+	defer s.feedMu.Unlock()
+	for _, st := range s.staleFeeds {
+		return st, true
+	}
+	return domain.FeedStatus{}, false
+}
+
+// inactiveStatus returns the non-active status last reported for the
+// signal's market, if any.
+func (s *RiskService) inactiveStatus(signal domain.TradeSignal) (domain.MarketStatus, bool) {
+	s.endMu.Lock()
+	defer s.endMu.Unlock()
+	if signal.MarketID != "" {
+		if st, ok := s.inactive[signal.MarketID]; ok {
+			return st, true
+		}
+	}
+	st, ok := s.inactive[signal.TokenID]
+	return st, ok
 }
 
 // PreTradeCheck validates a trade signal against the configured risk limits
@@ -44,36 +426,87 @@ func NewRiskService(
 // failed check, or nil if all checks pass.
 //
 // Checks performed:
-//  1. Maximum number of open positions
-//  2. Trade size within limits
-//  3. Estimated slippage within bounds
+//  1. Market not paused or closed, not excluded by the market lists, and
+//     market data feeds live (entries only; see HandleMarketUpdate,
+//     HandleFeedStatus and WithMarketLists)
+//  2. Maximum number of open positions
+//  3. Trade size within limits
+//  4. Strategy capital budget not exceeded (entries only; when a capital
+//     allocator is set)
+//  5. Slippage, the larger of the quoted and the strategy model's estimate,
+//     plus the fee paid when a fee model is set, within the strategy's limit
 func (s *RiskService) PreTradeCheck(ctx context.Context, signal domain.TradeSignal, wallet string) error {
-	// Check 1: max open positions.
+	// Check 1: market still open for entries.
+	if signal.Side == domain.OrderSideBuy {
+		if status, ok := s.inactiveStatus(signal); ok {
+			s.logger.WarnContext(ctx, "risk_service: entry into inactive market blocked",
+				slog.String("market_id", signal.MarketID),
+				slog.String("token_id", signal.TokenID),
+				slog.String("status", string(status)),
+			)
+			return fmt.Errorf("risk_service: market is %s", status)
+		}
+		if s.lists != nil {
+			if list, excluded := s.lists.Excluded(signal.MarketID, signal.TokenID); excluded {
+				s.logger.WarnContext(ctx, "risk_service: entry into excluded market blocked",
+					slog.String("market_id", signal.MarketID),
+					slog.String("token_id", signal.TokenID),
+					slog.String("list", string(list)),
+				)
+				if list == domain.MarketListBlacklist {
+					return fmt.Errorf("risk_service: market %s is blacklisted", signal.MarketID)
+				}
+				return fmt.Errorf("risk_service: market %s is not on the whitelist", signal.MarketID)
+			}
+		}
+		if feed, ok := s.staleFeed(); ok {
+			s.logger.WarnContext(ctx, "risk_service: entry blocked during feed gap",
+				slog.String("feed", feed.Feed),
+				slog.String("state", string(feed.State)),
+				slog.String("token_id", signal.TokenID),
+			)
+			return fmt.Errorf("risk_service: %s feed is %s, books stale", feed.Feed, feed.State)
+		}
+	}
+
+	// Check 2: max open positions.
 	openPositions, err := s.positions.GetOpen(ctx, wallet)
 	if err != nil {
 		return fmt.Errorf("risk_service: get open positions: %w", err)
 	}
-	if len(openPositions) >= s.cfg.MaxPositions {
+	if len(openPositions) >= s.config().MaxPositions {
 		s.logger.WarnContext(ctx, "risk_service: max positions reached",
 			slog.String("wallet", wallet),
 			slog.Int("open", len(openPositions)),
-			slog.Int("max", s.cfg.MaxPositions),
+			slog.Int("max", s.config().MaxPositions),
 		)
-		return fmt.Errorf("risk_service: max positions reached (%d/%d)", len(openPositions), s.cfg.MaxPositions)
+		return fmt.Errorf("risk_service: max positions reached (%d/%d)", len(openPositions), s.config().MaxPositions)
 	}
 
-	// Check 2: trade size within limits.
+	// Check 3: trade size within limits.
 	tradeAmount := signal.Price() * signal.Size()
-	if tradeAmount > s.cfg.MaxTradeAmount {
+	if tradeAmount > s.config().MaxTradeAmount {
 		s.logger.WarnContext(ctx, "risk_service: trade amount exceeds limit",
 			slog.String("wallet", wallet),
 			slog.Float64("amount", tradeAmount),
-			slog.Float64("max", s.cfg.MaxTradeAmount),
+			slog.Float64("max", s.config().MaxTradeAmount),
 		)
-		return fmt.Errorf("risk_service: trade amount %.2f exceeds max %.2f", tradeAmount, s.cfg.MaxTradeAmount)
+		return fmt.Errorf("risk_service: trade amount %.2f exceeds max %.2f", tradeAmount, s.config().MaxTradeAmount)
 	}
 
-	// Check 3: slippage bounds.
+	// Check 4: strategy capital budget.
+	if s.capital != nil && signal.Side == domain.OrderSideBuy {
+		if err := s.capital.Check(ctx, signal.Source, tradeAmount); err != nil {
+			s.logger.WarnContext(ctx, "risk_service: strategy budget exceeded",
+				slog.String("source", signal.Source),
+				slog.Float64("amount", tradeAmount),
+				slog.String("error", err.Error()),
+			)
+			return fmt.Errorf("risk_service: %w", err)
+		}
+	}
+
+	// Check 5: slippage bounds.
 	currentPrice, _, priceErr := s.prices.GetPrice(ctx, signal.TokenID)
 	if priceErr != nil {
 		// If we cannot fetch the current price, we cannot estimate slippage.
@@ -87,6 +520,7 @@ func (s *RiskService) PreTradeCheck(ctx context.Context, signal domain.TradeSign
 
 	if currentPrice > 0 {
 		signalPrice := signal.Price()
+		spec := s.config().slippageSpec(signal)
 		var slippageBps float64
 		switch signal.Side {
 		case domain.OrderSideBuy:
@@ -96,14 +530,35 @@ func (s *RiskService) PreTradeCheck(ctx context.Context, signal domain.TradeSign
 			// For sells, slippage is how much less we receive vs. current price.
 			slippageBps = ((currentPrice - signalPrice) / currentPrice) * 10_000
 		}
+		quotedBps := slippageBps
+		// The strategy's model estimates what filling costs beyond the
+		// quote, e.g. walking a thin book.
+		estimateBps := s.EstimateSlippageBps(ctx, signal)
+		slippageBps = math.Max(slippageBps, estimateBps)
+
+		// With a fee model the fee is part of the cost of filling: the taker
+		// fee for orders that cross, the maker fee (often a net rebate) for
+		// those that rest.
+		var feeBps float64
+		if s.fees != nil {
+			feeBps = s.fees.MakerFeeBps(ctx, signal.TokenID)
+			if quotedBps > 0 || signal.OrderType == domain.OrderTypeFOK || signal.OrderType == domain.OrderTypeFAK {
+				feeBps = s.fees.TakerFeeBps(ctx, signal.TokenID)
+			}
+			slippageBps += feeBps
+		}
 
-		if slippageBps > s.cfg.MaxSlippageBps {
+		if slippageBps > spec.MaxBps {
 			s.logger.WarnContext(ctx, "risk_service: slippage exceeds limit",
 				slog.String("wallet", wallet),
+				slog.String("source", signal.Source),
 				slog.Float64("slippage_bps", slippageBps),
-				slog.Float64("max_slippage_bps", s.cfg.MaxSlippageBps),
+				slog.Float64("quoted_bps", quotedBps),
+				slog.Float64("estimate_bps", estimateBps),
+				slog.Float64("fee_bps", feeBps),
+				slog.Float64("max_slippage_bps", spec.MaxBps),
 			)
-			return fmt.Errorf("risk_service: slippage %.1f bps exceeds max %.1f bps", slippageBps, s.cfg.MaxSlippageBps)
+			return fmt.Errorf("risk_service: slippage %.1f bps exceeds max %.1f bps", slippageBps, spec.MaxBps)
 		}
 	}
 
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// ShadowChannel is the bus channel signals of shadow-mode strategies are
+// published on.
+const ShadowChannel = "ch:shadow"
+
+// SignalRecorder persists every signal the strategy engine emits, executed
+// or not, to a SignalStore, together with the executor's resolution of it,
+// so it can be listed, replayed and scored in hindsight. The same recorder
+// over the shadow candidates store takes the signals of shadow-mode
+// strategies, publishing each on ShadowChannel as well.
+type SignalRecorder struct {
+	store     domain.SignalStore // optional when publishing
+	bus       domain.SignalBus   // optional
+	channel   string
+	event     string
+	in        chan domain.TradeSignal
+	resolved  chan domain.SignalResolution
+	flushDur  time.Duration
+	batchSize int
+	logger    *slog.Logger
+
+	buf         []domain.TradeSignal
+	resolutions []domain.SignalResolution
+}
+
+// NewSignalRecorder creates a SignalRecorder. Signals are written when
+// batchSize signals are buffered or every flushInterval, whichever comes first.
+func NewSignalRecorder(store domain.SignalStore, flushInterval time.Duration, batchSize int, logger *slog.Logger) *SignalRecorder {
+	if flushInterval <= 0 {
+		flushInterval = 5 * time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+	return &SignalRecorder{
+		store:     store,
+		in:        make(chan domain.TradeSignal, batchSize*4),
+		resolved:  make(chan domain.SignalResolution, batchSize*4),
+		flushDur:  flushInterval,
+		batchSize: batchSize,
+		logger:    logger.With(slog.String("component", "signal_recorder")),
+	}
+}
+
+// WithBus publishes each recorded signal on channel of bus, as an event of
+// the given name, when it is dequeued ahead of the batched store write.
+func (r *SignalRecorder) WithBus(bus domain.SignalBus, channel, event string) *SignalRecorder {
+	r.bus = bus
+	r.channel = channel
+	r.event = event
+	return r
+}
+
+// Record queues sig for the next flush. It never blocks; when the queue is
+// full the signal is dropped and logged.
+func (r *SignalRecorder) Record(sig domain.TradeSignal) {
+	select {
+	case r.in <- sig:
+	default:
+		r.logger.Warn("signal recorder queue full, signal not recorded",
+			slog.String("signal_id", sig.ID),
+			slog.String("source", sig.Source),
+		)
+	}
+}
+
+// Resolve queues the executor's resolution of a signal for the next flush.
+// Like Record it never blocks, dropping the resolution when the queue is
+// full.
+func (r *SignalRecorder) Resolve(res domain.SignalResolution) {
+	select {
+	case r.resolved <- res:
+	default:
+		r.logger.Warn("signal recorder queue full, resolution not recorded",
+			slog.String("signal_id", res.Signal.ID),
+			slog.String("status", string(res.Status)),
+		)
+	}
+}
+
+// Run writes queued signals until ctx is cancelled. Buffered signals are
+// flushed on shutdown.
+func (r *SignalRecorder) Run(ctx context.Context) error {
+	r.logger.Info("signal recorder started")
+	defer r.logger.Info("signal recorder stopped")
+
+	ticker := time.NewTicker(r.flushDur)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.drain()
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			r.flush(flushCtx)
+			cancel()
+			return ctx.Err()
+		case <-ticker.C:
+			r.flush(ctx)
+		case sig := <-r.in:
+			r.publish(ctx, sig)
+			r.buf = append(r.buf, sig)
+			if len(r.buf) >= r.batchSize {
+				r.flush(ctx)
+			}
+		case res := <-r.resolved:
+			r.resolutions = append(r.resolutions, res)
+			if len(r.resolutions) >= r.batchSize {
+				r.flush(ctx)
+			}
+		}
+	}
+}
+
+// drain moves any still-queued signals and resolutions into the buffers.
+func (r *SignalRecorder) drain() {
+	for {
+		select {
+		case sig := <-r.in:
+			r.buf = append(r.buf, sig)
+		case res := <-r.resolved:
+			r.resolutions = append(r.resolutions, res)
+		default:
+			return
+		}
+	}
+}
+
+// publish sends sig's fields on the recorder's bus channel.
+func (r *SignalRecorder) publish(ctx context.Context, sig domain.TradeSignal) {
+	if r.bus == nil {
+		return
+	}
+	payload, err := json.Marshal(map[string]any{
+		"event":      r.event,
+		"signal_id":  sig.ID,
+		"strategy":   sig.Source,
+		"market_id":  sig.MarketID,
+		"token_id":   sig.TokenID,
+		"side":       string(sig.Side),
+		"price":      sig.Price(),
+		"size":       sig.Size(),
+		"urgency":    int(sig.Urgency),
+		"reason":     sig.Reason,
+		"metadata":   sig.Metadata,
+		"created_at": sig.CreatedAt,
+	})
+	if err != nil {
+		return
+	}
+	if err := r.bus.Publish(ctx, r.channel, payload); err != nil {
+		r.logger.WarnContext(ctx, "signal recorder publish failed",
+			slog.String("signal_id", sig.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// flush writes buffered signals, then buffered resolutions; a resolution
+// whose signal is still queued inserts it.
+func (r *SignalRecorder) flush(ctx context.Context) {
+	if r.store != nil && len(r.buf) > 0 {
+		if err := r.store.InsertBatch(ctx, r.buf); err != nil {
+			r.logger.WarnContext(ctx, "signal recorder flush failed",
+				slog.Int("signals", len(r.buf)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	if r.store != nil && len(r.resolutions) > 0 {
+		if err := r.store.Resolve(ctx, r.resolutions); err != nil {
+			r.logger.WarnContext(ctx, "signal recorder flush resolutions failed",
+				slog.Int("resolutions", len(r.resolutions)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	r.buf = r.buf[:0]
+	r.resolutions = r.resolutions[:0]
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Slippage models estimate, in bps of the best price, what filling a signal
+// costs beyond the top of the book.
+const (
+	// SlippageStatic assumes a fixed Bps for every fill.
+	SlippageStatic = "static"
+	// SlippageDepth walks the signal's size through the cached book, up to
+	// its limit price, and measures the VWAP against the best level. Size
+	// the book cannot fill rests at the limit price.
+	SlippageDepth = "depth"
+	// SlippageVolatility adds VolMultiplier times the asset's realized
+	// volatility, in bps, to Bps.
+	SlippageVolatility = "volatility"
+)
+
+// SlippageSpec is the slippage model and limit of a strategy.
+type SlippageSpec struct {
+	Model string // SlippageStatic, SlippageDepth or SlippageVolatility; "" is static
+	// MaxBps bounds the estimated slippage plus fees in PreTradeCheck; 0
+	// falls back to RiskConfig.MaxSlippageBps.
+	MaxBps float64
+	// Bps is the static estimate, and the fallback of the depth and
+	// volatility models when the book or features are unavailable.
+	Bps           float64
+	VolMultiplier float64 // volatility model only
+}
+
+// slippageSpec returns the slippage spec of the signal's strategy: its entry
+// in SlippageByStrategy, keyed by signal Source and then by the instance's
+// strategy type, over the default Slippage. An entry without a model takes
+// the default's model, Bps and VolMultiplier; one without MaxBps takes the
+// default's.
+func (c RiskConfig) slippageSpec(signal domain.TradeSignal) SlippageSpec {
+	def := c.Slippage
+	if def.MaxBps <= 0 {
+		def.MaxBps = c.MaxSlippageBps
+	}
+	spec, ok := c.SlippageByStrategy[signal.Source]
+	if !ok {
+		spec, ok = c.SlippageByStrategy[signal.Metadata["strategy_type"]]
+	}
+	if !ok {
+		return def
+	}
+	if spec.Model == "" {
+		spec.Model, spec.Bps, spec.VolMultiplier = def.Model, def.Bps, def.VolMultiplier
+	}
+	if spec.MaxBps <= 0 {
+		spec.MaxBps = def.MaxBps
+	}
+	return spec
+}
+
+// EstimateSlippageBps returns the slippage the signal's strategy model
+// expects filling it to cost, in bps of the best price. The depth model
+// needs WithBooks and the volatility model WithFeatures; without them, or
+// without a book or features for the token, they fall back to Bps.
+func (s *RiskService) EstimateSlippageBps(ctx context.Context, signal domain.TradeSignal) float64 {
+	spec := s.config().slippageSpec(signal)
+	switch spec.Model {
+	case SlippageDepth:
+		if s.books == nil {
+			return spec.Bps
+		}
+		snap, err := s.books.GetSnapshot(ctx, signal.TokenID)
+		if err != nil {
+			return spec.Bps
+		}
+		if bps, ok := depthSlippageBps(snap, signal.Side, signal.Price(), signal.Size()); ok {
+			return bps
+		}
+		return spec.Bps
+	case SlippageVolatility:
+		if s.features == nil {
+			return spec.Bps
+		}
+		f, err := s.features.Get(ctx, signal.TokenID)
+		if err != nil {
+			return spec.Bps
+		}
+		return spec.Bps + spec.VolMultiplier*f.RealizedVol*10_000
+	default:
+		return spec.Bps
+	}
+}
+
+// depthSlippageBps returns how far the VWAP of taking size from the side of
+// snap a taker order on side consumes, stopping at limit, lies from the best
+// level, in bps. Size left over rests at limit. ok is false when that side
+// of the book is empty or size is not positive.
+func depthSlippageBps(snap domain.OrderbookSnapshot, side domain.OrderSide, limit, size float64) (float64, bool) {
+	src := snap.Asks
+	if side == domain.OrderSideSell {
+		src = snap.Bids
+	}
+	levels := make([]domain.PriceLevel, 0, len(src))
+	for _, l := range src {
+		if l.Price > 0 && l.Size > 0 {
+			levels = append(levels, l)
+		}
+	}
+	if len(levels) == 0 || size <= 0 {
+		return 0, false
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if side == domain.OrderSideSell {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+
+	best := levels[0].Price
+	remaining, cost := size, 0.0
+	for _, l := range levels {
+		if limit > 0 && (side == domain.OrderSideSell && l.Price < limit || side != domain.OrderSideSell && l.Price > limit) {
+			break
+		}
+		take := math.Min(remaining, l.Size)
+		cost += take * l.Price
+		remaining -= take
+		if remaining <= 1e-9 {
+			remaining = 0
+			break
+		}
+	}
+	if remaining > 0 {
+		if limit <= 0 {
+			return 0, false
+		}
+		cost += remaining * limit
+	}
+	vwap := cost / size
+	if side == domain.OrderSideSell {
+		return (best - vwap) / best * 10_000, true
+	}
+	return (vwap - best) / best * 10_000, true
+}
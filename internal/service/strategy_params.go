@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// strategyParamsKey is the key in StrategyConfig.Config under which runtime
+// parameter overrides are stored, keeping them apart from other settings
+// written through PUT /api/strategy/config.
+const strategyParamsKey = "params"
+
+// StrategyReconfigurer applies parameters to a registered strategy and
+// reports the effective parameters of each (strategy.Engine).
+type StrategyReconfigurer interface {
+	Reconfigure(name string, params map[string]any) error
+	EffectiveParams() map[string]map[string]any
+}
+
+// StrategyParamService updates strategy parameters at runtime and persists
+// the overrides so they survive a restart. It also keeps a version history
+// of each strategy's effective parameters, which can be listed and rolled
+// back to.
+type StrategyParamService struct {
+	configs domain.StrategyConfigStore
+	runtime StrategyReconfigurer
+	audit   domain.AuditStore // optional
+	logger  *slog.Logger
+}
+
+// NewStrategyParamService creates a StrategyParamService. configs may be nil,
+// in which case updates apply to the running process only.
+func NewStrategyParamService(configs domain.StrategyConfigStore, runtime StrategyReconfigurer, logger *slog.Logger) *StrategyParamService {
+	return &StrategyParamService{
+		configs: configs,
+		runtime: runtime,
+		logger:  logger.With(slog.String("component", "strategy_params")),
+	}
+}
+
+// WithAudit records each update as a "strategy_params_updated" audit entry.
+func (s *StrategyParamService) WithAudit(audit domain.AuditStore) *StrategyParamService {
+	s.audit = audit
+	return s
+}
+
+// Update validates params and applies them to the running strategy, then
+// merges them into the persisted overrides. It returns the full set of
+// persisted overrides for name. Validation errors wrap
+// domain.ErrInvalidParams; an unknown strategy wraps domain.ErrNotFound.
+func (s *StrategyParamService) Update(ctx context.Context, name string, params map[string]any) (map[string]any, error) {
+	if err := s.runtime.Reconfigure(name, params); err != nil {
+		return nil, err
+	}
+	if s.audit != nil {
+		if err := s.audit.Log(ctx, "strategy_params_updated", map[string]any{
+			"strategy": name,
+			"params":   params,
+		}); err != nil {
+			s.logger.WarnContext(ctx, "strategy_params: audit log failed",
+				slog.String("strategy", name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	if s.configs == nil {
+		return params, nil
+	}
+
+	cfg, err := s.configs.Get(ctx, name)
+	if errors.Is(err, domain.ErrNotFound) {
+		cfg = domain.StrategyConfig{Name: name, Enabled: true}
+	} else if err != nil {
+		return nil, fmt.Errorf("strategy_params: load config %s: %w", name, err)
+	}
+	if cfg.Config == nil {
+		cfg.Config = make(map[string]any)
+	}
+	merged := storedParams(cfg)
+	for k, v := range params {
+		merged[k] = v
+	}
+	cfg.Config[strategyParamsKey] = merged
+	if err := s.configs.Upsert(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("strategy_params: persist %s: %w", name, err)
+	}
+	if _, _, err := s.SaveVersion(ctx, name, domain.StrategyVersionAPI); err != nil {
+		s.logger.WarnContext(ctx, "strategy_params: save version failed",
+			slog.String("strategy", name),
+			slog.String("error", err.Error()),
+		)
+	}
+	return merged, nil
+}
+
+// Restore applies persisted overrides to the registered strategies. It is
+// called once at startup, after the registry is built from TOML. Overrides
+// for strategies that are not registered, or that no longer validate, are
+// skipped with a warning.
+func (s *StrategyParamService) Restore(ctx context.Context) error {
+	if s.configs == nil {
+		return nil
+	}
+	configs, err := s.configs.List(ctx)
+	if err != nil {
+		return fmt.Errorf("strategy_params: list configs: %w", err)
+	}
+	for _, cfg := range configs {
+		params := storedParams(cfg)
+		if len(params) == 0 {
+			continue
+		}
+		if err := s.runtime.Reconfigure(cfg.Name, params); err != nil {
+			s.logger.WarnContext(ctx, "strategy_params: skipping stored overrides",
+				slog.String("strategy", cfg.Name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return nil
+}
+
+// RestoreLatest applies the newest saved version of each registered
+// strategy's effective parameters, so the strategy starts as it last ran
+// rather than from TOML. Strategies without a saved version get their
+// persisted overrides, as with Restore. Versions or overrides that no
+// longer validate are skipped with a warning.
+func (s *StrategyParamService) RestoreLatest(ctx context.Context) error {
+	if s.configs == nil {
+		return nil
+	}
+	versions, err := s.configs.LatestVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("strategy_params: latest versions: %w", err)
+	}
+	restored := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		if len(v.Params) == 0 {
+			continue
+		}
+		if err := s.runtime.Reconfigure(v.Name, v.Params); err != nil {
+			s.logger.WarnContext(ctx, "strategy_params: skipping saved version",
+				slog.String("strategy", v.Name),
+				slog.Int("version", v.Version),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		restored[v.Name] = true
+	}
+	configs, err := s.configs.List(ctx)
+	if err != nil {
+		return fmt.Errorf("strategy_params: list configs: %w", err)
+	}
+	for _, cfg := range configs {
+		params := storedParams(cfg)
+		if restored[cfg.Name] || len(params) == 0 {
+			continue
+		}
+		if err := s.runtime.Reconfigure(cfg.Name, params); err != nil {
+			s.logger.WarnContext(ctx, "strategy_params: skipping stored overrides",
+				slog.String("strategy", cfg.Name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return nil
+}
+
+// SaveVersion records the effective parameters of name, with its persisted
+// overrides, as a new version from source, and reports whether it did.
+// Nothing is recorded when the strategy does not report its parameters, or
+// when they and the overrides are unchanged since the newest version.
+func (s *StrategyParamService) SaveVersion(ctx context.Context, name, source string) (domain.StrategyConfigVersion, bool, error) {
+	return s.saveVersion(ctx, name, source, 0)
+}
+
+// SaveVersions records a version of every registered strategy whose
+// effective parameters changed since its newest version.
+func (s *StrategyParamService) SaveVersions(ctx context.Context, source string) error {
+	if s.configs == nil {
+		return nil
+	}
+	effective := s.runtime.EffectiveParams()
+	names := make([]string, 0, len(effective))
+	for name := range effective {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, _, err := s.saveVersion(ctx, name, source, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StrategyParamService) saveVersion(ctx context.Context, name, source string, rollbackOf int) (domain.StrategyConfigVersion, bool, error) {
+	if s.configs == nil {
+		return domain.StrategyConfigVersion{}, false, nil
+	}
+	effective, ok := s.runtime.EffectiveParams()[name]
+	if !ok {
+		return domain.StrategyConfigVersion{}, false, nil
+	}
+	v := domain.StrategyConfigVersion{
+		Name:       name,
+		Params:     jsonParams(effective),
+		Source:     source,
+		RollbackOf: rollbackOf,
+	}
+	cfg, err := s.configs.Get(ctx, name)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return v, false, fmt.Errorf("strategy_params: load config %s: %w", name, err)
+	}
+	v.Overrides = jsonParams(storedParams(cfg))
+
+	if rollbackOf == 0 {
+		latest, err := s.configs.ListVersions(ctx, name, 1)
+		if err != nil {
+			return v, false, fmt.Errorf("strategy_params: latest version %s: %w", name, err)
+		}
+		if len(latest) > 0 && reflect.DeepEqual(latest[0].Params, v.Params) && reflect.DeepEqual(latest[0].Overrides, v.Overrides) {
+			return latest[0], false, nil
+		}
+	}
+	v, err = s.configs.SaveVersion(ctx, v)
+	if err != nil {
+		return v, false, fmt.Errorf("strategy_params: save version %s: %w", name, err)
+	}
+	s.logger.InfoContext(ctx, "strategy_params: version saved",
+		slog.String("strategy", name),
+		slog.Int("version", v.Version),
+		slog.String("source", source),
+	)
+	return v, true, nil
+}
+
+// Versions returns up to limit saved versions of name, newest first.
+func (s *StrategyParamService) Versions(ctx context.Context, name string, limit int) ([]domain.StrategyConfigVersion, error) {
+	if s.configs == nil {
+		return nil, nil
+	}
+	versions, err := s.configs.ListVersions(ctx, name, limit)
+	if err != nil {
+		return nil, fmt.Errorf("strategy_params: list versions %s: %w", name, err)
+	}
+	return versions, nil
+}
+
+// Rollback applies the effective parameters of a saved version of name to
+// the running strategy and makes that version's overrides the persisted
+// ones, then records the result as a new "rollback" version, which it
+// returns. An unknown strategy or version wraps domain.ErrNotFound;
+// parameters that no longer validate wrap domain.ErrInvalidParams.
+func (s *StrategyParamService) Rollback(ctx context.Context, name string, version int) (domain.StrategyConfigVersion, error) {
+	if s.configs == nil {
+		return domain.StrategyConfigVersion{}, fmt.Errorf("strategy_params: rollback %s: no config store", name)
+	}
+	target, err := s.configs.GetVersion(ctx, name, version)
+	if err != nil {
+		return domain.StrategyConfigVersion{}, fmt.Errorf("strategy_params: version %s/%d: %w", name, version, err)
+	}
+	if err := s.runtime.Reconfigure(name, target.Params); err != nil {
+		return domain.StrategyConfigVersion{}, err
+	}
+
+	cfg, err := s.configs.Get(ctx, name)
+	if errors.Is(err, domain.ErrNotFound) {
+		cfg = domain.StrategyConfig{Name: name, Enabled: true}
+	} else if err != nil {
+		return domain.StrategyConfigVersion{}, fmt.Errorf("strategy_params: load config %s: %w", name, err)
+	}
+	if cfg.Config == nil {
+		cfg.Config = make(map[string]any)
+	}
+	overrides := make(map[string]any, len(target.Overrides))
+	for k, v := range target.Overrides {
+		overrides[k] = v
+	}
+	cfg.Config[strategyParamsKey] = overrides
+	if err := s.configs.Upsert(ctx, cfg); err != nil {
+		return domain.StrategyConfigVersion{}, fmt.Errorf("strategy_params: persist %s: %w", name, err)
+	}
+	if s.audit != nil {
+		if err := s.audit.Log(ctx, "strategy_params_rolled_back", map[string]any{
+			"strategy": name,
+			"version":  version,
+			"params":   target.Params,
+		}); err != nil {
+			s.logger.WarnContext(ctx, "strategy_params: audit log failed",
+				slog.String("strategy", name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	v, _, err := s.saveVersion(ctx, name, domain.StrategyVersionRollback, version)
+	if err != nil {
+		return domain.StrategyConfigVersion{}, err
+	}
+	return v, nil
+}
+
+// Stored returns the persisted overrides of every strategy that has any,
+// keyed by strategy name.
+func (s *StrategyParamService) Stored(ctx context.Context) (map[string]map[string]any, error) {
+	out := make(map[string]map[string]any)
+	if s.configs == nil {
+		return out, nil
+	}
+	configs, err := s.configs.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("strategy_params: list configs: %w", err)
+	}
+	for _, cfg := range configs {
+		if params := storedParams(cfg); len(params) > 0 {
+			out[cfg.Name] = params
+		}
+	}
+	return out, nil
+}
+
+// storedParams returns a copy of the persisted overrides in cfg.
+func storedParams(cfg domain.StrategyConfig) map[string]any {
+	out := make(map[string]any)
+	if m, ok := cfg.Config[strategyParamsKey].(map[string]any); ok {
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// jsonParams returns params as they read back from JSON, so saved and
+// effective parameters compare equal whatever their Go number types.
+func jsonParams(params map[string]any) map[string]any {
+	out := make(map[string]any)
+	data, err := json.Marshal(params)
+	if err != nil {
+		return params
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return params
+	}
+	return out
+}
@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+	"github.com/alanyoungcy/polymarketbot/internal/platform/polygon"
+)
+
+const sweepLockKey = "lock:profit_sweep"
+
+// SweepTxManager submits on-chain transfers from the hot wallet (polygon.TxManager).
+type SweepTxManager interface {
+	ERC20Balance(ctx context.Context, token, owner string) (*big.Int, error)
+	TransferERC20(ctx context.Context, token, to string, amount *big.Int) (string, error)
+	WaitForReceipt(ctx context.Context, txHash string, confirmations uint64, poll time.Duration) (polygon.Receipt, error)
+}
+
+// SweepNotifier reports sweeps to external channels (notify.Dispatcher).
+type SweepNotifier interface {
+	Notify(ctx context.Context, event, title, message string) error
+}
+
+// SweepConfig controls when and how much profit is swept to cold storage.
+type SweepConfig struct {
+	Wallet         string        // hot wallet address (positions are recorded against it)
+	ColdAddress    string        // destination address
+	TokenAddress   string        // USDC contract
+	PnLThreshold   float64       // realized PnL since the last sweep that triggers a sweep
+	WorkingCapital float64       // USDC always left in the hot wallet
+	MinAmount      float64       // smallest transfer worth paying gas for
+	Interval       time.Duration // how often to check
+	Confirmations  int           // blocks required before a sweep counts as confirmed
+	ConfirmTimeout time.Duration // give up waiting for confirmations after this long
+}
+
+// SweepService periodically moves USDC above a working-capital floor from the
+// hot trading wallet to a cold address once realized PnL since the previous
+// sweep exceeds a threshold. Every sweep is persisted, audited and notified.
+// Collateral committed to open BUY orders stays in the wallet.
+type SweepService struct {
+	sweeps    domain.SweepStore
+	positions domain.PositionStore
+	orders    domain.OrderStore
+	tx        SweepTxManager
+	audit     domain.AuditStore
+	locks     domain.LockManager // optional; prevents two instances sweeping at once
+	notifier  SweepNotifier      // optional
+	cfg       SweepConfig
+	logger    *slog.Logger
+}
+
+// NewSweepService creates a SweepService. locks and notifier may be nil.
+func NewSweepService(
+	sweeps domain.SweepStore,
+	positions domain.PositionStore,
+	orders domain.OrderStore,
+	tx SweepTxManager,
+	audit domain.AuditStore,
+	locks domain.LockManager,
+	notifier SweepNotifier,
+	cfg SweepConfig,
+	logger *slog.Logger,
+) *SweepService {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 15 * time.Minute
+	}
+	if cfg.Confirmations <= 0 {
+		cfg.Confirmations = 1
+	}
+	if cfg.ConfirmTimeout <= 0 {
+		cfg.ConfirmTimeout = 10 * time.Minute
+	}
+	return &SweepService{
+		sweeps:    sweeps,
+		positions: positions,
+		orders:    orders,
+		tx:        tx,
+		audit:     audit,
+		locks:     locks,
+		notifier:  notifier,
+		cfg:       cfg,
+		logger:    logger.With(slog.String("component", "sweep_service")),
+	}
+}
+
+// Run checks for sweepable profit every interval until ctx is cancelled.
+// Call in a goroutine.
+func (s *SweepService) Run(ctx context.Context) error {
+	s.logger.InfoContext(ctx, "profit sweep started",
+		slog.String("cold_address", s.cfg.ColdAddress),
+		slog.Float64("pnl_threshold", s.cfg.PnLThreshold),
+		slog.Float64("working_capital", s.cfg.WorkingCapital),
+	)
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := s.Check(ctx); err != nil {
+				s.logger.ErrorContext(ctx, "profit sweep check failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+// Check first tracks unconfirmed sweeps, then sweeps profit if the realized
+// PnL threshold has been reached and the hot wallet holds more than the
+// working-capital floor plus the collateral of its open BUY orders. No new
+// sweep starts while an earlier one may still be mined. It returns the sweep
+// performed or confirmed, or nil when nothing was due.
+func (s *SweepService) Check(ctx context.Context) (*domain.Sweep, error) {
+	if s.locks != nil {
+		unlock, err := s.locks.Acquire(ctx, sweepLockKey, s.cfg.ConfirmTimeout+time.Minute)
+		if err != nil {
+			if errors.Is(err, domain.ErrLockHeld) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("sweep_service: acquire lock: %w", err)
+		}
+		defer unlock()
+	}
+
+	active, err := s.sweeps.ListActive(ctx, s.cfg.Wallet)
+	if err != nil {
+		return nil, fmt.Errorf("sweep_service: active sweeps: %w", err)
+	}
+	var tracked *domain.Sweep
+	for i := range active {
+		sw := &active[i]
+		if sw.TxHash == "" {
+			// Recorded but never broadcast (the process stopped in between).
+			s.fail(ctx, sw, errors.New("not broadcast"))
+			continue
+		}
+		if err := s.track(ctx, sw); err != nil && !errors.Is(err, polygon.ErrTxReverted) {
+			s.logger.WarnContext(ctx, "profit sweep still unconfirmed, not sweeping again",
+				slog.String("sweep_id", sw.ID),
+				slog.String("tx_hash", sw.TxHash),
+				slog.String("error", err.Error()),
+			)
+			return nil, nil
+		}
+		tracked = sw
+	}
+	if tracked != nil && tracked.Status == domain.SweepConfirmed {
+		// Realized PnL is measured from this sweep on; check again next pass.
+		return tracked, nil
+	}
+
+	var since time.Time
+	last, err := s.sweeps.LastConfirmed(ctx)
+	switch {
+	case err == nil && last.ConfirmedAt != nil:
+		since = *last.ConfirmedAt
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return nil, fmt.Errorf("sweep_service: last sweep: %w", err)
+	}
+
+	pnl, err := s.positions.RealizedPnLSince(ctx, s.cfg.Wallet, since)
+	if err != nil {
+		return nil, fmt.Errorf("sweep_service: realized pnl: %w", err)
+	}
+	if pnl < s.cfg.PnLThreshold {
+		return nil, nil
+	}
+
+	balance, err := s.tx.ERC20Balance(ctx, s.cfg.TokenAddress, s.cfg.Wallet)
+	if err != nil {
+		return nil, fmt.Errorf("sweep_service: wallet balance: %w", err)
+	}
+	committed, err := s.openBuyNotional(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sweep_service: open orders: %w", err)
+	}
+	amount := new(big.Int).Sub(balance, usdcUnits(s.cfg.WorkingCapital+committed))
+	if amount.Cmp(usdcUnits(s.cfg.MinAmount)) < 0 || amount.Sign() <= 0 {
+		s.logger.InfoContext(ctx, "profit sweep skipped: balance at or near working capital",
+			slog.Float64("realized_pnl", pnl),
+			slog.Float64("balance", unitsToUSDC(balance)),
+			slog.Float64("open_buy_notional", committed),
+		)
+		return nil, nil
+	}
+
+	sw := domain.Sweep{
+		ID:          uuid.NewString(),
+		FromAddress: s.cfg.Wallet,
+		ToAddress:   s.cfg.ColdAddress,
+		Amount:      unitsToUSDC(amount),
+		RealizedPnL: pnl,
+		Status:      domain.SweepPending,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.sweeps.Create(ctx, sw); err != nil {
+		return nil, fmt.Errorf("sweep_service: record sweep: %w", err)
+	}
+
+	txHash, err := s.tx.TransferERC20(ctx, s.cfg.TokenAddress, s.cfg.ColdAddress, amount)
+	if err != nil {
+		s.fail(ctx, &sw, err)
+		return &sw, fmt.Errorf("sweep_service: transfer: %w", err)
+	}
+	sw.TxHash = txHash
+	sw.Status = domain.SweepSubmitted
+	if err := s.sweeps.Update(ctx, sw); err != nil {
+		s.logger.WarnContext(ctx, "sweep_service: update sweep failed",
+			slog.String("sweep_id", sw.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+	s.auditLog(ctx, "profit_sweep_submitted", sw)
+	s.logger.InfoContext(ctx, "profit sweep submitted",
+		slog.String("sweep_id", sw.ID),
+		slog.String("tx_hash", txHash),
+		slog.Float64("amount", sw.Amount),
+	)
+
+	if err := s.track(ctx, &sw); err != nil {
+		if !errors.Is(err, polygon.ErrTxReverted) {
+			// Still pending: leave it submitted; the next pass tracks it
+			// before sweeping again.
+			s.notify(ctx, "error", "Profit sweep unconfirmed",
+				fmt.Sprintf("%.2f USDC to %s\nTx: %s\n%s", sw.Amount, sw.ToAddress, txHash, err.Error()))
+		}
+		return &sw, fmt.Errorf("sweep_service: %w", err)
+	}
+	return &sw, nil
+}
+
+// track waits for sw's confirmations and records the outcome. A reverted
+// transfer fails the sweep and returns polygon.ErrTxReverted; a transfer
+// still unconfirmed after ConfirmTimeout stays submitted and returns the
+// wait error.
+func (s *SweepService) track(ctx context.Context, sw *domain.Sweep) error {
+	waitCtx, cancel := context.WithTimeout(ctx, s.cfg.ConfirmTimeout)
+	defer cancel()
+	receipt, err := s.tx.WaitForReceipt(waitCtx, sw.TxHash, uint64(s.cfg.Confirmations), 0)
+	if err != nil {
+		if errors.Is(err, polygon.ErrTxReverted) {
+			s.fail(ctx, sw, err)
+			return err
+		}
+		return fmt.Errorf("wait for confirmations: %w", err)
+	}
+
+	now := time.Now().UTC()
+	sw.Status = domain.SweepConfirmed
+	sw.BlockNumber = int64(receipt.BlockNumber)
+	sw.ConfirmedAt = &now
+	if err := s.sweeps.Update(ctx, *sw); err != nil {
+		s.logger.WarnContext(ctx, "sweep_service: update sweep failed",
+			slog.String("sweep_id", sw.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+	s.auditLog(ctx, "profit_sweep_confirmed", *sw)
+	s.logger.InfoContext(ctx, "profit sweep confirmed",
+		slog.String("sweep_id", sw.ID),
+		slog.String("tx_hash", sw.TxHash),
+		slog.Int64("block", sw.BlockNumber),
+		slog.Float64("amount", sw.Amount),
+	)
+	s.notify(ctx, "profit_sweep", "Profit swept to cold storage",
+		fmt.Sprintf("%.2f USDC to %s\nRealized PnL: %+.2f USD\nTx: %s", sw.Amount, sw.ToAddress, sw.RealizedPnL, sw.TxHash))
+	return nil
+}
+
+// openBuyNotional returns the USDC committed to the unfilled remainder of
+// the wallet's open BUY orders.
+func (s *SweepService) openBuyNotional(ctx context.Context) (float64, error) {
+	open, err := s.orders.ListOpen(ctx, s.cfg.Wallet)
+	if err != nil {
+		return 0, err
+	}
+	var total float64
+	for _, o := range open {
+		if o.Side == domain.OrderSideBuy {
+			total += o.Price() * o.Remaining()
+		}
+	}
+	return total, nil
+}
+
+func (s *SweepService) fail(ctx context.Context, sw *domain.Sweep, cause error) {
+	sw.Status = domain.SweepFailed
+	sw.Error = cause.Error()
+	if err := s.sweeps.Update(ctx, *sw); err != nil {
+		s.logger.WarnContext(ctx, "sweep_service: update sweep failed",
+			slog.String("sweep_id", sw.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+	s.auditLog(ctx, "profit_sweep_failed", *sw)
+	s.notify(ctx, "error", "Profit sweep failed",
+		fmt.Sprintf("%.2f USDC to %s\n%s", sw.Amount, sw.ToAddress, cause.Error()))
+}
+
+func (s *SweepService) auditLog(ctx context.Context, event string, sw domain.Sweep) {
+	if s.audit == nil {
+		return
+	}
+	err := s.audit.Log(ctx, event, map[string]any{
+		"sweep_id":     sw.ID,
+		"from":         sw.FromAddress,
+		"to":           sw.ToAddress,
+		"amount":       sw.Amount,
+		"realized_pnl": sw.RealizedPnL,
+		"tx_hash":      sw.TxHash,
+		"block":        sw.BlockNumber,
+		"error":        sw.Error,
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "sweep_service: audit log failed",
+			slog.String("sweep_id", sw.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *SweepService) notify(ctx context.Context, event, title, message string) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.Notify(ctx, event, title, message); err != nil {
+		s.logger.WarnContext(ctx, "sweep_service: notify failed", slog.String("error", err.Error()))
+	}
+}
+
+// usdcUnits converts a USDC.e amount to token base units.
+func usdcUnits(v float64) *big.Int {
+	return domain.ToBaseUnits(v, domain.DefaultCollateralDecimals)
+}
+
+func unitsToUSDC(u *big.Int) float64 {
+	return domain.FromBaseUnits(u, domain.DefaultCollateralDecimals)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	defaultTimelineLimit = 200
+	maxTimelineLimit     = 1000
+)
+
+// TimelineService serves the bot activity timeline: signals, orders, fills,
+// feed connectivity, risk rejections and configuration changes in one
+// chronological feed, paged with opaque cursors.
+type TimelineService struct {
+	store domain.TimelineStore
+}
+
+// NewTimelineService creates a TimelineService.
+func NewTimelineService(store domain.TimelineStore) *TimelineService {
+	return &TimelineService{store: store}
+}
+
+// List returns up to limit events in [from, to), oldest first, resuming after
+// cursor when it is set, and the cursor of the next page (empty on the last
+// page). kinds restricts the event kinds (all when empty). Invalid arguments
+// wrap domain.ErrInvalidTimeline.
+func (s *TimelineService) List(ctx context.Context, from, to time.Time, cursor string, kinds []string, limit int) ([]domain.TimelineEvent, string, error) {
+	if !from.Before(to) {
+		return nil, "", fmt.Errorf("%w: from must be before to", domain.ErrInvalidTimeline)
+	}
+	for _, k := range kinds {
+		if !slices.Contains(domain.TimelineKinds, k) {
+			return nil, "", fmt.Errorf("%w: unknown kind %q", domain.ErrInvalidTimeline, k)
+		}
+	}
+	switch {
+	case limit <= 0:
+		limit = defaultTimelineLimit
+	case limit > maxTimelineLimit:
+		limit = maxTimelineLimit
+	}
+	q := domain.TimelineQuery{From: from, To: to, Kinds: kinds, Limit: limit}
+	if cursor != "" {
+		pos, err := decodeTimelineCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		q.After = &pos
+	}
+
+	events, err := s.store.List(ctx, q)
+	if err != nil {
+		return nil, "", fmt.Errorf("timeline: %w", err)
+	}
+	var next string
+	if len(events) == limit {
+		last := events[len(events)-1]
+		next = encodeTimelineCursor(domain.TimelinePosition{At: last.At, Source: last.Source, ID: last.ID})
+	}
+	return events, next, nil
+}
+
+// encodeTimelineCursor renders a position as "unixnano|source|id" in
+// URL-safe base64.
+func encodeTimelineCursor(p domain.TimelinePosition) string {
+	raw := strconv.FormatInt(p.At.UnixNano(), 10) + "|" + p.Source + "|" + p.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTimelineCursor(cursor string) (domain.TimelinePosition, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return domain.TimelinePosition{}, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTimeline)
+	}
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 {
+		return domain.TimelinePosition{}, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTimeline)
+	}
+	ns, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return domain.TimelinePosition{}, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTimeline)
+	}
+	return domain.TimelinePosition{At: time.Unix(0, ns).UTC(), Source: parts[1], ID: parts[2]}, nil
+}
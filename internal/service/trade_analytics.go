@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// TradeAnalyticsConfig configures a TradeAnalytics.
+type TradeAnalyticsConfig struct {
+	VWAPWindow    time.Duration // lookback of the VWAP, at most 24h
+	BucketWidth   float64       // price width of the volume profile buckets
+	FlushInterval time.Duration // how often changed markets are written to the cache
+}
+
+// tradeBin aggregates the trades of one market in one minute.
+type tradeBin struct {
+	usd      float64         // USD volume of every trade
+	trades   int             // every trade
+	tokens   float64         // token volume of the priced trades
+	notional float64         // sum of price * tokens of the priced trades
+	buckets  map[int]float64 // price bucket -> USD volume of the priced trades
+	counts   map[int]int     // price bucket -> priced trades
+}
+
+// marketTrades is the rolling trade state of one market.
+type marketTrades struct {
+	bins      map[int64]*tradeBin // unix minute -> trades of the minute
+	lastPrice float64
+	lastAt    time.Time
+	dirty     bool // changed since the last flush
+}
+
+// TradeAnalytics maintains rolling trade statistics per market from the
+// trade_ingested events on "trades": VWAP over a configurable window, and
+// USD volume and a volume-by-price profile over the last 24 hours. Trades
+// are kept in one-minute bins, so a market costs at most 1440 bins.
+//
+// Changed markets are written to the cache every flush interval, so other
+// processes (the API, strategies in another mode) read the same figures.
+type TradeAnalytics struct {
+	bus    domain.SignalBus
+	cache  domain.TradeAnalyticsCache // optional
+	cfg    TradeAnalyticsConfig
+	logger *slog.Logger
+
+	mu      sync.RWMutex
+	markets map[string]*marketTrades
+}
+
+// NewTradeAnalytics creates a TradeAnalytics. The VWAP window defaults to
+// 1h, the bucket width to 0.01 and the flush interval to 5s. Without Run it
+// only serves analytics from the cache.
+func NewTradeAnalytics(bus domain.SignalBus, cache domain.TradeAnalyticsCache, cfg TradeAnalyticsConfig, logger *slog.Logger) *TradeAnalytics {
+	if cfg.VWAPWindow <= 0 || cfg.VWAPWindow > domain.TradeAnalyticsVolumeWindow {
+		cfg.VWAPWindow = time.Hour
+	}
+	if cfg.BucketWidth <= 0 {
+		cfg.BucketWidth = 0.01
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	return &TradeAnalytics{
+		bus:     bus,
+		cache:   cache,
+		cfg:     cfg,
+		logger:  logger.With(slog.String("component", "trade_analytics")),
+		markets: make(map[string]*marketTrades),
+	}
+}
+
+// ingestedTradeEvent is the part of a trade_ingested event the analytics
+// need (TradeService.IngestTrades).
+type ingestedTradeEvent struct {
+	Event     string  `json:"event"`
+	Market    string  `json:"market"`
+	TokenSide string  `json:"token_side"`
+	Price     float64 `json:"price"`
+	Amount    float64 `json:"amount"`
+	Timestamp string  `json:"timestamp"`
+}
+
+// Run consumes ingested trades until ctx is cancelled. Changed markets are
+// flushed on shutdown. Call in a goroutine.
+func (s *TradeAnalytics) Run(ctx context.Context) error {
+	trades, err := s.bus.Subscribe(ctx, "trades")
+	if err != nil {
+		return fmt.Errorf("trade analytics: subscribe trades: %w", err)
+	}
+	flush := time.NewTicker(s.cfg.FlushInterval)
+	defer flush.Stop()
+
+	s.logger.InfoContext(ctx, "trade analytics started",
+		slog.Duration("vwap_window", s.cfg.VWAPWindow),
+		slog.Float64("bucket_width", s.cfg.BucketWidth),
+	)
+	defer s.logger.InfoContext(ctx, "trade analytics stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			s.flush(flushCtx, time.Now())
+			cancel()
+			return ctx.Err()
+		case now := <-flush.C:
+			s.flush(ctx, now)
+		case data, ok := <-trades:
+			if !ok {
+				s.flush(ctx, time.Now())
+				return nil
+			}
+			var ev ingestedTradeEvent
+			if err := json.Unmarshal(data, &ev); err != nil || ev.Event != "trade_ingested" || ev.Market == "" {
+				continue
+			}
+			ts := time.Now()
+			if t, err := time.Parse(time.RFC3339, ev.Timestamp); err == nil {
+				ts = t
+			}
+			s.add(ev.Market, ev.TokenSide, ev.Price, ev.Amount, ts)
+		}
+	}
+}
+
+// add records a trade of usd in marketID's outcome tokenSide at price.
+// Trades older than the volume window are ignored.
+func (s *TradeAnalytics) add(marketID, tokenSide string, price, usd float64, ts time.Time) {
+	if usd <= 0 || time.Since(ts) > domain.TradeAnalyticsVolumeWindow {
+		return
+	}
+	// Price every trade as the first outcome; trades of a third or later
+	// outcome only count toward volume.
+	priced := price > 0 && price <= 1
+	var tokens float64
+	if priced {
+		tokens = usd / price
+	}
+	switch tokenSide {
+	case "", "token1":
+	case "token2":
+		price = 1 - price
+	default:
+		priced = false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.markets[marketID]
+	if !ok {
+		m = &marketTrades{bins: make(map[int64]*tradeBin)}
+		s.markets[marketID] = m
+	}
+	minute := ts.Unix() / 60
+	b, ok := m.bins[minute]
+	if !ok {
+		b = &tradeBin{buckets: make(map[int]float64), counts: make(map[int]int)}
+		m.bins[minute] = b
+	}
+	b.usd += usd
+	b.trades++
+	if priced {
+		b.tokens += tokens
+		b.notional += price * tokens
+		i := int(math.Floor(price/s.cfg.BucketWidth + 1e-9))
+		b.buckets[i] += usd
+		b.counts[i]++
+		if !ts.Before(m.lastAt) {
+			m.lastPrice = price
+		}
+	}
+	if ts.After(m.lastAt) {
+		m.lastAt = ts
+	}
+	m.dirty = true
+}
+
+// TradeAnalytics returns the analytics of marketID, from memory when this
+// process tracks the market and from the cache otherwise.
+func (s *TradeAnalytics) TradeAnalytics(ctx context.Context, marketID string) (domain.MarketTradeAnalytics, error) {
+	now := time.Now()
+	s.mu.RLock()
+	m, ok := s.markets[marketID]
+	var a domain.MarketTradeAnalytics
+	if ok {
+		a = s.compute(marketID, m, now)
+	}
+	s.mu.RUnlock()
+	if ok && a.Trades24h > 0 {
+		return a, nil
+	}
+	if s.cache == nil {
+		return domain.MarketTradeAnalytics{}, domain.ErrNotFound
+	}
+	a, err := s.cache.Get(ctx, marketID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.MarketTradeAnalytics{}, err
+		}
+		return domain.MarketTradeAnalytics{}, fmt.Errorf("trade analytics: %s: %w", marketID, err)
+	}
+	if a.Trades24h == 0 {
+		return domain.MarketTradeAnalytics{}, domain.ErrNotFound
+	}
+	return a, nil
+}
+
+// flush drops bins older than the volume window and writes the markets
+// changed since the last flush, including those whose trades aged out, to
+// the cache. Markets left without trades are written once with zero volume
+// and forgotten.
+func (s *TradeAnalytics) flush(ctx context.Context, now time.Time) {
+	cutoff := now.Add(-domain.TradeAnalyticsVolumeWindow).Unix() / 60
+	s.mu.Lock()
+	var changed []domain.MarketTradeAnalytics
+	for id, m := range s.markets {
+		for minute := range m.bins {
+			if minute < cutoff {
+				delete(m.bins, minute)
+				m.dirty = true
+			}
+		}
+		if !m.dirty {
+			continue
+		}
+		m.dirty = false
+		changed = append(changed, s.compute(id, m, now))
+		if len(m.bins) == 0 {
+			delete(s.markets, id)
+		}
+	}
+	s.mu.Unlock()
+
+	if s.cache == nil || len(changed) == 0 {
+		return
+	}
+	if err := s.cache.SetBatch(ctx, changed); err != nil {
+		s.logger.WarnContext(ctx, "trade analytics: cache write failed",
+			slog.Int("markets", len(changed)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// compute derives the analytics of m as of now. Called with s.mu held.
+func (s *TradeAnalytics) compute(marketID string, m *marketTrades, now time.Time) domain.MarketTradeAnalytics {
+	a := domain.MarketTradeAnalytics{
+		MarketID:    marketID,
+		VWAPWindow:  s.cfg.VWAPWindow,
+		LastPrice:   m.lastPrice,
+		BucketWidth: s.cfg.BucketWidth,
+		UpdatedAt:   m.lastAt,
+	}
+	volumeFrom := now.Add(-domain.TradeAnalyticsVolumeWindow).Unix() / 60
+	vwapFrom := now.Add(-s.cfg.VWAPWindow).Unix() / 60
+	var tokens, notional float64
+	volume := make(map[int]*domain.VolumeBucket)
+	for minute, b := range m.bins {
+		if minute < volumeFrom {
+			continue
+		}
+		a.Volume24h += b.usd
+		a.Trades24h += b.trades
+		if minute >= vwapFrom {
+			tokens += b.tokens
+			notional += b.notional
+		}
+		for i, usd := range b.buckets {
+			vb, ok := volume[i]
+			if !ok {
+				vb = &domain.VolumeBucket{Price: float64(i) * s.cfg.BucketWidth}
+				volume[i] = vb
+			}
+			vb.VolumeUSD += usd
+			vb.Trades += b.counts[i]
+		}
+	}
+	if tokens > 0 {
+		a.VWAP = notional / tokens
+	}
+	a.Profile = make([]domain.VolumeBucket, 0, len(volume))
+	for _, vb := range volume {
+		a.Profile = append(a.Profile, *vb)
+	}
+	sort.Slice(a.Profile, func(i, j int) bool { return a.Profile[i].Price < a.Profile[j].Price })
+	return a
+}
+
+// Compile-time interface check.
+var _ domain.TradeAnalyticsProvider = (*TradeAnalytics)(nil)
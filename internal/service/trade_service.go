@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
@@ -16,6 +17,9 @@ type TradeService struct {
 	bus    domain.SignalBus
 	audit  domain.AuditStore
 	logger *slog.Logger
+
+	inserted   atomic.Int64
+	duplicates atomic.Int64
 }
 
 // NewTradeService creates a TradeService with all required dependencies.
@@ -34,27 +38,37 @@ func NewTradeService(
 }
 
 // IngestTrades inserts a batch of enriched trade fills into the store,
-// publishes an event for each trade on the signal bus, and writes an
-// audit log entry for the batch.
-func (s *TradeService) IngestTrades(ctx context.Context, trades []domain.Trade) error {
+// publishes an event for each trade stored, and writes an audit log entry
+// for the batch. Trades already stored are skipped and counted as
+// duplicates, so a batch ingested twice is accounted for once. It returns
+// the number of trades stored.
+func (s *TradeService) IngestTrades(ctx context.Context, trades []domain.Trade) (int, error) {
 	if len(trades) == 0 {
-		return nil
+		return 0, nil
 	}
 
-	if err := s.trades.InsertBatch(ctx, trades); err != nil {
-		return fmt.Errorf("trade_service: insert batch: %w", err)
+	inserted, err := s.trades.InsertBatch(ctx, trades)
+	if err != nil {
+		return 0, fmt.Errorf("trade_service: insert batch: %w", err)
 	}
+	duplicates := int64(len(trades)) - inserted
+	s.inserted.Add(inserted)
+	s.duplicates.Add(duplicates)
 
-	// Publish events for each trade.
+	// Publish events for each stored trade.
 	for _, t := range trades {
+		if t.ID == 0 {
+			continue // duplicate
+		}
 		evt, _ := json.Marshal(map[string]any{
-			"event":     "trade_ingested",
-			"trade_id":  t.ID,
-			"market":    t.MarketID,
-			"price":     t.Price,
-			"amount":    t.USDAmount,
-			"source":    t.Source,
-			"timestamp": t.Timestamp.Format(time.RFC3339),
+			"event":      "trade_ingested",
+			"trade_id":   t.ID,
+			"market":     t.MarketID,
+			"token_side": t.TokenSide,
+			"price":      t.Price,
+			"amount":     t.USDAmount,
+			"source":     t.Source,
+			"timestamp":  t.Timestamp.Format(time.RFC3339),
 		})
 		if pubErr := s.bus.Publish(ctx, "trades", evt); pubErr != nil {
 			s.logger.WarnContext(ctx, "trade_service: publish event failed",
@@ -66,7 +80,8 @@ func (s *TradeService) IngestTrades(ctx context.Context, trades []domain.Trade)
 
 	// Audit log for the batch.
 	if auditErr := s.audit.Log(ctx, "trades_ingested", map[string]any{
-		"count": len(trades),
+		"count":      inserted,
+		"duplicates": duplicates,
 	}); auditErr != nil {
 		s.logger.WarnContext(ctx, "trade_service: audit log failed",
 			slog.String("error", auditErr.Error()),
@@ -74,10 +89,20 @@ func (s *TradeService) IngestTrades(ctx context.Context, trades []domain.Trade)
 	}
 
 	s.logger.InfoContext(ctx, "trade_service: ingested trades",
-		slog.Int("count", len(trades)),
+		slog.Int64("count", inserted),
+		slog.Int64("duplicates", duplicates),
 	)
 
-	return nil
+	return int(inserted), nil
+}
+
+// IngestStats returns how many trades were stored and how many skipped as
+// duplicates since startup.
+func (s *TradeService) IngestStats() domain.TradeIngestStats {
+	return domain.TradeIngestStats{
+		Inserted:   s.inserted.Load(),
+		Duplicates: s.duplicates.Load(),
+	}
 }
 
 // GetLastTimestamp returns the timestamp of the most recently ingested trade,
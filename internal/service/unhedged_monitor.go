@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	// unhedgedSource is the TradeSignal.Source of corrective orders.
+	unhedgedSource = "unhedged_monitor"
+	// unhedgedAlertInterval is the minimum time between pages while the
+	// limit stays breached.
+	unhedgedAlertInterval = 15 * time.Minute
+	// unhedgedCorrectionCooldown is the minimum time between corrective
+	// signals for one asset, so a correction still in flight is not sent
+	// twice.
+	unhedgedCorrectionCooldown = time.Minute
+	// unhedgedScanLimit caps the executions read per sweep.
+	unhedgedScanLimit = 500
+)
+
+// UnhedgedMonitorConfig configures an UnhedgedExposureMonitor.
+type UnhedgedMonitorConfig struct {
+	Wallet      string
+	MaxNotional float64       // USD notional that may stay unhedged
+	Interval    time.Duration // how often executions are scanned
+	Lookback    time.Duration // executions started earlier are ignored
+	Corrective  bool          // send closing signals on a breach, not just page
+}
+
+// UnhedgedExposureMonitor finds the exposure left behind by multi-leg and
+// cross-platform arb executions that ended partial: legs that filled while
+// another leg was rejected or never placed. It nets the filled legs of the
+// recorded ArbExecutions per asset, caps long exposure at the wallet's open
+// position in that asset (so anything sold since no longer counts), and
+// compares the total against arbitrage.max_unhedged_notional. On a breach it
+// pages through notify and, when corrective, sends FAK signals that close
+// the largest exposures at the top of book until the total is back within
+// the limit. Short exposure cannot be checked against positions and is only
+// paged.
+type UnhedgedExposureMonitor struct {
+	executions domain.ArbExecutionStore
+	positions  domain.PositionStore
+	books      domain.OrderbookCache // optional; required for corrections
+	notifier   AlertNotifier         // optional
+	audit      domain.AuditStore     // optional
+	cfg        UnhedgedMonitorConfig
+	logger     *slog.Logger
+
+	mu          sync.Mutex
+	signals     chan<- domain.TradeSignal
+	exposures   []domain.UnhedgedExposure
+	total       float64
+	alertedAt   time.Time
+	correctedAt map[string]time.Time // token -> last corrective signal
+}
+
+// NewUnhedgedExposureMonitor creates an UnhedgedExposureMonitor. books,
+// notifier and audit may be nil.
+func NewUnhedgedExposureMonitor(
+	executions domain.ArbExecutionStore,
+	positions domain.PositionStore,
+	books domain.OrderbookCache,
+	notifier AlertNotifier,
+	audit domain.AuditStore,
+	cfg UnhedgedMonitorConfig,
+	logger *slog.Logger,
+) *UnhedgedExposureMonitor {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 30 * time.Second
+	}
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 24 * time.Hour
+	}
+	return &UnhedgedExposureMonitor{
+		executions:  executions,
+		positions:   positions,
+		books:       books,
+		notifier:    notifier,
+		audit:       audit,
+		cfg:         cfg,
+		logger:      logger.With(slog.String("component", "unhedged_monitor")),
+		correctedAt: make(map[string]time.Time),
+	}
+}
+
+// WithSignals sets the channel corrective signals are sent on, normally the
+// executor's signal channel so corrections pass the usual risk checks.
+// Without it a breach is only paged.
+func (m *UnhedgedExposureMonitor) WithSignals(ch chan<- domain.TradeSignal) *UnhedgedExposureMonitor {
+	m.mu.Lock()
+	m.signals = ch
+	m.mu.Unlock()
+	return m
+}
+
+// Run scans executions every interval until ctx is cancelled. Call in a
+// goroutine.
+func (m *UnhedgedExposureMonitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	m.logger.InfoContext(ctx, "unhedged exposure monitor started",
+		slog.Duration("interval", m.cfg.Interval),
+		slog.Float64("max_unhedged", m.cfg.MaxNotional),
+		slog.Bool("corrective", m.cfg.Corrective),
+	)
+	defer m.logger.InfoContext(ctx, "unhedged exposure monitor stopped")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.Sweep(ctx, time.Now().UTC())
+		}
+	}
+}
+
+// Exposure returns the unhedged exposures found by the last sweep, largest
+// first, and their total notional.
+func (m *UnhedgedExposureMonitor) Exposure() ([]domain.UnhedgedExposure, float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.UnhedgedExposure, len(m.exposures))
+	copy(out, m.exposures)
+	return out, m.total
+}
+
+// Sweep runs one pass at now and returns the unhedged total.
+func (m *UnhedgedExposureMonitor) Sweep(ctx context.Context, now time.Time) float64 {
+	execs, err := m.executions.ListRecent(ctx, unhedgedScanLimit)
+	if err != nil {
+		m.logger.WarnContext(ctx, "unhedged monitor: list executions failed",
+			slog.String("error", err.Error()))
+		return m.lastTotal()
+	}
+	held, err := m.heldSizes(ctx)
+	if err != nil {
+		m.logger.WarnContext(ctx, "unhedged monitor: list positions failed",
+			slog.String("error", err.Error()))
+		return m.lastTotal()
+	}
+
+	exposures := netUnhedged(execs, now.Add(-m.cfg.Lookback))
+	total := 0.0
+	kept := exposures[:0]
+	for _, x := range exposures {
+		if x.NetSize > 0 {
+			if h := held[x.TokenID]; h < x.NetSize {
+				x.Notional *= h / x.NetSize
+				x.NetSize = h
+			}
+		}
+		if x.Notional < 0.01 {
+			continue
+		}
+		total += x.Notional
+		kept = append(kept, x)
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Notional > kept[j].Notional })
+
+	m.mu.Lock()
+	m.exposures, m.total = kept, total
+	page := total > m.cfg.MaxNotional && now.Sub(m.alertedAt) >= unhedgedAlertInterval
+	if page {
+		m.alertedAt = now
+	}
+	if total <= m.cfg.MaxNotional {
+		m.alertedAt = time.Time{}
+	}
+	for token, at := range m.correctedAt {
+		if now.Sub(at) >= unhedgedCorrectionCooldown {
+			delete(m.correctedAt, token)
+		}
+	}
+	m.mu.Unlock()
+
+	if total <= m.cfg.MaxNotional {
+		return total
+	}
+	if page {
+		m.page(ctx, kept, total)
+	}
+	if m.cfg.Corrective {
+		m.correct(ctx, kept, total, now)
+	}
+	return total
+}
+
+func (m *UnhedgedExposureMonitor) lastTotal() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
+
+// heldSizes returns the size of the wallet's open long positions per token.
+func (m *UnhedgedExposureMonitor) heldSizes(ctx context.Context) (map[string]float64, error) {
+	open, err := m.positions.GetOpen(ctx, m.cfg.Wallet)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[string]float64, len(open))
+	for _, p := range open {
+		if p.Direction == domain.OrderSideSell {
+			continue
+		}
+		held[p.TokenID] += p.Size
+	}
+	return held, nil
+}
+
+// netUnhedged nets the filled legs of partial executions started after since
+// per token. Legs of fully filled executions hedge each other and are
+// skipped.
+func netUnhedged(execs []domain.ArbExecution, since time.Time) []domain.UnhedgedExposure {
+	type acc struct {
+		x          domain.UnhedgedExposure
+		cost, size float64
+	}
+	byToken := make(map[string]*acc)
+	var order []string
+	for _, ex := range execs {
+		if ex.StartedAt.Before(since) {
+			continue
+		}
+		if ex.Status != domain.ArbExecPartial && ex.Status != domain.ArbExecFailed {
+			continue
+		}
+		for _, leg := range ex.Legs {
+			if !leg.Filled() || leg.TokenID == "" || leg.Size <= 0 {
+				continue
+			}
+			a, ok := byToken[leg.TokenID]
+			if !ok {
+				a = &acc{x: domain.UnhedgedExposure{TokenID: leg.TokenID, MarketID: leg.MarketID}}
+				byToken[leg.TokenID] = a
+				order = append(order, leg.TokenID)
+			}
+			price := leg.FilledPrice
+			if price <= 0 {
+				price = leg.ExpectedPrice
+			}
+			if leg.Side == domain.OrderSideSell {
+				a.x.NetSize -= leg.Size
+			} else {
+				a.x.NetSize += leg.Size
+			}
+			a.cost += price * leg.Size
+			a.size += leg.Size
+			if n := len(a.x.Executions); n == 0 || a.x.Executions[n-1] != ex.ID {
+				a.x.Executions = append(a.x.Executions, ex.ID)
+			}
+		}
+	}
+	out := make([]domain.UnhedgedExposure, 0, len(order))
+	for _, token := range order {
+		a := byToken[token]
+		if a.size > 0 {
+			a.x.Notional = math.Abs(a.x.NetSize) * a.cost / a.size
+		}
+		out = append(out, a.x)
+	}
+	return out
+}
+
+// page notifies the operator and audits the breach.
+func (m *UnhedgedExposureMonitor) page(ctx context.Context, exposures []domain.UnhedgedExposure, total float64) {
+	m.logger.ErrorContext(ctx, "unhedged exposure over limit",
+		slog.Float64("unhedged_total", total),
+		slog.Float64("max_unhedged", m.cfg.MaxNotional),
+		slog.Int("assets", len(exposures)),
+	)
+
+	lines := make([]string, 0, min(len(exposures), 5))
+	for _, x := range exposures[:min(len(exposures), 5)] {
+		lines = append(lines, fmt.Sprintf("%s: %+.2f shares ($%.2f, %d executions)",
+			x.TokenID, x.NetSize, x.Notional, len(x.Executions)))
+	}
+	if m.notifier != nil {
+		msg := fmt.Sprintf("Unhedged arb exposure $%.2f exceeds limit $%.2f across %d assets:\n%s",
+			total, m.cfg.MaxNotional, len(exposures), strings.Join(lines, "\n"))
+		if err := m.notifier.Notify(ctx, "unhedged_exposure", "Unhedged exposure over limit", msg); err != nil {
+			m.logger.WarnContext(ctx, "unhedged monitor: notify failed", slog.String("error", err.Error()))
+		}
+	}
+	if m.audit != nil {
+		if err := m.audit.Log(ctx, "unhedged_exposure_breach", map[string]any{
+			"unhedged_total": total,
+			"max_unhedged":   m.cfg.MaxNotional,
+			"assets":         len(exposures),
+			"top":            lines,
+		}); err != nil {
+			m.logger.WarnContext(ctx, "unhedged monitor: audit log failed", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// correct sends closing signals for the largest long exposures until the
+// remaining total is within the limit.
+func (m *UnhedgedExposureMonitor) correct(ctx context.Context, exposures []domain.UnhedgedExposure, total float64, now time.Time) {
+	m.mu.Lock()
+	ch := m.signals
+	m.mu.Unlock()
+	if ch == nil || m.books == nil {
+		return
+	}
+
+	for _, x := range exposures {
+		if total <= m.cfg.MaxNotional {
+			return
+		}
+		if x.NetSize <= 0 {
+			continue
+		}
+		m.mu.Lock()
+		recent := now.Sub(m.correctedAt[x.TokenID]) < unhedgedCorrectionCooldown
+		m.mu.Unlock()
+		if recent {
+			total -= x.Notional
+			continue
+		}
+
+		log := m.logger.With(slog.String("token", x.TokenID), slog.String("market", x.MarketID))
+		bid, _, err := m.books.GetBBO(ctx, x.TokenID)
+		if err != nil || bid <= 0 {
+			log.WarnContext(ctx, "unhedged monitor: no bid to close exposure")
+			continue
+		}
+		sig := domain.TradeSignal{
+			ID:         uuid.New().String(),
+			Source:     unhedgedSource,
+			MarketID:   x.MarketID,
+			TokenID:    x.TokenID,
+			Side:       domain.OrderSideSell,
+			PriceTicks: domain.ToPriceTicks(bid),
+			SizeUnits:  domain.ToSizeUnits(x.NetSize),
+			Urgency:    domain.SignalUrgencyImmediate,
+			Reason:     fmt.Sprintf("close unhedged exposure of %d arb executions", len(x.Executions)),
+			Metadata:   map[string]string{"unhedged_of": strings.Join(x.Executions, ",")},
+			CreatedAt:  now,
+			ExpiresAt:  now.Add(m.cfg.Interval),
+			OrderType:  domain.OrderTypeFAK,
+		}
+		select {
+		case ch <- sig:
+		case <-ctx.Done():
+			return
+		}
+
+		m.mu.Lock()
+		m.correctedAt[x.TokenID] = now
+		m.mu.Unlock()
+		total -= x.Notional
+		log.WarnContext(ctx, "unhedged monitor: corrective signal sent",
+			slog.String("signal_id", sig.ID),
+			slog.Float64("size", x.NetSize),
+			slog.Float64("price", bid),
+			slog.Float64("unhedged_total", total),
+		)
+	}
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// AlertStore implements domain.AlertStore using PostgreSQL.
+type AlertStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewAlertStore creates a new AlertStore.
+func NewAlertStore(pool *pgxpool.Pool) *AlertStore {
+	return &AlertStore{pool: pool}
+}
+
+const alertColumns = `id, market_id, token_id, metric, operator, threshold, recurring, cooldown_seconds,
+	enabled, note, trigger_count, last_triggered_at, created_at, updated_at`
+
+// Create inserts a new alert.
+func (s *AlertStore) Create(ctx context.Context, a domain.Alert) error {
+	const query = `
+		INSERT INTO alerts (id, market_id, token_id, metric, operator, threshold, recurring, cooldown_seconds, enabled, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)`
+	_, err := s.pool.Exec(ctx, query,
+		a.ID, a.MarketID, a.TokenID, string(a.Metric), string(a.Operator), a.Threshold,
+		a.Recurring, int(a.Cooldown/time.Second), a.Enabled, a.Note, a.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: create alert %s: %w", a.ID, err)
+	}
+	return nil
+}
+
+// Update overwrites the user-editable fields of an alert.
+func (s *AlertStore) Update(ctx context.Context, a domain.Alert) error {
+	const query = `
+		UPDATE alerts SET
+			market_id = $2, token_id = $3, metric = $4, operator = $5, threshold = $6,
+			recurring = $7, cooldown_seconds = $8, enabled = $9, note = $10, updated_at = NOW()
+		WHERE id = $1`
+	tag, err := s.pool.Exec(ctx, query,
+		a.ID, a.MarketID, a.TokenID, string(a.Metric), string(a.Operator), a.Threshold,
+		a.Recurring, int(a.Cooldown/time.Second), a.Enabled, a.Note,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: update alert %s: %w", a.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes an alert.
+func (s *AlertStore) Delete(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM alerts WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete alert %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID returns an alert by id.
+func (s *AlertStore) GetByID(ctx context.Context, id string) (domain.Alert, error) {
+	query := `SELECT ` + alertColumns + ` FROM alerts WHERE id = $1`
+	a, err := scanAlert(s.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Alert{}, domain.ErrNotFound
+		}
+		return domain.Alert{}, fmt.Errorf("postgres: get alert %s: %w", id, err)
+	}
+	return a, nil
+}
+
+// List returns all alerts, newest first.
+func (s *AlertStore) List(ctx context.Context) ([]domain.Alert, error) {
+	list, err := s.queryAlerts(ctx, `SELECT `+alertColumns+` FROM alerts ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list alerts: %w", err)
+	}
+	return list, nil
+}
+
+// ListEnabled returns all enabled alerts.
+func (s *AlertStore) ListEnabled(ctx context.Context) ([]domain.Alert, error) {
+	list, err := s.queryAlerts(ctx, `SELECT `+alertColumns+` FROM alerts WHERE enabled ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list enabled alerts: %w", err)
+	}
+	return list, nil
+}
+
+// MarkTriggered records a firing of the alert.
+func (s *AlertStore) MarkTriggered(ctx context.Context, id string, at time.Time, disable bool) error {
+	const query = `
+		UPDATE alerts SET
+			trigger_count = trigger_count + 1,
+			last_triggered_at = $2,
+			enabled = enabled AND NOT $3,
+			updated_at = NOW()
+		WHERE id = $1`
+	if _, err := s.pool.Exec(ctx, query, id, at, disable); err != nil {
+		return fmt.Errorf("postgres: mark alert %s triggered: %w", id, err)
+	}
+	return nil
+}
+
+func (s *AlertStore) queryAlerts(ctx context.Context, query string, args ...any) ([]domain.Alert, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var list []domain.Alert
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+func scanAlert(row pgx.Row) (domain.Alert, error) {
+	var a domain.Alert
+	var metric, op string
+	var cooldownSec int
+	err := row.Scan(
+		&a.ID, &a.MarketID, &a.TokenID, &metric, &op, &a.Threshold, &a.Recurring, &cooldownSec,
+		&a.Enabled, &a.Note, &a.TriggerCount, &a.LastTriggeredAt, &a.CreatedAt, &a.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Alert{}, err
+	}
+	a.Metric = domain.AlertMetric(metric)
+	a.Operator = domain.AlertOperator(op)
+	a.Cooldown = time.Duration(cooldownSec) * time.Second
+	return a, nil
+}
@@ -157,3 +157,155 @@ func (s *ArbExecutionStore) SumPnLByType(ctx context.Context, arbType domain.Arb
 	}
 	return sum, nil
 }
+
+// Stats aggregates arb_history and arb_executions over [q.Since, q.Until):
+// totals and duration percentiles, a net edge histogram, executions per arb
+// type and first-leg strategy, and opportunities per market.
+func (s *ArbExecutionStore) Stats(ctx context.Context, q domain.ArbStatsQuery) (domain.ArbStats, error) {
+	stats := domain.ArbStats{Since: q.Since, Until: q.Until}
+	bucket := q.EdgeBucketBps
+	if bucket <= 0 {
+		bucket = 10
+	}
+
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE executed),
+		       COUNT(duration_ms),
+		       COALESCE(AVG(duration_ms), 0)::float8,
+		       COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_ms), 0)::float8,
+		       COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY duration_ms), 0)::float8,
+		       COALESCE(MAX(duration_ms), 0)::float8
+		FROM arb_history
+		WHERE detected_at >= $1 AND detected_at < $2`,
+		q.Since, q.Until,
+	).Scan(&stats.Detected, &stats.Executed, &stats.Duration.Count,
+		&stats.Duration.AvgMs, &stats.Duration.P50Ms, &stats.Duration.P90Ms, &stats.Duration.MaxMs)
+	if err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb stats opportunities: %w", err)
+	}
+
+	err = s.pool.QueryRow(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'filled'),
+		       COALESCE(SUM(net_pnl_usd), 0)::float8
+		FROM arb_executions
+		WHERE started_at >= $1 AND started_at < $2`,
+		q.Since, q.Until,
+	).Scan(&stats.Executions, &stats.Filled, &stats.NetPnLUSD)
+	if err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb stats executions: %w", err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT floor(net_edge_bps / $3)::bigint AS bucket,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE executed)
+		FROM arb_history
+		WHERE detected_at >= $1 AND detected_at < $2
+		GROUP BY bucket
+		ORDER BY bucket`,
+		q.Since, q.Until, bucket,
+	)
+	if err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb stats edge histogram: %w", err)
+	}
+	for rows.Next() {
+		var n int64
+		var b domain.ArbEdgeBucket
+		if err := rows.Scan(&n, &b.Detected, &b.Executed); err != nil {
+			rows.Close()
+			return domain.ArbStats{}, fmt.Errorf("postgres: scan arb edge bucket: %w", err)
+		}
+		b.LowBps = float64(n) * bucket
+		b.HighBps = b.LowBps + bucket
+		stats.EdgeHistogram = append(stats.EdgeHistogram, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb edge histogram rows: %w", err)
+	}
+
+	// Executions are attributed to the strategy of their first leg's order,
+	// as in strategy performance.
+	rows, err = s.pool.Query(ctx, `
+		SELECT e.arb_type,
+		       COALESCE(leg.strategy_name, '') AS strategy,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE e.status = 'filled'),
+		       COALESCE(SUM(e.net_pnl_usd), 0)::float8,
+		       COALESCE(SUM(e.total_fees), 0)::float8,
+		       COALESCE(AVG(e.gross_edge_bps), 0)::float8
+		FROM arb_executions e
+		LEFT JOIN LATERAL (
+			SELECT o.strategy_name
+			FROM arb_execution_legs l
+			JOIN orders o ON o.id = l.order_id
+			WHERE l.execution_id = e.id
+			ORDER BY l.id
+			LIMIT 1
+		) leg ON true
+		WHERE e.started_at >= $1 AND e.started_at < $2
+		GROUP BY e.arb_type, strategy
+		ORDER BY e.arb_type, strategy`,
+		q.Since, q.Until,
+	)
+	if err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb stats by strategy: %w", err)
+	}
+	for rows.Next() {
+		var st domain.ArbStrategyStats
+		var arbType string
+		if err := rows.Scan(&arbType, &st.Strategy, &st.Executions, &st.Filled,
+			&st.NetPnLUSD, &st.FeesUSD, &st.AvgGrossEdgeBps); err != nil {
+			rows.Close()
+			return domain.ArbStats{}, fmt.Errorf("postgres: scan arb strategy stats: %w", err)
+		}
+		st.ArbType = domain.ArbType(arbType)
+		stats.ByStrategy = append(stats.ByStrategy, st)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb strategy stats rows: %w", err)
+	}
+
+	maxMarkets := q.MaxMarkets
+	if maxMarkets <= 0 {
+		maxMarkets = 20
+	}
+	rows, err = s.pool.Query(ctx, `
+		SELECT COALESCE(h.poly_market_id, '') AS market,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE h.executed),
+		       COALESCE(AVG(h.net_edge_bps), 0)::float8,
+		       COALESCE(SUM(h.expected_pnl_usd), 0)::float8,
+		       COALESCE(SUM(x.pnl), 0)::float8
+		FROM arb_history h
+		LEFT JOIN LATERAL (
+			SELECT SUM(e.net_pnl_usd) AS pnl
+			FROM arb_executions e
+			WHERE e.opportunity_id = h.id
+		) x ON true
+		WHERE h.detected_at >= $1 AND h.detected_at < $2
+		GROUP BY market
+		ORDER BY COUNT(*) DESC, market
+		LIMIT $3`,
+		q.Since, q.Until, maxMarkets,
+	)
+	if err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb stats by market: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m domain.ArbMarketStats
+		if err := rows.Scan(&m.MarketID, &m.Detected, &m.Executed,
+			&m.AvgNetEdgeBps, &m.ExpectedPnLUSD, &m.NetPnLUSD); err != nil {
+			return domain.ArbStats{}, fmt.Errorf("postgres: scan arb market stats: %w", err)
+		}
+		stats.ByMarket = append(stats.ByMarket, m)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.ArbStats{}, fmt.Errorf("postgres: arb market stats rows: %w", err)
+	}
+	return stats, nil
+}
@@ -24,7 +24,9 @@ const arbSelectCols = `id, poly_market_id, poly_token_id, poly_price,
 	kalshi_market_id, kalshi_price,
 	gross_edge_bps, est_fee_bps, est_slippage_bps, est_latency_bps,
 	net_edge_bps, expected_pnl_usd, direction, max_amount,
-	detected_at, duration_ms, executed, executed_at`
+	detected_at, duration_ms, executed, executed_at,
+	status, dedup_key, last_seen_at, closed_at, execution_id,
+	COALESCE(realized_edge_bps, 0), COALESCE(realized_pnl_usd, 0)`
 
 // Insert stores a new arbitrage opportunity.
 func (s *ArbStore) Insert(ctx context.Context, opp domain.ArbOpportunity) error {
@@ -34,16 +36,29 @@ func (s *ArbStore) Insert(ctx context.Context, opp domain.ArbOpportunity) error
 			kalshi_market_id, kalshi_price,
 			gross_edge_bps, est_fee_bps, est_slippage_bps, est_latency_bps,
 			net_edge_bps, expected_pnl_usd, direction, max_amount,
-			detected_at, duration_ms, executed, executed_at
+			detected_at, duration_ms, executed, executed_at,
+			status, dedup_key, last_seen_at
 		) VALUES (
 			$1, $2, $3, $4,
 			$5, $6,
 			$7, $8, $9, $10,
 			$11, $12, $13, $14,
-			$15, $16, $17, $18
+			$15, $16, $17, $18,
+			$19, $20, $21
 		)`
 
 	durationMs := opp.Duration.Milliseconds()
+	status := opp.Status
+	if status == "" {
+		status = domain.ArbOppOpen
+		if opp.Executed {
+			status = domain.ArbOppExecuted
+		}
+	}
+	lastSeen := opp.LastSeenAt
+	if lastSeen.IsZero() {
+		lastSeen = opp.DetectedAt
+	}
 
 	// executed_at is only meaningful when Executed is true.
 	var executedAt *time.Time
@@ -58,6 +73,7 @@ func (s *ArbStore) Insert(ctx context.Context, opp domain.ArbOpportunity) error
 		opp.GrossEdgeBps, opp.EstFeeBps, opp.EstSlippageBps, opp.EstLatencyBps,
 		opp.NetEdgeBps, opp.ExpectedPnLUSD, opp.Direction, opp.MaxAmount,
 		opp.DetectedAt, durationMs, opp.Executed, executedAt,
+		string(status), opp.DedupKey, lastSeen,
 	)
 	if err != nil {
 		return fmt.Errorf("postgres: insert arb opportunity %s: %w", opp.ID, err)
@@ -70,7 +86,9 @@ func (s *ArbStore) MarkExecuted(ctx context.Context, id string) error {
 	const query = `
 		UPDATE arb_history SET
 			executed    = TRUE,
-			executed_at = NOW()
+			executed_at = NOW(),
+			status      = 'executed',
+			closed_at   = COALESCE(closed_at, NOW())
 		WHERE id = $1`
 
 	tag, err := s.pool.Exec(ctx, query, id)
@@ -83,6 +101,35 @@ func (s *ArbStore) MarkExecuted(ctx context.Context, id string) error {
 	return nil
 }
 
+// Close moves an opportunity to executed (from open or expired) or expired
+// (from open only), setting its duration from the last detection. It returns
+// domain.ErrNotFound when id cannot make the transition.
+func (s *ArbStore) Close(ctx context.Context, id string, c domain.ArbOpportunityClose) error {
+	const query = `
+		UPDATE arb_history SET
+			status            = $2::TEXT,
+			closed_at         = $3,
+			last_seen_at      = GREATEST(last_seen_at, $4),
+			duration_ms       = GREATEST(0, (EXTRACT(EPOCH FROM (GREATEST(last_seen_at, $4) - detected_at)) * 1000)::BIGINT),
+			executed          = executed OR $2 = 'executed',
+			executed_at       = CASE WHEN $2 = 'executed' THEN $3::TIMESTAMPTZ ELSE executed_at END,
+			execution_id      = NULLIF($5::TEXT, ''),
+			realized_edge_bps = CASE WHEN $2 = 'executed' THEN $6::NUMERIC END,
+			realized_pnl_usd  = CASE WHEN $2 = 'executed' THEN $7::NUMERIC END
+		WHERE id = $1
+		  AND (status = 'open' OR ($2 = 'executed' AND status = 'expired'))`
+
+	tag, err := s.pool.Exec(ctx, query, id, string(c.Status), c.At, c.LastSeenAt,
+		c.ExecutionID, c.RealizedEdgeBps, c.RealizedPnLUSD)
+	if err != nil {
+		return fmt.Errorf("postgres: close arb opportunity %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 // ListRecent returns the most recent arbitrage opportunities ordered by detection time.
 func (s *ArbStore) ListRecent(ctx context.Context, limit int) ([]domain.ArbOpportunity, error) {
 	query := `SELECT ` + arbSelectCols + ` FROM arb_history ORDER BY detected_at DESC`
@@ -103,7 +150,9 @@ func (s *ArbStore) ListRecent(ctx context.Context, limit int) ([]domain.ArbOppor
 	for rows.Next() {
 		var opp domain.ArbOpportunity
 		var durationMs int64
-		var executedAt *time.Time
+		var executedAt, lastSeen *time.Time
+		var status string
+		var executionID *string
 
 		if err := rows.Scan(
 			&opp.ID, &opp.PolyMarketID, &opp.PolyTokenID, &opp.PolyPrice,
@@ -111,10 +160,19 @@ func (s *ArbStore) ListRecent(ctx context.Context, limit int) ([]domain.ArbOppor
 			&opp.GrossEdgeBps, &opp.EstFeeBps, &opp.EstSlippageBps, &opp.EstLatencyBps,
 			&opp.NetEdgeBps, &opp.ExpectedPnLUSD, &opp.Direction, &opp.MaxAmount,
 			&opp.DetectedAt, &durationMs, &opp.Executed, &executedAt,
+			&status, &opp.DedupKey, &lastSeen, &opp.ClosedAt, &executionID,
+			&opp.RealizedEdgeBps, &opp.RealizedPnLUSD,
 		); err != nil {
 			return nil, fmt.Errorf("postgres: scan arb: %w", err)
 		}
 		opp.Duration = time.Duration(durationMs) * time.Millisecond
+		opp.Status = domain.ArbOpportunityStatus(status)
+		if lastSeen != nil {
+			opp.LastSeenAt = *lastSeen
+		}
+		if executionID != nil {
+			opp.ExecutionID = *executionID
+		}
 		opps = append(opps, opp)
 	}
 	if err := rows.Err(); err != nil {
@@ -136,7 +194,9 @@ func (s *ArbStore) ListBefore(ctx context.Context, before time.Time) ([]domain.A
 	for rows.Next() {
 		var opp domain.ArbOpportunity
 		var durationMs int64
-		var executedAt *time.Time
+		var executedAt, lastSeen *time.Time
+		var status string
+		var executionID *string
 
 		if err := rows.Scan(
 			&opp.ID, &opp.PolyMarketID, &opp.PolyTokenID, &opp.PolyPrice,
@@ -144,10 +204,19 @@ func (s *ArbStore) ListBefore(ctx context.Context, before time.Time) ([]domain.A
 			&opp.GrossEdgeBps, &opp.EstFeeBps, &opp.EstSlippageBps, &opp.EstLatencyBps,
 			&opp.NetEdgeBps, &opp.ExpectedPnLUSD, &opp.Direction, &opp.MaxAmount,
 			&opp.DetectedAt, &durationMs, &opp.Executed, &executedAt,
+			&status, &opp.DedupKey, &lastSeen, &opp.ClosedAt, &executionID,
+			&opp.RealizedEdgeBps, &opp.RealizedPnLUSD,
 		); err != nil {
 			return nil, fmt.Errorf("postgres: scan arb: %w", err)
 		}
 		opp.Duration = time.Duration(durationMs) * time.Millisecond
+		opp.Status = domain.ArbOpportunityStatus(status)
+		if lastSeen != nil {
+			opp.LastSeenAt = *lastSeen
+		}
+		if executionID != nil {
+			opp.ExecutionID = *executionID
+		}
 		opps = append(opps, opp)
 	}
 	if err := rows.Err(); err != nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
@@ -69,6 +70,51 @@ func (s *AuditStore) List(ctx context.Context, opts domain.ListOpts) ([]domain.A
 	if err != nil {
 		return nil, fmt.Errorf("postgres: list audit entries: %w", err)
 	}
+	return scanAuditEntries(rows)
+}
+
+// Query returns the entries matching q, newest first, keyset-paged on
+// (created_at, id).
+func (s *AuditStore) Query(ctx context.Context, q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	query := `SELECT id, event, detail, created_at FROM audit_log WHERE 1=1`
+	args := []any{}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(q.Events) > 0 {
+		query += " AND event = ANY(" + arg(q.Events) + ")"
+	}
+	if !q.From.IsZero() {
+		query += " AND created_at >= " + arg(q.From)
+	}
+	if !q.To.IsZero() {
+		query += " AND created_at < " + arg(q.To)
+	}
+	if q.OrderID != "" {
+		query += " AND detail ? 'order_id' AND detail->>'order_id' = " + arg(q.OrderID)
+	}
+	if q.PositionID != "" {
+		query += " AND detail ? 'position_id' AND detail->>'position_id' = " + arg(q.PositionID)
+	}
+	if q.Before != nil {
+		query += fmt.Sprintf(" AND (created_at, id) < (%s, %s)", arg(q.Before.CreatedAt), arg(q.Before.ID))
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if q.Limit > 0 {
+		query += " LIMIT " + arg(q.Limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: query audit entries: %w", err)
+	}
+	return scanAuditEntries(rows)
+}
+
+// scanAuditEntries reads (id, event, detail, created_at) rows and closes them.
+func scanAuditEntries(rows pgx.Rows) ([]domain.AuditEntry, error) {
 	defer rows.Close()
 
 	var entries []domain.AuditEntry
@@ -38,9 +38,13 @@ func (s *BondPositionStore) Create(ctx context.Context, pos domain.BondPosition)
 func (s *BondPositionStore) Update(ctx context.Context, pos domain.BondPosition) error {
 	const query = `
 		UPDATE bond_positions SET
-			status = $2, realized_pnl = $3, resolved_at = $4
+			entry_price = $2, size = $3, status = $4, realized_pnl = $5,
+			exited_size = $6, exit_price = $7, resolved_at = $8
 		WHERE id = $1`
-	_, err := s.pool.Exec(ctx, query, pos.ID, string(pos.Status), pos.RealizedPnL, pos.ResolvedAt)
+	_, err := s.pool.Exec(ctx, query,
+		pos.ID, pos.EntryPrice, pos.Size, string(pos.Status), pos.RealizedPnL,
+		pos.ExitedSize, pos.ExitPrice, pos.ResolvedAt,
+	)
 	if err != nil {
 		return fmt.Errorf("postgres: update bond_position %s: %w", pos.ID, err)
 	}
@@ -50,21 +54,29 @@ func (s *BondPositionStore) Update(ctx context.Context, pos domain.BondPosition)
 // GetOpen returns all open bond positions.
 func (s *BondPositionStore) GetOpen(ctx context.Context) ([]domain.BondPosition, error) {
 	const query = `
-		SELECT id, market_id, token_id, entry_price, expected_expiry, expected_apr, size, status, realized_pnl, created_at, resolved_at
+		SELECT id, market_id, token_id, entry_price, expected_expiry, expected_apr, size, status, realized_pnl, exited_size, exit_price, created_at, resolved_at
 		FROM bond_positions WHERE status = 'open' ORDER BY expected_expiry`
 	return s.queryPositions(ctx, query)
 }
 
+// GetClosed returns resolved and exited bond positions, most recent first.
+func (s *BondPositionStore) GetClosed(ctx context.Context) ([]domain.BondPosition, error) {
+	const query = `
+		SELECT id, market_id, token_id, entry_price, expected_expiry, expected_apr, size, status, realized_pnl, exited_size, exit_price, created_at, resolved_at
+		FROM bond_positions WHERE status <> 'open' ORDER BY resolved_at DESC NULLS LAST`
+	return s.queryPositions(ctx, query)
+}
+
 // GetByID returns a bond position by id.
 func (s *BondPositionStore) GetByID(ctx context.Context, id string) (domain.BondPosition, error) {
 	const query = `
-		SELECT id, market_id, token_id, entry_price, expected_expiry, expected_apr, size, status, realized_pnl, created_at, resolved_at
+		SELECT id, market_id, token_id, entry_price, expected_expiry, expected_apr, size, status, realized_pnl, exited_size, exit_price, created_at, resolved_at
 		FROM bond_positions WHERE id = $1`
 	var pos domain.BondPosition
 	var status string
 	err := s.pool.QueryRow(ctx, query, id).Scan(
 		&pos.ID, &pos.MarketID, &pos.TokenID, &pos.EntryPrice, &pos.ExpectedExpiry, &pos.ExpectedAPR,
-		&pos.Size, &status, &pos.RealizedPnL, &pos.CreatedAt, &pos.ResolvedAt,
+		&pos.Size, &status, &pos.RealizedPnL, &pos.ExitedSize, &pos.ExitPrice, &pos.CreatedAt, &pos.ResolvedAt,
 	)
 	if err != nil {
 		return domain.BondPosition{}, fmt.Errorf("postgres: get bond_position %s: %w", id, err)
@@ -85,7 +97,7 @@ func (s *BondPositionStore) queryPositions(ctx context.Context, query string, ar
 		var status string
 		if err := rows.Scan(
 			&pos.ID, &pos.MarketID, &pos.TokenID, &pos.EntryPrice, &pos.ExpectedExpiry, &pos.ExpectedAPR,
-			&pos.Size, &status, &pos.RealizedPnL, &pos.CreatedAt, &pos.ResolvedAt,
+			&pos.Size, &status, &pos.RealizedPnL, &pos.ExitedSize, &pos.ExitPrice, &pos.CreatedAt, &pos.ResolvedAt,
 		); err != nil {
 			return nil, err
 		}
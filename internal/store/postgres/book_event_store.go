@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// BookEventStore implements domain.BookEventStore using PostgreSQL.
+type BookEventStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewBookEventStore creates a new BookEventStore backed by the given connection pool.
+func NewBookEventStore(pool *pgxpool.Pool) *BookEventStore {
+	return &BookEventStore{pool: pool}
+}
+
+// InsertBatch inserts recorded book events using a pgx Batch. Snapshot levels
+// are stored as JSONB; delta events leave bids/asks NULL.
+func (s *BookEventStore) InsertBatch(ctx context.Context, events []domain.BookEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	const query = `
+		INSERT INTO book_events (asset_id, kind, bids, asks, side, price, size, best_bid, best_ask, ts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	for _, e := range events {
+		var bidsJSON, asksJSON []byte
+		if e.Kind == domain.BookEventSnapshot {
+			var err error
+			if bidsJSON, err = json.Marshal(e.Bids); err != nil {
+				return fmt.Errorf("postgres: marshal book event bids: %w", err)
+			}
+			if asksJSON, err = json.Marshal(e.Asks); err != nil {
+				return fmt.Errorf("postgres: marshal book event asks: %w", err)
+			}
+		}
+		batch.Queue(query,
+			e.AssetID, string(e.Kind), bidsJSON, asksJSON,
+			e.Side, e.Price, e.Size, e.BestBid, e.BestAsk, e.Timestamp,
+		)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range events {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("postgres: insert book event batch item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ListRange returns events for the given assets (or all assets when assetIDs
+// is empty) with ts in [from, to], oldest first.
+func (s *BookEventStore) ListRange(ctx context.Context, assetIDs []string, from, to time.Time, limit int) ([]domain.BookEvent, error) {
+	query := `
+		SELECT id, asset_id, kind, bids, asks, COALESCE(side, ''), COALESCE(price, 0), COALESCE(size, 0),
+		       COALESCE(best_bid, 0), COALESCE(best_ask, 0), ts
+		FROM book_events
+		WHERE ts >= $1 AND ts <= $2`
+	args := []any{from, to}
+	argIdx := 3
+	if len(assetIDs) > 0 {
+		query += fmt.Sprintf(" AND asset_id = ANY($%d)", argIdx)
+		args = append(args, assetIDs)
+		argIdx++
+	}
+	query += " ORDER BY ts, id"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list book events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.BookEvent
+	for rows.Next() {
+		var e domain.BookEvent
+		var kind string
+		var bidsJSON, asksJSON []byte
+
+		if err := rows.Scan(
+			&e.ID, &e.AssetID, &kind, &bidsJSON, &asksJSON, &e.Side, &e.Price, &e.Size,
+			&e.BestBid, &e.BestAsk, &e.Timestamp,
+		); err != nil {
+			return nil, fmt.Errorf("postgres: scan book event: %w", err)
+		}
+		e.Kind = domain.BookEventKind(kind)
+
+		if bidsJSON != nil {
+			if err := json.Unmarshal(bidsJSON, &e.Bids); err != nil {
+				return nil, fmt.Errorf("postgres: unmarshal book event bids: %w", err)
+			}
+		}
+		if asksJSON != nil {
+			if err := json.Unmarshal(asksJSON, &e.Asks); err != nil {
+				return nil, fmt.Errorf("postgres: unmarshal book event asks: %w", err)
+			}
+		}
+
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list book events rows: %w", err)
+	}
+	return events, nil
+}
+
+// DeleteBefore deletes all book events recorded before the given time. Returns the number deleted.
+func (s *BookEventStore) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM book_events WHERE ts < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: delete book events before: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// CandleStore implements domain.CandleStore using PostgreSQL.
+type CandleStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewCandleStore creates a new CandleStore backed by the given connection pool.
+func NewCandleStore(pool *pgxpool.Pool) *CandleStore {
+	return &CandleStore{pool: pool}
+}
+
+// UpsertBatch merges candles into price_candles using a pgx Batch.
+func (s *CandleStore) UpsertBatch(ctx context.Context, candles []domain.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	const query = `
+		INSERT INTO price_candles (asset_id, bucket, open, high, low, close, volume, trades)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (asset_id, bucket) DO UPDATE SET
+			high   = GREATEST(price_candles.high, EXCLUDED.high),
+			low    = LEAST(price_candles.low, EXCLUDED.low),
+			close  = EXCLUDED.close,
+			volume = price_candles.volume + EXCLUDED.volume,
+			trades = price_candles.trades + EXCLUDED.trades`
+
+	for _, c := range candles {
+		batch.Queue(query,
+			c.AssetID, c.Start, c.Open, c.High, c.Low, c.Close, c.Volume, c.Trades,
+		)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range candles {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("postgres: upsert candle batch item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ListRange aggregates the stored one-minute candles of assetID into bars of
+// the given interval, aligned to the Unix epoch, starting in [from, to).
+func (s *CandleStore) ListRange(ctx context.Context, assetID string, interval time.Duration, from, to time.Time, limit int) ([]domain.Candle, error) {
+	if interval < domain.CandleBase {
+		interval = domain.CandleBase
+	}
+	query := `
+		SELECT bar,
+		       (array_agg(open ORDER BY bucket))[1],
+		       MAX(high),
+		       MIN(low),
+		       (array_agg(close ORDER BY bucket DESC))[1],
+		       SUM(volume),
+		       SUM(trades)
+		FROM (
+			SELECT to_timestamp(floor(extract(epoch FROM bucket)::float8 / $2::float8) * $2::float8) AS bar,
+			       bucket, open, high, low, close, volume, trades
+			FROM price_candles
+			WHERE asset_id = $1 AND bucket >= $3 AND bucket < $4
+		) c
+		GROUP BY bar
+		ORDER BY bar DESC`
+	args := []any{assetID, interval.Seconds(), from, to}
+	if limit > 0 {
+		query += " LIMIT $5"
+		args = append(args, limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list candles: %w", err)
+	}
+	defer rows.Close()
+
+	var candles []domain.Candle
+	for rows.Next() {
+		c := domain.Candle{AssetID: assetID}
+		if err := rows.Scan(&c.Start, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume, &c.Trades); err != nil {
+			return nil, fmt.Errorf("postgres: scan candle: %w", err)
+		}
+		candles = append(candles, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list candles rows: %w", err)
+	}
+	// Newest first for LIMIT; callers get them oldest first.
+	slices.Reverse(candles)
+	return candles, nil
+}
+
+// DeleteBefore deletes all candles starting before the given time. Returns the number deleted.
+func (s *CandleStore) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM price_candles WHERE bucket < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: delete candles before: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
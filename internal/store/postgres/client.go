@@ -3,20 +3,14 @@ package postgres
 
 import (
 	"context"
-	"embed"
 	"errors"
 	"fmt"
-	"io/fs"
 	"net"
-	"sort"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-//go:embed migrations/*.sql
-var migrationsFS embed.FS
-
 // ClientConfig holds connection parameters for the PostgreSQL client.
 type ClientConfig struct {
 	DSN      string
@@ -128,82 +122,15 @@ func (c *Client) Pool() *pgxpool.Pool {
 	return c.pool
 }
 
+// Ping checks that the database answers.
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("postgres: ping: %w", err)
+	}
+	return nil
+}
+
 // Close shuts down the connection pool.
 func (c *Client) Close() {
 	c.pool.Close()
 }
-
-// RunMigrations reads embedded SQL files from the migrations/ directory,
-// applies them in lexicographic order, and tracks applied migrations in a
-// schema_migrations table.
-func (c *Client) RunMigrations(ctx context.Context) error {
-	// Ensure the tracking table exists.
-	const createTracker = `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			filename TEXT PRIMARY KEY,
-			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-		);`
-	if _, err := c.pool.Exec(ctx, createTracker); err != nil {
-		return fmt.Errorf("postgres: create schema_migrations table: %w", err)
-	}
-
-	// Read all migration files.
-	entries, err := fs.ReadDir(migrationsFS, "migrations")
-	if err != nil {
-		return fmt.Errorf("postgres: read migrations dir: %w", err)
-	}
-
-	// Sort entries by name to guarantee order.
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Name() < entries[j].Name()
-	})
-
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
-			continue
-		}
-
-		// Check if already applied.
-		var exists bool
-		err := c.pool.QueryRow(ctx,
-			"SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE filename = $1)",
-			entry.Name(),
-		).Scan(&exists)
-		if err != nil {
-			return fmt.Errorf("postgres: check migration %s: %w", entry.Name(), err)
-		}
-		if exists {
-			continue
-		}
-
-		// Read and execute the migration.
-		data, err := migrationsFS.ReadFile("migrations/" + entry.Name())
-		if err != nil {
-			return fmt.Errorf("postgres: read migration %s: %w", entry.Name(), err)
-		}
-
-		tx, err := c.pool.Begin(ctx)
-		if err != nil {
-			return fmt.Errorf("postgres: begin tx for %s: %w", entry.Name(), err)
-		}
-
-		if _, err := tx.Exec(ctx, string(data)); err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("postgres: exec migration %s: %w", entry.Name(), err)
-		}
-
-		if _, err := tx.Exec(ctx,
-			"INSERT INTO schema_migrations (filename) VALUES ($1)",
-			entry.Name(),
-		); err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("postgres: record migration %s: %w", entry.Name(), err)
-		}
-
-		if err := tx.Commit(ctx); err != nil {
-			return fmt.Errorf("postgres: commit migration %s: %w", entry.Name(), err)
-		}
-	}
-
-	return nil
-}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// CrossMatchStore implements domain.CrossMatchStore using PostgreSQL.
+type CrossMatchStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewCrossMatchStore creates a new CrossMatchStore.
+func NewCrossMatchStore(pool *pgxpool.Pool) *CrossMatchStore {
+	return &CrossMatchStore{pool: pool}
+}
+
+const crossMatchColumns = `id, market_id, market_slug, question, kalshi_ticker, kalshi_title,
+	confidence, scores, status, source, reviewed_at, created_at, updated_at`
+
+// Propose inserts a match, or refreshes the score and titles of a pending
+// match for the same pair.
+func (s *CrossMatchStore) Propose(ctx context.Context, m domain.CrossMarketMatch) error {
+	scores, _ := json.Marshal(m.Scores)
+	const query = `
+		INSERT INTO cross_market_matches (id, market_id, market_slug, question, kalshi_ticker, kalshi_title,
+			confidence, scores, status, source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		ON CONFLICT (market_id, kalshi_ticker) DO UPDATE SET
+			market_slug = EXCLUDED.market_slug,
+			question = EXCLUDED.question,
+			kalshi_title = EXCLUDED.kalshi_title,
+			confidence = EXCLUDED.confidence,
+			scores = EXCLUDED.scores,
+			updated_at = NOW()
+		WHERE cross_market_matches.status = 'pending'`
+	_, err := s.pool.Exec(ctx, query,
+		m.ID, m.MarketID, m.MarketSlug, m.Question, m.KalshiTicker, m.KalshiTitle,
+		m.Confidence, scores, string(m.Status), m.Source, m.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: propose cross match %s/%s: %w", m.MarketID, m.KalshiTicker, err)
+	}
+	return nil
+}
+
+// Create inserts a match or overwrites the one for the same pair.
+func (s *CrossMatchStore) Create(ctx context.Context, m domain.CrossMarketMatch) (domain.CrossMarketMatch, error) {
+	scores, _ := json.Marshal(m.Scores)
+	query := `
+		INSERT INTO cross_market_matches (id, market_id, market_slug, question, kalshi_ticker, kalshi_title,
+			confidence, scores, status, source, reviewed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		ON CONFLICT (market_id, kalshi_ticker) DO UPDATE SET
+			market_slug = EXCLUDED.market_slug,
+			question = EXCLUDED.question,
+			kalshi_title = EXCLUDED.kalshi_title,
+			confidence = EXCLUDED.confidence,
+			scores = EXCLUDED.scores,
+			status = EXCLUDED.status,
+			source = EXCLUDED.source,
+			reviewed_at = EXCLUDED.reviewed_at,
+			updated_at = NOW()
+		RETURNING ` + crossMatchColumns
+	out, err := scanCrossMatch(s.pool.QueryRow(ctx, query,
+		m.ID, m.MarketID, m.MarketSlug, m.Question, m.KalshiTicker, m.KalshiTitle,
+		m.Confidence, scores, string(m.Status), m.Source, m.ReviewedAt, m.CreatedAt,
+	))
+	if err != nil {
+		return domain.CrossMarketMatch{}, fmt.Errorf("postgres: create cross match %s/%s: %w", m.MarketID, m.KalshiTicker, err)
+	}
+	return out, nil
+}
+
+// GetByID returns a match by id.
+func (s *CrossMatchStore) GetByID(ctx context.Context, id string) (domain.CrossMarketMatch, error) {
+	query := `SELECT ` + crossMatchColumns + ` FROM cross_market_matches WHERE id = $1`
+	m, err := scanCrossMatch(s.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CrossMarketMatch{}, domain.ErrNotFound
+		}
+		return domain.CrossMarketMatch{}, fmt.Errorf("postgres: get cross match %s: %w", id, err)
+	}
+	return m, nil
+}
+
+// List returns matches with status (all when empty), highest confidence first.
+func (s *CrossMatchStore) List(ctx context.Context, status domain.CrossMatchStatus, opts domain.ListOpts) ([]domain.CrossMarketMatch, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	query := `SELECT ` + crossMatchColumns + ` FROM cross_market_matches
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY confidence DESC, created_at DESC
+		LIMIT $2 OFFSET $3`
+	rows, err := s.pool.Query(ctx, query, string(status), limit, opts.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list cross matches: %w", err)
+	}
+	defer rows.Close()
+	var list []domain.CrossMarketMatch
+	for rows.Next() {
+		m, err := scanCrossMatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: scan cross match: %w", err)
+		}
+		list = append(list, m)
+	}
+	return list, rows.Err()
+}
+
+// Approved returns the market IDs and tickers of approved matches.
+func (s *CrossMatchStore) Approved(ctx context.Context) (map[string]struct{}, map[string]struct{}, error) {
+	rows, err := s.pool.Query(ctx, `SELECT market_id, kalshi_ticker FROM cross_market_matches WHERE status = 'approved'`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres: list approved cross matches: %w", err)
+	}
+	defer rows.Close()
+	markets := make(map[string]struct{})
+	tickers := make(map[string]struct{})
+	for rows.Next() {
+		var marketID, ticker string
+		if err := rows.Scan(&marketID, &ticker); err != nil {
+			return nil, nil, fmt.Errorf("postgres: scan approved cross match: %w", err)
+		}
+		markets[marketID] = struct{}{}
+		tickers[ticker] = struct{}{}
+	}
+	return markets, tickers, rows.Err()
+}
+
+// SetStatus records a review decision.
+func (s *CrossMatchStore) SetStatus(ctx context.Context, id string, status domain.CrossMatchStatus) (domain.CrossMarketMatch, error) {
+	query := `
+		UPDATE cross_market_matches SET status = $2, reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + crossMatchColumns
+	m, err := scanCrossMatch(s.pool.QueryRow(ctx, query, id, string(status)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CrossMarketMatch{}, domain.ErrNotFound
+		}
+		return domain.CrossMarketMatch{}, fmt.Errorf("postgres: set cross match %s status: %w", id, err)
+	}
+	return m, nil
+}
+
+func scanCrossMatch(row pgx.Row) (domain.CrossMarketMatch, error) {
+	var m domain.CrossMarketMatch
+	var status string
+	var scores []byte
+	err := row.Scan(
+		&m.ID, &m.MarketID, &m.MarketSlug, &m.Question, &m.KalshiTicker, &m.KalshiTitle,
+		&m.Confidence, &scores, &status, &m.Source, &m.ReviewedAt, &m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		return domain.CrossMarketMatch{}, err
+	}
+	m.Status = domain.CrossMatchStatus(status)
+	if len(scores) > 0 {
+		_ = json.Unmarshal(scores, &m.Scores)
+	}
+	return m, nil
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// InstrumentStore implements domain.InstrumentStore using PostgreSQL.
+type InstrumentStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewInstrumentStore creates a new InstrumentStore.
+func NewInstrumentStore(pool *pgxpool.Pool) *InstrumentStore {
+	return &InstrumentStore{pool: pool}
+}
+
+// Upsert writes an instrument and replaces its identifier links.
+func (s *InstrumentStore) Upsert(ctx context.Context, inst domain.Instrument) error {
+	return s.UpsertBatch(ctx, []domain.Instrument{inst})
+}
+
+// UpsertBatch writes instruments and their identifier links in one
+// transaction. If any identifier already belongs to a different instrument
+// nothing is written and domain.ErrAlreadyExists is returned.
+func (s *InstrumentStore) UpsertBatch(ctx context.Context, insts []domain.Instrument) error {
+	if len(insts) == 0 {
+		return nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, inst := range insts {
+		if err := upsertInstrument(ctx, tx, inst); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func upsertInstrument(ctx context.Context, tx pgx.Tx, inst domain.Instrument) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO instruments (id, question, created_at, updated_at)
+		VALUES ($1, $2, NOW(), NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			question   = EXCLUDED.question,
+			updated_at = NOW()`,
+		inst.ID, inst.Question,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert instrument %s: %w", inst.ID, err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM instrument_ids WHERE instrument_id = $1`, inst.ID); err != nil {
+		return fmt.Errorf("postgres: clear instrument ids %s: %w", inst.ID, err)
+	}
+	for kind, value := range inst.Identifiers {
+		if value == "" {
+			continue
+		}
+		// The instrument's own links were just cleared, so a conflict here
+		// means the identifier belongs to another instrument.
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO instrument_ids (kind, value, instrument_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (kind, value) DO NOTHING`,
+			string(kind), value, inst.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("postgres: link instrument %s %s=%s: %w", inst.ID, kind, value, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("postgres: link instrument %s %s=%s: %w", inst.ID, kind, value, domain.ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// GetByID returns an instrument with all of its identifiers.
+func (s *InstrumentStore) GetByID(ctx context.Context, id string) (domain.Instrument, error) {
+	found, err := s.load(ctx, []string{id})
+	if err != nil {
+		return domain.Instrument{}, fmt.Errorf("postgres: get instrument %s: %w", id, err)
+	}
+	inst, ok := found[id]
+	if !ok {
+		return domain.Instrument{}, domain.ErrNotFound
+	}
+	return inst, nil
+}
+
+// Lookup returns the instrument an identifier belongs to.
+func (s *InstrumentStore) Lookup(ctx context.Context, kind domain.IDKind, value string) (domain.Instrument, error) {
+	found, err := s.LookupBatch(ctx, kind, []string{value})
+	if err != nil {
+		return domain.Instrument{}, err
+	}
+	inst, ok := found[value]
+	if !ok {
+		return domain.Instrument{}, domain.ErrNotFound
+	}
+	return inst, nil
+}
+
+// LookupBatch returns the instruments found for values, keyed by value.
+func (s *InstrumentStore) LookupBatch(ctx context.Context, kind domain.IDKind, values []string) (map[string]domain.Instrument, error) {
+	out := make(map[string]domain.Instrument, len(values))
+	if len(values) == 0 {
+		return out, nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT value, instrument_id FROM instrument_ids
+		WHERE kind = ANY($1) AND value = ANY($2)`,
+		kindStrings(kind.Expand()), values,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: lookup instruments by %s: %w", kind, err)
+	}
+	byValue := make(map[string]string, len(values))
+	for rows.Next() {
+		var value, id string
+		if err := rows.Scan(&value, &id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("postgres: scan instrument id: %w", err)
+		}
+		byValue[value] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: lookup instruments by %s: %w", kind, err)
+	}
+	if len(byValue) == 0 {
+		return out, nil
+	}
+
+	ids := make([]string, 0, len(byValue))
+	for _, id := range byValue {
+		ids = append(ids, id)
+	}
+	insts, err := s.load(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: lookup instruments by %s: %w", kind, err)
+	}
+	for value, id := range byValue {
+		if inst, ok := insts[id]; ok {
+			out[value] = inst
+		}
+	}
+	return out, nil
+}
+
+// List returns instruments ordered by ID.
+func (s *InstrumentStore) List(ctx context.Context, opts domain.ListOpts) ([]domain.Instrument, error) {
+	query := `SELECT id FROM instruments ORDER BY id`
+	args := []any{}
+	argIdx := 1
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, opts.Limit)
+		argIdx++
+	}
+	if opts.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIdx)
+		args = append(args, opts.Offset)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list instruments: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list instruments: %w", err)
+	}
+
+	insts, err := s.load(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list instruments: %w", err)
+	}
+	list := make([]domain.Instrument, 0, len(ids))
+	for _, id := range ids {
+		if inst, ok := insts[id]; ok {
+			list = append(list, inst)
+		}
+	}
+	return list, nil
+}
+
+// load reads the given instruments and their identifiers, keyed by ID.
+func (s *InstrumentStore) load(ctx context.Context, ids []string) (map[string]domain.Instrument, error) {
+	out := make(map[string]domain.Instrument, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, question, created_at, updated_at FROM instruments WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var inst domain.Instrument
+		if err := rows.Scan(&inst.ID, &inst.Question, &inst.CreatedAt, &inst.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		inst.Identifiers = map[domain.IDKind]string{}
+		out[inst.ID] = inst
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = s.pool.Query(ctx,
+		`SELECT instrument_id, kind, value FROM instrument_ids WHERE instrument_id = ANY($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, kind, value string
+		if err := rows.Scan(&id, &kind, &value); err != nil {
+			return nil, err
+		}
+		if inst, ok := out[id]; ok {
+			inst.Identifiers[domain.IDKind(kind)] = value
+		}
+	}
+	return out, rows.Err()
+}
+
+func kindStrings(kinds []domain.IDKind) []string {
+	out := make([]string, len(kinds))
+	for i, k := range kinds {
+		out[i] = string(k)
+	}
+	return out
+}
+
+// Compile-time interface check.
+var _ domain.InstrumentStore = (*InstrumentStore)(nil)
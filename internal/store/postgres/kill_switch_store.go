@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// KillSwitchStore implements domain.KillSwitchStore using PostgreSQL.
+type KillSwitchStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewKillSwitchStore creates a new KillSwitchStore.
+func NewKillSwitchStore(pool *pgxpool.Pool) *KillSwitchStore {
+	return &KillSwitchStore{pool: pool}
+}
+
+// Get returns the kill switch state of wallet.
+func (s *KillSwitchStore) Get(ctx context.Context, wallet string) (domain.KillSwitchState, error) {
+	const query = `
+		SELECT wallet, tripped, trip_reason, tripped_at, reset_at, baseline::float8, day, updated_at
+		FROM kill_switch_state WHERE wallet = $1`
+	var (
+		st  domain.KillSwitchState
+		day *time.Time
+	)
+	err := s.pool.QueryRow(ctx, query, wallet).Scan(
+		&st.Wallet, &st.Tripped, &st.TripReason, &st.TrippedAt, &st.ResetAt, &st.Baseline, &day, &st.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.KillSwitchState{}, domain.ErrNotFound
+		}
+		return domain.KillSwitchState{}, fmt.Errorf("postgres: get kill switch state %s: %w", wallet, err)
+	}
+	if day != nil {
+		st.Day = day.UTC()
+	}
+	return st, nil
+}
+
+// Save upserts the kill switch state of s.Wallet.
+func (s *KillSwitchStore) Save(ctx context.Context, st domain.KillSwitchState) error {
+	const query = `
+		INSERT INTO kill_switch_state (wallet, tripped, trip_reason, tripped_at, reset_at, baseline, day, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (wallet) DO UPDATE SET
+			tripped = EXCLUDED.tripped,
+			trip_reason = EXCLUDED.trip_reason,
+			tripped_at = EXCLUDED.tripped_at,
+			reset_at = EXCLUDED.reset_at,
+			baseline = EXCLUDED.baseline,
+			day = EXCLUDED.day,
+			updated_at = NOW()`
+	var day *time.Time
+	if !st.Day.IsZero() {
+		day = &st.Day
+	}
+	_, err := s.pool.Exec(ctx, query,
+		st.Wallet, st.Tripped, st.TripReason, st.TrippedAt, st.ResetAt, st.Baseline, day,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: save kill switch state %s: %w", st.Wallet, err)
+	}
+	return nil
+}
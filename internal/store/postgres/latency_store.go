@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LatencyStore implements domain.LatencyStore using PostgreSQL.
+type LatencyStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewLatencyStore creates a new LatencyStore backed by the given connection pool.
+func NewLatencyStore(pool *pgxpool.Pool) *LatencyStore {
+	return &LatencyStore{pool: pool}
+}
+
+// UpsertLatencies stores breakdowns, replacing those of the same order.
+func (s *LatencyStore) UpsertLatencies(ctx context.Context, latencies []domain.OrderLatency) error {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	const query = `
+		INSERT INTO order_latencies (
+			order_id, strategy, market_id, emitted_at, acked_at, filled_at,
+			queue_ms, risk_ms, sign_ms, post_ms, ack_ms, fill_ms, total_ms, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (order_id) DO UPDATE SET
+			strategy   = EXCLUDED.strategy,
+			market_id  = EXCLUDED.market_id,
+			emitted_at = EXCLUDED.emitted_at,
+			acked_at   = EXCLUDED.acked_at,
+			filled_at  = EXCLUDED.filled_at,
+			queue_ms   = EXCLUDED.queue_ms,
+			risk_ms    = EXCLUDED.risk_ms,
+			sign_ms    = EXCLUDED.sign_ms,
+			post_ms    = EXCLUDED.post_ms,
+			ack_ms     = EXCLUDED.ack_ms,
+			fill_ms    = EXCLUDED.fill_ms,
+			total_ms   = EXCLUDED.total_ms,
+			updated_at = EXCLUDED.updated_at`
+
+	// The stage columns follow domain.LatencyStages.
+	for _, l := range latencies {
+		args := []any{l.OrderID, l.Strategy, l.MarketID, nullTime(l.EmittedAt), nullTime(l.AckedAt), nullTime(l.FilledAt)}
+		for _, stage := range domain.LatencyStages {
+			var ms *float64
+			if d, ok := l.Stages[stage]; ok {
+				v := float64(d) / float64(time.Millisecond)
+				ms = &v
+			}
+			args = append(args, ms)
+		}
+		args = append(args, l.UpdatedAt)
+		batch.Queue(query, args...)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range latencies {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("postgres: upsert order latency batch item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ListSlowest returns up to limit breakdowns acknowledged since since, of
+// strategy (every strategy when empty), slowest acknowledgement first.
+func (s *LatencyStore) ListSlowest(ctx context.Context, strategy string, since time.Time, limit int) ([]domain.OrderLatency, error) {
+	const query = `
+		SELECT order_id, strategy, market_id, emitted_at, acked_at, filled_at,
+		       queue_ms, risk_ms, sign_ms, post_ms, ack_ms, fill_ms, total_ms, updated_at
+		FROM order_latencies
+		WHERE acked_at >= $1
+		  AND ($2 = '' OR strategy = $2)
+		ORDER BY COALESCE(ack_ms, post_ms) DESC NULLS LAST
+		LIMIT $3`
+
+	rows, err := s.pool.Query(ctx, query, since, strategy, limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list slowest order latencies: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.OrderLatency
+	for rows.Next() {
+		var (
+			l                      domain.OrderLatency
+			emitted, acked, filled *time.Time
+			ms                     [7]*float64
+		)
+		if err := rows.Scan(
+			&l.OrderID, &l.Strategy, &l.MarketID, &emitted, &acked, &filled,
+			&ms[0], &ms[1], &ms[2], &ms[3], &ms[4], &ms[5], &ms[6], &l.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("postgres: scan order latency: %w", err)
+		}
+		if emitted != nil {
+			l.EmittedAt = *emitted
+		}
+		if acked != nil {
+			l.AckedAt = *acked
+		}
+		if filled != nil {
+			l.FilledAt = *filled
+		}
+		l.Stages = make(map[domain.LatencyStage]time.Duration, len(domain.LatencyStages))
+		for i, stage := range domain.LatencyStages {
+			if ms[i] != nil {
+				l.Stages[stage] = time.Duration(*ms[i] * float64(time.Millisecond))
+			}
+		}
+		out = append(out, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list slowest order latencies rows: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// LPRewardStore implements domain.LPRewardStore using PostgreSQL.
+type LPRewardStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewLPRewardStore creates a new LPRewardStore backed by the given connection pool.
+func NewLPRewardStore(pool *pgxpool.Pool) *LPRewardStore {
+	return &LPRewardStore{pool: pool}
+}
+
+// UpsertDays stores rows, replacing those of the same day and market.
+func (s *LPRewardStore) UpsertDays(ctx context.Context, days []domain.LPRewardDay) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	const query = `
+		INSERT INTO lp_reward_days (
+			day, market_id, eligible, daily_rate, max_spread, min_size,
+			quoted_seconds, qualifying_seconds, share_seconds, estimated_usd, updated_at
+		) VALUES (($1::timestamptz AT TIME ZONE 'UTC')::date, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (day, market_id) DO UPDATE SET
+			eligible           = EXCLUDED.eligible,
+			daily_rate         = EXCLUDED.daily_rate,
+			max_spread         = EXCLUDED.max_spread,
+			min_size           = EXCLUDED.min_size,
+			quoted_seconds     = EXCLUDED.quoted_seconds,
+			qualifying_seconds = EXCLUDED.qualifying_seconds,
+			share_seconds      = EXCLUDED.share_seconds,
+			estimated_usd      = EXCLUDED.estimated_usd,
+			updated_at         = EXCLUDED.updated_at`
+
+	for _, d := range days {
+		batch.Queue(query,
+			d.Day, d.MarketID, d.Eligible, d.DailyRate, d.MaxSpread, d.MinSize,
+			d.QuotedSeconds, d.QualifyingSeconds, d.ShareSeconds, d.EstimatedUSD, d.UpdatedAt,
+		)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range days {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("postgres: upsert lp reward day batch item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ListDays returns stored rows of days in [from, to), oldest first.
+func (s *LPRewardStore) ListDays(ctx context.Context, marketID string, from, to time.Time) ([]domain.LPRewardDay, error) {
+	const query = `
+		SELECT day::timestamp, market_id, eligible, daily_rate::float8, max_spread::float8, min_size::float8,
+		       quoted_seconds::float8, qualifying_seconds::float8, share_seconds::float8,
+		       estimated_usd::float8, updated_at
+		FROM lp_reward_days
+		WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date
+		  AND day < ($2::timestamptz AT TIME ZONE 'UTC')::date
+		  AND ($3 = '' OR market_id = $3)
+		ORDER BY day, market_id`
+
+	rows, err := s.pool.Query(ctx, query, from, to, marketID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list lp reward days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []domain.LPRewardDay
+	for rows.Next() {
+		var d domain.LPRewardDay
+		if err := rows.Scan(
+			&d.Day, &d.MarketID, &d.Eligible, &d.DailyRate, &d.MaxSpread, &d.MinSize,
+			&d.QuotedSeconds, &d.QualifyingSeconds, &d.ShareSeconds,
+			&d.EstimatedUSD, &d.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("postgres: scan lp reward day: %w", err)
+		}
+		d.Day = time.Date(d.Day.Year(), d.Day.Month(), d.Day.Day(), 0, 0, 0, 0, time.UTC)
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list lp reward days rows: %w", err)
+	}
+	return days, nil
+}
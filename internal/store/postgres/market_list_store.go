@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// MarketListStore implements domain.MarketListStore using PostgreSQL.
+type MarketListStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewMarketListStore creates a new MarketListStore backed by the given connection pool.
+func NewMarketListStore(pool *pgxpool.Pool) *MarketListStore {
+	return &MarketListStore{pool: pool}
+}
+
+// Upsert adds entry to its list, replacing the reason and tokens when the
+// market is already on it. The original listing time is kept.
+func (s *MarketListStore) Upsert(ctx context.Context, entry domain.MarketListEntry) error {
+	const query = `
+		INSERT INTO market_lists (list, market_id, token_ids, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (list, market_id) DO UPDATE SET
+			token_ids = EXCLUDED.token_ids,
+			reason    = EXCLUDED.reason`
+
+	tokenIDs := entry.TokenIDs
+	if tokenIDs == nil {
+		tokenIDs = []string{}
+	}
+	if _, err := s.pool.Exec(ctx, query,
+		string(entry.List), entry.MarketID, tokenIDs, entry.Reason, entry.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("postgres: upsert market list %s %s: %w", entry.List, entry.MarketID, err)
+	}
+	return nil
+}
+
+// Delete removes marketID from list; domain.ErrNotFound when it is not on it.
+func (s *MarketListStore) Delete(ctx context.Context, list domain.MarketList, marketID string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM market_lists WHERE list = $1 AND market_id = $2`, string(list), marketID)
+	if err != nil {
+		return fmt.Errorf("postgres: delete market list %s %s: %w", list, marketID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// List returns every entry of both lists, oldest first.
+func (s *MarketListStore) List(ctx context.Context) ([]domain.MarketListEntry, error) {
+	const query = `
+		SELECT list, market_id, token_ids, reason, created_at
+		FROM market_lists
+		ORDER BY created_at, list, market_id`
+
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list market lists: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.MarketListEntry
+	for rows.Next() {
+		var (
+			e    domain.MarketListEntry
+			list string
+		)
+		if err := rows.Scan(&list, &e.MarketID, &e.TokenIDs, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("postgres: scan market list entry: %w", err)
+		}
+		e.List = domain.MarketList(list)
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list market lists rows: %w", err)
+	}
+	return out, nil
+}
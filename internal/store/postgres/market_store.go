@@ -26,11 +26,13 @@ func (s *MarketStore) Upsert(ctx context.Context, m domain.Market) error {
 		INSERT INTO markets (
 			id, question, slug, outcome_1, outcome_2,
 			token_id_1, token_id_2, condition_id, neg_risk,
-			volume, status, closed_at, created_at, updated_at
+			volume, status, closed_at, created_at, updated_at,
+			outcomes, token_ids, tags
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7, $8, $9,
-			$10, $11, $12, $13, NOW()
+			$10, $11, $12, $13, NOW(),
+			$14, $15, $16
 		)
 		ON CONFLICT (id) DO UPDATE SET
 			question     = EXCLUDED.question,
@@ -39,20 +41,17 @@ func (s *MarketStore) Upsert(ctx context.Context, m domain.Market) error {
 			outcome_2    = EXCLUDED.outcome_2,
 			token_id_1   = EXCLUDED.token_id_1,
 			token_id_2   = EXCLUDED.token_id_2,
+			outcomes     = EXCLUDED.outcomes,
+			token_ids    = EXCLUDED.token_ids,
 			condition_id = EXCLUDED.condition_id,
 			neg_risk     = EXCLUDED.neg_risk,
 			volume       = EXCLUDED.volume,
 			status       = EXCLUDED.status,
 			closed_at    = EXCLUDED.closed_at,
+			tags         = COALESCE(EXCLUDED.tags, markets.tags),
 			updated_at   = NOW()`
 
-	_, err := s.pool.Exec(ctx, query,
-		m.ID, m.Question, m.Slug,
-		m.Outcomes[0], m.Outcomes[1],
-		m.TokenIDs[0], m.TokenIDs[1],
-		m.ConditionID, m.NegRisk,
-		m.Volume, string(m.Status), m.ClosedAt, m.CreatedAt,
-	)
+	_, err := s.pool.Exec(ctx, query, marketArgs(m)...)
 	if err != nil {
 		return fmt.Errorf("postgres: upsert market %s: %w", m.ID, err)
 	}
@@ -70,11 +69,13 @@ func (s *MarketStore) UpsertBatch(ctx context.Context, markets []domain.Market)
 		INSERT INTO markets (
 			id, question, slug, outcome_1, outcome_2,
 			token_id_1, token_id_2, condition_id, neg_risk,
-			volume, status, closed_at, created_at, updated_at
+			volume, status, closed_at, created_at, updated_at,
+			outcomes, token_ids, tags
 		) VALUES (
 			$1, $2, $3, $4, $5,
 			$6, $7, $8, $9,
-			$10, $11, $12, $13, NOW()
+			$10, $11, $12, $13, NOW(),
+			$14, $15, $16
 		)
 		ON CONFLICT (id) DO UPDATE SET
 			question     = EXCLUDED.question,
@@ -83,21 +84,18 @@ func (s *MarketStore) UpsertBatch(ctx context.Context, markets []domain.Market)
 			outcome_2    = EXCLUDED.outcome_2,
 			token_id_1   = EXCLUDED.token_id_1,
 			token_id_2   = EXCLUDED.token_id_2,
+			outcomes     = EXCLUDED.outcomes,
+			token_ids    = EXCLUDED.token_ids,
 			condition_id = EXCLUDED.condition_id,
 			neg_risk     = EXCLUDED.neg_risk,
 			volume       = EXCLUDED.volume,
 			status       = EXCLUDED.status,
 			closed_at    = EXCLUDED.closed_at,
+			tags         = COALESCE(EXCLUDED.tags, markets.tags),
 			updated_at   = NOW()`
 
 	for _, m := range markets {
-		batch.Queue(query,
-			m.ID, m.Question, m.Slug,
-			m.Outcomes[0], m.Outcomes[1],
-			m.TokenIDs[0], m.TokenIDs[1],
-			m.ConditionID, m.NegRisk,
-			m.Volume, string(m.Status), m.ClosedAt, m.CreatedAt,
-		)
+		batch.Queue(query, marketArgs(m)...)
 	}
 
 	br := s.pool.SendBatch(ctx, batch)
@@ -111,17 +109,41 @@ func (s *MarketStore) UpsertBatch(ctx context.Context, markets []domain.Market)
 	return nil
 }
 
+// marketArgs returns the upsert arguments for m. The legacy two-outcome
+// columns are NOT NULL, so they get "" where m has fewer outcomes. A market
+// without tags is written as NULL so sources that do not report tags (the
+// market scraper) keep those stored by the event scraper.
+func marketArgs(m domain.Market) []any {
+	outcomeAt := func(i int) string {
+		if i < len(m.Outcomes) {
+			return m.Outcomes[i]
+		}
+		return ""
+	}
+	var tags []string
+	if len(m.Tags) > 0 {
+		tags = m.Tags
+	}
+	return []any{
+		m.ID, m.Question, m.Slug,
+		outcomeAt(0), outcomeAt(1),
+		m.TokenID(0), m.TokenID(1),
+		m.ConditionID, m.NegRisk,
+		m.Volume, string(m.Status), m.ClosedAt, m.CreatedAt,
+		m.Outcomes, m.TokenIDs, tags,
+	}
+}
+
 // scanMarket scans a single market row into a domain.Market.
 func scanMarket(row pgx.Row) (domain.Market, error) {
 	var m domain.Market
 	var status string
 	err := row.Scan(
 		&m.ID, &m.Question, &m.Slug,
-		&m.Outcomes[0], &m.Outcomes[1],
-		&m.TokenIDs[0], &m.TokenIDs[1],
+		&m.Outcomes, &m.TokenIDs,
 		&m.ConditionID, &m.NegRisk,
 		&m.Volume, &status, &m.ClosedAt,
-		&m.CreatedAt, &m.UpdatedAt,
+		&m.CreatedAt, &m.UpdatedAt, &m.Tags,
 	)
 	if err != nil {
 		return domain.Market{}, err
@@ -130,9 +152,14 @@ func scanMarket(row pgx.Row) (domain.Market, error) {
 	return m, nil
 }
 
-const marketCols = `id, question, slug, outcome_1, outcome_2,
-	token_id_1, token_id_2, condition_id, neg_risk,
-	volume, status, closed_at, created_at, updated_at`
+// marketCols reads outcomes and token IDs from the array columns, falling
+// back to the legacy pair for rows written before they existed.
+const marketCols = `id, question, slug,
+	COALESCE(outcomes, ARRAY[outcome_1, outcome_2]),
+	COALESCE(token_ids, ARRAY[token_id_1, token_id_2]),
+	condition_id, neg_risk,
+	volume, status, closed_at, created_at, updated_at,
+	COALESCE(tags, '{}')`
 
 // GetByID retrieves a market by its primary key.
 func (s *MarketStore) GetByID(ctx context.Context, id string) (domain.Market, error) {
@@ -148,10 +175,10 @@ func (s *MarketStore) GetByID(ctx context.Context, id string) (domain.Market, er
 	return m, nil
 }
 
-// GetByTokenID retrieves a market by either token ID.
+// GetByTokenID retrieves the market that has tokenID among its outcome tokens.
 func (s *MarketStore) GetByTokenID(ctx context.Context, tokenID string) (domain.Market, error) {
 	row := s.pool.QueryRow(ctx,
-		`SELECT `+marketCols+` FROM markets WHERE token_id_1 = $1 OR token_id_2 = $1`, tokenID)
+		`SELECT `+marketCols+` FROM markets WHERE $1 = ANY(token_ids) LIMIT 1`, tokenID)
 	m, err := scanMarket(row)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -213,19 +240,10 @@ func (s *MarketStore) ListActive(ctx context.Context, opts domain.ListOpts) ([]d
 
 	var markets []domain.Market
 	for rows.Next() {
-		var m domain.Market
-		var status string
-		if err := rows.Scan(
-			&m.ID, &m.Question, &m.Slug,
-			&m.Outcomes[0], &m.Outcomes[1],
-			&m.TokenIDs[0], &m.TokenIDs[1],
-			&m.ConditionID, &m.NegRisk,
-			&m.Volume, &status, &m.ClosedAt,
-			&m.CreatedAt, &m.UpdatedAt,
-		); err != nil {
+		m, err := scanMarket(rows)
+		if err != nil {
 			return nil, fmt.Errorf("postgres: scan active market: %w", err)
 		}
-		m.Status = domain.MarketStatus(status)
 		markets = append(markets, m)
 	}
 	if err := rows.Err(); err != nil {
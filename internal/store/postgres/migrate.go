@@ -0,0 +1,384 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationLockKey is the advisory lock held while migrations are applied or
+// rolled back, so bots starting together do not race on the schema.
+const migrationLockKey = 7_311_002_025
+
+// downSuffix marks the rollback script of a migration: NNN_name.down.sql
+// reverts NNN_name.sql.
+const downSuffix = ".down.sql"
+
+// migration is one embedded migration file.
+type migration struct {
+	version  int
+	file     string // tracked in schema_migrations.filename
+	sql      string
+	down     string // rollback script, empty when irreversible
+	checksum string
+}
+
+// MigrationStatus describes one migration, embedded or recorded as applied.
+type MigrationStatus struct {
+	Version    int
+	Name       string // file name without .sql
+	Applied    bool
+	AppliedAt  time.Time
+	Reversible bool // a .down.sql script exists
+	Modified   bool // applied, but the embedded file has changed since
+	Unknown    bool // applied, but not embedded in this binary
+}
+
+// ErrIrreversibleMigration is returned when rolling back a migration without
+// a .down.sql script.
+var ErrIrreversibleMigration = errors.New("postgres: migration has no rollback script")
+
+// ErrModifiedMigration is returned by RunMigrations when an applied
+// migration's embedded file no longer matches the checksum recorded when it
+// was applied.
+var ErrModifiedMigration = errors.New("postgres: applied migration modified since it was applied")
+
+// loadMigrations reads the embedded migrations ordered by version. Every
+// file must start with a unique numeric version ("024_price_candles.sql").
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationsFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("postgres: read migrations dir: %w", err)
+	}
+
+	downs := make(map[string]string)
+	var out []migration
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+		data, err := migrationsFS.ReadFile("migrations/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: read migration %s: %w", name, err)
+		}
+		if strings.HasSuffix(name, downSuffix) {
+			downs[strings.TrimSuffix(name, downSuffix)+".sql"] = string(data)
+			continue
+		}
+		version, err := migrationVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		out = append(out, migration{
+			version:  version,
+			file:     name,
+			sql:      string(data),
+			checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].version < out[j].version })
+	for i := range out {
+		if i > 0 && out[i].version == out[i-1].version {
+			return nil, fmt.Errorf("postgres: migrations %s and %s share version %d",
+				out[i-1].file, out[i].file, out[i].version)
+		}
+		out[i].down = downs[out[i].file]
+		delete(downs, out[i].file)
+	}
+	for file := range downs {
+		return nil, fmt.Errorf("postgres: rollback script for %s has no migration", file)
+	}
+	return out, nil
+}
+
+// migrationVersion parses the numeric prefix of a migration file name.
+func migrationVersion(file string) (int, error) {
+	prefix, _, ok := strings.Cut(file, "_")
+	if !ok {
+		return 0, fmt.Errorf("postgres: migration %s: name must be NNN_description.sql", file)
+	}
+	v, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: migration %s: version %q is not a number", file, prefix)
+	}
+	return v, nil
+}
+
+// ensureTracker creates schema_migrations, adding the version and checksum
+// columns to trackers created before they existed and backfilling versions.
+func ensureTracker(ctx context.Context, conn *pgxpool.Conn) error {
+	const createTracker = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			filename TEXT PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS version INTEGER;
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum TEXT;
+		UPDATE schema_migrations SET version = split_part(filename, '_', 1)::INTEGER
+		WHERE version IS NULL AND filename ~ '^[0-9]+_';`
+	if _, err := conn.Exec(ctx, createTracker); err != nil {
+		return fmt.Errorf("postgres: create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// withMigrationLock runs fn on a dedicated connection holding the migration
+// advisory lock, with the tracking table in place.
+func (c *Client) withMigrationLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := c.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: acquire migration connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", int64(migrationLockKey)); err != nil {
+		return fmt.Errorf("postgres: take migration lock: %w", err)
+	}
+	// Unlock even when ctx is already cancelled; a session lock left behind
+	// would block every later migration run on this pooled connection.
+	defer func() {
+		_, _ = conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", int64(migrationLockKey))
+	}()
+
+	if err := ensureTracker(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// appliedMigration is a schema_migrations row.
+type appliedMigration struct {
+	at       time.Time
+	checksum string // empty for rows recorded before checksums were
+}
+
+// migrationQuerier is a pool or a single connection.
+type migrationQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// appliedMigrations returns the tracked migrations keyed by file name.
+// withChecksum is false for trackers created before the checksum column.
+func appliedMigrations(ctx context.Context, q migrationQuerier, withChecksum bool) (map[string]appliedMigration, error) {
+	query := "SELECT filename, applied_at, COALESCE(checksum, '') FROM schema_migrations"
+	if !withChecksum {
+		query = "SELECT filename, applied_at, '' FROM schema_migrations"
+	}
+	rows, err := q.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]appliedMigration)
+	for rows.Next() {
+		var (
+			file string
+			a    appliedMigration
+		)
+		if err := rows.Scan(&file, &a.at, &a.checksum); err != nil {
+			return nil, fmt.Errorf("postgres: scan applied migration: %w", err)
+		}
+		out[file] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list applied migrations: %w", err)
+	}
+	return out, nil
+}
+
+// RunMigrations applies the embedded migrations not yet recorded in
+// schema_migrations, in version order. Each migration runs in its own
+// transaction together with its tracking row, so a failing migration leaves
+// no partial schema behind and is retried on the next run; the ones before it
+// stay applied. Concurrent runners are serialized by an advisory lock. It
+// applies nothing and returns ErrModifiedMigration when an applied migration
+// was edited after it was applied, since the schema may then differ from
+// what the embedded files describe.
+func (c *Client) RunMigrations(ctx context.Context) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	return c.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn, true)
+		if err != nil {
+			return err
+		}
+
+		var modified []string
+		for _, m := range migrations {
+			if a, ok := applied[m.file]; ok && a.checksum != "" && a.checksum != m.checksum {
+				modified = append(modified, m.file)
+			}
+		}
+		if len(modified) > 0 {
+			return fmt.Errorf("%w: %s", ErrModifiedMigration, strings.Join(modified, ", "))
+		}
+
+		for _, m := range migrations {
+			if _, ok := applied[m.file]; ok {
+				continue
+			}
+
+			tx, err := conn.Begin(ctx)
+			if err != nil {
+				return fmt.Errorf("postgres: begin tx for %s: %w", m.file, err)
+			}
+
+			if _, err := tx.Exec(ctx, m.sql); err != nil {
+				_ = tx.Rollback(ctx)
+				return fmt.Errorf("postgres: exec migration %s: %w", m.file, err)
+			}
+
+			if _, err := tx.Exec(ctx,
+				"INSERT INTO schema_migrations (filename, version, checksum) VALUES ($1, $2, $3)",
+				m.file, m.version, m.checksum,
+			); err != nil {
+				_ = tx.Rollback(ctx)
+				return fmt.Errorf("postgres: record migration %s: %w", m.file, err)
+			}
+
+			if err := tx.Commit(ctx); err != nil {
+				return fmt.Errorf("postgres: commit migration %s: %w", m.file, err)
+			}
+		}
+		return nil
+	})
+}
+
+// RollbackMigrations reverts the last steps applied migrations, newest
+// first, each with its .down.sql script in a transaction that also removes
+// its tracking row. It stops with ErrIrreversibleMigration at the first
+// migration without a script, and returns the names of those reverted.
+func (c *Client) RollbackMigrations(ctx context.Context, steps int) ([]string, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []string
+	err = c.withMigrationLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn, true)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.file]; !ok {
+				continue
+			}
+			if m.down == "" {
+				return fmt.Errorf("%w: %s", ErrIrreversibleMigration, m.file)
+			}
+
+			tx, err := conn.Begin(ctx)
+			if err != nil {
+				return fmt.Errorf("postgres: begin tx for rollback of %s: %w", m.file, err)
+			}
+
+			if _, err := tx.Exec(ctx, m.down); err != nil {
+				_ = tx.Rollback(ctx)
+				return fmt.Errorf("postgres: roll back migration %s: %w", m.file, err)
+			}
+
+			if _, err := tx.Exec(ctx,
+				"DELETE FROM schema_migrations WHERE filename = $1", m.file,
+			); err != nil {
+				_ = tx.Rollback(ctx)
+				return fmt.Errorf("postgres: unrecord migration %s: %w", m.file, err)
+			}
+
+			if err := tx.Commit(ctx); err != nil {
+				return fmt.Errorf("postgres: commit rollback of %s: %w", m.file, err)
+			}
+			reverted = append(reverted, strings.TrimSuffix(m.file, ".sql"))
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// MigrationStatus lists every embedded migration in version order, whether
+// and when it was applied, followed by applied migrations this binary does
+// not know. It does not modify the database.
+func (c *Client) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	if err := c.pool.QueryRow(ctx,
+		"SELECT to_regclass('schema_migrations') IS NOT NULL",
+	).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("postgres: check schema_migrations: %w", err)
+	}
+
+	applied := make(map[string]appliedMigration)
+	if exists {
+		// Trackers created before checksums were recorded lack the column.
+		var hasChecksum bool
+		if err := c.pool.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_name = 'schema_migrations' AND column_name = 'checksum'
+			)`,
+		).Scan(&hasChecksum); err != nil {
+			return nil, fmt.Errorf("postgres: check schema_migrations: %w", err)
+		}
+		applied, err = appliedMigrations(ctx, c.pool, hasChecksum)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		st := MigrationStatus{
+			Version:    m.version,
+			Name:       strings.TrimSuffix(m.file, ".sql"),
+			Reversible: m.down != "",
+		}
+		if a, ok := applied[m.file]; ok {
+			st.Applied = true
+			st.AppliedAt = a.at
+			st.Modified = a.checksum != "" && a.checksum != m.checksum
+			delete(applied, m.file)
+		}
+		out = append(out, st)
+	}
+
+	var unknown []MigrationStatus
+	for file, a := range applied {
+		version, _ := migrationVersion(file)
+		unknown = append(unknown, MigrationStatus{
+			Version:   version,
+			Name:      strings.TrimSuffix(file, ".sql"),
+			Applied:   true,
+			AppliedAt: a.at,
+			Unknown:   true,
+		})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Name < unknown[j].Name })
+	return append(out, unknown...), nil
+}
@@ -0,0 +1,16 @@
+-- Recorded orderbook snapshots and level deltas for book replay.
+CREATE TABLE IF NOT EXISTS book_events (
+    id         BIGSERIAL PRIMARY KEY,
+    asset_id   TEXT NOT NULL,
+    kind       TEXT NOT NULL CHECK (kind IN ('snapshot','delta')),
+    bids       JSONB,
+    asks       JSONB,
+    side       TEXT,
+    price      NUMERIC(10,6),
+    size       NUMERIC(20,6),
+    best_bid   NUMERIC(10,6),
+    best_ask   NUMERIC(10,6),
+    ts         TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_book_events_asset_ts ON book_events(asset_id, ts);
+CREATE INDEX IF NOT EXISTS idx_book_events_ts ON book_events(ts);
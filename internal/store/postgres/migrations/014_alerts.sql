@@ -0,0 +1,20 @@
+-- User-defined price alerts evaluated against the live price stream.
+CREATE TABLE IF NOT EXISTS alerts (
+  id                 TEXT PRIMARY KEY,
+  market_id          TEXT NOT NULL DEFAULT '',
+  token_id           TEXT NOT NULL,
+  metric             TEXT NOT NULL CHECK (metric IN ('mid','bid','ask','spread')),
+  operator           TEXT NOT NULL CHECK (operator IN ('>','>=','<','<=')),
+  threshold          NUMERIC(10,6) NOT NULL,
+  recurring          BOOLEAN NOT NULL DEFAULT FALSE,
+  cooldown_seconds   INTEGER NOT NULL DEFAULT 0 CHECK (cooldown_seconds >= 0),
+  enabled            BOOLEAN NOT NULL DEFAULT TRUE,
+  note               TEXT NOT NULL DEFAULT '',
+  trigger_count      INTEGER NOT NULL DEFAULT 0,
+  last_triggered_at  TIMESTAMPTZ,
+  created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alerts_token ON alerts(token_id);
+CREATE INDEX IF NOT EXISTS idx_alerts_enabled ON alerts(enabled) WHERE enabled;
@@ -0,0 +1,16 @@
+-- Profit sweeps from the hot trading wallet to cold storage.
+CREATE TABLE IF NOT EXISTS profit_sweeps (
+  id            TEXT PRIMARY KEY,
+  from_address  TEXT NOT NULL,
+  to_address    TEXT NOT NULL,
+  amount        NUMERIC(20,6) NOT NULL CHECK (amount > 0),
+  realized_pnl  NUMERIC(20,6) NOT NULL DEFAULT 0,
+  tx_hash       TEXT NOT NULL DEFAULT '',
+  block_number  BIGINT NOT NULL DEFAULT 0,
+  status        TEXT NOT NULL CHECK (status IN ('pending','submitted','confirmed','failed')),
+  error         TEXT NOT NULL DEFAULT '',
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  confirmed_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_profit_sweeps_status ON profit_sweeps(status, confirmed_at DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_exchange_order_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS exchange_order_id;
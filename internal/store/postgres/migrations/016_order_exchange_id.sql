@@ -0,0 +1,3 @@
+-- CLOB order hash, used to match user-channel fill events to local orders.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS exchange_order_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_orders_exchange_order_id ON orders(exchange_order_id) WHERE exchange_order_id IS NOT NULL;
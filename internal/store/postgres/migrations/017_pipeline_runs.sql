@@ -0,0 +1,14 @@
+-- On-demand pipeline runs requested via POST /api/pipeline/trigger.
+CREATE TABLE IF NOT EXISTS pipeline_runs (
+  id           TEXT PRIMARY KEY,
+  trigger      TEXT NOT NULL DEFAULT 'api',
+  status       TEXT NOT NULL CHECK (status IN ('queued', 'running', 'done', 'failed')),
+  stats        JSONB NOT NULL DEFAULT '{}',
+  error        TEXT NOT NULL DEFAULT '',
+  requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  started_at   TIMESTAMPTZ,
+  finished_at  TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_pipeline_runs_requested_at ON pipeline_runs(requested_at DESC);
+-- At most one queued run: concurrent triggers collapse into it.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pipeline_runs_one_queued ON pipeline_runs(status) WHERE status = 'queued';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS instrument_ids;
+DROP TABLE IF EXISTS instruments;
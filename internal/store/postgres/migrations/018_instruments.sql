@@ -0,0 +1,16 @@
+-- Canonical instruments and every identifier they are known by across venues.
+CREATE TABLE IF NOT EXISTS instruments (
+  id         TEXT PRIMARY KEY,
+  question   TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- An identifier (kind, value) belongs to exactly one instrument.
+CREATE TABLE IF NOT EXISTS instrument_ids (
+  kind          TEXT NOT NULL,
+  value         TEXT NOT NULL,
+  instrument_id TEXT NOT NULL REFERENCES instruments(id) ON DELETE CASCADE,
+  PRIMARY KEY (kind, value)
+);
+CREATE INDEX IF NOT EXISTS idx_instrument_ids_instrument ON instrument_ids(instrument_id);
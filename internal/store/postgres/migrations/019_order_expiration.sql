@@ -0,0 +1,2 @@
+-- Expiration of GTD orders; NULL for every other order type.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
@@ -0,0 +1,19 @@
+-- Every signal emitted by a strategy, executed or not, for hindsight evaluation.
+CREATE TABLE IF NOT EXISTS strategy_signals (
+    id               TEXT PRIMARY KEY,
+    source           TEXT NOT NULL,
+    market_id        TEXT NOT NULL,
+    token_id         TEXT NOT NULL,
+    side             TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    price_ticks      BIGINT NOT NULL,
+    size_units       BIGINT NOT NULL,
+    urgency          SMALLINT NOT NULL DEFAULT 0,
+    reason           TEXT,
+    metadata         JSONB,
+    order_type       TEXT,
+    order_expiration TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL,
+    expires_at       TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_strategy_signals_source_created ON strategy_signals(source, created_at);
+CREATE INDEX IF NOT EXISTS idx_strategy_signals_created ON strategy_signals(created_at);
@@ -0,0 +1,4 @@
+-- outcome_1/2 and token_id_1/2 still hold the first two outcomes.
+DROP INDEX IF EXISTS idx_markets_token_ids;
+ALTER TABLE markets DROP COLUMN IF EXISTS token_ids;
+ALTER TABLE markets DROP COLUMN IF EXISTS outcomes;
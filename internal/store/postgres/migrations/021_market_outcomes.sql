@@ -0,0 +1,12 @@
+-- Markets may have more than two outcomes (categorical / neg-risk events).
+-- outcomes and token_ids hold every outcome in order; outcome_1/2 and
+-- token_id_1/2 keep the first two for existing readers.
+ALTER TABLE markets ADD COLUMN IF NOT EXISTS outcomes TEXT[];
+ALTER TABLE markets ADD COLUMN IF NOT EXISTS token_ids TEXT[];
+
+UPDATE markets
+SET outcomes  = ARRAY[outcome_1, outcome_2],
+    token_ids = ARRAY[token_id_1, token_id_2]
+WHERE token_ids IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_markets_token_ids ON markets USING GIN (token_ids);
@@ -0,0 +1,18 @@
+-- Proposed and reviewed Polymarket <-> Kalshi market matches (GET/POST /api/crossmap).
+CREATE TABLE IF NOT EXISTS cross_market_matches (
+    id            TEXT PRIMARY KEY,
+    market_id     TEXT NOT NULL,
+    market_slug   TEXT NOT NULL DEFAULT '',
+    question      TEXT NOT NULL DEFAULT '',
+    kalshi_ticker TEXT NOT NULL,
+    kalshi_title  TEXT NOT NULL DEFAULT '',
+    confidence    NUMERIC(5,4) NOT NULL CHECK (confidence >= 0 AND confidence <= 1),
+    scores        JSONB,
+    status        TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','approved','rejected')),
+    source        TEXT NOT NULL DEFAULT 'auto',
+    reviewed_at   TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (market_id, kalshi_ticker)
+);
+CREATE INDEX IF NOT EXISTS idx_cross_market_matches_status ON cross_market_matches(status, confidence DESC);
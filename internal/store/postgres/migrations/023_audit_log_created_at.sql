@@ -0,0 +1,2 @@
+-- GET /api/timeline pages audit_log by time across all events.
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at, id);
@@ -0,0 +1,14 @@
+-- One-minute OHLCV price candles per asset for charts and backtests; coarser
+-- intervals are aggregated on read.
+CREATE TABLE IF NOT EXISTS price_candles (
+    asset_id   TEXT NOT NULL,
+    bucket     TIMESTAMPTZ NOT NULL,
+    open       NUMERIC(10,6) NOT NULL,
+    high       NUMERIC(10,6) NOT NULL,
+    low        NUMERIC(10,6) NOT NULL,
+    close      NUMERIC(10,6) NOT NULL,
+    volume     NUMERIC(20,6) NOT NULL DEFAULT 0,
+    trades     INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (asset_id, bucket)
+);
+CREATE INDEX IF NOT EXISTS idx_price_candles_bucket ON price_candles(bucket);
@@ -0,0 +1,22 @@
+-- Per-strategy daily performance attribution, recomputed by the performance job.
+CREATE TABLE IF NOT EXISTS strategy_performance_daily (
+    day               DATE NOT NULL,
+    strategy          TEXT NOT NULL,
+    closed            INTEGER NOT NULL DEFAULT 0,
+    wins              INTEGER NOT NULL DEFAULT 0,
+    realized_pnl      NUMERIC(20,6) NOT NULL DEFAULT 0,
+    closed_notional   NUMERIC(20,6) NOT NULL DEFAULT 0,
+    orders            INTEGER NOT NULL DEFAULT 0,
+    filled_orders     INTEGER NOT NULL DEFAULT 0,
+    filled_notional   NUMERIC(20,6) NOT NULL DEFAULT 0,
+    taker_notional    NUMERIC(20,6) NOT NULL DEFAULT 0,
+    expected_edge_usd NUMERIC(20,6) NOT NULL DEFAULT 0,
+    edge_notional     NUMERIC(20,6) NOT NULL DEFAULT 0,
+    arb_executions    INTEGER NOT NULL DEFAULT 0,
+    arb_fees_usd      NUMERIC(20,6) NOT NULL DEFAULT 0,
+    arb_slippage_usd  NUMERIC(20,6) NOT NULL DEFAULT 0,
+    fees_usd          NUMERIC(20,6) NOT NULL DEFAULT 0,
+    computed_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, strategy)
+);
+CREATE INDEX IF NOT EXISTS idx_strategy_performance_daily_strategy ON strategy_performance_daily(strategy, day);
@@ -0,0 +1,7 @@
+-- Fails, leaving the schema as is, while exited positions exist.
+ALTER TABLE bond_positions DROP CONSTRAINT IF EXISTS bond_positions_status_check;
+ALTER TABLE bond_positions ADD CONSTRAINT bond_positions_status_check
+  CHECK (status IN ('open','resolved_win','resolved_loss'));
+ALTER TABLE bond_positions ALTER COLUMN realized_pnl DROP NOT NULL;
+ALTER TABLE bond_positions DROP COLUMN IF EXISTS exit_price;
+ALTER TABLE bond_positions DROP COLUMN IF EXISTS exited_size;
@@ -0,0 +1,9 @@
+-- Bond positions sold before resolution by the bond strategy's early exit.
+ALTER TABLE bond_positions ADD COLUMN IF NOT EXISTS exited_size NUMERIC(20,6) NOT NULL DEFAULT 0;
+ALTER TABLE bond_positions ADD COLUMN IF NOT EXISTS exit_price NUMERIC(10,6) NOT NULL DEFAULT 0;
+UPDATE bond_positions SET realized_pnl = 0 WHERE realized_pnl IS NULL;
+ALTER TABLE bond_positions ALTER COLUMN realized_pnl SET NOT NULL;
+
+ALTER TABLE bond_positions DROP CONSTRAINT IF EXISTS bond_positions_status_check;
+ALTER TABLE bond_positions ADD CONSTRAINT bond_positions_status_check
+  CHECK (status IN ('open','resolved_win','resolved_loss','exited'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_cancel_after;
+ALTER TABLE orders DROP COLUMN IF EXISTS cancel_after;
@@ -0,0 +1,7 @@
+-- Time after which a still-resting order is cancelled by the order janitor
+-- (from the signal's expiry or the strategy's order TTL); NULL rests until
+-- filled or cancelled.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancel_after TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_orders_cancel_after ON orders (cancel_after)
+    WHERE cancel_after IS NOT NULL AND status IN ('pending', 'open', 'partially_filled');
@@ -0,0 +1,15 @@
+-- CTF splits, merges and redemptions from the Goldsky activity subgraph, for
+-- reconciling positions and PnL against on-chain token balances.
+CREATE TABLE IF NOT EXISTS onchain_events (
+    id           TEXT NOT NULL,
+    kind         TEXT NOT NULL,
+    stakeholder  TEXT NOT NULL,
+    condition_id TEXT NOT NULL,
+    amount       NUMERIC(20,6) NOT NULL DEFAULT 0,
+    index_sets   TEXT[] NOT NULL DEFAULT '{}',
+    timestamp    TIMESTAMPTZ NOT NULL,
+    inserted_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, id)
+);
+CREATE INDEX IF NOT EXISTS idx_onchain_events_stakeholder ON onchain_events(stakeholder, timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_onchain_events_kind_ts ON onchain_events(kind, timestamp DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_markets_tags;
+ALTER TABLE markets DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,5 @@
+-- Gamma tag slugs of a market and its event (e.g. politics, crypto), used by
+-- per-strategy category filters. NULL until the event scraper has seen it.
+ALTER TABLE markets ADD COLUMN IF NOT EXISTS tags TEXT[];
+
+CREATE INDEX IF NOT EXISTS idx_markets_tags ON markets USING GIN (tags);
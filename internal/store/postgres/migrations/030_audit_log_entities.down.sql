@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_audit_log_position_id;
+DROP INDEX IF EXISTS idx_audit_log_order_id;
@@ -0,0 +1,5 @@
+-- GET /api/audit looks entries up by the order or position they concern.
+CREATE INDEX IF NOT EXISTS idx_audit_log_order_id ON audit_log ((detail->>'order_id'), created_at)
+    WHERE detail ? 'order_id';
+CREATE INDEX IF NOT EXISTS idx_audit_log_position_id ON audit_log ((detail->>'position_id'), created_at)
+    WHERE detail ? 'position_id';
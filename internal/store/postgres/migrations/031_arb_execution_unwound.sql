@@ -0,0 +1,4 @@
+-- All_or_none executions rolled back after a failed leg.
+ALTER TABLE arb_executions DROP CONSTRAINT IF EXISTS arb_executions_status_check;
+ALTER TABLE arb_executions ADD CONSTRAINT arb_executions_status_check
+  CHECK (status IN ('pending','partial','filled','cancelled','failed','unwound'));
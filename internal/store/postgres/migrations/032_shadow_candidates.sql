@@ -0,0 +1,20 @@
+-- Signals of strategies running in shadow mode (strategy.shadow): recorded
+-- and streamed on ch:shadow, never executed.
+CREATE TABLE IF NOT EXISTS shadow_candidates (
+    id               TEXT PRIMARY KEY,
+    source           TEXT NOT NULL,
+    market_id        TEXT NOT NULL,
+    token_id         TEXT NOT NULL,
+    side             TEXT NOT NULL CHECK (side IN ('buy', 'sell')),
+    price_ticks      BIGINT NOT NULL,
+    size_units       BIGINT NOT NULL,
+    urgency          SMALLINT NOT NULL DEFAULT 0,
+    reason           TEXT,
+    metadata         JSONB,
+    order_type       TEXT,
+    order_expiration TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ NOT NULL,
+    expires_at       TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_shadow_candidates_source_created ON shadow_candidates(source, created_at);
+CREATE INDEX IF NOT EXISTS idx_shadow_candidates_created ON shadow_candidates(created_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS lp_reward_days;
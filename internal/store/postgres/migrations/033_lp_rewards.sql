@@ -0,0 +1,18 @@
+-- Per-market liquidity rewards by UTC day: the reward terms, how long our
+-- liquidity_provider quotes were tracked and qualified, and the estimated
+-- accrual (service.RewardsTracker).
+CREATE TABLE IF NOT EXISTS lp_reward_days (
+    day                DATE NOT NULL,
+    market_id          TEXT NOT NULL,
+    eligible           BOOLEAN NOT NULL DEFAULT FALSE,
+    daily_rate         NUMERIC NOT NULL DEFAULT 0,
+    max_spread         NUMERIC NOT NULL DEFAULT 0,
+    min_size           NUMERIC NOT NULL DEFAULT 0,
+    quoted_seconds     NUMERIC NOT NULL DEFAULT 0,
+    qualifying_seconds NUMERIC NOT NULL DEFAULT 0,
+    share_seconds      NUMERIC NOT NULL DEFAULT 0,
+    estimated_usd      NUMERIC NOT NULL DEFAULT 0,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, market_id)
+);
+CREATE INDEX IF NOT EXISTS idx_lp_reward_days_market_day ON lp_reward_days(market_id, day);
@@ -0,0 +1,20 @@
+-- Per-order latency breakdowns from signal emission to fill, in
+-- milliseconds; NULL where a stage was not measured
+-- (service.LatencyTracker).
+CREATE TABLE IF NOT EXISTS order_latencies (
+    order_id   TEXT PRIMARY KEY,
+    strategy   TEXT NOT NULL DEFAULT '',
+    market_id  TEXT NOT NULL DEFAULT '',
+    emitted_at TIMESTAMPTZ,
+    acked_at   TIMESTAMPTZ,
+    filled_at  TIMESTAMPTZ,
+    queue_ms   DOUBLE PRECISION,
+    risk_ms    DOUBLE PRECISION,
+    sign_ms    DOUBLE PRECISION,
+    post_ms    DOUBLE PRECISION,
+    ack_ms     DOUBLE PRECISION,
+    fill_ms    DOUBLE PRECISION,
+    total_ms   DOUBLE PRECISION,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_order_latencies_acked ON order_latencies(acked_at);
@@ -0,0 +1,10 @@
+-- Operator-managed market blacklist and whitelist (service.MarketListService).
+-- token_ids are the market's outcome tokens when it was listed.
+CREATE TABLE IF NOT EXISTS market_lists (
+    list       TEXT NOT NULL CHECK (list IN ('blacklist', 'whitelist')),
+    market_id  TEXT NOT NULL,
+    token_ids  TEXT[] NOT NULL DEFAULT '{}',
+    reason     TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list, market_id)
+);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_strategy_signals_status_created;
+DROP INDEX IF EXISTS idx_strategy_signals_market_created;
+ALTER TABLE strategy_signals
+    DROP COLUMN IF EXISTS resolved_at,
+    DROP COLUMN IF EXISTS order_ids,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
+ALTER TABLE shadow_candidates
+    DROP COLUMN IF EXISTS resolved_at,
+    DROP COLUMN IF EXISTS order_ids,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,16 @@
+-- What the executor made of each recorded signal: status, the orders placed
+-- for it and when it was resolved (service.SignalRecorder). Shadow
+-- candidates share the columns and stay pending.
+ALTER TABLE strategy_signals
+    ADD COLUMN IF NOT EXISTS status        TEXT NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS status_reason TEXT,
+    ADD COLUMN IF NOT EXISTS order_ids     TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS resolved_at   TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_strategy_signals_market_created ON strategy_signals(market_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_strategy_signals_status_created ON strategy_signals(status, created_at);
+
+ALTER TABLE shadow_candidates
+    ADD COLUMN IF NOT EXISTS status        TEXT NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS status_reason TEXT,
+    ADD COLUMN IF NOT EXISTS order_ids     TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS resolved_at   TIMESTAMPTZ;
@@ -0,0 +1,24 @@
+-- On-chain redemptions of resolved positions (service.RedemptionService).
+-- shares and payouts are per outcome, parallel to token_ids.
+CREATE TABLE IF NOT EXISTS redemptions (
+    id            TEXT PRIMARY KEY,
+    wallet        TEXT NOT NULL,
+    market_id     TEXT NOT NULL,
+    condition_id  TEXT NOT NULL,
+    neg_risk      BOOLEAN NOT NULL DEFAULT FALSE,
+    token_ids     TEXT[] NOT NULL DEFAULT '{}',
+    shares        NUMERIC(20,6)[] NOT NULL DEFAULT '{}',
+    payouts       NUMERIC(20,6)[] NOT NULL DEFAULT '{}',
+    proceeds      NUMERIC(20,6) NOT NULL DEFAULT 0,
+    position_ids  TEXT[] NOT NULL DEFAULT '{}',
+    tx_hash       TEXT NOT NULL DEFAULT '',
+    block_number  BIGINT NOT NULL DEFAULT 0,
+    gas_used      BIGINT NOT NULL DEFAULT 0,
+    status        TEXT NOT NULL CHECK (status IN ('pending','submitted','confirmed','failed')),
+    error         TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    confirmed_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_redemptions_wallet_status ON redemptions(wallet, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_redemptions_condition ON redemptions(condition_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_arb_history_open;
+ALTER TABLE arb_history
+    DROP COLUMN IF EXISTS realized_pnl_usd,
+    DROP COLUMN IF EXISTS realized_edge_bps,
+    DROP COLUMN IF EXISTS execution_id,
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS last_seen_at,
+    DROP COLUMN IF EXISTS dedup_key,
+    DROP COLUMN IF EXISTS status;
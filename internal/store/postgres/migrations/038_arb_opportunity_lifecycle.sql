@@ -0,0 +1,21 @@
+-- Lifecycle of detected arbitrage opportunities (service.ArbService): open
+-- while the detector keeps seeing the opportunity, then executed or expired,
+-- with the realized edge of its execution. Earlier rows were never tracked
+-- and are closed as executed or expired.
+ALTER TABLE arb_history
+    ADD COLUMN IF NOT EXISTS status            TEXT NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'executed', 'expired')),
+    ADD COLUMN IF NOT EXISTS dedup_key         TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS last_seen_at      TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS closed_at         TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS execution_id      TEXT,
+    ADD COLUMN IF NOT EXISTS realized_edge_bps NUMERIC(12,4),
+    ADD COLUMN IF NOT EXISTS realized_pnl_usd  NUMERIC(20,6);
+
+UPDATE arb_history
+SET status       = CASE WHEN executed THEN 'executed' ELSE 'expired' END,
+    last_seen_at = detected_at,
+    closed_at    = COALESCE(executed_at, detected_at)
+WHERE last_seen_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_arb_history_open ON arb_history(dedup_key) WHERE status = 'open';
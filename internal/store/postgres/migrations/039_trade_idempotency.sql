@@ -0,0 +1,19 @@
+-- Exactly-once trade ingestion keyed by the on-chain fill: a trade with a
+-- transaction hash and log index is stored once, whatever its source or
+-- source_trade_id. Goldsky fills are keyed by their subgraph entity ID
+-- (unique per fill) from now on; rows stored before were keyed by the
+-- transaction hash alone (source_trade_id = tx_hash, no log index), which
+-- TradeStore keeps from ingesting that transaction's fills again.
+--
+-- The same fill may already be stored more than once (e.g. under two sources
+-- or entity IDs); keep the first row of each (tx_hash, source_log_idx) and
+-- delete the rest so the index can be built.
+DELETE FROM trades t
+    USING trades k
+    WHERE t.tx_hash = k.tx_hash
+      AND t.source_log_idx = k.source_log_idx
+      AND t.id > k.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trades_tx_log
+    ON trades(tx_hash, source_log_idx)
+    WHERE tx_hash IS NOT NULL AND source_log_idx IS NOT NULL;
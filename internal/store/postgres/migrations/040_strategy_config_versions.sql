@@ -0,0 +1,14 @@
+-- Version history of each strategy's effective parameters, runtime overrides
+-- included, recorded at startup, on parameter updates, config reloads and
+-- rollbacks. With strategy.prefer_persisted the newest version is applied at
+-- startup instead of the TOML values.
+CREATE TABLE IF NOT EXISTS strategy_config_versions (
+    name        TEXT NOT NULL,
+    version     INTEGER NOT NULL CHECK (version > 0),
+    params      JSONB NOT NULL DEFAULT '{}',
+    overrides   JSONB NOT NULL DEFAULT '{}',
+    source      TEXT NOT NULL CHECK (source IN ('startup', 'api', 'reload', 'rollback')),
+    rollback_of INTEGER,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (name, version)
+);
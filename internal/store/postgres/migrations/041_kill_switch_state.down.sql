@@ -0,0 +1 @@
+DROP TABLE IF EXISTS kill_switch_state;
@@ -0,0 +1,12 @@
+-- Portfolio kill switch per wallet (service.PortfolioRiskManager), restored at
+-- startup so a restart neither re-arms trading nor re-marks the day's PnL.
+CREATE TABLE IF NOT EXISTS kill_switch_state (
+    wallet       TEXT PRIMARY KEY,
+    tripped      BOOLEAN NOT NULL DEFAULT FALSE,
+    trip_reason  TEXT NOT NULL DEFAULT '',
+    tripped_at   TIMESTAMPTZ,
+    reset_at     TIMESTAMPTZ,
+    baseline     NUMERIC(20,6) NOT NULL DEFAULT 0,
+    day          DATE,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
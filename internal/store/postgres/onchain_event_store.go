@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// OnchainEventStore implements domain.OnchainEventStore using PostgreSQL.
+type OnchainEventStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewOnchainEventStore creates a new OnchainEventStore backed by the given
+// connection pool.
+func NewOnchainEventStore(pool *pgxpool.Pool) *OnchainEventStore {
+	return &OnchainEventStore{pool: pool}
+}
+
+// InsertBatch inserts events using a pgx Batch. Events already stored (same
+// kind and ID) are skipped via ON CONFLICT DO NOTHING.
+func (s *OnchainEventStore) InsertBatch(ctx context.Context, events []domain.OnchainEvent) (int64, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	const query = `
+		INSERT INTO onchain_events (id, kind, stakeholder, condition_id, amount, index_sets, timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (kind, id) DO NOTHING`
+
+	for _, e := range events {
+		indexSets := e.IndexSets
+		if indexSets == nil {
+			indexSets = []string{}
+		}
+		batch.Queue(query,
+			e.ID, string(e.Kind), strings.ToLower(e.Stakeholder), e.ConditionID,
+			e.Amount, indexSets, e.Timestamp,
+		)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	var inserted int64
+	for i := range events {
+		tag, err := br.Exec()
+		if err != nil {
+			return inserted, fmt.Errorf("postgres: insert onchain event batch item %d: %w", i, err)
+		}
+		inserted += tag.RowsAffected()
+	}
+	return inserted, nil
+}
+
+// LastTimestamp returns the time of the newest stored event of kind.
+func (s *OnchainEventStore) LastTimestamp(ctx context.Context, kind domain.OnchainEventKind) (time.Time, error) {
+	var ts *time.Time
+	err := s.pool.QueryRow(ctx,
+		`SELECT MAX(timestamp) FROM onchain_events WHERE kind = $1`, string(kind),
+	).Scan(&ts)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("postgres: last onchain event timestamp: %w", err)
+	}
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	return *ts, nil
+}
+
+// ListByWallet returns wallet's events with pagination and optional time
+// filtering, newest first.
+func (s *OnchainEventStore) ListByWallet(ctx context.Context, wallet string, opts domain.ListOpts) ([]domain.OnchainEvent, error) {
+	query := `SELECT id, kind, stakeholder, condition_id, amount, index_sets, timestamp
+		FROM onchain_events WHERE stakeholder = $1`
+	args := []any{strings.ToLower(wallet)}
+	argIdx := 2
+
+	if opts.Since != nil {
+		query += fmt.Sprintf(" AND timestamp >= $%d", argIdx)
+		args = append(args, *opts.Since)
+		argIdx++
+	}
+	if opts.Until != nil {
+		query += fmt.Sprintf(" AND timestamp <= $%d", argIdx)
+		args = append(args, *opts.Until)
+		argIdx++
+	}
+
+	query += " ORDER BY timestamp DESC, id"
+
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, opts.Limit)
+		argIdx++
+	}
+	if opts.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIdx)
+		args = append(args, opts.Offset)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list onchain events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.OnchainEvent
+	for rows.Next() {
+		var (
+			e    domain.OnchainEvent
+			kind string
+		)
+		if err := rows.Scan(&e.ID, &kind, &e.Stakeholder, &e.ConditionID, &e.Amount, &e.IndexSets, &e.Timestamp); err != nil {
+			return nil, fmt.Errorf("postgres: scan onchain event: %w", err)
+		}
+		e.Kind = domain.OnchainEventKind(kind)
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list onchain events: %w", err)
+	}
+	return events, nil
+}
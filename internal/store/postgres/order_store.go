@@ -39,12 +39,12 @@ func (s *OrderStore) Create(ctx context.Context, o domain.Order) error {
 			id, market_id, token_id, wallet, side, order_type,
 			price_ticks, size_units, maker_amount, taker_amount,
 			price, size, filled_size, status, signature, strategy_name,
-			created_at, filled_at, cancelled_at, updated_at
+			created_at, filled_at, cancelled_at, exchange_order_id, expires_at, cancel_after, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6,
 			$7, $8, $9, $10,
 			$11, $12, $13, $14, $15, $16,
-			$17, $18, $19, NOW()
+			$17, $18, $19, NULLIF($20, ''), $21, $22, NOW()
 		)`
 
 	_, err := s.pool.Exec(ctx, query,
@@ -54,7 +54,7 @@ func (s *OrderStore) Create(ctx context.Context, o domain.Order) error {
 		makerAmountStr, takerAmountStr,
 		o.Price(), o.Size(), o.FilledSize,
 		string(o.Status), o.Signature, o.Strategy,
-		o.CreatedAt, o.FilledAt, o.CancelledAt,
+		o.CreatedAt, o.FilledAt, o.CancelledAt, o.ExchangeID, o.ExpiresAt, o.CancelAfter,
 	)
 	if err != nil {
 		return fmt.Errorf("postgres: create order %s: %w", o.ID, err)
@@ -69,7 +69,7 @@ func (s *OrderStore) UpdateStatus(ctx context.Context, id string, status domain.
 	switch status {
 	case domain.OrderStatusMatched:
 		query = `UPDATE orders SET status = $1, filled_at = NOW(), updated_at = NOW() WHERE id = $2`
-	case domain.OrderStatusCancelled:
+	case domain.OrderStatusCancelled, domain.OrderStatusExpired:
 		query = `UPDATE orders SET status = $1, cancelled_at = NOW(), updated_at = NOW() WHERE id = $2`
 	default:
 		query = `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2`
@@ -85,13 +85,42 @@ func (s *OrderStore) UpdateStatus(ctx context.Context, id string, status domain.
 	return nil
 }
 
+// SetExchangeID records the CLOB order hash assigned to a local order.
+func (s *OrderStore) SetExchangeID(ctx context.Context, id, exchangeID string) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE orders SET exchange_order_id = $1, updated_at = NOW() WHERE id = $2`, exchangeID, id)
+	if err != nil {
+		return fmt.Errorf("postgres: set exchange id %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// RecordFill sets an order's cumulative filled size and status. filled_at is
+// set on the first fill and kept thereafter.
+func (s *OrderStore) RecordFill(ctx context.Context, id string, filledSize float64, status domain.OrderStatus) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE orders
+		 SET filled_size = $1, status = $2, filled_at = COALESCE(filled_at, NOW()), updated_at = NOW()
+		 WHERE id = $3`, filledSize, string(status), id)
+	if err != nil {
+		return fmt.Errorf("postgres: record fill %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 // orderSelectCols lists the columns selected when reading orders.
 // The price and size columns are derived (stored redundantly for queries)
 // but we still need to scan them to satisfy the column list.
 const orderSelectCols = `id, market_id, token_id, wallet, side, order_type,
 	price_ticks, size_units, maker_amount, taker_amount,
 	price, size, filled_size, status, signature, strategy_name,
-	created_at, filled_at, cancelled_at`
+	created_at, filled_at, cancelled_at, COALESCE(exchange_order_id, ''), expires_at, cancel_after`
 
 func scanOrderFromRow(
 	scanner interface{ Scan(dest ...any) error },
@@ -110,7 +139,7 @@ func scanOrderFromRow(
 		&makerAmountStr, &takerAmountStr,
 		&dbPrice, &dbSize,
 		&o.FilledSize, &status, &o.Signature, &o.Strategy,
-		&o.CreatedAt, &o.FilledAt, &o.CancelledAt,
+		&o.CreatedAt, &o.FilledAt, &o.CancelledAt, &o.ExchangeID, &o.ExpiresAt, &o.CancelAfter,
 	)
 	if err != nil {
 		return domain.Order{}, err
@@ -159,11 +188,27 @@ func (s *OrderStore) GetByID(ctx context.Context, id string) (domain.Order, erro
 	return o, nil
 }
 
-// ListOpen returns all orders in open/pending status for the given wallet.
+// GetByExchangeID retrieves the order with the given CLOB order hash.
+func (s *OrderStore) GetByExchangeID(ctx context.Context, exchangeID string) (domain.Order, error) {
+	row := s.pool.QueryRow(ctx,
+		`SELECT `+orderSelectCols+` FROM orders WHERE exchange_order_id = $1`, exchangeID)
+
+	o, err := scanOrderFromRow(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Order{}, domain.ErrNotFound
+		}
+		return domain.Order{}, fmt.Errorf("postgres: get order by exchange id %s: %w", exchangeID, err)
+	}
+	return o, nil
+}
+
+// ListOpen returns all live (pending, open or partially filled) orders for
+// the given wallet.
 func (s *OrderStore) ListOpen(ctx context.Context, wallet string) ([]domain.Order, error) {
 	rows, err := s.pool.Query(ctx,
 		`SELECT `+orderSelectCols+` FROM orders
-		 WHERE wallet = $1 AND status IN ('pending', 'open')
+		 WHERE wallet = $1 AND status IN ('pending', 'open', 'partially_filled')
 		 ORDER BY created_at DESC`, wallet)
 	if err != nil {
 		return nil, fmt.Errorf("postgres: list open orders: %w", err)
@@ -219,6 +264,37 @@ func (s *OrderStore) ListByMarket(ctx context.Context, marketID string, opts dom
 	return orders, nil
 }
 
+// ListFilled returns the wallet's matched orders, and orders of any status
+// with a partial fill, filled at or before until, ordered by fill time
+// (falling back to creation time) ascending.
+func (s *OrderStore) ListFilled(ctx context.Context, wallet string, until time.Time) ([]domain.Order, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+orderSelectCols+` FROM orders
+		 WHERE wallet = $1 AND (status = 'matched' OR filled_size > 0) AND COALESCE(filled_at, created_at) <= $2
+		 ORDER BY COALESCE(filled_at, created_at) ASC`, wallet, until)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list filled orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders, err := scanOrderRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: scan filled orders: %w", err)
+	}
+	return orders, nil
+}
+
+// ListRange returns orders created in [from, to), oldest first.
+func (s *OrderStore) ListRange(ctx context.Context, from, to time.Time) ([]domain.Order, error) {
+	query := `SELECT ` + orderSelectCols + ` FROM orders WHERE created_at >= $1 AND created_at < $2 ORDER BY created_at ASC`
+	rows, err := s.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list orders in range: %w", err)
+	}
+	defer rows.Close()
+	return scanOrderRows(rows)
+}
+
 // ListBefore returns all orders created strictly before the given time (for archiving).
 func (s *OrderStore) ListBefore(ctx context.Context, before time.Time) ([]domain.Order, error) {
 	query := `SELECT ` + orderSelectCols + ` FROM orders WHERE created_at < $1 ORDER BY created_at ASC`
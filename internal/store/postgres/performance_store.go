@@ -0,0 +1,268 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PerformanceStore implements domain.PerformanceStore using PostgreSQL.
+type PerformanceStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPerformanceStore creates a new PerformanceStore backed by the given connection pool.
+func NewPerformanceStore(pool *pgxpool.Pool) *PerformanceStore {
+	return &PerformanceStore{pool: pool}
+}
+
+// perfKey identifies one StrategyDay while merging the per-table aggregates.
+type perfKey struct {
+	day      time.Time
+	strategy string
+}
+
+// ComputeDays aggregates positions closed, orders created and arb executions
+// started in [from, to) per UTC day and strategy. An arb execution belongs to
+// the strategy of its first leg's order.
+func (s *PerformanceStore) ComputeDays(ctx context.Context, from, to time.Time) ([]domain.StrategyDay, error) {
+	days := make(map[perfKey]*domain.StrategyDay)
+	row := func(day time.Time, strategy string) *domain.StrategyDay {
+		k := perfKey{time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC), strategy}
+		d, ok := days[k]
+		if !ok {
+			d = &domain.StrategyDay{Day: k.day, Strategy: strategy}
+			days[k] = d
+		}
+		return d
+	}
+
+	const positionsQuery = `
+		SELECT date_trunc('day', closed_at AT TIME ZONE 'UTC') AS day,
+		       COALESCE(strategy_name, '') AS strategy,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE pnl > 0),
+		       COALESCE(SUM(pnl), 0)::float8,
+		       COALESCE(SUM(entry_price * size), 0)::float8
+		FROM (
+			SELECT closed_at, strategy_name, entry_price, size,
+			       CASE direction
+			           WHEN 'buy'  THEN (exit_price - entry_price) * size
+			           WHEN 'sell' THEN (entry_price - exit_price) * size
+			       END + COALESCE(realized_pnl, 0) AS pnl
+			FROM positions
+			WHERE status = 'closed' AND exit_price IS NOT NULL
+			  AND closed_at >= $1 AND closed_at < $2
+		) p
+		GROUP BY day, strategy`
+	if err := s.scanDays(ctx, "positions", positionsQuery, []any{from, to}, func(rows pgx.Rows) error {
+		var day time.Time
+		var strategy string
+		var closed, wins int
+		var pnl, notional float64
+		if err := rows.Scan(&day, &strategy, &closed, &wins, &pnl, &notional); err != nil {
+			return err
+		}
+		d := row(day, strategy)
+		d.Closed, d.Wins, d.RealizedPnL, d.ClosedNotional = closed, wins, pnl, notional
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// edge_bps metadata is a string; anything that is not a plain number is
+	// ignored.
+	const ordersQuery = `
+		SELECT date_trunc('day', created_at AT TIME ZONE 'UTC') AS day,
+		       COALESCE(strategy_name, '') AS strategy,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE filled > 0),
+		       COALESCE(SUM(filled), 0)::float8,
+		       COALESCE(SUM(filled) FILTER (WHERE order_type IN ('FOK', 'FAK') AND NOT arb_leg), 0)::float8,
+		       COALESCE(SUM(filled * edge_bps / 10000) FILTER (WHERE edge_bps IS NOT NULL), 0)::float8,
+		       COALESCE(SUM(filled) FILTER (WHERE edge_bps IS NOT NULL), 0)::float8
+		FROM (
+			SELECT o.created_at, o.strategy_name, o.order_type,
+			       COALESCE(o.filled_size, 0) * COALESCE(o.price, o.price_ticks / 1e6) AS filled,
+			       EXISTS (SELECT 1 FROM arb_execution_legs l WHERE l.order_id = o.id) AS arb_leg,
+			       CASE WHEN sig.metadata->>'edge_bps' ~ '^-?[0-9]+(\.[0-9]+)?$'
+			            THEN (sig.metadata->>'edge_bps')::numeric END AS edge_bps
+			FROM orders o
+			LEFT JOIN strategy_signals sig ON sig.id = o.id
+			WHERE o.created_at >= $1 AND o.created_at < $2
+		) o
+		GROUP BY day, strategy`
+	if err := s.scanDays(ctx, "orders", ordersQuery, []any{from, to}, func(rows pgx.Rows) error {
+		var day time.Time
+		var strategy string
+		var orders, filled int
+		var notional, taker, edgeUSD, edgeNotional float64
+		if err := rows.Scan(&day, &strategy, &orders, &filled, &notional, &taker, &edgeUSD, &edgeNotional); err != nil {
+			return err
+		}
+		d := row(day, strategy)
+		d.Orders, d.FilledOrders, d.FilledNotional, d.TakerNotional = orders, filled, notional, taker
+		d.ExpectedEdgeUSD, d.EdgeNotional = edgeUSD, edgeNotional
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	const arbQuery = `
+		SELECT date_trunc('day', e.started_at AT TIME ZONE 'UTC') AS day,
+		       COALESCE(leg.strategy_name, '') AS strategy,
+		       COUNT(*),
+		       COALESCE(SUM(e.total_fees), 0)::float8,
+		       COALESCE(SUM(e.total_slippage), 0)::float8
+		FROM arb_executions e
+		LEFT JOIN LATERAL (
+			SELECT o.strategy_name
+			FROM arb_execution_legs l
+			JOIN orders o ON o.id = l.order_id
+			WHERE l.execution_id = e.id
+			ORDER BY l.id
+			LIMIT 1
+		) leg ON true
+		WHERE e.started_at >= $1 AND e.started_at < $2
+		GROUP BY day, strategy`
+	if err := s.scanDays(ctx, "arb executions", arbQuery, []any{from, to}, func(rows pgx.Rows) error {
+		var day time.Time
+		var strategy string
+		var n int
+		var fees, slippage float64
+		if err := rows.Scan(&day, &strategy, &n, &fees, &slippage); err != nil {
+			return err
+		}
+		d := row(day, strategy)
+		d.ArbExecutions, d.ArbFeesUSD, d.ArbSlippageUSD = n, fees, slippage
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	out := make([]domain.StrategyDay, 0, len(days))
+	for _, d := range days {
+		out = append(out, *d)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Day.Equal(out[j].Day) {
+			return out[i].Day.Before(out[j].Day)
+		}
+		return out[i].Strategy < out[j].Strategy
+	})
+	return out, nil
+}
+
+// scanDays runs one aggregate query of ComputeDays and hands each row to scan.
+func (s *PerformanceStore) scanDays(ctx context.Context, what, query string, args []any, scan func(pgx.Rows) error) error {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("postgres: performance %s: %w", what, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("postgres: scan performance %s: %w", what, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("postgres: performance %s rows: %w", what, err)
+	}
+	return nil
+}
+
+// UpsertDays stores rows using a pgx Batch.
+func (s *PerformanceStore) UpsertDays(ctx context.Context, days []domain.StrategyDay) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	const query = `
+		INSERT INTO strategy_performance_daily (
+			day, strategy, closed, wins, realized_pnl, closed_notional,
+			orders, filled_orders, filled_notional, taker_notional,
+			expected_edge_usd, edge_notional, arb_executions, arb_fees_usd,
+			arb_slippage_usd, fees_usd, computed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (day, strategy) DO UPDATE SET
+			closed            = EXCLUDED.closed,
+			wins              = EXCLUDED.wins,
+			realized_pnl      = EXCLUDED.realized_pnl,
+			closed_notional   = EXCLUDED.closed_notional,
+			orders            = EXCLUDED.orders,
+			filled_orders     = EXCLUDED.filled_orders,
+			filled_notional   = EXCLUDED.filled_notional,
+			taker_notional    = EXCLUDED.taker_notional,
+			expected_edge_usd = EXCLUDED.expected_edge_usd,
+			edge_notional     = EXCLUDED.edge_notional,
+			arb_executions    = EXCLUDED.arb_executions,
+			arb_fees_usd      = EXCLUDED.arb_fees_usd,
+			arb_slippage_usd  = EXCLUDED.arb_slippage_usd,
+			fees_usd          = EXCLUDED.fees_usd,
+			computed_at       = EXCLUDED.computed_at`
+
+	for _, d := range days {
+		batch.Queue(query,
+			d.Day, d.Strategy, d.Closed, d.Wins, d.RealizedPnL, d.ClosedNotional,
+			d.Orders, d.FilledOrders, d.FilledNotional, d.TakerNotional,
+			d.ExpectedEdgeUSD, d.EdgeNotional, d.ArbExecutions, d.ArbFeesUSD,
+			d.ArbSlippageUSD, d.FeesUSD, d.ComputedAt,
+		)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range days {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("postgres: upsert performance day batch item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ListDays returns stored rows of days in [from, to), oldest first.
+func (s *PerformanceStore) ListDays(ctx context.Context, strategy string, from, to time.Time) ([]domain.StrategyDay, error) {
+	const query = `
+		SELECT day::timestamp, strategy, closed, wins, realized_pnl::float8, closed_notional::float8,
+		       orders, filled_orders, filled_notional::float8, taker_notional::float8,
+		       expected_edge_usd::float8, edge_notional::float8, arb_executions, arb_fees_usd::float8,
+		       arb_slippage_usd::float8, fees_usd::float8, computed_at
+		FROM strategy_performance_daily
+		WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date
+		  AND day < ($2::timestamptz AT TIME ZONE 'UTC')::date
+		  AND ($3 = '' OR strategy = $3)
+		ORDER BY day, strategy`
+
+	rows, err := s.pool.Query(ctx, query, from, to, strategy)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list performance days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []domain.StrategyDay
+	for rows.Next() {
+		var d domain.StrategyDay
+		if err := rows.Scan(
+			&d.Day, &d.Strategy, &d.Closed, &d.Wins, &d.RealizedPnL, &d.ClosedNotional,
+			&d.Orders, &d.FilledOrders, &d.FilledNotional, &d.TakerNotional,
+			&d.ExpectedEdgeUSD, &d.EdgeNotional, &d.ArbExecutions, &d.ArbFeesUSD,
+			&d.ArbSlippageUSD, &d.FeesUSD, &d.ComputedAt,
+		); err != nil {
+			return nil, fmt.Errorf("postgres: scan performance day: %w", err)
+		}
+		d.Day = time.Date(d.Day.Year(), d.Day.Month(), d.Day.Day(), 0, 0, 0, 0, time.UTC)
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list performance days rows: %w", err)
+	}
+	return days, nil
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// PipelineRunStore implements domain.PipelineRunStore using PostgreSQL.
+type PipelineRunStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPipelineRunStore creates a new PipelineRunStore.
+func NewPipelineRunStore(pool *pgxpool.Pool) *PipelineRunStore {
+	return &PipelineRunStore{pool: pool}
+}
+
+const pipelineRunColumns = `id, trigger, status, stats, error, requested_at, started_at, finished_at`
+
+// Enqueue inserts a queued run. The partial unique index on queued runs makes
+// this safe against concurrent triggers: the loser gets ErrAlreadyExists.
+func (s *PipelineRunStore) Enqueue(ctx context.Context, run domain.PipelineRun) error {
+	stats, err := json.Marshal(run.Stats)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal pipeline run stats: %w", err)
+	}
+	const query = `
+		INSERT INTO pipeline_runs (id, trigger, status, stats, error, requested_at)
+		VALUES ($1, $2, 'queued', $3, '', $4)
+		ON CONFLICT (status) WHERE status = 'queued' DO NOTHING`
+	tag, err := s.pool.Exec(ctx, query, run.ID, run.Trigger, stats, run.RequestedAt)
+	if err != nil {
+		return fmt.Errorf("postgres: enqueue pipeline run %s: %w", run.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAlreadyExists
+	}
+	return nil
+}
+
+// Queued returns the currently queued run.
+func (s *PipelineRunStore) Queued(ctx context.Context) (domain.PipelineRun, error) {
+	row := s.pool.QueryRow(ctx,
+		`SELECT `+pipelineRunColumns+` FROM pipeline_runs WHERE status = 'queued' LIMIT 1`)
+	run, err := scanPipelineRun(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.PipelineRun{}, domain.ErrNotFound
+		}
+		return domain.PipelineRun{}, fmt.Errorf("postgres: get queued pipeline run: %w", err)
+	}
+	return run, nil
+}
+
+// ClaimNext atomically moves the oldest queued run to running.
+func (s *PipelineRunStore) ClaimNext(ctx context.Context) (domain.PipelineRun, error) {
+	const query = `
+		UPDATE pipeline_runs SET status = 'running', started_at = NOW()
+		WHERE id = (
+			SELECT id FROM pipeline_runs WHERE status = 'queued'
+			ORDER BY requested_at ASC LIMIT 1 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + pipelineRunColumns
+	run, err := scanPipelineRun(s.pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.PipelineRun{}, domain.ErrNotFound
+		}
+		return domain.PipelineRun{}, fmt.Errorf("postgres: claim pipeline run: %w", err)
+	}
+	return run, nil
+}
+
+// Finish records the outcome of a run.
+func (s *PipelineRunStore) Finish(ctx context.Context, run domain.PipelineRun) error {
+	stats, err := json.Marshal(run.Stats)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal pipeline run stats: %w", err)
+	}
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE pipeline_runs SET status = $2, stats = $3, error = $4, finished_at = $5 WHERE id = $1`,
+		run.ID, string(run.Status), stats, run.Error, run.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("postgres: finish pipeline run %s: %w", run.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// FailRunning marks every running run as failed with reason.
+func (s *PipelineRunStore) FailRunning(ctx context.Context, reason string) (int64, error) {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE pipeline_runs SET status = 'failed', error = $1, finished_at = NOW() WHERE status = 'running'`,
+		reason)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: fail running pipeline runs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetByID returns a single run.
+func (s *PipelineRunStore) GetByID(ctx context.Context, id string) (domain.PipelineRun, error) {
+	row := s.pool.QueryRow(ctx,
+		`SELECT `+pipelineRunColumns+` FROM pipeline_runs WHERE id = $1`, id)
+	run, err := scanPipelineRun(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.PipelineRun{}, domain.ErrNotFound
+		}
+		return domain.PipelineRun{}, fmt.Errorf("postgres: get pipeline run %s: %w", id, err)
+	}
+	return run, nil
+}
+
+// List returns the most recent runs, newest first.
+func (s *PipelineRunStore) List(ctx context.Context, limit int) ([]domain.PipelineRun, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+pipelineRunColumns+` FROM pipeline_runs ORDER BY requested_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list pipeline runs: %w", err)
+	}
+	defer rows.Close()
+	var list []domain.PipelineRun
+	for rows.Next() {
+		run, err := scanPipelineRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: scan pipeline run: %w", err)
+		}
+		list = append(list, run)
+	}
+	return list, rows.Err()
+}
+
+func scanPipelineRun(row pgx.Row) (domain.PipelineRun, error) {
+	var run domain.PipelineRun
+	var status string
+	var stats []byte
+	err := row.Scan(
+		&run.ID, &run.Trigger, &status, &stats, &run.Error,
+		&run.RequestedAt, &run.StartedAt, &run.FinishedAt,
+	)
+	if err != nil {
+		return domain.PipelineRun{}, err
+	}
+	run.Status = domain.PipelineRunStatus(status)
+	if len(stats) > 0 {
+		_ = json.Unmarshal(stats, &run.Stats)
+	}
+	return run, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -226,3 +227,130 @@ func (s *PositionStore) ListHistory(ctx context.Context, wallet string, opts dom
 	}
 	return positions, nil
 }
+
+// RealizedPnLSince sums the realized PnL of positions closed after since. PnL
+// is derived from entry and exit prices because Close does not persist it,
+// plus any realized_pnl booked by earlier partial reductions.
+func (s *PositionStore) RealizedPnLSince(ctx context.Context, wallet string, since time.Time) (float64, error) {
+	const query = `
+		SELECT COALESCE(SUM(
+			CASE direction
+				WHEN 'buy'  THEN (exit_price - entry_price) * size
+				WHEN 'sell' THEN (entry_price - exit_price) * size
+			END + COALESCE(realized_pnl, 0)
+		), 0)::float8
+		FROM positions
+		WHERE wallet = $1 AND status = 'closed' AND exit_price IS NOT NULL AND closed_at > $2`
+	var pnl float64
+	if err := s.pool.QueryRow(ctx, query, wallet, since).Scan(&pnl); err != nil {
+		return 0, fmt.Errorf("postgres: realized pnl since %s: %w", since.Format(time.RFC3339), err)
+	}
+	return pnl, nil
+}
+
+// positionFilterSQL appends the filter's conditions to a WHERE clause that
+// already has at least one condition, numbering placeholders from argIdx.
+func positionFilterSQL(filter domain.PositionFilter, args []any, argIdx int) (string, []any, int) {
+	var where string
+	if filter.Wallet != "" {
+		where += fmt.Sprintf(" AND wallet = $%d", argIdx)
+		args = append(args, filter.Wallet)
+		argIdx++
+	}
+	if filter.Strategy != "" {
+		where += fmt.Sprintf(" AND strategy_name = $%d", argIdx)
+		args = append(args, filter.Strategy)
+		argIdx++
+	}
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND status = $%d", argIdx)
+		args = append(args, string(filter.Status))
+		argIdx++
+	}
+	return where, args, argIdx
+}
+
+// List returns positions matching filter, newest first, with pagination and
+// optional opened_at bounds.
+func (s *PositionStore) List(ctx context.Context, filter domain.PositionFilter, opts domain.ListOpts) ([]domain.Position, error) {
+	where, args, argIdx := positionFilterSQL(filter, nil, 1)
+	query := `SELECT ` + positionSelectCols + ` FROM positions WHERE TRUE` + where
+
+	if opts.Since != nil {
+		query += fmt.Sprintf(" AND opened_at >= $%d", argIdx)
+		args = append(args, *opts.Since)
+		argIdx++
+	}
+	if opts.Until != nil {
+		query += fmt.Sprintf(" AND opened_at <= $%d", argIdx)
+		args = append(args, *opts.Until)
+		argIdx++
+	}
+
+	query += " ORDER BY opened_at DESC"
+
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, opts.Limit)
+		argIdx++
+	}
+	if opts.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIdx)
+		args = append(args, opts.Offset)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list positions: %w", err)
+	}
+	defer rows.Close()
+
+	positions, err := scanPositionRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: scan positions: %w", err)
+	}
+	return positions, nil
+}
+
+// RealizedPnLByDay sums the realized PnL of closed positions matching filter
+// per strategy and UTC day of closed_at, computed as in RealizedPnLSince.
+// filter.Status is ignored.
+func (s *PositionStore) RealizedPnLByDay(ctx context.Context, filter domain.PositionFilter, since, until time.Time) ([]domain.PnLBucket, error) {
+	filter.Status = ""
+	where, args, _ := positionFilterSQL(filter, []any{since, until}, 3)
+	query := `
+		SELECT date_trunc('day', closed_at AT TIME ZONE 'UTC') AS day,
+		       COALESCE(strategy_name, '') AS strategy,
+		       COALESCE(SUM(
+		           CASE direction
+		               WHEN 'buy'  THEN (exit_price - entry_price) * size
+		               WHEN 'sell' THEN (entry_price - exit_price) * size
+		           END + COALESCE(realized_pnl, 0)
+		       ), 0)::float8,
+		       COUNT(*)
+		FROM positions
+		WHERE status = 'closed' AND exit_price IS NOT NULL
+		  AND closed_at >= $1 AND closed_at < $2` + where + `
+		GROUP BY day, strategy
+		ORDER BY day, strategy`
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: realized pnl by day: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []domain.PnLBucket
+	for rows.Next() {
+		var b domain.PnLBucket
+		if err := rows.Scan(&b.Day, &b.Strategy, &b.RealizedPnL, &b.Closed); err != nil {
+			return nil, fmt.Errorf("postgres: scan realized pnl bucket: %w", err)
+		}
+		b.Day = time.Date(b.Day.Year(), b.Day.Month(), b.Day.Day(), 0, 0, 0, 0, time.UTC)
+		buckets = append(buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: realized pnl by day rows: %w", err)
+	}
+	return buckets, nil
+}
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// RedemptionStore implements domain.RedemptionStore using PostgreSQL.
+type RedemptionStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewRedemptionStore creates a new RedemptionStore.
+func NewRedemptionStore(pool *pgxpool.Pool) *RedemptionStore {
+	return &RedemptionStore{pool: pool}
+}
+
+const redemptionColumns = `id, wallet, market_id, condition_id, neg_risk, token_ids,
+	shares::float8[], payouts::float8[], proceeds::float8, position_ids, tx_hash,
+	block_number, gas_used, status, error, created_at, confirmed_at`
+
+// Create inserts a new redemption.
+func (s *RedemptionStore) Create(ctx context.Context, r domain.Redemption) error {
+	// The array columns are NOT NULL.
+	if r.TokenIDs == nil {
+		r.TokenIDs = []string{}
+	}
+	if r.Shares == nil {
+		r.Shares = []float64{}
+	}
+	if r.Payouts == nil {
+		r.Payouts = []float64{}
+	}
+	if r.PositionIDs == nil {
+		r.PositionIDs = []string{}
+	}
+	const query = `
+		INSERT INTO redemptions (id, wallet, market_id, condition_id, neg_risk, token_ids, shares, payouts,
+			proceeds, position_ids, tx_hash, block_number, gas_used, status, error, created_at, confirmed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`
+	_, err := s.pool.Exec(ctx, query,
+		r.ID, r.Wallet, r.MarketID, r.ConditionID, r.NegRisk, r.TokenIDs,
+		r.Shares, r.Payouts, r.Proceeds, r.PositionIDs,
+		r.TxHash, r.BlockNumber, r.GasUsed, string(r.Status), r.Error, r.CreatedAt, r.ConfirmedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: create redemption %s: %w", r.ID, err)
+	}
+	return nil
+}
+
+// Update records the transaction, status and outcome of a redemption and the
+// positions its proceeds were booked against.
+func (s *RedemptionStore) Update(ctx context.Context, r domain.Redemption) error {
+	if r.PositionIDs == nil {
+		r.PositionIDs = []string{}
+	}
+	const query = `
+		UPDATE redemptions SET
+			position_ids = $2, tx_hash = $3, block_number = $4, gas_used = $5,
+			status = $6, error = $7, confirmed_at = $8
+		WHERE id = $1`
+	tag, err := s.pool.Exec(ctx, query,
+		r.ID, r.PositionIDs, r.TxHash, r.BlockNumber, r.GasUsed,
+		string(r.Status), r.Error, r.ConfirmedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: update redemption %s: %w", r.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ListActive returns wallet's pending and submitted redemptions, oldest first.
+func (s *RedemptionStore) ListActive(ctx context.Context, wallet string) ([]domain.Redemption, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+redemptionColumns+` FROM redemptions
+		 WHERE wallet = $1 AND status IN ('pending', 'submitted')
+		 ORDER BY created_at`, wallet)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list active redemptions: %w", err)
+	}
+	return scanRedemptionRows(rows)
+}
+
+// List returns the most recent redemptions, newest first.
+func (s *RedemptionStore) List(ctx context.Context, limit int) ([]domain.Redemption, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+redemptionColumns+` FROM redemptions ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list redemptions: %w", err)
+	}
+	return scanRedemptionRows(rows)
+}
+
+func scanRedemptionRows(rows pgx.Rows) ([]domain.Redemption, error) {
+	defer rows.Close()
+	var list []domain.Redemption
+	for rows.Next() {
+		var r domain.Redemption
+		var status string
+		err := rows.Scan(
+			&r.ID, &r.Wallet, &r.MarketID, &r.ConditionID, &r.NegRisk, &r.TokenIDs,
+			&r.Shares, &r.Payouts, &r.Proceeds, &r.PositionIDs, &r.TxHash,
+			&r.BlockNumber, &r.GasUsed, &status, &r.Error, &r.CreatedAt, &r.ConfirmedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: scan redemption: %w", err)
+		}
+		r.Status = domain.RedemptionStatus(status)
+		list = append(list, r)
+	}
+	return list, rows.Err()
+}
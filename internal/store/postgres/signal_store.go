@@ -0,0 +1,339 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// SignalStore implements domain.SignalStore using PostgreSQL.
+type SignalStore struct {
+	pool  *pgxpool.Pool
+	table string
+}
+
+// NewSignalStore creates a new SignalStore backed by the given connection pool.
+func NewSignalStore(pool *pgxpool.Pool) *SignalStore {
+	return &SignalStore{pool: pool, table: "strategy_signals"}
+}
+
+// NewShadowCandidateStore creates a SignalStore over shadow_candidates, the
+// signals of strategies running in shadow mode, which never reach the
+// executor.
+func NewShadowCandidateStore(pool *pgxpool.Pool) *SignalStore {
+	return &SignalStore{pool: pool, table: "shadow_candidates"}
+}
+
+// InsertBatch inserts signals using a pgx Batch. Signals already recorded
+// (same ID) are ignored.
+func (s *SignalStore) InsertBatch(ctx context.Context, signals []domain.TradeSignal) error {
+	if len(signals) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO ` + s.table + ` (id, source, market_id, token_id, side, price_ticks, size_units,
+			urgency, reason, metadata, order_type, order_expiration, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (id) DO NOTHING`
+
+	for _, sig := range signals {
+		var metaJSON []byte
+		if len(sig.Metadata) > 0 {
+			var err error
+			if metaJSON, err = json.Marshal(sig.Metadata); err != nil {
+				return fmt.Errorf("postgres: marshal signal metadata: %w", err)
+			}
+		}
+		batch.Queue(query,
+			sig.ID, sig.Source, sig.MarketID, sig.TokenID, string(sig.Side), sig.PriceTicks, sig.SizeUnits,
+			int(sig.Urgency), sig.Reason, metaJSON, string(sig.OrderType),
+			nullTime(sig.OrderExpiration), sig.CreatedAt, nullTime(sig.ExpiresAt),
+		)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range signals {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("postgres: insert signal batch item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ListByStrategy returns signals from source created in [opts.Since,
+// opts.Until], newest first.
+func (s *SignalStore) ListByStrategy(ctx context.Context, source string, opts domain.ListOpts) ([]domain.TradeSignal, error) {
+	query := `
+		SELECT id, source, market_id, token_id, side, price_ticks, size_units, urgency,
+		       COALESCE(reason, ''), metadata, COALESCE(order_type, ''), order_expiration, created_at, expires_at
+		FROM ` + s.table + `
+		WHERE source = $1`
+	args := []any{source}
+	argIdx := 2
+
+	if opts.Since != nil {
+		query += fmt.Sprintf(" AND created_at >= $%d", argIdx)
+		args = append(args, *opts.Since)
+		argIdx++
+	}
+	if opts.Until != nil {
+		query += fmt.Sprintf(" AND created_at <= $%d", argIdx)
+		args = append(args, *opts.Until)
+		argIdx++
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, opts.Limit)
+		argIdx++
+	}
+	if opts.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIdx)
+		args = append(args, opts.Offset)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list signals: %w", err)
+	}
+	defer rows.Close()
+
+	var signals []domain.TradeSignal
+	for rows.Next() {
+		var sig domain.TradeSignal
+		var f signalFields
+		if err := rows.Scan(
+			&sig.ID, &sig.Source, &sig.MarketID, &sig.TokenID, &f.side, &sig.PriceTicks, &sig.SizeUnits, &f.urgency,
+			&sig.Reason, &f.metaJSON, &f.orderType, &f.orderExp, &sig.CreatedAt, &f.expiresAt,
+		); err != nil {
+			return nil, fmt.Errorf("postgres: scan signal: %w", err)
+		}
+		if err := f.apply(&sig); err != nil {
+			return nil, err
+		}
+		signals = append(signals, sig)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list signals rows: %w", err)
+	}
+	return signals, nil
+}
+
+// signalFields holds the scanned columns of a signal that need converting.
+type signalFields struct {
+	side, orderType     string
+	urgency             int
+	metaJSON            []byte
+	orderExp, expiresAt *time.Time
+}
+
+func (f signalFields) apply(sig *domain.TradeSignal) error {
+	sig.Side = domain.OrderSide(f.side)
+	sig.Urgency = domain.SignalUrgency(f.urgency)
+	sig.OrderType = domain.OrderType(f.orderType)
+	if f.orderExp != nil {
+		sig.OrderExpiration = *f.orderExp
+	}
+	if f.expiresAt != nil {
+		sig.ExpiresAt = *f.expiresAt
+	}
+	if f.metaJSON != nil {
+		if err := json.Unmarshal(f.metaJSON, &sig.Metadata); err != nil {
+			return fmt.Errorf("postgres: unmarshal signal metadata: %w", err)
+		}
+	}
+	return nil
+}
+
+// Resolve records the outcomes of signals in one batch. A signal not yet
+// inserted by InsertBatch is inserted from the resolution; order IDs are
+// appended to those already recorded.
+func (s *SignalStore) Resolve(ctx context.Context, resolutions []domain.SignalResolution) error {
+	if len(resolutions) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO ` + s.table + ` AS s (id, source, market_id, token_id, side, price_ticks, size_units,
+			urgency, reason, metadata, order_type, order_expiration, created_at, expires_at,
+			status, status_reason, order_ids, resolved_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (id) DO UPDATE SET
+			status        = EXCLUDED.status,
+			status_reason = EXCLUDED.status_reason,
+			order_ids     = s.order_ids || ARRAY(
+				SELECT x FROM unnest(EXCLUDED.order_ids) AS x WHERE x <> ALL(s.order_ids)),
+			resolved_at   = EXCLUDED.resolved_at`
+
+	for _, r := range resolutions {
+		sig := r.Signal
+		var metaJSON []byte
+		if len(sig.Metadata) > 0 {
+			var err error
+			if metaJSON, err = json.Marshal(sig.Metadata); err != nil {
+				return fmt.Errorf("postgres: marshal signal metadata: %w", err)
+			}
+		}
+		orderIDs := r.OrderIDs
+		if orderIDs == nil {
+			orderIDs = []string{}
+		}
+		batch.Queue(query,
+			sig.ID, sig.Source, sig.MarketID, sig.TokenID, string(sig.Side), sig.PriceTicks, sig.SizeUnits,
+			int(sig.Urgency), sig.Reason, metaJSON, string(sig.OrderType),
+			nullTime(sig.OrderExpiration), sig.CreatedAt, nullTime(sig.ExpiresAt),
+			string(r.Status), r.Reason, orderIDs, r.At,
+		)
+	}
+
+	br := s.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := range resolutions {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("postgres: resolve signal batch item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// signalStatusExpr is a signal's status, with pending signals past their
+// expiry reported as expired.
+const signalStatusExpr = `CASE WHEN s.status = 'pending' AND s.expires_at < NOW() THEN 'expired' ELSE s.status END`
+
+// List returns the signals matching f, newest first, with the filled size
+// and last fill time of their orders. Limit defaults to 100.
+func (s *SignalStore) List(ctx context.Context, f domain.SignalFilter) ([]domain.SignalRecord, error) {
+	query := `
+		SELECT s.id, s.source, s.market_id, s.token_id, s.side, s.price_ticks, s.size_units, s.urgency,
+		       COALESCE(s.reason, ''), s.metadata, COALESCE(s.order_type, ''), s.order_expiration,
+		       s.created_at, s.expires_at,
+		       ` + signalStatusExpr + `, COALESCE(s.status_reason, ''), s.order_ids, s.resolved_at,
+		       COALESCE(o.filled, 0), o.last_fill
+		FROM ` + s.table + ` s
+		LEFT JOIN LATERAL (
+			SELECT SUM(filled_size)::DOUBLE PRECISION AS filled, MAX(filled_at) AS last_fill
+			FROM orders WHERE id = ANY(s.order_ids)
+		) o ON TRUE
+		WHERE TRUE`
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if f.Strategy != "" {
+		query += " AND s.source = " + arg(f.Strategy)
+	}
+	if f.MarketID != "" {
+		query += " AND s.market_id = " + arg(f.MarketID)
+	}
+	if f.TokenID != "" {
+		query += " AND s.token_id = " + arg(f.TokenID)
+	}
+	if f.Status != "" {
+		query += " AND " + signalStatusExpr + " = " + arg(string(f.Status))
+	}
+	if !f.From.IsZero() {
+		query += " AND s.created_at >= " + arg(f.From)
+	}
+	if !f.To.IsZero() {
+		query += " AND s.created_at < " + arg(f.To)
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query += " ORDER BY s.created_at DESC, s.id DESC LIMIT " + arg(limit)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list signal records: %w", err)
+	}
+	defer rows.Close()
+
+	var out []domain.SignalRecord
+	for rows.Next() {
+		var (
+			rec                  domain.SignalRecord
+			sig                  = &rec.Signal
+			fields               signalFields
+			status               string
+			resolvedAt, lastFill *time.Time
+		)
+		if err := rows.Scan(
+			&sig.ID, &sig.Source, &sig.MarketID, &sig.TokenID, &fields.side, &sig.PriceTicks, &sig.SizeUnits, &fields.urgency,
+			&sig.Reason, &fields.metaJSON, &fields.orderType, &fields.orderExp, &sig.CreatedAt, &fields.expiresAt,
+			&status, &rec.StatusReason, &rec.OrderIDs, &resolvedAt, &rec.FilledSize, &lastFill,
+		); err != nil {
+			return nil, fmt.Errorf("postgres: scan signal record: %w", err)
+		}
+		if err := fields.apply(sig); err != nil {
+			return nil, err
+		}
+		rec.Status = domain.SignalStatus(status)
+		if resolvedAt != nil {
+			rec.ResolvedAt = *resolvedAt
+		}
+		if lastFill != nil {
+			rec.LastFillAt = *lastFill
+		}
+		out = append(out, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list signal records rows: %w", err)
+	}
+	return out, nil
+}
+
+// Sources returns the distinct strategies with signals created at or after since.
+func (s *SignalStore) Sources(ctx context.Context, since time.Time) ([]string, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT DISTINCT source FROM `+s.table+` WHERE created_at >= $1 ORDER BY source`, since)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list signal sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []string
+	for rows.Next() {
+		var src string
+		if err := rows.Scan(&src); err != nil {
+			return nil, fmt.Errorf("postgres: scan signal source: %w", err)
+		}
+		sources = append(sources, src)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list signal sources rows: %w", err)
+	}
+	return sources, nil
+}
+
+// DeleteBefore deletes all signals created before the given time. Returns the number deleted.
+func (s *SignalStore) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM `+s.table+` WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: delete signals before: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// nullTime maps the zero time to NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
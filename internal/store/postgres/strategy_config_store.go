@@ -101,3 +101,133 @@ func (s *StrategyConfigStore) List(ctx context.Context) ([]domain.StrategyConfig
 	}
 	return configs, nil
 }
+
+// SaveVersion stores v as the next version of v.Name. Params and Overrides
+// are stored as JSONB. Saves of the same name are serialized, so concurrent
+// callers get consecutive versions.
+func (s *StrategyConfigStore) SaveVersion(ctx context.Context, v domain.StrategyConfigVersion) (domain.StrategyConfigVersion, error) {
+	params, err := json.Marshal(nonNilMap(v.Params))
+	if err != nil {
+		return v, fmt.Errorf("postgres: marshal strategy params %s: %w", v.Name, err)
+	}
+	overrides, err := json.Marshal(nonNilMap(v.Overrides))
+	if err != nil {
+		return v, fmt.Errorf("postgres: marshal strategy overrides %s: %w", v.Name, err)
+	}
+	var rollbackOf *int
+	if v.RollbackOf > 0 {
+		rollbackOf = &v.RollbackOf
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return v, fmt.Errorf("postgres: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Concurrent saves of the same name would read the same MAX(version);
+	// the transaction-scoped advisory lock serializes them per name.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, v.Name); err != nil {
+		return v, fmt.Errorf("postgres: lock strategy config versions %s: %w", v.Name, err)
+	}
+
+	const query = `
+		INSERT INTO strategy_config_versions (name, version, params, overrides, source, rollback_of)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2::jsonb, $3::jsonb, $4, $5::integer
+		FROM strategy_config_versions WHERE name = $1
+		RETURNING version, created_at`
+
+	if err := tx.QueryRow(ctx, query, v.Name, params, overrides, v.Source, rollbackOf).Scan(&v.Version, &v.CreatedAt); err != nil {
+		return v, fmt.Errorf("postgres: save strategy config version %s: %w", v.Name, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return v, fmt.Errorf("postgres: commit strategy config version %s: %w", v.Name, err)
+	}
+	return v, nil
+}
+
+// ListVersions returns up to limit versions of name, newest first.
+func (s *StrategyConfigStore) ListVersions(ctx context.Context, name string, limit int) ([]domain.StrategyConfigVersion, error) {
+	const query = `
+		SELECT name, version, params, overrides, source, rollback_of, created_at
+		FROM strategy_config_versions
+		WHERE name = $1
+		ORDER BY version DESC
+		LIMIT $2`
+
+	rows, err := s.pool.Query(ctx, query, name, limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list strategy config versions %s: %w", name, err)
+	}
+	return collectStrategyConfigVersions(rows)
+}
+
+// GetVersion retrieves one version of name.
+func (s *StrategyConfigStore) GetVersion(ctx context.Context, name string, version int) (domain.StrategyConfigVersion, error) {
+	const query = `
+		SELECT name, version, params, overrides, source, rollback_of, created_at
+		FROM strategy_config_versions
+		WHERE name = $1 AND version = $2`
+
+	rows, err := s.pool.Query(ctx, query, name, version)
+	if err != nil {
+		return domain.StrategyConfigVersion{}, fmt.Errorf("postgres: get strategy config version %s/%d: %w", name, version, err)
+	}
+	versions, err := collectStrategyConfigVersions(rows)
+	if err != nil {
+		return domain.StrategyConfigVersion{}, err
+	}
+	if len(versions) == 0 {
+		return domain.StrategyConfigVersion{}, domain.ErrNotFound
+	}
+	return versions[0], nil
+}
+
+// LatestVersions returns the newest version of every strategy that has one.
+func (s *StrategyConfigStore) LatestVersions(ctx context.Context) ([]domain.StrategyConfigVersion, error) {
+	const query = `
+		SELECT DISTINCT ON (name) name, version, params, overrides, source, rollback_of, created_at
+		FROM strategy_config_versions
+		ORDER BY name, version DESC`
+
+	rows, err := s.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: latest strategy config versions: %w", err)
+	}
+	return collectStrategyConfigVersions(rows)
+}
+
+func collectStrategyConfigVersions(rows pgx.Rows) ([]domain.StrategyConfigVersion, error) {
+	defer rows.Close()
+
+	var out []domain.StrategyConfigVersion
+	for rows.Next() {
+		var v domain.StrategyConfigVersion
+		var params, overrides []byte
+		var rollbackOf *int
+		if err := rows.Scan(&v.Name, &v.Version, &params, &overrides, &v.Source, &rollbackOf, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("postgres: scan strategy config version: %w", err)
+		}
+		if err := json.Unmarshal(params, &v.Params); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal strategy params %s/%d: %w", v.Name, v.Version, err)
+		}
+		if err := json.Unmarshal(overrides, &v.Overrides); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal strategy overrides %s/%d: %w", v.Name, v.Version, err)
+		}
+		if rollbackOf != nil {
+			v.RollbackOf = *rollbackOf
+		}
+		out = append(out, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: strategy config versions rows: %w", err)
+	}
+	return out, nil
+}
+
+func nonNilMap(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// SweepStore implements domain.SweepStore using PostgreSQL.
+type SweepStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewSweepStore creates a new SweepStore.
+func NewSweepStore(pool *pgxpool.Pool) *SweepStore {
+	return &SweepStore{pool: pool}
+}
+
+const sweepColumns = `id, from_address, to_address, amount::float8, realized_pnl::float8, tx_hash,
+	block_number, status, error, created_at, confirmed_at`
+
+// Create inserts a new sweep.
+func (s *SweepStore) Create(ctx context.Context, sw domain.Sweep) error {
+	const query = `
+		INSERT INTO profit_sweeps (id, from_address, to_address, amount, realized_pnl, tx_hash, block_number, status, error, created_at, confirmed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := s.pool.Exec(ctx, query,
+		sw.ID, sw.FromAddress, sw.ToAddress, sw.Amount, sw.RealizedPnL, sw.TxHash,
+		sw.BlockNumber, string(sw.Status), sw.Error, sw.CreatedAt, sw.ConfirmedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: create sweep %s: %w", sw.ID, err)
+	}
+	return nil
+}
+
+// Update records the transaction hash, status and outcome of a sweep.
+func (s *SweepStore) Update(ctx context.Context, sw domain.Sweep) error {
+	const query = `
+		UPDATE profit_sweeps SET
+			tx_hash = $2, block_number = $3, status = $4, error = $5, confirmed_at = $6
+		WHERE id = $1`
+	tag, err := s.pool.Exec(ctx, query,
+		sw.ID, sw.TxHash, sw.BlockNumber, string(sw.Status), sw.Error, sw.ConfirmedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("postgres: update sweep %s: %w", sw.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// LastConfirmed returns the most recently confirmed sweep.
+func (s *SweepStore) LastConfirmed(ctx context.Context) (domain.Sweep, error) {
+	query := `SELECT ` + sweepColumns + ` FROM profit_sweeps
+		WHERE status = 'confirmed' ORDER BY confirmed_at DESC LIMIT 1`
+	sw, err := scanSweep(s.pool.QueryRow(ctx, query))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Sweep{}, domain.ErrNotFound
+		}
+		return domain.Sweep{}, fmt.Errorf("postgres: last confirmed sweep: %w", err)
+	}
+	return sw, nil
+}
+
+// ListActive returns the pending and submitted sweeps sent from fromAddress,
+// oldest first.
+func (s *SweepStore) ListActive(ctx context.Context, fromAddress string) ([]domain.Sweep, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+sweepColumns+` FROM profit_sweeps
+		 WHERE from_address = $1 AND status IN ('pending', 'submitted')
+		 ORDER BY created_at`, fromAddress)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list active sweeps: %w", err)
+	}
+	return scanSweepRows(rows)
+}
+
+// List returns the most recent sweeps, newest first.
+func (s *SweepStore) List(ctx context.Context, limit int) ([]domain.Sweep, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+sweepColumns+` FROM profit_sweeps ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list sweeps: %w", err)
+	}
+	return scanSweepRows(rows)
+}
+
+func scanSweepRows(rows pgx.Rows) ([]domain.Sweep, error) {
+	defer rows.Close()
+	var list []domain.Sweep
+	for rows.Next() {
+		sw, err := scanSweep(rows)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: scan sweep: %w", err)
+		}
+		list = append(list, sw)
+	}
+	return list, rows.Err()
+}
+
+func scanSweep(row pgx.Row) (domain.Sweep, error) {
+	var sw domain.Sweep
+	var status string
+	err := row.Scan(
+		&sw.ID, &sw.FromAddress, &sw.ToAddress, &sw.Amount, &sw.RealizedPnL, &sw.TxHash,
+		&sw.BlockNumber, &status, &sw.Error, &sw.CreatedAt, &sw.ConfirmedAt,
+	)
+	if err != nil {
+		return domain.Sweep{}, err
+	}
+	sw.Status = domain.SweepStatus(status)
+	return sw, nil
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// timelineSources are the per-table selects merged into the timeline. Each
+// yields (src, id, at, kind, event, market_id, detail); kind for audit rows
+// is filled in by List from domain.TimelineAuditKinds.
+var timelineSources = []struct {
+	source string
+	kind   string // fixed kind, or "" for audit rows
+	query  string
+}{
+	{
+		source: domain.TimelineSourceSignal,
+		kind:   domain.TimelineSignal,
+		query: `SELECT 'signal'::text AS src, id, created_at AS at, 'signal'::text AS kind,
+			'signal_emitted'::text AS event, market_id,
+			jsonb_build_object('strategy', source, 'token_id', token_id, 'side', side,
+				'price_ticks', price_ticks, 'size_units', size_units, 'urgency', urgency,
+				'reason', COALESCE(reason, ''), 'metadata', metadata) AS detail
+			FROM strategy_signals`,
+	},
+	{
+		source: domain.TimelineSourceOrder,
+		kind:   domain.TimelineOrder,
+		query: `SELECT 'order'::text AS src, id, created_at AS at, 'order'::text AS kind,
+			'order_created'::text AS event, market_id,
+			jsonb_build_object('strategy', COALESCE(strategy_name, ''), 'token_id', token_id,
+				'side', side, 'order_type', order_type, 'price_ticks', price_ticks,
+				'size_units', size_units, 'filled_size', filled_size, 'status', status,
+				'exchange_id', COALESCE(exchange_order_id, '')) AS detail
+			FROM orders`,
+	},
+	{
+		source: domain.TimelineSourceAudit,
+		query: `SELECT 'audit'::text AS src, id::text AS id, created_at AS at, %s AS kind,
+			event, COALESCE(detail->>'market', detail->>'market_id', '') AS market_id,
+			COALESCE(detail, '{}'::jsonb) AS detail
+			FROM audit_log WHERE event <> 'order_placed'`,
+	},
+}
+
+// TimelineStore implements domain.TimelineStore using PostgreSQL.
+type TimelineStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewTimelineStore creates a new TimelineStore backed by the given connection pool.
+func NewTimelineStore(pool *pgxpool.Pool) *TimelineStore {
+	return &TimelineStore{pool: pool}
+}
+
+// List returns events in [q.From, q.To) after q.After, oldest first. Each
+// source is keyset-paged on its created_at index and the pages are merged,
+// so a page costs at most q.Limit rows per source.
+func (s *TimelineStore) List(ctx context.Context, q domain.TimelineQuery) ([]domain.TimelineEvent, error) {
+	args := []any{q.From, q.To}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	filter := "at >= $1 AND at < $2"
+	if q.After != nil {
+		filter += fmt.Sprintf(" AND (at, src, id) > (%s, %s, %s)",
+			arg(q.After.At), arg(q.After.Source), arg(q.After.ID))
+	}
+	var kinds string
+	if len(q.Kinds) > 0 {
+		kinds = arg(q.Kinds)
+	}
+	limit := arg(q.Limit)
+
+	var branches []string
+	for _, src := range timelineSources {
+		if src.kind != "" && len(q.Kinds) > 0 && !slices.Contains(q.Kinds, src.kind) {
+			continue
+		}
+		query := src.query
+		if src.kind == "" {
+			query = fmt.Sprintf(query, auditKindCase(arg))
+		}
+		where := filter
+		if src.kind == "" && kinds != "" {
+			where += " AND kind = ANY(" + kinds + ")"
+		}
+		branches = append(branches, fmt.Sprintf(
+			"(SELECT * FROM (%s) b WHERE %s ORDER BY at, src, id LIMIT %s)", query, where, limit))
+	}
+	if len(branches) == 0 {
+		return []domain.TimelineEvent{}, nil
+	}
+	query := fmt.Sprintf(`SELECT src, id, at, kind, event, market_id, detail FROM (%s) t
+		ORDER BY at, src, id LIMIT %s`, strings.Join(branches, " UNION ALL "), limit)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list timeline: %w", err)
+	}
+	defer rows.Close()
+
+	events := []domain.TimelineEvent{}
+	for rows.Next() {
+		var e domain.TimelineEvent
+		var detailJSON []byte
+		if err := rows.Scan(&e.Source, &e.ID, &e.At, &e.Kind, &e.Event, &e.MarketID, &detailJSON); err != nil {
+			return nil, fmt.Errorf("postgres: scan timeline event: %w", err)
+		}
+		if detailJSON != nil {
+			if err := json.Unmarshal(detailJSON, &e.Detail); err != nil {
+				return nil, fmt.Errorf("postgres: unmarshal timeline detail: %w", err)
+			}
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("postgres: list timeline rows: %w", err)
+	}
+	return events, nil
+}
+
+// auditKindCase renders a CASE expression mapping audit events to timeline
+// kinds, binding each event and kind through arg.
+func auditKindCase(arg func(any) string) string {
+	events := make([]string, 0, len(domain.TimelineAuditKinds))
+	for ev := range domain.TimelineAuditKinds {
+		events = append(events, ev)
+	}
+	sort.Strings(events)
+
+	var b strings.Builder
+	b.WriteString("CASE event")
+	for _, ev := range events {
+		fmt.Fprintf(&b, " WHEN %s::text THEN %s::text", arg(ev), arg(domain.TimelineAuditKinds[ev]))
+	}
+	fmt.Fprintf(&b, " ELSE %s::text END", arg(domain.TimelineOther))
+	return b.String()
+}
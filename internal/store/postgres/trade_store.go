@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -42,12 +44,18 @@ func scanTradeRows(rows pgx.Rows) ([]domain.Trade, error) {
 	return trades, rows.Err()
 }
 
-// InsertBatch inserts multiple trades efficiently using pgx Batch.
-// Duplicate trades (same source, source_trade_id, source_log_idx) are
-// silently skipped via ON CONFLICT DO NOTHING.
-func (s *TradeStore) InsertBatch(ctx context.Context, trades []domain.Trade) error {
+// InsertBatch inserts multiple trades efficiently using pgx Batch and
+// returns the number inserted, setting their IDs. Duplicate trades (same
+// source, source_trade_id and source_log_idx, or same tx_hash and
+// source_log_idx) are silently skipped via ON CONFLICT DO NOTHING, as are
+// fills of transactions stored before migration 039 (see legacyTxHashes).
+func (s *TradeStore) InsertBatch(ctx context.Context, trades []domain.Trade) (int64, error) {
 	if len(trades) == 0 {
-		return nil
+		return 0, nil
+	}
+	legacy, err := s.legacyTxHashes(ctx, trades)
+	if err != nil {
+		return 0, err
 	}
 
 	batch := &pgx.Batch{}
@@ -62,26 +70,137 @@ func (s *TradeStore) InsertBatch(ctx context.Context, trades []domain.Trade) err
 			$5, $6, $7, $8,
 			$9, $10,
 			$11, $12, $13, $14
-		) ON CONFLICT (source, source_trade_id, COALESCE(source_log_idx, -1)) DO NOTHING`
+		) ON CONFLICT DO NOTHING
+		RETURNING id`
 
-	for _, t := range trades {
+	queued := make([]int, 0, len(trades)) // indexes into trades
+	for i, t := range trades {
+		if legacy[t.TxHash] && t.SourceTradeID != t.TxHash {
+			continue
+		}
 		batch.Queue(query,
 			t.Source, t.SourceTradeID, t.SourceLogIdx,
 			t.Timestamp, t.MarketID, t.Maker, t.Taker,
 			t.TokenSide, t.MakerDirection, t.TakerDirection,
 			t.Price, t.USDAmount, t.TokenAmount, t.TxHash,
 		)
+		queued = append(queued, i)
+	}
+	if len(queued) == 0 {
+		return 0, nil
 	}
 
 	br := s.pool.SendBatch(ctx, batch)
 	defer br.Close()
 
-	for i := range trades {
-		if _, err := br.Exec(); err != nil {
-			return fmt.Errorf("postgres: insert trade batch item %d: %w", i, err)
+	var inserted int64
+	for _, i := range queued {
+		err := br.QueryRow().Scan(&trades[i].ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue // already stored
+		}
+		if err != nil {
+			return inserted, fmt.Errorf("postgres: insert trade batch item %d: %w", i, err)
+		}
+		inserted++
+	}
+	return inserted, nil
+}
+
+// legacyTxHashes returns the transaction hashes of trades that were stored
+// keyed by the transaction hash alone, as fills were before migration 039.
+// Those rows cannot be matched to a fill, so a transaction ingested that
+// way is not ingested again.
+func (s *TradeStore) legacyTxHashes(ctx context.Context, trades []domain.Trade) (map[string]bool, error) {
+	hashes := make([]string, 0, len(trades))
+	for _, t := range trades {
+		if t.TxHash != "" && t.SourceTradeID != t.TxHash {
+			hashes = append(hashes, t.TxHash)
+		}
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT source_trade_id FROM trades
+		WHERE source_trade_id = ANY($1) AND source_log_idx IS NULL AND tx_hash = source_trade_id`, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: find legacy trades: %w", err)
+	}
+	defer rows.Close()
+	legacy := make(map[string]bool)
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return nil, fmt.Errorf("postgres: scan legacy trade: %w", err)
+		}
+		legacy[h] = true
+	}
+	return legacy, rows.Err()
+}
+
+// tradeCopyCols are the columns written by CopyTrades, in row order.
+var tradeCopyCols = []string{
+	"source", "source_trade_id", "source_log_idx", "timestamp",
+	"market_id", "maker", "taker", "token_side",
+	"maker_direction", "taker_direction",
+	"price", "usd_amount", "token_amount", "tx_hash",
+}
+
+// CopyTrades bulk-loads trades with COPY for backfills, where InsertBatch's
+// per-row statements are too slow. Rows are copied into a temporary table and
+// moved into trades in one statement so duplicates are still skipped. It
+// returns the number of trades actually inserted.
+func (s *TradeStore) CopyTrades(ctx context.Context, trades []domain.Trade) (int64, error) {
+	if len(trades) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: copy trades: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cols := strings.Join(tradeCopyCols, ", ")
+	if _, err := tx.Exec(ctx,
+		`CREATE TEMP TABLE trades_copy ON COMMIT DROP AS SELECT `+cols+` FROM trades WITH NO DATA`); err != nil {
+		return 0, fmt.Errorf("postgres: copy trades: create staging table: %w", err)
+	}
+
+	legacy, err := s.legacyTxHashes(ctx, trades)
+	if err != nil {
+		return 0, err
+	}
+	rows := make([][]any, 0, len(trades))
+	for _, t := range trades {
+		if legacy[t.TxHash] && t.SourceTradeID != t.TxHash {
+			continue
 		}
+		rows = append(rows, []any{
+			t.Source, t.SourceTradeID, t.SourceLogIdx, t.Timestamp,
+			t.MarketID, t.Maker, t.Taker, t.TokenSide,
+			t.MakerDirection, t.TakerDirection,
+			t.Price, t.USDAmount, t.TokenAmount, t.TxHash,
+		})
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"trades_copy"}, tradeCopyCols, pgx.CopyFromRows(rows)); err != nil {
+		return 0, fmt.Errorf("postgres: copy trades: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `INSERT INTO trades (`+cols+`)
+		SELECT `+cols+` FROM trades_copy
+		ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return 0, fmt.Errorf("postgres: copy trades: merge: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("postgres: copy trades: commit: %w", err)
 	}
-	return nil
+	return tag.RowsAffected(), nil
 }
 
 // GetLastTimestamp returns the most recent trade timestamp, or the zero time
@@ -184,6 +303,28 @@ func (s *TradeStore) ListByWallet(ctx context.Context, wallet string, opts domai
 	return trades, nil
 }
 
+// ListRange returns trades with timestamp in [from, to], oldest first.
+func (s *TradeStore) ListRange(ctx context.Context, from, to time.Time, limit int) ([]domain.Trade, error) {
+	query := `SELECT ` + tradeSelectCols + ` FROM trades WHERE timestamp >= $1 AND timestamp <= $2 ORDER BY timestamp ASC, id ASC`
+	args := []any{from, to}
+	if limit > 0 {
+		query += " LIMIT $3"
+		args = append(args, limit)
+	}
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list trades in range: %w", err)
+	}
+	defer rows.Close()
+
+	trades, err := scanTradeRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: scan trades in range: %w", err)
+	}
+	return trades, nil
+}
+
 // ListBefore returns all trades with timestamp strictly before the given time (for archiving).
 func (s *TradeStore) ListBefore(ctx context.Context, before time.Time) ([]domain.Trade, error) {
 	query := `SELECT ` + tradeSelectCols + ` FROM trades WHERE timestamp < $1 ORDER BY timestamp ASC`
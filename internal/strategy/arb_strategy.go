@@ -68,3 +68,18 @@ func (a *ArbStrategy) OnSignal(_ context.Context, signal domain.TradeSignal) ([]
 
 // Close releases resources. ArbStrategy has nothing to release.
 func (a *ArbStrategy) Close() error { return nil }
+
+// Reconfigure accepts only an empty update; ArbStrategy has no tunable
+// parameters.
+func (a *ArbStrategy) Reconfigure(params map[string]any) error {
+	return newParamSet(nil).apply(nil, params)
+}
+
+// EffectiveParams returns an empty set; ArbStrategy has no tunable parameters.
+func (a *ArbStrategy) EffectiveParams() map[string]any { return map[string]any{} }
+
+func init() {
+	RegisterType(TypeSpec{Type: "arb", New: func(cfg Config, d Deps) (Strategy, error) {
+		return NewArbStrategy(cfg, d.Logger), nil
+	}})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
@@ -17,21 +18,56 @@ const (
 	defaultMinDaysToExp    = 7
 	defaultMaxPositions    = 10
 	defaultSizePerPosition = 50.0
+	defaultBondStopPrice   = 0.85
 )
 
+// bondHeldRefresh is how long the held bond positions checked for early exit
+// are cached, and bondExitRetry how long an exit signal is left to fill
+// before the position may be signalled again.
+const (
+	bondHeldRefresh = 30 * time.Second
+	bondExitRetry   = 5 * time.Minute
+)
+
+// bondParams are the parameters Reconfigure accepts.
+var bondParams = paramSpecs{
+	"min_yes_price":     {kind: paramFloat, max: 1},
+	"min_apr":           {kind: paramFloat},
+	"min_volume":        {kind: paramFloat},
+	"max_days_to_exp":   {kind: paramInt, min: 1},
+	"min_days_to_exp":   {kind: paramInt},
+	"max_positions":     {kind: paramInt, min: 1},
+	"size_per_position": {kind: paramFloat, min: 1},
+	"stop_price":        {kind: paramFloat, max: 1},
+	"min_volume_24h":    {kind: paramFloat},
+}
+
 // BondStrategy buys high-probability YES tokens and holds to resolution (bond-like).
+// With WithEarlyExit it sells a held bond before resolution once its
+// remaining yield no longer pays min_apr, or its price falls below stop_price.
+// With WithTradeAnalytics it also skips markets traded less than
+// min_volume_24h over the last day.
 type BondStrategy struct {
-	cfg     Config
-	tracker *PriceTracker
-	bonds   domain.BondPositionStore
-	markets domain.MarketStore
-	logger  *slog.Logger
+	cfg       Config
+	params    *paramSet
+	tracker   *PriceTracker
+	bonds     domain.BondPositionStore
+	markets   domain.MarketStore
+	trades    domain.TradeAnalyticsProvider // optional
+	logger    *slog.Logger
+	earlyExit bool
+
+	mu       sync.Mutex
+	held     []domain.BondPosition
+	heldAt   time.Time
+	exitedAt map[string]time.Time // bond position ID -> last exit signal
 }
 
 // NewBondStrategy creates a BondStrategy.
 func NewBondStrategy(cfg Config, tracker *PriceTracker, bonds domain.BondPositionStore, markets domain.MarketStore, logger *slog.Logger) *BondStrategy {
 	return &BondStrategy{
 		cfg:     cfg,
+		params:  newParamSet(cfg.Params),
 		tracker: tracker,
 		bonds:   bonds,
 		markets: markets,
@@ -39,6 +75,23 @@ func NewBondStrategy(cfg Config, tracker *PriceTracker, bonds domain.BondPositio
 	}
 }
 
+// WithEarlyExit makes the strategy watch the bid of its open bond positions
+// and sell them before resolution.
+func (b *BondStrategy) WithEarlyExit() *BondStrategy {
+	b.earlyExit = true
+	b.exitedAt = make(map[string]time.Time)
+	return b
+}
+
+// WithTradeAnalytics makes the strategy skip markets whose USD volume over
+// the last 24 hours is below min_volume_24h; lifetime volume (min_volume)
+// says little about whether a bond can still be bought at the quoted price.
+// Markets without analytics yet are not filtered.
+func (b *BondStrategy) WithTradeAnalytics(p domain.TradeAnalyticsProvider) *BondStrategy {
+	b.trades = p
+	return b
+}
+
 // Name returns the strategy identifier.
 func (b *BondStrategy) Name() string { return "bond" }
 
@@ -46,7 +99,15 @@ func (b *BondStrategy) Name() string { return "bond" }
 func (b *BondStrategy) Init(_ context.Context) error { return nil }
 
 // OnBookUpdate checks if the asset qualifies as a bond (high YES price, APR, volume, expiry) and emits BUY.
+// With early exit enabled, held bonds in the asset that should be sold are
+// exited instead.
 func (b *BondStrategy) OnBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
+	if b.earlyExit {
+		exits, err := b.exitSignals(ctx, snap)
+		if err != nil || len(exits) > 0 {
+			return exits, err
+		}
+	}
 	yesPrice := snap.MidPrice
 	if yesPrice <= 0 && snap.BestBid > 0 {
 		yesPrice = snap.BestBid
@@ -63,6 +124,10 @@ func (b *BondStrategy) OnBookUpdate(ctx context.Context, snap domain.OrderbookSn
 	if vol < b.minVolume() {
 		return nil, nil
 	}
+	ta, haveTA := lookupTradeAnalytics(ctx, b.trades, mkt.ID)
+	if haveTA && ta.Volume24h < b.minVolume24h() {
+		return nil, nil
+	}
 	var daysToExp float64
 	if mkt.ClosedAt != nil {
 		daysToExp = mkt.ClosedAt.Sub(time.Now().UTC()).Hours() / 24
@@ -97,17 +162,112 @@ func (b *BondStrategy) OnBookUpdate(ctx context.Context, snap domain.OrderbookSn
 		MarketID:   mkt.ID,
 		TokenID:    snap.AssetID,
 		Side:       domain.OrderSideBuy,
-		PriceTicks: int64(yesPrice * 1e6),
-		SizeUnits:  int64(size * 1e6),
+		PriceTicks: domain.ToPriceTicks(yesPrice),
+		SizeUnits:  domain.ToSizeUnits(size),
 		Urgency:    domain.SignalUrgencyMedium,
 		Reason:     fmt.Sprintf("bond apr=%.2f%% yes=%.4f days=%.0f", apr*100, yesPrice, daysToExp),
 		Metadata:   map[string]string{"expected_apr": fmt.Sprintf("%.4f", apr)},
 		CreatedAt:  now,
 		ExpiresAt:  now.Add(5 * time.Minute),
 	}
+	if haveTA {
+		sig.Metadata["volume_24h"] = fmt.Sprintf("%.2f", ta.Volume24h)
+		sig.Metadata["vwap"] = fmt.Sprintf("%.6f", ta.VWAP)
+	}
 	return []domain.TradeSignal{sig}, nil
 }
 
+// exitSignals returns a SELL at the best bid for each held bond position in
+// snap's asset whose remaining APR at that bid, to its expected expiry, is
+// below min_apr (the price reached 1.0 sooner than expected, so the capital
+// earns more elsewhere), or whose bid is below stop_price. Positions past
+// their expected expiry are held for resolution unless stopped out.
+func (b *BondStrategy) exitSignals(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
+	bid := snap.BestBid
+	if bid <= 0 {
+		return nil, nil
+	}
+	held, err := b.heldBonds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var out []domain.TradeSignal
+	for _, pos := range held {
+		if pos.TokenID != snap.AssetID || pos.Remaining() <= 0 || !b.exitDue(pos.ID, now) {
+			continue
+		}
+		var reason string
+		urgency := domain.SignalUrgencyMedium
+		remainingAPR := 0.0
+		days := pos.ExpectedExpiry.Sub(now).Hours() / 24
+		if days > 0 {
+			remainingAPR = (1.0 - bid) / bid * (365 / days)
+		}
+		switch {
+		case b.stopPrice() > 0 && bid < b.stopPrice():
+			reason = "stop_loss"
+			urgency = domain.SignalUrgencyHigh
+		case days > 0 && remainingAPR < b.minAPR():
+			reason = "yield_compressed"
+		default:
+			continue
+		}
+
+		b.mu.Lock()
+		b.exitedAt[pos.ID] = now
+		b.mu.Unlock()
+		out = append(out, domain.TradeSignal{
+			ID:         fmt.Sprintf("bond-exit-%s-%d", pos.ID, now.UnixNano()),
+			Source:     b.Name(),
+			MarketID:   pos.MarketID,
+			TokenID:    pos.TokenID,
+			Side:       domain.OrderSideSell,
+			PriceTicks: domain.ToPriceTicks(bid),
+			SizeUnits:  domain.ToSizeUnits(pos.Remaining()),
+			Urgency:    urgency,
+			Reason: fmt.Sprintf("bond exit %s bid=%.4f entry=%.4f remaining_apr=%.2f%%",
+				reason, bid, pos.EntryPrice, remainingAPR*100),
+			Metadata: map[string]string{
+				"bond_position_id": pos.ID,
+				"bond_exit":        reason,
+				"remaining_apr":    fmt.Sprintf("%.4f", remainingAPR),
+			},
+			CreatedAt: now,
+			ExpiresAt: now.Add(bondExitRetry),
+		})
+	}
+	return out, nil
+}
+
+// heldBonds returns the open bond positions, read at most once per
+// bondHeldRefresh.
+func (b *BondStrategy) heldBonds(ctx context.Context) ([]domain.BondPosition, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.held != nil && time.Since(b.heldAt) < bondHeldRefresh {
+		return b.held, nil
+	}
+	open, err := b.bonds.GetOpen(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if open == nil {
+		open = []domain.BondPosition{}
+	}
+	b.held, b.heldAt = open, time.Now()
+	return open, nil
+}
+
+// exitDue reports whether no exit signal for the position is still pending.
+func (b *BondStrategy) exitDue(posID string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	last, ok := b.exitedAt[posID]
+	return !ok || now.Sub(last) >= bondExitRetry
+}
+
 func (b *BondStrategy) OnPriceChange(_ context.Context, change domain.PriceChange) ([]domain.TradeSignal, error) {
 	b.tracker.Track(change.AssetID, change.Price, change.Timestamp)
 	return nil, nil
@@ -121,54 +281,108 @@ func (b *BondStrategy) OnSignal(_ context.Context, _ domain.TradeSignal) ([]doma
 }
 func (b *BondStrategy) Close() error { return nil }
 
+// Reconfigure validates params and applies them to the running strategy.
+func (b *BondStrategy) Reconfigure(params map[string]any) error {
+	return b.params.apply(bondParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (b *BondStrategy) EffectiveParams() map[string]any {
+	return map[string]any{
+		"min_yes_price":     b.minYesPrice(),
+		"min_apr":           b.minAPR(),
+		"min_volume":        b.minVolume(),
+		"max_days_to_exp":   b.maxDaysToExp(),
+		"min_days_to_exp":   b.minDaysToExp(),
+		"max_positions":     b.maxPositions(),
+		"size_per_position": b.sizePerPosition(),
+		"stop_price":        b.stopPrice(),
+		"min_volume_24h":    b.minVolume24h(),
+	}
+}
+
 func (b *BondStrategy) minYesPrice() float64 {
-	if v, ok := b.cfg.Params["min_yes_price"].(float64); ok {
+	if v, ok := b.params.get("min_yes_price").(float64); ok {
 		return v
 	}
 	return defaultMinYesPrice
 }
 func (b *BondStrategy) minAPR() float64 {
-	if v, ok := b.cfg.Params["min_apr"].(float64); ok {
+	if v, ok := b.params.get("min_apr").(float64); ok {
 		return v
 	}
 	return defaultMinAPR
 }
 func (b *BondStrategy) minVolume() float64 {
-	if v, ok := b.cfg.Params["min_volume"].(float64); ok {
+	if v, ok := b.params.get("min_volume").(float64); ok {
 		return v
 	}
 	return defaultMinVolume
 }
 func (b *BondStrategy) maxDaysToExp() int {
-	if v, ok := b.cfg.Params["max_days_to_exp"].(int); ok {
+	if v, ok := b.params.get("max_days_to_exp").(int); ok {
 		return v
 	}
-	if v, ok := b.cfg.Params["max_days_to_exp"].(int64); ok {
+	if v, ok := b.params.get("max_days_to_exp").(int64); ok {
 		return int(v)
 	}
 	return defaultMaxDaysToExp
 }
 func (b *BondStrategy) minDaysToExp() int {
-	if v, ok := b.cfg.Params["min_days_to_exp"].(int); ok {
+	if v, ok := b.params.get("min_days_to_exp").(int); ok {
 		return v
 	}
-	if v, ok := b.cfg.Params["min_days_to_exp"].(int64); ok {
+	if v, ok := b.params.get("min_days_to_exp").(int64); ok {
 		return int(v)
 	}
 	return defaultMinDaysToExp
 }
 func (b *BondStrategy) maxPositions() int {
-	if v, ok := b.cfg.Params["max_positions"].(int); ok {
+	if v, ok := b.params.get("max_positions").(int); ok {
 		return v
 	}
-	if v, ok := b.cfg.Params["max_positions"].(int64); ok {
+	if v, ok := b.params.get("max_positions").(int64); ok {
 		return int(v)
 	}
 	return defaultMaxPositions
 }
 func (b *BondStrategy) sizePerPosition() float64 {
-	if v, ok := b.cfg.Params["size_per_position"].(float64); ok {
+	if v, ok := b.params.get("size_per_position").(float64); ok {
 		return v
 	}
 	return defaultSizePerPosition
-}
\ No newline at end of file
+}
+func (b *BondStrategy) stopPrice() float64 {
+	if v, ok := b.params.get("stop_price").(float64); ok {
+		return v
+	}
+	return defaultBondStopPrice
+}
+func (b *BondStrategy) minVolume24h() float64 {
+	if v, ok := b.params.get("min_volume_24h").(float64); ok {
+		return v
+	}
+	return 0
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "bond", New: newBondFromDeps, Singleton: true})
+}
+
+func newBondFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(
+		dep{"bond_position_store", d.BondPositions != nil},
+		dep{"market_store", d.Markets != nil},
+	); err != nil {
+		return nil, err
+	}
+	b := NewBondStrategy(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.BondPositions, d.markets(), d.Logger)
+	if d.BondEarlyExit {
+		b.WithEarlyExit()
+	}
+	if d.TradeAnalytics != nil {
+		b.WithTradeAnalytics(d.TradeAnalytics)
+	}
+	return b, nil
+}
@@ -0,0 +1,323 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// breakerChannel is the signal bus channel breaker trips and resets are
+// published on.
+const breakerChannel = "risk"
+
+// BreakerConfig sets when a strategy's circuit breaker trips. A limit of 0
+// is off.
+type BreakerConfig struct {
+	// MaxConsecutiveFailures trips the breaker after this many failed orders
+	// in a row; a placed order resets the count.
+	MaxConsecutiveFailures int
+	// MaxLossUSD trips the breaker when realized losses within LossWindow
+	// exceed it. Gains do not offset losses.
+	MaxLossUSD float64
+	LossWindow time.Duration
+}
+
+// BreakerNotifier delivers breaker trips to operators (notify.Dispatcher). Trips
+// are sent as "error" events: re-enabling a strategy needs an operator.
+type BreakerNotifier interface {
+	Notify(ctx context.Context, event, title, message string) error
+}
+
+// breaker is one strategy's circuit breaker. Guarded by Engine.mu.
+type breaker struct {
+	consecutive int
+	losses      []realizedLoss
+	orders      int64
+	failures    int64
+	tripped     bool
+	reason      string
+	trippedAt   time.Time
+}
+
+type realizedLoss struct {
+	at  time.Time
+	usd float64 // > 0
+}
+
+// windowLoss drops losses older than window and returns the sum of the rest.
+func (b *breaker) windowLoss(now time.Time, window time.Duration) float64 {
+	keep := b.losses[:0]
+	var sum float64
+	for _, l := range b.losses {
+		if window > 0 && now.Sub(l.at) > window {
+			continue
+		}
+		keep = append(keep, l)
+		sum += l.usd
+	}
+	b.losses = keep
+	return sum
+}
+
+// SetCircuitBreakers enables per-strategy circuit breakers. Order outcomes and
+// realized PnL reach them through RecordOrderOutcome and RecordRealizedPnL
+// (or WatchOutcomes). A tripped strategy's signals are discarded until
+// ResetBreaker. audit, bus and notifier may be nil.
+func (e *Engine) SetCircuitBreakers(cfg BreakerConfig, audit domain.AuditStore, bus domain.SignalBus, notifier BreakerNotifier) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.breakerCfg = cfg
+	e.breakersOn = true
+	e.breakerAudit = audit
+	e.breakerBus = bus
+	e.breakerNotify = notifier
+	if e.breakers == nil {
+		e.breakers = make(map[string]*breaker)
+	}
+}
+
+// WatchOutcomes feeds the circuit breakers from the "orders" channel
+// (order_placed, order_failed) and the "positions" channel (position_closed)
+// until ctx is cancelled. Events without a strategy are ignored.
+func (e *Engine) WatchOutcomes(ctx context.Context, bus domain.SignalBus) error {
+	orders, err := bus.Subscribe(ctx, "orders")
+	if err != nil {
+		return fmt.Errorf("strategy: subscribe orders: %w", err)
+	}
+	positions, err := bus.Subscribe(ctx, "positions")
+	if err != nil {
+		return fmt.Errorf("strategy: subscribe positions: %w", err)
+	}
+	for {
+		var data []byte
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case data, ok = <-orders:
+		case data, ok = <-positions:
+		}
+		if !ok {
+			return nil
+		}
+		var ev struct {
+			Event       string  `json:"event"`
+			Strategy    string  `json:"strategy"`
+			ErrorClass  string  `json:"error_class"`
+			Error       string  `json:"error"`
+			RealizedPnL float64 `json:"realized_pnl"`
+		}
+		if err := json.Unmarshal(data, &ev); err != nil || ev.Strategy == "" {
+			continue
+		}
+		switch ev.Event {
+		case "order_placed":
+			e.RecordOrderOutcome(ctx, ev.Strategy, nil)
+		case "order_failed":
+			e.RecordOrderOutcome(ctx, ev.Strategy, fmt.Errorf("%s: %s", ev.ErrorClass, ev.Error))
+		case "position_closed":
+			e.RecordRealizedPnL(ctx, ev.Strategy, ev.RealizedPnL)
+		}
+	}
+}
+
+// RecordOrderOutcome counts one order from strategy: placed when err is nil,
+// failed otherwise.
+func (e *Engine) RecordOrderOutcome(ctx context.Context, strategy string, err error) {
+	e.mu.Lock()
+	if !e.breakersOn {
+		e.mu.Unlock()
+		return
+	}
+	b := e.breakerLocked(strategy)
+	b.orders++
+	if err == nil {
+		b.consecutive = 0
+		e.mu.Unlock()
+		return
+	}
+	b.failures++
+	b.consecutive++
+	var reason string
+	if max := e.breakerCfg.MaxConsecutiveFailures; max > 0 && b.consecutive >= max && !b.tripped {
+		reason = fmt.Sprintf("%d consecutive failed orders (last: %s)", b.consecutive, err)
+		e.tripLocked(b, reason)
+	}
+	e.mu.Unlock()
+	if reason != "" {
+		e.onTrip(ctx, strategy, reason)
+	}
+}
+
+// RecordRealizedPnL adds a realized PnL booking for strategy; losses count
+// toward MaxLossUSD.
+func (e *Engine) RecordRealizedPnL(ctx context.Context, strategy string, pnl float64) {
+	if pnl >= 0 {
+		return
+	}
+	now := time.Now()
+	e.mu.Lock()
+	if !e.breakersOn {
+		e.mu.Unlock()
+		return
+	}
+	b := e.breakerLocked(strategy)
+	b.losses = append(b.losses, realizedLoss{at: now, usd: -pnl})
+	loss := b.windowLoss(now, e.breakerCfg.LossWindow)
+	var reason string
+	if max := e.breakerCfg.MaxLossUSD; max > 0 && loss > max && !b.tripped {
+		reason = fmt.Sprintf("realized loss %.2f USD within %s exceeds %.2f USD",
+			loss, e.breakerCfg.LossWindow, max)
+		e.tripLocked(b, reason)
+	}
+	e.mu.Unlock()
+	if reason != "" {
+		e.onTrip(ctx, strategy, reason)
+	}
+}
+
+// breakerLocked returns strategy's breaker, creating it. Caller must hold e.mu.
+func (e *Engine) breakerLocked(strategy string) *breaker {
+	b, ok := e.breakers[strategy]
+	if !ok {
+		b = &breaker{}
+		e.breakers[strategy] = b
+	}
+	return b
+}
+
+// tripLocked opens b. Caller must hold e.mu.
+func (e *Engine) tripLocked(b *breaker, reason string) {
+	b.tripped = true
+	b.reason = reason
+	b.trippedAt = time.Now().UTC()
+}
+
+// onTrip logs, audits, publishes and notifies a breaker trip. Called without
+// e.mu held.
+func (e *Engine) onTrip(ctx context.Context, strategy, reason string) {
+	e.mu.Lock()
+	audit, bus, notifier := e.breakerAudit, e.breakerBus, e.breakerNotify
+	e.mu.Unlock()
+
+	e.logger.Warn("strategy circuit breaker tripped, strategy disabled",
+		slog.String("strategy", strategy),
+		slog.String("reason", reason),
+	)
+	if audit != nil {
+		if err := audit.Log(ctx, "strategy_breaker_tripped", map[string]any{
+			"strategy": strategy,
+			"reason":   reason,
+		}); err != nil {
+			e.logger.Warn("audit breaker trip failed", slog.String("error", err.Error()))
+		}
+	}
+	e.publishBreaker(ctx, bus, "strategy_breaker_tripped", strategy, reason)
+	if notifier != nil {
+		if err := notifier.Notify(ctx, "error",
+			"Strategy disabled: "+strategy,
+			reason+"\nRe-enable with POST /api/strategy/"+strategy+"/enable"); err != nil {
+			e.logger.Warn("notify breaker trip failed", slog.String("error", err.Error()))
+		}
+	}
+}
+
+func (e *Engine) publishBreaker(ctx context.Context, bus domain.SignalBus, event, strategy, reason string) {
+	if bus == nil {
+		return
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"event":    event,
+		"strategy": strategy,
+		"reason":   reason,
+	})
+	if err := bus.Publish(ctx, breakerChannel, payload); err != nil {
+		e.logger.Warn("publish breaker event failed", slog.String("error", err.Error()))
+	}
+}
+
+// ResetBreaker closes strategy's breaker and clears its counters so its
+// signals are emitted again. It returns domain.ErrNotFound for a strategy
+// that is not registered.
+func (e *Engine) ResetBreaker(ctx context.Context, strategy string) error {
+	if _, err := e.registry.Get(strategy); err != nil {
+		return fmt.Errorf("reset breaker %q: %w", strategy, domain.ErrNotFound)
+	}
+	e.mu.Lock()
+	b, ok := e.breakers[strategy]
+	wasTripped := ok && b.tripped
+	if ok {
+		e.breakers[strategy] = &breaker{orders: b.orders, failures: b.failures}
+	}
+	audit, bus := e.breakerAudit, e.breakerBus
+	e.mu.Unlock()
+	if !wasTripped {
+		return nil
+	}
+
+	e.logger.Info("strategy circuit breaker reset, strategy re-enabled", slog.String("strategy", strategy))
+	if audit != nil {
+		if err := audit.Log(ctx, "strategy_breaker_reset", map[string]any{"strategy": strategy}); err != nil {
+			e.logger.Warn("audit breaker reset failed", slog.String("error", err.Error()))
+		}
+	}
+	e.publishBreaker(ctx, bus, "strategy_breaker_reset", strategy, "")
+	return nil
+}
+
+// BreakerStates returns the breaker of every registered strategy, sorted by
+// name. It is empty when circuit breakers are not enabled.
+func (e *Engine) BreakerStates() []domain.StrategyBreakerState {
+	names := e.registry.List()
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.breakersOn {
+		return []domain.StrategyBreakerState{}
+	}
+	out := make([]domain.StrategyBreakerState, 0, len(names))
+	for _, name := range names {
+		st := domain.StrategyBreakerState{Strategy: name}
+		if b, ok := e.breakers[name]; ok {
+			st.Tripped = b.tripped
+			st.Reason = b.reason
+			st.ConsecutiveFailures = b.consecutive
+			st.WindowLossUSD = b.windowLoss(now, e.breakerCfg.LossWindow)
+			st.Orders = b.orders
+			st.Failures = b.failures
+			if b.tripped {
+				at := b.trippedAt
+				st.TrippedAt = &at
+			}
+		}
+		out = append(out, st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Strategy < out[j].Strategy })
+	return out
+}
+
+// dropTripped returns signals without those from strategies whose breaker
+// is tripped.
+func (e *Engine) dropTripped(signals []domain.TradeSignal) []domain.TradeSignal {
+	if len(signals) == 0 {
+		return signals
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.breakersOn {
+		return signals
+	}
+	out := signals[:0:0]
+	for _, s := range signals {
+		if b, ok := e.breakers[s.Source]; ok && b.tripped {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
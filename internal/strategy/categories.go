@@ -0,0 +1,103 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// categoryScanPages caps the store pages ListActive reads to fill a limit
+// with matching markets.
+const categoryScanPages = 20
+
+// MarketGate decides which markets strategies may trade
+// (service.MarketListService).
+type MarketGate interface {
+	Allows(m domain.Market) bool
+}
+
+// categoryMarkets is a domain.MarketStore that hides markets a filter
+// rejects, such as markets outside a strategy's categories, so a strategy
+// given it only lists, looks up and trades the markets it lets through.
+// Lookups of a hidden market return domain.ErrNotFound. Writes pass through.
+type categoryMarkets struct {
+	domain.MarketStore
+	allows func(domain.Market) bool
+}
+
+// FilterMarkets returns markets restricted to filter, or markets itself when
+// the filter is empty.
+func FilterMarkets(markets domain.MarketStore, filter domain.CategoryFilter) domain.MarketStore {
+	if markets == nil || filter.IsZero() {
+		return markets
+	}
+	return &categoryMarkets{MarketStore: markets, allows: filter.Allows}
+}
+
+// GateMarkets returns markets restricted to those gate allows, or markets
+// itself when gate is nil. The gate is asked on every read, so changes to
+// it apply at once.
+func GateMarkets(markets domain.MarketStore, gate MarketGate) domain.MarketStore {
+	if markets == nil || gate == nil {
+		return markets
+	}
+	return &categoryMarkets{MarketStore: markets, allows: gate.Allows}
+}
+
+func (c *categoryMarkets) GetByID(ctx context.Context, id string) (domain.Market, error) {
+	return c.allow(c.MarketStore.GetByID(ctx, id))
+}
+
+func (c *categoryMarkets) GetByTokenID(ctx context.Context, tokenID string) (domain.Market, error) {
+	return c.allow(c.MarketStore.GetByTokenID(ctx, tokenID))
+}
+
+func (c *categoryMarkets) GetBySlug(ctx context.Context, slug string) (domain.Market, error) {
+	return c.allow(c.MarketStore.GetBySlug(ctx, slug))
+}
+
+// ListActive returns the active markets passing the filter. Offset and Limit
+// count matching markets; the store is read a page at a time until Limit
+// are found.
+func (c *categoryMarkets) ListActive(ctx context.Context, opts domain.ListOpts) ([]domain.Market, error) {
+	page := opts
+	page.Offset = 0
+	skip := opts.Offset
+
+	var out []domain.Market
+	for i := 0; i < categoryScanPages; i++ {
+		batch, err := c.MarketStore.ListActive(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range batch {
+			if !c.allows(m) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			out = append(out, m)
+			if len(out) == opts.Limit {
+				return out, nil
+			}
+		}
+		// Without a limit the store returned every market in one read.
+		if page.Limit <= 0 || len(batch) < page.Limit {
+			break
+		}
+		page.Offset += page.Limit
+	}
+	return out, nil
+}
+
+func (c *categoryMarkets) allow(m domain.Market, err error) (domain.Market, error) {
+	if err != nil {
+		return m, err
+	}
+	if !c.allows(m) {
+		return domain.Market{}, domain.ErrNotFound
+	}
+	return m, nil
+}
@@ -18,14 +18,31 @@ const (
 	defaultComboSizePerLeg = 5.0
 )
 
+// combinatorialArbParams are the parameters Reconfigure accepts.
+var combinatorialArbParams = paramSpecs{
+	"min_edge_bps":  {kind: paramInt, min: 1, max: 10000},
+	"max_relations": {kind: paramInt, min: 1},
+	"size_per_leg":  {kind: paramFloat, min: 1},
+}
+
 // RelationComputer computes implied target prices from source group prices (used by combinatorial_arb).
 type RelationComputer interface {
 	ComputeImpliedPrices(ctx context.Context, sourceGroupID string, sourcePrices map[string]float64, targetGroupID string) (map[string]float64, error)
 }
 
+// comboOutcome is one priced outcome of a condition group. Key names it in
+// a relation's outcome_map: the market ID for a binary market (priced by its
+// Yes token) and the token ID for each outcome of a categorical market.
+type comboOutcome struct {
+	Key      string
+	MarketID string
+	TokenID  string
+}
+
 // CombinatorialArb exploits mispricing between related condition groups.
 type CombinatorialArb struct {
 	cfg        Config
+	params     *paramSet
 	tracker    *PriceTracker
 	groups     domain.ConditionGroupStore
 	relations  domain.MarketRelationStore
@@ -40,6 +57,7 @@ type CombinatorialArb struct {
 func NewCombinatorialArb(cfg Config, tracker *PriceTracker, groups domain.ConditionGroupStore, relations domain.MarketRelationStore, relSvc RelationComputer, markets domain.MarketStore, prices domain.PriceCache, logger *slog.Logger) *CombinatorialArb {
 	return &CombinatorialArb{
 		cfg:       cfg,
+		params:    newParamSet(cfg.Params),
 		tracker:   tracker,
 		groups:    groups,
 		relations: relations,
@@ -84,19 +102,14 @@ func (c *CombinatorialArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 		if len(sourceMarketIDs) == 0 || len(targetMarketIDs) == 0 {
 			continue
 		}
-		// Build source prices (market ID -> YES price) from PriceCache. We need token ID per source market.
+		// Build source prices (outcome key -> YES price) from PriceCache.
 		sourcePrices := make(map[string]float64)
-		for _, mid := range sourceMarketIDs {
-			mkt, err := c.markets.GetByID(ctx, mid)
-			if err != nil {
-				continue
-			}
-			tokenID := mkt.TokenIDs[0]
-			p, _, err := c.prices.GetPrice(ctx, tokenID)
+		for _, o := range c.outcomes(ctx, sourceMarketIDs) {
+			p, _, err := c.prices.GetPrice(ctx, o.TokenID)
 			if err != nil || p < 0 {
 				continue
 			}
-			sourcePrices[mid] = p
+			sourcePrices[o.Key] = p
 		}
 		if len(sourcePrices) == 0 {
 			continue
@@ -106,17 +119,12 @@ func (c *CombinatorialArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 			continue
 		}
 		seen++
-		for _, targetMid := range targetMarketIDs {
-			impliedPrice, ok := implied[targetMid]
+		for _, o := range c.outcomes(ctx, targetMarketIDs) {
+			impliedPrice, ok := implied[o.Key]
 			if !ok || impliedPrice <= 0 {
 				continue
 			}
-			mkt, err := c.markets.GetByID(ctx, targetMid)
-			if err != nil {
-				continue
-			}
-			yesTokenID := mkt.TokenIDs[0]
-			actualPrice, _, err := c.prices.GetPrice(ctx, yesTokenID)
+			actualPrice, _, err := c.prices.GetPrice(ctx, o.TokenID)
 			if err != nil {
 				continue
 			}
@@ -131,13 +139,13 @@ func (c *CombinatorialArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 				side = domain.OrderSideSell
 			}
 			allSignals = append(allSignals, domain.TradeSignal{
-				ID:         fmt.Sprintf("ca-%s-%d", targetMid, now.UnixNano()),
+				ID:         fmt.Sprintf("ca-%s-%d", o.Key, now.UnixNano()),
 				Source:     c.Name(),
-				MarketID:   targetMid,
-				TokenID:    yesTokenID,
+				MarketID:   o.MarketID,
+				TokenID:    o.TokenID,
 				Side:       side,
-				PriceTicks: int64(actualPrice * 1e6),
-				SizeUnits:  int64(sizePerLeg * 1e6),
+				PriceTicks: domain.ToPriceTicks(actualPrice),
+				SizeUnits:  domain.ToSizeUnits(sizePerLeg),
 				Urgency:    domain.SignalUrgencyHigh,
 				Reason:     fmt.Sprintf("combinatorial_arb deviation_bps=%.0f", deviationBps),
 				Metadata: map[string]string{
@@ -152,26 +160,48 @@ func (c *CombinatorialArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 	return allSignals, nil
 }
 
+// outcomes resolves the priced outcomes of marketIDs, skipping markets that
+// cannot be loaded.
+func (c *CombinatorialArb) outcomes(ctx context.Context, marketIDs []string) []comboOutcome {
+	var out []comboOutcome
+	for _, mid := range marketIDs {
+		mkt, err := c.markets.GetByID(ctx, mid)
+		if err != nil {
+			continue
+		}
+		if mkt.IsBinary() {
+			if tok := mkt.YesTokenID(); tok != "" {
+				out = append(out, comboOutcome{Key: mid, MarketID: mid, TokenID: tok})
+			}
+			continue
+		}
+		for _, tok := range mkt.OutcomeTokens() {
+			out = append(out, comboOutcome{Key: tok, MarketID: mid, TokenID: tok})
+		}
+	}
+	return out
+}
+
 func (c *CombinatorialArb) minEdgeBps() int {
-	if v, ok := c.cfg.Params["min_edge_bps"].(int); ok {
+	if v, ok := c.params.get("min_edge_bps").(int); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["min_edge_bps"].(int64); ok {
+	if v, ok := c.params.get("min_edge_bps").(int64); ok {
 		return int(v)
 	}
 	return defaultComboMinEdgeBps
 }
 func (c *CombinatorialArb) maxRelations() int {
-	if v, ok := c.cfg.Params["max_relations"].(int); ok {
+	if v, ok := c.params.get("max_relations").(int); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["max_relations"].(int64); ok {
+	if v, ok := c.params.get("max_relations").(int64); ok {
 		return int(v)
 	}
 	return defaultMaxRelations
 }
 func (c *CombinatorialArb) sizePerLeg() float64 {
-	if v, ok := c.cfg.Params["size_per_leg"].(float64); ok {
+	if v, ok := c.params.get("size_per_leg").(float64); ok {
 		return v
 	}
 	return defaultComboSizePerLeg
@@ -189,3 +219,34 @@ func (c *CombinatorialArb) OnSignal(_ context.Context, _ domain.TradeSignal) ([]
 	return nil, nil
 }
 func (c *CombinatorialArb) Close() error { return nil }
+
+// Reconfigure validates params and applies them to the running strategy.
+func (c *CombinatorialArb) Reconfigure(params map[string]any) error {
+	return c.params.apply(combinatorialArbParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (c *CombinatorialArb) EffectiveParams() map[string]any {
+	return map[string]any{
+		"min_edge_bps":  c.minEdgeBps(),
+		"max_relations": c.maxRelations(),
+		"size_per_leg":  c.sizePerLeg(),
+	}
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "combinatorial_arb", New: newCombinatorialArbFromDeps})
+}
+
+func newCombinatorialArbFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(
+		dep{"condition_group_store", d.ConditionGroups != nil},
+		dep{"market_relation_store", d.MarketRelations != nil},
+		dep{"market_store", d.Markets != nil},
+	); err != nil {
+		return nil, err
+	}
+	return NewCombinatorialArb(cfg, NewPriceTracker(d.Prices, 5*time.Minute),
+		d.ConditionGroups, d.MarketRelations, d.Relations, d.markets(), d.Prices, d.Logger), nil
+}
@@ -20,37 +20,44 @@ const (
 	defaultCrossCooldown   = 3
 )
 
+// crossPlatformArbParams are the parameters Reconfigure accepts.
+var crossPlatformArbParams = paramSpecs{
+	"min_edge_bps":  {kind: paramInt, min: 1, max: 10000},
+	"size_per_leg":  {kind: paramFloat, min: 1},
+	"ttl_seconds":   {kind: paramInt, min: 1},
+	"refresh_sec":   {kind: paramInt, min: 1},
+	"max_stale_sec": {kind: paramInt, min: 1},
+	"cooldown_sec":  {kind: paramInt},
+}
+
 // KalshiMarketGetter fetches a Kalshi market quote.
 type KalshiMarketGetter interface {
 	GetMarket(ctx context.Context, ticker string) (kalshi.KalshiMarket, error)
 }
 
-type kalshiQuote struct {
-	yesAsk float64
-	yesBid float64
-	noAsk  float64
-	noBid  float64
-	at     time.Time
-}
-
-// CrossPlatformArb detects Polymarket/Kalshi pricing gaps and emits the
-// Polymarket leg as executable signal.
+// CrossPlatformArb detects pricing gaps between Polymarket and other
+// prediction venues (Kalshi, plus any read-only feeds added with
+// WithVenueFeed) and emits the Polymarket leg as a signal. Signals against
+// read-only venues are tagged for manual execution.
 type CrossPlatformArb struct {
 	cfg     Config
+	params  *paramSet
 	tracker *PriceTracker
 	markets domain.MarketStore
 	books   domain.OrderbookCache
-	kalshi  KalshiMarketGetter
 	logger  *slog.Logger
 
-	marketMap map[string]string // poly market ID (or slug) -> kalshi ticker
+	feeds       []VenueFeed
+	quotes      *VenueQuoteCache
+	instruments InstrumentResolver
 
 	mu       sync.Mutex
-	quotes   map[string]kalshiQuote // ticker -> quote
-	lastEmit map[string]time.Time   // poly market ID -> last signal
+	lastEmit *expiringMap[string, time.Time] // poly market ID -> last signal
 }
 
-// NewCrossPlatformArb creates a cross-platform strategy.
+// NewCrossPlatformArb creates a cross-platform strategy. kalshiClient may be
+// nil when only read-only venues are configured; marketMap maps Polymarket
+// market IDs or slugs to Kalshi tickers.
 func NewCrossPlatformArb(
 	cfg Config,
 	tracker *PriceTracker,
@@ -61,31 +68,65 @@ func NewCrossPlatformArb(
 	logger *slog.Logger,
 ) *CrossPlatformArb {
 	cp := &CrossPlatformArb{
-		cfg:       cfg,
-		tracker:   tracker,
-		markets:   markets,
-		books:     books,
-		kalshi:    kalshiClient,
-		logger:    logger.With(slog.String("strategy", "cross_platform_arb")),
-		marketMap: make(map[string]string, len(marketMap)),
-		quotes:    make(map[string]kalshiQuote),
-		lastEmit:  make(map[string]time.Time),
-	}
-	for k, v := range marketMap {
-		cp.marketMap[k] = v
+		cfg:      cfg,
+		params:   newParamSet(cfg.Params),
+		tracker:  tracker,
+		markets:  markets,
+		books:    books,
+		logger:   logger.With(slog.String("strategy", "cross_platform_arb")),
+		quotes:   NewVenueQuoteCache(),
+		lastEmit: newExpiringMap[string, time.Time](0),
+	}
+	if kalshiClient != nil {
+		cp.WithVenueFeed(VenueFeed{
+			Venue:   domain.VenueKalshi,
+			Quoter:  kalshiQuoter{client: kalshiClient},
+			Markets: marketMap,
+		})
 	}
 	return cp
 }
 
+// WithVenueFeed adds a venue to compare against. The market map is copied.
+func (c *CrossPlatformArb) WithVenueFeed(feed VenueFeed) *CrossPlatformArb {
+	markets := make(map[string]string, len(feed.Markets))
+	for k, v := range feed.Markets {
+		markets[k] = v
+	}
+	feed.Markets = markets
+	c.feeds = append(c.feeds, feed)
+	return c
+}
+
+// WithInstruments resolves venue refs from the instrument registry for
+// markets missing from a feed's market map.
+func (c *CrossPlatformArb) WithInstruments(r InstrumentResolver) *CrossPlatformArb {
+	c.instruments = r
+	return c
+}
+
+// venueRef returns the feed's ref for mkt: the configured map first, then the
+// venue link on the market's instrument.
+func (c *CrossPlatformArb) venueRef(feed VenueFeed, mkt domain.Market, inst domain.Instrument) string {
+	if ref := feed.ref(mkt.ID, mkt.Slug); ref != "" {
+		return ref
+	}
+	if kind, ok := domain.VenueIDKind(feed.Venue); ok {
+		return inst.Ref(kind)
+	}
+	return ""
+}
+
 // Name returns the strategy identifier.
 func (c *CrossPlatformArb) Name() string { return "cross_platform_arb" }
 
 // Init is a no-op.
 func (c *CrossPlatformArb) Init(_ context.Context) error { return nil }
 
-// OnBookUpdate compares polymarket YES/NO prices against Kalshi YES/NO prices.
+// OnBookUpdate compares Polymarket YES/NO prices against each mapped venue
+// and emits the Polymarket leg of the widest gap.
 func (c *CrossPlatformArb) OnBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
-	if c.markets == nil || c.books == nil || c.kalshi == nil {
+	if c.markets == nil || c.books == nil || len(c.feeds) == 0 {
 		return nil, nil
 	}
 
@@ -93,8 +134,18 @@ func (c *CrossPlatformArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 	if err != nil {
 		return nil, nil
 	}
-	ticker := c.mapTicker(mkt.ID, mkt.Slug)
-	if ticker == "" {
+	var inst domain.Instrument
+	if c.instruments != nil {
+		inst, _ = c.instruments.Resolve(ctx, domain.IDKindMarket, mkt.ID)
+	}
+	mapped := false
+	for _, feed := range c.feeds {
+		if c.venueRef(feed, mkt, inst) != "" {
+			mapped = true
+			break
+		}
+	}
+	if !mapped {
 		return nil, nil
 	}
 
@@ -103,7 +154,10 @@ func (c *CrossPlatformArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 		return nil, nil
 	}
 
-	yesToken, noToken := mkt.TokenIDs[0], mkt.TokenIDs[1]
+	if !mkt.IsBinary() {
+		return nil, nil
+	}
+	yesToken, noToken := mkt.YesTokenID(), mkt.NoTokenID()
 	if yesToken == "" || noToken == "" {
 		return nil, nil
 	}
@@ -122,15 +176,8 @@ func (c *CrossPlatformArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 		return nil, nil
 	}
 
-	quote, err := c.getKalshiQuote(ctx, ticker, now)
-	if err != nil {
-		c.logger.DebugContext(ctx, "cross_platform_arb: kalshi quote unavailable",
-			slog.String("ticker", ticker),
-			slog.String("error", err.Error()),
-		)
-		return nil, nil
-	}
 	minEdge := float64(c.minEdgeBps()) / 10_000
+	refreshTTL := time.Duration(c.refreshSec()) * time.Second
 
 	type candidate struct {
 		tokenID string
@@ -138,61 +185,88 @@ func (c *CrossPlatformArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 		price   float64
 		edge    float64
 		reason  string
+		feed    VenueFeed
+		ref     string
 	}
 	var best candidate
 
-	// Buy Poly YES + Buy Kalshi NO.
-	if polyYesAsk > 0 && quote.noAsk > 0 {
-		edge := 1.0 - (polyYesAsk + quote.noAsk)
-		if edge > minEdge && edge > best.edge {
-			best = candidate{
-				tokenID: yesToken,
-				side:    domain.OrderSideBuy,
-				price:   polyYesAsk,
-				edge:    edge,
-				reason:  fmt.Sprintf("cross_platform_arb poly_yes+kalshi_no edge_bps=%.1f", edge*10_000),
+	for _, feed := range c.feeds {
+		ref := c.venueRef(feed, mkt, inst)
+		if ref == "" {
+			continue
+		}
+		quote, err := c.quotes.Get(ctx, feed, ref, refreshTTL, now)
+		if err != nil {
+			c.logger.DebugContext(ctx, "cross_platform_arb: venue quote unavailable",
+				slog.String("venue", feed.Venue),
+				slog.String("ref", ref),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		v := feed.Venue
+
+		// Buy Poly YES + Buy venue NO.
+		if polyYesAsk > 0 && quote.NoAsk > 0 {
+			edge := 1.0 - (polyYesAsk + quote.NoAsk)
+			if edge > minEdge && edge > best.edge {
+				best = candidate{
+					tokenID: yesToken,
+					side:    domain.OrderSideBuy,
+					price:   polyYesAsk,
+					edge:    edge,
+					reason:  fmt.Sprintf("cross_platform_arb poly_yes+%s_no edge_bps=%.1f", v, edge*10_000),
+					feed:    feed,
+					ref:     ref,
+				}
 			}
 		}
-	}
 
-	// Buy Poly NO + Buy Kalshi YES.
-	if polyNoAsk > 0 && quote.yesAsk > 0 {
-		edge := 1.0 - (polyNoAsk + quote.yesAsk)
-		if edge > minEdge && edge > best.edge {
-			best = candidate{
-				tokenID: noToken,
-				side:    domain.OrderSideBuy,
-				price:   polyNoAsk,
-				edge:    edge,
-				reason:  fmt.Sprintf("cross_platform_arb poly_no+kalshi_yes edge_bps=%.1f", edge*10_000),
+		// Buy Poly NO + Buy venue YES.
+		if polyNoAsk > 0 && quote.YesAsk > 0 {
+			edge := 1.0 - (polyNoAsk + quote.YesAsk)
+			if edge > minEdge && edge > best.edge {
+				best = candidate{
+					tokenID: noToken,
+					side:    domain.OrderSideBuy,
+					price:   polyNoAsk,
+					edge:    edge,
+					reason:  fmt.Sprintf("cross_platform_arb poly_no+%s_yes edge_bps=%.1f", v, edge*10_000),
+					feed:    feed,
+					ref:     ref,
+				}
 			}
 		}
-	}
 
-	// Sell Poly YES vs Sell Kalshi NO.
-	if polyYesBid > 0 && quote.noBid > 0 {
-		edge := (polyYesBid + quote.noBid) - 1.0
-		if edge > minEdge && edge > best.edge {
-			best = candidate{
-				tokenID: yesToken,
-				side:    domain.OrderSideSell,
-				price:   polyYesBid,
-				edge:    edge,
-				reason:  fmt.Sprintf("cross_platform_arb sell_poly_yes_vs_kalshi_no edge_bps=%.1f", edge*10_000),
+		// Sell Poly YES vs Sell venue NO.
+		if polyYesBid > 0 && quote.NoBid > 0 {
+			edge := (polyYesBid + quote.NoBid) - 1.0
+			if edge > minEdge && edge > best.edge {
+				best = candidate{
+					tokenID: yesToken,
+					side:    domain.OrderSideSell,
+					price:   polyYesBid,
+					edge:    edge,
+					reason:  fmt.Sprintf("cross_platform_arb sell_poly_yes_vs_%s_no edge_bps=%.1f", v, edge*10_000),
+					feed:    feed,
+					ref:     ref,
+				}
 			}
 		}
-	}
 
-	// Sell Poly NO vs Sell Kalshi YES.
-	if polyNoBid > 0 && quote.yesBid > 0 {
-		edge := (polyNoBid + quote.yesBid) - 1.0
-		if edge > minEdge && edge > best.edge {
-			best = candidate{
-				tokenID: noToken,
-				side:    domain.OrderSideSell,
-				price:   polyNoBid,
-				edge:    edge,
-				reason:  fmt.Sprintf("cross_platform_arb sell_poly_no_vs_kalshi_yes edge_bps=%.1f", edge*10_000),
+		// Sell Poly NO vs Sell venue YES.
+		if polyNoBid > 0 && quote.YesBid > 0 {
+			edge := (polyNoBid + quote.YesBid) - 1.0
+			if edge > minEdge && edge > best.edge {
+				best = candidate{
+					tokenID: noToken,
+					side:    domain.OrderSideSell,
+					price:   polyNoBid,
+					edge:    edge,
+					reason:  fmt.Sprintf("cross_platform_arb sell_poly_no_vs_%s_yes edge_bps=%.1f", v, edge*10_000),
+					feed:    feed,
+					ref:     ref,
+				}
 			}
 		}
 	}
@@ -204,22 +278,31 @@ func (c *CrossPlatformArb) OnBookUpdate(ctx context.Context, snap domain.Orderbo
 	c.markEmitted(mkt.ID, now)
 	ttl := time.Duration(c.ttlSeconds()) * time.Second
 	sizePerLeg := c.sizePerLeg()
+	meta := map[string]string{
+		"venue":     best.feed.Venue,
+		"venue_ref": best.ref,
+		"arb_type":  string(domain.ArbTypeCrossPlatform),
+		"edge_bps":  fmt.Sprintf("%.1f", best.edge*10_000),
+	}
+	if best.feed.Venue == domain.VenueKalshi {
+		meta["kalshi_ticker"] = best.ref
+	}
+	if best.feed.ReadOnly {
+		meta["execution"] = "manual"
+	}
 	sig := domain.TradeSignal{
 		ID:         fmt.Sprintf("cp-%s-%d", mkt.ID, now.UnixNano()),
 		Source:     c.Name(),
 		MarketID:   mkt.ID,
 		TokenID:    best.tokenID,
 		Side:       best.side,
-		PriceTicks: int64(best.price * 1e6),
-		SizeUnits:  int64(sizePerLeg * 1e6),
+		PriceTicks: domain.ToPriceTicks(best.price),
+		SizeUnits:  domain.ToSizeUnits(sizePerLeg),
 		Urgency:    domain.SignalUrgencyHigh,
 		Reason:     best.reason,
-		Metadata: map[string]string{
-			"kalshi_ticker": ticker,
-			"arb_type":      string(domain.ArbTypeCrossPlatform),
-		},
-		CreatedAt: now,
-		ExpiresAt: now.Add(ttl),
+		Metadata:   meta,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
 	}
 	return []domain.TradeSignal{sig}, nil
 }
@@ -240,45 +323,29 @@ func (c *CrossPlatformArb) OnSignal(_ context.Context, _ domain.TradeSignal) ([]
 
 func (c *CrossPlatformArb) Close() error { return nil }
 
-func (c *CrossPlatformArb) snapshotForToken(ctx context.Context, current domain.OrderbookSnapshot, tokenID string) (domain.OrderbookSnapshot, error) {
-	if current.AssetID == tokenID {
-		return current, nil
-	}
-	return c.books.GetSnapshot(ctx, tokenID)
+// Reconfigure validates params and applies them to the running strategy.
+func (c *CrossPlatformArb) Reconfigure(params map[string]any) error {
+	return c.params.apply(crossPlatformArbParams, params)
 }
 
-func (c *CrossPlatformArb) mapTicker(marketID, slug string) string {
-	if v := c.marketMap[marketID]; v != "" {
-		return v
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (c *CrossPlatformArb) EffectiveParams() map[string]any {
+	return map[string]any{
+		"min_edge_bps":  c.minEdgeBps(),
+		"size_per_leg":  c.sizePerLeg(),
+		"ttl_seconds":   c.ttlSeconds(),
+		"refresh_sec":   c.refreshSec(),
+		"max_stale_sec": c.maxStaleSec(),
+		"cooldown_sec":  c.cooldownSec(),
 	}
-	return c.marketMap[slug]
 }
 
-func (c *CrossPlatformArb) getKalshiQuote(ctx context.Context, ticker string, now time.Time) (kalshiQuote, error) {
-	c.mu.Lock()
-	cached, ok := c.quotes[ticker]
-	c.mu.Unlock()
-
-	refreshTTL := time.Duration(c.refreshSec()) * time.Second
-	if ok && now.Sub(cached.at) <= refreshTTL {
-		return cached, nil
-	}
-
-	m, err := c.kalshi.GetMarket(ctx, ticker)
-	if err != nil {
-		return kalshiQuote{}, err
-	}
-	q := kalshiQuote{
-		yesAsk: normalizeProb(m.YesAsk),
-		yesBid: normalizeProb(m.YesBid),
-		noAsk:  normalizeProb(m.NoAsk),
-		noBid:  normalizeProb(m.NoBid),
-		at:     now,
+func (c *CrossPlatformArb) snapshotForToken(ctx context.Context, current domain.OrderbookSnapshot, tokenID string) (domain.OrderbookSnapshot, error) {
+	if current.AssetID == tokenID {
+		return current, nil
 	}
-	c.mu.Lock()
-	c.quotes[ticker] = q
-	c.mu.Unlock()
-	return q, nil
+	return c.books.GetSnapshot(ctx, tokenID)
 }
 
 func normalizeProb(v float64) float64 {
@@ -295,7 +362,7 @@ func normalizeProb(v float64) float64 {
 func (c *CrossPlatformArb) recentlyEmitted(marketID string, now time.Time) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	last, ok := c.lastEmit[marketID]
+	last, ok := c.lastEmit.get(marketID, now)
 	if !ok {
 		return false
 	}
@@ -305,83 +372,133 @@ func (c *CrossPlatformArb) recentlyEmitted(marketID string, now time.Time) bool
 func (c *CrossPlatformArb) markEmitted(marketID string, now time.Time) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.lastEmit[marketID] = now
+	cooldown := time.Duration(c.cooldownSec()) * time.Second
+	c.lastEmit.set(marketID, now, now, max(cooldown, minCooldownRetention))
 }
 
 func (c *CrossPlatformArb) minEdgeBps() int {
-	if v, ok := c.cfg.Params["min_edge_bps"].(int); ok {
+	if v, ok := c.params.get("min_edge_bps").(int); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["min_edge_bps"].(int64); ok {
+	if v, ok := c.params.get("min_edge_bps").(int64); ok {
 		return int(v)
 	}
-	if v, ok := c.cfg.Params["min_edge_bps"].(float64); ok {
+	if v, ok := c.params.get("min_edge_bps").(float64); ok {
 		return int(v)
 	}
 	return defaultCrossMinEdgeBps
 }
 
 func (c *CrossPlatformArb) sizePerLeg() float64 {
-	if v, ok := c.cfg.Params["size_per_leg"].(float64); ok {
+	if v, ok := c.params.get("size_per_leg").(float64); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["size_per_leg"].(int); ok {
+	if v, ok := c.params.get("size_per_leg").(int); ok {
 		return float64(v)
 	}
-	if v, ok := c.cfg.Params["size_per_leg"].(int64); ok {
+	if v, ok := c.params.get("size_per_leg").(int64); ok {
 		return float64(v)
 	}
 	return defaultCrossSizePerLeg
 }
 
 func (c *CrossPlatformArb) ttlSeconds() int {
-	if v, ok := c.cfg.Params["ttl_seconds"].(int); ok {
+	if v, ok := c.params.get("ttl_seconds").(int); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["ttl_seconds"].(int64); ok {
+	if v, ok := c.params.get("ttl_seconds").(int64); ok {
 		return int(v)
 	}
-	if v, ok := c.cfg.Params["ttl_seconds"].(float64); ok {
+	if v, ok := c.params.get("ttl_seconds").(float64); ok {
 		return int(v)
 	}
 	return defaultCrossTTLSeconds
 }
 
 func (c *CrossPlatformArb) refreshSec() int {
-	if v, ok := c.cfg.Params["refresh_sec"].(int); ok {
+	if v, ok := c.params.get("refresh_sec").(int); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["refresh_sec"].(int64); ok {
+	if v, ok := c.params.get("refresh_sec").(int64); ok {
 		return int(v)
 	}
-	if v, ok := c.cfg.Params["refresh_sec"].(float64); ok {
+	if v, ok := c.params.get("refresh_sec").(float64); ok {
 		return int(v)
 	}
 	return defaultCrossRefreshSec
 }
 
 func (c *CrossPlatformArb) maxStaleSec() int {
-	if v, ok := c.cfg.Params["max_stale_sec"].(int); ok {
+	if v, ok := c.params.get("max_stale_sec").(int); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["max_stale_sec"].(int64); ok {
+	if v, ok := c.params.get("max_stale_sec").(int64); ok {
 		return int(v)
 	}
-	if v, ok := c.cfg.Params["max_stale_sec"].(float64); ok {
+	if v, ok := c.params.get("max_stale_sec").(float64); ok {
 		return int(v)
 	}
 	return defaultCrossMaxStale
 }
 
 func (c *CrossPlatformArb) cooldownSec() int {
-	if v, ok := c.cfg.Params["cooldown_sec"].(int); ok {
+	if v, ok := c.params.get("cooldown_sec").(int); ok {
 		return v
 	}
-	if v, ok := c.cfg.Params["cooldown_sec"].(int64); ok {
+	if v, ok := c.params.get("cooldown_sec").(int64); ok {
 		return int(v)
 	}
-	if v, ok := c.cfg.Params["cooldown_sec"].(float64); ok {
+	if v, ok := c.params.get("cooldown_sec").(float64); ok {
 		return int(v)
 	}
 	return defaultCrossCooldown
 }
+
+// ResourceUsage reports the size of in-memory state for resource metering.
+func (c *CrossPlatformArb) ResourceUsage() map[string]int {
+	mapped := 0
+	for _, feed := range c.feeds {
+		mapped += len(feed.Markets)
+	}
+	c.mu.Lock()
+	lastEmit := c.lastEmit.len()
+	c.mu.Unlock()
+	return map[string]int{
+		"market_map": mapped,
+		"quotes":     c.quotes.Len(),
+		"last_emit":  lastEmit,
+	}
+}
+
+// Evictions reports entries dropped from in-memory state by expiry or caps.
+func (c *CrossPlatformArb) Evictions() map[string]int64 {
+	c.mu.Lock()
+	lastEmit := c.lastEmit.evictions()
+	c.mu.Unlock()
+	return map[string]int64{
+		"quotes":    c.quotes.Evictions(),
+		"last_emit": lastEmit,
+	}
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "cross_platform_arb", New: newCrossPlatformArbFromDeps})
+}
+
+func newCrossPlatformArbFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(
+		dep{"market_store", d.Markets != nil},
+		dep{"book_cache", d.Books != nil},
+		dep{"venue (kalshi_client, predictit or manifold)", d.Kalshi != nil || len(d.VenueFeeds) > 0},
+	); err != nil {
+		return nil, err
+	}
+	c := NewCrossPlatformArb(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.markets(), d.Books, d.Kalshi, d.KalshiMarkets, d.Logger)
+	for _, feed := range d.VenueFeeds {
+		c.WithVenueFeed(feed)
+	}
+	if d.Instruments != nil {
+		c.WithInstruments(d.Instruments)
+	}
+	return c, nil
+}
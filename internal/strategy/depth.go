@@ -0,0 +1,206 @@
+package strategy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Depth-aware sizing for spread strategies whose legs settle to 1.0 together
+// (YES+NO, long+short horizon, every outcome of a group). Each leg is filled
+// by sweeping its book from the best level outward, and the leg size is the
+// largest one at which every book can fill and the legs' combined VWAP still
+// clears the strategy's minimum edge.
+
+// depthSearchIters bounds the bisection between two level breakpoints.
+const depthSearchIters = 32
+
+// depthLeg is one leg's fill at the chosen size.
+type depthLeg struct {
+	VWAP  float64 // effective price including slippage
+	Limit float64 // worst level touched; used as the order's limit price
+}
+
+// bookSide returns the levels a taker order on side consumes, best first:
+// asks ascending for BUY, bids descending for SELL. Empty levels are skipped.
+func bookSide(snap domain.OrderbookSnapshot, side domain.OrderSide) []domain.PriceLevel {
+	src := snap.Asks
+	if side == domain.OrderSideSell {
+		src = snap.Bids
+	}
+	levels := make([]domain.PriceLevel, 0, len(src))
+	for _, l := range src {
+		if l.Price > 0 && l.Size > 0 {
+			levels = append(levels, l)
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if side == domain.OrderSideSell {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+	return levels
+}
+
+// sweep returns the VWAP and worst price of taking size from levels. ok is
+// false when the levels hold less than size.
+func sweep(levels []domain.PriceLevel, size float64) (vwap, worst float64, ok bool) {
+	if size <= 0 {
+		return 0, 0, false
+	}
+	remaining, cost := size, 0.0
+	for _, l := range levels {
+		take := math.Min(remaining, l.Size)
+		cost += take * l.Price
+		remaining -= take
+		worst = l.Price
+		if remaining <= 1e-9 {
+			return cost / size, worst, true
+		}
+	}
+	return 0, 0, false
+}
+
+// depthSize returns the largest size up to maxSize at which every book in
+// books can fill and accept holds for the resulting per-leg VWAPs, along with
+// each leg's fill at that size. accept must be monotone: once it fails for a
+// size it fails for every larger one, which holds for any threshold on the
+// summed VWAP because each leg's VWAP worsens as size grows. The returned size
+// is rounded down to 0.01 shares; zero means no size is acceptable.
+func depthSize(books [][]domain.PriceLevel, maxSize float64, accept func(vwaps []float64) bool) (float64, []depthLeg) {
+	if len(books) == 0 || maxSize <= 0 {
+		return 0, nil
+	}
+
+	// Candidate breakpoints: cumulative depth of every level in every book,
+	// capped at the shallowest book and maxSize. Between two breakpoints no
+	// leg crosses into a new level.
+	limit := maxSize
+	var points []float64
+	for _, levels := range books {
+		depth := 0.0
+		for _, l := range levels {
+			depth += l.Size
+			points = append(points, depth)
+		}
+		limit = math.Min(limit, depth)
+	}
+	if limit <= 0 {
+		return 0, nil
+	}
+	sort.Float64s(points)
+
+	eval := func(size float64) ([]depthLeg, bool) {
+		legs := make([]depthLeg, len(books))
+		vwaps := make([]float64, len(books))
+		for i, levels := range books {
+			vwap, worst, ok := sweep(levels, size)
+			if !ok {
+				return nil, false
+			}
+			legs[i] = depthLeg{VWAP: vwap, Limit: worst}
+			vwaps[i] = vwap
+		}
+		return legs, accept(vwaps)
+	}
+
+	lo, hi := 0.0, limit
+	for _, p := range points {
+		if p > limit {
+			break
+		}
+		if _, ok := eval(p); !ok {
+			hi = p
+			break
+		}
+		lo = p
+	}
+	if lo < hi {
+		if _, ok := eval(hi); !ok {
+			for i := 0; i < depthSearchIters; i++ {
+				mid := (lo + hi) / 2
+				if _, ok := eval(mid); ok {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+		} else {
+			lo = hi
+		}
+	}
+
+	size := math.Floor(lo*100) / 100
+	if size <= 0 {
+		return 0, nil
+	}
+	legs, ok := eval(size)
+	if !ok {
+		return 0, nil
+	}
+	return size, legs
+}
+
+// sizeSpreadLegs sizes legs that trade together on side and settle to 1.0
+// in total: buying them pays off when the summed VWAP is below 1-minEdge,
+// selling when it is above 1+minEdge. The size is capped at maxSize and ok
+// is false when less than minSize clears the edge.
+//
+// When a snapshot carries no levels on side (e.g. a cached best-bid/ask-only
+// book), the legs fall back to maxSize at the top-of-book price.
+func sizeSpreadLegs(snaps []domain.OrderbookSnapshot, side domain.OrderSide, maxSize, minSize, minEdge float64) (size float64, legs []depthLeg, ok bool) {
+	books := make([][]domain.PriceLevel, len(snaps))
+	for i, s := range snaps {
+		books[i] = bookSide(s, side)
+		if len(books[i]) == 0 {
+			return topOfBookLegs(snaps, side, maxSize)
+		}
+	}
+
+	size, legs = depthSize(books, maxSize, func(vwaps []float64) bool {
+		return spreadEdge(side, vwaps) > minEdge
+	})
+	if size <= 0 || size < minSize {
+		return 0, nil, false
+	}
+	return size, legs, true
+}
+
+// topOfBookLegs prices every leg at the best level for maxSize.
+func topOfBookLegs(snaps []domain.OrderbookSnapshot, side domain.OrderSide, maxSize float64) (float64, []depthLeg, bool) {
+	legs := make([]depthLeg, len(snaps))
+	for i, s := range snaps {
+		px := bestAsk(s)
+		if side == domain.OrderSideSell {
+			px = bestBid(s)
+		}
+		if px <= 0 {
+			return 0, nil, false
+		}
+		legs[i] = depthLeg{VWAP: px, Limit: px}
+	}
+	return maxSize, legs, true
+}
+
+// spreadEdge is the per-share edge of trading legs priced at prices on side.
+func spreadEdge(side domain.OrderSide, prices []float64) float64 {
+	sum := 0.0
+	for _, p := range prices {
+		sum += p
+	}
+	if side == domain.OrderSideSell {
+		return sum - 1.0
+	}
+	return 1.0 - sum
+}
+
+// legVWAPs returns the VWAP of each leg.
+func legVWAPs(legs []depthLeg) []float64 {
+	out := make([]float64, len(legs))
+	for i, l := range legs {
+		out[i] = l.VWAP
+	}
+	return out
+}
@@ -4,12 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
-	"golang.org/x/sync/errgroup"
-
 	"github.com/alanyoungcy/polymarketbot/internal/domain"
 )
 
@@ -30,10 +29,64 @@ type Engine struct {
 	bookChs  map[string]chan domain.OrderbookSnapshot
 	priceChs map[string]chan domain.PriceChange
 	tradeChs map[string]chan domain.Trade
+	metaChs  map[string]chan domain.MarketUpdate
 	closed   bool
 
+	// sendMu serializes sends to the strategy channels (see queue.go). It is
+	// taken before mu by anything that replaces or closes the channels, so a
+	// send blocked on a full queue never holds mu and never sees a channel
+	// closed under it.
+	sendMu      sync.Mutex
+	queuePolicy domain.StrategyQueuePolicy
+
+	// runCtx is the context passed to Run/RunAll; while set, SetActiveNames
+	// starts worker goroutines immediately so the active set can change at runtime.
+	runCtx     context.Context
+	workers    sync.WaitGroup
+	workerDone map[string]chan struct{} // per-strategy; a replacement worker waits for the previous one to Close
+
 	recentSignals []domain.TradeSignal
 	recentLimit   int
+
+	// Resource metering (see resources.go).
+	eventBudget time.Duration
+	usage       map[string]*strategyUsage
+
+	// Cross-detector opportunity dedup (see opportunities.go).
+	opps      domain.OpportunityRegistry
+	oppWindow time.Duration
+
+	// legOrderType is set on leg-group signals that carry no order type.
+	legOrderType domain.OrderType
+
+	// recorder persists every emitted signal for hindsight evaluation.
+	recorder SignalRecorder
+	// observers also see every emitted signal (e.g. the heat map).
+	observers []SignalRecorder
+
+	// Shadow-mode strategies and where their signals go (see shadow.go).
+	shadow     map[string]bool
+	shadowSink SignalRecorder
+
+	// Market data feeds not currently live (see feed_gate.go).
+	staleFeeds   map[string]domain.FeedStatus
+	staleDropped int64
+
+	// stateCaches returns a strategy's private cache namespace.
+	stateCaches func(strategy string) domain.StateCache
+
+	// Per-strategy circuit breakers (see breaker.go).
+	breakersOn    bool
+	breakerCfg    BreakerConfig
+	breakers      map[string]*breaker
+	breakerAudit  domain.AuditStore
+	breakerBus    domain.SignalBus
+	breakerNotify BreakerNotifier
+}
+
+// SignalRecorder receives each emitted signal. Record must not block.
+type SignalRecorder interface {
+	Record(sig domain.TradeSignal)
 }
 
 // NewEngine creates an Engine. The signalCh is the output channel where emitted
@@ -46,6 +99,7 @@ func NewEngine(registry *Registry, signalCh chan<- domain.TradeSignal, prices do
 		tracker:     NewPriceTracker(prices, 5*time.Minute),
 		logger:      logger.With(slog.String("component", "strategy_engine")),
 		recentLimit: 500,
+		queuePolicy: defaultQueuePolicy(),
 	}
 }
 
@@ -68,6 +122,12 @@ func (e *Engine) ListNames() []string {
 	return e.registry.List()
 }
 
+// Unavailable returns known strategies that were not registered, with the
+// dependencies each one is missing.
+func (e *Engine) Unavailable() map[string][]string {
+	return e.registry.Unavailable()
+}
+
 // RecentSignals returns up to limit most recent emitted signals in reverse
 // chronological order (newest first).
 func (e *Engine) RecentSignals(limit int) []domain.TradeSignal {
@@ -99,22 +159,28 @@ func (e *Engine) RecentSignals(limit int) []domain.TradeSignal {
 }
 
 // SetActive switches the active strategy to the one registered under name (single-strategy mode).
-// It returns an error if the name is not found in the registry.
+// Any multi-strategy workers are stopped. It returns an error if the name is not found in the registry.
 func (e *Engine) SetActive(name string) error {
-	e.mu.Lock()
-	e.activeNames = nil
-	e.mu.Unlock()
 	s, err := e.registry.Get(name)
 	if err != nil {
 		return fmt.Errorf("set active strategy: %w", err)
 	}
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+	e.mu.Lock()
+	e.closeStrategyChannelsLocked()
+	e.activeNames = nil
 	e.active = s
+	e.mu.Unlock()
 	e.logger.Info("active strategy changed", slog.String("strategy", name))
 	return nil
 }
 
 // SetActiveNames enables multi-strategy mode: all listed strategies will receive
-// events when RunAll is used. Names must be registered in the registry.
+// events when RunAll is used. Names must be registered in the registry. When
+// the engine is already running, only the difference is applied: strategies
+// that stay active keep their goroutines and queues, added ones start, and
+// removed ones stop after working through the events already queued to them.
 func (e *Engine) SetActiveNames(names []string) error {
 	if len(names) == 0 {
 		return fmt.Errorf("active names cannot be empty")
@@ -124,67 +190,189 @@ func (e *Engine) SetActiveNames(names []string) error {
 			return fmt.Errorf("strategy %q: %w", name, err)
 		}
 	}
+	var uniq []string
+	for _, name := range names {
+		if !slices.Contains(uniq, name) {
+			uniq = append(uniq, name)
+		}
+	}
+	names = uniq
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	// Close existing channels if any
-	e.closeStrategyChannelsLocked()
-	e.active = nil
-	e.activeNames = names
-	buf := 32
-	e.bookChs = make(map[string]chan domain.OrderbookSnapshot, len(names))
-	e.priceChs = make(map[string]chan domain.PriceChange, len(names))
-	e.tradeChs = make(map[string]chan domain.Trade, len(names))
+	var removed []string
+	for _, name := range e.activeNames {
+		if !slices.Contains(names, name) {
+			removed = append(removed, name)
+		}
+	}
+	e.closeStrategyChannelsLocked(removed...)
+	if e.bookChs == nil {
+		e.bookChs = make(map[string]chan domain.OrderbookSnapshot, len(names))
+		e.priceChs = make(map[string]chan domain.PriceChange, len(names))
+		e.tradeChs = make(map[string]chan domain.Trade, len(names))
+		e.metaChs = make(map[string]chan domain.MarketUpdate, len(names))
+	}
+	buf := e.queuePolicy.BufferSize
+	var added []string
 	for _, name := range names {
+		if _, ok := e.bookChs[name]; ok {
+			continue
+		}
 		e.bookChs[name] = make(chan domain.OrderbookSnapshot, buf)
 		e.priceChs[name] = make(chan domain.PriceChange, buf)
 		e.tradeChs[name] = make(chan domain.Trade, buf)
+		e.metaChs[name] = make(chan domain.MarketUpdate, buf)
+		added = append(added, name)
 	}
+	e.active = nil
+	e.activeNames = names
 	e.closed = false
-	e.logger.Info("active strategies set", slog.Any("strategies", names))
+	if e.runCtx != nil {
+		e.startWorkersLocked(e.runCtx, added)
+	}
+	e.logger.Info("active strategies set",
+		slog.Any("strategies", names),
+		slog.Any("added", added),
+		slog.Any("removed", removed),
+	)
 	return nil
 }
 
-func (e *Engine) closeStrategyChannelsLocked() {
-	for _, ch := range e.bookChs {
-		close(ch)
+// ApplyActive switches the engine to the given set of strategies without a
+// restart: none idles the engine, one uses single-strategy mode, and more
+// than one uses multi-strategy mode. An engine already in multi-strategy
+// mode stays in it for a single strategy, so the one left keeps running
+// undisturbed.
+func (e *Engine) ApplyActive(names []string) error {
+	switch len(names) {
+	case 0:
+		e.ClearActive()
+		return nil
+	case 1:
+		if e.Mode() == "multi" {
+			return e.SetActiveNames(names)
+		}
+		return e.SetActive(names[0])
+	default:
+		return e.SetActiveNames(names)
 	}
-	for _, ch := range e.priceChs {
-		close(ch)
+}
+
+// Reconfigure applies params to the strategy registered under name, whether
+// or not it is active. It returns domain.ErrNotFound for an unknown name and
+// domain.ErrInvalidParams when validation fails.
+func (e *Engine) Reconfigure(name string, params map[string]any) error {
+	s, err := e.registry.Get(name)
+	if err != nil {
+		return fmt.Errorf("reconfigure strategy %q: %w", name, domain.ErrNotFound)
 	}
-	for _, ch := range e.tradeChs {
-		close(ch)
+	if err := s.Reconfigure(params); err != nil {
+		return fmt.Errorf("reconfigure strategy %q: %w", name, err)
 	}
-	e.bookChs = nil
-	e.priceChs = nil
-	e.tradeChs = nil
+	e.logger.Info("strategy reconfigured", slog.String("strategy", name), slog.Any("params", params))
+	return nil
 }
 
-// HandleBookUpdate feeds an orderbook snapshot to the active strategy (or all active when using RunAll) and emits any resulting signals.
-func (e *Engine) HandleBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) error {
+// EffectiveParams returns the effective parameters of every registered
+// strategy that implements ParamReporter, keyed by strategy name.
+func (e *Engine) EffectiveParams() map[string]map[string]any {
+	out := make(map[string]map[string]any)
+	for _, name := range e.registry.List() {
+		s, err := e.registry.Get(name)
+		if err != nil {
+			continue
+		}
+		if pr, ok := s.(ParamReporter); ok {
+			out[name] = pr.EffectiveParams()
+		}
+	}
+	return out
+}
+
+// ClearActive stops all strategies; events are dropped until a new active set is applied.
+func (e *Engine) ClearActive() {
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
 	e.mu.Lock()
-	names := e.activeNames
-	bookChs := e.bookChs
-	active := e.active
+	e.closeStrategyChannelsLocked()
+	e.activeNames = nil
+	e.active = nil
 	e.mu.Unlock()
+	e.logger.Info("all strategies disabled")
+}
 
-	if len(names) > 0 && bookChs != nil {
-		for _, name := range names {
-			if ch, ok := bookChs[name]; ok {
-				select {
-				case ch <- snap:
-				case <-ctx.Done():
-					return ctx.Err()
-				default:
-					// Buffer full, skip this update for this strategy
-				}
-			}
+// ActiveNames returns the active strategy names in either mode.
+func (e *Engine) ActiveNames() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.active != nil {
+		return []string{e.active.Name()}
+	}
+	return append([]string(nil), e.activeNames...)
+}
+
+// Mode reports "single", "multi", or "idle".
+func (e *Engine) Mode() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch {
+	case e.active != nil:
+		return "single"
+	case len(e.activeNames) > 0:
+		return "multi"
+	default:
+		return "idle"
+	}
+}
+
+// closeStrategyChannelsLocked closes the queues of the named strategies, or
+// of all when none are named, ending their workers once the queued events
+// are handled. Caller must hold e.sendMu and e.mu.
+func (e *Engine) closeStrategyChannelsLocked(names ...string) {
+	if len(names) == 0 {
+		for name := range e.bookChs {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		if ch, ok := e.bookChs[name]; ok {
+			close(ch)
+			close(e.priceChs[name])
+			close(e.tradeChs[name])
+			close(e.metaChs[name])
+			delete(e.bookChs, name)
+			delete(e.priceChs, name)
+			delete(e.tradeChs, name)
+			delete(e.metaChs, name)
 		}
+	}
+	if len(e.bookChs) == 0 {
+		e.bookChs = nil
+		e.priceChs = nil
+		e.tradeChs = nil
+		e.metaChs = nil
+	}
+}
+
+// HandleBookUpdate feeds an orderbook snapshot to the active strategy (or all active when using RunAll) and emits any resulting signals.
+func (e *Engine) HandleBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dispatch(ctx, e, queueBook, func() map[string]chan domain.OrderbookSnapshot { return e.bookChs }, snap) {
 		return nil
 	}
+	e.mu.Lock()
+	active := e.active
+	e.mu.Unlock()
 	if active == nil {
 		return fmt.Errorf("no active strategy set")
 	}
+	start := time.Now()
 	signals, err := active.OnBookUpdate(ctx, snap)
+	e.observe(active.Name(), "OnBookUpdate", start, len(signals))
 	if err != nil {
 		return fmt.Errorf("strategy %s OnBookUpdate: %w", active.Name(), err)
 	}
@@ -194,29 +382,21 @@ func (e *Engine) HandleBookUpdate(ctx context.Context, snap domain.OrderbookSnap
 
 // HandlePriceChange feeds an incremental price change to the active strategy or all.
 func (e *Engine) HandlePriceChange(ctx context.Context, change domain.PriceChange) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dispatch(ctx, e, queuePrice, func() map[string]chan domain.PriceChange { return e.priceChs }, change) {
+		return nil
+	}
 	e.mu.Lock()
-	names := e.activeNames
-	priceChs := e.priceChs
 	active := e.active
 	e.mu.Unlock()
-
-	if len(names) > 0 && priceChs != nil {
-		for _, name := range names {
-			if ch, ok := priceChs[name]; ok {
-				select {
-				case ch <- change:
-				case <-ctx.Done():
-					return ctx.Err()
-				default:
-				}
-			}
-		}
-		return nil
-	}
 	if active == nil {
 		return fmt.Errorf("no active strategy set")
 	}
+	start := time.Now()
 	signals, err := active.OnPriceChange(ctx, change)
+	e.observe(active.Name(), "OnPriceChange", start, len(signals))
 	if err != nil {
 		return fmt.Errorf("strategy %s OnPriceChange: %w", active.Name(), err)
 	}
@@ -226,29 +406,21 @@ func (e *Engine) HandlePriceChange(ctx context.Context, change domain.PriceChang
 
 // HandleTrade feeds a trade event to the active strategy or all.
 func (e *Engine) HandleTrade(ctx context.Context, trade domain.Trade) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dispatch(ctx, e, queueTrade, func() map[string]chan domain.Trade { return e.tradeChs }, trade) {
+		return nil
+	}
 	e.mu.Lock()
-	names := e.activeNames
-	tradeChs := e.tradeChs
 	active := e.active
 	e.mu.Unlock()
-
-	if len(names) > 0 && tradeChs != nil {
-		for _, name := range names {
-			if ch, ok := tradeChs[name]; ok {
-				select {
-				case ch <- trade:
-				case <-ctx.Done():
-					return ctx.Err()
-				default:
-				}
-			}
-		}
-		return nil
-	}
 	if active == nil {
 		return fmt.Errorf("no active strategy set")
 	}
+	start := time.Now()
 	signals, err := active.OnTrade(ctx, trade)
+	e.observe(active.Name(), "OnTrade", start, len(signals))
 	if err != nil {
 		return fmt.Errorf("strategy %s OnTrade: %w", active.Name(), err)
 	}
@@ -256,26 +428,55 @@ func (e *Engine) HandleTrade(ctx context.Context, trade domain.Trade) error {
 	return nil
 }
 
+// HandleMarketUpdate feeds a market metadata change to the active strategies
+// that implement MarketUpdateHandler.
+func (e *Engine) HandleMarketUpdate(ctx context.Context, update domain.MarketUpdate) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dispatch(ctx, e, queueMarket, func() map[string]chan domain.MarketUpdate { return e.metaChs }, update) {
+		return nil
+	}
+	e.mu.Lock()
+	active := e.active
+	e.mu.Unlock()
+	if active == nil {
+		return nil
+	}
+	h, ok := active.(MarketUpdateHandler)
+	if !ok {
+		return nil
+	}
+	start := time.Now()
+	signals, err := h.OnMarketUpdate(ctx, update)
+	e.observe(active.Name(), "OnMarketUpdate", start, len(signals))
+	if err != nil {
+		return fmt.Errorf("strategy %s OnMarketUpdate: %w", active.Name(), err)
+	}
+	e.emit(ctx, signals)
+	return nil
+}
+
 // runStrategy runs a single strategy in a loop, reading from its channels and emitting signals.
-func (e *Engine) runStrategy(ctx context.Context, name string) error {
+// It returns when ctx is done or the channels are closed by a change of active set.
+func (e *Engine) runStrategy(ctx context.Context, name string, bookCh <-chan domain.OrderbookSnapshot, priceCh <-chan domain.PriceChange, tradeCh <-chan domain.Trade, metaCh <-chan domain.MarketUpdate) error {
 	strat, err := e.registry.Get(name)
 	if err != nil {
 		return err
 	}
+	e.mu.Lock()
+	caches := e.stateCaches
+	e.mu.Unlock()
+	if user, ok := strat.(StateCacheUser); ok && caches != nil {
+		user.SetStateCache(caches(name))
+	}
 	if err := strat.Init(ctx); err != nil {
 		e.logger.Error("strategy init failed", slog.String("strategy", name), slog.String("error", err.Error()))
 		return err
 	}
 	defer func() { _ = strat.Close() }()
-
-	e.mu.Lock()
-	bookCh := e.bookChs[name]
-	priceCh := e.priceChs[name]
-	tradeCh := e.tradeChs[name]
-	e.mu.Unlock()
-	if bookCh == nil || priceCh == nil || tradeCh == nil {
-		return nil
-	}
+	w := strategyWorker{e: e, name: name, strat: strat}
+	w.meta, _ = strat.(MarketUpdateHandler)
 
 	for {
 		select {
@@ -283,80 +484,188 @@ func (e *Engine) runStrategy(ctx context.Context, name string) error {
 			return ctx.Err()
 		case snap, ok := <-bookCh:
 			if !ok {
-				return nil
-			}
-			signals, err := strat.OnBookUpdate(ctx, snap)
-			if err != nil {
-				e.logger.Warn("strategy OnBookUpdate error", slog.String("strategy", name), slog.String("error", err.Error()))
-				continue
+				return w.drain(ctx, bookCh, priceCh, tradeCh, metaCh)
 			}
-			e.emit(ctx, signals)
+			w.book(ctx, snap)
 		case change, ok := <-priceCh:
 			if !ok {
-				return nil
-			}
-			signals, err := strat.OnPriceChange(ctx, change)
-			if err != nil {
-				e.logger.Warn("strategy OnPriceChange error", slog.String("strategy", name), slog.String("error", err.Error()))
-				continue
+				return w.drain(ctx, bookCh, priceCh, tradeCh, metaCh)
 			}
-			e.emit(ctx, signals)
+			w.price(ctx, change)
 		case trade, ok := <-tradeCh:
 			if !ok {
-				return nil
+				return w.drain(ctx, bookCh, priceCh, tradeCh, metaCh)
 			}
-			signals, err := strat.OnTrade(ctx, trade)
-			if err != nil {
-				e.logger.Warn("strategy OnTrade error", slog.String("strategy", name), slog.String("error", err.Error()))
-				continue
+			w.trade(ctx, trade)
+		case update, ok := <-metaCh:
+			if !ok {
+				return w.drain(ctx, bookCh, priceCh, tradeCh, metaCh)
 			}
-			e.emit(ctx, signals)
+			w.market(ctx, update)
 		}
 	}
 }
 
-// Run starts the engine's main loop (single-strategy mode). It blocks until the context is cancelled.
+// strategyWorker feeds events to one strategy of multi-strategy mode.
+type strategyWorker struct {
+	e     *Engine
+	name  string
+	strat Strategy
+	meta  MarketUpdateHandler // nil when strat does not handle market updates
+}
+
+func (w strategyWorker) book(ctx context.Context, snap domain.OrderbookSnapshot) {
+	w.e.observeBookLag(w.name, snap)
+	start := time.Now()
+	signals, err := w.strat.OnBookUpdate(ctx, snap)
+	w.e.observe(w.name, "OnBookUpdate", start, len(signals))
+	w.emit(ctx, "OnBookUpdate", signals, err)
+}
+
+func (w strategyWorker) price(ctx context.Context, change domain.PriceChange) {
+	start := time.Now()
+	signals, err := w.strat.OnPriceChange(ctx, change)
+	w.e.observe(w.name, "OnPriceChange", start, len(signals))
+	w.emit(ctx, "OnPriceChange", signals, err)
+}
+
+func (w strategyWorker) trade(ctx context.Context, trade domain.Trade) {
+	start := time.Now()
+	signals, err := w.strat.OnTrade(ctx, trade)
+	w.e.observe(w.name, "OnTrade", start, len(signals))
+	w.emit(ctx, "OnTrade", signals, err)
+}
+
+func (w strategyWorker) market(ctx context.Context, update domain.MarketUpdate) {
+	if w.meta == nil {
+		return
+	}
+	start := time.Now()
+	signals, err := w.meta.OnMarketUpdate(ctx, update)
+	w.e.observe(w.name, "OnMarketUpdate", start, len(signals))
+	w.emit(ctx, "OnMarketUpdate", signals, err)
+}
+
+func (w strategyWorker) emit(ctx context.Context, method string, signals []domain.TradeSignal, err error) {
+	if err != nil {
+		w.e.logger.Warn("strategy "+method+" error", slog.String("strategy", w.name), slog.String("error", err.Error()))
+		return
+	}
+	w.e.emit(ctx, signals)
+}
+
+// drain handles the events still queued when the strategy is removed from
+// the active set, so none already accepted are lost. The queues are closed
+// together, so each range ends once its queue is empty.
+func (w strategyWorker) drain(ctx context.Context, bookCh <-chan domain.OrderbookSnapshot, priceCh <-chan domain.PriceChange, tradeCh <-chan domain.Trade, metaCh <-chan domain.MarketUpdate) error {
+	n := 0
+	for snap := range bookCh {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		w.book(ctx, snap)
+		n++
+	}
+	for change := range priceCh {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		w.price(ctx, change)
+		n++
+	}
+	for trade := range tradeCh {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		w.trade(ctx, trade)
+		n++
+	}
+	for update := range metaCh {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		w.market(ctx, update)
+		n++
+	}
+	w.e.logger.Info("strategy stopped", slog.String("strategy", w.name), slog.Int("drained", n))
+	return nil
+}
+
+// Run starts the engine's main loop. It blocks until the context is cancelled.
+// Run and RunAll are equivalent: whichever mode the active set implies is
+// used, and ApplyActive may switch between modes while running.
 func (e *Engine) Run(ctx context.Context) error {
-	e.logger.Info("strategy engine started")
-	defer e.logger.Info("strategy engine stopped")
-	<-ctx.Done()
-	return ctx.Err()
+	return e.RunAll(ctx)
 }
 
-// RunAll starts one goroutine per enabled strategy. Each strategy receives events via its channels and emits to the shared signalCh. Blocks until context is cancelled.
+// RunAll starts one goroutine per active strategy in multi-strategy mode. Each strategy receives
+// events via its channels and emits to the shared signalCh. Later SetActiveNames calls start
+// workers for the new set. Blocks until context is cancelled.
 func (e *Engine) RunAll(ctx context.Context) error {
 	e.mu.Lock()
-	names := make([]string, len(e.activeNames))
-	copy(names, e.activeNames)
+	e.runCtx = ctx
+	e.startWorkersLocked(ctx, e.activeNames)
+	names := append([]string(nil), e.activeNames...)
 	e.mu.Unlock()
-	if len(names) == 0 {
-		e.logger.Info("RunAll: no active strategies, blocking until context done")
-		<-ctx.Done()
-		return ctx.Err()
-	}
 
-	e.logger.Info("strategy engine RunAll started", slog.Any("strategies", names))
-	defer func() {
-		e.mu.Lock()
-		e.closeStrategyChannelsLocked()
-		e.closed = true
-		e.mu.Unlock()
-		e.logger.Info("strategy engine RunAll stopped")
-	}()
+	e.logger.Info("strategy engine started", slog.Any("strategies", names))
+	defer e.logger.Info("strategy engine stopped")
 
-	g, gctx := errgroup.WithContext(ctx)
+	<-ctx.Done()
+	e.sendMu.Lock()
+	e.mu.Lock()
+	e.runCtx = nil
+	e.closeStrategyChannelsLocked()
+	e.closed = true
+	e.mu.Unlock()
+	e.sendMu.Unlock()
+	e.workers.Wait()
+	return ctx.Err()
+}
+
+// startWorkersLocked starts a runStrategy goroutine for each of names,
+// bound to its current channels. Caller must hold e.mu.
+func (e *Engine) startWorkersLocked(ctx context.Context, names []string) {
 	for _, name := range names {
 		name := name
-		g.Go(func() error {
-			return e.runStrategy(gctx, name)
-		})
+		bookCh, priceCh, tradeCh, metaCh := e.bookChs[name], e.priceChs[name], e.tradeChs[name], e.metaChs[name]
+		if bookCh == nil || priceCh == nil || tradeCh == nil || metaCh == nil {
+			continue
+		}
+		if e.workerDone == nil {
+			e.workerDone = make(map[string]chan struct{})
+		}
+		prev := e.workerDone[name]
+		done := make(chan struct{})
+		e.workerDone[name] = done
+		e.workers.Add(1)
+		go func() {
+			defer e.workers.Done()
+			defer close(done)
+			if prev != nil {
+				<-prev
+			}
+			if err := e.runStrategy(ctx, name, bookCh, priceCh, tradeCh, metaCh); err != nil && ctx.Err() == nil {
+				e.logger.Error("strategy worker exited", slog.String("strategy", name), slog.String("error", err.Error()))
+			}
+		}()
 	}
-	return g.Wait()
 }
 
-// emit sends each signal to the signal channel. It respects context cancellation.
+// emit sends each signal to the signal channel, skipping arbitrage groups
+// already claimed by another source. Signals of shadow-mode strategies are
+// diverted instead. Nothing is sent while a market data feed is in a gap.
+// It respects context cancellation.
 func (e *Engine) emit(ctx context.Context, signals []domain.TradeSignal) {
+	if e.dropWhileStale(signals) {
+		return
+	}
+	signals = e.dropTripped(signals)
+	signals = e.divertShadow(signals)
+	signals = e.claimOpportunities(ctx, signals)
+	e.applyLegOrderType(signals)
 	for i := range signals {
+		signals[i].EmittedAt = time.Now().UTC()
 		select {
 		case <-ctx.Done():
 			e.logger.Warn("context cancelled while emitting signals",
@@ -374,9 +683,63 @@ func (e *Engine) emit(ctx context.Context, signals []domain.TradeSignal) {
 	}
 }
 
+// SetStateCaches gives each strategy implementing StateCacheUser its own
+// cache namespace, from caches(name), before Init.
+func (e *Engine) SetStateCaches(caches func(strategy string) domain.StateCache) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stateCaches = caches
+}
+
+// SetLegOrderType sets the order type used for the legs of multi-leg
+// arbitrage signals (those with a "leg_group_id") that do not set their own,
+// e.g. FOK so a leg either fills completely or not at all. Empty leaves such
+// signals as GTC.
+func (e *Engine) SetLegOrderType(t domain.OrderType) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.legOrderType = t
+}
+
+func (e *Engine) applyLegOrderType(signals []domain.TradeSignal) {
+	e.mu.Lock()
+	t := e.legOrderType
+	e.mu.Unlock()
+	if t == "" {
+		return
+	}
+	for i := range signals {
+		if signals[i].OrderType == "" && signals[i].Metadata["leg_group_id"] != "" {
+			signals[i].OrderType = t
+		}
+	}
+}
+
+// SetSignalRecorder sets where emitted signals are recorded; nil disables
+// recording.
+func (e *Engine) SetSignalRecorder(r SignalRecorder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.recorder = r
+}
+
+// AddSignalObserver registers o to be handed every emitted signal alongside
+// the recorder. Record is called with the engine locked and must not block.
+func (e *Engine) AddSignalObserver(o SignalRecorder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.observers = append(e.observers, o)
+}
+
 func (e *Engine) rememberSignal(sig domain.TradeSignal) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
+	if e.recorder != nil {
+		e.recorder.Record(sig)
+	}
+	for _, o := range e.observers {
+		o.Record(sig)
+	}
 	e.recentSignals = append(e.recentSignals, sig)
 	if overflow := len(e.recentSignals) - e.recentLimit; overflow > 0 {
 		e.recentSignals = append([]domain.TradeSignal(nil), e.recentSignals[overflow:]...)
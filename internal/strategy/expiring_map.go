@@ -0,0 +1,107 @@
+package strategy
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// expiringSweepInterval is the minimum time between expiry sweeps.
+	expiringSweepInterval = time.Minute
+	// defaultMaxEntries caps strategy-internal maps keyed by market, pair or
+	// venue ref; far above what a healthy run holds at once.
+	defaultMaxEntries = 10_000
+	// minCooldownRetention keeps cooldown timestamps at least this long, so a
+	// cooldown raised by Reconfigure shortly after a signal still applies.
+	minCooldownRetention = time.Minute
+)
+
+type expiringEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// expiringMap is a map for strategy-internal state (cooldowns, quote caches)
+// whose keys arrive from the market stream and would otherwise accumulate for
+// the life of the process. Each entry expires ttl after it was set, expired
+// entries are swept on writes at most once per expiringSweepInterval, and when
+// the map exceeds maxEntries the entries closest to expiry are evicted first.
+// It is not safe for concurrent use; owners guard it with their own mutex.
+type expiringMap[K comparable, V any] struct {
+	maxEntries int
+	entries    map[K]expiringEntry[V]
+	lastSweep  time.Time
+	evicted    int64
+}
+
+// newExpiringMap creates an expiringMap holding at most maxEntries entries.
+// maxEntries <= 0 uses defaultMaxEntries.
+func newExpiringMap[K comparable, V any](maxEntries int) *expiringMap[K, V] {
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxEntries
+	}
+	return &expiringMap[K, V]{
+		maxEntries: maxEntries,
+		entries:    make(map[K]expiringEntry[V]),
+	}
+}
+
+// get returns the value for k unless it is missing or expired at now.
+func (m *expiringMap[K, V]) get(k K, now time.Time) (V, bool) {
+	e, ok := m.entries[k]
+	if !ok || !now.Before(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// set stores v for k until now+ttl, sweeping and enforcing the cap.
+func (m *expiringMap[K, V]) set(k K, v V, now time.Time, ttl time.Duration) {
+	m.entries[k] = expiringEntry[V]{value: v, expiresAt: now.Add(ttl)}
+	if now.Sub(m.lastSweep) >= expiringSweepInterval {
+		m.sweep(now)
+	}
+	if len(m.entries) > m.maxEntries {
+		m.shrink()
+	}
+}
+
+// sweep deletes entries expired at now.
+func (m *expiringMap[K, V]) sweep(now time.Time) {
+	m.lastSweep = now
+	for k, e := range m.entries {
+		if !now.Before(e.expiresAt) {
+			delete(m.entries, k)
+			m.evicted++
+		}
+	}
+}
+
+// shrink evicts the entries closest to expiry until the map is at 90% of its
+// cap, so a map at the cap does not pay for a sort on every write.
+func (m *expiringMap[K, V]) shrink() {
+	keys := make([]K, 0, len(m.entries))
+	for k := range m.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return m.entries[keys[i]].expiresAt.Before(m.entries[keys[j]].expiresAt)
+	})
+	target := m.maxEntries * 9 / 10
+	for _, k := range keys[:len(keys)-target] {
+		delete(m.entries, k)
+		m.evicted++
+	}
+}
+
+// len returns the number of entries held, including expired ones not yet
+// swept.
+func (m *expiringMap[K, V]) len() int {
+	return len(m.entries)
+}
+
+// evictions returns the number of entries removed by expiry or the cap.
+func (m *expiringMap[K, V]) evictions() int64 {
+	return m.evicted
+}
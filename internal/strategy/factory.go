@@ -0,0 +1,170 @@
+package strategy
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Deps are the shared dependencies strategy factories draw on. Any field may
+// be nil; a factory reports the ones it requires that are missing with a
+// *MissingDepsError. Optional providers are attached when set.
+type Deps struct {
+	Prices          domain.PriceCache
+	Books           domain.OrderbookCache
+	Markets         domain.MarketStore // scoped per instance by Categories
+	ConditionGroups domain.ConditionGroupStore
+	MarketRelations domain.MarketRelationStore
+	BondPositions   domain.BondPositionStore
+	ReferencePrices domain.ReferencePriceProvider
+
+	Relations      RelationComputer
+	Rewards        RewardsTracker
+	CancelRatio    CancelRatioReader
+	Inventory      InventoryReader
+	Features       domain.FeatureProvider
+	TradeAnalytics domain.TradeAnalyticsProvider
+	Toxicity       domain.ToxicityProvider
+
+	// Categories limits each strategy's markets, keyed by instance name
+	// and, as a fallback, by type.
+	Categories map[string]domain.CategoryFilter
+
+	// Type-level settings that are not tunable params.
+	BondEarlyExit bool
+	Kalshi        KalshiMarketGetter
+	KalshiMarkets map[string]string // Polymarket market ID or slug -> Kalshi ticker
+	VenueFeeds    []VenueFeed       // read-only venues for cross_platform_arb
+	Instruments   InstrumentResolver
+	Logger        *slog.Logger
+
+	instance, typ string // set by Build
+}
+
+// markets returns the market store scoped to the instance's categories.
+func (d Deps) markets() domain.MarketStore {
+	f, ok := d.Categories[d.instance]
+	if !ok {
+		f = d.Categories[d.typ]
+	}
+	return FilterMarkets(d.Markets, f)
+}
+
+// dep is one dependency a factory requires.
+type dep struct {
+	name string
+	ok   bool
+}
+
+// requireDeps returns a *MissingDepsError naming the deps that are not ok,
+// or nil.
+func requireDeps(deps ...dep) error {
+	var missing []string
+	for _, d := range deps {
+		if !d.ok {
+			missing = append(missing, d.name)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingDepsError{Missing: missing}
+	}
+	return nil
+}
+
+// MissingDepsError is returned by Build when dependencies the strategy type
+// requires are not configured.
+type MissingDepsError struct {
+	Missing []string
+}
+
+func (e *MissingDepsError) Error() string {
+	return "missing dependencies: " + strings.Join(e.Missing, ", ")
+}
+
+// Factory builds a strategy of one type from its config and the shared
+// dependencies.
+type Factory func(cfg Config, d Deps) (Strategy, error)
+
+// TypeSpec describes a strategy type Build can instantiate.
+type TypeSpec struct {
+	Type string
+	New  Factory
+	// Singleton types keep state keyed by their type name outside the
+	// strategy (bond positions, LP quote sets and rewards), so only one
+	// instance, named after the type, may run.
+	Singleton bool
+}
+
+var (
+	typesMu sync.RWMutex
+	types   = make(map[string]TypeSpec)
+)
+
+// RegisterType makes a strategy type available to Build. Strategy files call
+// it from init(); it panics on an empty or duplicate type.
+func RegisterType(spec TypeSpec) {
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	if spec.Type == "" || spec.New == nil {
+		panic("strategy: RegisterType needs a type and a factory")
+	}
+	if _, dup := types[spec.Type]; dup {
+		panic("strategy: RegisterType called twice for " + spec.Type)
+	}
+	types[spec.Type] = spec
+}
+
+// Types returns the registered strategy types in sorted order.
+func Types() []string {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	out := make([]string, 0, len(types))
+	for t := range types {
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Build creates a strategy of type typ named name. An instance named after
+// its type is the type's default one; any other name runs the strategy
+// under that name (see instance). It returns a *MissingDepsError when d
+// lacks what the type requires.
+func Build(typ, name string, cfg Config, d Deps) (Strategy, error) {
+	typesMu.RLock()
+	spec, ok := types[typ]
+	typesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("strategy: unknown type %q", typ)
+	}
+	if name == "" {
+		name = typ
+	}
+	if name != typ {
+		if spec.Singleton {
+			return nil, fmt.Errorf("strategy: %s runs as a single instance and cannot be instantiated as %q", typ, name)
+		}
+		if _, taken := types[name]; taken {
+			return nil, fmt.Errorf("strategy: instance name %q is a strategy type", name)
+		}
+	}
+	if d.Logger == nil {
+		d.Logger = slog.Default()
+	}
+	d.instance, d.typ = name, typ
+	if name != typ {
+		d.Logger = d.Logger.With(slog.String("instance", name))
+	}
+	s, err := spec.New(cfg, d)
+	if err != nil {
+		return nil, err
+	}
+	if name != typ {
+		s = &instance{Strategy: s, name: name, typ: typ}
+	}
+	return s, nil
+}
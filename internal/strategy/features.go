@@ -0,0 +1,55 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// lookupFeatures returns the features of assetID from p, and false when p is
+// nil or has none for the asset.
+func lookupFeatures(ctx context.Context, p domain.FeatureProvider, assetID string) (domain.MarketFeatures, bool) {
+	if p == nil {
+		return domain.MarketFeatures{}, false
+	}
+	f, err := p.Features(ctx, assetID)
+	if err != nil {
+		return domain.MarketFeatures{}, false
+	}
+	return f, true
+}
+
+// addFeatureMetadata records the features a signal was emitted on.
+func addFeatureMetadata(meta map[string]string, f domain.MarketFeatures) {
+	meta["microprice"] = fmt.Sprintf("%.6f", f.Microprice)
+	meta["depth_imbalance"] = fmt.Sprintf("%.4f", f.DepthImbalance)
+	meta["trade_flow_imbalance"] = fmt.Sprintf("%.4f", f.TradeFlowImbalance)
+	meta["realized_vol"] = fmt.Sprintf("%.6f", f.RealizedVol)
+}
+
+// lookupTradeAnalytics returns the trade analytics of marketID from p, and
+// false when p is nil or has none for the market.
+func lookupTradeAnalytics(ctx context.Context, p domain.TradeAnalyticsProvider, marketID string) (domain.MarketTradeAnalytics, bool) {
+	if p == nil || marketID == "" {
+		return domain.MarketTradeAnalytics{}, false
+	}
+	a, err := p.TradeAnalytics(ctx, marketID)
+	if err != nil {
+		return domain.MarketTradeAnalytics{}, false
+	}
+	return a, true
+}
+
+// lookupToxicity returns the order flow toxicity of assetID from p, and
+// false when p is nil or the score is not warm yet.
+func lookupToxicity(ctx context.Context, p domain.ToxicityProvider, assetID string) (domain.AssetToxicity, bool) {
+	if p == nil {
+		return domain.AssetToxicity{}, false
+	}
+	t, err := p.Toxicity(ctx, assetID)
+	if err != nil || !t.Warm {
+		return domain.AssetToxicity{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,59 @@
+package strategy
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// HandleFeedStatus records a market data feed connection state change. While
+// any feed is degraded or reconnecting, cached books may be arbitrarily old,
+// so signals computed from them are dropped instead of emitted.
+func (e *Engine) HandleFeedStatus(_ context.Context, status domain.FeedStatus) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if status.State.Live() {
+		if _, ok := e.staleFeeds[status.Feed]; ok {
+			delete(e.staleFeeds, status.Feed)
+			e.logger.Info("feed live again, emitting signals",
+				slog.String("feed", status.Feed),
+				slog.Duration("gap", status.LastGap),
+				slog.Int64("dropped", e.staleDropped),
+			)
+			e.staleDropped = 0
+		}
+		return nil
+	}
+	if e.staleFeeds == nil {
+		e.staleFeeds = make(map[string]domain.FeedStatus)
+	}
+	e.staleFeeds[status.Feed] = status
+	return nil
+}
+
+// StaleFeeds returns the feeds currently in a gap, keyed by feed name.
+func (e *Engine) StaleFeeds() map[string]domain.FeedStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make(map[string]domain.FeedStatus, len(e.staleFeeds))
+	for k, v := range e.staleFeeds {
+		out[k] = v
+	}
+	return out
+}
+
+// dropWhileStale reports whether signals must be discarded because a feed
+// is in a gap, counting them for the recovery log.
+func (e *Engine) dropWhileStale(signals []domain.TradeSignal) bool {
+	if len(signals) == 0 {
+		return false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.staleFeeds) == 0 {
+		return false
+	}
+	e.staleDropped += int64(len(signals))
+	return true
+}
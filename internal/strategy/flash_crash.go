@@ -10,17 +10,27 @@ import (
 )
 
 const (
-	defaultDropThreshold  = 0.10
-	defaultRecoveryTarget = 0.05
+	defaultDropThreshold     = 0.10
+	defaultRecoveryTarget    = 0.05
+	defaultMinDepthImbalance = -0.5
 )
 
+// flashCrashParams are the parameters Reconfigure accepts.
+var flashCrashParams = paramSpecs{
+	"drop_threshold":      {kind: paramFloat, min: 0.001, max: 1},
+	"recovery_target":     {kind: paramFloat, max: 1},
+	"min_depth_imbalance": {kind: paramFloat, min: -1, max: 1},
+}
+
 // FlashCrash implements a strategy that emits BUY signals when the price of an
 // asset drops sharply relative to its recent average. The idea is to capture
 // transient liquidity dislocations where the price is expected to recover.
 type FlashCrash struct {
-	cfg     Config
-	tracker *PriceTracker
-	logger  *slog.Logger
+	cfg      Config
+	params   *paramSet
+	tracker  *PriceTracker
+	features domain.FeatureProvider // optional
+	logger   *slog.Logger
 }
 
 // NewFlashCrash creates a FlashCrash strategy with the supplied configuration
@@ -30,14 +40,26 @@ type FlashCrash struct {
 //     Defaults to 0.10 (10 %).
 //   - "recovery_target" (float64): expected fractional recovery used to set the
 //     signal price above the crash level. Defaults to 0.05 (5 %).
+//   - "min_depth_imbalance" (float64): with features set, the lowest top-N
+//     depth imbalance at which a signal is emitted. Defaults to -0.5.
 func NewFlashCrash(cfg Config, tracker *PriceTracker, logger *slog.Logger) *FlashCrash {
 	return &FlashCrash{
 		cfg:     cfg,
+		params:  newParamSet(cfg.Params),
 		tracker: tracker,
 		logger:  logger.With(slog.String("strategy", "flash_crash")),
 	}
 }
 
+// WithFeatures makes the strategy hold off while the crash is still running:
+// no signal is emitted while the top-of-book depth imbalance is below
+// min_depth_imbalance (asks outweigh bids). Signals carry the features they
+// were emitted on.
+func (fc *FlashCrash) WithFeatures(p domain.FeatureProvider) *FlashCrash {
+	fc.features = p
+	return fc
+}
+
 // Name returns the strategy identifier.
 func (fc *FlashCrash) Name() string { return "flash_crash" }
 
@@ -47,8 +69,6 @@ func (fc *FlashCrash) Init(_ context.Context) error { return nil }
 // OnBookUpdate evaluates the latest orderbook snapshot for a flash crash
 // condition and returns a BUY signal if the threshold has been breached.
 func (fc *FlashCrash) OnBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
-	_ = ctx
-
 	assetID := snap.AssetID
 	bestBid := snap.BestBid
 
@@ -60,6 +80,15 @@ func (fc *FlashCrash) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 		return nil, nil
 	}
 
+	feats, haveFeats := lookupFeatures(ctx, fc.features, assetID)
+	if haveFeats && feats.DepthImbalance < fc.minDepthImbalance() {
+		fc.logger.Debug("flash crash held off: book still ask-heavy",
+			slog.String("asset", assetID),
+			slog.Float64("depth_imbalance", feats.DepthImbalance),
+		)
+		return nil, nil
+	}
+
 	avg := fc.tracker.GetAverage(assetID)
 	recovery := fc.recoveryTarget()
 
@@ -67,8 +96,8 @@ func (fc *FlashCrash) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 	// average, governed by the recovery target.
 	targetPrice := bestBid + (avg-bestBid)*recovery
 
-	priceTicks := int64(targetPrice * 1e6)
-	sizeUnits := int64(fc.cfg.Size * 1e6)
+	priceTicks := domain.ToPriceTicks(targetPrice)
+	sizeUnits := domain.ToSizeUnits(fc.cfg.Size)
 
 	now := time.Now().UTC()
 	sig := domain.TradeSignal{
@@ -89,6 +118,9 @@ func (fc *FlashCrash) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 		CreatedAt: now,
 		ExpiresAt: now.Add(30 * time.Second),
 	}
+	if haveFeats {
+		addFeatureMetadata(sig.Metadata, feats)
+	}
 
 	fc.logger.Info("flash crash signal emitted",
 		slog.String("asset", assetID),
@@ -121,9 +153,24 @@ func (fc *FlashCrash) OnSignal(_ context.Context, _ domain.TradeSignal) ([]domai
 // Close releases resources. FlashCrash has nothing to release.
 func (fc *FlashCrash) Close() error { return nil }
 
+// Reconfigure validates params and applies them to the running strategy.
+func (fc *FlashCrash) Reconfigure(params map[string]any) error {
+	return fc.params.apply(flashCrashParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (fc *FlashCrash) EffectiveParams() map[string]any {
+	return map[string]any{
+		"drop_threshold":      fc.dropThreshold(),
+		"recovery_target":     fc.recoveryTarget(),
+		"min_depth_imbalance": fc.minDepthImbalance(),
+	}
+}
+
 // dropThreshold returns the configured drop threshold or the default.
 func (fc *FlashCrash) dropThreshold() float64 {
-	if v, ok := fc.cfg.Params["drop_threshold"]; ok {
+	if v, ok := fc.params.lookup("drop_threshold"); ok {
 		if f, ok := v.(float64); ok {
 			return f
 		}
@@ -133,10 +180,33 @@ func (fc *FlashCrash) dropThreshold() float64 {
 
 // recoveryTarget returns the configured recovery target or the default.
 func (fc *FlashCrash) recoveryTarget() float64 {
-	if v, ok := fc.cfg.Params["recovery_target"]; ok {
+	if v, ok := fc.params.lookup("recovery_target"); ok {
 		if f, ok := v.(float64); ok {
 			return f
 		}
 	}
 	return defaultRecoveryTarget
 }
+
+// minDepthImbalance returns the configured minimum depth imbalance or the
+// default.
+func (fc *FlashCrash) minDepthImbalance() float64 {
+	if v, ok := fc.params.lookup("min_depth_imbalance"); ok {
+		if f, ok := v.(float64); ok {
+			return f
+		}
+	}
+	return defaultMinDepthImbalance
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "flash_crash", New: newFlashCrashFromDeps})
+}
+
+func newFlashCrashFromDeps(cfg Config, d Deps) (Strategy, error) {
+	fc := NewFlashCrash(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.Logger)
+	if d.Features != nil {
+		fc.WithFeatures(d.Features)
+	}
+	return fc, nil
+}
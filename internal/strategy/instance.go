@@ -0,0 +1,94 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// instance runs a strategy under a name other than its type's, so several
+// instances of one type with different params can run side by side. Its
+// signals carry the instance name as Source, with IDs prefixed by it so
+// instances reacting to the same event cannot collide, and the type in
+// Metadata["strategy_type"]. Optional interfaces are forwarded.
+type instance struct {
+	Strategy
+	name, typ string
+}
+
+// Name returns the instance name.
+func (i *instance) Name() string { return i.name }
+
+// tag rewrites signals emitted by the wrapped strategy as the instance's.
+func (i *instance) tag(signals []domain.TradeSignal, err error) ([]domain.TradeSignal, error) {
+	for k := range signals {
+		sig := &signals[k]
+		if sig.Source != i.typ {
+			continue // not emitted by the wrapped strategy
+		}
+		sig.Source = i.name
+		sig.ID = i.name + ":" + sig.ID
+		md := make(map[string]string, len(sig.Metadata)+1)
+		for key, v := range sig.Metadata {
+			md[key] = v
+		}
+		md["strategy_type"] = i.typ
+		sig.Metadata = md
+	}
+	return signals, err
+}
+
+func (i *instance) OnBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
+	return i.tag(i.Strategy.OnBookUpdate(ctx, snap))
+}
+
+func (i *instance) OnPriceChange(ctx context.Context, change domain.PriceChange) ([]domain.TradeSignal, error) {
+	return i.tag(i.Strategy.OnPriceChange(ctx, change))
+}
+
+func (i *instance) OnTrade(ctx context.Context, trade domain.Trade) ([]domain.TradeSignal, error) {
+	return i.tag(i.Strategy.OnTrade(ctx, trade))
+}
+
+func (i *instance) OnSignal(ctx context.Context, signal domain.TradeSignal) ([]domain.TradeSignal, error) {
+	return i.tag(i.Strategy.OnSignal(ctx, signal))
+}
+
+// OnMarketUpdate forwards to the wrapped strategy when it handles market
+// updates.
+func (i *instance) OnMarketUpdate(ctx context.Context, update domain.MarketUpdate) ([]domain.TradeSignal, error) {
+	h, ok := i.Strategy.(MarketUpdateHandler)
+	if !ok {
+		return nil, nil
+	}
+	return i.tag(h.OnMarketUpdate(ctx, update))
+}
+
+// SetStateCache forwards the instance's state cache, scoped by the engine to
+// the instance name.
+func (i *instance) SetStateCache(cache domain.StateCache) {
+	if u, ok := i.Strategy.(StateCacheUser); ok {
+		u.SetStateCache(cache)
+	}
+}
+
+func (i *instance) EffectiveParams() map[string]any {
+	if pr, ok := i.Strategy.(ParamReporter); ok {
+		return pr.EffectiveParams()
+	}
+	return nil
+}
+
+func (i *instance) ResourceUsage() map[string]int {
+	if rr, ok := i.Strategy.(ResourceReporter); ok {
+		return rr.ResourceUsage()
+	}
+	return nil
+}
+
+func (i *instance) Evictions() map[string]int64 {
+	if er, ok := i.Strategy.(EvictionReporter); ok {
+		return er.Evictions()
+	}
+	return nil
+}
@@ -14,9 +14,36 @@ type Strategy interface {
 	OnPriceChange(ctx context.Context, change domain.PriceChange) ([]domain.TradeSignal, error)
 	OnTrade(ctx context.Context, trade domain.Trade) ([]domain.TradeSignal, error)
 	OnSignal(ctx context.Context, signal domain.TradeSignal) ([]domain.TradeSignal, error)
+	// Reconfigure validates params and applies them while the strategy runs.
+	// Keys absent from params keep their value. An unknown key or invalid
+	// value returns an error wrapping domain.ErrInvalidParams and changes
+	// nothing.
+	Reconfigure(params map[string]any) error
 	Close() error
 }
 
+// MarketUpdateHandler is optionally implemented by strategies that react to
+// market metadata changes (end date moved, market paused or closed) reported
+// by the fast-poll market watcher.
+type MarketUpdateHandler interface {
+	OnMarketUpdate(ctx context.Context, update domain.MarketUpdate) ([]domain.TradeSignal, error)
+}
+
+// StateCacheUser is optionally implemented by strategies that keep state in
+// Redis. The engine passes a cache scoped to the strategy's own namespace
+// before Init, so its keys cannot collide with other strategies' and can be
+// flushed on their own.
+type StateCacheUser interface {
+	SetStateCache(cache domain.StateCache)
+}
+
+// ParamReporter is optionally implemented by strategies that can report the
+// parameter values they are running with: config and runtime overrides
+// merged over built-in defaults.
+type ParamReporter interface {
+	EffectiveParams() map[string]any
+}
+
 // Config holds strategy configuration.
 type Config struct {
 	Name         string
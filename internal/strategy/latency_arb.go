@@ -0,0 +1,449 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+const (
+	defaultLatencyMinMoveBps   = 30
+	defaultLatencyLookbackSec  = 30
+	defaultLatencyMaxLagMove   = 0.02
+	defaultLatencyMaxPrice     = 0.90
+	defaultLatencySize         = 5.0
+	defaultLatencyTTLSeconds   = 10
+	defaultLatencyMaxStaleSec  = 5
+	defaultLatencyMaxRefAgeSec = 10
+	defaultLatencyCooldownSec  = 30
+	defaultLatencyRefreshMins  = 10
+	defaultLatencyMaxMarkets   = 200
+
+	// latencyMaxLookbackSec bounds lookback_sec by the mid history kept per
+	// market.
+	latencyMaxLookbackSec = 300
+	latencyMidHistory     = latencyMaxLookbackSec * time.Second
+)
+
+// latencyArbParams are the parameters Reconfigure accepts.
+var latencyArbParams = paramSpecs{
+	"min_move_bps":    {kind: paramInt, min: 1, max: 10000},
+	"lookback_sec":    {kind: paramInt, min: 1, max: latencyMaxLookbackSec},
+	"max_lag_move":    {kind: paramFloat, max: 1},
+	"max_price":       {kind: paramFloat, min: 0.01, max: 0.99},
+	"size":            {kind: paramFloat, min: 1},
+	"ttl_seconds":     {kind: paramInt, min: 1},
+	"max_stale_sec":   {kind: paramInt, min: 1},
+	"max_ref_age_sec": {kind: paramInt, min: 1},
+	"cooldown_sec":    {kind: paramInt},
+	"refresh_minutes": {kind: paramInt, min: 1},
+	"max_markets":     {kind: paramInt, min: 1},
+}
+
+// latencyMarket is a crypto up/down market with the token that pays when the
+// spot price rises and the one that pays when it falls.
+type latencyMarket struct {
+	marketID  string
+	asset     string
+	upToken   string
+	downToken string
+}
+
+// LatencyArb buys the side of a crypto up/down market that a spot move
+// favours before the Polymarket book catches up: when the asset's spot price
+// (from a domain.ReferencePriceProvider) has moved at least min_move_bps over
+// lookback_sec while the market's up-probability mid has moved by less than
+// max_lag_move, it takes the ask of the favoured token up to max_price.
+type LatencyArb struct {
+	cfg     Config
+	params  *paramSet
+	tracker *PriceTracker // up-probability mid per market
+	markets domain.MarketStore
+	books   domain.OrderbookCache
+	refs    domain.ReferencePriceProvider
+	logger  *slog.Logger
+
+	mu          sync.Mutex
+	byToken     map[string]latencyMarket
+	count       int
+	lastRefresh time.Time
+	lastEmit    *expiringMap[string, time.Time] // market ID -> timestamp
+}
+
+// NewLatencyArb creates a latency-arb strategy.
+func NewLatencyArb(cfg Config, markets domain.MarketStore, books domain.OrderbookCache, refs domain.ReferencePriceProvider, logger *slog.Logger) *LatencyArb {
+	return &LatencyArb{
+		cfg:      cfg,
+		params:   newParamSet(cfg.Params),
+		tracker:  NewPriceTracker(nil, latencyMidHistory),
+		markets:  markets,
+		books:    books,
+		refs:     refs,
+		logger:   logger.With(slog.String("strategy", "latency_arb")),
+		byToken:  make(map[string]latencyMarket),
+		lastEmit: newExpiringMap[string, time.Time](0),
+	}
+}
+
+// Name returns the strategy identifier.
+func (l *LatencyArb) Name() string { return "latency_arb" }
+
+// Init discovers the crypto up/down markets.
+func (l *LatencyArb) Init(ctx context.Context) error {
+	return l.refreshMarkets(ctx, time.Now().UTC(), true)
+}
+
+// OnBookUpdate records the market's up-probability mid and, when the spot
+// price has run ahead of it, emits a buy of the favoured token.
+func (l *LatencyArb) OnBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
+	if l.markets == nil || l.books == nil || l.refs == nil {
+		return nil, nil
+	}
+	now := time.Now().UTC()
+	if now.Sub(l.lastRefresh) > time.Duration(l.refreshMinutes())*time.Minute {
+		_ = l.refreshMarkets(ctx, now, false)
+	}
+
+	l.mu.Lock()
+	m, ok := l.byToken[snap.AssetID]
+	l.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	bid, ask := bestBid(snap), bestAsk(snap)
+	if bid <= 0 || ask <= 0 {
+		return nil, nil
+	}
+	upMid := (bid + ask) / 2
+	if snap.AssetID == m.downToken {
+		upMid = 1 - upMid
+	}
+	ts := snap.Timestamp
+	if ts.IsZero() {
+		ts = now
+	}
+	l.tracker.Track(m.marketID, upMid, ts)
+
+	if l.recentlyEmitted(m.marketID, now) {
+		return nil, nil
+	}
+
+	ref, err := l.refs.ReferencePrice(ctx, m.asset)
+	if err != nil || now.Sub(ref.At) > time.Duration(l.maxRefAgeSec())*time.Second {
+		return nil, nil
+	}
+	lookback := time.Duration(l.lookbackSec()) * time.Second
+	spotMove, err := l.refs.ReferenceMove(ctx, m.asset, lookback)
+	if err != nil || math.Abs(spotMove) < float64(l.minMoveBps())/10_000 {
+		return nil, nil
+	}
+	before, ok := l.midBefore(m.marketID, ts.Add(-lookback))
+	if !ok {
+		return nil, nil
+	}
+	pmMove := upMid - before
+
+	// The book has lagged when the up-probability has not followed the spot
+	// move by max_lag_move in its direction.
+	buyToken, outcome := m.upToken, "up"
+	lag := pmMove
+	if spotMove < 0 {
+		buyToken, outcome = m.downToken, "down"
+		lag = -pmMove
+	}
+	if lag >= l.maxLagMove() {
+		return nil, nil
+	}
+
+	book := snap
+	if buyToken != snap.AssetID {
+		book, err = l.books.GetSnapshot(ctx, buyToken)
+		if err != nil {
+			return nil, nil
+		}
+	}
+	if book.AssetID == "" || now.Sub(book.Timestamp) > time.Duration(l.maxStaleSec())*time.Second {
+		return nil, nil
+	}
+	price := bestAsk(book)
+	if price <= 0 || price > l.maxPrice() {
+		return nil, nil
+	}
+
+	size := l.size()
+	l.markEmitted(m.marketID, now)
+	sig := domain.TradeSignal{
+		ID:         fmt.Sprintf("la-%s-%d", m.marketID, now.UnixNano()),
+		Source:     l.Name(),
+		MarketID:   m.marketID,
+		TokenID:    buyToken,
+		Side:       domain.OrderSideBuy,
+		PriceTicks: domain.ToPriceTicks(price),
+		SizeUnits:  domain.ToSizeUnits(size),
+		Urgency:    domain.SignalUrgencyImmediate,
+		Reason: fmt.Sprintf("latency_arb asset=%s spot_move_bps=%.1f pm_move=%.4f buy=%s ask=%.4f",
+			m.asset, spotMove*10_000, pmMove, outcome, price),
+		Metadata: map[string]string{
+			"asset":         m.asset,
+			"outcome":       outcome,
+			"spot_move_bps": fmt.Sprintf("%.1f", spotMove*10_000),
+			"pm_move":       fmt.Sprintf("%.4f", pmMove),
+			"lookback_sec":  fmt.Sprintf("%d", l.lookbackSec()),
+			"ref_price":     fmt.Sprintf("%.6f", ref.Price),
+			"ref_source":    ref.Source,
+		},
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(l.ttlSeconds()) * time.Second),
+	}
+	l.logger.InfoContext(ctx, "latency arb signal emitted",
+		slog.String("market", m.marketID),
+		slog.String("asset", m.asset),
+		slog.String("outcome", outcome),
+		slog.Float64("spot_move_bps", spotMove*10_000),
+		slog.Float64("pm_move", pmMove),
+		slog.Float64("ask", price),
+	)
+	return []domain.TradeSignal{sig}, nil
+}
+
+func (l *LatencyArb) OnPriceChange(_ context.Context, _ domain.PriceChange) ([]domain.TradeSignal, error) {
+	return nil, nil
+}
+
+func (l *LatencyArb) OnTrade(_ context.Context, _ domain.Trade) ([]domain.TradeSignal, error) {
+	return nil, nil
+}
+
+func (l *LatencyArb) OnSignal(_ context.Context, _ domain.TradeSignal) ([]domain.TradeSignal, error) {
+	return nil, nil
+}
+
+func (l *LatencyArb) Close() error { return nil }
+
+// Reconfigure validates params and applies them to the running strategy.
+func (l *LatencyArb) Reconfigure(params map[string]any) error {
+	return l.params.apply(latencyArbParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (l *LatencyArb) EffectiveParams() map[string]any {
+	return map[string]any{
+		"min_move_bps":    l.minMoveBps(),
+		"lookback_sec":    l.lookbackSec(),
+		"max_lag_move":    l.maxLagMove(),
+		"max_price":       l.maxPrice(),
+		"size":            l.size(),
+		"ttl_seconds":     l.ttlSeconds(),
+		"max_stale_sec":   l.maxStaleSec(),
+		"max_ref_age_sec": l.maxRefAgeSec(),
+		"cooldown_sec":    l.cooldownSec(),
+		"refresh_minutes": l.refreshMinutes(),
+		"max_markets":     l.maxMarkets(),
+	}
+}
+
+// midBefore returns the last up-probability mid of market recorded at or
+// before t.
+func (l *LatencyArb) midBefore(marketID string, t time.Time) (float64, bool) {
+	h := l.tracker.GetHistory(marketID)
+	for i := len(h) - 1; i >= 0; i-- {
+		if !h[i].Time.After(t) {
+			return h[i].Price, true
+		}
+	}
+	return 0, false
+}
+
+func (l *LatencyArb) refreshMarkets(ctx context.Context, now time.Time, logErrors bool) error {
+	markets, err := l.markets.ListActive(ctx, domain.ListOpts{Limit: 600})
+	if err != nil {
+		if logErrors {
+			l.logger.WarnContext(ctx, "latency_arb: list active markets failed", slog.String("error", err.Error()))
+		}
+		return err
+	}
+	maxMarkets := l.maxMarkets()
+	byToken := make(map[string]latencyMarket)
+	count := 0
+	for _, mk := range markets {
+		m, ok := describeLatencyMarket(mk)
+		if !ok {
+			continue
+		}
+		byToken[m.upToken] = m
+		byToken[m.downToken] = m
+		if count++; count >= maxMarkets {
+			break
+		}
+	}
+
+	l.mu.Lock()
+	l.byToken = byToken
+	l.count = count
+	l.lastRefresh = now
+	l.mu.Unlock()
+
+	if count > 0 {
+		l.logger.DebugContext(ctx, "latency_arb: markets refreshed", slog.Int("markets", count))
+	}
+	return nil
+}
+
+// describeLatencyMarket recognises a binary crypto market that settles on
+// the direction of the spot price: an "Up or Down" market with Up and Down
+// outcomes, or a Yes/No market asking whether the asset goes up (or down).
+func describeLatencyMarket(m domain.Market) (latencyMarket, bool) {
+	text := strings.ToLower(strings.TrimSpace(m.Question + " " + m.Slug))
+	if text == "" || !m.IsBinary() || m.TokenID(0) == "" || m.TokenID(1) == "" {
+		return latencyMarket{}, false
+	}
+	asset := extractAsset(text)
+	if asset == "" {
+		return latencyMarket{}, false
+	}
+	lm := latencyMarket{marketID: m.ID, asset: asset}
+
+	if len(m.Outcomes) == 2 && strings.EqualFold(m.Outcomes[0], "up") && strings.EqualFold(m.Outcomes[1], "down") {
+		lm.upToken, lm.downToken = m.TokenID(0), m.TokenID(1)
+		return lm, true
+	}
+	if strings.Contains(text, "up or down") {
+		return latencyMarket{}, false
+	}
+	switch {
+	case strings.Contains(text, " up"), strings.Contains(text, "higher"), strings.Contains(text, " rise"), strings.Contains(text, " increase"):
+		lm.upToken, lm.downToken = m.TokenID(0), m.TokenID(1)
+	case strings.Contains(text, " down"), strings.Contains(text, "lower"), strings.Contains(text, " fall"), strings.Contains(text, " decrease"):
+		lm.upToken, lm.downToken = m.TokenID(1), m.TokenID(0)
+	default:
+		return latencyMarket{}, false
+	}
+	return lm, true
+}
+
+func (l *LatencyArb) recentlyEmitted(marketID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	last, ok := l.lastEmit.get(marketID, now)
+	if !ok {
+		return false
+	}
+	return now.Sub(last) < time.Duration(l.cooldownSec())*time.Second
+}
+
+func (l *LatencyArb) markEmitted(marketID string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cooldown := time.Duration(l.cooldownSec()) * time.Second
+	l.lastEmit.set(marketID, now, now, max(cooldown, minCooldownRetention))
+}
+
+// intParam returns the integer parameter key or def.
+func (l *LatencyArb) intParam(key string, def int) int {
+	switch v := l.params.get(key).(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// floatParam returns the float parameter key or def.
+func (l *LatencyArb) floatParam(key string, def float64) float64 {
+	switch v := l.params.get(key).(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return def
+}
+
+func (l *LatencyArb) minMoveBps() int {
+	return l.intParam("min_move_bps", defaultLatencyMinMoveBps)
+}
+
+func (l *LatencyArb) lookbackSec() int {
+	return l.intParam("lookback_sec", defaultLatencyLookbackSec)
+}
+
+func (l *LatencyArb) maxLagMove() float64 {
+	return l.floatParam("max_lag_move", defaultLatencyMaxLagMove)
+}
+
+func (l *LatencyArb) maxPrice() float64 {
+	return l.floatParam("max_price", defaultLatencyMaxPrice)
+}
+
+func (l *LatencyArb) size() float64 {
+	return l.floatParam("size", defaultLatencySize)
+}
+
+func (l *LatencyArb) ttlSeconds() int {
+	return l.intParam("ttl_seconds", defaultLatencyTTLSeconds)
+}
+
+func (l *LatencyArb) maxStaleSec() int {
+	return l.intParam("max_stale_sec", defaultLatencyMaxStaleSec)
+}
+
+func (l *LatencyArb) maxRefAgeSec() int {
+	return l.intParam("max_ref_age_sec", defaultLatencyMaxRefAgeSec)
+}
+
+func (l *LatencyArb) cooldownSec() int {
+	return l.intParam("cooldown_sec", defaultLatencyCooldownSec)
+}
+
+func (l *LatencyArb) refreshMinutes() int {
+	return l.intParam("refresh_minutes", defaultLatencyRefreshMins)
+}
+
+func (l *LatencyArb) maxMarkets() int {
+	return l.intParam("max_markets", defaultLatencyMaxMarkets)
+}
+
+// ResourceUsage reports the size of in-memory state for resource metering.
+func (l *LatencyArb) ResourceUsage() map[string]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return map[string]int{
+		"markets":   l.count,
+		"by_token":  len(l.byToken),
+		"last_emit": l.lastEmit.len(),
+	}
+}
+
+// Evictions reports entries dropped from in-memory state by expiry or caps.
+func (l *LatencyArb) Evictions() map[string]int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return map[string]int64{"last_emit": l.lastEmit.evictions()}
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "latency_arb", New: newLatencyArbFromDeps})
+}
+
+func newLatencyArbFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(
+		dep{"market_store", d.Markets != nil},
+		dep{"book_cache", d.Books != nil},
+		dep{"pricefeed.enabled", d.ReferencePrices != nil},
+	); err != nil {
+		return nil, err
+	}
+	return NewLatencyArb(cfg, d.markets(), d.Books, d.ReferencePrices, d.Logger), nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -11,18 +14,58 @@ import (
 )
 
 const (
-	defaultHalfSpreadBps   = 50
+	defaultHalfSpreadBps    = 50
 	defaultRequoteThreshold = 0.005
-	defaultLPSize          = 10.0
-	defaultMaxMarkets      = 5
-	defaultLPMinVolume     = 50_000
+	defaultLPSize           = 10.0
+	defaultMaxMarkets       = 5
+	defaultLPMinVolume      = 50_000
+	defaultLPTickSize       = 0.01
+	defaultLPMaxInventory   = 100.0
+	defaultInventorySkewBps = 50
+	defaultLPMaxVolatility  = 0.03
+	defaultLPLevels         = 1
+	defaultLevelSpacingBps  = 100
+	defaultLPSizeDecay      = 1.0
+	defaultLPToxicSpread    = 2.0
+	maxLPLevels             = 10
 )
 
+// liquidityProviderParams are the parameters Reconfigure accepts.
+var liquidityProviderParams = paramSpecs{
+	"half_spread_bps":    {kind: paramInt, min: 1, max: 5000},
+	"requote_threshold":  {kind: paramFloat},
+	"size":               {kind: paramFloat, min: 1},
+	"max_markets":        {kind: paramInt, min: 1},
+	"tick_size":          {kind: paramFloat, min: 0.0001, max: 0.1},
+	"max_inventory":      {kind: paramFloat, min: 0},
+	"inventory_skew_bps": {kind: paramInt, min: 0, max: 5000},
+	"max_volatility":     {kind: paramFloat, min: 0, max: 1},
+	"min_volume_24h":     {kind: paramFloat, min: 0},
+	"min_reward_usd":     {kind: paramFloat, min: 0},
+	"levels":             {kind: paramInt, min: 1, max: maxLPLevels},
+	"level_spacing_bps":  {kind: paramInt, min: 1, max: 5000},
+	"size_decay":         {kind: paramFloat, min: 0.01, max: 1},
+	"toxicity_widen":     {kind: paramFloat, min: 0, max: 1},
+	"toxicity_pause":     {kind: paramFloat, min: 0, max: 1},
+	"toxicity_spread":    {kind: paramFloat, min: 1, max: 10},
+}
+
 // QuotePair holds last quoted bid/ask for a market (for requote logic).
+// BidLive and AskLive report which sides have a quote resting; Skew is the
+// inventory shift applied to the mid at the last quote, and HalfSpread the
+// half spread quoted, toxicity widening included. BidPrice and
+// AskPrice are the inner level of a ladder of Levels per side, all placed as
+// quote set QuoteSetID.
 type QuotePair struct {
 	MarketID    string
 	BidPrice    float64
 	AskPrice    float64
+	BidLive     bool
+	AskLive     bool
+	Skew        float64
+	HalfSpread  float64
+	Levels      int
+	QuoteSetID  string
 	LastMid     float64
 	LastQuoteAt time.Time
 }
@@ -30,23 +73,44 @@ type QuotePair struct {
 // LiquidityProvider places and maintains bid/ask quotes on eligible markets.
 type LiquidityProvider struct {
 	cfg          Config
+	params       *paramSet
 	tracker      *PriceTracker
 	rewards      RewardsTracker
 	markets      domain.MarketStore
-	activeQuotes map[string]*QuotePair // keyed by token (asset) ID
+	cancelRatio  CancelRatioReader             // optional
+	inventory    InventoryReader               // optional
+	trades       domain.TradeAnalyticsProvider // optional
+	toxicity     domain.ToxicityProvider       // optional
+	activeQuotes map[string]*QuotePair         // keyed by token (asset) ID
 	mu           sync.RWMutex
 	logger       *slog.Logger
 }
 
-// RewardsTracker is the service that provides eligible market IDs (injected to avoid circular import).
+// RewardsTracker is the service that provides eligible market IDs and their
+// estimated reward accrual (injected to avoid circular import).
 type RewardsTracker interface {
 	EligibleMarketIDs(ctx context.Context) ([]string, error)
+	RewardEstimates(ctx context.Context) (map[string]domain.LPRewardEstimate, error)
+}
+
+// CancelRatioReader reports markets whose rolling cancel ratio is close to the
+// venue limit (service.CancelRatioTracker).
+type CancelRatioReader interface {
+	NearCancelLimit(marketID string) bool
+}
+
+// InventoryReader reports the net shares of a token held in a market, with
+// shares of the market's other outcomes counted against it
+// (service.PositionInventory).
+type InventoryReader interface {
+	Inventory(ctx context.Context, marketID, tokenID string) (float64, error)
 }
 
 // NewLiquidityProvider creates a LiquidityProvider. rewards can be nil; then no markets are pre-selected.
 func NewLiquidityProvider(cfg Config, tracker *PriceTracker, rewards RewardsTracker, markets domain.MarketStore, logger *slog.Logger) *LiquidityProvider {
 	return &LiquidityProvider{
 		cfg:          cfg,
+		params:       newParamSet(cfg.Params),
 		tracker:      tracker,
 		rewards:      rewards,
 		markets:      markets,
@@ -55,10 +119,46 @@ func NewLiquidityProvider(cfg Config, tracker *PriceTracker, rewards RewardsTrac
 	}
 }
 
+// WithCancelRatio makes requotes on markets near their cancel-ratio limit wait
+// for the mid to move a full half spread instead of requote_threshold.
+func (lp *LiquidityProvider) WithCancelRatio(r CancelRatioReader) *LiquidityProvider {
+	lp.cancelRatio = r
+	return lp
+}
+
+// WithInventory makes quotes inventory aware: both quotes are shifted away
+// from the side the wallet is accumulating, by up to inventory_skew_bps at
+// max_inventory, and the side that would add to an inventory at
+// max_inventory is pulled.
+func (lp *LiquidityProvider) WithInventory(r InventoryReader) *LiquidityProvider {
+	lp.inventory = r
+	return lp
+}
+
+// WithTradeAnalytics makes the strategy hold off its first quote in a market
+// until the USD volume traded there over the last 24 hours reaches
+// min_volume_24h. Markets already quoted, or without analytics yet, are not
+// filtered.
+func (lp *LiquidityProvider) WithTradeAnalytics(p domain.TradeAnalyticsProvider) *LiquidityProvider {
+	lp.trades = p
+	return lp
+}
+
+// WithToxicity makes quoting react to order flow toxicity: once an asset's
+// VPIN reaches toxicity_widen the half spread is multiplied by
+// toxicity_spread, and at toxicity_pause both quotes are pulled until it
+// falls back. Both thresholds default to 0 (off); scores not warm yet are
+// ignored.
+func (lp *LiquidityProvider) WithToxicity(p domain.ToxicityProvider) *LiquidityProvider {
+	lp.toxicity = p
+	return lp
+}
+
 // Name returns the strategy identifier.
 func (lp *LiquidityProvider) Name() string { return "liquidity_provider" }
 
-// Init loads eligible markets and seeds activeQuotes (by token ID) up to max_markets.
+// Init loads eligible markets, ranks them by estimated daily reward accrual
+// and seeds activeQuotes (by token ID) up to max_markets.
 func (lp *LiquidityProvider) Init(ctx context.Context) error {
 	if lp.rewards == nil || lp.markets == nil {
 		return nil
@@ -67,6 +167,7 @@ func (lp *LiquidityProvider) Init(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	marketIDs = lp.rankByReward(ctx, marketIDs)
 	max := lp.maxMarkets()
 	lp.mu.Lock()
 	for i, mid := range marketIDs {
@@ -74,17 +175,70 @@ func (lp *LiquidityProvider) Init(ctx context.Context) error {
 			break
 		}
 		mkt, err := lp.markets.GetByID(ctx, mid)
-		if err != nil {
+		if err != nil || mkt.YesTokenID() == "" {
 			continue
 		}
-		yesTokenID := mkt.TokenIDs[0]
+		yesTokenID := mkt.YesTokenID()
 		lp.activeQuotes[yesTokenID] = &QuotePair{MarketID: mid}
 	}
 	lp.mu.Unlock()
 	return nil
 }
 
-// OnBookUpdate requotes when mid moves beyond threshold.
+// rankByReward orders marketIDs by estimated daily reward accrual, highest
+// first, and drops markets whose estimate from quoting history is below
+// min_reward_usd. Markets without history keep their order after those
+// with it. Without estimates marketIDs is returned as is.
+func (lp *LiquidityProvider) rankByReward(ctx context.Context, marketIDs []string) []string {
+	estimates, err := lp.rewards.RewardEstimates(ctx)
+	if err != nil {
+		lp.logger.WarnContext(ctx, "reward estimates unavailable, keeping eligible order",
+			slog.String("error", err.Error()),
+		)
+		return marketIDs
+	}
+	minUSD := lp.minRewardUSD()
+	ranked := make([]string, 0, len(marketIDs))
+	for _, id := range marketIDs {
+		if est, ok := estimates[id]; ok && est.Days > 0 && est.EstimatedDailyUSD < minUSD {
+			continue
+		}
+		ranked = append(ranked, id)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return estimates[ranked[i]].EstimatedDailyUSD > estimates[ranked[j]].EstimatedDailyUSD
+	})
+	return ranked
+}
+
+// rewardEstimate returns the reward estimate of marketID, and false when
+// there is none.
+func (lp *LiquidityProvider) rewardEstimate(ctx context.Context, marketID string) (domain.LPRewardEstimate, bool) {
+	if lp.rewards == nil || marketID == "" {
+		return domain.LPRewardEstimate{}, false
+	}
+	estimates, err := lp.rewards.RewardEstimates(ctx)
+	if err != nil {
+		return domain.LPRewardEstimate{}, false
+	}
+	est, ok := estimates[marketID]
+	return est, ok
+}
+
+// OnBookUpdate requotes when mid moves beyond threshold and the move changes
+// the quote by at least one tick; sub-tick moves would cancel and replace an
+// order at the same prices. Inventory changes that move the skew by a tick
+// or cross max_inventory requote as well, and so does a change of levels.
+// While the mid's volatility exceeds max_volatility, or its order flow
+// toxicity toxicity_pause, both quotes are pulled until it calms down;
+// toxicity above toxicity_widen widens the spread instead. A market is not
+// quoted at all while its estimated daily reward is below min_reward_usd.
+//
+// Each side is quoted as a ladder of levels orders, level_spacing_bps
+// (rounded to whole ticks) apart outward from the inner quote, each size_decay times the size of the one
+// inside it. Every requote emits the whole ladder as one quote set (Metadata
+// "lp_quote_set", with the order's "lp_level"), which the executor places in
+// place of the previous set on the token.
 func (lp *LiquidityProvider) OnBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
 	mid := snap.MidPrice
 	if mid <= 0 && snap.BestBid > 0 && snap.BestAsk > 0 {
@@ -93,72 +247,255 @@ func (lp *LiquidityProvider) OnBookUpdate(ctx context.Context, snap domain.Order
 	if mid <= 0 {
 		return nil, nil
 	}
-	lp.mu.Lock()
+	lp.mu.RLock()
 	q, ok := lp.activeQuotes[snap.AssetID]
+	var marketID string
+	var quoted bool
+	if ok {
+		marketID = q.MarketID
+		quoted = !q.LastQuoteAt.IsZero()
+	}
+	lp.mu.RUnlock()
 	if !ok {
-		lp.mu.Unlock()
 		return nil, nil
 	}
+	ts := snap.Timestamp
+	if ts.IsZero() {
+		ts = time.Now().UTC()
+	}
+	lp.tracker.Track(snap.AssetID, mid, ts)
+
+	if minVol := lp.minVolume24h(); !quoted && minVol > 0 {
+		if ta, ok := lookupTradeAnalytics(ctx, lp.trades, marketID); ok && ta.Volume24h < minVol {
+			return nil, nil
+		}
+	}
+
+	if minUSD := lp.minRewardUSD(); !quoted && minUSD > 0 {
+		if est, ok := lp.rewardEstimate(ctx, marketID); ok && est.Days > 0 && est.EstimatedDailyUSD < minUSD {
+			return nil, nil
+		}
+	}
+
+	if maxVol := lp.maxVolatility(); maxVol > 0 {
+		if vol := lp.tracker.GetVolatility(snap.AssetID); vol > maxVol {
+			return lp.pullAll(ctx, snap.AssetID, "max_volatility", vol, maxVol), nil
+		}
+	}
+
+	spreadMult := 1.0
+	if tox, ok := lookupToxicity(ctx, lp.toxicity, snap.AssetID); ok {
+		if pause := lp.toxicityPause(); pause > 0 && tox.VPIN >= pause {
+			return lp.pullAll(ctx, snap.AssetID, "toxicity_pause", tox.VPIN, pause), nil
+		}
+		if widen := lp.toxicityWiden(); widen > 0 && tox.VPIN >= widen {
+			spreadMult = lp.toxicitySpread()
+		}
+	}
+
+	maxInv := lp.maxInventory()
+	inv := 0.0
+	if lp.inventory != nil && marketID != "" {
+		var err error
+		if inv, err = lp.inventory.Inventory(ctx, marketID, snap.AssetID); err != nil {
+			lp.logger.WarnContext(ctx, "inventory unavailable, quoting without skew",
+				slog.String("market_id", marketID),
+				slog.String("error", err.Error()),
+			)
+			inv = 0
+		}
+	}
+	skew := 0.0
+	bidOn, askOn := true, true
+	if maxInv > 0 {
+		skew = math.Max(-1, math.Min(1, inv/maxInv)) * float64(lp.inventorySkewBps()) / 10_000
+		bidOn = inv < maxInv
+		askOn = inv > -maxInv
+	}
+
+	lp.mu.Lock()
+	halfSpread := float64(lp.halfSpreadBps()) / 10_000 * spreadMult
 	threshold := lp.requoteThreshold()
-	shouldQuote := !q.LastQuoteAt.IsZero() && (q.LastMid < 1e-9 || (mid-q.LastMid > threshold || q.LastMid-mid > threshold))
-	if q.LastQuoteAt.IsZero() {
-		shouldQuote = true
+	if lp.cancelRatio != nil && marketID != "" && lp.cancelRatio.NearCancelLimit(marketID) {
+		threshold = math.Max(threshold, halfSpread)
 	}
+	tick := lp.tickSize()
+	levels := lp.levels()
+	first := q.LastQuoteAt.IsZero()
+	sidesChanged := bidOn != q.BidLive || askOn != q.AskLive || levels != q.Levels
+	shouldQuote := first || q.LastMid < 1e-9 || mid-q.LastMid > threshold || q.LastMid-mid > threshold ||
+		math.Abs(skew-q.Skew) >= tick || math.Abs(halfSpread-q.HalfSpread) >= tick || sidesChanged
 	if !shouldQuote {
 		lp.mu.Unlock()
 		return nil, nil
 	}
-	halfSpread := float64(lp.halfSpreadBps()) / 10_000
-	bidPrice := mid - halfSpread
-	askPrice := mid + halfSpread
+	center := mid - skew
+	bidPrice := math.Floor((center-halfSpread)/tick+1e-9) * tick
+	askPrice := math.Ceil((center+halfSpread)/tick-1e-9) * tick
 	if bidPrice < 0 {
 		bidPrice = 0
 	}
 	if askPrice > 1 {
 		askPrice = 1
 	}
+	if !first && !sidesChanged && math.Abs(bidPrice-q.BidPrice) < tick/2 && math.Abs(askPrice-q.AskPrice) < tick/2 {
+		lp.mu.Unlock()
+		return nil, nil
+	}
+	now := time.Now().UTC()
+	sigID := fmt.Sprintf("lp-%s-%d", snap.AssetID, now.UnixNano())
+	bidWasLive, askWasLive := q.BidLive, q.AskLive
 	q.BidPrice = bidPrice
 	q.AskPrice = askPrice
+	q.BidLive = bidOn
+	q.AskLive = askOn
+	q.Skew = skew
+	q.HalfSpread = halfSpread
+	q.Levels = levels
+	q.QuoteSetID = sigID
 	q.LastMid = mid
-	q.LastQuoteAt = time.Now().UTC()
+	q.LastQuoteAt = now
 	lp.mu.Unlock()
 
-	size := lp.size()
-	now := time.Now().UTC()
-	sigID := fmt.Sprintf("lp-%s-%d", snap.AssetID, now.UnixNano())
-	signals := []domain.TradeSignal{
-		{
-			ID:         sigID + "-bid",
-			Source:     lp.Name(),
-			MarketID:   "",
-			TokenID:    snap.AssetID,
-			Side:       domain.OrderSideBuy,
-			PriceTicks: int64(bidPrice * 1e6),
-			SizeUnits:  int64(size * 1e6),
-			Urgency:    domain.SignalUrgencyMedium,
-			Reason:     "liquidity_provider bid",
-			CreatedAt:  now,
-			ExpiresAt:  now.Add(2 * time.Minute),
-		},
-		{
-			ID:         sigID + "-ask",
-			Source:     lp.Name(),
-			MarketID:   "",
-			TokenID:    snap.AssetID,
-			Side:       domain.OrderSideSell,
-			PriceTicks: int64(askPrice * 1e6),
-			SizeUnits:  int64(size * 1e6),
-			Urgency:    domain.SignalUrgencyMedium,
-			Reason:     "liquidity_provider ask",
-			CreatedAt:  now,
-			ExpiresAt:  now.Add(2 * time.Minute),
-		},
+	var signals []domain.TradeSignal
+	switch {
+	case bidOn:
+		signals = append(signals, lp.ladder(sigID, marketID, snap.AssetID, domain.OrderSideBuy, bidPrice, levels, now)...)
+	case bidWasLive:
+		signals = append(signals, lp.pullSignal(sigID+"-pull-bid", marketID, snap.AssetID, domain.OrderSideBuy, "max_inventory", now))
+	}
+	switch {
+	case askOn:
+		signals = append(signals, lp.ladder(sigID, marketID, snap.AssetID, domain.OrderSideSell, askPrice, levels, now)...)
+	case askWasLive:
+		signals = append(signals, lp.pullSignal(sigID+"-pull-ask", marketID, snap.AssetID, domain.OrderSideSell, "max_inventory", now))
+	}
+	if (!bidOn && bidWasLive) || (!askOn && askWasLive) {
+		lp.logger.InfoContext(ctx, "inventory at limit, pulling one side",
+			slog.String("market_id", marketID),
+			slog.Float64("inventory", inv),
+			slog.Float64("max_inventory", maxInv),
+		)
 	}
 	return signals, nil
 }
 
-func (lp *LiquidityProvider) OnPriceChange(_ context.Context, change domain.PriceChange) ([]domain.TradeSignal, error) {
-	lp.tracker.Track(change.AssetID, change.Price, change.Timestamp)
+// pullAll pulls both resting quotes of assetID because value exceeds the
+// limit of parameter cause (max_volatility or toxicity_pause). The quote is
+// placed afresh once value drops.
+func (lp *LiquidityProvider) pullAll(ctx context.Context, assetID, cause string, value, limit float64) []domain.TradeSignal {
+	lp.mu.Lock()
+	q, ok := lp.activeQuotes[assetID]
+	if !ok || (!q.BidLive && !q.AskLive) {
+		lp.mu.Unlock()
+		return nil
+	}
+	marketID := q.MarketID
+	bidWasLive, askWasLive := q.BidLive, q.AskLive
+	*q = QuotePair{MarketID: marketID}
+	lp.mu.Unlock()
+
+	lp.logger.InfoContext(ctx, "quote limit exceeded, pulling quotes",
+		slog.String("market_id", marketID),
+		slog.String("token_id", assetID),
+		slog.String("limit", cause),
+		slog.Float64("value", value),
+		slog.Float64("threshold", limit),
+	)
+	now := time.Now().UTC()
+	sigID := fmt.Sprintf("lp-%s-%d", assetID, now.UnixNano())
+	var signals []domain.TradeSignal
+	if bidWasLive {
+		signals = append(signals, lp.pullSignal(sigID+"-pull-bid", marketID, assetID, domain.OrderSideBuy, cause, now))
+	}
+	if askWasLive {
+		signals = append(signals, lp.pullSignal(sigID+"-pull-ask", marketID, assetID, domain.OrderSideSell, cause, now))
+	}
+	return signals
+}
+
+// ladder returns the quote signals of one side of quote set setID: levels
+// orders stepping level_spacing_bps (in whole ticks, at least one) outward
+// from inner, with sizes decaying by size_decay per level. Levels that would
+// leave (0, 1) are dropped. The inner level keeps the ID setID-bid (or -ask);
+// level i > 0 gets a -i suffix.
+func (lp *LiquidityProvider) ladder(setID, marketID, tokenID string, side domain.OrderSide, inner float64, levels int, now time.Time) []domain.TradeSignal {
+	tick := lp.tickSize()
+	step := math.Max(1, math.Round(float64(lp.levelSpacingBps())/10_000/tick)) * tick
+	if side == domain.OrderSideBuy {
+		step = -step
+	}
+	decay := lp.sizeDecay()
+	suffix := "-bid"
+	if side == domain.OrderSideSell {
+		suffix = "-ask"
+	}
+	size := lp.size()
+	signals := make([]domain.TradeSignal, 0, levels)
+	for i := 0; i < levels; i++ {
+		price := inner
+		if i > 0 {
+			price = math.Round((inner+float64(i)*step)/tick) * tick
+			if price < tick/2 || price > 1-tick/2 {
+				break
+			}
+		}
+		id := setID + suffix
+		if i > 0 {
+			id = fmt.Sprintf("%s-%d", id, i)
+		}
+		sig := lp.quoteSignal(id, marketID, tokenID, side, price, size, now)
+		sig.Metadata = map[string]string{
+			"lp_quote_set": setID,
+			"lp_level":     strconv.Itoa(i),
+		}
+		signals = append(signals, sig)
+		size *= decay
+	}
+	return signals
+}
+
+func (lp *LiquidityProvider) quoteSignal(id, marketID, tokenID string, side domain.OrderSide, price, size float64, now time.Time) domain.TradeSignal {
+	reason := "liquidity_provider bid"
+	if side == domain.OrderSideSell {
+		reason = "liquidity_provider ask"
+	}
+	return domain.TradeSignal{
+		ID:         id,
+		Source:     lp.Name(),
+		MarketID:   marketID,
+		TokenID:    tokenID,
+		Side:       side,
+		PriceTicks: domain.ToPriceTicks(price),
+		SizeUnits:  domain.ToSizeUnits(size),
+		Urgency:    domain.SignalUrgencyMedium,
+		Reason:     reason,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(2 * time.Minute),
+	}
+}
+
+// pullSignal asks the executor to cancel the resting quote on tokenID's side
+// (Metadata "lp_pull") without placing a new one. cause is the limit that
+// made the quote come down.
+func (lp *LiquidityProvider) pullSignal(id, marketID, tokenID string, side domain.OrderSide, cause string, now time.Time) domain.TradeSignal {
+	return domain.TradeSignal{
+		ID:        id,
+		Source:    lp.Name(),
+		MarketID:  marketID,
+		TokenID:   tokenID,
+		Side:      side,
+		Urgency:   domain.SignalUrgencyHigh,
+		Reason:    "liquidity_provider pull: " + cause,
+		Metadata:  map[string]string{"lp_pull": cause},
+		CreatedAt: now,
+		ExpiresAt: now.Add(2 * time.Minute),
+	}
+}
+
+// OnPriceChange ignores level updates: the tracker follows the mid from
+// OnBookUpdate, and a changed level's price is not a mid.
+func (lp *LiquidityProvider) OnPriceChange(_ context.Context, _ domain.PriceChange) ([]domain.TradeSignal, error) {
 	return nil, nil
 }
 func (lp *LiquidityProvider) OnTrade(_ context.Context, trade domain.Trade) ([]domain.TradeSignal, error) {
@@ -170,33 +507,194 @@ func (lp *LiquidityProvider) OnSignal(_ context.Context, _ domain.TradeSignal) (
 }
 func (lp *LiquidityProvider) Close() error { return nil }
 
+// Reconfigure validates params and applies them to the running strategy.
+func (lp *LiquidityProvider) Reconfigure(params map[string]any) error {
+	return lp.params.apply(liquidityProviderParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (lp *LiquidityProvider) EffectiveParams() map[string]any {
+	return map[string]any{
+		"half_spread_bps":    lp.halfSpreadBps(),
+		"requote_threshold":  lp.requoteThreshold(),
+		"size":               lp.size(),
+		"max_markets":        lp.maxMarkets(),
+		"tick_size":          lp.tickSize(),
+		"max_inventory":      lp.maxInventory(),
+		"inventory_skew_bps": lp.inventorySkewBps(),
+		"max_volatility":     lp.maxVolatility(),
+		"min_volume_24h":     lp.minVolume24h(),
+		"min_reward_usd":     lp.minRewardUSD(),
+		"levels":             lp.levels(),
+		"level_spacing_bps":  lp.levelSpacingBps(),
+		"size_decay":         lp.sizeDecay(),
+		"toxicity_widen":     lp.toxicityWiden(),
+		"toxicity_pause":     lp.toxicityPause(),
+		"toxicity_spread":    lp.toxicitySpread(),
+	}
+}
+
 func (lp *LiquidityProvider) halfSpreadBps() int {
-	if v, ok := lp.cfg.Params["half_spread_bps"].(int); ok {
+	if v, ok := lp.params.get("half_spread_bps").(int); ok {
 		return v
 	}
-	if v, ok := lp.cfg.Params["half_spread_bps"].(int64); ok {
+	if v, ok := lp.params.get("half_spread_bps").(int64); ok {
 		return int(v)
 	}
 	return defaultHalfSpreadBps
 }
 func (lp *LiquidityProvider) requoteThreshold() float64 {
-	if v, ok := lp.cfg.Params["requote_threshold"].(float64); ok {
+	if v, ok := lp.params.get("requote_threshold").(float64); ok {
 		return v
 	}
 	return defaultRequoteThreshold
 }
 func (lp *LiquidityProvider) size() float64 {
-	if v, ok := lp.cfg.Params["size"].(float64); ok {
+	if v, ok := lp.params.get("size").(float64); ok {
 		return v
 	}
 	return defaultLPSize
 }
 func (lp *LiquidityProvider) maxMarkets() int {
-	if v, ok := lp.cfg.Params["max_markets"].(int); ok {
+	if v, ok := lp.params.get("max_markets").(int); ok {
 		return v
 	}
-	if v, ok := lp.cfg.Params["max_markets"].(int64); ok {
+	if v, ok := lp.params.get("max_markets").(int64); ok {
 		return int(v)
 	}
 	return defaultMaxMarkets
 }
+
+func (lp *LiquidityProvider) tickSize() float64 {
+	if v, ok := lp.params.get("tick_size").(float64); ok && v > 0 {
+		return v
+	}
+	return defaultLPTickSize
+}
+
+func (lp *LiquidityProvider) maxInventory() float64 {
+	if v, ok := lp.params.get("max_inventory").(float64); ok && v >= 0 {
+		return v
+	}
+	return defaultLPMaxInventory
+}
+
+func (lp *LiquidityProvider) inventorySkewBps() int {
+	if v, ok := lp.params.get("inventory_skew_bps").(int); ok {
+		return v
+	}
+	if v, ok := lp.params.get("inventory_skew_bps").(int64); ok {
+		return int(v)
+	}
+	return defaultInventorySkewBps
+}
+
+func (lp *LiquidityProvider) maxVolatility() float64 {
+	if v, ok := lp.params.get("max_volatility").(float64); ok && v >= 0 {
+		return v
+	}
+	return defaultLPMaxVolatility
+}
+
+func (lp *LiquidityProvider) minVolume24h() float64 {
+	if v, ok := lp.params.get("min_volume_24h").(float64); ok && v >= 0 {
+		return v
+	}
+	return 0
+}
+
+// minRewardUSD is the estimated daily reward accrual below which a market
+// with quoting history is not worth quoting; 0 quotes every market.
+func (lp *LiquidityProvider) minRewardUSD() float64 {
+	if v, ok := lp.params.get("min_reward_usd").(float64); ok && v >= 0 {
+		return v
+	}
+	return 0
+}
+
+// levels is the number of orders quoted per side.
+func (lp *LiquidityProvider) levels() int {
+	v := defaultLPLevels
+	switch n := lp.params.get("levels").(type) {
+	case int:
+		v = n
+	case int64:
+		v = int(n)
+	}
+	return max(1, min(v, maxLPLevels))
+}
+
+// levelSpacingBps is the distance between consecutive ladder levels.
+func (lp *LiquidityProvider) levelSpacingBps() int {
+	if v, ok := lp.params.get("level_spacing_bps").(int); ok && v > 0 {
+		return v
+	}
+	if v, ok := lp.params.get("level_spacing_bps").(int64); ok && v > 0 {
+		return int(v)
+	}
+	return defaultLevelSpacingBps
+}
+
+// sizeDecay is the size of each ladder level relative to the one inside it.
+func (lp *LiquidityProvider) sizeDecay() float64 {
+	if v, ok := lp.params.get("size_decay").(float64); ok && v > 0 && v <= 1 {
+		return v
+	}
+	return defaultLPSizeDecay
+}
+
+// toxicityWiden is the VPIN at which the spread widens; 0 never widens.
+func (lp *LiquidityProvider) toxicityWiden() float64 {
+	if v, ok := lp.params.get("toxicity_widen").(float64); ok && v >= 0 {
+		return v
+	}
+	return 0
+}
+
+// toxicityPause is the VPIN at which quotes are pulled; 0 never pulls.
+func (lp *LiquidityProvider) toxicityPause() float64 {
+	if v, ok := lp.params.get("toxicity_pause").(float64); ok && v >= 0 {
+		return v
+	}
+	return 0
+}
+
+// toxicitySpread multiplies the half spread of assets past toxicity_widen.
+func (lp *LiquidityProvider) toxicitySpread() float64 {
+	if v, ok := lp.params.get("toxicity_spread").(float64); ok && v >= 1 {
+		return v
+	}
+	return defaultLPToxicSpread
+}
+
+// ResourceUsage reports the size of in-memory state for resource metering.
+func (lp *LiquidityProvider) ResourceUsage() map[string]int {
+	lp.mu.RLock()
+	defer lp.mu.RUnlock()
+	return map[string]int{"active_quotes": len(lp.activeQuotes)}
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "liquidity_provider", New: newLiquidityProviderFromDeps, Singleton: true})
+}
+
+func newLiquidityProviderFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(dep{"market_store", d.Markets != nil}); err != nil {
+		return nil, err
+	}
+	lp := NewLiquidityProvider(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.Rewards, d.markets(), d.Logger)
+	if d.CancelRatio != nil {
+		lp.WithCancelRatio(d.CancelRatio)
+	}
+	if d.Inventory != nil {
+		lp.WithInventory(d.Inventory)
+	}
+	if d.TradeAnalytics != nil {
+		lp.WithTradeAnalytics(d.TradeAnalytics)
+	}
+	if d.Toxicity != nil {
+		lp.WithToxicity(d.Toxicity)
+	}
+	return lp, nil
+}
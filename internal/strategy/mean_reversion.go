@@ -10,18 +10,27 @@ import (
 )
 
 const (
-	defaultStdDevThreshold  = 2.0
-	defaultLookbackWindow   = "5m"
+	defaultStdDevThreshold = 2.0
+	defaultLookbackWindow  = "5m"
+	defaultMaxAdverseFlow  = 0.8
 )
 
+// meanReversionParams are the parameters Reconfigure accepts.
+var meanReversionParams = paramSpecs{
+	"std_dev_threshold": {kind: paramFloat, min: 0.1},
+	"max_adverse_flow":  {kind: paramFloat, min: 0, max: 1},
+}
+
 // MeanReversion implements a strategy that buys when the current price is
 // significantly below the recent mean and sells when it is significantly
 // above.  "Significantly" is measured in multiples of the trailing standard
 // deviation (the std_dev_threshold parameter).
 type MeanReversion struct {
-	cfg     Config
-	tracker *PriceTracker
-	logger  *slog.Logger
+	cfg      Config
+	params   *paramSet
+	tracker  *PriceTracker
+	features domain.FeatureProvider // optional
+	logger   *slog.Logger
 }
 
 // NewMeanReversion creates a MeanReversion strategy. The following keys are
@@ -32,14 +41,26 @@ type MeanReversion struct {
 //     Defaults to "5m".
 //   - "std_dev_threshold" (float64): number of standard deviations away from
 //     the mean before a signal is emitted. Defaults to 2.0.
+//   - "max_adverse_flow" (float64): with features set, the strongest trade
+//     flow imbalance against a signal's side it is still emitted into.
+//     Defaults to 0.8.
 func NewMeanReversion(cfg Config, tracker *PriceTracker, logger *slog.Logger) *MeanReversion {
 	return &MeanReversion{
 		cfg:     cfg,
+		params:  newParamSet(cfg.Params),
 		tracker: tracker,
 		logger:  logger.With(slog.String("strategy", "mean_reversion")),
 	}
 }
 
+// WithFeatures makes the strategy measure the deviation at the microprice
+// rather than the mid, and skip signals against trade flow more one-sided
+// than max_adverse_flow. Signals carry the features they were emitted on.
+func (mr *MeanReversion) WithFeatures(p domain.FeatureProvider) *MeanReversion {
+	mr.features = p
+	return mr
+}
+
 // Name returns the strategy identifier.
 func (mr *MeanReversion) Name() string { return "mean_reversion" }
 
@@ -49,8 +70,6 @@ func (mr *MeanReversion) Init(_ context.Context) error { return nil }
 // OnBookUpdate evaluates whether the current mid price deviates enough from
 // the historical average to warrant a buy or sell signal.
 func (mr *MeanReversion) OnBookUpdate(ctx context.Context, snap domain.OrderbookSnapshot) ([]domain.TradeSignal, error) {
-	_ = ctx
-
 	assetID := snap.AssetID
 	mid := snap.MidPrice
 
@@ -65,14 +84,23 @@ func (mr *MeanReversion) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 	}
 
 	threshold := mr.stdDevThreshold()
-	deviation := (mid - avg) / vol
+	price := mid
+	feats, haveFeats := lookupFeatures(ctx, mr.features, assetID)
+	if haveFeats && feats.Microprice > 0 {
+		price = feats.Microprice
+	}
+	deviation := (price - avg) / vol
+	maxFlow := mr.maxAdverseFlow()
 
 	now := time.Now().UTC()
-	sizeUnits := int64(mr.cfg.Size * 1e6)
+	sizeUnits := domain.ToSizeUnits(mr.cfg.Size)
 
 	// Price significantly below mean: BUY.
 	if deviation <= -threshold {
-		priceTicks := int64(mid * 1e6)
+		if haveFeats && feats.TradeFlowImbalance < -maxFlow {
+			return nil, nil
+		}
+		priceTicks := domain.ToPriceTicks(mid)
 		sig := domain.TradeSignal{
 			ID:         fmt.Sprintf("mr-buy-%s-%d", assetID, now.UnixNano()),
 			Source:     mr.Name(),
@@ -92,6 +120,9 @@ func (mr *MeanReversion) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 			CreatedAt: now,
 			ExpiresAt: now.Add(60 * time.Second),
 		}
+		if haveFeats {
+			addFeatureMetadata(sig.Metadata, feats)
+		}
 
 		mr.logger.Info("mean reversion BUY signal",
 			slog.String("asset", assetID),
@@ -104,7 +135,10 @@ func (mr *MeanReversion) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 
 	// Price significantly above mean: SELL.
 	if deviation >= threshold {
-		priceTicks := int64(mid * 1e6)
+		if haveFeats && feats.TradeFlowImbalance > maxFlow {
+			return nil, nil
+		}
+		priceTicks := domain.ToPriceTicks(mid)
 		sig := domain.TradeSignal{
 			ID:         fmt.Sprintf("mr-sell-%s-%d", assetID, now.UnixNano()),
 			Source:     mr.Name(),
@@ -124,6 +158,9 @@ func (mr *MeanReversion) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 			CreatedAt: now,
 			ExpiresAt: now.Add(60 * time.Second),
 		}
+		if haveFeats {
+			addFeatureMetadata(sig.Metadata, feats)
+		}
 
 		mr.logger.Info("mean reversion SELL signal",
 			slog.String("asset", assetID),
@@ -158,9 +195,24 @@ func (mr *MeanReversion) OnSignal(_ context.Context, _ domain.TradeSignal) ([]do
 // Close releases resources. MeanReversion has nothing to release.
 func (mr *MeanReversion) Close() error { return nil }
 
+// Reconfigure validates params and applies them to the running strategy.
+func (mr *MeanReversion) Reconfigure(params map[string]any) error {
+	return mr.params.apply(meanReversionParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (mr *MeanReversion) EffectiveParams() map[string]any {
+	return map[string]any{
+		"std_dev_threshold": mr.stdDevThreshold(),
+		"lookback_window":   mr.LookbackWindow().String(),
+		"max_adverse_flow":  mr.maxAdverseFlow(),
+	}
+}
+
 // stdDevThreshold returns the configured threshold or the default.
 func (mr *MeanReversion) stdDevThreshold() float64 {
-	if v, ok := mr.cfg.Params["std_dev_threshold"]; ok {
+	if v, ok := mr.params.lookup("std_dev_threshold"); ok {
 		if f, ok := v.(float64); ok {
 			return f
 		}
@@ -168,11 +220,22 @@ func (mr *MeanReversion) stdDevThreshold() float64 {
 	return defaultStdDevThreshold
 }
 
+// maxAdverseFlow returns the configured maximum adverse trade flow or the
+// default.
+func (mr *MeanReversion) maxAdverseFlow() float64 {
+	if v, ok := mr.params.lookup("max_adverse_flow"); ok {
+		if f, ok := v.(float64); ok {
+			return f
+		}
+	}
+	return defaultMaxAdverseFlow
+}
+
 // LookbackWindow returns the configured lookback duration, falling back to the
 // default of 5 minutes. This can be used by callers when constructing the
 // PriceTracker for this strategy.
 func (mr *MeanReversion) LookbackWindow() time.Duration {
-	if v, ok := mr.cfg.Params["lookback_window"]; ok {
+	if v, ok := mr.params.lookup("lookback_window"); ok {
 		if s, ok := v.(string); ok {
 			d, err := time.ParseDuration(s)
 			if err == nil {
@@ -183,3 +246,15 @@ func (mr *MeanReversion) LookbackWindow() time.Duration {
 	d, _ := time.ParseDuration(defaultLookbackWindow)
 	return d
 }
+
+func init() {
+	RegisterType(TypeSpec{Type: "mean_reversion", New: newMeanReversionFromDeps})
+}
+
+func newMeanReversionFromDeps(cfg Config, d Deps) (Strategy, error) {
+	mr := NewMeanReversion(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.Logger)
+	if d.Features != nil {
+		mr.WithFeatures(d.Features)
+	}
+	return mr, nil
+}
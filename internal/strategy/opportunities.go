@@ -0,0 +1,92 @@
+package strategy
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// SetOpportunityRegistry enables cross-detector opportunity dedup: before an
+// arbitrage signal group (one with a "leg_group_id" or "arb_type") is
+// emitted, its fingerprint is claimed in reg for window, and the group is
+// dropped when another strategy holds it. A zero window or nil reg disables
+// the check.
+func (e *Engine) SetOpportunityRegistry(reg domain.OpportunityRegistry, window time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.opps = reg
+	e.oppWindow = window
+}
+
+// claimOpportunities returns signals without the arbitrage groups whose
+// opportunity is already claimed by another source. Registry errors fail
+// open so a Redis outage does not stop trading.
+func (e *Engine) claimOpportunities(ctx context.Context, signals []domain.TradeSignal) []domain.TradeSignal {
+	e.mu.Lock()
+	reg, window := e.opps, e.oppWindow
+	e.mu.Unlock()
+	if reg == nil || window <= 0 || len(signals) == 0 {
+		return signals
+	}
+
+	// Group arbitrage legs, keeping first-seen order.
+	var order []string
+	groups := make(map[string][]domain.TradeSignal)
+	for _, s := range signals {
+		key := s.Metadata["leg_group_id"]
+		if key == "" {
+			if s.Metadata["arb_type"] == "" {
+				continue
+			}
+			key = s.ID
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], s)
+	}
+	if len(order) == 0 {
+		return signals
+	}
+
+	dropped := make(map[string]bool, len(order))
+	for _, key := range order {
+		legs := groups[key]
+		fp := domain.SignalGroupFingerprint(legs)
+		ok, holder, err := reg.Claim(ctx, fp, legs[0].Source, window)
+		if err != nil {
+			e.logger.Warn("opportunity claim failed",
+				slog.String("strategy", legs[0].Source),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if !ok {
+			dropped[key] = true
+			e.logger.Info("opportunity already claimed, signals dropped",
+				slog.String("strategy", legs[0].Source),
+				slog.String("holder", holder),
+				slog.String("fingerprint", fp),
+				slog.Int("legs", len(legs)),
+			)
+		}
+	}
+	if len(dropped) == 0 {
+		return signals
+	}
+
+	out := make([]domain.TradeSignal, 0, len(signals))
+	for _, s := range signals {
+		key := s.Metadata["leg_group_id"]
+		if key == "" {
+			key = s.ID
+		}
+		if dropped[key] {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
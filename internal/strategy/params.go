@@ -0,0 +1,126 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// paramKind is the value type a tunable strategy parameter accepts.
+type paramKind int
+
+const (
+	paramFloat paramKind = iota // stored as float64
+	paramInt                    // stored as int; whole-number floats from JSON are accepted
+)
+
+// paramSpec describes one tunable parameter for Reconfigure validation.
+type paramSpec struct {
+	kind paramKind
+	min  float64 // inclusive lower bound
+	max  float64 // inclusive upper bound; 0 means unbounded
+}
+
+// paramSpecs maps parameter name to spec for one strategy.
+type paramSpecs map[string]paramSpec
+
+// paramSet holds a strategy's parameters. Event handlers read it while
+// Reconfigure replaces it from the HTTP goroutine, so the map is swapped
+// whole under a lock and never mutated in place.
+type paramSet struct {
+	mu sync.RWMutex
+	m  map[string]any
+}
+
+func newParamSet(m map[string]any) *paramSet {
+	cp := make(map[string]any, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return &paramSet{m: cp}
+}
+
+// get returns the value for key, or nil.
+func (p *paramSet) get(key string) any {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.m[key]
+}
+
+// lookup returns the value for key and whether it is set.
+func (p *paramSet) lookup(key string) (any, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	v, ok := p.m[key]
+	return v, ok
+}
+
+// apply validates update against specs and merges it over the current
+// values. Keys absent from update keep their value. On any error nothing
+// changes.
+func (p *paramSet) apply(specs paramSpecs, update map[string]any) error {
+	normalized := make(map[string]any, len(update))
+	keys := make([]string, 0, len(update))
+	for k := range update {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // report the first bad key deterministically
+	for _, k := range keys {
+		spec, ok := specs[k]
+		if !ok {
+			return fmt.Errorf("%w: unknown parameter %q", domain.ErrInvalidParams, k)
+		}
+		v, err := spec.normalize(update[k])
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", domain.ErrInvalidParams, k, err)
+		}
+		normalized[k] = v
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	next := make(map[string]any, len(p.m)+len(normalized))
+	for k, v := range p.m {
+		next[k] = v
+	}
+	for k, v := range normalized {
+		next[k] = v
+	}
+	p.m = next
+	return nil
+}
+
+// normalize checks v against the spec and converts it to the type the
+// strategy accessors read.
+func (s paramSpec) normalize(v any) (any, error) {
+	var f float64
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case int:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	default:
+		return nil, fmt.Errorf("must be a number")
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("must be finite")
+	}
+	if f < s.min {
+		return nil, fmt.Errorf("must be >= %g", s.min)
+	}
+	if s.max > 0 && f > s.max {
+		return nil, fmt.Errorf("must be <= %g", s.max)
+	}
+	if s.kind == paramInt {
+		if f != math.Trunc(f) {
+			return nil, fmt.Errorf("must be a whole number")
+		}
+		return int(f), nil
+	}
+	return f, nil
+}
@@ -20,6 +20,7 @@ type PriceTracker struct {
 	prices     domain.PriceCache
 	history    map[string][]PricePoint
 	windowSize time.Duration
+	lastSweep  time.Time
 	mu         sync.RWMutex
 }
 
@@ -35,7 +36,9 @@ func NewPriceTracker(prices domain.PriceCache, windowSize time.Duration) *PriceT
 }
 
 // Track records a new price observation for the given asset and trims points
-// that have fallen outside the sliding window.
+// that have fallen outside the sliding window. Once per window it also drops
+// assets with no point left in the window, so markets that stop trading do
+// not stay in memory.
 func (pt *PriceTracker) Track(assetID string, price float64, ts time.Time) {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
@@ -45,6 +48,9 @@ func (pt *PriceTracker) Track(assetID string, price float64, ts time.Time) {
 		Time:  ts,
 	})
 	pt.trim(assetID, ts)
+	if ts.Sub(pt.lastSweep) >= max(pt.windowSize, expiringSweepInterval) {
+		pt.sweep(ts)
+	}
 }
 
 // GetHistory returns a copy of the price history within the sliding window for
@@ -149,3 +155,15 @@ func (pt *PriceTracker) trim(assetID string, now time.Time) {
 		pt.history[assetID] = pts[i:]
 	}
 }
+
+// sweep deletes assets whose newest point is older than windowSize relative
+// to now. The caller must hold pt.mu.
+func (pt *PriceTracker) sweep(now time.Time) {
+	pt.lastSweep = now
+	cutoff := now.Add(-pt.windowSize)
+	for assetID, pts := range pt.history {
+		if len(pts) == 0 || pts[len(pts)-1].Time.Before(cutoff) {
+			delete(pt.history, assetID)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// Defaults for the per-strategy event queues of multi-strategy mode.
+const (
+	defaultQueueBuffer       = 32
+	defaultQueueBlockTimeout = 50 * time.Millisecond
+)
+
+func defaultQueuePolicy() domain.StrategyQueuePolicy {
+	return domain.StrategyQueuePolicy{
+		BufferSize:   defaultQueueBuffer,
+		Overflow:     domain.QueueOverflowDropOldest,
+		BlockTimeout: defaultQueueBlockTimeout,
+	}
+}
+
+// SetQueuePolicy sets the size of each strategy's event queues and what
+// happens to an event when one is full. Unset fields keep their defaults (32
+// events, drop_oldest, 50ms). The overflow policy applies immediately; a new
+// buffer size applies to strategies started afterwards.
+func (e *Engine) SetQueuePolicy(p domain.StrategyQueuePolicy) error {
+	def := defaultQueuePolicy()
+	if p.BufferSize <= 0 {
+		p.BufferSize = def.BufferSize
+	}
+	if p.Overflow == "" {
+		p.Overflow = def.Overflow
+	}
+	if p.BlockTimeout <= 0 {
+		p.BlockTimeout = def.BlockTimeout
+	}
+	switch p.Overflow {
+	case domain.QueueOverflowDropOldest, domain.QueueOverflowDropNewest, domain.QueueOverflowBlock:
+	default:
+		return fmt.Errorf("strategy queue: unknown overflow policy %q", p.Overflow)
+	}
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.queuePolicy = p
+	return nil
+}
+
+// QueuePolicy returns the queue policy in effect.
+func (e *Engine) QueuePolicy() domain.StrategyQueuePolicy {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.queuePolicy
+}
+
+// dispatch queues v on every active strategy's queue returned by chans and
+// reports whether the engine is in multi-strategy mode; when it is not,
+// nothing is queued. Senders hold e.sendMu but not e.mu, so the workers keep
+// draining while a send waits under the "block" policy.
+func dispatch[T any](ctx context.Context, e *Engine, queue string, chans func() map[string]chan T, v T) bool {
+	e.sendMu.Lock()
+	defer e.sendMu.Unlock()
+
+	e.mu.Lock()
+	names, chs, policy := e.activeNames, chans(), e.queuePolicy
+	e.mu.Unlock()
+	if len(names) == 0 || chs == nil {
+		return false
+	}
+	for _, name := range names {
+		if ch, ok := chs[name]; ok {
+			e.noteEnqueue(name, queue, enqueue(ctx, ch, v, policy))
+		}
+	}
+	return true
+}
+
+// enqueueResult is what happened to one event sent to a full queue.
+type enqueueResult struct {
+	evicted bool          // the oldest queued event was discarded to make room
+	dropped bool          // the event itself was discarded
+	waited  time.Duration // time spent waiting for room
+}
+
+// enqueue queues v without blocking while there is room. On a full queue
+// drop_oldest discards the oldest queued event, so a strategy catches up on
+// the newest state instead of working through a backlog of stale books;
+// drop_newest discards v; block waits up to the policy's timeout (or until
+// ctx ends) and then discards v. Callers hold e.sendMu, so no other sender
+// can refill the slot freed here.
+func enqueue[T any](ctx context.Context, ch chan T, v T, p domain.StrategyQueuePolicy) (r enqueueResult) {
+	select {
+	case ch <- v:
+		return r
+	default:
+	}
+
+	switch p.Overflow {
+	case domain.QueueOverflowDropNewest:
+		r.dropped = true
+		return r
+	case domain.QueueOverflowBlock:
+		start := time.Now()
+		timer := time.NewTimer(p.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case ch <- v:
+		case <-timer.C:
+			r.dropped = true
+		case <-ctx.Done():
+			r.dropped = true
+		}
+		r.waited = time.Since(start)
+		return r
+	}
+
+	select {
+	case <-ch:
+		r.evicted = true
+	default:
+	}
+	select {
+	case ch <- v:
+	default:
+		r.dropped = true
+	}
+	return r
+}
+
+// noteEnqueue counts the outcome of enqueue. An evicted book or price update
+// was superseded by the newer one and counts as coalesced; an evicted trade
+// or market update is lost and counts as dropped.
+func (e *Engine) noteEnqueue(name, queue string, r enqueueResult) {
+	if !r.evicted && !r.dropped && r.waited == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if r.waited > 0 {
+		u := e.usageLocked(name)
+		u.blocked[queue]++
+		u.blockedTime += r.waited
+	}
+	if r.evicted {
+		e.recordQueuePressureLocked(name, queue, queue == queueBook || queue == queuePrice)
+	}
+	if r.dropped {
+		e.recordQueuePressureLocked(name, queue, false)
+	}
+}
+
+// QueueStats returns the backpressure state of each active strategy's
+// queues in multi-strategy mode, sorted by strategy name. Strategies that
+// have run before and are no longer active keep their counters, with no
+// depth.
+func (e *Engine) QueueStats() []domain.StrategyQueueStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := make(map[string]struct{}, len(e.activeNames)+len(e.usage))
+	for _, name := range e.activeNames {
+		names[name] = struct{}{}
+	}
+	for name, u := range e.usage {
+		if len(u.dropped)+len(u.coalesced)+len(u.blocked) > 0 {
+			names[name] = struct{}{}
+		}
+	}
+	out := make([]domain.StrategyQueueStats, 0, len(names))
+	for name := range names {
+		row := domain.StrategyQueueStats{
+			Strategy:  name,
+			Depth:     map[string]int{},
+			Dropped:   map[string]int64{},
+			Coalesced: map[string]int64{},
+			Blocked:   map[string]int64{},
+		}
+		if ch, ok := e.bookChs[name]; ok {
+			row.Depth[queueBook] = len(ch)
+		}
+		if ch, ok := e.priceChs[name]; ok {
+			row.Depth[queuePrice] = len(ch)
+		}
+		if ch, ok := e.tradeChs[name]; ok {
+			row.Depth[queueTrade] = len(ch)
+		}
+		if ch, ok := e.metaChs[name]; ok {
+			row.Depth[queueMarket] = len(ch)
+		}
+		if u, ok := e.usage[name]; ok {
+			row.Dropped = copyCounts(u.dropped)
+			row.Coalesced = copyCounts(u.coalesced)
+			row.Blocked = copyCounts(u.blocked)
+			row.BlockedTime = u.blockedTime
+		}
+		out = append(out, row)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Strategy < out[j].Strategy })
+	return out
+}
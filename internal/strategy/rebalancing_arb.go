@@ -17,24 +17,48 @@ const (
 	defaultSizePerLeg   = 5.0
 	defaultTTLSeconds   = 30
 	defaultMaxStaleSec  = 5
+	defaultMinLegSize   = 1.0
 )
 
-// GroupPriceState holds YES/NO price state per market for one condition group.
+// rebalancingArbParams are the parameters Reconfigure accepts.
+var rebalancingArbParams = paramSpecs{
+	"min_edge_bps":     {kind: paramInt, min: 1, max: 10000},
+	"max_group_size":   {kind: paramInt, min: 2},
+	"size_per_leg":     {kind: paramFloat, min: 1},
+	"min_size_per_leg": {kind: paramFloat},
+	"ttl_seconds":      {kind: paramInt, min: 1},
+	"max_stale_sec":    {kind: paramInt, min: 1},
+}
+
+// GroupPriceState holds YES/NO price state per outcome for one condition group.
 type GroupPriceState struct {
 	GroupID     string
-	YesPrices   map[string]float64 // marketID -> YES price
+	YesPrices   map[string]float64 // outcome token ID -> YES price
 	NoPrices    map[string]float64
 	LastUpdate  map[string]time.Time
 	LastUpdateAt time.Time
 }
 
+// groupOutcome is one mutually exclusive outcome of a condition group.
+type groupOutcome struct {
+	MarketID string
+	TokenID  string
+}
+
 // RebalancingArb exploits mispricing within a single condition group (sum of YES != 1.0).
+// A group's outcomes are the Yes token of each binary market in it and every
+// token of each categorical market, so a lone categorical market is a group too.
+// With an orderbook cache (WithBooks) legs are sized from book depth up to
+// size_per_leg and priced to sweep it (see sizeSpreadLegs); without one every
+// leg uses size_per_leg at the mid price.
 type RebalancingArb struct {
 	cfg         Config
+	params      *paramSet
 	tracker     *PriceTracker
 	groups      domain.ConditionGroupStore
 	markets     domain.MarketStore
 	prices      domain.PriceCache
+	books       domain.OrderbookCache
 	groupStates map[string]*GroupPriceState
 	mu          sync.RWMutex
 	logger      *slog.Logger
@@ -44,6 +68,7 @@ type RebalancingArb struct {
 func NewRebalancingArb(cfg Config, tracker *PriceTracker, groups domain.ConditionGroupStore, markets domain.MarketStore, prices domain.PriceCache, logger *slog.Logger) *RebalancingArb {
 	return &RebalancingArb{
 		cfg:         cfg,
+		params:      newParamSet(cfg.Params),
 		tracker:     tracker,
 		groups:      groups,
 		markets:     markets,
@@ -53,6 +78,12 @@ func NewRebalancingArb(cfg Config, tracker *PriceTracker, groups domain.Conditio
 	}
 }
 
+// WithBooks enables depth-aware leg sizing from the orderbook cache.
+func (r *RebalancingArb) WithBooks(books domain.OrderbookCache) *RebalancingArb {
+	r.books = books
+	return r
+}
+
 // Name returns the strategy identifier.
 func (r *RebalancingArb) Name() string { return "rebalancing_arb" }
 
@@ -94,6 +125,9 @@ func (r *RebalancingArb) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 	if err != nil {
 		return nil, nil
 	}
+	if yesPrice <= 0 {
+		return nil, nil
+	}
 	maxSize := r.maxGroupSize()
 	staleSec := time.Duration(r.maxStaleSec()) * time.Second
 	now := time.Now().UTC()
@@ -103,18 +137,16 @@ func (r *RebalancingArb) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 		if len(marketIDs) > maxSize || len(marketIDs) == 0 {
 			continue
 		}
-		var marketID string
-		for _, mid := range marketIDs {
-			mkt, err := r.markets.GetByID(ctx, mid)
-			if err != nil {
-				continue
-			}
-			if mkt.TokenIDs[0] == snap.AssetID || mkt.TokenIDs[1] == snap.AssetID {
-				marketID = mid
-				break
-			}
+		outcomes, complement, ok := r.groupOutcomes(ctx, marketIDs)
+		if !ok || len(outcomes) < 2 || len(outcomes) > maxSize {
+			continue
+		}
+		// A binary market's No token prices its Yes outcome at 1-mid.
+		tokenID, price := snap.AssetID, yesPrice
+		if yes, isNo := complement[snap.AssetID]; isNo {
+			tokenID, price = yes, 1.0-yesPrice
 		}
-		if marketID == "" {
+		if !hasOutcome(outcomes, tokenID) {
 			continue
 		}
 
@@ -129,14 +161,14 @@ func (r *RebalancingArb) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 			}
 			r.groupStates[g.ID] = state
 		}
-		state.YesPrices[marketID] = yesPrice
-		state.NoPrices[marketID] = 1.0 - yesPrice
-		state.LastUpdate[marketID] = now
+		state.YesPrices[tokenID] = price
+		state.NoPrices[tokenID] = 1.0 - price
+		state.LastUpdate[tokenID] = now
 		state.LastUpdateAt = now
 		r.mu.Unlock()
 
-		// Check if all markets in this group have fresh prices and sum_yes deviates
-		signals, err := r.checkGroup(ctx, g.ID, marketIDs, state, staleSec, now)
+		// Check if all outcomes in this group have fresh prices and sum_yes deviates
+		signals, err := r.checkGroup(ctx, snap, outcomes, state, staleSec, now)
 		if err != nil {
 			return nil, err
 		}
@@ -147,18 +179,52 @@ func (r *RebalancingArb) OnBookUpdate(ctx context.Context, snap domain.Orderbook
 	return nil, nil
 }
 
-func (r *RebalancingArb) checkGroup(ctx context.Context, groupID string, marketIDs []string, state *GroupPriceState, maxStale time.Duration, now time.Time) ([]domain.TradeSignal, error) {
+// groupOutcomes resolves the outcomes of a group's markets. complement maps
+// each binary market's No token to its Yes token. ok is false when a market
+// cannot be resolved: the group trades as a unit, so a missing market skips
+// the opportunity rather than legging in partially.
+func (r *RebalancingArb) groupOutcomes(ctx context.Context, marketIDs []string) (outcomes []groupOutcome, complement map[string]string, ok bool) {
+	complement = make(map[string]string)
+	for _, mid := range marketIDs {
+		mkt, err := r.markets.GetByID(ctx, mid)
+		if err != nil {
+			return nil, nil, false
+		}
+		tokens := mkt.OutcomeTokens()
+		if len(tokens) == 0 {
+			return nil, nil, false
+		}
+		for _, tok := range tokens {
+			outcomes = append(outcomes, groupOutcome{MarketID: mid, TokenID: tok})
+		}
+		if mkt.IsBinary() && mkt.NoTokenID() != "" {
+			complement[mkt.NoTokenID()] = mkt.YesTokenID()
+		}
+	}
+	return outcomes, complement, true
+}
+
+func hasOutcome(outcomes []groupOutcome, tokenID string) bool {
+	for _, o := range outcomes {
+		if o.TokenID == tokenID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RebalancingArb) checkGroup(ctx context.Context, current domain.OrderbookSnapshot, outcomes []groupOutcome, state *GroupPriceState, maxStale time.Duration, now time.Time) ([]domain.TradeSignal, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	var sumYes float64
 	allFresh := true
-	for _, mid := range marketIDs {
-		t, ok := state.LastUpdate[mid]
+	for _, o := range outcomes {
+		t, ok := state.LastUpdate[o.TokenID]
 		if !ok || now.Sub(t) > maxStale {
 			allFresh = false
 			break
 		}
-		sumYes += state.YesPrices[mid]
+		sumYes += state.YesPrices[o.TokenID]
 	}
 	if !allFresh {
 		return nil, nil
@@ -168,68 +234,71 @@ func (r *RebalancingArb) checkGroup(ctx context.Context, groupID string, marketI
 		return nil, nil
 	}
 
-	sizePerLeg := r.sizePerLeg()
-	ttl := time.Duration(r.ttlSeconds()) * time.Second
-	legGroupID := uuid.New().String()
-	policy := string(domain.LegPolicyAllOrNone)
+	side := domain.OrderSideBuy
+	if sumYes > 1.0+minEdge {
+		side = domain.OrderSideSell
+	}
 
-	var signals []domain.TradeSignal
-	if sumYes < 1.0-minEdge {
-		// Long the group: BUY YES on all outcomes
-		for _, mid := range marketIDs {
-			mkt, err := r.markets.GetByID(ctx, mid)
-			if err != nil {
+	sizePerLeg := r.sizePerLeg()
+	legs := make([]depthLeg, len(outcomes))
+	for i, o := range outcomes {
+		legs[i] = depthLeg{VWAP: state.YesPrices[o.TokenID], Limit: state.YesPrices[o.TokenID]}
+	}
+	if r.books != nil {
+		snaps := make([]domain.OrderbookSnapshot, len(outcomes))
+		for i, o := range outcomes {
+			tok := o.TokenID
+			if tok == current.AssetID {
+				snaps[i] = current
 				continue
 			}
-			yesTokenID := mkt.TokenIDs[0]
-			price := state.YesPrices[mid]
-			signals = append(signals, domain.TradeSignal{
-				ID:         fmt.Sprintf("ra-buy-%s-%d", mid, now.UnixNano()),
-				Source:     r.Name(),
-				MarketID:   mid,
-				TokenID:    yesTokenID,
-				Side:       domain.OrderSideBuy,
-				PriceTicks: int64(price * 1e6),
-				SizeUnits:  int64(sizePerLeg * 1e6),
-				Urgency:    domain.SignalUrgencyHigh,
-				Reason:     fmt.Sprintf("rebalancing_arb sum_yes=%.4f < 1-min_edge", sumYes),
-				Metadata: map[string]string{
-					"leg_group_id": legGroupID,
-					"leg_count":    fmt.Sprintf("%d", len(marketIDs)),
-					"leg_policy":  policy,
-				},
-				CreatedAt: now,
-				ExpiresAt:  now.Add(ttl),
-			})
-		}
-	} else if sumYes > 1.0+minEdge {
-		// Short the group: SELL YES on all outcomes
-		for _, mid := range marketIDs {
-			mkt, err := r.markets.GetByID(ctx, mid)
-			if err != nil {
-				continue
+			snap, err := r.books.GetSnapshot(ctx, tok)
+			if err != nil || now.Sub(snap.Timestamp) > maxStale {
+				return nil, nil
 			}
-			yesTokenID := mkt.TokenIDs[0]
-			price := state.YesPrices[mid]
-			signals = append(signals, domain.TradeSignal{
-				ID:         fmt.Sprintf("ra-sell-%s-%d", mid, now.UnixNano()),
-				Source:     r.Name(),
-				MarketID:   mid,
-				TokenID:    yesTokenID,
-				Side:       domain.OrderSideSell,
-				PriceTicks: int64(price * 1e6),
-				SizeUnits:  int64(sizePerLeg * 1e6),
-				Urgency:    domain.SignalUrgencyHigh,
-				Reason:     fmt.Sprintf("rebalancing_arb sum_yes=%.4f > 1+min_edge", sumYes),
-				Metadata: map[string]string{
-					"leg_group_id": legGroupID,
-					"leg_count":    fmt.Sprintf("%d", len(marketIDs)),
-					"leg_policy":  policy,
-				},
-				CreatedAt: now,
-				ExpiresAt:  now.Add(ttl),
-			})
+			snaps[i] = snap
+		}
+		size, depthLegs, ok := sizeSpreadLegs(snaps, side, sizePerLeg, r.minSizePerLeg(), minEdge)
+		if !ok {
+			return nil, nil
 		}
+		sizePerLeg, legs = size, depthLegs
+	}
+
+	ttl := time.Duration(r.ttlSeconds()) * time.Second
+	legGroupID := uuid.New().String()
+	policy := string(domain.LegPolicyAllOrNone)
+	edge := spreadEdge(side, legVWAPs(legs))
+	edgeBps := fmt.Sprintf("%.1f", edge*10_000)
+
+	idPrefix, reason := "ra-buy", fmt.Sprintf("rebalancing_arb sum_yes=%.4f < 1-min_edge", sumYes)
+	if side == domain.OrderSideSell {
+		idPrefix, reason = "ra-sell", fmt.Sprintf("rebalancing_arb sum_yes=%.4f > 1+min_edge", sumYes)
+	}
+	reason += fmt.Sprintf(" vwap_edge_bps=%s size=%.2f", edgeBps, sizePerLeg)
+
+	signals := make([]domain.TradeSignal, 0, len(outcomes))
+	for i, o := range outcomes {
+		signals = append(signals, domain.TradeSignal{
+			ID:         fmt.Sprintf("%s-%s-%d-%d", idPrefix, o.MarketID, i, now.UnixNano()),
+			Source:     r.Name(),
+			MarketID:   o.MarketID,
+			TokenID:    o.TokenID,
+			Side:       side,
+			PriceTicks: domain.ToPriceTicks(legs[i].Limit),
+			SizeUnits:  domain.ToSizeUnits(sizePerLeg),
+			Urgency:    domain.SignalUrgencyHigh,
+			Reason:     reason,
+			Metadata: map[string]string{
+				"leg_group_id": legGroupID,
+				"leg_count":    fmt.Sprintf("%d", len(outcomes)),
+				"leg_policy":   policy,
+				"vwap":         fmt.Sprintf("%.6f", legs[i].VWAP),
+				"edge_bps":     edgeBps,
+			},
+			CreatedAt: now,
+			ExpiresAt: now.Add(ttl),
+		})
 	}
 	return signals, nil
 }
@@ -247,36 +316,92 @@ func (r *RebalancingArb) OnSignal(_ context.Context, _ domain.TradeSignal) ([]do
 }
 func (r *RebalancingArb) Close() error { return nil }
 
+// Reconfigure validates params and applies them to the running strategy.
+func (r *RebalancingArb) Reconfigure(params map[string]any) error {
+	return r.params.apply(rebalancingArbParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (r *RebalancingArb) EffectiveParams() map[string]any {
+	return map[string]any{
+		"min_edge_bps":     r.minEdgeBps(),
+		"max_group_size":   r.maxGroupSize(),
+		"size_per_leg":     r.sizePerLeg(),
+		"min_size_per_leg": r.minSizePerLeg(),
+		"ttl_seconds":      r.ttlSeconds(),
+		"max_stale_sec":    r.maxStaleSec(),
+	}
+}
+
 func (r *RebalancingArb) minEdgeBps() int {
-	if v, ok := r.cfg.Params["min_edge_bps"].(int); ok {
+	if v, ok := r.params.get("min_edge_bps").(int); ok {
 		return v
 	}
-	if v, ok := r.cfg.Params["min_edge_bps"].(int64); ok {
+	if v, ok := r.params.get("min_edge_bps").(int64); ok {
 		return int(v)
 	}
 	return defaultMinEdgeBps
 }
 func (r *RebalancingArb) maxGroupSize() int {
-	if v, ok := r.cfg.Params["max_group_size"].(int); ok {
+	if v, ok := r.params.get("max_group_size").(int); ok {
 		return v
 	}
 	return defaultMaxGroupSize
 }
 func (r *RebalancingArb) sizePerLeg() float64 {
-	if v, ok := r.cfg.Params["size_per_leg"].(float64); ok {
+	if v, ok := r.params.get("size_per_leg").(float64); ok {
 		return v
 	}
 	return defaultSizePerLeg
 }
+func (r *RebalancingArb) minSizePerLeg() float64 {
+	if v, ok := r.params.get("min_size_per_leg").(float64); ok {
+		return v
+	}
+	return defaultMinLegSize
+}
 func (r *RebalancingArb) ttlSeconds() int {
-	if v, ok := r.cfg.Params["ttl_seconds"].(int); ok {
+	if v, ok := r.params.get("ttl_seconds").(int); ok {
 		return v
 	}
 	return defaultTTLSeconds
 }
 func (r *RebalancingArb) maxStaleSec() int {
-	if v, ok := r.cfg.Params["max_stale_sec"].(int); ok {
+	if v, ok := r.params.get("max_stale_sec").(int); ok {
 		return v
 	}
 	return defaultMaxStaleSec
 }
+
+// ResourceUsage reports the size of in-memory state for resource metering.
+func (r *RebalancingArb) ResourceUsage() map[string]int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	markets := 0
+	for _, st := range r.groupStates {
+		markets += len(st.YesPrices)
+	}
+	return map[string]int{
+		"groups":        len(r.groupStates),
+		"group_markets": markets,
+	}
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "rebalancing_arb", New: newRebalancingArbFromDeps})
+}
+
+func newRebalancingArbFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(
+		dep{"condition_group_store", d.ConditionGroups != nil},
+		dep{"market_store", d.Markets != nil},
+	); err != nil {
+		return nil, err
+	}
+	r := NewRebalancingArb(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.ConditionGroups, d.markets(), d.Prices, d.Logger)
+	if d.Books != nil {
+		r.WithBooks(d.Books)
+	}
+	return r, nil
+}
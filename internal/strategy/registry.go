@@ -19,17 +19,39 @@ type StrategyInfo struct {
 // Registry manages a named collection of strategies that can be looked up at
 // runtime. It is safe for concurrent use.
 type Registry struct {
-	strategies map[string]Strategy
-	mu         sync.RWMutex
+	strategies  map[string]Strategy
+	unavailable map[string][]string // strategy name -> missing dependencies
+	mu          sync.RWMutex
 }
 
 // NewRegistry returns an empty, ready-to-use Registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		strategies: make(map[string]Strategy),
+		strategies:  make(map[string]Strategy),
+		unavailable: make(map[string][]string),
 	}
 }
 
+// MarkUnavailable records that a known strategy was not registered because
+// the listed dependencies (stores, clients, config flags) are missing.
+func (r *Registry) MarkUnavailable(name string, missing []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unavailable[name] = append([]string(nil), missing...)
+}
+
+// Unavailable returns the strategies recorded by MarkUnavailable with their
+// missing dependencies.
+func (r *Registry) Unavailable() map[string][]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string][]string, len(r.unavailable))
+	for name, missing := range r.unavailable {
+		out[name] = append([]string(nil), missing...)
+	}
+	return out
+}
+
 // Register adds a strategy to the registry under the given name.
 // If a strategy with the same name already exists it will be replaced.
 func (r *Registry) Register(name string, s Strategy) {
@@ -0,0 +1,259 @@
+package strategy
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// budgetWarnInterval rate-limits per-strategy "over budget" warnings.
+const budgetWarnInterval = 30 * time.Second
+
+// dropWarnInterval rate-limits per-strategy, per-queue "event dropped" warnings.
+const dropWarnInterval = 30 * time.Second
+
+// Per-strategy event queue names used in drop counters and logs.
+const (
+	queueBook   = "book"
+	queuePrice  = "price"
+	queueTrade  = "trade"
+	queueMarket = "market"
+)
+
+// ResourceReporter is optionally implemented by strategies that hold
+// in-memory state (pairs, quotes, cooldown maps). ResourceUsage returns the
+// number of entries per named structure.
+type ResourceReporter interface {
+	ResourceUsage() map[string]int
+}
+
+// EvictionReporter is optionally implemented by strategies whose in-memory
+// state is bounded by expiry or size caps. Evictions returns the number of
+// entries removed so far per named structure; a count that never moves while
+// the held entries grow points at a leak.
+type EvictionReporter interface {
+	Evictions() map[string]int64
+}
+
+// strategyUsage accumulates counters for one strategy. wall, maxWall and
+// lastWall are wall-clock time spent inside its event callbacks, measured
+// with time.Now around each call; they are not CPU time and include lock
+// waits, I/O and scheduling delay.
+type strategyUsage struct {
+	events      int64
+	signals     int64
+	wall        time.Duration
+	maxWall     time.Duration
+	lastWall    time.Duration
+	overBudget  int64
+	lastEventAt time.Time
+	lastWarnAt  time.Time
+
+	// Queue pressure, keyed by queue name. dropped counts events lost because
+	// the queue was full; coalesced counts queued book/price updates replaced
+	// by a newer one; blocked counts sends that waited for room under the
+	// "block" overflow policy. The *SinceWarn maps hold counts not yet logged.
+	dropped        map[string]int64
+	coalesced      map[string]int64
+	blocked        map[string]int64
+	blockedTime    time.Duration
+	dropsSinceWarn map[string]int64
+	dropWarnAt     map[string]time.Time
+	maxBookLag     time.Duration
+	lastBookLag    time.Duration
+}
+
+// SetEventBudget sets the per-event wall-clock processing-time budget. Events
+// that take longer are counted and logged (rate-limited). Zero disables the
+// check.
+func (e *Engine) SetEventBudget(d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.eventBudget = d
+}
+
+// EventBudget returns the configured per-event wall-clock processing-time
+// budget.
+func (e *Engine) EventBudget() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.eventBudget
+}
+
+// observe records the wall-clock duration of one strategy callback that
+// started at start and produced nSignals signals.
+func (e *Engine) observe(name, event string, start time.Time, nSignals int) {
+	now := time.Now()
+	d := now.Sub(start)
+
+	e.mu.Lock()
+	u := e.usageLocked(name)
+	u.events++
+	u.signals += int64(nSignals)
+	u.wall += d
+	u.lastWall = d
+	u.lastEventAt = now
+	if d > u.maxWall {
+		u.maxWall = d
+	}
+	warn := false
+	budget := e.eventBudget
+	if budget > 0 && d > budget {
+		u.overBudget++
+		if now.Sub(u.lastWarnAt) >= budgetWarnInterval {
+			u.lastWarnAt = now
+			warn = true
+		}
+	}
+	overBudget := u.overBudget
+	e.mu.Unlock()
+
+	if warn {
+		e.logger.Warn("strategy exceeded event processing budget",
+			slog.String("strategy", name),
+			slog.String("event", event),
+			slog.Duration("took", d),
+			slog.Duration("budget", budget),
+			slog.Int64("over_budget_total", overBudget),
+		)
+	}
+}
+
+// usageLocked returns the counters for name, creating them on first use.
+// Caller must hold e.mu.
+func (e *Engine) usageLocked(name string) *strategyUsage {
+	if e.usage == nil {
+		e.usage = make(map[string]*strategyUsage)
+	}
+	u, ok := e.usage[name]
+	if !ok {
+		u = &strategyUsage{
+			dropped:        make(map[string]int64),
+			coalesced:      make(map[string]int64),
+			blocked:        make(map[string]int64),
+			dropsSinceWarn: make(map[string]int64),
+			dropWarnAt:     make(map[string]time.Time),
+		}
+		e.usage[name] = u
+	}
+	return u
+}
+
+// recordQueuePressureLocked counts one full-queue event on a strategy's
+// queue. coalesced is true when an older queued update was replaced by the
+// newer one, false when the new event itself was lost. A warning with the
+// count since the previous one is logged at most once per dropWarnInterval
+// per strategy and queue. Caller must hold e.mu.
+func (e *Engine) recordQueuePressureLocked(name, queue string, coalesced bool) {
+	u := e.usageLocked(name)
+	if coalesced {
+		u.coalesced[queue]++
+	} else {
+		u.dropped[queue]++
+	}
+	u.dropsSinceWarn[queue]++
+
+	now := time.Now()
+	if now.Sub(u.dropWarnAt[queue]) < dropWarnInterval {
+		return
+	}
+	u.dropWarnAt[queue] = now
+	e.logger.Warn("strategy event queue full, events dropped or coalesced",
+		slog.String("strategy", name),
+		slog.String("queue", queue),
+		slog.Int64("since_last_warning", u.dropsSinceWarn[queue]),
+		slog.Int64("dropped_total", u.dropped[queue]),
+		slog.Int64("coalesced_total", u.coalesced[queue]),
+	)
+	u.dropsSinceWarn[queue] = 0
+}
+
+// observeBookLag records how old a book snapshot was when the strategy
+// started processing it.
+func (e *Engine) observeBookLag(name string, snap domain.OrderbookSnapshot) {
+	if snap.Timestamp.IsZero() {
+		return
+	}
+	lag := time.Since(snap.Timestamp)
+	if lag < 0 {
+		lag = 0
+	}
+	e.mu.Lock()
+	u := e.usageLocked(name)
+	u.lastBookLag = lag
+	if lag > u.maxBookLag {
+		u.maxBookLag = lag
+	}
+	e.mu.Unlock()
+}
+
+// ResourceUsage returns per-strategy resource usage sorted by wall-clock
+// processing time, heaviest first. Strategies that have not processed any event yet are
+// included with zero counters when they are active.
+func (e *Engine) ResourceUsage() []domain.StrategyResourceUsage {
+	e.mu.Lock()
+	names := make(map[string]struct{}, len(e.usage)+len(e.activeNames))
+	for name := range e.usage {
+		names[name] = struct{}{}
+	}
+	for _, name := range e.activeNames {
+		names[name] = struct{}{}
+	}
+	if e.active != nil {
+		names[e.active.Name()] = struct{}{}
+	}
+	out := make([]domain.StrategyResourceUsage, 0, len(names))
+	for name := range names {
+		row := domain.StrategyResourceUsage{Strategy: name}
+		if u, ok := e.usage[name]; ok {
+			row.Events = u.events
+			row.Signals = u.signals
+			row.WallTime = u.wall
+			row.MaxWall = u.maxWall
+			row.LastWall = u.lastWall
+			row.OverBudget = u.overBudget
+			row.LastEventAt = u.lastEventAt
+			row.Dropped = copyCounts(u.dropped)
+			row.Coalesced = copyCounts(u.coalesced)
+			row.MaxBookLag = u.maxBookLag
+			row.LastBookLag = u.lastBookLag
+			if u.events > 0 {
+				row.AvgWall = u.wall / time.Duration(u.events)
+			}
+		}
+		out = append(out, row)
+	}
+	e.mu.Unlock()
+
+	// Query held state outside the engine lock; strategies use their own locks.
+	for i := range out {
+		s, err := e.registry.Get(out[i].Strategy)
+		if err != nil {
+			continue
+		}
+		if rr, ok := s.(ResourceReporter); ok {
+			out[i].HeldEntries = rr.ResourceUsage()
+		}
+		if er, ok := s.(EvictionReporter); ok {
+			out[i].Evicted = er.Evictions()
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].WallTime != out[j].WallTime {
+			return out[i].WallTime > out[j].WallTime
+		}
+		return out[i].Strategy < out[j].Strategy
+	})
+	return out
+}
+
+func copyCounts(m map[string]int64) map[string]int64 {
+	out := make(map[string]int64, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
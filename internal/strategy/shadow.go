@@ -0,0 +1,65 @@
+package strategy
+
+import (
+	"maps"
+	"sort"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// SetShadow runs the named strategies in shadow mode: they receive events
+// like any active strategy, but their signals never reach the executor nor
+// claim arbitrage opportunities. Each is tagged shadow=true, kept with the
+// recent signals and handed to sink (which must not block) instead. Names
+// replace any set before; none turns shadow mode off.
+func (e *Engine) SetShadow(names []string, sink SignalRecorder) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shadow = make(map[string]bool, len(names))
+	for _, n := range names {
+		e.shadow[n] = true
+	}
+	e.shadowSink = sink
+}
+
+// ShadowNames returns the strategies running in shadow mode, sorted.
+func (e *Engine) ShadowNames() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := make([]string, 0, len(e.shadow))
+	for n := range e.shadow {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// divertShadow takes the signals of shadow-mode strategies out of signals,
+// records them as shadow candidates and returns the rest.
+func (e *Engine) divertShadow(signals []domain.TradeSignal) []domain.TradeSignal {
+	if len(signals) == 0 {
+		return signals
+	}
+	e.mu.Lock()
+	shadow, sink := e.shadow, e.shadowSink
+	e.mu.Unlock()
+	if len(shadow) == 0 {
+		return signals
+	}
+	out := signals[:0:0]
+	for _, s := range signals {
+		if !shadow[s.Source] {
+			out = append(out, s)
+			continue
+		}
+		meta := make(map[string]string, len(s.Metadata)+1)
+		maps.Copy(meta, s.Metadata)
+		meta["shadow"] = "true"
+		s.Metadata = meta
+		if sink != nil {
+			sink.Record(s)
+		}
+		e.rememberSignal(s)
+	}
+	return out
+}
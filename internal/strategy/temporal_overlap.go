@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"regexp"
 	"strings"
 	"sync"
@@ -22,8 +23,26 @@ const (
 	defaultTemporalCooldownSec = 3
 	defaultTemporalRefreshMins = 10
 	defaultTemporalMaxPairs    = 100
+	defaultTemporalMinLegSize  = 1.0
+	defaultTemporalMaxRefMove  = 50
+
+	// temporalRefMoveWindow is the spot lookback max_ref_move_bps applies to.
+	temporalRefMoveWindow = time.Minute
 )
 
+// temporalOverlapParams are the parameters Reconfigure accepts.
+var temporalOverlapParams = paramSpecs{
+	"min_edge_bps":     {kind: paramInt, min: 1, max: 10000},
+	"size_per_leg":     {kind: paramFloat, min: 1},
+	"min_size_per_leg": {kind: paramFloat},
+	"ttl_seconds":      {kind: paramInt, min: 1},
+	"max_stale_sec":    {kind: paramInt, min: 1},
+	"cooldown_sec":     {kind: paramInt},
+	"refresh_minutes":  {kind: paramInt, min: 1},
+	"max_pairs":        {kind: paramInt, min: 1},
+	"max_ref_move_bps": {kind: paramInt},
+}
+
 var temporalMinutesRE = regexp.MustCompile(`(?i)(\d{1,3})\s*(m|min|mins|minute|minutes)\b`)
 
 type temporalDescriptor struct {
@@ -46,34 +65,47 @@ type temporalPair struct {
 }
 
 // TemporalOverlap detects opportunities between short and long horizon markets
-// (e.g. long-window UP + short-window DOWN).
+// (e.g. long-window UP + short-window DOWN). Legs are sized from book depth up
+// to size_per_leg (see sizeSpreadLegs).
 type TemporalOverlap struct {
 	cfg     Config
+	params  *paramSet
 	tracker *PriceTracker
 	markets domain.MarketStore
 	books   domain.OrderbookCache
+	refs    domain.ReferencePriceProvider // optional
 	logger  *slog.Logger
 
 	mu          sync.Mutex
 	pairs       []temporalPair
 	byToken     map[string][]temporalPair
 	lastRefresh time.Time
-	lastEmit    map[string]time.Time // pair ID -> timestamp
+	lastEmit    *expiringMap[string, time.Time] // pair ID -> timestamp
 }
 
 // NewTemporalOverlap creates a temporal-overlap strategy.
 func NewTemporalOverlap(cfg Config, tracker *PriceTracker, markets domain.MarketStore, books domain.OrderbookCache, logger *slog.Logger) *TemporalOverlap {
 	return &TemporalOverlap{
 		cfg:      cfg,
+		params:   newParamSet(cfg.Params),
 		tracker:  tracker,
 		markets:  markets,
 		books:    books,
 		logger:   logger.With(slog.String("strategy", "temporal_overlap")),
 		byToken:  make(map[string][]temporalPair),
-		lastEmit: make(map[string]time.Time),
+		lastEmit: newExpiringMap[string, time.Time](0),
 	}
 }
 
+// WithReferencePrices makes the strategy skip an asset's pairs while its spot
+// price has moved more than max_ref_move_bps over the last minute: both legs
+// are then being repriced and an apparent overlap is usually a stale quote.
+// Signals carry the spot price they were emitted on.
+func (t *TemporalOverlap) WithReferencePrices(p domain.ReferencePriceProvider) *TemporalOverlap {
+	t.refs = p
+	return t
+}
+
 // Name returns the strategy identifier.
 func (t *TemporalOverlap) Name() string { return "temporal_overlap" }
 
@@ -103,12 +135,23 @@ func (t *TemporalOverlap) OnBookUpdate(ctx context.Context, snap domain.Orderboo
 	minEdge := float64(t.minEdgeBps()) / 10_000
 	maxStale := time.Duration(t.maxStaleSec()) * time.Second
 	ttl := time.Duration(t.ttlSeconds()) * time.Second
-	size := t.sizePerLeg()
+	maxSize, minSize := t.sizePerLeg(), t.minSizePerLeg()
+	maxRefMove := float64(t.maxRefMoveBps()) / 10_000
 
 	for _, p := range candidates {
 		if t.recentlyEmitted(p.id, now) {
 			continue
 		}
+		ref, haveRef := t.referencePrice(ctx, p.asset)
+		if haveRef && maxRefMove > 0 {
+			if move, err := t.refs.ReferenceMove(ctx, p.asset, temporalRefMoveWindow); err == nil && math.Abs(move) > maxRefMove {
+				t.logger.DebugContext(ctx, "temporal_overlap: pair held off while spot moves",
+					slog.String("asset", p.asset),
+					slog.Float64("move_bps", move*10_000),
+				)
+				continue
+			}
+		}
 
 		longSnap, err := t.snapshotForToken(ctx, snap, p.longTokenID)
 		if err != nil || longSnap.AssetID == "" || now.Sub(longSnap.Timestamp) > maxStale {
@@ -121,26 +164,33 @@ func (t *TemporalOverlap) OnBookUpdate(ctx context.Context, snap domain.Orderboo
 
 		longAsk, longBid := bestAsk(longSnap), bestBid(longSnap)
 		shortAsk, shortBid := bestAsk(shortSnap), bestBid(shortSnap)
+		books := []domain.OrderbookSnapshot{longSnap, shortSnap}
 
-		if longAsk > 0 && shortAsk > 0 {
-			sumAsk := longAsk + shortAsk
-			edge := 1.0 - sumAsk
-			if edge > minEdge {
+		if longAsk > 0 && shortAsk > 0 && 1.0-(longAsk+shortAsk) > minEdge {
+			if size, legs, ok := sizeSpreadLegs(books, domain.OrderSideBuy, maxSize, minSize, minEdge); ok {
+				edge := spreadEdge(domain.OrderSideBuy, legVWAPs(legs))
 				t.markEmitted(p.id, now)
-				return temporalPairSignals(p, domain.OrderSideBuy, longAsk, shortAsk, size, ttl, now,
-					fmt.Sprintf("temporal_overlap buy_pair asset=%s long=%dm short=%dm sum_ask=%.4f edge_bps=%.1f",
-						p.asset, p.longMinutes, p.shortMinutes, sumAsk, edge*10_000)), nil
+				sigs := temporalPairSignals(p, domain.OrderSideBuy, legs[0], legs[1], size, edge, ttl, now,
+					fmt.Sprintf("temporal_overlap buy_pair asset=%s long=%dm short=%dm vwap_sum=%.4f edge_bps=%.1f size=%.2f",
+						p.asset, p.longMinutes, p.shortMinutes, legs[0].VWAP+legs[1].VWAP, edge*10_000, size))
+				if haveRef {
+					addReferenceMetadata(sigs, ref)
+				}
+				return sigs, nil
 			}
 		}
 
-		if longBid > 0 && shortBid > 0 {
-			sumBid := longBid + shortBid
-			edge := sumBid - 1.0
-			if edge > minEdge {
+		if longBid > 0 && shortBid > 0 && (longBid+shortBid)-1.0 > minEdge {
+			if size, legs, ok := sizeSpreadLegs(books, domain.OrderSideSell, maxSize, minSize, minEdge); ok {
+				edge := spreadEdge(domain.OrderSideSell, legVWAPs(legs))
 				t.markEmitted(p.id, now)
-				return temporalPairSignals(p, domain.OrderSideSell, longBid, shortBid, size, ttl, now,
-					fmt.Sprintf("temporal_overlap sell_pair asset=%s long=%dm short=%dm sum_bid=%.4f edge_bps=%.1f",
-						p.asset, p.longMinutes, p.shortMinutes, sumBid, edge*10_000)), nil
+				sigs := temporalPairSignals(p, domain.OrderSideSell, legs[0], legs[1], size, edge, ttl, now,
+					fmt.Sprintf("temporal_overlap sell_pair asset=%s long=%dm short=%dm vwap_sum=%.4f edge_bps=%.1f size=%.2f",
+						p.asset, p.longMinutes, p.shortMinutes, legs[0].VWAP+legs[1].VWAP, edge*10_000, size))
+				if haveRef {
+					addReferenceMetadata(sigs, ref)
+				}
+				return sigs, nil
 			}
 		}
 	}
@@ -151,12 +201,14 @@ func (t *TemporalOverlap) OnBookUpdate(ctx context.Context, snap domain.Orderboo
 func temporalPairSignals(
 	p temporalPair,
 	side domain.OrderSide,
-	longPrice, shortPrice, size float64,
+	long, short depthLeg,
+	size, edge float64,
 	ttl time.Duration,
 	now time.Time,
 	reason string,
 ) []domain.TradeSignal {
 	legGroupID := uuid.New().String()
+	edgeBps := fmt.Sprintf("%.1f", edge*10_000)
 	return []domain.TradeSignal{
 		{
 			ID:         fmt.Sprintf("to-%s-long-%d", side, now.UnixNano()),
@@ -164,8 +216,8 @@ func temporalPairSignals(
 			MarketID:   p.longMarketID,
 			TokenID:    p.longTokenID,
 			Side:       side,
-			PriceTicks: int64(longPrice * 1e6),
-			SizeUnits:  int64(size * 1e6),
+			PriceTicks: domain.ToPriceTicks(long.Limit),
+			SizeUnits:  domain.ToSizeUnits(size),
 			Urgency:    domain.SignalUrgencyImmediate,
 			Reason:     reason,
 			Metadata: map[string]string{
@@ -173,6 +225,8 @@ func temporalPairSignals(
 				"leg_count":    "2",
 				"leg_policy":   string(domain.LegPolicyAllOrNone),
 				"arb_type":     string(domain.ArbTypeCombinatorial),
+				"vwap":         fmt.Sprintf("%.6f", long.VWAP),
+				"edge_bps":     edgeBps,
 			},
 			CreatedAt: now,
 			ExpiresAt: now.Add(ttl),
@@ -183,8 +237,8 @@ func temporalPairSignals(
 			MarketID:   p.shortMarketID,
 			TokenID:    p.shortTokenID,
 			Side:       side,
-			PriceTicks: int64(shortPrice * 1e6),
-			SizeUnits:  int64(size * 1e6),
+			PriceTicks: domain.ToPriceTicks(short.Limit),
+			SizeUnits:  domain.ToSizeUnits(size),
 			Urgency:    domain.SignalUrgencyImmediate,
 			Reason:     reason,
 			Metadata: map[string]string{
@@ -192,6 +246,8 @@ func temporalPairSignals(
 				"leg_count":    "2",
 				"leg_policy":   string(domain.LegPolicyAllOrNone),
 				"arb_type":     string(domain.ArbTypeCombinatorial),
+				"vwap":         fmt.Sprintf("%.6f", short.VWAP),
+				"edge_bps":     edgeBps,
 			},
 			CreatedAt: now,
 			ExpiresAt: now.Add(ttl),
@@ -215,6 +271,48 @@ func (t *TemporalOverlap) OnSignal(_ context.Context, _ domain.TradeSignal) ([]d
 
 func (t *TemporalOverlap) Close() error { return nil }
 
+// Reconfigure validates params and applies them to the running strategy.
+func (t *TemporalOverlap) Reconfigure(params map[string]any) error {
+	return t.params.apply(temporalOverlapParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (t *TemporalOverlap) EffectiveParams() map[string]any {
+	return map[string]any{
+		"min_edge_bps":     t.minEdgeBps(),
+		"size_per_leg":     t.sizePerLeg(),
+		"min_size_per_leg": t.minSizePerLeg(),
+		"ttl_seconds":      t.ttlSeconds(),
+		"max_stale_sec":    t.maxStaleSec(),
+		"cooldown_sec":     t.cooldownSec(),
+		"refresh_minutes":  t.refreshMinutes(),
+		"max_pairs":        t.maxPairs(),
+		"max_ref_move_bps": t.maxRefMoveBps(),
+	}
+}
+
+// referencePrice returns the spot price of asset when reference prices are
+// set and one is known.
+func (t *TemporalOverlap) referencePrice(ctx context.Context, asset string) (domain.ReferencePrice, bool) {
+	if t.refs == nil {
+		return domain.ReferencePrice{}, false
+	}
+	ref, err := t.refs.ReferencePrice(ctx, asset)
+	if err != nil {
+		return domain.ReferencePrice{}, false
+	}
+	return ref, true
+}
+
+// addReferenceMetadata records the spot price signals were emitted on.
+func addReferenceMetadata(sigs []domain.TradeSignal, ref domain.ReferencePrice) {
+	for i := range sigs {
+		sigs[i].Metadata["ref_price"] = fmt.Sprintf("%.6f", ref.Price)
+		sigs[i].Metadata["ref_source"] = ref.Source
+	}
+}
+
 func (t *TemporalOverlap) snapshotForToken(ctx context.Context, current domain.OrderbookSnapshot, tokenID string) (domain.OrderbookSnapshot, error) {
 	if current.AssetID == tokenID {
 		return current, nil
@@ -300,7 +398,7 @@ func (t *TemporalOverlap) refreshPairs(ctx context.Context, now time.Time, logEr
 
 func describeTemporalMarket(m domain.Market) (temporalDescriptor, bool) {
 	text := strings.ToLower(strings.TrimSpace(m.Question + " " + m.Slug))
-	if text == "" || m.TokenIDs[0] == "" {
+	if text == "" || !m.IsBinary() || m.YesTokenID() == "" {
 		return temporalDescriptor{}, false
 	}
 	minutes := extractMinutes(text)
@@ -322,7 +420,7 @@ func describeTemporalMarket(m domain.Market) (temporalDescriptor, bool) {
 	}
 	return temporalDescriptor{
 		marketID:  m.ID,
-		tokenID:   m.TokenIDs[0],
+		tokenID:   m.YesTokenID(),
 		asset:     asset,
 		direction: direction,
 		minutes:   minutes,
@@ -359,7 +457,7 @@ func extractAsset(text string) string {
 func (t *TemporalOverlap) recentlyEmitted(pairID string, now time.Time) bool {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	last, ok := t.lastEmit[pairID]
+	last, ok := t.lastEmit.get(pairID, now)
 	if !ok {
 		return false
 	}
@@ -369,96 +467,159 @@ func (t *TemporalOverlap) recentlyEmitted(pairID string, now time.Time) bool {
 func (t *TemporalOverlap) markEmitted(pairID string, now time.Time) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	t.lastEmit[pairID] = now
+	cooldown := time.Duration(t.cooldownSec()) * time.Second
+	t.lastEmit.set(pairID, now, now, max(cooldown, minCooldownRetention))
 }
 
 func (t *TemporalOverlap) minEdgeBps() int {
-	if v, ok := t.cfg.Params["min_edge_bps"].(int); ok {
+	if v, ok := t.params.get("min_edge_bps").(int); ok {
 		return v
 	}
-	if v, ok := t.cfg.Params["min_edge_bps"].(int64); ok {
+	if v, ok := t.params.get("min_edge_bps").(int64); ok {
 		return int(v)
 	}
-	if v, ok := t.cfg.Params["min_edge_bps"].(float64); ok {
+	if v, ok := t.params.get("min_edge_bps").(float64); ok {
 		return int(v)
 	}
 	return defaultTemporalMinEdgeBps
 }
 
 func (t *TemporalOverlap) sizePerLeg() float64 {
-	if v, ok := t.cfg.Params["size_per_leg"].(float64); ok {
+	if v, ok := t.params.get("size_per_leg").(float64); ok {
 		return v
 	}
-	if v, ok := t.cfg.Params["size_per_leg"].(int); ok {
+	if v, ok := t.params.get("size_per_leg").(int); ok {
 		return float64(v)
 	}
-	if v, ok := t.cfg.Params["size_per_leg"].(int64); ok {
+	if v, ok := t.params.get("size_per_leg").(int64); ok {
 		return float64(v)
 	}
 	return defaultTemporalSizePerLeg
 }
 
+func (t *TemporalOverlap) minSizePerLeg() float64 {
+	if v, ok := t.params.get("min_size_per_leg").(float64); ok {
+		return v
+	}
+	if v, ok := t.params.get("min_size_per_leg").(int); ok {
+		return float64(v)
+	}
+	if v, ok := t.params.get("min_size_per_leg").(int64); ok {
+		return float64(v)
+	}
+	return defaultTemporalMinLegSize
+}
+
 func (t *TemporalOverlap) ttlSeconds() int {
-	if v, ok := t.cfg.Params["ttl_seconds"].(int); ok {
+	if v, ok := t.params.get("ttl_seconds").(int); ok {
 		return v
 	}
-	if v, ok := t.cfg.Params["ttl_seconds"].(int64); ok {
+	if v, ok := t.params.get("ttl_seconds").(int64); ok {
 		return int(v)
 	}
-	if v, ok := t.cfg.Params["ttl_seconds"].(float64); ok {
+	if v, ok := t.params.get("ttl_seconds").(float64); ok {
 		return int(v)
 	}
 	return defaultTemporalTTLSeconds
 }
 
 func (t *TemporalOverlap) maxStaleSec() int {
-	if v, ok := t.cfg.Params["max_stale_sec"].(int); ok {
+	if v, ok := t.params.get("max_stale_sec").(int); ok {
 		return v
 	}
-	if v, ok := t.cfg.Params["max_stale_sec"].(int64); ok {
+	if v, ok := t.params.get("max_stale_sec").(int64); ok {
 		return int(v)
 	}
-	if v, ok := t.cfg.Params["max_stale_sec"].(float64); ok {
+	if v, ok := t.params.get("max_stale_sec").(float64); ok {
 		return int(v)
 	}
 	return defaultTemporalMaxStaleSec
 }
 
 func (t *TemporalOverlap) cooldownSec() int {
-	if v, ok := t.cfg.Params["cooldown_sec"].(int); ok {
+	if v, ok := t.params.get("cooldown_sec").(int); ok {
 		return v
 	}
-	if v, ok := t.cfg.Params["cooldown_sec"].(int64); ok {
+	if v, ok := t.params.get("cooldown_sec").(int64); ok {
 		return int(v)
 	}
-	if v, ok := t.cfg.Params["cooldown_sec"].(float64); ok {
+	if v, ok := t.params.get("cooldown_sec").(float64); ok {
 		return int(v)
 	}
 	return defaultTemporalCooldownSec
 }
 
 func (t *TemporalOverlap) refreshMinutes() int {
-	if v, ok := t.cfg.Params["refresh_minutes"].(int); ok {
+	if v, ok := t.params.get("refresh_minutes").(int); ok {
 		return v
 	}
-	if v, ok := t.cfg.Params["refresh_minutes"].(int64); ok {
+	if v, ok := t.params.get("refresh_minutes").(int64); ok {
 		return int(v)
 	}
-	if v, ok := t.cfg.Params["refresh_minutes"].(float64); ok {
+	if v, ok := t.params.get("refresh_minutes").(float64); ok {
 		return int(v)
 	}
 	return defaultTemporalRefreshMins
 }
 
 func (t *TemporalOverlap) maxPairs() int {
-	if v, ok := t.cfg.Params["max_pairs"].(int); ok {
+	if v, ok := t.params.get("max_pairs").(int); ok {
 		return v
 	}
-	if v, ok := t.cfg.Params["max_pairs"].(int64); ok {
+	if v, ok := t.params.get("max_pairs").(int64); ok {
 		return int(v)
 	}
-	if v, ok := t.cfg.Params["max_pairs"].(float64); ok {
+	if v, ok := t.params.get("max_pairs").(float64); ok {
 		return int(v)
 	}
 	return defaultTemporalMaxPairs
 }
+
+func (t *TemporalOverlap) maxRefMoveBps() int {
+	if v, ok := t.params.get("max_ref_move_bps").(int); ok {
+		return v
+	}
+	if v, ok := t.params.get("max_ref_move_bps").(int64); ok {
+		return int(v)
+	}
+	if v, ok := t.params.get("max_ref_move_bps").(float64); ok {
+		return int(v)
+	}
+	return defaultTemporalMaxRefMove
+}
+
+// ResourceUsage reports the size of in-memory state for resource metering.
+func (t *TemporalOverlap) ResourceUsage() map[string]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return map[string]int{
+		"pairs":     len(t.pairs),
+		"by_token":  len(t.byToken),
+		"last_emit": t.lastEmit.len(),
+	}
+}
+
+// Evictions reports entries dropped from in-memory state by expiry or caps.
+func (t *TemporalOverlap) Evictions() map[string]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return map[string]int64{"last_emit": t.lastEmit.evictions()}
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "temporal_overlap", New: newTemporalOverlapFromDeps})
+}
+
+func newTemporalOverlapFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(
+		dep{"market_store", d.Markets != nil},
+		dep{"book_cache", d.Books != nil},
+	); err != nil {
+		return nil, err
+	}
+	t := NewTemporalOverlap(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.markets(), d.Books, d.Logger)
+	if d.ReferencePrices != nil {
+		t.WithReferencePrices(d.ReferencePrices)
+	}
+	return t, nil
+}
@@ -0,0 +1,117 @@
+package strategy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alanyoungcy/polymarketbot/internal/domain"
+)
+
+// VenueQuoter fetches the YES/NO quote for one market on an external venue
+// (kalshi adapter, predictit.Client, manifold.Client).
+type VenueQuoter interface {
+	Quote(ctx context.Context, ref string) (domain.VenueQuote, error)
+}
+
+// InstrumentResolver maps an identifier to its canonical instrument
+// (service.InstrumentRegistry).
+type InstrumentResolver interface {
+	Resolve(ctx context.Context, kind domain.IDKind, value string) (domain.Instrument, error)
+}
+
+// VenueFeed is an external venue whose quotes cross_platform_arb compares
+// against Polymarket.
+type VenueFeed struct {
+	Venue  string
+	Quoter VenueQuoter
+	// Markets maps a Polymarket market ID or slug to the venue's market ref.
+	Markets map[string]string
+	// ReadOnly marks venues the bot cannot trade on. Signals found against
+	// them are tagged for manual execution and skipped by the executor.
+	ReadOnly bool
+	// MinRefresh is the shortest interval between fetches of one market,
+	// for venues whose data updates slowly or that rate-limit hard.
+	MinRefresh time.Duration
+}
+
+// ref returns the venue market mapped to a Polymarket market, or "".
+func (f VenueFeed) ref(marketID, slug string) string {
+	if v := f.Markets[marketID]; v != "" {
+		return v
+	}
+	return f.Markets[slug]
+}
+
+// VenueQuoteCache holds the latest quote per venue market so each is fetched
+// at most once per refresh interval regardless of book update rate. Quotes
+// are dropped once they are too old to be served.
+type VenueQuoteCache struct {
+	mu     sync.Mutex
+	quotes *expiringMap[string, domain.VenueQuote] // venue + "|" + ref -> quote
+}
+
+// NewVenueQuoteCache creates an empty VenueQuoteCache.
+func NewVenueQuoteCache() *VenueQuoteCache {
+	return &VenueQuoteCache{quotes: newExpiringMap[string, domain.VenueQuote](0)}
+}
+
+// Get returns the cached quote for ref on feed's venue if it is younger than
+// ttl, fetching a fresh one otherwise.
+func (c *VenueQuoteCache) Get(ctx context.Context, feed VenueFeed, ref string, ttl time.Duration, now time.Time) (domain.VenueQuote, error) {
+	key := feed.Venue + "|" + ref
+	ttl = max(ttl, feed.MinRefresh)
+
+	c.mu.Lock()
+	cached, ok := c.quotes.get(key, now)
+	c.mu.Unlock()
+	if ok && now.Sub(cached.At) <= ttl {
+		return cached, nil
+	}
+
+	q, err := feed.Quoter.Quote(ctx, ref)
+	if err != nil {
+		return domain.VenueQuote{}, err
+	}
+	q.Venue = feed.Venue
+	q.Ref = ref
+	q.At = now
+	c.mu.Lock()
+	c.quotes.set(key, q, now, ttl)
+	c.mu.Unlock()
+	return q, nil
+}
+
+// Len returns the number of cached quotes.
+func (c *VenueQuoteCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.quotes.len()
+}
+
+// Evictions returns the number of quotes dropped by expiry or the cap.
+func (c *VenueQuoteCache) Evictions() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.quotes.evictions()
+}
+
+// kalshiQuoter adapts a Kalshi market client to VenueQuoter.
+type kalshiQuoter struct {
+	client KalshiMarketGetter
+}
+
+func (k kalshiQuoter) Quote(ctx context.Context, ticker string) (domain.VenueQuote, error) {
+	m, err := k.client.GetMarket(ctx, ticker)
+	if err != nil {
+		return domain.VenueQuote{}, err
+	}
+	return domain.VenueQuote{
+		Venue:  domain.VenueKalshi,
+		Ref:    ticker,
+		YesAsk: normalizeProb(m.YesAsk),
+		YesBid: normalizeProb(m.YesBid),
+		NoAsk:  normalizeProb(m.NoAsk),
+		NoBid:  normalizeProb(m.NoBid),
+	}, nil
+}
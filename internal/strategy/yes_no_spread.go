@@ -18,33 +18,62 @@ const (
 	defaultYesNoTTLSeconds = 30
 	defaultYesNoMaxStale   = 5
 	defaultYesNoCooldown   = 2
+	defaultYesNoMinLegSize = 1.0
+	defaultYesNoToxicEdge  = 2.0
 )
 
+// yesNoSpreadParams are the parameters Reconfigure accepts.
+var yesNoSpreadParams = paramSpecs{
+	"min_edge_bps":     {kind: paramInt, min: 1, max: 10000},
+	"size_per_leg":     {kind: paramFloat, min: 1},
+	"min_size_per_leg": {kind: paramFloat},
+	"ttl_seconds":      {kind: paramInt, min: 1},
+	"max_stale_sec":    {kind: paramInt, min: 1},
+	"cooldown_sec":     {kind: paramInt},
+	"toxicity_widen":   {kind: paramFloat, min: 0, max: 1},
+	"toxicity_pause":   {kind: paramFloat, min: 0, max: 1},
+	"toxicity_edge":    {kind: paramFloat, min: 1, max: 10},
+}
+
 // YesNoSpread detects classic binary Dutch-book opportunities:
 // buy YES+NO when ask_yes+ask_no < 1-edge, or sell both when bid_yes+bid_no > 1+edge.
+// Legs are sized from book depth: up to size_per_leg, as long as the pair's
+// VWAP still clears the edge (see sizeSpreadLegs).
 type YesNoSpread struct {
-	cfg     Config
-	tracker *PriceTracker
-	markets domain.MarketStore
-	books   domain.OrderbookCache
-	logger  *slog.Logger
+	cfg      Config
+	params   *paramSet
+	tracker  *PriceTracker
+	markets  domain.MarketStore
+	books    domain.OrderbookCache
+	toxicity domain.ToxicityProvider // optional
+	logger   *slog.Logger
 
 	mu       sync.Mutex
-	lastEmit map[string]time.Time // marketID -> last signal time
+	lastEmit *expiringMap[string, time.Time] // marketID -> last signal time
 }
 
 // NewYesNoSpread creates a yes/no spread strategy.
 func NewYesNoSpread(cfg Config, tracker *PriceTracker, markets domain.MarketStore, books domain.OrderbookCache, logger *slog.Logger) *YesNoSpread {
 	return &YesNoSpread{
 		cfg:      cfg,
+		params:   newParamSet(cfg.Params),
 		tracker:  tracker,
 		markets:  markets,
 		books:    books,
 		logger:   logger.With(slog.String("strategy", "yes_no_spread")),
-		lastEmit: make(map[string]time.Time),
+		lastEmit: newExpiringMap[string, time.Time](0),
 	}
 }
 
+// WithToxicity makes the strategy wary of toxic order flow: when either
+// leg's VPIN reaches toxicity_widen the required edge is multiplied by
+// toxicity_edge, and at toxicity_pause the market is skipped. Both
+// thresholds default to 0 (off); scores not warm yet are ignored.
+func (y *YesNoSpread) WithToxicity(p domain.ToxicityProvider) *YesNoSpread {
+	y.toxicity = p
+	return y
+}
+
 // Name returns the strategy identifier.
 func (y *YesNoSpread) Name() string { return "yes_no_spread" }
 
@@ -58,10 +87,10 @@ func (y *YesNoSpread) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 	}
 
 	mkt, err := y.markets.GetByTokenID(ctx, snap.AssetID)
-	if err != nil {
+	if err != nil || !mkt.IsBinary() {
 		return nil, nil
 	}
-	yesToken, noToken := mkt.TokenIDs[0], mkt.TokenIDs[1]
+	yesToken, noToken := mkt.YesTokenID(), mkt.NoTokenID()
 	if yesToken == "" || noToken == "" {
 		return nil, nil
 	}
@@ -80,9 +109,19 @@ func (y *YesNoSpread) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 	yesAsk, yesBid := bestAsk(yesSnap), bestBid(yesSnap)
 	noAsk, noBid := bestAsk(noSnap), bestBid(noSnap)
 	minEdge := float64(y.minEdgeBps()) / 10_000
+	if vpin, ok := y.legToxicity(ctx, yesToken, noToken); ok {
+		if pause := y.toxicityPause(); pause > 0 && vpin >= pause {
+			return nil, nil
+		}
+		if widen := y.toxicityWiden(); widen > 0 && vpin >= widen {
+			minEdge *= y.toxicityEdge()
+		}
+	}
 
-	emit := func(side domain.OrderSide, yesPx, noPx, edge float64, reasonFmt string) []domain.TradeSignal {
-		sizePerLeg := y.sizePerLeg()
+	emit := func(side domain.OrderSide, sizePerLeg float64, yesLeg, noLeg depthLeg, reasonFmt string) []domain.TradeSignal {
+		edge := spreadEdge(side, []float64{yesLeg.VWAP, noLeg.VWAP})
+		reason := fmt.Sprintf(reasonFmt, yesLeg.VWAP+noLeg.VWAP, edge*10_000, sizePerLeg)
+		edgeBps := fmt.Sprintf("%.1f", edge*10_000)
 		ttl := time.Duration(y.ttlSeconds()) * time.Second
 		legGroupID := uuid.New().String()
 		signals := []domain.TradeSignal{
@@ -92,15 +131,17 @@ func (y *YesNoSpread) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 				MarketID:   mkt.ID,
 				TokenID:    yesToken,
 				Side:       side,
-				PriceTicks: int64(yesPx * 1e6),
-				SizeUnits:  int64(sizePerLeg * 1e6),
+				PriceTicks: domain.ToPriceTicks(yesLeg.Limit),
+				SizeUnits:  domain.ToSizeUnits(sizePerLeg),
 				Urgency:    domain.SignalUrgencyImmediate,
-				Reason:     fmt.Sprintf(reasonFmt, yesPx+noPx, edge*10_000),
+				Reason:     reason,
 				Metadata: map[string]string{
 					"leg_group_id": legGroupID,
 					"leg_count":    "2",
 					"leg_policy":   string(domain.LegPolicyAllOrNone),
 					"arb_type":     string(domain.ArbTypeRebalancing),
+					"vwap":         fmt.Sprintf("%.6f", yesLeg.VWAP),
+					"edge_bps":     edgeBps,
 				},
 				CreatedAt: now,
 				ExpiresAt: now.Add(ttl),
@@ -111,15 +152,17 @@ func (y *YesNoSpread) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 				MarketID:   mkt.ID,
 				TokenID:    noToken,
 				Side:       side,
-				PriceTicks: int64(noPx * 1e6),
-				SizeUnits:  int64(sizePerLeg * 1e6),
+				PriceTicks: domain.ToPriceTicks(noLeg.Limit),
+				SizeUnits:  domain.ToSizeUnits(sizePerLeg),
 				Urgency:    domain.SignalUrgencyImmediate,
-				Reason:     fmt.Sprintf(reasonFmt, yesPx+noPx, edge*10_000),
+				Reason:     reason,
 				Metadata: map[string]string{
 					"leg_group_id": legGroupID,
 					"leg_count":    "2",
 					"leg_policy":   string(domain.LegPolicyAllOrNone),
 					"arb_type":     string(domain.ArbTypeRebalancing),
+					"vwap":         fmt.Sprintf("%.6f", noLeg.VWAP),
+					"edge_bps":     edgeBps,
 				},
 				CreatedAt: now,
 				ExpiresAt: now.Add(ttl),
@@ -132,18 +175,23 @@ func (y *YesNoSpread) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 		return nil, nil
 	}
 
+	books := []domain.OrderbookSnapshot{yesSnap, noSnap}
+
 	if yesAsk > 0 && noAsk > 0 {
 		sumAsk := yesAsk + noAsk
 		edge := 1.0 - sumAsk
 		if edge > minEdge {
-			y.markEmitted(mkt.ID, now)
-			return emit(
-				domain.OrderSideBuy,
-				yesAsk,
-				noAsk,
-				edge,
-				"yes_no_spread buy_pair sum_ask=%.4f edge_bps=%.1f",
-			), nil
+			size, legs, ok := sizeSpreadLegs(books, domain.OrderSideBuy, y.sizePerLeg(), y.minSizePerLeg(), minEdge)
+			if ok {
+				y.markEmitted(mkt.ID, now)
+				return emit(
+					domain.OrderSideBuy,
+					size,
+					legs[0],
+					legs[1],
+					"yes_no_spread buy_pair vwap_sum=%.4f edge_bps=%.1f size=%.2f",
+				), nil
+			}
 		}
 	}
 
@@ -151,14 +199,17 @@ func (y *YesNoSpread) OnBookUpdate(ctx context.Context, snap domain.OrderbookSna
 		sumBid := yesBid + noBid
 		edge := sumBid - 1.0
 		if edge > minEdge {
-			y.markEmitted(mkt.ID, now)
-			return emit(
-				domain.OrderSideSell,
-				yesBid,
-				noBid,
-				edge,
-				"yes_no_spread sell_pair sum_bid=%.4f edge_bps=%.1f",
-			), nil
+			size, legs, ok := sizeSpreadLegs(books, domain.OrderSideSell, y.sizePerLeg(), y.minSizePerLeg(), minEdge)
+			if ok {
+				y.markEmitted(mkt.ID, now)
+				return emit(
+					domain.OrderSideSell,
+					size,
+					legs[0],
+					legs[1],
+					"yes_no_spread sell_pair vwap_sum=%.4f edge_bps=%.1f size=%.2f",
+				), nil
+			}
 		}
 	}
 
@@ -181,6 +232,40 @@ func (y *YesNoSpread) OnSignal(_ context.Context, _ domain.TradeSignal) ([]domai
 
 func (y *YesNoSpread) Close() error { return nil }
 
+// Reconfigure validates params and applies them to the running strategy.
+func (y *YesNoSpread) Reconfigure(params map[string]any) error {
+	return y.params.apply(yesNoSpreadParams, params)
+}
+
+// EffectiveParams returns the values the strategy is running with, with
+// defaults filled in for parameters that were never set.
+func (y *YesNoSpread) EffectiveParams() map[string]any {
+	return map[string]any{
+		"min_edge_bps":     y.minEdgeBps(),
+		"size_per_leg":     y.sizePerLeg(),
+		"min_size_per_leg": y.minSizePerLeg(),
+		"ttl_seconds":      y.ttlSeconds(),
+		"max_stale_sec":    y.maxStaleSec(),
+		"cooldown_sec":     y.cooldownSec(),
+		"toxicity_widen":   y.toxicityWiden(),
+		"toxicity_pause":   y.toxicityPause(),
+		"toxicity_edge":    y.toxicityEdge(),
+	}
+}
+
+// legToxicity returns the higher VPIN of the two legs, and false when
+// neither has a warm score.
+func (y *YesNoSpread) legToxicity(ctx context.Context, tokens ...string) (float64, bool) {
+	var vpin float64
+	found := false
+	for _, token := range tokens {
+		if t, ok := lookupToxicity(ctx, y.toxicity, token); ok {
+			vpin, found = max(vpin, t.VPIN), true
+		}
+	}
+	return vpin, found
+}
+
 func (y *YesNoSpread) snapshotForToken(ctx context.Context, current domain.OrderbookSnapshot, tokenID string) (domain.OrderbookSnapshot, error) {
 	if current.AssetID == tokenID {
 		return current, nil
@@ -191,7 +276,7 @@ func (y *YesNoSpread) snapshotForToken(ctx context.Context, current domain.Order
 func (y *YesNoSpread) recentlyEmitted(marketID string, now time.Time) bool {
 	y.mu.Lock()
 	defer y.mu.Unlock()
-	last, ok := y.lastEmit[marketID]
+	last, ok := y.lastEmit.get(marketID, now)
 	if !ok {
 		return false
 	}
@@ -201,74 +286,112 @@ func (y *YesNoSpread) recentlyEmitted(marketID string, now time.Time) bool {
 func (y *YesNoSpread) markEmitted(marketID string, now time.Time) {
 	y.mu.Lock()
 	defer y.mu.Unlock()
-	y.lastEmit[marketID] = now
+	cooldown := time.Duration(y.cooldownSec()) * time.Second
+	y.lastEmit.set(marketID, now, now, max(cooldown, minCooldownRetention))
 }
 
 func (y *YesNoSpread) minEdgeBps() int {
-	if v, ok := y.cfg.Params["min_edge_bps"].(int); ok {
+	if v, ok := y.params.get("min_edge_bps").(int); ok {
 		return v
 	}
-	if v, ok := y.cfg.Params["min_edge_bps"].(int64); ok {
+	if v, ok := y.params.get("min_edge_bps").(int64); ok {
 		return int(v)
 	}
-	if v, ok := y.cfg.Params["min_edge_bps"].(float64); ok {
+	if v, ok := y.params.get("min_edge_bps").(float64); ok {
 		return int(v)
 	}
 	return defaultYesNoMinEdgeBps
 }
 
 func (y *YesNoSpread) sizePerLeg() float64 {
-	if v, ok := y.cfg.Params["size_per_leg"].(float64); ok {
+	if v, ok := y.params.get("size_per_leg").(float64); ok {
 		return v
 	}
-	if v, ok := y.cfg.Params["size_per_leg"].(int); ok {
+	if v, ok := y.params.get("size_per_leg").(int); ok {
 		return float64(v)
 	}
-	if v, ok := y.cfg.Params["size_per_leg"].(int64); ok {
+	if v, ok := y.params.get("size_per_leg").(int64); ok {
 		return float64(v)
 	}
 	return defaultYesNoSizePerLeg
 }
 
+func (y *YesNoSpread) minSizePerLeg() float64 {
+	if v, ok := y.params.get("min_size_per_leg").(float64); ok {
+		return v
+	}
+	if v, ok := y.params.get("min_size_per_leg").(int); ok {
+		return float64(v)
+	}
+	if v, ok := y.params.get("min_size_per_leg").(int64); ok {
+		return float64(v)
+	}
+	return defaultYesNoMinLegSize
+}
+
 func (y *YesNoSpread) ttlSeconds() int {
-	if v, ok := y.cfg.Params["ttl_seconds"].(int); ok {
+	if v, ok := y.params.get("ttl_seconds").(int); ok {
 		return v
 	}
-	if v, ok := y.cfg.Params["ttl_seconds"].(int64); ok {
+	if v, ok := y.params.get("ttl_seconds").(int64); ok {
 		return int(v)
 	}
-	if v, ok := y.cfg.Params["ttl_seconds"].(float64); ok {
+	if v, ok := y.params.get("ttl_seconds").(float64); ok {
 		return int(v)
 	}
 	return defaultYesNoTTLSeconds
 }
 
 func (y *YesNoSpread) maxStaleSec() int {
-	if v, ok := y.cfg.Params["max_stale_sec"].(int); ok {
+	if v, ok := y.params.get("max_stale_sec").(int); ok {
 		return v
 	}
-	if v, ok := y.cfg.Params["max_stale_sec"].(int64); ok {
+	if v, ok := y.params.get("max_stale_sec").(int64); ok {
 		return int(v)
 	}
-	if v, ok := y.cfg.Params["max_stale_sec"].(float64); ok {
+	if v, ok := y.params.get("max_stale_sec").(float64); ok {
 		return int(v)
 	}
 	return defaultYesNoMaxStale
 }
 
 func (y *YesNoSpread) cooldownSec() int {
-	if v, ok := y.cfg.Params["cooldown_sec"].(int); ok {
+	if v, ok := y.params.get("cooldown_sec").(int); ok {
 		return v
 	}
-	if v, ok := y.cfg.Params["cooldown_sec"].(int64); ok {
+	if v, ok := y.params.get("cooldown_sec").(int64); ok {
 		return int(v)
 	}
-	if v, ok := y.cfg.Params["cooldown_sec"].(float64); ok {
+	if v, ok := y.params.get("cooldown_sec").(float64); ok {
 		return int(v)
 	}
 	return defaultYesNoCooldown
 }
 
+// toxicityWiden is the VPIN at which the required edge grows; 0 never.
+func (y *YesNoSpread) toxicityWiden() float64 {
+	if v, ok := y.params.get("toxicity_widen").(float64); ok && v >= 0 {
+		return v
+	}
+	return 0
+}
+
+// toxicityPause is the VPIN at which the market is skipped; 0 never.
+func (y *YesNoSpread) toxicityPause() float64 {
+	if v, ok := y.params.get("toxicity_pause").(float64); ok && v >= 0 {
+		return v
+	}
+	return 0
+}
+
+// toxicityEdge multiplies min_edge_bps on markets past toxicity_widen.
+func (y *YesNoSpread) toxicityEdge() float64 {
+	if v, ok := y.params.get("toxicity_edge").(float64); ok && v >= 1 {
+		return v
+	}
+	return defaultYesNoToxicEdge
+}
+
 func bestAsk(s domain.OrderbookSnapshot) float64 {
 	if s.BestAsk > 0 {
 		return s.BestAsk
@@ -288,3 +411,35 @@ func bestBid(s domain.OrderbookSnapshot) float64 {
 	}
 	return 0
 }
+
+// ResourceUsage reports the size of in-memory state for resource metering.
+func (y *YesNoSpread) ResourceUsage() map[string]int {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	return map[string]int{"last_emit": y.lastEmit.len()}
+}
+
+// Evictions reports entries dropped from in-memory state by expiry or caps.
+func (y *YesNoSpread) Evictions() map[string]int64 {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	return map[string]int64{"last_emit": y.lastEmit.evictions()}
+}
+
+func init() {
+	RegisterType(TypeSpec{Type: "yes_no_spread", New: newYesNoSpreadFromDeps})
+}
+
+func newYesNoSpreadFromDeps(cfg Config, d Deps) (Strategy, error) {
+	if err := requireDeps(
+		dep{"market_store", d.Markets != nil},
+		dep{"book_cache", d.Books != nil},
+	); err != nil {
+		return nil, err
+	}
+	y := NewYesNoSpread(cfg, NewPriceTracker(d.Prices, 5*time.Minute), d.markets(), d.Books, d.Logger)
+	if d.Toxicity != nil {
+		y.WithToxicity(d.Toxicity)
+	}
+	return y, nil
+}